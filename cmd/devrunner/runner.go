@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// runner builds the service and keeps the latest successful build running
+type runner struct {
+	pkg     string
+	binary  string
+	gcflags string
+	args    []string
+	grace   time.Duration
+
+	process *process
+}
+
+// process is a running build of the service
+type process struct {
+	cmd      *exec.Cmd
+	done     chan struct{}
+	stopping atomic.Bool
+}
+
+// rebuild builds the service and replaces the running build with the new one.
+// When the build fails the running build is kept.
+func (r *runner) rebuild(ctx context.Context) {
+	started := time.Now()
+	if err := r.build(ctx); err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Build failed, keeping the running build: %v", err)
+		}
+		return
+	}
+	log.Printf("Built %s in %s", r.pkg, time.Since(started).Round(time.Millisecond))
+
+	r.stop()
+	r.start()
+}
+
+// build compiles the service next to the binary and moves it into place once it
+// compiled, so a failed build never replaces a working one
+func (r *runner) build(ctx context.Context) error {
+	if err := os.MkdirAll(filepath.Dir(r.binary), 0o755); err != nil {
+		return err
+	}
+
+	next := r.binary + ".next"
+	cmd := exec.CommandContext(ctx, "go", "build", "-gcflags", r.gcflags, "-o", next, r.pkg)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+	return os.Rename(next, r.binary)
+}
+
+// start runs the built service. A service that exits on its own is restarted
+// by the next change.
+func (r *runner) start() {
+	cmd := exec.Command(r.binary, r.args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		log.Printf("Failed to start %s: %v", r.binary, err)
+		return
+	}
+
+	p := &process{cmd: cmd, done: make(chan struct{})}
+	go func() {
+		err := cmd.Wait()
+		if !p.stopping.Load() {
+			log.Printf("Service exited (%v), waiting for a change to restart it", exitReason(err))
+		}
+		close(p.done)
+	}()
+	r.process = p
+	log.Printf("Started %s (pid %d)", r.binary, cmd.Process.Pid)
+}
+
+// stop shuts the running service down with SIGTERM, killing it when it takes
+// longer than the grace period
+func (r *runner) stop() {
+	p := r.process
+	if p == nil {
+		return
+	}
+	r.process = nil
+	p.stopping.Store(true)
+
+	select {
+	case <-p.done:
+		return
+	default:
+	}
+
+	if err := p.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		log.Printf("Failed to signal the service: %v", err)
+	}
+	select {
+	case <-p.done:
+	case <-time.After(r.grace):
+		log.Printf("Service did not stop within %s, killing it", r.grace)
+		p.cmd.Process.Kill()
+		<-p.done
+	}
+}
+
+func exitReason(err error) string {
+	if err == nil {
+		return "status 0"
+	}
+	return err.Error()
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// watchedExtensions are the files a service is built or configured from. Tests
+// are left out: they don't change the binary.
+var watchedExtensions = map[string]bool{
+	".go":   true,
+	".mod":  true,
+	".sum":  true,
+	".sql":  true, // Embedded migrations
+	".yaml": true,
+	".yml":  true,
+}
+
+// fileState is what a change to a file is detected by
+type fileState struct {
+	modTime int64
+	size    int64
+}
+
+// snapshot is the state of the watched files by path
+type snapshot map[string]fileState
+
+// scan records the watched files of the directories. Hidden directories,
+// vendored code and test data are skipped.
+func scan(dirs []string) (snapshot, error) {
+	files := make(snapshot)
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				// Files deleted during the walk are picked up by the next scan
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if entry.IsDir() {
+				if path != dir && skipDir(entry.Name()) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !watched(entry.Name()) {
+				return nil
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			files[path] = fileState{modTime: info.ModTime().UnixNano(), size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata" || name == "node_modules" || name == "bin"
+}
+
+func watched(name string) bool {
+	if strings.HasSuffix(name, "_test.go") {
+		return false
+	}
+	return watchedExtensions[filepath.Ext(name)]
+}
+
+func (s snapshot) equal(other snapshot) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for path, state := range s {
+		if otherState, ok := other[path]; !ok || otherState != state {
+			return false
+		}
+	}
+	return true
+}
+
+// diff names a file that differs from the previous snapshot, and how many more do
+func (s snapshot) diff(previous snapshot) string {
+	var changed []string
+	for path, state := range s {
+		if previousState, ok := previous[path]; !ok || previousState != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range previous {
+		if _, ok := s[path]; !ok {
+			changed = append(changed, path+" (deleted)")
+		}
+	}
+
+	switch len(changed) {
+	case 0:
+		return "none"
+	case 1:
+		return changed[0]
+	default:
+		return fmt.Sprintf("%s and %d other files", changed[0], len(changed)-1)
+	}
+}
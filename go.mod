@@ -1,3 +1,27 @@
 module github.com/amiosamu/rocket-science
 
 go 1.23.2
+
+replace github.com/amiosamu/rocket-science/shared => ./shared
+
+replace github.com/amiosamu/rocket-science/shared/contracts/proto => ./shared/contracts/proto
+
+require (
+	github.com/amiosamu/rocket-science/shared v0.0.0-00010101000000-000000000000
+	github.com/amiosamu/rocket-science/shared/contracts/proto v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.73.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel v1.36.0 // indirect
+	go.opentelemetry.io/otel/metric v1.36.0 // indirect
+	go.opentelemetry.io/otel/trace v1.36.0 // indirect
+	golang.org/x/net v0.40.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.25.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250519155744-55703ea1f237 // indirect
+	google.golang.org/protobuf v1.36.6 // indirect
+)
@@ -3,14 +3,16 @@
 
 local json = require("json")
 
--- Helper function to extract session token from request
+-- Helper function to extract session token from request. Also returns where the
+-- token came from ("bearer" or "cookie"): browsers attach cookies to cross-site
+-- requests on their own, so services require a CSRF token for cookie sessions
 local function extract_session_token(request_handle)
     -- First, try Authorization header (Bearer token)
     local auth_header = request_handle:headers():get("authorization")
     if auth_header then
         local session_token = string.match(auth_header, "Bearer%s+(.+)")
         if session_token then
-            return session_token
+            return session_token, "bearer"
         end
     end
     
@@ -19,38 +21,71 @@ local function extract_session_token(request_handle)
     if cookie_header then
         local session_token = string.match(cookie_header, "session_token=([^;]+)")
         if session_token then
-            return session_token
+            return session_token, "cookie"
         end
     end
     
-    return nil
+    return nil, nil
 end
 
+-- Prefixes of the identity headers the gateway sets for downstream services,
+-- which authorize on them (e.g. x-user-role) and select the tenant's behavior
+-- on x-tenant-id (e.g. sandbox orders)
+local identity_header_prefixes = { "x-user-", "x-session-", "x-client-", "x-identity-", "x-tenant-" }
+
+-- Helper function to drop the identity headers sent by the client. Runs before
+-- anything else, public endpoints included: only the gateway may set them
+local function strip_identity_headers(request_handle)
+    local forged = {}
+    for name, _ in pairs(request_handle:headers()) do
+        local lower = string.lower(name)
+        for _, prefix in ipairs(identity_header_prefixes) do
+            if string.sub(lower, 1, #prefix) == prefix then
+                table.insert(forged, name)
+                break
+            end
+        end
+    end
+    for _, name in ipairs(forged) do
+        request_handle:headers():remove(name)
+    end
+end
+
+-- Public endpoints that don't require authentication, matched against the whole
+-- path without its query string
+local public_endpoints = {
+    ["/health"] = true,
+    ["/ready"] = true,
+    ["/metrics"] = true,
+    ["/iam.IAMService/Login"] = true,
+    ["/iam.IAMService/Register"] = true,
+    ["/iam.IAMService/RefreshToken"] = true,
+    ["/my/usage"] = true -- IAM checks the client token itself; reading usage is not metered
+}
+
 -- Helper function to check if endpoint requires authentication
 local function requires_auth(path, method)
-    -- Public endpoints that don't require authentication
-    local public_endpoints = {
-        "/health",
-        "/ready",
-        "/metrics",
-        "/iam.IAMService/Login",
-        "/iam.IAMService/Register",
-        "/iam.IAMService/RefreshToken"
-    }
-    
-    for _, endpoint in ipairs(public_endpoints) do
-        if string.find(path, endpoint) then
-            return false
-        end
+    local path_only = string.match(path, "^[^?#]*")
+    return not public_endpoints[path_only]
+end
+
+-- X-RateLimit headers IAM reports the quota of service clients in
+local quota_headers = { "x-ratelimit-limit", "x-ratelimit-remaining", "x-ratelimit-reset" }
+
+-- Helper function to copy the quota headers of an IAM response
+local function quota_of(headers)
+    local quota = {}
+    for _, name in ipairs(quota_headers) do
+        quota[name] = headers[name]
     end
-    
-    return true
+    return quota
 end
 
--- Helper function to validate session with IAM service
+-- Helper function to validate session with IAM service. Returns the user (or
+-- service client) data, or nil and the IAM response of a client over its quota
 local function validate_session(request_handle, session_token)
     local headers, body = request_handle:httpCall(
-        "iam-service",
+        "iam-http",
         {
             [":method"] = "POST",
             [":path"] = "/validate-session",
@@ -67,6 +102,14 @@ local function validate_session(request_handle, session_token)
     end
     
     local status = headers[":status"]
+    if status == "429" and body then
+        local success, rejection = pcall(json.decode, body)
+        if success and rejection and rejection.quota then
+            rejection.retry_after = headers["retry-after"]
+            rejection.quota_headers = quota_of(headers)
+            return nil, rejection
+        end
+    end
     if status ~= "200" then
         request_handle:logWarn("Session validation failed with status: " .. (status or "unknown"))
         return nil
@@ -75,6 +118,9 @@ local function validate_session(request_handle, session_token)
     if body then
         local success, user_data = pcall(json.decode, body)
         if success and user_data then
+            if user_data.client_id then
+                user_data.quota_headers = quota_of(headers)
+            end
             return user_data
         else
             request_handle:logErr("Failed to parse user data from IAM response")
@@ -91,6 +137,8 @@ function envoy_on_request(request_handle)
     
     -- Log the incoming request
     request_handle:logInfo("Processing request: " .. method .. " " .. path)
+
+    strip_identity_headers(request_handle)
     
     -- Check if this endpoint requires authentication
     if not requires_auth(path, method) then
@@ -99,7 +147,7 @@ function envoy_on_request(request_handle)
     end
     
     -- Extract session token
-    local session_token = extract_session_token(request_handle)
+    local session_token, session_source = extract_session_token(request_handle)
     if not session_token then
         request_handle:logWarn("Missing session token for protected endpoint: " .. path)
         request_handle:respond(
@@ -113,7 +161,28 @@ function envoy_on_request(request_handle)
     end
     
     -- Validate session with IAM service
-    local user_data = validate_session(request_handle, session_token)
+    local user_data, rejection = validate_session(request_handle, session_token)
+    if rejection then
+        -- Service client over its request quota
+        request_handle:logWarn("Quota exceeded for client: " .. (rejection.client_id or "unknown"))
+        local response_headers = {
+            [":status"] = "429",
+            ["content-type"] = "application/json",
+            ["retry-after"] = rejection.retry_after or "60"
+        }
+        for name, value in pairs(rejection.quota_headers) do
+            response_headers[name] = value
+        end
+        request_handle:respond(
+            response_headers,
+            json.encode({
+                error = "QuotaExceeded",
+                message = "API quota exceeded",
+                quota = rejection.quota
+            })
+        )
+        return
+    end
     if not user_data then
         request_handle:logWarn("Invalid session token for: " .. path)
         request_handle:respond(
@@ -126,7 +195,8 @@ function envoy_on_request(request_handle)
         return
     end
     
-    -- Add user information to headers for downstream services
+    -- Add user information to headers for downstream services. Any values sent by the
+    -- client were dropped by strip_identity_headers
     if user_data.user_id then
         request_handle:headers():add("x-user-id", tostring(user_data.user_id))
     end
@@ -136,15 +206,57 @@ function envoy_on_request(request_handle)
     if user_data.role then
         request_handle:headers():add("x-user-role", user_data.role)
     end
+    -- Permission snapshot of the session: services authorize on it instead of
+    -- calling CheckPermission, and IAM retakes it once the user's role changes
+    if user_data.permissions then
+        request_handle:headers():add("x-user-permissions", table.concat(user_data.permissions, ","))
+    end
+    -- Locale of the user's profile; services prefer it to Accept-Language
+    if user_data.locale then
+        request_handle:headers():add("x-user-locale", user_data.locale)
+    end
+    -- IAM's signature of the user ID, role and permissions: services only
+    -- trust them along with it
+    if user_data.identity_signature then
+        request_handle:headers():add("x-identity-signature", user_data.identity_signature)
+    end
+    
+    -- Service clients calling with a client credentials token: services
+    -- authorize on the token's scopes, and the client learns its remaining quota
+    if user_data.client_id then
+        request_handle:headers():add("x-client-id", user_data.client_id)
+        -- The tenant a client acts for is its client ID, never one of its choosing
+        if user_data.tenant_id then
+            request_handle:headers():add("x-tenant-id", user_data.tenant_id)
+        end
+        if user_data.scopes then
+            request_handle:headers():add("x-client-scopes", table.concat(user_data.scopes, ","))
+        end
+        -- Kept until the response, which reports them to the client
+        local metadata = request_handle:streamInfo():dynamicMetadata()
+        for name, value in pairs(user_data.quota_headers) do
+            metadata:set("envoy.filters.http.lua", name, value)
+        end
+    end
     
     -- Add session token to headers for downstream services that might need it
     request_handle:headers():add("x-session-token", session_token)
+    request_handle:headers():add("x-session-source", session_source)
     
-    request_handle:logInfo("Authentication successful for user: " .. (user_data.email or user_data.user_id or "unknown"))
+    request_handle:logInfo("Authentication successful for: " .. (user_data.email or user_data.user_id or user_data.client_id or "unknown"))
 end
 
--- Function called on response (optional, for logging)
+-- Function called on response: reports the quota of service clients
 function envoy_on_response(response_handle)
+    local quota = response_handle:streamInfo():dynamicMetadata():get("envoy.filters.http.lua")
+    if quota then
+        for _, name in ipairs(quota_headers) do
+            if quota[name] then
+                response_handle:headers():replace(name, quota[name])
+            end
+        end
+    end
+
     local status = response_handle:headers():get(":status")
     response_handle:logInfo("Response status: " .. (status or "unknown"))
 end
@@ -0,0 +1,323 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	iamv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+)
+
+// Session is the session of a logged in user. It can be saved and resumed
+// with UseSession while its refresh token is valid.
+type Session struct {
+	UserID       string    `json:"user_id"`
+	SessionID    string    `json:"session_id"`
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"` // Of the access token
+
+	// PasswordChangeRequired restricts the session to ChangePassword until the
+	// temporary password is changed
+	PasswordChangeRequired bool `json:"password_change_required,omitempty"`
+}
+
+// clientCredentials authenticate a registered service client. Its tokens are
+// reissued instead of refreshed.
+type clientCredentials struct {
+	clientID string
+	secret   string
+	scopes   []string
+}
+
+// publicMethods are called without an access token
+var publicMethods = []string{
+	"/iam.v1.IAMService/Login",
+	"/iam.v1.IAMService/RequestLoginLink",
+	"/iam.v1.IAMService/CompleteLoginWithLink",
+	"/iam.v1.IAMService/LockAccountWithToken",
+	"/iam.v1.IAMService/RefreshToken",
+	"/iam.v1.IAMService/IssueClientToken",
+}
+
+// authenticator holds the credentials of the client and attaches a valid
+// access token to every call
+type authenticator struct {
+	client        *Client
+	refreshBefore time.Duration
+	onRefresh     func(Session)
+
+	mu          sync.Mutex // Held while refreshing, so concurrent calls refresh once
+	session     *Session
+	credentials *clientCredentials
+}
+
+func newAuthenticator(client *Client, cfg Config) *authenticator {
+	return &authenticator{
+		client:        client,
+		refreshBefore: cfg.RefreshBefore,
+		onRefresh:     cfg.OnSessionRefresh,
+	}
+}
+
+// Login logs a user in and authenticates the following calls as that user
+func (c *Client) Login(ctx context.Context, email, password string) (*Session, error) {
+	resp, err := c.iam.Login(ctx, &iamv1.LoginRequest{
+		Email:     email,
+		Password:  password,
+		UserAgent: c.config.UserAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.CaptchaRequired {
+		return nil, fmt.Errorf("%w: %s", ErrCaptchaRequired, resp.Message)
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("login failed: %s", resp.Message)
+	}
+
+	session := Session{
+		UserID:                 resp.User.GetId(),
+		SessionID:              resp.SessionId,
+		AccessToken:            resp.AccessToken,
+		RefreshToken:           resp.RefreshToken,
+		ExpiresAt:              resp.ExpiresAt.AsTime(),
+		PasswordChangeRequired: resp.PasswordChangeRequired,
+	}
+	c.auth.setSession(session)
+	return &session, nil
+}
+
+// RequestLoginLink asks for a password-less login link to be sent to the user
+// with the given email. The link completes with LoginWithLink from a client
+// with the same user agent.
+func (c *Client) RequestLoginLink(ctx context.Context, email string) error {
+	_, err := c.iam.RequestLoginLink(ctx, &iamv1.RequestLoginLinkRequest{
+		Email:     email,
+		UserAgent: c.config.UserAgent,
+	})
+	return err
+}
+
+// LoginWithLink authenticates the following calls as the user a login link
+// was sent to, given the token of the link
+func (c *Client) LoginWithLink(ctx context.Context, token string) (*Session, error) {
+	resp, err := c.iam.CompleteLoginWithLink(ctx, &iamv1.CompleteLoginWithLinkRequest{
+		Token:     token,
+		UserAgent: c.config.UserAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("login failed: %s", resp.Message)
+	}
+
+	session := Session{
+		UserID:                 resp.User.GetId(),
+		SessionID:              resp.SessionId,
+		AccessToken:            resp.AccessToken,
+		RefreshToken:           resp.RefreshToken,
+		ExpiresAt:              resp.ExpiresAt.AsTime(),
+		PasswordChangeRequired: resp.PasswordChangeRequired,
+	}
+	c.auth.setSession(session)
+	return &session, nil
+}
+
+// LockAccountWithToken locks the account a failed login alert was sent for,
+// given the token of the alert's lock link, and returns when it unlocks
+func (c *Client) LockAccountWithToken(ctx context.Context, token string) (time.Time, error) {
+	resp, err := c.iam.LockAccountWithToken(ctx, &iamv1.LockAccountWithTokenRequest{Token: token})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !resp.Success {
+		return time.Time{}, fmt.Errorf("account lock failed: %s", resp.Message)
+	}
+	return resp.LockedUntil.AsTime(), nil
+}
+
+// LoginClient authenticates the following calls as a registered service
+// client, for tools acting on their own behalf rather than a user's. An empty
+// scopes list requests every scope of the client.
+func (c *Client) LoginClient(ctx context.Context, clientID, secret string, scopes ...string) error {
+	credentials := &clientCredentials{clientID: clientID, secret: secret, scopes: scopes}
+	session, err := c.auth.issueClientToken(ctx, credentials)
+	if err != nil {
+		return err
+	}
+
+	c.auth.mu.Lock()
+	c.auth.session = session
+	c.auth.credentials = credentials
+	c.auth.mu.Unlock()
+	return nil
+}
+
+// UseSession resumes a session saved from an earlier login
+func (c *Client) UseSession(session Session) {
+	c.auth.setSession(session)
+}
+
+// Session returns a copy of the current user session, reflecting token refreshes
+func (c *Client) Session() (Session, bool) {
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+
+	if c.auth.session == nil || c.auth.credentials != nil {
+		return Session{}, false
+	}
+	return *c.auth.session, true
+}
+
+// Logout ends the user session on the server and forgets the credentials
+func (c *Client) Logout(ctx context.Context) error {
+	c.auth.mu.Lock()
+	session := c.auth.session
+	c.auth.session, c.auth.credentials = nil, nil
+	c.auth.mu.Unlock()
+
+	if session == nil || session.SessionID == "" {
+		return nil
+	}
+
+	// The token is attached explicitly: the client no longer holds it
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+session.AccessToken)
+	_, err := c.iam.Logout(ctx, &iamv1.LogoutRequest{
+		SessionId:   session.SessionID,
+		AccessToken: session.AccessToken,
+	})
+	return err
+}
+
+func (a *authenticator) setSession(session Session) {
+	a.mu.Lock()
+	a.session = &session
+	a.credentials = nil
+	a.mu.Unlock()
+
+	if a.onRefresh != nil {
+		a.onRefresh(session)
+	}
+}
+
+// token returns an access token valid for at least refreshBefore, renewing it
+// when needed. A token the server rejected is renewed even if it looks valid,
+// unless a concurrent call renewed it already.
+func (a *authenticator) token(ctx context.Context, rejected string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.session == nil {
+		return "", ErrNotLoggedIn
+	}
+	if a.session.AccessToken != rejected && time.Until(a.session.ExpiresAt) > a.refreshBefore {
+		return a.session.AccessToken, nil
+	}
+
+	session, err := a.renew(ctx)
+	if err != nil {
+		return "", err
+	}
+	a.session = session
+	if a.onRefresh != nil && a.credentials == nil {
+		a.onRefresh(*session)
+	}
+	return session.AccessToken, nil
+}
+
+// renew obtains a new access token; a.mu is held
+func (a *authenticator) renew(ctx context.Context) (*Session, error) {
+	if a.credentials != nil {
+		return a.issueClientToken(ctx, a.credentials)
+	}
+
+	resp, err := a.client.iam.RefreshToken(ctx, &iamv1.RefreshTokenRequest{
+		RefreshToken: a.session.RefreshToken,
+		SessionId:    a.session.SessionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh access token: %w", err)
+	}
+	if !resp.Success {
+		return nil, &Error{Code: codes.Unauthenticated, Message: resp.Message}
+	}
+
+	session := *a.session
+	session.AccessToken = resp.AccessToken
+	session.ExpiresAt = resp.ExpiresAt.AsTime()
+	return &session, nil
+}
+
+func (a *authenticator) issueClientToken(ctx context.Context, credentials *clientCredentials) (*Session, error) {
+	resp, err := a.client.iam.IssueClientToken(ctx, &iamv1.IssueClientTokenRequest{
+		ClientId:     credentials.clientID,
+		ClientSecret: credentials.secret,
+		Scopes:       credentials.scopes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue client token: %w", err)
+	}
+	return &Session{
+		AccessToken: resp.AccessToken,
+		ExpiresAt:   resp.ExpiresAt.AsTime(),
+	}, nil
+}
+
+// unaryInterceptor attaches the access token to calls. A call rejected as
+// unauthenticated is repeated once with a renewed token, covering tokens
+// revoked or expired earlier than announced.
+func (a *authenticator) unaryInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if isPublicMethod(method) || hasAuthorization(ctx) {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	token, err := a.token(ctx, "")
+	if err != nil {
+		return err
+	}
+	err = invoker(withToken(ctx, token), method, req, reply, cc, opts...)
+	if !errors.Is(fromGRPC(err), ErrUnauthenticated) {
+		return err
+	}
+
+	if token, err = a.token(ctx, token); err != nil {
+		return err
+	}
+	return invoker(withToken(ctx, token), method, req, reply, cc, opts...)
+}
+
+func isPublicMethod(method string) bool {
+	for _, publicMethod := range publicMethods {
+		if strings.HasSuffix(method, publicMethod) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAuthorization reports whether the caller attached its own token
+func hasAuthorization(ctx context.Context) bool {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	return ok && len(md.Get("authorization")) > 0
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
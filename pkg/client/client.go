@@ -0,0 +1,214 @@
+// Package client is the Go SDK of the rocket-science APIs, for internal tools
+// and customer integrations. It connects to the IAM and inventory gRPC APIs and
+// the orders HTTP API, usually all through the API gateway, and takes care of:
+//
+//   - authentication: a user login or a service client token is attached to
+//     every call, and renewed before it expires or when the server rejects it
+//   - retries of transient failures with jittered exponential backoff,
+//     honoring the retry hints of the server; while a server shedding load
+//     asked to wait, calls fail fast instead of reaching it
+//   - typed errors: every API error is an *Error, matched with errors.Is
+//     against ErrNotFound, ErrInvalidArgument and the other sentinels
+//
+// A minimal client logs in and lists orders:
+//
+//	c, err := client.New(client.DefaultConfig("api.example.com:443", "https://api.example.com"))
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//
+//	if _, err := c.Login(ctx, email, password); err != nil {
+//		return err
+//	}
+//	page, err := c.ListOrders(ctx, client.OrderFilter{Status: "paid"})
+//
+// Calls not wrapped by the client are available on the generated gRPC clients
+// returned by IAM and Inventory, with the same authentication, retries and errors.
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	iamv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+	inventoryv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/inventory/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/backoff"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcclient"
+	"github.com/amiosamu/rocket-science/shared/platform/httpclient"
+)
+
+// Config configures a client
+type Config struct {
+	// GRPCAddress is the host:port of the gRPC APIs, usually the API gateway
+	GRPCAddress string
+
+	// InventoryAddress overrides GRPCAddress for the inventory API
+	InventoryAddress string
+
+	// OrdersURL is the base URL of the orders HTTP API, e.g. https://api.example.com.
+	// Order calls fail when it is empty.
+	OrdersURL string
+
+	// Insecure disables TLS on gRPC connections, for local development
+	Insecure bool
+
+	// Timeout bounds a single attempt of a call
+	Timeout time.Duration
+
+	// MaxRetries is the number of retries after the first attempt of a call
+	// that failed with a transient error
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+
+	// MaxRetryAfter is the longest retry hint of a server waited out; a call
+	// asked to wait longer fails with the server's error
+	MaxRetryAfter time.Duration
+
+	// RefreshBefore renews the access token this long before it expires
+	RefreshBefore time.Duration
+
+	// UserAgent is recorded by IAM on the sessions of the client
+	UserAgent string
+
+	// OnSessionRefresh is called with the session after every login and token
+	// refresh, e.g. to persist it for UseSession. It must not call the client.
+	OnSessionRefresh func(Session)
+}
+
+// DefaultConfig returns the configuration used unless a client overrides it
+func DefaultConfig(grpcAddress, ordersURL string) Config {
+	return Config{
+		GRPCAddress:    grpcAddress,
+		OrdersURL:      ordersURL,
+		Timeout:        10 * time.Second,
+		MaxRetries:     3,
+		RetryBaseDelay: 200 * time.Millisecond,
+		RetryMaxDelay:  5 * time.Second,
+		MaxRetryAfter:  backoff.DefaultMaxRetryAfter,
+		RefreshBefore:  time.Minute,
+		UserAgent:      "rocket-science-go-client",
+	}
+}
+
+// Client calls the rocket-science APIs. It is safe for concurrent use.
+type Client struct {
+	config Config
+	auth   *authenticator
+
+	iamConn       *grpc.ClientConn
+	inventoryConn *grpc.ClientConn // Same as iamConn unless InventoryAddress is set
+
+	iam       iamv1.IAMServiceClient
+	inventory inventoryv1.InventoryServiceClient
+
+	ordersURL  *url.URL // nil without OrdersURL
+	ordersHTTP *httpclient.Client
+}
+
+// New creates a client. Connections are established lazily on the first call.
+func New(cfg Config) (*Client, error) {
+	if cfg.GRPCAddress == "" {
+		return nil, fmt.Errorf("gRPC address is required")
+	}
+	if cfg.Timeout <= 0 {
+		return nil, fmt.Errorf("timeout must be positive")
+	}
+	if cfg.MaxRetries < 0 {
+		return nil, fmt.Errorf("retries cannot be negative")
+	}
+
+	c := &Client{config: cfg}
+	c.auth = newAuthenticator(c, cfg)
+
+	if cfg.OrdersURL != "" {
+		ordersURL, err := url.Parse(strings.TrimSuffix(cfg.OrdersURL, "/"))
+		if err != nil || ordersURL.Scheme == "" || ordersURL.Host == "" {
+			return nil, fmt.Errorf("invalid orders URL %q", cfg.OrdersURL)
+		}
+		c.ordersURL = ordersURL
+
+		// Only idempotent order calls are retried
+		httpConfig := httpclient.DefaultConfig("rocket-science-orders")
+		httpConfig.Timeout = cfg.Timeout
+		httpConfig.MaxRetries = cfg.MaxRetries
+		httpConfig.RetryBaseDelay = cfg.RetryBaseDelay
+		httpConfig.RetryMaxDelay = cfg.RetryMaxDelay
+		httpConfig.MaxRetryAfter = cfg.MaxRetryAfter
+		ordersHTTP, err := httpclient.New(httpConfig, nil)
+		if err != nil {
+			return nil, err
+		}
+		c.ordersHTTP = ordersHTTP
+	}
+
+	var err error
+	if c.iamConn, err = c.dial(cfg.GRPCAddress); err != nil {
+		return nil, err
+	}
+	c.inventoryConn = c.iamConn
+	if cfg.InventoryAddress != "" && cfg.InventoryAddress != cfg.GRPCAddress {
+		if c.inventoryConn, err = c.dial(cfg.InventoryAddress); err != nil {
+			c.iamConn.Close()
+			return nil, err
+		}
+	}
+
+	c.iam = iamv1.NewIAMServiceClient(c.iamConn)
+	c.inventory = inventoryv1.NewInventoryServiceClient(c.inventoryConn)
+	return c, nil
+}
+
+// dial creates a connection whose calls are retried, authenticated and
+// return typed errors
+func (c *Client) dial(address string) (*grpc.ClientConn, error) {
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if c.config.Insecure {
+		creds = insecure.NewCredentials()
+	}
+
+	// Retries are outermost so every attempt gets a fresh token. The hold-off
+	// only fails calls fast while the server asked to wait; retries are ours.
+	holdOff := grpcclient.DefaultConfig(address)
+	holdOff.MaxRetries = 0
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUserAgent(c.config.UserAgent),
+		grpc.WithChainUnaryInterceptor(c.retryInterceptor, c.auth.unaryInterceptor,
+			grpcclient.UnaryClientInterceptor(holdOff, nil)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	return conn, nil
+}
+
+// IAM returns the IAM gRPC client, for calls the client does not wrap
+func (c *Client) IAM() iamv1.IAMServiceClient {
+	return c.iam
+}
+
+// Inventory returns the inventory gRPC client, for calls the client does not wrap
+func (c *Client) Inventory() inventoryv1.InventoryServiceClient {
+	return c.inventory
+}
+
+// Close closes the connections of the client. It does not log out.
+func (c *Client) Close() error {
+	var firstErr error
+	if c.inventoryConn != c.iamConn {
+		firstErr = c.inventoryConn.Close()
+	}
+	if err := c.iamConn.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
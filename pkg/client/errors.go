@@ -0,0 +1,184 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amiosamu/rocket-science/shared/platform/backoff"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcerrors"
+)
+
+// Errors matched by errors.Is against the *Error returned by every call
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrUnauthenticated    = errors.New("unauthenticated")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrConflict           = errors.New("conflict")            // AlreadyExists and Aborted
+	ErrFailedPrecondition = errors.New("failed precondition") // e.g. insufficient stock
+	ErrRateLimited        = errors.New("rate limited")
+	ErrUnavailable        = errors.New("service unavailable") // Unavailable and DeadlineExceeded
+)
+
+// Errors of the client itself
+var (
+	ErrNotLoggedIn     = errors.New("client: not logged in")
+	ErrCaptchaRequired = errors.New("client: login requires a solved CAPTCHA")
+)
+
+// Error is an error returned by a rocket-science API. Orders API errors carry
+// their HTTP status and the gRPC code equivalent to it.
+type Error struct {
+	Code       codes.Code
+	Reason     string // ErrorInfo reason, e.g. INSUFFICIENT_STOCK
+	Message    string
+	HTTPStatus int // Zero for gRPC errors
+	Fields     []grpcerrors.FieldViolation
+	RetryAfter time.Duration // Server hint; zero when none was given
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("%s (%s): %s", e.Code, e.Reason, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Is matches the sentinel error of the code
+func (e *Error) Is(target error) bool {
+	return sentinelFor(e.Code) == target
+}
+
+// GRPCStatus lets status.FromError and status.Code read the error
+func (e *Error) GRPCStatus() *status.Status {
+	return status.New(e.Code, e.Message)
+}
+
+// Retryable reports whether the call may succeed when repeated
+func (e *Error) Retryable() bool {
+	if e.RetryAfter > 0 {
+		return true
+	}
+	switch e.Code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+func sentinelFor(code codes.Code) error {
+	switch code {
+	case codes.NotFound:
+		return ErrNotFound
+	case codes.InvalidArgument, codes.OutOfRange:
+		return ErrInvalidArgument
+	case codes.Unauthenticated:
+		return ErrUnauthenticated
+	case codes.PermissionDenied:
+		return ErrPermissionDenied
+	case codes.AlreadyExists, codes.Aborted:
+		return ErrConflict
+	case codes.FailedPrecondition:
+		return ErrFailedPrecondition
+	case codes.ResourceExhausted:
+		return ErrRateLimited
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return ErrUnavailable
+	default:
+		return nil
+	}
+}
+
+// fromGRPC converts the error of a gRPC call, reading its error details.
+// Errors without a gRPC status, such as a cancelled context, are returned as is.
+func fromGRPC(err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	apiErr = &Error{
+		Code:    st.Code(),
+		Reason:  grpcerrors.Reason(err),
+		Message: st.Message(),
+		Fields:  grpcerrors.FieldViolations(err),
+	}
+	if delay, ok := grpcerrors.RetryDelay(err); ok {
+		apiErr.RetryAfter = delay
+	}
+	return apiErr
+}
+
+// errorBody is the error response of the orders API
+type errorBody struct {
+	Error   string `json:"error"`
+	Code    int    `json:"code"`
+	Details string `json:"details,omitempty"`
+}
+
+// fromHTTP converts an error response of the orders API
+func fromHTTP(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	apiErr := &Error{
+		Code:       codeForHTTPStatus(resp.StatusCode),
+		Message:    http.StatusText(resp.StatusCode),
+		HTTPStatus: resp.StatusCode,
+	}
+
+	var decoded errorBody
+	if json.Unmarshal(body, &decoded) == nil && decoded.Error != "" {
+		apiErr.Message = decoded.Error
+		if decoded.Details != "" {
+			apiErr.Message += ": " + decoded.Details
+		}
+	}
+	if delay, ok := backoff.RetryAfter(resp, time.Now()); ok && delay > 0 {
+		apiErr.RetryAfter = delay
+	}
+	return apiErr
+}
+
+// codeForHTTPStatus maps an HTTP status to the gRPC code with the same meaning
+func codeForHTTPStatus(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+
+	iamv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+)
+
+// Profile returns the profile of the logged in user
+func (c *Client) Profile(ctx context.Context) (*iamv1.UserProfile, error) {
+	userID, err := c.userID()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.iam.GetProfile(ctx, &iamv1.GetProfileRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Found {
+		return nil, &Error{Code: codes.NotFound, Message: "profile not found"}
+	}
+	return resp.Profile, nil
+}
+
+// ChangePassword changes the password of the logged in user. The session of
+// the client stays valid and is no longer restricted to changing the password.
+func (c *Client) ChangePassword(ctx context.Context, currentPassword, newPassword string) error {
+	userID, err := c.userID()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.iam.ChangePassword(ctx, &iamv1.ChangePasswordRequest{
+		UserId:          userID,
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("password change failed: %s", resp.Message)
+	}
+
+	c.auth.mu.Lock()
+	if c.auth.session != nil {
+		c.auth.session.PasswordChangeRequired = false
+	}
+	c.auth.mu.Unlock()
+	return nil
+}
+
+// userID returns the ID of the logged in user
+func (c *Client) userID() (string, error) {
+	session, ok := c.Session()
+	if !ok || session.UserID == "" {
+		return "", ErrNotLoggedIn
+	}
+	return session.UserID, nil
+}
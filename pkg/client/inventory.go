@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+
+	inventoryv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/inventory/v1"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/pagination"
+	paginationv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/pagination/v1"
+)
+
+// Page selects a page of a list. The zero value is the first page with the
+// server's default size; following pages pass the NextPageToken of the
+// previous one.
+type Page struct {
+	Size  int
+	Token string
+}
+
+// ItemSearch filters SearchItems
+type ItemSearch struct {
+	Query         string // Name, description or SKU
+	Category      inventoryv1.ItemCategory
+	AvailableOnly bool
+	Page          Page
+}
+
+// GetItem returns an inventory item by ID
+func (c *Client) GetItem(ctx context.Context, itemID string) (*inventoryv1.InventoryItem, error) {
+	return c.getItem(ctx, &inventoryv1.GetItemRequest{
+		Identifier: &inventoryv1.GetItemRequest_ItemId{ItemId: itemID},
+	})
+}
+
+// GetItemBySKU returns an inventory item by SKU
+func (c *Client) GetItemBySKU(ctx context.Context, sku string) (*inventoryv1.InventoryItem, error) {
+	return c.getItem(ctx, &inventoryv1.GetItemRequest{
+		Identifier: &inventoryv1.GetItemRequest_Sku{Sku: sku},
+	})
+}
+
+func (c *Client) getItem(ctx context.Context, req *inventoryv1.GetItemRequest) (*inventoryv1.InventoryItem, error) {
+	resp, err := c.inventory.GetItem(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Found {
+		return nil, &Error{Code: codes.NotFound, Message: resp.Message}
+	}
+	return resp.Item, nil
+}
+
+// SearchItems returns a page of the inventory items matching search, sorted by SKU
+func (c *Client) SearchItems(ctx context.Context, search ItemSearch) ([]*inventoryv1.InventoryItem, pagination.PageInfo, error) {
+	resp, err := c.inventory.SearchItems(ctx, &inventoryv1.SearchItemsRequest{
+		Query:         search.Query,
+		Category:      search.Category,
+		AvailableOnly: search.AvailableOnly,
+		Page: &paginationv1.PageRequest{
+			PageSize:  int32(search.Page.Size),
+			PageToken: search.Page.Token,
+		},
+	})
+	if err != nil {
+		return nil, pagination.PageInfo{}, err
+	}
+	return resp.Items, pageInfoFromProto(resp.PageInfo), nil
+}
+
+func pageInfoFromProto(info *paginationv1.PageInfo) pagination.PageInfo {
+	if info == nil {
+		return pagination.PageInfo{}
+	}
+	return pagination.PageInfo{
+		NextPageToken: info.GetNextPageToken(),
+		HasMore:       info.GetHasMore(),
+		PageSize:      int(info.GetPageSize()),
+		TotalCount:    info.TotalCount,
+	}
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/pagination"
+)
+
+// Order is an order of the orders API
+type Order struct {
+	ID               string                 `json:"id"`
+	UserID           string                 `json:"user_id"`
+	Status           string                 `json:"status"`
+	Items            []OrderItem            `json:"items"`
+	TotalAmount      float64                `json:"total_amount"`
+	TotalAmountMinor int64                  `json:"total_amount_minor"` // Exact total in the currency's minor unit
+	Currency         string                 `json:"currency"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+	PaidAt           *time.Time             `json:"paid_at,omitempty"`
+	AssembledAt      *time.Time             `json:"assembled_at,omitempty"`
+	CompletedAt      *time.Time             `json:"completed_at,omitempty"`
+	Tags             []string               `json:"tags"`
+	Attributes       map[string]interface{} `json:"attributes"`
+}
+
+// OrderItem is an item of an order
+type OrderItem struct {
+	ID             string  `json:"id"`
+	ItemID         string  `json:"item_id"`
+	ItemName       string  `json:"item_name"`
+	Quantity       int     `json:"quantity"`
+	UnitPrice      float64 `json:"unit_price"`
+	UnitPriceMinor int64   `json:"unit_price_minor"`
+	Total          float64 `json:"total"`
+	TotalMinor     int64   `json:"total_minor"`
+}
+
+// CreateOrderRequest creates an order
+type CreateOrderRequest struct {
+	UserID     string                 `json:"user_id"`
+	Items      []CreateOrderItem      `json:"items"`
+	Expedited  bool                   `json:"expedited,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"` // String, number or boolean values
+}
+
+// CreateOrderItem is an item of a new order
+type CreateOrderItem struct {
+	ItemID   string `json:"item_id"`
+	Quantity int    `json:"quantity"`
+}
+
+// OrderFilter filters ListOrders; zero fields do not filter
+type OrderFilter struct {
+	UserID     string
+	Status     string
+	Tags       []string          // Orders carrying every tag
+	Attributes map[string]string // Orders whose attributes have these values
+	Page       Page
+}
+
+// OrderList is a page of orders
+type OrderList struct {
+	Orders   []Order             `json:"orders"`
+	PageInfo pagination.PageInfo `json:"page_info"`
+}
+
+// CreateOrder creates an order. It is not retried, since a retry could create
+// the order twice.
+func (c *Client) CreateOrder(ctx context.Context, req CreateOrderRequest) (*Order, error) {
+	var order Order
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/orders", nil, req, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetOrder returns an order by ID
+func (c *Client) GetOrder(ctx context.Context, orderID string) (*Order, error) {
+	var order Order
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/orders/"+url.PathEscape(orderID), nil, nil, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ListOrders returns a page of the orders matching filter
+func (c *Client) ListOrders(ctx context.Context, filter OrderFilter) (*OrderList, error) {
+	query := url.Values{}
+	if filter.UserID != "" {
+		query.Set("user_id", filter.UserID)
+	}
+	if filter.Status != "" {
+		query.Set("status", filter.Status)
+	}
+	for _, tag := range filter.Tags {
+		query.Add("tag", tag)
+	}
+	for name, value := range filter.Attributes {
+		query.Set("attr."+name, value)
+	}
+	if filter.Page.Size > 0 {
+		query.Set("page_size", strconv.Itoa(filter.Page.Size))
+	}
+	if filter.Page.Token != "" {
+		query.Set("page_token", filter.Page.Token)
+	}
+
+	var list OrderList
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/orders", query, nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// UpdateOrderStatus moves an order to status and returns the updated order
+func (c *Client) UpdateOrderStatus(ctx context.Context, orderID, status string) (*Order, error) {
+	body := map[string]string{"status": status}
+
+	var order Order
+	path := "/api/v1/orders/" + url.PathEscape(orderID) + "/status"
+	if err := c.doJSON(ctx, http.MethodPatch, path, nil, body, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// doJSON calls the orders API with a JSON body and decodes the JSON response
+// into out. A request rejected as unauthenticated is repeated once with a
+// renewed token.
+func (c *Client) doJSON(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	if c.ordersURL == nil {
+		return fmt.Errorf("orders URL is not configured")
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	token, err := c.auth.token(ctx, "")
+	if err != nil {
+		return err
+	}
+	resp, err := c.sendOrders(ctx, method, path, query, payload, token)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		if token, err = c.auth.token(ctx, token); err != nil {
+			return err
+		}
+		if resp, err = c.sendOrders(ctx, method, path, query, payload, token); err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fromHTTP(resp)
+	}
+	if out == nil {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// sendOrders sends one request to the orders API, retried by the HTTP client
+// when idempotent
+func (c *Client) sendOrders(ctx context.Context, method, path string, query url.Values, payload []byte, token string) (*http.Response, error) {
+	target := *c.ordersURL
+	target.Path += path
+	target.RawQuery = query.Encode()
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.ordersHTTP.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, &Error{Code: codes.Unavailable, Message: err.Error()}
+	}
+	return resp, nil
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amiosamu/rocket-science/shared/platform/backoff"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcerrors"
+)
+
+// readMethodPrefixes are the RPC name prefixes of calls without side effects.
+// Only they are retried after a timeout, which may hide a processed request.
+var readMethodPrefixes = []string{"Get", "List", "Search", "Check", "Validate"}
+
+// retryInterceptor bounds every attempt of a call by the configured timeout,
+// retries transient failures and converts the final error to an *Error
+func (c *Client) retryInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	var err error
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if waitErr := c.wait(ctx, attempt, err); waitErr != nil {
+				return fromGRPC(err)
+			}
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+		err = invoker(attemptCtx, method, req, reply, cc, opts...)
+		cancel()
+
+		if err == nil || ctx.Err() != nil || !retryable(method, err) {
+			break
+		}
+	}
+	return fromGRPC(err)
+}
+
+// retryable reports whether a call that failed with err may be repeated
+func retryable(method string, err error) bool {
+	if !grpcerrors.IsRetryable(err) {
+		return false
+	}
+	if status.Code(err) != codes.DeadlineExceeded {
+		return true
+	}
+
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range readMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// wait sleeps before a retry: exponential backoff from RetryBaseDelay, capped
+// at RetryMaxDelay, with full jitter over its upper half. A longer retry delay
+// asked for by the server is honored up to MaxRetryAfter; a call asked to wait
+// longer is not retried.
+func (c *Client) wait(ctx context.Context, attempt int, err error) error {
+	hint, _ := grpcerrors.RetryDelay(err)
+	policy := backoff.Policy{
+		BaseDelay:     c.config.RetryBaseDelay,
+		MaxDelay:      c.config.RetryMaxDelay,
+		MaxRetryAfter: c.config.MaxRetryAfter,
+	}
+	delay, ok := policy.Delay(attempt, hint)
+	if !ok {
+		return err // The caller gives up with the server's error
+	}
+	return backoff.Sleep(ctx, delay)
+}
@@ -2,13 +2,14 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"os"
-	"os/signal"
-	"sync"
-	"syscall"
 
 	"github.com/amiosamu/rocket-science/services/assembly-service/internal/container"
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/lifecycle"
 )
 
 func main() {
@@ -16,6 +17,23 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Layer the environment's config profile between defaults and environment variables
+	flags := platformconfig.RegisterFlags("PORT")
+	flag.Parse()
+	profile, err := flags.Apply()
+	if *flags.Validation.Validate {
+		// Report on the configuration instead of starting the service
+		validateConfiguration(profile, err, *flags.Validation.CheckConnectivity).Exit()
+	}
+	if err != nil {
+		fmt.Printf("❌ Failed to load config profile: %v\n", err)
+		os.Exit(1)
+	}
+	if *flags.MigrateOnly {
+		fmt.Println("✅ Assembly Service has no database migrations")
+		return
+	}
+
 	// Initialize dependency container
 	fmt.Println("🚀 Starting Assembly Service...")
 	container, err := container.NewContainer()
@@ -23,6 +41,10 @@ func main() {
 		fmt.Printf("❌ Failed to initialize container: %v\n", err)
 		os.Exit(1)
 	}
+	if err := profile.CheckUnknownKeys("ASSEMBLY_SERVICE_HEALTH_PORT"); err != nil {
+		fmt.Printf("❌ Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
 
 	container.Logger.Info(ctx, "Assembly service starting", map[string]interface{}{
 		"service_name":    container.Config.Service.Name,
@@ -31,95 +53,59 @@ func main() {
 		"port":            container.Config.Service.Port,
 	})
 
-	// Setup graceful shutdown
-	var wg sync.WaitGroup
-	shutdown := make(chan os.Signal, 1)
-	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
-
-	// Start Kafka consumer
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		container.Logger.Info(ctx, "Starting Kafka consumer")
-		if err := container.AssemblyConsumer.Start(ctx); err != nil {
-			container.Logger.Error(ctx, "Kafka consumer failed", err, nil)
-			cancel() // Cancel context to signal other goroutines to stop
-		}
-	}()
-
-	// Start health server
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		container.Logger.Info(ctx, "Starting health server")
-		if err := container.HealthServer.Start(); err != nil {
-			container.Logger.Error(ctx, "Health server failed to start", err, nil)
-			cancel() // Cancel context to signal other goroutines to stop
-		}
-
-		// Wait for context cancellation
-		<-ctx.Done()
-		container.Logger.Info(ctx, "Health server stopping")
-	}()
-
-	// Log service startup completion
-	container.Logger.Info(ctx, "🎉 Assembly service started successfully", map[string]interface{}{
+	// Register components in start order; they are stopped in reverse
+	runner := lifecycle.NewRunner(lifecycle.FromPlatformLogger(container.Logger))
+	runner.Add(
+		lifecycle.Component{
+			Name: "container",
+			Stop: func(ctx context.Context) error { return container.Close() },
+		},
+		lifecycle.Component{
+			Name:  "health-server",
+			Start: func(ctx context.Context) error { return container.HealthServer.Start() },
+			Stop:  func(ctx context.Context) error { return container.HealthServer.Stop() },
+		},
+		lifecycle.Component{
+			// Stops after the consumer, letting running assemblies finish
+			Name:        "assembly-service",
+			DependsOn:   []string{"container"},
+			Stop:        container.AssemblyService.Drain,
+			StopTimeout: container.Config.Service.GracefulTimeout,
+		},
+		lifecycle.Component{
+			Name:        "assembly-consumer",
+			DependsOn:   []string{"container"},
+			Start:       container.AssemblyConsumer.Start,
+			Stop:        func(ctx context.Context) error { return container.AssemblyConsumer.Stop() },
+			StopTimeout: container.Config.Service.GracefulTimeout,
+		},
+	)
+
+	// Log service startup configuration
+	container.Logger.Info(ctx, "🎉 Starting assembly service components", map[string]interface{}{
 		"kafka_brokers":       container.Config.Kafka.Consumer.Brokers,
 		"kafka_topics":        container.Config.Kafka.Consumer.Topics,
 		"simulation_duration": container.Config.Assembly.SimulationDuration.String(),
 		"max_concurrent":      container.Config.Assembly.MaxConcurrentAssemblies,
-		"failure_rate":        container.Config.Assembly.FailureRate,
+		"simulation_seed":     container.Config.Assembly.Simulation.Seed,
 	})
 
 	fmt.Printf("✅ Assembly Service is running!\n")
 	fmt.Printf("🏥 Health endpoints: http://localhost:8082/health\n")
 	fmt.Printf("📊 Simulation Duration: %s\n", container.Config.Assembly.SimulationDuration)
 	fmt.Printf("🔄 Max Concurrent Assemblies: %d\n", container.Config.Assembly.MaxConcurrentAssemblies)
-	fmt.Printf("⚠️  Failure Rate: %.1f%%\n", container.Config.Assembly.FailureRate*100)
+	fmt.Printf("⚠️  Engine Failure Rate: %.1f%% per engine\n", container.Config.Assembly.Simulation.EngineFailureRate*100)
 	fmt.Printf("📡 Kafka Brokers: %v\n", container.Config.Kafka.Consumer.Brokers)
 	fmt.Printf("📥 Listening for payment events on: %v\n", container.Config.Kafka.Consumer.Topics)
-	fmt.Printf("📤 Publishing assembly events to:\n")
-	fmt.Printf("   - Started: %s\n", container.Config.Kafka.Topics.AssemblyStarted)
-	fmt.Printf("   - Completed: %s\n", container.Config.Kafka.Topics.AssemblyCompleted)
-	fmt.Printf("   - Failed: %s\n", container.Config.Kafka.Topics.AssemblyFailed)
+	fmt.Printf("📤 Publishing assembly events to: %s\n", container.Config.Kafka.Topics.Name(topics.AssemblyEvents))
 	fmt.Println("\n🛑 Press Ctrl+C to stop the service")
 
-	// Wait for shutdown signal
-	<-shutdown
-
-	container.Logger.Info(ctx, "🛑 Shutdown signal received, starting graceful shutdown")
-	fmt.Println("\n🛑 Shutdown signal received, starting graceful shutdown...")
-
-	// Cancel context to signal all goroutines to stop
-	cancel()
-
-	// Create shutdown context with timeout
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), container.Config.Service.GracefulTimeout)
-	defer shutdownCancel()
-
-	// Close container dependencies
-	go func() {
-		if err := container.Close(); err != nil {
-			container.Logger.Error(shutdownCtx, "Error during container shutdown", err, nil)
-		}
-	}()
-
-	// Wait for all goroutines to finish or timeout
-	done := make(chan struct{})
-	go func() {
-		wg.Wait()
-		close(done)
-	}()
-
-	select {
-	case <-done:
-		container.Logger.Info(shutdownCtx, "✅ Graceful shutdown completed")
+	// Run until a shutdown signal is received or a component fails
+	if err := runner.Run(ctx); err != nil {
+		container.Logger.Error(ctx, "Assembly service stopped with errors", err, nil)
+		fmt.Printf("⚠️ Shutdown completed with errors: %v\n", err)
+	} else {
 		fmt.Println("✅ Graceful shutdown completed")
-	case <-shutdownCtx.Done():
-		container.Logger.Warn(shutdownCtx, "⚠️ Graceful shutdown timed out")
-		fmt.Println("⚠️ Graceful shutdown timed out")
 	}
 
 	fmt.Println("👋 Assembly Service stopped")
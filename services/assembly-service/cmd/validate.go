@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/amiosamu/rocket-science/services/assembly-service/internal/config"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+)
+
+// validateConfiguration loads the configuration the way startup does and
+// reports every problem found, optionally dialing the configured dependencies
+func validateConfiguration(profile *platformconfig.Profile, profileErr error, checkConnectivity bool) *platformconfig.Report {
+	report := platformconfig.NewReport("assembly-service")
+
+	cfg, err := config.Load()
+	report.Check("load configuration", err)
+	report.CheckParsedValues()
+	report.CheckProfile(profile, profileErr, "ASSEMBLY_SERVICE_HEALTH_PORT")
+
+	if !checkConnectivity {
+		return report
+	}
+	if cfg == nil {
+		report.Skip("connectivity", "configuration did not load")
+		return report
+	}
+
+	for _, broker := range cfg.Kafka.Consumer.Brokers {
+		report.CheckReachable("kafka broker", broker)
+	}
+	report.Check("kafka topics", kafka.VerifyTopics(cfg.Kafka.Consumer.Brokers, cfg.Kafka.Topics, config.UsedTopics...))
+	report.CheckReachable("redis", fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port))
+
+	return report
+}
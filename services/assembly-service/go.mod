@@ -7,12 +7,15 @@ replace github.com/amiosamu/rocket-science/shared => ../../shared
 require (
 	github.com/amiosamu/rocket-science/shared v0.0.0-00010101000000-000000000000
 	github.com/google/uuid v1.6.0
+	github.com/redis/go-redis/v9 v9.10.0
 	google.golang.org/protobuf v1.36.6
 )
 
 require (
 	github.com/IBM/sarama v1.45.2 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	github.com/eapache/go-resiliency v1.7.0 // indirect
 	github.com/eapache/go-xerial-snappy v0.0.0-20230731223053-c322873962e3 // indirect
 	github.com/eapache/queue v1.1.0 // indirect
@@ -7,16 +7,22 @@ import (
 	"strings"
 	"time"
 
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/database/redis"
 	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Service  ServiceConfig  `json:"service"`
-	Kafka    KafkaConfig    `json:"kafka"`
-	Logging  LoggingConfig  `json:"logging"`
-	Metrics  MetricsConfig  `json:"metrics"`
-	Assembly AssemblyConfig `json:"assembly"`
+	Service      ServiceConfig      `json:"service"`
+	Kafka        KafkaConfig        `json:"kafka"`
+	Redis        redis.Config       `json:"redis"`
+	Logging      LoggingConfig      `json:"logging"`
+	Metrics      MetricsConfig      `json:"metrics"`
+	Assembly     AssemblyConfig     `json:"assembly"`
+	Coordination CoordinationConfig `json:"coordination"`
+	Artifacts    ArtifactsConfig    `json:"artifacts"`
 }
 
 // ServiceConfig holds service-specific configuration
@@ -32,15 +38,32 @@ type ServiceConfig struct {
 type KafkaConfig struct {
 	Consumer kafka.ConsumerConfig `json:"consumer"`
 	Producer kafka.ProducerConfig `json:"producer"`
-	Topics   TopicsConfig         `json:"topics"`
+	Topics   topics.Registry      `json:"topics"`
 }
 
-// TopicsConfig defines Kafka topics used by the service
-type TopicsConfig struct {
-	PaymentProcessed  string `json:"payment_processed"`
-	AssemblyStarted   string `json:"assembly_started"`
-	AssemblyCompleted string `json:"assembly_completed"`
-	AssemblyFailed    string `json:"assembly_failed"`
+// UsedTopics are the registered topics the service consumes from and produces to
+var UsedTopics = []topics.Topic{topics.PaymentEvents, topics.AssemblyEvents, topics.AssemblyPartsConsumed}
+
+// CoordinationConfig holds the order claims that let several replicas share the
+// payment events without assembling an order twice
+type CoordinationConfig struct {
+	InstanceID    string        `json:"instance_id"`    // Identifies this replica in claims; defaults to the hostname
+	ClaimTTL      time.Duration `json:"claim_ttl"`      // How long a claim outlives a replica that stopped renewing it
+	RenewInterval time.Duration `json:"renew_interval"` // How often running assemblies renew their claims
+	Retention     time.Duration `json:"retention"`      // How long finished orders are remembered to drop redelivered payments
+	KeyPrefix     string        `json:"key_prefix"`     // Redis key prefix for order claims
+}
+
+// ArtifactsConfig holds the upload of completion reports to object storage. The
+// assembly completed event then carries a signed URL of the report instead of
+// the report itself, so heavy payloads stay out of Kafka. Events over the
+// producer's MaxMessageBytes are offloaded to the same storage.
+type ArtifactsConfig struct {
+	Enabled       bool          `json:"enabled"`
+	StorageDir    string        `json:"storage_dir"` // Directory the reports are stored in
+	URLSigningKey string        `json:"-"`           // Secret signing the report URLs; random per start when empty
+	URLExpiry     time.Duration `json:"url_expiry"`  // How long report URLs in events stay valid
+	PublicURL     string        `json:"public_url"`  // Base URL of the health server as reachable by consumers, e.g. "https://assembly.internal:8083"
 }
 
 // LoggingConfig holds logging configuration
@@ -61,14 +84,40 @@ type MetricsConfig struct {
 
 // AssemblyConfig holds assembly-specific configuration
 type AssemblyConfig struct {
-	SimulationDuration      time.Duration `json:"simulation_duration"`
-	MaxConcurrentAssemblies int           `json:"max_concurrent_assemblies"`
-	FailureRate             float64       `json:"failure_rate"` // 0.0 to 1.0
-	QualityThreshold        int           `json:"quality_threshold"`
+	SimulationDuration      time.Duration    `json:"simulation_duration"`
+	MaxConcurrentAssemblies int              `json:"max_concurrent_assemblies"`
+	QualityThreshold        int              `json:"quality_threshold"`
+	Simulation              SimulationConfig `json:"simulation"`
+	Resources               ResourcesConfig  `json:"resources"`
+}
+
+// ResourcesConfig models the shared equipment of the assembly floor, such as
+// two cleanrooms and one crane. A stage holds a unit of each resource it needs
+// while it runs, so assemblies queue for busy equipment. Without resources
+// only MaxConcurrentAssemblies limits the floor.
+type ResourcesConfig struct {
+	Capacity map[string]int      `json:"capacity"` // Units of each resource
+	Stages   map[string][]string `json:"stages"`   // Resources each stage needs
+}
+
+// SimulationConfig tunes how assembly duration and failures are derived from
+// the ordered bill of materials. SimulationDuration is the duration of a
+// standard single-engine build without payload; larger builds take longer.
+type SimulationConfig struct {
+	Seed                   int64   `json:"seed"`   // 0 seeds from the clock; otherwise runs are reproducible per order
+	Jitter                 float64 `json:"jitter"` // Relative per-stage duration jitter, 0.0 to 1.0
+	KittingFailureRate     float64 `json:"kitting_failure_rate"`
+	EngineFailureRate      float64 `json:"engine_failure_rate"` // Per engine installed
+	CalibrationFailureRate float64 `json:"calibration_failure_rate"`
+	PayloadFailureRate     float64 `json:"payload_failure_rate"` // Per 100 kg of payload
+	PayloadMassLimitKg     float64 `json:"payload_mass_limit_kg"`
+	InspectionFailureRate  float64 `json:"inspection_failure_rate"`
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
+	registry := topics.Load(platformconfig.Getenv)
+
 	return &Config{
 		Service: ServiceConfig{
 			Name:            "assembly-service",
@@ -82,7 +131,7 @@ func DefaultConfig() *Config {
 				Brokers:            strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
 				GroupID:            getEnv("KAFKA_CONSUMER_GROUP_ID", "assembly-service-group"),
 				ClientID:           getEnv("KAFKA_CONSUMER_CLIENT_ID", "assembly-service-consumer"),
-				Topics:             []string{getEnv("KAFKA_TOPIC_PAYMENT_PROCESSED", "payment.processed")},
+				Topics:             registry.Names(topics.PaymentEvents),
 				SessionTimeout:     getEnvAsDuration("KAFKA_SESSION_TIMEOUT", "30s"),
 				HeartbeatInterval:  getEnvAsDuration("KAFKA_HEARTBEAT_INTERVAL", "3s"),
 				RebalanceTimeout:   getEnvAsDuration("KAFKA_REBALANCE_TIMEOUT", "60s"),
@@ -95,6 +144,10 @@ func DefaultConfig() *Config {
 				RetryBackoff:       getEnvAsDuration("KAFKA_RETRY_BACKOFF", "1s"),
 				EnableDeadLetter:   getEnvAsBool("KAFKA_ENABLE_DEAD_LETTER", true),
 				DeadLetterTopic:    getEnv("KAFKA_DEAD_LETTER_TOPIC", "assembly.dead-letter"),
+				Membership: kafka.GroupMembership{
+					InstanceID:        getEnv("KAFKA_GROUP_INSTANCE_ID", ""),
+					RebalanceStrategy: getEnv("KAFKA_REBALANCE_STRATEGY", kafka.RebalanceStrategySticky),
+				},
 			},
 			Producer: kafka.ProducerConfig{
 				Brokers:            strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
@@ -104,17 +157,26 @@ func DefaultConfig() *Config {
 				FlushFrequency:     getEnvAsDuration("KAFKA_PRODUCER_FLUSH_FREQUENCY", "500ms"),
 				FlushMessages:      getEnvAsInt("KAFKA_PRODUCER_FLUSH_MESSAGES", 100),
 				CompressionType:    getEnv("KAFKA_PRODUCER_COMPRESSION", "snappy"),
+				CompressionLevel:   getEnvAsInt("KAFKA_PRODUCER_COMPRESSION_LEVEL", 0),
 				IdempotentProducer: getEnvAsBool("KAFKA_PRODUCER_IDEMPOTENT", true),
 				RequiredAcks:       getEnvAsInt("KAFKA_PRODUCER_REQUIRED_ACKS", -1),
 				MaxMessageBytes:    getEnvAsInt("KAFKA_PRODUCER_MAX_MESSAGE_BYTES", 1000000),
 				RequestTimeout:     getEnvAsDuration("KAFKA_PRODUCER_REQUEST_TIMEOUT", "30s"),
 			},
-			Topics: TopicsConfig{
-				PaymentProcessed:  getEnv("KAFKA_TOPIC_PAYMENT_PROCESSED", "payment.processed"),
-				AssemblyStarted:   getEnv("KAFKA_TOPIC_ASSEMBLY_STARTED", "assembly.started"),
-				AssemblyCompleted: getEnv("KAFKA_TOPIC_ASSEMBLY_COMPLETED", "assembly.completed"),
-				AssemblyFailed:    getEnv("KAFKA_TOPIC_ASSEMBLY_FAILED", "assembly.failed"),
-			},
+			Topics: registry,
+		},
+		Redis: redis.Config{
+			Host:         getEnv("REDIS_HOST", "localhost"),
+			Port:         getEnvAsInt("REDIS_PORT", 6379),
+			Password:     getEnv("REDIS_PASSWORD", ""),
+			DB:           getEnvAsInt("REDIS_DB", 0),
+			PoolSize:     getEnvAsInt("REDIS_POOL_SIZE", 10),
+			MinIdleConns: getEnvAsInt("REDIS_MIN_IDLE_CONNS", 2),
+			DialTimeout:  getEnvAsDuration("REDIS_DIAL_TIMEOUT", "5s"),
+			ReadTimeout:  getEnvAsDuration("REDIS_READ_TIMEOUT", "3s"),
+			WriteTimeout: getEnvAsDuration("REDIS_WRITE_TIMEOUT", "3s"),
+			IdleTimeout:  getEnvAsDuration("REDIS_IDLE_TIMEOUT", "5m"),
+			MaxRetries:   getEnvAsInt("REDIS_MAX_RETRIES", 3),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
@@ -131,12 +193,48 @@ func DefaultConfig() *Config {
 		Assembly: AssemblyConfig{
 			SimulationDuration:      getEnvAsDuration("ASSEMBLY_SIMULATION_DURATION", "10s"),
 			MaxConcurrentAssemblies: getEnvAsInt("ASSEMBLY_MAX_CONCURRENT", 10),
-			FailureRate:             getEnvAsFloat("ASSEMBLY_FAILURE_RATE", 0.05), // 5% failure rate
 			QualityThreshold:        getEnvAsInt("ASSEMBLY_QUALITY_THRESHOLD", 80),
+			Simulation: SimulationConfig{
+				Seed:                   getEnvAsInt64("ASSEMBLY_SIMULATION_SEED", 0),
+				Jitter:                 getEnvAsFloat("ASSEMBLY_SIMULATION_JITTER", 0.2),
+				KittingFailureRate:     getEnvAsFloat("ASSEMBLY_KITTING_FAILURE_RATE", 0.005),
+				EngineFailureRate:      getEnvAsFloat("ASSEMBLY_ENGINE_FAILURE_RATE", 0.02),
+				CalibrationFailureRate: getEnvAsFloat("ASSEMBLY_CALIBRATION_FAILURE_RATE", 0.01),
+				PayloadFailureRate:     getEnvAsFloat("ASSEMBLY_PAYLOAD_FAILURE_RATE", 0.01),
+				PayloadMassLimitKg:     getEnvAsFloat("ASSEMBLY_PAYLOAD_MASS_LIMIT_KG", 500),
+				InspectionFailureRate:  getEnvAsFloat("ASSEMBLY_INSPECTION_FAILURE_RATE", 0.015),
+			},
+			Resources: ResourcesConfig{
+				Capacity: getEnvAsCapacities("ASSEMBLY_RESOURCES"),
+				Stages:   getEnvAsStageResources("ASSEMBLY_STAGE_RESOURCES"),
+			},
+		},
+		Coordination: CoordinationConfig{
+			InstanceID:    getEnv("ASSEMBLY_INSTANCE_ID", hostname()),
+			ClaimTTL:      getEnvAsDuration("ASSEMBLY_CLAIM_TTL", "30s"),
+			RenewInterval: getEnvAsDuration("ASSEMBLY_CLAIM_RENEW_INTERVAL", "10s"),
+			Retention:     getEnvAsDuration("ASSEMBLY_CLAIM_RETENTION", "168h"),
+			KeyPrefix:     getEnv("ASSEMBLY_CLAIM_KEY_PREFIX", "assembly:order:"),
+		},
+		Artifacts: ArtifactsConfig{
+			Enabled:       getEnvAsBool("ASSEMBLY_ARTIFACTS_ENABLED", false),
+			StorageDir:    getEnv("ASSEMBLY_ARTIFACTS_STORAGE_DIR", "./data/assembly-artifacts"),
+			URLSigningKey: getEnv("ASSEMBLY_ARTIFACTS_URL_SIGNING_KEY", ""),
+			URLExpiry:     getEnvAsDuration("ASSEMBLY_ARTIFACTS_URL_EXPIRY", "168h"),
+			PublicURL:     getEnv("ASSEMBLY_ARTIFACTS_PUBLIC_URL", ""),
 		},
 	}
 }
 
+// hostname identifies the replica; in Kubernetes it is the pod name
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		return "assembly-service"
+	}
+	return name
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	config := DefaultConfig()
@@ -163,6 +261,14 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("kafka consumer group ID is required")
 	}
 
+	if err := c.Kafka.Consumer.Membership.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Kafka.Topics.Validate(); err != nil {
+		return err
+	}
+
 	if c.Assembly.SimulationDuration <= 0 {
 		return fmt.Errorf("assembly simulation duration must be positive")
 	}
@@ -171,8 +277,89 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("max concurrent assemblies must be positive")
 	}
 
-	if c.Assembly.FailureRate < 0 || c.Assembly.FailureRate > 1 {
-		return fmt.Errorf("assembly failure rate must be between 0 and 1")
+	if err := c.Assembly.Simulation.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Assembly.Resources.Validate(); err != nil {
+		return err
+	}
+
+	coordination := c.Coordination
+	if coordination.InstanceID == "" {
+		return fmt.Errorf("assembly instance ID is required")
+	}
+	if coordination.RenewInterval <= 0 || coordination.RenewInterval >= coordination.ClaimTTL {
+		return fmt.Errorf("assembly claim renew interval must be positive and shorter than the claim TTL")
+	}
+	if coordination.Retention < coordination.ClaimTTL {
+		return fmt.Errorf("assembly claim retention must not be shorter than the claim TTL")
+	}
+
+	if c.Artifacts.Enabled {
+		if c.Artifacts.StorageDir == "" {
+			return fmt.Errorf("assembly artifacts storage directory is required")
+		}
+		if c.Artifacts.URLExpiry <= 0 {
+			return fmt.Errorf("assembly artifacts URL expiry must be positive")
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the simulation settings
+func (sim SimulationConfig) Validate() error {
+	if sim.Jitter < 0 || sim.Jitter > 1 {
+		return fmt.Errorf("assembly simulation jitter must be between 0 and 1")
+	}
+
+	rates := map[string]float64{
+		"kitting":     sim.KittingFailureRate,
+		"engine":      sim.EngineFailureRate,
+		"calibration": sim.CalibrationFailureRate,
+		"payload":     sim.PayloadFailureRate,
+		"inspection":  sim.InspectionFailureRate,
+	}
+	for name, rate := range rates {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("assembly %s failure rate must be between 0 and 1", name)
+		}
+	}
+
+	if sim.PayloadMassLimitKg <= 0 {
+		return fmt.Errorf("assembly payload mass limit must be positive")
+	}
+
+	return nil
+}
+
+// assemblyStages are the stages of the simulation resources can be assigned to
+var assemblyStages = map[string]bool{
+	"kitting":              true,
+	"engine_installation":  true,
+	"avionics_calibration": true,
+	"payload_integration":  true,
+	"final_inspection":     true,
+}
+
+// Validate validates the assembly floor resources
+func (res ResourcesConfig) Validate() error {
+	for name, capacity := range res.Capacity {
+		if capacity <= 0 {
+			return fmt.Errorf("assembly resource %s must have a positive capacity", name)
+		}
+	}
+
+	for stage, resources := range res.Stages {
+		if !assemblyStages[stage] {
+			return fmt.Errorf("unknown assembly stage %q in stage resources", stage)
+		}
+		for _, name := range resources {
+			if _, ok := res.Capacity[name]; !ok {
+				return fmt.Errorf("assembly stage %s needs undeclared resource %q", stage, name)
+			}
+		}
 	}
 
 	return nil
@@ -180,47 +367,109 @@ func (c *Config) Validate() error {
 
 // Helper functions for environment variable parsing
 func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := platformconfig.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
 func getEnvAsInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		intValue, err := strconv.Atoi(value)
+		if err == nil {
 			return intValue
 		}
+		platformconfig.InvalidValue(key, value, err)
+	}
+	return defaultValue
+}
+
+func getEnvAsInt64(key string, defaultValue int64) int64 {
+	if value := platformconfig.Getenv(key); value != "" {
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err == nil {
+			return intValue
+		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	return defaultValue
 }
 
 func getEnvAsBool(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
-		if boolValue, err := strconv.ParseBool(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		boolValue, err := strconv.ParseBool(value)
+		if err == nil {
 			return boolValue
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	return defaultValue
 }
 
 func getEnvAsFloat(key string, defaultValue float64) float64 {
-	if value := os.Getenv(key); value != "" {
-		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		floatValue, err := strconv.ParseFloat(value, 64)
+		if err == nil {
 			return floatValue
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	return defaultValue
 }
 
 func getEnvAsDuration(key string, defaultValue string) time.Duration {
 	value := getEnv(key, defaultValue)
-	if duration, err := time.ParseDuration(value); err == nil {
+	duration, err := time.ParseDuration(value)
+	if err == nil {
 		return duration
 	}
+	platformconfig.InvalidValue(key, value, err)
 	// Fallback to default if parsing fails
 	if duration, err := time.ParseDuration(defaultValue); err == nil {
 		return duration
 	}
 	return 30 * time.Second // Final fallback
 }
+
+// getEnvAsCapacities parses name=capacity pairs, e.g. "cleanroom=2,crane=1"
+func getEnvAsCapacities(key string) map[string]int {
+	value := platformconfig.Getenv(key)
+	capacities := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, capacity, found := strings.Cut(pair, "=")
+		parsed, err := strconv.Atoi(strings.TrimSpace(capacity))
+		if !found || err != nil {
+			platformconfig.InvalidValue(key, value, fmt.Errorf("invalid resource %q, want name=capacity", pair))
+			continue
+		}
+		capacities[strings.ToLower(strings.TrimSpace(name))] = parsed
+	}
+	return capacities
+}
+
+// getEnvAsStageResources parses stage=resource pairs, several resources joined
+// by "+", e.g. "engine_installation=crane,payload_integration=cleanroom+crane"
+func getEnvAsStageResources(key string) map[string][]string {
+	value := platformconfig.Getenv(key)
+	stages := make(map[string][]string)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		stage, resources, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(resources) == "" {
+			platformconfig.InvalidValue(key, value, fmt.Errorf("invalid stage resources %q, want stage=resource+resource", pair))
+			continue
+		}
+		stage = strings.ToLower(strings.TrimSpace(stage))
+		for _, name := range strings.Split(resources, "+") {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				stages[stage] = append(stages[stage], name)
+			}
+		}
+	}
+	return stages
+}
@@ -1,6 +1,7 @@
 package container
 
 import (
+	"crypto/rand"
 	"fmt"
 	"log/slog"
 	"os"
@@ -9,8 +10,14 @@ import (
 	assemblyKafka "github.com/amiosamu/rocket-science/services/assembly-service/internal/messaging/kafka"
 	"github.com/amiosamu/rocket-science/services/assembly-service/internal/service"
 	"github.com/amiosamu/rocket-science/services/assembly-service/internal/transport/http"
+	"github.com/amiosamu/rocket-science/shared/platform/database/redis"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/objectstore"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/grpclog"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
 )
 
 // Container holds all the application dependencies
@@ -19,8 +26,13 @@ type Container struct {
 	Config *config.Config
 
 	// Infrastructure
-	Logger  logging.Logger
-	Metrics metrics.Metrics
+	Logger      logging.Logger
+	Metrics     metrics.Metrics
+	Maintenance *maintenance.Mode
+	Redis       *redis.Connection
+
+	// Receives the panics recovered from Kafka messages
+	CrashReporter recovery.Reporter
 
 	// Messaging
 	AssemblyConsumer *assemblyKafka.AssemblyConsumer
@@ -62,47 +74,105 @@ func NewContainer() (*Container, error) {
 	}
 	container.Metrics = metrics
 
+	// Initialize crash reporting
+	recoveryConfig, err := recovery.FromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("invalid crash reporting configuration: %w", err)
+	}
+	crashReporter, err := recovery.New(recoveryConfig, cfg.Service.Name, grpclog.FromLogger(logger))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create crash reporter: %w", err)
+	}
+	container.CrashReporter = crashReporter
+
+	// Check that the registered topics exist before producing to or consuming from them
+	if err := kafka.VerifyTopics(cfg.Kafka.Producer.Brokers, cfg.Kafka.Topics, config.UsedTopics...); err != nil {
+		if cfg.Kafka.Topics.Strict() {
+			return nil, fmt.Errorf("kafka topic check failed: %w", err)
+		}
+		logger.Warn(nil, "Kafka topic check failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
 	// Initialize assembly producer
 	assemblyProducer, err := assemblyKafka.NewAssemblyProducer(
 		cfg.Kafka.Producer,
 		logger,
 		metrics,
-		cfg.Kafka.Topics.AssemblyStarted,
-		cfg.Kafka.Topics.AssemblyCompleted,
-		cfg.Kafka.Topics.AssemblyFailed,
+		cfg.Kafka.Topics,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create assembly producer: %w", err)
 	}
 	container.AssemblyProducer = assemblyProducer
 
+	// Initialize order claims; without Redis each replica only sees its own claims,
+	// so more than one replica may assemble an order twice
+	var claims service.OrderClaims
+	redisConn, err := redis.NewConnection(cfg.Redis, logger)
+	if err != nil {
+		logger.Warn(nil, "Redis unavailable, using in-memory order claims", map[string]interface{}{
+			"error": err.Error(),
+		})
+		claims = service.NewMemoryOrderClaims(cfg.Coordination.ClaimTTL, cfg.Coordination.Retention)
+	} else {
+		container.Redis = redisConn
+		claims = service.NewRedisOrderClaims(redisConn.Client, cfg.Coordination.KeyPrefix, cfg.Coordination.ClaimTTL, cfg.Coordination.Retention)
+	}
+
 	// Initialize assembly service
 	assemblyService := service.NewAssemblyService(
 		cfg.Assembly,
+		cfg.Coordination,
+		claims,
 		assemblyProducer,
 		logger,
 		metrics,
 	)
 	container.AssemblyService = assemblyService
 
+	// Initialize completion artifact uploads
+	var artifacts *service.ObjectStoreArtifacts
+	if cfg.Artifacts.Enabled {
+		artifacts, err = newArtifacts(cfg.Artifacts, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create artifact storage: %w", err)
+		}
+		assemblyService.SetArtifactUploader(artifacts)
+		assemblyProducer.SetOverflowStore(artifacts)
+	}
+
 	// Initialize assembly consumer
 	assemblyConsumer, err := assemblyKafka.NewAssemblyConsumer(
 		cfg.Kafka.Consumer,
 		assemblyService, // AssemblyService implements PaymentEventHandler interface
 		logger,
 		metrics,
+		crashReporter,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create assembly consumer: %w", err)
 	}
 	container.AssemblyConsumer = assemblyConsumer
 
+	// Initialize maintenance mode; while enabled no new payment events are consumed
+	maintenanceMode := maintenance.FromEnv()
+	maintenanceMode.OnChange(func(enabled bool) {
+		if enabled {
+			assemblyConsumer.Pause()
+		} else {
+			assemblyConsumer.Resume()
+		}
+	})
+	container.Maintenance = maintenanceMode
+
 	// Initialize health server
 	structuredLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	})).With("service", cfg.Service.Name, "version", cfg.Service.Version)
 
-	healthServer := http.NewHealthServer(structuredLogger, cfg, assemblyService)
+	healthServer := http.NewHealthServer(structuredLogger, cfg, assemblyService, maintenanceMode, artifacts)
 	container.HealthServer = healthServer
 
 	logger.Info(nil, "Dependency injection container initialized successfully", map[string]interface{}{
@@ -111,22 +181,39 @@ func NewContainer() (*Container, error) {
 		"environment":     cfg.Service.Environment,
 		"kafka_brokers":   cfg.Kafka.Consumer.Brokers,
 		"kafka_topics":    cfg.Kafka.Consumer.Topics,
+		"maintenance":     maintenanceMode.Enabled(),
+		"instance_id":     cfg.Coordination.InstanceID,
+		"shared_claims":   container.Redis != nil,
+		"artifacts":       cfg.Artifacts.Enabled,
 	})
 
 	return container, nil
 }
 
-// Close gracefully shuts down all container dependencies
-func (c *Container) Close() error {
-	c.Logger.Info(nil, "Shutting down assembly service container")
+// newArtifacts creates the object storage completion reports are uploaded to
+func newArtifacts(cfg config.ArtifactsConfig, logger logging.Logger) (*service.ObjectStoreArtifacts, error) {
+	store, err := objectstore.NewFileStore(cfg.StorageDir)
+	if err != nil {
+		return nil, err
+	}
 
-	// Close assembly consumer
-	if c.AssemblyConsumer != nil {
-		if err := c.AssemblyConsumer.Stop(); err != nil {
-			c.Logger.Error(nil, "Failed to stop assembly consumer", err, nil)
+	signingKey := []byte(cfg.URLSigningKey)
+	if len(signingKey) == 0 {
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			return nil, fmt.Errorf("failed to generate URL signing key: %w", err)
 		}
+		logger.Warn(nil, "Artifact URL signing key not configured, signed URLs will not survive a restart")
 	}
 
+	return service.NewObjectStoreArtifacts(cfg, store, objectstore.NewSigner(signingKey)), nil
+}
+
+// Close releases the container's connections. The consumer and health server
+// are started and stopped by the lifecycle runner in main.
+func (c *Container) Close() error {
+	c.Logger.Info(nil, "Shutting down assembly service container")
+
 	// Close assembly producer
 	if c.AssemblyProducer != nil {
 		if err := c.AssemblyProducer.Close(); err != nil {
@@ -134,10 +221,10 @@ func (c *Container) Close() error {
 		}
 	}
 
-	// Stop health server
-	if c.HealthServer != nil {
-		if err := c.HealthServer.Stop(); err != nil {
-			c.Logger.Error(nil, "Failed to stop health server", err, nil)
+	// Close Redis connection
+	if c.Redis != nil {
+		if err := c.Redis.Close(); err != nil {
+			c.Logger.Error(nil, "Failed to close Redis connection", err, nil)
 		}
 	}
 
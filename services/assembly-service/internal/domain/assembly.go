@@ -56,29 +56,72 @@ func (q AssemblyQuality) String() string {
 // RocketComponent represents a component used in rocket assembly
 type RocketComponent struct {
 	ID          string `json:"id"`
+	SKU         string `json:"sku,omitempty"` // Inventory item the component is drawn from; empty for the standard kit
 	Name        string `json:"name"`
 	Type        string `json:"type"`
 	Weight      int32  `json:"weight"`      // in grams
 	Dimensions  string `json:"dimensions"`  // e.g., "10x5x3 cm"
 	Material    string `json:"material"`    // e.g., "aluminum", "carbon_fiber"
 	Criticality string `json:"criticality"` // "low", "medium", "high", "critical"
+	Quantity    int32  `json:"quantity"`
+}
+
+// StageTiming records the planned and actual duration of one assembly stage,
+// and how long it queued for floor resources before it started
+type StageTiming struct {
+	Stage           string        `json:"stage"`
+	PlannedDuration time.Duration `json:"planned_duration"`
+	QueuedDuration  time.Duration `json:"queued_duration,omitempty"`
+	ActualDuration  time.Duration `json:"actual_duration"`
+	Failed          bool          `json:"failed,omitempty"`
+}
+
+// SerialNumber identifies a serialized inventory unit built into the rocket
+type SerialNumber struct {
+	SKU    string `json:"sku"`
+	Serial string `json:"serial_number"`
+}
+
+// DeliveryAddress is where the finished rocket ships, as placed with the order
+type DeliveryAddress struct {
+	RecipientName string `json:"recipient_name"`
+	Line1         string `json:"line1"`
+	Line2         string `json:"line2,omitempty"`
+	City          string `json:"city"`
+	Region        string `json:"region,omitempty"`
+	PostalCode    string `json:"postal_code"`
+	Country       string `json:"country"`
+	Phone         string `json:"phone,omitempty"`
+}
+
+// ConsumedPart is a quantity of an inventory item built into the rocket
+type ConsumedPart struct {
+	SKU           string   `json:"sku"`
+	Quantity      int32    `json:"quantity"`
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
 }
 
 // Assembly represents the rocket assembly process
 type Assembly struct {
 	ID                       string            `json:"id"`
 	OrderID                  string            `json:"order_id"`
+	ShipmentID               string            `json:"shipment_id,omitempty"` // Set for one shipment of a partially fulfilled order
 	UserID                   string            `json:"user_id"`
 	Status                   AssemblyStatus    `json:"status"`
 	Components               []RocketComponent `json:"components"`
+	SerialNumbers            []SerialNumber    `json:"serial_numbers,omitempty"`
+	ShippingAddress          *DeliveryAddress  `json:"shipping_address,omitempty"`
 	Quality                  AssemblyQuality   `json:"quality"`
 	EstimatedDurationSeconds int32             `json:"estimated_duration_seconds"`
 	ActualDurationSeconds    int32             `json:"actual_duration_seconds"`
+	Stages                   []StageTiming     `json:"stages,omitempty"`
+	FailedStage              string            `json:"failed_stage,omitempty"`
 	StartedAt                *time.Time        `json:"started_at,omitempty"`
 	CompletedAt              *time.Time        `json:"completed_at,omitempty"`
 	FailedAt                 *time.Time        `json:"failed_at,omitempty"`
 	FailureReason            string            `json:"failure_reason,omitempty"`
 	ErrorCode                string            `json:"error_code,omitempty"`
+	ArtifactURL              string            `json:"artifact_url,omitempty"` // Signed URL of the uploaded completion report
 	CreatedAt                time.Time         `json:"created_at"`
 	UpdatedAt                time.Time         `json:"updated_at"`
 }
@@ -99,6 +142,16 @@ func NewAssembly(orderID, userID string, components []RocketComponent) *Assembly
 	}
 }
 
+// ClaimKey returns what the assembly is claimed and its events identified by: the
+// order, or the shipment of the order for partially fulfilled orders, whose
+// shipments are assembled one by one
+func (a *Assembly) ClaimKey() string {
+	if a.ShipmentID == "" {
+		return a.OrderID
+	}
+	return a.OrderID + "/" + a.ShipmentID
+}
+
 // Start begins the assembly process
 func (a *Assembly) Start() {
 	now := time.Now()
@@ -133,6 +186,15 @@ func (a *Assembly) Fail(reason, errorCode string) {
 	a.UpdatedAt = now
 }
 
+// RecordStage appends the timing of a finished stage
+func (a *Assembly) RecordStage(timing StageTiming) {
+	a.Stages = append(a.Stages, timing)
+	if timing.Failed {
+		a.FailedStage = timing.Stage
+	}
+	a.UpdatedAt = time.Now()
+}
+
 // determineQuality calculates assembly quality based on components and performance
 func (a *Assembly) determineQuality() {
 	// Simple quality determination logic
@@ -168,6 +230,42 @@ func (a *Assembly) determineQuality() {
 	}
 }
 
+// ConsumedParts totals the inventory parts built into the rocket by SKU, in the
+// order they were listed. Components of the standard kit have no SKU and are
+// left out; serialized units count towards their item.
+func (a *Assembly) ConsumedParts() []ConsumedPart {
+	var parts []ConsumedPart
+	index := make(map[string]int)
+	part := func(sku string) *ConsumedPart {
+		i, ok := index[sku]
+		if !ok {
+			i = len(parts)
+			index[sku] = i
+			parts = append(parts, ConsumedPart{SKU: sku})
+		}
+		return &parts[i]
+	}
+
+	for _, component := range a.Components {
+		if component.SKU == "" {
+			continue
+		}
+		quantity := component.Quantity
+		if quantity <= 0 {
+			quantity = 1
+		}
+		part(component.SKU).Quantity += quantity
+	}
+	for _, serial := range a.SerialNumbers {
+		p := part(serial.SKU)
+		p.SerialNumbers = append(p.SerialNumbers, serial.Serial)
+		if int32(len(p.SerialNumbers)) > p.Quantity {
+			p.Quantity = int32(len(p.SerialNumbers))
+		}
+	}
+	return parts
+}
+
 // IsCompleted returns true if the assembly is completed
 func (a *Assembly) IsCompleted() bool {
 	return a.Status == AssemblyStatusCompleted
@@ -9,6 +9,7 @@ import (
 	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
 	"github.com/amiosamu/rocket-science/shared/proto/events"
 )
 
@@ -32,6 +33,7 @@ func NewAssemblyConsumer(
 	paymentHandler PaymentEventHandler,
 	logger logging.Logger,
 	metrics metrics.Metrics,
+	reporter recovery.Reporter,
 ) (*AssemblyConsumer, error) {
 	consumer, err := kafka.NewConsumer(config, logger, metrics)
 	if err != nil {
@@ -46,8 +48,8 @@ func NewAssemblyConsumer(
 		topics:         config.Topics,
 	}
 
-	// Register this consumer as the message handler
-	consumer.RegisterHandler(assemblyConsumer)
+	// Register this consumer as the message handler; a panic fails only its message
+	consumer.RegisterHandler(recovery.Handler(assemblyConsumer, reporter))
 
 	return assemblyConsumer, nil
 }
@@ -68,7 +70,7 @@ func (c *AssemblyConsumer) HandleMessage(ctx context.Context, message *kafka.Mes
 	})
 
 	// Record metrics
-	c.metrics.IncrementCounter("kafka_messages_received_total", map[string]string{
+	c.metrics.IncrementCounter(ctx, "kafka_messages_received_total", map[string]string{
 		"topic":      message.Topic,
 		"event_type": message.EventType,
 	})
@@ -77,7 +79,7 @@ func (c *AssemblyConsumer) HandleMessage(ctx context.Context, message *kafka.Mes
 	start := time.Now()
 	defer func() {
 		duration := time.Since(start)
-		c.metrics.RecordDuration("kafka_message_processing_duration_seconds", duration, map[string]string{
+		c.metrics.RecordDuration(ctx, "kafka_message_processing_duration_seconds", duration, map[string]string{
 			"topic":      message.Topic,
 			"event_type": message.EventType,
 		})
@@ -91,13 +93,23 @@ func (c *AssemblyConsumer) HandleMessage(ctx context.Context, message *kafka.Mes
 			"offset":    message.Offset,
 			"raw_value": string(message.Value),
 		})
-		c.metrics.IncrementCounter("kafka_message_processing_errors_total", map[string]string{
+		c.metrics.IncrementCounter(ctx, "kafka_message_processing_errors_total", map[string]string{
 			"topic": message.Topic,
 			"error": "unmarshal_envelope_failed",
 		})
 		return fmt.Errorf("failed to unmarshal event envelope: %w", err)
 	}
 
+	// Messages not wrapped in an event envelope decode without an event
+	if envelope.Event == nil {
+		c.logger.Warn(ctx, "Received message without event envelope", map[string]interface{}{
+			"topic":      message.Topic,
+			"event_type": message.EventType,
+			"offset":     message.Offset,
+		})
+		return nil
+	}
+
 	// Route based on event type
 	switch envelope.Event.Type {
 	case "payment.processed":
@@ -108,7 +120,7 @@ func (c *AssemblyConsumer) HandleMessage(ctx context.Context, message *kafka.Mes
 			"event_id":   envelope.Event.Id,
 			"topic":      message.Topic,
 		})
-		c.metrics.IncrementCounter("kafka_message_processing_errors_total", map[string]string{
+		c.metrics.IncrementCounter(ctx, "kafka_message_processing_errors_total", map[string]string{
 			"topic": message.Topic,
 			"error": "unknown_event_type",
 		})
@@ -130,7 +142,7 @@ func (c *AssemblyConsumer) handlePaymentProcessedEvent(ctx context.Context, enve
 		c.logger.Error(ctx, "Failed to unmarshal payment processed event", err, map[string]interface{}{
 			"event_id": envelope.Event.Id,
 		})
-		c.metrics.IncrementCounter("kafka_message_processing_errors_total", map[string]string{
+		c.metrics.IncrementCounter(ctx, "kafka_message_processing_errors_total", map[string]string{
 			"event_type": "payment.processed",
 			"error":      "unmarshal_event_data_failed",
 		})
@@ -143,7 +155,7 @@ func (c *AssemblyConsumer) handlePaymentProcessedEvent(ctx context.Context, enve
 			"event_id":   envelope.Event.Id,
 			"payment_id": paymentEvent.PaymentId,
 		})
-		c.metrics.IncrementCounter("kafka_message_processing_errors_total", map[string]string{
+		c.metrics.IncrementCounter(ctx, "kafka_message_processing_errors_total", map[string]string{
 			"event_type": "payment.processed",
 			"error":      "missing_order_id",
 		})
@@ -156,7 +168,7 @@ func (c *AssemblyConsumer) handlePaymentProcessedEvent(ctx context.Context, enve
 			"order_id":   paymentEvent.OrderId,
 			"payment_id": paymentEvent.PaymentId,
 		})
-		c.metrics.IncrementCounter("kafka_message_processing_errors_total", map[string]string{
+		c.metrics.IncrementCounter(ctx, "kafka_message_processing_errors_total", map[string]string{
 			"event_type": "payment.processed",
 			"error":      "missing_user_id",
 		})
@@ -182,7 +194,7 @@ func (c *AssemblyConsumer) handlePaymentProcessedEvent(ctx context.Context, enve
 			"order_id":   paymentEvent.OrderId,
 			"payment_id": paymentEvent.PaymentId,
 		})
-		c.metrics.IncrementCounter("kafka_message_processing_errors_total", map[string]string{
+		c.metrics.IncrementCounter(ctx, "kafka_message_processing_errors_total", map[string]string{
 			"event_type": "payment.processed",
 			"error":      "handler_failed",
 		})
@@ -195,7 +207,7 @@ func (c *AssemblyConsumer) handlePaymentProcessedEvent(ctx context.Context, enve
 		"payment_id": paymentEvent.PaymentId,
 	})
 
-	c.metrics.IncrementCounter("kafka_messages_processed_total", map[string]string{
+	c.metrics.IncrementCounter(ctx, "kafka_messages_processed_total", map[string]string{
 		"event_type": "payment.processed",
 		"status":     "success",
 	})
@@ -218,6 +230,16 @@ func (c *AssemblyConsumer) Stop() error {
 	return c.consumer.Stop()
 }
 
+// Pause stops pulling new payment events; assemblies already started keep running
+func (c *AssemblyConsumer) Pause() {
+	c.consumer.Pause()
+}
+
+// Resume resumes pulling payment events after a Pause
+func (c *AssemblyConsumer) Resume() {
+	c.consumer.Resume()
+}
+
 // HealthCheck checks the health of the consumer
 func (c *AssemblyConsumer) HealthCheck(ctx context.Context) error {
 	return c.consumer.HealthCheck(ctx)
@@ -3,12 +3,14 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/amiosamu/rocket-science/services/assembly-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
 	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
@@ -21,9 +23,8 @@ type AssemblyProducer struct {
 	logger   logging.Logger
 	metrics  metrics.Metrics
 	topics   struct {
-		assemblyStarted   string
-		assemblyCompleted string
-		assemblyFailed    string
+		assemblyEvents string // Started, completed and failed events
+		partsConsumed  string
 	}
 }
 
@@ -32,9 +33,7 @@ func NewAssemblyProducer(
 	config kafka.ProducerConfig,
 	logger logging.Logger,
 	metrics metrics.Metrics,
-	assemblyStartedTopic string,
-	assemblyCompletedTopic string,
-	assemblyFailedTopic string,
+	registry topics.Registry,
 ) (*AssemblyProducer, error) {
 	producer, err := kafka.NewProducer(config, logger, metrics)
 	if err != nil {
@@ -47,9 +46,8 @@ func NewAssemblyProducer(
 		metrics:  metrics,
 	}
 
-	assemblyProducer.topics.assemblyStarted = assemblyStartedTopic
-	assemblyProducer.topics.assemblyCompleted = assemblyCompletedTopic
-	assemblyProducer.topics.assemblyFailed = assemblyFailedTopic
+	assemblyProducer.topics.assemblyEvents = registry.Name(topics.AssemblyEvents)
+	assemblyProducer.topics.partsConsumed = registry.Name(topics.AssemblyPartsConsumed)
 
 	return assemblyProducer, nil
 }
@@ -69,13 +67,14 @@ func (p *AssemblyProducer) PublishAssemblyStarted(ctx context.Context, assembly
 	assemblyEvent := &events.AssemblyStartedEvent{
 		AssemblyId:               assembly.ID,
 		OrderId:                  assembly.OrderID,
+		ShipmentId:               assembly.ShipmentID,
 		UserId:                   assembly.UserID,
 		Components:               []*events.RocketComponent{}, // Simplified for demo
 		StartedAt:                timestamppb.New(time.Now()),
 		EstimatedDurationSeconds: assembly.EstimatedDurationSeconds,
 	}
 
-	return p.publishEvent(ctx, p.topics.assemblyStarted, "assembly.started", assembly.OrderID, assemblyEvent)
+	return p.publishEvent(ctx, p.topics.assemblyEvents, "assembly.started", assembly, assemblyEvent, true)
 }
 
 // PublishAssemblyCompleted publishes an assembly completed event
@@ -91,13 +90,34 @@ func (p *AssemblyProducer) PublishAssemblyCompleted(ctx context.Context, assembl
 	assemblyEvent := &events.AssemblyCompletedEvent{
 		AssemblyId:            assembly.ID,
 		OrderId:               assembly.OrderID,
+		ShipmentId:            assembly.ShipmentID,
 		UserId:                assembly.UserID,
 		ActualDurationSeconds: assembly.ActualDurationSeconds,
 		Quality:               events.AssemblyQuality(assembly.Quality),
 		CompletedAt:           timestamppb.New(*assembly.CompletedAt),
+		StageTimings:          stageTimings(assembly.Stages),
+		ArtifactUrl:           assembly.ArtifactURL,
+	}
+	for _, serial := range assembly.SerialNumbers {
+		assemblyEvent.SerialNumbers = append(assemblyEvent.SerialNumbers, &events.AllocatedSerial{
+			Sku:          serial.SKU,
+			SerialNumber: serial.Serial,
+		})
+	}
+	if address := assembly.ShippingAddress; address != nil {
+		assemblyEvent.ShippingAddress = &events.DeliveryAddress{
+			RecipientName: address.RecipientName,
+			Line1:         address.Line1,
+			Line2:         address.Line2,
+			City:          address.City,
+			Region:        address.Region,
+			PostalCode:    address.PostalCode,
+			Country:       address.Country,
+			Phone:         address.Phone,
+		}
 	}
 
-	return p.publishEvent(ctx, p.topics.assemblyCompleted, "assembly.completed", assembly.OrderID, assemblyEvent)
+	return p.publishEvent(ctx, p.topics.assemblyEvents, "assembly.completed", assembly, assemblyEvent, true)
 }
 
 // PublishAssemblyFailed publishes an assembly failed event
@@ -114,18 +134,69 @@ func (p *AssemblyProducer) PublishAssemblyFailed(ctx context.Context, assembly *
 	assemblyEvent := &events.AssemblyFailedEvent{
 		AssemblyId:       assembly.ID,
 		OrderId:          assembly.OrderID,
+		ShipmentId:       assembly.ShipmentID,
 		UserId:           assembly.UserID,
 		Reason:           assembly.FailureReason,
 		ErrorCode:        assembly.ErrorCode,
 		FailedAt:         timestamppb.New(*assembly.FailedAt),
 		FailedComponents: []string{}, // Could be filled with actual failed components
+		FailedStage:      assembly.FailedStage,
+		StageTimings:     stageTimings(assembly.Stages),
+	}
+
+	return p.publishEvent(ctx, p.topics.assemblyEvents, "assembly.failed", assembly, assemblyEvent, true)
+}
+
+// PublishAssemblyPartsConsumed publishes the inventory parts built into a completed
+// rocket. Assemblies of the standard kit consume no inventory parts and publish nothing.
+func (p *AssemblyProducer) PublishAssemblyPartsConsumed(ctx context.Context, assembly *domain.Assembly) error {
+	parts := assembly.ConsumedParts()
+	if len(parts) == 0 {
+		return nil
+	}
+
+	consumedAt := time.Now()
+	if assembly.CompletedAt != nil {
+		consumedAt = *assembly.CompletedAt
+	}
+
+	partsEvent := &events.AssemblyPartsConsumedEvent{
+		AssemblyId: assembly.ID,
+		OrderId:    assembly.OrderID,
+		ShipmentId: assembly.ShipmentID,
+		UserId:     assembly.UserID,
+		ConsumedAt: timestamppb.New(consumedAt),
+	}
+	for _, part := range parts {
+		partsEvent.Parts = append(partsEvent.Parts, &events.ConsumedPart{
+			Sku:           part.SKU,
+			Quantity:      part.Quantity,
+			SerialNumbers: part.SerialNumbers,
+		})
 	}
 
-	return p.publishEvent(ctx, p.topics.assemblyFailed, "assembly.failed", assembly.OrderID, assemblyEvent)
+	// Stock bookkeeping is of no interest to the customer
+	return p.publishEvent(ctx, p.topics.partsConsumed, "assembly.parts-consumed", assembly, partsEvent, false)
+}
+
+// stageTimings converts recorded assembly stages into their event form
+func stageTimings(stages []domain.StageTiming) []*events.AssemblyStageTiming {
+	timings := make([]*events.AssemblyStageTiming, 0, len(stages))
+	for _, stage := range stages {
+		timings = append(timings, &events.AssemblyStageTiming{
+			Stage:             stage.Stage,
+			PlannedDurationMs: stage.PlannedDuration.Milliseconds(),
+			ActualDurationMs:  stage.ActualDuration.Milliseconds(),
+			Failed:            stage.Failed,
+		})
+	}
+	return timings
 }
 
 // publishEvent is a helper method to publish events with consistent structure
-func (p *AssemblyProducer) publishEvent(ctx context.Context, topic, eventType, orderID string, eventData interface{}) error {
+func (p *AssemblyProducer) publishEvent(ctx context.Context, topic, eventType string, assembly *domain.Assembly, eventData interface{}, notify bool) error {
+	orderID := assembly.OrderID
+
 	// For demo purposes, we'll use simple JSON serialization
 	// In production, this would use proper protobuf serialization
 
@@ -135,9 +206,14 @@ func (p *AssemblyProducer) publishEvent(ctx context.Context, topic, eventType, o
 		"order_id":   orderID,
 	})
 
+	// The event ID is derived from the order (or shipment) and event type, so an
+	// event published again (by a replica that re-ran the assembly) is dropped by
+	// consumers that dedupe on it
+	eventID := eventIDFor(assembly.ClaimKey(), eventType)
+
 	// Simple event structure for demo
 	simpleEvent := map[string]interface{}{
-		"id":        uuid.New().String(),
+		"id":        eventID,
 		"type":      eventType,
 		"source":    "assembly-service",
 		"subject":   orderID,
@@ -145,14 +221,24 @@ func (p *AssemblyProducer) publishEvent(ctx context.Context, topic, eventType, o
 		"data":      eventData,
 	}
 
+	// Keyed by order ID so every event of an order lands on one partition, in order.
+	// Only the events worth telling the customer about are flagged for notification.
+	headers := map[string]string{
+		kafka.EventTypeHeader:   eventType,
+		kafka.EventIDHeader:     eventID,
+		kafka.EventSourceHeader: "assembly-service",
+		kafka.NotifyHeader:      strconv.FormatBool(notify),
+		"content-type":          "application/json",
+	}
+
 	// Send the event
-	if err := p.producer.SendMessage(ctx, topic, orderID, simpleEvent, nil); err != nil {
+	if err := p.producer.SendMessage(ctx, topic, orderID, simpleEvent, headers); err != nil {
 		p.logger.Error(ctx, "Failed to publish event", err, map[string]interface{}{
 			"event_type": eventType,
 			"topic":      topic,
 			"order_id":   orderID,
 		})
-		p.metrics.IncrementCounter("kafka_publish_errors_total", map[string]string{
+		p.metrics.IncrementCounter(ctx, "kafka_publish_errors_total", map[string]string{
 			"topic": topic,
 			"error": "send_failed",
 		})
@@ -165,7 +251,7 @@ func (p *AssemblyProducer) publishEvent(ctx context.Context, topic, eventType, o
 		"order_id":   orderID,
 	})
 
-	p.metrics.IncrementCounter("kafka_events_published_total", map[string]string{
+	p.metrics.IncrementCounter(ctx, "kafka_events_published_total", map[string]string{
 		"topic":      topic,
 		"event_type": eventType,
 	})
@@ -173,6 +259,17 @@ func (p *AssemblyProducer) publishEvent(ctx context.Context, topic, eventType, o
 	return nil
 }
 
+// eventIDFor returns the deterministic ID of an order's event of the given type
+func eventIDFor(claimKey, eventType string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(claimKey+":"+eventType)).String()
+}
+
+// SetOverflowStore offloads events larger than the maximum message size to the
+// store, e.g. those of assemblies with very many components
+func (p *AssemblyProducer) SetOverflowStore(store kafka.OverflowStore) {
+	p.producer.SetOverflowStore(store)
+}
+
 // Close closes the producer
 func (p *AssemblyProducer) Close() error {
 	p.logger.Info(nil, "Closing assembly producer")
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/assembly-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/assembly-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/assembly-service/internal/simulation"
+)
+
+// Errors returned by the admin operations
+var (
+	ErrAssemblyNotFound  = errors.New("assembly not found")
+	ErrAssemblyNotActive = errors.New("assembly is not in flight")
+	ErrAssemblyNotFailed = errors.New("only failed assemblies can be requeued")
+	ErrOrderBeingBuilt   = errors.New("order is being assembled")
+)
+
+// Operator cancellations fail the assembly with this reason
+const (
+	cancelledReason = "cancelled_by_operator"
+	cancelledCode   = "ASM_006"
+)
+
+// SimulationSettings is the simulation tuning that can be changed at runtime
+type SimulationSettings struct {
+	SimulationDuration time.Duration           `json:"simulation_duration"`
+	Simulation         config.SimulationConfig `json:"simulation"`
+}
+
+// ListInFlightAssemblies returns the pending and running assemblies of this replica
+func (s *AssemblyService) ListInFlightAssemblies(ctx context.Context) []domain.Assembly {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	assemblies := make([]domain.Assembly, 0, len(s.runs))
+	for id := range s.runs {
+		if assembly, ok := s.activeAssemblies[id]; ok {
+			assemblies = append(assemblies, *assembly)
+		}
+	}
+	return assemblies
+}
+
+// CancelAssembly stops an in-flight assembly. It fails with reason
+// "cancelled_by_operator" and the failure is published like any other, so the
+// order isn't assembled again when its payment event is redelivered.
+func (s *AssemblyService) CancelAssembly(ctx context.Context, assemblyID, cancelledBy string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.activeAssemblies[assemblyID]; !exists {
+		return fmt.Errorf("%w: %s", ErrAssemblyNotFound, assemblyID)
+	}
+	cancel, running := s.runs[assemblyID]
+	if !running {
+		return fmt.Errorf("%w: %s", ErrAssemblyNotActive, assemblyID)
+	}
+
+	s.cancelled[assemblyID] = true
+	cancel()
+
+	s.logger.Warn(ctx, "Assembly cancelled by operator", map[string]interface{}{
+		"assembly_id":  assemblyID,
+		"cancelled_by": cancelledBy,
+	})
+	return nil
+}
+
+// RequeueAssembly assembles the order of a failed assembly again, as a new
+// assembly with the same components and serial numbers. It returns the new
+// assembly.
+func (s *AssemblyService) RequeueAssembly(ctx context.Context, assemblyID, requeuedBy string) (*domain.Assembly, error) {
+	s.mu.RLock()
+	failed, exists := s.activeAssemblies[assemblyID]
+	s.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrAssemblyNotFound, assemblyID)
+	}
+	if !failed.IsFailed() {
+		return nil, fmt.Errorf("%w: %s is %s", ErrAssemblyNotFailed, assemblyID, failed.Status)
+	}
+
+	claimed, err := s.claims.Reopen(ctx, failed.ClaimKey(), s.coordination.InstanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim order %s: %w", failed.ClaimKey(), err)
+	}
+	if !claimed {
+		return nil, fmt.Errorf("%w: %s", ErrOrderBeingBuilt, failed.ClaimKey())
+	}
+
+	assembly := domain.NewAssembly(failed.OrderID, failed.UserID, append([]domain.RocketComponent(nil), failed.Components...))
+	assembly.SerialNumbers = append([]domain.SerialNumber(nil), failed.SerialNumbers...)
+	assembly.ShippingAddress = failed.ShippingAddress
+	assembly.ShipmentID = failed.ShipmentID
+
+	// The retry replaces the failed assembly
+	s.mu.Lock()
+	delete(s.activeAssemblies, assemblyID)
+	s.mu.Unlock()
+
+	s.launch(ctx, assembly)
+
+	s.logger.Info(ctx, "Failed assembly requeued by operator", map[string]interface{}{
+		"assembly_id":        assembly.ID,
+		"failed_assembly_id": assemblyID,
+		"order_id":           assembly.OrderID,
+		"requeued_by":        requeuedBy,
+	})
+	s.metrics.IncrementCounter(ctx, "assemblies_requeued_total", nil)
+
+	return assembly, nil
+}
+
+// SimulationSettings returns the simulation tuning in use
+func (s *AssemblyService) SimulationSettings() SimulationSettings {
+	duration, cfg := s.simulator.Tuning()
+	return SimulationSettings{SimulationDuration: duration, Simulation: cfg}
+}
+
+// UpdateSimulation changes the simulation tuning of assemblies started from now
+// on. Running assemblies keep their plan.
+func (s *AssemblyService) UpdateSimulation(ctx context.Context, settings SimulationSettings, updatedBy string) error {
+	if settings.SimulationDuration <= 0 {
+		return fmt.Errorf("assembly simulation duration must be positive")
+	}
+	if err := settings.Simulation.Validate(); err != nil {
+		return err
+	}
+
+	s.simulator.Tune(settings.SimulationDuration, settings.Simulation)
+
+	s.logger.Warn(ctx, "Assembly simulation tuning changed", map[string]interface{}{
+		"simulation_duration": settings.SimulationDuration.String(),
+		"simulation":          settings.Simulation,
+		"updated_by":          updatedBy,
+	})
+	return nil
+}
+
+// stopAssembly ends an assembly whose context was cancelled. Assemblies cancelled
+// by an operator fail; the rest are interrupted and released for another replica.
+func (s *AssemblyService) stopAssembly(ctx context.Context, assembly *domain.Assembly) {
+	s.mu.RLock()
+	cancelled := s.cancelled[assembly.ID]
+	s.mu.RUnlock()
+
+	if !cancelled {
+		s.interruptAssembly(assembly)
+		return
+	}
+
+	// The run context is cancelled; publishing and the claim outlive it
+	ctx = context.WithoutCancel(ctx)
+	defer s.finishClaim(ctx, assembly)
+	s.handleAssemblyFailure(ctx, assembly, simulation.FailureMode{Reason: cancelledReason, Code: cancelledCode})
+}
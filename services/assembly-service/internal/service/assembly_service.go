@@ -3,12 +3,14 @@ package service
 import (
 	"context"
 	"fmt"
-	"math/rand"
+	"math"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/amiosamu/rocket-science/services/assembly-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/assembly-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/assembly-service/internal/simulation"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
 	"github.com/amiosamu/rocket-science/shared/proto/events"
@@ -19,42 +21,89 @@ type AssemblyProducer interface {
 	PublishAssemblyStarted(ctx context.Context, assembly *domain.Assembly) error
 	PublishAssemblyCompleted(ctx context.Context, assembly *domain.Assembly) error
 	PublishAssemblyFailed(ctx context.Context, assembly *domain.Assembly) error
+	PublishAssemblyPartsConsumed(ctx context.Context, assembly *domain.Assembly) error
 }
 
 // AssemblyService handles the core assembly business logic
 type AssemblyService struct {
-	config   config.AssemblyConfig
-	producer AssemblyProducer
-	logger   logging.Logger
-	metrics  metrics.Metrics
+	config       config.AssemblyConfig
+	coordination config.CoordinationConfig
+	claims       OrderClaims
+	simulator    *simulation.Simulator
+	resources    *simulation.ResourcePool
+	producer     AssemblyProducer
+	artifacts    ArtifactUploader // nil unless completion artifacts are uploaded
+	logger       logging.Logger
+	metrics      metrics.Metrics
 
 	// In-memory storage for active assemblies (in production, this would be in a database)
 	activeAssemblies map[string]*domain.Assembly
 	mu               sync.RWMutex
 
+	// Cancel funcs of the running assemblies, and the ones an operator cancelled
+	runs      map[string]context.CancelFunc
+	cancelled map[string]bool
+
 	// Channel for managing concurrent assemblies
 	assemblySemaphore chan struct{}
+
+	// Assemblies run detached from the payment message that started them;
+	// stop cancels the ones still running when the service drains
+	inFlight sync.WaitGroup
+	stop     context.CancelFunc
+	stopCtx  context.Context
 }
 
 // NewAssemblyService creates a new assembly service
 func NewAssemblyService(
 	config config.AssemblyConfig,
+	coordination config.CoordinationConfig,
+	claims OrderClaims,
 	producer AssemblyProducer,
 	logger logging.Logger,
 	metrics metrics.Metrics,
 ) *AssemblyService {
-	return &AssemblyService{
+	stopCtx, stop := context.WithCancel(context.Background())
+	s := &AssemblyService{
 		config:            config,
+		coordination:      coordination,
+		claims:            claims,
+		simulator:         simulation.NewSimulator(config),
 		producer:          producer,
 		logger:            logger,
 		metrics:           metrics,
 		activeAssemblies:  make(map[string]*domain.Assembly),
+		runs:              make(map[string]context.CancelFunc),
+		cancelled:         make(map[string]bool),
 		assemblySemaphore: make(chan struct{}, config.MaxConcurrentAssemblies),
+		stop:              stop,
+		stopCtx:           stopCtx,
 	}
+	s.resources = simulation.NewResourcePool(config.Resources, s.recordResourceUsage)
+	return s
 }
 
-// HandlePaymentProcessed processes payment completion and starts rocket assembly
+// HandlePaymentProcessed processes payment completion and starts rocket assembly.
+// The order is claimed first so that a payment event redelivered after a
+// consumer group rebalance, or to another replica, does not assemble it twice.
 func (s *AssemblyService) HandlePaymentProcessed(ctx context.Context, paymentEvent *events.PaymentProcessedEvent) error {
+	claimKey := (&domain.Assembly{OrderID: paymentEvent.OrderId, ShipmentID: paymentEvent.ShipmentId}).ClaimKey()
+	claimed, err := s.claims.Claim(ctx, claimKey, s.coordination.InstanceID)
+	if err != nil {
+		// Returning the error leaves the event to be retried rather than risk a duplicate
+		return fmt.Errorf("failed to claim order %s: %w", claimKey, err)
+	}
+	if !claimed {
+		s.logger.Info(ctx, "Skipping payment event for order already claimed", map[string]interface{}{
+			"order_id":    paymentEvent.OrderId,
+			"shipment_id": paymentEvent.ShipmentId,
+			"payment_id":  paymentEvent.PaymentId,
+			"instance_id": s.coordination.InstanceID,
+		})
+		s.metrics.IncrementCounter(ctx, "assembly_duplicates_skipped_total", nil)
+		return nil
+	}
+
 	s.logger.Info(ctx, "Starting assembly for paid order", map[string]interface{}{
 		"order_id":   paymentEvent.OrderId,
 		"user_id":    paymentEvent.UserId,
@@ -64,35 +113,99 @@ func (s *AssemblyService) HandlePaymentProcessed(ctx context.Context, paymentEve
 	})
 
 	// Record metrics
-	s.metrics.IncrementCounter("assembly_requests_total", map[string]string{
+	s.metrics.IncrementCounter(ctx, "assembly_requests_total", map[string]string{
 		"user_id": paymentEvent.UserId,
 	})
 
-	// Extract rocket components from payment metadata (in a real system, this might come from the order service)
-	components := s.generateRocketComponents(paymentEvent.OrderId)
+	// Build from the ordered bill of materials; events from publishers that
+	// don't send one yet get the standard rocket kit
+	components := componentsFromBOM(paymentEvent.OrderId, paymentEvent.Components)
+	if len(components) == 0 {
+		components = s.generateRocketComponents(paymentEvent.OrderId)
+	}
 
-	// Create new assembly
+	// Create new assembly; launch plans its stages up front so the estimate
+	// reflects the build
 	assembly := domain.NewAssembly(paymentEvent.OrderId, paymentEvent.UserId, components)
+	assembly.ShipmentID = paymentEvent.ShipmentId
 
-	// Store assembly in memory
-	s.mu.Lock()
-	s.activeAssemblies[assembly.ID] = assembly
-	s.mu.Unlock()
+	// Serialized units allocated at reservation confirmation are carried through to completion
+	for _, serial := range paymentEvent.SerialNumbers {
+		assembly.SerialNumbers = append(assembly.SerialNumbers, domain.SerialNumber{
+			SKU:    serial.Sku,
+			Serial: serial.SerialNumber,
+		})
+	}
+	if address := paymentEvent.ShippingAddress; address != nil {
+		assembly.ShippingAddress = &domain.DeliveryAddress{
+			RecipientName: address.RecipientName,
+			Line1:         address.Line1,
+			Line2:         address.Line2,
+			City:          address.City,
+			Region:        address.Region,
+			PostalCode:    address.PostalCode,
+			Country:       address.Country,
+			Phone:         address.Phone,
+		}
+	}
 
 	// Start assembly process asynchronously
-	go s.processAssembly(ctx, assembly)
+	s.launch(ctx, assembly)
 
-	s.metrics.IncrementCounter("assemblies_started_total", map[string]string{
+	s.metrics.IncrementCounter(ctx, "assemblies_started_total", map[string]string{
 		"user_id": paymentEvent.UserId,
 	})
 
 	return nil
 }
 
+// launch plans an assembly and runs it asynchronously. The order must be
+// claimed. The assembly outlives the message or request that started it, whose
+// context ends once the handler returns, but keeps its values for tracing.
+func (s *AssemblyService) launch(ctx context.Context, assembly *domain.Assembly) {
+	plan := s.simulator.Plan(assembly.OrderID, assembly.Components)
+	assembly.EstimatedDurationSeconds = int32(math.Ceil(plan.Duration().Seconds()))
+
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stopRun := context.AfterFunc(s.stopCtx, cancel)
+
+	// Store assembly in memory
+	s.mu.Lock()
+	s.activeAssemblies[assembly.ID] = assembly
+	s.runs[assembly.ID] = cancel
+	s.mu.Unlock()
+
+	s.inFlight.Add(1)
+	go func() {
+		defer s.inFlight.Done()
+		defer stopRun()
+		defer s.endRun(assembly.ID)
+		go s.renewClaim(runCtx, cancel, assembly)
+		s.processAssembly(runCtx, assembly, plan)
+	}()
+}
+
+// endRun cancels the context of a finished assembly and forgets its run
+func (s *AssemblyService) endRun(assemblyID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cancel, ok := s.runs[assemblyID]; ok {
+		cancel()
+	}
+	delete(s.runs, assemblyID)
+	delete(s.cancelled, assemblyID)
+}
+
 // processAssembly handles the actual assembly process
-func (s *AssemblyService) processAssembly(ctx context.Context, assembly *domain.Assembly) {
+func (s *AssemblyService) processAssembly(ctx context.Context, assembly *domain.Assembly, plan *simulation.Plan) {
 	// Acquire semaphore to limit concurrent assemblies
-	s.assemblySemaphore <- struct{}{}
+	select {
+	case s.assemblySemaphore <- struct{}{}:
+	case <-ctx.Done():
+		s.stopAssembly(ctx, assembly)
+		return
+	}
 	defer func() { <-s.assemblySemaphore }()
 
 	s.logger.Info(ctx, "Beginning rocket assembly process", map[string]interface{}{
@@ -100,6 +213,9 @@ func (s *AssemblyService) processAssembly(ctx context.Context, assembly *domain.
 		"order_id":    assembly.OrderID,
 		"user_id":     assembly.UserID,
 		"components":  len(assembly.Components),
+		"engines":     plan.Profile.EngineCount,
+		"payload_kg":  plan.Profile.PayloadMassKg,
+		"stages":      len(plan.Stages),
 	})
 
 	// Start the assembly
@@ -118,17 +234,26 @@ func (s *AssemblyService) processAssembly(ctx context.Context, assembly *domain.
 		})
 	}
 
-	// Simulate assembly process with configurable duration
-	s.simulateAssemblyWork(ctx, assembly)
+	// Work through the planned stages; the plan decides whether and where
+	// the assembly fails
+	s.runStages(ctx, assembly, plan)
+
+	// An assembly interrupted by shutdown or a lost claim is neither completed
+	// nor failed; the claim is given up so another replica can redo it
+	if ctx.Err() != nil {
+		s.stopAssembly(ctx, assembly)
+		return
+	}
+	defer s.finishClaim(ctx, assembly)
 
-	// Check if assembly should fail (simulate random failures)
-	if s.shouldSimulateFailure() {
-		s.handleAssemblyFailure(ctx, assembly)
+	if plan.Failure != nil {
+		s.handleAssemblyFailure(ctx, assembly, *plan.Failure)
 		return
 	}
 
 	// Complete the assembly
 	assembly.Complete()
+	s.uploadArtifacts(ctx, assembly)
 
 	// Update assembly in storage
 	s.mu.Lock()
@@ -143,6 +268,14 @@ func (s *AssemblyService) processAssembly(ctx context.Context, assembly *domain.
 		})
 	}
 
+	// Inventory books the parts built in out of the order's confirmed stock
+	if err := s.producer.PublishAssemblyPartsConsumed(ctx, assembly); err != nil {
+		s.logger.Error(ctx, "Failed to publish assembly parts consumed event", err, map[string]interface{}{
+			"assembly_id": assembly.ID,
+			"order_id":    assembly.OrderID,
+		})
+	}
+
 	s.logger.Info(ctx, "Rocket assembly completed successfully", map[string]interface{}{
 		"assembly_id":       assembly.ID,
 		"order_id":          assembly.OrderID,
@@ -152,71 +285,198 @@ func (s *AssemblyService) processAssembly(ctx context.Context, assembly *domain.
 		"estimated_seconds": assembly.EstimatedDurationSeconds,
 	})
 
-	s.metrics.IncrementCounter("assemblies_completed_total", map[string]string{
+	s.metrics.IncrementCounter(ctx, "assemblies_completed_total", map[string]string{
 		"user_id": assembly.UserID,
 		"quality": assembly.Quality.String(),
 	})
 
-	s.metrics.RecordValue("assembly_duration_seconds", float64(assembly.ActualDurationSeconds), map[string]string{
+	s.metrics.RecordValue(ctx, "assembly_duration_seconds", float64(assembly.ActualDurationSeconds), map[string]string{
 		"user_id": assembly.UserID,
 		"quality": assembly.Quality.String(),
 	})
 }
 
-// simulateAssemblyWork simulates the rocket assembly process
-func (s *AssemblyService) simulateAssemblyWork(ctx context.Context, assembly *domain.Assembly) {
-	duration := s.config.SimulationDuration
+// renewClaim keeps the order claimed while the assembly runs and cancels the
+// assembly if another replica has taken the order over
+func (s *AssemblyService) renewClaim(ctx context.Context, cancel context.CancelFunc, assembly *domain.Assembly) {
+	ticker := time.NewTicker(s.coordination.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			held, err := s.claims.Renew(ctx, assembly.ClaimKey(), s.coordination.InstanceID)
+			if err != nil {
+				// The claim outlives a few missed renewals; keep assembling
+				s.logger.Warn(ctx, "Failed to renew order claim", map[string]interface{}{
+					"assembly_id": assembly.ID,
+					"order_id":    assembly.OrderID,
+					"error":       err.Error(),
+				})
+				continue
+			}
+			if !held && ctx.Err() == nil {
+				s.logger.Warn(ctx, "Order claim lost, cancelling assembly", map[string]interface{}{
+					"assembly_id": assembly.ID,
+					"order_id":    assembly.OrderID,
+					"instance_id": s.coordination.InstanceID,
+				})
+				s.metrics.IncrementCounter(ctx, "assembly_claims_lost_total", nil)
+				cancel()
+				return
+			}
+		}
+	}
+}
 
-	s.logger.Debug(ctx, "Simulating assembly work", map[string]interface{}{
-		"assembly_id":      assembly.ID,
-		"duration_seconds": duration.Seconds(),
-		"components":       len(assembly.Components),
-	})
+// finishClaim records the order as assembled so redelivered payment events are dropped
+func (s *AssemblyService) finishClaim(ctx context.Context, assembly *domain.Assembly) {
+	if err := s.claims.Finish(ctx, assembly.ClaimKey(), s.coordination.InstanceID); err != nil {
+		s.logger.Error(ctx, "Failed to finish order claim", err, map[string]interface{}{
+			"assembly_id": assembly.ID,
+			"order_id":    assembly.OrderID,
+		})
+	}
+}
 
-	// Add some variability to the assembly time (±20%)
-	variability := time.Duration(float64(duration) * 0.2 * (rand.Float64() - 0.5) * 2)
-	actualDuration := duration + variability
+// interruptAssembly drops an assembly that was cancelled before it finished and
+// releases its claim. No outcome is published for it.
+func (s *AssemblyService) interruptAssembly(assembly *domain.Assembly) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	// Simulate work by sleeping
-	select {
-	case <-time.After(actualDuration):
-		// Assembly completed normally
-		s.logger.Debug(ctx, "Assembly simulation completed", map[string]interface{}{
-			"assembly_id":        assembly.ID,
-			"actual_duration":    actualDuration.Seconds(),
-			"estimated_duration": duration.Seconds(),
-		})
-	case <-ctx.Done():
-		// Context was cancelled
-		s.logger.Warn(ctx, "Assembly cancelled due to context cancellation", map[string]interface{}{
+	s.mu.Lock()
+	delete(s.activeAssemblies, assembly.ID)
+	s.mu.Unlock()
+
+	if err := s.claims.Release(ctx, assembly.ClaimKey(), s.coordination.InstanceID); err != nil {
+		s.logger.Error(ctx, "Failed to release order claim", err, map[string]interface{}{
 			"assembly_id": assembly.ID,
+			"order_id":    assembly.OrderID,
 		})
-		return
 	}
+
+	s.logger.Warn(ctx, "Rocket assembly interrupted, order released for another replica", map[string]interface{}{
+		"assembly_id": assembly.ID,
+		"order_id":    assembly.OrderID,
+		"instance_id": s.coordination.InstanceID,
+	})
+
+	s.metrics.IncrementCounter(ctx, "assemblies_interrupted_total", nil)
 }
 
-// handleAssemblyFailure handles assembly failures
-func (s *AssemblyService) handleAssemblyFailure(ctx context.Context, assembly *domain.Assembly) {
-	// Determine failure reason
-	failureReasons := []string{
-		"component_malfunction",
-		"quality_check_failed",
-		"insufficient_materials",
-		"calibration_error",
-		"safety_protocol_violation",
+// Drain waits for the running assemblies to finish. Those still running when ctx
+// ends are cancelled and their orders released, so they can be assembled again
+// when their payment events are replayed.
+func (s *AssemblyService) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
 	}
 
-	failureCodes := []string{
-		"ASM_001",
-		"ASM_002",
-		"ASM_003",
-		"ASM_004",
-		"ASM_005",
+	s.logger.Warn(ctx, "Cancelling assemblies still running at shutdown", map[string]interface{}{
+		"in_flight": len(s.assemblySemaphore),
+	})
+	s.stop()
+	<-done
+	return nil
+}
+
+// runStages simulates the planned assembly stages in order and records
+// their timings on the assembly
+func (s *AssemblyService) runStages(ctx context.Context, assembly *domain.Assembly, plan *simulation.Plan) {
+	cancelled := false
+	for _, stage := range plan.Stages {
+		queued := time.Now()
+
+		// The stage waits for the floor resources it needs; once the context
+		// is cancelled the remaining stages are not waited for
+		release := func() {}
+		if !cancelled {
+			var err error
+			if release, err = s.resources.Acquire(ctx, stage.Stage); err != nil {
+				release = func() {}
+				cancelled = true
+				s.logger.Warn(ctx, "Assembly cancelled while waiting for resources", map[string]interface{}{
+					"assembly_id": assembly.ID,
+					"stage":       stage.Stage,
+				})
+			}
+		}
+		started := time.Now()
+
+		if !cancelled {
+			select {
+			case <-time.After(stage.Duration):
+			case <-ctx.Done():
+				cancelled = true
+				s.logger.Warn(ctx, "Assembly cancelled due to context cancellation", map[string]interface{}{
+					"assembly_id": assembly.ID,
+					"stage":       stage.Stage,
+				})
+			}
+		}
+		release()
+
+		timing := domain.StageTiming{
+			Stage:           string(stage.Stage),
+			PlannedDuration: stage.Duration,
+			QueuedDuration:  started.Sub(queued),
+			ActualDuration:  time.Since(started),
+			Failed:          stage.Stage == plan.FailedStage,
+		}
+
+		s.mu.Lock()
+		assembly.RecordStage(timing)
+		s.mu.Unlock()
+
+		s.logger.Debug(ctx, "Assembly stage finished", map[string]interface{}{
+			"assembly_id":     assembly.ID,
+			"stage":           timing.Stage,
+			"planned_seconds": timing.PlannedDuration.Seconds(),
+			"queued_seconds":  timing.QueuedDuration.Seconds(),
+			"actual_seconds":  timing.ActualDuration.Seconds(),
+			"failed":          timing.Failed,
+			"failure_chance":  stage.FailureProbability,
+		})
+
+		s.metrics.RecordValue(ctx, "assembly_stage_duration_seconds", timing.ActualDuration.Seconds(), map[string]string{
+			"stage":  timing.Stage,
+			"failed": strconv.FormatBool(timing.Failed),
+		})
+		s.metrics.RecordValue(ctx, "assembly_stage_queue_seconds", timing.QueuedDuration.Seconds(), map[string]string{
+			"stage": timing.Stage,
+		})
+
+		if timing.Failed {
+			return
+		}
 	}
+}
+
+// recordResourceUsage exports the queue and utilization of a floor resource
+func (s *AssemblyService) recordResourceUsage(usage simulation.ResourceUsage) {
+	ctx := context.Background()
+	labels := map[string]string{"resource": usage.Name}
+	s.metrics.SetGauge(ctx, "assembly_resource_capacity", float64(usage.Capacity), labels)
+	s.metrics.SetGauge(ctx, "assembly_resource_in_use", float64(usage.InUse), labels)
+	s.metrics.SetGauge(ctx, "assembly_resource_queue_length", float64(usage.Queued), labels)
+	s.metrics.SetGauge(ctx, "assembly_resource_utilization", usage.Utilization, labels)
+}
 
-	index := rand.Intn(len(failureReasons))
-	reason := failureReasons[index]
-	code := failureCodes[index]
+// handleAssemblyFailure handles assembly failures
+func (s *AssemblyService) handleAssemblyFailure(ctx context.Context, assembly *domain.Assembly, failure simulation.FailureMode) {
+	reason := failure.Reason
+	code := failure.Code
 
 	assembly.Fail(reason, code)
 
@@ -239,20 +499,17 @@ func (s *AssemblyService) handleAssemblyFailure(ctx context.Context, assembly *d
 		"user_id":        assembly.UserID,
 		"failure_reason": reason,
 		"error_code":     code,
+		"failed_stage":   assembly.FailedStage,
 	})
 
-	s.metrics.IncrementCounter("assemblies_failed_total", map[string]string{
+	s.metrics.IncrementCounter(ctx, "assemblies_failed_total", map[string]string{
 		"user_id":        assembly.UserID,
 		"failure_reason": reason,
 		"error_code":     code,
+		"stage":          assembly.FailedStage,
 	})
 }
 
-// shouldSimulateFailure determines if an assembly should fail based on configured failure rate
-func (s *AssemblyService) shouldSimulateFailure() bool {
-	return rand.Float64() < s.config.FailureRate
-}
-
 // generateRocketComponents generates realistic rocket components for an order
 func (s *AssemblyService) generateRocketComponents(orderID string) []domain.RocketComponent {
 	// In a real system, this would fetch components from the order service or inventory
@@ -307,16 +564,62 @@ func (s *AssemblyService) generateRocketComponents(orderID string) []domain.Rock
 	}
 
 	// Add some randomness to component materials for quality calculation
+	rng := s.simulator.Rand("components:" + orderID)
 	materials := []string{"aluminum", "carbon_fiber", "titanium", "steel"}
 	for i := range components {
-		if rand.Float64() < 0.3 { // 30% chance to upgrade material
-			components[i].Material = materials[rand.Intn(len(materials))]
+		components[i].Quantity = 1
+		if rng.Float64() < 0.3 { // 30% chance to upgrade material
+			components[i].Material = materials[rng.Intn(len(materials))]
 		}
 	}
 
 	return components
 }
 
+// componentTypes maps ordered component types to assembly component types
+var componentTypes = map[events.ComponentType]string{
+	events.ComponentType_COMPONENT_TYPE_ENGINE:          simulation.ComponentTypeEngine,
+	events.ComponentType_COMPONENT_TYPE_FUEL_TANK:       "tank",
+	events.ComponentType_COMPONENT_TYPE_GUIDANCE_SYSTEM: "electronics",
+	events.ComponentType_COMPONENT_TYPE_PAYLOAD_BAY:     simulation.ComponentTypePayload,
+	events.ComponentType_COMPONENT_TYPE_HEAT_SHIELD:     "heat_shield",
+	events.ComponentType_COMPONENT_TYPE_LANDING_GEAR:    "landing_gear",
+	events.ComponentType_COMPONENT_TYPE_COMMUNICATION:   "communication",
+	events.ComponentType_COMPONENT_TYPE_STRUCTURAL:      "structure",
+}
+
+// componentsFromBOM converts the ordered bill of materials into assembly
+// components. Mass is read from the "mass_kg" specification.
+func componentsFromBOM(orderID string, bom []*events.RocketComponent) []domain.RocketComponent {
+	components := make([]domain.RocketComponent, 0, len(bom))
+	for _, item := range bom {
+		componentType, ok := componentTypes[item.Type]
+		if !ok {
+			componentType = "other"
+		}
+
+		// Ordered components are identified by the SKU of the inventory item
+		component := domain.RocketComponent{
+			ID:          item.ComponentId,
+			SKU:         item.ComponentId,
+			Name:        item.ComponentName,
+			Type:        componentType,
+			Dimensions:  item.Specifications["dimensions"],
+			Material:    item.Specifications["material"],
+			Criticality: item.Specifications["criticality"],
+			Quantity:    item.Quantity,
+		}
+		if component.ID == "" {
+			component.ID = fmt.Sprintf("%s-%s", componentType, orderID)
+		}
+		if massKg, err := strconv.ParseFloat(item.Specifications["mass_kg"], 64); err == nil && massKg > 0 {
+			component.Weight = int32(massKg * 1000)
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
 // GetAssembly retrieves an assembly by ID
 func (s *AssemblyService) GetAssembly(ctx context.Context, assemblyID string) (*domain.Assembly, error) {
 	s.mu.RLock()
@@ -324,7 +627,7 @@ func (s *AssemblyService) GetAssembly(ctx context.Context, assemblyID string) (*
 
 	assembly, exists := s.activeAssemblies[assemblyID]
 	if !exists {
-		return nil, fmt.Errorf("assembly not found: %s", assemblyID)
+		return nil, fmt.Errorf("%w: %s", ErrAssemblyNotFound, assemblyID)
 	}
 
 	return assembly, nil
@@ -362,12 +665,15 @@ func (s *AssemblyService) GetStats(ctx context.Context) map[string]interface{} {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	simulationDuration, simulation := s.simulator.Tuning()
 	stats := map[string]interface{}{
 		"active_assemblies":      len(s.activeAssemblies),
 		"max_concurrent":         s.config.MaxConcurrentAssemblies,
 		"current_semaphore_load": len(s.assemblySemaphore),
-		"simulation_duration":    s.config.SimulationDuration.String(),
-		"failure_rate":           s.config.FailureRate,
+		"simulation_duration":    simulationDuration.String(),
+		"simulation":             simulation,
+		"resources":              s.resources.Usage(),
+		"instance_id":            s.coordination.InstanceID,
 	}
 
 	// Count assemblies by status
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/assembly-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/assembly-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/platform/objectstore"
+)
+
+// ArtifactFilesPath is the health server path signed completion reports are served under
+const ArtifactFilesPath = "/artifacts/files/"
+
+// ArtifactUploader stores the artifacts of a completed assembly and returns the
+// URL consumers of the assembly completed event fetch them from
+type ArtifactUploader interface {
+	UploadCompletionArtifacts(ctx context.Context, assembly *domain.Assembly) (string, error)
+}
+
+// CompletionReport is the report of a completed assembly stored as its artifact
+type CompletionReport struct {
+	AssemblyID            string                   `json:"assembly_id"`
+	OrderID               string                   `json:"order_id"`
+	ShipmentID            string                   `json:"shipment_id,omitempty"`
+	UserID                string                   `json:"user_id"`
+	Quality               string                   `json:"quality"`
+	Components            []domain.RocketComponent `json:"components"`
+	SerialNumbers         []domain.SerialNumber    `json:"serial_numbers,omitempty"`
+	Stages                []domain.StageTiming     `json:"stages"`
+	ActualDurationSeconds int32                    `json:"actual_duration_seconds"`
+	StartedAt             *time.Time               `json:"started_at,omitempty"`
+	CompletedAt           *time.Time               `json:"completed_at,omitempty"`
+}
+
+// NewCompletionReport builds the report of a completed assembly
+func NewCompletionReport(assembly *domain.Assembly) *CompletionReport {
+	return &CompletionReport{
+		AssemblyID:            assembly.ID,
+		OrderID:               assembly.OrderID,
+		ShipmentID:            assembly.ShipmentID,
+		UserID:                assembly.UserID,
+		Quality:               assembly.Quality.String(),
+		Components:            assembly.Components,
+		SerialNumbers:         assembly.SerialNumbers,
+		Stages:                assembly.Stages,
+		ActualDurationSeconds: assembly.ActualDurationSeconds,
+		StartedAt:             assembly.StartedAt,
+		CompletedAt:           assembly.CompletedAt,
+	}
+}
+
+// ObjectStoreArtifacts stores completion reports in object storage and hands
+// them out through signed URLs served by the health server
+type ObjectStoreArtifacts struct {
+	config config.ArtifactsConfig
+	store  objectstore.Store
+	signer *objectstore.Signer
+}
+
+// NewObjectStoreArtifacts creates an uploader storing reports in the store
+func NewObjectStoreArtifacts(cfg config.ArtifactsConfig, store objectstore.Store, signer *objectstore.Signer) *ObjectStoreArtifacts {
+	return &ObjectStoreArtifacts{
+		config: cfg,
+		store:  store,
+		signer: signer,
+	}
+}
+
+// UploadCompletionArtifacts stores the assembly's completion report, replacing
+// the report of an earlier run of the same assembly, and returns its signed URL
+func (a *ObjectStoreArtifacts) UploadCompletionArtifacts(ctx context.Context, assembly *domain.Assembly) (string, error) {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(NewCompletionReport(assembly)); err != nil {
+		return "", fmt.Errorf("failed to render completion report: %w", err)
+	}
+
+	object, err := a.store.Put(ctx, artifactKey(assembly), &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to store completion report: %w", err)
+	}
+	return a.signer.SignURL(a.downloadBaseURL(), object.Key, a.config.URLExpiry).URL, nil
+}
+
+// DownloadHandler serves the completion reports to holders of a signed URL; it
+// is mounted at ArtifactFilesPath
+func (a *ObjectStoreArtifacts) DownloadHandler() http.Handler {
+	return objectstore.Handler(a.store, a.signer, ArtifactFilesPath)
+}
+
+// downloadBaseURL is the public URL reports are served under; a relative path
+// when no public URL is configured
+func (a *ObjectStoreArtifacts) downloadBaseURL() string {
+	return strings.TrimSuffix(a.config.PublicURL, "/") + ArtifactFilesPath
+}
+
+// Offload stores the payload of an event too large for Kafka next to the reports
+// and returns its signed URL, so the artifact store doubles as the producer's
+// overflow store
+func (a *ObjectStoreArtifacts) Offload(ctx context.Context, topic string, payload []byte) (string, error) {
+	key := "kafka-overflow/" + topic + "/" + uuid.New().String()
+	object, err := a.store.Put(ctx, key, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to store event payload: %w", err)
+	}
+	return a.signer.SignURL(a.downloadBaseURL(), object.Key, a.config.URLExpiry).URL, nil
+}
+
+// artifactKey is the object key of a completion report, e.g.
+// "assemblies/<order_id>/completion-report.json", with the shipment ID as a
+// further segment for one shipment of a partially fulfilled order
+func artifactKey(assembly *domain.Assembly) string {
+	return "assemblies/" + assembly.ClaimKey() + "/completion-report.json"
+}
+
+// SetArtifactUploader enables uploading completion artifacts; without one the
+// assembly completed event carries no artifact URL
+func (s *AssemblyService) SetArtifactUploader(uploader ArtifactUploader) {
+	s.artifacts = uploader
+}
+
+// uploadArtifacts uploads the artifacts of a completed assembly and records their
+// URL on it. Failures are logged only: the event is published without the URL.
+func (s *AssemblyService) uploadArtifacts(ctx context.Context, assembly *domain.Assembly) {
+	if s.artifacts == nil {
+		return
+	}
+
+	url, err := s.artifacts.UploadCompletionArtifacts(ctx, assembly)
+	if err != nil {
+		s.metrics.IncrementCounter(ctx, "assembly_artifact_uploads_total", map[string]string{
+			"outcome": "failed",
+		})
+		s.logger.Error(ctx, "Failed to upload assembly completion artifacts", err, map[string]interface{}{
+			"assembly_id": assembly.ID,
+			"order_id":    assembly.OrderID,
+		})
+		return
+	}
+
+	assembly.ArtifactURL = url
+	s.metrics.IncrementCounter(ctx, "assembly_artifact_uploads_total", map[string]string{
+		"outcome": "uploaded",
+	})
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// OrderClaims makes sure only one replica assembles an order. A replica claims the
+// order before assembling it and renews the claim while it runs; a claim that is
+// not renewed expires, so an order held by a crashed replica can be assembled
+// again. Finished orders stay claimed for a retention period so redelivered
+// payment events are dropped.
+type OrderClaims interface {
+	// Claim claims the order for the owner and reports whether it was free
+	Claim(ctx context.Context, orderID, owner string) (bool, error)
+	// Renew extends the owner's claim and reports whether the owner still holds it
+	Renew(ctx context.Context, orderID, owner string) (bool, error)
+	// Finish marks the owner's claimed order as assembled
+	Finish(ctx context.Context, orderID, owner string) error
+	// Release gives up the owner's claim so the order can be assembled again
+	Release(ctx context.Context, orderID, owner string) error
+	// Reopen claims an assembled (or unclaimed) order for the owner again, for an
+	// operator retrying a failed assembly. It reports false while the order is
+	// being assembled.
+	Reopen(ctx context.Context, orderID, owner string) (bool, error)
+}
+
+// Claim values: "running:<owner>" while assembling, "done:<owner>" afterwards
+const (
+	claimRunning = "running:"
+	claimDone    = "done:"
+)
+
+// ownerScript changes a claim only while the owner still holds it running.
+// KEYS[1] claim key; ARGV[1] running value; ARGV[2] new value ("" deletes);
+// ARGV[3] TTL in milliseconds. Returns 1 if the claim was changed.
+var ownerScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if ARGV[2] == "" then
+	redis.call("DEL", KEYS[1])
+else
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+end
+return 1
+`)
+
+// reopenScript claims an order unless it is being assembled.
+// KEYS[1] claim key; ARGV[1] running value; ARGV[2] TTL in milliseconds.
+// Returns 1 if the order was claimed.
+var reopenScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current and string.find(current, "running:", 1, true) == 1 then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return 1
+`)
+
+// RedisOrderClaims is an OrderClaims backed by Redis, shared by all replicas
+type RedisOrderClaims struct {
+	client    *redis.Client
+	keyPrefix string
+	ttl       time.Duration
+	retention time.Duration
+}
+
+// NewRedisOrderClaims creates Redis backed order claims
+func NewRedisOrderClaims(client *redis.Client, keyPrefix string, ttl, retention time.Duration) *RedisOrderClaims {
+	return &RedisOrderClaims{
+		client:    client,
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
+		retention: retention,
+	}
+}
+
+// Claim implements OrderClaims
+func (c *RedisOrderClaims) Claim(ctx context.Context, orderID, owner string) (bool, error) {
+	claimed, err := c.client.SetNX(ctx, c.keyPrefix+orderID, claimRunning+owner, c.ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim order: %w", err)
+	}
+	return claimed, nil
+}
+
+// Renew implements OrderClaims
+func (c *RedisOrderClaims) Renew(ctx context.Context, orderID, owner string) (bool, error) {
+	return c.update(ctx, orderID, owner, claimRunning+owner, c.ttl)
+}
+
+// Finish implements OrderClaims
+func (c *RedisOrderClaims) Finish(ctx context.Context, orderID, owner string) error {
+	_, err := c.update(ctx, orderID, owner, claimDone+owner, c.retention)
+	return err
+}
+
+// Release implements OrderClaims
+func (c *RedisOrderClaims) Release(ctx context.Context, orderID, owner string) error {
+	_, err := c.update(ctx, orderID, owner, "", 0)
+	return err
+}
+
+// Reopen implements OrderClaims
+func (c *RedisOrderClaims) Reopen(ctx context.Context, orderID, owner string) (bool, error) {
+	claimed, err := reopenScript.Run(ctx, c.client, []string{c.keyPrefix + orderID},
+		claimRunning+owner, c.ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to reopen order claim: %w", err)
+	}
+	return claimed == 1, nil
+}
+
+func (c *RedisOrderClaims) update(ctx context.Context, orderID, owner, value string, ttl time.Duration) (bool, error) {
+	changed, err := ownerScript.Run(ctx, c.client, []string{c.keyPrefix + orderID},
+		claimRunning+owner, value, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to update order claim: %w", err)
+	}
+	return changed == 1, nil
+}
+
+// MemoryOrderClaims is an in-process OrderClaims used when Redis is unavailable.
+// It only prevents duplicate assemblies within one replica.
+type MemoryOrderClaims struct {
+	mu        sync.Mutex
+	claims    map[string]memoryClaim
+	ttl       time.Duration
+	retention time.Duration
+}
+
+type memoryClaim struct {
+	value     string
+	expiresAt time.Time
+}
+
+// NewMemoryOrderClaims creates in-memory order claims
+func NewMemoryOrderClaims(ttl, retention time.Duration) *MemoryOrderClaims {
+	return &MemoryOrderClaims{
+		claims:    make(map[string]memoryClaim),
+		ttl:       ttl,
+		retention: retention,
+	}
+}
+
+// Claim implements OrderClaims
+func (c *MemoryOrderClaims) Claim(ctx context.Context, orderID, owner string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if claim, exists := c.claims[orderID]; exists && now.Before(claim.expiresAt) {
+		return false, nil
+	}
+
+	// Opportunistically drop expired claims to bound memory usage
+	for id, claim := range c.claims {
+		if !now.Before(claim.expiresAt) {
+			delete(c.claims, id)
+		}
+	}
+
+	c.claims[orderID] = memoryClaim{value: claimRunning + owner, expiresAt: now.Add(c.ttl)}
+	return true, nil
+}
+
+// Renew implements OrderClaims
+func (c *MemoryOrderClaims) Renew(ctx context.Context, orderID, owner string) (bool, error) {
+	return c.update(orderID, owner, claimRunning+owner, c.ttl), nil
+}
+
+// Finish implements OrderClaims
+func (c *MemoryOrderClaims) Finish(ctx context.Context, orderID, owner string) error {
+	c.update(orderID, owner, claimDone+owner, c.retention)
+	return nil
+}
+
+// Release implements OrderClaims
+func (c *MemoryOrderClaims) Release(ctx context.Context, orderID, owner string) error {
+	c.update(orderID, owner, "", 0)
+	return nil
+}
+
+// Reopen implements OrderClaims
+func (c *MemoryOrderClaims) Reopen(ctx context.Context, orderID, owner string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	claim, exists := c.claims[orderID]
+	if exists && now.Before(claim.expiresAt) && strings.HasPrefix(claim.value, claimRunning) {
+		return false, nil
+	}
+	c.claims[orderID] = memoryClaim{value: claimRunning + owner, expiresAt: now.Add(c.ttl)}
+	return true, nil
+}
+
+func (c *MemoryOrderClaims) update(orderID, owner, value string, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	claim, exists := c.claims[orderID]
+	if !exists || claim.value != claimRunning+owner || !time.Now().Before(claim.expiresAt) {
+		return false
+	}
+	if value == "" {
+		delete(c.claims, orderID)
+		return true
+	}
+	c.claims[orderID] = memoryClaim{value: value, expiresAt: time.Now().Add(ttl)}
+	return true
+}
@@ -0,0 +1,158 @@
+package simulation
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/assembly-service/internal/config"
+)
+
+// ResourceUsage is a snapshot of one assembly floor resource
+type ResourceUsage struct {
+	Name         string  `json:"name"`
+	Capacity     int     `json:"capacity"`
+	InUse        int     `json:"in_use"`
+	Queued       int     `json:"queued"`      // Stages waiting for a unit
+	Utilization  float64 `json:"utilization"` // Share of units in use, 0.0 to 1.0
+	Acquisitions int64   `json:"acquisitions"`
+	// Time spent waiting for the resource, averaged over its acquisitions
+	AverageWaitSeconds float64 `json:"average_wait_seconds"`
+}
+
+// resource is a counting semaphore over the units of one resource
+type resource struct {
+	name     string
+	units    chan struct{}
+	observer func(ResourceUsage)
+
+	mu           sync.Mutex
+	queued       int
+	acquisitions int64
+	totalWait    time.Duration
+}
+
+func (r *resource) acquire(ctx context.Context) error {
+	started := time.Now()
+	r.update(func() { r.queued++ })
+
+	var err error
+	select {
+	case r.units <- struct{}{}:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	r.update(func() {
+		r.queued--
+		if err == nil {
+			r.acquisitions++
+			r.totalWait += time.Since(started)
+		}
+	})
+	return err
+}
+
+func (r *resource) release() {
+	<-r.units
+	r.update(func() {})
+}
+
+// update changes the counters of the resource and reports its usage to the observer
+func (r *resource) update(change func()) {
+	r.mu.Lock()
+	change()
+	usage := r.usageLocked()
+	r.mu.Unlock()
+
+	if r.observer != nil {
+		r.observer(usage)
+	}
+}
+
+func (r *resource) usage() ResourceUsage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.usageLocked()
+}
+
+func (r *resource) usageLocked() ResourceUsage {
+	usage := ResourceUsage{
+		Name:         r.name,
+		Capacity:     cap(r.units),
+		InUse:        len(r.units),
+		Queued:       r.queued,
+		Acquisitions: r.acquisitions,
+	}
+	usage.Utilization = float64(usage.InUse) / float64(usage.Capacity)
+	if r.acquisitions > 0 {
+		usage.AverageWaitSeconds = r.totalWait.Seconds() / float64(r.acquisitions)
+	}
+	return usage
+}
+
+// ResourcePool holds the constrained equipment of the assembly floor. Stages
+// acquire the resources they need before they run and release them after.
+type ResourcePool struct {
+	resources []*resource           // Sorted by name
+	stages    map[Stage][]*resource // Sorted by name, so stages can't deadlock each other
+}
+
+// NewResourcePool creates the resources of the configuration. Stages without
+// resources run unconstrained. The observer, if any, is called with the usage
+// of a resource whenever it changes.
+func NewResourcePool(cfg config.ResourcesConfig, observer func(ResourceUsage)) *ResourcePool {
+	pool := &ResourcePool{stages: make(map[Stage][]*resource)}
+
+	byName := make(map[string]*resource, len(cfg.Capacity))
+	for name, capacity := range cfg.Capacity {
+		r := &resource{name: name, units: make(chan struct{}, capacity), observer: observer}
+		byName[name] = r
+		pool.resources = append(pool.resources, r)
+	}
+	sort.Slice(pool.resources, func(i, j int) bool { return pool.resources[i].name < pool.resources[j].name })
+
+	for stage, names := range cfg.Stages {
+		seen := make(map[string]bool, len(names))
+		for _, name := range names {
+			if r, ok := byName[name]; ok && !seen[name] {
+				seen[name] = true
+				pool.stages[Stage(stage)] = append(pool.stages[Stage(stage)], r)
+			}
+		}
+		needed := pool.stages[Stage(stage)]
+		sort.Slice(needed, func(i, j int) bool { return needed[i].name < needed[j].name })
+	}
+
+	return pool
+}
+
+// Acquire waits for a unit of every resource the stage needs and returns the
+// function releasing them. If ctx ends first the units already taken are
+// released and its error returned.
+func (p *ResourcePool) Acquire(ctx context.Context, stage Stage) (release func(), err error) {
+	needed := p.stages[stage]
+	for i, r := range needed {
+		if err := r.acquire(ctx); err != nil {
+			releaseAll(needed[:i])
+			return nil, err
+		}
+	}
+	return func() { releaseAll(needed) }, nil
+}
+
+func releaseAll(resources []*resource) {
+	for _, r := range resources {
+		r.release()
+	}
+}
+
+// Usage returns a snapshot of every resource, sorted by name
+func (p *ResourcePool) Usage() []ResourceUsage {
+	usage := make([]ResourceUsage, 0, len(p.resources))
+	for _, r := range p.resources {
+		usage = append(usage, r.usage())
+	}
+	return usage
+}
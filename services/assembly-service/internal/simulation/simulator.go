@@ -0,0 +1,249 @@
+package simulation
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/assembly-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/assembly-service/internal/domain"
+)
+
+// Stage names an assembly stage
+type Stage string
+
+const (
+	StageKitting             Stage = "kitting"
+	StageEngineInstallation  Stage = "engine_installation"
+	StageAvionicsCalibration Stage = "avionics_calibration"
+	StagePayloadIntegration  Stage = "payload_integration"
+	StageFinalInspection     Stage = "final_inspection"
+)
+
+// Component types that drive the simulation
+const (
+	ComponentTypeEngine  = "engine"
+	ComponentTypePayload = "payload"
+)
+
+// FailureMode is how an assembly fails in a given stage
+type FailureMode struct {
+	Reason string
+	Code   string
+}
+
+// stageFailureModes maps each stage to the failure it can produce
+var stageFailureModes = map[Stage]FailureMode{
+	StageKitting:             {Reason: "insufficient_materials", Code: "ASM_003"},
+	StageEngineInstallation:  {Reason: "component_malfunction", Code: "ASM_001"},
+	StageAvionicsCalibration: {Reason: "calibration_error", Code: "ASM_004"},
+	StagePayloadIntegration:  {Reason: "safety_protocol_violation", Code: "ASM_005"},
+	StageFinalInspection:     {Reason: "quality_check_failed", Code: "ASM_002"},
+}
+
+// Profile summarizes the bill of materials as seen by the simulation
+type Profile struct {
+	ComponentCount int     `json:"component_count"`
+	EngineCount    int     `json:"engine_count"`
+	PayloadMassKg  float64 `json:"payload_mass_kg"`
+	TotalMassKg    float64 `json:"total_mass_kg"`
+}
+
+// standardProfile is the build SimulationDuration is calibrated against
+var standardProfile = Profile{ComponentCount: 5, EngineCount: 1}
+
+// ProfileFor derives the simulation profile from assembly components
+func ProfileFor(components []domain.RocketComponent) Profile {
+	var profile Profile
+	for _, component := range components {
+		quantity := int(component.Quantity)
+		if quantity <= 0 {
+			quantity = 1
+		}
+		massKg := float64(component.Weight) / 1000 * float64(quantity)
+
+		profile.ComponentCount += quantity
+		profile.TotalMassKg += massKg
+		switch component.Type {
+		case ComponentTypeEngine:
+			profile.EngineCount += quantity
+		case ComponentTypePayload:
+			profile.PayloadMassKg += massKg
+		}
+	}
+	return profile
+}
+
+// StagePlan is one planned stage of an assembly
+type StagePlan struct {
+	Stage              Stage         `json:"stage"`
+	Duration           time.Duration `json:"duration"`
+	FailureProbability float64       `json:"failure_probability"`
+}
+
+// Plan is the simulated course of one assembly. If Failure is set the
+// assembly fails at the end of FailedStage and the stages after it don't run.
+type Plan struct {
+	Profile     Profile      `json:"profile"`
+	Stages      []StagePlan  `json:"stages"`
+	FailedStage Stage        `json:"failed_stage,omitempty"`
+	Failure     *FailureMode `json:"failure,omitempty"`
+}
+
+// Duration returns the planned duration of all stages, as estimated before
+// the assembly starts
+func (p *Plan) Duration() time.Duration {
+	var total time.Duration
+	for _, stage := range p.Stages {
+		total += stage.Duration
+	}
+	return total
+}
+
+// Simulator plans assemblies from their bill of materials. Its tuning can be
+// changed at runtime; assemblies already planned keep their plan.
+type Simulator struct {
+	mu           sync.RWMutex
+	baseDuration time.Duration
+	config       config.SimulationConfig
+}
+
+// NewSimulator creates a new simulator
+func NewSimulator(cfg config.AssemblyConfig) *Simulator {
+	return &Simulator{
+		baseDuration: cfg.SimulationDuration,
+		config:       cfg.Simulation,
+	}
+}
+
+// Plan derives stage durations and the outcome of an assembly. With a
+// configured seed the plan depends only on the seed, the order and its
+// components, so concurrent assemblies stay reproducible.
+func (s *Simulator) Plan(orderID string, components []domain.RocketComponent) *Plan {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rng := s.rand(orderID)
+	profile := ProfileFor(components)
+	unit := float64(s.baseDuration) / totalWork(standardProfile)
+
+	plan := &Plan{Profile: profile}
+	for _, stage := range stagesFor(profile) {
+		jitter := 1 + s.config.Jitter*(rng.Float64()*2-1)
+		plan.Stages = append(plan.Stages, StagePlan{
+			Stage:              stage,
+			Duration:           time.Duration(stageWork(stage, profile) * unit * jitter),
+			FailureProbability: s.failureProbability(stage, profile),
+		})
+	}
+
+	// Every stage is rolled so the number of draws doesn't depend on the
+	// outcome; the first failing stage ends the assembly
+	for _, stage := range plan.Stages {
+		if rng.Float64() < stage.FailureProbability && plan.Failure == nil {
+			mode := stageFailureModes[stage.Stage]
+			plan.FailedStage = stage.Stage
+			plan.Failure = &mode
+		}
+	}
+
+	return plan
+}
+
+// Rand returns a random source for the given key. With a configured seed
+// the source is reproducible per key, otherwise it is seeded from the clock.
+func (s *Simulator) Rand(key string) *rand.Rand {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rand(key)
+}
+
+func (s *Simulator) rand(key string) *rand.Rand {
+	if s.config.Seed == 0 {
+		return rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+	return rand.New(rand.NewSource(s.config.Seed ^ int64(hash.Sum64())))
+}
+
+// Tuning returns the standard build duration and simulation settings in use
+func (s *Simulator) Tuning() (time.Duration, config.SimulationConfig) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.baseDuration, s.config
+}
+
+// Tune replaces the standard build duration and simulation settings for
+// assemblies planned from now on
+func (s *Simulator) Tune(baseDuration time.Duration, cfg config.SimulationConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.baseDuration = baseDuration
+	s.config = cfg
+}
+
+// failureProbability returns the chance a stage fails for the given build
+func (s *Simulator) failureProbability(stage Stage, profile Profile) float64 {
+	var p float64
+	switch stage {
+	case StageKitting:
+		// Every additional part is another chance of a shortage
+		p = s.config.KittingFailureRate * float64(profile.ComponentCount) / float64(standardProfile.ComponentCount)
+	case StageEngineInstallation:
+		// Each engine is installed and fired independently
+		p = 1 - math.Pow(1-s.config.EngineFailureRate, float64(profile.EngineCount))
+	case StageAvionicsCalibration:
+		// Thrust vectoring across several engines is harder to calibrate
+		p = s.config.CalibrationFailureRate * (1 + 0.5*float64(max(profile.EngineCount-1, 0)))
+	case StagePayloadIntegration:
+		if profile.PayloadMassKg > s.config.PayloadMassLimitKg {
+			return 1
+		}
+		p = s.config.PayloadFailureRate * profile.PayloadMassKg / 100
+	case StageFinalInspection:
+		p = s.config.InspectionFailureRate
+	}
+	return math.Min(p, 1)
+}
+
+// stagesFor lists the stages a build goes through
+func stagesFor(profile Profile) []Stage {
+	stages := []Stage{StageKitting}
+	if profile.EngineCount > 0 {
+		stages = append(stages, StageEngineInstallation)
+	}
+	stages = append(stages, StageAvionicsCalibration)
+	if profile.PayloadMassKg > 0 {
+		stages = append(stages, StagePayloadIntegration)
+	}
+	return append(stages, StageFinalInspection)
+}
+
+// stageWork returns the relative amount of work in a stage
+func stageWork(stage Stage, profile Profile) float64 {
+	switch stage {
+	case StageKitting:
+		return 0.5 * float64(profile.ComponentCount)
+	case StageEngineInstallation:
+		return 2.5 * float64(profile.EngineCount)
+	case StageAvionicsCalibration:
+		return 1.5 + 0.5*float64(profile.EngineCount)
+	case StagePayloadIntegration:
+		return 1 + profile.PayloadMassKg/25
+	case StageFinalInspection:
+		return 1 + 0.2*float64(profile.ComponentCount) + 0.01*profile.TotalMassKg
+	default:
+		return 0
+	}
+}
+
+func totalWork(profile Profile) float64 {
+	var total float64
+	for _, stage := range stagesFor(profile) {
+		total += stageWork(stage, profile)
+	}
+	return total
+}
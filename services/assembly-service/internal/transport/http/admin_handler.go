@@ -0,0 +1,195 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/assembly-service/internal/service"
+)
+
+// operatorRequest is the optional JSON body of assembly admin actions
+type operatorRequest struct {
+	Operator string `json:"operator"`
+}
+
+// simulationRequest is the JSON body for changing the simulation tuning. Omitted
+// fields keep their current value.
+type simulationRequest struct {
+	Operator               string   `json:"operator"`
+	SimulationDuration     string   `json:"simulation_duration"` // e.g. "20s"
+	Seed                   *int64   `json:"seed"`
+	Jitter                 *float64 `json:"jitter"`
+	KittingFailureRate     *float64 `json:"kitting_failure_rate"`
+	EngineFailureRate      *float64 `json:"engine_failure_rate"`
+	CalibrationFailureRate *float64 `json:"calibration_failure_rate"`
+	PayloadFailureRate     *float64 `json:"payload_failure_rate"`
+	PayloadMassLimitKg     *float64 `json:"payload_mass_limit_kg"`
+	InspectionFailureRate  *float64 `json:"inspection_failure_rate"`
+}
+
+// simulationResponse reports the simulation tuning
+type simulationResponse struct {
+	SimulationDuration string      `json:"simulation_duration"`
+	Simulation         interface{} `json:"simulation"`
+}
+
+// assembliesHandler serves the assembly admin endpoints:
+//
+//	GET  /admin/assemblies                 in-flight assemblies of this replica
+//	POST /admin/assemblies/{id}/cancel     fail an in-flight assembly
+//	POST /admin/assemblies/{id}/requeue    assemble the order of a failed assembly again
+func (h *HealthServer) assembliesHandler(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/assemblies"), "/")
+	ctx := r.Context()
+
+	if path == "" {
+		if r.Method != http.MethodGet {
+			h.methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		assemblies := h.assemblyService.ListInFlightAssemblies(ctx)
+		h.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"assemblies": assemblies,
+			"count":      len(assemblies),
+		})
+		return
+	}
+
+	assemblyID, action, ok := strings.Cut(path, "/")
+	if !ok || (action != "cancel" && action != "requeue") {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+		return
+	}
+	if r.Method != http.MethodPost {
+		h.methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var body operatorRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+	}
+
+	h.logger.Info("Assembly admin action requested",
+		"action", action,
+		"assembly_id", assemblyID,
+		"operator", body.Operator,
+		"remote_addr", r.RemoteAddr)
+
+	if action == "cancel" {
+		if err := h.assemblyService.CancelAssembly(ctx, assemblyID, body.Operator); err != nil {
+			h.writeAdminError(w, err)
+			return
+		}
+		h.writeJSON(w, http.StatusAccepted, map[string]string{
+			"assembly_id": assemblyID,
+			"status":      "cancelling",
+		})
+		return
+	}
+
+	assembly, err := h.assemblyService.RequeueAssembly(ctx, assemblyID, body.Operator)
+	if err != nil {
+		h.writeAdminError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusAccepted, map[string]string{
+		"assembly_id":        assembly.ID,
+		"failed_assembly_id": assemblyID,
+		"order_id":           assembly.OrderID,
+	})
+}
+
+// simulationHandler reports and changes the simulation tuning at runtime:
+//
+//	GET   /admin/simulation    current duration and failure rates
+//	PATCH /admin/simulation    change them for assemblies started from now on
+func (h *HealthServer) simulationHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.writeJSON(w, http.StatusOK, newSimulationResponse(h.assemblyService.SimulationSettings()))
+
+	case http.MethodPatch:
+		var body simulationRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		settings := h.assemblyService.SimulationSettings()
+		if body.SimulationDuration != "" {
+			duration, err := time.ParseDuration(body.SimulationDuration)
+			if err != nil {
+				h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid simulation_duration"})
+				return
+			}
+			settings.SimulationDuration = duration
+		}
+		sim := &settings.Simulation
+		setIfPresent(&sim.Seed, body.Seed)
+		setIfPresent(&sim.Jitter, body.Jitter)
+		setIfPresent(&sim.KittingFailureRate, body.KittingFailureRate)
+		setIfPresent(&sim.EngineFailureRate, body.EngineFailureRate)
+		setIfPresent(&sim.CalibrationFailureRate, body.CalibrationFailureRate)
+		setIfPresent(&sim.PayloadFailureRate, body.PayloadFailureRate)
+		setIfPresent(&sim.PayloadMassLimitKg, body.PayloadMassLimitKg)
+		setIfPresent(&sim.InspectionFailureRate, body.InspectionFailureRate)
+
+		if err := h.assemblyService.UpdateSimulation(r.Context(), settings, body.Operator); err != nil {
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		h.writeJSON(w, http.StatusOK, newSimulationResponse(settings))
+
+	default:
+		h.methodNotAllowed(w, http.MethodGet+", "+http.MethodPatch)
+	}
+}
+
+func newSimulationResponse(settings service.SimulationSettings) simulationResponse {
+	return simulationResponse{
+		SimulationDuration: settings.SimulationDuration.String(),
+		Simulation:         settings.Simulation,
+	}
+}
+
+func setIfPresent[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+// writeAdminError maps assembly admin errors to HTTP statuses
+func (h *HealthServer) writeAdminError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	message := err.Error()
+	switch {
+	case errors.Is(err, service.ErrAssemblyNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrAssemblyNotActive),
+		errors.Is(err, service.ErrAssemblyNotFailed),
+		errors.Is(err, service.ErrOrderBeingBuilt):
+		status = http.StatusConflict
+	default:
+		h.logger.Error("Assembly admin action failed", "error", err)
+		message = "internal error"
+	}
+	h.writeJSON(w, status, map[string]string{"error": message})
+}
+
+func (h *HealthServer) methodNotAllowed(w http.ResponseWriter, allow string) {
+	w.Header().Set("Allow", allow)
+	h.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+}
+
+func (h *HealthServer) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
@@ -11,6 +11,9 @@ import (
 
 	"github.com/amiosamu/rocket-science/services/assembly-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/assembly-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/adminhttp"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
+	"github.com/amiosamu/rocket-science/shared/platform/version"
 )
 
 // HealthServer provides HTTP health check endpoints
@@ -18,6 +21,8 @@ type HealthServer struct {
 	logger          *slog.Logger
 	config          *config.Config
 	assemblyService *service.AssemblyService
+	artifacts       *service.ObjectStoreArtifacts // nil unless completion artifacts are uploaded
+	maintenance     *maintenance.Mode
 	server          *http.Server
 	startTime       time.Time
 }
@@ -34,11 +39,13 @@ type HealthResponse struct {
 }
 
 // NewHealthServer creates a new health check server
-func NewHealthServer(logger *slog.Logger, cfg *config.Config, assemblyService *service.AssemblyService) *HealthServer {
+func NewHealthServer(logger *slog.Logger, cfg *config.Config, assemblyService *service.AssemblyService, maintenanceMode *maintenance.Mode, artifacts *service.ObjectStoreArtifacts) *HealthServer {
 	return &HealthServer{
 		logger:          logger.With("component", "health_server"),
 		config:          cfg,
 		assemblyService: assemblyService,
+		artifacts:       artifacts,
+		maintenance:     maintenanceMode,
 		startTime:       time.Now(),
 	}
 }
@@ -56,6 +63,14 @@ func (h *HealthServer) Start() error {
 	mux.HandleFunc("/live", h.livenessHandler)
 	mux.HandleFunc("/metrics", h.metricsHandler)
 	mux.HandleFunc("/stats", h.statsHandler)
+	mux.Handle("/version", version.Handler("assembly-service"))
+	mux.HandleFunc("/admin/maintenance", h.maintenanceHandler)
+	mux.HandleFunc("/admin/assemblies", h.assembliesHandler)
+	mux.HandleFunc("/admin/assemblies/", h.assembliesHandler)
+	mux.HandleFunc("/admin/simulation", h.simulationHandler)
+	if h.artifacts != nil {
+		mux.Handle(service.ArtifactFilesPath, h.artifacts.DownloadHandler())
+	}
 
 	h.server = &http.Server{
 		Addr:         ":" + port,
@@ -65,10 +80,22 @@ func (h *HealthServer) Start() error {
 		IdleTimeout:  15 * time.Second,
 	}
 
-	h.logger.Info("Starting health server", "port", port)
+	security, err := adminhttp.FromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid health server security configuration: %w", err)
+	}
+	listener, err := security.Listen(h.server)
+	if err != nil {
+		return err
+	}
+	if !security.AuthEnabled() {
+		h.logger.Warn("Health server admin routes are unauthenticated; set HEALTH_ADMIN_TOKEN or HEALTH_TLS_CLIENT_CA_FILE")
+	}
+
+	h.logger.Info("Starting health server", "address", h.server.Addr, "tls", security.TLSEnabled(), "admin_auth", security.AuthEnabled())
 
 	go func() {
-		if err := h.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := h.server.Serve(listener); err != nil && err != http.ErrServerClosed {
 			h.logger.Error("Health server error", "error", err)
 		}
 	}()
@@ -149,6 +176,10 @@ func (h *HealthServer) readinessHandler(w http.ResponseWriter, r *http.Request)
 		status = "not ready"
 		statusCode = http.StatusServiceUnavailable
 	}
+	if h.maintenance.Enabled() {
+		status = "maintenance"
+		statusCode = http.StatusServiceUnavailable
+	}
 
 	response := HealthResponse{
 		Service:   "assembly-service",
@@ -162,6 +193,20 @@ func (h *HealthServer) readinessHandler(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(response)
 }
 
+// maintenanceHandler reports and toggles maintenance mode
+func (h *HealthServer) maintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	if h.maintenance == nil {
+		http.Error(w, "maintenance mode not configured", http.StatusNotImplemented)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		h.logger.Info("Maintenance mode change requested", "method", r.Method, "remote_addr", r.RemoteAddr)
+	}
+
+	h.maintenance.Handler().ServeHTTP(w, r)
+}
+
 // livenessHandler checks if the service is alive
 func (h *HealthServer) livenessHandler(w http.ResponseWriter, r *http.Request) {
 	h.setCORSHeaders(w)
@@ -2,17 +2,18 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"os/signal"
-	"sync"
 	"syscall"
 	"time"
 
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/container"
 	grpcTransport "github.com/amiosamu/rocket-science/services/iam-service/internal/transport/grpc"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/transport/http"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/lifecycle"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 )
 
@@ -21,7 +22,7 @@ const (
 	serviceName    = "iam-service"
 	serviceVersion = "1.0.0"
 
-	// Shutdown timeouts
+	// Shutdown timeouts: servers drain in-flight requests, the container only closes connections
 	gracefulShutdownTimeout = 30 * time.Second
 	forceShutdownTimeout    = 5 * time.Second
 )
@@ -33,27 +34,17 @@ type Application struct {
 	healthServer *http.HealthServer
 	logger       logging.Logger
 
-	// Lifecycle management
-	ctx        context.Context
-	cancel     context.CancelFunc
-	shutdownWg sync.WaitGroup
-	isShutdown bool
-	mu         sync.RWMutex
+	ctx context.Context
 }
 
 // NewApplication creates a new application instance
 func NewApplication() (*Application, error) {
-	// Create application context
-	ctx, cancel := context.WithCancel(context.Background())
-
 	app := &Application{
-		ctx:    ctx,
-		cancel: cancel,
+		ctx: context.Background(),
 	}
 
 	// Initialize application components
 	if err := app.initializeComponents(); err != nil {
-		cancel()
 		return nil, fmt.Errorf("failed to initialize application: %w", err)
 	}
 
@@ -200,7 +191,7 @@ func (app *Application) postInitializationChecks() error {
 	return nil
 }
 
-// Start starts the application
+// Start runs the application until a shutdown signal is received or a server fails
 func (app *Application) Start() error {
 	app.logger.Info(app.ctx, "Starting IAM service", map[string]interface{}{
 		"service": serviceName,
@@ -208,49 +199,81 @@ func (app *Application) Start() error {
 		"address": app.grpcServer.GetAddress(),
 	})
 
-	// Setup signal handling for graceful shutdown
-	app.setupSignalHandling()
-
-	// Start gRPC server in a goroutine
-	app.shutdownWg.Add(1)
-	go func() {
-		defer app.shutdownWg.Done()
-
-		app.logger.Info(app.ctx, "Starting gRPC server", map[string]interface{}{
-			"address": app.grpcServer.GetAddress(),
+	// Servers are stopped before the container closes the database connections
+	runner := lifecycle.NewRunner(lifecycle.FromPlatformLogger(app.logger),
+		lifecycle.WithSignals(syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP),
+		lifecycle.WithDefaultStopTimeout(gracefulShutdownTimeout),
+	)
+	runner.Add(
+		lifecycle.Component{
+			Name:        "container",
+			Stop:        func(ctx context.Context) error { return app.container.Close() },
+			StopTimeout: forceShutdownTimeout,
+		},
+		lifecycle.Component{
+			Name:      "grpc-server",
+			DependsOn: []string{"container"},
+			Run:       func(ctx context.Context) error { return app.grpcServer.Start() },
+			Stop:      app.grpcServer.Stop,
+		},
+		lifecycle.Component{
+			Name:      "health-server",
+			DependsOn: []string{"container"},
+			Start:     app.healthServer.Start,
+			Stop:      app.healthServer.Stop,
+		},
+	)
+	if purgeJob := app.container.GetUserPurgeJob(); purgeJob != nil {
+		runner.Add(lifecycle.Component{
+			Name:      "user-purge",
+			DependsOn: []string{"container"},
+			Run:       purgeJob.Run,
 		})
-
-		if err := app.grpcServer.Start(); err != nil {
-			app.logger.Error(app.ctx, "gRPC server failed", err, map[string]interface{}{
-				"address": app.grpcServer.GetAddress(),
-			})
-
-			// Trigger shutdown on server failure
-			app.initiateShutdown("server_failure")
-		}
-	}()
-
-	// Start HTTP health server in a goroutine
-	app.shutdownWg.Add(1)
-	go func() {
-		defer app.shutdownWg.Done()
-
-		app.logger.Info(app.ctx, "Starting HTTP health server", map[string]interface{}{
-			"address": app.healthServer.GetAddress(),
+	}
+	runner.Add(lifecycle.Component{
+		Name:      "account-deletion",
+		DependsOn: []string{"container"},
+		Run:       app.container.GetAccountDeletionJob().Run,
+	})
+	if purger := app.container.GetRetentionPurger(); purger != nil {
+		runner.Add(lifecycle.Component{
+			Name:      "retention",
+			DependsOn: []string{"container"},
+			Run:       purger.Run,
 		})
+	}
+	if worker := app.container.GetJobWorker(); worker != nil {
+		runner.Add(lifecycle.Component{
+			Name:      "job-worker",
+			DependsOn: []string{"container"},
+			Run:       worker.Run,
+		})
+	}
+	if relay := app.container.GetUserEventRelay(); relay != nil {
+		runner.Add(lifecycle.Component{
+			Name:      "user-event-relay",
+			DependsOn: []string{"container"},
+			Run:       relay.Run,
+		})
+	}
+	if consumer := app.container.GetSecurityEventConsumer(); consumer != nil {
+		runner.Add(lifecycle.Component{
+			Name:      "security-event-consumer",
+			DependsOn: []string{"container"},
+			// The consumer keeps reconnecting in the background, so an
+			// unavailable Kafka does not hold up startup
+			Run: func(ctx context.Context) error {
+				if err := consumer.Start(ctx); err != nil && ctx.Err() == nil {
+					app.logger.Error(ctx, "Security event consumer not ready", err)
+				}
+				<-ctx.Done()
+				return nil
+			},
+			Stop: func(context.Context) error { return consumer.Stop() },
+		})
+	}
 
-		if err := app.healthServer.Start(app.ctx); err != nil {
-			app.logger.Error(app.ctx, "HTTP health server failed", err, map[string]interface{}{
-				"address": app.healthServer.GetAddress(),
-			})
-
-			// Trigger shutdown on server failure
-			app.initiateShutdown("health_server_failure")
-		}
-	}()
-
-	// Log successful startup
-	app.logger.Info(app.ctx, "IAM service started successfully", map[string]interface{}{
+	app.logger.Info(app.ctx, "IAM service components registered", map[string]interface{}{
 		"service":        serviceName,
 		"version":        serviceVersion,
 		"grpc_address":   app.grpcServer.GetAddress(),
@@ -259,122 +282,7 @@ func (app *Application) Start() error {
 		"health_status":  app.container.GetHealthStatus(),
 	})
 
-	// Wait for shutdown signal
-	<-app.ctx.Done()
-
-	app.logger.Info(app.ctx, "Shutdown signal received, initiating graceful shutdown...")
-	return app.shutdown()
-}
-
-// setupSignalHandling configures signal handlers for graceful shutdown
-func (app *Application) setupSignalHandling() {
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan,
-		syscall.SIGINT,  // Ctrl+C
-		syscall.SIGTERM, // Termination request
-		syscall.SIGHUP,  // Hang up
-	)
-
-	go func() {
-		sig := <-sigChan
-		app.logger.Info(app.ctx, "Received shutdown signal", map[string]interface{}{
-			"signal": sig.String(),
-		})
-
-		app.initiateShutdown(fmt.Sprintf("signal_%s", sig.String()))
-	}()
-}
-
-// initiateShutdown triggers the shutdown process
-func (app *Application) initiateShutdown(reason string) {
-	app.mu.Lock()
-	defer app.mu.Unlock()
-
-	if app.isShutdown {
-		return // Already shutting down
-	}
-
-	app.isShutdown = true
-	app.logger.Info(app.ctx, "Initiating shutdown", map[string]interface{}{
-		"reason": reason,
-	})
-
-	app.cancel()
-}
-
-// shutdown performs graceful shutdown of all components
-func (app *Application) shutdown() error {
-	app.logger.Info(app.ctx, "Starting graceful shutdown process...")
-
-	// Create shutdown context with timeout
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), gracefulShutdownTimeout)
-	defer shutdownCancel()
-
-	var shutdownErrors []error
-
-	// Step 1: Stop accepting new requests (stop servers)
-	if app.grpcServer != nil {
-		app.logger.Info(shutdownCtx, "Stopping gRPC server...")
-
-		if err := app.grpcServer.Stop(shutdownCtx); err != nil {
-			app.logger.Error(shutdownCtx, "Failed to stop gRPC server gracefully", err)
-			shutdownErrors = append(shutdownErrors, fmt.Errorf("gRPC server shutdown failed: %w", err))
-		} else {
-			app.logger.Info(shutdownCtx, "gRPC server stopped successfully")
-		}
-	}
-
-	if app.healthServer != nil {
-		app.logger.Info(shutdownCtx, "Stopping HTTP health server...")
-
-		if err := app.healthServer.Stop(shutdownCtx); err != nil {
-			app.logger.Error(shutdownCtx, "Failed to stop health server gracefully", err)
-			shutdownErrors = append(shutdownErrors, fmt.Errorf("health server shutdown failed: %w", err))
-		} else {
-			app.logger.Info(shutdownCtx, "HTTP health server stopped successfully")
-		}
-	}
-
-	// Step 2: Wait for ongoing requests to complete
-	app.logger.Info(shutdownCtx, "Waiting for ongoing requests to complete...")
-
-	// Wait for all goroutines to finish with timeout
-	done := make(chan struct{})
-	go func() {
-		app.shutdownWg.Wait()
-		close(done)
-	}()
-
-	select {
-	case <-done:
-		app.logger.Info(shutdownCtx, "All ongoing requests completed")
-	case <-shutdownCtx.Done():
-		app.logger.Warn(shutdownCtx, "Timeout waiting for requests to complete, forcing shutdown")
-	}
-
-	// Step 3: Close container and database connections
-	if app.container != nil {
-		app.logger.Info(shutdownCtx, "Closing container and database connections...")
-
-		if err := app.container.Close(); err != nil {
-			app.logger.Error(shutdownCtx, "Failed to close container", err)
-			shutdownErrors = append(shutdownErrors, fmt.Errorf("container shutdown failed: %w", err))
-		} else {
-			app.logger.Info(shutdownCtx, "Container closed successfully")
-		}
-	}
-
-	// Step 4: Final cleanup
-	app.logger.Info(shutdownCtx, "Performing final cleanup...")
-
-	// Log shutdown completion
-	if len(shutdownErrors) > 0 {
-		app.logger.Error(shutdownCtx, "Shutdown completed with errors", fmt.Errorf("shutdown errors: %v", shutdownErrors))
-		return fmt.Errorf("shutdown completed with %d errors", len(shutdownErrors))
-	}
-
-	app.logger.Info(shutdownCtx, "Graceful shutdown completed successfully")
-	return nil
+	return runner.Run(app.ctx)
 }
 
 // GetStats returns comprehensive application statistics
@@ -417,15 +325,56 @@ func (app *Application) HealthCheck() error {
 	return app.grpcServer.HealthCheck(app.ctx)
 }
 
+// checkSchema runs the migration check of the container. The IAM schema is
+// created by the database's init scripts, so there is nothing to apply.
+func checkSchema() error {
+	c, err := container.NewContainer(container.ContainerConfig{LogLevel: os.Getenv(platformconfig.LogLevelEnv)})
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	defer c.Close()
+
+	return c.RunMigrations()
+}
+
 // main is the application entry point
 func main() {
 	log.Printf("Starting %s v%s", serviceName, serviceVersion)
 
+	// Layer the environment's config profile between defaults and environment variables
+	flags := platformconfig.RegisterFlags("IAM_SERVER_PORT")
+	reencrypt := flag.Bool("reencrypt-pii", false, "re-encrypt stored user PII with the active key and exit")
+	reencryptBatchSize := flag.Int("reencrypt-batch-size", 500, "users per re-encryption batch")
+	flag.Parse()
+	profile, err := flags.Apply()
+	if *flags.Validation.Validate {
+		// Report on the configuration instead of starting the service
+		validateConfiguration(profile, err, *flags.Validation.CheckConnectivity).Exit()
+	}
+	if err != nil {
+		log.Fatalf("Failed to load config profile: %v", err)
+	}
+	if *flags.MigrateOnly {
+		if err := checkSchema(); err != nil {
+			log.Fatalf("Schema check failed: %v", err)
+		}
+		return
+	}
+	if *reencrypt {
+		if err := reencryptPII(*reencryptBatchSize); err != nil {
+			log.Fatalf("PII re-encryption failed: %v", err)
+		}
+		return
+	}
+
 	// Create application
 	app, err := NewApplication()
 	if err != nil {
 		log.Fatalf("Failed to create application: %v", err)
 	}
+	if err := profile.CheckUnknownKeys("LOG_LEVEL", "IAM_HEALTH_PORT"); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
 	// Handle application lifecycle
 	if err := app.Start(); err != nil {
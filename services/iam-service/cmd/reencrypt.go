@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/container"
+)
+
+// reencryptPII rewrites user PII that is stored in plaintext or under a
+// retired key with the active encryption key, one batch of users at a time.
+// It is safe to re-run; rows already under the active key are skipped.
+func reencryptPII(batchSize int) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("batch size must be positive")
+	}
+
+	c, err := container.NewContainer(container.ContainerConfig{LogLevel: "info"})
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	defer c.Close()
+
+	if c.PIIReencryptor == nil {
+		return fmt.Errorf("PII encryption is not enabled (IAM_PII_ENCRYPTION_ENABLED)")
+	}
+
+	ctx := context.Background()
+	var afterID string
+	var scanned, updated int
+	for {
+		batch, err := c.PIIReencryptor.ReencryptPIIBatch(ctx, afterID, batchSize)
+		if err != nil {
+			return fmt.Errorf("re-encryption stopped after user %q: %w", afterID, err)
+		}
+		if batch.Scanned == 0 {
+			break
+		}
+
+		scanned += batch.Scanned
+		updated += batch.Updated
+		afterID = batch.LastID
+		log.Printf("Re-encrypted %d of %d users so far (last user %s)", updated, scanned, afterID)
+	}
+
+	log.Printf("PII re-encryption complete: %d users scanned, %d re-encrypted with key %s",
+		scanned, updated, c.Config.Encryption.ActiveKeyID)
+	return nil
+}
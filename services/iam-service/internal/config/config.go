@@ -2,9 +2,15 @@ package config
 
 import (
 	"fmt"
-	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	"github.com/amiosamu/rocket-science/shared/platform/authz"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/retention"
 )
 
 // Config holds all configuration for the IAM service
@@ -14,6 +20,19 @@ type Config struct {
 	Redis         RedisConfig         `json:"redis"`
 	JWT           JWTConfig           `json:"jwt"`
 	Security      SecurityConfig      `json:"security"`
+	Cookies       CookieConfig        `json:"cookies"`
+	GeoIP         GeoIPConfig         `json:"geoip"`
+	Captcha       CaptchaConfig       `json:"captcha"`
+	Encryption    EncryptionConfig    `json:"encryption"`
+	Retention     RetentionConfig     `json:"retention"`
+	DataRetention DataRetentionConfig `json:"data_retention"`
+	Deletion      DeletionConfig      `json:"deletion"`
+	LoginLinks    LoginLinkConfig     `json:"login_links"`
+	LoginAlerts   LoginAlertConfig    `json:"login_alerts"`
+	LoginHistory  LoginHistoryConfig  `json:"login_history"`
+	Purge         PurgeConfig         `json:"purge"`
+	Clients       ClientsConfig       `json:"clients"`
+	Kafka         KafkaConfig         `json:"kafka"`
 	Observability ObservabilityConfig `json:"observability"`
 }
 
@@ -55,6 +74,26 @@ type RedisConfig struct {
 	ReadTimeout  time.Duration `json:"read_timeout"`
 	WriteTimeout time.Duration `json:"write_timeout"`
 	IdleTimeout  time.Duration `json:"idle_timeout"`
+
+	// Region of this Redis deployment, the primary session store
+	Region  string             `json:"region"`
+	Replica RedisReplicaConfig `json:"replica"`
+}
+
+// RedisReplicaConfig configures the session store replica in the standby region
+type RedisReplicaConfig struct {
+	Enabled  bool   `json:"enabled"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Password string `json:"-"`
+	Region   string `json:"region"`
+
+	// ReadFallback serves session reads from the replica while the primary is unreachable
+	ReadFallback bool `json:"read_fallback"`
+	// MaxStaleness stops replica reads once it has been cut off from the primary this long
+	MaxStaleness time.Duration `json:"max_staleness"`
+	// StaleBlacklistReads lets revoked token checks use the replica, which may miss recent revocations
+	StaleBlacklistReads bool `json:"stale_blacklist_reads"`
 }
 
 // JWTConfig holds JWT token configuration
@@ -78,6 +117,193 @@ type SecurityConfig struct {
 	LoginAttemptWindow     time.Duration `json:"login_attempt_window"`
 	AccountLockoutTime     time.Duration `json:"account_lockout_time"`
 	SessionCleanupInterval time.Duration `json:"session_cleanup_interval"`
+
+	// ImpossibleTravelSpeedKmh is the travel speed between two logins above
+	// which the anomaly detector flags the newer session
+	ImpossibleTravelSpeedKmh int `json:"impossible_travel_speed_kmh"`
+
+	// Brute-force protection for RefreshToken and ValidateSession. Requests
+	// are rate limited per client IP; failed attempts are counted per IP and
+	// per session, and a client crossing a threshold is blocked.
+	TokenRateLimitWindow       time.Duration `json:"token_rate_limit_window"`
+	RefreshRateLimit           int           `json:"refresh_rate_limit"`
+	ValidateRateLimit          int           `json:"validate_rate_limit"`
+	TokenFailureThreshold      int           `json:"token_failure_threshold"`
+	SessionRefreshFailureLimit int           `json:"session_refresh_failure_limit"`
+	TokenBlockDuration         time.Duration `json:"token_block_duration"`
+
+	// AbuseLockDuration locks accounts reported as abusive by other services,
+	// e.g. for repeatedly exceeding the order placement limits
+	AbuseLockDuration time.Duration `json:"abuse_lock_duration"`
+
+	// GatewayIdentityKey signs the identities of validated sessions; services
+	// only trust the identity headers the gateway sets along with the signature
+	GatewayIdentityKey string `json:"-"`
+}
+
+// CookieConfig holds the session cookies set for web clients that log in with cookie
+// token delivery. Browsers send them back on their own, so scripts never see the tokens.
+type CookieConfig struct {
+	AccessTokenName  string `json:"access_token_name"`  // Read by the gateway to authenticate requests
+	RefreshTokenName string `json:"refresh_token_name"` // Only sent to RefreshPath
+	SessionIDName    string `json:"session_id_name"`    // Only sent to RefreshPath
+	Domain           string `json:"domain"`
+	Path             string `json:"path"`
+	RefreshPath      string `json:"refresh_path"`
+	Secure           bool   `json:"secure"`
+	SameSite         string `json:"same_site"` // "strict", "lax" or "none"
+}
+
+// GeoIPConfig holds the GeoIP lookup used to enrich sessions with a location
+type GeoIPConfig struct {
+	Provider     string `json:"provider"` // "none" or "maxmind"
+	DatabasePath string `json:"database_path"`
+}
+
+// CaptchaConfig holds the CAPTCHA challenge for logins. Once a client IP has
+// failed FailureThreshold logins within FailureWindow, its logins need a
+// solved CAPTCHA until ChallengeDuration has passed.
+type CaptchaConfig struct {
+	Provider          string        `json:"provider"` // "none", "hcaptcha" or "turnstile"
+	SiteKey           string        `json:"site_key"` // Handed to clients to render the widget
+	SecretKey         string        `json:"-"`
+	VerifyURL         string        `json:"verify_url"` // Overrides the provider's siteverify endpoint
+	Timeout           time.Duration `json:"timeout"`
+	FailureThreshold  int           `json:"failure_threshold"`
+	FailureWindow     time.Duration `json:"failure_window"`
+	ChallengeDuration time.Duration `json:"challenge_duration"`
+}
+
+// EncryptionConfig holds at-rest encryption of user PII (phone, Telegram
+// chat ID and metadata). Keys are read from the secrets provider as
+// "<key id>:<base64 key>" entries; rotate by adding a key, switching the
+// active key ID and running the service with --reencrypt-pii.
+type EncryptionConfig struct {
+	Enabled         bool   `json:"enabled"`
+	SecretsProvider string `json:"secrets_provider"` // "env" or "file"
+	SecretsDir      string `json:"secrets_dir"`      // Used by the file provider
+	KeysSecret      string `json:"keys_secret"`
+	ActiveKeyID     string `json:"active_key_id"`
+}
+
+// RetentionConfig holds the purge of soft-deleted users. Users are hard
+// deleted, together with their sessions, once they have been deleted for
+// longer than DeletedUserRetentionDays.
+type RetentionConfig struct {
+	PurgeEnabled             bool          `json:"purge_enabled"`
+	DeletedUserRetentionDays int           `json:"deleted_user_retention_days"`
+	PurgeInterval            time.Duration `json:"purge_interval"`
+	PurgeBatchSize           int           `json:"purge_batch_size"` // Users purged per run
+}
+
+// DataRetentionConfig holds the purge of internal bookkeeping rows once they
+// are older than their table's TTL; a zero TTL keeps a table's rows forever
+type DataRetentionConfig struct {
+	retention.Config
+
+	PurgeAuditTTL time.Duration `json:"purge_audit_ttl"` // Audit trail of purged users
+	JobsTTL       time.Duration `json:"jobs_ttl"`        // Finished test data purge jobs
+	UserEventsTTL time.Duration `json:"user_events_ttl"` // Published user lifecycle events
+}
+
+// DeletionConfig holds self-service account deletion. An account is deleted
+// for good GracePeriod after its owner asks, unless the owner logs back in
+// and cancels.
+type DeletionConfig struct {
+	GracePeriod       time.Duration `json:"grace_period"`
+	FinalizeInterval  time.Duration `json:"finalize_interval"`
+	FinalizeBatchSize int           `json:"finalize_batch_size"` // Accounts deleted per run
+}
+
+// LoginLinkConfig holds password-less login. A user asks for a link by email,
+// notification-service delivers it, and opening it within TTL logs the user in
+// once from the browser that asked. Each email may ask RateLimit times per
+// RateLimitWindow.
+type LoginLinkConfig struct {
+	URL             string        `json:"url"` // Page completing the login; the token is added as ?token=
+	TTL             time.Duration `json:"ttl"`
+	RateLimit       int           `json:"rate_limit"`
+	RateLimitWindow time.Duration `json:"rate_limit_window"`
+}
+
+// LoginAlertConfig holds the warnings sent to account owners when failed logins
+// pile up. Reaching Threshold failed logins within Window sends one alert with a
+// link to LockURL, which locks the account for LockDuration if opened within
+// LockLinkTTL.
+type LoginAlertConfig struct {
+	Threshold    int           `json:"threshold"`
+	Window       time.Duration `json:"window"`
+	LockURL      string        `json:"lock_url"` // Page locking the account; the token is added as ?token=
+	LockLinkTTL  time.Duration `json:"lock_link_ttl"`
+	LockDuration time.Duration `json:"lock_duration"`
+}
+
+// LoginHistoryConfig holds the login history shown to users. The latest
+// MaxEvents logins of each user are kept, none older than Retention: older
+// logins are hidden at once and deleted on the user's next login.
+type LoginHistoryConfig struct {
+	MaxEvents int           `json:"max_events"`
+	Retention time.Duration `json:"retention"`
+}
+
+// PurgeConfig holds the test data purge, which deletes test users with their
+// data across the services. Whether it may run at all is decided by the
+// environment gate (PURGE_ENABLED, ENVIRONMENT), which never allows production.
+type PurgeConfig struct {
+	EmailDomains    []string      `json:"email_domains"` // Only users of these domains can be purged
+	OrderURL        string        `json:"order_url"`     // Base URLs of the participants' purge endpoints
+	InventoryURL    string        `json:"inventory_url"`
+	PaymentURL      string        `json:"payment_url"`
+	NotificationURL string        `json:"notification_url"`
+	AdminToken      string        `json:"-"` // Bearer token of the participants' admin routes
+	RequestTimeout  time.Duration `json:"request_timeout"`
+}
+
+// KafkaConfig holds publishing of user events, which notification-service
+// delivers to the user over Telegram, and consuming of the security events
+// other services report, such as order placement abuse
+type KafkaConfig struct {
+	Brokers       []string        `json:"brokers"` // Empty disables publishing and consuming
+	Topics        topics.Registry `json:"topics"`
+	ConsumerGroup string          `json:"consumer_group"`
+
+	// Encryption encrypts the payloads of topics carrying personal data, such as
+	// the emails and chat IDs of user events, and decrypts consumed ones
+	Encryption kafka.EncryptionConfig `json:"encryption"`
+
+	// User lifecycle events wait in the outbox until relayed, in batches
+	OutboxRelayInterval  time.Duration `json:"outbox_relay_interval"`
+	OutboxRelayBatchSize int           `json:"outbox_relay_batch_size"`
+}
+
+// UsedTopics are the registered topics the service produces to and consumes from
+var UsedTopics = []topics.Topic{topics.UserEvents, topics.SecurityEvents}
+
+// ClientsConfig holds the client credentials grant for registered service
+// clients. Client tokens cannot be refreshed, so they are kept short-lived.
+type ClientsConfig struct {
+	TokenDuration time.Duration `json:"token_duration"`
+
+	// Requests the gateway lets each client make per quota window
+	Quota ClientQuotaConfig `json:"quota"`
+}
+
+// ClientQuotaConfig holds the request quota of service clients. Requests are
+// counted in fixed windows aligned to the Unix epoch, so a 24h window resets
+// at midnight UTC for every client.
+type ClientQuotaConfig struct {
+	Enabled bool             `json:"enabled"`
+	Limit   int64            `json:"limit"`  // Requests per window of clients without their own limit
+	Window  time.Duration    `json:"window"` // Length of a quota window
+	Limits  map[string]int64 `json:"limits"` // Limits of individual clients by client ID
+}
+
+// LimitFor returns the request limit of the client
+func (c ClientQuotaConfig) LimitFor(clientID string) int64 {
+	if limit, ok := c.Limits[clientID]; ok {
+		return limit
+	}
+	return c.Limit
 }
 
 // ObservabilityConfig holds observability configuration
@@ -125,6 +351,17 @@ func Load() (*Config, error) {
 			ReadTimeout:  getEnvAsDuration("IAM_REDIS_READ_TIMEOUT", "3s"),
 			WriteTimeout: getEnvAsDuration("IAM_REDIS_WRITE_TIMEOUT", "3s"),
 			IdleTimeout:  getEnvAsDuration("IAM_REDIS_IDLE_TIMEOUT", "5m"),
+			Region:       getEnv("IAM_REDIS_REGION", "primary"),
+			Replica: RedisReplicaConfig{
+				Enabled:             getEnvAsBool("IAM_REDIS_REPLICA_ENABLED", false),
+				Host:                getEnv("IAM_REDIS_REPLICA_HOST", ""),
+				Port:                getEnvAsInt("IAM_REDIS_REPLICA_PORT", 6379),
+				Password:            getEnv("IAM_REDIS_REPLICA_PASSWORD", getEnv("IAM_REDIS_PASSWORD", "")),
+				Region:              getEnv("IAM_REDIS_REPLICA_REGION", "replica"),
+				ReadFallback:        getEnvAsBool("IAM_REDIS_REPLICA_READ_FALLBACK", true),
+				MaxStaleness:        getEnvAsDuration("IAM_REDIS_REPLICA_MAX_STALENESS", "10m"),
+				StaleBlacklistReads: getEnvAsBool("IAM_REDIS_REPLICA_STALE_BLACKLIST_READS", false),
+			},
 		},
 		JWT: JWTConfig{
 			SecretKey:            getEnv("IAM_JWT_SECRET", "your-secret-key-change-in-production"),
@@ -144,6 +381,115 @@ func Load() (*Config, error) {
 			LoginAttemptWindow:     getEnvAsDuration("IAM_LOGIN_ATTEMPT_WINDOW", "15m"),
 			AccountLockoutTime:     getEnvAsDuration("IAM_ACCOUNT_LOCKOUT_TIME", "30m"),
 			SessionCleanupInterval: getEnvAsDuration("IAM_SESSION_CLEANUP_INTERVAL", "1h"),
+
+			ImpossibleTravelSpeedKmh: getEnvAsInt("IAM_IMPOSSIBLE_TRAVEL_SPEED_KMH", 1000),
+
+			TokenRateLimitWindow:       getEnvAsDuration("IAM_TOKEN_RATE_LIMIT_WINDOW", "1m"),
+			RefreshRateLimit:           getEnvAsInt("IAM_REFRESH_RATE_LIMIT", 30),
+			ValidateRateLimit:          getEnvAsInt("IAM_VALIDATE_RATE_LIMIT", 1200),
+			TokenFailureThreshold:      getEnvAsInt("IAM_TOKEN_FAILURE_THRESHOLD", 20),
+			SessionRefreshFailureLimit: getEnvAsInt("IAM_SESSION_REFRESH_FAILURE_LIMIT", 5),
+			TokenBlockDuration:         getEnvAsDuration("IAM_TOKEN_BLOCK_DURATION", "15m"),
+
+			AbuseLockDuration: getEnvAsDuration("IAM_ABUSE_LOCK_DURATION", "24h"),
+
+			GatewayIdentityKey: getEnv(authz.IdentityKeyEnv, ""),
+		},
+		Cookies: CookieConfig{
+			AccessTokenName:  getEnv("IAM_COOKIE_ACCESS_TOKEN_NAME", "session_token"),
+			RefreshTokenName: getEnv("IAM_COOKIE_REFRESH_TOKEN_NAME", "refresh_token"),
+			SessionIDName:    getEnv("IAM_COOKIE_SESSION_ID_NAME", "session_id"),
+			Domain:           getEnv("IAM_COOKIE_DOMAIN", ""),
+			Path:             getEnv("IAM_COOKIE_PATH", "/"),
+			RefreshPath:      getEnv("IAM_COOKIE_REFRESH_PATH", "/iam.v1.IAMService/"),
+			Secure:           getEnvAsBool("IAM_COOKIE_SECURE", true),
+			SameSite:         getEnv("IAM_COOKIE_SAME_SITE", "strict"),
+		},
+		GeoIP: GeoIPConfig{
+			Provider:     getEnv("IAM_GEOIP_PROVIDER", "none"),
+			DatabasePath: getEnv("IAM_GEOIP_DATABASE_PATH", ""),
+		},
+		Captcha: CaptchaConfig{
+			Provider:          getEnv("IAM_CAPTCHA_PROVIDER", "none"),
+			SiteKey:           getEnv("IAM_CAPTCHA_SITE_KEY", ""),
+			SecretKey:         getEnv("IAM_CAPTCHA_SECRET_KEY", ""),
+			VerifyURL:         getEnv("IAM_CAPTCHA_VERIFY_URL", ""),
+			Timeout:           getEnvAsDuration("IAM_CAPTCHA_TIMEOUT", "5s"),
+			FailureThreshold:  getEnvAsInt("IAM_CAPTCHA_FAILURE_THRESHOLD", 3),
+			FailureWindow:     getEnvAsDuration("IAM_CAPTCHA_FAILURE_WINDOW", "15m"),
+			ChallengeDuration: getEnvAsDuration("IAM_CAPTCHA_CHALLENGE_DURATION", "1h"),
+		},
+		Encryption: EncryptionConfig{
+			Enabled:         getEnvAsBool("IAM_PII_ENCRYPTION_ENABLED", false),
+			SecretsProvider: getEnv("IAM_SECRETS_PROVIDER", "env"),
+			SecretsDir:      getEnv("IAM_SECRETS_DIR", "/run/secrets"),
+			KeysSecret:      getEnv("IAM_PII_KEYS_SECRET", "iam_pii_encryption_keys"),
+			ActiveKeyID:     getEnv("IAM_PII_ACTIVE_KEY_ID", ""),
+		},
+		Retention: RetentionConfig{
+			PurgeEnabled:             getEnvAsBool("IAM_USER_PURGE_ENABLED", true),
+			DeletedUserRetentionDays: getEnvAsInt("IAM_DELETED_USER_RETENTION_DAYS", 30),
+			PurgeInterval:            getEnvAsDuration("IAM_USER_PURGE_INTERVAL", "1h"),
+			PurgeBatchSize:           getEnvAsInt("IAM_USER_PURGE_BATCH_SIZE", 100),
+		},
+		DataRetention: DataRetentionConfig{
+			Config: retention.Config{
+				Enabled:   getEnvAsBool("RETENTION_ENABLED", true),
+				Interval:  getEnvAsDuration("RETENTION_INTERVAL", "1h"),
+				BatchSize: getEnvAsInt("RETENTION_BATCH_SIZE", 1000),
+			},
+			PurgeAuditTTL: getEnvAsDuration("IAM_RETENTION_PURGE_AUDIT_TTL", "8760h"),
+			JobsTTL:       getEnvAsDuration("RETENTION_JOBS_TTL", "168h"),
+			UserEventsTTL: getEnvAsDuration("IAM_RETENTION_USER_EVENTS_TTL", "168h"),
+		},
+		Deletion: DeletionConfig{
+			GracePeriod:       getEnvAsDuration("IAM_ACCOUNT_DELETION_GRACE_PERIOD", "336h"),
+			FinalizeInterval:  getEnvAsDuration("IAM_ACCOUNT_DELETION_INTERVAL", "1h"),
+			FinalizeBatchSize: getEnvAsInt("IAM_ACCOUNT_DELETION_BATCH_SIZE", 100),
+		},
+		LoginAlerts: LoginAlertConfig{
+			Threshold:    getEnvAsInt("IAM_LOGIN_ALERT_THRESHOLD", 5),
+			Window:       getEnvAsDuration("IAM_LOGIN_ALERT_WINDOW", "15m"),
+			LockURL:      getEnv("IAM_ACCOUNT_LOCK_URL", "http://localhost:3000/account/lock"),
+			LockLinkTTL:  getEnvAsDuration("IAM_ACCOUNT_LOCK_LINK_TTL", "24h"),
+			LockDuration: getEnvAsDuration("IAM_ACCOUNT_LOCK_DURATION", "24h"),
+		},
+		LoginHistory: LoginHistoryConfig{
+			MaxEvents: getEnvAsInt("IAM_LOGIN_HISTORY_MAX_EVENTS", 50),
+			Retention: getEnvAsDuration("IAM_LOGIN_HISTORY_RETENTION", "2160h"),
+		},
+		LoginLinks: LoginLinkConfig{
+			URL:             getEnv("IAM_LOGIN_LINK_URL", "http://localhost:3000/login/link"),
+			TTL:             getEnvAsDuration("IAM_LOGIN_LINK_TTL", "15m"),
+			RateLimit:       getEnvAsInt("IAM_LOGIN_LINK_RATE_LIMIT", 3),
+			RateLimitWindow: getEnvAsDuration("IAM_LOGIN_LINK_RATE_LIMIT_WINDOW", "1h"),
+		},
+		Purge: PurgeConfig{
+			EmailDomains:    getEnvAsList("PURGE_EMAIL_DOMAINS", "example.com,test.com"),
+			OrderURL:        getEnv("PURGE_ORDER_URL", "http://order-service:8080"),
+			InventoryURL:    getEnv("PURGE_INVENTORY_URL", "http://inventory-service:8080"),
+			PaymentURL:      getEnv("PURGE_PAYMENT_URL", "http://payment-service:8081"),
+			NotificationURL: getEnv("PURGE_NOTIFICATION_URL", "http://notification-service:8088"),
+			AdminToken:      getEnv("PURGE_ADMIN_TOKEN", platformconfig.Getenv("HEALTH_ADMIN_TOKEN")),
+			RequestTimeout:  getEnvAsDuration("PURGE_REQUEST_TIMEOUT", "60s"),
+		},
+		Clients: ClientsConfig{
+			TokenDuration: getEnvAsDuration("IAM_CLIENT_TOKEN_DURATION", "5m"),
+			Quota: ClientQuotaConfig{
+				Enabled: getEnvAsBool("IAM_CLIENT_QUOTA_ENABLED", true),
+				Limit:   int64(getEnvAsInt("IAM_CLIENT_QUOTA_LIMIT", 10000)),
+				Window:  getEnvAsDuration("IAM_CLIENT_QUOTA_WINDOW", "24h"),
+				Limits:  getEnvAsLimits("IAM_CLIENT_QUOTA_LIMITS"),
+			},
+		},
+		Kafka: KafkaConfig{
+			Brokers:       getEnvAsList("KAFKA_BROKERS", ""),
+			Topics:        topics.Load(platformconfig.Getenv),
+			ConsumerGroup: getEnv("IAM_KAFKA_CONSUMER_GROUP", "iam-service"),
+			Encryption:    kafka.LoadEncryptionConfig(platformconfig.Getenv),
+
+			OutboxRelayInterval:  getEnvAsDuration("IAM_USER_EVENTS_RELAY_INTERVAL", "1s"),
+			OutboxRelayBatchSize: getEnvAsInt("IAM_USER_EVENTS_RELAY_BATCH_SIZE", 100),
 		},
 		Observability: ObservabilityConfig{
 			ServiceName:    getEnv("SERVICE_NAME", "iam-service"),
@@ -184,6 +530,17 @@ func (c *Config) validate() error {
 	if c.Redis.Host == "" {
 		return fmt.Errorf("Redis host cannot be empty")
 	}
+	if c.Redis.Replica.Enabled {
+		if c.Redis.Replica.Host == "" {
+			return fmt.Errorf("Redis replica host cannot be empty when the replica is enabled")
+		}
+		if c.Redis.Replica.Region == c.Redis.Region {
+			return fmt.Errorf("Redis replica region must differ from the primary region %q", c.Redis.Region)
+		}
+		if c.Redis.Replica.MaxStaleness < 0 {
+			return fmt.Errorf("Redis replica max staleness cannot be negative")
+		}
+	}
 
 	// Validate JWT config
 	if c.JWT.SecretKey == "" {
@@ -203,6 +560,177 @@ func (c *Config) validate() error {
 	if c.Security.MaxLoginAttempts < 1 {
 		return fmt.Errorf("max login attempts must be at least 1")
 	}
+	if c.Security.ImpossibleTravelSpeedKmh <= 0 {
+		return fmt.Errorf("impossible travel speed must be positive")
+	}
+	if len(c.Security.GatewayIdentityKey) < authz.MinIdentityKeyLength {
+		return fmt.Errorf("%s must be at least %d characters long", authz.IdentityKeyEnv, authz.MinIdentityKeyLength)
+	}
+	if c.Security.TokenRateLimitWindow <= 0 || c.Security.TokenBlockDuration <= 0 {
+		return fmt.Errorf("token rate limit window and block duration must be positive")
+	}
+	if c.Security.RefreshRateLimit < 1 || c.Security.ValidateRateLimit < 1 {
+		return fmt.Errorf("token endpoint rate limits must be at least 1")
+	}
+	if c.Security.TokenFailureThreshold < 1 || c.Security.SessionRefreshFailureLimit < 1 {
+		return fmt.Errorf("token failure thresholds must be at least 1")
+	}
+
+	// Validate cookie config
+	if c.Cookies.AccessTokenName == "" || c.Cookies.RefreshTokenName == "" || c.Cookies.SessionIDName == "" {
+		return fmt.Errorf("session cookie names cannot be empty")
+	}
+	switch c.Cookies.SameSite {
+	case "strict", "lax":
+	case "none":
+		// Browsers drop SameSite=None cookies that are not Secure
+		if !c.Cookies.Secure {
+			return fmt.Errorf("SameSite=None session cookies must be secure")
+		}
+	default:
+		return fmt.Errorf("invalid session cookie SameSite mode: %s", c.Cookies.SameSite)
+	}
+
+	// Validate GeoIP config
+	switch c.GeoIP.Provider {
+	case "none":
+	case "maxmind":
+		if c.GeoIP.DatabasePath == "" {
+			return fmt.Errorf("GeoIP database path is required for the maxmind provider")
+		}
+	default:
+		return fmt.Errorf("invalid GeoIP provider: %s", c.GeoIP.Provider)
+	}
+
+	// Validate CAPTCHA config
+	switch c.Captcha.Provider {
+	case "none":
+	case "hcaptcha", "turnstile":
+		if c.Captcha.SecretKey == "" || c.Captcha.SiteKey == "" {
+			return fmt.Errorf("CAPTCHA site and secret keys are required for the %s provider", c.Captcha.Provider)
+		}
+		if c.Captcha.Timeout <= 0 || c.Captcha.FailureWindow <= 0 || c.Captcha.ChallengeDuration <= 0 {
+			return fmt.Errorf("CAPTCHA timeout, failure window and challenge duration must be positive")
+		}
+		if c.Captcha.FailureThreshold < 1 {
+			return fmt.Errorf("CAPTCHA failure threshold must be at least 1")
+		}
+	default:
+		return fmt.Errorf("invalid CAPTCHA provider: %s", c.Captcha.Provider)
+	}
+
+	// Validate encryption config
+	if c.Encryption.Enabled {
+		if c.Encryption.ActiveKeyID == "" {
+			return fmt.Errorf("active PII encryption key ID is required when encryption is enabled")
+		}
+		if c.Encryption.KeysSecret == "" {
+			return fmt.Errorf("PII encryption keys secret name cannot be empty")
+		}
+		switch c.Encryption.SecretsProvider {
+		case "env", "file":
+		default:
+			return fmt.Errorf("invalid secrets provider: %s", c.Encryption.SecretsProvider)
+		}
+	}
+
+	// Validate retention config
+	if c.Retention.PurgeEnabled {
+		if c.Retention.DeletedUserRetentionDays < 1 {
+			return fmt.Errorf("deleted user retention must be at least 1 day")
+		}
+		if c.Retention.PurgeInterval <= 0 || c.Retention.PurgeBatchSize < 1 {
+			return fmt.Errorf("user purge interval and batch size must be positive")
+		}
+	}
+
+	// Validate account deletion config
+	if c.Deletion.GracePeriod < time.Hour {
+		return fmt.Errorf("account deletion grace period must be at least 1h")
+	}
+	if c.Deletion.FinalizeInterval <= 0 || c.Deletion.FinalizeBatchSize < 1 {
+		return fmt.Errorf("account deletion interval and batch size must be positive")
+	}
+
+	// Validate login link config
+	if c.LoginLinks.URL == "" {
+		return fmt.Errorf("login link URL cannot be empty")
+	}
+	if c.LoginLinks.TTL <= 0 || c.LoginLinks.TTL > 24*time.Hour {
+		return fmt.Errorf("login link TTL must be positive and at most 24h")
+	}
+	if c.LoginLinks.RateLimit < 1 || c.LoginLinks.RateLimitWindow <= 0 {
+		return fmt.Errorf("login link rate limit and window must be positive")
+	}
+
+	// Validate failed login alert config
+	if c.LoginAlerts.Threshold < 1 || c.LoginAlerts.Window <= 0 {
+		return fmt.Errorf("login alert threshold and window must be positive")
+	}
+	if c.LoginAlerts.LockURL == "" {
+		return fmt.Errorf("account lock URL cannot be empty")
+	}
+	if c.LoginAlerts.LockLinkTTL <= 0 || c.LoginAlerts.LockDuration <= 0 {
+		return fmt.Errorf("account lock link TTL and lock duration must be positive")
+	}
+
+	// Validate login history config
+	if c.LoginHistory.MaxEvents < 1 || c.LoginHistory.Retention <= 0 {
+		return fmt.Errorf("login history max events and retention must be positive")
+	}
+
+	// Validate test data purge config
+	if len(c.Purge.EmailDomains) == 0 {
+		return fmt.Errorf("test data purge email domains cannot be empty")
+	}
+	if c.Purge.OrderURL == "" || c.Purge.InventoryURL == "" || c.Purge.PaymentURL == "" || c.Purge.NotificationURL == "" {
+		return fmt.Errorf("test data purge participant URLs cannot be empty")
+	}
+	if c.Purge.RequestTimeout <= 0 {
+		return fmt.Errorf("test data purge request timeout must be positive")
+	}
+
+	if err := c.Kafka.Topics.Validate(); err != nil {
+		return err
+	}
+	if len(c.Kafka.Brokers) > 0 && c.Kafka.ConsumerGroup == "" {
+		return fmt.Errorf("consumer group cannot be empty when Kafka brokers are set")
+	}
+	if c.Kafka.OutboxRelayInterval <= 0 || c.Kafka.OutboxRelayBatchSize < 1 {
+		return fmt.Errorf("user event relay interval and batch size must be positive")
+	}
+	if err := c.Kafka.Encryption.Validate(); err != nil {
+		return err
+	}
+	if c.Security.AbuseLockDuration <= 0 {
+		return fmt.Errorf("abuse lock duration must be positive")
+	}
+
+	// Validate data retention config
+	if err := c.DataRetention.Validate(); err != nil {
+		return err
+	}
+	if c.DataRetention.PurgeAuditTTL < 0 || c.DataRetention.JobsTTL < 0 || c.DataRetention.UserEventsTTL < 0 {
+		return fmt.Errorf("retention TTLs cannot be negative")
+	}
+
+	// Validate client credentials config
+	if c.Clients.TokenDuration <= 0 || c.Clients.TokenDuration > time.Hour {
+		return fmt.Errorf("client token duration must be positive and at most 1h")
+	}
+	if quota := c.Clients.Quota; quota.Enabled {
+		if quota.Window < time.Minute {
+			return fmt.Errorf("client quota window must be at least 1m")
+		}
+		if quota.Limit < 1 {
+			return fmt.Errorf("client quota limit must be at least 1")
+		}
+		for clientID, limit := range quota.Limits {
+			if limit < 1 {
+				return fmt.Errorf("client quota limit of %s must be at least 1", clientID)
+			}
+		}
+	}
 
 	return nil
 }
@@ -218,39 +746,76 @@ func (c *RedisConfig) RedisAddr() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
+// Addr returns the Redis replica connection address
+func (c *RedisReplicaConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
+
 // Helper functions for environment variable parsing
 
 func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := platformconfig.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
 func getEnvAsInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		intValue, err := strconv.Atoi(value)
+		if err == nil {
 			return intValue
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	return defaultValue
 }
 
 func getEnvAsBool(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
-		if boolValue, err := strconv.ParseBool(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		boolValue, err := strconv.ParseBool(value)
+		if err == nil {
 			return boolValue
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	return defaultValue
 }
 
 func getEnvAsDuration(key string, defaultValue string) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		duration, err := time.ParseDuration(value)
+		if err == nil {
 			return duration
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	duration, _ := time.ParseDuration(defaultValue)
 	return duration
 }
+
+// getEnvAsList splits a comma-separated variable, dropping empty entries
+func getEnvAsList(key string, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvAsLimits parses a comma-separated list of key=limit pairs
+func getEnvAsLimits(key string) map[string]int64 {
+	limits := make(map[string]int64)
+	for _, pair := range getEnvAsList(key, "") {
+		name, value, found := strings.Cut(pair, "=")
+		limit, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if !found || err != nil {
+			platformconfig.InvalidValue(key, pair, fmt.Errorf("expected key=limit"))
+			continue
+		}
+		limits[strings.TrimSpace(name)] = limit
+	}
+	return limits
+}
@@ -10,14 +10,28 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/redis/go-redis/v9"
 
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/captcha"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/encryption"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/geoip"
+	iamKafka "github.com/amiosamu/rocket-science/services/iam-service/internal/messaging/kafka"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/postgres"
 	redisRepo "github.com/amiosamu/rocket-science/services/iam-service/internal/repository/redis"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/secrets"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
 	sharedPostgres "github.com/amiosamu/rocket-science/shared/platform/database/postgres"
 	sharedRedis "github.com/amiosamu/rocket-science/shared/platform/database/redis"
+	"github.com/amiosamu/rocket-science/shared/platform/jobs"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/grpclog"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+	"github.com/amiosamu/rocket-science/shared/platform/purge"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
+	"github.com/amiosamu/rocket-science/shared/platform/retention"
 )
 
 // Container holds all application dependencies
@@ -26,19 +40,82 @@ type Container struct {
 	Config *config.Config
 	Logger logging.Logger
 
+	// Receives the panics recovered from gRPC calls and Kafka messages
+	CrashReporter recovery.Reporter
+
 	// Database connections
 	PostgresConn *sharedPostgres.Connection
 	RedisConn    *sharedRedis.Connection
+	RedisReplica *sharedRedis.Connection // Session store replica in the standby region; nil when not configured
 	PostgresDB   *sqlx.DB
 	RedisClient  *redis.Client
 
 	// Repositories
-	UserRepository    interfaces.UserRepository
-	SessionRepository interfaces.SessionRepository
+	UserRepository            interfaces.UserRepository
+	SessionRepository         interfaces.SessionRepository
+	AttemptRepository         interfaces.AttemptRepository
+	LoginLinkRepository       interfaces.LoginLinkRepository
+	AccountLockLinkRepository interfaces.AccountLockLinkRepository
+	ServiceClientRepository   interfaces.ServiceClientRepository
+	LoginHistoryRepository    interfaces.LoginHistoryRepository
+	PermissionCacheRepository interfaces.PermissionCacheRepository
+	QuotaRepository           interfaces.QuotaRepository
+
+	// PIIReencryptor rewrites stored PII under the active key; nil unless encryption is enabled
+	PIIReencryptor interfaces.PIIReencryptor
+
+	// SessionStorePromoter fails sessions over to the replica region; nil without a replica
+	SessionStorePromoter interfaces.SessionStorePromoter
+
+	// Session enrichment
+	GeoIPProvider geoip.Provider
 
 	// Services
 	AuthService *service.AuthService
 	UserService *service.UserService
+
+	// Issues tokens to registered service clients
+	ClientCredentialsService *service.ClientCredentialsService
+
+	// Throttles RefreshToken, ValidateSession and IssueClientToken
+	BruteForceGuard *service.BruteForceGuard
+
+	// Meters the requests service clients make through the gateway
+	ClientQuota *service.ClientQuota
+
+	// Requires a CAPTCHA from client IPs with repeated failed logins
+	LoginChallenge *service.LoginChallenge
+
+	// Purges users soft deleted longer than the retention period; nil when disabled
+	UserPurgeJob *service.UserPurgeJob
+
+	// Deletes accounts whose self-service deletion grace period ended
+	AccountDeletionJob *service.AccountDeletionJob
+
+	// Publishes user events for notification-service; nil without Kafka brokers
+	UserEventProducer *iamKafka.UserEventProducer
+
+	// Publishes the user lifecycle events of the outbox; nil without Kafka brokers
+	UserEventRelay *service.UserEventRelay
+
+	// Locks accounts other services report as abusive; nil without Kafka brokers
+	SecurityEventConsumer *iamKafka.SecurityEventConsumer
+
+	// Encrypts the payloads of user events and decrypts security events; nil
+	// without encryption keys
+	PayloadEncryption *kafka.PayloadEncryption
+
+	// Deletes test users with their data across the services; nil unless the
+	// environment allows test data purges
+	TestDataPurge *service.TestDataPurgeService
+	JobStore      jobs.Store
+	JobWorker     *jobs.Worker
+
+	// Maintenance mode switch
+	Maintenance *maintenance.Mode
+
+	// Purges expired audit and job rows; nil when disabled
+	RetentionPurger *retention.Purger
 }
 
 // ContainerConfig holds configuration for container initialization
@@ -61,6 +138,11 @@ func NewContainer(cfg ContainerConfig) (*Container, error) {
 		return nil, fmt.Errorf("failed to initialize config: %w", err)
 	}
 
+	// Initialize crash reporting before anything can handle requests
+	if err := container.initCrashReporter(); err != nil {
+		return nil, fmt.Errorf("failed to initialize crash reporter: %w", err)
+	}
+
 	// Initialize database connections
 	if err := container.initDatabases(); err != nil {
 		return nil, fmt.Errorf("failed to initialize databases: %w", err)
@@ -76,6 +158,14 @@ func NewContainer(cfg ContainerConfig) (*Container, error) {
 		return nil, fmt.Errorf("failed to initialize services: %w", err)
 	}
 
+	// Initialize the purge of expired bookkeeping rows
+	if err := container.initRetention(); err != nil {
+		return nil, fmt.Errorf("failed to initialize retention: %w", err)
+	}
+
+	// Maintenance mode can be preset through MAINTENANCE_MODE and toggled at runtime
+	container.Maintenance = maintenance.FromEnv()
+
 	// Run health checks
 	if err := container.healthCheck(); err != nil {
 		return nil, fmt.Errorf("health check failed: %w", err)
@@ -113,6 +203,23 @@ func (c *Container) initConfig() error {
 	return nil
 }
 
+// initCrashReporter initializes the reporter of recovered panics
+func (c *Container) initCrashReporter() error {
+	recoveryConfig, err := recovery.FromEnv()
+	if err != nil {
+		return err
+	}
+
+	reporter, err := recovery.New(recoveryConfig, "iam-service", grpclog.FromLogger(c.Logger))
+	if err != nil {
+		return err
+	}
+
+	c.CrashReporter = reporter
+	log.Printf("Crash reporter initialized: sentry=%t", recoveryConfig.SentryDSN != "")
+	return nil
+}
+
 // initDatabases initializes all database connections
 func (c *Container) initDatabases() error {
 	// Initialize PostgreSQL connection
@@ -224,41 +331,384 @@ func (c *Container) initRedis() error {
 	c.RedisClient = conn.Client // Extract the underlying *redis.Client
 
 	log.Printf("Redis connection established: %s:%d", redisConfig.Host, redisConfig.Port)
+
+	if replica := c.Config.Redis.Replica; replica.Enabled {
+		redisConfig.Host = replica.Host
+		redisConfig.Port = replica.Port
+		redisConfig.Password = replica.Password
+
+		// The replica is only needed once the primary region is lost, so start without it
+		replicaConn, err := sharedRedis.NewConnection(redisConfig, c.Logger)
+		if err != nil {
+			log.Printf("Warning: Redis replica in %s at %s unreachable, running without session failover: %v",
+				replica.Region, replica.Addr(), err)
+			return nil
+		}
+		c.RedisReplica = replicaConn
+		log.Printf("Redis replica connection established: %s in %s", replica.Addr(), replica.Region)
+	}
 	return nil
 }
 
 // initRepositories initializes all repository instances
 func (c *Container) initRepositories() error {
-	// Initialize User Repository
-	c.UserRepository = postgres.NewUserRepository(c.PostgresDB)
+	// Initialize User Repository, encrypting PII at rest when configured
+	var userRepoOpts []postgres.UserRepositoryOption
+	if c.Config.Encryption.Enabled {
+		keyring, err := c.loadEncryptionKeyring()
+		if err != nil {
+			return err
+		}
+		userRepoOpts = append(userRepoOpts, postgres.WithFieldEncryption(keyring))
+		log.Printf("PII encryption enabled with active key %s", keyring.ActiveKeyID())
+	}
+	c.UserRepository = postgres.NewUserRepository(c.PostgresDB, userRepoOpts...)
+	if c.Config.Encryption.Enabled {
+		c.PIIReencryptor, _ = c.UserRepository.(interfaces.PIIReencryptor)
+	}
+
+	// Initialize Session Repository, replicated to the standby region when configured
+	if c.RedisReplica != nil {
+		replicated := redisRepo.NewReplicatedSessionRepository(c.RedisClient, c.RedisReplica.Client, redisRepo.ReplicationConfig{
+			PrimaryRegion:       c.Config.Redis.Region,
+			ReplicaRegion:       c.Config.Redis.Replica.Region,
+			ReadFallback:        c.Config.Redis.Replica.ReadFallback,
+			MaxStaleness:        c.Config.Redis.Replica.MaxStaleness,
+			StaleBlacklistReads: c.Config.Redis.Replica.StaleBlacklistReads,
+		})
+		c.SessionRepository = replicated
+		c.SessionStorePromoter = replicated
+	} else {
+		c.SessionRepository = redisRepo.NewSessionRepository(c.RedisClient)
+	}
+
+	// Initialize Attempt Repository for brute-force protection
+	c.AttemptRepository = redisRepo.NewAttemptRepository(c.RedisClient)
+
+	// Initialize Login Link Repository for password-less login
+	c.LoginLinkRepository = redisRepo.NewLoginLinkRepository(c.RedisClient)
+
+	// Initialize Account Lock Link Repository for failed login alerts
+	c.AccountLockLinkRepository = redisRepo.NewAccountLockLinkRepository(c.RedisClient)
+
+	// Initialize Permission Cache Repository for session permission snapshots
+	c.PermissionCacheRepository = redisRepo.NewPermissionCacheRepository(c.RedisClient)
+
+	// Initialize Quota Repository for service client request quotas
+	c.QuotaRepository = redisRepo.NewQuotaRepository(c.RedisClient)
 
-	// Initialize Session Repository
-	c.SessionRepository = redisRepo.NewSessionRepository(c.RedisClient)
+	// Initialize Service Client Repository for the client credentials grant
+	c.ServiceClientRepository = postgres.NewServiceClientRepository(c.PostgresDB)
+
+	// Initialize Login History Repository for the logins shown to users
+	c.LoginHistoryRepository = postgres.NewLoginHistoryRepository(c.PostgresDB)
 
 	log.Printf("Repositories initialized successfully")
 	return nil
 }
 
+// loadEncryptionKeyring reads the PII encryption keys from the secrets provider
+func (c *Container) loadEncryptionKeyring() (*encryption.Keyring, error) {
+	cfg := c.Config.Encryption
+
+	provider, err := secrets.NewProvider(cfg.SecretsProvider, cfg.SecretsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize secrets provider: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	keyring, err := encryption.LoadKeyring(ctx, provider, cfg.KeysSecret, cfg.ActiveKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PII encryption keys: %w", err)
+	}
+	return keyring, nil
+}
+
 // initServices initializes all service instances
 func (c *Container) initServices() error {
+	// Session enrichment: GeoIP location and impossible-travel detection
+	geoProvider, err := geoip.NewProvider(c.Config.GeoIP.Provider, c.Config.GeoIP.DatabasePath)
+	if err != nil {
+		return fmt.Errorf("failed to initialize GeoIP provider: %w", err)
+	}
+	c.GeoIPProvider = geoProvider
+	anomalyDetector := service.NewAnomalyDetector(c.SessionRepository, float64(c.Config.Security.ImpossibleTravelSpeedKmh))
+
+	// User events are delivered to the user over Telegram by notification-service
+	if len(c.Config.Kafka.Brokers) > 0 {
+		if err := kafka.VerifyTopics(c.Config.Kafka.Brokers, c.Config.Kafka.Topics, config.UsedTopics...); err != nil {
+			if c.Config.Kafka.Topics.Strict() {
+				return fmt.Errorf("kafka topic check failed: %w", err)
+			}
+			log.Printf("Warning: Kafka topic check failed: %v", err)
+		}
+
+		if c.Config.Kafka.Encryption.Enabled() {
+			encryption, err := c.Config.Kafka.Encryption.NewPayloadEncryption(c.Config.Kafka.Topics)
+			if err != nil {
+				return fmt.Errorf("failed to initialize Kafka payload encryption: %w", err)
+			}
+			c.PayloadEncryption = encryption
+		}
+
+		producer, err := c.newUserEventProducer()
+		if err != nil {
+			return fmt.Errorf("failed to initialize user event producer: %w", err)
+		}
+		c.UserEventProducer = producer
+	} else {
+		log.Printf("Warning: Kafka brokers not configured, account deletion notifications, login links and failed login alerts are disabled and user lifecycle events stay in the outbox")
+	}
+
 	// Initialize Auth Service
+	authServiceOpts := []service.AuthServiceOption{
+		service.WithGeoIPProvider(geoProvider),
+		service.WithAnomalyDetector(anomalyDetector),
+		service.WithPermissionCache(c.PermissionCacheRepository),
+		service.WithLoginHistory(c.LoginHistoryRepository, c.Config.LoginHistory),
+	}
+	if c.UserEventProducer != nil {
+		// Login links are bearer credentials and only travel encrypted
+		if c.PayloadEncryption != nil && c.Config.Kafka.Encryption.ActiveKeyID != "" {
+			authServiceOpts = append(authServiceOpts,
+				service.WithLoginLinks(c.LoginLinkRepository, c.AttemptRepository, c.UserEventProducer, c.Config.LoginLinks))
+		} else {
+			log.Printf("Warning: Kafka encryption keys or active key not configured, login links are disabled")
+		}
+		authServiceOpts = append(authServiceOpts,
+			service.WithLoginAlerts(c.AttemptRepository, c.AccountLockLinkRepository, c.UserEventProducer, c.Config.LoginAlerts))
+	}
 	c.AuthService = service.NewAuthService(
 		c.UserRepository,
 		c.SessionRepository,
 		c.Config,
+		authServiceOpts...,
 	)
 
+	// Initialize brute-force protection for token endpoints
+	c.BruteForceGuard = service.NewBruteForceGuard(c.AttemptRepository, c.Config.Security)
+
+	// Initialize the CAPTCHA challenge for repeated failed logins
+	captchaCfg := c.Config.Captcha
+	verifier, err := captcha.NewVerifier(captchaCfg.Provider, captchaCfg.SecretKey, captchaCfg.VerifyURL, captchaCfg.Timeout)
+	if err != nil {
+		return fmt.Errorf("failed to initialize CAPTCHA verifier: %w", err)
+	}
+	c.LoginChallenge = service.NewLoginChallenge(c.AttemptRepository, verifier, captchaCfg)
+
+	// Account deletion requests are announced to the user, and changes to users
+	// to the services caching their data
+	userServiceOpts := []service.UserServiceOption{
+		service.WithPermissionVersions(c.PermissionCacheRepository),
+	}
+	if c.UserEventProducer != nil {
+		userServiceOpts = append(userServiceOpts,
+			service.WithDeletionNotifier(c.UserEventProducer),
+			service.WithUserChangeNotifier(c.UserEventProducer))
+	}
+
 	// Initialize User Service
 	c.UserService = service.NewUserService(
 		c.UserRepository,
 		c.SessionRepository,
 		c.Config,
+		userServiceOpts...,
+	)
+
+	// User lifecycle events, recorded in the outbox by the users table's trigger,
+	// are relayed to the user events topic
+	if c.UserEventProducer != nil {
+		c.UserEventRelay = service.NewUserEventRelay(
+			postgres.NewUserEventOutboxRepository(c.PostgresDB),
+			c.UserEventProducer,
+			c.Config.Kafka.OutboxRelayInterval,
+			c.Config.Kafka.OutboxRelayBatchSize,
+		)
+	}
+
+	if len(c.Config.Kafka.Brokers) > 0 {
+		consumer, err := c.newSecurityEventConsumer()
+		if err != nil {
+			return fmt.Errorf("failed to initialize security event consumer: %w", err)
+		}
+		c.SecurityEventConsumer = consumer
+	} else {
+		log.Printf("Warning: Kafka brokers not configured, accounts reported as abusive are not locked")
+	}
+
+	// Initialize client credentials grant for service clients
+	c.ClientCredentialsService = service.NewClientCredentialsService(
+		c.ServiceClientRepository,
+		c.SessionRepository,
+		c.Config,
+	)
+	c.ClientQuota = service.NewClientQuota(c.QuotaRepository, c.Config.Clients.Quota)
+
+	// Initialize retention purge of deleted users
+	if c.Config.Retention.PurgeEnabled {
+		c.UserPurgeJob = service.NewUserPurgeJob(
+			c.UserRepository,
+			c.SessionRepository,
+			time.Duration(c.Config.Retention.DeletedUserRetentionDays)*24*time.Hour,
+			c.Config.Retention.PurgeInterval,
+			c.Config.Retention.PurgeBatchSize,
+		)
+	}
+
+	// Initialize finalization of self-service account deletions
+	c.AccountDeletionJob = service.NewAccountDeletionJob(
+		c.UserRepository,
+		c.SessionRepository,
+		c.Config.Deletion.FinalizeInterval,
+		c.Config.Deletion.FinalizeBatchSize,
 	)
 
+	// Initialize the test data purge where the environment allows it
+	gate, err := purge.GateFromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid test data purge gate: %w", err)
+	}
+	if gate.Check() == nil {
+		if err := c.initTestDataPurge(gate); err != nil {
+			return fmt.Errorf("failed to initialize test data purge: %w", err)
+		}
+		log.Printf("Warning: test data purge enabled in the %s environment", gate.Environment)
+	}
+
 	log.Printf("Services initialized successfully")
 	return nil
 }
 
+// initTestDataPurge creates the purge saga over the services and the job
+// worker running it
+func (c *Container) initTestDataPurge(gate purge.Gate) error {
+	purgeCfg := c.Config.Purge
+	participants := make(map[string]purge.Participant)
+	for name, url := range map[string]string{
+		"order-service":        purgeCfg.OrderURL,
+		"inventory-service":    purgeCfg.InventoryURL,
+		"payment-service":      purgeCfg.PaymentURL,
+		"notification-service": purgeCfg.NotificationURL,
+	} {
+		client, err := purge.NewClient(name, url, purgeCfg.AdminToken, purgeCfg.RequestTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to create %s purge client: %w", name, err)
+		}
+		participants[name] = client
+	}
+
+	store := jobs.NewPostgresStore(c.PostgresDB)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := store.EnsureSchema(ctx); err != nil {
+		return fmt.Errorf("failed to create job schema: %w", err)
+	}
+
+	sharedMetrics, err := metrics.NewMetrics("iam-service")
+	if err != nil {
+		return fmt.Errorf("failed to create metrics: %w", err)
+	}
+
+	c.JobStore = store
+	c.JobWorker = jobs.NewWorker(store, jobs.DefaultWorkerConfig(), c.Logger, sharedMetrics)
+	c.TestDataPurge = service.NewTestDataPurgeService(
+		c.UserRepository,
+		c.SessionRepository,
+		store,
+		gate,
+		purgeCfg.EmailDomains,
+		participants["order-service"],
+		participants["inventory-service"],
+		participants["payment-service"],
+		participants["notification-service"],
+	)
+	c.TestDataPurge.Register(c.JobWorker)
+	return nil
+}
+
+// initRetention creates the purger of the audit trail of purged users, of
+// published user lifecycle events and, where the test data purge runs, of its
+// finished jobs
+func (c *Container) initRetention() error {
+	cfg := c.Config.DataRetention
+	if !cfg.Enabled {
+		return nil
+	}
+
+	sharedMetrics, err := metrics.NewMetrics("iam-service")
+	if err != nil {
+		return fmt.Errorf("failed to create metrics: %w", err)
+	}
+
+	policies := []retention.Policy{
+		postgres.PurgeAuditRetentionPolicy(cfg.PurgeAuditTTL),
+		postgres.UserEventOutboxRetentionPolicy(cfg.UserEventsTTL),
+	}
+	if c.JobStore != nil {
+		policies = append(policies, jobs.RetentionPolicy(cfg.JobsTTL))
+	}
+
+	purger, err := retention.NewPurger(c.PostgresDB, cfg.Config, policies, c.Logger, sharedMetrics)
+	if err != nil {
+		return err
+	}
+	c.RetentionPurger = purger
+	return nil
+}
+
+// newUserEventProducer creates the Kafka producer for user events
+func (c *Container) newUserEventProducer() (*iamKafka.UserEventProducer, error) {
+	sharedMetrics, err := metrics.NewMetrics("iam-service")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics: %w", err)
+	}
+
+	producerConfig := kafka.DefaultProducerConfig()
+	producerConfig.Brokers = c.Config.Kafka.Brokers
+	producerConfig.ClientID = "iam-service"
+
+	producer, err := kafka.NewProducer(producerConfig, c.Logger, sharedMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+	}
+	if c.PayloadEncryption != nil {
+		producer.SetPayloadEncryption(c.PayloadEncryption)
+	}
+
+	topic := c.Config.Kafka.Topics.Name(topics.UserEvents)
+	log.Printf("User event producer initialized: brokers=%v topic=%s", c.Config.Kafka.Brokers, topic)
+	return iamKafka.NewUserEventProducer(producer, topic, c.PayloadEncryption), nil
+}
+
+// newSecurityEventConsumer creates the Kafka consumer for security events reported by other services
+func (c *Container) newSecurityEventConsumer() (*iamKafka.SecurityEventConsumer, error) {
+	sharedMetrics, err := metrics.NewMetrics("iam-service")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics: %w", err)
+	}
+
+	consumerConfig := kafka.DefaultConsumerConfig()
+	consumerConfig.Brokers = c.Config.Kafka.Brokers
+	consumerConfig.GroupID = c.Config.Kafka.ConsumerGroup
+	consumerConfig.ClientID = "iam-service"
+	topic := c.Config.Kafka.Topics.Name(topics.SecurityEvents)
+	consumerConfig.Topics = []string{topic}
+
+	consumer, err := kafka.NewConsumer(consumerConfig, c.Logger, sharedMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka consumer: %w", err)
+	}
+	if c.PayloadEncryption != nil {
+		consumer.SetPayloadEncryption(c.PayloadEncryption)
+	}
+
+	log.Printf("Security event consumer initialized: brokers=%v topic=%s group=%s",
+		c.Config.Kafka.Brokers, topic, c.Config.Kafka.ConsumerGroup)
+	return iamKafka.NewSecurityEventConsumer(consumer, topic, c.UserService, c.CrashReporter), nil
+}
+
 // healthCheck performs health checks on all components
 func (c *Container) healthCheck() error {
 	ctx := context.Background()
@@ -293,12 +743,31 @@ func (c *Container) Close() error {
 		}
 	}
 
-	// Close Redis connection
+	// Close Redis connections
 	if c.RedisConn != nil {
 		if err := c.RedisConn.Close(); err != nil {
 			errors = append(errors, fmt.Errorf("failed to close Redis: %w", err))
 		}
 	}
+	if c.RedisReplica != nil {
+		if err := c.RedisReplica.Close(); err != nil {
+			errors = append(errors, fmt.Errorf("failed to close Redis replica: %w", err))
+		}
+	}
+
+	// Close Kafka producer
+	if c.UserEventProducer != nil {
+		if err := c.UserEventProducer.Close(); err != nil {
+			errors = append(errors, fmt.Errorf("failed to close user event producer: %w", err))
+		}
+	}
+
+	// Close GeoIP database
+	if c.GeoIPProvider != nil {
+		if err := c.GeoIPProvider.Close(); err != nil {
+			errors = append(errors, fmt.Errorf("failed to close GeoIP provider: %w", err))
+		}
+	}
 
 	if len(errors) > 0 {
 		return fmt.Errorf("errors during container shutdown: %v", errors)
@@ -328,6 +797,76 @@ func (c *Container) GetSessionRepository() interfaces.SessionRepository {
 	return c.SessionRepository
 }
 
+// GetClientCredentialsService returns the service client token service
+func (c *Container) GetClientCredentialsService() *service.ClientCredentialsService {
+	return c.ClientCredentialsService
+}
+
+// GetClientQuota returns the service client request quota
+func (c *Container) GetClientQuota() *service.ClientQuota {
+	return c.ClientQuota
+}
+
+// GetBruteForceGuard returns the token endpoint brute-force guard
+func (c *Container) GetBruteForceGuard() *service.BruteForceGuard {
+	return c.BruteForceGuard
+}
+
+// GetSessionStorePromoter returns the session store failover operation, nil without a replica
+func (c *Container) GetSessionStorePromoter() interfaces.SessionStorePromoter {
+	return c.SessionStorePromoter
+}
+
+// GetLoginChallenge returns the login CAPTCHA challenge
+func (c *Container) GetLoginChallenge() *service.LoginChallenge {
+	return c.LoginChallenge
+}
+
+// GetUserPurgeJob returns the deleted user purge job, or nil when disabled
+func (c *Container) GetUserPurgeJob() *service.UserPurgeJob {
+	return c.UserPurgeJob
+}
+
+// GetSecurityEventConsumer returns the security event consumer, nil without Kafka brokers
+func (c *Container) GetSecurityEventConsumer() *iamKafka.SecurityEventConsumer {
+	return c.SecurityEventConsumer
+}
+
+// GetUserEventRelay returns the relay of the user events outbox, or nil without Kafka brokers
+func (c *Container) GetUserEventRelay() *service.UserEventRelay {
+	return c.UserEventRelay
+}
+
+// GetAccountDeletionJob returns the self-service account deletion job
+func (c *Container) GetAccountDeletionJob() *service.AccountDeletionJob {
+	return c.AccountDeletionJob
+}
+
+// GetTestDataPurge returns the test data purge, or nil when the environment does not allow it
+func (c *Container) GetTestDataPurge() *service.TestDataPurgeService {
+	return c.TestDataPurge
+}
+
+// GetJobStore returns the background job store, or nil when no job runs
+func (c *Container) GetJobStore() jobs.Store {
+	return c.JobStore
+}
+
+// GetJobWorker returns the background job worker, or nil when no job runs
+func (c *Container) GetJobWorker() *jobs.Worker {
+	return c.JobWorker
+}
+
+// GetRetentionPurger returns the purger of expired bookkeeping rows, or nil when disabled
+func (c *Container) GetRetentionPurger() *retention.Purger {
+	return c.RetentionPurger
+}
+
+// GetCrashReporter returns the reporter of recovered panics
+func (c *Container) GetCrashReporter() recovery.Reporter {
+	return c.CrashReporter
+}
+
 // GetConfig returns the configuration instance
 func (c *Container) GetConfig() *config.Config {
 	return c.Config
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// GeoLocation is the approximate location of a session's client IP address
+type GeoLocation struct {
+	CountryCode string  `json:"country_code,omitempty"`
+	Country     string  `json:"country,omitempty"`
+	City        string  `json:"city,omitempty"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	AccuracyKm  int     `json:"accuracy_km,omitempty"`
+}
+
+// Device types reported in DeviceInfo
+const (
+	DeviceTypeDesktop = "desktop"
+	DeviceTypeMobile  = "mobile"
+	DeviceTypeTablet  = "tablet"
+	DeviceTypeBot     = "bot"
+	DeviceTypeUnknown = "unknown"
+)
+
+// DeviceInfo describes the client device parsed from a session's user agent
+type DeviceInfo struct {
+	Browser     string `json:"browser,omitempty"`
+	OS          string `json:"os,omitempty"`
+	Type        string `json:"type"`
+	Description string `json:"description"` // e.g. "Chrome on macOS (desktop)"
+}
+
+// earthRadiusKm is the mean Earth radius used for distance calculations
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance between two locations
+func (l *GeoLocation) DistanceKm(other *GeoLocation) float64 {
+	lat1 := l.Latitude * math.Pi / 180
+	lat2 := other.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (other.Longitude - l.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// String returns a human-readable location such as "Berlin, Germany"
+func (l *GeoLocation) String() string {
+	switch {
+	case l.City != "" && l.Country != "":
+		return fmt.Sprintf("%s, %s", l.City, l.Country)
+	case l.Country != "":
+		return l.Country
+	default:
+		return fmt.Sprintf("%.2f, %.2f", l.Latitude, l.Longitude)
+	}
+}
+
+// userAgentPattern matches a product token and its major version
+type userAgentPattern struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// Order matters: several browsers also include the tokens of the ones they
+// are based on (Edge and Opera include Chrome, Chrome includes Safari)
+var browserPatterns = []userAgentPattern{
+	{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/(\d+)`)},
+	{"Opera", regexp.MustCompile(`(?:OPR|Opera)/(\d+)`)},
+	{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/(\d+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/(\d+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/(\d+)`)},
+	{"Safari", regexp.MustCompile(`Version/(\d+).*Safari/`)},
+}
+
+var osPatterns = []userAgentPattern{
+	{"iOS", regexp.MustCompile(`(?:iPhone|iPad|iPod).*? OS (\d+)`)},
+	{"Android", regexp.MustCompile(`Android (\d+)`)},
+	{"Windows", regexp.MustCompile(`Windows NT (\d+)`)},
+	{"ChromeOS", regexp.MustCompile(`CrOS`)},
+	{"macOS", regexp.MustCompile(`Mac OS X`)},
+	{"Linux", regexp.MustCompile(`Linux`)},
+}
+
+// Common non-browser clients such as SDKs and command-line tools
+var clientPatterns = []userAgentPattern{
+	{"grpc-go", regexp.MustCompile(`grpc-go/(\d+)`)},
+	{"curl", regexp.MustCompile(`curl/(\d+)`)},
+	{"Go HTTP client", regexp.MustCompile(`Go-http-client/(\d+)`)},
+	{"Postman", regexp.MustCompile(`PostmanRuntime/(\d+)`)},
+}
+
+var botPattern = regexp.MustCompile(`(?i)bot|crawler|spider|slurp`)
+
+// ParseUserAgent derives a device description from a User-Agent header. It
+// recognises the common browsers and platforms; anything else is reported
+// as an unknown device rather than failing.
+func ParseUserAgent(userAgent string) *DeviceInfo {
+	userAgent = strings.TrimSpace(userAgent)
+	device := &DeviceInfo{Type: DeviceTypeUnknown}
+
+	if userAgent == "" {
+		device.Description = "Unknown device"
+		return device
+	}
+
+	if botPattern.MatchString(userAgent) {
+		device.Type = DeviceTypeBot
+		device.Description = "Bot"
+		return device
+	}
+
+	device.Browser = matchUserAgent(browserPatterns, userAgent)
+	if device.Browser == "" {
+		device.Browser = matchUserAgent(clientPatterns, userAgent)
+	}
+	device.OS = matchUserAgent(osPatterns, userAgent)
+
+	switch {
+	case strings.Contains(userAgent, "iPad") || strings.Contains(userAgent, "Tablet"):
+		device.Type = DeviceTypeTablet
+	case strings.Contains(userAgent, "Mobi") || strings.Contains(userAgent, "iPhone"):
+		device.Type = DeviceTypeMobile
+	case strings.HasPrefix(device.OS, "Android"):
+		device.Type = DeviceTypeTablet // Android browsers omit "Mobile" on tablets
+	case device.OS != "":
+		device.Type = DeviceTypeDesktop
+	}
+
+	switch {
+	case device.Browser != "" && device.OS != "":
+		device.Description = fmt.Sprintf("%s on %s", device.Browser, device.OS)
+	case device.Browser != "":
+		device.Description = device.Browser
+	case device.OS != "":
+		device.Description = "Unknown browser on " + device.OS
+	default:
+		device.Description = "Unknown device"
+	}
+	if device.Type != DeviceTypeUnknown {
+		device.Description += " (" + device.Type + ")"
+	}
+
+	return device
+}
+
+// matchUserAgent returns the first matching pattern's name with the major
+// version appended when the pattern captures one
+func matchUserAgent(patterns []userAgentPattern, userAgent string) string {
+	for _, p := range patterns {
+		match := p.pattern.FindStringSubmatch(userAgent)
+		if match == nil {
+			continue
+		}
+		if p.name == "Windows" {
+			return windowsVersion(match[1])
+		}
+		if len(match) > 1 && match[1] != "" {
+			return p.name + " " + match[1]
+		}
+		return p.name
+	}
+	return ""
+}
+
+// windowsVersion maps the NT kernel major version to a marketing name
+func windowsVersion(ntMajor string) string {
+	switch ntMajor {
+	case "10":
+		return "Windows 10/11"
+	case "6":
+		return "Windows 7/8"
+	default:
+		return "Windows"
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"time"
+)
+
+// ErrInvalidLockLink is returned for unknown, used or expired account lock links
+var ErrInvalidLockLink = errors.New("invalid or expired account lock link")
+
+// FailedLoginBurst describes failed logins to an account piling up within a
+// short window, reported to the account owner with the latest attempt's origin
+type FailedLoginBurst struct {
+	UserID     string        `json:"user_id"`
+	Attempts   int64         `json:"attempts"`
+	Window     time.Duration `json:"window"`
+	IPAddress  string        `json:"ip_address"`
+	Device     *DeviceInfo   `json:"device,omitempty"`
+	Location   *GeoLocation  `json:"location,omitempty"`
+	OccurredAt time.Time     `json:"occurred_at"`
+}
+
+// AccountLockLink is a single-use link sent with a failed login alert, letting
+// the owner lock their account without logging in. Only the hash of its token
+// is stored.
+type AccountLockLink struct {
+	TokenHash string    `json:"-"`
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewAccountLockLink creates a lock link for a user and returns it with its
+// token, which is only handed to the user
+func NewAccountLockLink(userID string, ttl time.Duration) (*AccountLockLink, string, error) {
+	token, err := randomToken(loginLinkTokenBytes, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &AccountLockLink{
+		TokenHash: HashLoginLinkToken(token),
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, token, nil
+}
+
+// IsExpired checks if the link can no longer be used
+func (l *AccountLockLink) IsExpired() bool {
+	return time.Now().After(l.ExpiresAt)
+}
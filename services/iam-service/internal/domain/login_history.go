@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Login methods recorded in the login history
+const (
+	LoginMethodPassword  = "password"
+	LoginMethodLoginLink = "login_link"
+)
+
+// Reasons a login recorded in the login history failed
+const (
+	LoginFailureInvalidCredentials = "invalid_credentials"
+	LoginFailureAccountLocked      = "account_locked"
+	LoginFailureAccountInactive    = "account_inactive"
+)
+
+// LoginEvent is a login to an account, successful or not, kept so the owner can
+// review recent access to it. Logins to unknown emails belong to no account and
+// are not recorded.
+type LoginEvent struct {
+	ID            int64        `json:"id"`
+	UserID        string       `json:"user_id"`
+	OccurredAt    time.Time    `json:"occurred_at"`
+	Method        string       `json:"method"`
+	Success       bool         `json:"success"`
+	FailureReason string       `json:"failure_reason,omitempty"`
+	IPAddress     string       `json:"ip_address"`
+	UserAgent     string       `json:"user_agent"`
+	Device        *DeviceInfo  `json:"device,omitempty"`   // Parsed from the user agent
+	Location      *GeoLocation `json:"location,omitempty"` // Approximate location of the IP address, if known
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// loginLinkTokenBytes is the entropy of a login link token
+const loginLinkTokenBytes = 32
+
+// Login link errors
+var (
+	ErrInvalidLoginLink     = errors.New("invalid or expired login link")
+	ErrLoginLinkRateLimited = errors.New("too many login link requests")
+)
+
+// LoginLink is a single-use, password-less login sent to a user. Only the
+// hash of its token is stored, and the link is bound to the user agent it
+// was requested from, so a link forwarded or intercepted in transit cannot
+// be completed from another browser.
+type LoginLink struct {
+	TokenHash     string    `json:"-"`
+	UserID        string    `json:"user_id"`
+	UserAgentHash string    `json:"user_agent_hash"`
+	IPAddress     string    `json:"ip_address"` // Where the link was requested from
+	CreatedAt     time.Time `json:"created_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// NewLoginLink creates a login link for a user and returns it with its token,
+// which is only handed to the user
+func NewLoginLink(userID, ipAddress, userAgent string, ttl time.Duration) (*LoginLink, string, error) {
+	token, err := randomToken(loginLinkTokenBytes, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &LoginLink{
+		TokenHash:     HashLoginLinkToken(token),
+		UserID:        userID,
+		UserAgentHash: hashUserAgent(userAgent),
+		IPAddress:     ipAddress,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(ttl),
+	}, token, nil
+}
+
+// HashLoginLinkToken returns the hash a login link is stored under
+func HashLoginLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired checks if the link can no longer be used
+func (l *LoginLink) IsExpired() bool {
+	return time.Now().After(l.ExpiresAt)
+}
+
+// MatchesUserAgent reports whether the link is completed from the user agent
+// it was requested from
+func (l *LoginLink) MatchesUserAgent(userAgent string) bool {
+	return l.UserAgentHash == hashUserAgent(userAgent)
+}
+
+func hashUserAgent(userAgent string) string {
+	sum := sha256.Sum256([]byte(userAgent))
+	return hex.EncodeToString(sum[:])
+}
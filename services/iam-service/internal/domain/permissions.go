@@ -0,0 +1,77 @@
+package domain
+
+import "time"
+
+// RolePermissions returns the permissions granted to a role. Permissions are
+// "resource.action" pairs, where "resource.*" grants every action on a resource
+// and "*" grants everything.
+func RolePermissions(role UserRole) []string {
+	switch role {
+	case RoleAdmin:
+		return []string{
+			"*", // Admin has all permissions
+		}
+	case RoleOperator:
+		return []string{
+			"orders.*",
+			"inventory.*",
+			"users.read",
+			"users.update",
+		}
+	case RoleSupport:
+		return []string{
+			"orders.read",
+			"orders.update",
+			"users.read",
+			"inventory.read",
+		}
+	case RoleCustomer:
+		return []string{
+			"orders.create",
+			"orders.read", // Own orders only
+			"profile.*",
+		}
+	default:
+		return []string{
+			"profile.read",
+		}
+	}
+}
+
+// HasPermission reports whether permissions grant the action on the resource
+func HasPermission(permissions []string, resource, action string) bool {
+	for _, perm := range permissions {
+		if perm == "*" || perm == resource+".*" || perm == resource+"."+action {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionSnapshot is the permissions a session's user held when it was taken.
+// Snapshots are cached per session so the gateway can authorize requests without
+// asking IAM about each permission; a snapshot is only valid while Version still
+// matches the user's permission version, which changes with their role.
+type PermissionSnapshot struct {
+	UserID      string    `json:"user_id"`
+	Role        UserRole  `json:"role"`
+	Permissions []string  `json:"permissions"`
+	Version     int64     `json:"version"`
+	TakenAt     time.Time `json:"taken_at"`
+}
+
+// NewPermissionSnapshot takes a snapshot of a user's permissions at a permission version
+func NewPermissionSnapshot(user *User, version int64) *PermissionSnapshot {
+	return &PermissionSnapshot{
+		UserID:      user.ID,
+		Role:        user.Role,
+		Permissions: RolePermissions(user.Role),
+		Version:     version,
+		TakenAt:     time.Now(),
+	}
+}
+
+// IsCurrent reports whether the snapshot still reflects a user at a permission version
+func (p *PermissionSnapshot) IsCurrent(user *User, version int64) bool {
+	return p.Version == version && p.UserID == user.ID && p.Role == user.Role
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+	_ "time/tzdata" // Validate time zones without relying on the image's zoneinfo
+)
+
+// Preferences are the typed user settings, stored apart from the free-form metadata
+type Preferences struct {
+	Locale         string                  `json:"locale"`   // BCP 47 language tag, e.g. "en" or "pt-BR"
+	Timezone       string                  `json:"timezone"` // IANA time zone, e.g. "Europe/Berlin"
+	MarketingOptIn bool                    `json:"marketing_opt_in"`
+	Notifications  NotificationPreferences `json:"notifications"`
+}
+
+// NotificationPreferences select the notifications a user receives and their channels
+type NotificationPreferences struct {
+	OrderUpdates   bool `json:"order_updates"`
+	SecurityAlerts bool `json:"security_alerts"`
+	Telegram       bool `json:"telegram"`
+	Email          bool `json:"email"`
+}
+
+// Preference defaults
+const (
+	DefaultLocale   = "en"
+	DefaultTimezone = "UTC"
+)
+
+// ErrInvalidPreferences is returned for preferences that fail validation
+var ErrInvalidPreferences = errors.New("invalid preferences")
+
+// localePattern matches a language with optional script and region subtags
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z][a-z]{3})?(-([A-Z]{2}|[0-9]{3}))?$`)
+
+// DefaultPreferences returns the preferences of a user who hasn't set any. Users
+// receive every notification, but marketing needs an explicit opt-in.
+func DefaultPreferences() Preferences {
+	return Preferences{
+		Locale:   DefaultLocale,
+		Timezone: DefaultTimezone,
+		Notifications: NotificationPreferences{
+			OrderUpdates:   true,
+			SecurityAlerts: true,
+			Telegram:       true,
+			Email:          true,
+		},
+	}
+}
+
+// Validate checks the locale and time zone
+func (p Preferences) Validate() error {
+	if err := validateLocale(p.Locale); err != nil {
+		return err
+	}
+	return validateTimezone(p.Timezone)
+}
+
+func validateLocale(locale string) error {
+	if !localePattern.MatchString(locale) {
+		return fmt.Errorf("%w: locale %q is not a language tag such as en or pt-BR", ErrInvalidPreferences, locale)
+	}
+	return nil
+}
+
+func validateTimezone(timezone string) error {
+	if timezone == "" || timezone == "Local" {
+		return fmt.Errorf("%w: timezone is required", ErrInvalidPreferences)
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidPreferences, timezone)
+	}
+	return nil
+}
+
+// Metadata keys that held preferences before they were typed. Of the alternative keys
+// for a setting, the last one present wins.
+var (
+	legacyLocaleKeys   = []string{"language", "locale"}
+	legacyTimezoneKeys = []string{"time_zone", "timezone"}
+	legacyBoolKeys     = map[string]func(*Preferences) *bool{
+		"marketing_opt_in":       func(p *Preferences) *bool { return &p.MarketingOptIn },
+		"notify_order_updates":   func(p *Preferences) *bool { return &p.Notifications.OrderUpdates },
+		"notify_security_alerts": func(p *Preferences) *bool { return &p.Notifications.SecurityAlerts },
+		"notify_telegram":        func(p *Preferences) *bool { return &p.Notifications.Telegram },
+		"notify_email":           func(p *Preferences) *bool { return &p.Notifications.Email },
+	}
+)
+
+// MigrateLegacyPreferences moves preferences kept in the metadata of a user into
+// their typed preferences. Keys holding valid values are removed from the metadata;
+// invalid values are left where they are. It reports whether anything was moved.
+func (u *User) MigrateLegacyPreferences() bool {
+	migrated := false
+
+	for _, key := range legacyLocaleKeys {
+		if value, ok := u.Metadata[key]; ok && validateLocale(value) == nil {
+			u.Preferences.Locale = value
+			delete(u.Metadata, key)
+			migrated = true
+		}
+	}
+	for _, key := range legacyTimezoneKeys {
+		if value, ok := u.Metadata[key]; ok && validateTimezone(value) == nil {
+			u.Preferences.Timezone = value
+			delete(u.Metadata, key)
+			migrated = true
+		}
+	}
+	for key, field := range legacyBoolKeys {
+		value, ok := u.Metadata[key]
+		if !ok {
+			continue
+		}
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			*field(&u.Preferences) = enabled
+			delete(u.Metadata, key)
+			migrated = true
+		}
+	}
+
+	return migrated
+}
@@ -0,0 +1,255 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ServiceClient is a machine client, such as the gateway or the dashboard, that
+// obtains tokens with the client credentials grant instead of a user's login.
+// Only a bcrypt hash of its secret is kept.
+type ServiceClient struct {
+	ID              string              `json:"id" db:"id"`
+	ClientID        string              `json:"client_id" db:"client_id"`
+	Name            string              `json:"name" db:"name"`
+	SecretHash      string              `json:"-" db:"secret_hash"`
+	Scopes          []string            `json:"scopes" db:"scopes"`
+	Status          ServiceClientStatus `json:"status" db:"status"`
+	CreatedBy       string              `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt       time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at" db:"updated_at"`
+	SecretRotatedAt time.Time           `json:"secret_rotated_at" db:"secret_rotated_at"`
+	LastUsedAt      *time.Time          `json:"last_used_at,omitempty" db:"last_used_at"`
+}
+
+// ServiceClientStatus represents whether a client may obtain tokens
+type ServiceClientStatus string
+
+const (
+	ServiceClientStatusActive   ServiceClientStatus = "active"
+	ServiceClientStatusDisabled ServiceClientStatus = "disabled"
+)
+
+// ClientTokenAudience marks client credentials tokens so they are never
+// accepted where a user's session token is expected, and vice versa
+const ClientTokenAudience = "service-client"
+
+// Service client errors
+var (
+	ErrServiceClientNotFound   = errors.New("service client not found")
+	ErrServiceClientExists     = errors.New("service client already exists")
+	ErrServiceClientDisabled   = errors.New("service client is disabled")
+	ErrInvalidClientCredential = errors.New("invalid client credentials")
+	ErrInvalidScope            = errors.New("invalid scope")
+	ErrScopeNotGranted         = errors.New("scope not granted to client")
+)
+
+// Scopes are lower-case words joined by ':', '.' or '_', e.g. "orders:read"
+var scopePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*([:.][a-z0-9_*]+)*$`)
+
+// clientSecretBytes is the entropy of generated client secrets
+const clientSecretBytes = 32
+
+// NewServiceClient registers a client with the scopes it may request. It returns
+// the client and its secret, which is only ever available here and on rotation.
+func NewServiceClient(name string, scopes []string, createdBy string) (*ServiceClient, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("service client name is required")
+	}
+	scopes, err := NormalizeScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("%w: at least one scope is required", ErrInvalidScope)
+	}
+
+	clientID, err := randomToken(12, hex.EncodeToString)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	client := &ServiceClient{
+		ID:        uuid.New().String(),
+		ClientID:  "svc_" + clientID,
+		Name:      name,
+		Scopes:    scopes,
+		Status:    ServiceClientStatusActive,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	secret, err := client.RotateSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	return client, secret, nil
+}
+
+// RotateSecret replaces the client's secret and returns the new one. Tokens
+// issued with the old secret stay valid until they expire.
+func (c *ServiceClient) RotateSecret() (string, error) {
+	secret, err := randomToken(clientSecretBytes, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return "", err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash client secret: %w", err)
+	}
+
+	now := time.Now()
+	c.SecretHash = string(hash)
+	c.SecretRotatedAt = now
+	c.UpdatedAt = now
+	return secret, nil
+}
+
+// Authenticate checks the client's secret and that it may obtain tokens
+func (c *ServiceClient) Authenticate(secret string) error {
+	if bcrypt.CompareHashAndPassword([]byte(c.SecretHash), []byte(secret)) != nil {
+		return ErrInvalidClientCredential
+	}
+	if c.Status != ServiceClientStatusActive {
+		return ErrServiceClientDisabled
+	}
+	return nil
+}
+
+// GrantScopes returns the scopes of a token requesting the given scopes; no
+// requested scopes grants all of the client's scopes
+func (c *ServiceClient) GrantScopes(requested []string) ([]string, error) {
+	requested, err := NormalizeScopes(requested)
+	if err != nil {
+		return nil, err
+	}
+	if len(requested) == 0 {
+		return append([]string(nil), c.Scopes...), nil
+	}
+	for _, scope := range requested {
+		if !c.HasScope(scope) {
+			return nil, fmt.Errorf("%w: %s", ErrScopeNotGranted, scope)
+		}
+	}
+	return requested, nil
+}
+
+// HasScope reports whether the client was registered with the scope
+func (c *ServiceClient) HasScope(scope string) bool {
+	for _, granted := range c.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Disable stops the client from obtaining new tokens
+func (c *ServiceClient) Disable() {
+	c.Status = ServiceClientStatusDisabled
+	c.UpdatedAt = time.Now()
+}
+
+// NormalizeScopes validates, de-duplicates and sorts scopes
+func NormalizeScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || seen[scope] {
+			continue
+		}
+		if !scopePattern.MatchString(scope) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidScope, scope)
+		}
+		seen[scope] = true
+		normalized = append(normalized, scope)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// ClientClaims are the claims of a client credentials access token
+type ClientClaims struct {
+	ClientID string   `json:"client_id"`
+	Scopes   []string `json:"scopes"`
+	jwt.RegisteredClaims
+}
+
+// HasScope reports whether the token grants the scope
+func (c *ClientClaims) HasScope(scope string) bool {
+	for _, granted := range c.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateClientToken signs a short-lived access token for the client
+func GenerateClientToken(client *ServiceClient, scopes []string, secretKey, issuer string, duration time.Duration) (string, *ClientClaims, error) {
+	now := time.Now()
+	claims := &ClientClaims{
+		ClientID: client.ClientID,
+		Scopes:   scopes,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    issuer,
+			Subject:   client.ClientID,
+			Audience:  jwt.ClaimStrings{ClientTokenAudience},
+			ID:        uuid.New().String(),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secretKey))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate client token: %w", err)
+	}
+	return token, claims, nil
+}
+
+// ValidateClientToken validates a client credentials token and returns its claims
+func ValidateClientToken(tokenString, secretKey string) (*ClientClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &ClientClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secretKey), nil
+	}, jwt.WithAudience(ClientTokenAudience))
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*ClientClaims)
+	if !ok || !token.Valid || claims.ClientID == "" {
+		return nil, ErrInvalidJWTClaims
+	}
+	return claims, nil
+}
+
+// randomToken returns n random bytes in the given encoding
+func randomToken(n int, encode func([]byte) string) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return encode(buf), nil
+}
@@ -22,6 +22,15 @@ type Session struct {
 	UserAgent        string        `json:"user_agent" redis:"user_agent"`
 	Status           SessionStatus `json:"status" redis:"status"`
 	RefreshExpiresAt time.Time     `json:"refresh_expires_at" redis:"refresh_expires_at"`
+
+	// Version is stamped into the session's tokens and bumped when the user's role
+	// or status changes, so tokens issued before the change stop validating
+	Version int64 `json:"version,omitempty" redis:"-"`
+
+	// Enrichment recorded when the session is created
+	Location  *GeoLocation `json:"location,omitempty" redis:"-"`
+	Device    *DeviceInfo  `json:"device,omitempty" redis:"-"`
+	Anomalies []string     `json:"anomalies,omitempty" redis:"-"` // Reasons the anomaly detector flagged the session
 }
 
 // SessionStatus represents session status
@@ -41,6 +50,7 @@ type JWTClaims struct {
 	Role      string    `json:"role"`
 	Email     string    `json:"email"`
 	IssuedAt  time.Time `json:"iat"`
+	Version   int64     `json:"sv,omitempty"` // Version of the session the token was issued at
 	jwt.RegisteredClaims
 }
 
@@ -50,6 +60,7 @@ var (
 	ErrSessionExpired      = errors.New("session has expired")
 	ErrSessionRevoked      = errors.New("session has been revoked")
 	ErrSessionInvalid      = errors.New("session is invalid")
+	ErrSessionRestamped    = errors.New("session was re-stamped after a role or status change")
 	ErrInvalidToken        = errors.New("invalid token")
 	ErrTokenExpired        = errors.New("token has expired")
 	ErrInvalidRefreshToken = errors.New("invalid refresh token")
@@ -72,6 +83,7 @@ func NewSession(userID, ipAddress, userAgent string, sessionDuration, accessToke
 		UserAgent:        userAgent,
 		Status:           SessionStatusActive,
 		RefreshExpiresAt: now.Add(refreshTokenDuration),
+		Device:           ParseUserAgent(userAgent),
 	}
 
 	return session
@@ -176,6 +188,7 @@ func (s *Session) GenerateTokens(user *User, secretKey string, accessDuration, r
 		Role:      string(user.Role),
 		Email:     user.Email,
 		IssuedAt:  now,
+		Version:   s.Version,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(accessDuration)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -199,6 +212,7 @@ func (s *Session) GenerateTokens(user *User, secretKey string, accessDuration, r
 		Role:      string(user.Role),
 		Email:     user.Email,
 		IssuedAt:  now,
+		Version:   s.Version,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(refreshDuration)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -238,6 +252,7 @@ func (s *Session) RefreshAccessToken(user *User, secretKey string, accessDuratio
 		Role:      string(user.Role),
 		Email:     user.Email,
 		IssuedAt:  now,
+		Version:   s.Version,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(accessDuration)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -332,6 +347,9 @@ type SessionInfo struct {
 	Status         SessionStatus `json:"status"`
 	IsActive       bool          `json:"is_active"`
 	RemainingTime  string        `json:"remaining_time"`
+	Location       *GeoLocation  `json:"location,omitempty"`
+	Device         *DeviceInfo   `json:"device,omitempty"`
+	Anomalies      []string      `json:"anomalies,omitempty"`
 }
 
 // ToSessionInfo converts session to session info
@@ -347,6 +365,9 @@ func (s *Session) ToSessionInfo() *SessionInfo {
 		Status:         s.Status,
 		IsActive:       s.IsActive(),
 		RemainingTime:  s.GetRemainingTime().String(),
+		Location:       s.Location,
+		Device:         s.Device,
+		Anomalies:      s.Anomalies,
 	}
 }
 
@@ -1,8 +1,10 @@
 package domain
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"math/big"
 	"regexp"
 	"strings"
 	"time"
@@ -25,6 +27,7 @@ type User struct {
 	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
 	LastLoginAt  *time.Time        `json:"last_login_at,omitempty" db:"last_login_at"`
 	Metadata     map[string]string `json:"metadata,omitempty" db:"metadata"`
+	Preferences  Preferences       `json:"preferences" db:"preferences"`
 
 	// Security tracking
 	LoginAttempts int        `json:"-" db:"login_attempts"`
@@ -34,6 +37,12 @@ type User struct {
 	Phone            string `json:"phone,omitempty" db:"phone"`
 	TelegramUsername string `json:"telegram_username,omitempty" db:"telegram_username"`
 	TelegramChatID   string `json:"telegram_chat_id,omitempty" db:"telegram_chat_id"`
+
+	// MustChangePassword is set for users holding a temporary password
+	MustChangePassword bool `json:"must_change_password" db:"must_change_password"`
+
+	// DeletionScheduledAt is when a pending deletion is finalized
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty" db:"deletion_scheduled_at"`
 }
 
 // UserRole represents user roles in the system
@@ -54,6 +63,10 @@ const (
 	StatusInactive  UserStatus = "inactive"
 	StatusSuspended UserStatus = "suspended"
 	StatusDeleted   UserStatus = "deleted"
+
+	// StatusPendingDeletion marks an account its owner asked to delete; it is
+	// deleted for good once the grace period ends, unless the owner cancels
+	StatusPendingDeletion UserStatus = "pending_deletion"
 )
 
 // UserProfile represents user profile information
@@ -82,12 +95,18 @@ var (
 	ErrUnauthorized       = errors.New("insufficient permissions")
 	ErrInvalidRole        = errors.New("invalid user role")
 	ErrInvalidStatus      = errors.New("invalid user status")
+
+	ErrPasswordChangeRequired = errors.New("password change required")
+
+	ErrDeletionPending    = errors.New("account is pending deletion")
+	ErrDeletionNotPending = errors.New("account is not pending deletion")
+	ErrDeletionNotAllowed = errors.New("only customer accounts can request their own deletion")
 )
 
 // NewUser creates a new user with the given details
 func NewUser(email, password, firstName, lastName string, role UserRole) (*User, error) {
 	// Validate inputs
-	if err := validateEmail(email); err != nil {
+	if err := ValidateUserDetails(email, firstName, lastName, role); err != nil {
 		return nil, err
 	}
 
@@ -95,18 +114,6 @@ func NewUser(email, password, firstName, lastName string, role UserRole) (*User,
 		return nil, err
 	}
 
-	if err := validateRole(role); err != nil {
-		return nil, err
-	}
-
-	if strings.TrimSpace(firstName) == "" {
-		return nil, fmt.Errorf("first name cannot be empty")
-	}
-
-	if strings.TrimSpace(lastName) == "" {
-		return nil, fmt.Errorf("last name cannot be empty")
-	}
-
 	// Hash password
 	passwordHash, err := hashPassword(password)
 	if err != nil {
@@ -125,15 +132,37 @@ func NewUser(email, password, firstName, lastName string, role UserRole) (*User,
 		CreatedAt:     now,
 		UpdatedAt:     now,
 		Metadata:      make(map[string]string),
+		Preferences:   DefaultPreferences(),
 		LoginAttempts: 0,
 	}
 
 	return user, nil
 }
 
+// ValidateUserDetails validates the non-password fields required to create a user
+func ValidateUserDetails(email, firstName, lastName string, role UserRole) error {
+	if err := validateEmail(email); err != nil {
+		return err
+	}
+
+	if err := validateRole(role); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(firstName) == "" {
+		return fmt.Errorf("first name cannot be empty")
+	}
+
+	if strings.TrimSpace(lastName) == "" {
+		return fmt.Errorf("last name cannot be empty")
+	}
+
+	return nil
+}
+
 // ValidatePassword checks if the provided password matches the user's password
 func (u *User) ValidatePassword(password string) error {
-	if u.Status != StatusActive {
+	if !u.CanSignIn() {
 		return ErrAccountInactive
 	}
 
@@ -168,6 +197,22 @@ func (u *User) ChangePassword(currentPassword, newPassword string) error {
 	}
 
 	u.PasswordHash = passwordHash
+	u.MustChangePassword = false
+	u.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// SetTemporaryPassword replaces the user's password with a generated one that
+// must be changed on the next login
+func (u *User) SetTemporaryPassword(password string) error {
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash temporary password: %w", err)
+	}
+
+	u.PasswordHash = passwordHash
+	u.MustChangePassword = true
 	u.UpdatedAt = time.Now()
 
 	return nil
@@ -241,6 +286,49 @@ func (u *User) RecordSuccessfulLogin() {
 	u.UpdatedAt = now
 }
 
+// CanSignIn reports whether the user may authenticate. Accounts pending
+// deletion may sign in, but only to cancel the deletion.
+func (u *User) CanSignIn() bool {
+	return u.Status == StatusActive || u.Status == StatusPendingDeletion
+}
+
+// IsPendingDeletion checks if the user asked for the account to be deleted
+func (u *User) IsPendingDeletion() bool {
+	return u.Status == StatusPendingDeletion
+}
+
+// RequestDeletion schedules the account for deletion after the grace period
+func (u *User) RequestDeletion(gracePeriod time.Duration) error {
+	if u.Role != RoleCustomer {
+		return ErrDeletionNotAllowed
+	}
+	if u.IsPendingDeletion() {
+		return ErrDeletionPending
+	}
+	if u.Status != StatusActive {
+		return ErrAccountInactive
+	}
+
+	now := time.Now()
+	scheduledAt := now.Add(gracePeriod)
+	u.Status = StatusPendingDeletion
+	u.DeletionScheduledAt = &scheduledAt
+	u.UpdatedAt = now
+	return nil
+}
+
+// CancelDeletion keeps an account that is pending deletion
+func (u *User) CancelDeletion() error {
+	if !u.IsPendingDeletion() {
+		return ErrDeletionNotPending
+	}
+
+	u.Status = StatusActive
+	u.DeletionScheduledAt = nil
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
 // HasRole checks if the user has the specified role
 func (u *User) HasRole(role UserRole) bool {
 	return u.Role == role
@@ -410,6 +498,57 @@ func hashPassword(password string) (string, error) {
 	return HashPassword(password)
 }
 
+// Temporary passwords omit characters that are easily confused when a
+// password is read out or copied from a report
+const (
+	temporaryPasswordLength = 16
+	temporaryPasswordUpper  = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	temporaryPasswordLower  = "abcdefghijkmnopqrstuvwxyz"
+	temporaryPasswordDigits = "23456789"
+)
+
+// GenerateTemporaryPassword returns a random password that satisfies
+// ValidatePassword
+func GenerateTemporaryPassword() (string, error) {
+	alphabet := temporaryPasswordUpper + temporaryPasswordLower + temporaryPasswordDigits
+	password := make([]byte, temporaryPasswordLength)
+
+	// Guarantee one character of each required class, fill the rest randomly
+	for i, class := range []string{temporaryPasswordUpper, temporaryPasswordLower, temporaryPasswordDigits} {
+		c, err := randomChar(class)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+	for i := 3; i < len(password); i++ {
+		c, err := randomChar(alphabet)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+
+	// Shuffle so the guaranteed characters are not always in front
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate temporary password: %w", err)
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomChar(alphabet string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate temporary password: %w", err)
+	}
+	return alphabet[n.Int64()], nil
+}
+
 // IsValidRole checks if a role string is valid
 func IsValidRole(role string) bool {
 	switch UserRole(role) {
@@ -423,7 +562,7 @@ func IsValidRole(role string) bool {
 // IsValidStatus checks if a status string is valid
 func IsValidStatus(status string) bool {
 	switch UserStatus(status) {
-	case StatusActive, StatusInactive, StatusSuspended, StatusDeleted:
+	case StatusActive, StatusInactive, StatusSuspended, StatusDeleted, StatusPendingDeletion:
 		return true
 	default:
 		return false
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Types of the user lifecycle events recorded in the outbox
+const (
+	UserEventCreated     = "user.created"
+	UserEventUpdated     = "user.updated"
+	UserEventDeactivated = "user.deactivated" // Became inactive or suspended
+	UserEventDeleted     = "user.deleted"     // Soft deleted, or hard deleted without a soft delete first
+)
+
+// UserLifecycleEvent is a change to a user, recorded by the users table's
+// trigger in the transaction of the write that made it. The payload holds the
+// user's ID, role and status and the fields that changed, never personal data.
+type UserLifecycleEvent struct {
+	ID         int64           `json:"id"`       // Position in the outbox; events are published in this order
+	EventID    string          `json:"event_id"` // Stable across redeliveries
+	Type       string          `json:"type"`
+	UserID     string          `json:"user_id"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
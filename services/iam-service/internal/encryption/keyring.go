@@ -0,0 +1,196 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/secrets"
+)
+
+// encryptedPrefix marks a stored value as ciphertext. Values without it are
+// legacy plaintext, which is still readable until the re-encryption job has run.
+const encryptedPrefix = "enc:"
+
+// keySize is the AES-256 key length in bytes
+const keySize = 32
+
+var (
+	// ErrUnknownKey is returned when a value was encrypted with a key that is not in the keyring
+	ErrUnknownKey = errors.New("unknown encryption key")
+
+	// ErrMalformedCiphertext is returned when a stored value cannot be parsed as ciphertext
+	ErrMalformedCiphertext = errors.New("malformed ciphertext")
+)
+
+// Keyring encrypts field values with AES-GCM. New values are always written
+// with the active key; values encrypted with any other key in the ring stay
+// readable, so keys can be rotated by adding a new active key and running the
+// re-encryption job before the old one is removed.
+//
+// Stored values have the form "enc:<key id>:<base64 nonce+ciphertext>". The
+// field name is bound as additional data, so a value copied into another
+// column fails to decrypt.
+type Keyring struct {
+	activeID string
+	aeads    map[string]cipher.AEAD
+}
+
+// NewKeyring creates a keyring from 32-byte keys indexed by key ID
+func NewKeyring(activeID string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[activeID]; !ok {
+		return nil, fmt.Errorf("active key %q is not in the keyring", activeID)
+	}
+
+	k := &Keyring{activeID: activeID, aeads: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid key ID %q", id)
+		}
+		if len(key) != keySize {
+			return nil, fmt.Errorf("key %q must be %d bytes, got %d", id, keySize, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		k.aeads[id] = aead
+	}
+
+	return k, nil
+}
+
+// LoadKeyring reads the keys from the secrets provider. The secret holds one
+// "<key id>:<base64 key>" entry per line or comma-separated.
+func LoadKeyring(ctx context.Context, provider secrets.Provider, secretName, activeID string) (*Keyring, error) {
+	data, err := provider.Get(ctx, secretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encryption keys: %w", err)
+	}
+
+	keys, err := ParseKeys(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeyring(activeID, keys)
+}
+
+// ParseKeys parses "<key id>:<base64 key>" entries separated by newlines or commas
+func ParseKeys(data string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	entries := strings.FieldsFunc(data, func(r rune) bool { return r == '\n' || r == ',' })
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid key entry: expected <key id>:<base64 key>")
+		}
+		if _, duplicate := keys[id]; duplicate {
+			return nil, fmt.Errorf("duplicate key ID %q", id)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("key %q is not valid base64: %w", id, err)
+		}
+		keys[id] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no encryption keys configured")
+	}
+	return keys, nil
+}
+
+// ActiveKeyID returns the ID of the key used for new values
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+// Encrypt encrypts a field value with the active key. Empty values are
+// stored as-is so that "not set" stays distinguishable without decrypting.
+func (k *Keyring) Encrypt(field, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	aead := k.aeads[k.activeID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(field))
+	return encryptedPrefix + k.activeID + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of a stored field value. Values that are not
+// encrypted are returned unchanged.
+func (k *Keyring) Decrypt(field, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	keyID, sealed, err := parseEncrypted(value)
+	if err != nil {
+		return "", err
+	}
+	aead, ok := k.aeads[keyID]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", ErrMalformedCiphertext
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(field))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s: %w", field, err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReencryption reports whether a stored value is plaintext or was
+// encrypted with a key other than the active one
+func (k *Keyring) NeedsReencryption(value string) bool {
+	if value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	keyID, _, err := parseEncrypted(value)
+	return err != nil || keyID != k.activeID
+}
+
+// IsEncrypted reports whether a stored value is ciphertext
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+func parseEncrypted(value string) (keyID string, sealed []byte, err error) {
+	keyID, encoded, ok := strings.Cut(strings.TrimPrefix(value, encryptedPrefix), ":")
+	if !ok {
+		return "", nil, ErrMalformedCiphertext
+	}
+	sealed, err = base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, ErrMalformedCiphertext
+	}
+	return keyID, sealed, nil
+}
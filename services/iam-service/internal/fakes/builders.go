@@ -0,0 +1,170 @@
+package fakes
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+)
+
+// DefaultPassword is the password of users built without WithPassword
+const DefaultPassword = "Passw0rdTest"
+
+var userSeq atomic.Int64
+
+// UserBuilder builds valid users with the domain constructor. Every user gets a
+// unique email unless one is set.
+type UserBuilder struct {
+	email, password     string
+	firstName, lastName string
+	role                domain.UserRole
+	mutations           []func(*domain.User)
+}
+
+// NewUser starts building an active customer
+func NewUser() *UserBuilder {
+	n := userSeq.Add(1)
+	return &UserBuilder{
+		email:     fmt.Sprintf("user%d@rocket-science.dev", n),
+		password:  DefaultPassword,
+		firstName: "Test",
+		lastName:  fmt.Sprintf("User%d", n),
+		role:      domain.RoleCustomer,
+	}
+}
+
+// WithEmail sets the email
+func (b *UserBuilder) WithEmail(email string) *UserBuilder {
+	b.email = email
+	return b
+}
+
+// WithPassword sets the password; it must meet the password policy
+func (b *UserBuilder) WithPassword(password string) *UserBuilder {
+	b.password = password
+	return b
+}
+
+// WithName sets the first and last name
+func (b *UserBuilder) WithName(firstName, lastName string) *UserBuilder {
+	b.firstName, b.lastName = firstName, lastName
+	return b
+}
+
+// WithRole sets the role
+func (b *UserBuilder) WithRole(role domain.UserRole) *UserBuilder {
+	b.role = role
+	return b
+}
+
+// WithStatus sets the status
+func (b *UserBuilder) WithStatus(status domain.UserStatus) *UserBuilder {
+	return b.With(func(u *domain.User) { u.Status = status })
+}
+
+// WithTelegram links a Telegram chat
+func (b *UserBuilder) WithTelegram(chatID, username string) *UserBuilder {
+	return b.With(func(u *domain.User) { u.UpdateTelegramChatID(chatID, username) })
+}
+
+// Locked locks the account for the duration
+func (b *UserBuilder) Locked(duration time.Duration) *UserBuilder {
+	return b.With(func(u *domain.User) { u.LockAccount(duration) })
+}
+
+// PendingDeletion marks the account as pending deletion, finalized at scheduledAt
+func (b *UserBuilder) PendingDeletion(scheduledAt time.Time) *UserBuilder {
+	return b.With(func(u *domain.User) {
+		u.Status = domain.StatusPendingDeletion
+		u.DeletionScheduledAt = &scheduledAt
+	})
+}
+
+// CreatedAt backdates the user
+func (b *UserBuilder) CreatedAt(t time.Time) *UserBuilder {
+	return b.With(func(u *domain.User) {
+		u.CreatedAt = t
+		u.UpdatedAt = t
+	})
+}
+
+// With applies an arbitrary change after the user is constructed
+func (b *UserBuilder) With(fn func(*domain.User)) *UserBuilder {
+	b.mutations = append(b.mutations, fn)
+	return b
+}
+
+// Build returns the user. It panics if the details fail domain validation, as a
+// broken fixture is a bug in the test.
+func (b *UserBuilder) Build() *domain.User {
+	user, err := domain.NewUser(b.email, b.password, b.firstName, b.lastName, b.role)
+	if err != nil {
+		panic(fmt.Sprintf("fakes: invalid user fixture: %v", err))
+	}
+	for _, fn := range b.mutations {
+		fn(user)
+	}
+	return user
+}
+
+// SessionBuilder builds sessions with opaque tokens
+type SessionBuilder struct {
+	userID, ipAddress, userAgent string
+	sessionTTL, refreshTTL       time.Duration
+	mutations                    []func(*domain.Session)
+}
+
+// NewSession starts building an active session of the user that lasts a day
+func NewSession(userID string) *SessionBuilder {
+	return &SessionBuilder{
+		userID:     userID,
+		ipAddress:  "203.0.113.10",
+		userAgent:  "Mozilla/5.0 (X11; Linux x86_64) Firefox/128.0",
+		sessionTTL: 24 * time.Hour,
+		refreshTTL: 7 * 24 * time.Hour,
+	}
+}
+
+// WithClient sets the IP address and user agent
+func (b *SessionBuilder) WithClient(ipAddress, userAgent string) *SessionBuilder {
+	b.ipAddress, b.userAgent = ipAddress, userAgent
+	return b
+}
+
+// WithTTL sets how long the session and its refresh token last
+func (b *SessionBuilder) WithTTL(session, refresh time.Duration) *SessionBuilder {
+	b.sessionTTL, b.refreshTTL = session, refresh
+	return b
+}
+
+// WithTokens sets the access and refresh tokens
+func (b *SessionBuilder) WithTokens(accessToken, refreshToken string) *SessionBuilder {
+	return b.With(func(s *domain.Session) {
+		s.AccessToken, s.RefreshToken = accessToken, refreshToken
+	})
+}
+
+// Revoked builds a revoked session
+func (b *SessionBuilder) Revoked() *SessionBuilder {
+	return b.With((*domain.Session).Revoke)
+}
+
+// With applies an arbitrary change after the session is constructed
+func (b *SessionBuilder) With(fn func(*domain.Session)) *SessionBuilder {
+	b.mutations = append(b.mutations, fn)
+	return b
+}
+
+// Build returns the session
+func (b *SessionBuilder) Build() *domain.Session {
+	session := domain.NewSession(b.userID, b.ipAddress, b.userAgent, b.sessionTTL, b.sessionTTL, b.refreshTTL)
+	session.AccessToken = "access-" + uuid.NewString()
+	session.RefreshToken = "refresh-" + uuid.NewString()
+	for _, fn := range b.mutations {
+		fn(session)
+	}
+	return session
+}
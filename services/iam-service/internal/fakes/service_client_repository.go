@@ -0,0 +1,98 @@
+package fakes
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/testing/memstore"
+)
+
+var _ interfaces.ServiceClientRepository = (*ServiceClientRepository)(nil)
+
+// ServiceClientRepository is an in-memory interfaces.ServiceClientRepository keyed by client ID
+type ServiceClientRepository struct {
+	*memstore.Store[string, *domain.ServiceClient]
+}
+
+// NewServiceClientRepository creates an empty repository seeded with the given clients
+func NewServiceClientRepository(clients ...*domain.ServiceClient) *ServiceClientRepository {
+	r := &ServiceClientRepository{Store: memstore.New[string](cloneServiceClient)}
+	for _, client := range clients {
+		r.Put(client.ClientID, client)
+	}
+	return r
+}
+
+func (r *ServiceClientRepository) Create(ctx context.Context, client *domain.ServiceClient) error {
+	if err := r.Call("Create"); err != nil {
+		return err
+	}
+	if !r.Insert(client.ClientID, client) {
+		return domain.ErrServiceClientExists
+	}
+	return nil
+}
+
+func (r *ServiceClientRepository) GetByClientID(ctx context.Context, clientID string) (*domain.ServiceClient, error) {
+	if err := r.Call("GetByClientID"); err != nil {
+		return nil, err
+	}
+	client, ok := r.Get(clientID)
+	if !ok {
+		return nil, domain.ErrServiceClientNotFound
+	}
+	return client, nil
+}
+
+func (r *ServiceClientRepository) List(ctx context.Context) ([]*domain.ServiceClient, error) {
+	if err := r.Call("List"); err != nil {
+		return nil, err
+	}
+	clients := r.Store.List(nil)
+	sort.SliceStable(clients, func(i, j int) bool {
+		if !clients[i].CreatedAt.Equal(clients[j].CreatedAt) {
+			return clients[i].CreatedAt.Before(clients[j].CreatedAt)
+		}
+		return clients[i].ClientID < clients[j].ClientID
+	})
+	return clients, nil
+}
+
+func (r *ServiceClientRepository) Update(ctx context.Context, client *domain.ServiceClient) error {
+	if err := r.Call("Update"); err != nil {
+		return err
+	}
+	ok, _ := r.Modify(client.ClientID, func(stored *domain.ServiceClient) (*domain.ServiceClient, error) {
+		updated := cloneServiceClient(client)
+		updated.LastUsedAt = stored.LastUsedAt
+		return updated, nil
+	})
+	if !ok {
+		return domain.ErrServiceClientNotFound
+	}
+	return nil
+}
+
+func (r *ServiceClientRepository) TouchLastUsed(ctx context.Context, clientID string, usedAt time.Time) error {
+	if err := r.Call("TouchLastUsed"); err != nil {
+		return err
+	}
+	r.Modify(clientID, func(c *domain.ServiceClient) (*domain.ServiceClient, error) {
+		c.LastUsedAt = &usedAt
+		return c, nil
+	})
+	return nil
+}
+
+func cloneServiceClient(c *domain.ServiceClient) *domain.ServiceClient {
+	clone := *c
+	clone.Scopes = append([]string(nil), c.Scopes...)
+	if c.LastUsedAt != nil {
+		usedAt := *c.LastUsedAt
+		clone.LastUsedAt = &usedAt
+	}
+	return &clone
+}
@@ -0,0 +1,605 @@
+package fakes
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/testing/memstore"
+)
+
+var _ interfaces.SessionRepository = (*SessionRepository)(nil)
+
+// SessionRepository is an in-memory interfaces.SessionRepository. Sessions past
+// their expiry are gone, as if Redis had evicted their keys.
+type SessionRepository struct {
+	*memstore.Store[string, *domain.Session]
+	blacklist *memstore.Store[string, time.Time]
+}
+
+// NewSessionRepository creates an empty repository seeded with the given sessions
+func NewSessionRepository(sessions ...*domain.Session) *SessionRepository {
+	r := &SessionRepository{
+		Store:     memstore.New[string](cloneSession),
+		blacklist: memstore.New[string, time.Time](nil),
+	}
+	for _, session := range sessions {
+		r.Put(session.ID, session)
+	}
+	return r
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	if err := r.Call("Create"); err != nil {
+		return err
+	}
+	if time.Until(session.ExpiresAt) <= 0 {
+		return errors.New("session already expired")
+	}
+	r.Put(session.ID, session)
+	return nil
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, sessionID string) (*domain.Session, error) {
+	if err := r.Call("GetByID"); err != nil {
+		return nil, err
+	}
+	session, ok := r.Get(sessionID)
+	if !ok || evicted(session) {
+		return nil, domain.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (r *SessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	if err := r.Call("Update"); err != nil {
+		return err
+	}
+	if stored, ok := r.Get(session.ID); !ok || evicted(stored) {
+		return domain.ErrSessionNotFound
+	}
+	if evicted(session) {
+		r.Store.Delete(session.ID)
+		return nil
+	}
+	r.Put(session.ID, session)
+	return nil
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, sessionID string) error {
+	if err := r.Call("Delete"); err != nil {
+		return err
+	}
+	r.Store.Delete(sessionID)
+	return nil
+}
+
+func (r *SessionRepository) ValidateSession(ctx context.Context, sessionID, accessToken string) (*domain.Session, error) {
+	if err := r.Call("ValidateSession"); err != nil {
+		return nil, err
+	}
+	session, err := r.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.IsValid(); err != nil {
+		return nil, err
+	}
+	if session.AccessToken != accessToken {
+		return nil, domain.ErrInvalidToken
+	}
+	session.UpdateLastAccessed()
+	_ = r.Update(ctx, session)
+	return session, nil
+}
+
+func (r *SessionRepository) RefreshSession(ctx context.Context, sessionID, refreshToken string) (*domain.Session, error) {
+	if err := r.Call("RefreshSession"); err != nil {
+		return nil, err
+	}
+	session, err := r.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.IsRefreshTokenValid(); err != nil {
+		return nil, err
+	}
+	if session.RefreshToken != refreshToken {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	return session, nil
+}
+
+func (r *SessionRepository) GetUserSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	if err := r.Call("GetUserSessions"); err != nil {
+		return nil, err
+	}
+	return r.live(func(s *domain.Session) bool { return s.UserID == userID }), nil
+}
+
+func (r *SessionRepository) GetActiveUserSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	if err := r.Call("GetActiveUserSessions"); err != nil {
+		return nil, err
+	}
+	return r.live(func(s *domain.Session) bool { return s.UserID == userID && s.IsActive() }), nil
+}
+
+func (r *SessionRepository) RevokeUserSessions(ctx context.Context, userID string) error {
+	if err := r.Call("RevokeUserSessions"); err != nil {
+		return err
+	}
+	for _, session := range r.live(func(s *domain.Session) bool { return s.UserID == userID }) {
+		r.change(session.ID, (*domain.Session).Revoke)
+	}
+	return nil
+}
+
+func (r *SessionRepository) RevokeUserSessionsExcept(ctx context.Context, userID, keepSessionID string) error {
+	if err := r.Call("RevokeUserSessionsExcept"); err != nil {
+		return err
+	}
+	for _, session := range r.live(func(s *domain.Session) bool { return s.UserID == userID && s.ID != keepSessionID }) {
+		r.change(session.ID, (*domain.Session).Revoke)
+	}
+	return nil
+}
+
+func (r *SessionRepository) RestampUserSessions(ctx context.Context, userID string) (int, error) {
+	if err := r.Call("RestampUserSessions"); err != nil {
+		return 0, err
+	}
+	sessions := r.live(func(s *domain.Session) bool { return s.UserID == userID && s.IsActive() })
+	for _, session := range sessions {
+		r.change(session.ID, func(s *domain.Session) { s.Version++ })
+	}
+	return len(sessions), nil
+}
+
+func (r *SessionRepository) RevokeSession(ctx context.Context, sessionID string) error {
+	if err := r.Call("RevokeSession"); err != nil {
+		return err
+	}
+	return r.change(sessionID, (*domain.Session).Revoke)
+}
+
+func (r *SessionRepository) ExpireSession(ctx context.Context, sessionID string) error {
+	if err := r.Call("ExpireSession"); err != nil {
+		return err
+	}
+	return r.change(sessionID, (*domain.Session).Expire)
+}
+
+func (r *SessionRepository) InvalidateSession(ctx context.Context, sessionID string) error {
+	if err := r.Call("InvalidateSession"); err != nil {
+		return err
+	}
+	return r.change(sessionID, (*domain.Session).Invalidate)
+}
+
+func (r *SessionRepository) UpdateLastAccessed(ctx context.Context, sessionID string) error {
+	if err := r.Call("UpdateLastAccessed"); err != nil {
+		return err
+	}
+	return r.change(sessionID, (*domain.Session).UpdateLastAccessed)
+}
+
+func (r *SessionRepository) BlacklistToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if err := r.Call("BlacklistToken"); err != nil {
+		return err
+	}
+	if time.Until(expiresAt) > 0 {
+		r.blacklist.Put(tokenID, expiresAt)
+	}
+	return nil
+}
+
+func (r *SessionRepository) IsTokenBlacklisted(ctx context.Context, tokenID string) (bool, error) {
+	if err := r.Call("IsTokenBlacklisted"); err != nil {
+		return false, err
+	}
+	expiresAt, ok := r.blacklist.Get(tokenID)
+	return ok && time.Now().Before(expiresAt), nil
+}
+
+func (r *SessionRepository) GetBlacklistedTokens(ctx context.Context) ([]string, error) {
+	if err := r.Call("GetBlacklistedTokens"); err != nil {
+		return nil, err
+	}
+	return r.blacklistedIDs(), nil
+}
+
+func (r *SessionRepository) CleanupBlacklistedTokens(ctx context.Context) (int, error) {
+	if err := r.Call("CleanupBlacklistedTokens"); err != nil {
+		return 0, err
+	}
+	cleaned := 0
+	for _, tokenID := range r.blacklistedIDs() {
+		if expiresAt, _ := r.blacklist.Get(tokenID); !time.Now().Before(expiresAt) {
+			r.blacklist.Delete(tokenID)
+			cleaned++
+		}
+	}
+	return cleaned, nil
+}
+
+func (r *SessionRepository) GetSessionsByStatus(ctx context.Context, status domain.SessionStatus) ([]*domain.Session, error) {
+	if err := r.Call("GetSessionsByStatus"); err != nil {
+		return nil, err
+	}
+	return r.live(func(s *domain.Session) bool { return s.Status == status }), nil
+}
+
+func (r *SessionRepository) GetExpiredSessions(ctx context.Context) ([]*domain.Session, error) {
+	return r.GetSessionsByStatus(ctx, domain.SessionStatusExpired)
+}
+
+func (r *SessionRepository) GetSessionsByUserAgent(ctx context.Context, userAgent string) ([]*domain.Session, error) {
+	if err := r.Call("GetSessionsByUserAgent"); err != nil {
+		return nil, err
+	}
+	return r.live(func(s *domain.Session) bool { return s.UserAgent == userAgent }), nil
+}
+
+func (r *SessionRepository) GetSessionsByIPAddress(ctx context.Context, ipAddress string) ([]*domain.Session, error) {
+	if err := r.Call("GetSessionsByIPAddress"); err != nil {
+		return nil, err
+	}
+	return r.live(func(s *domain.Session) bool { return s.IPAddress == ipAddress }), nil
+}
+
+func (r *SessionRepository) CleanupExpiredSessions(ctx context.Context) (*domain.SessionCleanupInfo, error) {
+	if err := r.Call("CleanupExpiredSessions"); err != nil {
+		return nil, err
+	}
+	info := &domain.SessionCleanupInfo{}
+	for _, session := range r.Store.List(nil) {
+		if session.IsExpired() {
+			r.Store.Delete(session.ID)
+			info.ExpiredSessions++
+			info.TotalCleaned++
+			continue
+		}
+		switch session.Status {
+		case domain.SessionStatusRevoked:
+			info.RevokedSessions++
+		case domain.SessionStatusInvalid:
+			info.InvalidSessions++
+		}
+	}
+	return info, nil
+}
+
+func (r *SessionRepository) CleanupUserSessions(ctx context.Context, userID string, maxSessions int) error {
+	if err := r.Call("CleanupUserSessions"); err != nil {
+		return err
+	}
+	sessions := r.live(func(s *domain.Session) bool { return s.UserID == userID })
+	if len(sessions) <= maxSessions {
+		return nil
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LastAccessedAt.Before(sessions[j].LastAccessedAt)
+	})
+	for _, session := range sessions[:len(sessions)-maxSessions] {
+		r.Store.Delete(session.ID)
+	}
+	return nil
+}
+
+func (r *SessionRepository) GetStaleSessionsForCleanup(ctx context.Context, staleSince time.Time) ([]*domain.Session, error) {
+	if err := r.Call("GetStaleSessionsForCleanup"); err != nil {
+		return nil, err
+	}
+	return r.live(func(s *domain.Session) bool { return s.LastAccessedAt.Before(staleSince) }), nil
+}
+
+func (r *SessionRepository) GetSessionStats(ctx context.Context) (*interfaces.SessionStats, error) {
+	if err := r.Call("GetSessionStats"); err != nil {
+		return nil, err
+	}
+	return r.sessionStats(interfaces.SessionStatsSourceCounters), nil
+}
+
+func (r *SessionRepository) RecountSessionStats(ctx context.Context) (*interfaces.SessionStats, error) {
+	if err := r.Call("RecountSessionStats"); err != nil {
+		return nil, err
+	}
+	return r.sessionStats(interfaces.SessionStatsSourceRecount), nil
+}
+
+// sessionStats counts the live sessions exactly, whatever the source
+func (r *SessionRepository) sessionStats(source string) *interfaces.SessionStats {
+	stats := &interfaces.SessionStats{
+		Source:            source,
+		SessionsByStatus:  make(map[domain.SessionStatus]int),
+		BlacklistedTokens: len(r.blacklistedIDs()),
+	}
+	now := time.Now()
+	uniqueUsers := make(map[string]bool)
+	for _, session := range r.live(nil) {
+		stats.TotalSessions++
+		stats.SessionsByStatus[session.Status]++
+		switch session.Status {
+		case domain.SessionStatusActive:
+			stats.ActiveSessions++
+		case domain.SessionStatusExpired:
+			stats.ExpiredSessions++
+		case domain.SessionStatusRevoked:
+			stats.RevokedSessions++
+		case domain.SessionStatusInvalid:
+			stats.InvalidSessions++
+		}
+		uniqueUsers[session.UserID] = true
+
+		age := now.Sub(session.CreatedAt)
+		if age <= time.Hour {
+			stats.RecentSessions++
+		}
+		if age <= 24*time.Hour {
+			stats.TodaySessions++
+		}
+		if age <= 7*24*time.Hour {
+			stats.WeekSessions++
+		}
+	}
+	stats.UniqueActiveUsers = len(uniqueUsers)
+	return stats
+}
+
+func (r *SessionRepository) GetActiveSessionCount(ctx context.Context) (int, error) {
+	if err := r.Call("GetActiveSessionCount"); err != nil {
+		return 0, err
+	}
+	return len(r.live(nil)), nil
+}
+
+func (r *SessionRepository) GetUserSessionCount(ctx context.Context, userID string) (int, error) {
+	if err := r.Call("GetUserSessionCount"); err != nil {
+		return 0, err
+	}
+	return len(r.live(func(s *domain.Session) bool { return s.UserID == userID })), nil
+}
+
+func (r *SessionRepository) GetSessionsByTimeRange(ctx context.Context, start, end time.Time) ([]*domain.Session, error) {
+	if err := r.Call("GetSessionsByTimeRange"); err != nil {
+		return nil, err
+	}
+	return r.live(func(s *domain.Session) bool {
+		return !s.CreatedAt.Before(start) && !s.CreatedAt.After(end)
+	}), nil
+}
+
+func (r *SessionRepository) CreateBatch(ctx context.Context, sessions []*domain.Session) error {
+	if err := r.Call("CreateBatch"); err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if time.Until(session.ExpiresAt) > 0 {
+			r.Put(session.ID, session)
+		}
+	}
+	return nil
+}
+
+func (r *SessionRepository) DeleteBatch(ctx context.Context, sessionIDs []string) error {
+	if err := r.Call("DeleteBatch"); err != nil {
+		return err
+	}
+	for _, sessionID := range sessionIDs {
+		r.Store.Delete(sessionID)
+	}
+	return nil
+}
+
+func (r *SessionRepository) UpdateBatch(ctx context.Context, sessions []*domain.Session) error {
+	if err := r.Call("UpdateBatch"); err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if err := r.Update(ctx, session); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SessionRepository) ExtendSession(ctx context.Context, sessionID string, duration time.Duration) error {
+	if err := r.Call("ExtendSession"); err != nil {
+		return err
+	}
+	return r.change(sessionID, func(s *domain.Session) {
+		s.ExpiresAt = s.ExpiresAt.Add(duration)
+	})
+}
+
+func (r *SessionRepository) RenewSession(ctx context.Context, sessionID string, newExpiryTime time.Time) error {
+	if err := r.Call("RenewSession"); err != nil {
+		return err
+	}
+	return r.change(sessionID, func(s *domain.Session) {
+		s.ExpiresAt = newExpiryTime
+	})
+}
+
+func (r *SessionRepository) FindSessionsByFilter(ctx context.Context, filter interfaces.SessionFilter) ([]*domain.Session, error) {
+	if err := r.Call("FindSessionsByFilter"); err != nil {
+		return nil, err
+	}
+	sessions := r.live(func(s *domain.Session) bool { return matchesSessionFilter(s, filter) })
+	if filter.Limit > 0 {
+		if filter.Offset >= len(sessions) {
+			return []*domain.Session{}, nil
+		}
+		sessions = sessions[filter.Offset:]
+		if len(sessions) > filter.Limit {
+			sessions = sessions[:filter.Limit]
+		}
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) GetRecentSessions(ctx context.Context, limit int) ([]*domain.Session, error) {
+	return r.FindSessionsByFilter(ctx, interfaces.SessionFilter{
+		Limit:     limit,
+		SortBy:    "created_at",
+		SortOrder: "desc",
+	})
+}
+
+func (r *SessionRepository) GetLongLivedSessions(ctx context.Context, threshold time.Duration) ([]*domain.Session, error) {
+	thresholdTime := time.Now().Add(-threshold)
+	return r.FindSessionsByFilter(ctx, interfaces.SessionFilter{CreatedBefore: &thresholdTime})
+}
+
+func (r *SessionRepository) GetSuspiciousSessions(ctx context.Context, criteria interfaces.SuspiciousSessionCriteria) ([]*domain.Session, error) {
+	if err := r.Call("GetSuspiciousSessions"); err != nil {
+		return nil, err
+	}
+	sessions := r.live(nil)
+	userIPs := make(map[string]map[string]bool)
+	for _, session := range sessions {
+		if userIPs[session.UserID] == nil {
+			userIPs[session.UserID] = make(map[string]bool)
+		}
+		userIPs[session.UserID][session.IPAddress] = true
+	}
+
+	var suspicious []*domain.Session
+	for _, session := range sessions {
+		if len(userIPs[session.UserID]) >= criteria.MultipleIPsThreshold ||
+			time.Since(session.CreatedAt) > criteria.LongDurationThreshold ||
+			time.Since(session.LastAccessedAt) > criteria.InactiveThreshold {
+			suspicious = append(suspicious, session)
+		}
+	}
+	return suspicious, nil
+}
+
+func (r *SessionRepository) GetSessionsByMultipleIPs(ctx context.Context, userID string) ([]*domain.Session, error) {
+	if err := r.Call("GetSessionsByMultipleIPs"); err != nil {
+		return nil, err
+	}
+	sessions := r.live(func(s *domain.Session) bool { return s.UserID == userID })
+	ips := make(map[string]bool)
+	for _, session := range sessions {
+		ips[session.IPAddress] = true
+	}
+	if len(ips) <= 1 {
+		return []*domain.Session{}, nil
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) GetConcurrentSessions(ctx context.Context, userID string, timeWindow time.Duration) ([]*domain.Session, error) {
+	if err := r.Call("GetConcurrentSessions"); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return r.live(func(s *domain.Session) bool {
+		return s.UserID == userID && now.Sub(s.CreatedAt) <= timeWindow
+	}), nil
+}
+
+func (r *SessionRepository) HealthCheck(ctx context.Context) error {
+	return r.Call("HealthCheck")
+}
+
+func (r *SessionRepository) GetConnectionInfo(ctx context.Context) (*interfaces.RedisConnectionInfo, error) {
+	if err := r.Call("GetConnectionInfo"); err != nil {
+		return &interfaces.RedisConnectionInfo{Connected: false}, err
+	}
+	return &interfaces.RedisConnectionInfo{Connected: true, Address: "memory"}, nil
+}
+
+// live returns the sessions that have not been evicted and match accepts
+func (r *SessionRepository) live(match func(*domain.Session) bool) []*domain.Session {
+	return r.Store.List(func(s *domain.Session) bool {
+		return !evicted(s) && (match == nil || match(s))
+	})
+}
+
+// change applies fn to a stored session, the way the Redis repository loads,
+// mutates and saves it
+func (r *SessionRepository) change(sessionID string, fn func(*domain.Session)) error {
+	var gone bool
+	found, _ := r.Modify(sessionID, func(s *domain.Session) (*domain.Session, error) {
+		if evicted(s) {
+			gone = true
+			return s, nil
+		}
+		fn(s)
+		return s, nil
+	})
+	if !found || gone {
+		return domain.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *SessionRepository) blacklistedIDs() []string {
+	return r.blacklist.Keys()
+}
+
+// evicted reports whether Redis would have dropped the session's key by now
+func evicted(s *domain.Session) bool {
+	return !time.Now().Before(s.ExpiresAt)
+}
+
+func matchesSessionFilter(s *domain.Session, filter interfaces.SessionFilter) bool {
+	if filter.UserID != nil && s.UserID != *filter.UserID {
+		return false
+	}
+	if filter.Status != nil && s.Status != *filter.Status {
+		return false
+	}
+	if filter.IPAddress != nil && s.IPAddress != *filter.IPAddress {
+		return false
+	}
+	if filter.IPNetwork != nil {
+		ip := net.ParseIP(s.IPAddress)
+		if ip == nil || !filter.IPNetwork.Contains(ip) {
+			return false
+		}
+	}
+	if filter.UserAgent != nil && !strings.Contains(strings.ToLower(s.UserAgent), strings.ToLower(*filter.UserAgent)) {
+		return false
+	}
+	if filter.CreatedAfter != nil && s.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && s.CreatedAt.After(*filter.CreatedBefore) {
+		return false
+	}
+	if filter.ExpiresAfter != nil && s.ExpiresAt.Before(*filter.ExpiresAfter) {
+		return false
+	}
+	if filter.ExpiresBefore != nil && s.ExpiresAt.After(*filter.ExpiresBefore) {
+		return false
+	}
+	if filter.AccessedAfter != nil && s.LastAccessedAt.Before(*filter.AccessedAfter) {
+		return false
+	}
+	if filter.AccessedBefore != nil && s.LastAccessedAt.After(*filter.AccessedBefore) {
+		return false
+	}
+	return true
+}
+
+func cloneSession(s *domain.Session) *domain.Session {
+	c := *s
+	if s.Location != nil {
+		location := *s.Location
+		c.Location = &location
+	}
+	if s.Device != nil {
+		device := *s.Device
+		c.Device = &device
+	}
+	c.Anomalies = append([]string(nil), s.Anomalies...)
+	return &c
+}
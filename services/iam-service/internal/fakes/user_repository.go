@@ -0,0 +1,601 @@
+// Package fakes provides in-memory implementations of the IAM repositories and
+// builders for IAM domain objects, for unit tests and cross-service behavior tests.
+// The fakes follow the semantics of the PostgreSQL and Redis repositories, including
+// their domain errors, and can be told to fail any operation with FailOn.
+package fakes
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/testing/memstore"
+)
+
+var _ interfaces.UserRepository = (*UserRepository)(nil)
+
+// UserRepository is an in-memory interfaces.UserRepository. Deleted users are soft
+// deleted and hidden from lookups, as in PostgreSQL.
+type UserRepository struct {
+	*memstore.Store[string, *domain.User]
+	purges []interfaces.UserPurgeRecord
+}
+
+// NewUserRepository creates an empty repository seeded with the given users
+func NewUserRepository(users ...*domain.User) *UserRepository {
+	r := &UserRepository{Store: memstore.New[string](cloneUser)}
+	for _, user := range users {
+		r.Put(user.ID, user)
+	}
+	return r
+}
+
+// Purges returns the audit entries written by PurgeDeletedUser,
+// FinalizeAccountDeletion and PurgeTestUser
+func (r *UserRepository) Purges() []interfaces.UserPurgeRecord {
+	return append([]interfaces.UserPurgeRecord(nil), r.purges...)
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	if err := r.Call("Create"); err != nil {
+		return err
+	}
+	if exists, _ := r.ExistsByEmail(ctx, user.Email); exists {
+		return domain.ErrEmailExists
+	}
+	if !r.Insert(user.ID, user) {
+		return domain.ErrEmailExists
+	}
+	return nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	if err := r.Call("GetByID"); err != nil {
+		return nil, err
+	}
+	user, ok := r.Get(id)
+	if !ok || user.Status == domain.StatusDeleted {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	if err := r.Call("GetByEmail"); err != nil {
+		return nil, err
+	}
+	users := r.Store.List(func(u *domain.User) bool {
+		return u.Email == strings.ToLower(email) && u.Status != domain.StatusDeleted
+	})
+	if len(users) == 0 {
+		return nil, domain.ErrUserNotFound
+	}
+	return users[0], nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	if err := r.Call("Update"); err != nil {
+		return err
+	}
+	if !r.Has(user.ID) {
+		return domain.ErrUserNotFound
+	}
+	r.Put(user.ID, user)
+	return nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	if err := r.Call("Delete"); err != nil {
+		return err
+	}
+	return r.modifyLive(id, func(u *domain.User) {
+		u.Status = domain.StatusDeleted
+	})
+}
+
+func (r *UserRepository) List(ctx context.Context, filter interfaces.UserFilter) ([]*domain.User, int, error) {
+	if err := r.Call("List"); err != nil {
+		return nil, 0, err
+	}
+	users := r.Store.List(func(u *domain.User) bool { return matchesUserFilter(u, filter) })
+	return pageUsers(users, filter), len(users), nil
+}
+
+func (r *UserRepository) Search(ctx context.Context, query string, filter interfaces.UserFilter) ([]*domain.User, int, error) {
+	if err := r.Call("Search"); err != nil {
+		return nil, 0, err
+	}
+	query = strings.ToLower(query)
+	users := r.Store.List(func(u *domain.User) bool {
+		if !matchesUserFilter(u, filter) {
+			return false
+		}
+		return query == "" ||
+			strings.Contains(strings.ToLower(u.FirstName), query) ||
+			strings.Contains(strings.ToLower(u.LastName), query) ||
+			strings.Contains(strings.ToLower(u.Email), query)
+	})
+	return pageUsers(users, filter), len(users), nil
+}
+
+func (r *UserRepository) ValidateCredentials(ctx context.Context, email, password string) (*domain.User, error) {
+	if err := r.Call("ValidateCredentials"); err != nil {
+		return nil, err
+	}
+	user, err := r.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.ValidatePassword(password); err != nil {
+		_ = r.RecordLoginAttempt(ctx, user.ID)
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
+	if err := r.Call("UpdatePassword"); err != nil {
+		return err
+	}
+	return r.modify(userID, func(u *domain.User) {
+		u.PasswordHash = passwordHash
+	})
+}
+
+func (r *UserRepository) RecordLoginAttempt(ctx context.Context, userID string) error {
+	if err := r.Call("RecordLoginAttempt"); err != nil {
+		return err
+	}
+	r.modify(userID, func(u *domain.User) {
+		u.LoginAttempts++
+	})
+	return nil
+}
+
+func (r *UserRepository) ResetLoginAttempts(ctx context.Context, userID string) error {
+	if err := r.Call("ResetLoginAttempts"); err != nil {
+		return err
+	}
+	r.modify(userID, func(u *domain.User) {
+		u.LoginAttempts = 0
+		u.LockedUntil = nil
+	})
+	return nil
+}
+
+func (r *UserRepository) LockAccount(ctx context.Context, userID string, lockUntil time.Time) error {
+	if err := r.Call("LockAccount"); err != nil {
+		return err
+	}
+	r.modify(userID, func(u *domain.User) {
+		u.LockedUntil = &lockUntil
+	})
+	return nil
+}
+
+func (r *UserRepository) UnlockAccount(ctx context.Context, userID string) error {
+	if err := r.Call("UnlockAccount"); err != nil {
+		return err
+	}
+	r.modify(userID, func(u *domain.User) {
+		u.LockedUntil = nil
+		u.LoginAttempts = 0
+	})
+	return nil
+}
+
+func (r *UserRepository) UpdateLastLogin(ctx context.Context, userID string, loginTime time.Time) error {
+	if err := r.Call("UpdateLastLogin"); err != nil {
+		return err
+	}
+	r.modify(userID, func(u *domain.User) {
+		u.LastLoginAt = &loginTime
+		u.LoginAttempts = 0
+		u.LockedUntil = nil
+	})
+	return nil
+}
+
+func (r *UserRepository) UpdateProfile(ctx context.Context, userID string, updates interfaces.ProfileUpdate) error {
+	if err := r.Call("UpdateProfile"); err != nil {
+		return err
+	}
+	return r.modify(userID, func(u *domain.User) {
+		if updates.FirstName != nil {
+			u.FirstName = *updates.FirstName
+		}
+		if updates.LastName != nil {
+			u.LastName = *updates.LastName
+		}
+		if updates.Phone != nil {
+			u.Phone = *updates.Phone
+		}
+		if updates.TelegramUsername != nil {
+			u.TelegramUsername = *updates.TelegramUsername
+		}
+		if updates.TelegramChatID != nil {
+			u.TelegramChatID = *updates.TelegramChatID
+		}
+	})
+}
+
+func (r *UserRepository) UpdateTelegramInfo(ctx context.Context, userID, chatID, username string) error {
+	if err := r.Call("UpdateTelegramInfo"); err != nil {
+		return err
+	}
+	return r.modify(userID, func(u *domain.User) {
+		u.TelegramChatID = chatID
+		u.TelegramUsername = username
+	})
+}
+
+func (r *UserRepository) GetTelegramInfo(ctx context.Context, userID string) (chatID, username string, err error) {
+	if err := r.Call("GetTelegramInfo"); err != nil {
+		return "", "", err
+	}
+	user, ok := r.Get(userID)
+	if !ok || user.Status == domain.StatusDeleted {
+		return "", "", domain.ErrUserNotFound
+	}
+	return user.TelegramChatID, user.TelegramUsername, nil
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, userID string, role domain.UserRole) error {
+	if err := r.Call("UpdateRole"); err != nil {
+		return err
+	}
+	return r.modify(userID, func(u *domain.User) {
+		u.Role = role
+	})
+}
+
+func (r *UserRepository) UpdateStatus(ctx context.Context, userID string, status domain.UserStatus) error {
+	if err := r.Call("UpdateStatus"); err != nil {
+		return err
+	}
+	return r.modify(userID, func(u *domain.User) {
+		u.Status = status
+	})
+}
+
+func (r *UserRepository) GetUsersByRole(ctx context.Context, role domain.UserRole) ([]*domain.User, error) {
+	if err := r.Call("GetUsersByRole"); err != nil {
+		return nil, err
+	}
+	users := r.Store.List(func(u *domain.User) bool {
+		return u.Role == role && u.Status != domain.StatusDeleted
+	})
+	sortUsers(users, "created_at", "desc")
+	return users, nil
+}
+
+func (r *UserRepository) GetUsersByStatus(ctx context.Context, status domain.UserStatus) ([]*domain.User, error) {
+	if err := r.Call("GetUsersByStatus"); err != nil {
+		return nil, err
+	}
+	users := r.Store.List(func(u *domain.User) bool { return u.Status == status })
+	sortUsers(users, "created_at", "desc")
+	return users, nil
+}
+
+func (r *UserRepository) UpdateMetadata(ctx context.Context, userID string, metadata map[string]string) error {
+	if err := r.Call("UpdateMetadata"); err != nil {
+		return err
+	}
+	return r.modify(userID, func(u *domain.User) {
+		u.Metadata = metadata
+	})
+}
+
+func (r *UserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	if err := r.Call("ExistsByEmail"); err != nil {
+		return false, err
+	}
+	users := r.Store.List(func(u *domain.User) bool {
+		return u.Email == strings.ToLower(email) && u.Status != domain.StatusDeleted
+	})
+	return len(users) > 0, nil
+}
+
+func (r *UserRepository) ExistsByID(ctx context.Context, id string) (bool, error) {
+	if err := r.Call("ExistsByID"); err != nil {
+		return false, err
+	}
+	user, ok := r.Get(id)
+	return ok && user.Status != domain.StatusDeleted, nil
+}
+
+func (r *UserRepository) GetTotalUsers(ctx context.Context) (int, error) {
+	if err := r.Call("GetTotalUsers"); err != nil {
+		return 0, err
+	}
+	return len(r.Store.List(func(u *domain.User) bool { return u.Status != domain.StatusDeleted })), nil
+}
+
+func (r *UserRepository) GetUserStats(ctx context.Context) (*interfaces.UserStats, error) {
+	if err := r.Call("GetUserStats"); err != nil {
+		return nil, err
+	}
+	stats := &interfaces.UserStats{UsersByRole: make(map[domain.UserRole]int)}
+	dayAgo := time.Now().Add(-24 * time.Hour)
+	for _, u := range r.Store.List(nil) {
+		stats.TotalUsers++
+		switch u.Status {
+		case domain.StatusActive:
+			stats.ActiveUsers++
+		case domain.StatusInactive:
+			stats.InactiveUsers++
+		case domain.StatusSuspended:
+			stats.SuspendedUsers++
+		case domain.StatusDeleted:
+			stats.DeletedUsers++
+		}
+		if u.IsLocked() {
+			stats.LockedUsers++
+		}
+		if u.TelegramChatID != "" {
+			stats.UsersWithTelegram++
+		}
+		if u.CreatedAt.After(dayAgo) {
+			stats.RecentSignups++
+		}
+		if u.LastLoginAt != nil && u.LastLoginAt.After(dayAgo) {
+			stats.RecentLogins++
+		}
+		if u.Status != domain.StatusDeleted {
+			stats.UsersByRole[u.Role]++
+		}
+	}
+	return stats, nil
+}
+
+func (r *UserRepository) GetLockedUsers(ctx context.Context) ([]*domain.User, error) {
+	if err := r.Call("GetLockedUsers"); err != nil {
+		return nil, err
+	}
+	users := r.Store.List(func(u *domain.User) bool { return u.IsLocked() })
+	sort.SliceStable(users, func(i, j int) bool { return users[i].LockedUntil.After(*users[j].LockedUntil) })
+	return users, nil
+}
+
+func (r *UserRepository) GetRecentUsers(ctx context.Context, limit int) ([]*domain.User, error) {
+	if err := r.Call("GetRecentUsers"); err != nil {
+		return nil, err
+	}
+	users := r.Store.List(func(u *domain.User) bool { return u.Status != domain.StatusDeleted })
+	sortUsers(users, "created_at", "desc")
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+func (r *UserRepository) DeleteInactiveUsers(ctx context.Context, inactiveSince time.Time) (int, error) {
+	if err := r.Call("DeleteInactiveUsers"); err != nil {
+		return 0, err
+	}
+	inactive := r.Store.List(func(u *domain.User) bool {
+		return u.Status == domain.StatusInactive &&
+			(u.LastLoginAt == nil || u.LastLoginAt.Before(inactiveSince)) &&
+			u.CreatedAt.Before(inactiveSince)
+	})
+	for _, u := range inactive {
+		r.modify(u.ID, func(u *domain.User) {
+			u.Status = domain.StatusDeleted
+		})
+	}
+	return len(inactive), nil
+}
+
+func (r *UserRepository) GetUsersForCleanup(ctx context.Context, criteria interfaces.CleanupCriteria) ([]*domain.User, error) {
+	if err := r.Call("GetUsersForCleanup"); err != nil {
+		return nil, err
+	}
+	users := r.Store.List(func(u *domain.User) bool {
+		if !criteria.InactiveSince.IsZero() && u.LastLoginAt != nil && !u.LastLoginAt.Before(criteria.InactiveSince) {
+			return false
+		}
+		if !criteria.DeletedBefore.IsZero() && (u.Status != domain.StatusDeleted || !u.UpdatedAt.Before(criteria.DeletedBefore)) {
+			return false
+		}
+		if !criteria.DeletionDueBy.IsZero() && (u.Status != domain.StatusPendingDeletion ||
+			u.DeletionScheduledAt == nil || u.DeletionScheduledAt.After(criteria.DeletionDueBy)) {
+			return false
+		}
+		if criteria.NeverLoggedIn && u.LastLoginAt != nil {
+			return false
+		}
+		if criteria.Status != nil && u.Status != *criteria.Status {
+			return false
+		}
+		if !criteria.IncludeTestUsers && (strings.HasSuffix(u.Email, "@example.com") || strings.HasSuffix(u.Email, "@test.com")) {
+			return false
+		}
+		if len(criteria.EmailDomains) > 0 && !hasEmailDomain(u.Email, criteria.EmailDomains) {
+			return false
+		}
+		return true
+	})
+	sortUsers(users, "created_at", "asc")
+	if criteria.Limit > 0 && len(users) > criteria.Limit {
+		users = users[:criteria.Limit]
+	}
+	return users, nil
+}
+
+func (r *UserRepository) PurgeDeletedUser(ctx context.Context, record *interfaces.UserPurgeRecord) error {
+	if err := r.Call("PurgeDeletedUser"); err != nil {
+		return err
+	}
+	user, ok := r.Get(record.UserID)
+	if !ok || user.Status != domain.StatusDeleted || user.UpdatedAt.After(record.DeletedAt) {
+		return domain.ErrUserNotFound
+	}
+	r.Store.Delete(record.UserID)
+	r.purges = append(r.purges, *record)
+	return nil
+}
+
+func (r *UserRepository) FinalizeAccountDeletion(ctx context.Context, record *interfaces.UserPurgeRecord) error {
+	if err := r.Call("FinalizeAccountDeletion"); err != nil {
+		return err
+	}
+	user, ok := r.Get(record.UserID)
+	if !ok || user.Status != domain.StatusPendingDeletion || user.DeletionScheduledAt == nil || user.DeletionScheduledAt.After(time.Now()) {
+		return domain.ErrUserNotFound
+	}
+	r.Store.Delete(record.UserID)
+	r.purges = append(r.purges, *record)
+	return nil
+}
+
+func (r *UserRepository) PurgeTestUser(ctx context.Context, record *interfaces.UserPurgeRecord) error {
+	if err := r.Call("PurgeTestUser"); err != nil {
+		return err
+	}
+	if !r.Store.Delete(record.UserID) {
+		return domain.ErrUserNotFound
+	}
+	r.purges = append(r.purges, *record)
+	return nil
+}
+
+// modify updates a user, deleted or not, and bumps its update time
+func (r *UserRepository) modify(userID string, fn func(*domain.User)) error {
+	found, _ := r.Modify(userID, func(u *domain.User) (*domain.User, error) {
+		fn(u)
+		u.UpdatedAt = time.Now()
+		return u, nil
+	})
+	if !found {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// modifyLive updates a user that is not deleted
+func (r *UserRepository) modifyLive(userID string, fn func(*domain.User)) error {
+	if user, ok := r.Get(userID); !ok || user.Status == domain.StatusDeleted {
+		return domain.ErrUserNotFound
+	}
+	return r.modify(userID, fn)
+}
+
+func matchesUserFilter(u *domain.User, filter interfaces.UserFilter) bool {
+	if filter.Status != nil {
+		if u.Status != *filter.Status {
+			return false
+		}
+	} else if u.Status == domain.StatusDeleted {
+		return false
+	}
+	if filter.Role != nil && u.Role != *filter.Role {
+		return false
+	}
+	if filter.CreatedAfter != nil && u.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && u.CreatedAt.After(*filter.CreatedBefore) {
+		return false
+	}
+	if filter.LastLoginAfter != nil && (u.LastLoginAt == nil || u.LastLoginAt.Before(*filter.LastLoginAfter)) {
+		return false
+	}
+	if filter.LastLoginBefore != nil && (u.LastLoginAt == nil || u.LastLoginAt.After(*filter.LastLoginBefore)) {
+		return false
+	}
+	if filter.IsLocked != nil && u.IsLocked() != *filter.IsLocked {
+		return false
+	}
+	return true
+}
+
+// pageUsers sorts and pages users the way the SQL repository does; a zero limit
+// returns nothing, as LIMIT 0 does
+func pageUsers(users []*domain.User, filter interfaces.UserFilter) []*domain.User {
+	sortUsers(users, filter.SortBy, filter.SortOrder)
+	if filter.Offset >= len(users) {
+		return nil
+	}
+	users = users[filter.Offset:]
+	if len(users) > filter.Limit {
+		users = users[:filter.Limit]
+	}
+	return users
+}
+
+func sortUsers(users []*domain.User, sortBy, sortOrder string) {
+	less := func(a, b *domain.User) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	switch sortBy {
+	case "updated_at":
+		less = func(a, b *domain.User) bool { return a.UpdatedAt.Before(b.UpdatedAt) }
+	case "last_login_at":
+		less = func(a, b *domain.User) bool { return loginTime(a).Before(loginTime(b)) }
+	case "email":
+		less = func(a, b *domain.User) bool { return a.Email < b.Email }
+	case "name":
+		less = func(a, b *domain.User) bool {
+			if a.FirstName != b.FirstName {
+				return a.FirstName < b.FirstName
+			}
+			return a.LastName < b.LastName
+		}
+	case "first_name":
+		less = func(a, b *domain.User) bool { return a.FirstName < b.FirstName }
+	case "last_name":
+		less = func(a, b *domain.User) bool { return a.LastName < b.LastName }
+	case "created_at":
+	default:
+		sortOrder = "desc"
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		if sortOrder == "asc" {
+			return less(users[i], users[j])
+		}
+		return less(users[j], users[i])
+	})
+}
+
+func loginTime(u *domain.User) time.Time {
+	if u.LastLoginAt == nil {
+		return time.Time{}
+	}
+	return *u.LastLoginAt
+}
+
+func cloneUser(u *domain.User) *domain.User {
+	c := *u
+	if u.Metadata != nil {
+		c.Metadata = make(map[string]string, len(u.Metadata))
+		for k, v := range u.Metadata {
+			c.Metadata[k] = v
+		}
+	}
+	if u.LastLoginAt != nil {
+		t := *u.LastLoginAt
+		c.LastLoginAt = &t
+	}
+	if u.LockedUntil != nil {
+		t := *u.LockedUntil
+		c.LockedUntil = &t
+	}
+	if u.DeletionScheduledAt != nil {
+		t := *u.DeletionScheduledAt
+		c.DeletionScheduledAt = &t
+	}
+	return &c
+}
+
+func hasEmailDomain(email string, domains []string) bool {
+	_, emailDomain, _ := strings.Cut(email, "@")
+	for _, candidate := range domains {
+		if strings.EqualFold(emailDomain, candidate) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package geoip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/oschwald/geoip2-golang"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+)
+
+// Supported provider names for IAM_GEOIP_PROVIDER
+const (
+	ProviderNone    = "none"
+	ProviderMaxMind = "maxmind"
+)
+
+// Provider resolves client IP addresses to approximate locations. Lookup
+// returns nil without an error when the address is not in the database or
+// cannot be located, e.g. private and loopback addresses.
+type Provider interface {
+	Lookup(ctx context.Context, ipAddress string) (*domain.GeoLocation, error)
+	Close() error
+}
+
+// NewProvider creates the provider selected by name
+func NewProvider(name, databasePath string) (Provider, error) {
+	switch strings.ToLower(name) {
+	case "", ProviderNone:
+		return NoopProvider{}, nil
+	case ProviderMaxMind:
+		return NewMaxMindProvider(databasePath)
+	default:
+		return nil, fmt.Errorf("unknown GeoIP provider %q", name)
+	}
+}
+
+// NoopProvider never resolves a location; sessions are stored without one
+type NoopProvider struct{}
+
+// Lookup always returns no location
+func (NoopProvider) Lookup(ctx context.Context, ipAddress string) (*domain.GeoLocation, error) {
+	return nil, nil
+}
+
+// Close is a no-op
+func (NoopProvider) Close() error {
+	return nil
+}
+
+// MaxMindProvider looks up locations in a MaxMind GeoIP2 or GeoLite2 City database file
+type MaxMindProvider struct {
+	reader *geoip2.Reader
+}
+
+// NewMaxMindProvider opens a MaxMind City database (.mmdb) file
+func NewMaxMindProvider(databasePath string) (*MaxMindProvider, error) {
+	if databasePath == "" {
+		return nil, fmt.Errorf("MaxMind GeoIP provider requires a database path")
+	}
+
+	reader, err := geoip2.Open(databasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open MaxMind database %s: %w", databasePath, err)
+	}
+
+	return &MaxMindProvider{reader: reader}, nil
+}
+
+// Lookup resolves an IP address using the City database
+func (p *MaxMindProvider) Lookup(ctx context.Context, ipAddress string) (*domain.GeoLocation, error) {
+	ip := parseIP(ipAddress)
+	if ip == nil || ip.IsPrivate() || ip.IsLoopback() || ip.IsUnspecified() {
+		return nil, nil
+	}
+
+	record, err := p.reader.City(ip)
+	if err != nil {
+		return nil, fmt.Errorf("GeoIP lookup failed for %s: %w", ipAddress, err)
+	}
+
+	// An all-zero record means the address is not in the database
+	if record.Country.IsoCode == "" && record.Location.Latitude == 0 && record.Location.Longitude == 0 {
+		return nil, nil
+	}
+
+	return &domain.GeoLocation{
+		CountryCode: record.Country.IsoCode,
+		Country:     record.Country.Names["en"],
+		City:        record.City.Names["en"],
+		Latitude:    record.Location.Latitude,
+		Longitude:   record.Location.Longitude,
+		AccuracyKm:  int(record.Location.AccuracyRadius),
+	}, nil
+}
+
+// Close releases the database file
+func (p *MaxMindProvider) Close() error {
+	return p.reader.Close()
+}
+
+// parseIP accepts a bare address or host:port, as clients report either
+func parseIP(address string) net.IP {
+	address = strings.TrimSpace(address)
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	return net.ParseIP(address)
+}
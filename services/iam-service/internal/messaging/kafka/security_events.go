@@ -0,0 +1,95 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/cloudevents"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
+)
+
+// EventTypeOrderAbuseDetected is published by order-service when a user keeps
+// exceeding the order placement limits
+const EventTypeOrderAbuseDetected = "order.abuse_detected"
+
+// AbuseLocker locks accounts reported as abusive
+type AbuseLocker interface {
+	LockForAbuse(ctx context.Context, userID, reason string) error
+}
+
+// orderAbusePayload is the wire format of an order abuse event
+type orderAbusePayload struct {
+	UserID        string `json:"user_id"`
+	ClientIP      string `json:"client_ip"`
+	Rejections    int64  `json:"rejections"`
+	WindowSeconds int64  `json:"window_seconds"`
+}
+
+// SecurityEventConsumer locks the accounts other services report as abusive
+type SecurityEventConsumer struct {
+	consumer *kafka.Consumer
+	topic    string
+	locker   AbuseLocker
+}
+
+// NewSecurityEventConsumer registers a security event consumer for the topic on the shared Kafka consumer
+func NewSecurityEventConsumer(consumer *kafka.Consumer, topic string, locker AbuseLocker, reporter recovery.Reporter) *SecurityEventConsumer {
+	c := &SecurityEventConsumer{
+		consumer: consumer,
+		topic:    topic,
+		locker:   locker,
+	}
+	consumer.RegisterHandler(recovery.Handler(c, reporter))
+	return c
+}
+
+// GetSupportedTopics implements kafka.MessageHandler
+func (c *SecurityEventConsumer) GetSupportedTopics() []string {
+	return []string{c.topic}
+}
+
+// HandleMessage implements kafka.MessageHandler. Locking is idempotent, so a
+// redelivered event does no harm.
+func (c *SecurityEventConsumer) HandleMessage(ctx context.Context, message *kafka.Message) error {
+	event, err := cloudevents.Decode(message.Headers[cloudevents.ContentTypeHeader], message.Value)
+	if err != nil {
+		return err
+	}
+
+	eventType := message.EventType
+	if eventType == "" {
+		eventType = event.Type
+	}
+	if eventType != EventTypeOrderAbuseDetected {
+		return nil
+	}
+
+	var payload orderAbusePayload
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal order abuse event: %w", err)
+	}
+	if payload.UserID == "" {
+		log.Printf("Skipping order abuse event %s without a user ID", event.ID)
+		return nil
+	}
+
+	reason := fmt.Sprintf("order placement limit exceeded %d times within %ds (client IP %s)",
+		payload.Rejections, payload.WindowSeconds, payload.ClientIP)
+	if err := c.locker.LockForAbuse(ctx, payload.UserID, reason); err != nil {
+		return fmt.Errorf("failed to lock user %s for order abuse: %w", payload.UserID, err)
+	}
+	return nil
+}
+
+// Start starts consuming security events
+func (c *SecurityEventConsumer) Start(ctx context.Context) error {
+	return c.consumer.Start(ctx)
+}
+
+// Stop stops the underlying Kafka consumer
+func (c *SecurityEventConsumer) Stop() error {
+	return c.consumer.Stop()
+}
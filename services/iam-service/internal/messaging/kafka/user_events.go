@@ -0,0 +1,196 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/cloudevents"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+)
+
+// Event types of the user events consumed by notification-service
+const (
+	EventTypeDeletionRequested  = "user.deletion_requested"
+	EventTypeDeletionCancelled  = "user.deletion_cancelled"
+	EventTypeLoginLinkRequested = "user.login_link_requested"
+	EventTypeFailedLoginBurst   = "iam.failed-login-burst"
+
+	// EventTypeUserChanged tells services caching user data to drop it; it
+	// notifies nobody
+	EventTypeUserChanged = "user.changed"
+)
+
+const eventSource = "iam-service"
+
+// deletionRequestedPayload is the wire format of a deletion requested event
+type deletionRequestedPayload struct {
+	UserID              string    `json:"user_id"`
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+	Reason              string    `json:"reason,omitempty"`
+}
+
+// deletionCancelledPayload is the wire format of a deletion cancelled event
+type deletionCancelledPayload struct {
+	UserID string `json:"user_id"`
+}
+
+// loginLinkRequestedPayload is the wire format of a login link requested event.
+// The link logs its holder in, so it travels encrypted whether or not the topic is.
+type loginLinkRequestedPayload struct {
+	UserID            string    `json:"user_id"`
+	EncryptedLoginURL string    `json:"encrypted_login_url"` // kafka.PayloadEncryption.EncryptField
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// failedLoginBurstPayload is the wire format of a failed login burst event
+type failedLoginBurstPayload struct {
+	UserID        string    `json:"user_id"`
+	Attempts      int64     `json:"attempts"`
+	WindowSeconds int64     `json:"window_seconds"`
+	IPAddress     string    `json:"ip_address"`
+	Device        string    `json:"device,omitempty"`   // e.g. "Chrome on macOS (desktop)"
+	Location      string    `json:"location,omitempty"` // e.g. "Berlin, Germany"
+	OccurredAt    time.Time `json:"occurred_at"`
+	LockURL       string    `json:"lock_url"`
+	LockExpiresAt time.Time `json:"lock_expires_at"`
+}
+
+// userChangedPayload is the wire format of a user changed event
+type userChangedPayload struct {
+	UserID string `json:"user_id"`
+	Change string `json:"change"` // What changed, e.g. profile or role
+}
+
+// UserEventProducer publishes user account events to Kafka
+type UserEventProducer struct {
+	producer   *kafka.Producer
+	topic      string
+	encryption *kafka.PayloadEncryption // Nil without Kafka encryption keys, which disables login links
+}
+
+// NewUserEventProducer creates a new user event producer on top of the shared Kafka producer.
+// encryption encrypts the login links of login link events and may be nil.
+func NewUserEventProducer(producer *kafka.Producer, topic string, encryption *kafka.PayloadEncryption) *UserEventProducer {
+	return &UserEventProducer{
+		producer:   producer,
+		topic:      topic,
+		encryption: encryption,
+	}
+}
+
+// DeletionRequested implements service.DeletionNotifier
+func (p *UserEventProducer) DeletionRequested(ctx context.Context, user *domain.User, reason string) error {
+	if user.DeletionScheduledAt == nil {
+		return fmt.Errorf("user %s has no scheduled deletion", user.ID)
+	}
+
+	return p.publish(ctx, EventTypeDeletionRequested, user.ID, deletionRequestedPayload{
+		UserID:              user.ID,
+		DeletionScheduledAt: user.DeletionScheduledAt.UTC(),
+		Reason:              reason,
+	})
+}
+
+// DeletionCancelled implements service.DeletionNotifier
+func (p *UserEventProducer) DeletionCancelled(ctx context.Context, user *domain.User) error {
+	return p.publish(ctx, EventTypeDeletionCancelled, user.ID, deletionCancelledPayload{
+		UserID: user.ID,
+	})
+}
+
+// SendLoginLink implements service.LoginLinkSender
+func (p *UserEventProducer) SendLoginLink(ctx context.Context, user *domain.User, link string, expiresAt time.Time) error {
+	if p.encryption == nil {
+		return fmt.Errorf("login links cannot be sent without kafka payload encryption keys")
+	}
+	encryptedLink, err := p.encryption.EncryptField(ctx, link)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt login link: %w", err)
+	}
+
+	return p.publish(ctx, EventTypeLoginLinkRequested, user.ID, loginLinkRequestedPayload{
+		UserID:            user.ID,
+		EncryptedLoginURL: encryptedLink,
+		ExpiresAt:         expiresAt.UTC(),
+	})
+}
+
+// FailedLoginBurst implements service.FailedLoginAlerter
+func (p *UserEventProducer) FailedLoginBurst(ctx context.Context, user *domain.User, burst *domain.FailedLoginBurst, lockURL string, lockExpiresAt time.Time) error {
+	payload := failedLoginBurstPayload{
+		UserID:        user.ID,
+		Attempts:      burst.Attempts,
+		WindowSeconds: int64(burst.Window.Seconds()),
+		IPAddress:     burst.IPAddress,
+		OccurredAt:    burst.OccurredAt.UTC(),
+		LockURL:       lockURL,
+		LockExpiresAt: lockExpiresAt.UTC(),
+	}
+	if burst.Device != nil {
+		payload.Device = burst.Device.Description
+	}
+	if burst.Location != nil {
+		payload.Location = burst.Location.String()
+	}
+	return p.publish(ctx, EventTypeFailedLoginBurst, user.ID, payload)
+}
+
+// UserChanged implements service.UserChangeNotifier
+func (p *UserEventProducer) UserChanged(ctx context.Context, userID, change string) error {
+	return p.send(ctx, EventTypeUserChanged, userID, userChangedPayload{
+		UserID: userID,
+		Change: change,
+	}, false)
+}
+
+// PublishLifecycleEvent implements service.UserLifecycleEventPublisher. The
+// CloudEvent keeps the outbox event's ID and time, so consumers can drop
+// redeliveries, and notifies nobody.
+func (p *UserEventProducer) PublishLifecycleEvent(ctx context.Context, event *domain.UserLifecycleEvent) error {
+	ce, err := cloudevents.New("/rocket-science/"+eventSource, event.Type, event.UserID, event.OccurredAt, event.Payload)
+	if err != nil {
+		return err
+	}
+	ce.ID = event.EventID
+	return p.sendEvent(ctx, ce, event.UserID, false)
+}
+
+// publish sends a user event as a JSON CloudEvent flagged to notify the user
+func (p *UserEventProducer) publish(ctx context.Context, eventType, userID string, data interface{}) error {
+	return p.send(ctx, eventType, userID, data, true)
+}
+
+// send sends a user event as a JSON CloudEvent
+func (p *UserEventProducer) send(ctx context.Context, eventType, userID string, data interface{}, notify bool) error {
+	event, err := cloudevents.New("/rocket-science/"+eventSource, eventType, userID, time.Now(), data)
+	if err != nil {
+		return err
+	}
+	return p.sendEvent(ctx, event, userID, notify)
+}
+
+func (p *UserEventProducer) sendEvent(ctx context.Context, event *cloudevents.Event, userID string, notify bool) error {
+	eventType := event.Type
+	headers := map[string]string{
+		kafka.EventTypeHeader:         eventType,
+		kafka.EventIDHeader:           event.ID,
+		kafka.EventSourceHeader:       eventSource,
+		kafka.NotifyHeader:            strconv.FormatBool(notify),
+		cloudevents.ContentTypeHeader: cloudevents.JSONContentType,
+	}
+
+	// Keyed by user ID so the events of an account are consumed in order and
+	// services caching user data know whose to drop
+	if err := p.producer.SendMessage(ctx, p.topic, userID, event, headers); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+// Close closes the underlying Kafka producer
+func (p *UserEventProducer) Close() error {
+	return p.producer.Close()
+}
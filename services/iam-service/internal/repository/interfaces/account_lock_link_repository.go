@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+)
+
+// AccountLockLinkRepository stores the lock links of failed login alerts until
+// they are used or expire
+type AccountLockLinkRepository interface {
+	// Create stores a link until it expires
+	Create(ctx context.Context, link *domain.AccountLockLink) error
+
+	// Consume removes and returns the link stored under a token hash, so a
+	// link can be used once; it fails with domain.ErrInvalidLockLink when
+	// there is none
+	Consume(ctx context.Context, tokenHash string) (*domain.AccountLockLink, error)
+}
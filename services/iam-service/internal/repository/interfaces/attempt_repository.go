@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// AttemptRepository keeps the short-lived counters and blocks used to
+// throttle brute-force attempts against token endpoints
+type AttemptRepository interface {
+	// Hit increments the counter for key in a fixed window starting at its
+	// first hit and returns the new count
+	Hit(ctx context.Context, key string, window time.Duration) (int64, error)
+
+	// Block rejects key for the given duration
+	Block(ctx context.Context, key string, duration time.Duration) error
+
+	// BlockedFor returns how long key stays blocked, or zero if it is not
+	BlockedFor(ctx context.Context, key string) (time.Duration, error)
+
+	// IncrementAnomaly bumps a named anomaly counter
+	IncrementAnomaly(ctx context.Context, name string) error
+
+	// GetAnomalyCounts returns all anomaly counters since they were last reset
+	GetAnomalyCounts(ctx context.Context) (map[string]int64, error)
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+)
+
+// LoginHistoryQuery selects a page of a user's login history, newest first
+type LoginHistoryQuery struct {
+	UserID   string
+	Since    time.Time // Older events are left out
+	BeforeID int64     // Only events before this one; 0 starts at the newest
+	Limit    int
+}
+
+// LoginHistoryRepository stores the latest logins to each account
+type LoginHistoryRepository interface {
+	// Record stores a login and drops the user's events beyond the newest keep
+	// and those that occurred before cutoff
+	Record(ctx context.Context, event *domain.LoginEvent, keep int, cutoff time.Time) error
+
+	// List returns the user's events matching the query, newest first
+	List(ctx context.Context, query LoginHistoryQuery) ([]*domain.LoginEvent, error)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+)
+
+// LoginLinkRepository stores pending password-less login links until they
+// are used or expire
+type LoginLinkRepository interface {
+	// Create stores a link until it expires
+	Create(ctx context.Context, link *domain.LoginLink) error
+
+	// Consume removes and returns the link stored under a token hash, so a
+	// link can be used once; it fails with domain.ErrInvalidLoginLink when
+	// there is none
+	Consume(ctx context.Context, tokenHash string) (*domain.LoginLink, error)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+)
+
+// PermissionCacheRepository caches the permission snapshots of sessions, and keeps
+// the permission version of each user that snapshots are checked against
+type PermissionCacheRepository interface {
+	// Get returns the snapshot cached for a session, nil when there is none, along
+	// with the user's current permission version
+	Get(ctx context.Context, sessionID, userID string) (*domain.PermissionSnapshot, int64, error)
+
+	// Save caches a session's snapshot until the session expires
+	Save(ctx context.Context, sessionID string, snapshot *domain.PermissionSnapshot, expiresAt time.Time) error
+
+	// BumpVersion changes a user's permission version, invalidating the snapshots
+	// of all their sessions
+	BumpVersion(ctx context.Context, userID string) error
+}
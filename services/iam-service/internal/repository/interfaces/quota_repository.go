@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// QuotaRepository keeps the request counters of service client quotas
+type QuotaRepository interface {
+	// Consume increments the counter of the client's window starting at
+	// windowStart, which expires at windowEnd, and returns the new count
+	Consume(ctx context.Context, clientID string, windowStart, windowEnd time.Time) (int64, error)
+
+	// Used returns the count of the client's window starting at windowStart,
+	// or zero if it made no requests in it
+	Used(ctx context.Context, clientID string, windowStart time.Time) (int64, error)
+}
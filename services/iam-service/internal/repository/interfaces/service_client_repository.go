@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+)
+
+// ServiceClientRepository stores the service clients registered for the
+// client credentials grant
+type ServiceClientRepository interface {
+	// Create stores a new client; it returns domain.ErrServiceClientExists if
+	// the client ID is taken
+	Create(ctx context.Context, client *domain.ServiceClient) error
+
+	// GetByClientID returns domain.ErrServiceClientNotFound for unknown clients
+	GetByClientID(ctx context.Context, clientID string) (*domain.ServiceClient, error)
+
+	// List returns all clients, oldest first
+	List(ctx context.Context) ([]*domain.ServiceClient, error)
+
+	// Update saves the client's name, scopes, status and secret
+	Update(ctx context.Context, client *domain.ServiceClient) error
+
+	// TouchLastUsed records when the client last obtained a token
+	TouchLastUsed(ctx context.Context, clientID string, usedAt time.Time) error
+}
@@ -89,6 +89,7 @@ type SessionService interface {
 	// Session cleanup and maintenance
 	CleanupExpiredSessions(ctx context.Context) (*domain.SessionCleanupInfo, error)
 	GetSessionStats(ctx context.Context) (*SessionStats, error)
+	RecountSessionStats(ctx context.Context) (*SessionStats, error)
 
 	// Security monitoring
 	DetectSuspiciousSessions(ctx context.Context) ([]*domain.Session, error)
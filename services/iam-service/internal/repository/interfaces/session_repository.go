@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"net"
 	"time"
 
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
@@ -24,6 +25,7 @@ type SessionRepository interface {
 	GetActiveUserSessions(ctx context.Context, userID string) ([]*domain.Session, error)
 	RevokeUserSessions(ctx context.Context, userID string) error
 	RevokeUserSessionsExcept(ctx context.Context, userID, keepSessionID string) error
+	RestampUserSessions(ctx context.Context, userID string) (int, error) // Bumps the version of the user's active sessions, returning how many
 
 	// Session status management
 	RevokeSession(ctx context.Context, sessionID string) error
@@ -50,6 +52,7 @@ type SessionRepository interface {
 
 	// Session statistics
 	GetSessionStats(ctx context.Context) (*SessionStats, error)
+	RecountSessionStats(ctx context.Context) (*SessionStats, error)
 	GetActiveSessionCount(ctx context.Context) (int, error)
 	GetUserSessionCount(ctx context.Context, userID string) (int, error)
 	GetSessionsByTimeRange(ctx context.Context, start, end time.Time) ([]*domain.Session, error)
@@ -83,6 +86,7 @@ type SessionFilter struct {
 	UserID    *string               `json:"user_id,omitempty"`
 	Status    *domain.SessionStatus `json:"status,omitempty"`
 	IPAddress *string               `json:"ip_address,omitempty"`
+	IPNetwork *net.IPNet            `json:"ip_network,omitempty"` // Matches sessions whose IP is in the range
 	UserAgent *string               `json:"user_agent,omitempty"`
 
 	// Time filters
@@ -132,8 +136,19 @@ type SessionStats struct {
 	// Performance metrics
 	CleanupCandidates int `json:"cleanup_candidates"`
 	LongLivedSessions int `json:"long_lived_sessions"`
+
+	Source string `json:"source"` // SessionStatsSourceCounters or SessionStatsSourceRecount
 }
 
+// Sources of session statistics
+const (
+	// SessionStatsSourceCounters marks statistics served from the rolling counters
+	// kept as sessions change, with approximate time windows and unique users
+	SessionStatsSourceCounters = "counters"
+	// SessionStatsSourceRecount marks statistics computed by reading every session
+	SessionStatsSourceRecount = "recount"
+)
+
 // SuspiciousSessionCriteria defines criteria for identifying suspicious sessions
 type SuspiciousSessionCriteria struct {
 	MultipleIPsThreshold     int           `json:"multiple_ips_threshold"` // Sessions from multiple IPs
@@ -195,3 +210,36 @@ type SessionCleanupResult struct {
 	Duration       time.Duration              `json:"duration"`
 	Errors         []string                   `json:"errors,omitempty"`
 }
+
+// SessionStorePromoter fails session storage over to the replica region
+type SessionStorePromoter interface {
+	// PromoteSessionStore makes the replica the primary session store. Unless
+	// force is set, it refuses while the primary still answers, so a network
+	// partition can't leave two writable stores behind.
+	PromoteSessionStore(ctx context.Context, force bool) (*SessionStoreFailover, error)
+
+	// SessionStoreStatus reports the regions and whether reads are being served
+	// from the replica
+	SessionStoreStatus(ctx context.Context) (*SessionStoreStatus, error)
+}
+
+// SessionStoreStatus describes the replicated session store
+type SessionStoreStatus struct {
+	PrimaryRegion    string        `json:"primary_region"`
+	PrimaryAddress   string        `json:"primary_address"`
+	PrimaryReachable bool          `json:"primary_reachable"`
+	ReplicaRegion    string        `json:"replica_region,omitempty"`
+	ReplicaAddress   string        `json:"replica_address,omitempty"`
+	ReplicaStaleness time.Duration `json:"replica_staleness"` // Time since the replica last heard from the primary
+	ReadingReplica   bool          `json:"reading_replica"`   // Reads currently fall back to the replica
+}
+
+// SessionStoreFailover reports the outcome of a session store promotion
+type SessionStoreFailover struct {
+	PreviousPrimaryRegion string        `json:"previous_primary_region"`
+	PrimaryRegion         string        `json:"primary_region"`
+	PrimaryAddress        string        `json:"primary_address"`
+	ReplicaStaleness      time.Duration `json:"replica_staleness"` // Writes newer than this may have been lost
+	PromotedAt            time.Time     `json:"promoted_at"`
+	Steps                 []string      `json:"steps"`
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+)
+
+// UserEventPublishFunc publishes one user lifecycle event
+type UserEventPublishFunc func(ctx context.Context, event *domain.UserLifecycleEvent) error
+
+// UserEventOutboxRepository reads the outbox of user lifecycle events, which
+// the users table's trigger writes in the transaction of each change
+type UserEventOutboxRepository interface {
+	// Relay hands up to limit of the oldest unpublished events to publish, in
+	// order, and marks those it published. It stops at the first event publish
+	// fails, so the events of a user are never published out of order. One relay
+	// runs at a time across replicas; the others relay nothing.
+	Relay(ctx context.Context, limit int, publish UserEventPublishFunc) (int, error)
+
+	// Pending counts the events not published yet
+	Pending(ctx context.Context) (int, error)
+}
@@ -56,6 +56,19 @@ type UserRepository interface {
 	// Cleanup operations
 	DeleteInactiveUsers(ctx context.Context, inactiveSince time.Time) (int, error)
 	GetUsersForCleanup(ctx context.Context, criteria CleanupCriteria) ([]*domain.User, error)
+	// PurgeDeletedUser permanently deletes a user that is still soft deleted
+	// and records the purge in the audit trail, in one transaction. It
+	// returns domain.ErrUserNotFound if the user was restored or already purged.
+	PurgeDeletedUser(ctx context.Context, record *UserPurgeRecord) error
+	// FinalizeAccountDeletion permanently deletes a user whose deletion is
+	// still pending and due, and records it in the audit trail, in one
+	// transaction. It returns domain.ErrUserNotFound if the deletion was
+	// cancelled or already finalized.
+	FinalizeAccountDeletion(ctx context.Context, record *UserPurgeRecord) error
+	// PurgeTestUser permanently deletes a test user whatever its status, and
+	// records the purge in the audit trail, in one transaction. It returns
+	// domain.ErrUserNotFound if the user was already purged.
+	PurgeTestUser(ctx context.Context, record *UserPurgeRecord) error
 }
 
 // UserFilter defines filtering options for user queries
@@ -104,7 +117,35 @@ type UserStats struct {
 type CleanupCriteria struct {
 	InactiveSince    time.Time          `json:"inactive_since"`
 	DeletedBefore    time.Time          `json:"deleted_before"`
+	DeletionDueBy    time.Time          `json:"deletion_due_by"` // Pending deletions scheduled at or before
 	NeverLoggedIn    bool               `json:"never_logged_in"`
 	Status           *domain.UserStatus `json:"status,omitempty"`
 	IncludeTestUsers bool               `json:"include_test_users"`
+	EmailDomains     []string           `json:"email_domains,omitempty"` // Users with an email at one of the domains
+	Limit            int                `json:"limit"`                   // 0 returns every matching user
+}
+
+// UserPurgeRecord is the audit entry written when a deleted user is purged
+type UserPurgeRecord struct {
+	UserID          string          `json:"user_id"`
+	EmailHash       string          `json:"email_hash"` // SHA-256 of the lower-cased email
+	Role            domain.UserRole `json:"role"`
+	DeletedAt       time.Time       `json:"deleted_at"`
+	SessionsRemoved int             `json:"sessions_removed"`
+	Reason          string          `json:"reason"`
+}
+
+// PIIReencryptor rewrites encrypted user PII with the active encryption key
+type PIIReencryptor interface {
+	// ReencryptPIIBatch re-encrypts the PII of up to limit users with IDs
+	// after afterID, in ID order. It returns the last ID scanned, which is
+	// empty when there are no more users.
+	ReencryptPIIBatch(ctx context.Context, afterID string, limit int) (*ReencryptionBatch, error)
+}
+
+// ReencryptionBatch reports the outcome of one re-encryption batch
+type ReencryptionBatch struct {
+	LastID  string `json:"last_id"`
+	Scanned int    `json:"scanned"`
+	Updated int    `json:"updated"`
 }
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// LoginHistoryRepository implements the LoginHistoryRepository interface for PostgreSQL
+type LoginHistoryRepository struct {
+	db *sqlx.DB
+}
+
+// NewLoginHistoryRepository creates a new PostgreSQL login history repository
+func NewLoginHistoryRepository(db *sqlx.DB) interfaces.LoginHistoryRepository {
+	return &LoginHistoryRepository{db: db}
+}
+
+const loginEventColumns = `id, user_id, occurred_at, method, success, failure_reason,
+	ip_address, user_agent, location`
+
+// Record stores a login and prunes the user's history in one transaction
+func (r *LoginHistoryRepository) Record(ctx context.Context, event *domain.LoginEvent, keep int, cutoff time.Time) error {
+	var location interface{}
+	if event.Location != nil {
+		locationJSON, err := json.Marshal(event.Location)
+		if err != nil {
+			return fmt.Errorf("failed to marshal login location: %w", err)
+		}
+		location = string(locationJSON)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO login_events (user_id, occurred_at, method, success, failure_reason, ip_address, user_agent, location)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		event.UserID, event.OccurredAt, event.Method, event.Success, nullableString(event.FailureReason),
+		event.IPAddress, event.UserAgent, location).Scan(&event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record login event: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM login_events
+		WHERE user_id = $1
+		  AND (occurred_at < $2 OR id NOT IN (
+			SELECT id FROM login_events WHERE user_id = $1 ORDER BY id DESC LIMIT $3
+		  ))`,
+		event.UserID, cutoff, keep)
+	if err != nil {
+		return fmt.Errorf("failed to prune login history: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit login event: %w", err)
+	}
+	return nil
+}
+
+// List returns a page of a user's login history, newest first
+func (r *LoginHistoryRepository) List(ctx context.Context, query interfaces.LoginHistoryQuery) ([]*domain.LoginEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+loginEventColumns+`
+		FROM login_events
+		WHERE user_id = $1 AND occurred_at >= $2 AND ($3 = 0 OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4`,
+		query.UserID, query.Since, query.BeforeID, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.LoginEvent
+	for rows.Next() {
+		event, err := scanLoginEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan login event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// scanLoginEvent scans a login event from a row; the device is parsed from the
+// stored user agent
+func scanLoginEvent(row interface{ Scan(...interface{}) error }) (*domain.LoginEvent, error) {
+	event := &domain.LoginEvent{}
+	var failureReason sql.NullString
+	var locationJSON []byte
+
+	err := row.Scan(
+		&event.ID,
+		&event.UserID,
+		&event.OccurredAt,
+		&event.Method,
+		&event.Success,
+		&failureReason,
+		&event.IPAddress,
+		&event.UserAgent,
+		&locationJSON,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event.FailureReason = failureReason.String
+	event.Device = domain.ParseUserAgent(event.UserAgent)
+	if len(locationJSON) > 0 {
+		event.Location = &domain.GeoLocation{}
+		if err := json.Unmarshal(locationJSON, event.Location); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal login location: %w", err)
+		}
+	}
+	return event, nil
+}
+
+// nullableString stores an empty string as NULL
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
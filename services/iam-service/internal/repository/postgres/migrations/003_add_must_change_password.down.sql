@@ -0,0 +1,5 @@
+-- Drop index
+DROP INDEX IF EXISTS idx_users_must_change_password;
+
+-- Drop column
+ALTER TABLE users DROP COLUMN IF EXISTS must_change_password;
@@ -0,0 +1,5 @@
+-- Users created with a temporary password (e.g. CSV imports) must choose a
+-- new password on first login
+ALTER TABLE users ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_users_must_change_password ON users(must_change_password) WHERE must_change_password;
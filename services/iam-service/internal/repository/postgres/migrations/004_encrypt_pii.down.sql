@@ -0,0 +1,15 @@
+-- Restore the original trigger function
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Encrypted values do not fit the original sizes; only roll back once the
+-- stored PII is plaintext again
+ALTER TABLE users ALTER COLUMN telegram_chat_id TYPE VARCHAR(100);
+ALTER TABLE users ALTER COLUMN phone TYPE VARCHAR(20);
+
+CREATE INDEX IF NOT EXISTS idx_users_telegram_chat_id ON users(telegram_chat_id) WHERE telegram_chat_id IS NOT NULL;
@@ -0,0 +1,20 @@
+-- Phone, Telegram chat ID and metadata may hold AES-GCM ciphertext
+-- ("enc:<key id>:<base64>"), which outgrows the original column sizes
+ALTER TABLE users ALTER COLUMN phone TYPE TEXT;
+ALTER TABLE users ALTER COLUMN telegram_chat_id TYPE TEXT;
+
+-- Ciphertext uses a random nonce, so the chat ID can no longer be looked up by value
+DROP INDEX IF EXISTS idx_users_telegram_chat_id;
+
+-- Let maintenance jobs such as PII re-encryption rewrite rows without
+-- bumping updated_at, which drives retention of deleted users
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF current_setting('iam.preserve_updated_at', true) = 'on' THEN
+        RETURN NEW;
+    END IF;
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_user_purge_audit_purged_at;
+DROP INDEX IF EXISTS idx_user_purge_audit_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS user_purge_audit;
@@ -0,0 +1,15 @@
+-- Audit trail of soft-deleted users that were permanently purged. Only a hash
+-- of the email is kept so the entry itself holds no personal data.
+CREATE TABLE IF NOT EXISTS user_purge_audit (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    email_hash VARCHAR(64) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    purged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    sessions_removed INTEGER NOT NULL DEFAULT 0,
+    reason TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_purge_audit_user_id ON user_purge_audit(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_purge_audit_purged_at ON user_purge_audit(purged_at);
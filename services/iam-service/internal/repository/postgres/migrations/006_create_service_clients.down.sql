@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_service_clients_status;
+
+-- Drop table
+DROP TABLE IF EXISTS service_clients;
@@ -0,0 +1,2 @@
+-- Drop column. Preferences are not moved back into metadata.
+ALTER TABLE users DROP COLUMN IF EXISTS preferences;
@@ -0,0 +1,29 @@
+-- Typed user preferences, kept apart from the free-form metadata. Preferences
+-- missing from the document take their defaults in the service.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS preferences JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Move the preference keys out of plaintext metadata. Encrypted metadata is a
+-- JSON string rather than an object; its keys are moved by the service when
+-- the user is next saved.
+UPDATE users SET
+    preferences = jsonb_strip_nulls(jsonb_build_object(
+        'locale', COALESCE(metadata->>'locale', metadata->>'language'),
+        'timezone', COALESCE(metadata->>'timezone', metadata->>'time_zone'),
+        'marketing_opt_in', CASE lower(metadata->>'marketing_opt_in')
+            WHEN 'true' THEN TRUE WHEN '1' THEN TRUE WHEN 'false' THEN FALSE WHEN '0' THEN FALSE END,
+        'notifications', jsonb_strip_nulls(jsonb_build_object(
+            'order_updates', CASE lower(metadata->>'notify_order_updates')
+                WHEN 'true' THEN TRUE WHEN '1' THEN TRUE WHEN 'false' THEN FALSE WHEN '0' THEN FALSE END,
+            'security_alerts', CASE lower(metadata->>'notify_security_alerts')
+                WHEN 'true' THEN TRUE WHEN '1' THEN TRUE WHEN 'false' THEN FALSE WHEN '0' THEN FALSE END,
+            'telegram', CASE lower(metadata->>'notify_telegram')
+                WHEN 'true' THEN TRUE WHEN '1' THEN TRUE WHEN 'false' THEN FALSE WHEN '0' THEN FALSE END,
+            'email', CASE lower(metadata->>'notify_email')
+                WHEN 'true' THEN TRUE WHEN '1' THEN TRUE WHEN 'false' THEN FALSE WHEN '0' THEN FALSE END
+        ))
+    )),
+    metadata = metadata - ARRAY['locale', 'language', 'timezone', 'time_zone', 'marketing_opt_in',
+        'notify_order_updates', 'notify_security_alerts', 'notify_telegram', 'notify_email']
+WHERE jsonb_typeof(metadata) = 'object'
+  AND metadata ?| ARRAY['locale', 'language', 'timezone', 'time_zone', 'marketing_opt_in',
+        'notify_order_updates', 'notify_security_alerts', 'notify_telegram', 'notify_email'];
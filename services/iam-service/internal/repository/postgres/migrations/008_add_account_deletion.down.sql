@@ -0,0 +1,10 @@
+-- Accounts still pending deletion are kept
+UPDATE users SET status = 'active' WHERE status = 'pending_deletion';
+
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('active', 'inactive', 'suspended', 'deleted'));
+
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
@@ -0,0 +1,10 @@
+-- Self-service account deletion. Accounts pending deletion are deleted for
+-- good by the service once deletion_scheduled_at has passed.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('active', 'inactive', 'suspended', 'deleted', 'pending_deletion'));
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_at ON users(deletion_scheduled_at)
+    WHERE status = 'pending_deletion';
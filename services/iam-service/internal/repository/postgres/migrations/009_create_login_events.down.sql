@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_login_events_occurred_at;
+DROP INDEX IF EXISTS idx_login_events_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS login_events;
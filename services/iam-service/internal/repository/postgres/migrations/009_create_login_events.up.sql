@@ -0,0 +1,17 @@
+-- Login history shown to account owners: the latest logins to each account,
+-- successful or not. The service keeps a bounded number of events per user
+-- and drops events older than the retention period.
+CREATE TABLE IF NOT EXISTS login_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    method VARCHAR(20) NOT NULL,
+    success BOOLEAN NOT NULL,
+    failure_reason VARCHAR(50),
+    ip_address VARCHAR(45) NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    location JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_events_user_id ON login_events(user_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_login_events_occurred_at ON login_events(occurred_at);
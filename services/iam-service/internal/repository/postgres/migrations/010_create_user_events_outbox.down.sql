@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trigger_record_user_event ON users;
+DROP FUNCTION IF EXISTS record_user_event();
+DROP TABLE IF EXISTS user_events_outbox;
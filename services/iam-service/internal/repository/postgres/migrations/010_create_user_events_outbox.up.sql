@@ -0,0 +1,98 @@
+-- Outbox of user lifecycle events. A trigger records an event in the
+-- transaction of every write that creates, changes or deletes a user, and the
+-- service relays the events to Kafka in order. Consumers react to users being
+-- created, updated, deactivated or deleted without polling the users table.
+-- Payloads carry no personal data; consumers look the user up when they need it.
+CREATE TABLE IF NOT EXISTS user_events_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    event_type VARCHAR(50) NOT NULL,
+    user_id UUID NOT NULL, -- No foreign key: deletions are recorded too
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_events_outbox_pending ON user_events_outbox(id)
+    WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_events_outbox_published_at ON user_events_outbox(published_at)
+    WHERE published_at IS NOT NULL;
+
+-- Sign-ins only touch last_login_at and login_attempts and announce nothing.
+-- Phone, Telegram chat ID and metadata are re-encrypted with a fresh nonce on
+-- every write, so they are not compared; Telegram links are detected by the
+-- username, which is stored in plaintext. Maintenance rewrites that preserve
+-- updated_at, such as PII re-encryption, announce nothing either.
+CREATE OR REPLACE FUNCTION record_user_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    subject users%ROWTYPE;
+    kind VARCHAR(50);
+    changed TEXT[] := ARRAY[]::TEXT[];
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        subject := NEW;
+        kind := 'user.created';
+    ELSIF TG_OP = 'DELETE' THEN
+        IF OLD.status = 'deleted' THEN
+            RETURN OLD; -- Announced when the user was soft deleted
+        END IF;
+        subject := OLD;
+        kind := 'user.deleted';
+    ELSE
+        IF current_setting('iam.preserve_updated_at', true) = 'on' THEN
+            RETURN NEW;
+        END IF;
+        subject := NEW;
+
+        IF NEW.email IS DISTINCT FROM OLD.email THEN changed := changed || 'email'::TEXT; END IF;
+        IF NEW.first_name IS DISTINCT FROM OLD.first_name OR NEW.last_name IS DISTINCT FROM OLD.last_name THEN
+            changed := changed || 'name'::TEXT;
+        END IF;
+        IF NEW.password_hash IS DISTINCT FROM OLD.password_hash THEN changed := changed || 'password'::TEXT; END IF;
+        IF NEW.role IS DISTINCT FROM OLD.role THEN changed := changed || 'role'::TEXT; END IF;
+        IF NEW.status IS DISTINCT FROM OLD.status THEN changed := changed || 'status'::TEXT; END IF;
+        IF NEW.locked_until IS DISTINCT FROM OLD.locked_until THEN changed := changed || 'lock'::TEXT; END IF;
+        IF NEW.telegram_username IS DISTINCT FROM OLD.telegram_username THEN changed := changed || 'telegram'::TEXT; END IF;
+        IF NEW.preferences IS DISTINCT FROM OLD.preferences THEN changed := changed || 'preferences'::TEXT; END IF;
+        IF NEW.must_change_password IS DISTINCT FROM OLD.must_change_password THEN
+            changed := changed || 'must_change_password'::TEXT;
+        END IF;
+        IF NEW.deletion_scheduled_at IS DISTINCT FROM OLD.deletion_scheduled_at THEN
+            changed := changed || 'deletion_scheduled_at'::TEXT;
+        END IF;
+
+        IF cardinality(changed) = 0 THEN
+            RETURN NEW;
+        END IF;
+
+        IF NEW.status = 'deleted' AND OLD.status <> 'deleted' THEN
+            kind := 'user.deleted';
+        ELSIF NEW.status IN ('inactive', 'suspended') AND OLD.status NOT IN ('inactive', 'suspended') THEN
+            kind := 'user.deactivated';
+        ELSE
+            kind := 'user.updated';
+        END IF;
+    END IF;
+
+    INSERT INTO user_events_outbox (event_type, user_id, payload)
+    VALUES (kind, subject.id, jsonb_build_object(
+        'user_id', subject.id,
+        'role', subject.role,
+        'status', subject.status,
+        'changed', to_jsonb(changed),
+        'hard_delete', TG_OP = 'DELETE'
+    ));
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_record_user_event ON users;
+CREATE TRIGGER trigger_record_user_event
+    AFTER INSERT OR UPDATE OR DELETE ON users
+    FOR EACH ROW
+    EXECUTE FUNCTION record_user_event();
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/platform/retention"
+)
+
+// PurgeAuditRetentionPolicy is the retention policy of the audit trail of purged users
+func PurgeAuditRetentionPolicy(ttl time.Duration) retention.Policy {
+	return retention.Policy{
+		Table:      "user_purge_audit",
+		TimeColumn: "purged_at",
+		TTL:        ttl,
+	}
+}
+
+// UserEventOutboxRetentionPolicy is the retention policy of published user
+// events; pending ones are kept until they are published
+func UserEventOutboxRetentionPolicy(ttl time.Duration) retention.Policy {
+	return retention.Policy{
+		Table:      "user_events_outbox",
+		TimeColumn: "published_at",
+		TTL:        ttl,
+		Condition:  "published_at IS NOT NULL",
+	}
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// ServiceClientRepository implements the ServiceClientRepository interface for PostgreSQL
+type ServiceClientRepository struct {
+	db *sqlx.DB
+}
+
+// NewServiceClientRepository creates a new PostgreSQL service client repository
+func NewServiceClientRepository(db *sqlx.DB) interfaces.ServiceClientRepository {
+	return &ServiceClientRepository{db: db}
+}
+
+const serviceClientColumns = `id, client_id, name, secret_hash, scopes, status, created_by,
+	created_at, updated_at, secret_rotated_at, last_used_at`
+
+// Create stores a new service client
+func (r *ServiceClientRepository) Create(ctx context.Context, client *domain.ServiceClient) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO service_clients (`+serviceClientColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		client.ID, client.ClientID, client.Name, client.SecretHash, pq.Array(client.Scopes),
+		string(client.Status), nullableUUID(client.CreatedBy),
+		client.CreatedAt, client.UpdatedAt, client.SecretRotatedAt, client.LastUsedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
+			return domain.ErrServiceClientExists
+		}
+		return fmt.Errorf("failed to create service client: %w", err)
+	}
+	return nil
+}
+
+// GetByClientID retrieves a service client by its client ID
+func (r *ServiceClientRepository) GetByClientID(ctx context.Context, clientID string) (*domain.ServiceClient, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+serviceClientColumns+`
+		FROM service_clients
+		WHERE client_id = $1`, clientID)
+
+	client, err := scanServiceClient(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrServiceClientNotFound
+		}
+		return nil, fmt.Errorf("failed to get service client: %w", err)
+	}
+	return client, nil
+}
+
+// List returns all service clients, oldest first
+func (r *ServiceClientRepository) List(ctx context.Context) ([]*domain.ServiceClient, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+serviceClientColumns+`
+		FROM service_clients
+		ORDER BY created_at, client_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service clients: %w", err)
+	}
+	defer rows.Close()
+
+	var clients []*domain.ServiceClient
+	for rows.Next() {
+		client, err := scanServiceClient(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan service client: %w", err)
+		}
+		clients = append(clients, client)
+	}
+	return clients, rows.Err()
+}
+
+// Update saves a service client's mutable fields
+func (r *ServiceClientRepository) Update(ctx context.Context, client *domain.ServiceClient) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE service_clients
+		SET name = $2, secret_hash = $3, scopes = $4, status = $5,
+			updated_at = $6, secret_rotated_at = $7
+		WHERE client_id = $1`,
+		client.ClientID, client.Name, client.SecretHash, pq.Array(client.Scopes),
+		string(client.Status), client.UpdatedAt, client.SecretRotatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update service client: %w", err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err == nil && rowsAffected == 0 {
+		return domain.ErrServiceClientNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records when a service client last obtained a token
+func (r *ServiceClientRepository) TouchLastUsed(ctx context.Context, clientID string, usedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE service_clients SET last_used_at = $2 WHERE client_id = $1`, clientID, usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record service client use: %w", err)
+	}
+	return nil
+}
+
+// scanServiceClient scans a service client from a row
+func scanServiceClient(row interface{ Scan(...interface{}) error }) (*domain.ServiceClient, error) {
+	client := &domain.ServiceClient{}
+	var status string
+	var createdBy sql.NullString
+	var lastUsedAt sql.NullTime
+
+	err := row.Scan(
+		&client.ID,
+		&client.ClientID,
+		&client.Name,
+		&client.SecretHash,
+		pq.Array(&client.Scopes),
+		&status,
+		&createdBy,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+		&client.SecretRotatedAt,
+		&lastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	client.Status = domain.ServiceClientStatus(status)
+	client.CreatedBy = createdBy.String
+	if lastUsedAt.Valid {
+		client.LastUsedAt = &lastUsedAt.Time
+	}
+	return client, nil
+}
+
+// nullableUUID stores an empty ID as NULL
+func nullableUUID(id string) interface{} {
+	if id == "" {
+		return nil
+	}
+	return id
+}
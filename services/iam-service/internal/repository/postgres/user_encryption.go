@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/encryption"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// Encrypted PII columns; the column name is bound to the ciphertext
+const (
+	fieldPhone          = "users.phone"
+	fieldTelegramChatID = "users.telegram_chat_id"
+	fieldMetadata       = "users.metadata"
+)
+
+// UserRepositoryOption configures optional user repository behaviour
+type UserRepositoryOption func(*UserRepository)
+
+// WithFieldEncryption encrypts phone, Telegram chat ID and metadata at rest.
+// Values written before encryption was enabled remain readable.
+func WithFieldEncryption(keyring *encryption.Keyring) UserRepositoryOption {
+	return func(r *UserRepository) {
+		r.keyring = keyring
+	}
+}
+
+// encryptField encrypts a PII value when encryption is enabled
+func (r *UserRepository) encryptField(field, value string) (string, error) {
+	if r.keyring == nil {
+		return value, nil
+	}
+	encrypted, err := r.keyring.Encrypt(field, value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt %s: %w", field, err)
+	}
+	return encrypted, nil
+}
+
+// decryptField decrypts a stored PII value; plaintext values pass through
+func (r *UserRepository) decryptField(field, value string) (string, error) {
+	if !encryption.IsEncrypted(value) {
+		return value, nil
+	}
+	if r.keyring == nil {
+		return "", fmt.Errorf("%s is encrypted but no encryption keys are configured", field)
+	}
+	return r.keyring.Decrypt(field, value)
+}
+
+// encodeMetadata marshals metadata for the JSONB column. Encrypted metadata
+// is stored as a JSON string holding the ciphertext of the JSON object.
+func (r *UserRepository) encodeMetadata(metadata map[string]string) ([]byte, error) {
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	if r.keyring == nil {
+		return metadataJSON, nil
+	}
+
+	encrypted, err := r.encryptField(fieldMetadata, string(metadataJSON))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encrypted)
+}
+
+// decodeMetadata reverses encodeMetadata, accepting plaintext objects too
+func (r *UserRepository) decodeMetadata(raw []byte) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if len(raw) == 0 {
+		return metadata, nil
+	}
+
+	if raw[0] == '"' {
+		var stored string
+		if err := json.Unmarshal(raw, &stored); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+		plaintext, err := r.decryptField(fieldMetadata, stored)
+		if err != nil {
+			return nil, err
+		}
+		raw = []byte(plaintext)
+	}
+
+	if err := json.Unmarshal(raw, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// decryptUser replaces the stored PII of a scanned user with its plaintext and
+// decodes its preferences
+func (r *UserRepository) decryptUser(user *domain.User, metadataJSON, preferencesJSON []byte) error {
+	var err error
+	if user.Phone, err = r.decryptField(fieldPhone, user.Phone); err != nil {
+		return err
+	}
+	if user.TelegramChatID, err = r.decryptField(fieldTelegramChatID, user.TelegramChatID); err != nil {
+		return err
+	}
+	if user.Metadata, err = r.decodeMetadata(metadataJSON); err != nil {
+		return err
+	}
+	return decodePreferences(user, preferencesJSON)
+}
+
+// decodePreferences unmarshals the preferences column over the defaults, so settings
+// added later default for existing users. Preferences still held in encrypted metadata,
+// which the migration couldn't reach, are moved over and stored with the next update.
+func decodePreferences(user *domain.User, preferencesJSON []byte) error {
+	user.Preferences = domain.DefaultPreferences()
+	if len(preferencesJSON) > 0 {
+		if err := json.Unmarshal(preferencesJSON, &user.Preferences); err != nil {
+			return fmt.Errorf("failed to unmarshal preferences: %w", err)
+		}
+	}
+	user.MigrateLegacyPreferences()
+	return nil
+}
+
+// ReencryptPIIBatch rewrites the PII of a batch of users that is stored in
+// plaintext or under a retired key. Rows are updated without touching
+// updated_at, which drives retention of deleted users.
+func (r *UserRepository) ReencryptPIIBatch(ctx context.Context, afterID string, limit int) (*interfaces.ReencryptionBatch, error) {
+	if r.keyring == nil {
+		return nil, fmt.Errorf("PII encryption is not enabled")
+	}
+
+	query := `
+		SELECT id, COALESCE(phone, ''), COALESCE(telegram_chat_id, ''), metadata
+		FROM users
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2`
+
+	if afterID == "" {
+		afterID = "00000000-0000-0000-0000-000000000000"
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read users for re-encryption: %w", err)
+	}
+
+	type piiRow struct {
+		id, phone, chatID string
+		metadata          []byte
+	}
+	var batch []piiRow
+	for rows.Next() {
+		var row piiRow
+		if err := rows.Scan(&row.id, &row.phone, &row.chatID, &row.metadata); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan user for re-encryption: %w", err)
+		}
+		batch = append(batch, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read users for re-encryption: %w", err)
+	}
+
+	result := &interfaces.ReencryptionBatch{Scanned: len(batch)}
+	if len(batch) == 0 {
+		return result, nil
+	}
+	result.LastID = batch[len(batch)-1].id
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin re-encryption transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Checked by the updated_at trigger, see migration 004
+	if _, err := tx.ExecContext(ctx, "SET LOCAL iam.preserve_updated_at = 'on'"); err != nil {
+		return nil, fmt.Errorf("failed to configure re-encryption transaction: %w", err)
+	}
+
+	for _, row := range batch {
+		if !r.needsReencryption(row.phone, row.chatID, row.metadata) {
+			continue
+		}
+
+		if err := r.reencryptRow(ctx, tx, row.id, row.phone, row.chatID, row.metadata); err != nil {
+			return nil, fmt.Errorf("failed to re-encrypt user %s: %w", row.id, err)
+		}
+		result.Updated++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit re-encryption batch: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *UserRepository) needsReencryption(phone, chatID string, metadata []byte) bool {
+	if r.keyring.NeedsReencryption(phone) || r.keyring.NeedsReencryption(chatID) {
+		return true
+	}
+
+	if len(metadata) == 0 || metadata[0] != '"' {
+		return true // Plaintext metadata object
+	}
+	var stored string
+	if err := json.Unmarshal(metadata, &stored); err != nil {
+		return true
+	}
+	return r.keyring.NeedsReencryption(stored)
+}
+
+func (r *UserRepository) reencryptRow(ctx context.Context, tx *sql.Tx, id, phone, chatID string, metadataJSON []byte) error {
+	var err error
+	if phone, err = r.decryptField(fieldPhone, phone); err != nil {
+		return err
+	}
+	if phone, err = r.encryptField(fieldPhone, phone); err != nil {
+		return err
+	}
+	if chatID, err = r.decryptField(fieldTelegramChatID, chatID); err != nil {
+		return err
+	}
+	if chatID, err = r.encryptField(fieldTelegramChatID, chatID); err != nil {
+		return err
+	}
+
+	metadata, err := r.decodeMetadata(metadataJSON)
+	if err != nil {
+		return err
+	}
+	if metadataJSON, err = r.encodeMetadata(metadata); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users
+		SET phone = NULLIF($1, ''), telegram_chat_id = NULLIF($2, ''), metadata = $3
+		WHERE id = $4`,
+		phone, chatID, metadataJSON, id)
+	return err
+}
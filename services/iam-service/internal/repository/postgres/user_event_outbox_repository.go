@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// userEventRelayLock is the advisory lock held by the replica relaying the outbox
+const userEventRelayLock = "iam.user_events_outbox"
+
+// UserEventOutboxRepository implements the UserEventOutboxRepository interface for PostgreSQL
+type UserEventOutboxRepository struct {
+	db *sqlx.DB
+}
+
+// NewUserEventOutboxRepository creates a new PostgreSQL user event outbox repository
+func NewUserEventOutboxRepository(db *sqlx.DB) interfaces.UserEventOutboxRepository {
+	return &UserEventOutboxRepository{db: db}
+}
+
+type userEventRow struct {
+	ID         int64     `db:"id"`
+	EventID    string    `db:"event_id"`
+	EventType  string    `db:"event_type"`
+	UserID     string    `db:"user_id"`
+	Payload    []byte    `db:"payload"`
+	OccurredAt time.Time `db:"occurred_at"`
+}
+
+// Relay publishes a batch of pending events in one transaction, which holds the
+// advisory lock so replicas don't publish the same events
+func (r *UserEventOutboxRepository) Relay(ctx context.Context, limit int, publish interfaces.UserEventPublishFunc) (int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.GetContext(ctx, &locked, `SELECT pg_try_advisory_xact_lock(hashtext($1))`, userEventRelayLock); err != nil {
+		return 0, fmt.Errorf("failed to take user event relay lock: %w", err)
+	}
+	if !locked {
+		return 0, nil // Another replica is relaying
+	}
+
+	var rows []userEventRow
+	if err := tx.SelectContext(ctx, &rows, `
+		SELECT id, event_id, event_type, user_id, payload, occurred_at
+		FROM user_events_outbox
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1`, limit); err != nil {
+		return 0, fmt.Errorf("failed to get pending user events: %w", err)
+	}
+
+	var published []int64
+	var publishErr error
+	for _, row := range rows {
+		event := &domain.UserLifecycleEvent{
+			ID:         row.ID,
+			EventID:    row.EventID,
+			Type:       row.EventType,
+			UserID:     row.UserID,
+			Payload:    row.Payload,
+			OccurredAt: row.OccurredAt,
+		}
+		if publishErr = publish(ctx, event); publishErr != nil {
+			break
+		}
+		published = append(published, row.ID)
+	}
+
+	if len(published) > 0 {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE user_events_outbox SET published_at = NOW()
+			WHERE id = ANY($1)`, pq.Array(published)); err != nil {
+			return 0, fmt.Errorf("failed to mark user events published: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, fmt.Errorf("failed to commit user event relay: %w", err)
+		}
+	}
+
+	if publishErr != nil {
+		return len(published), fmt.Errorf("failed to publish user event: %w", publishErr)
+	}
+	return len(published), nil
+}
+
+// Pending counts the events not published yet
+func (r *UserEventOutboxRepository) Pending(ctx context.Context) (int, error) {
+	var pending int
+	if err := r.db.GetContext(ctx, &pending, `SELECT COUNT(*) FROM user_events_outbox WHERE published_at IS NULL`); err != nil {
+		return 0, fmt.Errorf("failed to count pending user events: %w", err)
+	}
+	return pending, nil
+}
@@ -10,22 +10,27 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
-	_ "github.com/lib/pq"
 
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/encryption"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
 )
 
 // UserRepository implements the UserRepository interface for PostgreSQL
 type UserRepository struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	keyring *encryption.Keyring // Optional; nil stores PII in plaintext
 }
 
 // NewUserRepository creates a new PostgreSQL user repository
-func NewUserRepository(db *sqlx.DB) interfaces.UserRepository {
-	return &UserRepository{
+func NewUserRepository(db *sqlx.DB, opts ...UserRepositoryOption) interfaces.UserRepository {
+	r := &UserRepository{
 		db: db,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Create creates a new user in the database
@@ -34,16 +39,30 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 		INSERT INTO users (
 			id, email, password_hash, first_name, last_name, role, status,
 			created_at, updated_at, last_login_at, login_attempts, locked_until,
-			phone, telegram_username, telegram_chat_id, metadata
+			phone, telegram_username, telegram_chat_id, metadata, preferences, must_change_password,
+			deletion_scheduled_at
 		) VALUES (
 			:id, :email, :password_hash, :first_name, :last_name, :role, :status,
 			:created_at, :updated_at, :last_login_at, :login_attempts, :locked_until,
-			:phone, :telegram_username, :telegram_chat_id, :metadata
+			:phone, :telegram_username, :telegram_chat_id, :metadata, :preferences, :must_change_password,
+			:deletion_scheduled_at
 		)`
 
-	metadataJSON, err := json.Marshal(user.Metadata)
+	metadataJSON, err := r.encodeMetadata(user.Metadata)
+	if err != nil {
+		return err
+	}
+	preferencesJSON, err := json.Marshal(user.Preferences)
+	if err != nil {
+		return fmt.Errorf("failed to marshal preferences: %w", err)
+	}
+	phone, err := r.encryptField(fieldPhone, user.Phone)
+	if err != nil {
+		return err
+	}
+	chatID, err := r.encryptField(fieldTelegramChatID, user.TelegramChatID)
 	if err != nil {
-		return fmt.Errorf("failed to marshal metadata: %w", err)
+		return err
 	}
 
 	params := map[string]interface{}{
@@ -59,10 +78,14 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 		"last_login_at":     user.LastLoginAt,
 		"login_attempts":    user.LoginAttempts,
 		"locked_until":      user.LockedUntil,
-		"phone":             user.Phone,
+		"phone":             phone,
 		"telegram_username": user.TelegramUsername,
-		"telegram_chat_id":  user.TelegramChatID,
+		"telegram_chat_id":  chatID,
 		"metadata":          metadataJSON,
+		"preferences":       preferencesJSON,
+
+		"must_change_password":  user.MustChangePassword,
+		"deletion_scheduled_at": user.DeletionScheduledAt,
 	}
 
 	_, err = r.db.NamedExecContext(ctx, query, params)
@@ -86,7 +109,8 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User,
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, role, status,
 			   created_at, updated_at, last_login_at, login_attempts, locked_until,
-			   phone, telegram_username, telegram_chat_id, metadata
+			   phone, telegram_username, telegram_chat_id, metadata, preferences, must_change_password,
+			   deletion_scheduled_at
 		FROM users 
 		WHERE id = $1 AND status != 'deleted'`
 
@@ -106,7 +130,8 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, role, status,
 			   created_at, updated_at, last_login_at, login_attempts, locked_until,
-			   phone, telegram_username, telegram_chat_id, metadata
+			   phone, telegram_username, telegram_chat_id, metadata, preferences, must_change_password,
+			   deletion_scheduled_at
 		FROM users 
 		WHERE email = $1 AND status != 'deleted'`
 
@@ -138,12 +163,27 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 			phone = :phone,
 			telegram_username = :telegram_username,
 			telegram_chat_id = :telegram_chat_id,
-			metadata = :metadata
+			metadata = :metadata,
+			preferences = :preferences,
+			must_change_password = :must_change_password,
+			deletion_scheduled_at = :deletion_scheduled_at
 		WHERE id = :id`
 
-	metadataJSON, err := json.Marshal(user.Metadata)
+	metadataJSON, err := r.encodeMetadata(user.Metadata)
+	if err != nil {
+		return err
+	}
+	preferencesJSON, err := json.Marshal(user.Preferences)
+	if err != nil {
+		return fmt.Errorf("failed to marshal preferences: %w", err)
+	}
+	phone, err := r.encryptField(fieldPhone, user.Phone)
+	if err != nil {
+		return err
+	}
+	chatID, err := r.encryptField(fieldTelegramChatID, user.TelegramChatID)
 	if err != nil {
-		return fmt.Errorf("failed to marshal metadata: %w", err)
+		return err
 	}
 
 	params := map[string]interface{}{
@@ -158,10 +198,14 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 		"last_login_at":     user.LastLoginAt,
 		"login_attempts":    user.LoginAttempts,
 		"locked_until":      user.LockedUntil,
-		"phone":             user.Phone,
+		"phone":             phone,
 		"telegram_username": user.TelegramUsername,
-		"telegram_chat_id":  user.TelegramChatID,
+		"telegram_chat_id":  chatID,
 		"metadata":          metadataJSON,
+		"preferences":       preferencesJSON,
+
+		"must_change_password":  user.MustChangePassword,
+		"deletion_scheduled_at": user.DeletionScheduledAt,
 	}
 
 	result, err := r.db.NamedExecContext(ctx, query, params)
@@ -233,7 +277,8 @@ func (r *UserRepository) List(ctx context.Context, filter interfaces.UserFilter)
 	query := fmt.Sprintf(`
 		SELECT id, email, password_hash, first_name, last_name, role, status,
 			   created_at, updated_at, last_login_at, login_attempts, locked_until,
-			   phone, telegram_username, telegram_chat_id, metadata
+			   phone, telegram_username, telegram_chat_id, metadata, preferences, must_change_password,
+			   deletion_scheduled_at
 		FROM users %s %s
 		LIMIT $%d OFFSET $%d`,
 		where, orderBy, len(args)+1, len(args)+2)
@@ -284,7 +329,8 @@ func (r *UserRepository) Search(ctx context.Context, query string, filter interf
 	searchQuery := fmt.Sprintf(`
 		SELECT id, email, password_hash, first_name, last_name, role, status,
 			   created_at, updated_at, last_login_at, login_attempts, locked_until,
-			   phone, telegram_username, telegram_chat_id, metadata
+			   phone, telegram_username, telegram_chat_id, metadata, preferences, must_change_password,
+			   deletion_scheduled_at
 		FROM users %s %s
 		LIMIT $%d OFFSET $%d`,
 		where, orderBy, len(args)+1, len(args)+2)
@@ -319,7 +365,7 @@ func (r *UserRepository) ValidateCredentials(ctx context.Context, email, passwor
 func (r *UserRepository) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
 	query := `
 		UPDATE users 
-		SET password_hash = $1, updated_at = NOW()
+		SET password_hash = $1, must_change_password = FALSE, updated_at = NOW()
 		WHERE id = $2`
 
 	result, err := r.db.ExecContext(ctx, query, passwordHash, userID)
@@ -433,8 +479,12 @@ func (r *UserRepository) UpdateProfile(ctx context.Context, userID string, updat
 	}
 
 	if updates.Phone != nil {
+		phone, err := r.encryptField(fieldPhone, *updates.Phone)
+		if err != nil {
+			return err
+		}
 		setParts = append(setParts, fmt.Sprintf("phone = $%d", argIndex))
-		args = append(args, *updates.Phone)
+		args = append(args, phone)
 		argIndex++
 	}
 
@@ -445,8 +495,12 @@ func (r *UserRepository) UpdateProfile(ctx context.Context, userID string, updat
 	}
 
 	if updates.TelegramChatID != nil {
+		chatID, err := r.encryptField(fieldTelegramChatID, *updates.TelegramChatID)
+		if err != nil {
+			return err
+		}
 		setParts = append(setParts, fmt.Sprintf("telegram_chat_id = $%d", argIndex))
-		args = append(args, *updates.TelegramChatID)
+		args = append(args, chatID)
 		argIndex++
 	}
 
@@ -486,7 +540,12 @@ func (r *UserRepository) UpdateTelegramInfo(ctx context.Context, userID, chatID,
 		SET telegram_chat_id = $1, telegram_username = $2, updated_at = NOW()
 		WHERE id = $3`
 
-	result, err := r.db.ExecContext(ctx, query, chatID, username, userID)
+	encryptedChatID, err := r.encryptField(fieldTelegramChatID, chatID)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, encryptedChatID, username, userID)
 	if err != nil {
 		return fmt.Errorf("failed to update telegram info: %w", err)
 	}
@@ -518,6 +577,11 @@ func (r *UserRepository) GetTelegramInfo(ctx context.Context, userID string) (ch
 		return "", "", fmt.Errorf("failed to get telegram info: %w", err)
 	}
 
+	chatID, err = r.decryptField(fieldTelegramChatID, chatID)
+	if err != nil {
+		return "", "", err
+	}
+
 	return chatID, username, nil
 }
 
@@ -574,7 +638,8 @@ func (r *UserRepository) GetUsersByRole(ctx context.Context, role domain.UserRol
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, role, status,
 			   created_at, updated_at, last_login_at, login_attempts, locked_until,
-			   phone, telegram_username, telegram_chat_id, metadata
+			   phone, telegram_username, telegram_chat_id, metadata, preferences, must_change_password,
+			   deletion_scheduled_at
 		FROM users 
 		WHERE role = $1 AND status != 'deleted'
 		ORDER BY created_at DESC`
@@ -592,7 +657,8 @@ func (r *UserRepository) GetUsersByStatus(ctx context.Context, status domain.Use
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, role, status,
 			   created_at, updated_at, last_login_at, login_attempts, locked_until,
-			   phone, telegram_username, telegram_chat_id, metadata
+			   phone, telegram_username, telegram_chat_id, metadata, preferences, must_change_password,
+			   deletion_scheduled_at
 		FROM users 
 		WHERE status = $1
 		ORDER BY created_at DESC`
@@ -607,9 +673,9 @@ func (r *UserRepository) GetUsersByStatus(ctx context.Context, status domain.Use
 
 // UpdateMetadata updates user metadata
 func (r *UserRepository) UpdateMetadata(ctx context.Context, userID string, metadata map[string]string) error {
-	metadataJSON, err := json.Marshal(metadata)
+	metadataJSON, err := r.encodeMetadata(metadata)
 	if err != nil {
-		return fmt.Errorf("failed to marshal metadata: %w", err)
+		return err
 	}
 
 	query := `
@@ -746,7 +812,8 @@ func (r *UserRepository) GetLockedUsers(ctx context.Context) ([]*domain.User, er
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, role, status,
 			   created_at, updated_at, last_login_at, login_attempts, locked_until,
-			   phone, telegram_username, telegram_chat_id, metadata
+			   phone, telegram_username, telegram_chat_id, metadata, preferences, must_change_password,
+			   deletion_scheduled_at
 		FROM users 
 		WHERE locked_until IS NOT NULL AND locked_until > NOW()
 		ORDER BY locked_until DESC`
@@ -764,7 +831,8 @@ func (r *UserRepository) GetRecentUsers(ctx context.Context, limit int) ([]*doma
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, role, status,
 			   created_at, updated_at, last_login_at, login_attempts, locked_until,
-			   phone, telegram_username, telegram_chat_id, metadata
+			   phone, telegram_username, telegram_chat_id, metadata, preferences, must_change_password,
+			   deletion_scheduled_at
 		FROM users 
 		WHERE status != 'deleted'
 		ORDER BY created_at DESC
@@ -818,6 +886,12 @@ func (r *UserRepository) GetUsersForCleanup(ctx context.Context, criteria interf
 		argIndex++
 	}
 
+	if !criteria.DeletionDueBy.IsZero() {
+		whereParts = append(whereParts, fmt.Sprintf("status = 'pending_deletion' AND deletion_scheduled_at <= $%d", argIndex))
+		args = append(args, criteria.DeletionDueBy)
+		argIndex++
+	}
+
 	if criteria.NeverLoggedIn {
 		whereParts = append(whereParts, "last_login_at IS NULL")
 	}
@@ -832,15 +906,31 @@ func (r *UserRepository) GetUsersForCleanup(ctx context.Context, criteria interf
 		whereParts = append(whereParts, "email NOT LIKE '%@example.com' AND email NOT LIKE '%@test.com'")
 	}
 
+	if len(criteria.EmailDomains) > 0 {
+		domains := make([]string, len(criteria.EmailDomains))
+		for i, emailDomain := range criteria.EmailDomains {
+			domains[i] = strings.ToLower(emailDomain)
+		}
+		whereParts = append(whereParts, fmt.Sprintf("LOWER(SPLIT_PART(email, '@', 2)) = ANY($%d)", argIndex))
+		args = append(args, pq.Array(domains))
+		argIndex++
+	}
+
 	query := fmt.Sprintf(`
 		SELECT id, email, password_hash, first_name, last_name, role, status,
 			   created_at, updated_at, last_login_at, login_attempts, locked_until,
-			   phone, telegram_username, telegram_chat_id, metadata
+			   phone, telegram_username, telegram_chat_id, metadata, preferences, must_change_password,
+			   deletion_scheduled_at
 		FROM users 
 		WHERE %s
 		ORDER BY created_at ASC`,
 		strings.Join(whereParts, " AND "))
 
+	if criteria.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, criteria.Limit)
+	}
+
 	users, err := r.scanUsers(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users for cleanup: %w", err)
@@ -849,12 +939,129 @@ func (r *UserRepository) GetUsersForCleanup(ctx context.Context, criteria interf
 	return users, nil
 }
 
+// PurgeDeletedUser hard deletes a soft-deleted user and writes the audit entry.
+// Sessions stored in PostgreSQL are removed by the foreign key cascade.
+func (r *UserRepository) PurgeDeletedUser(ctx context.Context, record *interfaces.UserPurgeRecord) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin purge transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Only purge if the user was not restored or deleted again since it was selected
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM users
+		WHERE id = $1 AND status = 'deleted' AND updated_at <= $2`,
+		record.UserID, record.DeletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to purge user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_purge_audit (user_id, email_hash, role, deleted_at, sessions_removed, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		record.UserID, record.EmailHash, string(record.Role), record.DeletedAt, record.SessionsRemoved, record.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to write purge audit entry: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit user purge: %w", err)
+	}
+	return nil
+}
+
+// FinalizeAccountDeletion hard deletes a user whose self-service deletion is
+// due and writes the audit entry. Sessions stored in PostgreSQL are removed by
+// the foreign key cascade.
+func (r *UserRepository) FinalizeAccountDeletion(ctx context.Context, record *interfaces.UserPurgeRecord) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin account deletion transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Only delete if the user did not cancel since it was selected
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM users
+		WHERE id = $1 AND status = 'pending_deletion' AND deletion_scheduled_at <= NOW()`,
+		record.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_purge_audit (user_id, email_hash, role, deleted_at, sessions_removed, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		record.UserID, record.EmailHash, string(record.Role), record.DeletedAt, record.SessionsRemoved, record.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to write purge audit entry: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit account deletion: %w", err)
+	}
+	return nil
+}
+
+// PurgeTestUser hard deletes a test user and writes the audit entry. Sessions
+// stored in PostgreSQL are removed by the foreign key cascade.
+func (r *UserRepository) PurgeTestUser(ctx context.Context, record *interfaces.UserPurgeRecord) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin purge transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, record.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to purge test user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_purge_audit (user_id, email_hash, role, deleted_at, sessions_removed, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		record.UserID, record.EmailHash, string(record.Role), record.DeletedAt, record.SessionsRemoved, record.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to write purge audit entry: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit test user purge: %w", err)
+	}
+	return nil
+}
+
 // Helper functions
 
 // scanUser scans a single user from a query result
 func (r *UserRepository) scanUser(ctx context.Context, query string, args ...interface{}) (*domain.User, error) {
 	user := &domain.User{}
-	var metadataJSON []byte
+	var metadataJSON, preferencesJSON []byte
 
 	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&user.ID,
@@ -873,18 +1080,17 @@ func (r *UserRepository) scanUser(ctx context.Context, query string, args ...int
 		&user.TelegramUsername,
 		&user.TelegramChatID,
 		&metadataJSON,
+		&preferencesJSON,
+		&user.MustChangePassword,
+		&user.DeletionScheduledAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	// Unmarshal metadata
-	if len(metadataJSON) > 0 {
-		if err := json.Unmarshal(metadataJSON, &user.Metadata); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-		}
-	} else {
-		user.Metadata = make(map[string]string)
+	// Decrypt PII and unmarshal metadata
+	if err := r.decryptUser(user, metadataJSON, preferencesJSON); err != nil {
+		return nil, err
 	}
 
 	return user, nil
@@ -901,7 +1107,7 @@ func (r *UserRepository) scanUsers(ctx context.Context, query string, args ...in
 	var users []*domain.User
 	for rows.Next() {
 		user := &domain.User{}
-		var metadataJSON []byte
+		var metadataJSON, preferencesJSON []byte
 
 		err := rows.Scan(
 			&user.ID,
@@ -920,18 +1126,17 @@ func (r *UserRepository) scanUsers(ctx context.Context, query string, args ...in
 			&user.TelegramUsername,
 			&user.TelegramChatID,
 			&metadataJSON,
+			&preferencesJSON,
+			&user.MustChangePassword,
+			&user.DeletionScheduledAt,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		// Unmarshal metadata
-		if len(metadataJSON) > 0 {
-			if err := json.Unmarshal(metadataJSON, &user.Metadata); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-			}
-		} else {
-			user.Metadata = make(map[string]string)
+		// Decrypt PII and unmarshal metadata
+		if err := r.decryptUser(user, metadataJSON, preferencesJSON); err != nil {
+			return nil, err
 		}
 
 		users = append(users, user)
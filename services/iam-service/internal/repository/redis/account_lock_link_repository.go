@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+const accountLockLinkKeyPrefix = "account_lock_link:"
+
+// AccountLockLinkRepository implements the AccountLockLinkRepository interface for Redis
+type AccountLockLinkRepository struct {
+	client *redis.Client
+}
+
+// NewAccountLockLinkRepository creates a new Redis account lock link repository
+func NewAccountLockLinkRepository(client *redis.Client) interfaces.AccountLockLinkRepository {
+	return &AccountLockLinkRepository{
+		client: client,
+	}
+}
+
+// Create stores a link keyed by its token hash; Redis drops it once it expires
+func (r *AccountLockLinkRepository) Create(ctx context.Context, link *domain.AccountLockLink) error {
+	ttl := time.Until(link.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("account lock link has already expired")
+	}
+
+	data, err := json.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("failed to marshal account lock link: %w", err)
+	}
+	if err := r.client.Set(ctx, accountLockLinkKeyPrefix+link.TokenHash, data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store account lock link: %w", err)
+	}
+	return nil
+}
+
+// Consume gets and deletes a link in one step, so a link locks the account once
+func (r *AccountLockLinkRepository) Consume(ctx context.Context, tokenHash string) (*domain.AccountLockLink, error) {
+	data, err := r.client.GetDel(ctx, accountLockLinkKeyPrefix+tokenHash).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, domain.ErrInvalidLockLink
+		}
+		return nil, fmt.Errorf("failed to consume account lock link: %w", err)
+	}
+
+	var link domain.AccountLockLink
+	if err := json.Unmarshal(data, &link); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal account lock link: %w", err)
+	}
+	link.TokenHash = tokenHash
+	return &link, nil
+}
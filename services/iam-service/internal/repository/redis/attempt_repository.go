@@ -0,0 +1,91 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+const (
+	attemptKeyPrefix = "attempts:"
+	blockKeyPrefix   = "attempt_block:"
+	anomaliesKey     = "attempt_anomalies"
+)
+
+// AttemptRepository implements the AttemptRepository interface for Redis
+type AttemptRepository struct {
+	client *redis.Client
+}
+
+// NewAttemptRepository creates a new Redis attempt repository
+func NewAttemptRepository(client *redis.Client) interfaces.AttemptRepository {
+	return &AttemptRepository{
+		client: client,
+	}
+}
+
+// Hit increments a fixed-window counter; the window starts with the first hit
+func (r *AttemptRepository) Hit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	counterKey := attemptKeyPrefix + key
+
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, counterKey)
+	pipe.ExpireNX(ctx, counterKey, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to count attempt: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
+// Block rejects key until the duration has passed
+func (r *AttemptRepository) Block(ctx context.Context, key string, duration time.Duration) error {
+	if err := r.client.Set(ctx, blockKeyPrefix+key, time.Now().Unix(), duration).Err(); err != nil {
+		return fmt.Errorf("failed to block %s: %w", key, err)
+	}
+	return nil
+}
+
+// BlockedFor returns the remaining block time of key
+func (r *AttemptRepository) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.PTTL(ctx, blockKeyPrefix+key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check block for %s: %w", key, err)
+	}
+	// Negative TTLs mean the key does not exist or never expires
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// IncrementAnomaly bumps a named anomaly counter
+func (r *AttemptRepository) IncrementAnomaly(ctx context.Context, name string) error {
+	if err := r.client.HIncrBy(ctx, anomaliesKey, name, 1).Err(); err != nil {
+		return fmt.Errorf("failed to increment anomaly counter %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetAnomalyCounts returns all anomaly counters
+func (r *AttemptRepository) GetAnomalyCounts(ctx context.Context) (map[string]int64, error) {
+	values, err := r.client.HGetAll(ctx, anomaliesKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get anomaly counters: %w", err)
+	}
+
+	counts := make(map[string]int64, len(values))
+	for name, value := range values {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		counts[name] = count
+	}
+	return counts, nil
+}
@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+const loginLinkKeyPrefix = "login_link:"
+
+// LoginLinkRepository implements the LoginLinkRepository interface for Redis
+type LoginLinkRepository struct {
+	client *redis.Client
+}
+
+// NewLoginLinkRepository creates a new Redis login link repository
+func NewLoginLinkRepository(client *redis.Client) interfaces.LoginLinkRepository {
+	return &LoginLinkRepository{
+		client: client,
+	}
+}
+
+// Create stores a link keyed by its token hash; Redis drops it once it expires
+func (r *LoginLinkRepository) Create(ctx context.Context, link *domain.LoginLink) error {
+	ttl := time.Until(link.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("login link has already expired")
+	}
+
+	data, err := json.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("failed to marshal login link: %w", err)
+	}
+	if err := r.client.Set(ctx, loginLinkKeyPrefix+link.TokenHash, data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store login link: %w", err)
+	}
+	return nil
+}
+
+// Consume gets and deletes a link in one step, so concurrent attempts with
+// the same token cannot both succeed
+func (r *LoginLinkRepository) Consume(ctx context.Context, tokenHash string) (*domain.LoginLink, error) {
+	data, err := r.client.GetDel(ctx, loginLinkKeyPrefix+tokenHash).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, domain.ErrInvalidLoginLink
+		}
+		return nil, fmt.Errorf("failed to consume login link: %w", err)
+	}
+
+	var link domain.LoginLink
+	if err := json.Unmarshal(data, &link); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal login link: %w", err)
+	}
+	link.TokenHash = tokenHash
+	return &link, nil
+}
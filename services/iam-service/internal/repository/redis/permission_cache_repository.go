@@ -0,0 +1,83 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+const (
+	permissionSnapshotKeyPrefix = "session_permissions:"
+	permissionVersionKeyPrefix  = "permission_version:"
+)
+
+// PermissionCacheRepository implements the PermissionCacheRepository interface for Redis
+type PermissionCacheRepository struct {
+	client *redis.Client
+}
+
+// NewPermissionCacheRepository creates a new Redis permission cache repository
+func NewPermissionCacheRepository(client *redis.Client) interfaces.PermissionCacheRepository {
+	return &PermissionCacheRepository{
+		client: client,
+	}
+}
+
+// Get reads the snapshot and the user's version in a single round trip. Users whose
+// role never changed have no version key and are at version 0.
+func (r *PermissionCacheRepository) Get(ctx context.Context, sessionID, userID string) (*domain.PermissionSnapshot, int64, error) {
+	values, err := r.client.MGet(ctx, permissionSnapshotKeyPrefix+sessionID, permissionVersionKeyPrefix+userID).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get permission snapshot: %w", err)
+	}
+
+	var version int64
+	if raw, ok := values[1].(string); ok {
+		if version, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			return nil, 0, fmt.Errorf("invalid permission version %q: %w", raw, err)
+		}
+	}
+
+	raw, ok := values[0].(string)
+	if !ok {
+		return nil, version, nil
+	}
+	var snapshot domain.PermissionSnapshot
+	if err := json.Unmarshal([]byte(raw), &snapshot); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal permission snapshot: %w", err)
+	}
+	return &snapshot, version, nil
+}
+
+// Save stores the snapshot under the session, expiring with it
+func (r *PermissionCacheRepository) Save(ctx context.Context, sessionID string, snapshot *domain.PermissionSnapshot, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal permission snapshot: %w", err)
+	}
+	if err := r.client.Set(ctx, permissionSnapshotKeyPrefix+sessionID, data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store permission snapshot: %w", err)
+	}
+	return nil
+}
+
+// BumpVersion increments the user's version. The key has no TTL: were it to expire,
+// the version would fall back to one that older snapshots were taken at.
+func (r *PermissionCacheRepository) BumpVersion(ctx context.Context, userID string) error {
+	if err := r.client.Incr(ctx, permissionVersionKeyPrefix+userID).Err(); err != nil {
+		return fmt.Errorf("failed to bump permission version: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+const quotaKeyPrefix = "client_quota:"
+
+// QuotaRepository implements the QuotaRepository interface for Redis
+type QuotaRepository struct {
+	client *redis.Client
+}
+
+// NewQuotaRepository creates a new Redis quota repository
+func NewQuotaRepository(client *redis.Client) interfaces.QuotaRepository {
+	return &QuotaRepository{
+		client: client,
+	}
+}
+
+// Consume increments the window's counter; the counter expires with its window
+func (r *QuotaRepository) Consume(ctx context.Context, clientID string, windowStart, windowEnd time.Time) (int64, error) {
+	key := quotaKey(clientID, windowStart)
+
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireAt(ctx, key, windowEnd)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to count request of client %s: %w", clientID, err)
+	}
+
+	return incr.Val(), nil
+}
+
+// Used returns the window's count
+func (r *QuotaRepository) Used(ctx context.Context, clientID string, windowStart time.Time) (int64, error) {
+	value, err := r.client.Get(ctx, quotaKey(clientID, windowStart)).Result()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get request count of client %s: %w", clientID, err)
+	}
+
+	used, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid request count of client %s: %w", clientID, err)
+	}
+	return used, nil
+}
+
+// quotaKey names the counter of a client's window
+func quotaKey(clientID string, windowStart time.Time) string {
+	return quotaKeyPrefix + clientID + ":" + strconv.FormatInt(windowStart.Unix(), 10)
+}
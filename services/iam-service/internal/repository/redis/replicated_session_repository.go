@@ -0,0 +1,683 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+var (
+	// ErrNoSessionReplica is returned when promoting without a replica configured
+	ErrNoSessionReplica = errors.New("no session store replica configured")
+
+	// ErrPrimaryReachable is returned when promoting while the primary still answers
+	ErrPrimaryReachable = errors.New("primary session store is reachable, promotion requires force")
+
+	// ErrReplicaTooStale is returned for reads the replica can't serve within the
+	// configured staleness tolerance
+	ErrReplicaTooStale = errors.New("session store replica is too stale")
+)
+
+// replicaCheckInterval is how long a replica's replication state is cached
+const replicaCheckInterval = time.Second
+
+// failoverMarkerKey is written to a promoted store to prove it accepts writes
+const failoverMarkerKey = "session_store:failover"
+
+// ReplicationConfig configures the session store replica in the standby region
+type ReplicationConfig struct {
+	PrimaryRegion string
+	ReplicaRegion string
+
+	// ReadFallback serves session reads from the replica while the primary is
+	// unreachable
+	ReadFallback bool
+
+	// MaxStaleness bounds how long the replica may have been cut off from the
+	// primary and still serve reads
+	MaxStaleness time.Duration
+
+	// StaleBlacklistReads lets token blacklist checks fall back to the replica.
+	// Otherwise they fail while the primary is down, since the replica may not
+	// have seen a recent revocation.
+	StaleBlacklistReads bool
+}
+
+// sessionStore is the session store of one region
+type sessionStore struct {
+	region string
+	client *redis.Client
+	repo   interfaces.SessionRepository
+}
+
+// replicationState is the replication section of a store's INFO
+type replicationState struct {
+	role      string        // "master" or "slave"
+	staleness time.Duration // Time since a replica last heard from its primary
+}
+
+// ReplicatedSessionRepository stores sessions in an active-passive pair of Redis
+// deployments in two regions. Writes go to the primary only; the replica follows
+// it through Redis replication. While the primary is unreachable, reads fall back
+// to the replica as long as it is within the staleness tolerance, so users stay
+// signed in through a region loss. PromoteSessionStore makes the replica the
+// primary; other instances adopt it once their writes to the old primary fail.
+type ReplicatedSessionRepository struct {
+	config ReplicationConfig
+
+	mu      sync.RWMutex
+	primary *sessionStore
+	replica *sessionStore // nil once promoted
+
+	checkMu   sync.Mutex
+	checked   replicationState
+	checkedAt time.Time
+	checkErr  error
+
+	readingMu      sync.Mutex
+	readingReplica bool
+}
+
+// NewReplicatedSessionRepository creates a session repository that writes to the
+// primary client and reads from the replica client while the primary is down
+func NewReplicatedSessionRepository(primary, replica *redis.Client, cfg ReplicationConfig) *ReplicatedSessionRepository {
+	return &ReplicatedSessionRepository{
+		config: cfg,
+		primary: &sessionStore{
+			region: cfg.PrimaryRegion,
+			client: primary,
+			repo:   NewSessionRepository(primary),
+		},
+		replica: &sessionStore{
+			region: cfg.ReplicaRegion,
+			client: replica,
+			repo:   NewSessionRepository(replica),
+		},
+	}
+}
+
+// stores returns the current primary and replica
+func (r *ReplicatedSessionRepository) stores() (*sessionStore, *sessionStore) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.primary, r.replica
+}
+
+// readWithFallback runs a read on the primary, falling back to the replica when
+// the primary is unreachable
+func readWithFallback[T any](r *ReplicatedSessionRepository, ctx context.Context, fallback bool, fn func(repo interfaces.SessionRepository) (T, error)) (T, error) {
+	primary, replica := r.stores()
+	value, err := fn(primary.repo)
+	if err == nil || !storeUnavailable(err) {
+		r.setReadingReplica(false, nil)
+		return value, err
+	}
+	if replica == nil || !fallback || !r.config.ReadFallback {
+		return value, err
+	}
+
+	state, checkErr := r.checkReplica(ctx, replica)
+	if checkErr != nil {
+		return value, fmt.Errorf("%w (replica: %v)", err, checkErr)
+	}
+	if state.role == "master" {
+		// Another instance promoted the replica; it is the primary now
+		r.adopt(replica)
+	} else {
+		r.setReadingReplica(true, err)
+	}
+	return fn(replica.repo)
+}
+
+// onPrimary runs an operation on the primary. When the primary is unreachable and
+// the replica has been promoted meanwhile, the replica is adopted and the
+// operation retried there.
+func onPrimary[T any](r *ReplicatedSessionRepository, ctx context.Context, fn func(repo interfaces.SessionRepository) (T, error)) (T, error) {
+	primary, replica := r.stores()
+	value, err := fn(primary.repo)
+	if err == nil || !storeUnavailable(err) || replica == nil {
+		return value, err
+	}
+	if state, checkErr := r.checkReplica(ctx, replica); checkErr != nil || state.role != "master" {
+		return value, err
+	}
+	r.adopt(replica)
+	return fn(replica.repo)
+}
+
+// exec is onPrimary for operations without a result
+func (r *ReplicatedSessionRepository) exec(ctx context.Context, fn func(repo interfaces.SessionRepository) error) error {
+	_, err := onPrimary(r, ctx, func(repo interfaces.SessionRepository) (struct{}, error) {
+		return struct{}{}, fn(repo)
+	})
+	return err
+}
+
+// checkReplica returns the replication state of the replica, refusing reads once
+// it has been cut off from the primary for longer than the staleness tolerance
+func (r *ReplicatedSessionRepository) checkReplica(ctx context.Context, replica *sessionStore) (replicationState, error) {
+	r.checkMu.Lock()
+	defer r.checkMu.Unlock()
+
+	if time.Since(r.checkedAt) > replicaCheckInterval {
+		r.checked, r.checkErr = replicationInfo(ctx, replica.client)
+		r.checkedAt = time.Now()
+	}
+	if r.checkErr != nil {
+		return r.checked, r.checkErr
+	}
+	if r.checked.role != "master" && r.config.MaxStaleness > 0 && r.checked.staleness > r.config.MaxStaleness {
+		return r.checked, fmt.Errorf("%w: %s behind, tolerance %s", ErrReplicaTooStale, r.checked.staleness, r.config.MaxStaleness)
+	}
+	return r.checked, nil
+}
+
+// adopt makes a replica promoted by another instance the primary
+func (r *ReplicatedSessionRepository) adopt(replica *sessionStore) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.replica != replica {
+		return
+	}
+	log.Printf("Session store replica in %s was promoted, adopting it as the primary in place of %s", replica.region, r.primary.region)
+	r.primary, r.replica = replica, nil
+
+	r.readingMu.Lock()
+	r.readingReplica = false
+	r.readingMu.Unlock()
+}
+
+// setReadingReplica logs switches between the primary and the replica for reads
+func (r *ReplicatedSessionRepository) setReadingReplica(reading bool, cause error) {
+	r.readingMu.Lock()
+	defer r.readingMu.Unlock()
+	if r.readingReplica == reading {
+		return
+	}
+	r.readingReplica = reading
+	if reading {
+		log.Printf("Primary session store unreachable, serving session reads from the %s replica: %v", r.config.ReplicaRegion, cause)
+	} else {
+		log.Printf("Primary session store reachable again, serving session reads from it")
+	}
+}
+
+// PromoteSessionStore fails the session store over to the replica region. The
+// runbook steps taken are returned for the operator.
+func (r *ReplicatedSessionRepository) PromoteSessionStore(ctx context.Context, force bool) (*interfaces.SessionStoreFailover, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.replica == nil {
+		return nil, ErrNoSessionReplica
+	}
+	primary, replica := r.primary, r.replica
+	failover := &interfaces.SessionStoreFailover{
+		PreviousPrimaryRegion: primary.region,
+		PrimaryRegion:         replica.region,
+		PrimaryAddress:        replica.client.Options().Addr,
+	}
+	step := func(format string, args ...interface{}) {
+		message := fmt.Sprintf(format, args...)
+		failover.Steps = append(failover.Steps, message)
+		log.Printf("Session store failover: %s", message)
+	}
+
+	// 1. Make sure the primary is really gone, unless the operator says otherwise
+	primaryErr := primary.client.Ping(ctx).Err()
+	if primaryErr == nil && !force {
+		return nil, ErrPrimaryReachable
+	}
+	if primaryErr == nil {
+		step("primary in %s is reachable, promoting anyway as forced", primary.region)
+	} else {
+		step("primary in %s is unreachable: %v", primary.region, primaryErr)
+	}
+
+	// 2. Record how far behind the replica is; writes within that window are lost
+	state, err := replicationInfo(ctx, replica.client)
+	if err != nil {
+		return nil, fmt.Errorf("replica in %s is unreachable: %w", replica.region, err)
+	}
+	failover.ReplicaStaleness = state.staleness
+	step("replica in %s last heard from the primary %s ago", replica.region, state.staleness)
+
+	// 3. Promote the replica
+	if state.role == "master" {
+		step("replica in %s is already a primary", replica.region)
+	} else {
+		if err := replica.client.Do(ctx, "REPLICAOF", "NO", "ONE").Err(); err != nil {
+			return nil, fmt.Errorf("failed to promote replica in %s: %w", replica.region, err)
+		}
+		step("promoted %s with REPLICAOF NO ONE", failover.PrimaryAddress)
+	}
+
+	// 4. Prove the new primary accepts writes
+	failover.PromotedAt = time.Now().UTC()
+	if err := replica.client.Set(ctx, failoverMarkerKey, failover.PromotedAt.Format(time.RFC3339), 24*time.Hour).Err(); err != nil {
+		return nil, fmt.Errorf("promoted replica in %s does not accept writes: %w", replica.region, err)
+	}
+	step("verified %s accepts writes", failover.PrimaryAddress)
+
+	// 5. Demote a reachable old primary, so instances still writing to it fail
+	// over instead of diverging
+	if primaryErr == nil {
+		host, port, _ := net.SplitHostPort(failover.PrimaryAddress)
+		if err := primary.client.Do(ctx, "REPLICAOF", host, port).Err(); err != nil {
+			step("failed to demote the old primary in %s, stop writes to it by hand: %v", primary.region, err)
+		} else {
+			step("demoted the old primary in %s to a replica of %s", primary.region, failover.PrimaryAddress)
+		}
+	}
+
+	r.primary, r.replica = replica, nil
+	r.checkMu.Lock()
+	r.checkedAt = time.Time{}
+	r.checkMu.Unlock()
+	r.readingMu.Lock()
+	r.readingReplica = false
+	r.readingMu.Unlock()
+
+	step("this instance now writes to %s; other instances switch once their writes to %s fail", replica.region, primary.region)
+	step("when %s returns, make it a replica of %s, then swap IAM_REDIS_HOST and IAM_REDIS_REPLICA_HOST before the next deploy", primary.region, failover.PrimaryAddress)
+	return failover, nil
+}
+
+// SessionStoreStatus reports the regions of the session store
+func (r *ReplicatedSessionRepository) SessionStoreStatus(ctx context.Context) (*interfaces.SessionStoreStatus, error) {
+	primary, replica := r.stores()
+	status := &interfaces.SessionStoreStatus{
+		PrimaryRegion:    primary.region,
+		PrimaryAddress:   primary.client.Options().Addr,
+		PrimaryReachable: primary.client.Ping(ctx).Err() == nil,
+	}
+
+	r.readingMu.Lock()
+	status.ReadingReplica = r.readingReplica
+	r.readingMu.Unlock()
+
+	if replica != nil {
+		status.ReplicaRegion = replica.region
+		status.ReplicaAddress = replica.client.Options().Addr
+		state, err := replicationInfo(ctx, replica.client)
+		if err != nil {
+			return status, fmt.Errorf("replica in %s is unreachable: %w", replica.region, err)
+		}
+		status.ReplicaStaleness = state.staleness
+	}
+	return status, nil
+}
+
+// replicationInfo reads the replication section of a store's INFO
+func replicationInfo(ctx context.Context, client *redis.Client) (replicationState, error) {
+	info, err := client.Info(ctx, "replication").Result()
+	if err != nil {
+		return replicationState{}, err
+	}
+
+	var state replicationState
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		if key, value, ok := strings.Cut(strings.TrimSpace(line), ":"); ok {
+			fields[key] = value
+		}
+	}
+	state.role = fields["role"]
+	if state.role == "master" {
+		return state, nil
+	}
+
+	// While the link is up, staleness is the time since the last exchange with
+	// the primary; once it is down, the time since it went down
+	seconds := fields["master_last_io_seconds_ago"]
+	if fields["master_link_status"] != "up" {
+		seconds = fields["master_link_down_since_seconds"]
+	}
+	if n, err := strconv.ParseInt(seconds, 10, 64); err == nil && n >= 0 {
+		state.staleness = time.Duration(n) * time.Second
+	} else {
+		// The replica never synced with the primary
+		state.staleness = time.Duration(1<<63 - 1)
+	}
+	return state, nil
+}
+
+// storeUnavailable reports whether an error means the store could not be reached,
+// rather than that the operation failed
+func storeUnavailable(err error) bool {
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, redis.ErrClosed),
+		errors.Is(err, redis.ErrPoolTimeout),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET):
+		return true
+	}
+	// A demoted primary rejects writes
+	return strings.Contains(err.Error(), "READONLY")
+}
+
+// validateReadOnly validates a session without recording the access, for stores
+// that don't accept writes
+func validateReadOnly(ctx context.Context, repo interfaces.SessionRepository, sessionID, accessToken string) (*domain.Session, error) {
+	session, err := repo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.IsValid(); err != nil {
+		return nil, err
+	}
+	if session.AccessToken != accessToken {
+		return nil, domain.ErrInvalidToken
+	}
+	return session, nil
+}
+
+// Reads that fall back to the replica
+
+// GetByID retrieves a session by ID
+func (r *ReplicatedSessionRepository) GetByID(ctx context.Context, sessionID string) (*domain.Session, error) {
+	return readWithFallback(r, ctx, true, func(repo interfaces.SessionRepository) (*domain.Session, error) {
+		return repo.GetByID(ctx, sessionID)
+	})
+}
+
+// ValidateSession validates a session with session ID and access token. On the
+// replica the access is not recorded.
+func (r *ReplicatedSessionRepository) ValidateSession(ctx context.Context, sessionID, accessToken string) (*domain.Session, error) {
+	primary, _ := r.stores()
+	return readWithFallback(r, ctx, true, func(repo interfaces.SessionRepository) (*domain.Session, error) {
+		if repo == primary.repo {
+			return repo.ValidateSession(ctx, sessionID, accessToken)
+		}
+		return validateReadOnly(ctx, repo, sessionID, accessToken)
+	})
+}
+
+// GetUserSessions retrieves all sessions for a user
+func (r *ReplicatedSessionRepository) GetUserSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	return readWithFallback(r, ctx, true, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetUserSessions(ctx, userID)
+	})
+}
+
+// GetActiveUserSessions retrieves active sessions for a user
+func (r *ReplicatedSessionRepository) GetActiveUserSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	return readWithFallback(r, ctx, true, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetActiveUserSessions(ctx, userID)
+	})
+}
+
+// IsTokenBlacklisted checks if a token is blacklisted. It falls back to the
+// replica only when stale blacklist reads are allowed.
+func (r *ReplicatedSessionRepository) IsTokenBlacklisted(ctx context.Context, tokenID string) (bool, error) {
+	return readWithFallback(r, ctx, r.config.StaleBlacklistReads, func(repo interfaces.SessionRepository) (bool, error) {
+		return repo.IsTokenBlacklisted(ctx, tokenID)
+	})
+}
+
+// GetActiveSessionCount returns the number of active sessions
+func (r *ReplicatedSessionRepository) GetActiveSessionCount(ctx context.Context) (int, error) {
+	return readWithFallback(r, ctx, true, func(repo interfaces.SessionRepository) (int, error) {
+		return repo.GetActiveSessionCount(ctx)
+	})
+}
+
+// GetUserSessionCount returns the number of sessions for a user
+func (r *ReplicatedSessionRepository) GetUserSessionCount(ctx context.Context, userID string) (int, error) {
+	return readWithFallback(r, ctx, true, func(repo interfaces.SessionRepository) (int, error) {
+		return repo.GetUserSessionCount(ctx, userID)
+	})
+}
+
+// HealthCheck checks the primary, reporting healthy while the replica can serve
+// reads in its place
+func (r *ReplicatedSessionRepository) HealthCheck(ctx context.Context) error {
+	_, err := readWithFallback(r, ctx, true, func(repo interfaces.SessionRepository) (struct{}, error) {
+		return struct{}{}, repo.HealthCheck(ctx)
+	})
+	return err
+}
+
+// Operations on the primary
+
+// Create creates a new session
+func (r *ReplicatedSessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.Create(ctx, session) })
+}
+
+// Update updates an existing session
+func (r *ReplicatedSessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.Update(ctx, session) })
+}
+
+// Delete deletes a session
+func (r *ReplicatedSessionRepository) Delete(ctx context.Context, sessionID string) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.Delete(ctx, sessionID) })
+}
+
+// RefreshSession validates and refreshes a session using refresh token
+func (r *ReplicatedSessionRepository) RefreshSession(ctx context.Context, sessionID, refreshToken string) (*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) (*domain.Session, error) {
+		return repo.RefreshSession(ctx, sessionID, refreshToken)
+	})
+}
+
+// RevokeUserSessions revokes all sessions for a user
+func (r *ReplicatedSessionRepository) RevokeUserSessions(ctx context.Context, userID string) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.RevokeUserSessions(ctx, userID) })
+}
+
+// RevokeUserSessionsExcept revokes all sessions for a user but one
+func (r *ReplicatedSessionRepository) RevokeUserSessionsExcept(ctx context.Context, userID, keepSessionID string) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error {
+		return repo.RevokeUserSessionsExcept(ctx, userID, keepSessionID)
+	})
+}
+
+// RestampUserSessions bumps the version of all active sessions of a user
+func (r *ReplicatedSessionRepository) RestampUserSessions(ctx context.Context, userID string) (int, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) (int, error) {
+		return repo.RestampUserSessions(ctx, userID)
+	})
+}
+
+// RevokeSession revokes a session
+func (r *ReplicatedSessionRepository) RevokeSession(ctx context.Context, sessionID string) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.RevokeSession(ctx, sessionID) })
+}
+
+// ExpireSession expires a session
+func (r *ReplicatedSessionRepository) ExpireSession(ctx context.Context, sessionID string) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.ExpireSession(ctx, sessionID) })
+}
+
+// InvalidateSession invalidates a session
+func (r *ReplicatedSessionRepository) InvalidateSession(ctx context.Context, sessionID string) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.InvalidateSession(ctx, sessionID) })
+}
+
+// UpdateLastAccessed records the last access of a session
+func (r *ReplicatedSessionRepository) UpdateLastAccessed(ctx context.Context, sessionID string) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.UpdateLastAccessed(ctx, sessionID) })
+}
+
+// BlacklistToken adds a token to the blacklist
+func (r *ReplicatedSessionRepository) BlacklistToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.BlacklistToken(ctx, tokenID, expiresAt) })
+}
+
+// GetBlacklistedTokens returns all blacklisted tokens
+func (r *ReplicatedSessionRepository) GetBlacklistedTokens(ctx context.Context) ([]string, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]string, error) {
+		return repo.GetBlacklistedTokens(ctx)
+	})
+}
+
+// CleanupBlacklistedTokens removes expired tokens from the blacklist
+func (r *ReplicatedSessionRepository) CleanupBlacklistedTokens(ctx context.Context) (int, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) (int, error) {
+		return repo.CleanupBlacklistedTokens(ctx)
+	})
+}
+
+// GetSessionsByStatus retrieves sessions by status
+func (r *ReplicatedSessionRepository) GetSessionsByStatus(ctx context.Context, status domain.SessionStatus) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetSessionsByStatus(ctx, status)
+	})
+}
+
+// GetExpiredSessions retrieves expired sessions
+func (r *ReplicatedSessionRepository) GetExpiredSessions(ctx context.Context) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetExpiredSessions(ctx)
+	})
+}
+
+// GetSessionsByUserAgent retrieves sessions by user agent
+func (r *ReplicatedSessionRepository) GetSessionsByUserAgent(ctx context.Context, userAgent string) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetSessionsByUserAgent(ctx, userAgent)
+	})
+}
+
+// GetSessionsByIPAddress retrieves sessions by IP address
+func (r *ReplicatedSessionRepository) GetSessionsByIPAddress(ctx context.Context, ipAddress string) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetSessionsByIPAddress(ctx, ipAddress)
+	})
+}
+
+// CleanupExpiredSessions removes expired sessions
+func (r *ReplicatedSessionRepository) CleanupExpiredSessions(ctx context.Context) (*domain.SessionCleanupInfo, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) (*domain.SessionCleanupInfo, error) {
+		return repo.CleanupExpiredSessions(ctx)
+	})
+}
+
+// CleanupUserSessions keeps only the newest maxSessions sessions of a user
+func (r *ReplicatedSessionRepository) CleanupUserSessions(ctx context.Context, userID string, maxSessions int) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error {
+		return repo.CleanupUserSessions(ctx, userID, maxSessions)
+	})
+}
+
+// GetStaleSessionsForCleanup retrieves sessions not accessed since staleSince
+func (r *ReplicatedSessionRepository) GetStaleSessionsForCleanup(ctx context.Context, staleSince time.Time) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetStaleSessionsForCleanup(ctx, staleSince)
+	})
+}
+
+// GetSessionStats returns session statistics
+func (r *ReplicatedSessionRepository) GetSessionStats(ctx context.Context) (*interfaces.SessionStats, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) (*interfaces.SessionStats, error) {
+		return repo.GetSessionStats(ctx)
+	})
+}
+
+// RecountSessionStats recounts session statistics from every session
+func (r *ReplicatedSessionRepository) RecountSessionStats(ctx context.Context) (*interfaces.SessionStats, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) (*interfaces.SessionStats, error) {
+		return repo.RecountSessionStats(ctx)
+	})
+}
+
+// GetSessionsByTimeRange retrieves sessions created within a time range
+func (r *ReplicatedSessionRepository) GetSessionsByTimeRange(ctx context.Context, start, end time.Time) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetSessionsByTimeRange(ctx, start, end)
+	})
+}
+
+// CreateBatch creates multiple sessions
+func (r *ReplicatedSessionRepository) CreateBatch(ctx context.Context, sessions []*domain.Session) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.CreateBatch(ctx, sessions) })
+}
+
+// DeleteBatch deletes multiple sessions
+func (r *ReplicatedSessionRepository) DeleteBatch(ctx context.Context, sessionIDs []string) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.DeleteBatch(ctx, sessionIDs) })
+}
+
+// UpdateBatch updates multiple sessions
+func (r *ReplicatedSessionRepository) UpdateBatch(ctx context.Context, sessions []*domain.Session) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.UpdateBatch(ctx, sessions) })
+}
+
+// ExtendSession extends a session by duration
+func (r *ReplicatedSessionRepository) ExtendSession(ctx context.Context, sessionID string, duration time.Duration) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error { return repo.ExtendSession(ctx, sessionID, duration) })
+}
+
+// RenewSession moves the expiry of a session
+func (r *ReplicatedSessionRepository) RenewSession(ctx context.Context, sessionID string, newExpiryTime time.Time) error {
+	return r.exec(ctx, func(repo interfaces.SessionRepository) error {
+		return repo.RenewSession(ctx, sessionID, newExpiryTime)
+	})
+}
+
+// FindSessionsByFilter retrieves sessions matching a filter
+func (r *ReplicatedSessionRepository) FindSessionsByFilter(ctx context.Context, filter interfaces.SessionFilter) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.FindSessionsByFilter(ctx, filter)
+	})
+}
+
+// GetRecentSessions retrieves the most recent sessions
+func (r *ReplicatedSessionRepository) GetRecentSessions(ctx context.Context, limit int) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetRecentSessions(ctx, limit)
+	})
+}
+
+// GetLongLivedSessions retrieves sessions older than threshold
+func (r *ReplicatedSessionRepository) GetLongLivedSessions(ctx context.Context, threshold time.Duration) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetLongLivedSessions(ctx, threshold)
+	})
+}
+
+// GetSuspiciousSessions retrieves sessions matching suspicious criteria
+func (r *ReplicatedSessionRepository) GetSuspiciousSessions(ctx context.Context, criteria interfaces.SuspiciousSessionCriteria) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetSuspiciousSessions(ctx, criteria)
+	})
+}
+
+// GetSessionsByMultipleIPs retrieves a user's sessions when they span several IPs
+func (r *ReplicatedSessionRepository) GetSessionsByMultipleIPs(ctx context.Context, userID string) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetSessionsByMultipleIPs(ctx, userID)
+	})
+}
+
+// GetConcurrentSessions retrieves a user's sessions created within timeWindow
+func (r *ReplicatedSessionRepository) GetConcurrentSessions(ctx context.Context, userID string, timeWindow time.Duration) ([]*domain.Session, error) {
+	return onPrimary(r, ctx, func(repo interfaces.SessionRepository) ([]*domain.Session, error) {
+		return repo.GetConcurrentSessions(ctx, userID, timeWindow)
+	})
+}
+
+// GetConnectionInfo returns connection information of the primary
+func (r *ReplicatedSessionRepository) GetConnectionInfo(ctx context.Context) (*interfaces.RedisConnectionInfo, error) {
+	primary, _ := r.stores()
+	return primary.repo.GetConnectionInfo(ctx)
+}
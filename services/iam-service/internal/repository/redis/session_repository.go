@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +15,144 @@ import (
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
 )
 
+// stampVersionLua defines stamp_version(data, version), which sets the version of
+// a session JSON in place. Go escapes the quotes within strings, so the only
+// "version" key is the session's; it is left out while zero.
+const stampVersionLua = `
+local function stamp_version(data, version)
+	local stamped, replaced = string.gsub(data, '"version":%d+', '"version":' .. version, 1)
+	if replaced == 0 then
+		stamped = '{"version":' .. version .. ',' .. string.sub(data, 2)
+	end
+	return stamped
+end
+`
+
+// saveSessionScript writes a session, its metadata and its membership of the user and
+// active session sets in one step. A session is never written back with a lower
+// version than the stored one: an update read before a concurrent re-stamp keeps
+// the re-stamped version, so its tokens need refreshing. Every key expires at the session's absolute expiry,
+// so extending or renewing a session can't leave the metadata behind it, and the user
+// set is only ever pushed out to the latest expiry of its sessions. It also keeps the
+// session statistics counters (see session_stats.go): the session moves to the set of
+// its status, and a created session counts towards the hour it was created in.
+//
+// KEYS: session, session metadata, user sessions set, active sessions set, hourly
+// created counter, hourly users HyperLogLog, set of the session's status, then the
+// sets of the other statuses
+// ARGV: session JSON, expiry and current time in unix milliseconds, session ID,
+// "1" to create rather than update, user ID, statistics retention in milliseconds,
+// then the metadata field and value pairs
+var saveSessionScript = redis.NewScript(stampVersionLua + `
+local expires_at = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local stored = redis.call('GET', KEYS[1])
+if ARGV[5] ~= '1' and not stored then
+	return 0
+end
+
+local data = ARGV[1]
+if stored then
+	local stored_version = tonumber(cjson.decode(stored).version) or 0
+	if stored_version > (tonumber(cjson.decode(data).version) or 0) then
+		data = stamp_version(data, stored_version)
+	end
+end
+
+redis.call('SET', KEYS[1], data)
+redis.call('PEXPIREAT', KEYS[1], expires_at)
+
+redis.call('DEL', KEYS[2])
+redis.call('HSET', KEYS[2], unpack(ARGV, 8))
+redis.call('PEXPIREAT', KEYS[2], expires_at)
+
+redis.call('SADD', KEYS[3], ARGV[4])
+local ttl = redis.call('PTTL', KEYS[3])
+if ttl < 0 or now + ttl < expires_at then
+	redis.call('PEXPIREAT', KEYS[3], expires_at)
+end
+
+redis.call('SADD', KEYS[4], ARGV[4])
+
+redis.call('ZADD', KEYS[7], expires_at, ARGV[4])
+redis.call('ZREMRANGEBYSCORE', KEYS[7], '-inf', now)
+for i = 8, #KEYS do
+	redis.call('ZREM', KEYS[i], ARGV[4])
+end
+if ARGV[5] == '1' then
+	redis.call('INCR', KEYS[5])
+	redis.call('PEXPIRE', KEYS[5], ARGV[7])
+	redis.call('PFADD', KEYS[6], ARGV[6])
+	redis.call('PEXPIRE', KEYS[6], ARGV[7])
+end
+return 1
+`)
+
+// touchSessionScript records the last access of a session in its metadata. The
+// metadata is written only while it exists, so a touch racing the session's expiry
+// can't recreate it without a TTL.
+//
+// KEYS: session metadata
+// ARGV: access time in unix seconds
+var touchSessionScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'last_accessed_at', ARGV[1])
+return 1
+`)
+
+// validateSessionScript checks a session's access token and records the access in a
+// single round trip. Only active sessions presented with their own token are touched.
+// It returns 0 for a missing session, or 1 when the token matches and -1 when it
+// doesn't, followed by the session JSON.
+//
+// KEYS: session, session metadata
+// ARGV: access token, access time in unix seconds
+var validateSessionScript = redis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if not data then
+	return {0}
+end
+
+local session = cjson.decode(data)
+if session.access_token ~= ARGV[1] then
+	return {-1, data}
+end
+
+if session.status == 'active' and redis.call('EXISTS', KEYS[2]) == 1 then
+	redis.call('HSET', KEYS[2], 'last_accessed_at', ARGV[2])
+end
+return {1, data}
+`)
+
+// restampSessionScript bumps the version of an active session in place, leaving
+// the rest of it as stored, e.g. tokens a concurrent refresh just issued. The
+// version is compared and set: it returns 1 when bumped from the version read, 0
+// when the session is gone or no longer active, and -1 when a concurrent
+// re-stamp bumped it already.
+//
+// KEYS: session
+// ARGV: version read
+var restampSessionScript = redis.NewScript(stampVersionLua + `
+local data = redis.call('GET', KEYS[1])
+if not data then
+	return 0
+end
+
+local session = cjson.decode(data)
+if session.status ~= 'active' then
+	return 0
+end
+local version = tonumber(session.version) or 0
+if version ~= tonumber(ARGV[1]) then
+	return -1
+end
+
+redis.call('SET', KEYS[1], stamp_version(data, version + 1), 'KEEPTTL')
+return 1
+`)
+
 // SessionRepository implements the SessionRepository interface for Redis
 type SessionRepository struct {
 	client *redis.Client
@@ -34,52 +173,29 @@ func (r *SessionRepository) Create(ctx context.Context, session *domain.Session)
 		return fmt.Errorf("failed to marshal session: %w", err)
 	}
 
-	// Calculate TTL
-	ttl := time.Until(session.ExpiresAt)
-	if ttl <= 0 {
+	if !session.ExpiresAt.After(time.Now()) {
 		return fmt.Errorf("session already expired")
 	}
 
-	pipe := r.client.Pipeline()
-
-	// Store session data
-	sessionKey := session.GetSessionKey()
-	pipe.Set(ctx, sessionKey, sessionData, ttl)
-
-	// Add session to user's session set
-	userSessionsKey := domain.GetUserSessionsKey(session.UserID)
-	pipe.SAdd(ctx, userSessionsKey, session.ID)
-	pipe.Expire(ctx, userSessionsKey, ttl)
-
-	// Store session ID in a set for easy cleanup
-	pipe.SAdd(ctx, "active_sessions", session.ID)
-
-	// Store session metadata for quick lookups
-	metaKey := fmt.Sprintf("session_meta:%s", session.ID)
-	metaData := map[string]interface{}{
-		"user_id":    session.UserID,
-		"created_at": session.CreatedAt.Unix(),
-		"expires_at": session.ExpiresAt.Unix(),
-		"ip_address": session.IPAddress,
-		"user_agent": session.UserAgent,
-		"status":     string(session.Status),
-	}
-	pipe.HMSet(ctx, metaKey, metaData)
-	pipe.Expire(ctx, metaKey, ttl)
-
-	_, err = pipe.Exec(ctx)
-	if err != nil {
+	if err := r.saveSession(ctx, r.client, session, sessionData, true).Err(); err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
 
 	return nil
 }
 
-// GetByID retrieves a session by ID
+// GetByID retrieves a session by ID. Accesses are recorded in the session metadata
+// only, so the last access time is read from there.
 func (r *SessionRepository) GetByID(ctx context.Context, sessionID string) (*domain.Session, error) {
 	sessionKey := fmt.Sprintf("session:%s", sessionID)
+	metaKey := fmt.Sprintf("session_meta:%s", sessionID)
 
-	sessionData, err := r.client.Get(ctx, sessionKey).Result()
+	pipe := r.client.Pipeline()
+	getCmd := pipe.Get(ctx, sessionKey)
+	lastAccessedCmd := pipe.HGet(ctx, metaKey, "last_accessed_at")
+	_, _ = pipe.Exec(ctx)
+
+	sessionData, err := getCmd.Result()
 	if err != nil {
 		if err == redis.Nil {
 			return nil, domain.ErrSessionNotFound
@@ -92,58 +208,92 @@ func (r *SessionRepository) GetByID(ctx context.Context, sessionID string) (*dom
 		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
 	}
 
+	if lastAccessed, err := lastAccessedCmd.Int64(); err == nil {
+		session.LastAccessedAt = latestAccess(session.LastAccessedAt, lastAccessed)
+	}
+
 	return &session, nil
 }
 
-// Update updates an existing session
-func (r *SessionRepository) Update(ctx context.Context, session *domain.Session) error {
-	// Check if session exists
-	sessionKey := session.GetSessionKey()
-	exists, err := r.client.Exists(ctx, sessionKey).Result()
-	if err != nil {
-		return fmt.Errorf("failed to check session existence: %w", err)
-	}
-	if exists == 0 {
-		return domain.ErrSessionNotFound
+// latestAccess returns the later of a session's stored access time and one recorded
+// in its metadata, which has second precision
+func latestAccess(stored time.Time, recorded int64) time.Time {
+	if recordedAt := time.Unix(recorded, 0); recordedAt.Unix() > stored.Unix() {
+		return recordedAt
 	}
+	return stored
+}
 
+// Update updates an existing session
+func (r *SessionRepository) Update(ctx context.Context, session *domain.Session) error {
 	// Marshal session to JSON
 	sessionData, err := json.Marshal(session)
 	if err != nil {
 		return fmt.Errorf("failed to marshal session: %w", err)
 	}
 
-	// Calculate TTL
-	ttl := time.Until(session.ExpiresAt)
-	if ttl <= 0 {
+	if !session.ExpiresAt.After(time.Now()) {
 		// Session expired, delete it
 		return r.Delete(ctx, session.ID)
 	}
 
-	pipe := r.client.Pipeline()
+	saved, err := r.saveSession(ctx, r.client, session, sessionData, false).Int()
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	if saved == 0 {
+		return domain.ErrSessionNotFound
+	}
 
-	// Update session data
-	pipe.Set(ctx, sessionKey, sessionData, ttl)
+	return nil
+}
 
-	// Update session metadata
-	metaKey := fmt.Sprintf("session_meta:%s", session.ID)
-	metaData := map[string]interface{}{
-		"user_id":          session.UserID,
-		"created_at":       session.CreatedAt.Unix(),
-		"expires_at":       session.ExpiresAt.Unix(),
-		"last_accessed_at": session.LastAccessedAt.Unix(),
-		"ip_address":       session.IPAddress,
-		"user_agent":       session.UserAgent,
-		"status":           string(session.Status),
+// saveSession runs saveSessionScript for a session on a client or pipeline
+func (r *SessionRepository) saveSession(ctx context.Context, scripter redis.Scripter, session *domain.Session, sessionData []byte, create bool) *redis.Cmd {
+	now := time.Now()
+	keys := []string{
+		session.GetSessionKey(),
+		fmt.Sprintf("session_meta:%s", session.ID),
+		domain.GetUserSessionsKey(session.UserID),
+		"active_sessions",
+		sessionsCreatedKey(now),
+		sessionUsersKey(now),
+		sessionStatusKey(session.Status),
+	}
+	for _, status := range sessionStatuses {
+		if status != session.Status {
+			keys = append(keys, sessionStatusKey(status))
+		}
 	}
-	pipe.HMSet(ctx, metaKey, metaData)
-	pipe.Expire(ctx, metaKey, ttl)
+	createFlag := "0"
+	if create {
+		createFlag = "1"
+	}
+	args := []interface{}{
+		sessionData,
+		session.ExpiresAt.UnixMilli(),
+		now.UnixMilli(),
+		session.ID,
+		createFlag,
+		session.UserID,
+		sessionStatsRetention.Milliseconds(),
+		"user_id", session.UserID,
+		"created_at", session.CreatedAt.Unix(),
+		"expires_at", session.ExpiresAt.Unix(),
+		"last_accessed_at", session.LastAccessedAt.Unix(),
+		"ip_address", session.IPAddress,
+		"user_agent", session.UserAgent,
+		"status", string(session.Status),
+	}
+	return saveSessionScript.Run(ctx, scripter, keys, args...)
+}
 
-	_, err = pipe.Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to update session: %w", err)
+// loadSaveSessionScript loads saveSessionScript ahead of a pipeline, where it runs by
+// SHA without the fallback to sending the script
+func (r *SessionRepository) loadSaveSessionScript(ctx context.Context) error {
+	if err := saveSessionScript.Load(ctx, r.client).Err(); err != nil {
+		return fmt.Errorf("failed to load session script: %w", err)
 	}
-
 	return nil
 }
 
@@ -170,6 +320,7 @@ func (r *SessionRepository) Delete(ctx context.Context, sessionID string) error
 
 	// Remove from active sessions set
 	pipe.SRem(ctx, "active_sessions", sessionID)
+	forgetSessionStatus(ctx, pipe, sessionID)
 
 	// Delete session metadata
 	metaKey := fmt.Sprintf("session_meta:%s", sessionID)
@@ -183,11 +334,29 @@ func (r *SessionRepository) Delete(ctx context.Context, sessionID string) error
 	return nil
 }
 
-// ValidateSession validates a session with session ID and access token
+// ValidateSession validates a session with session ID and access token. The token is
+// checked and the access recorded by validateSessionScript in one round trip, instead
+// of reading and rewriting the whole session.
 func (r *SessionRepository) ValidateSession(ctx context.Context, sessionID, accessToken string) (*domain.Session, error) {
-	session, err := r.GetByID(ctx, sessionID)
+	now := time.Now()
+	keys := []string{
+		fmt.Sprintf("session:%s", sessionID),
+		fmt.Sprintf("session_meta:%s", sessionID),
+	}
+
+	result, err := validateSessionScript.Run(ctx, r.client, keys, accessToken, now.Unix()).Slice()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to validate session: %w", err)
+	}
+	code, _ := result[0].(int64)
+	if code == 0 || len(result) < 2 {
+		return nil, domain.ErrSessionNotFound
+	}
+	sessionData, _ := result[1].(string)
+
+	var session domain.Session
+	if err := json.Unmarshal([]byte(sessionData), &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
 	}
 
 	// Validate session
@@ -196,18 +365,12 @@ func (r *SessionRepository) ValidateSession(ctx context.Context, sessionID, acce
 	}
 
 	// Validate access token
-	if session.AccessToken != accessToken {
+	if code != 1 {
 		return nil, domain.ErrInvalidToken
 	}
 
-	// Update last accessed time
-	session.UpdateLastAccessed()
-	if err := r.Update(ctx, session); err != nil {
-		// Log error but don't fail validation
-		// This is a minor issue and shouldn't prevent access
-	}
-
-	return session, nil
+	session.LastAccessedAt = now
+	return &session, nil
 }
 
 // RefreshSession validates and refreshes a session using refresh token
@@ -278,18 +441,17 @@ func (r *SessionRepository) RevokeUserSessions(ctx context.Context, userID strin
 		return err
 	}
 
+	if err := r.loadSaveSessionScript(ctx); err != nil {
+		return err
+	}
+
 	pipe := r.client.Pipeline()
 	for _, session := range sessions {
 		session.Revoke()
 
-		// Update session
+		// Update session and metadata
 		sessionData, _ := json.Marshal(session)
-		sessionKey := session.GetSessionKey()
-		pipe.Set(ctx, sessionKey, sessionData, time.Until(session.ExpiresAt))
-
-		// Update metadata
-		metaKey := fmt.Sprintf("session_meta:%s", session.ID)
-		pipe.HSet(ctx, metaKey, "status", string(session.Status))
+		r.saveSession(ctx, pipe, session, sessionData, false)
 	}
 
 	_, err = pipe.Exec(ctx)
@@ -303,6 +465,10 @@ func (r *SessionRepository) RevokeUserSessionsExcept(ctx context.Context, userID
 		return err
 	}
 
+	if err := r.loadSaveSessionScript(ctx); err != nil {
+		return err
+	}
+
 	pipe := r.client.Pipeline()
 	for _, session := range sessions {
 		if session.ID == keepSessionID {
@@ -311,20 +477,48 @@ func (r *SessionRepository) RevokeUserSessionsExcept(ctx context.Context, userID
 
 		session.Revoke()
 
-		// Update session
+		// Update session and metadata
 		sessionData, _ := json.Marshal(session)
-		sessionKey := session.GetSessionKey()
-		pipe.Set(ctx, sessionKey, sessionData, time.Until(session.ExpiresAt))
-
-		// Update metadata
-		metaKey := fmt.Sprintf("session_meta:%s", session.ID)
-		pipe.HSet(ctx, metaKey, "status", string(session.Status))
+		r.saveSession(ctx, pipe, session, sessionData, false)
 	}
 
 	_, err = pipe.Exec(ctx)
 	return err
 }
 
+// RestampUserSessions bumps the version of all active sessions of a user, so the
+// access tokens issued before a role or status change stop validating
+func (r *SessionRepository) RestampUserSessions(ctx context.Context, userID string) (int, error) {
+	sessions, err := r.GetActiveUserSessions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(sessions) == 0 {
+		return 0, nil
+	}
+
+	if err := restampSessionScript.Load(ctx, r.client).Err(); err != nil {
+		return 0, fmt.Errorf("failed to load session restamp script: %w", err)
+	}
+
+	pipe := r.client.Pipeline()
+	results := make([]*redis.Cmd, len(sessions))
+	for i, session := range sessions {
+		results[i] = restampSessionScript.Run(ctx, pipe, []string{session.GetSessionKey()}, session.Version)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to restamp sessions: %w", err)
+	}
+
+	restamped := 0
+	for _, result := range results {
+		if code, _ := result.Int(); code != 0 {
+			restamped++ // Bumped by this or a concurrent re-stamp
+		}
+	}
+	return restamped, nil
+}
+
 // RevokeSession revokes a specific session
 func (r *SessionRepository) RevokeSession(ctx context.Context, sessionID string) error {
 	session, err := r.GetByID(ctx, sessionID)
@@ -360,13 +554,15 @@ func (r *SessionRepository) InvalidateSession(ctx context.Context, sessionID str
 
 // UpdateLastAccessed updates the last accessed time for a session
 func (r *SessionRepository) UpdateLastAccessed(ctx context.Context, sessionID string) error {
-	session, err := r.GetByID(ctx, sessionID)
+	metaKey := fmt.Sprintf("session_meta:%s", sessionID)
+	touched, err := touchSessionScript.Run(ctx, r.client, []string{metaKey}, time.Now().Unix()).Int()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to update last accessed time: %w", err)
 	}
-
-	session.UpdateLastAccessed()
-	return r.Update(ctx, session)
+	if touched == 0 {
+		return domain.ErrSessionNotFound
+	}
+	return nil
 }
 
 // BlacklistToken adds a token to the blacklist
@@ -557,6 +753,7 @@ func (r *SessionRepository) CleanupExpiredSessions(ctx context.Context) (*domain
 		if exists == 0 {
 			// Session expired or deleted
 			pipe.SRem(ctx, "active_sessions", sessionID)
+			forgetSessionStatus(ctx, pipe, sessionID)
 
 			// Clean up metadata
 			metaKey := fmt.Sprintf("session_meta:%s", sessionID)
@@ -662,10 +859,13 @@ func (r *SessionRepository) GetStaleSessionsForCleanup(ctx context.Context, stal
 	return staleSessions, nil
 }
 
-// GetSessionStats returns session statistics
-func (r *SessionRepository) GetSessionStats(ctx context.Context) (*interfaces.SessionStats, error) {
+// RecountSessionStats computes session statistics by reading every session, and
+// re-seeds the status counters with what it read. It is slow, for operators checking
+// or repairing the counters GetSessionStats serves from.
+func (r *SessionRepository) RecountSessionStats(ctx context.Context) (*interfaces.SessionStats, error) {
 	stats := &interfaces.SessionStats{
 		SessionsByStatus: make(map[domain.SessionStatus]int),
+		Source:           interfaces.SessionStatsSourceRecount,
 	}
 
 	// Get active session count
@@ -689,6 +889,7 @@ func (r *SessionRepository) GetSessionStats(ctx context.Context) (*interfaces.Se
 
 	now := time.Now()
 	uniqueUsers := make(map[string]bool)
+	reseed := r.client.Pipeline()
 
 	for _, sessionID := range sessionIDs {
 		metaKey := fmt.Sprintf("session_meta:%s", sessionID)
@@ -699,6 +900,9 @@ func (r *SessionRepository) GetSessionStats(ctx context.Context) (*interfaces.Se
 
 		// Count by status
 		if status, ok := metadata["status"]; ok {
+			if expiresAt, err := strconv.ParseInt(metadata["expires_at"], 10, 64); err == nil {
+				seedSessionStatus(ctx, reseed, sessionID, domain.SessionStatus(status), time.Unix(expiresAt, 0))
+			}
 			stats.SessionsByStatus[domain.SessionStatus(status)]++
 			switch domain.SessionStatus(status) {
 			case domain.SessionStatusActive:
@@ -735,6 +939,10 @@ func (r *SessionRepository) GetSessionStats(ctx context.Context) (*interfaces.Se
 		}
 	}
 
+	if _, err := reseed.Exec(ctx); err != nil {
+		return stats, fmt.Errorf("failed to re-seed session status counters: %w", err)
+	}
+
 	stats.UniqueActiveUsers = len(uniqueUsers)
 	return stats, nil
 }
@@ -797,6 +1005,10 @@ func (r *SessionRepository) GetSessionsByTimeRange(ctx context.Context, start, e
 
 // CreateBatch creates multiple sessions in a batch
 func (r *SessionRepository) CreateBatch(ctx context.Context, sessions []*domain.Session) error {
+	if err := r.loadSaveSessionScript(ctx); err != nil {
+		return err
+	}
+
 	pipe := r.client.Pipeline()
 
 	for _, session := range sessions {
@@ -805,31 +1017,11 @@ func (r *SessionRepository) CreateBatch(ctx context.Context, sessions []*domain.
 			return fmt.Errorf("failed to marshal session %s: %w", session.ID, err)
 		}
 
-		ttl := time.Until(session.ExpiresAt)
-		if ttl <= 0 {
+		if !session.ExpiresAt.After(time.Now()) {
 			continue // Skip expired sessions
 		}
 
-		sessionKey := session.GetSessionKey()
-		pipe.Set(ctx, sessionKey, sessionData, ttl)
-
-		userSessionsKey := domain.GetUserSessionsKey(session.UserID)
-		pipe.SAdd(ctx, userSessionsKey, session.ID)
-		pipe.Expire(ctx, userSessionsKey, ttl)
-
-		pipe.SAdd(ctx, "active_sessions", session.ID)
-
-		metaKey := fmt.Sprintf("session_meta:%s", session.ID)
-		metaData := map[string]interface{}{
-			"user_id":    session.UserID,
-			"created_at": session.CreatedAt.Unix(),
-			"expires_at": session.ExpiresAt.Unix(),
-			"ip_address": session.IPAddress,
-			"user_agent": session.UserAgent,
-			"status":     string(session.Status),
-		}
-		pipe.HMSet(ctx, metaKey, metaData)
-		pipe.Expire(ctx, metaKey, ttl)
+		r.saveSession(ctx, pipe, session, sessionData, true)
 	}
 
 	_, err := pipe.Exec(ctx)
@@ -857,6 +1049,7 @@ func (r *SessionRepository) DeleteBatch(ctx context.Context, sessionIDs []string
 		pipe.SRem(ctx, userSessionsKey, sessionID)
 
 		pipe.SRem(ctx, "active_sessions", sessionID)
+		forgetSessionStatus(ctx, pipe, sessionID)
 
 		metaKey := fmt.Sprintf("session_meta:%s", sessionID)
 		pipe.Del(ctx, metaKey)
@@ -872,6 +1065,10 @@ func (r *SessionRepository) DeleteBatch(ctx context.Context, sessionIDs []string
 
 // UpdateBatch updates multiple sessions in a batch
 func (r *SessionRepository) UpdateBatch(ctx context.Context, sessions []*domain.Session) error {
+	if err := r.loadSaveSessionScript(ctx); err != nil {
+		return err
+	}
+
 	pipe := r.client.Pipeline()
 
 	for _, session := range sessions {
@@ -880,26 +1077,11 @@ func (r *SessionRepository) UpdateBatch(ctx context.Context, sessions []*domain.
 			continue
 		}
 
-		ttl := time.Until(session.ExpiresAt)
-		if ttl <= 0 {
+		if !session.ExpiresAt.After(time.Now()) {
 			continue
 		}
 
-		sessionKey := session.GetSessionKey()
-		pipe.Set(ctx, sessionKey, sessionData, ttl)
-
-		metaKey := fmt.Sprintf("session_meta:%s", session.ID)
-		metaData := map[string]interface{}{
-			"user_id":          session.UserID,
-			"created_at":       session.CreatedAt.Unix(),
-			"expires_at":       session.ExpiresAt.Unix(),
-			"last_accessed_at": session.LastAccessedAt.Unix(),
-			"ip_address":       session.IPAddress,
-			"user_agent":       session.UserAgent,
-			"status":           string(session.Status),
-		}
-		pipe.HMSet(ctx, metaKey, metaData)
-		pipe.Expire(ctx, metaKey, ttl)
+		r.saveSession(ctx, pipe, session, sessionData, false)
 	}
 
 	_, err := pipe.Exec(ctx)
@@ -1138,6 +1320,13 @@ func (r *SessionRepository) matchesFilter(session *domain.Session, filter interf
 		return false
 	}
 
+	if filter.IPNetwork != nil {
+		ip := net.ParseIP(session.IPAddress)
+		if ip == nil || !filter.IPNetwork.Contains(ip) {
+			return false
+		}
+	}
+
 	if filter.UserAgent != nil && !strings.Contains(strings.ToLower(session.UserAgent), strings.ToLower(*filter.UserAgent)) {
 		return false
 	}
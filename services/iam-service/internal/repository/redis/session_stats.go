@@ -0,0 +1,134 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// Session statistics are served from counters kept by saveSessionScript, so reading
+// them doesn't walk every session:
+//
+//	session_stats:status:<status>  sorted set of the sessions with the status, scored
+//	                               by expiry, so sessions expiring in Redis drop out
+//	session_stats:created:<hour>   sessions created in the hour since the epoch
+//	session_stats:users:<hour>     HyperLogLog of the users creating them
+const sessionStatsRetention = 8 * 24 * time.Hour
+
+// sessionStatuses are the statuses with a status set
+var sessionStatuses = []domain.SessionStatus{
+	domain.SessionStatusActive,
+	domain.SessionStatusExpired,
+	domain.SessionStatusRevoked,
+	domain.SessionStatusInvalid,
+}
+
+func sessionStatusKey(status domain.SessionStatus) string {
+	return fmt.Sprintf("session_stats:status:%s", status)
+}
+
+func sessionsCreatedKey(at time.Time) string {
+	return fmt.Sprintf("session_stats:created:%d", at.Unix()/3600)
+}
+
+func sessionUsersKey(at time.Time) string {
+	return fmt.Sprintf("session_stats:users:%d", at.Unix()/3600)
+}
+
+// forgetSessionStatus removes a deleted session from the status sets
+func forgetSessionStatus(ctx context.Context, pipe redis.Pipeliner, sessionID string) {
+	for _, status := range sessionStatuses {
+		pipe.ZRem(ctx, sessionStatusKey(status), sessionID)
+	}
+}
+
+// seedSessionStatus puts a session in the set of its status, as saveSessionScript does
+func seedSessionStatus(ctx context.Context, pipe redis.Pipeliner, sessionID string, status domain.SessionStatus, expiresAt time.Time) {
+	for _, other := range sessionStatuses {
+		if other == status {
+			pipe.ZAdd(ctx, sessionStatusKey(status), redis.Z{Score: float64(expiresAt.UnixMilli()), Member: sessionID})
+		} else {
+			pipe.ZRem(ctx, sessionStatusKey(other), sessionID)
+		}
+	}
+}
+
+// GetSessionStats returns session statistics from the rolling counters. Session
+// counts by creation time are estimated from hourly counts, and unique active users
+// are the users who started a session within the last day, counted approximately.
+func (r *SessionRepository) GetSessionStats(ctx context.Context) (*interfaces.SessionStats, error) {
+	now := time.Now()
+	minScore := strconv.FormatInt(now.UnixMilli(), 10)
+
+	// One hour more than a week, for the part of the oldest hour inside the window
+	createdKeys := make([]string, 7*24+1)
+	for i := range createdKeys {
+		createdKeys[i] = sessionsCreatedKey(now.Add(-time.Duration(i) * time.Hour))
+	}
+	usersKeys := make([]string, 24+1)
+	for i := range usersKeys {
+		usersKeys[i] = sessionUsersKey(now.Add(-time.Duration(i) * time.Hour))
+	}
+
+	pipe := r.client.Pipeline()
+	statusCmds := make(map[domain.SessionStatus]*redis.IntCmd, len(sessionStatuses))
+	for _, status := range sessionStatuses {
+		statusCmds[status] = pipe.ZCount(ctx, sessionStatusKey(status), minScore, "+inf")
+	}
+	createdCmd := pipe.MGet(ctx, createdKeys...)
+	usersCmd := pipe.PFCount(ctx, usersKeys...)
+	blacklistCmd := pipe.SCard(ctx, "blacklisted_tokens")
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to read session statistics: %w", err)
+	}
+
+	stats := &interfaces.SessionStats{
+		SessionsByStatus:  make(map[domain.SessionStatus]int, len(sessionStatuses)),
+		UniqueActiveUsers: int(usersCmd.Val()),
+		BlacklistedTokens: int(blacklistCmd.Val()),
+		Source:            interfaces.SessionStatsSourceCounters,
+	}
+	for _, status := range sessionStatuses {
+		count := int(statusCmds[status].Val())
+		stats.SessionsByStatus[status] = count
+		stats.TotalSessions += count
+	}
+	stats.ActiveSessions = stats.SessionsByStatus[domain.SessionStatusActive]
+	stats.ExpiredSessions = stats.SessionsByStatus[domain.SessionStatusExpired]
+	stats.RevokedSessions = stats.SessionsByStatus[domain.SessionStatusRevoked]
+	stats.InvalidSessions = stats.SessionsByStatus[domain.SessionStatusInvalid]
+
+	created := make([]int64, len(createdKeys))
+	for i, value := range createdCmd.Val() {
+		if text, ok := value.(string); ok {
+			created[i], _ = strconv.ParseInt(text, 10, 64)
+		}
+	}
+	stats.RecentSessions = rollingCount(created, time.Hour, now)
+	stats.TodaySessions = rollingCount(created, 24*time.Hour, now)
+	stats.WeekSessions = rollingCount(created, 7*24*time.Hour, now)
+
+	return stats, nil
+}
+
+// rollingCount estimates the sessions created within a window ending now from hourly
+// counts, newest first. The hour the window starts in counts by the share of it
+// inside the window.
+func rollingCount(counts []int64, window time.Duration, now time.Time) int {
+	hours := int(window / time.Hour)
+	var total float64
+	for i := 0; i < hours && i < len(counts); i++ {
+		total += float64(counts[i])
+	}
+	if hours < len(counts) {
+		elapsed := now.Sub(now.Truncate(time.Hour))
+		total += float64(counts[hours]) * (1 - elapsed.Hours())
+	}
+	return int(total)
+}
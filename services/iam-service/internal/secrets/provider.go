@@ -0,0 +1,76 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Supported provider names for IAM_SECRETS_PROVIDER
+const (
+	ProviderEnv  = "env"
+	ProviderFile = "file"
+)
+
+// ErrSecretNotFound is returned when a secret does not exist in the provider
+var ErrSecretNotFound = errors.New("secret not found")
+
+// Provider resolves named secrets such as encryption keys. Secret values are
+// kept out of the regular configuration so they are never logged or written
+// to config profiles.
+type Provider interface {
+	Get(ctx context.Context, name string) ([]byte, error)
+}
+
+// NewProvider creates the provider selected by name
+func NewProvider(name, dir string) (Provider, error) {
+	switch strings.ToLower(name) {
+	case "", ProviderEnv:
+		return EnvProvider{}, nil
+	case ProviderFile:
+		if dir == "" {
+			return nil, fmt.Errorf("file secrets provider requires a directory")
+		}
+		return FileProvider{Dir: dir}, nil
+	default:
+		return nil, fmt.Errorf("unknown secrets provider %q", name)
+	}
+}
+
+// EnvProvider reads secrets from environment variables; the secret
+// "iam_pii_encryption_keys" is read from IAM_PII_ENCRYPTION_KEYS
+type EnvProvider struct{}
+
+// Get returns the value of the environment variable named after the secret
+func (EnvProvider) Get(ctx context.Context, name string) ([]byte, error) {
+	value, ok := os.LookupEnv(strings.ToUpper(name))
+	if !ok || value == "" {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return []byte(value), nil
+}
+
+// FileProvider reads secrets from files in a directory, as mounted by Docker
+// and Kubernetes secrets (e.g. /run/secrets/iam_pii_encryption_keys)
+type FileProvider struct {
+	Dir string
+}
+
+// Get returns the trimmed content of the file named after the secret
+func (p FileProvider) Get(ctx context.Context, name string) ([]byte, error) {
+	if strings.ContainsAny(name, `/\`) || name == ".." {
+		return nil, fmt.Errorf("invalid secret name %q", name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(p.Dir, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to read secret %s: %w", name, err)
+	}
+	return []byte(strings.TrimSpace(string(data))), nil
+}
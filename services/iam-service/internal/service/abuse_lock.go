@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+)
+
+// LockForAbuse locks an account reported as abusive by another service, e.g.
+// order-service for a user who keeps exceeding the order placement limits, and
+// revokes its sessions. A longer lock already in place is kept, and
+// administrators are never locked out this way.
+func (s *UserService) LockForAbuse(ctx context.Context, userID, reason string) error {
+	if userID == "" {
+		return fmt.Errorf("user ID cannot be empty")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Role == domain.RoleAdmin {
+		log.Printf("Not locking administrator %s reported for abuse: %s", userID, reason)
+		return nil
+	}
+
+	lockUntil := time.Now().Add(s.config.Security.AbuseLockDuration)
+	if user.LockedUntil != nil && user.LockedUntil.After(lockUntil) {
+		return nil
+	}
+
+	if err := s.userRepo.LockAccount(ctx, userID, lockUntil); err != nil {
+		return err
+	}
+	if err := s.sessionRepo.RevokeUserSessions(ctx, userID); err != nil {
+		log.Printf("Failed to revoke sessions of user %s locked for abuse: %v", userID, err)
+	}
+
+	s.notifyUserChanged(ctx, userID, UserChangeLock)
+
+	log.Printf("Account %s locked for abuse until %s: %s", userID, lockUntil.Format(time.RFC3339), reason)
+	return nil
+}
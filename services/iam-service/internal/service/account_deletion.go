@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// accountDeletionReason is recorded in the audit trail of every finalized
+// self-service deletion
+const accountDeletionReason = "deletion requested by user"
+
+// DeletionNotifier tells users about the deletion of their account
+type DeletionNotifier interface {
+	// DeletionRequested announces that the account is deleted at its scheduled time
+	DeletionRequested(ctx context.Context, user *domain.User, reason string) error
+	// DeletionCancelled announces that the account is kept
+	DeletionCancelled(ctx context.Context, user *domain.User) error
+}
+
+// noopDeletionNotifier is used when user events are not published
+type noopDeletionNotifier struct{}
+
+func (noopDeletionNotifier) DeletionRequested(context.Context, *domain.User, string) error {
+	return nil
+}
+
+func (noopDeletionNotifier) DeletionCancelled(context.Context, *domain.User) error {
+	return nil
+}
+
+// RequestAccountDeletion schedules the deletion of a customer's own account
+// after the configured grace period and revokes all of its sessions. The
+// password confirms that the owner, not a stolen session, asks.
+func (s *UserService) RequestAccountDeletion(ctx context.Context, userID, password, reason string) (*UserInfo, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+	if password == "" {
+		return nil, domain.ErrInvalidPassword
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.ValidatePassword(password); err != nil {
+		return nil, err
+	}
+	if err := user.RequestDeletion(s.config.Deletion.GracePeriod); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to schedule account deletion: %w", err)
+	}
+
+	// The owner has to log in again to cancel
+	if err := s.sessionRepo.RevokeUserSessions(ctx, userID); err != nil {
+		log.Printf("Failed to revoke sessions of user %s pending deletion: %v", userID, err)
+	}
+
+	if err := s.deletionNotifier.DeletionRequested(ctx, user, strings.TrimSpace(reason)); err != nil {
+		log.Printf("Failed to notify user %s of the account deletion request: %v", userID, err)
+	}
+
+	log.Printf("Account deletion requested for user %s, scheduled at %s",
+		userID, user.DeletionScheduledAt.Format(time.RFC3339))
+	return s.userToInfo(user), nil
+}
+
+// CancelAccountDeletion keeps an account that is pending deletion
+func (s *UserService) CancelAccountDeletion(ctx context.Context, userID string) (*UserInfo, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.CancelDeletion(); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+
+	if err := s.deletionNotifier.DeletionCancelled(ctx, user); err != nil {
+		log.Printf("Failed to notify user %s of the cancelled account deletion: %v", userID, err)
+	}
+
+	log.Printf("Account deletion cancelled for user %s", userID)
+	return s.userToInfo(user), nil
+}
+
+// AccountDeletionResult summarizes one finalization run
+type AccountDeletionResult struct {
+	Candidates      int
+	Deleted         int
+	Skipped         int // Cancelled or deleted elsewhere since they were selected
+	Failed          int
+	SessionsRemoved int
+}
+
+// AccountDeletionStats are the cumulative counters reported on /metrics
+type AccountDeletionStats struct {
+	Runs            int64     `json:"runs"`
+	UsersDeleted    int64     `json:"users_deleted"`
+	Failures        int64     `json:"failures"`
+	SessionsRemoved int64     `json:"sessions_removed"`
+	LastRunAt       time.Time `json:"last_run_at"`
+	LastRunDuration float64   `json:"last_run_duration_seconds"`
+}
+
+// AccountDeletionJob permanently deletes accounts whose owners asked for
+// their deletion once the grace period has ended, along with their sessions
+type AccountDeletionJob struct {
+	userRepo    interfaces.UserRepository
+	sessionRepo interfaces.SessionRepository
+	interval    time.Duration
+	batchSize   int
+
+	mu    sync.Mutex
+	stats AccountDeletionStats
+}
+
+// NewAccountDeletionJob creates a new account deletion job
+func NewAccountDeletionJob(
+	userRepo interfaces.UserRepository,
+	sessionRepo interfaces.SessionRepository,
+	interval time.Duration,
+	batchSize int,
+) *AccountDeletionJob {
+	return &AccountDeletionJob{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		interval:    interval,
+		batchSize:   batchSize,
+	}
+}
+
+// Run finalizes due deletions every interval until the context is cancelled
+func (j *AccountDeletionJob) Run(ctx context.Context) error {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := j.FinalizeOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Account deletion run failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// FinalizeOnce deletes up to one batch of accounts whose grace period ended
+func (j *AccountDeletionJob) FinalizeOnce(ctx context.Context) (*AccountDeletionResult, error) {
+	start := time.Now()
+
+	users, err := j.userRepo.GetUsersForCleanup(ctx, interfaces.CleanupCriteria{
+		DeletionDueBy:    start,
+		IncludeTestUsers: true,
+		Limit:            j.batchSize,
+	})
+	if err != nil {
+		j.recordRun(start, &AccountDeletionResult{Failed: 1})
+		return nil, fmt.Errorf("failed to find accounts due for deletion: %w", err)
+	}
+
+	result := &AccountDeletionResult{Candidates: len(users)}
+	for _, user := range users {
+		if ctx.Err() != nil {
+			break
+		}
+
+		sessionsRemoved, err := j.deleteAccount(ctx, user)
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			result.Skipped++
+		case err != nil:
+			result.Failed++
+			log.Printf("Failed to delete account of user %s: %v", user.ID, err)
+		default:
+			result.Deleted++
+			result.SessionsRemoved += sessionsRemoved
+		}
+	}
+
+	j.recordRun(start, result)
+	if result.Candidates > 0 {
+		log.Printf("Account deletion finished: %d deleted, %d skipped, %d failed, %d sessions removed",
+			result.Deleted, result.Skipped, result.Failed, result.SessionsRemoved)
+	}
+
+	return result, nil
+}
+
+// Stats returns the cumulative account deletion counters
+func (j *AccountDeletionJob) Stats() AccountDeletionStats {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.stats
+}
+
+// deleteAccount removes a user's sessions and then the user itself
+func (j *AccountDeletionJob) deleteAccount(ctx context.Context, user *domain.User) (int, error) {
+	sessions, err := j.sessionRepo.GetUserSessions(ctx, user.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+	if len(sessions) > 0 {
+		sessionIDs := make([]string, len(sessions))
+		for i, session := range sessions {
+			sessionIDs[i] = session.ID
+		}
+		if err := j.sessionRepo.DeleteBatch(ctx, sessionIDs); err != nil {
+			return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+		}
+	}
+
+	emailHash := sha256.Sum256([]byte(strings.ToLower(user.Email)))
+	err = j.userRepo.FinalizeAccountDeletion(ctx, &interfaces.UserPurgeRecord{
+		UserID:          user.ID,
+		EmailHash:       hex.EncodeToString(emailHash[:]),
+		Role:            user.Role,
+		DeletedAt:       *user.DeletionScheduledAt,
+		SessionsRemoved: len(sessions),
+		Reason:          accountDeletionReason,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(sessions), nil
+}
+
+func (j *AccountDeletionJob) recordRun(start time.Time, result *AccountDeletionResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.stats.Runs++
+	j.stats.UsersDeleted += int64(result.Deleted)
+	j.stats.Failures += int64(result.Failed)
+	j.stats.SessionsRemoved += int64(result.SessionsRemoved)
+	j.stats.LastRunAt = start
+	j.stats.LastRunDuration = time.Since(start).Seconds()
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// minTravelTime avoids flagging near-simultaneous logins from nearby locations
+// as infinitely fast travel
+const minTravelTime = time.Minute
+
+// TravelAnomaly describes a login from a location the user could not have
+// reached since their previous login
+type TravelAnomaly struct {
+	PreviousSessionID string              `json:"previous_session_id"`
+	From              *domain.GeoLocation `json:"from"`
+	To                *domain.GeoLocation `json:"to"`
+	DistanceKm        float64             `json:"distance_km"`
+	Elapsed           time.Duration       `json:"elapsed"`
+	SpeedKmh          float64             `json:"speed_kmh"`
+}
+
+// Reason returns a short description stored on the flagged session
+func (a *TravelAnomaly) Reason() string {
+	return fmt.Sprintf("impossible travel: %.0f km from %s in %s (%.0f km/h)",
+		a.DistanceKm, a.From, a.Elapsed.Round(time.Minute), a.SpeedKmh)
+}
+
+// AnomalyDetector checks new sessions against the user's recent sessions
+type AnomalyDetector struct {
+	sessionRepo interfaces.SessionRepository
+	maxSpeedKmh float64
+}
+
+// NewAnomalyDetector creates a detector that flags travel faster than maxSpeedKmh
+func NewAnomalyDetector(sessionRepo interfaces.SessionRepository, maxSpeedKmh float64) *AnomalyDetector {
+	return &AnomalyDetector{
+		sessionRepo: sessionRepo,
+		maxSpeedKmh: maxSpeedKmh,
+	}
+}
+
+// CheckImpossibleTravel compares a new session's location with the user's
+// most recent located session. It returns nil when the session has no
+// location, there is nothing to compare with, or the travel is plausible.
+// The distance is reduced by both locations' accuracy radius so that
+// imprecise lookups do not produce false positives.
+func (d *AnomalyDetector) CheckImpossibleTravel(ctx context.Context, session *domain.Session) (*TravelAnomaly, error) {
+	if session.Location == nil {
+		return nil, nil
+	}
+
+	sessions, err := d.sessionRepo.GetUserSessions(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+
+	var previous *domain.Session
+	for _, candidate := range sessions {
+		if candidate.ID == session.ID || candidate.Location == nil || candidate.CreatedAt.After(session.CreatedAt) {
+			continue
+		}
+		if previous == nil || candidate.CreatedAt.After(previous.CreatedAt) {
+			previous = candidate
+		}
+	}
+	if previous == nil {
+		return nil, nil
+	}
+
+	distance := previous.Location.DistanceKm(session.Location)
+	effectiveDistance := distance - float64(previous.Location.AccuracyKm+session.Location.AccuracyKm)
+	if effectiveDistance <= 0 {
+		return nil, nil
+	}
+
+	elapsed := session.CreatedAt.Sub(previous.CreatedAt)
+	if elapsed < minTravelTime {
+		elapsed = minTravelTime
+	}
+
+	speed := effectiveDistance / elapsed.Hours()
+	if speed <= d.maxSpeedKmh {
+		return nil, nil
+	}
+
+	return &TravelAnomaly{
+		PreviousSessionID: previous.ID,
+		From:              previous.Location,
+		To:                session.Location,
+		DistanceKm:        distance,
+		Elapsed:           session.CreatedAt.Sub(previous.CreatedAt),
+		SpeedKmh:          speed,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/fakes"
+)
+
+var (
+	berlin = &domain.GeoLocation{City: "Berlin", Latitude: 52.52, Longitude: 13.40, AccuracyKm: 20}
+	tokyo  = &domain.GeoLocation{City: "Tokyo", Latitude: 35.68, Longitude: 139.69, AccuracyKm: 20}
+)
+
+// locatedSession builds a session of the user opened at the location ago before now
+func locatedSession(userID string, location *domain.GeoLocation, ago time.Duration) *domain.Session {
+	return fakes.NewSession(userID).With(func(s *domain.Session) {
+		s.Location = location
+		s.CreatedAt = s.CreatedAt.Add(-ago)
+	}).Build()
+}
+
+func TestCheckImpossibleTravel(t *testing.T) {
+	user := fakes.NewUser().Build()
+
+	tests := []struct {
+		name     string
+		previous *domain.Session
+		want     bool
+	}{
+		{"nothing to compare with", nil, false},
+		{"same city", locatedSession(user.ID, berlin, 10*time.Minute), false},
+		{"across the world within an hour", locatedSession(user.ID, tokyo, time.Hour), true},
+		{"across the world in two days", locatedSession(user.ID, tokyo, 48*time.Hour), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions := fakes.NewSessionRepository()
+			if tt.previous != nil {
+				sessions = fakes.NewSessionRepository(tt.previous)
+			}
+			detector := NewAnomalyDetector(sessions, 1000)
+
+			anomaly, err := detector.CheckImpossibleTravel(context.Background(), locatedSession(user.ID, berlin, 0))
+			if err != nil {
+				t.Fatalf("CheckImpossibleTravel: %v", err)
+			}
+			if got := anomaly != nil; got != tt.want {
+				t.Fatalf("flagged %v (%v), want %v", got, anomaly, tt.want)
+			}
+			if anomaly != nil && anomaly.PreviousSessionID != tt.previous.ID {
+				t.Fatalf("flagged against session %s, want %s", anomaly.PreviousSessionID, tt.previous.ID)
+			}
+		})
+	}
+}
+
+func TestCheckImpossibleTravelReportsRepositoryErrors(t *testing.T) {
+	sessions := fakes.NewSessionRepository()
+	sessions.FailOn("GetUserSessions", errors.New("redis unavailable"))
+
+	if _, err := NewAnomalyDetector(sessions, 1000).CheckImpossibleTravel(context.Background(), locatedSession("user-1", berlin, 0)); err == nil {
+		t.Fatal("CheckImpossibleTravel succeeded while the session repository failed")
+	}
+}
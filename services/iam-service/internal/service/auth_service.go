@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -10,14 +12,38 @@ import (
 
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/geoip"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
 )
 
 // AuthService implements authentication business logic
 type AuthService struct {
-	userRepo    interfaces.UserRepository
-	sessionRepo interfaces.SessionRepository
-	config      *config.Config
+	userRepo        interfaces.UserRepository
+	sessionRepo     interfaces.SessionRepository
+	config          *config.Config
+	geoProvider     geoip.Provider
+	anomalyDetector *AnomalyDetector
+	loginLinks      *loginLinks
+	loginAlerts     *loginAlerts
+	loginHistory    *loginHistory
+	permissionCache interfaces.PermissionCacheRepository
+}
+
+// AuthServiceOption configures optional AuthService dependencies
+type AuthServiceOption func(*AuthService)
+
+// WithGeoIPProvider enables location lookups for new sessions
+func WithGeoIPProvider(provider geoip.Provider) AuthServiceOption {
+	return func(s *AuthService) {
+		s.geoProvider = provider
+	}
+}
+
+// WithAnomalyDetector enables impossible-travel checks for new sessions
+func WithAnomalyDetector(detector *AnomalyDetector) AuthServiceOption {
+	return func(s *AuthService) {
+		s.anomalyDetector = detector
+	}
 }
 
 // NewAuthService creates a new authentication service
@@ -25,22 +51,37 @@ func NewAuthService(
 	userRepo interfaces.UserRepository,
 	sessionRepo interfaces.SessionRepository,
 	config *config.Config,
+	opts ...AuthServiceOption,
 ) *AuthService {
-	return &AuthService{
+	s := &AuthService{
 		userRepo:    userRepo,
 		sessionRepo: sessionRepo,
 		config:      config,
+		geoProvider: geoip.NoopProvider{},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // LoginResult represents the result of a login operation
 type LoginResult struct {
-	AccessToken  string              `json:"access_token"`
-	RefreshToken string              `json:"refresh_token"`
-	ExpiresAt    time.Time           `json:"expires_at"`
-	SessionID    string              `json:"session_id"`
-	User         *UserInfo           `json:"user"`
-	SessionInfo  *domain.SessionInfo `json:"session_info"`
+	AccessToken      string              `json:"access_token"`
+	RefreshToken     string              `json:"refresh_token"`
+	RefreshExpiresAt time.Time           `json:"refresh_expires_at"`
+	ExpiresAt        time.Time           `json:"expires_at"`
+	SessionID        string              `json:"session_id"`
+	User             *UserInfo           `json:"user"`
+	SessionInfo      *domain.SessionInfo `json:"session_info"`
+
+	// PasswordChangeRequired marks a restricted session that only permits
+	// ChangePassword until the user replaces their temporary password
+	PasswordChangeRequired bool `json:"password_change_required"`
+
+	// DeletionPending marks a restricted session of an account pending
+	// deletion; it only permits cancelling the deletion
+	DeletionPending bool `json:"deletion_pending"`
 }
 
 // UserInfo represents user information in responses
@@ -51,8 +92,14 @@ type UserInfo struct {
 	LastName  string    `json:"last_name"`
 	Role      string    `json:"role"`
 	Status    string    `json:"status"`
+	Locale    string    `json:"locale,omitempty"` // From the user's preferences
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	MustChangePassword bool `json:"must_change_password"`
+
+	// DeletionScheduledAt is set while the account is pending deletion
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
 }
 
 // TokenValidationResult represents token validation result
@@ -61,6 +108,17 @@ type TokenValidationResult struct {
 	Claims      *domain.JWTClaims   `json:"claims,omitempty"`
 	User        *UserInfo           `json:"user,omitempty"`
 	SessionInfo *domain.SessionInfo `json:"session_info,omitempty"`
+
+	// PasswordChangeRequired is set while the user still holds a temporary
+	// password; the session may then only be used to change it
+	PasswordChangeRequired bool `json:"password_change_required"`
+
+	// DeletionPending is set while the account is pending deletion; the
+	// session may then only be used to cancel the deletion
+	DeletionPending bool `json:"deletion_pending"`
+
+	// Permissions is the permission snapshot of the session
+	Permissions *domain.PermissionSnapshot `json:"permissions,omitempty"`
 }
 
 // Login authenticates a user and creates a session
@@ -87,11 +145,13 @@ func (s *AuthService) Login(ctx context.Context, email, password, ipAddress, use
 
 	// Check if user account is locked
 	if user.IsLocked() {
+		s.recordLogin(ctx, user.ID, domain.LoginMethodPassword, domain.LoginFailureAccountLocked, ipAddress, userAgent, nil)
 		return nil, domain.ErrAccountLocked
 	}
 
-	// Check if user is active
-	if user.Status != domain.StatusActive {
+	// Check if user is active; accounts pending deletion may log in to cancel it
+	if !user.CanSignIn() {
+		s.recordLogin(ctx, user.ID, domain.LoginMethodPassword, domain.LoginFailureAccountInactive, ipAddress, userAgent, nil)
 		return nil, domain.ErrAccountInactive
 	}
 
@@ -99,48 +159,15 @@ func (s *AuthService) Login(ctx context.Context, email, password, ipAddress, use
 	if err := user.ValidatePassword(password); err != nil {
 		// Record failed login attempt
 		s.userRepo.RecordLoginAttempt(ctx, user.ID)
+		s.recordFailedLogin(ctx, user, ipAddress, userAgent)
+		s.recordLogin(ctx, user.ID, domain.LoginMethodPassword, domain.LoginFailureInvalidCredentials, ipAddress, userAgent, nil)
 		return nil, domain.ErrInvalidCredentials
 	}
 
 	// Reset failed login attempts on successful authentication
 	s.userRepo.ResetLoginAttempts(ctx, user.ID)
 
-	// Create new session
-	session := domain.NewSession(
-		user.ID,
-		ipAddress,
-		userAgent,
-		time.Duration(s.config.JWT.AccessTokenDuration)*time.Hour,
-		time.Duration(s.config.JWT.AccessTokenDuration)*time.Hour,
-		time.Duration(s.config.JWT.RefreshTokenDuration)*time.Hour,
-	)
-
-	// Generate JWT tokens
-	if err := session.GenerateTokens(
-		user,
-		s.config.JWT.SecretKey,
-		time.Duration(s.config.JWT.AccessTokenDuration)*time.Hour,
-		time.Duration(s.config.JWT.RefreshTokenDuration)*time.Hour,
-	); err != nil {
-		return nil, fmt.Errorf("failed to generate tokens: %w", err)
-	}
-
-	// Store session in Redis
-	if err := s.sessionRepo.Create(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
-	}
-
-	// Update user's last login time
-	s.userRepo.UpdateLastLogin(ctx, user.ID, time.Now())
-
-	return &LoginResult{
-		AccessToken:  session.AccessToken,
-		RefreshToken: session.RefreshToken,
-		ExpiresAt:    session.ExpiresAt,
-		SessionID:    session.ID,
-		User:         s.userToInfo(user),
-		SessionInfo:  session.ToSessionInfo(),
-	}, nil
+	return s.startSession(ctx, user, domain.LoginMethodPassword, ipAddress, userAgent)
 }
 
 // Logout invalidates a user session
@@ -194,22 +221,27 @@ func (s *AuthService) ValidateToken(ctx context.Context, accessToken string) (*T
 	}
 
 	// Check if user is still active
-	if user.Status != domain.StatusActive {
+	if !user.CanSignIn() {
 		// Revoke session for inactive user
 		s.sessionRepo.RevokeSession(ctx, session.ID)
 		return nil, domain.ErrAccountInactive
 	}
 
-	// Update last accessed time (async to avoid impacting performance)
-	go func() {
-		s.sessionRepo.UpdateLastAccessed(context.Background(), session.ID)
-	}()
+	// Tokens issued before the user's role or status changed carry stale claims
+	// and must be refreshed
+	if claims.Version != session.Version {
+		return nil, domain.ErrSessionRestamped
+	}
 
 	return &TokenValidationResult{
 		Valid:       true,
 		Claims:      claims,
 		User:        s.userToInfo(user),
 		SessionInfo: session.ToSessionInfo(),
+
+		PasswordChangeRequired: user.MustChangePassword,
+		DeletionPending:        user.IsPendingDeletion(),
+		Permissions:            s.sessionPermissions(ctx, session, user),
 	}, nil
 }
 
@@ -235,7 +267,7 @@ func (s *AuthService) RefreshToken(ctx context.Context, sessionID, refreshToken
 	}
 
 	// Check if user is still active
-	if user.Status != domain.StatusActive {
+	if !user.CanSignIn() {
 		// Revoke session for inactive user
 		s.sessionRepo.RevokeSession(ctx, session.ID)
 		return nil, domain.ErrAccountInactive
@@ -256,15 +288,116 @@ func (s *AuthService) RefreshToken(ctx context.Context, sessionID, refreshToken
 	}
 
 	return &LoginResult{
-		AccessToken:  session.AccessToken,
-		RefreshToken: session.RefreshToken,
-		ExpiresAt:    session.ExpiresAt,
-		SessionID:    session.ID,
-		User:         s.userToInfo(user),
-		SessionInfo:  session.ToSessionInfo(),
+		AccessToken:      session.AccessToken,
+		RefreshToken:     session.RefreshToken,
+		RefreshExpiresAt: session.RefreshExpiresAt,
+		ExpiresAt:        session.ExpiresAt,
+		SessionID:        session.ID,
+		User:             s.userToInfo(user),
+		SessionInfo:      session.ToSessionInfo(),
+
+		PasswordChangeRequired: user.MustChangePassword,
+		DeletionPending:        user.IsPendingDeletion(),
+	}, nil
+}
+
+// startSession creates a session for an authenticated user and records the
+// login made with method in the login history
+func (s *AuthService) startSession(ctx context.Context, user *domain.User, method, ipAddress, userAgent string) (*LoginResult, error) {
+	// Create new session
+	session := domain.NewSession(
+		user.ID,
+		ipAddress,
+		userAgent,
+		time.Duration(s.config.JWT.AccessTokenDuration)*time.Hour,
+		time.Duration(s.config.JWT.AccessTokenDuration)*time.Hour,
+		time.Duration(s.config.JWT.RefreshTokenDuration)*time.Hour,
+	)
+
+	// Generate JWT tokens
+	if err := session.GenerateTokens(
+		user,
+		s.config.JWT.SecretKey,
+		time.Duration(s.config.JWT.AccessTokenDuration)*time.Hour,
+		time.Duration(s.config.JWT.RefreshTokenDuration)*time.Hour,
+	); err != nil {
+		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+	}
+
+	// Record where the session comes from and check it against recent logins
+	s.enrichSession(ctx, session)
+
+	// Store session in Redis
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	// Update user's last login time
+	s.userRepo.UpdateLastLogin(ctx, user.ID, time.Now())
+	s.recordLogin(ctx, user.ID, method, "", ipAddress, userAgent, session.Location)
+
+	return &LoginResult{
+		AccessToken:      session.AccessToken,
+		RefreshToken:     session.RefreshToken,
+		RefreshExpiresAt: session.RefreshExpiresAt,
+		ExpiresAt:        session.ExpiresAt,
+		SessionID:        session.ID,
+		User:             s.userToInfo(user),
+		SessionInfo:      session.ToSessionInfo(),
+
+		PasswordChangeRequired: user.MustChangePassword,
+		DeletionPending:        user.IsPendingDeletion(),
 	}, nil
 }
 
+// enrichSession adds the client location to a new session and flags it when
+// the anomaly detector finds it suspicious. Lookup failures never block login.
+func (s *AuthService) enrichSession(ctx context.Context, session *domain.Session) {
+	location, err := s.geoProvider.Lookup(ctx, session.IPAddress)
+	if err != nil {
+		log.Printf("GeoIP lookup failed for session %s: %v", session.ID, err)
+	}
+	session.Location = location
+
+	if s.anomalyDetector == nil {
+		return
+	}
+
+	anomaly, err := s.anomalyDetector.CheckImpossibleTravel(ctx, session)
+	if err != nil {
+		log.Printf("Anomaly check failed for session %s: %v", session.ID, err)
+		return
+	}
+	if anomaly != nil {
+		session.Anomalies = append(session.Anomalies, anomaly.Reason())
+		log.Printf("Suspicious login for user %s (session %s, previous session %s): %s",
+			session.UserID, session.ID, anomaly.PreviousSessionID, anomaly.Reason())
+	}
+}
+
+// ListUserSessions returns a user's active sessions, most recent first
+func (s *AuthService) ListUserSessions(ctx context.Context, userID string) ([]*domain.SessionInfo, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	sessions, err := s.sessionRepo.GetActiveUserSessions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+
+	infos := make([]*domain.SessionInfo, len(sessions))
+	for i, session := range sessions {
+		infos[i] = session.ToSessionInfo()
+	}
+
+	return infos, nil
+}
+
 // GetSessionInfo retrieves session information
 func (s *AuthService) GetSessionInfo(ctx context.Context, sessionID string) (*domain.SessionInfo, *UserInfo, error) {
 	if sessionID == "" {
@@ -479,6 +612,11 @@ func (s *AuthService) GetSessionStats(ctx context.Context) (*interfaces.SessionS
 	return s.sessionRepo.GetSessionStats(ctx)
 }
 
+// RecountSessionStats returns session statistics recounted from every session
+func (s *AuthService) RecountSessionStats(ctx context.Context) (*interfaces.SessionStats, error) {
+	return s.sessionRepo.RecountSessionStats(ctx)
+}
+
 // CleanupExpiredSessions removes expired sessions
 func (s *AuthService) CleanupExpiredSessions(ctx context.Context) (*domain.SessionCleanupInfo, error) {
 	return s.sessionRepo.CleanupExpiredSessions(ctx)
@@ -503,7 +641,11 @@ func (s *AuthService) userToInfo(user *domain.User) *UserInfo {
 		LastName:  user.LastName,
 		Role:      string(user.Role),
 		Status:    string(user.Status),
+		Locale:    user.Preferences.Locale,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
+
+		MustChangePassword:  user.MustChangePassword,
+		DeletionScheduledAt: user.DeletionScheduledAt,
 	}
 }
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// TokenEndpoint names an endpoint protected by the brute-force guard
+type TokenEndpoint string
+
+const (
+	EndpointRefreshToken    TokenEndpoint = "refresh_token"
+	EndpointValidateSession TokenEndpoint = "validate_session"
+	EndpointClientToken     TokenEndpoint = "client_token"
+)
+
+// ErrTooManyAttempts is returned when a client is throttled on a token endpoint
+var ErrTooManyAttempts = errors.New("too many attempts")
+
+// ThrottledError tells the client how long to back off
+type ThrottledError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s: %s, retry after %s", ErrTooManyAttempts, e.Reason, e.RetryAfter.Round(time.Second))
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// BruteForceGuard throttles RefreshToken, ValidateSession and IssueClientToken
+// so session IDs, tokens and client secrets cannot be guessed by hammering them. Every request counts
+// against a per-IP rate limit; failed attempts additionally count against the
+// client IP and, for refreshes, the targeted session, and a client crossing
+// the failure threshold is blocked outright. Redis errors never reject a
+// request - the guard fails open and logs.
+type BruteForceGuard struct {
+	attempts interfaces.AttemptRepository
+	config   config.SecurityConfig
+}
+
+// NewBruteForceGuard creates a guard using the security configuration
+func NewBruteForceGuard(attempts interfaces.AttemptRepository, config config.SecurityConfig) *BruteForceGuard {
+	return &BruteForceGuard{
+		attempts: attempts,
+		config:   config,
+	}
+}
+
+// Check reports whether a request from clientIP may proceed. It returns a
+// *ThrottledError when the client is blocked or over the rate limit.
+func (g *BruteForceGuard) Check(ctx context.Context, endpoint TokenEndpoint, clientIP, sessionID string) error {
+	if clientIP != "" {
+		if err := g.checkBlocked(ctx, ipBlockKey(clientIP), "client is blocked"); err != nil {
+			return err
+		}
+	}
+	if endpoint == EndpointRefreshToken && sessionID != "" {
+		if err := g.checkBlocked(ctx, sessionBlockKey(sessionID), "session refresh is blocked"); err != nil {
+			return err
+		}
+	}
+
+	if clientIP == "" {
+		return nil
+	}
+
+	limit := g.config.ValidateRateLimit
+	if endpoint == EndpointRefreshToken {
+		limit = g.config.RefreshRateLimit
+	}
+
+	count, err := g.attempts.Hit(ctx, fmt.Sprintf("rate:%s:%s", endpoint, clientIP), g.config.TokenRateLimitWindow)
+	if err != nil {
+		log.Printf("Brute-force guard: rate limit check failed for %s: %v", clientIP, err)
+		return nil
+	}
+	if count > int64(limit) {
+		// Only the first excess request per window is worth recording
+		if count == int64(limit)+1 {
+			g.recordAnomaly(ctx, string(endpoint)+"_rate_limited")
+			log.Printf("Brute-force guard: %s rate limit of %d exceeded by %s", endpoint, limit, clientIP)
+		}
+		return &ThrottledError{Reason: "rate limit exceeded", RetryAfter: g.config.TokenRateLimitWindow}
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed attempt, blocking the client IP or the
+// targeted session once its failure threshold is reached
+func (g *BruteForceGuard) RecordFailure(ctx context.Context, endpoint TokenEndpoint, clientIP, sessionID string) {
+	g.recordAnomaly(ctx, string(endpoint)+"_failures")
+
+	if clientIP != "" {
+		g.countFailure(ctx, "fail:ip:"+clientIP, ipBlockKey(clientIP), g.config.TokenFailureThreshold,
+			"ip_blocked", fmt.Sprintf("client %s", clientIP))
+	}
+	if endpoint == EndpointRefreshToken && sessionID != "" {
+		g.countFailure(ctx, "fail:session:"+sessionID, sessionBlockKey(sessionID), g.config.SessionRefreshFailureLimit,
+			"session_blocked", fmt.Sprintf("refresh of session %s", sessionID))
+	}
+}
+
+// AnomalyCounts returns the guard's anomaly counters, such as rate-limited
+// requests, failed attempts and blocks, per endpoint
+func (g *BruteForceGuard) AnomalyCounts(ctx context.Context) (map[string]int64, error) {
+	return g.attempts.GetAnomalyCounts(ctx)
+}
+
+func (g *BruteForceGuard) checkBlocked(ctx context.Context, key, reason string) error {
+	remaining, err := g.attempts.BlockedFor(ctx, key)
+	if err != nil {
+		log.Printf("Brute-force guard: block check failed for %s: %v", key, err)
+		return nil
+	}
+	if remaining > 0 {
+		return &ThrottledError{Reason: reason, RetryAfter: remaining}
+	}
+	return nil
+}
+
+func (g *BruteForceGuard) countFailure(ctx context.Context, counterKey, blockKey string, threshold int, anomaly, subject string) {
+	count, err := g.attempts.Hit(ctx, counterKey, g.config.TokenRateLimitWindow)
+	if err != nil {
+		log.Printf("Brute-force guard: failed to count failure for %s: %v", subject, err)
+		return
+	}
+	if count != int64(threshold) {
+		return
+	}
+
+	if err := g.attempts.Block(ctx, blockKey, g.config.TokenBlockDuration); err != nil {
+		log.Printf("Brute-force guard: failed to block %s: %v", subject, err)
+		return
+	}
+	g.recordAnomaly(ctx, anomaly)
+	log.Printf("Brute-force guard: blocked %s for %s after %d failed attempts", subject, g.config.TokenBlockDuration, count)
+}
+
+func (g *BruteForceGuard) recordAnomaly(ctx context.Context, name string) {
+	if err := g.attempts.IncrementAnomaly(ctx, name); err != nil {
+		log.Printf("Brute-force guard: %v", err)
+	}
+}
+
+func ipBlockKey(clientIP string) string {
+	return "ip:" + clientIP
+}
+
+func sessionBlockKey(sessionID string) string {
+	return "session:" + sessionID
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// ClientToken is an access token issued to a service client
+type ClientToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	Scopes      []string
+}
+
+// ClientTokenValidation is the result of validating a client token
+type ClientTokenValidation struct {
+	Claims *domain.ClientClaims
+	Client *domain.ServiceClient
+}
+
+// ClientCredentialsService issues tokens to registered service clients, such
+// as the gateway, the dashboard and external integrations, so they never need
+// to impersonate a user. Client tokens carry scopes rather than a role, have
+// no session and cannot be refreshed; clients request a new one on expiry.
+type ClientCredentialsService struct {
+	clientRepo  interfaces.ServiceClientRepository
+	sessionRepo interfaces.SessionRepository
+	config      *config.Config
+}
+
+// NewClientCredentialsService creates a new client credentials service
+func NewClientCredentialsService(
+	clientRepo interfaces.ServiceClientRepository,
+	sessionRepo interfaces.SessionRepository,
+	config *config.Config,
+) *ClientCredentialsService {
+	return &ClientCredentialsService{
+		clientRepo:  clientRepo,
+		sessionRepo: sessionRepo,
+		config:      config,
+	}
+}
+
+// RegisterClient registers a service client and returns it with its secret,
+// which is not stored and cannot be retrieved later
+func (s *ClientCredentialsService) RegisterClient(ctx context.Context, name string, scopes []string, createdBy string) (*domain.ServiceClient, string, error) {
+	client, secret, err := domain.NewServiceClient(name, scopes, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.clientRepo.Create(ctx, client); err != nil {
+		return nil, "", err
+	}
+
+	log.Printf("Service client %s (%s) registered by %s with scopes %v", client.ClientID, client.Name, createdBy, client.Scopes)
+	return client, secret, nil
+}
+
+// IssueToken exchanges a client's credentials for an access token with the
+// requested scopes, or all of the client's scopes if none are requested
+func (s *ClientCredentialsService) IssueToken(ctx context.Context, clientID, secret string, scopes []string) (*ClientToken, error) {
+	if clientID == "" || secret == "" {
+		return nil, domain.ErrInvalidClientCredential
+	}
+
+	client, err := s.clientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrServiceClientNotFound) {
+			// Unknown clients look the same as wrong secrets
+			return nil, domain.ErrInvalidClientCredential
+		}
+		return nil, fmt.Errorf("failed to get service client: %w", err)
+	}
+	if err := client.Authenticate(secret); err != nil {
+		return nil, err
+	}
+
+	granted, err := client.GrantScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	token, claims, err := domain.GenerateClientToken(client, granted, s.config.JWT.SecretKey, s.config.JWT.Issuer, s.config.Clients.TokenDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.clientRepo.TouchLastUsed(ctx, client.ClientID, time.Now()); err != nil {
+		log.Printf("Failed to record use of service client %s: %v", client.ClientID, err)
+	}
+
+	return &ClientToken{
+		AccessToken: token,
+		ExpiresAt:   claims.ExpiresAt.Time,
+		Scopes:      granted,
+	}, nil
+}
+
+// ValidateToken validates a client token. Tokens of disabled clients are
+// rejected before they expire.
+func (s *ClientCredentialsService) ValidateToken(ctx context.Context, accessToken string) (*ClientTokenValidation, error) {
+	if accessToken == "" {
+		return nil, domain.ErrInvalidToken
+	}
+
+	claims, err := domain.ValidateClientToken(accessToken, s.config.JWT.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	isBlacklisted, err := s.sessionRepo.IsTokenBlacklisted(ctx, claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token blacklist: %w", err)
+	}
+	if isBlacklisted {
+		return nil, fmt.Errorf("token is blacklisted")
+	}
+
+	client, err := s.clientRepo.GetByClientID(ctx, claims.ClientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrServiceClientNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get service client: %w", err)
+	}
+	if client.Status != domain.ServiceClientStatusActive {
+		return nil, domain.ErrServiceClientDisabled
+	}
+
+	return &ClientTokenValidation{Claims: claims, Client: client}, nil
+}
+
+// ListClients returns all registered service clients
+func (s *ClientCredentialsService) ListClients(ctx context.Context) ([]*domain.ServiceClient, error) {
+	return s.clientRepo.List(ctx)
+}
+
+// RotateSecret replaces a client's secret and returns the new one. Tokens
+// already issued stay valid until they expire, which the short token lifetime
+// keeps brief.
+func (s *ClientCredentialsService) RotateSecret(ctx context.Context, clientID string) (*domain.ServiceClient, string, error) {
+	client, err := s.clientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := client.RotateSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.clientRepo.Update(ctx, client); err != nil {
+		return nil, "", err
+	}
+
+	log.Printf("Secret of service client %s rotated", client.ClientID)
+	return client, secret, nil
+}
+
+// DisableClient stops a client from obtaining tokens and invalidates the
+// tokens it already holds
+func (s *ClientCredentialsService) DisableClient(ctx context.Context, clientID string) (*domain.ServiceClient, error) {
+	client, err := s.clientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	client.Disable()
+	if err := s.clientRepo.Update(ctx, client); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Service client %s disabled", client.ClientID)
+	return client, nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// ErrQuotaExceeded is returned when a service client used up its request quota
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaUsage is a client's consumption of its request quota in the current window
+type QuotaUsage struct {
+	ClientID  string
+	Limit     int64
+	Used      int64
+	Remaining int64
+	ResetAt   time.Time // End of the current window
+}
+
+// QuotaExceededError tells the client its quota and when it resets
+type QuotaExceededError struct {
+	Usage QuotaUsage
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: %d of %d requests used, resets at %s",
+		ErrQuotaExceeded, e.Usage.Used, e.Usage.Limit, e.Usage.ResetAt.Format(time.RFC3339))
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// RetryAfter returns how long until the quota resets
+func (e *QuotaExceededError) RetryAfter() time.Duration {
+	return time.Until(e.Usage.ResetAt)
+}
+
+// ClientQuota meters the requests service clients make through the gateway
+// against their quota. Every request the gateway validates counts, rejected
+// ones included, so a client retrying in a loop stays over quota until the
+// window resets. Redis errors never reject a request - the quota fails open
+// and logs.
+type ClientQuota struct {
+	counters interfaces.QuotaRepository
+	config   config.ClientQuotaConfig
+}
+
+// NewClientQuota creates a client quota using the client credentials configuration
+func NewClientQuota(counters interfaces.QuotaRepository, config config.ClientQuotaConfig) *ClientQuota {
+	return &ClientQuota{
+		counters: counters,
+		config:   config,
+	}
+}
+
+// Enabled reports whether client requests are metered
+func (q *ClientQuota) Enabled() bool {
+	return q.config.Enabled
+}
+
+// Consume counts a request of the client and returns its usage. It returns a
+// *QuotaExceededError when the client is over its quota.
+func (q *ClientQuota) Consume(ctx context.Context, clientID string) (*QuotaUsage, error) {
+	if !q.config.Enabled {
+		return nil, nil
+	}
+
+	start, end := q.window(time.Now())
+	used, err := q.counters.Consume(ctx, clientID, start, end)
+	if err != nil {
+		log.Printf("Client quota: failed to count request of %s: %v", clientID, err)
+		return nil, nil
+	}
+
+	usage := q.usage(clientID, used, end)
+	if used > usage.Limit {
+		// Only the first excess request per window is worth logging
+		if used == usage.Limit+1 {
+			log.Printf("Client quota: %s used its quota of %d requests until %s", clientID, usage.Limit, end.Format(time.RFC3339))
+		}
+		return usage, &QuotaExceededError{Usage: *usage}
+	}
+	return usage, nil
+}
+
+// Usage returns the client's usage of the current window without counting a request
+func (q *ClientQuota) Usage(ctx context.Context, clientID string) (*QuotaUsage, error) {
+	start, end := q.window(time.Now())
+	used, err := q.counters.Used(ctx, clientID, start)
+	if err != nil {
+		return nil, err
+	}
+	return q.usage(clientID, used, end), nil
+}
+
+// window returns the quota window containing the time
+func (q *ClientQuota) window(now time.Time) (time.Time, time.Time) {
+	start := now.UTC().Truncate(q.config.Window)
+	return start, start.Add(q.config.Window)
+}
+
+func (q *ClientQuota) usage(clientID string, used int64, resetAt time.Time) *QuotaUsage {
+	limit := q.config.LimitFor(clientID)
+	return &QuotaUsage{
+		ClientID:  clientID,
+		Limit:     limit,
+		Used:      min(used, limit),
+		Remaining: max(limit-used, 0),
+		ResetAt:   resetAt,
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// ErrLoginAlertsDisabled is returned when no failed login alerter is configured
+var ErrLoginAlertsDisabled = errors.New("failed login alerts are not enabled")
+
+// FailedLoginAlerter warns account owners of failed logins piling up
+type FailedLoginAlerter interface {
+	// FailedLoginBurst sends the alert with a link locking the account; the
+	// link must not be logged
+	FailedLoginBurst(ctx context.Context, user *domain.User, burst *domain.FailedLoginBurst, lockURL string, lockExpiresAt time.Time) error
+}
+
+// loginAlerts holds the failed login alert dependencies of the auth service
+type loginAlerts struct {
+	attempts interfaces.AttemptRepository
+	links    interfaces.AccountLockLinkRepository
+	alerter  FailedLoginAlerter
+	config   config.LoginAlertConfig
+}
+
+// WithLoginAlerts enables failed login alerts: failed logins are counted in
+// attempts, lock links are stored in links and alerter delivers the alerts
+func WithLoginAlerts(attempts interfaces.AttemptRepository, links interfaces.AccountLockLinkRepository, alerter FailedLoginAlerter, config config.LoginAlertConfig) AuthServiceOption {
+	return func(s *AuthService) {
+		s.loginAlerts = &loginAlerts{
+			attempts: attempts,
+			links:    links,
+			alerter:  alerter,
+			config:   config,
+		}
+	}
+}
+
+// recordFailedLogin counts a failed login to an account and alerts its owner
+// once the threshold is reached. One alert is sent per window, however many
+// attempts follow. Failures are logged and never change the login outcome.
+func (s *AuthService) recordFailedLogin(ctx context.Context, user *domain.User, ipAddress, userAgent string) {
+	if s.loginAlerts == nil {
+		return
+	}
+
+	cfg := s.loginAlerts.config
+	count, err := s.loginAlerts.attempts.Hit(ctx, failedLoginKey(user.ID), cfg.Window)
+	if err != nil {
+		log.Printf("Failed login alert: counting failed logins of user %s failed: %v", user.ID, err)
+		return
+	}
+	if count != int64(cfg.Threshold) {
+		return
+	}
+
+	burst := &domain.FailedLoginBurst{
+		UserID:     user.ID,
+		Attempts:   count,
+		Window:     cfg.Window,
+		IPAddress:  ipAddress,
+		Device:     domain.ParseUserAgent(userAgent),
+		OccurredAt: time.Now(),
+	}
+	if burst.Location, err = s.geoProvider.Lookup(ctx, ipAddress); err != nil {
+		log.Printf("GeoIP lookup failed for failed login alert of user %s: %v", user.ID, err)
+	}
+
+	if err := s.sendFailedLoginAlert(ctx, user, burst); err != nil {
+		log.Printf("Failed login alert: alerting user %s failed: %v", user.ID, err)
+		return
+	}
+	log.Printf("Failed login alert sent to user %s after %d failed logins (latest from %s)", user.ID, count, ipAddress)
+}
+
+// sendFailedLoginAlert stores a lock link for the user and sends it with the alert
+func (s *AuthService) sendFailedLoginAlert(ctx context.Context, user *domain.User, burst *domain.FailedLoginBurst) error {
+	cfg := s.loginAlerts.config
+	link, token, err := domain.NewAccountLockLink(user.ID, cfg.LockLinkTTL)
+	if err != nil {
+		return err
+	}
+	if err := s.loginAlerts.links.Create(ctx, link); err != nil {
+		return fmt.Errorf("failed to store account lock link: %w", err)
+	}
+
+	lockURL, err := loginLinkURL(cfg.LockURL, token)
+	if err != nil {
+		return err
+	}
+	return s.loginAlerts.alerter.FailedLoginBurst(ctx, user, burst, lockURL, link.ExpiresAt)
+}
+
+// LockAccountWithToken locks the account a failed login alert was sent for and
+// revokes its sessions, given the token of the alert's lock link. A longer lock
+// already in place is kept. It returns when the account unlocks.
+func (s *AuthService) LockAccountWithToken(ctx context.Context, token string) (time.Time, error) {
+	if s.loginAlerts == nil {
+		return time.Time{}, ErrLoginAlertsDisabled
+	}
+	if token == "" {
+		return time.Time{}, domain.ErrInvalidLockLink
+	}
+
+	link, err := s.loginAlerts.links.Consume(ctx, domain.HashLoginLinkToken(token))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if link.IsExpired() {
+		return time.Time{}, domain.ErrInvalidLockLink
+	}
+
+	user, err := s.userRepo.GetByID(ctx, link.UserID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			return time.Time{}, domain.ErrInvalidLockLink
+		}
+		return time.Time{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	lockUntil := time.Now().Add(s.loginAlerts.config.LockDuration)
+	if user.LockedUntil != nil && user.LockedUntil.After(lockUntil) {
+		lockUntil = *user.LockedUntil
+	} else if err := s.userRepo.LockAccount(ctx, user.ID, lockUntil); err != nil {
+		return time.Time{}, err
+	}
+
+	// Whoever was guessing the password may already have got in
+	if err := s.sessionRepo.RevokeUserSessions(ctx, user.ID); err != nil {
+		log.Printf("Failed to revoke sessions of user %s locked by its owner: %v", user.ID, err)
+	}
+
+	log.Printf("Account %s locked by its owner from a failed login alert until %s", user.ID, lockUntil.Format(time.RFC3339))
+	return lockUntil, nil
+}
+
+func failedLoginKey(userID string) string {
+	return "failed_login:" + userID
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/captcha"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// Errors returned by the login challenge
+var (
+	ErrChallengeRequired    = errors.New("captcha required")
+	ErrChallengeFailed      = errors.New("captcha verification failed")
+	ErrChallengeUnavailable = errors.New("captcha verification unavailable")
+)
+
+// LoginChallenge requires a solved CAPTCHA from client IPs with repeated failed
+// logins. Failures are counted per IP; once an IP crosses the failure threshold
+// its logins carry a challenge until the challenge duration has passed, and
+// every retry must include a fresh verification token. Like the brute-force
+// guard it fails open when Redis is unavailable.
+type LoginChallenge struct {
+	attempts interfaces.AttemptRepository
+	verifier captcha.Verifier
+	config   config.CaptchaConfig
+}
+
+// NewLoginChallenge creates a login challenge using the CAPTCHA configuration
+func NewLoginChallenge(attempts interfaces.AttemptRepository, verifier captcha.Verifier, config config.CaptchaConfig) *LoginChallenge {
+	return &LoginChallenge{
+		attempts: attempts,
+		verifier: verifier,
+		config:   config,
+	}
+}
+
+// Enabled reports whether a CAPTCHA provider is configured
+func (c *LoginChallenge) Enabled() bool {
+	return c.verifier.Provider() != captcha.ProviderNone
+}
+
+// Provider names the CAPTCHA provider whose widget clients must render
+func (c *LoginChallenge) Provider() string {
+	return c.verifier.Provider()
+}
+
+// SiteKey is the public key clients render the CAPTCHA widget with
+func (c *LoginChallenge) SiteKey() string {
+	return c.config.SiteKey
+}
+
+// Check reports whether a login from clientIP may proceed. It returns
+// ErrChallengeRequired when the IP is challenged and no token was given,
+// ErrChallengeFailed when the provider rejects the token and
+// ErrChallengeUnavailable when the provider could not be asked.
+func (c *LoginChallenge) Check(ctx context.Context, clientIP, token string) error {
+	if !c.Enabled() || clientIP == "" {
+		return nil
+	}
+
+	remaining, err := c.attempts.BlockedFor(ctx, challengeKey(clientIP))
+	if err != nil {
+		log.Printf("Login challenge: check failed for %s: %v", clientIP, err)
+		return nil
+	}
+	if remaining <= 0 {
+		return nil
+	}
+
+	if token == "" {
+		return ErrChallengeRequired
+	}
+
+	ok, err := c.verifier.Verify(ctx, token, clientIP)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrChallengeUnavailable, err)
+	}
+	if !ok {
+		c.recordAnomaly(ctx, "login_captcha_failures")
+		return ErrChallengeFailed
+	}
+	return nil
+}
+
+// RecordFailure counts a failed login, challenging the client IP once its
+// failure threshold is reached
+func (c *LoginChallenge) RecordFailure(ctx context.Context, clientIP string) {
+	if !c.Enabled() || clientIP == "" {
+		return
+	}
+
+	count, err := c.attempts.Hit(ctx, "fail:login:"+clientIP, c.config.FailureWindow)
+	if err != nil {
+		log.Printf("Login challenge: failed to count failure for %s: %v", clientIP, err)
+		return
+	}
+	if count != int64(c.config.FailureThreshold) {
+		return
+	}
+
+	if err := c.attempts.Block(ctx, challengeKey(clientIP), c.config.ChallengeDuration); err != nil {
+		log.Printf("Login challenge: failed to challenge %s: %v", clientIP, err)
+		return
+	}
+	c.recordAnomaly(ctx, "login_captcha_required")
+	log.Printf("Login challenge: requiring a CAPTCHA from %s for %s after %d failed logins", clientIP, c.config.ChallengeDuration, count)
+}
+
+func (c *LoginChallenge) recordAnomaly(ctx context.Context, name string) {
+	if err := c.attempts.IncrementAnomaly(ctx, name); err != nil {
+		log.Printf("Login challenge: %v", err)
+	}
+}
+
+func challengeKey(clientIP string) string {
+	return "captcha:" + clientIP
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// ErrLoginHistoryDisabled is returned when no login history repository is configured
+var ErrLoginHistoryDisabled = errors.New("login history is not enabled")
+
+// loginHistory holds the login history dependencies of the auth service
+type loginHistory struct {
+	repo   interfaces.LoginHistoryRepository
+	config config.LoginHistoryConfig
+}
+
+// WithLoginHistory records every login to an account, successful or not, in
+// repo so its owner can review them
+func WithLoginHistory(repo interfaces.LoginHistoryRepository, config config.LoginHistoryConfig) AuthServiceOption {
+	return func(s *AuthService) {
+		s.loginHistory = &loginHistory{
+			repo:   repo,
+			config: config,
+		}
+	}
+}
+
+// recordLogin adds a login to the user's history; an empty failureReason marks
+// a successful one. Failures are logged and never change the login outcome.
+func (s *AuthService) recordLogin(ctx context.Context, userID, method, failureReason, ipAddress, userAgent string, location *domain.GeoLocation) {
+	if s.loginHistory == nil {
+		return
+	}
+
+	now := time.Now()
+	event := &domain.LoginEvent{
+		UserID:        userID,
+		OccurredAt:    now,
+		Method:        method,
+		Success:       failureReason == "",
+		FailureReason: failureReason,
+		IPAddress:     ipAddress,
+		UserAgent:     userAgent,
+		Location:      location,
+	}
+	if event.Location == nil && !event.Success {
+		var err error
+		if event.Location, err = s.geoProvider.Lookup(ctx, ipAddress); err != nil {
+			log.Printf("GeoIP lookup failed for login history of user %s: %v", userID, err)
+		}
+	}
+
+	cfg := s.loginHistory.config
+	if err := s.loginHistory.repo.Record(ctx, event, cfg.MaxEvents, now.Add(-cfg.Retention)); err != nil {
+		log.Printf("Login history: recording login of user %s failed: %v", userID, err)
+	}
+}
+
+// GetLoginHistory returns up to limit of the user's logins within the retention
+// period, newest first, starting after the login beforeID (0 for the newest)
+func (s *AuthService) GetLoginHistory(ctx context.Context, userID string, beforeID int64, limit int) ([]*domain.LoginEvent, error) {
+	if s.loginHistory == nil {
+		return nil, ErrLoginHistoryDisabled
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	events, err := s.loginHistory.repo.List(ctx, interfaces.LoginHistoryQuery{
+		UserID:   userID,
+		Since:    time.Now().Add(-s.loginHistory.config.Retention),
+		BeforeID: beforeID,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login history: %w", err)
+	}
+	return events, nil
+}
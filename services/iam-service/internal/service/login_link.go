@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// ErrLoginLinksDisabled is returned when no login link sender is configured
+var ErrLoginLinksDisabled = errors.New("login links are not enabled")
+
+// LoginLinkSender delivers password-less login links to users
+type LoginLinkSender interface {
+	// SendLoginLink hands the link to the user; it must not be logged
+	SendLoginLink(ctx context.Context, user *domain.User, link string, expiresAt time.Time) error
+}
+
+// loginLinks holds the magic-link login dependencies of the auth service
+type loginLinks struct {
+	links    interfaces.LoginLinkRepository
+	attempts interfaces.AttemptRepository
+	sender   LoginLinkSender
+	config   config.LoginLinkConfig
+}
+
+// WithLoginLinks enables password-less login: links are stored in links, the
+// requests of each email are counted in attempts and sender delivers them
+func WithLoginLinks(links interfaces.LoginLinkRepository, attempts interfaces.AttemptRepository, sender LoginLinkSender, config config.LoginLinkConfig) AuthServiceOption {
+	return func(s *AuthService) {
+		s.loginLinks = &loginLinks{
+			links:    links,
+			attempts: attempts,
+			sender:   sender,
+			config:   config,
+		}
+	}
+}
+
+// RequestLoginLink sends a single-use login link to the user with the given
+// email. Unknown, locked and inactive accounts get no link but the same
+// answer, so the request does not reveal which emails have an account. The
+// link only completes from the user agent that asked for it.
+func (s *AuthService) RequestLoginLink(ctx context.Context, email, ipAddress, userAgent string) error {
+	if s.loginLinks == nil {
+		return ErrLoginLinksDisabled
+	}
+	if email == "" {
+		return domain.ErrInvalidEmail
+	}
+
+	// Normalize email
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	// Counted before the lookup, so unknown emails are limited alike. Like the
+	// brute-force guard the limit fails open when Redis is unavailable.
+	cfg := s.loginLinks.config
+	count, err := s.loginLinks.attempts.Hit(ctx, loginLinkRateKey(email), cfg.RateLimitWindow)
+	if err != nil {
+		log.Printf("Login link: rate limit check failed for %s: %v", email, err)
+	} else if count > int64(cfg.RateLimit) {
+		return domain.ErrLoginLinkRateLimited
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			return nil // Don't reveal that user doesn't exist
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsLocked() || !user.CanSignIn() {
+		return nil // Don't reveal the account status
+	}
+
+	link, token, err := domain.NewLoginLink(user.ID, ipAddress, userAgent, cfg.TTL)
+	if err != nil {
+		return err
+	}
+	if err := s.loginLinks.links.Create(ctx, link); err != nil {
+		return fmt.Errorf("failed to store login link: %w", err)
+	}
+
+	loginURL, err := loginLinkURL(cfg.URL, token)
+	if err != nil {
+		return err
+	}
+	if err := s.loginLinks.sender.SendLoginLink(ctx, user, loginURL, link.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to send login link: %w", err)
+	}
+	return nil
+}
+
+// CompleteLoginWithLink exchanges a login link token for a session. The link
+// is used up by the first attempt, even one from another user agent, so a
+// leaked link cannot be retried.
+func (s *AuthService) CompleteLoginWithLink(ctx context.Context, token, ipAddress, userAgent string) (*LoginResult, error) {
+	if s.loginLinks == nil {
+		return nil, ErrLoginLinksDisabled
+	}
+	if token == "" {
+		return nil, domain.ErrInvalidLoginLink
+	}
+
+	link, err := s.loginLinks.links.Consume(ctx, domain.HashLoginLinkToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if link.IsExpired() {
+		return nil, domain.ErrInvalidLoginLink
+	}
+	if !link.MatchesUserAgent(userAgent) {
+		log.Printf("Login link: user %s completed from another user agent (requested from %s, completed from %s)",
+			link.UserID, link.IPAddress, ipAddress)
+		return nil, domain.ErrInvalidLoginLink
+	}
+
+	user, err := s.userRepo.GetByID(ctx, link.UserID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			return nil, domain.ErrInvalidLoginLink
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// The account may have changed since the link was sent
+	if user.IsLocked() {
+		s.recordLogin(ctx, user.ID, domain.LoginMethodLoginLink, domain.LoginFailureAccountLocked, ipAddress, userAgent, nil)
+		return nil, domain.ErrAccountLocked
+	}
+	if !user.CanSignIn() {
+		s.recordLogin(ctx, user.ID, domain.LoginMethodLoginLink, domain.LoginFailureAccountInactive, ipAddress, userAgent, nil)
+		return nil, domain.ErrAccountInactive
+	}
+
+	return s.startSession(ctx, user, domain.LoginMethodLoginLink, ipAddress, userAgent)
+}
+
+// loginLinkURL adds a token to the login link page URL
+func loginLinkURL(base, token string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid login link URL: %w", err)
+	}
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func loginLinkRateKey(email string) string {
+	return "login_link:" + email
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// WithPermissionCache caches a permission snapshot per session, which session
+// validation hands to the gateway so services can authorize requests without a
+// CheckPermission call each
+func WithPermissionCache(cache interfaces.PermissionCacheRepository) AuthServiceOption {
+	return func(s *AuthService) {
+		s.permissionCache = cache
+	}
+}
+
+// WithPermissionVersions invalidates the cached permission snapshots of a user's
+// sessions when their role changes
+func WithPermissionVersions(cache interfaces.PermissionCacheRepository) UserServiceOption {
+	return func(s *UserService) {
+		s.permissionCache = cache
+	}
+}
+
+// sessionPermissions returns the permission snapshot of a validated session. The
+// cached snapshot is reused while it matches the user's permission version and
+// role; otherwise a new one is taken and cached. Cache failures are logged and
+// answered with a fresh, uncached snapshot.
+func (s *AuthService) sessionPermissions(ctx context.Context, session *domain.Session, user *domain.User) *domain.PermissionSnapshot {
+	if s.permissionCache == nil {
+		return domain.NewPermissionSnapshot(user, 0)
+	}
+
+	cached, version, err := s.permissionCache.Get(ctx, session.ID, user.ID)
+	if err != nil {
+		log.Printf("Reading permission snapshot of session %s failed: %v", session.ID, err)
+		return domain.NewPermissionSnapshot(user, version)
+	}
+	if cached != nil && cached.IsCurrent(user, version) {
+		return cached
+	}
+
+	snapshot := domain.NewPermissionSnapshot(user, version)
+	if err := s.permissionCache.Save(ctx, session.ID, snapshot, session.ExpiresAt); err != nil {
+		log.Printf("Caching permission snapshot of session %s failed: %v", session.ID, err)
+	}
+	return snapshot
+}
+
+// invalidatePermissions bumps a user's permission version after their role
+// changed. A failure is only logged: snapshots also record the role they were
+// taken for, so they are retaken anyway once validation sees the new role.
+func (s *UserService) invalidatePermissions(ctx context.Context, userID string) {
+	if s.permissionCache == nil {
+		return
+	}
+	if err := s.permissionCache.BumpVersion(ctx, userID); err != nil {
+		log.Printf("Invalidating permission snapshots of user %s failed: %v", userID, err)
+	}
+}
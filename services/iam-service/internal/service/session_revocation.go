@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// sessionRevocationBatchSize is the number of sessions written per batch
+const sessionRevocationBatchSize = 100
+
+// ErrNoRevocationCriteria is returned when a bulk revocation names no criteria,
+// which would otherwise revoke every session in the system
+var ErrNoRevocationCriteria = errors.New("at least one revocation criterion is required")
+
+// SessionRevocationCriteria selects the sessions revoked in bulk; a session
+// must match every criterion that is set
+type SessionRevocationCriteria struct {
+	IPNetwork     *net.IPNet
+	UserAgent     string // Case-insensitive substring
+	CreatedBefore *time.Time
+
+	KeepSessionID string // Never revoked, typically the caller's session
+	DryRun        bool
+	Reason        string
+}
+
+// SessionRevocationResult summarizes a bulk revocation
+type SessionRevocationResult struct {
+	DryRun        bool
+	Matched       int
+	Revoked       int
+	Failed        int
+	AffectedUsers int
+}
+
+// ParseIPRange parses a CIDR range or a single IP address into a network
+func ParseIPRange(value string) (*net.IPNet, error) {
+	if strings.Contains(value, "/") {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP range %q", value)
+		}
+		return network, nil
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", value)
+	}
+	bits := 128
+	if ip.To4() != nil {
+		ip = ip.To4()
+		bits = 32
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// RevokeSessionsByFilter revokes all active sessions matching the criteria in
+// batches. In dry-run mode the matching sessions are only counted.
+func (s *AuthService) RevokeSessionsByFilter(ctx context.Context, criteria SessionRevocationCriteria) (*SessionRevocationResult, error) {
+	if criteria.IPNetwork == nil && criteria.UserAgent == "" && criteria.CreatedBefore == nil {
+		return nil, ErrNoRevocationCriteria
+	}
+
+	active := domain.SessionStatusActive
+	filter := interfaces.SessionFilter{
+		Status:        &active,
+		IPNetwork:     criteria.IPNetwork,
+		CreatedBefore: criteria.CreatedBefore,
+	}
+	if criteria.UserAgent != "" {
+		filter.UserAgent = &criteria.UserAgent
+	}
+
+	sessions, err := s.sessionRepo.FindSessionsByFilter(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+
+	matched := make([]*domain.Session, 0, len(sessions))
+	users := make(map[string]struct{})
+	for _, session := range sessions {
+		if session.ID == criteria.KeepSessionID {
+			continue
+		}
+		matched = append(matched, session)
+		users[session.UserID] = struct{}{}
+	}
+
+	result := &SessionRevocationResult{
+		DryRun:        criteria.DryRun,
+		Matched:       len(matched),
+		AffectedUsers: len(users),
+	}
+	if criteria.DryRun {
+		return result, nil
+	}
+
+	for start := 0; start < len(matched); start += sessionRevocationBatchSize {
+		end := start + sessionRevocationBatchSize
+		if end > len(matched) {
+			end = len(matched)
+		}
+
+		batch := matched[start:end]
+		for _, session := range batch {
+			session.Revoke()
+		}
+		if err := s.sessionRepo.UpdateBatch(ctx, batch); err != nil {
+			log.Printf("Bulk session revocation batch failed (%d sessions): %v", len(batch), err)
+			result.Failed += len(batch)
+			continue
+		}
+		result.Revoked += len(batch)
+	}
+
+	log.Printf("Bulk session revocation (reason: %q): %d matched, %d revoked, %d failed across %d users",
+		criteria.Reason, result.Matched, result.Revoked, result.Failed, result.AffectedUsers)
+
+	return result, nil
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+	platformErrors "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/jobs"
+	"github.com/amiosamu/rocket-science/shared/platform/purge"
+)
+
+// TestDataPurgeJobType is the job type of test data purges
+const TestDataPurgeJobType = "test_data_purge"
+
+// testDataPurgeReason is recorded in the audit trail of every purged test user
+const testDataPurgeReason = "test data purge"
+
+// testDataPurgeRetryPolicy retries failed purges, which re-runs the saga and
+// so carries it forward from the step that failed
+var testDataPurgeRetryPolicy = jobs.RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 30 * time.Second,
+	MaxBackoff:     5 * time.Minute,
+}
+
+// TestDataPurgeRequest selects the test users to purge. Users are selected by
+// email domain, by ID, or both; every one of them must have an email at one of
+// the test domains.
+type TestDataPurgeRequest struct {
+	EmailDomains []string `json:"email_domains,omitempty"`
+	UserIDs      []string `json:"user_ids,omitempty"`
+	DryRun       bool     `json:"dry_run,omitempty"`
+}
+
+// TestDataPurgeService resets demo and test environments. It deletes test
+// users with their sessions, orders, reservations, payments and notifications
+// through a purge saga over the services, run as a background job.
+type TestDataPurgeService struct {
+	userRepo     interfaces.UserRepository
+	sessionRepo  interfaces.SessionRepository
+	store        jobs.Store
+	saga         *purge.Saga
+	gate         purge.Gate
+	emailDomains []string
+}
+
+// NewTestDataPurgeService creates the test data purge. The participants are
+// the purge endpoints of the other services; iam-service deletes its users
+// last, so a purge that failed halfway still finds them when it runs again.
+func NewTestDataPurgeService(
+	userRepo interfaces.UserRepository,
+	sessionRepo interfaces.SessionRepository,
+	store jobs.Store,
+	gate purge.Gate,
+	emailDomains []string,
+	orders, inventory, payments, notifications purge.Participant,
+) *TestDataPurgeService {
+	s := &TestDataPurgeService{
+		userRepo:     userRepo,
+		sessionRepo:  sessionRepo,
+		store:        store,
+		gate:         gate,
+		emailDomains: emailDomains,
+	}
+	s.saga = purge.NewSaga(
+		purge.Step{Name: "orders-discovery", Participant: orders, Discover: true},
+		purge.Step{Name: "notifications", Participant: notifications},
+		purge.Step{Name: "payments", Participant: payments},
+		purge.Step{Name: "inventory", Participant: inventory},
+		purge.Step{Name: "orders", Participant: orders},
+		purge.Step{Name: "users", Participant: purge.ParticipantFunc(s.purgeUsers)},
+	)
+	return s
+}
+
+// Register registers the purge job with worker
+func (s *TestDataPurgeService) Register(worker *jobs.Worker) {
+	worker.Register(TestDataPurgeJobType, s.run, testDataPurgeRetryPolicy)
+}
+
+// Request selects the users of req and enqueues their purge. It returns
+// purge.ErrDisabled unless the environment allows purges.
+func (s *TestDataPurgeService) Request(ctx context.Context, req TestDataPurgeRequest) (*jobs.Job, error) {
+	if err := s.gate.Check(); err != nil {
+		return nil, err
+	}
+
+	scope, err := s.resolveScope(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := jobs.Enqueue(ctx, s.store, TestDataPurgeJobType, scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue test data purge: %w", err)
+	}
+
+	log.Printf("Test data purge %s requested for %d users (dry run: %t)", job.ID, len(scope.UserIDs), scope.DryRun)
+	return job, nil
+}
+
+// resolveScope turns a request into the scope of the saga
+func (s *TestDataPurgeService) resolveScope(ctx context.Context, req TestDataPurgeRequest) (purge.Scope, error) {
+	if len(req.EmailDomains) == 0 && len(req.UserIDs) == 0 {
+		return purge.Scope{}, platformErrors.NewValidation("select users by email domain or user ID")
+	}
+	for _, emailDomain := range req.EmailDomains {
+		if !containsFold(s.emailDomains, emailDomain) {
+			return purge.Scope{}, platformErrors.NewValidation(fmt.Sprintf("%s is not a test email domain", emailDomain))
+		}
+	}
+
+	scope := purge.Scope{DryRun: req.DryRun}
+	seen := make(map[string]bool)
+
+	if len(req.EmailDomains) > 0 {
+		users, err := s.userRepo.GetUsersForCleanup(ctx, interfaces.CleanupCriteria{
+			IncludeTestUsers: true,
+			EmailDomains:     req.EmailDomains,
+			Limit:            purge.MaxScopeSize + 1,
+		})
+		if err != nil {
+			return purge.Scope{}, fmt.Errorf("failed to find test users: %w", err)
+		}
+		for _, user := range users {
+			if !seen[user.ID] {
+				seen[user.ID] = true
+				scope.UserIDs = append(scope.UserIDs, user.ID)
+			}
+		}
+	}
+
+	for _, userID := range req.UserIDs {
+		if seen[userID] {
+			continue
+		}
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return purge.Scope{}, platformErrors.NewValidation(fmt.Sprintf("user %s not found", userID))
+		}
+		if err != nil {
+			return purge.Scope{}, fmt.Errorf("failed to get user %s: %w", userID, err)
+		}
+		if !s.isTestUser(user) {
+			return purge.Scope{}, platformErrors.NewValidation(fmt.Sprintf("user %s is not a test user", userID))
+		}
+		seen[userID] = true
+		scope.UserIDs = append(scope.UserIDs, userID)
+	}
+
+	if len(scope.UserIDs) == 0 {
+		return purge.Scope{}, platformErrors.NewValidation("no test users match the request")
+	}
+	if err := scope.Validate(); err != nil {
+		return purge.Scope{}, platformErrors.NewValidation(err.Error())
+	}
+	return scope, nil
+}
+
+// run is the job handler, running the saga and storing its report
+func (s *TestDataPurgeService) run(ctx context.Context, exec *jobs.Execution) error {
+	if err := s.gate.Check(); err != nil {
+		return jobs.Permanent(err)
+	}
+
+	var scope purge.Scope
+	if err := exec.Decode(&scope); err != nil {
+		return err
+	}
+
+	report, err := s.saga.Run(ctx, scope, func(done, total int) {
+		if err := exec.ReportProgress(ctx, int64(done), int64(total)); err != nil {
+			log.Printf("Failed to report test data purge progress: %v", err)
+		}
+	})
+	if err != nil {
+		if purge.IsDisabled(err) {
+			return jobs.Permanent(err)
+		}
+		return err
+	}
+
+	log.Printf("Test data purge %s completed for %d users and %d orders (dry run: %t)",
+		exec.Job.ID, len(report.Scope.UserIDs), len(report.Scope.OrderIDs), scope.DryRun)
+	return exec.SetResult(report)
+}
+
+// purgeUsers is the iam-service step of the saga, deleting the users with
+// their sessions. Users already gone are skipped, so a re-run completes.
+func (s *TestDataPurgeService) purgeUsers(ctx context.Context, scope purge.Scope) (*purge.Result, error) {
+	result := purge.NewResult("iam-service", scope)
+	result.Deleted["users"] = 0
+	result.Deleted["sessions"] = 0
+
+	for _, userID := range scope.UserIDs {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user %s: %w", userID, err)
+		}
+		// The scope was checked when the purge was requested; check again
+		// rather than trust the stored job
+		if !s.isTestUser(user) {
+			return nil, jobs.Permanent(fmt.Errorf("user %s is not a test user", userID))
+		}
+
+		sessions, err := s.sessionRepo.GetUserSessions(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user sessions: %w", err)
+		}
+		if scope.DryRun {
+			result.Deleted["users"]++
+			result.Deleted["sessions"] += int64(len(sessions))
+			continue
+		}
+
+		if len(sessions) > 0 {
+			sessionIDs := make([]string, len(sessions))
+			for i, session := range sessions {
+				sessionIDs[i] = session.ID
+			}
+			if err := s.sessionRepo.DeleteBatch(ctx, sessionIDs); err != nil {
+				return nil, fmt.Errorf("failed to delete user sessions: %w", err)
+			}
+		}
+
+		emailHash := sha256.Sum256([]byte(strings.ToLower(user.Email)))
+		err = s.userRepo.PurgeTestUser(ctx, &interfaces.UserPurgeRecord{
+			UserID:          user.ID,
+			EmailHash:       hex.EncodeToString(emailHash[:]),
+			Role:            user.Role,
+			DeletedAt:       time.Now(),
+			SessionsRemoved: len(sessions),
+			Reason:          testDataPurgeReason,
+		})
+		if errors.Is(err, domain.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Deleted["users"]++
+		result.Deleted["sessions"] += int64(len(sessions))
+	}
+
+	return result, nil
+}
+
+// isTestUser reports whether the user's email is at one of the test domains
+func (s *TestDataPurgeService) isTestUser(user *domain.User) bool {
+	at := strings.LastIndex(user.Email, "@")
+	return at >= 0 && containsFold(s.emailDomains, user.Email[at+1:])
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"log"
+)
+
+// Changes announced to services caching user data
+const (
+	UserChangeProfile     = "profile"
+	UserChangePreferences = "preferences"
+	UserChangeRole        = "role"
+	UserChangeStatus      = "status"
+	UserChangeLock        = "lock"
+	UserChangeTelegram    = "telegram"
+	UserChangeDeleted     = "deleted"
+)
+
+// UserChangeNotifier announces changes to users, so services caching their data
+// drop it before it expires
+type UserChangeNotifier interface {
+	UserChanged(ctx context.Context, userID, change string) error
+}
+
+// WithUserChangeNotifier announces changes to users
+func WithUserChangeNotifier(notifier UserChangeNotifier) UserServiceOption {
+	return func(s *UserService) {
+		s.changeNotifier = notifier
+	}
+}
+
+// notifyUserChanged announces a change to a user. A failure is only logged:
+// cached user data still expires with its TTL.
+func (s *UserService) notifyUserChanged(ctx context.Context, userID, change string) {
+	if s.changeNotifier == nil {
+		return
+	}
+	if err := s.changeNotifier.UserChanged(ctx, userID, change); err != nil {
+		log.Printf("Failed to announce %s change of user %s: %v", change, userID, err)
+	}
+}
+
+// restampSessions bumps the version of a user's active sessions after their role
+// or status changed, so access tokens carrying the old claims stop validating
+// right away instead of at expiry. Clients refresh to get tokens with the new
+// role. A failure is only logged: the tokens still expire with their duration.
+func (s *UserService) restampSessions(ctx context.Context, userID string) {
+	restamped, err := s.sessionRepo.RestampUserSessions(ctx, userID)
+	if err != nil {
+		log.Printf("Re-stamping sessions of user %s failed: %v", userID, err)
+		return
+	}
+	if restamped > 0 {
+		log.Printf("Re-stamped %d sessions of user %s", restamped, userID)
+	}
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+const (
+	// MaxImportRows bounds the number of data rows accepted in one import
+	MaxImportRows = 5000
+
+	// exportPageSize is the number of users read per repository call during export
+	exportPageSize = 500
+)
+
+// ErrInvalidCSV is returned when an import file cannot be processed at all
+var ErrInvalidCSV = errors.New("invalid CSV file")
+
+// userExportColumns is the header row of exported user files
+var userExportColumns = []string{
+	"id", "email", "first_name", "last_name", "role", "status",
+	"phone", "telegram_username", "created_at", "last_login_at",
+}
+
+// userImportColumns lists the columns accepted in import files; email,
+// first_name and last_name are required, role defaults to customer
+var userImportColumns = map[string]bool{
+	"email": true, "first_name": true, "last_name": true,
+	"role": true, "phone": true, "telegram_username": true,
+}
+
+// ImportRowStatus is the outcome of importing a single CSV row
+type ImportRowStatus string
+
+const (
+	ImportRowValid   ImportRowStatus = "valid"   // Passed validation (dry run)
+	ImportRowCreated ImportRowStatus = "created" // User was created
+	ImportRowFailed  ImportRowStatus = "failed"  // Row was rejected
+)
+
+// UserImportRow reports the outcome for one data row of an import file
+type UserImportRow struct {
+	Line              int             `json:"line"` // Line number in the file, the header is line 1
+	Email             string          `json:"email"`
+	Status            ImportRowStatus `json:"status"`
+	Error             string          `json:"error,omitempty"`
+	UserID            string          `json:"user_id,omitempty"`
+	TemporaryPassword string          `json:"temporary_password,omitempty"`
+}
+
+// UserImportResult summarises a CSV import
+type UserImportResult struct {
+	DryRun  bool             `json:"dry_run"`
+	Rows    []*UserImportRow `json:"rows"`
+	Valid   int              `json:"valid"`
+	Created int              `json:"created"`
+	Failed  int              `json:"failed"`
+}
+
+// ExportUsersCSV writes all users matching the options as CSV. Pagination in
+// the options is ignored; every matching user is exported.
+func (s *UserService) ExportUsersCSV(ctx context.Context, options UserListOptions) ([]byte, int, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(userExportColumns); err != nil {
+		return nil, 0, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	filter := interfaces.UserFilter{
+		Role:            options.Role,
+		Status:          options.Status,
+		CreatedAfter:    options.CreatedAfter,
+		CreatedBefore:   options.CreatedBefore,
+		LastLoginAfter:  options.LastLoginAfter,
+		LastLoginBefore: options.LastLoginBefore,
+		IsLocked:        options.IsLocked,
+		Limit:           exportPageSize,
+		SortBy:          "created_at",
+		SortOrder:       "asc",
+	}
+
+	exported := 0
+	for {
+		var users []*domain.User
+		var total int
+		var err error
+		if options.Search != "" {
+			users, total, err = s.userRepo.Search(ctx, options.Search, filter)
+		} else {
+			users, total, err = s.userRepo.List(ctx, filter)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list users for export: %w", err)
+		}
+
+		for _, user := range users {
+			if err := writer.Write(userToCSVRecord(user)); err != nil {
+				return nil, 0, fmt.Errorf("failed to write CSV row: %w", err)
+			}
+		}
+		exported += len(users)
+
+		filter.Offset += len(users)
+		if len(users) == 0 || filter.Offset >= total {
+			break
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, 0, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return buf.Bytes(), exported, nil
+}
+
+// ImportUsersCSV creates users from CSV data. Every row is validated and
+// reported individually; invalid rows do not stop the import. Imported users
+// get a generated temporary password, returned in the row report, that must
+// be changed on first login. With dryRun nothing is written.
+func (s *UserService) ImportUsersCSV(ctx context.Context, data []byte, dryRun bool) (*UserImportResult, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1 // Column count mismatches are reported per row
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("%w: file is empty", ErrInvalidCSV)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+
+	columns, err := parseImportHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UserImportResult{DryRun: dryRun}
+	seen := make(map[string]int)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		row := &UserImportRow{}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			row.Line = parseErr.StartLine
+		} else if err == nil {
+			row.Line, _ = reader.FieldPos(0)
+		}
+		result.Rows = append(result.Rows, row)
+
+		if len(result.Rows) > MaxImportRows {
+			return nil, fmt.Errorf("%w: more than %d rows", ErrInvalidCSV, MaxImportRows)
+		}
+
+		if err != nil {
+			s.failImportRow(result, row, err)
+			continue
+		}
+		if len(record) != len(header) {
+			s.failImportRow(result, row, fmt.Errorf("expected %d columns, got %d", len(header), len(record)))
+			continue
+		}
+
+		req, err := importRowToRequest(columns, record)
+		row.Email = req.Email
+		if err != nil {
+			s.failImportRow(result, row, err)
+			continue
+		}
+
+		if firstLine, duplicate := seen[req.Email]; duplicate {
+			s.failImportRow(result, row, fmt.Errorf("duplicate of line %d", firstLine))
+			continue
+		}
+		seen[req.Email] = row.Line
+
+		exists, err := s.userRepo.ExistsByEmail(ctx, req.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check email existence: %w", err)
+		}
+		if exists {
+			s.failImportRow(result, row, domain.ErrEmailExists)
+			continue
+		}
+
+		if dryRun {
+			row.Status = ImportRowValid
+			result.Valid++
+			continue
+		}
+
+		user, password, err := s.createImportedUser(ctx, req)
+		if err != nil {
+			s.failImportRow(result, row, err)
+			continue
+		}
+
+		row.Status = ImportRowCreated
+		row.UserID = user.ID
+		row.TemporaryPassword = password
+		result.Valid++
+		result.Created++
+	}
+
+	return result, nil
+}
+
+// createImportedUser creates a user with a temporary password that must be
+// changed on first login
+func (s *UserService) createImportedUser(ctx context.Context, req *CreateUserRequest) (*domain.User, string, error) {
+	password, err := domain.GenerateTemporaryPassword()
+	if err != nil {
+		return nil, "", err
+	}
+
+	user, err := domain.NewUser(req.Email, password, req.FirstName, req.LastName, req.Role)
+	if err != nil {
+		return nil, "", err
+	}
+	user.MustChangePassword = true
+	user.Phone = req.Phone
+	user.TelegramUsername = req.TelegramUsername
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, domain.ErrEmailExists) {
+			return nil, "", err
+		}
+		return nil, "", fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, password, nil
+}
+
+func (s *UserService) failImportRow(result *UserImportResult, row *UserImportRow, err error) {
+	row.Status = ImportRowFailed
+	row.Error = err.Error()
+	result.Failed++
+}
+
+// parseImportHeader maps column names to their index and checks that the
+// required columns are present
+func parseImportHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // Spreadsheet exports may start with a BOM
+		}
+		if !userImportColumns[name] {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidCSV, name)
+		}
+		if _, duplicate := columns[name]; duplicate {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrInvalidCSV, name)
+		}
+		columns[name] = i
+	}
+
+	for _, required := range []string{"email", "first_name", "last_name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing required column %q", ErrInvalidCSV, required)
+		}
+	}
+
+	return columns, nil
+}
+
+// importRowToRequest converts a CSV record to a validated create request. The
+// email is always set on the returned request so it can be reported.
+func importRowToRequest(columns map[string]int, record []string) (*CreateUserRequest, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	req := &CreateUserRequest{
+		Email:            strings.ToLower(field("email")),
+		FirstName:        field("first_name"),
+		LastName:         field("last_name"),
+		Role:             domain.RoleCustomer,
+		Phone:            field("phone"),
+		TelegramUsername: field("telegram_username"),
+	}
+
+	if role := strings.ToLower(field("role")); role != "" {
+		if !domain.IsValidRole(role) {
+			return req, fmt.Errorf("%w: %q", domain.ErrInvalidRole, role)
+		}
+		req.Role = domain.UserRole(role)
+	}
+
+	if err := domain.ValidateUserDetails(req.Email, req.FirstName, req.LastName, req.Role); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
+
+// userToCSVRecord converts a user to an export row
+func userToCSVRecord(user *domain.User) []string {
+	lastLogin := ""
+	if user.LastLoginAt != nil {
+		lastLogin = user.LastLoginAt.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		user.ID,
+		user.Email,
+		user.FirstName,
+		user.LastName,
+		string(user.Role),
+		string(user.Status),
+		user.Phone,
+		user.TelegramUsername,
+		user.CreatedAt.UTC().Format(time.RFC3339),
+		lastLogin,
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// UserLifecycleEventPublisher publishes the user lifecycle events relayed from the outbox
+type UserLifecycleEventPublisher interface {
+	PublishLifecycleEvent(ctx context.Context, event *domain.UserLifecycleEvent) error
+}
+
+// UserEventRelayStats are the cumulative counters reported on /metrics
+type UserEventRelayStats struct {
+	Runs            int64     `json:"runs"`
+	EventsPublished int64     `json:"events_published"`
+	Failures        int64     `json:"failures"`
+	Pending         int       `json:"pending"` // Unpublished events after the last run
+	LastRunAt       time.Time `json:"last_run_at"`
+}
+
+// UserEventRelay publishes the user lifecycle events of the outbox to Kafka.
+// Events are recorded in the transaction of the change, so none is lost when
+// Kafka is unavailable: they wait in the outbox and are published in order
+// once it is back. A consumer may see an event twice, with the same ID.
+type UserEventRelay struct {
+	outbox    interfaces.UserEventOutboxRepository
+	publisher UserLifecycleEventPublisher
+	interval  time.Duration
+	batchSize int
+
+	mu    sync.Mutex
+	stats UserEventRelayStats
+}
+
+// NewUserEventRelay creates a new user event relay
+func NewUserEventRelay(
+	outbox interfaces.UserEventOutboxRepository,
+	publisher UserLifecycleEventPublisher,
+	interval time.Duration,
+	batchSize int,
+) *UserEventRelay {
+	return &UserEventRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run relays pending events every interval until the context is cancelled. A
+// full batch is followed by the next one right away.
+func (r *UserEventRelay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		published, err := r.RelayOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("User event relay failed after %d events: %v", published, err)
+		}
+		if err == nil && published == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes up to one batch of pending events, returning how many were published
+func (r *UserEventRelay) RelayOnce(ctx context.Context) (int, error) {
+	start := time.Now()
+	published, err := r.outbox.Relay(ctx, r.batchSize, r.publisher.PublishLifecycleEvent)
+
+	pending, pendingErr := r.outbox.Pending(ctx)
+	if pendingErr != nil && ctx.Err() == nil {
+		log.Printf("Failed to count pending user events: %v", pendingErr)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.Runs++
+	r.stats.EventsPublished += int64(published)
+	if err != nil {
+		r.stats.Failures++
+	}
+	if pendingErr == nil {
+		r.stats.Pending = pending
+	}
+	r.stats.LastRunAt = start
+
+	return published, err
+}
+
+// Stats returns the cumulative relay counters
+func (r *UserEventRelay) Stats() UserEventRelayStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
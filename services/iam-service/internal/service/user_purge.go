@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
+)
+
+// userPurgeReason is recorded in the audit trail of every scheduled purge
+const userPurgeReason = "retention period elapsed"
+
+// UserPurgeResult summarizes one purge run
+type UserPurgeResult struct {
+	Candidates      int
+	Purged          int
+	Skipped         int // Restored or purged elsewhere since they were selected
+	Failed          int
+	SessionsRemoved int
+}
+
+// UserPurgeStats are the cumulative counters reported on /metrics
+type UserPurgeStats struct {
+	Runs            int64     `json:"runs"`
+	UsersPurged     int64     `json:"users_purged"`
+	Failures        int64     `json:"failures"`
+	SessionsRemoved int64     `json:"sessions_removed"`
+	LastRunAt       time.Time `json:"last_run_at"`
+	LastRunDuration float64   `json:"last_run_duration_seconds"`
+}
+
+// UserPurgeJob hard deletes users that have been soft deleted for longer than
+// the retention period, along with their sessions
+type UserPurgeJob struct {
+	userRepo    interfaces.UserRepository
+	sessionRepo interfaces.SessionRepository
+	retention   time.Duration
+	interval    time.Duration
+	batchSize   int
+
+	mu    sync.Mutex
+	stats UserPurgeStats
+}
+
+// NewUserPurgeJob creates a new purge job
+func NewUserPurgeJob(
+	userRepo interfaces.UserRepository,
+	sessionRepo interfaces.SessionRepository,
+	retention time.Duration,
+	interval time.Duration,
+	batchSize int,
+) *UserPurgeJob {
+	return &UserPurgeJob{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		retention:   retention,
+		interval:    interval,
+		batchSize:   batchSize,
+	}
+}
+
+// Run purges expired users every interval until the context is cancelled
+func (j *UserPurgeJob) Run(ctx context.Context) error {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := j.PurgeOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("User purge run failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeOnce purges up to one batch of users whose retention period elapsed
+func (j *UserPurgeJob) PurgeOnce(ctx context.Context) (*UserPurgeResult, error) {
+	start := time.Now()
+	deletedBefore := start.Add(-j.retention)
+
+	users, err := j.userRepo.GetUsersForCleanup(ctx, interfaces.CleanupCriteria{
+		DeletedBefore:    deletedBefore,
+		IncludeTestUsers: true,
+		Limit:            j.batchSize,
+	})
+	if err != nil {
+		j.recordRun(start, &UserPurgeResult{Failed: 1})
+		return nil, fmt.Errorf("failed to find users to purge: %w", err)
+	}
+
+	result := &UserPurgeResult{Candidates: len(users)}
+	for _, user := range users {
+		if ctx.Err() != nil {
+			break
+		}
+
+		sessionsRemoved, err := j.purgeUser(ctx, user)
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			result.Skipped++
+		case err != nil:
+			result.Failed++
+			log.Printf("Failed to purge deleted user %s: %v", user.ID, err)
+		default:
+			result.Purged++
+			result.SessionsRemoved += sessionsRemoved
+		}
+	}
+
+	j.recordRun(start, result)
+	if result.Candidates > 0 {
+		log.Printf("User purge finished: %d purged, %d skipped, %d failed, %d sessions removed (deleted before %s)",
+			result.Purged, result.Skipped, result.Failed, result.SessionsRemoved, deletedBefore.Format(time.RFC3339))
+	}
+
+	return result, nil
+}
+
+// Stats returns the cumulative purge counters
+func (j *UserPurgeJob) Stats() UserPurgeStats {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.stats
+}
+
+// purgeUser removes a user's sessions and then the user itself
+func (j *UserPurgeJob) purgeUser(ctx context.Context, user *domain.User) (int, error) {
+	sessions, err := j.sessionRepo.GetUserSessions(ctx, user.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+	if len(sessions) > 0 {
+		sessionIDs := make([]string, len(sessions))
+		for i, session := range sessions {
+			sessionIDs[i] = session.ID
+		}
+		if err := j.sessionRepo.DeleteBatch(ctx, sessionIDs); err != nil {
+			return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+		}
+	}
+
+	emailHash := sha256.Sum256([]byte(strings.ToLower(user.Email)))
+	err = j.userRepo.PurgeDeletedUser(ctx, &interfaces.UserPurgeRecord{
+		UserID:          user.ID,
+		EmailHash:       hex.EncodeToString(emailHash[:]),
+		Role:            user.Role,
+		DeletedAt:       user.UpdatedAt,
+		SessionsRemoved: len(sessions),
+		Reason:          userPurgeReason,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(sessions), nil
+}
+
+func (j *UserPurgeJob) recordRun(start time.Time, result *UserPurgeResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.stats.Runs++
+	j.stats.UsersPurged += int64(result.Purged)
+	j.stats.Failures += int64(result.Failed)
+	j.stats.SessionsRemoved += int64(result.SessionsRemoved)
+	j.stats.LastRunAt = start
+	j.stats.LastRunDuration = time.Since(start).Seconds()
+}
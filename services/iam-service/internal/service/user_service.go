@@ -13,9 +13,22 @@ import (
 
 // UserService implements user management business logic
 type UserService struct {
-	userRepo    interfaces.UserRepository
-	sessionRepo interfaces.SessionRepository
-	config      *config.Config
+	userRepo         interfaces.UserRepository
+	sessionRepo      interfaces.SessionRepository
+	config           *config.Config
+	deletionNotifier DeletionNotifier
+	permissionCache  interfaces.PermissionCacheRepository
+	changeNotifier   UserChangeNotifier
+}
+
+// UserServiceOption configures optional UserService dependencies
+type UserServiceOption func(*UserService)
+
+// WithDeletionNotifier tells users about their account deletion requests
+func WithDeletionNotifier(notifier DeletionNotifier) UserServiceOption {
+	return func(s *UserService) {
+		s.deletionNotifier = notifier
+	}
 }
 
 // NewUserService creates a new user service
@@ -23,12 +36,18 @@ func NewUserService(
 	userRepo interfaces.UserRepository,
 	sessionRepo interfaces.SessionRepository,
 	config *config.Config,
+	opts ...UserServiceOption,
 ) *UserService {
-	return &UserService{
-		userRepo:    userRepo,
-		sessionRepo: sessionRepo,
-		config:      config,
+	s := &UserService{
+		userRepo:         userRepo,
+		sessionRepo:      sessionRepo,
+		config:           config,
+		deletionNotifier: noopDeletionNotifier{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // CreateUserRequest represents a request to create a new user
@@ -178,25 +197,31 @@ func (s *UserService) UpdateUser(ctx context.Context, userID string, req *Update
 	}
 
 	// Update role (requires admin privileges - should be checked by caller)
+	roleChanged := false
 	if req.Role != nil && *req.Role != user.Role {
 		if err := s.validateRoleChange(user.Role, *req.Role); err != nil {
 			return nil, err
 		}
 		user.Role = *req.Role
 		updated = true
+		roleChanged = true
 	}
 
 	// Update status (requires admin privileges - should be checked by caller)
+	statusChanged := false
 	if req.Status != nil && *req.Status != user.Status {
 		if err := s.validateStatusChange(user.Status, *req.Status); err != nil {
 			return nil, err
 		}
 		user.Status = *req.Status
+		user.DeletionScheduledAt = nil
 		updated = true
 
 		// If user is being deactivated, revoke all sessions
 		if *req.Status != domain.StatusActive {
 			s.sessionRepo.RevokeUserSessions(ctx, userID)
+		} else {
+			statusChanged = true
 		}
 	}
 
@@ -206,10 +231,50 @@ func (s *UserService) UpdateUser(ctx context.Context, userID string, req *Update
 			return nil, fmt.Errorf("failed to update user: %w", err)
 		}
 	}
+	if roleChanged {
+		s.invalidatePermissions(ctx, userID)
+	}
+	if roleChanged || statusChanged {
+		s.restampSessions(ctx, userID)
+	}
+	if updated {
+		s.notifyUserChanged(ctx, userID, UserChangeProfile)
+	}
 
 	return s.userToInfo(user), nil
 }
 
+// ResetUserPassword replaces a user's password with a generated temporary one
+// (admin operation). The user's sessions are revoked and the next login
+// returns a restricted session until the password is changed.
+func (s *UserService) ResetUserPassword(ctx context.Context, userID string) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("user ID cannot be empty")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	password, err := domain.GenerateTemporaryPassword()
+	if err != nil {
+		return "", err
+	}
+
+	if err := user.SetTemporaryPassword(password); err != nil {
+		return "", err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return "", fmt.Errorf("failed to reset password: %w", err)
+	}
+
+	s.sessionRepo.RevokeUserSessions(ctx, userID)
+
+	return password, nil
+}
+
 // UpdateUserProfile updates user profile information (for self-service)
 func (s *UserService) UpdateUserProfile(ctx context.Context, userID string, updates interfaces.ProfileUpdate) (*UserInfo, error) {
 	if userID == "" {
@@ -220,11 +285,73 @@ func (s *UserService) UpdateUserProfile(ctx context.Context, userID string, upda
 	if err := s.userRepo.UpdateProfile(ctx, userID, updates); err != nil {
 		return nil, fmt.Errorf("failed to update profile: %w", err)
 	}
+	s.notifyUserChanged(ctx, userID, UserChangeProfile)
 
 	// Return updated user
 	return s.GetUser(ctx, userID)
 }
 
+// PreferencesUpdate changes some of a user's preferences. Nil fields are kept.
+type PreferencesUpdate struct {
+	Locale         *string                         `json:"locale,omitempty"`
+	Timezone       *string                         `json:"timezone,omitempty"`
+	MarketingOptIn *bool                           `json:"marketing_opt_in,omitempty"`
+	Notifications  *domain.NotificationPreferences `json:"notifications,omitempty"`
+}
+
+// GetPreferences returns the preferences of a user
+func (s *UserService) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.Preferences, nil
+}
+
+// UpdatePreferences validates and applies a preferences update. The user is saved
+// whole, so preferences still held in its metadata move out of it in the same write.
+func (s *UserService) UpdatePreferences(ctx context.Context, userID string, update PreferencesUpdate) (*domain.Preferences, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := user.Preferences
+	if update.Locale != nil {
+		preferences.Locale = strings.TrimSpace(*update.Locale)
+	}
+	if update.Timezone != nil {
+		preferences.Timezone = strings.TrimSpace(*update.Timezone)
+	}
+	if update.MarketingOptIn != nil {
+		preferences.MarketingOptIn = *update.MarketingOptIn
+	}
+	if update.Notifications != nil {
+		preferences.Notifications = *update.Notifications
+	}
+	if err := preferences.Validate(); err != nil {
+		return nil, err
+	}
+
+	user.Preferences = preferences
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update preferences: %w", err)
+	}
+	s.notifyUserChanged(ctx, userID, UserChangePreferences)
+
+	return &user.Preferences, nil
+}
+
 // DeleteUser soft deletes a user by setting status to deleted
 func (s *UserService) DeleteUser(ctx context.Context, userID string) error {
 	if userID == "" {
@@ -254,6 +381,7 @@ func (s *UserService) DeleteUser(ctx context.Context, userID string) error {
 
 	// Revoke all user sessions
 	s.sessionRepo.RevokeUserSessions(ctx, userID)
+	s.notifyUserChanged(ctx, userID, UserChangeDeleted)
 
 	return nil
 }
@@ -284,6 +412,7 @@ func (s *UserService) HardDeleteUser(ctx context.Context, userID string) error {
 	if err := s.userRepo.Delete(ctx, userID); err != nil {
 		return fmt.Errorf("failed to hard delete user: %w", err)
 	}
+	s.notifyUserChanged(ctx, userID, UserChangeDeleted)
 
 	return nil
 }
@@ -387,6 +516,7 @@ func (s *UserService) LockUser(ctx context.Context, userID string, duration time
 
 	// Revoke all user sessions
 	s.sessionRepo.RevokeUserSessions(ctx, userID)
+	s.notifyUserChanged(ctx, userID, UserChangeLock)
 
 	return nil
 }
@@ -400,6 +530,7 @@ func (s *UserService) UnlockUser(ctx context.Context, userID string) error {
 	if err := s.userRepo.UnlockAccount(ctx, userID); err != nil {
 		return fmt.Errorf("failed to unlock user account: %w", err)
 	}
+	s.notifyUserChanged(ctx, userID, UserChangeLock)
 
 	return nil
 }
@@ -440,6 +571,9 @@ func (s *UserService) UpdateUserRole(ctx context.Context, userID string, newRole
 	if err := s.userRepo.UpdateRole(ctx, userID, newRole); err != nil {
 		return fmt.Errorf("failed to update user role: %w", err)
 	}
+	s.invalidatePermissions(ctx, userID)
+	s.restampSessions(ctx, userID)
+	s.notifyUserChanged(ctx, userID, UserChangeRole)
 
 	return nil
 }
@@ -469,7 +603,10 @@ func (s *UserService) UpdateUserStatus(ctx context.Context, userID string, newSt
 	// If user is being deactivated, revoke all sessions
 	if newStatus != domain.StatusActive {
 		s.sessionRepo.RevokeUserSessions(ctx, userID)
+	} else {
+		s.restampSessions(ctx, userID)
 	}
+	s.notifyUserChanged(ctx, userID, UserChangeStatus)
 
 	return nil
 }
@@ -483,6 +620,7 @@ func (s *UserService) UpdateTelegramInfo(ctx context.Context, userID, chatID, us
 	if err := s.userRepo.UpdateTelegramInfo(ctx, userID, chatID, username); err != nil {
 		return fmt.Errorf("failed to update Telegram info: %w", err)
 	}
+	s.notifyUserChanged(ctx, userID, UserChangeTelegram)
 
 	return nil
 }
@@ -632,6 +770,11 @@ func (s *UserService) validateStatusChange(currentStatus, newStatus domain.UserS
 		return fmt.Errorf("cannot reactivate deleted user")
 	}
 
+	// A pending deletion needs a schedule, which only RequestAccountDeletion sets
+	if newStatus == domain.StatusPendingDeletion {
+		return fmt.Errorf("account deletion can only be requested by the account owner")
+	}
+
 	return nil
 }
 
@@ -665,7 +808,11 @@ func (s *UserService) userToInfo(user *domain.User) *UserInfo {
 		LastName:  user.LastName,
 		Role:      string(user.Role),
 		Status:    string(user.Status),
+		Locale:    user.Preferences.Locale,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
+
+		MustChangePassword:  user.MustChangePassword,
+		DeletionScheduledAt: user.DeletionScheduledAt,
 	}
 }
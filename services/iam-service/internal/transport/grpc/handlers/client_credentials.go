@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/service"
+	pb "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+)
+
+// Client Credentials Methods
+
+// IssueClientToken exchanges a service client's ID and secret for a short-lived access token
+func (h *IAMHandler) IssueClientToken(ctx context.Context, req *pb.IssueClientTokenRequest) (*pb.IssueClientTokenResponse, error) {
+	if req.ClientId == "" || req.ClientSecret == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_id and client_secret are required")
+	}
+
+	ip := clientIP(ctx)
+	if err := h.guard.Check(ctx, service.EndpointClientToken, ip, ""); err != nil {
+		return nil, throttledError(ctx, err)
+	}
+
+	token, err := h.clientService.IssueToken(ctx, req.ClientId, req.ClientSecret, req.Scopes)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidClientCredential):
+			h.guard.RecordFailure(ctx, service.EndpointClientToken, ip, "")
+			return nil, status.Error(codes.Unauthenticated, "invalid client credentials")
+		case errors.Is(err, domain.ErrServiceClientDisabled):
+			return nil, status.Error(codes.PermissionDenied, "service client is disabled")
+		case errors.Is(err, domain.ErrInvalidScope), errors.Is(err, domain.ErrScopeNotGranted):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.Printf("Client token issuance failed for %s: %v", req.ClientId, err)
+		return nil, status.Error(codes.Internal, "failed to issue client token")
+	}
+
+	return &pb.IssueClientTokenResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresAt:   timestamppb.New(token.ExpiresAt),
+		Scopes:      token.Scopes,
+	}, nil
+}
+
+// ValidateClientToken validates a service client's access token
+func (h *IAMHandler) ValidateClientToken(ctx context.Context, req *pb.ValidateClientTokenRequest) (*pb.ValidateClientTokenResponse, error) {
+	if req.AccessToken == "" {
+		return &pb.ValidateClientTokenResponse{Valid: false}, nil
+	}
+
+	ip := clientIP(ctx)
+	if err := h.guard.Check(ctx, service.EndpointValidateSession, ip, ""); err != nil {
+		return nil, throttledError(ctx, err)
+	}
+
+	result, err := h.clientService.ValidateToken(ctx, req.AccessToken)
+	if err != nil {
+		h.guard.RecordFailure(ctx, service.EndpointValidateSession, ip, "")
+		return &pb.ValidateClientTokenResponse{Valid: false}, nil
+	}
+
+	return &pb.ValidateClientTokenResponse{
+		Valid:      true,
+		ClientId:   result.Client.ClientID,
+		ClientName: result.Client.Name,
+		Scopes:     result.Claims.Scopes,
+		ExpiresAt:  timestamppb.New(result.Claims.ExpiresAt.Time),
+	}, nil
+}
+
+// RegisterServiceClient registers a service client; its secret is only returned here
+func (h *IAMHandler) RegisterServiceClient(ctx context.Context, req *pb.RegisterServiceClientRequest) (*pb.RegisterServiceClientResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	adminID, _ := ctxmeta.UserID(ctx)
+	client, secret, err := h.clientService.RegisterClient(ctx, req.Name, req.Scopes, adminID)
+	if err != nil {
+		if errors.Is(err, domain.ErrServiceClientExists) {
+			return nil, status.Error(codes.AlreadyExists, "service client already exists")
+		}
+		if errors.Is(err, domain.ErrInvalidScope) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.Printf("Service client registration failed: %v", err)
+		return nil, status.Error(codes.Internal, "failed to register service client")
+	}
+
+	return &pb.RegisterServiceClientResponse{
+		Client:       h.convertServiceClientToProto(client),
+		ClientSecret: secret,
+	}, nil
+}
+
+// ListServiceClients lists the registered service clients
+func (h *IAMHandler) ListServiceClients(ctx context.Context, req *pb.ListServiceClientsRequest) (*pb.ListServiceClientsResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	clients, err := h.clientService.ListClients(ctx)
+	if err != nil {
+		log.Printf("Failed to list service clients: %v", err)
+		return nil, status.Error(codes.Internal, "failed to list service clients")
+	}
+
+	response := &pb.ListServiceClientsResponse{Clients: make([]*pb.ServiceClient, 0, len(clients))}
+	for _, client := range clients {
+		response.Clients = append(response.Clients, h.convertServiceClientToProto(client))
+	}
+	return response, nil
+}
+
+// RotateServiceClientSecret issues a new secret for a service client
+func (h *IAMHandler) RotateServiceClientSecret(ctx context.Context, req *pb.RotateServiceClientSecretRequest) (*pb.RotateServiceClientSecretResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.ClientId == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_id is required")
+	}
+
+	client, secret, err := h.clientService.RotateSecret(ctx, req.ClientId)
+	if err != nil {
+		return nil, serviceClientError(req.ClientId, "rotate secret of", err)
+	}
+
+	return &pb.RotateServiceClientSecretResponse{
+		Client:       h.convertServiceClientToProto(client),
+		ClientSecret: secret,
+	}, nil
+}
+
+// DisableServiceClient stops a service client from obtaining or using tokens
+func (h *IAMHandler) DisableServiceClient(ctx context.Context, req *pb.DisableServiceClientRequest) (*pb.DisableServiceClientResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.ClientId == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_id is required")
+	}
+
+	client, err := h.clientService.DisableClient(ctx, req.ClientId)
+	if err != nil {
+		return nil, serviceClientError(req.ClientId, "disable", err)
+	}
+
+	return &pb.DisableServiceClientResponse{
+		Client: h.convertServiceClientToProto(client),
+	}, nil
+}
+
+// serviceClientError maps a failed admin operation on a service client to a gRPC status
+func serviceClientError(clientID, operation string, err error) error {
+	if errors.Is(err, domain.ErrServiceClientNotFound) {
+		return status.Error(codes.NotFound, "service client not found")
+	}
+	log.Printf("Failed to %s service client %s: %v", operation, clientID, err)
+	return status.Errorf(codes.Internal, "failed to %s service client", operation)
+}
+
+func (h *IAMHandler) convertServiceClientToProto(client *domain.ServiceClient) *pb.ServiceClient {
+	proto := &pb.ServiceClient{
+		ClientId:        client.ClientID,
+		Name:            client.Name,
+		Scopes:          client.Scopes,
+		Status:          string(client.Status),
+		CreatedBy:       client.CreatedBy,
+		CreatedAt:       timestamppb.New(client.CreatedAt),
+		SecretRotatedAt: timestamppb.New(client.SecretRotatedAt),
+	}
+	if client.LastUsedAt != nil {
+		proto.LastUsedAt = timestamppb.New(*client.LastUsedAt)
+	}
+	return proto
+}
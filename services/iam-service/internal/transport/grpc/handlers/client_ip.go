@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// clientIP returns the address of the end client. Gateways and services
+// calling on behalf of a user forward it in x-forwarded-for or x-real-ip;
+// otherwise the peer address of the connection is used.
+func clientIP(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			if ip := strings.TrimSpace(strings.Split(values[0], ",")[0]); ip != "" {
+				return ip
+			}
+		}
+		if values := md.Get("x-real-ip"); len(values) > 0 && values[0] != "" {
+			return strings.TrimSpace(values[0])
+		}
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// clientUserAgent returns the user agent of the end client as forwarded by
+// the HTTP gateway, or the one of the calling client otherwise
+func clientUserAgent(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+			if values := md.Get(key); len(values) > 0 && values[0] != "" {
+				return values[0]
+			}
+		}
+	}
+	return ""
+}
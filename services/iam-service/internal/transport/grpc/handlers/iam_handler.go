@@ -2,31 +2,52 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"strconv"
 	"strings"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/repository/interfaces"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/service"
-	pb "github.com/amiosamu/rocket-science/services/iam-service/proto/iam"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/transport/grpc/sessioncookie"
+	pb "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/pagination"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcerrors"
+	"github.com/amiosamu/rocket-science/shared/platform/version"
 )
 
 // IAMHandler implements the gRPC IAMService
 type IAMHandler struct {
 	pb.UnimplementedIAMServiceServer
-	authService *service.AuthService
-	userService *service.UserService
+	authService   *service.AuthService
+	userService   *service.UserService
+	clientService *service.ClientCredentialsService
+	guard         *service.BruteForceGuard
+	challenge     *service.LoginChallenge
+	sessionStore  interfaces.SessionStorePromoter // nil without a session store replica
+	cookies       *sessioncookie.Jar
 }
 
 // NewIAMHandler creates a new IAM gRPC handler
-func NewIAMHandler(authService *service.AuthService, userService *service.UserService) *IAMHandler {
+func NewIAMHandler(authService *service.AuthService, userService *service.UserService, clientService *service.ClientCredentialsService, guard *service.BruteForceGuard, challenge *service.LoginChallenge, sessionStore interfaces.SessionStorePromoter, cookies *sessioncookie.Jar) *IAMHandler {
 	return &IAMHandler{
-		authService: authService,
-		userService: userService,
+		authService:   authService,
+		userService:   userService,
+		clientService: clientService,
+		guard:         guard,
+		challenge:     challenge,
+		sessionStore:  sessionStore,
+		cookies:       cookies,
 	}
 }
 
@@ -34,25 +55,127 @@ func NewIAMHandler(authService *service.AuthService, userService *service.UserSe
 
 // Login authenticates a user and creates a session
 func (h *IAMHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	log.Printf("Login attempt for email: %s", req.Email)
-
 	if req.Email == "" || req.Password == "" {
 		return nil, status.Error(codes.InvalidArgument, "email and password are required")
 	}
 
+	ip := req.IpAddress
+	if ip == "" {
+		ip = clientIP(ctx)
+	}
+	if err := h.challenge.Check(ctx, ip, req.CaptchaToken); err != nil {
+		if errors.Is(err, service.ErrChallengeUnavailable) {
+			return nil, status.Error(codes.Unavailable, "captcha verification unavailable, retry later")
+		}
+		return &pb.LoginResponse{
+			Success:         false,
+			Message:         err.Error(),
+			CaptchaRequired: true,
+			CaptchaProvider: h.challenge.Provider(),
+			CaptchaSiteKey:  h.challenge.SiteKey(),
+		}, nil
+	}
+
 	loginResp, err := h.authService.Login(ctx, req.Email, req.Password, req.IpAddress, req.UserAgent)
 	if err != nil {
-		log.Printf("Login failed for %s: %v", req.Email, err)
 		if strings.Contains(err.Error(), "invalid credentials") {
+			h.challenge.RecordFailure(ctx, ip)
 			return nil, status.Error(codes.Unauthenticated, "invalid email or password")
 		}
 		if strings.Contains(err.Error(), "account locked") {
 			return nil, status.Error(codes.PermissionDenied, "account is locked")
 		}
+		log.Printf("Login failed: %v", err)
+		return nil, status.Error(codes.Internal, "login failed")
+	}
+
+	response := &pb.LoginResponse{
+		Success:      true,
+		Message:      "Login successful",
+		AccessToken:  loginResp.AccessToken,
+		RefreshToken: loginResp.RefreshToken,
+		SessionId:    loginResp.SessionID,
+		User:         h.convertUserInfoToProto(loginResp.User),
+		ExpiresAt:    timestamppb.New(loginResp.ExpiresAt),
+
+		PasswordChangeRequired: loginResp.PasswordChangeRequired,
+	}
+	if loginResp.User != nil && loginResp.User.DeletionScheduledAt != nil {
+		response.DeletionScheduledAt = timestamppb.New(*loginResp.User.DeletionScheduledAt)
+	}
+
+	if req.TokenDelivery == pb.TokenDelivery_TOKEN_DELIVERY_COOKIE {
+		if err := h.setSessionCookies(ctx, loginResp); err != nil {
+			return nil, err
+		}
+		response.AccessToken, response.RefreshToken = "", ""
+	}
+	return response, nil
+}
+
+// RequestLoginLink sends a password-less login link. Emails without an
+// account get the same answer, so the response never reveals which exist.
+func (h *IAMHandler) RequestLoginLink(ctx context.Context, req *pb.RequestLoginLinkRequest) (*pb.RequestLoginLinkResponse, error) {
+	if req.Email == "" {
+		return nil, grpcerrors.FieldError("email", "email is required")
+	}
+
+	ip, userAgent := req.IpAddress, req.UserAgent
+	if ip == "" {
+		ip = clientIP(ctx)
+	}
+	if userAgent == "" {
+		userAgent = clientUserAgent(ctx)
+	}
+
+	if err := h.authService.RequestLoginLink(ctx, req.Email, ip, userAgent); err != nil {
+		switch {
+		case errors.Is(err, service.ErrLoginLinksDisabled):
+			return nil, status.Error(codes.FailedPrecondition, "login links are not enabled")
+		case errors.Is(err, domain.ErrLoginLinkRateLimited):
+			return nil, grpcerrors.New(codes.ResourceExhausted, grpcerrors.ReasonRateLimited, "too many login link requests, retry later")
+		}
+		log.Printf("Login link request failed: %v", err)
+		return nil, status.Error(codes.Internal, "failed to send login link")
+	}
+
+	return &pb.RequestLoginLinkResponse{
+		Success: true,
+		Message: "If the email belongs to an account, a login link is on its way",
+	}, nil
+}
+
+// CompleteLoginWithLink exchanges a login link token for a session
+func (h *IAMHandler) CompleteLoginWithLink(ctx context.Context, req *pb.CompleteLoginWithLinkRequest) (*pb.CompleteLoginWithLinkResponse, error) {
+	if req.Token == "" {
+		return nil, grpcerrors.FieldError("token", "token is required")
+	}
+
+	ip, userAgent := req.IpAddress, req.UserAgent
+	if ip == "" {
+		ip = clientIP(ctx)
+	}
+	if userAgent == "" {
+		userAgent = clientUserAgent(ctx)
+	}
+
+	loginResp, err := h.authService.CompleteLoginWithLink(ctx, req.Token, ip, userAgent)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrLoginLinksDisabled):
+			return nil, status.Error(codes.FailedPrecondition, "login links are not enabled")
+		case errors.Is(err, domain.ErrInvalidLoginLink):
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired login link")
+		case errors.Is(err, domain.ErrAccountLocked):
+			return nil, status.Error(codes.PermissionDenied, "account is locked")
+		case errors.Is(err, domain.ErrAccountInactive):
+			return nil, status.Error(codes.PermissionDenied, "account is not active")
+		}
+		log.Printf("Login with link failed: %v", err)
 		return nil, status.Error(codes.Internal, "login failed")
 	}
 
-	return &pb.LoginResponse{
+	response := &pb.CompleteLoginWithLinkResponse{
 		Success:      true,
 		Message:      "Login successful",
 		AccessToken:  loginResp.AccessToken,
@@ -60,20 +183,69 @@ func (h *IAMHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Login
 		SessionId:    loginResp.SessionID,
 		User:         h.convertUserInfoToProto(loginResp.User),
 		ExpiresAt:    timestamppb.New(loginResp.ExpiresAt),
+
+		PasswordChangeRequired: loginResp.PasswordChangeRequired,
+	}
+	if loginResp.User != nil && loginResp.User.DeletionScheduledAt != nil {
+		response.DeletionScheduledAt = timestamppb.New(*loginResp.User.DeletionScheduledAt)
+	}
+
+	if req.TokenDelivery == pb.TokenDelivery_TOKEN_DELIVERY_COOKIE {
+		if err := h.setSessionCookies(ctx, loginResp); err != nil {
+			return nil, err
+		}
+		response.AccessToken, response.RefreshToken = "", ""
+	}
+	return response, nil
+}
+
+// LockAccountWithToken locks an account from the link sent with a failed login
+// alert, so its owner can shut out whoever is guessing the password
+func (h *IAMHandler) LockAccountWithToken(ctx context.Context, req *pb.LockAccountWithTokenRequest) (*pb.LockAccountWithTokenResponse, error) {
+	if req.Token == "" {
+		return nil, grpcerrors.FieldError("token", "token is required")
+	}
+
+	lockedUntil, err := h.authService.LockAccountWithToken(ctx, req.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrLoginAlertsDisabled):
+			return nil, status.Error(codes.FailedPrecondition, "failed login alerts are not enabled")
+		case errors.Is(err, domain.ErrInvalidLockLink):
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired account lock link")
+		}
+		log.Printf("Account lock with token failed: %v", err)
+		return nil, status.Error(codes.Internal, "failed to lock account")
+	}
+
+	return &pb.LockAccountWithTokenResponse{
+		Success:     true,
+		Message:     "Account locked and all sessions signed out",
+		LockedUntil: timestamppb.New(lockedUntil),
 	}, nil
 }
 
 // Logout invalidates a user session
 func (h *IAMHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
-	if req.SessionId == "" {
-		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	sessionID := req.SessionId
+	if sessionID == "" {
+		sessionID, _ = h.cookies.Session(ctx)
+	}
+	if sessionID == "" {
+		return nil, grpcerrors.FieldError("session_id", "session_id is required")
 	}
 
-	err := h.authService.Logout(ctx, req.SessionId)
+	err := h.authService.Logout(ctx, sessionID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "logout failed")
 	}
 
+	if h.cookies.HasSession(ctx) {
+		if err := h.cookies.Clear(ctx); err != nil {
+			return nil, status.Error(codes.Internal, "failed to clear session cookies")
+		}
+	}
+
 	return &pb.LogoutResponse{
 		Success: true,
 		Message: "Logout successful",
@@ -82,23 +254,93 @@ func (h *IAMHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.Log
 
 // RefreshToken refreshes an access token
 func (h *IAMHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
-	if req.RefreshToken == "" || req.SessionId == "" {
+	sessionID, refreshToken := req.SessionId, req.RefreshToken
+	fromCookies := sessionID == "" && refreshToken == ""
+	if fromCookies {
+		sessionID, refreshToken = h.cookies.Session(ctx)
+	}
+	if refreshToken == "" || sessionID == "" {
 		return nil, status.Error(codes.InvalidArgument, "refresh_token and session_id are required")
 	}
 
-	refreshResp, err := h.authService.RefreshToken(ctx, req.SessionId, req.RefreshToken)
+	refreshResp, err := h.refreshSession(ctx, sessionID, refreshToken)
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		return nil, err
 	}
 
-	return &pb.RefreshTokenResponse{
+	response := &pb.RefreshTokenResponse{
 		Success:     true,
 		Message:     "Token refreshed successfully",
 		AccessToken: refreshResp.AccessToken,
 		ExpiresAt:   timestamppb.New(refreshResp.ExpiresAt),
+	}
+
+	// Tokens kept in HttpOnly cookies are never handed to the page
+	if fromCookies || req.TokenDelivery == pb.TokenDelivery_TOKEN_DELIVERY_COOKIE {
+		if err := h.setSessionCookies(ctx, refreshResp); err != nil {
+			return nil, err
+		}
+		response.AccessToken = ""
+	}
+	return response, nil
+}
+
+// ExchangeSessionCookies moves a session whose tokens the client holds into session
+// cookies, e.g. after a native login flow hands over to a web view
+func (h *IAMHandler) ExchangeSessionCookies(ctx context.Context, req *pb.ExchangeSessionCookiesRequest) (*pb.ExchangeSessionCookiesResponse, error) {
+	if req.RefreshToken == "" || req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token and session_id are required")
+	}
+
+	// Refreshing proves possession of the session and issues a fresh access token, so
+	// the one the client held before stays out of the cookie
+	refreshResp, err := h.refreshSession(ctx, req.SessionId, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.setSessionCookies(ctx, refreshResp); err != nil {
+		return nil, err
+	}
+
+	return &pb.ExchangeSessionCookiesResponse{
+		Success:   true,
+		Message:   "Session cookies set",
+		SessionId: refreshResp.SessionID,
+		User:      h.convertUserInfoToProto(refreshResp.User),
+		ExpiresAt: timestamppb.New(refreshResp.ExpiresAt),
 	}, nil
 }
 
+// refreshSession refreshes a session's access token behind the brute-force guard
+func (h *IAMHandler) refreshSession(ctx context.Context, sessionID, refreshToken string) (*service.LoginResult, error) {
+	ip := clientIP(ctx)
+	if err := h.guard.Check(ctx, service.EndpointRefreshToken, ip, sessionID); err != nil {
+		return nil, throttledError(ctx, err)
+	}
+
+	refreshResp, err := h.authService.RefreshToken(ctx, sessionID, refreshToken)
+	if err != nil {
+		h.guard.RecordFailure(ctx, service.EndpointRefreshToken, ip, sessionID)
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	}
+	return refreshResp, nil
+}
+
+// setSessionCookies sends the session tokens as cookies instead of in the response
+func (h *IAMHandler) setSessionCookies(ctx context.Context, result *service.LoginResult) error {
+	err := h.cookies.Set(ctx, sessioncookie.Tokens{
+		SessionID:        result.SessionID,
+		AccessToken:      result.AccessToken,
+		RefreshToken:     result.RefreshToken,
+		ExpiresAt:        result.ExpiresAt,
+		RefreshExpiresAt: result.RefreshExpiresAt,
+	})
+	if err != nil {
+		return status.Error(codes.Internal, "failed to set session cookies")
+	}
+	return nil
+}
+
 // Session Management Methods
 
 // ValidateSession validates an access token
@@ -110,26 +352,57 @@ func (h *IAMHandler) ValidateSession(ctx context.Context, req *pb.ValidateSessio
 		}, nil
 	}
 
+	ip := clientIP(ctx)
+	if err := h.guard.Check(ctx, service.EndpointValidateSession, ip, req.SessionId); err != nil {
+		return nil, throttledError(ctx, err)
+	}
+
 	validateResp, err := h.authService.ValidateToken(ctx, req.AccessToken)
+	if errors.Is(err, domain.ErrSessionRestamped) {
+		return &pb.ValidateSessionResponse{
+			Valid:   false,
+			Message: "Session privileges changed, refresh the access token",
+		}, nil
+	}
 	if err != nil {
+		h.guard.RecordFailure(ctx, service.EndpointValidateSession, ip, req.SessionId)
 		return &pb.ValidateSessionResponse{
 			Valid:   false,
 			Message: "Invalid token",
 		}, nil
 	}
 
-	return &pb.ValidateSessionResponse{
+	// Restricted sessions must not grant access to other services
+	if validateResp.PasswordChangeRequired {
+		return &pb.ValidateSessionResponse{
+			Valid:   false,
+			Message: "Password change required",
+		}, nil
+	}
+	if validateResp.DeletionPending {
+		return &pb.ValidateSessionResponse{
+			Valid:   false,
+			Message: "Account pending deletion",
+		}, nil
+	}
+
+	resp := &pb.ValidateSessionResponse{
 		Valid:   validateResp.Valid,
 		Message: "Session is valid",
 		User:    h.convertUserInfoToProto(validateResp.User),
 		Session: h.convertSessionInfoToProto(validateResp.SessionInfo),
-	}, nil
+	}
+	if snapshot := validateResp.Permissions; snapshot != nil {
+		resp.Permissions = snapshot.Permissions
+		resp.PermissionsVersion = snapshot.Version
+	}
+	return resp, nil
 }
 
 // GetSessionInfo retrieves session information
 func (h *IAMHandler) GetSessionInfo(ctx context.Context, req *pb.GetSessionInfoRequest) (*pb.GetSessionInfoResponse, error) {
 	if req.SessionId == "" {
-		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+		return nil, grpcerrors.FieldError("session_id", "session_id is required")
 	}
 
 	sessionInfo, userInfo, err := h.authService.GetSessionInfo(ctx, req.SessionId)
@@ -147,7 +420,7 @@ func (h *IAMHandler) GetSessionInfo(ctx context.Context, req *pb.GetSessionInfoR
 // InvalidateSession invalidates a session
 func (h *IAMHandler) InvalidateSession(ctx context.Context, req *pb.InvalidateSessionRequest) (*pb.InvalidateSessionResponse, error) {
 	if req.SessionId == "" {
-		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+		return nil, grpcerrors.FieldError("session_id", "session_id is required")
 	}
 
 	err := h.authService.RevokeSession(ctx, req.SessionId)
@@ -161,6 +434,135 @@ func (h *IAMHandler) InvalidateSession(ctx context.Context, req *pb.InvalidateSe
 	}, nil
 }
 
+// ListMySessions lists the caller's active sessions with device and location details
+func (h *IAMHandler) ListMySessions(ctx context.Context, req *pb.ListMySessionsRequest) (*pb.ListMySessionsResponse, error) {
+	userID, _ := ctxmeta.UserID(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	currentSessionID, _ := ctxmeta.SessionID(ctx)
+
+	sessions, err := h.authService.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list sessions")
+	}
+
+	protoSessions := make([]*pb.Session, len(sessions))
+	for i, session := range sessions {
+		protoSessions[i] = h.convertSessionInfoToProto(session)
+		protoSessions[i].Current = session.ID == currentSessionID
+	}
+
+	return &pb.ListMySessionsResponse{Sessions: protoSessions}, nil
+}
+
+// loginHistoryPageLimits are the page sizes applied by GetMyLoginHistory
+var loginHistoryPageLimits = pagination.Limits{Default: 20, Max: 100}
+
+// GetMyLoginHistory returns the recent logins to the caller's account, newest
+// first, so users can spot access they do not recognize
+func (h *IAMHandler) GetMyLoginHistory(ctx context.Context, req *pb.GetMyLoginHistoryRequest) (*pb.GetMyLoginHistoryResponse, error) {
+	userID, _ := ctxmeta.UserID(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	// Logins are paged by ID, the order they were recorded in
+	cursor, err := pagination.DecodeToken(req.GetPage().GetPageToken(), pagination.Fingerprint("login_history", userID))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var beforeID int64
+	if len(cursor.After) == 1 {
+		if beforeID, err = strconv.ParseInt(cursor.After[0], 10, 64); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+	pageSize := loginHistoryPageLimits.PageSize(int(req.GetPage().GetPageSize()))
+
+	// One extra login tells whether another page follows
+	events, err := h.authService.GetLoginHistory(ctx, userID, beforeID, pageSize+1)
+	if err != nil {
+		if errors.Is(err, service.ErrLoginHistoryDisabled) {
+			return nil, status.Error(codes.FailedPrecondition, "login history is not enabled")
+		}
+		log.Printf("Login history of user %s failed: %v", userID, err)
+		return nil, status.Error(codes.Internal, "failed to get login history")
+	}
+
+	events, hasMore := pagination.Trim(events, pageSize)
+	pageInfo := pagination.KeysetPage(cursor, pageSize, hasMore, func() []string {
+		return []string{strconv.FormatInt(events[len(events)-1].ID, 10)}
+	})
+
+	protoEvents := make([]*pb.LoginEvent, len(events))
+	for i, event := range events {
+		protoEvents[i] = &pb.LoginEvent{
+			Id:            event.ID,
+			OccurredAt:    timestamppb.New(event.OccurredAt),
+			Method:        event.Method,
+			Success:       event.Success,
+			FailureReason: event.FailureReason,
+			IpAddress:     event.IPAddress,
+			UserAgent:     event.UserAgent,
+			Device:        h.convertDeviceInfoToProto(event.Device),
+			Location:      h.convertGeoLocationToProto(event.Location),
+		}
+	}
+
+	return &pb.GetMyLoginHistoryResponse{
+		Events:   protoEvents,
+		PageInfo: pageInfo.ToProto(),
+	}, nil
+}
+
+// RevokeSessionsByFilter revokes active sessions by IP range, user agent and
+// creation time for incident response
+func (h *IAMHandler) RevokeSessionsByFilter(ctx context.Context, req *pb.RevokeSessionsByFilterRequest) (*pb.RevokeSessionsByFilterResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	criteria := service.SessionRevocationCriteria{
+		UserAgent: strings.TrimSpace(req.UserAgentContains),
+		DryRun:    req.DryRun,
+		Reason:    req.Reason,
+	}
+	criteria.KeepSessionID, _ = ctxmeta.SessionID(ctx)
+	if req.IpRange != "" {
+		network, err := service.ParseIPRange(strings.TrimSpace(req.IpRange))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		criteria.IPNetwork = network
+	}
+	if req.CreatedBefore != nil {
+		createdBefore := req.CreatedBefore.AsTime()
+		criteria.CreatedBefore = &createdBefore
+	}
+
+	result, err := h.authService.RevokeSessionsByFilter(ctx, criteria)
+	if err != nil {
+		if errors.Is(err, service.ErrNoRevocationCriteria) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.Printf("Bulk session revocation failed: %v", err)
+		return nil, status.Error(codes.Internal, "failed to revoke sessions")
+	}
+
+	adminID, _ := ctxmeta.UserID(ctx)
+	log.Printf("Admin %s revoked sessions by filter (ip_range=%q user_agent=%q created_before=%v dry_run=%t): %d matched, %d revoked",
+		adminID, req.IpRange, req.UserAgentContains, criteria.CreatedBefore, req.DryRun, result.Matched, result.Revoked)
+
+	return &pb.RevokeSessionsByFilterResponse{
+		DryRun:            result.DryRun,
+		MatchedCount:      int32(result.Matched),
+		RevokedCount:      int32(result.Revoked),
+		FailedCount:       int32(result.Failed),
+		AffectedUserCount: int32(result.AffectedUsers),
+	}, nil
+}
+
 // User Management Methods
 
 // CreateUser creates a new user
@@ -230,7 +632,7 @@ func (h *IAMHandler) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.G
 
 func (h *IAMHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
 	}
 
 	// Create update request
@@ -275,7 +677,7 @@ func (h *IAMHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 
 func (h *IAMHandler) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
 	}
 
 	err := h.userService.DeleteUser(ctx, req.UserId)
@@ -292,11 +694,12 @@ func (h *IAMHandler) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest)
 	}, nil
 }
 
+// userPageLimits are the page sizes applied by ListUsers
+var userPageLimits = pagination.Limits{Default: 20, Max: 100}
+
 func (h *IAMHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
 	// Create options
 	options := service.UserListOptions{
-		Limit:  int(req.Limit),
-		Offset: int(req.Offset),
 		Search: req.SearchQuery,
 	}
 
@@ -313,13 +716,24 @@ func (h *IAMHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*
 		options.Status = &status_val
 	}
 
-	// Set default limit if not provided
-	if options.Limit <= 0 {
-		options.Limit = 20
-	}
-	if options.Limit > 100 {
-		options.Limit = 100 // Maximum limit
+	// Page tokens are only valid for the filters they were issued for. Users are sorted
+	// by a column callers may choose, so pages are addressed by offset.
+	query := pagination.Fingerprint("users", req.GetRoleFilter().String(), req.GetStatusFilter().String(), req.SearchQuery)
+	var cursor pagination.Cursor
+	var pageSize int
+	if page := req.GetPage(); page != nil {
+		var err error
+		if cursor, err = pagination.DecodeToken(page.GetPageToken(), query); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		pageSize = userPageLimits.PageSize(int(page.GetPageSize()))
+	} else {
+		// Legacy limit and offset
+		cursor = pagination.Cursor{Offset: max(int(req.Offset), 0), Query: query}
+		pageSize = userPageLimits.PageSize(int(req.Limit))
 	}
+	options.Limit = pageSize
+	options.Offset = cursor.Offset
 
 	result, err := h.userService.ListUsers(ctx, options)
 	if err != nil {
@@ -332,20 +746,148 @@ func (h *IAMHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*
 		protoUsers = append(protoUsers, h.convertUserInfoToProto(user))
 	}
 
-	hasMore := options.Offset+options.Limit < result.Total
+	hasMore := options.Offset+len(result.Users) < result.Total
+	pageInfo := pagination.OffsetPage(cursor, pageSize, len(result.Users), hasMore).WithTotal(result.Total)
 
 	return &pb.ListUsersResponse{
 		Users:      protoUsers,
 		TotalCount: int32(result.Total),
 		HasMore:    hasMore,
+		PageInfo:   pageInfo.ToProto(),
+	}, nil
+}
+
+// Bulk User Administration Methods
+
+// ExportUsers exports the users matching the filters as CSV
+func (h *IAMHandler) ExportUsers(ctx context.Context, req *pb.ExportUsersRequest) (*pb.ExportUsersResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	options := service.UserListOptions{Search: req.SearchQuery}
+	if req.RoleFilter != nil {
+		role, err := h.convertProtoRoleToDomain(*req.RoleFilter)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid role filter")
+		}
+		options.Role = &role
+	}
+	if req.StatusFilter != nil {
+		statusVal := h.convertProtoStatusToDomain(*req.StatusFilter)
+		options.Status = &statusVal
+	}
+
+	data, count, err := h.userService.ExportUsersCSV(ctx, options)
+	if err != nil {
+		log.Printf("User export failed: %v", err)
+		return nil, status.Error(codes.Internal, "failed to export users")
+	}
+
+	log.Printf("Exported %d users to CSV", count)
+
+	return &pb.ExportUsersResponse{
+		CsvData:   data,
+		UserCount: int32(count),
+	}, nil
+}
+
+// ImportUsers creates users from CSV, reporting the outcome of every row
+func (h *IAMHandler) ImportUsers(ctx context.Context, req *pb.ImportUsersRequest) (*pb.ImportUsersResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if len(req.CsvData) == 0 {
+		return nil, grpcerrors.FieldError("csv_data", "csv_data is required")
+	}
+
+	result, err := h.userService.ImportUsersCSV(ctx, req.CsvData, req.DryRun)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCSV) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.Printf("User import failed: %v", err)
+		return nil, status.Error(codes.Internal, "failed to import users")
+	}
+
+	log.Printf("User import finished (dry run: %t): %d valid, %d created, %d failed",
+		result.DryRun, result.Valid, result.Created, result.Failed)
+
+	rows := make([]*pb.ImportUserRowResult, len(result.Rows))
+	for i, row := range result.Rows {
+		rows[i] = &pb.ImportUserRowResult{
+			Line:              int32(row.Line),
+			Email:             row.Email,
+			Status:            h.convertImportRowStatusToProto(row.Status),
+			Error:             row.Error,
+			UserId:            row.UserID,
+			TemporaryPassword: row.TemporaryPassword,
+		}
+	}
+
+	return &pb.ImportUsersResponse{
+		DryRun:       result.DryRun,
+		ValidCount:   int32(result.Valid),
+		CreatedCount: int32(result.Created),
+		FailedCount:  int32(result.Failed),
+		Rows:         rows,
 	}, nil
 }
 
+// ResetUserPassword sets a temporary password that must be changed on the next login
+func (h *IAMHandler) ResetUserPassword(ctx context.Context, req *pb.ResetUserPasswordRequest) (*pb.ResetUserPasswordResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.UserId == "" {
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
+	}
+
+	password, err := h.userService.ResetUserPassword(ctx, req.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		log.Printf("Password reset failed for user %s: %v", req.UserId, err)
+		return nil, status.Error(codes.Internal, "failed to reset password")
+	}
+
+	log.Printf("Temporary password issued for user %s", req.UserId)
+
+	return &pb.ResetUserPasswordResponse{
+		Success:           true,
+		Message:           "Temporary password issued; it must be changed on next login",
+		TemporaryPassword: password,
+	}, nil
+}
+
+// requireAdmin rejects callers whose authenticated role is not admin
+func (h *IAMHandler) requireAdmin(ctx context.Context) error {
+	if !ctxmeta.HasRole(ctx, string(domain.RoleAdmin)) {
+		return status.Error(codes.PermissionDenied, "admin role required")
+	}
+	return nil
+}
+
+// throttledError maps a brute-force guard rejection to ResourceExhausted, sending
+// the back-off in a retry-after header (seconds) and as a RetryInfo detail
+func throttledError(ctx context.Context, err error) error {
+	var throttled *service.ThrottledError
+	if errors.As(err, &throttled) {
+		retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+		grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryAfter)))
+		return grpcerrors.New(codes.ResourceExhausted, grpcerrors.ReasonRateLimited,
+			fmt.Sprintf("too many attempts, retry after %ds", retryAfter),
+			grpcerrors.WithRetryAfter(throttled.RetryAfter))
+	}
+	return grpcerrors.New(codes.ResourceExhausted, grpcerrors.ReasonRateLimited, "too many attempts")
+}
+
 // Profile Management Methods
 
 func (h *IAMHandler) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.GetProfileResponse, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
 	}
 
 	userInfo, err := h.userService.GetUser(ctx, req.UserId)
@@ -372,7 +914,7 @@ func (h *IAMHandler) GetProfile(ctx context.Context, req *pb.GetProfileRequest)
 
 func (h *IAMHandler) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UpdateProfileResponse, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
 	}
 
 	// Create update request with profile fields
@@ -414,18 +956,134 @@ func (h *IAMHandler) UpdateProfile(ctx context.Context, req *pb.UpdateProfileReq
 	}, nil
 }
 
+// GetPreferences returns the typed preferences of a user
+func (h *IAMHandler) GetPreferences(ctx context.Context, req *pb.GetPreferencesRequest) (*pb.GetPreferencesResponse, error) {
+	if req.UserId == "" {
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
+	}
+
+	preferences, err := h.userService.GetPreferences(ctx, req.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get preferences")
+	}
+
+	return &pb.GetPreferencesResponse{
+		Preferences: h.convertPreferencesToProto(preferences),
+	}, nil
+}
+
+// UpdatePreferences changes the typed preferences of a user
+func (h *IAMHandler) UpdatePreferences(ctx context.Context, req *pb.UpdatePreferencesRequest) (*pb.UpdatePreferencesResponse, error) {
+	if req.UserId == "" {
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
+	}
+
+	update := service.PreferencesUpdate{
+		Locale:         req.Locale,
+		Timezone:       req.Timezone,
+		MarketingOptIn: req.MarketingOptIn,
+	}
+	if req.Notifications != nil {
+		update.Notifications = &domain.NotificationPreferences{
+			OrderUpdates:   req.Notifications.OrderUpdates,
+			SecurityAlerts: req.Notifications.SecurityAlerts,
+			Telegram:       req.Notifications.Telegram,
+			Email:          req.Notifications.Email,
+		}
+	}
+
+	preferences, err := h.userService.UpdatePreferences(ctx, req.UserId, update)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidPreferences):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, domain.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to update preferences")
+	}
+
+	return &pb.UpdatePreferencesResponse{
+		Preferences: h.convertPreferencesToProto(preferences),
+	}, nil
+}
+
+// RequestAccountDeletion schedules the deletion of the caller's own account
+func (h *IAMHandler) RequestAccountDeletion(ctx context.Context, req *pb.RequestAccountDeletionRequest) (*pb.RequestAccountDeletionResponse, error) {
+	userID, _ := ctxmeta.UserID(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if req.Password == "" {
+		return nil, grpcerrors.FieldError("password", "password is required")
+	}
+
+	userInfo, err := h.userService.RequestAccountDeletion(ctx, userID, req.Password, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCredentials):
+			return nil, status.Error(codes.Unauthenticated, "password is incorrect")
+		case errors.Is(err, domain.ErrDeletionNotAllowed):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, domain.ErrDeletionPending),
+			errors.Is(err, domain.ErrAccountInactive),
+			errors.Is(err, domain.ErrAccountLocked):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, domain.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to request account deletion")
+	}
+
+	return &pb.RequestAccountDeletionResponse{
+		Success:             true,
+		Message:             "Account deletion scheduled; log in again before then to cancel it",
+		DeletionScheduledAt: timestamppb.New(*userInfo.DeletionScheduledAt),
+	}, nil
+}
+
+// CancelAccountDeletion keeps the caller's account that is pending deletion
+func (h *IAMHandler) CancelAccountDeletion(ctx context.Context, req *pb.CancelAccountDeletionRequest) (*pb.CancelAccountDeletionResponse, error) {
+	userID, _ := ctxmeta.UserID(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	userInfo, err := h.userService.CancelAccountDeletion(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrDeletionNotPending):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, domain.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to cancel account deletion")
+	}
+
+	return &pb.CancelAccountDeletionResponse{
+		Success: true,
+		Message: "Account deletion cancelled",
+		User:    h.convertUserInfoToProto(userInfo),
+	}, nil
+}
+
 func (h *IAMHandler) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
 	}
 	if req.CurrentPassword == "" {
-		return nil, status.Error(codes.InvalidArgument, "current_password is required")
+		return nil, grpcerrors.FieldError("current_password", "current_password is required")
 	}
 	if req.NewPassword == "" {
-		return nil, status.Error(codes.InvalidArgument, "new_password is required")
+		return nil, grpcerrors.FieldError("new_password", "new_password is required")
 	}
 
-	err := h.authService.ChangePassword(ctx, req.UserId, req.CurrentPassword, req.NewPassword, true, "")
+	// Keep the caller's session: once the password is changed it is no longer restricted
+	sessionID, _ := ctxmeta.SessionID(ctx)
+	err := h.authService.ChangePassword(ctx, req.UserId, req.CurrentPassword, req.NewPassword, true, sessionID)
 	if err != nil {
 		if strings.Contains(err.Error(), "invalid credentials") {
 			return nil, status.Error(codes.Unauthenticated, "current password is incorrect")
@@ -446,13 +1104,13 @@ func (h *IAMHandler) ChangePassword(ctx context.Context, req *pb.ChangePasswordR
 
 func (h *IAMHandler) CheckPermission(ctx context.Context, req *pb.CheckPermissionRequest) (*pb.CheckPermissionResponse, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
 	}
 	if req.Resource == "" {
-		return nil, status.Error(codes.InvalidArgument, "resource is required")
+		return nil, grpcerrors.FieldError("resource", "resource is required")
 	}
 	if req.Action == "" {
-		return nil, status.Error(codes.InvalidArgument, "action is required")
+		return nil, grpcerrors.FieldError("action", "action is required")
 	}
 
 	// Get user to check role
@@ -474,7 +1132,7 @@ func (h *IAMHandler) CheckPermission(ctx context.Context, req *pb.CheckPermissio
 
 func (h *IAMHandler) GetUserPermissions(ctx context.Context, req *pb.GetUserPermissionsRequest) (*pb.GetUserPermissionsResponse, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
 	}
 
 	userInfo, err := h.userService.GetUser(ctx, req.UserId)
@@ -498,7 +1156,7 @@ func (h *IAMHandler) GetUserPermissions(ctx context.Context, req *pb.GetUserPerm
 
 func (h *IAMHandler) GetUserTelegramChatID(ctx context.Context, req *pb.GetUserTelegramChatIDRequest) (*pb.GetUserTelegramChatIDResponse, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
 	}
 
 	chatID, username, err := h.userService.GetTelegramInfo(ctx, req.UserId)
@@ -518,10 +1176,10 @@ func (h *IAMHandler) GetUserTelegramChatID(ctx context.Context, req *pb.GetUserT
 
 func (h *IAMHandler) UpdateTelegramChatID(ctx context.Context, req *pb.UpdateTelegramChatIDRequest) (*pb.UpdateTelegramChatIDResponse, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, grpcerrors.FieldError("user_id", "user_id is required")
 	}
 	if req.ChatId == "" {
-		return nil, status.Error(codes.InvalidArgument, "chat_id is required")
+		return nil, grpcerrors.FieldError("chat_id", "chat_id is required")
 	}
 
 	err := h.userService.UpdateTelegramInfo(ctx, req.UserId, req.ChatId, req.TelegramUsername)
@@ -538,6 +1196,22 @@ func (h *IAMHandler) UpdateTelegramChatID(ctx context.Context, req *pb.UpdateTel
 	}, nil
 }
 
+// Build Information Methods
+
+// GetVersion returns build information for deployment verification
+func (h *IAMHandler) GetVersion(ctx context.Context, req *pb.GetVersionRequest) (*pb.GetVersionResponse, error) {
+	info := version.Get("iam-service")
+
+	return &pb.GetVersionResponse{
+		Service:   info.Service,
+		Version:   info.Version,
+		GitCommit: info.GitCommit,
+		BuildTime: info.BuildTime,
+		GoVersion: info.GoVersion,
+		Platform:  info.Platform,
+	}, nil
+}
+
 // Helper Methods for Conversion
 
 // convertUserInfoToProto converts service UserInfo to protobuf User
@@ -546,7 +1220,7 @@ func (h *IAMHandler) convertUserInfoToProto(user *service.UserInfo) *pb.User {
 		return nil
 	}
 
-	return &pb.User{
+	protoUser := &pb.User{
 		Id:        user.ID,
 		Email:     user.Email,
 		FirstName: user.FirstName,
@@ -555,6 +1229,41 @@ func (h *IAMHandler) convertUserInfoToProto(user *service.UserInfo) *pb.User {
 		Status:    h.convertStringStatusToProto(user.Status),
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.UpdatedAt),
+
+		MustChangePassword: user.MustChangePassword,
+	}
+	if user.DeletionScheduledAt != nil {
+		protoUser.DeletionScheduledAt = timestamppb.New(*user.DeletionScheduledAt)
+	}
+	return protoUser
+}
+
+// convertPreferencesToProto converts domain Preferences to protobuf UserPreferences
+func (h *IAMHandler) convertPreferencesToProto(preferences *domain.Preferences) *pb.UserPreferences {
+	return &pb.UserPreferences{
+		Locale:         preferences.Locale,
+		Timezone:       preferences.Timezone,
+		MarketingOptIn: preferences.MarketingOptIn,
+		Notifications: &pb.NotificationPreferences{
+			OrderUpdates:   preferences.Notifications.OrderUpdates,
+			SecurityAlerts: preferences.Notifications.SecurityAlerts,
+			Telegram:       preferences.Notifications.Telegram,
+			Email:          preferences.Notifications.Email,
+		},
+	}
+}
+
+// convertImportRowStatusToProto converts an import row status to protobuf ImportRowStatus
+func (h *IAMHandler) convertImportRowStatusToProto(rowStatus service.ImportRowStatus) pb.ImportRowStatus {
+	switch rowStatus {
+	case service.ImportRowValid:
+		return pb.ImportRowStatus_IMPORT_ROW_STATUS_VALID
+	case service.ImportRowCreated:
+		return pb.ImportRowStatus_IMPORT_ROW_STATUS_CREATED
+	case service.ImportRowFailed:
+		return pb.ImportRowStatus_IMPORT_ROW_STATUS_FAILED
+	default:
+		return pb.ImportRowStatus_IMPORT_ROW_STATUS_UNSPECIFIED
 	}
 }
 
@@ -573,6 +1282,39 @@ func (h *IAMHandler) convertSessionInfoToProto(sessionInfo *domain.SessionInfo)
 		IpAddress:      sessionInfo.IPAddress,
 		UserAgent:      sessionInfo.UserAgent,
 		Status:         h.convertDomainSessionStatusToProto(sessionInfo.Status),
+		Device:         h.convertDeviceInfoToProto(sessionInfo.Device),
+		Location:       h.convertGeoLocationToProto(sessionInfo.Location),
+		Anomalies:      sessionInfo.Anomalies,
+	}
+}
+
+// convertDeviceInfoToProto converts domain DeviceInfo to protobuf DeviceInfo
+func (h *IAMHandler) convertDeviceInfoToProto(device *domain.DeviceInfo) *pb.DeviceInfo {
+	if device == nil {
+		return nil
+	}
+
+	return &pb.DeviceInfo{
+		Browser:     device.Browser,
+		Os:          device.OS,
+		Type:        device.Type,
+		Description: device.Description,
+	}
+}
+
+// convertGeoLocationToProto converts domain GeoLocation to protobuf GeoLocation
+func (h *IAMHandler) convertGeoLocationToProto(location *domain.GeoLocation) *pb.GeoLocation {
+	if location == nil {
+		return nil
+	}
+
+	return &pb.GeoLocation{
+		CountryCode: location.CountryCode,
+		Country:     location.Country,
+		City:        location.City,
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
+		AccuracyKm:  int32(location.AccuracyKm),
 	}
 }
 
@@ -603,6 +1345,8 @@ func (h *IAMHandler) convertStringStatusToProto(status string) pb.UserStatus {
 		return pb.UserStatus_USER_STATUS_SUSPENDED
 	case "deleted":
 		return pb.UserStatus_USER_STATUS_DELETED
+	case "pending_deletion":
+		return pb.UserStatus_USER_STATUS_PENDING_DELETION
 	default:
 		return pb.UserStatus_USER_STATUS_UNSPECIFIED
 	}
@@ -653,6 +1397,8 @@ func (h *IAMHandler) convertProtoStatusToDomain(status pb.UserStatus) domain.Use
 		return domain.StatusSuspended
 	case pb.UserStatus_USER_STATUS_DELETED:
 		return domain.StatusDeleted
+	case pb.UserStatus_USER_STATUS_PENDING_DELETION:
+		return domain.StatusPendingDeletion
 	default:
 		return domain.StatusActive
 	}
@@ -662,50 +1408,12 @@ func (h *IAMHandler) convertProtoStatusToDomain(status pb.UserStatus) domain.Use
 
 // checkRolePermission checks if a role has permission for a resource/action
 func (h *IAMHandler) checkRolePermission(role, resource, action string) bool {
-	permissions := h.getRolePermissions(role)
-
-	// Check for wildcard permissions
-	for _, perm := range permissions {
-		if perm == "*" || perm == resource+".*" || perm == resource+"."+action {
-			return true
-		}
-	}
-
-	return false
+	return domain.HasPermission(h.getRolePermissions(role), resource, action)
 }
 
 // getRolePermissions returns the permissions for a role
 func (h *IAMHandler) getRolePermissions(role string) []string {
-	switch role {
-	case "admin":
-		return []string{
-			"*", // Admin has all permissions
-		}
-	case "operator":
-		return []string{
-			"orders.*",
-			"inventory.*",
-			"users.read",
-			"users.update",
-		}
-	case "support":
-		return []string{
-			"orders.read",
-			"orders.update",
-			"users.read",
-			"inventory.read",
-		}
-	case "customer":
-		return []string{
-			"orders.create",
-			"orders.read", // Own orders only
-			"profile.*",
-		}
-	default:
-		return []string{
-			"profile.read",
-		}
-	}
+	return domain.RolePermissions(domain.UserRole(role))
 }
 
 // getPermissionMessage returns a message describing the permission check result
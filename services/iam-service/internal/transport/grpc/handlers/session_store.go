@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	redisRepo "github.com/amiosamu/rocket-science/services/iam-service/internal/repository/redis"
+	pb "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+)
+
+// GetSessionStoreStatus reports the regions of the session store and whether
+// session reads are being served from the replica
+func (h *IAMHandler) GetSessionStoreStatus(ctx context.Context, req *pb.GetSessionStoreStatusRequest) (*pb.GetSessionStoreStatusResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.sessionStore == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no session store replica configured")
+	}
+
+	// An unreachable replica still leaves the primary's side worth reporting
+	storeStatus, err := h.sessionStore.SessionStoreStatus(ctx)
+	if err != nil {
+		log.Printf("Session store status incomplete: %v", err)
+	}
+
+	return &pb.GetSessionStoreStatusResponse{
+		PrimaryRegion:           storeStatus.PrimaryRegion,
+		PrimaryAddress:          storeStatus.PrimaryAddress,
+		PrimaryReachable:        storeStatus.PrimaryReachable,
+		ReplicaRegion:           storeStatus.ReplicaRegion,
+		ReplicaAddress:          storeStatus.ReplicaAddress,
+		ReplicaStalenessSeconds: int64(storeStatus.ReplicaStaleness.Seconds()),
+		ReadingReplica:          storeStatus.ReadingReplica,
+	}, nil
+}
+
+// PromoteSessionStore fails the session store over to the replica region after
+// the primary region is lost
+func (h *IAMHandler) PromoteSessionStore(ctx context.Context, req *pb.PromoteSessionStoreRequest) (*pb.PromoteSessionStoreResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.sessionStore == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no session store replica configured")
+	}
+
+	adminID, _ := ctxmeta.UserID(ctx)
+	log.Printf("Session store promotion requested by %s (force=%t): %s", adminID, req.Force, req.Reason)
+
+	failover, err := h.sessionStore.PromoteSessionStore(ctx, req.Force)
+	if err != nil {
+		switch {
+		case errors.Is(err, redisRepo.ErrNoSessionReplica):
+			return nil, status.Error(codes.FailedPrecondition, "session store has no replica left to promote")
+		case errors.Is(err, redisRepo.ErrPrimaryReachable):
+			return nil, status.Error(codes.FailedPrecondition, "primary session store is reachable, set force to promote anyway")
+		}
+		log.Printf("Session store promotion failed: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "session store promotion failed: %v", err)
+	}
+
+	return &pb.PromoteSessionStoreResponse{
+		PreviousPrimaryRegion:   failover.PreviousPrimaryRegion,
+		PrimaryRegion:           failover.PrimaryRegion,
+		PrimaryAddress:          failover.PrimaryAddress,
+		ReplicaStalenessSeconds: int64(failover.ReplicaStaleness.Seconds()),
+		PromotedAt:              timestamppb.New(failover.PromotedAt),
+		Steps:                   failover.Steps,
+	}, nil
+}
@@ -2,6 +2,7 @@ package interceptors
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"google.golang.org/grpc"
@@ -9,20 +10,25 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/service"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/transport/grpc/sessioncookie"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 )
 
 // AuthInterceptor handles authentication for gRPC calls
 type AuthInterceptor struct {
 	authService *service.AuthService
+	cookies     *sessioncookie.Jar
 	logger      logging.Logger
 }
 
 // NewAuthInterceptor creates a new authentication interceptor
-func NewAuthInterceptor(authService *service.AuthService, logger logging.Logger) *AuthInterceptor {
+func NewAuthInterceptor(authService *service.AuthService, cookies *sessioncookie.Jar, logger logging.Logger) *AuthInterceptor {
 	return &AuthInterceptor{
 		authService: authService,
+		cookies:     cookies,
 		logger:      logger,
 	}
 }
@@ -36,7 +42,7 @@ func (a *AuthInterceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 		}
 
 		// Extract and validate token
-		authCtx, err := a.authenticateRequest(ctx)
+		authCtx, err := a.authenticateRequest(ctx, info.FullMethod)
 		if err != nil {
 			a.logger.Warn(ctx, "Authentication failed for gRPC call", map[string]interface{}{
 				"method": info.FullMethod,
@@ -59,7 +65,7 @@ func (a *AuthInterceptor) StreamServerInterceptor() grpc.StreamServerInterceptor
 		}
 
 		// Extract and validate token
-		authCtx, err := a.authenticateRequest(stream.Context())
+		authCtx, err := a.authenticateRequest(stream.Context(), info.FullMethod)
 		if err != nil {
 			a.logger.Warn(stream.Context(), "Authentication failed for gRPC stream", map[string]interface{}{
 				"method": info.FullMethod,
@@ -82,7 +88,15 @@ func (a *AuthInterceptor) StreamServerInterceptor() grpc.StreamServerInterceptor
 func (a *AuthInterceptor) shouldSkipAuth(method string) bool {
 	// List of methods that don't require authentication
 	publicMethods := []string{
-		"/iam.IAMService/Login",
+		"/iam.v1.IAMService/Login",
+		"/iam.v1.IAMService/RequestLoginLink",
+		"/iam.v1.IAMService/CompleteLoginWithLink",  // Authenticated by the login link
+		"/iam.v1.IAMService/LockAccountWithToken",   // Authenticated by the lock link
+		"/iam.v1.IAMService/RefreshToken",           // Authenticated by the refresh token
+		"/iam.v1.IAMService/ExchangeSessionCookies", // Authenticated by the refresh token
+		"/iam.v1.IAMService/IssueClientToken",       // Authenticated by the client secret
+		"/iam.v1.IAMService/ValidateClientToken",    // Validates the token it is given
+		"/iam.v1.IAMService/GetVersion",
 		"/grpc.health.v1.Health/Check",
 		"/grpc.health.v1.Health/Watch",
 	}
@@ -96,18 +110,56 @@ func (a *AuthInterceptor) shouldSkipAuth(method string) bool {
 	return false
 }
 
+// isPasswordChangeMethod reports whether a method may be called with a
+// restricted session, i.e. by a user who must still change their password
+func (a *AuthInterceptor) isPasswordChangeMethod(method string) bool {
+	restrictedMethods := []string{
+		"/iam.v1.IAMService/ChangePassword",
+		"/iam.v1.IAMService/Logout",
+	}
+
+	for _, restrictedMethod := range restrictedMethods {
+		if strings.HasSuffix(method, restrictedMethod) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isDeletionPendingMethod reports whether a method may be called with the
+// restricted session of an account pending deletion
+func (a *AuthInterceptor) isDeletionPendingMethod(method string) bool {
+	restrictedMethods := []string{
+		"/iam.v1.IAMService/CancelAccountDeletion",
+		"/iam.v1.IAMService/Logout",
+	}
+
+	for _, restrictedMethod := range restrictedMethods {
+		if strings.HasSuffix(method, restrictedMethod) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // authenticateRequest extracts and validates authentication token from context
-func (a *AuthInterceptor) authenticateRequest(ctx context.Context) (context.Context, error) {
+func (a *AuthInterceptor) authenticateRequest(ctx context.Context, method string) (context.Context, error) {
 	// Extract metadata from context
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "missing metadata")
 	}
 
-	// Extract authorization header
+	// Extract authorization header; web clients send the session cookie instead
 	authHeaders := md.Get("authorization")
 	if len(authHeaders) == 0 {
-		return nil, status.Error(codes.Unauthenticated, "missing authorization header")
+		token := a.cookies.AccessToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "missing authorization header")
+		}
+		return a.authenticateToken(ctx, method, token)
 	}
 
 	authHeader := authHeaders[0]
@@ -126,16 +178,35 @@ func (a *AuthInterceptor) authenticateRequest(ctx context.Context) (context.Cont
 		return nil, status.Error(codes.Unauthenticated, "empty token")
 	}
 
+	return a.authenticateToken(ctx, method, token)
+}
+
+// authenticateToken validates an access token and adds its user to the context
+func (a *AuthInterceptor) authenticateToken(ctx context.Context, method, token string) (context.Context, error) {
+
 	// Validate token using auth service
 	validateResp, err := a.authService.ValidateToken(ctx, token)
+	if errors.Is(err, domain.ErrSessionRestamped) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, "invalid token")
 	}
 
+	// Users holding a temporary password may only change it
+	if validateResp.PasswordChangeRequired && !a.isPasswordChangeMethod(method) {
+		return nil, status.Error(codes.PermissionDenied, domain.ErrPasswordChangeRequired.Error())
+	}
+
+	// Accounts pending deletion may only cancel it
+	if validateResp.DeletionPending && !a.isDeletionPendingMethod(method) {
+		return nil, status.Error(codes.PermissionDenied, domain.ErrDeletionPending.Error())
+	}
+
 	// Add user information to context
-	authCtx := context.WithValue(ctx, "user_id", validateResp.User.ID)
-	authCtx = context.WithValue(authCtx, "user_role", validateResp.User.Role)
-	authCtx = context.WithValue(authCtx, "session_id", validateResp.SessionInfo.ID)
+	authCtx := ctxmeta.WithUserID(ctx, validateResp.User.ID)
+	authCtx = ctxmeta.WithRoles(authCtx, string(validateResp.User.Role))
+	authCtx = ctxmeta.WithSessionID(authCtx, validateResp.SessionInfo.ID)
 
 	return authCtx, nil
 }
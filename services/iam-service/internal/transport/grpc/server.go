@@ -16,8 +16,13 @@ import (
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/container"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/transport/grpc/handlers"
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/transport/grpc/interceptors"
-	pb "github.com/amiosamu/rocket-science/services/iam-service/proto/iam"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/transport/grpc/sessioncookie"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/compat"
+	pb "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/grpclog"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
 )
 
 // Server represents the gRPC server
@@ -34,6 +39,12 @@ func NewServer(container *container.Container) (*Server, error) {
 	cfg := container.GetConfig()
 	logger := container.GetLogger()
 
+	// Request logging samples successful calls and redacts sensitive fields
+	logConfig, err := grpclog.FromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("invalid gRPC request logging configuration: %w", err)
+	}
+
 	// Create listener
 	address := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
 	listener, err := net.Listen("tcp", address)
@@ -42,9 +53,9 @@ func NewServer(container *container.Container) (*Server, error) {
 	}
 
 	// Create interceptors
-	authInterceptor := interceptors.NewAuthInterceptor(container.GetAuthService(), logger)
-	loggingInterceptor := interceptors.NewLoggingInterceptor(logger)
-	recoveryInterceptor := interceptors.NewRecoveryInterceptor(logger)
+	cookies := sessioncookie.New(cfg.Cookies)
+	authInterceptor := interceptors.NewAuthInterceptor(container.GetAuthService(), cookies, logger)
+	loggingInterceptor := grpclog.New(grpclog.FromLogger(logger), logConfig)
 
 	// Configure server options
 	serverOpts := []grpc.ServerOption{
@@ -62,12 +73,15 @@ func NewServer(container *container.Container) (*Server, error) {
 		grpc.MaxRecvMsgSize(4 * 1024 * 1024), // 4MB
 		grpc.MaxSendMsgSize(4 * 1024 * 1024), // 4MB
 		grpc.ChainUnaryInterceptor(
-			recoveryInterceptor.UnaryServerInterceptor(),
+			recovery.UnaryServerInterceptor(container.GetCrashReporter()),
+			ctxmeta.UnaryServerInterceptor(),
 			loggingInterceptor.UnaryServerInterceptor(),
 			authInterceptor.UnaryServerInterceptor(),
+			compat.IAM.UnaryServerInterceptor(),
 		),
 		grpc.ChainStreamInterceptor(
-			recoveryInterceptor.StreamServerInterceptor(),
+			recovery.StreamServerInterceptor(container.GetCrashReporter()),
+			ctxmeta.StreamServerInterceptor(),
 			loggingInterceptor.StreamServerInterceptor(),
 			authInterceptor.StreamServerInterceptor(),
 		),
@@ -84,6 +98,11 @@ func NewServer(container *container.Container) (*Server, error) {
 	iamHandler := handlers.NewIAMHandler(
 		container.GetAuthService(),
 		container.GetUserService(),
+		container.GetClientCredentialsService(),
+		container.GetBruteForceGuard(),
+		container.GetLoginChallenge(),
+		container.GetSessionStorePromoter(),
+		cookies,
 	)
 	pb.RegisterIAMServiceServer(grpcServer, iamHandler)
 
@@ -97,6 +116,22 @@ func NewServer(container *container.Container) (*Server, error) {
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 	healthServer.SetServingStatus("iam-service", grpc_health_v1.HealthCheckResponse_SERVING)
 
+	// Report NOT_SERVING while in maintenance; in-flight calls still complete
+	if container.Maintenance != nil {
+		container.Maintenance.OnChange(func(enabled bool) {
+			status := grpc_health_v1.HealthCheckResponse_SERVING
+			if enabled {
+				status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+			}
+			healthServer.SetServingStatus("", status)
+			healthServer.SetServingStatus("iam-service", status)
+			logger.Info(context.Background(), "Maintenance mode changed", map[string]interface{}{
+				"enabled":       enabled,
+				"health_status": status.String(),
+			})
+		})
+	}
+
 	server := &Server{
 		grpcServer: grpcServer,
 		listener:   listener,
@@ -0,0 +1,129 @@
+// Package sessioncookie carries the IAM sessions of web clients in cookies. Tokens are
+// set with set-cookie response headers, which the gateway forwards to the browser, and
+// read back from the cookie request header. The cookies are HttpOnly, so page scripts
+// never see the tokens; the refresh token and session ID are scoped to the IAM service
+// path, so other services never receive them.
+package sessioncookie
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/config"
+)
+
+// Jar sets and reads the session cookies
+type Jar struct {
+	cfg config.CookieConfig
+}
+
+// New creates a cookie jar with the given configuration
+func New(cfg config.CookieConfig) *Jar {
+	return &Jar{cfg: cfg}
+}
+
+// Tokens are the session credentials stored in the cookies
+type Tokens struct {
+	SessionID    string
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time // When the access token expires
+
+	RefreshExpiresAt time.Time
+}
+
+// Set sends the session cookies with the response
+func (j *Jar) Set(ctx context.Context, tokens Tokens) error {
+	return j.send(ctx,
+		j.cookie(j.cfg.AccessTokenName, tokens.AccessToken, j.cfg.Path, tokens.ExpiresAt),
+		j.cookie(j.cfg.RefreshTokenName, tokens.RefreshToken, j.cfg.RefreshPath, tokens.RefreshExpiresAt),
+		j.cookie(j.cfg.SessionIDName, tokens.SessionID, j.cfg.RefreshPath, tokens.RefreshExpiresAt),
+	)
+}
+
+// Clear tells the browser to drop the session cookies
+func (j *Jar) Clear(ctx context.Context) error {
+	expired := time.Unix(0, 0)
+	return j.send(ctx,
+		j.cookie(j.cfg.AccessTokenName, "", j.cfg.Path, expired),
+		j.cookie(j.cfg.RefreshTokenName, "", j.cfg.RefreshPath, expired),
+		j.cookie(j.cfg.SessionIDName, "", j.cfg.RefreshPath, expired),
+	)
+}
+
+// AccessToken returns the access token cookie of the request, if any
+func (j *Jar) AccessToken(ctx context.Context) string {
+	return j.read(ctx)[j.cfg.AccessTokenName]
+}
+
+// Session returns the session ID and refresh token cookies of the request, if any
+func (j *Jar) Session(ctx context.Context) (sessionID, refreshToken string) {
+	cookies := j.read(ctx)
+	return cookies[j.cfg.SessionIDName], cookies[j.cfg.RefreshTokenName]
+}
+
+// HasSession reports whether the request carries any session cookie
+func (j *Jar) HasSession(ctx context.Context) bool {
+	cookies := j.read(ctx)
+	return cookies[j.cfg.AccessTokenName] != "" || cookies[j.cfg.SessionIDName] != ""
+}
+
+func (j *Jar) cookie(name, value, path string, expires time.Time) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Domain:   j.cfg.Domain,
+		Path:     path,
+		Expires:  expires,
+		Secure:   j.cfg.Secure,
+		HttpOnly: true,
+		SameSite: j.sameSite(),
+	}
+	if value == "" {
+		cookie.MaxAge = -1
+	}
+	return cookie
+}
+
+func (j *Jar) sameSite() http.SameSite {
+	switch j.cfg.SameSite {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
+
+func (j *Jar) send(ctx context.Context, cookies ...*http.Cookie) error {
+	md := metadata.MD{}
+	for _, cookie := range cookies {
+		md.Append("set-cookie", cookie.String())
+	}
+	return grpc.SetHeader(ctx, md)
+}
+
+// read parses the cookie headers forwarded by the gateway
+func (j *Jar) read(ctx context.Context) map[string]string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	cookies := map[string]string{}
+	for _, header := range md.Get("cookie") {
+		parsed, err := http.ParseCookie(header)
+		if err != nil {
+			continue
+		}
+		for _, cookie := range parsed {
+			cookies[cookie.Name] = cookie.Value
+		}
+	}
+	return cookies
+}
@@ -1,14 +1,23 @@
 package http
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/container"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/adminhttp"
+	platformErrors "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/jobs"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/purge"
+	"github.com/amiosamu/rocket-science/shared/platform/version"
 )
 
 // HealthServer provides HTTP health check endpoints
@@ -67,6 +76,17 @@ func NewHealthServer(container *container.Container, port string) *HealthServer
 	mux.HandleFunc("/health", hs.healthHandler)
 	mux.HandleFunc("/ready", hs.readinessHandler)
 	mux.HandleFunc("/metrics", hs.metricsHandler)
+	mux.Handle("/version", version.Handler("iam-service"))
+
+	// Gateway endpoints: session and client token validation, and the usage
+	// of a service client's request quota
+	NewSessionValidationServer(container).SetupRoutes(mux)
+
+	// Admin endpoints
+	mux.HandleFunc("/admin/maintenance", hs.maintenanceHandler)
+	mux.HandleFunc("POST /admin/test-data/purge", hs.testDataPurgeHandler)
+	mux.HandleFunc("GET /admin/test-data/purge/{id}", hs.testDataPurgeStatusHandler)
+	mux.HandleFunc("GET /admin/sessions/stats", hs.sessionStatsHandler)
 
 	// Debug endpoints (for development)
 	mux.HandleFunc("/debug/config", hs.configHandler)
@@ -88,22 +108,34 @@ func NewHealthServer(container *container.Container, port string) *HealthServer
 
 // Start starts the health check HTTP server
 func (hs *HealthServer) Start(ctx context.Context) error {
+	security, err := adminhttp.FromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid health server security configuration: %w", err)
+	}
+	listener, err := security.Listen(hs.server)
+	if err != nil {
+		return err
+	}
+	if !security.AuthEnabled() {
+		hs.logger.Warn(ctx, "HTTP health server admin and debug routes are unauthenticated; set HEALTH_ADMIN_TOKEN or HEALTH_TLS_CLIENT_CA_FILE")
+	}
+
 	hs.logger.Info(ctx, "Starting HTTP health server", map[string]interface{}{
-		"port": hs.port,
+		"address":    hs.server.Addr,
+		"tls":        security.TLSEnabled(),
+		"admin_auth": security.AuthEnabled(),
 	})
 
 	// Start server in goroutine
 	go func() {
-		if err := hs.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := hs.server.Serve(listener); err != nil && err != http.ErrServerClosed {
 			hs.logger.Error(ctx, "Health server failed", err, map[string]interface{}{
 				"port": hs.port,
 			})
 		}
 	}()
 
-	// Wait for context cancellation
-	<-ctx.Done()
-	return hs.Stop(context.Background())
+	return nil
 }
 
 // Stop stops the health check HTTP server
@@ -116,9 +148,9 @@ func (hs *HealthServer) Stop(ctx context.Context) error {
 	return hs.server.Shutdown(shutdownCtx)
 }
 
-// GetAddress returns the server address
+// GetAddress returns the server address, including the bind address once started
 func (hs *HealthServer) GetAddress() string {
-	return ":" + hs.port
+	return hs.server.Addr
 }
 
 // healthHandler handles /health endpoint
@@ -233,6 +265,13 @@ func (hs *HealthServer) readinessHandler(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	// Take the instance out of rotation while in maintenance mode
+	components["maintenance"] = !hs.container.Maintenance.Enabled()
+	if !components["maintenance"] {
+		ready = false
+		message = "Service is in maintenance mode"
+	}
+
 	// Create response
 	response := ReadinessResponse{
 		Ready:      ready,
@@ -262,6 +301,120 @@ func (hs *HealthServer) readinessHandler(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// maintenanceHandler reports and toggles maintenance mode
+func (hs *HealthServer) maintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	if hs.container.Maintenance == nil {
+		http.Error(w, "maintenance mode not configured", http.StatusNotImplemented)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		hs.logger.Info(r.Context(), "Maintenance mode change requested", map[string]interface{}{
+			"method":      r.Method,
+			"remote_addr": r.RemoteAddr,
+		})
+	}
+
+	hs.container.Maintenance.Handler().ServeHTTP(w, r)
+}
+
+// testDataPurgeHandler enqueues a purge of test users with their data across
+// the services and answers with the status of its job
+func (hs *HealthServer) testDataPurgeHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	purgeService := hs.container.GetTestDataPurge()
+	if purgeService == nil {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]string{"error": purge.ErrDisabled.Error()})
+		return
+	}
+
+	var req service.TestDataPurgeRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid purge request: " + err.Error()})
+		return
+	}
+
+	job, err := purgeService.Request(r.Context(), req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case purge.IsDisabled(err):
+			statusCode = http.StatusForbidden
+		case platformErrors.IsValidation(err):
+			statusCode = http.StatusBadRequest
+		}
+		w.WriteHeader(statusCode)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	hs.logger.Info(r.Context(), "Test data purge requested", map[string]interface{}{
+		"job_id":        job.ID.String(),
+		"email_domains": req.EmailDomains,
+		"user_ids":      len(req.UserIDs),
+		"dry_run":       req.DryRun,
+		"remote_addr":   r.RemoteAddr,
+	})
+
+	w.Header().Set("Location", "/admin/test-data/purge/"+job.ID.String())
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(jobs.NewStatus(job))
+}
+
+// testDataPurgeStatusHandler reports the progress of a purge, with the report
+// of every saga step once it finished
+func (hs *HealthServer) testDataPurgeStatusHandler(w http.ResponseWriter, r *http.Request) {
+	store := hs.container.GetJobStore()
+	if store == nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]string{"error": purge.ErrDisabled.Error()})
+		return
+	}
+
+	jobs.StatusHandler(store, func(r *http.Request) string { return r.PathValue("id") }).ServeHTTP(w, r)
+}
+
+// sessionStatsHandler reports session statistics from the rolling counters, or
+// with ?recount=true from a slow read of every session, which also repairs the
+// status counters
+func (hs *HealthServer) sessionStatsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	recount, err := strconv.ParseBool(cmp.Or(r.URL.Query().Get("recount"), "false"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid recount: " + err.Error()})
+		return
+	}
+
+	authService := hs.container.GetAuthService()
+	getStats := authService.GetSessionStats
+	if recount {
+		hs.logger.Info(r.Context(), "Session statistics recount requested", map[string]interface{}{
+			"remote_addr": r.RemoteAddr,
+		})
+		getStats = authService.RecountSessionStats
+	}
+
+	stats, err := getStats(r.Context())
+	if err != nil {
+		hs.logger.Error(r.Context(), "Failed to get session statistics", err, map[string]interface{}{
+			"recount": recount,
+		})
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "failed to get session statistics"})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(stats)
+}
+
 // metricsHandler handles /metrics endpoint (basic metrics)
 func (hs *HealthServer) metricsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -297,6 +450,94 @@ iam_service_components_status %d
 		len(hs.container.GetHealthStatus().Services),
 	)
 
+	if purgeJob := hs.container.GetUserPurgeJob(); purgeJob != nil {
+		stats := purgeJob.Stats()
+		metrics += fmt.Sprintf(`
+# HELP iam_user_purge_runs_total Number of deleted user purge runs
+# TYPE iam_user_purge_runs_total counter
+iam_user_purge_runs_total %d
+
+# HELP iam_user_purge_users_total Number of deleted users permanently purged
+# TYPE iam_user_purge_users_total counter
+iam_user_purge_users_total %d
+
+# HELP iam_user_purge_failures_total Number of users that failed to purge
+# TYPE iam_user_purge_failures_total counter
+iam_user_purge_failures_total %d
+
+# HELP iam_user_purge_sessions_removed_total Number of sessions removed with purged users
+# TYPE iam_user_purge_sessions_removed_total counter
+iam_user_purge_sessions_removed_total %d
+
+# HELP iam_user_purge_last_run_duration_seconds Duration of the last purge run
+# TYPE iam_user_purge_last_run_duration_seconds gauge
+iam_user_purge_last_run_duration_seconds %f
+`,
+			stats.Runs,
+			stats.UsersPurged,
+			stats.Failures,
+			stats.SessionsRemoved,
+			stats.LastRunDuration,
+		)
+	}
+
+	if deletionJob := hs.container.GetAccountDeletionJob(); deletionJob != nil {
+		stats := deletionJob.Stats()
+		metrics += fmt.Sprintf(`
+# HELP iam_account_deletion_runs_total Number of account deletion runs
+# TYPE iam_account_deletion_runs_total counter
+iam_account_deletion_runs_total %d
+
+# HELP iam_account_deletion_users_total Number of accounts deleted after their grace period
+# TYPE iam_account_deletion_users_total counter
+iam_account_deletion_users_total %d
+
+# HELP iam_account_deletion_failures_total Number of accounts that failed to delete
+# TYPE iam_account_deletion_failures_total counter
+iam_account_deletion_failures_total %d
+
+# HELP iam_account_deletion_sessions_removed_total Number of sessions removed with deleted accounts
+# TYPE iam_account_deletion_sessions_removed_total counter
+iam_account_deletion_sessions_removed_total %d
+
+# HELP iam_account_deletion_last_run_duration_seconds Duration of the last account deletion run
+# TYPE iam_account_deletion_last_run_duration_seconds gauge
+iam_account_deletion_last_run_duration_seconds %f
+`,
+			stats.Runs,
+			stats.UsersDeleted,
+			stats.Failures,
+			stats.SessionsRemoved,
+			stats.LastRunDuration,
+		)
+	}
+
+	if relay := hs.container.GetUserEventRelay(); relay != nil {
+		stats := relay.Stats()
+		metrics += fmt.Sprintf(`
+# HELP iam_user_events_relay_runs_total Number of user event outbox relay runs
+# TYPE iam_user_events_relay_runs_total counter
+iam_user_events_relay_runs_total %d
+
+# HELP iam_user_events_published_total Number of user lifecycle events published from the outbox
+# TYPE iam_user_events_published_total counter
+iam_user_events_published_total %d
+
+# HELP iam_user_events_relay_failures_total Number of relay runs that failed to publish an event
+# TYPE iam_user_events_relay_failures_total counter
+iam_user_events_relay_failures_total %d
+
+# HELP iam_user_events_outbox_pending User lifecycle events waiting in the outbox
+# TYPE iam_user_events_outbox_pending gauge
+iam_user_events_outbox_pending %d
+`,
+			stats.Runs,
+			stats.EventsPublished,
+			stats.Failures,
+			stats.Pending,
+		)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(metrics))
 
@@ -349,6 +590,11 @@ func (hs *HealthServer) statsHandler(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
+	// Brute-force guard counters: rate-limited requests, failures and blocks
+	if anomalies, err := hs.container.GetBruteForceGuard().AnomalyCounts(ctx); err == nil {
+		statsResponse["token_endpoint_anomalies"] = anomalies
+	}
+
 	json.NewEncoder(w).Encode(statsResponse)
 
 	hs.logger.Debug(ctx, "Stats endpoint accessed")
@@ -2,13 +2,26 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/amiosamu/rocket-science/services/iam-service/internal/container"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/iam-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/authz"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
 )
 
+// identitySignatureTTL bounds how long the identity of a validated session is
+// trusted by services; the gateway validates the session on every request
+const identitySignatureTTL = time.Minute
+
 // SessionValidationServer provides HTTP session validation for Envoy
 type SessionValidationServer struct {
 	container *container.Container
@@ -27,6 +40,37 @@ type SessionValidationResponse struct {
 	Email   string `json:"email,omitempty"`
 	Role    string `json:"role,omitempty"`
 	Message string `json:"message,omitempty"`
+
+	// Permission snapshot of the session, forwarded by the gateway so services
+	// can authorize without calling CheckPermission
+	Permissions        []string `json:"permissions,omitempty"`
+	PermissionsVersion int64    `json:"permissions_version,omitempty"`
+
+	// Locale of the user's profile, forwarded by the gateway as X-User-Locale
+	Locale string `json:"locale,omitempty"`
+
+	// TenantID is the tenant a service client acts for, its client ID,
+	// forwarded by the gateway as X-Tenant-ID
+	TenantID string `json:"tenant_id,omitempty"`
+
+	// IdentitySignature signs the user ID, tenant, role and permissions,
+	// forwarded by the gateway as X-Identity-Signature; services only trust
+	// them along with it
+	IdentitySignature string `json:"identity_signature,omitempty"`
+
+	// Service client calling with a client credentials token instead of a
+	// user's session, and its request quota after counting this request
+	ClientID string         `json:"client_id,omitempty"`
+	Scopes   []string       `json:"scopes,omitempty"`
+	Quota    *QuotaResponse `json:"quota,omitempty"`
+}
+
+// QuotaResponse is a service client's consumption of its request quota
+type QuotaResponse struct {
+	Limit     int64     `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining int64     `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
 }
 
 // NewSessionValidationServer creates a new session validation server
@@ -98,23 +142,76 @@ func (s *SessionValidationServer) ValidateSessionHandler(w http.ResponseWriter,
 		return
 	}
 
+	// Throttle clients hammering the endpoint
+	guard := s.container.GetBruteForceGuard()
+	ip := clientIP(r)
+	if err := guard.Check(ctx, service.EndpointValidateSession, ip, ""); err != nil {
+		var throttled *service.ThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		}
+		response := SessionValidationResponse{
+			Valid:   false,
+			Message: "Too many attempts",
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	// Validate token with auth service
 	authService := s.container.GetAuthService()
 	tokenResult, err := authService.ValidateToken(ctx, sessionToken)
 	if err != nil {
+		// Service clients call with client credentials tokens
+		if client, clientErr := s.container.GetClientCredentialsService().ValidateToken(ctx, sessionToken); clientErr == nil {
+			s.validateClient(w, r, client)
+			return
+		}
+
+		// Re-stamped sessions hold a genuine token that only needs refreshing
+		message := "Session privileges changed, refresh the access token"
+		if !errors.Is(err, domain.ErrSessionRestamped) {
+			guard.RecordFailure(ctx, service.EndpointValidateSession, ip, "")
+			message = "Invalid or expired session"
+		}
 		s.logger.Debug(ctx, "Session validation failed", map[string]interface{}{
 			"error": err.Error(),
 		})
 
 		response := SessionValidationResponse{
 			Valid:   false,
-			Message: "Invalid or expired session",
+			Message: message,
 		}
 		w.WriteHeader(http.StatusUnauthorized)
 		json.NewEncoder(w).Encode(response)
 		return
 	}
 
+	// Restricted sessions only permit changing the temporary password
+	if tokenResult.PasswordChangeRequired {
+		response := SessionValidationResponse{
+			Valid:   false,
+			UserID:  tokenResult.User.ID,
+			Message: "Password change required",
+		}
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Accounts pending deletion only permit cancelling it
+	if tokenResult.DeletionPending {
+		response := SessionValidationResponse{
+			Valid:   false,
+			UserID:  tokenResult.User.ID,
+			Message: "Account pending deletion",
+		}
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	// Session is valid, return user info
 	response := SessionValidationResponse{
 		Valid:   true,
@@ -122,7 +219,13 @@ func (s *SessionValidationServer) ValidateSessionHandler(w http.ResponseWriter,
 		Email:   tokenResult.User.Email,
 		Role:    tokenResult.User.Role,
 		Message: "Session is valid",
+		Locale:  tokenResult.User.Locale,
 	}
+	if snapshot := tokenResult.Permissions; snapshot != nil {
+		response.Permissions = snapshot.Permissions
+		response.PermissionsVersion = snapshot.Version
+	}
+	s.signIdentity(&response)
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
@@ -133,10 +236,164 @@ func (s *SessionValidationServer) ValidateSessionHandler(w http.ResponseWriter,
 	})
 }
 
+// validateClient counts the request of a service client against its quota,
+// rejecting it once the quota is used up
+func (s *SessionValidationServer) validateClient(w http.ResponseWriter, r *http.Request, client *service.ClientTokenValidation) {
+	ctx := r.Context()
+	clientID := client.Claims.ClientID
+
+	usage, err := s.container.GetClientQuota().Consume(ctx, clientID)
+	if usage != nil {
+		writeQuotaHeaders(w, usage)
+	}
+
+	var exceeded *service.QuotaExceededError
+	if errors.As(err, &exceeded) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(exceeded.RetryAfter().Seconds()))))
+		response := SessionValidationResponse{
+			Valid:    false,
+			ClientID: clientID,
+			Message:  "Quota exceeded",
+			Quota:    newQuotaResponse(usage),
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	response := SessionValidationResponse{
+		Valid:    true,
+		ClientID: clientID,
+		Scopes:   client.Claims.Scopes,
+		Message:  "Client token is valid",
+		Quota:    newQuotaResponse(usage),
+		TenantID: clientID,
+	}
+	s.signIdentity(&response)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+
+	s.logger.Debug(ctx, "Client token validation successful", map[string]interface{}{
+		"client_id": clientID,
+	})
+}
+
+// signIdentity signs the identity of a valid session or client token
+func (s *SessionValidationServer) signIdentity(response *SessionValidationResponse) {
+	response.IdentitySignature = authz.SignIdentity(
+		[]byte(s.container.GetConfig().Security.GatewayIdentityKey),
+		response.UserID, response.TenantID, response.Role, strings.Join(response.Permissions, ","),
+		time.Now().Add(identitySignatureTTL))
+}
+
+// UsageHandler serves GET /my/usage: the calling service client's consumption
+// of its request quota in the current window and when it resets. Reading the
+// usage is not counted against the quota.
+func (s *SessionValidationServer) UsageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeUsageError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+		return
+	}
+
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		writeUsageError(w, http.StatusUnauthorized, "Unauthorized", "Client token is required")
+		return
+	}
+
+	guard := s.container.GetBruteForceGuard()
+	ip := clientIP(r)
+	if err := guard.Check(ctx, service.EndpointValidateSession, ip, ""); err != nil {
+		var throttled *service.ThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		}
+		writeUsageError(w, http.StatusTooManyRequests, "TooManyRequests", "Too many attempts")
+		return
+	}
+
+	client, err := s.container.GetClientCredentialsService().ValidateToken(ctx, token)
+	if err != nil {
+		guard.RecordFailure(ctx, service.EndpointValidateSession, ip, "")
+		writeUsageError(w, http.StatusUnauthorized, "Unauthorized", "Invalid or expired client token")
+		return
+	}
+
+	quota := s.container.GetClientQuota()
+	if !quota.Enabled() {
+		writeUsageError(w, http.StatusNotFound, "QuotaDisabled", "Client quotas are not enabled")
+		return
+	}
+
+	usage, err := quota.Usage(ctx, client.Claims.ClientID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get client quota usage", err, map[string]interface{}{
+			"client_id": client.Claims.ClientID,
+		})
+		writeUsageError(w, http.StatusServiceUnavailable, "Unavailable", "Quota usage is unavailable")
+		return
+	}
+
+	writeQuotaHeaders(w, usage)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(struct {
+		ClientID string `json:"client_id"`
+		*QuotaResponse
+	}{usage.ClientID, newQuotaResponse(usage)})
+}
+
+// writeQuotaHeaders reports the quota in the X-RateLimit headers the gateway
+// forwards to the client
+func writeQuotaHeaders(w http.ResponseWriter, usage *service.QuotaUsage) {
+	w.Header().Set("X-RateLimit-Limit", strconv.FormatInt(usage.Limit, 10))
+	w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(usage.Remaining, 10))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(usage.ResetAt.Unix(), 10))
+}
+
+// writeUsageError writes an error in the gateway's error format
+func writeUsageError(w http.ResponseWriter, status int, code, message string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": code, "message": message})
+}
+
+func newQuotaResponse(usage *service.QuotaUsage) *QuotaResponse {
+	if usage == nil {
+		return nil
+	}
+	return &QuotaResponse{
+		Limit:     usage.Limit,
+		Used:      usage.Used,
+		Remaining: usage.Remaining,
+		ResetAt:   usage.ResetAt,
+	}
+}
+
+// clientIP returns the end client address, preferring the one forwarded by the gateway
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		if ip := strings.TrimSpace(strings.Split(forwarded, ",")[0]); ip != "" {
+			return ip
+		}
+	}
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // SetupRoutes sets up the HTTP routes for session validation
 func (s *SessionValidationServer) SetupRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/validate-session", s.ValidateSessionHandler)
 	mux.HandleFunc("/auth/validate", s.ValidateSessionHandler) // Alternative endpoint
+	mux.HandleFunc("/my/usage", s.UsageHandler)
 }
 
 // StartValidationServer starts a simple HTTP server for session validation
@@ -167,7 +424,7 @@ func StartValidationServer(container *container.Container, port string) error {
 	// Create HTTP server
 	server := &http.Server{
 		Addr:    ":" + port,
-		Handler: mux,
+		Handler: recovery.Middleware(container.GetCrashReporter())(mux),
 	}
 
 	logger.Info(nil, "Starting session validation server", map[string]interface{}{
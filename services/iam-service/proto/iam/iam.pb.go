@@ -1,3165 +0,0 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.36.6
-// 	protoc        v5.29.3
-// source: proto/iam/iam.proto
-
-package iam
-
-import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
-	reflect "reflect"
-	sync "sync"
-	unsafe "unsafe"
-)
-
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
-
-type UserRole int32
-
-const (
-	UserRole_USER_ROLE_UNSPECIFIED UserRole = 0
-	UserRole_USER_ROLE_CUSTOMER    UserRole = 1 // Regular customers who place orders
-	UserRole_USER_ROLE_ADMIN       UserRole = 2 // System administrators
-	UserRole_USER_ROLE_OPERATOR    UserRole = 3 // Operations team (can manage orders/inventory)
-	UserRole_USER_ROLE_SUPPORT     UserRole = 4 // Customer support team
-)
-
-// Enum value maps for UserRole.
-var (
-	UserRole_name = map[int32]string{
-		0: "USER_ROLE_UNSPECIFIED",
-		1: "USER_ROLE_CUSTOMER",
-		2: "USER_ROLE_ADMIN",
-		3: "USER_ROLE_OPERATOR",
-		4: "USER_ROLE_SUPPORT",
-	}
-	UserRole_value = map[string]int32{
-		"USER_ROLE_UNSPECIFIED": 0,
-		"USER_ROLE_CUSTOMER":    1,
-		"USER_ROLE_ADMIN":       2,
-		"USER_ROLE_OPERATOR":    3,
-		"USER_ROLE_SUPPORT":     4,
-	}
-)
-
-func (x UserRole) Enum() *UserRole {
-	p := new(UserRole)
-	*p = x
-	return p
-}
-
-func (x UserRole) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
-}
-
-func (UserRole) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_iam_iam_proto_enumTypes[0].Descriptor()
-}
-
-func (UserRole) Type() protoreflect.EnumType {
-	return &file_proto_iam_iam_proto_enumTypes[0]
-}
-
-func (x UserRole) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
-}
-
-// Deprecated: Use UserRole.Descriptor instead.
-func (UserRole) EnumDescriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{0}
-}
-
-type UserStatus int32
-
-const (
-	UserStatus_USER_STATUS_UNSPECIFIED UserStatus = 0
-	UserStatus_USER_STATUS_ACTIVE      UserStatus = 1 // Active user
-	UserStatus_USER_STATUS_INACTIVE    UserStatus = 2 // Temporarily disabled
-	UserStatus_USER_STATUS_SUSPENDED   UserStatus = 3 // Suspended due to violations
-	UserStatus_USER_STATUS_DELETED     UserStatus = 4 // Soft deleted
-)
-
-// Enum value maps for UserStatus.
-var (
-	UserStatus_name = map[int32]string{
-		0: "USER_STATUS_UNSPECIFIED",
-		1: "USER_STATUS_ACTIVE",
-		2: "USER_STATUS_INACTIVE",
-		3: "USER_STATUS_SUSPENDED",
-		4: "USER_STATUS_DELETED",
-	}
-	UserStatus_value = map[string]int32{
-		"USER_STATUS_UNSPECIFIED": 0,
-		"USER_STATUS_ACTIVE":      1,
-		"USER_STATUS_INACTIVE":    2,
-		"USER_STATUS_SUSPENDED":   3,
-		"USER_STATUS_DELETED":     4,
-	}
-)
-
-func (x UserStatus) Enum() *UserStatus {
-	p := new(UserStatus)
-	*p = x
-	return p
-}
-
-func (x UserStatus) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
-}
-
-func (UserStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_iam_iam_proto_enumTypes[1].Descriptor()
-}
-
-func (UserStatus) Type() protoreflect.EnumType {
-	return &file_proto_iam_iam_proto_enumTypes[1]
-}
-
-func (x UserStatus) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
-}
-
-// Deprecated: Use UserStatus.Descriptor instead.
-func (UserStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{1}
-}
-
-type SessionStatus int32
-
-const (
-	SessionStatus_SESSION_STATUS_UNSPECIFIED SessionStatus = 0
-	SessionStatus_SESSION_STATUS_ACTIVE      SessionStatus = 1 // Active session
-	SessionStatus_SESSION_STATUS_EXPIRED     SessionStatus = 2 // Expired session
-	SessionStatus_SESSION_STATUS_REVOKED     SessionStatus = 3 // Manually revoked
-	SessionStatus_SESSION_STATUS_INVALID     SessionStatus = 4 // Invalid/corrupted session
-)
-
-// Enum value maps for SessionStatus.
-var (
-	SessionStatus_name = map[int32]string{
-		0: "SESSION_STATUS_UNSPECIFIED",
-		1: "SESSION_STATUS_ACTIVE",
-		2: "SESSION_STATUS_EXPIRED",
-		3: "SESSION_STATUS_REVOKED",
-		4: "SESSION_STATUS_INVALID",
-	}
-	SessionStatus_value = map[string]int32{
-		"SESSION_STATUS_UNSPECIFIED": 0,
-		"SESSION_STATUS_ACTIVE":      1,
-		"SESSION_STATUS_EXPIRED":     2,
-		"SESSION_STATUS_REVOKED":     3,
-		"SESSION_STATUS_INVALID":     4,
-	}
-)
-
-func (x SessionStatus) Enum() *SessionStatus {
-	p := new(SessionStatus)
-	*p = x
-	return p
-}
-
-func (x SessionStatus) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
-}
-
-func (SessionStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_iam_iam_proto_enumTypes[2].Descriptor()
-}
-
-func (SessionStatus) Type() protoreflect.EnumType {
-	return &file_proto_iam_iam_proto_enumTypes[2]
-}
-
-func (x SessionStatus) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
-}
-
-// Deprecated: Use SessionStatus.Descriptor instead.
-func (SessionStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{2}
-}
-
-type LoginRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	UserAgent     string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"` // For session tracking
-	IpAddress     string                 `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"` // For security tracking
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *LoginRequest) Reset() {
-	*x = LoginRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *LoginRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*LoginRequest) ProtoMessage() {}
-
-func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
-func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *LoginRequest) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
-}
-
-func (x *LoginRequest) GetPassword() string {
-	if x != nil {
-		return x.Password
-	}
-	return ""
-}
-
-func (x *LoginRequest) GetUserAgent() string {
-	if x != nil {
-		return x.UserAgent
-	}
-	return ""
-}
-
-func (x *LoginRequest) GetIpAddress() string {
-	if x != nil {
-		return x.IpAddress
-	}
-	return ""
-}
-
-type LoginResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	AccessToken   string                 `protobuf:"bytes,3,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`    // JWT token for API access
-	RefreshToken  string                 `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Token for refreshing access token
-	SessionId     string                 `protobuf:"bytes,5,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`          // Session identifier
-	User          *User                  `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`                                     // User information
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *LoginResponse) Reset() {
-	*x = LoginResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *LoginResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*LoginResponse) ProtoMessage() {}
-
-func (x *LoginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[1]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
-func (*LoginResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *LoginResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *LoginResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *LoginResponse) GetAccessToken() string {
-	if x != nil {
-		return x.AccessToken
-	}
-	return ""
-}
-
-func (x *LoginResponse) GetRefreshToken() string {
-	if x != nil {
-		return x.RefreshToken
-	}
-	return ""
-}
-
-func (x *LoginResponse) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
-}
-
-func (x *LoginResponse) GetUser() *User {
-	if x != nil {
-		return x.User
-	}
-	return nil
-}
-
-func (x *LoginResponse) GetExpiresAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ExpiresAt
-	}
-	return nil
-}
-
-type LogoutRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *LogoutRequest) Reset() {
-	*x = LogoutRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *LogoutRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*LogoutRequest) ProtoMessage() {}
-
-func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[2]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
-func (*LogoutRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *LogoutRequest) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
-}
-
-func (x *LogoutRequest) GetAccessToken() string {
-	if x != nil {
-		return x.AccessToken
-	}
-	return ""
-}
-
-type LogoutResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *LogoutResponse) Reset() {
-	*x = LogoutResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *LogoutResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*LogoutResponse) ProtoMessage() {}
-
-func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[3]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
-func (*LogoutResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *LogoutResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *LogoutResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-type RefreshTokenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
-	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *RefreshTokenRequest) Reset() {
-	*x = RefreshTokenRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *RefreshTokenRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*RefreshTokenRequest) ProtoMessage() {}
-
-func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[4]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
-func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *RefreshTokenRequest) GetRefreshToken() string {
-	if x != nil {
-		return x.RefreshToken
-	}
-	return ""
-}
-
-func (x *RefreshTokenRequest) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
-}
-
-type RefreshTokenResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	AccessToken   string                 `protobuf:"bytes,3,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *RefreshTokenResponse) Reset() {
-	*x = RefreshTokenResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *RefreshTokenResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*RefreshTokenResponse) ProtoMessage() {}
-
-func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[5]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
-func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *RefreshTokenResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *RefreshTokenResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *RefreshTokenResponse) GetAccessToken() string {
-	if x != nil {
-		return x.AccessToken
-	}
-	return ""
-}
-
-func (x *RefreshTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ExpiresAt
-	}
-	return nil
-}
-
-type ValidateSessionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ValidateSessionRequest) Reset() {
-	*x = ValidateSessionRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ValidateSessionRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ValidateSessionRequest) ProtoMessage() {}
-
-func (x *ValidateSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[6]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ValidateSessionRequest.ProtoReflect.Descriptor instead.
-func (*ValidateSessionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *ValidateSessionRequest) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
-}
-
-func (x *ValidateSessionRequest) GetAccessToken() string {
-	if x != nil {
-		return x.AccessToken
-	}
-	return ""
-}
-
-type ValidateSessionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`       // User info if session is valid
-	Session       *Session               `protobuf:"bytes,4,opt,name=session,proto3" json:"session,omitempty"` // Session details
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ValidateSessionResponse) Reset() {
-	*x = ValidateSessionResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ValidateSessionResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ValidateSessionResponse) ProtoMessage() {}
-
-func (x *ValidateSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[7]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ValidateSessionResponse.ProtoReflect.Descriptor instead.
-func (*ValidateSessionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *ValidateSessionResponse) GetValid() bool {
-	if x != nil {
-		return x.Valid
-	}
-	return false
-}
-
-func (x *ValidateSessionResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *ValidateSessionResponse) GetUser() *User {
-	if x != nil {
-		return x.User
-	}
-	return nil
-}
-
-func (x *ValidateSessionResponse) GetSession() *Session {
-	if x != nil {
-		return x.Session
-	}
-	return nil
-}
-
-type GetSessionInfoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetSessionInfoRequest) Reset() {
-	*x = GetSessionInfoRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetSessionInfoRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetSessionInfoRequest) ProtoMessage() {}
-
-func (x *GetSessionInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[8]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetSessionInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetSessionInfoRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *GetSessionInfoRequest) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
-}
-
-type GetSessionInfoResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
-	Session       *Session               `protobuf:"bytes,2,opt,name=session,proto3" json:"session,omitempty"`
-	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetSessionInfoResponse) Reset() {
-	*x = GetSessionInfoResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetSessionInfoResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetSessionInfoResponse) ProtoMessage() {}
-
-func (x *GetSessionInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[9]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetSessionInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetSessionInfoResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *GetSessionInfoResponse) GetFound() bool {
-	if x != nil {
-		return x.Found
-	}
-	return false
-}
-
-func (x *GetSessionInfoResponse) GetSession() *Session {
-	if x != nil {
-		return x.Session
-	}
-	return nil
-}
-
-func (x *GetSessionInfoResponse) GetUser() *User {
-	if x != nil {
-		return x.User
-	}
-	return nil
-}
-
-type InvalidateSessionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Why session is being invalidated
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *InvalidateSessionRequest) Reset() {
-	*x = InvalidateSessionRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *InvalidateSessionRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*InvalidateSessionRequest) ProtoMessage() {}
-
-func (x *InvalidateSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[10]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use InvalidateSessionRequest.ProtoReflect.Descriptor instead.
-func (*InvalidateSessionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *InvalidateSessionRequest) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
-}
-
-func (x *InvalidateSessionRequest) GetReason() string {
-	if x != nil {
-		return x.Reason
-	}
-	return ""
-}
-
-type InvalidateSessionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *InvalidateSessionResponse) Reset() {
-	*x = InvalidateSessionResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[11]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *InvalidateSessionResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*InvalidateSessionResponse) ProtoMessage() {}
-
-func (x *InvalidateSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[11]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use InvalidateSessionResponse.ProtoReflect.Descriptor instead.
-func (*InvalidateSessionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *InvalidateSessionResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *InvalidateSessionResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-type CreateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	FirstName     string                 `protobuf:"bytes,3,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName      string                 `protobuf:"bytes,4,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	Role          UserRole               `protobuf:"varint,5,opt,name=role,proto3,enum=iam.v1.UserRole" json:"role,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Additional user data
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateUserRequest) Reset() {
-	*x = CreateUserRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[12]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateUserRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CreateUserRequest) ProtoMessage() {}
-
-func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[12]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
-func (*CreateUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *CreateUserRequest) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
-}
-
-func (x *CreateUserRequest) GetPassword() string {
-	if x != nil {
-		return x.Password
-	}
-	return ""
-}
-
-func (x *CreateUserRequest) GetFirstName() string {
-	if x != nil {
-		return x.FirstName
-	}
-	return ""
-}
-
-func (x *CreateUserRequest) GetLastName() string {
-	if x != nil {
-		return x.LastName
-	}
-	return ""
-}
-
-func (x *CreateUserRequest) GetRole() UserRole {
-	if x != nil {
-		return x.Role
-	}
-	return UserRole_USER_ROLE_UNSPECIFIED
-}
-
-func (x *CreateUserRequest) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
-}
-
-type CreateUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
-	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateUserResponse) Reset() {
-	*x = CreateUserResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[13]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateUserResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CreateUserResponse) ProtoMessage() {}
-
-func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[13]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
-func (*CreateUserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *CreateUserResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *CreateUserResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *CreateUserResponse) GetUser() *User {
-	if x != nil {
-		return x.User
-	}
-	return nil
-}
-
-func (x *CreateUserResponse) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-type GetUserRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Identifier:
-	//
-	//	*GetUserRequest_UserId
-	//	*GetUserRequest_Email
-	Identifier    isGetUserRequest_Identifier `protobuf_oneof:"identifier"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetUserRequest) Reset() {
-	*x = GetUserRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[14]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetUserRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetUserRequest) ProtoMessage() {}
-
-func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[14]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
-func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *GetUserRequest) GetIdentifier() isGetUserRequest_Identifier {
-	if x != nil {
-		return x.Identifier
-	}
-	return nil
-}
-
-func (x *GetUserRequest) GetUserId() string {
-	if x != nil {
-		if x, ok := x.Identifier.(*GetUserRequest_UserId); ok {
-			return x.UserId
-		}
-	}
-	return ""
-}
-
-func (x *GetUserRequest) GetEmail() string {
-	if x != nil {
-		if x, ok := x.Identifier.(*GetUserRequest_Email); ok {
-			return x.Email
-		}
-	}
-	return ""
-}
-
-type isGetUserRequest_Identifier interface {
-	isGetUserRequest_Identifier()
-}
-
-type GetUserRequest_UserId struct {
-	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3,oneof"`
-}
-
-type GetUserRequest_Email struct {
-	Email string `protobuf:"bytes,2,opt,name=email,proto3,oneof"`
-}
-
-func (*GetUserRequest_UserId) isGetUserRequest_Identifier() {}
-
-func (*GetUserRequest_Email) isGetUserRequest_Identifier() {}
-
-type GetUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
-	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetUserResponse) Reset() {
-	*x = GetUserResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[15]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetUserResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetUserResponse) ProtoMessage() {}
-
-func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[15]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
-func (*GetUserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *GetUserResponse) GetFound() bool {
-	if x != nil {
-		return x.Found
-	}
-	return false
-}
-
-func (x *GetUserResponse) GetUser() *User {
-	if x != nil {
-		return x.User
-	}
-	return nil
-}
-
-func (x *GetUserResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-type UpdateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Email         *string                `protobuf:"bytes,2,opt,name=email,proto3,oneof" json:"email,omitempty"`
-	FirstName     *string                `protobuf:"bytes,3,opt,name=first_name,json=firstName,proto3,oneof" json:"first_name,omitempty"`
-	LastName      *string                `protobuf:"bytes,4,opt,name=last_name,json=lastName,proto3,oneof" json:"last_name,omitempty"`
-	Role          *UserRole              `protobuf:"varint,5,opt,name=role,proto3,enum=iam.v1.UserRole,oneof" json:"role,omitempty"`
-	Status        *UserStatus            `protobuf:"varint,6,opt,name=status,proto3,enum=iam.v1.UserStatus,oneof" json:"status,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UpdateUserRequest) Reset() {
-	*x = UpdateUserRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[16]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UpdateUserRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UpdateUserRequest) ProtoMessage() {}
-
-func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[16]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *UpdateUserRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *UpdateUserRequest) GetEmail() string {
-	if x != nil && x.Email != nil {
-		return *x.Email
-	}
-	return ""
-}
-
-func (x *UpdateUserRequest) GetFirstName() string {
-	if x != nil && x.FirstName != nil {
-		return *x.FirstName
-	}
-	return ""
-}
-
-func (x *UpdateUserRequest) GetLastName() string {
-	if x != nil && x.LastName != nil {
-		return *x.LastName
-	}
-	return ""
-}
-
-func (x *UpdateUserRequest) GetRole() UserRole {
-	if x != nil && x.Role != nil {
-		return *x.Role
-	}
-	return UserRole_USER_ROLE_UNSPECIFIED
-}
-
-func (x *UpdateUserRequest) GetStatus() UserStatus {
-	if x != nil && x.Status != nil {
-		return *x.Status
-	}
-	return UserStatus_USER_STATUS_UNSPECIFIED
-}
-
-func (x *UpdateUserRequest) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
-}
-
-type UpdateUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UpdateUserResponse) Reset() {
-	*x = UpdateUserResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[17]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UpdateUserResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UpdateUserResponse) ProtoMessage() {}
-
-func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[17]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
-func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{17}
-}
-
-func (x *UpdateUserResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *UpdateUserResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *UpdateUserResponse) GetUser() *User {
-	if x != nil {
-		return x.User
-	}
-	return nil
-}
-
-type DeleteUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeleteUserRequest) Reset() {
-	*x = DeleteUserRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[18]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *DeleteUserRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*DeleteUserRequest) ProtoMessage() {}
-
-func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[18]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
-func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{18}
-}
-
-func (x *DeleteUserRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *DeleteUserRequest) GetReason() string {
-	if x != nil {
-		return x.Reason
-	}
-	return ""
-}
-
-type DeleteUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeleteUserResponse) Reset() {
-	*x = DeleteUserResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[19]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *DeleteUserResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*DeleteUserResponse) ProtoMessage() {}
-
-func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[19]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
-func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{19}
-}
-
-func (x *DeleteUserResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *DeleteUserResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-type ListUsersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoleFilter    *UserRole              `protobuf:"varint,1,opt,name=role_filter,json=roleFilter,proto3,enum=iam.v1.UserRole,oneof" json:"role_filter,omitempty"`
-	StatusFilter  *UserStatus            `protobuf:"varint,2,opt,name=status_filter,json=statusFilter,proto3,enum=iam.v1.UserStatus,oneof" json:"status_filter,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
-	SearchQuery   string                 `protobuf:"bytes,5,opt,name=search_query,json=searchQuery,proto3" json:"search_query,omitempty"` // Search by name or email
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListUsersRequest) Reset() {
-	*x = ListUsersRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[20]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListUsersRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListUsersRequest) ProtoMessage() {}
-
-func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[20]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
-func (*ListUsersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{20}
-}
-
-func (x *ListUsersRequest) GetRoleFilter() UserRole {
-	if x != nil && x.RoleFilter != nil {
-		return *x.RoleFilter
-	}
-	return UserRole_USER_ROLE_UNSPECIFIED
-}
-
-func (x *ListUsersRequest) GetStatusFilter() UserStatus {
-	if x != nil && x.StatusFilter != nil {
-		return *x.StatusFilter
-	}
-	return UserStatus_USER_STATUS_UNSPECIFIED
-}
-
-func (x *ListUsersRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *ListUsersRequest) GetOffset() int32 {
-	if x != nil {
-		return x.Offset
-	}
-	return 0
-}
-
-func (x *ListUsersRequest) GetSearchQuery() string {
-	if x != nil {
-		return x.SearchQuery
-	}
-	return ""
-}
-
-type ListUsersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListUsersResponse) Reset() {
-	*x = ListUsersResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[21]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListUsersResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListUsersResponse) ProtoMessage() {}
-
-func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[21]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
-func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{21}
-}
-
-func (x *ListUsersResponse) GetUsers() []*User {
-	if x != nil {
-		return x.Users
-	}
-	return nil
-}
-
-func (x *ListUsersResponse) GetTotalCount() int32 {
-	if x != nil {
-		return x.TotalCount
-	}
-	return 0
-}
-
-func (x *ListUsersResponse) GetHasMore() bool {
-	if x != nil {
-		return x.HasMore
-	}
-	return false
-}
-
-type GetProfileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetProfileRequest) Reset() {
-	*x = GetProfileRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[22]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetProfileRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetProfileRequest) ProtoMessage() {}
-
-func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[22]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetProfileRequest.ProtoReflect.Descriptor instead.
-func (*GetProfileRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *GetProfileRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-type GetProfileResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
-	Profile       *UserProfile           `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetProfileResponse) Reset() {
-	*x = GetProfileResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[23]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetProfileResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetProfileResponse) ProtoMessage() {}
-
-func (x *GetProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[23]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetProfileResponse.ProtoReflect.Descriptor instead.
-func (*GetProfileResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *GetProfileResponse) GetFound() bool {
-	if x != nil {
-		return x.Found
-	}
-	return false
-}
-
-func (x *GetProfileResponse) GetProfile() *UserProfile {
-	if x != nil {
-		return x.Profile
-	}
-	return nil
-}
-
-type UpdateProfileRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	FirstName        *string                `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3,oneof" json:"first_name,omitempty"`
-	LastName         *string                `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3,oneof" json:"last_name,omitempty"`
-	Phone            *string                `protobuf:"bytes,4,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
-	TelegramUsername *string                `protobuf:"bytes,5,opt,name=telegram_username,json=telegramUsername,proto3,oneof" json:"telegram_username,omitempty"`
-	Preferences      map[string]string      `protobuf:"bytes,6,rep,name=preferences,proto3" json:"preferences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
-}
-
-func (x *UpdateProfileRequest) Reset() {
-	*x = UpdateProfileRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[24]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UpdateProfileRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UpdateProfileRequest) ProtoMessage() {}
-
-func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[24]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{24}
-}
-
-func (x *UpdateProfileRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *UpdateProfileRequest) GetFirstName() string {
-	if x != nil && x.FirstName != nil {
-		return *x.FirstName
-	}
-	return ""
-}
-
-func (x *UpdateProfileRequest) GetLastName() string {
-	if x != nil && x.LastName != nil {
-		return *x.LastName
-	}
-	return ""
-}
-
-func (x *UpdateProfileRequest) GetPhone() string {
-	if x != nil && x.Phone != nil {
-		return *x.Phone
-	}
-	return ""
-}
-
-func (x *UpdateProfileRequest) GetTelegramUsername() string {
-	if x != nil && x.TelegramUsername != nil {
-		return *x.TelegramUsername
-	}
-	return ""
-}
-
-func (x *UpdateProfileRequest) GetPreferences() map[string]string {
-	if x != nil {
-		return x.Preferences
-	}
-	return nil
-}
-
-type UpdateProfileResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Profile       *UserProfile           `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UpdateProfileResponse) Reset() {
-	*x = UpdateProfileResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[25]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UpdateProfileResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UpdateProfileResponse) ProtoMessage() {}
-
-func (x *UpdateProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[25]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UpdateProfileResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProfileResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{25}
-}
-
-func (x *UpdateProfileResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *UpdateProfileResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *UpdateProfileResponse) GetProfile() *UserProfile {
-	if x != nil {
-		return x.Profile
-	}
-	return nil
-}
-
-type ChangePasswordRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CurrentPassword string                 `protobuf:"bytes,2,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
-	NewPassword     string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
-}
-
-func (x *ChangePasswordRequest) Reset() {
-	*x = ChangePasswordRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[26]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ChangePasswordRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ChangePasswordRequest) ProtoMessage() {}
-
-func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[26]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
-func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{26}
-}
-
-func (x *ChangePasswordRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *ChangePasswordRequest) GetCurrentPassword() string {
-	if x != nil {
-		return x.CurrentPassword
-	}
-	return ""
-}
-
-func (x *ChangePasswordRequest) GetNewPassword() string {
-	if x != nil {
-		return x.NewPassword
-	}
-	return ""
-}
-
-type ChangePasswordResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ChangePasswordResponse) Reset() {
-	*x = ChangePasswordResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[27]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ChangePasswordResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ChangePasswordResponse) ProtoMessage() {}
-
-func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[27]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
-func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{27}
-}
-
-func (x *ChangePasswordResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *ChangePasswordResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-type CheckPermissionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Resource      string                 `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"` // e.g., "orders", "inventory", "admin"
-	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`     // e.g., "read", "write", "delete"
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CheckPermissionRequest) Reset() {
-	*x = CheckPermissionRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[28]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CheckPermissionRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CheckPermissionRequest) ProtoMessage() {}
-
-func (x *CheckPermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[28]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CheckPermissionRequest.ProtoReflect.Descriptor instead.
-func (*CheckPermissionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{28}
-}
-
-func (x *CheckPermissionRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *CheckPermissionRequest) GetResource() string {
-	if x != nil {
-		return x.Resource
-	}
-	return ""
-}
-
-func (x *CheckPermissionRequest) GetAction() string {
-	if x != nil {
-		return x.Action
-	}
-	return ""
-}
-
-type CheckPermissionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Permissions   []string               `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions,omitempty"` // All user permissions
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CheckPermissionResponse) Reset() {
-	*x = CheckPermissionResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[29]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CheckPermissionResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CheckPermissionResponse) ProtoMessage() {}
-
-func (x *CheckPermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[29]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CheckPermissionResponse.ProtoReflect.Descriptor instead.
-func (*CheckPermissionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{29}
-}
-
-func (x *CheckPermissionResponse) GetAllowed() bool {
-	if x != nil {
-		return x.Allowed
-	}
-	return false
-}
-
-func (x *CheckPermissionResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *CheckPermissionResponse) GetPermissions() []string {
-	if x != nil {
-		return x.Permissions
-	}
-	return nil
-}
-
-type GetUserPermissionsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetUserPermissionsRequest) Reset() {
-	*x = GetUserPermissionsRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[30]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetUserPermissionsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetUserPermissionsRequest) ProtoMessage() {}
-
-func (x *GetUserPermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[30]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetUserPermissionsRequest.ProtoReflect.Descriptor instead.
-func (*GetUserPermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{30}
-}
-
-func (x *GetUserPermissionsRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-type GetUserPermissionsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Permissions   []string               `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
-	Role          UserRole               `protobuf:"varint,2,opt,name=role,proto3,enum=iam.v1.UserRole" json:"role,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetUserPermissionsResponse) Reset() {
-	*x = GetUserPermissionsResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[31]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetUserPermissionsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetUserPermissionsResponse) ProtoMessage() {}
-
-func (x *GetUserPermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[31]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetUserPermissionsResponse.ProtoReflect.Descriptor instead.
-func (*GetUserPermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{31}
-}
-
-func (x *GetUserPermissionsResponse) GetPermissions() []string {
-	if x != nil {
-		return x.Permissions
-	}
-	return nil
-}
-
-func (x *GetUserPermissionsResponse) GetRole() UserRole {
-	if x != nil {
-		return x.Role
-	}
-	return UserRole_USER_ROLE_UNSPECIFIED
-}
-
-type GetUserTelegramChatIDRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetUserTelegramChatIDRequest) Reset() {
-	*x = GetUserTelegramChatIDRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[32]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetUserTelegramChatIDRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetUserTelegramChatIDRequest) ProtoMessage() {}
-
-func (x *GetUserTelegramChatIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[32]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetUserTelegramChatIDRequest.ProtoReflect.Descriptor instead.
-func (*GetUserTelegramChatIDRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{32}
-}
-
-func (x *GetUserTelegramChatIDRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-type GetUserTelegramChatIDResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Found            bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
-	ChatId           string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	TelegramUsername string                 `protobuf:"bytes,3,opt,name=telegram_username,json=telegramUsername,proto3" json:"telegram_username,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
-}
-
-func (x *GetUserTelegramChatIDResponse) Reset() {
-	*x = GetUserTelegramChatIDResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[33]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetUserTelegramChatIDResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetUserTelegramChatIDResponse) ProtoMessage() {}
-
-func (x *GetUserTelegramChatIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[33]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetUserTelegramChatIDResponse.ProtoReflect.Descriptor instead.
-func (*GetUserTelegramChatIDResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{33}
-}
-
-func (x *GetUserTelegramChatIDResponse) GetFound() bool {
-	if x != nil {
-		return x.Found
-	}
-	return false
-}
-
-func (x *GetUserTelegramChatIDResponse) GetChatId() string {
-	if x != nil {
-		return x.ChatId
-	}
-	return ""
-}
-
-func (x *GetUserTelegramChatIDResponse) GetTelegramUsername() string {
-	if x != nil {
-		return x.TelegramUsername
-	}
-	return ""
-}
-
-type UpdateTelegramChatIDRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	ChatId           string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	TelegramUsername string                 `protobuf:"bytes,3,opt,name=telegram_username,json=telegramUsername,proto3" json:"telegram_username,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
-}
-
-func (x *UpdateTelegramChatIDRequest) Reset() {
-	*x = UpdateTelegramChatIDRequest{}
-	mi := &file_proto_iam_iam_proto_msgTypes[34]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UpdateTelegramChatIDRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UpdateTelegramChatIDRequest) ProtoMessage() {}
-
-func (x *UpdateTelegramChatIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[34]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UpdateTelegramChatIDRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTelegramChatIDRequest) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{34}
-}
-
-func (x *UpdateTelegramChatIDRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *UpdateTelegramChatIDRequest) GetChatId() string {
-	if x != nil {
-		return x.ChatId
-	}
-	return ""
-}
-
-func (x *UpdateTelegramChatIDRequest) GetTelegramUsername() string {
-	if x != nil {
-		return x.TelegramUsername
-	}
-	return ""
-}
-
-type UpdateTelegramChatIDResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UpdateTelegramChatIDResponse) Reset() {
-	*x = UpdateTelegramChatIDResponse{}
-	mi := &file_proto_iam_iam_proto_msgTypes[35]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UpdateTelegramChatIDResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UpdateTelegramChatIDResponse) ProtoMessage() {}
-
-func (x *UpdateTelegramChatIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[35]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UpdateTelegramChatIDResponse.ProtoReflect.Descriptor instead.
-func (*UpdateTelegramChatIDResponse) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{35}
-}
-
-func (x *UpdateTelegramChatIDResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *UpdateTelegramChatIDResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	FirstName     string                 `protobuf:"bytes,3,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName      string                 `protobuf:"bytes,4,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	Role          UserRole               `protobuf:"varint,5,opt,name=role,proto3,enum=iam.v1.UserRole" json:"role,omitempty"`
-	Status        UserStatus             `protobuf:"varint,6,opt,name=status,proto3,enum=iam.v1.UserStatus" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	LastLoginAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *User) Reset() {
-	*x = User{}
-	mi := &file_proto_iam_iam_proto_msgTypes[36]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *User) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*User) ProtoMessage() {}
-
-func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[36]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{36}
-}
-
-func (x *User) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *User) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
-}
-
-func (x *User) GetFirstName() string {
-	if x != nil {
-		return x.FirstName
-	}
-	return ""
-}
-
-func (x *User) GetLastName() string {
-	if x != nil {
-		return x.LastName
-	}
-	return ""
-}
-
-func (x *User) GetRole() UserRole {
-	if x != nil {
-		return x.Role
-	}
-	return UserRole_USER_ROLE_UNSPECIFIED
-}
-
-func (x *User) GetStatus() UserStatus {
-	if x != nil {
-		return x.Status
-	}
-	return UserStatus_USER_STATUS_UNSPECIFIED
-}
-
-func (x *User) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
-}
-
-func (x *User) GetLastLoginAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.LastLoginAt
-	}
-	return nil
-}
-
-func (x *User) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
-}
-
-type UserProfile struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	FirstName        string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName         string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	Email            string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
-	Phone            string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
-	TelegramUsername string                 `protobuf:"bytes,6,opt,name=telegram_username,json=telegramUsername,proto3" json:"telegram_username,omitempty"`
-	TelegramChatId   string                 `protobuf:"bytes,7,opt,name=telegram_chat_id,json=telegramChatId,proto3" json:"telegram_chat_id,omitempty"`
-	Preferences      map[string]string      `protobuf:"bytes,8,rep,name=preferences,proto3" json:"preferences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
-}
-
-func (x *UserProfile) Reset() {
-	*x = UserProfile{}
-	mi := &file_proto_iam_iam_proto_msgTypes[37]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UserProfile) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UserProfile) ProtoMessage() {}
-
-func (x *UserProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[37]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
-func (*UserProfile) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{37}
-}
-
-func (x *UserProfile) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *UserProfile) GetFirstName() string {
-	if x != nil {
-		return x.FirstName
-	}
-	return ""
-}
-
-func (x *UserProfile) GetLastName() string {
-	if x != nil {
-		return x.LastName
-	}
-	return ""
-}
-
-func (x *UserProfile) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
-}
-
-func (x *UserProfile) GetPhone() string {
-	if x != nil {
-		return x.Phone
-	}
-	return ""
-}
-
-func (x *UserProfile) GetTelegramUsername() string {
-	if x != nil {
-		return x.TelegramUsername
-	}
-	return ""
-}
-
-func (x *UserProfile) GetTelegramChatId() string {
-	if x != nil {
-		return x.TelegramChatId
-	}
-	return ""
-}
-
-func (x *UserProfile) GetPreferences() map[string]string {
-	if x != nil {
-		return x.Preferences
-	}
-	return nil
-}
-
-func (x *UserProfile) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
-}
-
-type Session struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	AccessToken    string                 `protobuf:"bytes,3,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	RefreshToken   string                 `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
-	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	LastAccessedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_accessed_at,json=lastAccessedAt,proto3" json:"last_accessed_at,omitempty"`
-	IpAddress      string                 `protobuf:"bytes,8,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
-	UserAgent      string                 `protobuf:"bytes,9,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
-	Status         SessionStatus          `protobuf:"varint,10,opt,name=status,proto3,enum=iam.v1.SessionStatus" json:"status,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
-}
-
-func (x *Session) Reset() {
-	*x = Session{}
-	mi := &file_proto_iam_iam_proto_msgTypes[38]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *Session) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Session) ProtoMessage() {}
-
-func (x *Session) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_iam_iam_proto_msgTypes[38]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Session.ProtoReflect.Descriptor instead.
-func (*Session) Descriptor() ([]byte, []int) {
-	return file_proto_iam_iam_proto_rawDescGZIP(), []int{38}
-}
-
-func (x *Session) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Session) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *Session) GetAccessToken() string {
-	if x != nil {
-		return x.AccessToken
-	}
-	return ""
-}
-
-func (x *Session) GetRefreshToken() string {
-	if x != nil {
-		return x.RefreshToken
-	}
-	return ""
-}
-
-func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *Session) GetExpiresAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ExpiresAt
-	}
-	return nil
-}
-
-func (x *Session) GetLastAccessedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.LastAccessedAt
-	}
-	return nil
-}
-
-func (x *Session) GetIpAddress() string {
-	if x != nil {
-		return x.IpAddress
-	}
-	return ""
-}
-
-func (x *Session) GetUserAgent() string {
-	if x != nil {
-		return x.UserAgent
-	}
-	return ""
-}
-
-func (x *Session) GetStatus() SessionStatus {
-	if x != nil {
-		return x.Status
-	}
-	return SessionStatus_SESSION_STATUS_UNSPECIFIED
-}
-
-var File_proto_iam_iam_proto protoreflect.FileDescriptor
-
-const file_proto_iam_iam_proto_rawDesc = "" +
-	"\n" +
-	"\x13proto/iam/iam.proto\x12\x06iam.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"~\n" +
-	"\fLoginRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1d\n" +
-	"\n" +
-	"user_agent\x18\x03 \x01(\tR\tuserAgent\x12\x1d\n" +
-	"\n" +
-	"ip_address\x18\x04 \x01(\tR\tipAddress\"\x87\x02\n" +
-	"\rLoginResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12!\n" +
-	"\faccess_token\x18\x03 \x01(\tR\vaccessToken\x12#\n" +
-	"\rrefresh_token\x18\x04 \x01(\tR\frefreshToken\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x05 \x01(\tR\tsessionId\x12 \n" +
-	"\x04user\x18\x06 \x01(\v2\f.iam.v1.UserR\x04user\x129\n" +
-	"\n" +
-	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"Q\n" +
-	"\rLogoutRequest\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x12!\n" +
-	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\"D\n" +
-	"\x0eLogoutResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"Y\n" +
-	"\x13RefreshTokenRequest\x12#\n" +
-	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x02 \x01(\tR\tsessionId\"\xa8\x01\n" +
-	"\x14RefreshTokenResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12!\n" +
-	"\faccess_token\x18\x03 \x01(\tR\vaccessToken\x129\n" +
-	"\n" +
-	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"Z\n" +
-	"\x16ValidateSessionRequest\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x12!\n" +
-	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\"\x96\x01\n" +
-	"\x17ValidateSessionResponse\x12\x14\n" +
-	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12 \n" +
-	"\x04user\x18\x03 \x01(\v2\f.iam.v1.UserR\x04user\x12)\n" +
-	"\asession\x18\x04 \x01(\v2\x0f.iam.v1.SessionR\asession\"6\n" +
-	"\x15GetSessionInfoRequest\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\"{\n" +
-	"\x16GetSessionInfoResponse\x12\x14\n" +
-	"\x05found\x18\x01 \x01(\bR\x05found\x12)\n" +
-	"\asession\x18\x02 \x01(\v2\x0f.iam.v1.SessionR\asession\x12 \n" +
-	"\x04user\x18\x03 \x01(\v2\f.iam.v1.UserR\x04user\"Q\n" +
-	"\x18InvalidateSessionRequest\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x16\n" +
-	"\x06reason\x18\x02 \x01(\tR\x06reason\"O\n" +
-	"\x19InvalidateSessionResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xa9\x02\n" +
-	"\x11CreateUserRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x03 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x04 \x01(\tR\blastName\x12$\n" +
-	"\x04role\x18\x05 \x01(\x0e2\x10.iam.v1.UserRoleR\x04role\x12C\n" +
-	"\bmetadata\x18\x06 \x03(\v2'.iam.v1.CreateUserRequest.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x83\x01\n" +
-	"\x12CreateUserResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12 \n" +
-	"\x04user\x18\x03 \x01(\v2\f.iam.v1.UserR\x04user\x12\x17\n" +
-	"\auser_id\x18\x04 \x01(\tR\x06userId\"Q\n" +
-	"\x0eGetUserRequest\x12\x19\n" +
-	"\auser_id\x18\x01 \x01(\tH\x00R\x06userId\x12\x16\n" +
-	"\x05email\x18\x02 \x01(\tH\x00R\x05emailB\f\n" +
-	"\n" +
-	"identifier\"c\n" +
-	"\x0fGetUserResponse\x12\x14\n" +
-	"\x05found\x18\x01 \x01(\bR\x05found\x12 \n" +
-	"\x04user\x18\x02 \x01(\v2\f.iam.v1.UserR\x04user\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\xa6\x03\n" +
-	"\x11UpdateUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
-	"\x05email\x18\x02 \x01(\tH\x00R\x05email\x88\x01\x01\x12\"\n" +
-	"\n" +
-	"first_name\x18\x03 \x01(\tH\x01R\tfirstName\x88\x01\x01\x12 \n" +
-	"\tlast_name\x18\x04 \x01(\tH\x02R\blastName\x88\x01\x01\x12)\n" +
-	"\x04role\x18\x05 \x01(\x0e2\x10.iam.v1.UserRoleH\x03R\x04role\x88\x01\x01\x12/\n" +
-	"\x06status\x18\x06 \x01(\x0e2\x12.iam.v1.UserStatusH\x04R\x06status\x88\x01\x01\x12C\n" +
-	"\bmetadata\x18\a \x03(\v2'.iam.v1.UpdateUserRequest.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
-	"\x06_emailB\r\n" +
-	"\v_first_nameB\f\n" +
-	"\n" +
-	"_last_nameB\a\n" +
-	"\x05_roleB\t\n" +
-	"\a_status\"j\n" +
-	"\x12UpdateUserResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12 \n" +
-	"\x04user\x18\x03 \x01(\v2\f.iam.v1.UserR\x04user\"D\n" +
-	"\x11DeleteUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
-	"\x06reason\x18\x02 \x01(\tR\x06reason\"H\n" +
-	"\x12DeleteUserResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xfb\x01\n" +
-	"\x10ListUsersRequest\x126\n" +
-	"\vrole_filter\x18\x01 \x01(\x0e2\x10.iam.v1.UserRoleH\x00R\n" +
-	"roleFilter\x88\x01\x01\x12<\n" +
-	"\rstatus_filter\x18\x02 \x01(\x0e2\x12.iam.v1.UserStatusH\x01R\fstatusFilter\x88\x01\x01\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12!\n" +
-	"\fsearch_query\x18\x05 \x01(\tR\vsearchQueryB\x0e\n" +
-	"\f_role_filterB\x10\n" +
-	"\x0e_status_filter\"s\n" +
-	"\x11ListUsersResponse\x12\"\n" +
-	"\x05users\x18\x01 \x03(\v2\f.iam.v1.UserR\x05users\x12\x1f\n" +
-	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\x12\x19\n" +
-	"\bhas_more\x18\x03 \x01(\bR\ahasMore\",\n" +
-	"\x11GetProfileRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"Y\n" +
-	"\x12GetProfileResponse\x12\x14\n" +
-	"\x05found\x18\x01 \x01(\bR\x05found\x12-\n" +
-	"\aprofile\x18\x02 \x01(\v2\x13.iam.v1.UserProfileR\aprofile\"\x90\x03\n" +
-	"\x14UpdateProfileRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\"\n" +
-	"\n" +
-	"first_name\x18\x02 \x01(\tH\x00R\tfirstName\x88\x01\x01\x12 \n" +
-	"\tlast_name\x18\x03 \x01(\tH\x01R\blastName\x88\x01\x01\x12\x19\n" +
-	"\x05phone\x18\x04 \x01(\tH\x02R\x05phone\x88\x01\x01\x120\n" +
-	"\x11telegram_username\x18\x05 \x01(\tH\x03R\x10telegramUsername\x88\x01\x01\x12O\n" +
-	"\vpreferences\x18\x06 \x03(\v2-.iam.v1.UpdateProfileRequest.PreferencesEntryR\vpreferences\x1a>\n" +
-	"\x10PreferencesEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\r\n" +
-	"\v_first_nameB\f\n" +
-	"\n" +
-	"_last_nameB\b\n" +
-	"\x06_phoneB\x14\n" +
-	"\x12_telegram_username\"z\n" +
-	"\x15UpdateProfileResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12-\n" +
-	"\aprofile\x18\x03 \x01(\v2\x13.iam.v1.UserProfileR\aprofile\"~\n" +
-	"\x15ChangePasswordRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
-	"\x10current_password\x18\x02 \x01(\tR\x0fcurrentPassword\x12!\n" +
-	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\"L\n" +
-	"\x16ChangePasswordResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"e\n" +
-	"\x16CheckPermissionRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
-	"\bresource\x18\x02 \x01(\tR\bresource\x12\x16\n" +
-	"\x06action\x18\x03 \x01(\tR\x06action\"o\n" +
-	"\x17CheckPermissionResponse\x12\x18\n" +
-	"\aallowed\x18\x01 \x01(\bR\aallowed\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12 \n" +
-	"\vpermissions\x18\x03 \x03(\tR\vpermissions\"4\n" +
-	"\x19GetUserPermissionsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"d\n" +
-	"\x1aGetUserPermissionsResponse\x12 \n" +
-	"\vpermissions\x18\x01 \x03(\tR\vpermissions\x12$\n" +
-	"\x04role\x18\x02 \x01(\x0e2\x10.iam.v1.UserRoleR\x04role\"7\n" +
-	"\x1cGetUserTelegramChatIDRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"{\n" +
-	"\x1dGetUserTelegramChatIDResponse\x12\x14\n" +
-	"\x05found\x18\x01 \x01(\bR\x05found\x12\x17\n" +
-	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12+\n" +
-	"\x11telegram_username\x18\x03 \x01(\tR\x10telegramUsername\"|\n" +
-	"\x1bUpdateTelegramChatIDRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
-	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12+\n" +
-	"\x11telegram_username\x18\x03 \x01(\tR\x10telegramUsername\"R\n" +
-	"\x1cUpdateTelegramChatIDResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xe5\x03\n" +
-	"\x04User\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x03 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x04 \x01(\tR\blastName\x12$\n" +
-	"\x04role\x18\x05 \x01(\x0e2\x10.iam.v1.UserRoleR\x04role\x12*\n" +
-	"\x06status\x18\x06 \x01(\x0e2\x12.iam.v1.UserStatusR\x06status\x129\n" +
-	"\n" +
-	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
-	"\rlast_login_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vlastLoginAt\x126\n" +
-	"\bmetadata\x18\n" +
-	" \x03(\v2\x1a.iam.v1.User.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa8\x03\n" +
-	"\vUserProfile\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x14\n" +
-	"\x05email\x18\x04 \x01(\tR\x05email\x12\x14\n" +
-	"\x05phone\x18\x05 \x01(\tR\x05phone\x12+\n" +
-	"\x11telegram_username\x18\x06 \x01(\tR\x10telegramUsername\x12(\n" +
-	"\x10telegram_chat_id\x18\a \x01(\tR\x0etelegramChatId\x12F\n" +
-	"\vpreferences\x18\b \x03(\v2$.iam.v1.UserProfile.PreferencesEntryR\vpreferences\x129\n" +
-	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a>\n" +
-	"\x10PreferencesEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa3\x03\n" +
-	"\aSession\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
-	"\faccess_token\x18\x03 \x01(\tR\vaccessToken\x12#\n" +
-	"\rrefresh_token\x18\x04 \x01(\tR\frefreshToken\x129\n" +
-	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12D\n" +
-	"\x10last_accessed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0elastAccessedAt\x12\x1d\n" +
-	"\n" +
-	"ip_address\x18\b \x01(\tR\tipAddress\x12\x1d\n" +
-	"\n" +
-	"user_agent\x18\t \x01(\tR\tuserAgent\x12-\n" +
-	"\x06status\x18\n" +
-	" \x01(\x0e2\x15.iam.v1.SessionStatusR\x06status*\x81\x01\n" +
-	"\bUserRole\x12\x19\n" +
-	"\x15USER_ROLE_UNSPECIFIED\x10\x00\x12\x16\n" +
-	"\x12USER_ROLE_CUSTOMER\x10\x01\x12\x13\n" +
-	"\x0fUSER_ROLE_ADMIN\x10\x02\x12\x16\n" +
-	"\x12USER_ROLE_OPERATOR\x10\x03\x12\x15\n" +
-	"\x11USER_ROLE_SUPPORT\x10\x04*\x8f\x01\n" +
-	"\n" +
-	"UserStatus\x12\x1b\n" +
-	"\x17USER_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
-	"\x12USER_STATUS_ACTIVE\x10\x01\x12\x18\n" +
-	"\x14USER_STATUS_INACTIVE\x10\x02\x12\x19\n" +
-	"\x15USER_STATUS_SUSPENDED\x10\x03\x12\x17\n" +
-	"\x13USER_STATUS_DELETED\x10\x04*\x9e\x01\n" +
-	"\rSessionStatus\x12\x1e\n" +
-	"\x1aSESSION_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
-	"\x15SESSION_STATUS_ACTIVE\x10\x01\x12\x1a\n" +
-	"\x16SESSION_STATUS_EXPIRED\x10\x02\x12\x1a\n" +
-	"\x16SESSION_STATUS_REVOKED\x10\x03\x12\x1a\n" +
-	"\x16SESSION_STATUS_INVALID\x10\x042\xf0\n" +
-	"\n" +
-	"\n" +
-	"IAMService\x124\n" +
-	"\x05Login\x12\x14.iam.v1.LoginRequest\x1a\x15.iam.v1.LoginResponse\x127\n" +
-	"\x06Logout\x12\x15.iam.v1.LogoutRequest\x1a\x16.iam.v1.LogoutResponse\x12I\n" +
-	"\fRefreshToken\x12\x1b.iam.v1.RefreshTokenRequest\x1a\x1c.iam.v1.RefreshTokenResponse\x12R\n" +
-	"\x0fValidateSession\x12\x1e.iam.v1.ValidateSessionRequest\x1a\x1f.iam.v1.ValidateSessionResponse\x12O\n" +
-	"\x0eGetSessionInfo\x12\x1d.iam.v1.GetSessionInfoRequest\x1a\x1e.iam.v1.GetSessionInfoResponse\x12X\n" +
-	"\x11InvalidateSession\x12 .iam.v1.InvalidateSessionRequest\x1a!.iam.v1.InvalidateSessionResponse\x12C\n" +
-	"\n" +
-	"CreateUser\x12\x19.iam.v1.CreateUserRequest\x1a\x1a.iam.v1.CreateUserResponse\x12:\n" +
-	"\aGetUser\x12\x16.iam.v1.GetUserRequest\x1a\x17.iam.v1.GetUserResponse\x12C\n" +
-	"\n" +
-	"UpdateUser\x12\x19.iam.v1.UpdateUserRequest\x1a\x1a.iam.v1.UpdateUserResponse\x12C\n" +
-	"\n" +
-	"DeleteUser\x12\x19.iam.v1.DeleteUserRequest\x1a\x1a.iam.v1.DeleteUserResponse\x12@\n" +
-	"\tListUsers\x12\x18.iam.v1.ListUsersRequest\x1a\x19.iam.v1.ListUsersResponse\x12C\n" +
-	"\n" +
-	"GetProfile\x12\x19.iam.v1.GetProfileRequest\x1a\x1a.iam.v1.GetProfileResponse\x12L\n" +
-	"\rUpdateProfile\x12\x1c.iam.v1.UpdateProfileRequest\x1a\x1d.iam.v1.UpdateProfileResponse\x12O\n" +
-	"\x0eChangePassword\x12\x1d.iam.v1.ChangePasswordRequest\x1a\x1e.iam.v1.ChangePasswordResponse\x12R\n" +
-	"\x0fCheckPermission\x12\x1e.iam.v1.CheckPermissionRequest\x1a\x1f.iam.v1.CheckPermissionResponse\x12[\n" +
-	"\x12GetUserPermissions\x12!.iam.v1.GetUserPermissionsRequest\x1a\".iam.v1.GetUserPermissionsResponse\x12d\n" +
-	"\x15GetUserTelegramChatID\x12$.iam.v1.GetUserTelegramChatIDRequest\x1a%.iam.v1.GetUserTelegramChatIDResponse\x12a\n" +
-	"\x14UpdateTelegramChatID\x12#.iam.v1.UpdateTelegramChatIDRequest\x1a$.iam.v1.UpdateTelegramChatIDResponseBCZAgithub.com/amiosamu/rocket-science/services/iam-service/proto/iamb\x06proto3"
-
-var (
-	file_proto_iam_iam_proto_rawDescOnce sync.Once
-	file_proto_iam_iam_proto_rawDescData []byte
-)
-
-func file_proto_iam_iam_proto_rawDescGZIP() []byte {
-	file_proto_iam_iam_proto_rawDescOnce.Do(func() {
-		file_proto_iam_iam_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_iam_iam_proto_rawDesc), len(file_proto_iam_iam_proto_rawDesc)))
-	})
-	return file_proto_iam_iam_proto_rawDescData
-}
-
-var file_proto_iam_iam_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_proto_iam_iam_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
-var file_proto_iam_iam_proto_goTypes = []any{
-	(UserRole)(0),                         // 0: iam.v1.UserRole
-	(UserStatus)(0),                       // 1: iam.v1.UserStatus
-	(SessionStatus)(0),                    // 2: iam.v1.SessionStatus
-	(*LoginRequest)(nil),                  // 3: iam.v1.LoginRequest
-	(*LoginResponse)(nil),                 // 4: iam.v1.LoginResponse
-	(*LogoutRequest)(nil),                 // 5: iam.v1.LogoutRequest
-	(*LogoutResponse)(nil),                // 6: iam.v1.LogoutResponse
-	(*RefreshTokenRequest)(nil),           // 7: iam.v1.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),          // 8: iam.v1.RefreshTokenResponse
-	(*ValidateSessionRequest)(nil),        // 9: iam.v1.ValidateSessionRequest
-	(*ValidateSessionResponse)(nil),       // 10: iam.v1.ValidateSessionResponse
-	(*GetSessionInfoRequest)(nil),         // 11: iam.v1.GetSessionInfoRequest
-	(*GetSessionInfoResponse)(nil),        // 12: iam.v1.GetSessionInfoResponse
-	(*InvalidateSessionRequest)(nil),      // 13: iam.v1.InvalidateSessionRequest
-	(*InvalidateSessionResponse)(nil),     // 14: iam.v1.InvalidateSessionResponse
-	(*CreateUserRequest)(nil),             // 15: iam.v1.CreateUserRequest
-	(*CreateUserResponse)(nil),            // 16: iam.v1.CreateUserResponse
-	(*GetUserRequest)(nil),                // 17: iam.v1.GetUserRequest
-	(*GetUserResponse)(nil),               // 18: iam.v1.GetUserResponse
-	(*UpdateUserRequest)(nil),             // 19: iam.v1.UpdateUserRequest
-	(*UpdateUserResponse)(nil),            // 20: iam.v1.UpdateUserResponse
-	(*DeleteUserRequest)(nil),             // 21: iam.v1.DeleteUserRequest
-	(*DeleteUserResponse)(nil),            // 22: iam.v1.DeleteUserResponse
-	(*ListUsersRequest)(nil),              // 23: iam.v1.ListUsersRequest
-	(*ListUsersResponse)(nil),             // 24: iam.v1.ListUsersResponse
-	(*GetProfileRequest)(nil),             // 25: iam.v1.GetProfileRequest
-	(*GetProfileResponse)(nil),            // 26: iam.v1.GetProfileResponse
-	(*UpdateProfileRequest)(nil),          // 27: iam.v1.UpdateProfileRequest
-	(*UpdateProfileResponse)(nil),         // 28: iam.v1.UpdateProfileResponse
-	(*ChangePasswordRequest)(nil),         // 29: iam.v1.ChangePasswordRequest
-	(*ChangePasswordResponse)(nil),        // 30: iam.v1.ChangePasswordResponse
-	(*CheckPermissionRequest)(nil),        // 31: iam.v1.CheckPermissionRequest
-	(*CheckPermissionResponse)(nil),       // 32: iam.v1.CheckPermissionResponse
-	(*GetUserPermissionsRequest)(nil),     // 33: iam.v1.GetUserPermissionsRequest
-	(*GetUserPermissionsResponse)(nil),    // 34: iam.v1.GetUserPermissionsResponse
-	(*GetUserTelegramChatIDRequest)(nil),  // 35: iam.v1.GetUserTelegramChatIDRequest
-	(*GetUserTelegramChatIDResponse)(nil), // 36: iam.v1.GetUserTelegramChatIDResponse
-	(*UpdateTelegramChatIDRequest)(nil),   // 37: iam.v1.UpdateTelegramChatIDRequest
-	(*UpdateTelegramChatIDResponse)(nil),  // 38: iam.v1.UpdateTelegramChatIDResponse
-	(*User)(nil),                          // 39: iam.v1.User
-	(*UserProfile)(nil),                   // 40: iam.v1.UserProfile
-	(*Session)(nil),                       // 41: iam.v1.Session
-	nil,                                   // 42: iam.v1.CreateUserRequest.MetadataEntry
-	nil,                                   // 43: iam.v1.UpdateUserRequest.MetadataEntry
-	nil,                                   // 44: iam.v1.UpdateProfileRequest.PreferencesEntry
-	nil,                                   // 45: iam.v1.User.MetadataEntry
-	nil,                                   // 46: iam.v1.UserProfile.PreferencesEntry
-	(*timestamppb.Timestamp)(nil),         // 47: google.protobuf.Timestamp
-}
-var file_proto_iam_iam_proto_depIdxs = []int32{
-	39, // 0: iam.v1.LoginResponse.user:type_name -> iam.v1.User
-	47, // 1: iam.v1.LoginResponse.expires_at:type_name -> google.protobuf.Timestamp
-	47, // 2: iam.v1.RefreshTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
-	39, // 3: iam.v1.ValidateSessionResponse.user:type_name -> iam.v1.User
-	41, // 4: iam.v1.ValidateSessionResponse.session:type_name -> iam.v1.Session
-	41, // 5: iam.v1.GetSessionInfoResponse.session:type_name -> iam.v1.Session
-	39, // 6: iam.v1.GetSessionInfoResponse.user:type_name -> iam.v1.User
-	0,  // 7: iam.v1.CreateUserRequest.role:type_name -> iam.v1.UserRole
-	42, // 8: iam.v1.CreateUserRequest.metadata:type_name -> iam.v1.CreateUserRequest.MetadataEntry
-	39, // 9: iam.v1.CreateUserResponse.user:type_name -> iam.v1.User
-	39, // 10: iam.v1.GetUserResponse.user:type_name -> iam.v1.User
-	0,  // 11: iam.v1.UpdateUserRequest.role:type_name -> iam.v1.UserRole
-	1,  // 12: iam.v1.UpdateUserRequest.status:type_name -> iam.v1.UserStatus
-	43, // 13: iam.v1.UpdateUserRequest.metadata:type_name -> iam.v1.UpdateUserRequest.MetadataEntry
-	39, // 14: iam.v1.UpdateUserResponse.user:type_name -> iam.v1.User
-	0,  // 15: iam.v1.ListUsersRequest.role_filter:type_name -> iam.v1.UserRole
-	1,  // 16: iam.v1.ListUsersRequest.status_filter:type_name -> iam.v1.UserStatus
-	39, // 17: iam.v1.ListUsersResponse.users:type_name -> iam.v1.User
-	40, // 18: iam.v1.GetProfileResponse.profile:type_name -> iam.v1.UserProfile
-	44, // 19: iam.v1.UpdateProfileRequest.preferences:type_name -> iam.v1.UpdateProfileRequest.PreferencesEntry
-	40, // 20: iam.v1.UpdateProfileResponse.profile:type_name -> iam.v1.UserProfile
-	0,  // 21: iam.v1.GetUserPermissionsResponse.role:type_name -> iam.v1.UserRole
-	0,  // 22: iam.v1.User.role:type_name -> iam.v1.UserRole
-	1,  // 23: iam.v1.User.status:type_name -> iam.v1.UserStatus
-	47, // 24: iam.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	47, // 25: iam.v1.User.updated_at:type_name -> google.protobuf.Timestamp
-	47, // 26: iam.v1.User.last_login_at:type_name -> google.protobuf.Timestamp
-	45, // 27: iam.v1.User.metadata:type_name -> iam.v1.User.MetadataEntry
-	46, // 28: iam.v1.UserProfile.preferences:type_name -> iam.v1.UserProfile.PreferencesEntry
-	47, // 29: iam.v1.UserProfile.updated_at:type_name -> google.protobuf.Timestamp
-	47, // 30: iam.v1.Session.created_at:type_name -> google.protobuf.Timestamp
-	47, // 31: iam.v1.Session.expires_at:type_name -> google.protobuf.Timestamp
-	47, // 32: iam.v1.Session.last_accessed_at:type_name -> google.protobuf.Timestamp
-	2,  // 33: iam.v1.Session.status:type_name -> iam.v1.SessionStatus
-	3,  // 34: iam.v1.IAMService.Login:input_type -> iam.v1.LoginRequest
-	5,  // 35: iam.v1.IAMService.Logout:input_type -> iam.v1.LogoutRequest
-	7,  // 36: iam.v1.IAMService.RefreshToken:input_type -> iam.v1.RefreshTokenRequest
-	9,  // 37: iam.v1.IAMService.ValidateSession:input_type -> iam.v1.ValidateSessionRequest
-	11, // 38: iam.v1.IAMService.GetSessionInfo:input_type -> iam.v1.GetSessionInfoRequest
-	13, // 39: iam.v1.IAMService.InvalidateSession:input_type -> iam.v1.InvalidateSessionRequest
-	15, // 40: iam.v1.IAMService.CreateUser:input_type -> iam.v1.CreateUserRequest
-	17, // 41: iam.v1.IAMService.GetUser:input_type -> iam.v1.GetUserRequest
-	19, // 42: iam.v1.IAMService.UpdateUser:input_type -> iam.v1.UpdateUserRequest
-	21, // 43: iam.v1.IAMService.DeleteUser:input_type -> iam.v1.DeleteUserRequest
-	23, // 44: iam.v1.IAMService.ListUsers:input_type -> iam.v1.ListUsersRequest
-	25, // 45: iam.v1.IAMService.GetProfile:input_type -> iam.v1.GetProfileRequest
-	27, // 46: iam.v1.IAMService.UpdateProfile:input_type -> iam.v1.UpdateProfileRequest
-	29, // 47: iam.v1.IAMService.ChangePassword:input_type -> iam.v1.ChangePasswordRequest
-	31, // 48: iam.v1.IAMService.CheckPermission:input_type -> iam.v1.CheckPermissionRequest
-	33, // 49: iam.v1.IAMService.GetUserPermissions:input_type -> iam.v1.GetUserPermissionsRequest
-	35, // 50: iam.v1.IAMService.GetUserTelegramChatID:input_type -> iam.v1.GetUserTelegramChatIDRequest
-	37, // 51: iam.v1.IAMService.UpdateTelegramChatID:input_type -> iam.v1.UpdateTelegramChatIDRequest
-	4,  // 52: iam.v1.IAMService.Login:output_type -> iam.v1.LoginResponse
-	6,  // 53: iam.v1.IAMService.Logout:output_type -> iam.v1.LogoutResponse
-	8,  // 54: iam.v1.IAMService.RefreshToken:output_type -> iam.v1.RefreshTokenResponse
-	10, // 55: iam.v1.IAMService.ValidateSession:output_type -> iam.v1.ValidateSessionResponse
-	12, // 56: iam.v1.IAMService.GetSessionInfo:output_type -> iam.v1.GetSessionInfoResponse
-	14, // 57: iam.v1.IAMService.InvalidateSession:output_type -> iam.v1.InvalidateSessionResponse
-	16, // 58: iam.v1.IAMService.CreateUser:output_type -> iam.v1.CreateUserResponse
-	18, // 59: iam.v1.IAMService.GetUser:output_type -> iam.v1.GetUserResponse
-	20, // 60: iam.v1.IAMService.UpdateUser:output_type -> iam.v1.UpdateUserResponse
-	22, // 61: iam.v1.IAMService.DeleteUser:output_type -> iam.v1.DeleteUserResponse
-	24, // 62: iam.v1.IAMService.ListUsers:output_type -> iam.v1.ListUsersResponse
-	26, // 63: iam.v1.IAMService.GetProfile:output_type -> iam.v1.GetProfileResponse
-	28, // 64: iam.v1.IAMService.UpdateProfile:output_type -> iam.v1.UpdateProfileResponse
-	30, // 65: iam.v1.IAMService.ChangePassword:output_type -> iam.v1.ChangePasswordResponse
-	32, // 66: iam.v1.IAMService.CheckPermission:output_type -> iam.v1.CheckPermissionResponse
-	34, // 67: iam.v1.IAMService.GetUserPermissions:output_type -> iam.v1.GetUserPermissionsResponse
-	36, // 68: iam.v1.IAMService.GetUserTelegramChatID:output_type -> iam.v1.GetUserTelegramChatIDResponse
-	38, // 69: iam.v1.IAMService.UpdateTelegramChatID:output_type -> iam.v1.UpdateTelegramChatIDResponse
-	52, // [52:70] is the sub-list for method output_type
-	34, // [34:52] is the sub-list for method input_type
-	34, // [34:34] is the sub-list for extension type_name
-	34, // [34:34] is the sub-list for extension extendee
-	0,  // [0:34] is the sub-list for field type_name
-}
-
-func init() { file_proto_iam_iam_proto_init() }
-func file_proto_iam_iam_proto_init() {
-	if File_proto_iam_iam_proto != nil {
-		return
-	}
-	file_proto_iam_iam_proto_msgTypes[14].OneofWrappers = []any{
-		(*GetUserRequest_UserId)(nil),
-		(*GetUserRequest_Email)(nil),
-	}
-	file_proto_iam_iam_proto_msgTypes[16].OneofWrappers = []any{}
-	file_proto_iam_iam_proto_msgTypes[20].OneofWrappers = []any{}
-	file_proto_iam_iam_proto_msgTypes[24].OneofWrappers = []any{}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_iam_iam_proto_rawDesc), len(file_proto_iam_iam_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   44,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_proto_iam_iam_proto_goTypes,
-		DependencyIndexes: file_proto_iam_iam_proto_depIdxs,
-		EnumInfos:         file_proto_iam_iam_proto_enumTypes,
-		MessageInfos:      file_proto_iam_iam_proto_msgTypes,
-	}.Build()
-	File_proto_iam_iam_proto = out.File
-	file_proto_iam_iam_proto_goTypes = nil
-	file_proto_iam_iam_proto_depIdxs = nil
-}
@@ -2,15 +2,17 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
-	"log"
 	"log/slog"
 	"os"
-	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/container"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/lifecycle"
+	"github.com/amiosamu/rocket-science/shared/platform/version"
 )
 
 const (
@@ -28,36 +30,69 @@ func main() {
 		Level: slog.LevelInfo,
 	})).With("service", serviceName, "version", serviceVersion)
 
+	buildInfo := version.Get(serviceName)
 	bootstrapLogger.Info("🚀 Starting Rocket Science Inventory Service",
 		"version", serviceVersion,
+		"build_version", buildInfo.Version,
+		"git_commit", buildInfo.GitCommit,
+		"build_time", buildInfo.BuildTime,
 		"pid", os.Getpid())
 
+	// Layer the environment's config profile between defaults and environment variables
+	flags := platformconfig.RegisterFlags("INVENTORY_SERVICE_PORT")
+	backfillCatalog := flag.Bool("backfill-catalog", false, "publish every item to the catalog change feed and exit")
+	flag.Parse()
+	profile, err := flags.Apply()
+	if *flags.Validation.Validate {
+		// Report on the configuration instead of starting the service
+		validateConfiguration(profile, err, *flags.Validation.CheckConnectivity).Exit()
+	}
+	if err != nil {
+		bootstrapLogger.Error("Failed to load config profile", "error", err)
+		os.Exit(1)
+	}
+	if *flags.MigrateOnly {
+		if err := container.NewContainer().EnsureIndexes(); err != nil {
+			bootstrapLogger.Error("Failed to create MongoDB indexes", "error", err)
+			os.Exit(1)
+		}
+		bootstrapLogger.Info("MongoDB indexes are in place")
+		return
+	}
+	if profile != nil {
+		bootstrapLogger.Info("Loaded config profile", "path", profile.Path, "keys", len(profile.Keys()))
+	}
+
 	// Print environment info for debugging
 	printEnvironmentInfo(bootstrapLogger)
 
-	// Create context that can be cancelled
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	// Setup signal handling for graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
-
 	// Create and initialize the DI container
 	c, err := initializeContainer(bootstrapLogger)
 	if err != nil {
 		bootstrapLogger.Error("Failed to initialize container", "error", err)
 		os.Exit(1)
 	}
+	if err := profile.CheckUnknownKeys("SEED_TEST_DATA"); err != nil {
+		bootstrapLogger.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	if *backfillCatalog {
+		published, err := c.BackfillCatalogFeed(context.Background())
+		if err != nil {
+			bootstrapLogger.Error("Failed to backfill the catalog change feed", "published", published, "error", err)
+			os.Exit(1)
+		}
+		bootstrapLogger.Info("Catalog change feed backfilled", "items", published)
+		return
+	}
 
-	// Start the application
-	if err := startApplication(ctx, c); err != nil {
-		c.GetLogger().Error("Failed to start application", "error", err)
+	// Run the application until a shutdown signal is received
+	if err := runApplication(context.Background(), c); err != nil {
+		c.GetLogger().Error("Application stopped with errors", "error", err)
 		os.Exit(1)
 	}
 
-	// Wait for shutdown signal
-	waitForShutdown(sigChan, c)
+	c.GetLogger().Info("🏁 Inventory Service stopped")
 }
 
 // initializeContainer creates and initializes the dependency injection container
@@ -81,8 +116,8 @@ func initializeContainer(bootstrapLogger *slog.Logger) (*container.Container, er
 	return c, nil
 }
 
-// startApplication starts the main application services
-func startApplication(ctx context.Context, c *container.Container) error {
+// runApplication starts the main application services and blocks until shutdown
+func runApplication(ctx context.Context, c *container.Container) error {
 	logger := c.GetLogger()
 	config := c.GetConfig()
 
@@ -103,51 +138,32 @@ func startApplication(ctx context.Context, c *container.Container) error {
 		}
 	}
 
-	// Start the container (this will start the gRPC server and background jobs)
-	if err := c.Start(ctx); err != nil {
-		return fmt.Errorf("failed to start container: %w", err)
-	}
-
-	logger.Info("✅ Inventory Service started successfully",
-		"status", "ready",
-		"grpc_address", fmt.Sprintf(":%s", config.Server.Port),
-		"database", config.Database.DatabaseName)
-
-	return nil
-}
-
-// waitForShutdown waits for shutdown signals and performs graceful shutdown
-func waitForShutdown(sigChan <-chan os.Signal, c *container.Container) {
-	logger := c.GetLogger()
-
-	// Wait for signal
-	sig := <-sigChan
-	logger.Info("🛑 Received shutdown signal", "signal", sig.String())
-
-	// Create shutdown context with timeout
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
-	defer cancel()
-
-	// Perform graceful shutdown
-	logger.Info("Starting graceful shutdown",
-		"timeout", shutdownTimeout.String())
-
-	// Stop the container
-	done := make(chan struct{})
-	go func() {
-		c.Stop()
-		close(done)
-	}()
-
-	// Wait for shutdown completion or timeout
-	select {
-	case <-done:
-		logger.Info("✅ Graceful shutdown completed successfully")
-	case <-shutdownCtx.Done():
-		logger.Error("❌ Shutdown timeout exceeded, forcing exit")
-	}
+	// Register the container with the runner; it starts its servers in order
+	// and stops them in reverse
+	runner := lifecycle.NewRunner(logger,
+		lifecycle.WithSignals(syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP),
+	)
+	runner.Add(lifecycle.Component{
+		Name: "container",
+		Start: func(ctx context.Context) error {
+			if err := c.Start(ctx); err != nil {
+				return err
+			}
 
-	logger.Info("🏁 Inventory Service stopped")
+			logger.Info("✅ Inventory Service started successfully",
+				"status", "ready",
+				"grpc_address", fmt.Sprintf(":%s", config.Server.Port),
+				"database", config.Database.DatabaseName)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			c.Stop()
+			return nil
+		},
+		StopTimeout: shutdownTimeout,
+	})
+
+	return runner.Run(ctx)
 }
 
 // printEnvironmentInfo logs relevant environment information for debugging
@@ -260,26 +276,6 @@ func healthCheck() error {
 	return nil
 }
 
-// Version information
-type VersionInfo struct {
-	Service   string `json:"service"`
-	Version   string `json:"version"`
-	GoVersion string `json:"go_version"`
-	BuildTime string `json:"build_time,omitempty"`
-	GitCommit string `json:"git_commit,omitempty"`
-}
-
-// getVersionInfo returns version information about the service
-func getVersionInfo() VersionInfo {
-	return VersionInfo{
-		Service:   serviceName,
-		Version:   serviceVersion,
-		GoVersion: os.Getenv("GO_VERSION"),
-		BuildTime: os.Getenv("BUILD_TIME"), // Set during Docker build
-		GitCommit: os.Getenv("GIT_COMMIT"), // Set during Docker build
-	}
-}
-
 // Configuration validation for startup
 func validateStartupRequirements() error {
 	// Check required environment variables
@@ -297,26 +293,6 @@ func validateStartupRequirements() error {
 	return nil
 }
 
-// Recovery function to handle panics gracefully
-func handlePanic() {
-	if r := recover(); r != nil {
-		log.Printf("💥 PANIC: %v", r)
-
-		// In production, you might want to:
-		// 1. Send panic info to monitoring system
-		// 2. Attempt graceful shutdown
-		// 3. Restart the service
-
-		os.Exit(1)
-	}
-}
-
-// Example of how to run with panic recovery
-func runWithRecovery() {
-	defer handlePanic()
-	main()
-}
-
 // Example environment variable documentation
 /*
 Environment Variables:
@@ -338,7 +314,8 @@ Inventory Configuration:
 - INVENTORY_DEFAULT_STOCK_LEVEL: Default stock level for new items (default: 100)
 - INVENTORY_LOW_STOCK_THRESHOLD: Low stock alert threshold (default: 10)
 - INVENTORY_MAX_RESERVATION_TIME_MIN: Maximum reservation time in minutes (default: 30)
-- INVENTORY_AUTO_RESTOCK_ENABLED: Enable automatic restocking (default: false)
+- INVENTORY_AUTO_RESTOCK_ENABLED: Publish restock requests of low stock items to Kafka (default: false)
+- INVENTORY_RESTOCK_CHECK_INTERVAL: How often low stock items are checked for restocking (default: 1h)
 
 Observability:
 - LOG_LEVEL: Logging level - debug, info, warn, error (default: info)
@@ -346,6 +323,9 @@ Observability:
 - TRACING_ENABLED: Enable distributed tracing (default: true)
 - SERVICE_NAME: Service name for observability (default: inventory-service)
 - SERVICE_VERSION: Service version (default: 1.0.0)
+- SENTRY_DSN: Sentry project DSN for recovered panics; empty only logs them
+- SENTRY_ENVIRONMENT: Environment reported with crashes (default: $ENVIRONMENT)
+- CRASH_REPORT_TIMEOUT: Bound on sending one crash report (default: 5s)
 
 Development:
 - ENVIRONMENT: Environment name - development, staging, production (default: development)
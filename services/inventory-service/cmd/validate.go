@@ -0,0 +1,51 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/config"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+)
+
+// validateConfiguration loads the configuration the way startup does and
+// reports every problem found, optionally dialing the configured dependencies
+func validateConfiguration(profile *platformconfig.Profile, profileErr error, checkConnectivity bool) *platformconfig.Report {
+	report := platformconfig.NewReport(serviceName)
+
+	cfg, err := config.Load()
+	report.Check("load configuration", err)
+	report.CheckParsedValues()
+	report.CheckProfile(profile, profileErr, "SEED_TEST_DATA")
+
+	if !checkConnectivity {
+		return report
+	}
+	if cfg == nil {
+		report.Skip("connectivity", "configuration did not load")
+		return report
+	}
+
+	mongoURL, err := url.Parse(cfg.Database.ConnectionURL)
+	switch {
+	case err != nil:
+		report.Check("mongodb", err)
+	case mongoURL.Scheme == "mongodb+srv":
+		report.Skip("mongodb", "SRV connection strings are resolved by the driver")
+	default:
+		// A replica set URL lists several comma-separated hosts
+		for _, host := range strings.Split(mongoURL.Host, ",") {
+			report.CheckReachable("mongodb", host)
+		}
+	}
+
+	for _, broker := range cfg.Kafka.Brokers {
+		report.CheckReachable("kafka broker", broker)
+	}
+	if len(cfg.Kafka.Brokers) > 0 {
+		report.Check("kafka topics", kafka.VerifyTopics(cfg.Kafka.Brokers, cfg.Kafka.Topics, cfg.Kafka.UsedTopics()...))
+	}
+
+	return report
+}
@@ -2,9 +2,12 @@ package config
 
 import (
 	"fmt"
-	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
 )
 
 // Config holds all configuration for the Inventory Service
@@ -12,6 +15,8 @@ type Config struct {
 	Server        ServerConfig
 	Database      DatabaseConfig
 	Inventory     InventoryConfig
+	Catalog       CatalogConfig
+	Kafka         KafkaConfig
 	Observability ObservabilityConfig
 }
 
@@ -40,6 +45,73 @@ type InventoryConfig struct {
 	LowStockThreshold     int
 	MaxReservationTimeMin int // Maximum time to hold reservations
 	AutoRestockEnabled    bool
+
+	// With AutoRestockEnabled, low stock items are checked every
+	// RestockCheckInterval and a restock requested from their chosen supplier
+	// unless stock is already on the way
+	RestockCheckInterval time.Duration
+
+	// SerialTrackedCategories lists item categories (e.g. "engines") whose units are
+	// always tracked by serial number; other items opt in when serials are registered
+	SerialTrackedCategories []string
+
+	// The stock consistency check compares every item's stock levels with its
+	// reservations; drifts up to ConsistencyMaxRepairDrift are repaired when
+	// ConsistencyAutoRepair is set, larger ones only raise the alarm
+	ConsistencyCheckInterval  time.Duration // Zero disables the periodic check
+	ConsistencyAutoRepair     bool
+	ConsistencyMaxRepairDrift float64
+
+	// Expedited reservations may preempt standard ones expiring within
+	// PreemptionWindow; the preempted orders are notified through Kafka
+	PreemptionEnabled bool
+	PreemptionWindow  time.Duration
+
+	// DiscontinuedReplacement sets how availability checks and reservations of a
+	// discontinued item with a replacement treat it: ReplacementOff ignores the
+	// replacement, ReplacementSuggest reports it next to the item's own result and
+	// ReplacementSubstitute checks and reserves the replacement in its place
+	DiscontinuedReplacement string
+}
+
+// Replacement modes of discontinued items
+const (
+	ReplacementOff        = "off"
+	ReplacementSuggest    = "suggest"
+	ReplacementSubstitute = "substitute"
+)
+
+// CatalogConfig contains settings for the public storefront catalog endpoints
+type CatalogConfig struct {
+	CacheMaxAge          time.Duration // How long browsers and CDNs may serve a cached response
+	StaleWhileRevalidate time.Duration // How long a stale response may be served while refetching
+}
+
+// KafkaConfig contains settings for publishing inventory events and consuming
+// assembly events
+type KafkaConfig struct {
+	Brokers              []string // Empty disables event publishing and consuming
+	Topics               topics.Registry
+	ConsumerGroupID      string
+	ConsumePartsConsumed bool // Book out the parts of completed assemblies
+
+	// CatalogFeed publishes the full state of every saved item to the compacted
+	// items changed topic for data warehouse sync; the topic is created on startup
+	CatalogFeed                  bool
+	CatalogFeedPartitions        int
+	CatalogFeedReplicationFactor int
+}
+
+// UsedTopics returns the registered topics the service produces to and consumes from
+func (k KafkaConfig) UsedTopics() []topics.Topic {
+	used := []topics.Topic{topics.InventoryEvents}
+	if k.ConsumePartsConsumed {
+		used = append(used, topics.AssemblyPartsConsumed)
+	}
+	if k.CatalogFeed {
+		used = append(used, topics.InventoryItemsChanged)
+	}
+	return used
 }
 
 // ObservabilityConfig contains observability settings
@@ -74,6 +146,33 @@ func Load() (*Config, error) {
 			LowStockThreshold:     parseIntOrDefault("INVENTORY_LOW_STOCK_THRESHOLD", "10"),
 			MaxReservationTimeMin: parseIntOrDefault("INVENTORY_MAX_RESERVATION_TIME_MIN", "30"),
 			AutoRestockEnabled:    parseBoolOrDefault("INVENTORY_AUTO_RESTOCK_ENABLED", "false"),
+
+			RestockCheckInterval: parseDurationOrDefault("INVENTORY_RESTOCK_CHECK_INTERVAL", "1h"),
+
+			SerialTrackedCategories: parseListOrDefault("INVENTORY_SERIAL_TRACKED_CATEGORIES", ""),
+
+			ConsistencyCheckInterval:  parseDurationOrDefault("INVENTORY_CONSISTENCY_CHECK_INTERVAL", "1h"),
+			ConsistencyAutoRepair:     parseBoolOrDefault("INVENTORY_CONSISTENCY_AUTO_REPAIR", "false"),
+			ConsistencyMaxRepairDrift: parseFloatOrDefault("INVENTORY_CONSISTENCY_MAX_REPAIR_DRIFT", "1"),
+
+			PreemptionEnabled: parseBoolOrDefault("INVENTORY_RESERVATION_PREEMPTION_ENABLED", "true"),
+			PreemptionWindow:  parseDurationOrDefault("INVENTORY_RESERVATION_PREEMPTION_WINDOW", "5m"),
+
+			DiscontinuedReplacement: getEnvOrDefault("INVENTORY_DISCONTINUED_REPLACEMENT_MODE", ReplacementSuggest),
+		},
+		Catalog: CatalogConfig{
+			CacheMaxAge:          parseDurationOrDefault("INVENTORY_CATALOG_CACHE_MAX_AGE", "60s"),
+			StaleWhileRevalidate: parseDurationOrDefault("INVENTORY_CATALOG_STALE_WHILE_REVALIDATE", "5m"),
+		},
+		Kafka: KafkaConfig{
+			Brokers:              parseListOrDefault("KAFKA_BROKERS", ""),
+			Topics:               topics.Load(platformconfig.Getenv),
+			ConsumerGroupID:      getEnvOrDefault("KAFKA_CONSUMER_GROUP_ID", "inventory-service-group"),
+			ConsumePartsConsumed: parseBoolOrDefault("INVENTORY_CONSUME_PARTS_CONSUMED", "true"),
+
+			CatalogFeed:                  parseBoolOrDefault("INVENTORY_CATALOG_FEED_ENABLED", "false"),
+			CatalogFeedPartitions:        parseIntOrDefault("INVENTORY_CATALOG_FEED_PARTITIONS", "3"),
+			CatalogFeedReplicationFactor: parseIntOrDefault("INVENTORY_CATALOG_FEED_REPLICATION_FACTOR", "1"),
 		},
 		Observability: ObservabilityConfig{
 			LogLevel:       getEnvOrDefault("LOG_LEVEL", "info"),
@@ -125,6 +224,39 @@ func (c *Config) validate() error {
 	if c.Inventory.MaxReservationTimeMin <= 0 {
 		return fmt.Errorf("max reservation time must be positive")
 	}
+	if c.Inventory.AutoRestockEnabled && c.Inventory.RestockCheckInterval <= 0 {
+		return fmt.Errorf("restock check interval must be positive when auto restock is enabled")
+	}
+	if c.Inventory.ConsistencyCheckInterval < 0 {
+		return fmt.Errorf("consistency check interval cannot be negative")
+	}
+	if c.Inventory.ConsistencyMaxRepairDrift < 0 {
+		return fmt.Errorf("consistency max repair drift cannot be negative")
+	}
+	if c.Inventory.PreemptionWindow < 0 {
+		return fmt.Errorf("reservation preemption window cannot be negative")
+	}
+	switch c.Inventory.DiscontinuedReplacement {
+	case ReplacementOff, ReplacementSuggest, ReplacementSubstitute:
+	default:
+		return fmt.Errorf("unknown discontinued replacement mode %q", c.Inventory.DiscontinuedReplacement)
+	}
+
+	// Validate catalog config
+	if c.Catalog.CacheMaxAge < 0 || c.Catalog.StaleWhileRevalidate < 0 {
+		return fmt.Errorf("catalog cache durations cannot be negative")
+	}
+
+	// Validate Kafka config
+	if err := c.Kafka.Topics.Validate(); err != nil {
+		return err
+	}
+	if len(c.Kafka.Brokers) > 0 && c.Kafka.ConsumePartsConsumed && c.Kafka.ConsumerGroupID == "" {
+		return fmt.Errorf("kafka consumer group ID cannot be empty")
+	}
+	if c.Kafka.CatalogFeed && (c.Kafka.CatalogFeedPartitions < 1 || c.Kafka.CatalogFeedReplicationFactor < 1) {
+		return fmt.Errorf("catalog feed partitions and replication factor must be at least 1")
+	}
 
 	// Validate observability config
 	if c.Observability.ServiceName == "" {
@@ -150,17 +282,19 @@ func (c *Config) GetMongoDBConfig() map[string]interface{} {
 // Helper functions for environment variable parsing
 
 func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := platformconfig.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
 func parseIntOrDefault(key string, defaultValue string) int {
-	if value := os.Getenv(key); value != "" {
-		if parsed, err := strconv.Atoi(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err == nil {
 			return parsed
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	if parsed, err := strconv.Atoi(defaultValue); err == nil {
 		return parsed
@@ -169,10 +303,12 @@ func parseIntOrDefault(key string, defaultValue string) int {
 }
 
 func parseFloatOrDefault(key string, defaultValue string) float64 {
-	if value := os.Getenv(key); value != "" {
-		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err == nil {
 			return parsed
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	if parsed, err := strconv.ParseFloat(defaultValue, 64); err == nil {
 		return parsed
@@ -181,10 +317,12 @@ func parseFloatOrDefault(key string, defaultValue string) float64 {
 }
 
 func parseBoolOrDefault(key string, defaultValue string) bool {
-	if value := os.Getenv(key); value != "" {
-		if parsed, err := strconv.ParseBool(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err == nil {
 			return parsed
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	if parsed, err := strconv.ParseBool(defaultValue); err == nil {
 		return parsed
@@ -193,13 +331,30 @@ func parseBoolOrDefault(key string, defaultValue string) bool {
 }
 
 func parseDurationOrDefault(key string, defaultValue string) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if parsed, err := time.ParseDuration(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err == nil {
 			return parsed
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	if parsed, err := time.ParseDuration(defaultValue); err == nil {
 		return parsed
 	}
 	return 30 * time.Second
 }
+
+func parseListOrDefault(key string, defaultValue string) []string {
+	value := platformconfig.Getenv(key)
+	if value == "" {
+		value = defaultValue
+	}
+
+	var list []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
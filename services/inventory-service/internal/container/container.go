@@ -9,10 +9,20 @@ import (
 
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	inventoryKafka "github.com/amiosamu/rocket-science/services/inventory-service/internal/messaging/kafka"
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/repository/mongodb"
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
 	grpcTransport "github.com/amiosamu/rocket-science/services/inventory-service/internal/transport/grpc"
 	httpTransport "github.com/amiosamu/rocket-science/services/inventory-service/internal/transport/http"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	"github.com/amiosamu/rocket-science/shared/platform/fxrates"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/grpclog"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
 )
 
 // Container manages all dependencies for the Inventory Service
@@ -22,13 +32,26 @@ type Container struct {
 	config *config.Config
 
 	// Infrastructure
-	logger *slog.Logger
+	logger        *slog.Logger
+	maintenance   *maintenance.Mode
+	crashReporter recovery.Reporter
 
 	// Data layer
-	repository domain.InventoryRepository
+	repository               domain.InventoryRepository
+	bundleRepository         domain.BundleRepository
+	serialRepository         domain.SerialRepository
+	purchaseOrderRepository  domain.PurchaseOrderRepository
+	backorderRepository      domain.BackorderRepository
+	stockMovementRepository  domain.StockMovementRepository
+	categoryPolicyRepository domain.CategoryPolicyRepository
+	itemSupplierRepository   domain.ItemSupplierRepository
 
 	// Business Services
-	inventoryService service.InventoryService
+	inventoryService    service.InventoryService
+	itemWatcher         *service.ItemWatcher
+	reservationProducer *inventoryKafka.ReservationEventProducer
+	catalogProducer     *inventoryKafka.CatalogChangeProducer
+	partsConsumer       *inventoryKafka.PartsConsumedConsumer
 
 	// Transport Layer
 	grpcServer   *grpcTransport.Server
@@ -103,6 +126,11 @@ func (c *Container) Initialize() error {
 		"service", c.config.Observability.ServiceName,
 		"version", c.config.Observability.ServiceVersion)
 
+	// Panics recovered from gRPC calls and Kafka messages are reported here
+	if err := c.initializeCrashReporter(); err != nil {
+		return fmt.Errorf("failed to initialize crash reporter: %w", err)
+	}
+
 	// Step 3: Initialize data layer (MongoDB repository)
 	if err := c.initializeRepository(); err != nil {
 		return fmt.Errorf("failed to initialize repository: %w", err)
@@ -124,6 +152,25 @@ func (c *Container) Initialize() error {
 	return nil
 }
 
+// EnsureIndexes connects to MongoDB, which creates the indexes of the inventory
+// collections, and disconnects again. It is the migration step of the service.
+func (c *Container) EnsureIndexes() error {
+	if err := c.initializeConfig(); err != nil {
+		return fmt.Errorf("failed to initialize config: %w", err)
+	}
+	if err := c.initializeLogger(); err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	if err := c.initializeRepository(); err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+
+	if mongoRepo, ok := c.repository.(*mongodb.MongoInventoryRepository); ok {
+		return mongoRepo.Close()
+	}
+	return nil
+}
+
 // Start begins the application lifecycle
 func (c *Container) Start(ctx context.Context) error {
 	if !c.initialized {
@@ -141,6 +188,16 @@ func (c *Container) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start health server: %w", err)
 	}
 
+	// Consume assembly parts consumed events; the consumer retries connecting
+	// in the background, so it does not hold up startup
+	if c.partsConsumer != nil {
+		go func() {
+			if err := c.partsConsumer.Start(ctx); err != nil {
+				c.logger.Error("Failed to start parts consumed consumer", "error", err)
+			}
+		}()
+	}
+
 	// Start background jobs (reservation cleanup, etc.)
 	c.grpcServer.StartBackgroundJobs(ctx)
 
@@ -173,6 +230,25 @@ func (c *Container) Stop() {
 		c.grpcServer.Stop()
 	}
 
+	// Stop consuming before the repository connections close
+	if c.partsConsumer != nil {
+		if err := c.partsConsumer.Stop(); err != nil {
+			c.logger.Error("Failed to stop parts consumed consumer", "error", err)
+		}
+	}
+
+	// Close Kafka producer after the servers so in-flight reservations can publish
+	if c.reservationProducer != nil {
+		if err := c.reservationProducer.Close(); err != nil {
+			c.logger.Error("Failed to close reservation event producer", "error", err)
+		}
+	}
+	if c.catalogProducer != nil {
+		if err := c.catalogProducer.Close(); err != nil {
+			c.logger.Error("Failed to close catalog change producer", "error", err)
+		}
+	}
+
 	// Close repository connections
 	if c.repository != nil {
 		if mongoRepo, ok := c.repository.(*mongodb.MongoInventoryRepository); ok {
@@ -204,6 +280,11 @@ func (c *Container) GetInventoryService() service.InventoryService {
 	return c.inventoryService
 }
 
+// GetMaintenanceMode provides access to the maintenance mode switch
+func (c *Container) GetMaintenanceMode() *maintenance.Mode {
+	return c.maintenance
+}
+
 // GetGRPCServer provides access to the gRPC server
 func (c *Container) GetGRPCServer() *grpcTransport.Server {
 	return c.grpcServer
@@ -343,6 +424,22 @@ func (c *Container) initializeLogger() error {
 	return nil
 }
 
+// initializeCrashReporter sets up the reporter of recovered panics
+func (c *Container) initializeCrashReporter() error {
+	recoveryConfig, err := recovery.FromEnv()
+	if err != nil {
+		return err
+	}
+
+	c.crashReporter, err = recovery.New(recoveryConfig, c.config.Observability.ServiceName, grpclog.FromSlog(c.logger))
+	if err != nil {
+		return err
+	}
+
+	c.logger.Debug("Crash reporter initialized", "sentry", recoveryConfig.SentryDSN != "")
+	return nil
+}
+
 // initializeRepository creates the MongoDB repository
 func (c *Container) initializeRepository() error {
 	// If a custom repository was provided, use it (useful for testing)
@@ -360,6 +457,13 @@ func (c *Container) initializeRepository() error {
 	}
 
 	c.repository = mongoRepo
+	c.bundleRepository = mongodb.NewMongoBundleRepository(mongoRepo, c.logger)
+	c.serialRepository = mongodb.NewMongoSerialRepository(mongoRepo, c.logger)
+	c.purchaseOrderRepository = mongodb.NewMongoPurchaseOrderRepository(mongoRepo, c.logger)
+	c.stockMovementRepository = mongodb.NewMongoStockMovementRepository(mongoRepo, c.logger)
+	c.backorderRepository = mongodb.NewMongoBackorderRepository(mongoRepo, c.logger)
+	c.categoryPolicyRepository = mongodb.NewMongoCategoryPolicyRepository(mongoRepo, c.logger)
+	c.itemSupplierRepository = mongodb.NewMongoItemSupplierRepository(mongoRepo, c.logger)
 
 	// Test the connection
 	ctx, cancel := context.WithTimeout(context.Background(), c.config.Database.ConnectTimeout)
@@ -378,25 +482,234 @@ func (c *Container) initializeServices() error {
 	c.logger.Debug("Initializing business services")
 
 	// Create inventory service with dependencies
-	c.inventoryService = service.NewInventoryService(c.config, c.logger, c.repository)
+	c.itemWatcher = service.NewItemWatcher()
+	opts := []service.InventoryServiceOption{service.WithItemWatcher(c.itemWatcher)}
+	if c.bundleRepository != nil {
+		opts = append(opts, service.WithBundleRepository(c.bundleRepository))
+	}
+	if c.serialRepository != nil {
+		opts = append(opts, service.WithSerialRepository(c.serialRepository))
+	}
+	if c.purchaseOrderRepository != nil {
+		opts = append(opts, service.WithPurchaseOrderRepository(c.purchaseOrderRepository))
+	}
+	if c.stockMovementRepository != nil {
+		opts = append(opts, service.WithStockMovementRepository(c.stockMovementRepository))
+	}
+	if c.backorderRepository != nil {
+		opts = append(opts, service.WithBackorderRepository(c.backorderRepository))
+	}
+	if c.categoryPolicyRepository != nil {
+		opts = append(opts, service.WithCategoryPolicyRepository(c.categoryPolicyRepository))
+	}
+	if c.itemSupplierRepository != nil {
+		opts = append(opts, service.WithItemSupplierRepository(c.itemSupplierRepository))
+	}
+
+	// Preempted reservations and reserved backorders are published to Kafka so
+	// order-service can compensate or resume their orders, and restock requests
+	// of low stock items for purchasing
+	if len(c.config.Kafka.Brokers) > 0 {
+		// The catalog feed topic keeps the latest state per SKU only if it is compacted,
+		// so it is created here instead of by the brokers on first use
+		if c.config.Kafka.CatalogFeed {
+			topic := c.config.Kafka.Topics.Name(topics.InventoryItemsChanged)
+			if err := kafka.EnsureCompactedTopic(c.config.Kafka.Brokers, topic, int32(c.config.Kafka.CatalogFeedPartitions), int16(c.config.Kafka.CatalogFeedReplicationFactor)); err != nil {
+				return fmt.Errorf("failed to create catalog feed topic: %w", err)
+			}
+		}
+
+		if err := kafka.VerifyTopics(c.config.Kafka.Brokers, c.config.Kafka.Topics, c.config.Kafka.UsedTopics()...); err != nil {
+			if c.config.Kafka.Topics.Strict() {
+				return fmt.Errorf("kafka topic check failed: %w", err)
+			}
+			c.logger.Warn("Kafka topic check failed", "error", err)
+		}
+
+		producer, err := c.newReservationEventProducer()
+		if err != nil {
+			return fmt.Errorf("failed to create reservation event producer: %w", err)
+		}
+		c.reservationProducer = producer
+		opts = append(opts, service.WithReservationEventPublisher(producer))
+		if c.config.Inventory.AutoRestockEnabled {
+			opts = append(opts, service.WithRestockEventPublisher(producer))
+		}
+
+		// Every saved item is published to the catalog change feed for data warehouse sync
+		if c.config.Kafka.CatalogFeed {
+			catalogProducer, err := c.newCatalogChangeProducer()
+			if err != nil {
+				return fmt.Errorf("failed to create catalog change producer: %w", err)
+			}
+			c.catalogProducer = catalogProducer
+			opts = append(opts, service.WithCatalogChangePublisher(catalogProducer))
+		}
+	} else if c.config.Inventory.PreemptionEnabled {
+		c.logger.Warn("Kafka brokers not configured, preempted orders will not be notified")
+	}
+
+	c.inventoryService = service.NewInventoryService(c.config, c.logger, c.repository, opts...)
 
-	c.logger.Debug("Business services initialized successfully")
+	// Parts built into completed rockets are booked out of the orders' confirmed stock
+	if len(c.config.Kafka.Brokers) > 0 && c.config.Kafka.ConsumePartsConsumed && c.stockMovementRepository != nil {
+		consumer, err := c.newPartsConsumedConsumer()
+		if err != nil {
+			return fmt.Errorf("failed to create parts consumed consumer: %w", err)
+		}
+		c.partsConsumer = consumer
+	}
+
+	c.logger.Debug("Business services initialized successfully",
+		"preemption_enabled", c.config.Inventory.PreemptionEnabled)
 	return nil
 }
 
+// newReservationEventProducer creates the Kafka producer for reservation events
+func (c *Container) newReservationEventProducer() (*inventoryKafka.ReservationEventProducer, error) {
+	sharedLogger, sharedMetrics, err := c.newKafkaObservability()
+	if err != nil {
+		return nil, err
+	}
+
+	producerConfig := kafka.DefaultProducerConfig()
+	producerConfig.Brokers = c.config.Kafka.Brokers
+	producerConfig.ClientID = c.config.Observability.ServiceName
+
+	producer, err := kafka.NewProducer(producerConfig, sharedLogger, sharedMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	topic := c.config.Kafka.Topics.Name(topics.InventoryEvents)
+	c.logger.Info("Reservation event producer created",
+		"brokers", c.config.Kafka.Brokers,
+		"topic", topic)
+
+	return inventoryKafka.NewReservationEventProducer(producer, topic, c.logger), nil
+}
+
+// newCatalogChangeProducer creates the Kafka producer of the catalog change feed
+func (c *Container) newCatalogChangeProducer() (*inventoryKafka.CatalogChangeProducer, error) {
+	sharedLogger, sharedMetrics, err := c.newKafkaObservability()
+	if err != nil {
+		return nil, err
+	}
+
+	producerConfig := kafka.DefaultProducerConfig()
+	producerConfig.Brokers = c.config.Kafka.Brokers
+	producerConfig.ClientID = c.config.Observability.ServiceName
+
+	producer, err := kafka.NewProducer(producerConfig, sharedLogger, sharedMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	topic := c.config.Kafka.Topics.Name(topics.InventoryItemsChanged)
+	c.logger.Info("Catalog change producer created",
+		"brokers", c.config.Kafka.Brokers,
+		"topic", topic)
+
+	return inventoryKafka.NewCatalogChangeProducer(producer, topic, c.logger), nil
+}
+
+// BackfillCatalogFeed publishes the current state of every item to the catalog
+// change feed, waiting for each to be acknowledged. The container must be
+// initialized with the catalog feed enabled.
+func (c *Container) BackfillCatalogFeed(ctx context.Context) (int, error) {
+	if c.catalogProducer == nil {
+		return 0, fmt.Errorf("catalog feed is not enabled (INVENTORY_CATALOG_FEED_ENABLED and KAFKA_BROKERS)")
+	}
+
+	published, err := service.BackfillCatalogFeed(ctx, c.repository, c.catalogProducer.Sync())
+	if closeErr := c.catalogProducer.Close(); err == nil {
+		err = closeErr
+	}
+	return published, err
+}
+
+// newPartsConsumedConsumer creates the Kafka consumer for assembly parts consumed events
+func (c *Container) newPartsConsumedConsumer() (*inventoryKafka.PartsConsumedConsumer, error) {
+	sharedLogger, sharedMetrics, err := c.newKafkaObservability()
+	if err != nil {
+		return nil, err
+	}
+
+	consumerConfig := kafka.DefaultConsumerConfig()
+	consumerConfig.Brokers = c.config.Kafka.Brokers
+	consumerConfig.GroupID = c.config.Kafka.ConsumerGroupID
+	consumerConfig.ClientID = c.config.Observability.ServiceName
+	topic := c.config.Kafka.Topics.Name(topics.AssemblyPartsConsumed)
+	consumerConfig.Topics = []string{topic}
+	consumerConfig.InitialOffset = "oldest" // Consumption must not be missed while the service is down
+
+	consumer, err := kafka.NewConsumer(consumerConfig, sharedLogger, sharedMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("Parts consumed consumer created",
+		"brokers", c.config.Kafka.Brokers,
+		"topic", topic,
+		"groupID", c.config.Kafka.ConsumerGroupID)
+
+	return inventoryKafka.NewPartsConsumedConsumer(consumer, topic, c.inventoryService, c.logger, c.crashReporter), nil
+}
+
+// newKafkaObservability creates the shared logger and metrics the shared Kafka clients report to
+func (c *Container) newKafkaObservability() (logging.Logger, metrics.Metrics, error) {
+	sharedLogger, err := logging.NewServiceLogger(
+		c.config.Observability.ServiceName,
+		c.config.Observability.ServiceVersion,
+		c.config.Observability.LogLevel,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	sharedMetrics, err := metrics.NewMetrics(c.config.Observability.ServiceName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create metrics: %w", err)
+	}
+
+	return sharedLogger, sharedMetrics, nil
+}
+
 // initializeTransport sets up all transport layers (gRPC and HTTP health)
 func (c *Container) initializeTransport() error {
 	c.logger.Debug("Initializing transport layer")
 
+	// Maintenance mode switch shared by the gRPC and health servers
+	c.maintenance = maintenance.FromEnv()
+	if c.maintenance.Enabled() {
+		c.logger.Warn("Starting in maintenance mode", "reason", c.maintenance.Status().Reason)
+	}
+
+	// Exchange rates for catalog prices in a requested display currency
+	rates, err := fxrates.FromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid exchange rates configuration: %w", err)
+	}
+	if rates == nil {
+		c.logger.Info("No exchange rates configured, display currencies are limited to item currencies")
+	}
+	displayPrices := service.NewDisplayPrices(rates)
+
 	// Create gRPC server with all dependencies
-	c.grpcServer = grpcTransport.NewServer(c.config, c.logger, c.inventoryService)
+	c.grpcServer = grpcTransport.NewServerWithOptions(c.config, c.logger, c.inventoryService,
+		grpcTransport.WithMaintenanceMode(c.maintenance),
+		grpcTransport.WithDisplayPrices(displayPrices),
+		grpcTransport.WithCrashReporter(c.crashReporter))
 
 	// Create HTTP health server
 	c.healthServer = httpTransport.NewHealthServer(
 		c.inventoryService,
 		c.repository,
+		c.maintenance,
 		c.logger,
 		c.config.Server.HealthPort,
+		c.config.Catalog,
+		displayPrices,
 	)
 
 	c.logger.Debug("Transport layer initialized successfully")
@@ -568,6 +881,10 @@ func (c *Container) SeedTestData(ctx context.Context) error {
 		createTestItem("RKT-TANK-500", "Main Fuel Tank", "Large capacity fuel storage tank", domain.CategoryFuelTanks, 15000.00),
 		createTestItem("RKT-TANK-100", "Secondary Fuel Tank", "Smaller auxiliary fuel tank", domain.CategoryFuelTanks, 8000.00),
 
+		// Propellants, stocked by mass and volume rather than in pieces
+		createBulkTestItem("RKT-PROP-LOX", "Liquid Oxygen", "Cryogenic oxidizer, price per kilogram", domain.CategoryFuelTanks, 0.20, domain.UnitKilogram, 250000),
+		createBulkTestItem("RKT-PROP-RP1", "RP-1 Kerosene", "Rocket-grade kerosene, price per liter", domain.CategoryFuelTanks, 1.50, domain.UnitLiter, 120000),
+
 		// Navigation
 		createTestItem("RKT-NAV-001", "Flight Computer", "Advanced navigation and flight control system", domain.CategoryNavigation, 25000.00),
 		createTestItem("RKT-NAV-002", "GPS Module", "High-precision GPS navigation module", domain.CategoryNavigation, 5000.00),
@@ -584,6 +901,23 @@ func (c *Container) SeedTestData(ctx context.Context) error {
 		}
 	}
 
+	// Kit composed of the parts above
+	if c.bundleRepository != nil {
+		starterKit, err := domain.NewBundle("KIT-STARTER-001", "Starter Rocket Kit",
+			"Raptor engine, main fuel tank and flight computer",
+			money.New(8500000, "USD"),
+			[]domain.BundleComponent{
+				{SKU: "RKT-ENG-001", Quantity: 1},
+				{SKU: "RKT-TANK-500", Quantity: 1},
+				{SKU: "RKT-NAV-001", Quantity: 1},
+			})
+		if err == nil {
+			if err := c.bundleRepository.Save(starterKit); err != nil {
+				c.logger.Error("Failed to save test bundle", "sku", starterKit.SKU(), "error", err)
+			}
+		}
+	}
+
 	c.logger.Info("Test data seeding completed", "itemsCreated", len(testItems))
 	return nil
 }
@@ -592,11 +926,29 @@ func (c *Container) SeedTestData(ctx context.Context) error {
 func createTestItem(sku, name, description string, category domain.ItemCategory, price float64) *domain.InventoryItem {
 	item, _ := domain.NewInventoryItem(
 		sku, name, description, category,
-		domain.Money{Amount: price, Currency: "USD"},
+		money.FromFloat(price, "USD"),
 	)
 
+	// Test stock is bought at 60% of the sale price
+	item.SetCostPrice(money.FromFloat(price*0.6, "USD"))
+
 	// Add some initial stock
 	item.AddStock(100, "Initial stock")
 
 	return item
 }
+
+// Helper function to create test items stocked in a unit other than pieces
+func createBulkTestItem(sku, name, description string, category domain.ItemCategory, price float64, unit domain.UnitOfMeasure, stock float64) *domain.InventoryItem {
+	item, _ := domain.NewInventoryItem(
+		sku, name, description, category,
+		money.FromFloat(price, "USD"),
+	)
+
+	// The unit must be set while the item is still empty
+	item.SetUnitOfMeasure(unit)
+	item.SetCostPrice(money.FromFloat(price*0.6, "USD"))
+	item.AddStock(stock, "Initial stock")
+
+	return item
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"math"
+	"sort"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// CategoryAvailability summarizes the stock of one item category. Every item
+// counts in exactly one of the in-stock, low-stock and out-of-stock buckets.
+// Discontinued items are left out.
+type CategoryAvailability struct {
+	Category         ItemCategory
+	ItemCount        int
+	InStockCount     int     // Available stock above the minimum level
+	LowStockCount    int     // Some available stock, at or below the minimum level
+	OutOfStockCount  int     // No available stock
+	ReservedQuantity float64 // Stock held by active reservations
+	Valuation        []Money // Stock on hand, reserved included, times unit price; one per currency
+}
+
+// SummarizeAvailability computes the availability summary of the items, one
+// entry per category that has items, in category order
+func SummarizeAvailability(items []*InventoryItem) []CategoryAvailability {
+	byCategory := make(map[ItemCategory]*CategoryAvailability)
+	valuations := make(map[ItemCategory]map[string]float64)
+	for _, item := range items {
+		if item.Status() == ItemStatusDiscontinued {
+			continue
+		}
+		summary, exists := byCategory[item.Category()]
+		if !exists {
+			summary = &CategoryAvailability{Category: item.Category()}
+			byCategory[item.Category()] = summary
+			valuations[item.Category()] = make(map[string]float64)
+		}
+
+		summary.ItemCount++
+		switch {
+		case item.IsOutOfStock():
+			summary.OutOfStockCount++
+		case item.IsLowStock():
+			summary.LowStockCount++
+		default:
+			summary.InStockCount++
+		}
+		summary.ReservedQuantity += item.ReservedStock()
+
+		price := item.UnitPrice()
+		valuations[item.Category()][price.Currency] += item.TotalStock() * float64(price.Minor)
+	}
+
+	summaries := make([]CategoryAvailability, 0, len(byCategory))
+	for _, category := range ItemCategories {
+		summary, exists := byCategory[category]
+		if !exists {
+			continue
+		}
+		for currency, minor := range valuations[category] {
+			summary.Valuation = append(summary.Valuation, money.New(int64(math.Round(minor)), currency))
+		}
+		SortValuation(summary.Valuation)
+		summaries = append(summaries, *summary)
+	}
+	return summaries
+}
+
+// SortValuation orders valuation amounts by currency
+func SortValuation(valuation []Money) {
+	sort.Slice(valuation, func(i, j int) bool { return valuation[i].Currency < valuation[j].Currency })
+}
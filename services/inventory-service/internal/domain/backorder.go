@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Backorder is an order's demand for items that were out of stock when it was
+// placed. It waits until stock arrives and is then reserved for the order as a
+// whole, under the order ID or, for one shipment of a partially fulfilled order,
+// the shipment ID.
+type Backorder struct {
+	id                         string
+	orderID                    string
+	shipmentID                 string // Empty when the whole order is backordered
+	status                     BackorderStatus
+	items                      []BackorderItem
+	reservationDurationMinutes int
+	priority                   ReservationPriority
+	reservationID              string // Set once reserved
+	createdAt                  time.Time
+	updatedAt                  time.Time
+	reservedAt                 *time.Time
+	version                    int
+}
+
+// BackorderStatus represents the lifecycle state of a backorder
+type BackorderStatus int
+
+const (
+	BackorderStatusWaiting BackorderStatus = iota // Waiting for stock
+	BackorderStatusReserved
+	BackorderStatusCancelled
+)
+
+// String provides human-readable backorder status names
+func (bs BackorderStatus) String() string {
+	switch bs {
+	case BackorderStatusWaiting:
+		return "waiting"
+	case BackorderStatusReserved:
+		return "reserved"
+	case BackorderStatusCancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseBackorderStatus parses a backorder status name
+func ParseBackorderStatus(name string) (BackorderStatus, bool) {
+	for _, status := range []BackorderStatus{BackorderStatusWaiting, BackorderStatusReserved, BackorderStatusCancelled} {
+		if status.String() == name {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
+// BackorderItem is the quantity of one item a backorder waits for
+type BackorderItem struct {
+	SKU      string
+	Quantity float64
+	Unit     UnitOfMeasure // Unit of Quantity; empty means the item's own unit
+}
+
+// NewBackorder creates a backorder waiting for stock
+func NewBackorder(orderID, shipmentID string, items []BackorderItem, reservationDurationMinutes int, priority ReservationPriority) (*Backorder, error) {
+	if orderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+	if len(items) == 0 {
+		return nil, ErrBackorderWithoutItems
+	}
+	for _, item := range items {
+		if item.SKU == "" {
+			return nil, ErrInvalidSKU
+		}
+		if item.Quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+	}
+
+	now := time.Now()
+	return &Backorder{
+		id:                         "bo_" + uuid.New().String(),
+		orderID:                    orderID,
+		shipmentID:                 shipmentID,
+		status:                     BackorderStatusWaiting,
+		items:                      items,
+		reservationDurationMinutes: reservationDurationMinutes,
+		priority:                   priority,
+		createdAt:                  now,
+		updatedAt:                  now,
+		version:                    1,
+	}, nil
+}
+
+// ReconstructBackorder recreates a backorder from persisted data
+func ReconstructBackorder(
+	id, orderID, shipmentID string,
+	status BackorderStatus,
+	items []BackorderItem,
+	reservationDurationMinutes int,
+	priority ReservationPriority,
+	reservationID string,
+	createdAt, updatedAt time.Time,
+	reservedAt *time.Time,
+	version int,
+) (*Backorder, error) {
+	if id == "" {
+		return nil, ErrBackorderNotFound
+	}
+	if len(items) == 0 {
+		return nil, ErrBackorderWithoutItems
+	}
+
+	return &Backorder{
+		id:                         id,
+		orderID:                    orderID,
+		shipmentID:                 shipmentID,
+		status:                     status,
+		items:                      items,
+		reservationDurationMinutes: reservationDurationMinutes,
+		priority:                   priority,
+		reservationID:              reservationID,
+		createdAt:                  createdAt,
+		updatedAt:                  updatedAt,
+		reservedAt:                 reservedAt,
+		version:                    version,
+	}, nil
+}
+
+// ReservationKey returns the order reference the backorder's stock is reserved under
+func (b *Backorder) ReservationKey() string {
+	if b.shipmentID != "" {
+		return b.shipmentID
+	}
+	return b.orderID
+}
+
+// MarkReserved records that the stock of the backorder was reserved
+func (b *Backorder) MarkReserved(reservationID string) error {
+	if b.status != BackorderStatusWaiting {
+		return ErrInvalidBackorderTransition
+	}
+
+	now := time.Now()
+	b.status = BackorderStatusReserved
+	b.reservationID = reservationID
+	b.reservedAt = &now
+	b.touch()
+	return nil
+}
+
+// Cancel gives up a backorder. A reserved backorder's reservation must be
+// released by the caller.
+func (b *Backorder) Cancel() error {
+	if b.status == BackorderStatusCancelled {
+		return ErrInvalidBackorderTransition
+	}
+
+	b.status = BackorderStatusCancelled
+	b.touch()
+	return nil
+}
+
+func (b *Backorder) touch() {
+	b.updatedAt = time.Now()
+	b.version++
+}
+
+// Getter methods
+
+func (b *Backorder) ID() string                      { return b.id }
+func (b *Backorder) OrderID() string                 { return b.orderID }
+func (b *Backorder) ShipmentID() string              { return b.shipmentID }
+func (b *Backorder) Status() BackorderStatus         { return b.status }
+func (b *Backorder) Items() []BackorderItem          { return b.items }
+func (b *Backorder) ReservationDurationMinutes() int { return b.reservationDurationMinutes }
+func (b *Backorder) Priority() ReservationPriority   { return b.priority }
+func (b *Backorder) ReservationID() string           { return b.reservationID }
+func (b *Backorder) CreatedAt() time.Time            { return b.createdAt }
+func (b *Backorder) UpdatedAt() time.Time            { return b.updatedAt }
+func (b *Backorder) ReservedAt() *time.Time          { return b.reservedAt }
+func (b *Backorder) Version() int                    { return b.version }
+
+// Backorder errors
+
+var (
+	ErrBackorderWithoutItems      = errors.New("backorder must have at least one item")
+	ErrBackorderNotFound          = errors.New("backorder not found")
+	ErrInvalidBackorderTransition = errors.New("backorder cannot change to the requested status")
+	ErrBackorderConflict          = errors.New("backorder was modified concurrently")
+)
+
+// BackorderRepository defines the contract for backorder persistence
+type BackorderRepository interface {
+	// Create stores a new backorder
+	Create(backorder *Backorder) error
+
+	// Update persists changes to a backorder, failing with ErrBackorderConflict
+	// if the stored version no longer matches expectedVersion
+	Update(backorder *Backorder, expectedVersion int) error
+
+	// FindOpenByReservationKey retrieves the waiting or reserved backorder reserved
+	// under the given order or shipment ID, returning nil if there is none
+	FindOpenByReservationKey(key string) (*Backorder, error)
+
+	// FindWaitingBySKU retrieves the backorders waiting for an item, oldest first
+	FindWaitingBySKU(sku string) ([]*Backorder, error)
+
+	// FindAll retrieves backorders, optionally filtered by status, oldest first
+	FindAll(status *BackorderStatus) ([]*Backorder, error)
+}
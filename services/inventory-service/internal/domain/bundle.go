@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// Bundle represents a kit product (e.g. "Starter Rocket Kit") composed of other inventory items.
+// A bundle holds no stock of its own: its availability is derived from its components
+// and reserving a bundle reserves each component.
+type Bundle struct {
+	sku         string            // Bundle SKU (e.g., "KIT-STARTER-001")
+	name        string            // Human-readable name
+	description string            // Detailed description
+	unitPrice   Money             // Price per kit
+	components  []BundleComponent // Component SKUs and quantities per kit
+
+	createdAt time.Time
+	updatedAt time.Time
+	version   int
+}
+
+// BundleComponent is a single component line of a bundle
+type BundleComponent struct {
+	SKU      string // Component item SKU
+	Quantity int    // Units of the component per bundle
+}
+
+// NewBundle creates a new bundle definition with validation
+func NewBundle(sku, name, description string, unitPrice Money, components []BundleComponent) (*Bundle, error) {
+	if sku == "" {
+		return nil, ErrInvalidSKU
+	}
+	if name == "" {
+		return nil, ErrInvalidName
+	}
+	if unitPrice.IsNegative() {
+		return nil, ErrInvalidPrice
+	}
+	if err := validateBundleComponents(sku, components); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Bundle{
+		sku:         sku,
+		name:        name,
+		description: description,
+		unitPrice:   unitPrice,
+		components:  append([]BundleComponent(nil), components...),
+		createdAt:   now,
+		updatedAt:   now,
+		version:     1,
+	}, nil
+}
+
+// ReconstructBundle recreates a bundle from persisted data
+func ReconstructBundle(
+	sku, name, description string,
+	unitPrice Money,
+	components []BundleComponent,
+	createdAt, updatedAt time.Time,
+	version int,
+) (*Bundle, error) {
+	if sku == "" {
+		return nil, ErrInvalidSKU
+	}
+	if err := validateBundleComponents(sku, components); err != nil {
+		return nil, err
+	}
+
+	return &Bundle{
+		sku:         sku,
+		name:        name,
+		description: description,
+		unitPrice:   unitPrice,
+		components:  components,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+		version:     version,
+	}, nil
+}
+
+// Update replaces the bundle definition, keeping its identity and creation time
+func (b *Bundle) Update(name, description string, unitPrice Money, components []BundleComponent) error {
+	if name == "" {
+		return ErrInvalidName
+	}
+	if unitPrice.IsNegative() {
+		return ErrInvalidPrice
+	}
+	if err := validateBundleComponents(b.sku, components); err != nil {
+		return err
+	}
+
+	b.name = name
+	b.description = description
+	b.unitPrice = unitPrice
+	b.components = append([]BundleComponent(nil), components...)
+	b.updatedAt = time.Now()
+	b.version++
+
+	return nil
+}
+
+// ComponentQuantities returns the component quantities needed for the given number of bundles
+func (b *Bundle) ComponentQuantities(bundles int) []BundleComponent {
+	quantities := make([]BundleComponent, len(b.components))
+	for i, component := range b.components {
+		quantities[i] = BundleComponent{SKU: component.SKU, Quantity: component.Quantity * bundles}
+	}
+	return quantities
+}
+
+// AvailableBundles computes how many bundles can be assembled from the given component items.
+// It also returns the SKU of the component limiting availability; a missing component yields zero.
+func (b *Bundle) AvailableBundles(items map[string]*InventoryItem) (int, string) {
+	available := -1
+	limitingSKU := ""
+
+	for _, component := range b.components {
+		item, exists := items[component.SKU]
+		if !exists || item == nil {
+			return 0, component.SKU
+		}
+
+		possible := int(math.Floor(item.GetAvailableStock() / float64(component.Quantity)))
+		if available < 0 || possible < available {
+			available = possible
+			limitingSKU = component.SKU
+		}
+	}
+
+	if available < 0 {
+		return 0, ""
+	}
+	return available, limitingSKU
+}
+
+// validateBundleComponents checks component lines are positive, unique and not self-referencing
+func validateBundleComponents(bundleSKU string, components []BundleComponent) error {
+	if len(components) == 0 {
+		return ErrBundleWithoutComponents
+	}
+
+	seen := make(map[string]bool, len(components))
+	for _, component := range components {
+		sku := strings.TrimSpace(component.SKU)
+		if sku == "" {
+			return ErrInvalidSKU
+		}
+		if component.Quantity <= 0 {
+			return ErrInvalidQuantity
+		}
+		if sku == bundleSKU {
+			return ErrBundleSelfReference
+		}
+		if seen[sku] {
+			return ErrDuplicateBundleComponent
+		}
+		seen[sku] = true
+	}
+
+	return nil
+}
+
+// Getter methods
+
+func (b *Bundle) SKU() string                   { return b.sku }
+func (b *Bundle) Name() string                  { return b.name }
+func (b *Bundle) Description() string           { return b.description }
+func (b *Bundle) UnitPrice() Money              { return b.unitPrice }
+func (b *Bundle) Components() []BundleComponent { return b.components }
+func (b *Bundle) CreatedAt() time.Time          { return b.createdAt }
+func (b *Bundle) UpdatedAt() time.Time          { return b.updatedAt }
+func (b *Bundle) Version() int                  { return b.version }
+
+// Bundle errors
+
+var (
+	ErrBundleWithoutComponents  = errors.New("bundle must have at least one component")
+	ErrBundleSelfReference      = errors.New("bundle cannot contain itself")
+	ErrDuplicateBundleComponent = errors.New("bundle component SKUs must be unique")
+	ErrBundleNotFound           = errors.New("bundle not found")
+	ErrBundleSKUConflict        = errors.New("bundle SKU conflicts with an existing inventory item")
+	ErrBundleComponentNotFound  = errors.New("bundle component item not found")
+)
+
+// BundleRepository defines the contract for bundle definition persistence
+type BundleRepository interface {
+	// Save persists a bundle definition
+	Save(bundle *Bundle) error
+
+	// FindBySKU retrieves a bundle by its SKU, returning nil if it does not exist
+	FindBySKU(sku string) (*Bundle, error)
+
+	// FindAll retrieves all bundle definitions
+	FindAll() ([]*Bundle, error)
+
+	// Delete removes a bundle definition
+	Delete(sku string) error
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// CategoryPolicy holds the stocking rules of an item category. Its levels are
+// the defaults of new items in the category, and its reservation TTL, restock
+// quantity and currencies take the place of the global settings for every item
+// in it. Zero values fall back to the global settings.
+type CategoryPolicy struct {
+	category ItemCategory
+	settings CategoryPolicySettings
+
+	createdAt time.Time
+	updatedAt time.Time
+	version   int
+}
+
+// CategoryPolicySettings are the rules a category policy sets
+type CategoryPolicySettings struct {
+	MinStockLevel     float64       // Default low stock threshold of new items
+	MaxStockLevel     float64       // Default capacity of new items; zero keeps the item default
+	ReservationTTL    time.Duration // Longest and default reservation hold; zero for the global maximum
+	RestockQuantity   float64       // Quantity to reorder when low; zero restocks up to the maximum level
+	AllowedCurrencies []string      // ISO 4217 codes prices and costs may use; empty allows any
+}
+
+// NewCategoryPolicy creates a new category policy with validation
+func NewCategoryPolicy(category ItemCategory, settings CategoryPolicySettings) (*CategoryPolicy, error) {
+	settings, err := validateCategoryPolicySettings(category, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &CategoryPolicy{
+		category:  category,
+		settings:  settings,
+		createdAt: now,
+		updatedAt: now,
+		version:   1,
+	}, nil
+}
+
+// ReconstructCategoryPolicy recreates a category policy from persisted data
+func ReconstructCategoryPolicy(
+	category ItemCategory,
+	settings CategoryPolicySettings,
+	createdAt, updatedAt time.Time,
+	version int,
+) (*CategoryPolicy, error) {
+	settings, err := validateCategoryPolicySettings(category, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CategoryPolicy{
+		category:  category,
+		settings:  settings,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+		version:   version,
+	}, nil
+}
+
+// Update replaces the policy's rules, keeping its category and creation time
+func (p *CategoryPolicy) Update(settings CategoryPolicySettings) error {
+	settings, err := validateCategoryPolicySettings(p.category, settings)
+	if err != nil {
+		return err
+	}
+
+	p.settings = settings
+	p.updatedAt = time.Now()
+	p.version++
+
+	return nil
+}
+
+// AllowsCurrency reports whether prices and costs of the category may use the currency
+func (p *CategoryPolicy) AllowsCurrency(currency string) bool {
+	if len(p.settings.AllowedCurrencies) == 0 {
+		return true
+	}
+	for _, allowed := range p.settings.AllowedCurrencies {
+		if strings.EqualFold(allowed, currency) {
+			return true
+		}
+	}
+	return false
+}
+
+// RestockQuantity returns the quantity of the item to reorder: the policy's
+// restock quantity, or what brings its total stock up to its maximum level
+func (p *CategoryPolicy) RestockQuantity(item *InventoryItem) float64 {
+	if p.settings.RestockQuantity > 0 {
+		return p.settings.RestockQuantity
+	}
+	return math.Max(0, item.MaxStockLevel()-item.TotalStock())
+}
+
+// validateCategoryPolicySettings checks the levels and quantities and normalizes the currencies
+func validateCategoryPolicySettings(category ItemCategory, settings CategoryPolicySettings) (CategoryPolicySettings, error) {
+	if category.String() == "unknown" {
+		return settings, ErrInvalidCategory
+	}
+	if settings.MinStockLevel < 0 || settings.MaxStockLevel < 0 || settings.RestockQuantity < 0 {
+		return settings, ErrInvalidCategoryPolicy
+	}
+	if settings.MaxStockLevel > 0 && settings.MaxStockLevel < settings.MinStockLevel {
+		return settings, ErrInvalidCategoryPolicy
+	}
+	if settings.ReservationTTL < 0 || settings.ReservationTTL%time.Minute != 0 {
+		return settings, ErrInvalidCategoryPolicy
+	}
+
+	currencies := make([]string, 0, len(settings.AllowedCurrencies))
+	for _, currency := range settings.AllowedCurrencies {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if len(currency) != 3 {
+			return settings, ErrInvalidCategoryPolicy
+		}
+		currencies = append(currencies, currency)
+	}
+	settings.AllowedCurrencies = currencies
+
+	return settings, nil
+}
+
+// Getter methods
+
+func (p *CategoryPolicy) Category() ItemCategory           { return p.category }
+func (p *CategoryPolicy) Settings() CategoryPolicySettings { return p.settings }
+func (p *CategoryPolicy) CreatedAt() time.Time             { return p.createdAt }
+func (p *CategoryPolicy) UpdatedAt() time.Time             { return p.updatedAt }
+func (p *CategoryPolicy) Version() int                     { return p.version }
+
+// Category policy errors
+
+var (
+	ErrInvalidCategory        = errors.New("invalid item category")
+	ErrInvalidCategoryPolicy  = errors.New("invalid category policy levels, reservation TTL or currencies")
+	ErrCategoryPolicyNotFound = errors.New("category policy not found")
+	ErrCurrencyNotAllowed     = errors.New("currency is not allowed for the item category")
+)
+
+// CategoryPolicyRepository defines the contract for category policy persistence
+type CategoryPolicyRepository interface {
+	// Save persists a category policy
+	Save(policy *CategoryPolicy) error
+
+	// FindByCategory retrieves the policy of a category, returning nil if it has none
+	FindByCategory(category ItemCategory) (*CategoryPolicy, error)
+
+	// FindAll retrieves all category policies
+	FindAll() ([]*CategoryPolicy, error)
+
+	// Delete removes the policy of a category
+	Delete(category ItemCategory) error
+}
@@ -3,9 +3,12 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
 )
 
 // InventoryItem represents a rocket part in our inventory
@@ -20,18 +23,22 @@ type InventoryItem struct {
 	// Categorization
 	category ItemCategory // Engine, Fuel Tank, Navigation, etc.
 
-	// Stock management
-	stockLevel    int // Current available stock
-	reservedStock int // Currently reserved stock
-	totalStock    int // Total stock (available + reserved)
-	minStockLevel int // Minimum stock threshold
-	maxStockLevel int // Maximum stock capacity
+	// Stock management, in the item's unit of measure
+	unit          UnitOfMeasure // Unit stock is kept and reserved in (ea, kg, l, ...)
+	stockLevel    float64       // Current available stock
+	reservedStock float64       // Currently reserved stock
+	totalStock    float64       // Total stock (available + reserved)
+	minStockLevel float64       // Minimum stock threshold
+	maxStockLevel float64       // Maximum stock capacity
 
 	// Reservations
 	reservations map[string]*Reservation // Active reservations by order ID
 
 	// Pricing and specifications
 	unitPrice      Money             // Price per unit
+	costPrice      Money             // Cost per unit; its currency is empty if unknown
+	costLayers     []CostLayer       // Receipts still on hand, oldest first
+	averageCost    Money             // Moving average unit cost of the cost layers
 	weight         float64           // Weight in kg
 	dimensions     Dimensions        // Physical dimensions
 	specifications map[string]string // Technical specifications
@@ -42,7 +49,11 @@ type InventoryItem struct {
 	version   int       // Version for optimistic locking
 
 	// Status
-	status ItemStatus // Active, Discontinued, OutOfStock
+	status         ItemStatus // Active, Discontinued, OutOfStock
+	replacementSKU string     // Item superseding a discontinued one, if any
+
+	// Serialization
+	serialTracked bool // Units are tracked individually by serial number
 }
 
 // ItemCategory represents different types of rocket parts
@@ -59,6 +70,28 @@ const (
 	CategoryLandingGear
 )
 
+// ItemCategories lists every item category in display order
+var ItemCategories = []ItemCategory{
+	CategoryEngines,
+	CategoryFuelTanks,
+	CategoryNavigation,
+	CategoryStructural,
+	CategoryElectronics,
+	CategoryLifeSupport,
+	CategoryPayload,
+	CategoryLandingGear,
+}
+
+// ParseItemCategory returns the category with the given name, as produced by String
+func ParseItemCategory(name string) (ItemCategory, bool) {
+	for _, category := range ItemCategories {
+		if category.String() == name {
+			return category, true
+		}
+	}
+	return 0, false
+}
+
 // String provides human-readable category names
 func (ic ItemCategory) String() string {
 	switch ic {
@@ -112,11 +145,8 @@ func (is ItemStatus) String() string {
 	}
 }
 
-// Money represents currency amounts (reused from payment service concept)
-type Money struct {
-	Amount   float64 // The monetary amount
-	Currency string  // Currency code (e.g., "USD")
-}
+// Money represents currency amounts in minor units (the shared contracts money type)
+type Money = money.Money
 
 // Dimensions represents physical dimensions of rocket parts
 type Dimensions struct {
@@ -130,20 +160,22 @@ type Reservation struct {
 	id         string    // Unique reservation identifier
 	orderID    string    // Associated order ID
 	itemID     string    // Item being reserved
-	quantity   int       // Quantity reserved
+	quantity   float64   // Quantity reserved, in the item's unit
 	reservedAt time.Time // When reservation was made
 	expiresAt  time.Time // When reservation expires
 	status     ReservationStatus
+	priority   ReservationPriority // Higher priorities may preempt lower ones nearing expiry
 }
 
 // Reservation getter methods
-func (r *Reservation) ID() string                { return r.id }
-func (r *Reservation) OrderID() string           { return r.orderID }
-func (r *Reservation) ItemID() string            { return r.itemID }
-func (r *Reservation) Quantity() int             { return r.quantity }
-func (r *Reservation) ReservedAt() time.Time     { return r.reservedAt }
-func (r *Reservation) ExpiresAt() time.Time      { return r.expiresAt }
-func (r *Reservation) Status() ReservationStatus { return r.status }
+func (r *Reservation) ID() string                    { return r.id }
+func (r *Reservation) OrderID() string               { return r.orderID }
+func (r *Reservation) ItemID() string                { return r.itemID }
+func (r *Reservation) Quantity() float64             { return r.quantity }
+func (r *Reservation) ReservedAt() time.Time         { return r.reservedAt }
+func (r *Reservation) ExpiresAt() time.Time          { return r.expiresAt }
+func (r *Reservation) Status() ReservationStatus     { return r.status }
+func (r *Reservation) Priority() ReservationPriority { return r.priority }
 
 // IsExpired checks if the reservation has expired
 func (r *Reservation) IsExpired() bool {
@@ -163,6 +195,7 @@ const (
 	ReservationStatusConfirmed
 	ReservationStatusExpired
 	ReservationStatusCancelled
+	ReservationStatusPreempted
 )
 
 // String provides human-readable reservation status names
@@ -176,6 +209,28 @@ func (rs ReservationStatus) String() string {
 		return "expired"
 	case ReservationStatusCancelled:
 		return "cancelled"
+	case ReservationStatusPreempted:
+		return "preempted"
+	default:
+		return "unknown"
+	}
+}
+
+// ReservationPriority ranks reservations for preemption
+type ReservationPriority int
+
+const (
+	ReservationPriorityStandard ReservationPriority = iota
+	ReservationPriorityExpedited
+)
+
+// String provides human-readable reservation priority names
+func (rp ReservationPriority) String() string {
+	switch rp {
+	case ReservationPriorityStandard:
+		return "standard"
+	case ReservationPriorityExpedited:
+		return "expedited"
 	default:
 		return "unknown"
 	}
@@ -187,8 +242,8 @@ func (rs ReservationStatus) String() string {
 type StockUpdatedEvent struct {
 	ItemID       string
 	SKU          string
-	OldStock     int
-	NewStock     int
+	OldStock     float64
+	NewStock     float64
 	ChangeReason string
 	UpdatedAt    time.Time
 }
@@ -197,8 +252,8 @@ type StockUpdatedEvent struct {
 type LowStockEvent struct {
 	ItemID       string
 	SKU          string
-	CurrentStock int
-	MinThreshold int
+	CurrentStock float64
+	MinThreshold float64
 	AlertedAt    time.Time
 }
 
@@ -207,7 +262,7 @@ type ReservationCreatedEvent struct {
 	ReservationID string
 	OrderID       string
 	ItemID        string
-	Quantity      int
+	Quantity      float64
 	CreatedAt     time.Time
 }
 
@@ -222,7 +277,7 @@ func NewInventoryItem(sku, name, description string, category ItemCategory, unit
 	if name == "" {
 		return nil, ErrInvalidName
 	}
-	if unitPrice.Amount < 0 {
+	if unitPrice.IsNegative() {
 		return nil, ErrInvalidPrice
 	}
 
@@ -235,6 +290,7 @@ func NewInventoryItem(sku, name, description string, category ItemCategory, unit
 		name:           name,
 		description:    description,
 		category:       category,
+		unit:           UnitEach,
 		stockLevel:     0,
 		reservedStock:  0,
 		totalStock:     0,
@@ -257,7 +313,7 @@ func NewInventoryItem(sku, name, description string, category ItemCategory, unit
 func ReconstructInventoryItem(
 	id, sku, name, description string,
 	category ItemCategory,
-	stockLevel, reservedStock, totalStock, minStockLevel, maxStockLevel int,
+	stockLevel, reservedStock, totalStock, minStockLevel, maxStockLevel float64,
 	unitPrice Money,
 	weight float64,
 	dimensions Dimensions,
@@ -284,6 +340,7 @@ func ReconstructInventoryItem(
 		name:           name,
 		description:    description,
 		category:       category,
+		unit:           UnitEach,
 		stockLevel:     stockLevel,
 		reservedStock:  reservedStock,
 		totalStock:     totalStock,
@@ -300,8 +357,12 @@ func ReconstructInventoryItem(
 		status:         status,
 	}
 
-	// Validate reconstructed state
+	// Validate reconstructed state. Inconsistent stock levels still return the
+	// item, so the levels can be repaired with RepairStock.
 	if err := item.validateState(); err != nil {
+		if errors.Is(err, ErrStockInvariant) {
+			return item, fmt.Errorf("invalid reconstructed state: %w", err)
+		}
 		return nil, fmt.Errorf("invalid reconstructed state: %w", err)
 	}
 
@@ -312,9 +373,10 @@ func ReconstructInventoryItem(
 // This method should only be called during object restoration from persistence
 func (item *InventoryItem) RestoreReservation(
 	id, orderID string,
-	quantity int,
+	quantity float64,
 	reservedAt, expiresAt time.Time,
 	status ReservationStatus,
+	priority ReservationPriority,
 ) error {
 	if id == "" {
 		return ErrInvalidReservationID
@@ -334,19 +396,43 @@ func (item *InventoryItem) RestoreReservation(
 		reservedAt: reservedAt,
 		expiresAt:  expiresAt,
 		status:     status,
+		priority:   priority,
 	}
 
 	item.reservations[orderID] = reservation
 	return nil
 }
 
+// RestoreSerialTracked restores the serial tracking flag during reconstruction
+func (item *InventoryItem) RestoreSerialTracked() {
+	item.serialTracked = true
+}
+
+// RestoreReplacementSKU restores the replacement of a discontinued item during reconstruction
+func (item *InventoryItem) RestoreReplacementSKU(sku string) {
+	item.replacementSKU = sku
+}
+
+// RestoreUnitOfMeasure restores the unit of measure during reconstruction.
+// Items persisted before units were introduced have none and are stocked in pieces.
+func (item *InventoryItem) RestoreUnitOfMeasure(unit UnitOfMeasure) error {
+	if unit == "" {
+		unit = UnitEach
+	}
+	if !unit.IsValid() {
+		return fmt.Errorf("%w: %q", ErrUnknownUnit, unit)
+	}
+	item.unit = unit
+	return nil
+}
+
 // SetInternalState allows setting internal state during reconstruction
 // This method should only be used by repositories during object restoration
 func (item *InventoryItem) SetInternalState(
 	weight float64,
 	dimensions Dimensions,
 	specifications map[string]string,
-	minStockLevel, maxStockLevel int,
+	minStockLevel, maxStockLevel float64,
 ) error {
 	if minStockLevel < 0 {
 		return ErrInvalidStockLevel
@@ -364,20 +450,68 @@ func (item *InventoryItem) SetInternalState(
 	return nil
 }
 
-// validateState validates the internal state of a reconstructed item
-func (item *InventoryItem) validateState() error {
-	// Validate stock levels are consistent
-	if item.stockLevel < 0 {
-		return fmt.Errorf("stock level cannot be negative")
+// SetSerialTracked enables or disables per-unit serial number tracking for the item.
+// Only items stocked in pieces can be tracked.
+func (item *InventoryItem) SetSerialTracked(tracked bool) error {
+	if item.serialTracked == tracked {
+		return nil
+	}
+	if tracked && !item.unit.IsCountable() {
+		return ErrSerialUncountableUnit
+	}
+	item.serialTracked = tracked
+	item.updatedAt = time.Now()
+	item.version++
+	return nil
+}
+
+// SetUnitOfMeasure changes the unit the item is stocked in. Existing levels would be
+// meaningless in another unit, so this is only allowed while the item holds no stock.
+func (item *InventoryItem) SetUnitOfMeasure(unit UnitOfMeasure) error {
+	if !unit.IsValid() {
+		return fmt.Errorf("%w: %q", ErrUnknownUnit, unit)
 	}
-	if item.reservedStock < 0 {
-		return fmt.Errorf("reserved stock cannot be negative")
+	if item.unit == unit {
+		return nil
 	}
-	if item.totalStock < 0 {
-		return fmt.Errorf("total stock cannot be negative")
+	if item.totalStock != 0 || len(item.reservations) > 0 {
+		return ErrUnitChangeWithStock
 	}
-	if item.stockLevel+item.reservedStock > item.totalStock {
-		return fmt.Errorf("available + reserved stock cannot exceed total stock")
+	if item.serialTracked && !unit.IsCountable() {
+		return ErrSerialUncountableUnit
+	}
+	item.unit = unit
+	item.updatedAt = time.Now()
+	item.version++
+	return nil
+}
+
+// SetStockThresholds sets the low stock threshold and the capacity of the item
+func (item *InventoryItem) SetStockThresholds(minStockLevel, maxStockLevel float64) error {
+	if minStockLevel < 0 || maxStockLevel <= 0 || maxStockLevel < minStockLevel {
+		return ErrInvalidStockLevel
+	}
+	item.minStockLevel = minStockLevel
+	item.maxStockLevel = maxStockLevel
+	item.updatedAt = time.Now()
+	item.version++
+	return nil
+}
+
+// ToStockUnit converts a quantity expressed in the given unit into the item's unit.
+// An empty unit means the quantity is already in the item's unit.
+func (item *InventoryItem) ToStockUnit(quantity float64, unit UnitOfMeasure) (float64, error) {
+	if unit == "" {
+		unit = item.unit
+	}
+	return ConvertQuantity(quantity, unit, item.unit)
+}
+
+// validateState validates the internal state of a reconstructed item
+func (item *InventoryItem) validateState() error {
+	// Validate stock levels are consistent
+	if err := item.guardLevels("load", item.Levels()); err != nil {
+		return err
 	}
 
 	// Validate min/max stock levels
@@ -389,7 +523,7 @@ func (item *InventoryItem) validateState() error {
 	}
 
 	// Validate price
-	if item.unitPrice.Amount < 0 {
+	if item.unitPrice.IsNegative() {
 		return fmt.Errorf("unit price cannot be negative")
 	}
 
@@ -398,15 +532,35 @@ func (item *InventoryItem) validateState() error {
 
 // Business methods - these encapsulate inventory business logic
 
-// AddStock increases the available stock
-func (item *InventoryItem) AddStock(quantity int, reason string) error {
-	if quantity <= 0 {
-		return ErrInvalidQuantity
+// AddStock increases the available stock. The stock is costed at the item's
+// cost price, if it has one.
+func (item *InventoryItem) AddStock(quantity float64, reason string) error {
+	if err := item.addStock(quantity); err != nil {
+		return err
+	}
+	if item.costPrice.Currency != "" {
+		item.addCostLayer(item.unit.Round(quantity), item.costPrice, reason)
+	}
+	return nil
+}
+
+func (item *InventoryItem) addStock(quantity float64) error {
+	if err := item.unit.ValidateQuantity(quantity); err != nil {
+		return err
+	}
+
+	levels := StockLevels{
+		Available: item.unit.Round(item.stockLevel + quantity),
+		Reserved:  item.reservedStock,
+		Total:     item.unit.Round(item.totalStock + quantity),
+	}
+	if err := item.guardLevels("add stock", levels); err != nil {
+		return err
 	}
 
 	// oldStock := item.stockLevel // Can be used for event sourcing later
-	item.stockLevel += quantity
-	item.totalStock += quantity
+	item.stockLevel = levels.Available
+	item.totalStock = levels.Total
 	item.updatedAt = time.Now()
 	item.version++
 
@@ -426,18 +580,30 @@ func (item *InventoryItem) AddStock(quantity int, reason string) error {
 	return nil
 }
 
-// RemoveStock decreases the available stock
-func (item *InventoryItem) RemoveStock(quantity int, reason string) error {
-	if quantity <= 0 {
-		return ErrInvalidQuantity
+// RemoveStock decreases the available stock, using up the oldest cost layers
+func (item *InventoryItem) RemoveStock(quantity float64, reason string) error {
+	if err := item.unit.ValidateQuantity(quantity); err != nil {
+		return err
 	}
+	quantity = item.unit.Round(quantity)
 	if quantity > item.stockLevel {
 		return ErrInsufficientStock
 	}
 
+	levels := StockLevels{
+		Available: item.unit.Round(item.stockLevel - quantity),
+		Reserved:  item.reservedStock,
+		Total:     item.unit.Round(item.totalStock - quantity),
+	}
+	if err := item.guardLevels("remove stock", levels); err != nil {
+		return err
+	}
+
 	// oldStock := item.stockLevel // Can be used for event sourcing later
-	item.stockLevel -= quantity
-	item.totalStock -= quantity
+	item.stockLevel = levels.Available
+	item.totalStock = levels.Total
+	// Stock written off uses up its cost layers like stock sold
+	item.issueCost(quantity)
 	item.updatedAt = time.Now()
 	item.version++
 
@@ -457,15 +623,21 @@ func (item *InventoryItem) RemoveStock(quantity int, reason string) error {
 	return nil
 }
 
-// ReserveStock creates a reservation for the specified quantity
-func (item *InventoryItem) ReserveStock(orderID string, quantity int, expirationMinutes int) (*Reservation, error) {
+// ReserveStock creates a reservation for the specified quantity, in the item's unit
+func (item *InventoryItem) ReserveStock(
+	orderID string,
+	quantity float64,
+	expirationMinutes int,
+	priority ReservationPriority,
+) (*Reservation, error) {
 	// Business rules validation
 	if orderID == "" {
 		return nil, ErrInvalidOrderID
 	}
-	if quantity <= 0 {
-		return nil, ErrInvalidQuantity
+	if err := item.unit.ValidateQuantity(quantity); err != nil {
+		return nil, err
 	}
+	quantity = item.unit.Round(quantity)
 	if quantity > item.GetAvailableStock() {
 		return nil, ErrInsufficientStock
 	}
@@ -475,6 +647,15 @@ func (item *InventoryItem) ReserveStock(orderID string, quantity int, expiration
 		return nil, ErrReservationAlreadyExists
 	}
 
+	levels := StockLevels{
+		Available: item.unit.Round(item.stockLevel - quantity),
+		Reserved:  item.unit.Round(item.reservedStock + quantity),
+		Total:     item.totalStock,
+	}
+	if err := item.guardLevels("reserve stock", levels); err != nil {
+		return nil, err
+	}
+
 	// Create reservation
 	reservation := &Reservation{
 		id:         uuid.New().String(),
@@ -484,11 +665,12 @@ func (item *InventoryItem) ReserveStock(orderID string, quantity int, expiration
 		reservedAt: time.Now(),
 		expiresAt:  time.Now().Add(time.Duration(expirationMinutes) * time.Minute),
 		status:     ReservationStatusActive,
+		priority:   priority,
 	}
 
 	// Update stock levels
-	item.stockLevel -= quantity
-	item.reservedStock += quantity
+	item.stockLevel = levels.Available
+	item.reservedStock = levels.Reserved
 	item.reservations[orderID] = reservation
 	item.updatedAt = time.Now()
 	item.version++
@@ -496,21 +678,32 @@ func (item *InventoryItem) ReserveStock(orderID string, quantity int, expiration
 	return reservation, nil
 }
 
-// ConfirmReservation converts a reservation to a confirmed sale
-func (item *InventoryItem) ConfirmReservation(orderID string) error {
+// ConfirmReservation converts a reservation to a confirmed sale and returns the
+// cost of the goods sold
+func (item *InventoryItem) ConfirmReservation(orderID string) (CostOfGoods, error) {
 	reservation, exists := item.reservations[orderID]
 	if !exists {
-		return ErrReservationNotFound
+		return CostOfGoods{}, ErrReservationNotFound
 	}
 
 	if reservation.status != ReservationStatusActive {
-		return ErrInvalidReservationStatus
+		return CostOfGoods{}, ErrInvalidReservationStatus
+	}
+
+	levels := StockLevels{
+		Available: item.stockLevel,
+		Reserved:  item.unit.Round(item.reservedStock - reservation.quantity),
+		Total:     item.unit.Round(item.totalStock - reservation.quantity),
+	}
+	if err := item.guardLevels("confirm reservation", levels); err != nil {
+		return CostOfGoods{}, err
 	}
 
 	// Confirm the reservation (stock already removed from available)
 	reservation.status = ReservationStatusConfirmed
-	item.reservedStock -= reservation.quantity
-	item.totalStock -= reservation.quantity
+	item.reservedStock = levels.Reserved
+	item.totalStock = levels.Total
+	cost := item.issueCost(reservation.quantity)
 	item.updatedAt = time.Now()
 	item.version++
 
@@ -520,7 +713,7 @@ func (item *InventoryItem) ConfirmReservation(orderID string) error {
 	// Update status
 	item.updateStatus()
 
-	return nil
+	return cost, nil
 }
 
 // ReleaseReservation cancels a reservation and returns stock to available
@@ -534,9 +727,14 @@ func (item *InventoryItem) ReleaseReservation(orderID string) error {
 		return ErrInvalidReservationStatus
 	}
 
+	levels := item.releasedLevels(reservation)
+	if err := item.guardLevels("release reservation", levels); err != nil {
+		return err
+	}
+
 	// Return stock to available
-	item.stockLevel += reservation.quantity
-	item.reservedStock -= reservation.quantity
+	item.stockLevel = levels.Available
+	item.reservedStock = levels.Reserved
 	reservation.status = ReservationStatusCancelled
 	item.updatedAt = time.Now()
 	item.version++
@@ -550,21 +748,67 @@ func (item *InventoryItem) ReleaseReservation(orderID string) error {
 	return nil
 }
 
-// CheckAvailability verifies if the requested quantity is available
-func (item *InventoryItem) CheckAvailability(quantity int) bool {
-	return quantity > 0 && quantity <= item.GetAvailableStock()
+// CheckAvailability verifies if the requested quantity, in the item's unit, is available
+func (item *InventoryItem) CheckAvailability(quantity float64) bool {
+	return quantity > 0 && item.unit.Round(quantity) <= item.GetAvailableStock()
 }
 
-// CleanupExpiredReservations removes expired reservations and returns stock
-func (item *InventoryItem) CleanupExpiredReservations() []string {
+// Discontinue takes the item out of the range, optionally naming the item that
+// supersedes it. Discontinuing again only changes the replacement.
+func (item *InventoryItem) Discontinue(replacementSKU string) error {
+	if replacementSKU == item.sku {
+		return ErrReplacementSelfReference
+	}
+	if item.status == ItemStatusDiscontinued && item.replacementSKU == replacementSKU {
+		return nil
+	}
+	item.status = ItemStatusDiscontinued
+	item.replacementSKU = replacementSKU
+	item.updatedAt = time.Now()
+	item.version++
+	return nil
+}
+
+// MarkIncoming flags an out-of-stock item as having replenishment in transit
+func (item *InventoryItem) MarkIncoming() {
+	if item.status == ItemStatusDiscontinued || item.status == ItemStatusIncoming || item.stockLevel > 0 {
+		return
+	}
+	item.status = ItemStatusIncoming
+	item.updatedAt = time.Now()
+	item.version++
+}
+
+// ClearIncoming removes the incoming flag once no replenishment is in transit anymore
+func (item *InventoryItem) ClearIncoming() {
+	if item.status != ItemStatusIncoming {
+		return
+	}
+	item.status = ItemStatusActive
+	item.updateStatus()
+	item.updatedAt = time.Now()
+	item.version++
+}
+
+// CleanupExpiredReservations removes expired reservations and returns stock.
+// Reservations whose release would break the stock invariants are kept, and
+// reported in the returned error, until the item is repaired.
+func (item *InventoryItem) CleanupExpiredReservations() ([]string, error) {
 	now := time.Now()
 	expiredOrders := make([]string, 0)
+	var violations []error
 
 	for orderID, reservation := range item.reservations {
 		if now.After(reservation.expiresAt) {
+			levels := item.releasedLevels(reservation)
+			if err := item.guardLevels("expire reservation", levels); err != nil {
+				violations = append(violations, err)
+				continue
+			}
+
 			// Return stock to available
-			item.stockLevel += reservation.quantity
-			item.reservedStock -= reservation.quantity
+			item.stockLevel = levels.Available
+			item.reservedStock = levels.Reserved
 			reservation.status = ReservationStatusExpired
 
 			expiredOrders = append(expiredOrders, orderID)
@@ -578,7 +822,89 @@ func (item *InventoryItem) CleanupExpiredReservations() []string {
 		item.updateStatus()
 	}
 
-	return expiredOrders
+	return expiredOrders, errors.Join(violations...)
+}
+
+// PreemptibleStock returns the stock held by active reservations of a lower
+// priority that expire within the window, in the item's unit
+func (item *InventoryItem) PreemptibleStock(priority ReservationPriority, window time.Duration) float64 {
+	var preemptible float64
+	for _, reservation := range item.preemptionCandidates(priority, window) {
+		preemptible += reservation.quantity
+	}
+	return item.unit.Round(preemptible)
+}
+
+// PreemptReservations releases lower-priority reservations nearing expiry,
+// soonest expiry first, until the quantity becomes available. Nothing is
+// released when the candidates cannot free enough stock. The preempted
+// reservations are returned so their orders can be compensated.
+func (item *InventoryItem) PreemptReservations(
+	quantity float64,
+	priority ReservationPriority,
+	window time.Duration,
+) ([]*Reservation, error) {
+	if err := item.unit.ValidateQuantity(quantity); err != nil {
+		return nil, err
+	}
+	quantity = item.unit.Round(quantity)
+	if quantity <= item.GetAvailableStock() {
+		return nil, nil
+	}
+	if quantity > item.unit.Round(item.GetAvailableStock()+item.PreemptibleStock(priority, window)) {
+		return nil, ErrInsufficientStock
+	}
+
+	var preempted []*Reservation
+	for _, reservation := range item.preemptionCandidates(priority, window) {
+		if quantity <= item.GetAvailableStock() {
+			break
+		}
+
+		levels := item.releasedLevels(reservation)
+		if err := item.guardLevels("preempt reservation", levels); err != nil {
+			return preempted, err
+		}
+
+		item.stockLevel = levels.Available
+		item.reservedStock = levels.Reserved
+		reservation.status = ReservationStatusPreempted
+		delete(item.reservations, reservation.orderID)
+		preempted = append(preempted, reservation)
+	}
+
+	item.updatedAt = time.Now()
+	item.version++
+	item.updateStatus()
+
+	return preempted, nil
+}
+
+// preemptionCandidates returns the active reservations below the priority
+// that expire within the window, ordered by expiry
+func (item *InventoryItem) preemptionCandidates(priority ReservationPriority, window time.Duration) []*Reservation {
+	deadline := time.Now().Add(window)
+	candidates := make([]*Reservation, 0)
+	for _, reservation := range item.reservations {
+		if reservation.status == ReservationStatusActive &&
+			reservation.priority < priority &&
+			!reservation.expiresAt.After(deadline) {
+			candidates = append(candidates, reservation)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].expiresAt.Before(candidates[j].expiresAt)
+	})
+	return candidates
+}
+
+// releasedLevels returns the stock levels after returning a reservation to available stock
+func (item *InventoryItem) releasedLevels(reservation *Reservation) StockLevels {
+	return StockLevels{
+		Available: item.unit.Round(item.stockLevel + reservation.quantity),
+		Reserved:  item.unit.Round(item.reservedStock - reservation.quantity),
+		Total:     item.totalStock,
+	}
 }
 
 // updateStatus updates item status based on current stock levels
@@ -588,6 +914,9 @@ func (item *InventoryItem) updateStatus() {
 	}
 
 	if item.stockLevel <= 0 {
+		if item.status == ItemStatusIncoming {
+			return // Replenishment is on its way; cleared by ClearIncoming or new stock
+		}
 		item.status = ItemStatusOutOfStock
 	} else if item.stockLevel <= item.minStockLevel {
 		// Could trigger low stock alert
@@ -604,11 +933,12 @@ func (item *InventoryItem) SKU() string                       { return item.sku
 func (item *InventoryItem) Name() string                      { return item.name }
 func (item *InventoryItem) Description() string               { return item.description }
 func (item *InventoryItem) Category() ItemCategory            { return item.category }
-func (item *InventoryItem) StockLevel() int                   { return item.stockLevel }
-func (item *InventoryItem) ReservedStock() int                { return item.reservedStock }
-func (item *InventoryItem) TotalStock() int                   { return item.totalStock }
-func (item *InventoryItem) MinStockLevel() int                { return item.minStockLevel }
-func (item *InventoryItem) MaxStockLevel() int                { return item.maxStockLevel }
+func (item *InventoryItem) Unit() UnitOfMeasure               { return item.unit }
+func (item *InventoryItem) StockLevel() float64               { return item.stockLevel }
+func (item *InventoryItem) ReservedStock() float64            { return item.reservedStock }
+func (item *InventoryItem) TotalStock() float64               { return item.totalStock }
+func (item *InventoryItem) MinStockLevel() float64            { return item.minStockLevel }
+func (item *InventoryItem) MaxStockLevel() float64            { return item.maxStockLevel }
 func (item *InventoryItem) UnitPrice() Money                  { return item.unitPrice }
 func (item *InventoryItem) Weight() float64                   { return item.weight }
 func (item *InventoryItem) Dimensions() Dimensions            { return item.dimensions }
@@ -617,9 +947,11 @@ func (item *InventoryItem) CreatedAt() time.Time              { return item.crea
 func (item *InventoryItem) UpdatedAt() time.Time              { return item.updatedAt }
 func (item *InventoryItem) Version() int                      { return item.version }
 func (item *InventoryItem) Status() ItemStatus                { return item.status }
+func (item *InventoryItem) SerialTracked() bool               { return item.serialTracked }
+func (item *InventoryItem) ReplacementSKU() string            { return item.replacementSKU }
 
 // GetAvailableStock returns stock available for new reservations
-func (item *InventoryItem) GetAvailableStock() int {
+func (item *InventoryItem) GetAvailableStock() float64 {
 	return item.stockLevel
 }
 
@@ -661,10 +993,24 @@ var (
 	ErrInvalidReservationStatus = errors.New("invalid reservation status for this operation")
 	ErrItemNotFound             = errors.New("inventory item not found")
 	ErrItemAlreadyExists        = errors.New("inventory item with this SKU already exists")
+	ErrReplacementSelfReference = errors.New("item cannot replace itself")
+	ErrReplacementNotFound      = errors.New("replacement item not found")
+	ErrReplacementDiscontinued  = errors.New("replacement item is discontinued")
 )
 
 // Repository interface
 
+// ItemSearch selects a page of items. A category takes precedence over the
+// query; without either, only active items in stock match.
+type ItemSearch struct {
+	Query         string
+	Category      *ItemCategory
+	AvailableOnly bool   // Only items with stock on hand
+	AfterSKU      string // Resume after this SKU (keyset paging)
+	Offset        int    // Skip this many items (legacy offset paging)
+	Limit         int    // Zero returns every matching item
+}
+
 // InventoryRepository defines the contract for inventory persistence
 type InventoryRepository interface {
 	// Save persists an inventory item
@@ -676,6 +1022,14 @@ type InventoryRepository interface {
 	// FindBySKU retrieves an item by its SKU
 	FindBySKU(sku string) (*InventoryItem, error)
 
+	// FindBySKUForRepair retrieves an item by its SKU even if its stock levels
+	// break the invariants, so RepairStock can fix them
+	FindBySKUForRepair(sku string) (*InventoryItem, error)
+
+	// FindAllForRepair retrieves every item, including those whose stock levels
+	// break the invariants, for the stock consistency check
+	FindAllForRepair() ([]*InventoryItem, error)
+
 	// FindByCategory retrieves items by category
 	FindByCategory(category ItemCategory) ([]*InventoryItem, error)
 
@@ -690,4 +1044,12 @@ type InventoryRepository interface {
 
 	// Search finds items by name or description
 	Search(query string) ([]*InventoryItem, error)
+
+	// SearchPage returns the items matching the search, sorted by SKU, and the
+	// number of matching items regardless of paging
+	SearchPage(search ItemSearch) ([]*InventoryItem, int, error)
+
+	// AvailabilitySummary returns per-category stock counts, reserved quantity
+	// and valuation, as SummarizeAvailability computes them
+	AvailabilitySummary() ([]CategoryAvailability, error)
 }
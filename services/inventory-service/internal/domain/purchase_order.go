@@ -0,0 +1,301 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// PurchaseOrder is an inbound order placed with a supplier to replenish stock.
+// A purchase order is created open, marked in transit once shipped, and received
+// (possibly in several partial deliveries) into inventory.
+type PurchaseOrder struct {
+	id              string
+	supplier        string
+	status          PurchaseOrderStatus
+	lines           []PurchaseOrderLine
+	receipts        []PurchaseOrderReceipt
+	expectedArrival *time.Time // Supplier's estimated delivery date, if known
+	trackingRef     string     // Carrier tracking reference, set when shipped
+	createdBy       string
+	createdAt       time.Time
+	updatedAt       time.Time
+	version         int
+}
+
+// PurchaseOrderStatus represents the lifecycle state of a purchase order
+type PurchaseOrderStatus int
+
+const (
+	PurchaseOrderStatusOpen PurchaseOrderStatus = iota
+	PurchaseOrderStatusInTransit
+	PurchaseOrderStatusPartiallyReceived
+	PurchaseOrderStatusReceived
+	PurchaseOrderStatusCancelled
+)
+
+// String provides human-readable purchase order status names
+func (ps PurchaseOrderStatus) String() string {
+	switch ps {
+	case PurchaseOrderStatusOpen:
+		return "open"
+	case PurchaseOrderStatusInTransit:
+		return "in_transit"
+	case PurchaseOrderStatusPartiallyReceived:
+		return "partially_received"
+	case PurchaseOrderStatusReceived:
+		return "received"
+	case PurchaseOrderStatusCancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// PurchaseOrderLine is the quantity of one item ordered from the supplier
+type PurchaseOrderLine struct {
+	SKU              string
+	OrderedQuantity  int
+	ReceivedQuantity int
+	UnitCost         Money // Agreed cost per unit; its currency is empty if not known
+}
+
+// Outstanding returns the quantity still expected for the line
+func (l PurchaseOrderLine) Outstanding() int {
+	return l.OrderedQuantity - l.ReceivedQuantity
+}
+
+// PurchaseOrderReceipt records a delivery of goods against a purchase order line
+type PurchaseOrderReceipt struct {
+	SKU        string
+	Quantity   int
+	ReceivedBy string
+	ReceivedAt time.Time
+}
+
+// NewPurchaseOrder creates an open purchase order
+func NewPurchaseOrder(supplier string, lines []PurchaseOrderLine, expectedArrival *time.Time, createdBy string) (*PurchaseOrder, error) {
+	if supplier == "" {
+		return nil, ErrInvalidSupplier
+	}
+	if len(lines) == 0 {
+		return nil, ErrPurchaseOrderWithoutLines
+	}
+
+	seen := make(map[string]bool, len(lines))
+	normalized := make([]PurchaseOrderLine, 0, len(lines))
+	for _, line := range lines {
+		if line.SKU == "" {
+			return nil, ErrInvalidSKU
+		}
+		if line.OrderedQuantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+		if line.UnitCost.Currency != "" && (line.UnitCost.IsNegative() || !money.ValidCurrency(line.UnitCost.Currency)) {
+			return nil, ErrInvalidCostPrice
+		}
+		if seen[line.SKU] {
+			return nil, ErrDuplicatePurchaseOrderLine
+		}
+		seen[line.SKU] = true
+		normalized = append(normalized, PurchaseOrderLine{SKU: line.SKU, OrderedQuantity: line.OrderedQuantity, UnitCost: line.UnitCost})
+	}
+
+	now := time.Now()
+	return &PurchaseOrder{
+		id:              "po_" + uuid.New().String(),
+		supplier:        supplier,
+		status:          PurchaseOrderStatusOpen,
+		lines:           normalized,
+		expectedArrival: expectedArrival,
+		createdBy:       createdBy,
+		createdAt:       now,
+		updatedAt:       now,
+		version:         1,
+	}, nil
+}
+
+// ReconstructPurchaseOrder recreates a purchase order from persisted data
+func ReconstructPurchaseOrder(
+	id, supplier string,
+	status PurchaseOrderStatus,
+	lines []PurchaseOrderLine,
+	receipts []PurchaseOrderReceipt,
+	expectedArrival *time.Time,
+	trackingRef, createdBy string,
+	createdAt, updatedAt time.Time,
+	version int,
+) (*PurchaseOrder, error) {
+	if id == "" {
+		return nil, ErrPurchaseOrderNotFound
+	}
+	if len(lines) == 0 {
+		return nil, ErrPurchaseOrderWithoutLines
+	}
+
+	return &PurchaseOrder{
+		id:              id,
+		supplier:        supplier,
+		status:          status,
+		lines:           lines,
+		receipts:        receipts,
+		expectedArrival: expectedArrival,
+		trackingRef:     trackingRef,
+		createdBy:       createdBy,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+		version:         version,
+	}, nil
+}
+
+// MarkInTransit records that the supplier has shipped the order
+func (po *PurchaseOrder) MarkInTransit(expectedArrival *time.Time, trackingRef string) error {
+	if po.status != PurchaseOrderStatusOpen {
+		return ErrInvalidPurchaseOrderTransition
+	}
+
+	po.status = PurchaseOrderStatusInTransit
+	if expectedArrival != nil {
+		po.expectedArrival = expectedArrival
+	}
+	po.trackingRef = trackingRef
+	po.touch()
+	return nil
+}
+
+// Receive records a (possibly partial) delivery of an item. Receiving more than the
+// outstanding quantity of the line is rejected.
+func (po *PurchaseOrder) Receive(sku string, quantity int, receivedBy string) error {
+	if !po.IsReceivable() {
+		return ErrInvalidPurchaseOrderTransition
+	}
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+
+	index := po.lineIndex(sku)
+	if index < 0 {
+		return ErrPurchaseOrderLineNotFound
+	}
+	if quantity > po.lines[index].Outstanding() {
+		return ErrOverReceipt
+	}
+
+	now := time.Now()
+	po.lines[index].ReceivedQuantity += quantity
+	po.receipts = append(po.receipts, PurchaseOrderReceipt{
+		SKU:        sku,
+		Quantity:   quantity,
+		ReceivedBy: receivedBy,
+		ReceivedAt: now,
+	})
+
+	po.status = PurchaseOrderStatusReceived
+	for _, line := range po.lines {
+		if line.Outstanding() > 0 {
+			po.status = PurchaseOrderStatusPartiallyReceived
+			break
+		}
+	}
+	po.touch()
+	return nil
+}
+
+// Cancel closes a purchase order that will not be (fully) delivered.
+// Quantities already received stay in stock.
+func (po *PurchaseOrder) Cancel() error {
+	if !po.IsReceivable() {
+		return ErrInvalidPurchaseOrderTransition
+	}
+
+	po.status = PurchaseOrderStatusCancelled
+	po.touch()
+	return nil
+}
+
+// IsReceivable reports whether goods can still be received against the order
+func (po *PurchaseOrder) IsReceivable() bool {
+	switch po.status {
+	case PurchaseOrderStatusOpen, PurchaseOrderStatusInTransit, PurchaseOrderStatusPartiallyReceived:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsInbound reports whether the goods have shipped and are on their way
+func (po *PurchaseOrder) IsInbound() bool {
+	return po.status == PurchaseOrderStatusInTransit || po.status == PurchaseOrderStatusPartiallyReceived
+}
+
+// OutstandingQuantity returns the quantity of an item still expected on the order
+func (po *PurchaseOrder) OutstandingQuantity(sku string) int {
+	index := po.lineIndex(sku)
+	if index < 0 || !po.IsReceivable() {
+		return 0
+	}
+	return po.lines[index].Outstanding()
+}
+
+func (po *PurchaseOrder) lineIndex(sku string) int {
+	for i, line := range po.lines {
+		if line.SKU == sku {
+			return i
+		}
+	}
+	return -1
+}
+
+func (po *PurchaseOrder) touch() {
+	po.updatedAt = time.Now()
+	po.version++
+}
+
+// Getter methods
+
+func (po *PurchaseOrder) ID() string                       { return po.id }
+func (po *PurchaseOrder) Supplier() string                 { return po.supplier }
+func (po *PurchaseOrder) Status() PurchaseOrderStatus      { return po.status }
+func (po *PurchaseOrder) Lines() []PurchaseOrderLine       { return po.lines }
+func (po *PurchaseOrder) Receipts() []PurchaseOrderReceipt { return po.receipts }
+func (po *PurchaseOrder) ExpectedArrival() *time.Time      { return po.expectedArrival }
+func (po *PurchaseOrder) TrackingRef() string              { return po.trackingRef }
+func (po *PurchaseOrder) CreatedBy() string                { return po.createdBy }
+func (po *PurchaseOrder) CreatedAt() time.Time             { return po.createdAt }
+func (po *PurchaseOrder) UpdatedAt() time.Time             { return po.updatedAt }
+func (po *PurchaseOrder) Version() int                     { return po.version }
+
+// Purchase order errors
+
+var (
+	ErrInvalidSupplier                = errors.New("supplier cannot be empty")
+	ErrPurchaseOrderWithoutLines      = errors.New("purchase order must have at least one line")
+	ErrDuplicatePurchaseOrderLine     = errors.New("purchase order lists the same item more than once")
+	ErrPurchaseOrderNotFound          = errors.New("purchase order not found")
+	ErrPurchaseOrderLineNotFound      = errors.New("item is not on the purchase order")
+	ErrInvalidPurchaseOrderTransition = errors.New("purchase order cannot change to the requested status")
+	ErrOverReceipt                    = errors.New("received quantity exceeds the outstanding quantity")
+	ErrPurchaseOrderConflict          = errors.New("purchase order was modified concurrently")
+)
+
+// PurchaseOrderRepository defines the contract for purchase order persistence
+type PurchaseOrderRepository interface {
+	// Create stores a new purchase order
+	Create(po *PurchaseOrder) error
+
+	// Update persists changes to a purchase order, failing with ErrPurchaseOrderConflict
+	// if the stored version no longer matches expectedVersion (the version it was loaded at)
+	Update(po *PurchaseOrder, expectedVersion int) error
+
+	// FindByID retrieves a purchase order, returning nil if it does not exist
+	FindByID(id string) (*PurchaseOrder, error)
+
+	// FindAll retrieves purchase orders, optionally filtered by status, newest first
+	FindAll(status *PurchaseOrderStatus) ([]*PurchaseOrder, error)
+
+	// FindReceivableBySKU retrieves purchase orders with goods still expected for an item
+	FindReceivableBySKU(sku string) ([]*PurchaseOrder, error)
+}
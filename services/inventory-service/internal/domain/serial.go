@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// SerialNumber is a single serialized unit of an inventory item (e.g. an individual engine).
+// Serial-tracked items keep a pool of serial numbers; serials are allocated to an order
+// when its reservation is confirmed and keep a history of every state change.
+type SerialNumber struct {
+	serial    string
+	sku       string
+	status    SerialStatus
+	orderID   string // Order the serial is allocated to, if any
+	history   []SerialEvent
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// SerialStatus represents the lifecycle state of a serialized unit
+type SerialStatus int
+
+const (
+	SerialStatusAvailable SerialStatus = iota
+	SerialStatusAllocated
+	SerialStatusReturned
+)
+
+// String provides human-readable serial status names
+func (ss SerialStatus) String() string {
+	switch ss {
+	case SerialStatusAvailable:
+		return "available"
+	case SerialStatusAllocated:
+		return "allocated"
+	case SerialStatusReturned:
+		return "returned"
+	default:
+		return "unknown"
+	}
+}
+
+// SerialEvent is an entry in a serial number's history
+type SerialEvent struct {
+	Status     SerialStatus
+	OrderID    string
+	Note       string
+	OccurredAt time.Time
+}
+
+// NewSerialNumber registers a new serialized unit in an item's pool
+func NewSerialNumber(serial, sku string) (*SerialNumber, error) {
+	if serial == "" {
+		return nil, ErrInvalidSerialNumber
+	}
+	if sku == "" {
+		return nil, ErrInvalidSKU
+	}
+
+	now := time.Now()
+	return &SerialNumber{
+		serial:    serial,
+		sku:       sku,
+		status:    SerialStatusAvailable,
+		history:   []SerialEvent{{Status: SerialStatusAvailable, Note: "registered", OccurredAt: now}},
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// ReconstructSerialNumber recreates a serial number from persisted data
+func ReconstructSerialNumber(
+	serial, sku string,
+	status SerialStatus,
+	orderID string,
+	history []SerialEvent,
+	createdAt, updatedAt time.Time,
+) (*SerialNumber, error) {
+	if serial == "" {
+		return nil, ErrInvalidSerialNumber
+	}
+	if sku == "" {
+		return nil, ErrInvalidSKU
+	}
+
+	return &SerialNumber{
+		serial:    serial,
+		sku:       sku,
+		status:    status,
+		orderID:   orderID,
+		history:   history,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}, nil
+}
+
+// Allocate assigns the serial to an order
+func (s *SerialNumber) Allocate(orderID string) error {
+	if orderID == "" {
+		return ErrInvalidOrderID
+	}
+	if s.status == SerialStatusAllocated {
+		return ErrSerialNotAvailable
+	}
+
+	s.status = SerialStatusAllocated
+	s.orderID = orderID
+	s.record("allocated at reservation confirmation")
+	return nil
+}
+
+// Return puts an allocated serial back into the pool (e.g. after a cancelled shipment)
+func (s *SerialNumber) Return(note string) error {
+	if s.status != SerialStatusAllocated {
+		return ErrSerialNotAllocated
+	}
+
+	orderID := s.orderID
+	s.status = SerialStatusReturned
+	s.orderID = ""
+	s.history = append(s.history, SerialEvent{
+		Status:     SerialStatusReturned,
+		OrderID:    orderID,
+		Note:       note,
+		OccurredAt: time.Now(),
+	})
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// IsAllocatable reports whether the serial can be allocated to an order
+func (s *SerialNumber) IsAllocatable() bool {
+	return s.status == SerialStatusAvailable || s.status == SerialStatusReturned
+}
+
+func (s *SerialNumber) record(note string) {
+	now := time.Now()
+	s.history = append(s.history, SerialEvent{
+		Status:     s.status,
+		OrderID:    s.orderID,
+		Note:       note,
+		OccurredAt: now,
+	})
+	s.updatedAt = now
+}
+
+// Getter methods
+
+func (s *SerialNumber) Serial() string         { return s.serial }
+func (s *SerialNumber) SKU() string            { return s.sku }
+func (s *SerialNumber) Status() SerialStatus   { return s.status }
+func (s *SerialNumber) OrderID() string        { return s.orderID }
+func (s *SerialNumber) History() []SerialEvent { return s.history }
+func (s *SerialNumber) CreatedAt() time.Time   { return s.createdAt }
+func (s *SerialNumber) UpdatedAt() time.Time   { return s.updatedAt }
+
+// Serial number errors
+
+var (
+	ErrInvalidSerialNumber  = errors.New("serial number cannot be empty")
+	ErrSerialNotAvailable   = errors.New("serial number is not available")
+	ErrSerialNotAllocated   = errors.New("serial number is not allocated")
+	ErrSerialNotFound       = errors.New("serial number not found")
+	ErrSerialAlreadyExists  = errors.New("serial number already registered")
+	ErrInsufficientSerials  = errors.New("not enough serial numbers in the pool")
+	ErrItemNotSerialTracked = errors.New("item is not serial tracked")
+)
+
+// SerialRepository defines the contract for serial number persistence
+type SerialRepository interface {
+	// Create registers new serial numbers, failing if any already exists
+	Create(serials []*SerialNumber) error
+
+	// Save persists changes to a serial number
+	Save(serial *SerialNumber) error
+
+	// SaveAllocation persists an allocation, failing with ErrSerialNotAvailable if another
+	// order allocated the serial first
+	SaveAllocation(serial *SerialNumber) error
+
+	// FindBySerial retrieves a serial number, returning nil if it does not exist
+	FindBySerial(serial string) (*SerialNumber, error)
+
+	// FindAllocatable retrieves up to limit serials of an item that can be allocated, oldest first
+	FindAllocatable(sku string, limit int) ([]*SerialNumber, error)
+
+	// FindByOrder retrieves the serials allocated to an order
+	FindByOrder(orderID string) ([]*SerialNumber, error)
+
+	// CountAllocatable returns the number of serials of an item that can be allocated
+	CountAllocatable(sku string) (int, error)
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrStockInvariant is matched (via errors.Is) by every StockInvariantError
+var ErrStockInvariant = errors.New("stock invariant violated")
+
+// StockLevels are the three stock counters of an item. Available plus reserved
+// stock never exceeds the total, and none of them is negative.
+type StockLevels struct {
+	Available float64 `json:"available"`
+	Reserved  float64 `json:"reserved"`
+	Total     float64 `json:"total"`
+}
+
+// Valid reports whether the levels satisfy the stock invariants
+func (l StockLevels) Valid() bool {
+	return l.violation() == ""
+}
+
+// violation returns why the levels break the stock invariants, or "" if they hold
+func (l StockLevels) violation() string {
+	switch {
+	case l.Available < 0:
+		return "stock level cannot be negative"
+	case l.Reserved < 0:
+		return "reserved stock cannot be negative"
+	case l.Total < 0:
+		return "total stock cannot be negative"
+	case l.Available+l.Reserved > l.Total+quantityTolerance:
+		return "available + reserved stock cannot exceed total stock"
+	}
+	return ""
+}
+
+// StockInvariantError describes stock levels an operation would have left (or a
+// load found) inconsistent. The operation is rejected and the item left unchanged.
+type StockInvariantError struct {
+	ItemID    string
+	SKU       string
+	Operation string
+	Levels    StockLevels // The offending levels
+	Reason    string
+}
+
+// Error implements the error interface
+func (e *StockInvariantError) Error() string {
+	return fmt.Sprintf("%s of item %s would break stock invariant: %s (available %g, reserved %g, total %g)",
+		e.Operation, e.SKU, e.Reason, e.Levels.Available, e.Levels.Reserved, e.Levels.Total)
+}
+
+// Is makes errors.Is(err, ErrStockInvariant) match any StockInvariantError
+func (e *StockInvariantError) Is(target error) bool {
+	return target == ErrStockInvariant
+}
+
+// Levels returns the current stock levels of the item
+func (item *InventoryItem) Levels() StockLevels {
+	return StockLevels{Available: item.stockLevel, Reserved: item.reservedStock, Total: item.totalStock}
+}
+
+// guardLevels checks the levels an operation is about to set, before they are applied
+func (item *InventoryItem) guardLevels(operation string, levels StockLevels) error {
+	if reason := levels.violation(); reason != "" {
+		return &StockInvariantError{
+			ItemID:    item.id,
+			SKU:       item.sku,
+			Operation: operation,
+			Levels:    levels,
+			Reason:    reason,
+		}
+	}
+	return nil
+}
+
+// StockRepair is the outcome of recomputing an item's stock levels from its
+// reservation ledger
+type StockRepair struct {
+	Before       StockLevels `json:"before"`
+	After        StockLevels `json:"after"`
+	Reservations int         `json:"reservations"` // Active reservations in the ledger
+	Oversold     float64     `json:"oversold"`     // Reserved quantity that exceeded the total stock
+	Changed      bool        `json:"changed"`
+}
+
+// RepairStock recomputes the stock levels from the active reservations. The
+// reserved stock becomes the sum of the reservations and the available stock
+// whatever is left of the total. If the reservations exceed the total stock, the
+// total is raised to cover them and the excess is reported as oversold, so the
+// orders holding them can still be confirmed or released.
+func (item *InventoryItem) RepairStock() StockRepair {
+	repair := item.expectedStock()
+	if !repair.Changed {
+		return repair
+	}
+
+	item.stockLevel = repair.After.Available
+	item.reservedStock = repair.After.Reserved
+	item.totalStock = repair.After.Total
+	item.updatedAt = time.Now()
+	item.version++
+	item.updateStatus()
+
+	return repair
+}
+
+// expectedStock computes the repair of RepairStock without applying it
+func (item *InventoryItem) expectedStock() StockRepair {
+	repair := StockRepair{Before: item.Levels()}
+
+	var reserved float64
+	for _, reservation := range item.reservations {
+		if reservation.status == ReservationStatusActive {
+			reserved += reservation.quantity
+			repair.Reservations++
+		}
+	}
+	reserved = item.unit.Round(reserved)
+
+	total := item.totalStock
+	if total < 0 {
+		total = 0
+	}
+	if reserved > total {
+		repair.Oversold = item.unit.Round(reserved - total)
+		total = reserved
+	}
+
+	repair.After = StockLevels{
+		Available: item.unit.Round(total - reserved),
+		Reserved:  reserved,
+		Total:     item.unit.Round(total),
+	}
+	repair.Changed = repair.After != repair.Before
+	return repair
+}
+
+// StockDrift is how far an item's stock levels are from the levels its
+// reservation ledger implies. The stock movement ledger only follows stock once
+// it is confirmed for an order, so the total stock is taken as correct and only
+// the split into available and reserved stock (and a total too small for the
+// reservations) is checked.
+type StockDrift struct {
+	SKU      string      `json:"sku"`
+	Levels   StockLevels `json:"levels"`   // Stored levels
+	Expected StockLevels `json:"expected"` // Levels RepairStock would set
+	Oversold float64     `json:"oversold"`
+	Drift    float64     `json:"drift"` // Largest difference between a stored and an expected level
+}
+
+// Drifted reports whether the stored levels differ from the expected ones
+func (d StockDrift) Drifted() bool {
+	return d.Drift > quantityTolerance
+}
+
+// CheckStock compares the stock levels with the reservation ledger without
+// changing the item
+func (item *InventoryItem) CheckStock() StockDrift {
+	repair := item.expectedStock()
+	drift := StockDrift{
+		SKU:      item.sku,
+		Levels:   repair.Before,
+		Expected: repair.After,
+		Oversold: repair.Oversold,
+	}
+	drift.Drift = math.Max(math.Abs(repair.After.Available-repair.Before.Available),
+		math.Max(math.Abs(repair.After.Reserved-repair.Before.Reserved),
+			math.Abs(repair.After.Total-repair.Before.Total)))
+	return drift
+}
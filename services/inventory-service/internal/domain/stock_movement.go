@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MovementType is the kind of a stock movement
+type MovementType string
+
+const (
+	MovementConfirmed MovementType = "confirmed" // Reserved stock confirmed for an order at payment
+	MovementConsumed  MovementType = "consumed"  // Confirmed stock built into the order's rocket
+)
+
+// StockMovement is an entry in the stock movement ledger. The ledger records
+// what happened to an order's stock after its reservation was confirmed: the
+// stock levels of the item no longer include it, but the order keeps a record
+// of the confirmed stock until assembly consumes it.
+type StockMovement struct {
+	ID            string
+	Type          MovementType
+	SKU           string
+	OrderID       string
+	Quantity      float64 // In the item's unit
+	Unit          UnitOfMeasure
+	SerialNumbers []string
+	Reference     string       // Reservation or assembly the movement belongs to
+	SourceEventID string       // Event or reservation that caused the movement
+	Cost          *CostOfGoods // Cost of the goods sold, for confirmed stock
+	OccurredAt    time.Time
+	RecordedAt    time.Time
+}
+
+// NewStockMovement creates a movement of an order's stock. The ID is derived
+// from the type, source and SKU, so a redelivered event records the movement once.
+func NewStockMovement(movementType MovementType, sku, orderID, sourceEventID string, quantity float64, unit UnitOfMeasure) (*StockMovement, error) {
+	if sku == "" {
+		return nil, ErrInvalidSKU
+	}
+	if orderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+	if sourceEventID == "" {
+		return nil, ErrInvalidMovementSource
+	}
+	if err := unit.ValidateQuantity(quantity); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &StockMovement{
+		ID:            uuid.NewSHA1(uuid.NameSpaceOID, []byte(string(movementType)+":"+sourceEventID+":"+sku)).String(),
+		Type:          movementType,
+		SKU:           sku,
+		OrderID:       orderID,
+		Quantity:      unit.Round(quantity),
+		Unit:          unit,
+		SourceEventID: sourceEventID,
+		OccurredAt:    now,
+		RecordedAt:    now,
+	}, nil
+}
+
+// OrderStock is the stock of an item confirmed for an order and how much of it
+// assembly consumed
+type OrderStock struct {
+	SKU       string        `json:"sku"`
+	Unit      UnitOfMeasure `json:"unit"`
+	Confirmed float64       `json:"confirmed"`
+	Consumed  float64       `json:"consumed"`
+}
+
+// Outstanding returns the confirmed stock not consumed yet
+func (s OrderStock) Outstanding() float64 {
+	if s.Consumed >= s.Confirmed {
+		return 0
+	}
+	return s.Unit.Round(s.Confirmed - s.Consumed)
+}
+
+// Unconfirmed returns the consumed stock beyond what was confirmed for the order,
+// e.g. for orders confirmed before the ledger existed
+func (s OrderStock) Unconfirmed() float64 {
+	if s.Consumed <= s.Confirmed {
+		return 0
+	}
+	return s.Unit.Round(s.Consumed - s.Confirmed)
+}
+
+// SummarizeOrderStock totals an order's movements by SKU, in the order the SKUs
+// first appear
+func SummarizeOrderStock(movements []*StockMovement) []OrderStock {
+	var stock []OrderStock
+	index := make(map[string]int)
+	for _, movement := range movements {
+		i, ok := index[movement.SKU]
+		if !ok {
+			i = len(stock)
+			index[movement.SKU] = i
+			stock = append(stock, OrderStock{SKU: movement.SKU, Unit: movement.Unit})
+		}
+
+		switch movement.Type {
+		case MovementConfirmed:
+			stock[i].Confirmed = movement.Unit.Round(stock[i].Confirmed + movement.Quantity)
+		case MovementConsumed:
+			stock[i].Consumed = movement.Unit.Round(stock[i].Consumed + movement.Quantity)
+		}
+	}
+	return stock
+}
+
+// Stock movement errors
+
+var (
+	ErrInvalidMovementSource      = errors.New("stock movement source cannot be empty")
+	ErrStockMovementAlreadyExists = errors.New("stock movement already recorded")
+)
+
+// StockMovementRepository defines the contract for stock movement persistence
+type StockMovementRepository interface {
+	// Record appends a movement to the ledger, failing with ErrStockMovementAlreadyExists
+	// if a movement with the same ID was recorded before
+	Record(movement *StockMovement) error
+
+	// FindByOrder retrieves the movements of an order, oldest first
+	FindByOrder(orderID string) ([]*StockMovement, error)
+
+	// FindByType retrieves the movements of a type that occurred in [from, to), oldest first
+	FindByType(movementType MovementType, from, to time.Time) ([]*StockMovement, error)
+}
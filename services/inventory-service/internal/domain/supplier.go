@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// ItemSupplier is a supplier an item can be reordered from, with the supplier's
+// own SKU for it and the terms it is sold on. An item may have several; restocks
+// go to the preferred one, or else the one delivering soonest.
+type ItemSupplier struct {
+	sku      string
+	supplier string
+	settings ItemSupplierSettings
+
+	createdAt time.Time
+	updatedAt time.Time
+	version   int
+}
+
+// ItemSupplierSettings are the terms an item is bought from a supplier on
+type ItemSupplierSettings struct {
+	SupplierSKU      string        // The supplier's code for the item; empty if it uses ours
+	LeadTime         time.Duration // Time from ordering to delivery, in whole days
+	MinOrderQuantity float64       // Smallest quantity the supplier accepts; zero for no minimum
+	UnitCost         Money         // Cost per unit; its currency is empty if not known
+	Preferred        bool          // Restocks go to the preferred supplier whatever its lead time
+}
+
+// NewItemSupplier creates a new supplier record of an item with validation
+func NewItemSupplier(sku, supplier string, settings ItemSupplierSettings) (*ItemSupplier, error) {
+	supplier = strings.TrimSpace(supplier)
+	if sku == "" {
+		return nil, ErrInvalidSKU
+	}
+	if supplier == "" {
+		return nil, ErrInvalidSupplier
+	}
+	if err := validateItemSupplierSettings(settings); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &ItemSupplier{
+		sku:       sku,
+		supplier:  supplier,
+		settings:  settings,
+		createdAt: now,
+		updatedAt: now,
+		version:   1,
+	}, nil
+}
+
+// ReconstructItemSupplier recreates an item supplier from persisted data
+func ReconstructItemSupplier(
+	sku, supplier string,
+	settings ItemSupplierSettings,
+	createdAt, updatedAt time.Time,
+	version int,
+) (*ItemSupplier, error) {
+	if sku == "" || supplier == "" {
+		return nil, ErrItemSupplierNotFound
+	}
+
+	return &ItemSupplier{
+		sku:       sku,
+		supplier:  supplier,
+		settings:  settings,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+		version:   version,
+	}, nil
+}
+
+// Update replaces the supplier's terms, keeping the item, supplier and creation time
+func (s *ItemSupplier) Update(settings ItemSupplierSettings) error {
+	if err := validateItemSupplierSettings(settings); err != nil {
+		return err
+	}
+
+	s.settings = settings
+	s.updatedAt = time.Now()
+	s.version++
+
+	return nil
+}
+
+// OrderQuantity returns the quantity to order from the supplier to get at least
+// the wanted quantity, raised to its minimum order quantity
+func (s *ItemSupplier) OrderQuantity(wanted float64) float64 {
+	return math.Max(wanted, s.settings.MinOrderQuantity)
+}
+
+// ExpectedDelivery returns when an order placed at the time is expected to arrive
+func (s *ItemSupplier) ExpectedDelivery(orderedAt time.Time) time.Time {
+	return orderedAt.Add(s.settings.LeadTime)
+}
+
+// ChooseSupplier returns the supplier to restock an item from: the preferred
+// one, or else the one with the shortest lead time, the cheaper on a tie. It
+// returns nil when the item has no suppliers.
+func ChooseSupplier(suppliers []*ItemSupplier) *ItemSupplier {
+	var chosen *ItemSupplier
+	for _, candidate := range suppliers {
+		if chosen == nil || candidate.betterThan(chosen) {
+			chosen = candidate
+		}
+	}
+	return chosen
+}
+
+// betterThan reports whether restocks should rather go to s than to other
+func (s *ItemSupplier) betterThan(other *ItemSupplier) bool {
+	if s.settings.Preferred != other.settings.Preferred {
+		return s.settings.Preferred
+	}
+	if s.settings.LeadTime != other.settings.LeadTime {
+		return s.settings.LeadTime < other.settings.LeadTime
+	}
+	cost, otherCost := s.settings.UnitCost, other.settings.UnitCost
+	if cost.Currency != "" && cost.Currency == otherCost.Currency && cost.Minor != otherCost.Minor {
+		return cost.Minor < otherCost.Minor
+	}
+	return s.supplier < other.supplier
+}
+
+// validateItemSupplierSettings checks the lead time, minimum order quantity and cost
+func validateItemSupplierSettings(settings ItemSupplierSettings) error {
+	if settings.LeadTime < 0 || settings.LeadTime%(24*time.Hour) != 0 {
+		return ErrInvalidItemSupplier
+	}
+	if settings.MinOrderQuantity < 0 {
+		return ErrInvalidItemSupplier
+	}
+	if settings.UnitCost.Currency != "" && (settings.UnitCost.IsNegative() || !money.ValidCurrency(settings.UnitCost.Currency)) {
+		return ErrInvalidCostPrice
+	}
+	return nil
+}
+
+// Getter methods
+
+func (s *ItemSupplier) SKU() string                    { return s.sku }
+func (s *ItemSupplier) Supplier() string               { return s.supplier }
+func (s *ItemSupplier) Settings() ItemSupplierSettings { return s.settings }
+func (s *ItemSupplier) LeadTime() time.Duration        { return s.settings.LeadTime }
+func (s *ItemSupplier) CreatedAt() time.Time           { return s.createdAt }
+func (s *ItemSupplier) UpdatedAt() time.Time           { return s.updatedAt }
+func (s *ItemSupplier) Version() int                   { return s.version }
+
+// Item supplier errors
+
+var (
+	ErrInvalidItemSupplier  = errors.New("invalid supplier lead time or minimum order quantity")
+	ErrItemSupplierNotFound = errors.New("item supplier not found")
+)
+
+// ItemSupplierRepository defines the contract for item supplier persistence
+type ItemSupplierRepository interface {
+	// Save persists an item supplier, creating or replacing the item's record of the supplier
+	Save(supplier *ItemSupplier) error
+
+	// Find retrieves the item's record of a supplier, returning nil if it has none
+	Find(sku, supplier string) (*ItemSupplier, error)
+
+	// FindBySKU retrieves the suppliers of an item ordered by supplier
+	FindBySKU(sku string) ([]*ItemSupplier, error)
+
+	// Delete removes the item's record of a supplier
+	Delete(sku, supplier string) error
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// UnitOfMeasure is the unit an item is stocked and reserved in
+type UnitOfMeasure string
+
+const (
+	UnitEach       UnitOfMeasure = "ea" // Discrete parts (engines, computers, ...)
+	UnitGram       UnitOfMeasure = "g"
+	UnitKilogram   UnitOfMeasure = "kg"
+	UnitTonne      UnitOfMeasure = "t"
+	UnitMilliliter UnitOfMeasure = "ml"
+	UnitLiter      UnitOfMeasure = "l"
+	UnitCubicMeter UnitOfMeasure = "m3"
+)
+
+// UnitDimension groups units that can be converted into each other
+type UnitDimension int
+
+const (
+	DimensionCount UnitDimension = iota
+	DimensionMass
+	DimensionVolume
+)
+
+// String provides human-readable dimension names
+func (d UnitDimension) String() string {
+	switch d {
+	case DimensionCount:
+		return "count"
+	case DimensionMass:
+		return "mass"
+	case DimensionVolume:
+		return "volume"
+	default:
+		return "unknown"
+	}
+}
+
+// unitDefinition describes how a unit relates to the base unit of its dimension
+type unitDefinition struct {
+	dimension UnitDimension
+	factor    float64 // Base units (ea, kg, l) per unit
+	precision int     // Decimal places a quantity in this unit may have
+}
+
+var unitDefinitions = map[UnitOfMeasure]unitDefinition{
+	UnitEach:       {dimension: DimensionCount, factor: 1, precision: 0},
+	UnitGram:       {dimension: DimensionMass, factor: 0.001, precision: 0},
+	UnitKilogram:   {dimension: DimensionMass, factor: 1, precision: 3},
+	UnitTonne:      {dimension: DimensionMass, factor: 1000, precision: 6},
+	UnitMilliliter: {dimension: DimensionVolume, factor: 0.001, precision: 0},
+	UnitLiter:      {dimension: DimensionVolume, factor: 1, precision: 3},
+	UnitCubicMeter: {dimension: DimensionVolume, factor: 1000, precision: 6},
+}
+
+// quantityTolerance absorbs floating point noise when checking a quantity's precision
+const quantityTolerance = 1e-6
+
+// ParseUnitOfMeasure returns the unit with the given symbol. An empty symbol means each,
+// which is what every item was stocked in before units were introduced.
+func ParseUnitOfMeasure(symbol string) (UnitOfMeasure, error) {
+	symbol = strings.ToLower(strings.TrimSpace(symbol))
+	if symbol == "" {
+		return UnitEach, nil
+	}
+	unit := UnitOfMeasure(symbol)
+	if !unit.IsValid() {
+		return "", fmt.Errorf("%w: %q", ErrUnknownUnit, symbol)
+	}
+	return unit, nil
+}
+
+// IsValid reports whether the unit is known
+func (u UnitOfMeasure) IsValid() bool {
+	_, ok := unitDefinitions[u]
+	return ok
+}
+
+// Dimension returns what the unit measures
+func (u UnitOfMeasure) Dimension() UnitDimension {
+	return unitDefinitions[u].dimension
+}
+
+// Precision returns the number of decimal places a quantity in the unit may have
+func (u UnitOfMeasure) Precision() int {
+	return unitDefinitions[u].precision
+}
+
+// IsCountable reports whether the unit counts discrete pieces
+func (u UnitOfMeasure) IsCountable() bool {
+	return u.IsValid() && u.Dimension() == DimensionCount
+}
+
+// Round rounds a quantity to the precision of the unit
+func (u UnitOfMeasure) Round(quantity float64) float64 {
+	scale := math.Pow10(u.Precision())
+	return math.Round(quantity*scale) / scale
+}
+
+// ValidateQuantity checks that a quantity is positive and representable in the unit
+func (u UnitOfMeasure) ValidateQuantity(quantity float64) error {
+	if quantity <= 0 || math.IsNaN(quantity) || math.IsInf(quantity, 0) {
+		return ErrInvalidQuantity
+	}
+	scaled := quantity * math.Pow10(u.Precision())
+	if math.Abs(scaled-math.Round(scaled)) > quantityTolerance*math.Max(1, scaled) {
+		return fmt.Errorf("%w: %v %s allows %d decimal places", ErrQuantityPrecision, quantity, u, u.Precision())
+	}
+	return nil
+}
+
+// ConvertQuantity converts a quantity between units of the same dimension, rounded to the
+// precision of the target unit. Quantities the target unit cannot represent are rejected
+// rather than silently rounded, e.g. 1.5 kg into grams is fine but 0.5 g into kilograms is not.
+func ConvertQuantity(quantity float64, from, to UnitOfMeasure) (float64, error) {
+	if !from.IsValid() {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownUnit, from)
+	}
+	if !to.IsValid() {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownUnit, to)
+	}
+	if from.Dimension() != to.Dimension() {
+		return 0, fmt.Errorf("%w: cannot convert %s (%s) to %s (%s)",
+			ErrIncompatibleUnits, from, from.Dimension(), to, to.Dimension())
+	}
+
+	converted := quantity
+	if from != to {
+		converted = quantity * unitDefinitions[from].factor / unitDefinitions[to].factor
+	}
+	if err := to.ValidateQuantity(converted); err != nil {
+		return 0, err
+	}
+	return to.Round(converted), nil
+}
+
+// Unit of measure errors
+
+var (
+	ErrUnknownUnit           = errors.New("unknown unit of measure")
+	ErrIncompatibleUnits     = errors.New("incompatible units of measure")
+	ErrQuantityPrecision     = errors.New("quantity has more decimal places than the unit allows")
+	ErrUnitChangeWithStock   = errors.New("unit of measure can only be changed while the item holds no stock")
+	ErrSerialUncountableUnit = errors.New("only items stocked in pieces can be serial tracked")
+)
@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// ValuationMethod is how the cost of stock leaving inventory is determined
+type ValuationMethod string
+
+const (
+	ValuationFIFO    ValuationMethod = "fifo"    // The oldest receipts are used up first
+	ValuationAverage ValuationMethod = "average" // Moving average cost of the stock on hand
+)
+
+// IsValid checks if the valuation method is known
+func (m ValuationMethod) IsValid() bool {
+	return m == ValuationFIFO || m == ValuationAverage
+}
+
+// CostLayer is the part of a stock receipt still on hand, at the unit cost it
+// was received at
+type CostLayer struct {
+	Quantity   float64 // Remaining, in the item's unit
+	UnitCost   Money   // Cost of one unit
+	ReceivedAt time.Time
+	Source     string // Purchase order or adjustment the stock came from
+}
+
+// CostOfGoods is the cost of stock issued from inventory under both valuation
+// methods. Stock beyond the cost layers is valued at the item's cost price, or
+// reported as uncosted if it has none.
+type CostOfGoods struct {
+	Quantity float64 // Issued, in the item's unit
+	FIFO     Money
+	Average  Money
+	Uncosted float64 // Quantity no cost is known for
+}
+
+// Cost returns the cost under the given method
+func (c CostOfGoods) Cost(method ValuationMethod) Money {
+	if method == ValuationAverage {
+		return c.Average
+	}
+	return c.FIFO
+}
+
+// StockValuation is the value of an item's stock on hand under both valuation methods
+type StockValuation struct {
+	Quantity       float64 // On hand, reserved included
+	CostedQuantity float64 // Covered by cost layers or the cost price
+	FIFO           Money
+	Average        Money
+}
+
+// Value returns the value under the given method
+func (v StockValuation) Value(method ValuationMethod) Money {
+	if method == ValuationAverage {
+		return v.Average
+	}
+	return v.FIFO
+}
+
+// Valuation errors
+
+var (
+	ErrInvalidCostPrice     = errors.New("cost price cannot be negative")
+	ErrCostCurrencyMismatch = errors.New("cost currency differs from the item's cost currency")
+	ErrUnknownValuation     = errors.New("unknown valuation method")
+)
+
+// SetCostPrice sets the cost of one unit of the item, used for stock added
+// without a cost of its own and stock beyond the cost layers. Its currency must
+// match the cost layers on hand.
+func (item *InventoryItem) SetCostPrice(costPrice Money) error {
+	if costPrice.IsNegative() || !money.ValidCurrency(costPrice.Currency) {
+		return ErrInvalidCostPrice
+	}
+	if currency := item.costCurrency(); currency != "" && currency != costPrice.Currency {
+		return ErrCostCurrencyMismatch
+	}
+	item.costPrice = costPrice
+	item.updatedAt = time.Now()
+	item.version++
+	return nil
+}
+
+// ReceiveStock adds stock received at the given unit cost, as a new cost layer.
+// The unit cost becomes the item's cost price.
+func (item *InventoryItem) ReceiveStock(quantity float64, unitCost Money, source string) error {
+	if unitCost.IsNegative() || !money.ValidCurrency(unitCost.Currency) {
+		return ErrInvalidCostPrice
+	}
+	if currency := item.costCurrency(); currency != "" && currency != unitCost.Currency {
+		return ErrCostCurrencyMismatch
+	}
+	if err := item.addStock(quantity); err != nil {
+		return err
+	}
+	item.costPrice = unitCost
+	item.addCostLayer(item.unit.Round(quantity), unitCost, source)
+	return nil
+}
+
+// RestoreCosting restores the cost price and cost layers during reconstruction
+func (item *InventoryItem) RestoreCosting(costPrice Money, layers []CostLayer, averageCost Money) {
+	item.costPrice = costPrice
+	item.costLayers = layers
+	item.averageCost = averageCost
+}
+
+// ValueStock values the stock on hand. Stock beyond the cost layers is valued
+// at the cost price, if the item has one.
+func (item *InventoryItem) ValueStock() StockValuation {
+	valuation := StockValuation{Quantity: item.totalStock}
+	currency := item.costCurrency()
+	if currency == "" {
+		return valuation
+	}
+
+	var fifoMinor, layered float64
+	for _, layer := range item.costLayers {
+		fifoMinor += layer.Quantity * float64(layer.UnitCost.Minor)
+		layered += layer.Quantity
+	}
+	averageMinor := layered * float64(item.averageCost.Minor)
+
+	if extra := item.totalStock - layered; extra > 0 && item.costPrice.Currency != "" {
+		fifoMinor += extra * float64(item.costPrice.Minor)
+		averageMinor += extra * float64(item.costPrice.Minor)
+		layered += extra
+	}
+
+	valuation.CostedQuantity = item.unit.Round(math.Min(layered, item.totalStock))
+	valuation.FIFO = money.New(int64(math.Round(fifoMinor)), currency)
+	valuation.Average = money.New(int64(math.Round(averageMinor)), currency)
+	return valuation
+}
+
+// addCostLayer records received stock and updates the moving average cost
+func (item *InventoryItem) addCostLayer(quantity float64, unitCost Money, source string) {
+	if quantity <= 0 {
+		return
+	}
+
+	var onHand float64
+	for _, layer := range item.costLayers {
+		onHand += layer.Quantity
+	}
+	averageMinor := (onHand*float64(item.averageCost.Minor) + quantity*float64(unitCost.Minor)) / (onHand + quantity)
+	item.averageCost = money.New(int64(math.Round(averageMinor)), unitCost.Currency)
+
+	item.costLayers = append(item.costLayers, CostLayer{
+		Quantity:   quantity,
+		UnitCost:   unitCost,
+		ReceivedAt: time.Now(),
+		Source:     source,
+	})
+}
+
+// issueCost uses up cost layers for stock leaving inventory, oldest first, and
+// returns its cost
+func (item *InventoryItem) issueCost(quantity float64) CostOfGoods {
+	issued := CostOfGoods{Quantity: quantity}
+	currency := item.costCurrency()
+
+	var fifoMinor, averageMinor float64
+	remaining := quantity
+	for remaining > 0 && len(item.costLayers) > 0 {
+		layer := &item.costLayers[0]
+		taken := math.Min(layer.Quantity, remaining)
+		fifoMinor += taken * float64(layer.UnitCost.Minor)
+		averageMinor += taken * float64(item.averageCost.Minor)
+		remaining = item.unit.Round(remaining - taken)
+
+		layer.Quantity = item.unit.Round(layer.Quantity - taken)
+		if layer.Quantity <= 0 {
+			item.costLayers = item.costLayers[1:]
+		}
+	}
+	if len(item.costLayers) == 0 {
+		item.costLayers = nil
+	}
+
+	if remaining > 0 {
+		if item.costPrice.Currency != "" {
+			fifoMinor += remaining * float64(item.costPrice.Minor)
+			averageMinor += remaining * float64(item.costPrice.Minor)
+		} else {
+			issued.Uncosted = remaining
+		}
+	}
+
+	if currency != "" {
+		issued.FIFO = money.New(int64(math.Round(fifoMinor)), currency)
+		issued.Average = money.New(int64(math.Round(averageMinor)), currency)
+	}
+	return issued
+}
+
+// costCurrency returns the currency of the item's costs, empty if it has none
+func (item *InventoryItem) costCurrency() string {
+	if len(item.costLayers) > 0 {
+		return item.costLayers[0].UnitCost.Currency
+	}
+	return item.costPrice.Currency
+}
+
+// CostPrice returns the cost of one unit; its currency is empty if not set
+func (item *InventoryItem) CostPrice() Money { return item.costPrice }
+
+// CostLayers returns the receipts still on hand, oldest first
+func (item *InventoryItem) CostLayers() []CostLayer { return item.costLayers }
+
+// AverageCost returns the moving average unit cost of the cost layers
+func (item *InventoryItem) AverageCost() Money { return item.averageCost }
@@ -0,0 +1,135 @@
+package fakes
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+var itemSeq atomic.Int64
+
+type itemReservation struct {
+	orderID  string
+	quantity float64
+}
+
+// ItemBuilder builds valid inventory items through the domain operations, so the
+// stock levels of a built item are always consistent. Every item gets a unique SKU
+// unless one is set.
+type ItemBuilder struct {
+	sku, name, description string
+	category               domain.ItemCategory
+	unitPrice              domain.Money
+	unit                   domain.UnitOfMeasure
+	stock                  float64
+	minStock, maxStock     float64
+	reservations           []itemReservation
+	status                 domain.ItemStatus
+	serialTracked          bool
+}
+
+// NewItem starts building an active engine part priced at 1000.00 USD with no stock
+func NewItem() *ItemBuilder {
+	n := itemSeq.Add(1)
+	return &ItemBuilder{
+		sku:       fmt.Sprintf("TST-PRT-%04d", n),
+		name:      fmt.Sprintf("Test Part %d", n),
+		category:  domain.CategoryEngines,
+		unitPrice: money.New(100000, "USD"),
+		unit:      domain.UnitEach,
+		maxStock:  1000,
+		status:    domain.ItemStatusActive,
+	}
+}
+
+// WithSKU sets the SKU
+func (b *ItemBuilder) WithSKU(sku string) *ItemBuilder {
+	b.sku = sku
+	return b
+}
+
+// WithName sets the name and description
+func (b *ItemBuilder) WithName(name, description string) *ItemBuilder {
+	b.name, b.description = name, description
+	return b
+}
+
+// WithCategory sets the category
+func (b *ItemBuilder) WithCategory(category domain.ItemCategory) *ItemBuilder {
+	b.category = category
+	return b
+}
+
+// WithPrice sets the unit price
+func (b *ItemBuilder) WithPrice(price domain.Money) *ItemBuilder {
+	b.unitPrice = price
+	return b
+}
+
+// WithUnit sets the unit of measure
+func (b *ItemBuilder) WithUnit(unit domain.UnitOfMeasure) *ItemBuilder {
+	b.unit = unit
+	return b
+}
+
+// WithStock sets the stock on hand, before reservations
+func (b *ItemBuilder) WithStock(quantity float64) *ItemBuilder {
+	b.stock = quantity
+	return b
+}
+
+// WithLimits sets the minimum and maximum stock levels
+func (b *ItemBuilder) WithLimits(min, max float64) *ItemBuilder {
+	b.minStock, b.maxStock = min, max
+	return b
+}
+
+// WithReservation reserves stock for the order
+func (b *ItemBuilder) WithReservation(orderID string, quantity float64) *ItemBuilder {
+	b.reservations = append(b.reservations, itemReservation{orderID: orderID, quantity: quantity})
+	return b
+}
+
+// WithStatus sets the lifecycle status
+func (b *ItemBuilder) WithStatus(status domain.ItemStatus) *ItemBuilder {
+	b.status = status
+	return b
+}
+
+// SerialTracked tracks units individually by serial number
+func (b *ItemBuilder) SerialTracked() *ItemBuilder {
+	b.serialTracked = true
+	return b
+}
+
+// Build returns the item. It panics if a domain operation rejects the fixture, as a
+// broken fixture is a bug in the test.
+func (b *ItemBuilder) Build() *domain.InventoryItem {
+	item, err := domain.NewInventoryItem(b.sku, b.name, b.description, b.category, b.unitPrice)
+	must(err)
+	must(item.SetUnitOfMeasure(b.unit))
+	must(item.SetInternalState(item.Weight(), item.Dimensions(), item.Specifications(), b.minStock, b.maxStock))
+	if b.serialTracked {
+		must(item.SetSerialTracked(true))
+	}
+	if b.stock > 0 {
+		must(item.AddStock(b.stock, "test fixture"))
+	}
+	for _, reservation := range b.reservations {
+		_, err := item.ReserveStock(reservation.orderID, reservation.quantity, 15, domain.ReservationPriorityStandard)
+		must(err)
+	}
+	if b.status != item.Status() {
+		item, err = restore(item, b.status)
+		must(err)
+	}
+	return item
+}
+
+func must(err error) {
+	if err != nil {
+		panic(fmt.Sprintf("fakes: invalid inventory item fixture: %v", err))
+	}
+}
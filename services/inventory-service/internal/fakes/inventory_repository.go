@@ -0,0 +1,260 @@
+// Package fakes provides an in-memory inventory repository and a builder for
+// inventory items, for unit tests and cross-service behavior tests. The fake follows
+// the semantics of the MongoDB repository, including nil results for missing items,
+// and can be told to fail any operation with FailOn.
+package fakes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/testing/memstore"
+)
+
+var _ domain.InventoryRepository = (*InventoryRepository)(nil)
+
+// InventoryRepository is an in-memory domain.InventoryRepository. Items are stored
+// the way MongoDB stores them: only active reservations survive a save.
+type InventoryRepository struct {
+	*memstore.Store[string, *domain.InventoryItem]
+}
+
+// NewInventoryRepository creates an empty repository seeded with the given items
+func NewInventoryRepository(items ...*domain.InventoryItem) *InventoryRepository {
+	r := &InventoryRepository{Store: memstore.New[string](cloneItem)}
+	for _, item := range items {
+		r.Put(item.ID(), item)
+	}
+	return r
+}
+
+func (r *InventoryRepository) Save(item *domain.InventoryItem) error {
+	if err := r.Call("Save"); err != nil {
+		return err
+	}
+	// SKUs are unique, as enforced by the MongoDB index
+	clash := r.List(func(other *domain.InventoryItem) bool {
+		return other.SKU() == item.SKU() && other.ID() != item.ID()
+	})
+	if len(clash) > 0 {
+		return domain.ErrItemAlreadyExists
+	}
+	r.Put(item.ID(), item)
+	return nil
+}
+
+func (r *InventoryRepository) FindByID(id string) (*domain.InventoryItem, error) {
+	if err := r.Call("FindByID"); err != nil {
+		return nil, err
+	}
+	item, _ := r.Get(id)
+	return loaded(item)
+}
+
+func (r *InventoryRepository) FindBySKU(sku string) (*domain.InventoryItem, error) {
+	if err := r.Call("FindBySKU"); err != nil {
+		return nil, err
+	}
+	items := r.List(func(item *domain.InventoryItem) bool { return item.SKU() == sku })
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return loaded(items[0])
+}
+
+func (r *InventoryRepository) FindBySKUForRepair(sku string) (*domain.InventoryItem, error) {
+	if err := r.Call("FindBySKUForRepair"); err != nil {
+		return nil, err
+	}
+	items := r.List(func(item *domain.InventoryItem) bool { return item.SKU() == sku })
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return items[0], nil
+}
+
+func (r *InventoryRepository) FindAllForRepair() ([]*domain.InventoryItem, error) {
+	if err := r.Call("FindAllForRepair"); err != nil {
+		return nil, err
+	}
+	items := r.List(func(*domain.InventoryItem) bool { return true })
+	sort.Slice(items, func(i, j int) bool { return items[i].SKU() < items[j].SKU() })
+	return items, nil
+}
+
+func (r *InventoryRepository) FindByCategory(category domain.ItemCategory) ([]*domain.InventoryItem, error) {
+	if err := r.Call("FindByCategory"); err != nil {
+		return nil, err
+	}
+	return r.list(func(item *domain.InventoryItem) bool { return item.Category() == category }), nil
+}
+
+func (r *InventoryRepository) FindLowStockItems() ([]*domain.InventoryItem, error) {
+	if err := r.Call("FindLowStockItems"); err != nil {
+		return nil, err
+	}
+	return r.list(func(item *domain.InventoryItem) bool {
+		return item.StockLevel() <= item.MinStockLevel() &&
+			(item.Status() == domain.ItemStatusActive || item.Status() == domain.ItemStatusIncoming)
+	}), nil
+}
+
+func (r *InventoryRepository) FindAvailableItems() ([]*domain.InventoryItem, error) {
+	if err := r.Call("FindAvailableItems"); err != nil {
+		return nil, err
+	}
+	return r.list(available), nil
+}
+
+func (r *InventoryRepository) Delete(id string) error {
+	if err := r.Call("Delete"); err != nil {
+		return err
+	}
+	if !r.Store.Delete(id) {
+		return domain.ErrItemNotFound
+	}
+	return nil
+}
+
+func (r *InventoryRepository) Search(query string) ([]*domain.InventoryItem, error) {
+	if err := r.Call("Search"); err != nil {
+		return nil, err
+	}
+	return r.list(matchesQuery(query)), nil
+}
+
+func (r *InventoryRepository) AvailabilitySummary() ([]domain.CategoryAvailability, error) {
+	if err := r.Call("AvailabilitySummary"); err != nil {
+		return nil, err
+	}
+	return domain.SummarizeAvailability(r.List(nil)), nil
+}
+
+func (r *InventoryRepository) SearchPage(search domain.ItemSearch) ([]*domain.InventoryItem, int, error) {
+	if err := r.Call("SearchPage"); err != nil {
+		return nil, 0, err
+	}
+
+	var match func(*domain.InventoryItem) bool
+	switch {
+	case search.Category != nil:
+		match = func(item *domain.InventoryItem) bool { return item.Category() == *search.Category }
+	case search.Query != "":
+		match = matchesQuery(search.Query)
+	default:
+		match = available
+	}
+	items := r.list(func(item *domain.InventoryItem) bool {
+		return match(item) && (!search.AvailableOnly || item.StockLevel() > 0)
+	})
+	total := len(items)
+
+	sort.Slice(items, func(i, j int) bool { return items[i].SKU() < items[j].SKU() })
+	if search.AfterSKU != "" {
+		start := sort.Search(len(items), func(i int) bool { return items[i].SKU() > search.AfterSKU })
+		items = items[start:]
+	} else if search.Offset > 0 {
+		if search.Offset >= len(items) {
+			items = nil
+		} else {
+			items = items[search.Offset:]
+		}
+	}
+	if search.Limit > 0 && len(items) > search.Limit {
+		items = items[:search.Limit]
+	}
+	return items, total, nil
+}
+
+// list returns the items match accepts, skipping the ones whose stock levels are
+// broken, as the MongoDB listings do
+func (r *InventoryRepository) list(match func(*domain.InventoryItem) bool) []*domain.InventoryItem {
+	return r.List(func(item *domain.InventoryItem) bool {
+		return item.Levels().Valid() && match(item)
+	})
+}
+
+// loaded fails like the MongoDB repository does for an item whose stock levels are broken
+func loaded(item *domain.InventoryItem) (*domain.InventoryItem, error) {
+	if item != nil && !item.Levels().Valid() {
+		return nil, fmt.Errorf("failed to reconstruct domain item: %w", domain.ErrStockInvariant)
+	}
+	return item, nil
+}
+
+func available(item *domain.InventoryItem) bool {
+	return item.StockLevel() > 0 && item.Status() == domain.ItemStatusActive
+}
+
+// matchesQuery matches active items by name, description or SKU, like the regex
+// fallback of the MongoDB search
+func matchesQuery(query string) func(*domain.InventoryItem) bool {
+	query = strings.ToLower(query)
+	return func(item *domain.InventoryItem) bool {
+		if item.Status() != domain.ItemStatusActive {
+			return false
+		}
+		return query == "" ||
+			strings.Contains(strings.ToLower(item.Name()), query) ||
+			strings.Contains(strings.ToLower(item.Description()), query) ||
+			strings.Contains(strings.ToLower(item.SKU()), query)
+	}
+}
+
+// cloneItem copies an item through the same reconstruction path the MongoDB
+// repository uses when it loads one
+func cloneItem(item *domain.InventoryItem) *domain.InventoryItem {
+	clone, err := restore(item, item.Status())
+	if err != nil {
+		panic(fmt.Sprintf("fakes: cannot copy inventory item %s: %v", item.ID(), err))
+	}
+	return clone
+}
+
+func restore(item *domain.InventoryItem, status domain.ItemStatus) (*domain.InventoryItem, error) {
+	specifications := make(map[string]string, len(item.Specifications()))
+	for k, v := range item.Specifications() {
+		specifications[k] = v
+	}
+
+	clone, err := domain.ReconstructInventoryItem(
+		item.ID(), item.SKU(), item.Name(), item.Description(),
+		item.Category(),
+		item.StockLevel(), item.ReservedStock(), item.TotalStock(), item.MinStockLevel(), item.MaxStockLevel(),
+		item.UnitPrice(),
+		item.Weight(),
+		item.Dimensions(),
+		specifications,
+		item.CreatedAt(), item.UpdatedAt(),
+		item.Version(),
+		status,
+	)
+	// Items with broken stock levels are kept as they are, so repairs can be tested
+	if err != nil && !(clone != nil && errors.Is(err, domain.ErrStockInvariant)) {
+		return nil, err
+	}
+	if err := clone.RestoreUnitOfMeasure(item.Unit()); err != nil {
+		return nil, err
+	}
+	if item.SerialTracked() {
+		clone.RestoreSerialTracked()
+	}
+	clone.RestoreCosting(item.CostPrice(), append([]domain.CostLayer(nil), item.CostLayers()...), item.AverageCost())
+	for _, reservation := range item.GetActiveReservations() {
+		if err := clone.RestoreReservation(
+			reservation.ID(),
+			reservation.OrderID(),
+			reservation.Quantity(),
+			reservation.ReservedAt(),
+			reservation.ExpiresAt(),
+			reservation.Status(),
+			reservation.Priority(),
+		); err != nil {
+			return nil, err
+		}
+	}
+	return clone, nil
+}
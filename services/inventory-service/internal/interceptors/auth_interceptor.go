@@ -12,7 +12,9 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
-	iampb "github.com/amiosamu/rocket-science/services/iam-service/proto/iam"
+	iampb "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcclient"
 )
 
 type AuthInterceptor struct {
@@ -22,7 +24,10 @@ type AuthInterceptor struct {
 }
 
 func NewAuthInterceptor(iamAddress string, logger *slog.Logger) (*AuthInterceptor, error) {
-	conn, err := grpc.Dial(iamAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(iamAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(grpcclient.UnaryClientInterceptor(grpcclient.DefaultConfig("iam"), nil)),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to IAM service: %w", err)
 	}
@@ -135,9 +140,10 @@ func (a *AuthInterceptor) authenticateRequest(ctx context.Context) (context.Cont
 	}
 
 	// Add user info to context
-	newCtx := context.WithValue(ctx, "user_id", resp.User.Id)
-	newCtx = context.WithValue(newCtx, "user_role", resp.User.Role.String())
-	newCtx = context.WithValue(newCtx, "session_id", sessionID)
+	newCtx := ctxmeta.WithUserID(ctx, resp.User.Id)
+	newCtx = ctxmeta.WithRoles(newCtx, resp.User.Role.String())
+	newCtx = ctxmeta.WithPermissions(newCtx, resp.Permissions...)
+	newCtx = ctxmeta.WithSessionID(newCtx, sessionID)
 
 	return newCtx, nil
 }
@@ -0,0 +1,156 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+)
+
+// EventTypeItemChanged carries the full state of an item after it was created,
+// updated or discontinued
+const EventTypeItemChanged = "inventory.item.changed"
+
+// EventTypeItemDeleted marks the tombstone of a deleted item
+const EventTypeItemDeleted = "inventory.item.deleted"
+
+// itemStatePayload is the wire format of an item on the catalog change feed
+type itemStatePayload struct {
+	ID             string            `json:"id"`
+	SKU            string            `json:"sku"`
+	Name           string            `json:"name"`
+	Description    string            `json:"description"`
+	Category       string            `json:"category"`
+	Status         string            `json:"status"`
+	ReplacementSKU string            `json:"replacement_sku,omitempty"`
+	Unit           string            `json:"unit"`
+	StockLevel     float64           `json:"stock_level"`
+	ReservedStock  float64           `json:"reserved_stock"`
+	TotalStock     float64           `json:"total_stock"`
+	MinStockLevel  float64           `json:"min_stock_level"`
+	MaxStockLevel  float64           `json:"max_stock_level"`
+	UnitPrice      float64           `json:"unit_price"`
+	UnitPriceMinor int64             `json:"unit_price_minor"`
+	Currency       string            `json:"currency"`
+	Weight         float64           `json:"weight"`
+	Length         float64           `json:"length"`
+	Width          float64           `json:"width"`
+	Height         float64           `json:"height"`
+	Specifications map[string]string `json:"specifications,omitempty"`
+	Version        int               `json:"version"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// CatalogChangeProducer publishes the catalog change feed: the full state of
+// every item, keyed by SKU, to a compacted topic. Items are sent asynchronously
+// so stock writes don't wait for the brokers.
+type CatalogChangeProducer struct {
+	producer *kafka.Producer
+	topic    string
+	logger   *slog.Logger
+	sync     bool // Wait for the brokers to acknowledge each item
+}
+
+// NewCatalogChangeProducer creates a new catalog change producer on top of the shared Kafka producer
+func NewCatalogChangeProducer(producer *kafka.Producer, topic string, logger *slog.Logger) *CatalogChangeProducer {
+	return &CatalogChangeProducer{
+		producer: producer,
+		topic:    topic,
+		logger:   logger.With("component", "catalog_change_producer"),
+	}
+}
+
+// Sync returns a producer of the same feed that waits for the brokers to
+// acknowledge each item, so a backfill fails on the first item not sent
+func (p *CatalogChangeProducer) Sync() *CatalogChangeProducer {
+	sync := *p
+	sync.sync = true
+	return &sync
+}
+
+// PublishItemChanged implements service.CatalogChangePublisher
+func (p *CatalogChangeProducer) PublishItemChanged(ctx context.Context, item *domain.InventoryItem) error {
+	headers := map[string]string{
+		"event-type":     EventTypeItemChanged,
+		"event-id":       uuid.New().String(),
+		"event-version":  "1.0",
+		"source-service": "inventory-service",
+		"sku":            item.SKU(),
+	}
+
+	unitPrice := item.UnitPrice()
+	dimensions := item.Dimensions()
+	payload := itemStatePayload{
+		ID:             item.ID(),
+		SKU:            item.SKU(),
+		Name:           item.Name(),
+		Description:    item.Description(),
+		Category:       item.Category().String(),
+		Status:         item.Status().String(),
+		ReplacementSKU: item.ReplacementSKU(),
+		Unit:           string(item.Unit()),
+		StockLevel:     item.StockLevel(),
+		ReservedStock:  item.ReservedStock(),
+		TotalStock:     item.TotalStock(),
+		MinStockLevel:  item.MinStockLevel(),
+		MaxStockLevel:  item.MaxStockLevel(),
+		UnitPrice:      unitPrice.Float64(),
+		UnitPriceMinor: unitPrice.Minor,
+		Currency:       unitPrice.Currency,
+		Weight:         item.Weight(),
+		Length:         dimensions.Length,
+		Width:          dimensions.Width,
+		Height:         dimensions.Height,
+		Specifications: item.Specifications(),
+		Version:        item.Version(),
+		CreatedAt:      item.CreatedAt(),
+		UpdatedAt:      item.UpdatedAt(),
+	}
+
+	if err := p.send(ctx, item.SKU(), payload, headers); err != nil {
+		return fmt.Errorf("failed to publish item changed event: %w", err)
+	}
+
+	p.logger.Debug("Item changed event published",
+		"topic", p.topic,
+		"sku", item.SKU(),
+		"version", item.Version())
+
+	return nil
+}
+
+// PublishItemDeleted implements service.CatalogChangePublisher. The tombstone has
+// no value, so compaction eventually drops the item from the topic.
+func (p *CatalogChangeProducer) PublishItemDeleted(ctx context.Context, sku string) error {
+	headers := map[string]string{
+		"event-type":     EventTypeItemDeleted,
+		"event-id":       uuid.New().String(),
+		"source-service": "inventory-service",
+		"sku":            sku,
+	}
+
+	if err := p.send(ctx, sku, []byte(nil), headers); err != nil {
+		return fmt.Errorf("failed to publish item deleted event: %w", err)
+	}
+
+	p.logger.Info("Item deleted event published", "topic", p.topic, "sku", sku)
+	return nil
+}
+
+func (p *CatalogChangeProducer) send(ctx context.Context, sku string, value interface{}, headers map[string]string) error {
+	if p.sync {
+		return p.producer.SendMessage(ctx, p.topic, sku, value, headers)
+	}
+	return p.producer.SendMessageAsync(ctx, p.topic, sku, value, headers)
+}
+
+// Close sends the queued items and closes the underlying Kafka producer
+func (p *CatalogChangeProducer) Close() error {
+	return p.producer.Close()
+}
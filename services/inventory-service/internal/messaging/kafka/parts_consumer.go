@@ -0,0 +1,130 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
+	"github.com/amiosamu/rocket-science/shared/proto/events"
+)
+
+// EventTypePartsConsumed is published by assembly-service when a completed rocket used inventory parts
+const EventTypePartsConsumed = "assembly.parts-consumed"
+
+// PartsConsumptionHandler books consumed parts out of an order's confirmed stock
+type PartsConsumptionHandler interface {
+	ConsumeParts(ctx context.Context, req service.ConsumePartsRequest) (*service.ConsumePartsResult, error)
+}
+
+// assemblyEventPayload is the wire format of assembly events: an envelope whose
+// data is the JSON form of the event message
+type assemblyEventPayload struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// PartsConsumedConsumer feeds assembly parts consumed events into the stock movement ledger
+type PartsConsumedConsumer struct {
+	consumer *kafka.Consumer
+	topic    string
+	handler  PartsConsumptionHandler
+	logger   *slog.Logger
+}
+
+// NewPartsConsumedConsumer registers a parts consumed consumer for the topic on the shared Kafka consumer
+func NewPartsConsumedConsumer(consumer *kafka.Consumer, topic string, handler PartsConsumptionHandler, logger *slog.Logger, reporter recovery.Reporter) *PartsConsumedConsumer {
+	c := &PartsConsumedConsumer{
+		consumer: consumer,
+		topic:    topic,
+		handler:  handler,
+		logger:   logger.With("component", "parts_consumed_consumer"),
+	}
+	consumer.RegisterHandler(recovery.Handler(c, reporter))
+	return c
+}
+
+// GetSupportedTopics implements kafka.MessageHandler
+func (c *PartsConsumedConsumer) GetSupportedTopics() []string {
+	return []string{c.topic}
+}
+
+// HandleMessage implements kafka.MessageHandler. The event ID is derived from the
+// order, so a redelivered or republished event books the parts once.
+func (c *PartsConsumedConsumer) HandleMessage(ctx context.Context, message *kafka.Message) error {
+	var payload assemblyEventPayload
+	if err := json.Unmarshal(message.Value, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal assembly event: %w", err)
+	}
+
+	eventType := message.EventType
+	if eventType == "" {
+		eventType = payload.Type
+	}
+	if eventType != EventTypePartsConsumed {
+		c.logger.Debug("Skipping assembly event", "eventType", eventType, "topic", message.Topic)
+		return nil
+	}
+
+	var event events.AssemblyPartsConsumedEvent
+	if err := json.Unmarshal(payload.Data, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal parts consumed event: %w", err)
+	}
+
+	eventID := message.EventID
+	if eventID == "" {
+		eventID = payload.ID
+	}
+
+	// Shipments of partially fulfilled orders are reserved and confirmed under the
+	// shipment ID, so their consumption is booked against it too
+	orderRef := event.OrderId
+	if event.ShipmentId != "" {
+		orderRef = event.ShipmentId
+	}
+
+	req := service.ConsumePartsRequest{
+		EventID:    eventID,
+		OrderID:    orderRef,
+		AssemblyID: event.AssemblyId,
+		Parts:      make([]service.ConsumedPart, 0, len(event.Parts)),
+	}
+	if event.ConsumedAt != nil {
+		req.ConsumedAt = event.ConsumedAt.AsTime()
+	}
+	for _, part := range event.Parts {
+		req.Parts = append(req.Parts, service.ConsumedPart{
+			SKU:           part.Sku,
+			Quantity:      float64(part.Quantity),
+			SerialNumbers: part.SerialNumbers,
+		})
+	}
+
+	result, err := c.handler.ConsumeParts(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to record parts consumption for order %s: %w", orderRef, err)
+	}
+
+	c.logger.Info("Parts consumed event processed",
+		"eventID", eventID,
+		"orderID", orderRef,
+		"assemblyID", event.AssemblyId,
+		"recorded", result.Recorded,
+		"duplicates", result.Duplicates)
+
+	return nil
+}
+
+// Start starts consuming parts consumed events
+func (c *PartsConsumedConsumer) Start(ctx context.Context) error {
+	return c.consumer.Start(ctx)
+}
+
+// Stop stops the underlying Kafka consumer
+func (c *PartsConsumedConsumer) Stop() error {
+	return c.consumer.Stop()
+}
@@ -0,0 +1,199 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+)
+
+// EventTypeReservationPreempted is consumed by order-service to compensate the preempted order
+const EventTypeReservationPreempted = "inventory.reservation.preempted"
+
+// EventTypeBackorderReserved is consumed by order-service to resume the backordered order
+const EventTypeBackorderReserved = "inventory.backorder.reserved"
+
+// EventTypeRestockRequested asks purchasing to reorder a low stock item from its chosen supplier
+const EventTypeRestockRequested = "inventory.restock.requested"
+
+// reservationPreemptedPayload is the wire format of a reservation preempted event
+type reservationPreemptedPayload struct {
+	OrderID            string    `json:"order_id"`
+	PreemptedByOrderID string    `json:"preempted_by_order_id"`
+	ReservationID      string    `json:"reservation_id"`
+	ItemID             string    `json:"item_id"`
+	SKU                string    `json:"sku"`
+	Quantity           float64   `json:"quantity"`
+	Unit               string    `json:"unit"`
+	PreemptedAt        time.Time `json:"preempted_at"`
+}
+
+// backorderReservedPayload is the wire format of a backorder reserved event
+type backorderReservedPayload struct {
+	BackorderID   string    `json:"backorder_id"`
+	OrderID       string    `json:"order_id"`
+	ShipmentID    string    `json:"shipment_id,omitempty"`
+	ReservationID string    `json:"reservation_id"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	ReservedAt    time.Time `json:"reserved_at"`
+}
+
+// restockRequestedPayload is the wire format of a restock requested event
+type restockRequestedPayload struct {
+	SKU              string     `json:"sku"`
+	Name             string     `json:"name"`
+	Quantity         float64    `json:"quantity"`
+	Unit             string     `json:"unit"`
+	StockLevel       float64    `json:"stock_level"`
+	MinStockLevel    float64    `json:"min_stock_level"`
+	Supplier         string     `json:"supplier,omitempty"`
+	SupplierSKU      string     `json:"supplier_sku,omitempty"`
+	LeadTimeDays     int        `json:"lead_time_days"`
+	ExpectedDelivery *time.Time `json:"expected_delivery,omitempty"`
+	UnitCost         float64    `json:"unit_cost,omitempty"`
+	UnitCostMinor    int64      `json:"unit_cost_minor,omitempty"`
+	Currency         string     `json:"currency,omitempty"`
+	RequestedAt      time.Time  `json:"requested_at"`
+}
+
+// ReservationEventProducer publishes reservation and restock events to Kafka
+type ReservationEventProducer struct {
+	producer *kafka.Producer
+	topic    string
+	logger   *slog.Logger
+}
+
+// NewReservationEventProducer creates a new reservation event producer on top of the shared Kafka producer
+func NewReservationEventProducer(producer *kafka.Producer, topic string, logger *slog.Logger) *ReservationEventProducer {
+	return &ReservationEventProducer{
+		producer: producer,
+		topic:    topic,
+		logger:   logger.With("component", "reservation_event_producer"),
+	}
+}
+
+// PublishReservationPreempted implements service.ReservationEventPublisher
+func (p *ReservationEventProducer) PublishReservationPreempted(ctx context.Context, event service.ReservationPreemptedEvent) error {
+	eventID := uuid.New().String()
+
+	headers := map[string]string{
+		"event-type":     EventTypeReservationPreempted,
+		"event-id":       eventID,
+		"event-version":  "1.0",
+		"source-service": "inventory-service",
+		"order-id":       event.OrderID,
+	}
+
+	payload := reservationPreemptedPayload{
+		OrderID:            event.OrderID,
+		PreemptedByOrderID: event.PreemptedByOrderID,
+		ReservationID:      event.ReservationID,
+		ItemID:             event.ItemID,
+		SKU:                event.SKU,
+		Quantity:           event.Quantity,
+		Unit:               string(event.Unit),
+		PreemptedAt:        event.PreemptedAt,
+	}
+
+	// Partition by the preempted order ID so the event is ordered with its other events
+	if err := p.producer.SendMessage(ctx, p.topic, event.OrderID, payload, headers); err != nil {
+		return fmt.Errorf("failed to publish reservation preempted event: %w", err)
+	}
+
+	p.logger.Info("Reservation preempted event published",
+		"eventID", eventID,
+		"topic", p.topic,
+		"orderID", event.OrderID,
+		"preemptedByOrderID", event.PreemptedByOrderID,
+		"sku", event.SKU)
+
+	return nil
+}
+
+// PublishBackorderReserved implements service.ReservationEventPublisher
+func (p *ReservationEventProducer) PublishBackorderReserved(ctx context.Context, event service.BackorderReservedEvent) error {
+	// The backorder is reserved at most once, so its ID identifies the event
+	eventID := event.BackorderID
+
+	headers := map[string]string{
+		"event-type":     EventTypeBackorderReserved,
+		"event-id":       eventID,
+		"event-version":  "1.0",
+		"source-service": "inventory-service",
+		"order-id":       event.OrderID,
+	}
+
+	payload := backorderReservedPayload{
+		BackorderID:   event.BackorderID,
+		OrderID:       event.OrderID,
+		ShipmentID:    event.ShipmentID,
+		ReservationID: event.ReservationID,
+		ExpiresAt:     event.ExpiresAt,
+		ReservedAt:    event.ReservedAt,
+	}
+
+	if err := p.producer.SendMessage(ctx, p.topic, event.OrderID, payload, headers); err != nil {
+		return fmt.Errorf("failed to publish backorder reserved event: %w", err)
+	}
+
+	p.logger.Info("Backorder reserved event published",
+		"eventID", eventID,
+		"topic", p.topic,
+		"orderID", event.OrderID,
+		"shipmentID", event.ShipmentID)
+
+	return nil
+}
+
+// PublishRestockRequested implements service.RestockEventPublisher
+func (p *ReservationEventProducer) PublishRestockRequested(ctx context.Context, event service.RestockRequestedEvent) error {
+	eventID := uuid.New().String()
+
+	headers := map[string]string{
+		"event-type":     EventTypeRestockRequested,
+		"event-id":       eventID,
+		"event-version":  "1.0",
+		"source-service": "inventory-service",
+		"sku":            event.SKU,
+	}
+
+	payload := restockRequestedPayload{
+		SKU:              event.SKU,
+		Name:             event.Name,
+		Quantity:         event.Quantity,
+		Unit:             string(event.Unit),
+		StockLevel:       event.StockLevel,
+		MinStockLevel:    event.MinStockLevel,
+		Supplier:         event.Supplier,
+		SupplierSKU:      event.SupplierSKU,
+		LeadTimeDays:     int(event.LeadTime / (24 * time.Hour)),
+		ExpectedDelivery: event.ExpectedDelivery,
+		UnitCost:         event.UnitCost.Float64(),
+		UnitCostMinor:    event.UnitCost.Minor,
+		Currency:         event.UnitCost.Currency,
+		RequestedAt:      event.RequestedAt,
+	}
+
+	if err := p.producer.SendMessage(ctx, p.topic, event.SKU, payload, headers); err != nil {
+		return fmt.Errorf("failed to publish restock requested event: %w", err)
+	}
+
+	p.logger.Info("Restock requested event published",
+		"eventID", eventID,
+		"topic", p.topic,
+		"sku", event.SKU,
+		"supplier", event.Supplier,
+		"quantity", event.Quantity)
+
+	return nil
+}
+
+// Close closes the underlying Kafka producer
+func (p *ReservationEventProducer) Close() error {
+	return p.producer.Close()
+}
@@ -0,0 +1,267 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+const (
+	// backorderCollection holds orders waiting for out-of-stock items
+	backorderCollection = "inventory_backorders"
+
+	backorderIDIndex     = "backorder_id_index"
+	backorderKeyIndex    = "backorder_reservation_key_status_index"
+	backorderSKUIndex    = "backorder_item_sku_status_index"
+	backorderStatusIndex = "backorder_status_created_index"
+)
+
+// MongoBackorderRepository implements the domain.BackorderRepository interface using MongoDB
+type MongoBackorderRepository struct {
+	collection *mongo.Collection
+	logger     *slog.Logger
+	timeout    time.Duration
+}
+
+// backorderDoc represents a backorder document in MongoDB
+type backorderDoc struct {
+	BackorderID                string             `bson:"backorder_id"`
+	OrderID                    string             `bson:"order_id"`
+	ShipmentID                 string             `bson:"shipment_id,omitempty"`
+	ReservationKey             string             `bson:"reservation_key"`
+	Status                     int                `bson:"status"`
+	Items                      []backorderItemDoc `bson:"items"`
+	ReservationDurationMinutes int                `bson:"reservation_duration_minutes"`
+	Priority                   int                `bson:"priority"`
+	ReservationID              string             `bson:"reservation_id,omitempty"`
+	CreatedAt                  time.Time          `bson:"created_at"`
+	UpdatedAt                  time.Time          `bson:"updated_at"`
+	ReservedAt                 *time.Time         `bson:"reserved_at,omitempty"`
+	Version                    int                `bson:"version"`
+}
+
+// backorderItemDoc represents a backordered item in MongoDB
+type backorderItemDoc struct {
+	SKU      string  `bson:"sku"`
+	Quantity float64 `bson:"quantity"`
+	Unit     string  `bson:"unit,omitempty"`
+}
+
+// NewMongoBackorderRepository creates a backorder repository sharing the inventory repository's database
+func NewMongoBackorderRepository(inventoryRepo *MongoInventoryRepository, logger *slog.Logger) *MongoBackorderRepository {
+	repo := &MongoBackorderRepository{
+		collection: inventoryRepo.database.Collection(backorderCollection),
+		logger:     logger,
+		timeout:    inventoryRepo.timeout,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), repo.timeout)
+	defer cancel()
+
+	_, err := repo.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "backorder_id", Value: 1}},
+			Options: options.Index().SetName(backorderIDIndex).SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "reservation_key", Value: 1}, {Key: "status", Value: 1}},
+			Options: options.Index().SetName(backorderKeyIndex),
+		},
+		{
+			Keys:    bson.D{{Key: "items.sku", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName(backorderSKUIndex),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName(backorderStatusIndex),
+		},
+	})
+	if err != nil {
+		logger.Warn("Failed to create backorder indexes", "error", err)
+		// Don't fail - indexes can be created later
+	}
+
+	return repo
+}
+
+// Create stores a new backorder
+func (r *MongoBackorderRepository) Create(backorder *domain.Backorder) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	if _, err := r.collection.InsertOne(ctx, backorderToDocument(backorder)); err != nil {
+		r.logger.Error("Failed to create backorder", "error", err, "backorderID", backorder.ID())
+		return fmt.Errorf("failed to create backorder: %w", err)
+	}
+
+	return nil
+}
+
+// Update persists changes to a backorder using the version for optimistic locking
+func (r *MongoBackorderRepository) Update(backorder *domain.Backorder, expectedVersion int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{"backorder_id": backorder.ID(), "version": expectedVersion}
+	update := bson.M{"$set": backorderToDocument(backorder)}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update backorder", "error", err, "backorderID", backorder.ID())
+		return fmt.Errorf("failed to update backorder: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrBackorderConflict
+	}
+
+	return nil
+}
+
+// FindOpenByReservationKey retrieves the waiting or reserved backorder of an order or shipment
+func (r *MongoBackorderRepository) FindOpenByReservationKey(key string) (*domain.Backorder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"reservation_key": key,
+		"status": bson.M{"$in": []int{
+			int(domain.BackorderStatusWaiting),
+			int(domain.BackorderStatusReserved),
+		}},
+	}
+
+	var doc backorderDoc
+	err := r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // No open backorder
+		}
+		r.logger.Error("Failed to find backorder", "error", err, "reservationKey", key)
+		return nil, fmt.Errorf("failed to find backorder: %w", err)
+	}
+
+	return documentToBackorder(&doc)
+}
+
+// FindWaitingBySKU retrieves the backorders waiting for an item, oldest first
+func (r *MongoBackorderRepository) FindWaitingBySKU(sku string) ([]*domain.Backorder, error) {
+	filter := bson.M{
+		"items.sku": sku,
+		"status":    int(domain.BackorderStatusWaiting),
+	}
+
+	return r.find(filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+}
+
+// FindAll retrieves backorders, optionally filtered by status, oldest first
+func (r *MongoBackorderRepository) FindAll(status *domain.BackorderStatus) ([]*domain.Backorder, error) {
+	filter := bson.M{}
+	if status != nil {
+		filter["status"] = int(*status)
+	}
+
+	return r.find(filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+}
+
+// find runs a query and converts the resulting documents
+func (r *MongoBackorderRepository) find(filter bson.M, opts *options.FindOptions) ([]*domain.Backorder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to find backorders", "error", err)
+		return nil, fmt.Errorf("failed to find backorders: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var backorders []*domain.Backorder
+	for cursor.Next(ctx) {
+		var doc backorderDoc
+		if err := cursor.Decode(&doc); err != nil {
+			r.logger.Warn("Failed to decode backorder", "error", err)
+			continue
+		}
+
+		backorder, err := documentToBackorder(&doc)
+		if err != nil {
+			r.logger.Warn("Failed to convert backorder document to domain", "error", err)
+			continue
+		}
+
+		backorders = append(backorders, backorder)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return backorders, nil
+}
+
+// backorderToDocument converts a domain Backorder to a MongoDB document
+func backorderToDocument(backorder *domain.Backorder) *backorderDoc {
+	items := make([]backorderItemDoc, 0, len(backorder.Items()))
+	for _, item := range backorder.Items() {
+		items = append(items, backorderItemDoc{
+			SKU:      item.SKU,
+			Quantity: item.Quantity,
+			Unit:     string(item.Unit),
+		})
+	}
+
+	return &backorderDoc{
+		BackorderID:                backorder.ID(),
+		OrderID:                    backorder.OrderID(),
+		ShipmentID:                 backorder.ShipmentID(),
+		ReservationKey:             backorder.ReservationKey(),
+		Status:                     int(backorder.Status()),
+		Items:                      items,
+		ReservationDurationMinutes: backorder.ReservationDurationMinutes(),
+		Priority:                   int(backorder.Priority()),
+		ReservationID:              backorder.ReservationID(),
+		CreatedAt:                  backorder.CreatedAt(),
+		UpdatedAt:                  backorder.UpdatedAt(),
+		ReservedAt:                 backorder.ReservedAt(),
+		Version:                    backorder.Version(),
+	}
+}
+
+// documentToBackorder converts a MongoDB document to a domain Backorder
+func documentToBackorder(doc *backorderDoc) (*domain.Backorder, error) {
+	items := make([]domain.BackorderItem, 0, len(doc.Items))
+	for _, item := range doc.Items {
+		items = append(items, domain.BackorderItem{
+			SKU:      item.SKU,
+			Quantity: item.Quantity,
+			Unit:     domain.UnitOfMeasure(item.Unit),
+		})
+	}
+
+	backorder, err := domain.ReconstructBackorder(
+		doc.BackorderID,
+		doc.OrderID,
+		doc.ShipmentID,
+		domain.BackorderStatus(doc.Status),
+		items,
+		doc.ReservationDurationMinutes,
+		domain.ReservationPriority(doc.Priority),
+		doc.ReservationID,
+		doc.CreatedAt,
+		doc.UpdatedAt,
+		doc.ReservedAt,
+		doc.Version,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct backorder: %w", err)
+	}
+
+	return backorder, nil
+}
@@ -0,0 +1,209 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+const (
+	// bundleCollection holds kit/bundle definitions
+	bundleCollection = "inventory_bundles"
+
+	bundleSKUIndex = "bundle_sku_index"
+)
+
+// MongoBundleRepository implements the domain.BundleRepository interface using MongoDB
+type MongoBundleRepository struct {
+	collection *mongo.Collection
+	logger     *slog.Logger
+	timeout    time.Duration
+}
+
+// bundleDoc represents a bundle definition document in MongoDB
+type bundleDoc struct {
+	SKU         string               `bson:"sku"`
+	Name        string               `bson:"name"`
+	Description string               `bson:"description"`
+	UnitPrice   moneyDoc             `bson:"unit_price"`
+	Components  []bundleComponentDoc `bson:"components"`
+	CreatedAt   time.Time            `bson:"created_at"`
+	UpdatedAt   time.Time            `bson:"updated_at"`
+	Version     int                  `bson:"version"`
+}
+
+// bundleComponentDoc represents a single bundle component in MongoDB
+type bundleComponentDoc struct {
+	SKU      string `bson:"sku"`
+	Quantity int    `bson:"quantity"`
+}
+
+// NewMongoBundleRepository creates a bundle repository sharing the inventory repository's database
+func NewMongoBundleRepository(inventoryRepo *MongoInventoryRepository, logger *slog.Logger) *MongoBundleRepository {
+	repo := &MongoBundleRepository{
+		collection: inventoryRepo.database.Collection(bundleCollection),
+		logger:     logger,
+		timeout:    inventoryRepo.timeout,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), repo.timeout)
+	defer cancel()
+
+	_, err := repo.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "sku", Value: 1}},
+		Options: options.Index().SetName(bundleSKUIndex).SetUnique(true),
+	})
+	if err != nil {
+		logger.Warn("Failed to create bundle indexes", "error", err)
+		// Don't fail - indexes can be created later
+	}
+
+	return repo
+}
+
+// Save persists a bundle definition to MongoDB
+func (r *MongoBundleRepository) Save(bundle *domain.Bundle) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{"sku": bundle.SKU()}
+	update := bson.M{"$set": bundleToDocument(bundle)}
+	opts := options.Update().SetUpsert(true)
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update, opts); err != nil {
+		r.logger.Error("Failed to save bundle", "error", err, "sku", bundle.SKU())
+		return fmt.Errorf("failed to save bundle: %w", err)
+	}
+
+	r.logger.Debug("Bundle saved", "sku", bundle.SKU(), "components", len(bundle.Components()))
+	return nil
+}
+
+// FindBySKU retrieves a bundle definition by its SKU
+func (r *MongoBundleRepository) FindBySKU(sku string) (*domain.Bundle, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var doc bundleDoc
+	err := r.collection.FindOne(ctx, bson.M{"sku": sku}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Bundle not found
+		}
+		r.logger.Error("Failed to find bundle by SKU", "error", err, "sku", sku)
+		return nil, fmt.Errorf("failed to find bundle: %w", err)
+	}
+
+	return documentToBundle(&doc)
+}
+
+// FindAll retrieves all bundle definitions ordered by SKU
+func (r *MongoBundleRepository) FindAll() ([]*domain.Bundle, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "sku", Value: 1}}))
+	if err != nil {
+		r.logger.Error("Failed to list bundles", "error", err)
+		return nil, fmt.Errorf("failed to list bundles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var bundles []*domain.Bundle
+	for cursor.Next(ctx) {
+		var doc bundleDoc
+		if err := cursor.Decode(&doc); err != nil {
+			r.logger.Warn("Failed to decode bundle", "error", err)
+			continue
+		}
+
+		bundle, err := documentToBundle(&doc)
+		if err != nil {
+			r.logger.Warn("Failed to convert bundle document to domain", "error", err)
+			continue
+		}
+
+		bundles = append(bundles, bundle)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return bundles, nil
+}
+
+// Delete removes a bundle definition
+func (r *MongoBundleRepository) Delete(sku string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"sku": sku})
+	if err != nil {
+		r.logger.Error("Failed to delete bundle", "error", err, "sku", sku)
+		return fmt.Errorf("failed to delete bundle: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrBundleNotFound
+	}
+
+	r.logger.Info("Bundle deleted", "sku", sku)
+	return nil
+}
+
+// bundleToDocument converts a domain Bundle to a MongoDB document
+func bundleToDocument(bundle *domain.Bundle) *bundleDoc {
+	components := make([]bundleComponentDoc, 0, len(bundle.Components()))
+	for _, component := range bundle.Components() {
+		components = append(components, bundleComponentDoc{
+			SKU:      component.SKU,
+			Quantity: component.Quantity,
+		})
+	}
+
+	return &bundleDoc{
+		SKU:         bundle.SKU(),
+		Name:        bundle.Name(),
+		Description: bundle.Description(),
+		UnitPrice:   newMoneyDoc(bundle.UnitPrice()),
+		Components:  components,
+		CreatedAt:   bundle.CreatedAt(),
+		UpdatedAt:   bundle.UpdatedAt(),
+		Version:     bundle.Version(),
+	}
+}
+
+// documentToBundle converts a MongoDB document to a domain Bundle
+func documentToBundle(doc *bundleDoc) (*domain.Bundle, error) {
+	components := make([]domain.BundleComponent, 0, len(doc.Components))
+	for _, component := range doc.Components {
+		components = append(components, domain.BundleComponent{
+			SKU:      component.SKU,
+			Quantity: component.Quantity,
+		})
+	}
+
+	bundle, err := domain.ReconstructBundle(
+		doc.SKU,
+		doc.Name,
+		doc.Description,
+		doc.UnitPrice.toDomain(),
+		components,
+		doc.CreatedAt,
+		doc.UpdatedAt,
+		doc.Version,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct bundle: %w", err)
+	}
+
+	return bundle, nil
+}
@@ -0,0 +1,193 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+const (
+	// categoryPolicyCollection holds the stocking policies of item categories
+	categoryPolicyCollection = "inventory_category_policies"
+
+	categoryPolicyIndex = "category_policy_category_index"
+)
+
+// MongoCategoryPolicyRepository implements the domain.CategoryPolicyRepository interface using MongoDB
+type MongoCategoryPolicyRepository struct {
+	collection *mongo.Collection
+	logger     *slog.Logger
+	timeout    time.Duration
+}
+
+// categoryPolicyDoc represents a category policy document in MongoDB
+type categoryPolicyDoc struct {
+	Category          int       `bson:"category"`
+	MinStockLevel     float64   `bson:"min_stock_level"`
+	MaxStockLevel     float64   `bson:"max_stock_level"`
+	ReservationTTLMin int       `bson:"reservation_ttl_minutes"`
+	RestockQuantity   float64   `bson:"restock_quantity"`
+	AllowedCurrencies []string  `bson:"allowed_currencies,omitempty"`
+	CreatedAt         time.Time `bson:"created_at"`
+	UpdatedAt         time.Time `bson:"updated_at"`
+	Version           int       `bson:"version"`
+}
+
+// NewMongoCategoryPolicyRepository creates a category policy repository sharing the inventory repository's database
+func NewMongoCategoryPolicyRepository(inventoryRepo *MongoInventoryRepository, logger *slog.Logger) *MongoCategoryPolicyRepository {
+	repo := &MongoCategoryPolicyRepository{
+		collection: inventoryRepo.database.Collection(categoryPolicyCollection),
+		logger:     logger,
+		timeout:    inventoryRepo.timeout,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), repo.timeout)
+	defer cancel()
+
+	_, err := repo.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "category", Value: 1}},
+		Options: options.Index().SetName(categoryPolicyIndex).SetUnique(true),
+	})
+	if err != nil {
+		logger.Warn("Failed to create category policy indexes", "error", err)
+		// Don't fail - indexes can be created later
+	}
+
+	return repo
+}
+
+// Save persists a category policy to MongoDB
+func (r *MongoCategoryPolicyRepository) Save(policy *domain.CategoryPolicy) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{"category": int(policy.Category())}
+	update := bson.M{"$set": categoryPolicyToDocument(policy)}
+	opts := options.Update().SetUpsert(true)
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update, opts); err != nil {
+		r.logger.Error("Failed to save category policy", "error", err, "category", policy.Category().String())
+		return fmt.Errorf("failed to save category policy: %w", err)
+	}
+
+	r.logger.Debug("Category policy saved", "category", policy.Category().String(), "version", policy.Version())
+	return nil
+}
+
+// FindByCategory retrieves the policy of a category
+func (r *MongoCategoryPolicyRepository) FindByCategory(category domain.ItemCategory) (*domain.CategoryPolicy, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var doc categoryPolicyDoc
+	err := r.collection.FindOne(ctx, bson.M{"category": int(category)}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Category has no policy
+		}
+		r.logger.Error("Failed to find category policy", "error", err, "category", category.String())
+		return nil, fmt.Errorf("failed to find category policy: %w", err)
+	}
+
+	return documentToCategoryPolicy(&doc)
+}
+
+// FindAll retrieves all category policies ordered by category
+func (r *MongoCategoryPolicyRepository) FindAll() ([]*domain.CategoryPolicy, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "category", Value: 1}}))
+	if err != nil {
+		r.logger.Error("Failed to list category policies", "error", err)
+		return nil, fmt.Errorf("failed to list category policies: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var policies []*domain.CategoryPolicy
+	for cursor.Next(ctx) {
+		var doc categoryPolicyDoc
+		if err := cursor.Decode(&doc); err != nil {
+			r.logger.Warn("Failed to decode category policy", "error", err)
+			continue
+		}
+
+		policy, err := documentToCategoryPolicy(&doc)
+		if err != nil {
+			r.logger.Warn("Failed to convert category policy document to domain", "error", err)
+			continue
+		}
+
+		policies = append(policies, policy)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return policies, nil
+}
+
+// Delete removes the policy of a category
+func (r *MongoCategoryPolicyRepository) Delete(category domain.ItemCategory) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"category": int(category)})
+	if err != nil {
+		r.logger.Error("Failed to delete category policy", "error", err, "category", category.String())
+		return fmt.Errorf("failed to delete category policy: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrCategoryPolicyNotFound
+	}
+
+	r.logger.Info("Category policy deleted", "category", category.String())
+	return nil
+}
+
+// categoryPolicyToDocument converts a domain CategoryPolicy to a MongoDB document
+func categoryPolicyToDocument(policy *domain.CategoryPolicy) *categoryPolicyDoc {
+	settings := policy.Settings()
+	return &categoryPolicyDoc{
+		Category:          int(policy.Category()),
+		MinStockLevel:     settings.MinStockLevel,
+		MaxStockLevel:     settings.MaxStockLevel,
+		ReservationTTLMin: int(settings.ReservationTTL / time.Minute),
+		RestockQuantity:   settings.RestockQuantity,
+		AllowedCurrencies: settings.AllowedCurrencies,
+		CreatedAt:         policy.CreatedAt(),
+		UpdatedAt:         policy.UpdatedAt(),
+		Version:           policy.Version(),
+	}
+}
+
+// documentToCategoryPolicy converts a MongoDB document to a domain CategoryPolicy
+func documentToCategoryPolicy(doc *categoryPolicyDoc) (*domain.CategoryPolicy, error) {
+	policy, err := domain.ReconstructCategoryPolicy(
+		domain.ItemCategory(doc.Category),
+		domain.CategoryPolicySettings{
+			MinStockLevel:     doc.MinStockLevel,
+			MaxStockLevel:     doc.MaxStockLevel,
+			ReservationTTL:    time.Duration(doc.ReservationTTLMin) * time.Minute,
+			RestockQuantity:   doc.RestockQuantity,
+			AllowedCurrencies: doc.AllowedCurrencies,
+		},
+		doc.CreatedAt,
+		doc.UpdatedAt,
+		doc.Version,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct category policy: %w", err)
+	}
+
+	return policy, nil
+}
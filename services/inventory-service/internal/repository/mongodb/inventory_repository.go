@@ -2,8 +2,10 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"strings"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
 )
 
 const (
@@ -49,13 +52,17 @@ type inventoryItemDoc struct {
 	Name           string             `bson:"name"`
 	Description    string             `bson:"description"`
 	Category       int                `bson:"category"`
-	StockLevel     int                `bson:"stock_level"`
-	ReservedStock  int                `bson:"reserved_stock"`
-	TotalStock     int                `bson:"total_stock"`
-	MinStockLevel  int                `bson:"min_stock_level"`
-	MaxStockLevel  int                `bson:"max_stock_level"`
+	Unit           string             `bson:"unit"`
+	StockLevel     float64            `bson:"stock_level"`
+	ReservedStock  float64            `bson:"reserved_stock"`
+	TotalStock     float64            `bson:"total_stock"`
+	MinStockLevel  float64            `bson:"min_stock_level"`
+	MaxStockLevel  float64            `bson:"max_stock_level"`
 	Reservations   []reservationDoc   `bson:"reservations"`
 	UnitPrice      moneyDoc           `bson:"unit_price"`
+	CostPrice      *moneyDoc          `bson:"cost_price,omitempty"`
+	CostLayers     []costLayerDoc     `bson:"cost_layers,omitempty"`
+	AverageCost    *moneyDoc          `bson:"average_cost,omitempty"`
 	Weight         float64            `bson:"weight"`
 	Dimensions     dimensionsDoc      `bson:"dimensions"`
 	Specifications map[string]string  `bson:"specifications"`
@@ -63,6 +70,8 @@ type inventoryItemDoc struct {
 	UpdatedAt      time.Time          `bson:"updated_at"`
 	Version        int                `bson:"version"`
 	Status         int                `bson:"status"`
+	SerialTracked  bool               `bson:"serial_tracked"`
+	ReplacementSKU string             `bson:"replacement_sku,omitempty"`
 }
 
 // reservationDoc represents a stock reservation in MongoDB
@@ -70,16 +79,54 @@ type reservationDoc struct {
 	ID         string    `bson:"id"`
 	OrderID    string    `bson:"order_id"`
 	ItemID     string    `bson:"item_id"`
-	Quantity   int       `bson:"quantity"`
+	Quantity   float64   `bson:"quantity"`
 	ReservedAt time.Time `bson:"reserved_at"`
 	ExpiresAt  time.Time `bson:"expires_at"`
 	Status     int       `bson:"status"`
+	Priority   int       `bson:"priority"` // Reservations saved before priorities existed are standard
+}
+
+// costLayerDoc represents stock received at one unit cost in MongoDB
+type costLayerDoc struct {
+	Quantity   float64   `bson:"quantity"`
+	UnitCost   moneyDoc  `bson:"unit_cost"`
+	ReceivedAt time.Time `bson:"received_at"`
+	Source     string    `bson:"source,omitempty"`
 }
 
 // moneyDoc represents currency amounts in MongoDB
 type moneyDoc struct {
-	Amount   float64 `bson:"amount"`
-	Currency string  `bson:"currency"`
+	MinorUnits int64   `bson:"minor_units"`
+	Amount     float64 `bson:"amount"` // Kept for readers that predate minor_units
+	Currency   string  `bson:"currency"`
+}
+
+func newMoneyDoc(m domain.Money) moneyDoc {
+	return moneyDoc{MinorUnits: m.Minor, Amount: m.Float64(), Currency: m.Currency}
+}
+
+// newOptionalMoneyDoc leaves out amounts without a currency, such as unknown costs
+func newOptionalMoneyDoc(m domain.Money) *moneyDoc {
+	if m.Currency == "" {
+		return nil
+	}
+	doc := newMoneyDoc(m)
+	return &doc
+}
+
+// toDomain falls back to the float amount for documents written before minor_units
+func (d moneyDoc) toDomain() domain.Money {
+	if d.MinorUnits == 0 && d.Amount != 0 {
+		return money.FromFloat(d.Amount, d.Currency)
+	}
+	return money.New(d.MinorUnits, d.Currency)
+}
+
+func optionalMoney(d *moneyDoc) domain.Money {
+	if d == nil {
+		return domain.Money{}
+	}
+	return d.toDomain()
 }
 
 // dimensionsDoc represents physical dimensions in MongoDB
@@ -130,6 +177,12 @@ func NewMongoInventoryRepository(cfg *config.Config, logger *slog.Logger) (*Mong
 		// Don't fail - indexes can be created later
 	}
 
+	// Bring documents written before units of measure up to date
+	if err := repo.migrateQuantities(ctx); err != nil {
+		logger.Warn("Failed to migrate stock quantities", "error", err)
+		// Don't fail - integer levels are still read correctly
+	}
+
 	logger.Info("MongoDB inventory repository initialized",
 		"database", cfg.Database.DatabaseName,
 		"collection", inventoryCollection)
@@ -207,6 +260,64 @@ func (r *MongoInventoryRepository) FindBySKU(sku string) (*domain.InventoryItem,
 	return r.documentToDomain(&doc)
 }
 
+// FindBySKUForRepair retrieves an inventory item by its SKU even if its stock
+// levels are inconsistent, so they can be repaired
+func (r *MongoInventoryRepository) FindBySKUForRepair(sku string) (*domain.InventoryItem, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{"sku": sku}
+	var doc inventoryItemDoc
+
+	err := r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Item not found
+		}
+		r.logger.Error("Failed to find inventory item by SKU", "error", err, "sku", sku)
+		return nil, fmt.Errorf("failed to find inventory item: %w", err)
+	}
+
+	return r.restoreDocument(&doc, true)
+}
+
+// FindAllForRepair retrieves every inventory item, sorted by SKU, even if its
+// stock levels are inconsistent
+func (r *MongoInventoryRepository) FindAllForRepair() ([]*domain.InventoryItem, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "sku", Value: 1}}))
+	if err != nil {
+		r.logger.Error("Failed to find inventory items", "error", err)
+		return nil, fmt.Errorf("failed to find inventory items: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var items []*domain.InventoryItem
+	for cursor.Next(ctx) {
+		var doc inventoryItemDoc
+		if err := cursor.Decode(&doc); err != nil {
+			r.logger.Warn("Failed to decode inventory item", "error", err)
+			continue
+		}
+
+		item, err := r.restoreDocument(&doc, true)
+		if err != nil {
+			r.logger.Warn("Failed to convert document to domain", "error", err, "sku", doc.SKU)
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return items, nil
+}
+
 // FindByCategory retrieves inventory items by category
 func (r *MongoInventoryRepository) FindByCategory(category domain.ItemCategory) ([]*domain.InventoryItem, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
@@ -255,7 +366,8 @@ func (r *MongoInventoryRepository) FindLowStockItems() ([]*domain.InventoryItem,
 		"$expr": bson.M{
 			"$lte": []interface{}{"$stock_level", "$min_stock_level"},
 		},
-		"status": int(domain.ItemStatusActive), // Only active items
+		// Only active items, plus items waiting on a purchase order in transit
+		"status": bson.M{"$in": []int{int(domain.ItemStatusActive), int(domain.ItemStatusIncoming)}},
 	}
 
 	cursor, err := r.collection.Find(ctx, filter)
@@ -348,7 +460,38 @@ func (r *MongoInventoryRepository) Search(query string) ([]*domain.InventoryItem
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
-	// Use MongoDB text search if available, otherwise use regex
+	filter := r.searchFilter(query)
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to search inventory items", "error", err, "query", query)
+		return nil, fmt.Errorf("failed to search inventory items: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var items []*domain.InventoryItem
+	for cursor.Next(ctx) {
+		var doc inventoryItemDoc
+		if err := cursor.Decode(&doc); err != nil {
+			r.logger.Warn("Failed to decode inventory item", "error", err)
+			continue
+		}
+
+		item, err := r.documentToDomain(&doc)
+		if err != nil {
+			r.logger.Warn("Failed to convert document to domain", "error", err)
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// searchFilter matches active items by name, description, or SKU. It uses the
+// text index when it exists and falls back to a regex match otherwise.
+func (r *MongoInventoryRepository) searchFilter(query string) bson.M {
 	var filter bson.M
 	
 	if query == "" {
@@ -382,10 +525,51 @@ func (r *MongoInventoryRepository) Search(query string) ([]*domain.InventoryItem
 		}
 	}
 
-	cursor, err := r.collection.Find(ctx, filter)
+	return filter
+}
+
+// SearchPage finds a page of items sorted by SKU and counts all matching items
+func (r *MongoInventoryRepository) SearchPage(search domain.ItemSearch) ([]*domain.InventoryItem, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var filter bson.M
+	switch {
+	case search.Category != nil:
+		filter = bson.M{"category": int(*search.Category)}
+	case search.Query != "":
+		filter = r.searchFilter(search.Query)
+	default:
+		filter = bson.M{
+			"stock_level": bson.M{"$gt": 0},
+			"status":      int(domain.ItemStatusActive),
+		}
+	}
+	if search.AvailableOnly {
+		filter["stock_level"] = bson.M{"$gt": 0}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
-		r.logger.Error("Failed to search inventory items", "error", err, "query", query)
-		return nil, fmt.Errorf("failed to search inventory items: %w", err)
+		r.logger.Error("Failed to count inventory items", "error", err)
+		return nil, 0, fmt.Errorf("failed to count inventory items: %w", err)
+	}
+
+	// The SKU is unique, so it is a stable sort key to resume from
+	opts := options.Find().SetSort(bson.D{{Key: "sku", Value: 1}})
+	if search.AfterSKU != "" {
+		filter["sku"] = bson.M{"$gt": search.AfterSKU}
+	} else if search.Offset > 0 {
+		opts.SetSkip(int64(search.Offset))
+	}
+	if search.Limit > 0 {
+		opts.SetLimit(int64(search.Limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to search inventory items", "error", err, "query", search.Query)
+		return nil, 0, fmt.Errorf("failed to search inventory items: %w", err)
 	}
 	defer cursor.Close(ctx)
 
@@ -406,7 +590,107 @@ func (r *MongoInventoryRepository) Search(query string) ([]*domain.InventoryItem
 		items = append(items, item)
 	}
 
-	return items, nil
+	if err := cursor.Err(); err != nil {
+		return nil, 0, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return items, int(total), nil
+}
+
+// availabilityGroup is one row of the availability aggregation: the items of
+// a category priced in one currency
+type availabilityGroup struct {
+	ID struct {
+		Category int    `bson:"category"`
+		Currency string `bson:"currency"`
+	} `bson:"_id"`
+	Items           int     `bson:"items"`
+	InStock         int     `bson:"in_stock"`
+	LowStock        int     `bson:"low_stock"`
+	OutOfStock      int     `bson:"out_of_stock"`
+	Reserved        float64 `bson:"reserved"`
+	ValuationMinor  float64 `bson:"valuation_minor"`
+	LegacyValuation float64 `bson:"legacy_valuation"` // From float amounts of documents without minor_units
+}
+
+// AvailabilitySummary aggregates per-category stock counts, reserved quantity
+// and valuation in MongoDB instead of loading every item
+func (r *MongoInventoryRepository) AvailabilitySummary() ([]domain.CategoryAvailability, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	countIf := func(condition bson.M) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{condition, 1, 0}}}
+	}
+	hasMinorUnits := bson.M{"$ne": bson.A{bson.M{"$ifNull": bson.A{"$unit_price.minor_units", 0}}, 0}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": bson.M{"$ne": int(domain.ItemStatusDiscontinued)}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          bson.M{"category": "$category", "currency": "$unit_price.currency"},
+			"items":        bson.M{"$sum": 1},
+			"out_of_stock": countIf(bson.M{"$lte": bson.A{"$stock_level", 0}}),
+			"low_stock": countIf(bson.M{"$and": bson.A{
+				bson.M{"$gt": bson.A{"$stock_level", 0}},
+				bson.M{"$lte": bson.A{"$stock_level", "$min_stock_level"}},
+			}}),
+			"in_stock": countIf(bson.M{"$gt": bson.A{"$stock_level", "$min_stock_level"}}),
+			"reserved": bson.M{"$sum": "$reserved_stock"},
+			"valuation_minor": bson.M{"$sum": bson.M{"$cond": bson.A{
+				hasMinorUnits,
+				bson.M{"$multiply": bson.A{"$total_stock", "$unit_price.minor_units"}},
+				0,
+			}}},
+			"legacy_valuation": bson.M{"$sum": bson.M{"$cond": bson.A{
+				hasMinorUnits,
+				0,
+				bson.M{"$multiply": bson.A{"$total_stock", bson.M{"$ifNull": bson.A{"$unit_price.amount", 0}}}},
+			}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("Failed to aggregate inventory availability", "error", err)
+		return nil, fmt.Errorf("failed to aggregate inventory availability: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groups []availabilityGroup
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("failed to decode inventory availability: %w", err)
+	}
+
+	// Merge the per-currency rows of each category
+	byCategory := make(map[domain.ItemCategory]*domain.CategoryAvailability)
+	for _, group := range groups {
+		category := domain.ItemCategory(group.ID.Category)
+		summary, exists := byCategory[category]
+		if !exists {
+			summary = &domain.CategoryAvailability{Category: category}
+			byCategory[category] = summary
+		}
+		summary.ItemCount += group.Items
+		summary.InStockCount += group.InStock
+		summary.LowStockCount += group.LowStock
+		summary.OutOfStockCount += group.OutOfStock
+		summary.ReservedQuantity += group.Reserved
+
+		valuation := money.New(int64(math.Round(group.ValuationMinor)), group.ID.Currency)
+		if group.LegacyValuation != 0 {
+			valuation.Minor += money.FromFloat(group.LegacyValuation, group.ID.Currency).Minor
+		}
+		summary.Valuation = append(summary.Valuation, valuation)
+	}
+
+	summaries := make([]domain.CategoryAvailability, 0, len(byCategory))
+	for _, category := range domain.ItemCategories {
+		if summary, exists := byCategory[category]; exists {
+			domain.SortValuation(summary.Valuation)
+			summaries = append(summaries, *summary)
+		}
+	}
+	return summaries, nil
 }
 
 // Close closes the MongoDB connection
@@ -458,6 +742,41 @@ func (r *MongoInventoryRepository) createIndexes(ctx context.Context) error {
 	return nil
 }
 
+// migrateQuantities converts the integer stock levels and reservation quantities of documents
+// written before units of measure existed into doubles, and stocks those items in pieces.
+// Documents that already have a unit are left untouched, so running it again is a no-op.
+func (r *MongoInventoryRepository) migrateQuantities(ctx context.Context) error {
+	filter := bson.M{"unit": bson.M{"$exists": false}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"unit":            string(domain.UnitEach),
+			"stock_level":     bson.M{"$toDouble": "$stock_level"},
+			"reserved_stock":  bson.M{"$toDouble": "$reserved_stock"},
+			"total_stock":     bson.M{"$toDouble": "$total_stock"},
+			"min_stock_level": bson.M{"$toDouble": "$min_stock_level"},
+			"max_stock_level": bson.M{"$toDouble": "$max_stock_level"},
+			"reservations": bson.M{"$map": bson.M{
+				"input": bson.M{"$ifNull": bson.A{"$reservations", bson.A{}}},
+				"as":    "reservation",
+				"in": bson.M{"$mergeObjects": bson.A{
+					"$$reservation",
+					bson.M{"quantity": bson.M{"$toDouble": "$$reservation.quantity"}},
+				}},
+			}},
+		}}},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to migrate stock quantities: %w", err)
+	}
+
+	if result.ModifiedCount > 0 {
+		r.logger.Info("Migrated stock quantities to units of measure", "items", result.ModifiedCount)
+	}
+	return nil
+}
+
 // Conversion methods between domain and MongoDB models
 
 // domainToDocument converts a domain InventoryItem to MongoDB document
@@ -473,6 +792,17 @@ func (r *MongoInventoryRepository) domainToDocument(item *domain.InventoryItem)
 			ReservedAt: reservation.ReservedAt(),
 			ExpiresAt:  reservation.ExpiresAt(),
 			Status:     int(reservation.Status()),
+			Priority:   int(reservation.Priority()),
+		})
+	}
+
+	costLayers := make([]costLayerDoc, 0, len(item.CostLayers()))
+	for _, layer := range item.CostLayers() {
+		costLayers = append(costLayers, costLayerDoc{
+			Quantity:   layer.Quantity,
+			UnitCost:   newMoneyDoc(layer.UnitCost),
+			ReceivedAt: layer.ReceivedAt,
+			Source:     layer.Source,
 		})
 	}
 
@@ -482,16 +812,17 @@ func (r *MongoInventoryRepository) domainToDocument(item *domain.InventoryItem)
 		Name:          item.Name(),
 		Description:   item.Description(),
 		Category:      int(item.Category()),
+		Unit:          string(item.Unit()),
 		StockLevel:    item.StockLevel(),
 		ReservedStock: item.ReservedStock(),
 		TotalStock:    item.TotalStock(),
 		MinStockLevel: item.MinStockLevel(),
 		MaxStockLevel: item.MaxStockLevel(),
 		Reservations:  reservations,
-		UnitPrice: moneyDoc{
-			Amount:   item.UnitPrice().Amount,
-			Currency: item.UnitPrice().Currency,
-		},
+		UnitPrice: newMoneyDoc(item.UnitPrice()),
+		CostPrice: newOptionalMoneyDoc(item.CostPrice()),
+		CostLayers: costLayers,
+		AverageCost: newOptionalMoneyDoc(item.AverageCost()),
 		Weight: item.Weight(),
 		Dimensions: dimensionsDoc{
 			Length: item.Dimensions().Length,
@@ -503,11 +834,20 @@ func (r *MongoInventoryRepository) domainToDocument(item *domain.InventoryItem)
 		UpdatedAt:      item.UpdatedAt(),
 		Version:        item.Version(),
 		Status:         int(item.Status()),
+		SerialTracked:  item.SerialTracked(),
+		ReplacementSKU: item.ReplacementSKU(),
 	}
 }
 
 // documentToDomain converts a MongoDB document to domain InventoryItem
 func (r *MongoInventoryRepository) documentToDomain(doc *inventoryItemDoc) (*domain.InventoryItem, error) {
+	return r.restoreDocument(doc, false)
+}
+
+// restoreDocument converts a MongoDB document to domain InventoryItem. With
+// allowInvalidStock, items whose stock levels break the invariants are restored
+// as they are instead of failing.
+func (r *MongoInventoryRepository) restoreDocument(doc *inventoryItemDoc, allowInvalidStock bool) (*domain.InventoryItem, error) {
 	// Use the reconstruction factory method to restore full state
 	item, err := domain.ReconstructInventoryItem(
 		doc.ItemID,
@@ -520,10 +860,7 @@ func (r *MongoInventoryRepository) documentToDomain(doc *inventoryItemDoc) (*dom
 		doc.TotalStock,
 		doc.MinStockLevel,
 		doc.MaxStockLevel,
-		domain.Money{
-			Amount:   doc.UnitPrice.Amount,
-			Currency: doc.UnitPrice.Currency,
-		},
+		doc.UnitPrice.toDomain(),
 		doc.Weight,
 		domain.Dimensions{
 			Length: doc.Dimensions.Length,
@@ -536,10 +873,31 @@ func (r *MongoInventoryRepository) documentToDomain(doc *inventoryItemDoc) (*dom
 		doc.Version,
 		domain.ItemStatus(doc.Status),
 	)
-	if err != nil {
+	if err != nil && !(allowInvalidStock && item != nil && errors.Is(err, domain.ErrStockInvariant)) {
+		return nil, fmt.Errorf("failed to reconstruct domain item: %w", err)
+	}
+
+	if err := item.RestoreUnitOfMeasure(domain.UnitOfMeasure(doc.Unit)); err != nil {
 		return nil, fmt.Errorf("failed to reconstruct domain item: %w", err)
 	}
 
+	// Restoring the flag must not bump the version read from storage
+	if doc.SerialTracked {
+		item.RestoreSerialTracked()
+	}
+	item.RestoreReplacementSKU(doc.ReplacementSKU)
+
+	costLayers := make([]domain.CostLayer, 0, len(doc.CostLayers))
+	for _, layer := range doc.CostLayers {
+		costLayers = append(costLayers, domain.CostLayer{
+			Quantity:   layer.Quantity,
+			UnitCost:   layer.UnitCost.toDomain(),
+			ReceivedAt: layer.ReceivedAt,
+			Source:     layer.Source,
+		})
+	}
+	item.RestoreCosting(optionalMoney(doc.CostPrice), costLayers, optionalMoney(doc.AverageCost))
+
 	// Restore reservations
 	for _, reservationDoc := range doc.Reservations {
 		err := item.RestoreReservation(
@@ -549,6 +907,7 @@ func (r *MongoInventoryRepository) documentToDomain(doc *inventoryItemDoc) (*dom
 			reservationDoc.ReservedAt,
 			reservationDoc.ExpiresAt,
 			domain.ReservationStatus(reservationDoc.Status),
+			domain.ReservationPriority(reservationDoc.Priority),
 		)
 		if err != nil {
 			r.logger.Warn("Failed to restore reservation", 
@@ -0,0 +1,284 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+const (
+	// purchaseOrderCollection holds inbound purchase orders
+	purchaseOrderCollection = "inventory_purchase_orders"
+
+	purchaseOrderIDIndex     = "po_id_index"
+	purchaseOrderSKUIndex    = "po_line_sku_status_index"
+	purchaseOrderStatusIndex = "po_status_created_index"
+)
+
+// MongoPurchaseOrderRepository implements the domain.PurchaseOrderRepository interface using MongoDB
+type MongoPurchaseOrderRepository struct {
+	collection *mongo.Collection
+	logger     *slog.Logger
+	timeout    time.Duration
+}
+
+// purchaseOrderDoc represents a purchase order document in MongoDB
+type purchaseOrderDoc struct {
+	POID            string                    `bson:"po_id"`
+	Supplier        string                    `bson:"supplier"`
+	Status          int                       `bson:"status"`
+	Lines           []purchaseOrderLineDoc    `bson:"lines"`
+	Receipts        []purchaseOrderReceiptDoc `bson:"receipts"`
+	ExpectedArrival *time.Time                `bson:"expected_arrival,omitempty"`
+	TrackingRef     string                    `bson:"tracking_ref,omitempty"`
+	CreatedBy       string                    `bson:"created_by"`
+	CreatedAt       time.Time                 `bson:"created_at"`
+	UpdatedAt       time.Time                 `bson:"updated_at"`
+	Version         int                       `bson:"version"`
+}
+
+// purchaseOrderLineDoc represents a purchase order line in MongoDB
+type purchaseOrderLineDoc struct {
+	SKU              string    `bson:"sku"`
+	OrderedQuantity  int       `bson:"ordered_quantity"`
+	ReceivedQuantity int       `bson:"received_quantity"`
+	UnitCost         *moneyDoc `bson:"unit_cost,omitempty"`
+}
+
+// purchaseOrderReceiptDoc represents a delivery received against a purchase order in MongoDB
+type purchaseOrderReceiptDoc struct {
+	SKU        string    `bson:"sku"`
+	Quantity   int       `bson:"quantity"`
+	ReceivedBy string    `bson:"received_by,omitempty"`
+	ReceivedAt time.Time `bson:"received_at"`
+}
+
+// NewMongoPurchaseOrderRepository creates a purchase order repository sharing the inventory repository's database
+func NewMongoPurchaseOrderRepository(inventoryRepo *MongoInventoryRepository, logger *slog.Logger) *MongoPurchaseOrderRepository {
+	repo := &MongoPurchaseOrderRepository{
+		collection: inventoryRepo.database.Collection(purchaseOrderCollection),
+		logger:     logger,
+		timeout:    inventoryRepo.timeout,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), repo.timeout)
+	defer cancel()
+
+	_, err := repo.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "po_id", Value: 1}},
+			Options: options.Index().SetName(purchaseOrderIDIndex).SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "lines.sku", Value: 1}, {Key: "status", Value: 1}},
+			Options: options.Index().SetName(purchaseOrderSKUIndex),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName(purchaseOrderStatusIndex),
+		},
+	})
+	if err != nil {
+		logger.Warn("Failed to create purchase order indexes", "error", err)
+		// Don't fail - indexes can be created later
+	}
+
+	return repo
+}
+
+// Create stores a new purchase order
+func (r *MongoPurchaseOrderRepository) Create(po *domain.PurchaseOrder) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	if _, err := r.collection.InsertOne(ctx, purchaseOrderToDocument(po)); err != nil {
+		r.logger.Error("Failed to create purchase order", "error", err, "poID", po.ID())
+		return fmt.Errorf("failed to create purchase order: %w", err)
+	}
+
+	return nil
+}
+
+// Update persists changes to a purchase order using the version for optimistic locking
+func (r *MongoPurchaseOrderRepository) Update(po *domain.PurchaseOrder, expectedVersion int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{"po_id": po.ID(), "version": expectedVersion}
+	update := bson.M{"$set": purchaseOrderToDocument(po)}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update purchase order", "error", err, "poID", po.ID())
+		return fmt.Errorf("failed to update purchase order: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrPurchaseOrderConflict
+	}
+
+	return nil
+}
+
+// FindByID retrieves a purchase order by its identifier
+func (r *MongoPurchaseOrderRepository) FindByID(id string) (*domain.PurchaseOrder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var doc purchaseOrderDoc
+	err := r.collection.FindOne(ctx, bson.M{"po_id": id}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Purchase order not found
+		}
+		r.logger.Error("Failed to find purchase order", "error", err, "poID", id)
+		return nil, fmt.Errorf("failed to find purchase order: %w", err)
+	}
+
+	return documentToPurchaseOrder(&doc)
+}
+
+// FindAll retrieves purchase orders, optionally filtered by status, newest first
+func (r *MongoPurchaseOrderRepository) FindAll(status *domain.PurchaseOrderStatus) ([]*domain.PurchaseOrder, error) {
+	filter := bson.M{}
+	if status != nil {
+		filter["status"] = int(*status)
+	}
+
+	return r.find(filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+}
+
+// FindReceivableBySKU retrieves purchase orders with goods still expected for an item
+func (r *MongoPurchaseOrderRepository) FindReceivableBySKU(sku string) ([]*domain.PurchaseOrder, error) {
+	filter := bson.M{
+		"lines.sku": sku,
+		"status": bson.M{"$in": []int{
+			int(domain.PurchaseOrderStatusOpen),
+			int(domain.PurchaseOrderStatusInTransit),
+			int(domain.PurchaseOrderStatusPartiallyReceived),
+		}},
+	}
+
+	return r.find(filter, options.Find().SetSort(bson.D{{Key: "expected_arrival", Value: 1}}))
+}
+
+// find runs a query and converts the resulting documents
+func (r *MongoPurchaseOrderRepository) find(filter bson.M, opts *options.FindOptions) ([]*domain.PurchaseOrder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to find purchase orders", "error", err)
+		return nil, fmt.Errorf("failed to find purchase orders: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var orders []*domain.PurchaseOrder
+	for cursor.Next(ctx) {
+		var doc purchaseOrderDoc
+		if err := cursor.Decode(&doc); err != nil {
+			r.logger.Warn("Failed to decode purchase order", "error", err)
+			continue
+		}
+
+		po, err := documentToPurchaseOrder(&doc)
+		if err != nil {
+			r.logger.Warn("Failed to convert purchase order document to domain", "error", err)
+			continue
+		}
+
+		orders = append(orders, po)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return orders, nil
+}
+
+// purchaseOrderToDocument converts a domain PurchaseOrder to a MongoDB document
+func purchaseOrderToDocument(po *domain.PurchaseOrder) *purchaseOrderDoc {
+	lines := make([]purchaseOrderLineDoc, 0, len(po.Lines()))
+	for _, line := range po.Lines() {
+		lines = append(lines, purchaseOrderLineDoc{
+			SKU:              line.SKU,
+			OrderedQuantity:  line.OrderedQuantity,
+			ReceivedQuantity: line.ReceivedQuantity,
+			UnitCost:         newOptionalMoneyDoc(line.UnitCost),
+		})
+	}
+
+	receipts := make([]purchaseOrderReceiptDoc, 0, len(po.Receipts()))
+	for _, receipt := range po.Receipts() {
+		receipts = append(receipts, purchaseOrderReceiptDoc{
+			SKU:        receipt.SKU,
+			Quantity:   receipt.Quantity,
+			ReceivedBy: receipt.ReceivedBy,
+			ReceivedAt: receipt.ReceivedAt,
+		})
+	}
+
+	return &purchaseOrderDoc{
+		POID:            po.ID(),
+		Supplier:        po.Supplier(),
+		Status:          int(po.Status()),
+		Lines:           lines,
+		Receipts:        receipts,
+		ExpectedArrival: po.ExpectedArrival(),
+		TrackingRef:     po.TrackingRef(),
+		CreatedBy:       po.CreatedBy(),
+		CreatedAt:       po.CreatedAt(),
+		UpdatedAt:       po.UpdatedAt(),
+		Version:         po.Version(),
+	}
+}
+
+// documentToPurchaseOrder converts a MongoDB document to a domain PurchaseOrder
+func documentToPurchaseOrder(doc *purchaseOrderDoc) (*domain.PurchaseOrder, error) {
+	lines := make([]domain.PurchaseOrderLine, 0, len(doc.Lines))
+	for _, line := range doc.Lines {
+		lines = append(lines, domain.PurchaseOrderLine{
+			SKU:              line.SKU,
+			OrderedQuantity:  line.OrderedQuantity,
+			ReceivedQuantity: line.ReceivedQuantity,
+			UnitCost:         optionalMoney(line.UnitCost),
+		})
+	}
+
+	receipts := make([]domain.PurchaseOrderReceipt, 0, len(doc.Receipts))
+	for _, receipt := range doc.Receipts {
+		receipts = append(receipts, domain.PurchaseOrderReceipt{
+			SKU:        receipt.SKU,
+			Quantity:   receipt.Quantity,
+			ReceivedBy: receipt.ReceivedBy,
+			ReceivedAt: receipt.ReceivedAt,
+		})
+	}
+
+	po, err := domain.ReconstructPurchaseOrder(
+		doc.POID,
+		doc.Supplier,
+		domain.PurchaseOrderStatus(doc.Status),
+		lines,
+		receipts,
+		doc.ExpectedArrival,
+		doc.TrackingRef,
+		doc.CreatedBy,
+		doc.CreatedAt,
+		doc.UpdatedAt,
+		doc.Version,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct purchase order: %w", err)
+	}
+
+	return po, nil
+}
@@ -0,0 +1,298 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+const (
+	// serialCollection holds serial numbers of serial-tracked items
+	serialCollection = "inventory_serials"
+
+	serialIndex      = "serial_index"
+	serialSKUIndex   = "serial_sku_status_index"
+	serialOrderIndex = "serial_order_index"
+)
+
+// MongoSerialRepository implements the domain.SerialRepository interface using MongoDB
+type MongoSerialRepository struct {
+	collection *mongo.Collection
+	logger     *slog.Logger
+	timeout    time.Duration
+}
+
+// serialDoc represents a serial number document in MongoDB
+type serialDoc struct {
+	Serial    string           `bson:"serial"`
+	SKU       string           `bson:"sku"`
+	Status    int              `bson:"status"`
+	OrderID   string           `bson:"order_id"`
+	History   []serialEventDoc `bson:"history"`
+	CreatedAt time.Time        `bson:"created_at"`
+	UpdatedAt time.Time        `bson:"updated_at"`
+}
+
+// serialEventDoc represents a serial history entry in MongoDB
+type serialEventDoc struct {
+	Status     int       `bson:"status"`
+	OrderID    string    `bson:"order_id,omitempty"`
+	Note       string    `bson:"note,omitempty"`
+	OccurredAt time.Time `bson:"occurred_at"`
+}
+
+// NewMongoSerialRepository creates a serial repository sharing the inventory repository's database
+func NewMongoSerialRepository(inventoryRepo *MongoInventoryRepository, logger *slog.Logger) *MongoSerialRepository {
+	repo := &MongoSerialRepository{
+		collection: inventoryRepo.database.Collection(serialCollection),
+		logger:     logger,
+		timeout:    inventoryRepo.timeout,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), repo.timeout)
+	defer cancel()
+
+	_, err := repo.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "serial", Value: 1}},
+			Options: options.Index().SetName(serialIndex).SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "sku", Value: 1},
+				{Key: "status", Value: 1},
+				{Key: "created_at", Value: 1},
+			},
+			Options: options.Index().SetName(serialSKUIndex),
+		},
+		{
+			Keys:    bson.D{{Key: "order_id", Value: 1}},
+			Options: options.Index().SetName(serialOrderIndex),
+		},
+	})
+	if err != nil {
+		logger.Warn("Failed to create serial indexes", "error", err)
+		// Don't fail - indexes can be created later
+	}
+
+	return repo
+}
+
+// Create registers new serial numbers
+func (r *MongoSerialRepository) Create(serials []*domain.SerialNumber) error {
+	if len(serials) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	docs := make([]interface{}, 0, len(serials))
+	for _, serial := range serials {
+		docs = append(docs, serialToDocument(serial))
+	}
+
+	if _, err := r.collection.InsertMany(ctx, docs); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrSerialAlreadyExists
+		}
+		r.logger.Error("Failed to register serial numbers", "error", err, "count", len(serials))
+		return fmt.Errorf("failed to register serial numbers: %w", err)
+	}
+
+	return nil
+}
+
+// Save persists changes to a serial number
+func (r *MongoSerialRepository) Save(serial *domain.SerialNumber) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{"serial": serial.Serial()}
+	update := bson.M{"$set": serialToDocument(serial)}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to save serial number", "error", err, "serial", serial.Serial())
+		return fmt.Errorf("failed to save serial number: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrSerialNotFound
+	}
+
+	return nil
+}
+
+// SaveAllocation persists a newly allocated serial only if it is still in the pool,
+// so that concurrent confirmations cannot allocate the same unit twice
+func (r *MongoSerialRepository) SaveAllocation(serial *domain.SerialNumber) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := allocatableFilter(serial.SKU())
+	filter["serial"] = serial.Serial()
+	update := bson.M{"$set": serialToDocument(serial)}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to save serial allocation", "error", err, "serial", serial.Serial())
+		return fmt.Errorf("failed to save serial allocation: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrSerialNotAvailable
+	}
+
+	return nil
+}
+
+// FindBySerial retrieves a serial number
+func (r *MongoSerialRepository) FindBySerial(serial string) (*domain.SerialNumber, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var doc serialDoc
+	err := r.collection.FindOne(ctx, bson.M{"serial": serial}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Serial not found
+		}
+		r.logger.Error("Failed to find serial number", "error", err, "serial", serial)
+		return nil, fmt.Errorf("failed to find serial number: %w", err)
+	}
+
+	return documentToSerial(&doc)
+}
+
+// FindAllocatable retrieves serials of an item that can be allocated, oldest first
+func (r *MongoSerialRepository) FindAllocatable(sku string, limit int) ([]*domain.SerialNumber, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	return r.find(allocatableFilter(sku), opts)
+}
+
+// FindByOrder retrieves the serials allocated to an order
+func (r *MongoSerialRepository) FindByOrder(orderID string) ([]*domain.SerialNumber, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "sku", Value: 1}, {Key: "serial", Value: 1}})
+	return r.find(bson.M{"order_id": orderID}, opts)
+}
+
+// CountAllocatable returns the number of serials of an item that can be allocated
+func (r *MongoSerialRepository) CountAllocatable(sku string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, allocatableFilter(sku))
+	if err != nil {
+		r.logger.Error("Failed to count serial numbers", "error", err, "sku", sku)
+		return 0, fmt.Errorf("failed to count serial numbers: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// find runs a query and converts the resulting documents
+func (r *MongoSerialRepository) find(filter bson.M, opts *options.FindOptions) ([]*domain.SerialNumber, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to find serial numbers", "error", err)
+		return nil, fmt.Errorf("failed to find serial numbers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var serials []*domain.SerialNumber
+	for cursor.Next(ctx) {
+		var doc serialDoc
+		if err := cursor.Decode(&doc); err != nil {
+			r.logger.Warn("Failed to decode serial number", "error", err)
+			continue
+		}
+
+		serial, err := documentToSerial(&doc)
+		if err != nil {
+			r.logger.Warn("Failed to convert serial document to domain", "error", err)
+			continue
+		}
+
+		serials = append(serials, serial)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return serials, nil
+}
+
+// allocatableFilter matches serials of an item that are in the pool
+func allocatableFilter(sku string) bson.M {
+	return bson.M{
+		"sku": sku,
+		"status": bson.M{"$in": []int{
+			int(domain.SerialStatusAvailable),
+			int(domain.SerialStatusReturned),
+		}},
+	}
+}
+
+// serialToDocument converts a domain SerialNumber to a MongoDB document
+func serialToDocument(serial *domain.SerialNumber) *serialDoc {
+	history := make([]serialEventDoc, 0, len(serial.History()))
+	for _, event := range serial.History() {
+		history = append(history, serialEventDoc{
+			Status:     int(event.Status),
+			OrderID:    event.OrderID,
+			Note:       event.Note,
+			OccurredAt: event.OccurredAt,
+		})
+	}
+
+	return &serialDoc{
+		Serial:    serial.Serial(),
+		SKU:       serial.SKU(),
+		Status:    int(serial.Status()),
+		OrderID:   serial.OrderID(),
+		History:   history,
+		CreatedAt: serial.CreatedAt(),
+		UpdatedAt: serial.UpdatedAt(),
+	}
+}
+
+// documentToSerial converts a MongoDB document to a domain SerialNumber
+func documentToSerial(doc *serialDoc) (*domain.SerialNumber, error) {
+	history := make([]domain.SerialEvent, 0, len(doc.History))
+	for _, event := range doc.History {
+		history = append(history, domain.SerialEvent{
+			Status:     domain.SerialStatus(event.Status),
+			OrderID:    event.OrderID,
+			Note:       event.Note,
+			OccurredAt: event.OccurredAt,
+		})
+	}
+
+	serial, err := domain.ReconstructSerialNumber(
+		doc.Serial,
+		doc.SKU,
+		domain.SerialStatus(doc.Status),
+		doc.OrderID,
+		history,
+		doc.CreatedAt,
+		doc.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct serial number: %w", err)
+	}
+
+	return serial, nil
+}
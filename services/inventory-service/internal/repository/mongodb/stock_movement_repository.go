@@ -0,0 +1,202 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+const (
+	// stockMovementCollection holds the stock movement ledger
+	stockMovementCollection = "inventory_stock_movements"
+
+	stockMovementOrderIndex = "stock_movement_order_index"
+	stockMovementTypeIndex  = "stock_movement_type_index"
+)
+
+// MongoStockMovementRepository implements the domain.StockMovementRepository interface using MongoDB
+type MongoStockMovementRepository struct {
+	collection *mongo.Collection
+	logger     *slog.Logger
+	timeout    time.Duration
+}
+
+// stockMovementDoc represents a stock movement document in MongoDB; the _id is
+// the movement ID, which makes recording idempotent
+type stockMovementDoc struct {
+	ID            string    `bson:"_id"`
+	Type          string    `bson:"type"`
+	SKU           string    `bson:"sku"`
+	OrderID       string    `bson:"order_id"`
+	Quantity      float64   `bson:"quantity"`
+	Unit          string    `bson:"unit"`
+	SerialNumbers []string  `bson:"serial_numbers,omitempty"`
+	Reference     string    `bson:"reference,omitempty"`
+	SourceEventID string    `bson:"source_event_id"`
+	Cost          *costDoc  `bson:"cost,omitempty"`
+	OccurredAt    time.Time `bson:"occurred_at"`
+	RecordedAt    time.Time `bson:"recorded_at"`
+}
+
+// costDoc represents the cost of the goods of a movement in MongoDB
+type costDoc struct {
+	Quantity float64  `bson:"quantity"`
+	FIFO     moneyDoc `bson:"fifo"`
+	Average  moneyDoc `bson:"average"`
+	Uncosted float64  `bson:"uncosted,omitempty"`
+}
+
+// NewMongoStockMovementRepository creates a stock movement repository sharing the inventory repository's database
+func NewMongoStockMovementRepository(inventoryRepo *MongoInventoryRepository, logger *slog.Logger) *MongoStockMovementRepository {
+	repo := &MongoStockMovementRepository{
+		collection: inventoryRepo.database.Collection(stockMovementCollection),
+		logger:     logger,
+		timeout:    inventoryRepo.timeout,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), repo.timeout)
+	defer cancel()
+
+	_, err := repo.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "order_id", Value: 1},
+				{Key: "occurred_at", Value: 1},
+			},
+			Options: options.Index().SetName(stockMovementOrderIndex),
+		},
+		{
+			Keys: bson.D{
+				{Key: "type", Value: 1},
+				{Key: "occurred_at", Value: 1},
+			},
+			Options: options.Index().SetName(stockMovementTypeIndex),
+		},
+	})
+	if err != nil {
+		logger.Warn("Failed to create stock movement indexes", "error", err)
+		// Don't fail - indexes can be created later
+	}
+
+	return repo
+}
+
+// Record appends a movement to the ledger
+func (r *MongoStockMovementRepository) Record(movement *domain.StockMovement) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	doc := &stockMovementDoc{
+		ID:            movement.ID,
+		Type:          string(movement.Type),
+		SKU:           movement.SKU,
+		OrderID:       movement.OrderID,
+		Quantity:      movement.Quantity,
+		Unit:          string(movement.Unit),
+		SerialNumbers: movement.SerialNumbers,
+		Reference:     movement.Reference,
+		SourceEventID: movement.SourceEventID,
+		OccurredAt:    movement.OccurredAt,
+		RecordedAt:    movement.RecordedAt,
+	}
+	if cost := movement.Cost; cost != nil {
+		doc.Cost = &costDoc{
+			Quantity: cost.Quantity,
+			FIFO:     newMoneyDoc(cost.FIFO),
+			Average:  newMoneyDoc(cost.Average),
+			Uncosted: cost.Uncosted,
+		}
+	}
+
+	if _, err := r.collection.InsertOne(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrStockMovementAlreadyExists
+		}
+		r.logger.Error("Failed to record stock movement", "error", err,
+			"type", movement.Type,
+			"sku", movement.SKU,
+			"orderID", movement.OrderID)
+		return fmt.Errorf("failed to record stock movement: %w", err)
+	}
+
+	return nil
+}
+
+// FindByOrder retrieves the movements of an order, oldest first
+func (r *MongoStockMovementRepository) FindByOrder(orderID string) ([]*domain.StockMovement, error) {
+	movements, err := r.find(bson.M{"order_id": orderID})
+	if err != nil {
+		r.logger.Error("Failed to find stock movements", "error", err, "orderID", orderID)
+	}
+	return movements, err
+}
+
+// FindByType retrieves the movements of a type that occurred in [from, to), oldest first
+func (r *MongoStockMovementRepository) FindByType(movementType domain.MovementType, from, to time.Time) ([]*domain.StockMovement, error) {
+	movements, err := r.find(bson.M{
+		"type":        string(movementType),
+		"occurred_at": bson.M{"$gte": from, "$lt": to},
+	})
+	if err != nil {
+		r.logger.Error("Failed to find stock movements", "error", err, "type", movementType)
+	}
+	return movements, err
+}
+
+// find retrieves the movements matching the filter, oldest first
+func (r *MongoStockMovementRepository) find(filter bson.M) ([]*domain.StockMovement, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stock movements: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var movements []*domain.StockMovement
+	for cursor.Next(ctx) {
+		var doc stockMovementDoc
+		if err := cursor.Decode(&doc); err != nil {
+			r.logger.Warn("Failed to decode stock movement", "error", err)
+			continue
+		}
+
+		movement := &domain.StockMovement{
+			ID:            doc.ID,
+			Type:          domain.MovementType(doc.Type),
+			SKU:           doc.SKU,
+			OrderID:       doc.OrderID,
+			Quantity:      doc.Quantity,
+			Unit:          domain.UnitOfMeasure(doc.Unit),
+			SerialNumbers: doc.SerialNumbers,
+			Reference:     doc.Reference,
+			SourceEventID: doc.SourceEventID,
+			OccurredAt:    doc.OccurredAt,
+			RecordedAt:    doc.RecordedAt,
+		}
+		if doc.Cost != nil {
+			movement.Cost = &domain.CostOfGoods{
+				Quantity: doc.Cost.Quantity,
+				FIFO:     doc.Cost.FIFO.toDomain(),
+				Average:  doc.Cost.Average.toDomain(),
+				Uncosted: doc.Cost.Uncosted,
+			}
+		}
+		movements = append(movements, movement)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return movements, nil
+}
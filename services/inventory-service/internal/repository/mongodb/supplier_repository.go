@@ -0,0 +1,196 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+const (
+	// itemSupplierCollection holds the suppliers items can be reordered from
+	itemSupplierCollection = "inventory_item_suppliers"
+
+	itemSupplierIndex = "item_supplier_sku_supplier_index"
+)
+
+// MongoItemSupplierRepository implements the domain.ItemSupplierRepository interface using MongoDB
+type MongoItemSupplierRepository struct {
+	collection *mongo.Collection
+	logger     *slog.Logger
+	timeout    time.Duration
+}
+
+// itemSupplierDoc represents an item supplier document in MongoDB
+type itemSupplierDoc struct {
+	SKU              string    `bson:"sku"`
+	Supplier         string    `bson:"supplier"`
+	SupplierSKU      string    `bson:"supplier_sku,omitempty"`
+	LeadTimeDays     int       `bson:"lead_time_days"`
+	MinOrderQuantity float64   `bson:"min_order_quantity"`
+	UnitCost         *moneyDoc `bson:"unit_cost,omitempty"`
+	Preferred        bool      `bson:"preferred"`
+	CreatedAt        time.Time `bson:"created_at"`
+	UpdatedAt        time.Time `bson:"updated_at"`
+	Version          int       `bson:"version"`
+}
+
+// NewMongoItemSupplierRepository creates an item supplier repository sharing the inventory repository's database
+func NewMongoItemSupplierRepository(inventoryRepo *MongoInventoryRepository, logger *slog.Logger) *MongoItemSupplierRepository {
+	repo := &MongoItemSupplierRepository{
+		collection: inventoryRepo.database.Collection(itemSupplierCollection),
+		logger:     logger,
+		timeout:    inventoryRepo.timeout,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), repo.timeout)
+	defer cancel()
+
+	_, err := repo.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "sku", Value: 1}, {Key: "supplier", Value: 1}},
+		Options: options.Index().SetName(itemSupplierIndex).SetUnique(true),
+	})
+	if err != nil {
+		logger.Warn("Failed to create item supplier indexes", "error", err)
+		// Don't fail - indexes can be created later
+	}
+
+	return repo
+}
+
+// Save persists an item supplier to MongoDB
+func (r *MongoItemSupplierRepository) Save(supplier *domain.ItemSupplier) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{"sku": supplier.SKU(), "supplier": supplier.Supplier()}
+	update := bson.M{"$set": itemSupplierToDocument(supplier)}
+	opts := options.Update().SetUpsert(true)
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update, opts); err != nil {
+		r.logger.Error("Failed to save item supplier", "error", err, "sku", supplier.SKU(), "supplier", supplier.Supplier())
+		return fmt.Errorf("failed to save item supplier: %w", err)
+	}
+
+	r.logger.Debug("Item supplier saved", "sku", supplier.SKU(), "supplier", supplier.Supplier(), "version", supplier.Version())
+	return nil
+}
+
+// Find retrieves the item's record of a supplier
+func (r *MongoItemSupplierRepository) Find(sku, supplier string) (*domain.ItemSupplier, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var doc itemSupplierDoc
+	err := r.collection.FindOne(ctx, bson.M{"sku": sku, "supplier": supplier}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Item has no record of the supplier
+		}
+		r.logger.Error("Failed to find item supplier", "error", err, "sku", sku, "supplier", supplier)
+		return nil, fmt.Errorf("failed to find item supplier: %w", err)
+	}
+
+	return documentToItemSupplier(&doc)
+}
+
+// FindBySKU retrieves the suppliers of an item ordered by supplier
+func (r *MongoItemSupplierRepository) FindBySKU(sku string) ([]*domain.ItemSupplier, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"sku": sku}, options.Find().SetSort(bson.D{{Key: "supplier", Value: 1}}))
+	if err != nil {
+		r.logger.Error("Failed to list item suppliers", "error", err, "sku", sku)
+		return nil, fmt.Errorf("failed to list item suppliers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var suppliers []*domain.ItemSupplier
+	for cursor.Next(ctx) {
+		var doc itemSupplierDoc
+		if err := cursor.Decode(&doc); err != nil {
+			r.logger.Warn("Failed to decode item supplier", "error", err)
+			continue
+		}
+
+		supplier, err := documentToItemSupplier(&doc)
+		if err != nil {
+			r.logger.Warn("Failed to convert item supplier document to domain", "error", err)
+			continue
+		}
+
+		suppliers = append(suppliers, supplier)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return suppliers, nil
+}
+
+// Delete removes the item's record of a supplier
+func (r *MongoItemSupplierRepository) Delete(sku, supplier string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"sku": sku, "supplier": supplier})
+	if err != nil {
+		r.logger.Error("Failed to delete item supplier", "error", err, "sku", sku, "supplier", supplier)
+		return fmt.Errorf("failed to delete item supplier: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrItemSupplierNotFound
+	}
+
+	r.logger.Info("Item supplier deleted", "sku", sku, "supplier", supplier)
+	return nil
+}
+
+// itemSupplierToDocument converts a domain ItemSupplier to a MongoDB document
+func itemSupplierToDocument(supplier *domain.ItemSupplier) *itemSupplierDoc {
+	settings := supplier.Settings()
+	return &itemSupplierDoc{
+		SKU:              supplier.SKU(),
+		Supplier:         supplier.Supplier(),
+		SupplierSKU:      settings.SupplierSKU,
+		LeadTimeDays:     int(settings.LeadTime / (24 * time.Hour)),
+		MinOrderQuantity: settings.MinOrderQuantity,
+		UnitCost:         newOptionalMoneyDoc(settings.UnitCost),
+		Preferred:        settings.Preferred,
+		CreatedAt:        supplier.CreatedAt(),
+		UpdatedAt:        supplier.UpdatedAt(),
+		Version:          supplier.Version(),
+	}
+}
+
+// documentToItemSupplier converts a MongoDB document to a domain ItemSupplier
+func documentToItemSupplier(doc *itemSupplierDoc) (*domain.ItemSupplier, error) {
+	supplier, err := domain.ReconstructItemSupplier(
+		doc.SKU,
+		doc.Supplier,
+		domain.ItemSupplierSettings{
+			SupplierSKU:      doc.SupplierSKU,
+			LeadTime:         time.Duration(doc.LeadTimeDays) * 24 * time.Hour,
+			MinOrderQuantity: doc.MinOrderQuantity,
+			UnitCost:         optionalMoney(doc.UnitCost),
+			Preferred:        doc.Preferred,
+		},
+		doc.CreatedAt,
+		doc.UpdatedAt,
+		doc.Version,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct item supplier: %w", err)
+	}
+
+	return supplier, nil
+}
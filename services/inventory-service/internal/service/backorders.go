@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// WithBackorderRepository enables backorders backed by the given repository
+func WithBackorderRepository(backorders domain.BackorderRepository) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.backorders = backorders
+	}
+}
+
+// ErrBackordersNotConfigured is returned by backorder operations when no repository is configured
+var ErrBackordersNotConfigured = errors.New("backorders are not configured")
+
+// BackorderReservedEvent tells the owner of a backorder that its stock arrived and is reserved
+type BackorderReservedEvent struct {
+	BackorderID   string
+	OrderID       string
+	ShipmentID    string // Empty when the whole order was backordered
+	ReservationID string
+	ExpiresAt     time.Time
+	ReservedAt    time.Time
+}
+
+// Backorder DTOs
+
+type CreateBackorderRequest struct {
+	OrderID                    string
+	ShipmentID                 string // Set when only one shipment of the order is backordered
+	Items                      []ItemReservationRequest
+	ReservationDurationMinutes int
+	Priority                   domain.ReservationPriority
+}
+
+type BackorderDTO struct {
+	ID            string             `json:"id"`
+	OrderID       string             `json:"order_id"`
+	ShipmentID    string             `json:"shipment_id,omitempty"`
+	Status        string             `json:"status"`
+	Items         []BackorderItemDTO `json:"items"`
+	Priority      string             `json:"priority"`
+	ReservationID string             `json:"reservation_id,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	ReservedAt    *time.Time         `json:"reserved_at,omitempty"`
+	Version       int                `json:"version"`
+}
+
+type BackorderItemDTO struct {
+	SKU      string  `json:"sku"`
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit,omitempty"`
+}
+
+// CreateBackorder records an order's demand for out-of-stock items. The items are
+// reserved for the order as soon as enough stock arrives.
+func (s *inventoryService) CreateBackorder(ctx context.Context, req CreateBackorderRequest) (*BackorderDTO, error) {
+	if s.backorders == nil {
+		return nil, ErrBackordersNotConfigured
+	}
+
+	s.logger.Info("Creating backorder",
+		"orderID", req.OrderID,
+		"shipmentID", req.ShipmentID,
+		"itemCount", len(req.Items))
+
+	// Only stocked items can be backordered; kits wait on their components' stock,
+	// which would never wake them up
+	items := make([]domain.BackorderItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		inventoryItem, err := s.repository.FindBySKU(item.SKU)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find item: %w", err)
+		}
+		if inventoryItem == nil {
+			return nil, fmt.Errorf("%w: %s", domain.ErrItemNotFound, item.SKU)
+		}
+		items = append(items, domain.BackorderItem{
+			SKU:      item.SKU,
+			Quantity: item.Quantity,
+			Unit:     item.Unit,
+		})
+	}
+
+	backorder, err := domain.NewBackorder(req.OrderID, req.ShipmentID, items, req.ReservationDurationMinutes, req.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retried requests find the backorder they created
+	existing, err := s.backorders.FindOpenByReservationKey(backorder.ReservationKey())
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		dto := convertBackorderToDTO(existing)
+		return &dto, nil
+	}
+
+	if err := s.backorders.Create(backorder); err != nil {
+		return nil, err
+	}
+
+	// Stock may have arrived since the order checked availability
+	s.fulfillBackorder(ctx, backorder)
+
+	dto := convertBackorderToDTO(backorder)
+	return &dto, nil
+}
+
+// CancelBackorder gives up the open backorder of an order or of one of its
+// shipments, releasing its stock if it was already reserved
+func (s *inventoryService) CancelBackorder(ctx context.Context, orderID, shipmentID string) (*BackorderDTO, error) {
+	if s.backorders == nil {
+		return nil, ErrBackordersNotConfigured
+	}
+
+	key := orderID
+	if shipmentID != "" {
+		key = shipmentID
+	}
+	backorder, err := s.backorders.FindOpenByReservationKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if backorder == nil {
+		return nil, domain.ErrBackorderNotFound
+	}
+
+	reserved := backorder.Status() == domain.BackorderStatusReserved
+	loadedVersion := backorder.Version()
+	if err := backorder.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := s.backorders.Update(backorder, loadedVersion); err != nil {
+		return nil, err
+	}
+
+	if reserved {
+		if _, err := s.ReleaseReservation(ctx, ReleaseReservationRequest{
+			OrderID:       backorder.ReservationKey(),
+			ReservationID: backorder.ReservationID(),
+			Reason:        "backorder cancelled",
+		}); err != nil {
+			s.logger.Error("Failed to release cancelled backorder reservation",
+				"backorderID", backorder.ID(),
+				"reservationID", backorder.ReservationID(),
+				"error", err)
+		}
+	}
+
+	s.logger.Info("Backorder cancelled",
+		"backorderID", backorder.ID(),
+		"orderID", backorder.OrderID(),
+		"shipmentID", backorder.ShipmentID(),
+		"released", reserved)
+
+	dto := convertBackorderToDTO(backorder)
+	return &dto, nil
+}
+
+// ListBackorders retrieves backorders, optionally filtered by status
+func (s *inventoryService) ListBackorders(ctx context.Context, status *domain.BackorderStatus) ([]BackorderDTO, error) {
+	if s.backorders == nil {
+		return nil, ErrBackordersNotConfigured
+	}
+
+	backorders, err := s.backorders.FindAll(status)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]BackorderDTO, 0, len(backorders))
+	for _, backorder := range backorders {
+		dtos = append(dtos, convertBackorderToDTO(backorder))
+	}
+	return dtos, nil
+}
+
+// fulfillBackorders reserves stock for the backorders waiting for the given items,
+// oldest first, after stock of them was added. A backorder is only reserved once
+// all of its items can be, so a large one may wait while younger ones are served.
+func (s *inventoryService) fulfillBackorders(ctx context.Context, skus ...string) {
+	if s.backorders == nil {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, sku := range skus {
+		waiting, err := s.backorders.FindWaitingBySKU(sku)
+		if err != nil {
+			s.logger.Error("Failed to find waiting backorders", "sku", sku, "error", err)
+			continue
+		}
+		for _, backorder := range waiting {
+			if seen[backorder.ID()] {
+				continue
+			}
+			seen[backorder.ID()] = true
+			s.fulfillBackorder(ctx, backorder)
+		}
+	}
+}
+
+// fulfillBackorder reserves a waiting backorder's items if they are all in stock
+// and tells its order. It reports whether the backorder was reserved.
+func (s *inventoryService) fulfillBackorder(ctx context.Context, backorder *domain.Backorder) bool {
+	if !s.backorderInStock(backorder) {
+		return false
+	}
+
+	items := make([]ItemReservationRequest, 0, len(backorder.Items()))
+	for _, item := range backorder.Items() {
+		items = append(items, ItemReservationRequest{
+			SKU:      item.SKU,
+			Quantity: item.Quantity,
+			Unit:     item.Unit,
+		})
+	}
+
+	result, err := s.ReserveItems(ctx, ReserveItemsRequest{
+		OrderID:                    backorder.ReservationKey(),
+		Items:                      items,
+		ReservationDurationMinutes: backorder.ReservationDurationMinutes(),
+		Priority:                   backorder.Priority(),
+	})
+	if err != nil || !result.Success {
+		// Partial reservations were released; the backorder keeps waiting
+		s.logger.Debug("Backorder not yet reservable",
+			"backorderID", backorder.ID(),
+			"error", err)
+		return false
+	}
+
+	loadedVersion := backorder.Version()
+	if err := backorder.MarkReserved(result.ReservationID); err == nil {
+		err = s.backorders.Update(backorder, loadedVersion)
+	}
+	if err != nil {
+		// Without the record the order would never hear of its stock
+		s.logger.Error("Failed to record backorder reservation, releasing it",
+			"backorderID", backorder.ID(),
+			"reservationID", result.ReservationID,
+			"error", err)
+		if _, releaseErr := s.ReleaseReservation(ctx, ReleaseReservationRequest{
+			OrderID:       backorder.ReservationKey(),
+			ReservationID: result.ReservationID,
+			Reason:        "backorder reservation not recorded",
+		}); releaseErr != nil {
+			s.logger.Error("Failed to release backorder reservation",
+				"backorderID", backorder.ID(),
+				"error", releaseErr)
+		}
+		return false
+	}
+
+	s.logger.Info("Backorder reserved",
+		"backorderID", backorder.ID(),
+		"orderID", backorder.OrderID(),
+		"shipmentID", backorder.ShipmentID(),
+		"reservationID", result.ReservationID)
+
+	if s.reservationEvents != nil {
+		event := BackorderReservedEvent{
+			BackorderID:   backorder.ID(),
+			OrderID:       backorder.OrderID(),
+			ShipmentID:    backorder.ShipmentID(),
+			ReservationID: result.ReservationID,
+			ExpiresAt:     result.ExpiresAt,
+			ReservedAt:    *backorder.ReservedAt(),
+		}
+		if err := s.reservationEvents.PublishBackorderReserved(ctx, event); err != nil {
+			s.logger.Error("Failed to publish backorder reserved event",
+				"backorderID", backorder.ID(),
+				"orderID", backorder.OrderID(),
+				"error", err)
+		}
+	}
+
+	return true
+}
+
+// backorderInStock checks the availability of a backorder's items, sparing a
+// reservation attempt that would fail
+func (s *inventoryService) backorderInStock(backorder *domain.Backorder) bool {
+	checks := make([]ItemAvailabilityCheck, 0, len(backorder.Items()))
+	for _, item := range backorder.Items() {
+		checks = append(checks, ItemAvailabilityCheck{
+			SKU:      item.SKU,
+			Quantity: item.Quantity,
+			Unit:     item.Unit,
+		})
+	}
+
+	result, err := s.CheckAvailability(context.Background(), CheckAvailabilityRequest{Items: checks})
+	return err == nil && result.AllAvailable
+}
+
+func convertBackorderToDTO(backorder *domain.Backorder) BackorderDTO {
+	items := make([]BackorderItemDTO, 0, len(backorder.Items()))
+	for _, item := range backorder.Items() {
+		items = append(items, BackorderItemDTO{
+			SKU:      item.SKU,
+			Quantity: item.Quantity,
+			Unit:     string(item.Unit),
+		})
+	}
+
+	return BackorderDTO{
+		ID:            backorder.ID(),
+		OrderID:       backorder.OrderID(),
+		ShipmentID:    backorder.ShipmentID(),
+		Status:        backorder.Status().String(),
+		Items:         items,
+		Priority:      backorder.Priority().String(),
+		ReservationID: backorder.ReservationID(),
+		CreatedAt:     backorder.CreatedAt(),
+		UpdatedAt:     backorder.UpdatedAt(),
+		ReservedAt:    backorder.ReservedAt(),
+		Version:       backorder.Version(),
+	}
+}
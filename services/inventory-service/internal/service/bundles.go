@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// InventoryServiceOption configures optional inventory service dependencies
+type InventoryServiceOption func(*inventoryService)
+
+// WithBundleRepository enables kit/bundle products backed by the given repository
+func WithBundleRepository(bundles domain.BundleRepository) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.bundles = bundles
+	}
+}
+
+// ErrBundlesNotConfigured is returned by bundle operations when no bundle repository is configured
+var ErrBundlesNotConfigured = errors.New("bundles are not configured")
+
+// Bundle DTOs
+
+type SaveBundleRequest struct {
+	SKU         string
+	Name        string
+	Description string
+	UnitPrice   domain.Money
+	Components  []domain.BundleComponent
+}
+
+type BundleDTO struct {
+	SKU               string               `json:"sku"`
+	Name              string               `json:"name"`
+	Description       string               `json:"description"`
+	UnitPrice         float64              `json:"unit_price"`
+	UnitPriceMinor    int64                `json:"unit_price_minor"`
+	Currency          string               `json:"currency"`
+	Components        []BundleComponentDTO `json:"components"`
+	AvailableQuantity int                  `json:"available_quantity"`
+	LimitingSKU       string               `json:"limiting_sku,omitempty"`
+	CreatedAt         time.Time            `json:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+	Version           int                  `json:"version"`
+}
+
+type BundleComponentDTO struct {
+	SKU               string  `json:"sku"`
+	Name              string  `json:"name,omitempty"`
+	Quantity          int     `json:"quantity"`
+	AvailableQuantity float64 `json:"available_quantity"`
+	Unit              string  `json:"unit,omitempty"` // Unit of both quantities, the component item's unit
+}
+
+// SaveBundle creates or replaces a bundle definition
+func (s *inventoryService) SaveBundle(ctx context.Context, req SaveBundleRequest) (*BundleDTO, error) {
+	if s.bundles == nil {
+		return nil, ErrBundlesNotConfigured
+	}
+
+	s.logger.Info("Saving bundle", "sku", req.SKU, "components", len(req.Components))
+
+	// A bundle SKU must not shadow a stocked item, and every component must be a stocked item
+	existingItem, err := s.repository.FindBySKU(req.SKU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bundle SKU: %w", err)
+	}
+	if existingItem != nil {
+		return nil, domain.ErrBundleSKUConflict
+	}
+
+	components, err := s.loadBundleComponents(req.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := s.bundles.FindBySKU(req.SKU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bundle: %w", err)
+	}
+
+	if bundle == nil {
+		bundle, err = domain.NewBundle(req.SKU, req.Name, req.Description, req.UnitPrice, req.Components)
+	} else {
+		err = bundle.Update(req.Name, req.Description, req.UnitPrice, req.Components)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.bundles.Save(bundle); err != nil {
+		return nil, fmt.Errorf("failed to save bundle: %w", err)
+	}
+
+	dto := s.convertBundleToDTO(bundle, components)
+	return &dto, nil
+}
+
+// GetBundle retrieves a bundle definition with availability computed from its components
+func (s *inventoryService) GetBundle(ctx context.Context, sku string) (*BundleDTO, error) {
+	if s.bundles == nil {
+		return nil, ErrBundlesNotConfigured
+	}
+
+	bundle, err := s.bundles.FindBySKU(sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bundle: %w", err)
+	}
+	if bundle == nil {
+		return nil, domain.ErrBundleNotFound
+	}
+
+	components, err := s.findBundleComponentItems(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := s.convertBundleToDTO(bundle, components)
+	return &dto, nil
+}
+
+// ListBundles retrieves all bundle definitions with their availability
+func (s *inventoryService) ListBundles(ctx context.Context) ([]BundleDTO, error) {
+	if s.bundles == nil {
+		return nil, ErrBundlesNotConfigured
+	}
+
+	bundles, err := s.bundles.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bundles: %w", err)
+	}
+
+	dtos := make([]BundleDTO, 0, len(bundles))
+	for _, bundle := range bundles {
+		components, err := s.findBundleComponentItems(bundle)
+		if err != nil {
+			return nil, err
+		}
+		dtos = append(dtos, s.convertBundleToDTO(bundle, components))
+	}
+
+	return dtos, nil
+}
+
+// DeleteBundle removes a bundle definition; existing component reservations are unaffected
+func (s *inventoryService) DeleteBundle(ctx context.Context, sku string) error {
+	if s.bundles == nil {
+		return ErrBundlesNotConfigured
+	}
+
+	s.logger.Info("Deleting bundle", "sku", sku)
+	return s.bundles.Delete(sku)
+}
+
+// findBundle looks up a bundle definition, returning nil when bundles are disabled or the SKU is not a bundle
+func (s *inventoryService) findBundle(sku string) (*domain.Bundle, error) {
+	if s.bundles == nil {
+		return nil, nil
+	}
+	return s.bundles.FindBySKU(sku)
+}
+
+// findBundleComponentItems loads the inventory items of a bundle's components, keyed by SKU.
+// Components that no longer exist are simply absent from the map.
+func (s *inventoryService) findBundleComponentItems(bundle *domain.Bundle) (map[string]*domain.InventoryItem, error) {
+	items := make(map[string]*domain.InventoryItem, len(bundle.Components()))
+	for _, component := range bundle.Components() {
+		item, err := s.repository.FindBySKU(component.SKU)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find bundle component %s: %w", component.SKU, err)
+		}
+		if item != nil {
+			items[component.SKU] = item
+		}
+	}
+	return items, nil
+}
+
+// loadBundleComponents loads the component items of a new bundle definition, requiring all of them to exist
+func (s *inventoryService) loadBundleComponents(components []domain.BundleComponent) (map[string]*domain.InventoryItem, error) {
+	items := make(map[string]*domain.InventoryItem, len(components))
+	for _, component := range components {
+		item, err := s.repository.FindBySKU(component.SKU)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find bundle component %s: %w", component.SKU, err)
+		}
+		if item == nil {
+			return nil, fmt.Errorf("%w: %s", domain.ErrBundleComponentNotFound, component.SKU)
+		}
+		items[component.SKU] = item
+	}
+	return items, nil
+}
+
+// bundleCount returns the number of kits requested by a bundle line; kits are only sold whole
+func bundleCount(quantity float64, unit domain.UnitOfMeasure) (int, error) {
+	if unit == "" {
+		unit = domain.UnitEach
+	}
+	count, err := domain.ConvertQuantity(quantity, unit, domain.UnitEach)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// checkBundleAvailability computes availability for a bundle line from its components
+func (s *inventoryService) checkBundleAvailability(bundle *domain.Bundle, requested float64, unit domain.UnitOfMeasure) ItemAvailabilityResult {
+	result := ItemAvailabilityResult{
+		SKU:               bundle.SKU(),
+		Name:              bundle.Name(),
+		RequestedQuantity: requested,
+		Unit:              domain.UnitEach,
+		UnitPrice:         bundle.UnitPrice(),
+		Bundle:            true,
+	}
+
+	quantity, err := bundleCount(requested, unit)
+	if err != nil {
+		result.Reason = fmt.Sprintf("Invalid quantity: %v", err)
+		return result
+	}
+
+	components, err := s.findBundleComponentItems(bundle)
+	if err != nil {
+		s.logger.Error("Failed to find bundle components", "sku", bundle.SKU(), "error", err)
+		result.Reason = "Failed to retrieve bundle component information"
+		return result
+	}
+
+	available, limitingSKU := bundle.AvailableBundles(components)
+	result.AvailableQuantity = float64(available)
+	result.Available = quantity <= available
+
+	if !result.Available {
+		if _, exists := components[limitingSKU]; !exists {
+			result.Reason = fmt.Sprintf("Bundle component %s not found", limitingSKU)
+		} else {
+			result.Reason = fmt.Sprintf("Insufficient stock of component %s (available kits: %d, requested: %d)",
+				limitingSKU, available, quantity)
+		}
+	}
+
+	return result
+}
+
+// bundleReservationLine records a requested bundle line expanded into component reservations
+type bundleReservationLine struct {
+	request ItemReservationRequest
+	bundle  *domain.Bundle
+}
+
+// expandBundleReservations replaces bundle lines with their component lines.
+// Quantities of the same SKU are merged, since an order holds a single reservation per item;
+// lines of one SKU in different units are merged in the item's own unit.
+func (s *inventoryService) expandBundleReservations(items []ItemReservationRequest) ([]ItemReservationRequest, []bundleReservationLine, error) {
+	expanded := make([]ItemReservationRequest, 0, len(items))
+	positions := make(map[string]int)
+	bundleLines := make([]bundleReservationLine, 0)
+
+	add := func(line ItemReservationRequest) error {
+		i, exists := positions[line.SKU]
+		if !exists {
+			positions[line.SKU] = len(expanded)
+			expanded = append(expanded, line)
+			return nil
+		}
+
+		if expanded[i].Unit != line.Unit {
+			merged, err := s.toItemUnit(expanded[i])
+			if err != nil {
+				return err
+			}
+			if line, err = s.toItemUnit(line); err != nil {
+				return err
+			}
+			expanded[i] = merged
+		}
+		expanded[i].Quantity += line.Quantity
+		return nil
+	}
+
+	for _, item := range items {
+		bundle, err := s.findBundle(item.SKU)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find bundle %s: %w", item.SKU, err)
+		}
+
+		if bundle == nil {
+			if err := add(item); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		count, err := bundleCount(item.Quantity, item.Unit)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bundle %s: %w", item.SKU, err)
+		}
+
+		bundleLines = append(bundleLines, bundleReservationLine{request: item, bundle: bundle})
+		for _, component := range bundle.ComponentQuantities(count) {
+			// Component quantities are in the component item's own unit
+			if err := add(ItemReservationRequest{SKU: component.SKU, Quantity: float64(component.Quantity)}); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return expanded, bundleLines, nil
+}
+
+// toItemUnit expresses a reservation line in its item's unit. Lines of unknown items are
+// returned unchanged; reserving them fails later anyway.
+func (s *inventoryService) toItemUnit(line ItemReservationRequest) (ItemReservationRequest, error) {
+	item, err := s.repository.FindBySKU(line.SKU)
+	if err != nil {
+		return line, fmt.Errorf("failed to find item %s: %w", line.SKU, err)
+	}
+	if item == nil {
+		return line, nil
+	}
+
+	quantity, err := item.ToStockUnit(line.Quantity, line.Unit)
+	if err != nil {
+		return line, fmt.Errorf("item %s: %w", line.SKU, err)
+	}
+	return ItemReservationRequest{SKU: line.SKU, Quantity: quantity, Unit: item.Unit()}, nil
+}
+
+// bundleReservationResults summarises each bundle line from its component reservation results
+func (s *inventoryService) bundleReservationResults(lines []bundleReservationLine, componentResults []ItemReservationResult, allReserved bool) []ItemReservationResult {
+	resultsBySKU := make(map[string]ItemReservationResult, len(componentResults))
+	for _, result := range componentResults {
+		resultsBySKU[result.SKU] = result
+	}
+
+	results := make([]ItemReservationResult, 0, len(lines))
+	for _, line := range lines {
+		failures := make([]string, 0)
+		for _, component := range line.bundle.Components() {
+			if result, exists := resultsBySKU[component.SKU]; exists && !result.Reserved {
+				failures = append(failures, fmt.Sprintf("%s: %s", component.SKU, result.Reason))
+			}
+		}
+
+		reason := ""
+		switch {
+		case len(failures) > 0:
+			reason = "Component reservation failed (" + strings.Join(failures, "; ") + ")"
+		case !allReserved:
+			reason = "Reservation rolled back because other items could not be reserved"
+		}
+
+		results = append(results, ItemReservationResult{
+			SKU:      line.bundle.SKU(),
+			Name:     line.bundle.Name(),
+			Reserved: allReserved,
+			Quantity: line.request.Quantity,
+			Unit:     domain.UnitEach,
+			Reason:   reason,
+			Bundle:   true,
+		})
+	}
+
+	return results
+}
+
+// convertBundleToDTO converts a domain Bundle and its component items to a DTO
+func (s *inventoryService) convertBundleToDTO(bundle *domain.Bundle, items map[string]*domain.InventoryItem) BundleDTO {
+	available, limitingSKU := bundle.AvailableBundles(items)
+
+	components := make([]BundleComponentDTO, 0, len(bundle.Components()))
+	for _, component := range bundle.Components() {
+		dto := BundleComponentDTO{
+			SKU:      component.SKU,
+			Quantity: component.Quantity,
+		}
+		if item, exists := items[component.SKU]; exists {
+			dto.Name = item.Name()
+			dto.AvailableQuantity = item.GetAvailableStock()
+			dto.Unit = string(item.Unit())
+		}
+		components = append(components, dto)
+	}
+
+	return BundleDTO{
+		SKU:               bundle.SKU(),
+		Name:              bundle.Name(),
+		Description:       bundle.Description(),
+		UnitPrice:         bundle.UnitPrice().Float64(),
+		UnitPriceMinor:    bundle.UnitPrice().Minor,
+		Currency:          bundle.UnitPrice().Currency,
+		Components:        components,
+		AvailableQuantity: available,
+		LimitingSKU:       limitingSKU,
+		CreatedAt:         bundle.CreatedAt(),
+		UpdatedAt:         bundle.UpdatedAt(),
+		Version:           bundle.Version(),
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// CatalogChangePublisher publishes the full state of items to the catalog change
+// feed, keyed by SKU, so downstream systems can sync the catalog without polling
+type CatalogChangePublisher interface {
+	PublishItemChanged(ctx context.Context, item *domain.InventoryItem) error
+	PublishItemDeleted(ctx context.Context, sku string) error
+}
+
+// WithCatalogChangePublisher publishes every saved or deleted item to the catalog
+// change feed. Creates, stock and price updates and discontinuations all save the
+// item, so each publishes its full state.
+func WithCatalogChangePublisher(publisher CatalogChangePublisher) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.repository = &catalogFeedRepository{InventoryRepository: s.repository, publisher: publisher, logger: s.logger}
+	}
+}
+
+// BackfillCatalogFeed publishes the current state of every item to the catalog
+// change feed, to bootstrap a new consumer or repair the feed after changes were
+// lost. Returns the number of items published.
+func BackfillCatalogFeed(ctx context.Context, repository domain.InventoryRepository, publisher CatalogChangePublisher) (int, error) {
+	items, err := repository.FindAllForRepair()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load items: %w", err)
+	}
+
+	for i, item := range items {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		if err := publisher.PublishItemChanged(ctx, item); err != nil {
+			return i, fmt.Errorf("failed to publish item %s: %w", item.SKU(), err)
+		}
+	}
+	return len(items), nil
+}
+
+// catalogFeedRepository publishes successful writes to the catalog change feed.
+// A write is not failed by a publishing error: the item is saved, and the next
+// change of the item or a backfill brings the feed up to date.
+type catalogFeedRepository struct {
+	domain.InventoryRepository
+	publisher CatalogChangePublisher
+	logger    *slog.Logger
+}
+
+func (r *catalogFeedRepository) Save(item *domain.InventoryItem) error {
+	if err := r.InventoryRepository.Save(item); err != nil {
+		return err
+	}
+	if err := r.publisher.PublishItemChanged(context.Background(), item); err != nil {
+		r.logger.Warn("Failed to publish item to the catalog change feed", "sku", item.SKU(), "error", err)
+	}
+	return nil
+}
+
+func (r *catalogFeedRepository) Delete(id string) error {
+	item, err := r.InventoryRepository.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if err := r.InventoryRepository.Delete(id); err != nil {
+		return err
+	}
+	if item != nil {
+		if err := r.publisher.PublishItemDeleted(context.Background(), item.SKU()); err != nil {
+			r.logger.Warn("Failed to publish item deletion to the catalog change feed", "sku", item.SKU(), "error", err)
+		}
+	}
+	return nil
+}
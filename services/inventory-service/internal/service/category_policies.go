@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// WithCategoryPolicyRepository enables per-category stocking policies backed by the given repository
+func WithCategoryPolicyRepository(policies domain.CategoryPolicyRepository) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.categoryPolicies = policies
+	}
+}
+
+// ErrCategoryPoliciesNotConfigured is returned by category policy operations when no policy repository is configured
+var ErrCategoryPoliciesNotConfigured = errors.New("category policies are not configured")
+
+// Category policy DTOs
+
+type SaveCategoryPolicyRequest struct {
+	Category domain.ItemCategory
+	Settings domain.CategoryPolicySettings
+}
+
+type CategoryPolicyDTO struct {
+	Category              string    `json:"category"`
+	MinStockLevel         float64   `json:"min_stock_level"`
+	MaxStockLevel         float64   `json:"max_stock_level"`
+	ReservationTTLMinutes int       `json:"reservation_ttl_minutes"`
+	RestockQuantity       float64   `json:"restock_quantity"`
+	AllowedCurrencies     []string  `json:"allowed_currencies"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+	Version               int       `json:"version"`
+}
+
+type CreateItemRequest struct {
+	SKU           string
+	Name          string
+	Description   string
+	Category      domain.ItemCategory
+	Unit          domain.UnitOfMeasure // Empty for each
+	UnitPrice     domain.Money
+	MinStockLevel *float64 // Nil for the category policy's level
+	MaxStockLevel *float64 // Nil for the category policy's level
+}
+
+// SaveCategoryPolicy creates or replaces the stocking policy of a category
+func (s *inventoryService) SaveCategoryPolicy(ctx context.Context, req SaveCategoryPolicyRequest) (*CategoryPolicyDTO, error) {
+	if s.categoryPolicies == nil {
+		return nil, ErrCategoryPoliciesNotConfigured
+	}
+
+	existing, err := s.categoryPolicies.FindByCategory(req.Category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category policy: %w", err)
+	}
+
+	policy := existing
+	if policy != nil {
+		err = policy.Update(req.Settings)
+	} else {
+		policy, err = domain.NewCategoryPolicy(req.Category, req.Settings)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.categoryPolicies.Save(policy); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Category policy saved",
+		"category", policy.Category().String(),
+		"version", policy.Version())
+	dto := convertCategoryPolicyToDTO(policy)
+	return &dto, nil
+}
+
+// GetCategoryPolicy retrieves the stocking policy of a category
+func (s *inventoryService) GetCategoryPolicy(ctx context.Context, category domain.ItemCategory) (*CategoryPolicyDTO, error) {
+	if s.categoryPolicies == nil {
+		return nil, ErrCategoryPoliciesNotConfigured
+	}
+
+	policy, err := s.categoryPolicies.FindByCategory(category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category policy: %w", err)
+	}
+	if policy == nil {
+		return nil, domain.ErrCategoryPolicyNotFound
+	}
+
+	dto := convertCategoryPolicyToDTO(policy)
+	return &dto, nil
+}
+
+// ListCategoryPolicies retrieves the stocking policies of all categories that have one
+func (s *inventoryService) ListCategoryPolicies(ctx context.Context) ([]CategoryPolicyDTO, error) {
+	if s.categoryPolicies == nil {
+		return nil, ErrCategoryPoliciesNotConfigured
+	}
+
+	policies, err := s.categoryPolicies.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]CategoryPolicyDTO, 0, len(policies))
+	for _, policy := range policies {
+		dtos = append(dtos, convertCategoryPolicyToDTO(policy))
+	}
+	return dtos, nil
+}
+
+// DeleteCategoryPolicy removes the stocking policy of a category, returning it to the global settings
+func (s *inventoryService) DeleteCategoryPolicy(ctx context.Context, category domain.ItemCategory) error {
+	if s.categoryPolicies == nil {
+		return ErrCategoryPoliciesNotConfigured
+	}
+
+	if err := s.categoryPolicies.Delete(category); err != nil {
+		return err
+	}
+
+	s.logger.Info("Category policy deleted", "category", category.String())
+	return nil
+}
+
+// CreateItem adds an item to the range. Stock levels not given come from the
+// category policy, falling back to the global low stock threshold.
+func (s *inventoryService) CreateItem(ctx context.Context, req CreateItemRequest) (*InventoryItemDTO, error) {
+	if req.Category.String() == "unknown" {
+		return nil, domain.ErrInvalidCategory
+	}
+
+	s.logger.Info("Creating item", "sku", req.SKU, "category", req.Category.String())
+
+	existing, err := s.repository.FindBySKU(req.SKU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check item SKU: %w", err)
+	}
+	if existing != nil {
+		return nil, domain.ErrItemAlreadyExists
+	}
+	if s.bundles != nil {
+		bundle, err := s.bundles.FindBySKU(req.SKU)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check item SKU: %w", err)
+		}
+		if bundle != nil {
+			return nil, domain.ErrBundleSKUConflict
+		}
+	}
+
+	policy := s.categoryPolicy(req.Category)
+	if policy != nil && !policy.AllowsCurrency(req.UnitPrice.Currency) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrCurrencyNotAllowed, req.UnitPrice.Currency)
+	}
+
+	item, err := domain.NewInventoryItem(req.SKU, req.Name, req.Description, req.Category, req.UnitPrice)
+	if err != nil {
+		return nil, err
+	}
+	if req.Unit != "" {
+		if err := item.SetUnitOfMeasure(req.Unit); err != nil {
+			return nil, err
+		}
+	}
+
+	minStockLevel, maxStockLevel := float64(s.config.Inventory.LowStockThreshold), item.MaxStockLevel()
+	if policy != nil {
+		settings := policy.Settings()
+		minStockLevel = settings.MinStockLevel
+		if settings.MaxStockLevel > 0 {
+			maxStockLevel = settings.MaxStockLevel
+		}
+	}
+	if req.MinStockLevel != nil {
+		minStockLevel = *req.MinStockLevel
+	}
+	if req.MaxStockLevel != nil {
+		maxStockLevel = *req.MaxStockLevel
+	}
+	if err := item.SetStockThresholds(minStockLevel, maxStockLevel); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Save(item); err != nil {
+		return nil, fmt.Errorf("failed to save item: %w", err)
+	}
+
+	s.logger.Info("Item created",
+		"sku", item.SKU(),
+		"minStockLevel", item.MinStockLevel(),
+		"maxStockLevel", item.MaxStockLevel())
+	dto := s.convertDomainToDTO(item)
+	return &dto, nil
+}
+
+// categoryPolicy returns the stocking policy of a category, or nil when it has
+// none or policies are not configured. A failed lookup falls back to the
+// global settings rather than failing the operation.
+func (s *inventoryService) categoryPolicy(category domain.ItemCategory) *domain.CategoryPolicy {
+	if s.categoryPolicies == nil {
+		return nil
+	}
+
+	policy, err := s.categoryPolicies.FindByCategory(category)
+	if err != nil {
+		s.logger.Warn("Failed to load category policy, using global settings",
+			"category", category.String(),
+			"error", err)
+		return nil
+	}
+	return policy
+}
+
+// reservationMinutes returns how long to hold a reservation of the item: the
+// requested duration capped by the category's reservation TTL, or by the
+// global maximum when the category sets none. No requested duration means the cap.
+func (s *inventoryService) reservationMinutes(item *domain.InventoryItem, requested int) int {
+	limit := s.config.Inventory.MaxReservationTimeMin
+	if policy := s.categoryPolicy(item.Category()); policy != nil && policy.Settings().ReservationTTL > 0 {
+		limit = int(policy.Settings().ReservationTTL / time.Minute)
+	}
+
+	if requested <= 0 || requested > limit {
+		return limit
+	}
+	return requested
+}
+
+// restockQuantity returns the quantity of the item to reorder, per its category
+// policy or else up to its maximum stock level
+func (s *inventoryService) restockQuantity(item *domain.InventoryItem) float64 {
+	if policy := s.categoryPolicy(item.Category()); policy != nil {
+		return policy.RestockQuantity(item)
+	}
+	return math.Max(0, item.MaxStockLevel()-item.TotalStock())
+}
+
+// checkCurrency rejects prices and costs of the item in a currency its category does not allow
+func (s *inventoryService) checkCurrency(item *domain.InventoryItem, currency string) error {
+	if currency == "" {
+		return nil
+	}
+	if policy := s.categoryPolicy(item.Category()); policy != nil && !policy.AllowsCurrency(currency) {
+		return fmt.Errorf("%w: %s for %s", domain.ErrCurrencyNotAllowed, currency, item.SKU())
+	}
+	return nil
+}
+
+// convertCategoryPolicyToDTO converts a domain category policy to a DTO
+func convertCategoryPolicyToDTO(policy *domain.CategoryPolicy) CategoryPolicyDTO {
+	settings := policy.Settings()
+	currencies := settings.AllowedCurrencies
+	if currencies == nil {
+		currencies = []string{}
+	}
+	return CategoryPolicyDTO{
+		Category:              policy.Category().String(),
+		MinStockLevel:         settings.MinStockLevel,
+		MaxStockLevel:         settings.MaxStockLevel,
+		ReservationTTLMinutes: int(settings.ReservationTTL / time.Minute),
+		RestockQuantity:       settings.RestockQuantity,
+		AllowedCurrencies:     currencies,
+		CreatedAt:             policy.CreatedAt(),
+		UpdatedAt:             policy.UpdatedAt(),
+		Version:               policy.Version(),
+	}
+}
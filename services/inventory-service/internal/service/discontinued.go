@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// Discontinued item DTOs
+
+type DiscontinueItemRequest struct {
+	SKU            string
+	ReplacementSKU string // Item superseding it; empty for none
+	DiscontinuedBy string
+}
+
+// DiscontinueItem takes an item out of the range. The replacement must be a
+// stocked item that is not discontinued itself.
+func (s *inventoryService) DiscontinueItem(ctx context.Context, req DiscontinueItemRequest) (*InventoryItemDTO, error) {
+	if req.SKU == "" {
+		return nil, domain.ErrInvalidSKU
+	}
+
+	item, err := s.repository.FindBySKU(req.SKU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find item: %w", err)
+	}
+	if item == nil {
+		return nil, domain.ErrItemNotFound
+	}
+
+	if req.ReplacementSKU != "" && req.ReplacementSKU != req.SKU {
+		replacement, err := s.repository.FindBySKU(req.ReplacementSKU)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find replacement item: %w", err)
+		}
+		if replacement == nil {
+			return nil, domain.ErrReplacementNotFound
+		}
+		if replacement.Status() == domain.ItemStatusDiscontinued {
+			return nil, domain.ErrReplacementDiscontinued
+		}
+	}
+
+	if err := item.Discontinue(req.ReplacementSKU); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Save(item); err != nil {
+		return nil, fmt.Errorf("failed to save discontinued item: %w", err)
+	}
+
+	s.logger.Info("Item discontinued",
+		"sku", item.SKU(),
+		"replacementSKU", item.ReplacementSKU(),
+		"discontinuedBy", req.DiscontinuedBy)
+
+	dto := s.convertDomainToDTO(item)
+	return &dto, nil
+}
+
+// discontinuedReplacement returns the replacement of a discontinued item for
+// availability checks and reservations, and whether it substitutes the item.
+// It is nil when replacements are off, the item names none, or the replacement
+// is gone or discontinued itself by now.
+func (s *inventoryService) discontinuedReplacement(item *domain.InventoryItem) (*domain.InventoryItem, bool) {
+	mode := s.config.Inventory.DiscontinuedReplacement
+	if mode != config.ReplacementSuggest && mode != config.ReplacementSubstitute {
+		return nil, false
+	}
+	if item.Status() != domain.ItemStatusDiscontinued || item.ReplacementSKU() == "" {
+		return nil, false
+	}
+
+	replacement, err := s.repository.FindBySKU(item.ReplacementSKU())
+	if err != nil {
+		s.logger.Warn("Failed to find replacement item",
+			"sku", item.SKU(),
+			"replacementSKU", item.ReplacementSKU(),
+			"error", err)
+		return nil, false
+	}
+	if replacement == nil || replacement.Status() == domain.ItemStatusDiscontinued {
+		return nil, false
+	}
+	return replacement, mode == config.ReplacementSubstitute
+}
+
+// checkDiscontinuedAvailability checks an item, suggesting or substituting the
+// replacement of a discontinued one
+func (s *inventoryService) checkDiscontinuedAvailability(inventoryItem *domain.InventoryItem, item ItemAvailabilityCheck) ItemAvailabilityResult {
+	replacement, substitute := s.discontinuedReplacement(inventoryItem)
+	if replacement == nil {
+		return s.checkItemAvailability(inventoryItem, item)
+	}
+
+	if !substitute {
+		result := s.checkItemAvailability(inventoryItem, item)
+		result.ReplacementSKU = replacement.SKU()
+		if !result.Available {
+			result.Reason += fmt.Sprintf(" (discontinued, replaced by %s)", replacement.SKU())
+		}
+		return result
+	}
+
+	result := s.checkItemAvailability(replacement, item)
+	result.SKU = inventoryItem.SKU()
+	result.ReplacementSKU = replacement.SKU()
+	result.Substituted = true
+	return result
+}
+
+// reserveDiscontinuedItem reserves an item, suggesting the replacement of a
+// discontinued one or reserving the replacement in its place
+func (s *inventoryService) reserveDiscontinuedItem(
+	ctx context.Context,
+	inventoryItem *domain.InventoryItem,
+	item ItemReservationRequest,
+	orderID string,
+	durationMinutes int,
+	priority domain.ReservationPriority,
+	preempt bool,
+) ItemReservationResult {
+	replacement, substitute := s.discontinuedReplacement(inventoryItem)
+	if replacement == nil {
+		return s.reserveItem(ctx, inventoryItem, item, orderID, durationMinutes, priority, preempt)
+	}
+
+	if !substitute {
+		result := s.reserveItem(ctx, inventoryItem, item, orderID, durationMinutes, priority, preempt)
+		result.ReplacementSKU = replacement.SKU()
+		return result
+	}
+
+	result := s.reserveItem(ctx, replacement, item, orderID, durationMinutes, priority, preempt)
+	result.SKU = inventoryItem.SKU()
+	result.ReplacementSKU = replacement.SKU()
+	result.Substituted = true
+
+	s.logger.Info("Reserved replacement of discontinued item",
+		"sku", inventoryItem.SKU(),
+		"replacementSKU", replacement.SKU(),
+		"orderID", orderID,
+		"reserved", result.Reserved)
+
+	return result
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+	"github.com/amiosamu/rocket-science/shared/platform/fxrates"
+)
+
+// Display currency errors; a currency the rates do not cover fails with
+// fxrates.ErrUnsupportedCurrency
+var (
+	ErrInvalidDisplayCurrency       = errors.New("invalid display currency")
+	ErrDisplayCurrencyNotConfigured = errors.New("display currency conversion is not configured")
+)
+
+// DisplayPrices prices catalog items in the currency a storefront shows, so
+// customers see local prices without the client converting them. Items keep
+// their unit price; the converted price is for display only and orders are
+// still charged in the item's currency.
+type DisplayPrices struct {
+	rates fxrates.Provider
+}
+
+// NewDisplayPrices creates display pricing over the shared exchange rates. A
+// nil provider only allows displaying prices in their own currency.
+func NewDisplayPrices(rates fxrates.Provider) *DisplayPrices {
+	return &DisplayPrices{rates: rates}
+}
+
+// DisplayConversion converts prices into one display currency
+type DisplayConversion struct {
+	Currency string
+	Rates    map[string]fxrates.Rate // Keyed by the currency converted from; none for prices already in Currency
+}
+
+// Convert returns price in the display currency. Prices already in it have no
+// rate and are returned unchanged.
+func (c *DisplayConversion) Convert(price domain.Money) domain.Money {
+	rate, ok := c.Rates[price.Currency]
+	if !ok {
+		return price
+	}
+	return price.Convert(c.Currency, rate.Value)
+}
+
+// SortedRates returns the rates ordered by the currency converted from
+func (c *DisplayConversion) SortedRates() []fxrates.Rate {
+	rates := make([]fxrates.Rate, 0, len(c.Rates))
+	for _, rate := range c.Rates {
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Base < rates[j].Base })
+	return rates
+}
+
+// Prepare looks up the rates converting the prices of items into currency. An
+// empty currency returns a nil conversion: prices are shown as they are.
+func (d *DisplayPrices) Prepare(ctx context.Context, currency string, items []InventoryItemDTO) (*DisplayConversion, error) {
+	if currency == "" {
+		return nil, nil
+	}
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !money.ValidCurrency(currency) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDisplayCurrency, currency)
+	}
+
+	conversion := &DisplayConversion{Currency: currency, Rates: make(map[string]fxrates.Rate)}
+	for _, item := range items {
+		from := item.UnitPrice.Currency
+		if _, ok := conversion.Rates[from]; ok || from == currency {
+			continue
+		}
+		if d == nil || d.rates == nil {
+			return nil, ErrDisplayCurrencyNotConfigured
+		}
+
+		rate, err := d.rates.Rate(ctx, from, currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s/%s rate: %w", from, currency, err)
+		}
+		conversion.Rates[from] = rate
+	}
+	return conversion, nil
+}
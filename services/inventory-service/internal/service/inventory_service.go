@@ -2,12 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/pagination"
 )
 
 // InventoryService defines the interface for inventory operations
@@ -40,8 +46,126 @@ type InventoryService interface {
 	// GetItemsByCategory retrieves items in a specific category
 	GetItemsByCategory(ctx context.Context, req GetItemsByCategoryRequest) (*GetItemsByCategoryResult, error)
 
+	// GetAvailabilitySummary returns per-category stock counts, reserved quantity and valuation
+	GetAvailabilitySummary(ctx context.Context) (*GetAvailabilitySummaryResult, error)
+
 	// CleanupExpiredReservations removes expired reservations across all items
 	CleanupExpiredReservations(ctx context.Context) (*CleanupResult, error)
+
+	// SaveBundle creates or replaces a kit/bundle definition (admin operation)
+	SaveBundle(ctx context.Context, req SaveBundleRequest) (*BundleDTO, error)
+
+	// GetBundle retrieves a bundle definition with availability computed from its components
+	GetBundle(ctx context.Context, sku string) (*BundleDTO, error)
+
+	// ListBundles retrieves all bundle definitions
+	ListBundles(ctx context.Context) ([]BundleDTO, error)
+
+	// DeleteBundle removes a bundle definition (admin operation)
+	DeleteBundle(ctx context.Context, sku string) error
+
+	// RegisterSerials adds serial numbers to an item's pool (admin operation)
+	RegisterSerials(ctx context.Context, req RegisterSerialsRequest) (*RegisterSerialsResult, error)
+
+	// GetSerialHistory retrieves a serial number with its history
+	GetSerialHistory(ctx context.Context, serialNumber string) (*SerialNumberDTO, error)
+
+	// GetOrderSerials retrieves the serial numbers allocated to an order
+	GetOrderSerials(ctx context.Context, orderID string) ([]SerialNumberDTO, error)
+
+	// CreatePurchaseOrder records a new inbound purchase order (admin operation)
+	CreatePurchaseOrder(ctx context.Context, req CreatePurchaseOrderRequest) (*PurchaseOrderDTO, error)
+
+	// GetPurchaseOrder retrieves a purchase order with its receipts
+	GetPurchaseOrder(ctx context.Context, id string) (*PurchaseOrderDTO, error)
+
+	// ListPurchaseOrders retrieves purchase orders, optionally filtered by status
+	ListPurchaseOrders(ctx context.Context, status *domain.PurchaseOrderStatus) ([]PurchaseOrderDTO, error)
+
+	// MarkPurchaseOrderInTransit records that a purchase order has shipped (admin operation)
+	MarkPurchaseOrderInTransit(ctx context.Context, req MarkPurchaseOrderInTransitRequest) (*PurchaseOrderDTO, error)
+
+	// ReceivePurchaseOrder receives quantities against a purchase order into stock (admin operation)
+	ReceivePurchaseOrder(ctx context.Context, req ReceivePurchaseOrderRequest) (*PurchaseOrderDTO, error)
+
+	// CancelPurchaseOrder cancels the outstanding part of a purchase order (admin operation)
+	CancelPurchaseOrder(ctx context.Context, id string) (*PurchaseOrderDTO, error)
+
+	// WatchItems subscribes to stock and price changes of the given SKUs
+	WatchItems(ctx context.Context, skus []string, skipSnapshot bool) (*ItemSubscription, error)
+
+	// RepairItemStock recomputes an item's stock levels from its reservations (admin operation)
+	RepairItemStock(ctx context.Context, req RepairItemStockRequest) (*RepairItemStockResult, error)
+
+	// DiscontinueItem takes an item out of the range, optionally naming its replacement (admin operation)
+	DiscontinueItem(ctx context.Context, req DiscontinueItemRequest) (*InventoryItemDTO, error)
+
+	// StockInvariantViolations returns how many stock invariant violations were detected
+	StockInvariantViolations() int64
+
+	// CheckStockConsistency compares every item's stock levels with its reservations,
+	// optionally repairing small drifts
+	CheckStockConsistency(ctx context.Context, req CheckStockConsistencyRequest) (*StockConsistencyReport, error)
+
+	// LastStockConsistencyReport returns the report of the latest consistency check
+	LastStockConsistencyReport() *StockConsistencyReport
+
+	// ConsumeParts books parts built into an order's rocket out of its confirmed stock
+	ConsumeParts(ctx context.Context, req ConsumePartsRequest) (*ConsumePartsResult, error)
+
+	// GetOrderStockMovements retrieves the stock movements of an order
+	GetOrderStockMovements(ctx context.Context, orderID string) (*OrderStockMovementsDTO, error)
+
+	// PurgeReservations releases the active reservations of test orders (admin operation)
+	PurgeReservations(ctx context.Context, orderIDs []string, dryRun bool) (*PurgeReservationsResult, error)
+
+	// GetItemCosting retrieves the cost price and cost layers of an item
+	GetItemCosting(ctx context.Context, sku string) (*ItemCostingDTO, error)
+
+	// SetCostPrice sets the cost of one unit of an item (admin operation)
+	SetCostPrice(ctx context.Context, sku string, costPrice domain.Money) (*ItemCostingDTO, error)
+
+	// GetInventoryValuation values the stock on hand and the cost of goods sold in a period
+	GetInventoryValuation(ctx context.Context, req GetInventoryValuationRequest) (*InventoryValuationReport, error)
+
+	// CreateBackorder records an order's demand for out-of-stock items, reserved once stock arrives
+	CreateBackorder(ctx context.Context, req CreateBackorderRequest) (*BackorderDTO, error)
+
+	// CancelBackorder gives up the open backorder of an order or one of its shipments
+	CancelBackorder(ctx context.Context, orderID, shipmentID string) (*BackorderDTO, error)
+
+	// ListBackorders retrieves backorders, optionally filtered by status
+	ListBackorders(ctx context.Context, status *domain.BackorderStatus) ([]BackorderDTO, error)
+
+	// CreateItem adds an item to the range with its category's default stock levels (admin operation)
+	CreateItem(ctx context.Context, req CreateItemRequest) (*InventoryItemDTO, error)
+
+	// SaveCategoryPolicy creates or replaces the stocking policy of a category (admin operation)
+	SaveCategoryPolicy(ctx context.Context, req SaveCategoryPolicyRequest) (*CategoryPolicyDTO, error)
+
+	// GetCategoryPolicy retrieves the stocking policy of a category
+	GetCategoryPolicy(ctx context.Context, category domain.ItemCategory) (*CategoryPolicyDTO, error)
+
+	// ListCategoryPolicies retrieves all category stocking policies
+	ListCategoryPolicies(ctx context.Context) ([]CategoryPolicyDTO, error)
+
+	// DeleteCategoryPolicy removes the stocking policy of a category (admin operation)
+	DeleteCategoryPolicy(ctx context.Context, category domain.ItemCategory) error
+
+	// SaveItemSupplier creates or replaces an item's record of a supplier (admin operation)
+	SaveItemSupplier(ctx context.Context, req SaveItemSupplierRequest) (*ItemSupplierDTO, error)
+
+	// GetItemSupplier retrieves an item's record of a supplier
+	GetItemSupplier(ctx context.Context, sku, supplier string) (*ItemSupplierDTO, error)
+
+	// ListItemSuppliers retrieves the suppliers of an item
+	ListItemSuppliers(ctx context.Context, sku string) ([]ItemSupplierDTO, error)
+
+	// DeleteItemSupplier removes an item's record of a supplier (admin operation)
+	DeleteItemSupplier(ctx context.Context, sku, supplier string) error
+
+	// RequestRestocks publishes restock requests of low stock items not yet restocking
+	RequestRestocks(ctx context.Context) (*RequestRestocksResult, error)
 }
 
 // Service DTOs - Data Transfer Objects for the service layer
@@ -52,7 +176,8 @@ type CheckAvailabilityRequest struct {
 
 type ItemAvailabilityCheck struct {
 	SKU      string
-	Quantity int
+	Quantity float64
+	Unit     domain.UnitOfMeasure // Unit of Quantity; empty means the item's own unit
 }
 
 type CheckAvailabilityResult struct {
@@ -65,21 +190,31 @@ type ItemAvailabilityResult struct {
 	SKU               string
 	Name              string
 	Available         bool
-	RequestedQuantity int
-	AvailableQuantity int
-	ReservedQuantity  int
+	RequestedQuantity float64
+	AvailableQuantity float64
+	ReservedQuantity  float64
+	Unit              domain.UnitOfMeasure // Unit of the quantities; the item's unit once it was found
+	UnitPrice         domain.Money         // Price per unit; zero when the item was not found
 	Reason            string
+	Bundle            bool // True when the SKU is a kit whose availability is derived from its components
+
+	// A discontinued item's replacement is suggested next to the item's own result,
+	// or checked in its place when Substituted
+	ReplacementSKU string
+	Substituted    bool
 }
 
 type ReserveItemsRequest struct {
 	OrderID                    string
 	Items                      []ItemReservationRequest
 	ReservationDurationMinutes int
+	Priority                   domain.ReservationPriority // Expedited orders may preempt standard reservations
 }
 
 type ItemReservationRequest struct {
 	SKU      string
-	Quantity int
+	Quantity float64
+	Unit     domain.UnitOfMeasure // Unit of Quantity; empty means the item's own unit
 }
 
 type ReserveItemsResult struct {
@@ -94,9 +229,27 @@ type ItemReservationResult struct {
 	SKU           string
 	Name          string
 	Reserved      bool
-	Quantity      int
+	Quantity      float64
+	Unit          domain.UnitOfMeasure
 	ReservationID string
 	Reason        string
+	Bundle        bool // True for the summary line of a kit reserved through its components
+	Shortage      bool // Not reserved for lack of stock, unlike invalid or unknown items
+
+	// A discontinued item's replacement is suggested next to the item's own result,
+	// or reserved in its place when Substituted
+	ReplacementSKU string
+	Substituted    bool
+
+	expiresAt time.Time // When the reservation expires, per the item's category policy
+}
+
+// reservedSKU is the SKU of the item holding the reservation
+func (r ItemReservationResult) reservedSKU() string {
+	if r.Substituted {
+		return r.ReplacementSKU
+	}
+	return r.SKU
 }
 
 type ConfirmReservationRequest struct {
@@ -112,11 +265,13 @@ type ConfirmReservationResult struct {
 }
 
 type ItemConfirmationResult struct {
-	SKU       string
-	Name      string
-	Confirmed bool
-	Quantity  int
-	Reason    string
+	SKU           string
+	Name          string
+	Confirmed     bool
+	Quantity      float64
+	Unit          domain.UnitOfMeasure
+	Reason        string
+	SerialNumbers []string // Serials allocated to the order for serial-tracked items
 }
 
 type ReleaseReservationRequest struct {
@@ -136,7 +291,8 @@ type ItemReleaseResult struct {
 	SKU      string
 	Name     string
 	Released bool
-	Quantity int
+	Quantity float64
+	Unit     domain.UnitOfMeasure
 	Reason   string
 }
 
@@ -155,28 +311,32 @@ type SearchItemsRequest struct {
 	Query         string
 	Category      *domain.ItemCategory
 	AvailableOnly bool
-	Limit         int
-	Offset        int
+	Limit         int    // Page size; zero returns every matching item
+	Offset        int    // Deprecated: use PageToken
+	PageToken     string // Token of the page to return; empty for the first
 }
 
 type SearchItemsResult struct {
 	Items      []InventoryItemDTO
 	TotalCount int
 	HasMore    bool
+	PageInfo   pagination.PageInfo
 	Message    string
 }
 
 type UpdateStockRequest struct {
 	SKU            string
-	QuantityChange int
+	QuantityChange float64
+	Unit           domain.UnitOfMeasure // Unit of QuantityChange; empty means the item's own unit
 	Reason         string
 	UpdatedBy      string
 }
 
 type UpdateStockResult struct {
 	Success       bool
-	OldStockLevel int
-	NewStockLevel int
+	OldStockLevel float64
+	NewStockLevel float64
+	Unit          domain.UnitOfMeasure
 	UpdatedAt     time.Time
 	Message       string
 }
@@ -206,6 +366,11 @@ type GetItemsByCategoryResult struct {
 	Message    string
 }
 
+type GetAvailabilitySummaryResult struct {
+	Categories  []domain.CategoryAvailability
+	GeneratedAt time.Time
+}
+
 type CleanupResult struct {
 	CleanedReservations int
 	AffectedItems       []string
@@ -220,11 +385,12 @@ type InventoryItemDTO struct {
 	Name           string
 	Description    string
 	Category       domain.ItemCategory
-	StockLevel     int
-	ReservedStock  int
-	TotalStock     int
-	MinStockLevel  int
-	MaxStockLevel  int
+	Unit           domain.UnitOfMeasure
+	StockLevel     float64
+	ReservedStock  float64
+	TotalStock     float64
+	MinStockLevel  float64
+	MaxStockLevel  float64
 	UnitPrice      domain.Money
 	Weight         float64
 	Dimensions     domain.Dimensions
@@ -233,12 +399,21 @@ type InventoryItemDTO struct {
 	UpdatedAt      time.Time
 	Version        int
 	Status         domain.ItemStatus
+	ReplacementSKU string // Item superseding a discontinued one, if any
 }
 
 type LowStockItemDTO struct {
 	Item             InventoryItemDTO
-	ShortageQuantity int
+	ShortageQuantity float64
 	DaysOfStock      int
+	IncomingQuantity int        // Quantity on purchase orders not yet received
+	ExpectedArrival  *time.Time // Earliest expected arrival of a shipment in transit
+	RestockQuantity  float64    // Quantity to reorder, per the category policy and the supplier's minimum order
+
+	Supplier          string        // Supplier to reorder from; empty when the item has none
+	SupplierSKU       string        // The supplier's code for the item, if it has its own
+	LeadTime          time.Duration // The supplier's time from ordering to delivery
+	ExpectedRestockAt *time.Time    // When stock reordered now is expected; nil without a supplier
 }
 
 // inventoryService is the concrete implementation of InventoryService
@@ -246,15 +421,40 @@ type inventoryService struct {
 	config     *config.Config
 	logger     *slog.Logger
 	repository domain.InventoryRepository
+	bundles    domain.BundleRepository // Optional; nil disables kit/bundle products
+	serials    domain.SerialRepository // Optional; nil disables serial number tracking
+
+	purchaseOrders domain.PurchaseOrderRepository // Optional; nil disables the receiving workflow
+	watcher        *ItemWatcher                   // Optional; nil disables WatchItems
+	movements      domain.StockMovementRepository // Optional; nil disables the stock movement ledger
+
+	backorders domain.BackorderRepository // Optional; nil disables backorders
+
+	categoryPolicies domain.CategoryPolicyRepository // Optional; nil applies the global settings to every category
+
+	suppliers       domain.ItemSupplierRepository // Optional; nil restocks without choosing a supplier
+	restockEvents   RestockEventPublisher         // Optional; nil disables restock requests
+	restockRequests sync.Map                      // SKU -> time.Time before which a restock is not requested again
+
+	reservationEvents ReservationEventPublisher // Optional; nil skips notifying preempted and backordered orders
+
+	invariantViolations   atomic.Int64                           // Stock invariant violations detected, for the alarm
+	lastConsistencyReport atomic.Pointer[StockConsistencyReport] // Latest stock consistency check, for the drift alarm
 }
 
 // NewInventoryService creates a new inventory service with dependencies
-func NewInventoryService(cfg *config.Config, logger *slog.Logger, repository domain.InventoryRepository) InventoryService {
-	return &inventoryService{
+func NewInventoryService(cfg *config.Config, logger *slog.Logger, repository domain.InventoryRepository, opts ...InventoryServiceOption) InventoryService {
+	s := &inventoryService{
 		config:     cfg,
 		logger:     logger,
 		repository: repository,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // CheckAvailability verifies if requested items are available in stock
@@ -271,6 +471,7 @@ func (s *inventoryService) CheckAvailability(ctx context.Context, req CheckAvail
 				SKU:               item.SKU,
 				Available:         false,
 				RequestedQuantity: item.Quantity,
+				Unit:              item.Unit,
 				Reason:            fmt.Sprintf("Invalid request: %v", err),
 			}
 			results = append(results, result)
@@ -286,6 +487,7 @@ func (s *inventoryService) CheckAvailability(ctx context.Context, req CheckAvail
 				SKU:               item.SKU,
 				Available:         false,
 				RequestedQuantity: item.Quantity,
+				Unit:              item.Unit,
 				Reason:            "Failed to retrieve item information",
 			}
 			results = append(results, result)
@@ -294,10 +496,25 @@ func (s *inventoryService) CheckAvailability(ctx context.Context, req CheckAvail
 		}
 
 		if inventoryItem == nil {
+			// Not a stocked item: it may be a kit whose availability comes from its components
+			bundle, err := s.findBundle(item.SKU)
+			if err != nil {
+				s.logger.Error("Failed to find bundle by SKU", "sku", item.SKU, "error", err)
+			}
+			if bundle != nil {
+				result := s.checkBundleAvailability(bundle, item.Quantity, item.Unit)
+				results = append(results, result)
+				if !result.Available {
+					allAvailable = false
+				}
+				continue
+			}
+
 			result := ItemAvailabilityResult{
 				SKU:               item.SKU,
 				Available:         false,
 				RequestedQuantity: item.Quantity,
+				Unit:              item.Unit,
 				Reason:            "Item not found",
 			}
 			results = append(results, result)
@@ -305,30 +522,9 @@ func (s *inventoryService) CheckAvailability(ctx context.Context, req CheckAvail
 			continue
 		}
 
-		// Check availability
-		available := inventoryItem.CheckAvailability(item.Quantity)
-		reason := ""
-		if !available {
-			if inventoryItem.IsOutOfStock() {
-				reason = "Out of stock"
-			} else {
-				reason = fmt.Sprintf("Insufficient stock (available: %d, requested: %d)",
-					inventoryItem.GetAvailableStock(), item.Quantity)
-			}
-		}
-
-		result := ItemAvailabilityResult{
-			SKU:               inventoryItem.SKU(),
-			Name:              inventoryItem.Name(),
-			Available:         available,
-			RequestedQuantity: item.Quantity,
-			AvailableQuantity: inventoryItem.GetAvailableStock(),
-			ReservedQuantity:  inventoryItem.ReservedStock(),
-			Reason:            reason,
-		}
+		result := s.checkDiscontinuedAvailability(inventoryItem, item)
 		results = append(results, result)
-
-		if !available {
+		if !result.Available {
 			allAvailable = false
 		}
 	}
@@ -349,6 +545,47 @@ func (s *inventoryService) CheckAvailability(ctx context.Context, req CheckAvail
 	}, nil
 }
 
+// checkItemAvailability checks the requested quantity of a stocked item
+func (s *inventoryService) checkItemAvailability(inventoryItem *domain.InventoryItem, item ItemAvailabilityCheck) ItemAvailabilityResult {
+	// Requests may use any unit of the item's dimension, e.g. kilograms of fuel stocked in tonnes
+	quantity, err := inventoryItem.ToStockUnit(item.Quantity, item.Unit)
+	if err != nil {
+		return ItemAvailabilityResult{
+			SKU:               inventoryItem.SKU(),
+			Name:              inventoryItem.Name(),
+			Available:         false,
+			RequestedQuantity: item.Quantity,
+			Unit:              item.Unit,
+			Reason:            fmt.Sprintf("Invalid quantity: %v", err),
+		}
+	}
+
+	// Check availability
+	available := inventoryItem.CheckAvailability(quantity)
+	reason := ""
+	if !available {
+		if inventoryItem.IsOutOfStock() {
+			reason = "Out of stock"
+		} else {
+			reason = fmt.Sprintf("Insufficient stock (available: %s, requested: %s)",
+				formatQuantity(inventoryItem.GetAvailableStock(), inventoryItem.Unit()),
+				formatQuantity(quantity, inventoryItem.Unit()))
+		}
+	}
+
+	return ItemAvailabilityResult{
+		SKU:               inventoryItem.SKU(),
+		Name:              inventoryItem.Name(),
+		Available:         available,
+		RequestedQuantity: quantity,
+		AvailableQuantity: inventoryItem.GetAvailableStock(),
+		ReservedQuantity:  inventoryItem.ReservedStock(),
+		Unit:              inventoryItem.Unit(),
+		UnitPrice:         inventoryItem.UnitPrice(),
+		Reason:            reason,
+	}
+}
+
 // ReserveItems creates reservations for items in an order
 func (s *inventoryService) ReserveItems(ctx context.Context, req ReserveItemsRequest) (*ReserveItemsResult, error) {
 	s.logger.Info("Creating reservations for order",
@@ -367,12 +604,27 @@ func (s *inventoryService) ReserveItems(ctx context.Context, req ReserveItemsReq
 	allReserved := true
 	reservationID := s.generateReservationID(req.OrderID)
 
-	// Calculate expiration time
-	expiresAt := time.Now().Add(time.Duration(req.ReservationDurationMinutes) * time.Minute)
+	// Expand kit lines into their component items
+	items, bundleLines, err := s.expandBundleReservations(req.Items)
+	if errors.Is(err, domain.ErrQuantityPrecision) || errors.Is(err, domain.ErrIncompatibleUnits) {
+		return &ReserveItemsResult{
+			Success: false,
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		}, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to expand bundle reservations", "orderID", req.OrderID, "error", err)
+		return &ReserveItemsResult{
+			Success: false,
+			Message: "Failed to retrieve bundle information",
+		}, nil
+	}
+
+	preempt := s.shouldPreempt(items, req.Priority)
 
 	// Process each item reservation
-	for _, item := range req.Items {
-		result := s.processItemReservation(item, req.OrderID, req.ReservationDurationMinutes)
+	for _, item := range items {
+		result := s.processItemReservation(ctx, item, req.OrderID, req.ReservationDurationMinutes, req.Priority, preempt)
 		results = append(results, result)
 
 		if !result.Reserved {
@@ -386,6 +638,21 @@ func (s *inventoryService) ReserveItems(ctx context.Context, req ReserveItemsReq
 		s.releasePartialReservations(req.OrderID, results)
 	}
 
+	// The order's reservation lapses with its first item reservation to expire,
+	// as each category may hold its items for a different time
+	var expiresAt time.Time
+	for _, result := range results {
+		if result.Reserved && (expiresAt.IsZero() || result.expiresAt.Before(expiresAt)) {
+			expiresAt = result.expiresAt
+		}
+	}
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(time.Duration(s.config.Inventory.MaxReservationTimeMin) * time.Minute)
+	}
+
+	// Kits are reserved all-or-nothing together with the rest of the order
+	results = append(results, s.bundleReservationResults(bundleLines, results, allReserved)...)
+
 	message := "All items reserved successfully"
 	if !allReserved {
 		message = "Some items could not be reserved"
@@ -406,7 +673,14 @@ func (s *inventoryService) ReserveItems(ctx context.Context, req ReserveItemsReq
 }
 
 // processItemReservation handles reservation for a single item
-func (s *inventoryService) processItemReservation(item ItemReservationRequest, orderID string, durationMinutes int) ItemReservationResult {
+func (s *inventoryService) processItemReservation(
+	ctx context.Context,
+	item ItemReservationRequest,
+	orderID string,
+	durationMinutes int,
+	priority domain.ReservationPriority,
+	preempt bool,
+) ItemReservationResult {
 	// Find item by SKU
 	inventoryItem, err := s.repository.FindBySKU(item.SKU)
 	if err != nil {
@@ -415,6 +689,7 @@ func (s *inventoryService) processItemReservation(item ItemReservationRequest, o
 			SKU:      item.SKU,
 			Reserved: false,
 			Quantity: item.Quantity,
+			Unit:     item.Unit,
 			Reason:   "Failed to retrieve item information",
 		}
 	}
@@ -424,25 +699,64 @@ func (s *inventoryService) processItemReservation(item ItemReservationRequest, o
 			SKU:      item.SKU,
 			Reserved: false,
 			Quantity: item.Quantity,
+			Unit:     item.Unit,
 			Reason:   "Item not found",
 		}
 	}
 
-	// Attempt to reserve stock
-	reservation, err := inventoryItem.ReserveStock(orderID, item.Quantity, durationMinutes)
+	return s.reserveDiscontinuedItem(ctx, inventoryItem, item, orderID, durationMinutes, priority, preempt)
+}
+
+// reserveItem reserves the requested quantity of a stocked item
+func (s *inventoryService) reserveItem(
+	ctx context.Context,
+	inventoryItem *domain.InventoryItem,
+	item ItemReservationRequest,
+	orderID string,
+	durationMinutes int,
+	priority domain.ReservationPriority,
+	preempt bool,
+) ItemReservationResult {
+	// Reservations are held for the item's category TTL at most
+	durationMinutes = s.reservationMinutes(inventoryItem, durationMinutes)
+
+	// Reservations are held in the item's unit
+	quantity, err := inventoryItem.ToStockUnit(item.Quantity, item.Unit)
+	if err != nil {
+		return ItemReservationResult{
+			SKU:      inventoryItem.SKU(),
+			Name:     inventoryItem.Name(),
+			Reserved: false,
+			Quantity: item.Quantity,
+			Unit:     item.Unit,
+			Reason:   fmt.Sprintf("Invalid quantity: %v", err),
+		}
+	}
+
+	// Attempt to reserve stock, preempting reservations nearing expiry if allowed
+	reservation, err := inventoryItem.ReserveStock(orderID, quantity, durationMinutes, priority)
+	var preempted []*domain.Reservation
+	if err == domain.ErrInsufficientStock && preempt {
+		reservation, preempted, err = s.preemptForReservation(inventoryItem, orderID, quantity, durationMinutes, priority)
+	}
 	if err != nil {
+		s.observeStockInvariant(err)
 		reason := err.Error()
-		if err == domain.ErrInsufficientStock {
-			reason = fmt.Sprintf("Insufficient stock (available: %d, requested: %d)",
-				inventoryItem.GetAvailableStock(), item.Quantity)
+		shortage := err == domain.ErrInsufficientStock
+		if shortage {
+			reason = fmt.Sprintf("Insufficient stock (available: %s, requested: %s)",
+				formatQuantity(inventoryItem.GetAvailableStock(), inventoryItem.Unit()),
+				formatQuantity(quantity, inventoryItem.Unit()))
 		}
 
 		return ItemReservationResult{
 			SKU:      inventoryItem.SKU(),
 			Name:     inventoryItem.Name(),
 			Reserved: false,
-			Quantity: item.Quantity,
+			Quantity: quantity,
+			Unit:     inventoryItem.Unit(),
 			Reason:   reason,
+			Shortage: shortage,
 		}
 	}
 
@@ -458,24 +772,30 @@ func (s *inventoryService) processItemReservation(item ItemReservationRequest, o
 			SKU:      inventoryItem.SKU(),
 			Name:     inventoryItem.Name(),
 			Reserved: false,
-			Quantity: item.Quantity,
+			Quantity: quantity,
+			Unit:     inventoryItem.Unit(),
 			Reason:   "Failed to save reservation",
 		}
 	}
 
+	s.publishPreemptions(ctx, inventoryItem, orderID, preempted)
+
 	s.logger.Debug("Item reserved successfully",
 		"sku", item.SKU,
 		"orderID", orderID,
-		"quantity", item.Quantity,
+		"quantity", quantity,
+		"unit", inventoryItem.Unit(),
 		"reservationID", reservation.ID())
 
 	return ItemReservationResult{
 		SKU:           inventoryItem.SKU(),
 		Name:          inventoryItem.Name(),
 		Reserved:      true,
-		Quantity:      item.Quantity,
+		Quantity:      quantity,
+		Unit:          inventoryItem.Unit(),
 		ReservationID: reservation.ID(),
 		Reason:        "",
+		expiresAt:     reservation.ExpiresAt(),
 	}
 }
 
@@ -511,10 +831,14 @@ func (s *inventoryService) ConfirmReservation(ctx context.Context, req ConfirmRe
 		// Check if this item has a reservation for the order
 		reservations := item.GetActiveReservations()
 		hasReservation := false
+		var reservationQuantity float64
+		var reservationID string
 
 		for _, reservation := range reservations {
 			if reservation.OrderID() == req.OrderID {
 				hasReservation = true
+				reservationQuantity = reservation.Quantity()
+				reservationID = reservation.ID()
 				break
 			}
 		}
@@ -523,14 +847,45 @@ func (s *inventoryService) ConfirmReservation(ctx context.Context, req ConfirmRe
 			continue // Skip items without reservations for this order
 		}
 
+		// Serial-tracked units are allocated before confirming so a short pool leaves the reservation intact
+		var serialNumbers []string
+		if s.isSerialTracked(item) {
+			// Serial-tracked items are stocked in pieces, so the quantity is whole
+			serialNumbers, err = s.allocateSerials(item, req.OrderID, int(reservationQuantity))
+			if err != nil {
+				s.logger.Error("Failed to allocate serial numbers",
+					"orderID", req.OrderID,
+					"sku", item.SKU(),
+					"quantity", reservationQuantity,
+					"error", err)
+
+				result := ItemConfirmationResult{
+					SKU:       item.SKU(),
+					Name:      item.Name(),
+					Confirmed: false,
+					Quantity:  reservationQuantity,
+					Unit:      item.Unit(),
+					Reason:    err.Error(),
+				}
+				results = append(results, result)
+				allConfirmed = false
+				continue
+			}
+		}
+
 		// Confirm the reservation
-		err := item.ConfirmReservation(req.OrderID)
+		cost, err := item.ConfirmReservation(req.OrderID)
 		if err != nil {
+			s.observeStockInvariant(err)
 			s.logger.Error("Failed to confirm reservation",
 				"orderID", req.OrderID,
 				"sku", item.SKU(),
 				"error", err)
 
+			if len(serialNumbers) > 0 {
+				s.returnSerialNumbers(serialNumbers, "reservation confirmation failed")
+			}
+
 			result := ItemConfirmationResult{
 				SKU:       item.SKU(),
 				Name:      item.Name(),
@@ -549,6 +904,10 @@ func (s *inventoryService) ConfirmReservation(ctx context.Context, req ConfirmRe
 				"orderID", req.OrderID,
 				"error", err)
 
+			if len(serialNumbers) > 0 {
+				s.returnSerialNumbers(serialNumbers, "reservation confirmation failed")
+			}
+
 			result := ItemConfirmationResult{
 				SKU:       item.SKU(),
 				Name:      item.Name(),
@@ -560,12 +919,16 @@ func (s *inventoryService) ConfirmReservation(ctx context.Context, req ConfirmRe
 			continue
 		}
 
+		s.recordConfirmation(item, req.OrderID, reservationID, reservationQuantity, serialNumbers, cost, confirmedAt)
+
 		result := ItemConfirmationResult{
-			SKU:       item.SKU(),
-			Name:      item.Name(),
-			Confirmed: true,
-			Quantity:  0, // We'd need to track the original quantity
-			Reason:    "",
+			SKU:           item.SKU(),
+			Name:          item.Name(),
+			Confirmed:     true,
+			Quantity:      reservationQuantity,
+			Unit:          item.Unit(),
+			Reason:        "",
+			SerialNumbers: serialNumbers,
 		}
 		results = append(results, result)
 	}
@@ -619,7 +982,7 @@ func (s *inventoryService) ReleaseReservation(ctx context.Context, req ReleaseRe
 		// Check if this item has a reservation for the order
 		reservations := item.GetActiveReservations()
 		hasReservation := false
-		var reservationQuantity int
+		var reservationQuantity float64
 
 		for _, reservation := range reservations {
 			if reservation.OrderID() == req.OrderID {
@@ -636,6 +999,7 @@ func (s *inventoryService) ReleaseReservation(ctx context.Context, req ReleaseRe
 		// Release the reservation
 		err := item.ReleaseReservation(req.OrderID)
 		if err != nil {
+			s.observeStockInvariant(err)
 			s.logger.Error("Failed to release reservation",
 				"orderID", req.OrderID,
 				"sku", item.SKU(),
@@ -646,6 +1010,7 @@ func (s *inventoryService) ReleaseReservation(ctx context.Context, req ReleaseRe
 				Name:     item.Name(),
 				Released: false,
 				Quantity: reservationQuantity,
+				Unit:     item.Unit(),
 				Reason:   err.Error(),
 			}
 			results = append(results, result)
@@ -665,6 +1030,7 @@ func (s *inventoryService) ReleaseReservation(ctx context.Context, req ReleaseRe
 				Name:     item.Name(),
 				Released: false,
 				Quantity: reservationQuantity,
+				Unit:     item.Unit(),
 				Reason:   "Failed to save release",
 			}
 			results = append(results, result)
@@ -677,6 +1043,7 @@ func (s *inventoryService) ReleaseReservation(ctx context.Context, req ReleaseRe
 			Name:     item.Name(),
 			Released: true,
 			Quantity: reservationQuantity,
+			Unit:     item.Unit(),
 			Reason:   "",
 		}
 		results = append(results, result)
@@ -720,6 +1087,7 @@ func (s *inventoryService) GetItem(ctx context.Context, req GetItemRequest) (*Ge
 	}
 
 	if err != nil {
+		s.observeStockInvariant(err)
 		s.logger.Error("Failed to find item", "error", err)
 		return nil, fmt.Errorf("failed to find item: %w", err)
 	}
@@ -732,7 +1100,8 @@ func (s *inventoryService) GetItem(ctx context.Context, req GetItemRequest) (*Ge
 	}
 
 	// Clean up expired reservations
-	expiredOrders := item.CleanupExpiredReservations()
+	expiredOrders, err := item.CleanupExpiredReservations()
+	s.observeStockInvariant(err)
 	if len(expiredOrders) > 0 {
 		s.logger.Info("Cleaned up expired reservations",
 			"sku", item.SKU(),
@@ -756,50 +1125,45 @@ func (s *inventoryService) SearchItems(ctx context.Context, req SearchItemsReque
 		"category", req.Category,
 		"availableOnly", req.AvailableOnly)
 
-	var items []*domain.InventoryItem
-	var err error
-
-	// Determine search strategy
+	category := ""
 	if req.Category != nil {
-		// Search by category
-		items, err = s.repository.FindByCategory(*req.Category)
-	} else if req.Query != "" {
-		// Text search
-		items, err = s.repository.Search(req.Query)
-	} else {
-		// Get all available items
-		items, err = s.repository.FindAvailableItems()
+		category = strconv.Itoa(int(*req.Category))
 	}
-
+	cursor, err := pagination.DecodeToken(req.PageToken,
+		pagination.Fingerprint("items", req.Query, category, strconv.FormatBool(req.AvailableOnly)))
 	if err != nil {
-		s.logger.Error("Failed to search items", "error", err)
-		return nil, fmt.Errorf("failed to search items: %w", err)
+		return nil, err
 	}
 
-	// Filter by availability if requested
-	if req.AvailableOnly {
-		filteredItems := make([]*domain.InventoryItem, 0)
-		for _, item := range items {
-			if !item.IsOutOfStock() {
-				filteredItems = append(filteredItems, item)
-			}
-		}
-		items = filteredItems
+	search := domain.ItemSearch{
+		Query:         req.Query,
+		Category:      req.Category,
+		AvailableOnly: req.AvailableOnly,
+	}
+	if len(cursor.After) > 0 {
+		search.AfterSKU = cursor.After[0]
+	} else if req.PageToken == "" {
+		search.Offset = max(req.Offset, 0)
+	}
+	if req.Limit > 0 {
+		// One extra item tells whether another page follows
+		search.Limit = req.Limit + 1
 	}
 
-	// Apply pagination
-	totalCount := len(items)
-	startIdx := req.Offset
-	endIdx := req.Offset + req.Limit
+	items, totalCount, err := s.repository.SearchPage(search)
+	if err != nil {
+		s.logger.Error("Failed to search items", "error", err)
+		return nil, fmt.Errorf("failed to search items: %w", err)
+	}
 
-	if startIdx >= totalCount {
-		items = []*domain.InventoryItem{}
-	} else {
-		if endIdx > totalCount {
-			endIdx = totalCount
-		}
-		items = items[startIdx:endIdx]
+	pageSize, hasMore := len(items), false
+	if req.Limit > 0 {
+		pageSize = req.Limit
+		items, hasMore = pagination.Trim(items, req.Limit)
 	}
+	pageInfo := pagination.KeysetPage(cursor, pageSize, hasMore, func() []string {
+		return []string{items[len(items)-1].SKU()}
+	})
 
 	// Convert to DTOs
 	itemDTOs := make([]InventoryItemDTO, len(items))
@@ -807,12 +1171,11 @@ func (s *inventoryService) SearchItems(ctx context.Context, req SearchItemsReque
 		itemDTOs[i] = s.convertDomainToDTO(item)
 	}
 
-	hasMore := req.Offset+len(items) < totalCount
-
 	return &SearchItemsResult{
 		Items:      itemDTOs,
 		TotalCount: totalCount,
 		HasMore:    hasMore,
+		PageInfo:   pageInfo.WithTotal(totalCount),
 		Message:    fmt.Sprintf("Found %d items", totalCount),
 	}, nil
 }
@@ -822,6 +1185,7 @@ func (s *inventoryService) UpdateStock(ctx context.Context, req UpdateStockReque
 	s.logger.Info("Updating stock",
 		"sku", req.SKU,
 		"quantityChange", req.QuantityChange,
+		"unit", req.Unit,
 		"reason", req.Reason,
 		"updatedBy", req.UpdatedBy)
 
@@ -849,25 +1213,34 @@ func (s *inventoryService) UpdateStock(ctx context.Context, req UpdateStockReque
 
 	oldStockLevel := item.StockLevel()
 
-	// Apply stock change
+	// Apply stock change, converted into the item's unit
+	var quantity float64
 	if req.QuantityChange > 0 {
 		// Adding stock
-		err = item.AddStock(req.QuantityChange, req.Reason)
+		quantity, err = item.ToStockUnit(req.QuantityChange, req.Unit)
+		if err == nil {
+			err = item.AddStock(quantity, req.Reason)
+		}
 	} else if req.QuantityChange < 0 {
 		// Removing stock
-		err = item.RemoveStock(-req.QuantityChange, req.Reason)
+		quantity, err = item.ToStockUnit(-req.QuantityChange, req.Unit)
+		if err == nil {
+			err = item.RemoveStock(quantity, req.Reason)
+		}
 	} else {
 		// No change
 		return &UpdateStockResult{
 			Success:       true,
 			OldStockLevel: oldStockLevel,
 			NewStockLevel: oldStockLevel,
+			Unit:          item.Unit(),
 			UpdatedAt:     time.Now(),
 			Message:       "No stock change applied",
 		}, nil
 	}
 
 	if err != nil {
+		s.observeStockInvariant(err)
 		s.logger.Warn("Failed to update stock",
 			"sku", req.SKU,
 			"quantityChange", req.QuantityChange,
@@ -894,12 +1267,18 @@ func (s *inventoryService) UpdateStock(ctx context.Context, req UpdateStockReque
 		"sku", req.SKU,
 		"oldStock", oldStockLevel,
 		"newStock", newStockLevel,
+		"unit", item.Unit(),
 		"change", req.QuantityChange)
 
+	if req.QuantityChange > 0 {
+		s.fulfillBackorders(ctx, item.SKU())
+	}
+
 	return &UpdateStockResult{
 		Success:       true,
 		OldStockLevel: oldStockLevel,
 		NewStockLevel: newStockLevel,
+		Unit:          item.Unit(),
 		UpdatedAt:     updatedAt,
 		Message:       "Stock updated successfully",
 	}, nil
@@ -947,13 +1326,30 @@ func (s *inventoryService) GetLowStockItems(ctx context.Context, req GetLowStock
 		daysOfStock := 0
 		if item.StockLevel() > 0 {
 			// This is a simplified calculation - in reality you'd use historical usage data
-			daysOfStock = item.StockLevel() / max(1, item.MinStockLevel()/30) // Assume min stock lasts 30 days
+			daysOfStock = int(item.StockLevel() / math.Max(1, math.Floor(item.MinStockLevel()/30))) // Assume min stock lasts 30 days
+		}
+
+		incomingQuantity, expectedArrival, err := s.incomingStock(item.SKU())
+		if err != nil {
+			// Replenishment data is informational; report the item without it
+			s.logger.Warn("Failed to load incoming stock", "sku", item.SKU(), "error", err)
 		}
 
 		lowStockItems[i] = LowStockItemDTO{
 			Item:             s.convertDomainToDTO(item),
 			ShortageQuantity: shortageQuantity,
 			DaysOfStock:      daysOfStock,
+			IncomingQuantity: incomingQuantity,
+			ExpectedArrival:  expectedArrival,
+			RestockQuantity:  s.restockQuantity(item),
+		}
+		if supplier := s.chooseSupplier(item.SKU()); supplier != nil {
+			expectedRestockAt := supplier.ExpectedDelivery(time.Now())
+			lowStockItems[i].RestockQuantity = supplier.OrderQuantity(lowStockItems[i].RestockQuantity)
+			lowStockItems[i].Supplier = supplier.Supplier()
+			lowStockItems[i].SupplierSKU = supplier.Settings().SupplierSKU
+			lowStockItems[i].LeadTime = supplier.LeadTime()
+			lowStockItems[i].ExpectedRestockAt = &expectedRestockAt
 		}
 	}
 
@@ -1017,6 +1413,21 @@ func (s *inventoryService) GetItemsByCategory(ctx context.Context, req GetItemsB
 	}, nil
 }
 
+// GetAvailabilitySummary returns per-category stock counts, reserved quantity and
+// valuation. The repository aggregates them, so items are never loaded.
+func (s *inventoryService) GetAvailabilitySummary(ctx context.Context) (*GetAvailabilitySummaryResult, error) {
+	categories, err := s.repository.AvailabilitySummary()
+	if err != nil {
+		s.logger.Error("Failed to summarize availability", "error", err)
+		return nil, fmt.Errorf("failed to summarize availability: %w", err)
+	}
+
+	return &GetAvailabilitySummaryResult{
+		Categories:  categories,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
 // CleanupExpiredReservations removes expired reservations across all items
 func (s *inventoryService) CleanupExpiredReservations(ctx context.Context) (*CleanupResult, error) {
 	s.logger.Info("Starting cleanup of expired reservations")
@@ -1032,7 +1443,8 @@ func (s *inventoryService) CleanupExpiredReservations(ctx context.Context) (*Cle
 	affectedItems := make([]string, 0)
 
 	for _, item := range items {
-		expiredOrders := item.CleanupExpiredReservations()
+		expiredOrders, err := item.CleanupExpiredReservations()
+		s.observeStockInvariant(err)
 		if len(expiredOrders) > 0 {
 			totalCleaned += len(expiredOrders)
 			affectedItems = append(affectedItems, item.SKU())
@@ -1062,12 +1474,9 @@ func (s *inventoryService) CleanupExpiredReservations(ctx context.Context) (*Cle
 	}, nil
 }
 
-// Helper function for max
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
+// formatQuantity renders a quantity with its unit for messages, e.g. "1250.5 l"
+func formatQuantity(quantity float64, unit domain.UnitOfMeasure) string {
+	return strconv.FormatFloat(quantity, 'f', -1, 64) + " " + string(unit)
 }
 
 // Helper methods
@@ -1079,6 +1488,9 @@ func (s *inventoryService) validateAvailabilityCheck(item ItemAvailabilityCheck)
 	if item.Quantity <= 0 {
 		return fmt.Errorf("quantity must be positive")
 	}
+	if item.Unit != "" && !item.Unit.IsValid() {
+		return fmt.Errorf("unknown unit of measure %q", item.Unit)
+	}
 	return nil
 }
 
@@ -1089,12 +1501,9 @@ func (s *inventoryService) validateReserveItemsRequest(req ReserveItemsRequest)
 	if len(req.Items) == 0 {
 		return fmt.Errorf("at least one item is required")
 	}
-	if req.ReservationDurationMinutes <= 0 {
-		req.ReservationDurationMinutes = s.config.Inventory.MaxReservationTimeMin
-	}
-	if req.ReservationDurationMinutes > s.config.Inventory.MaxReservationTimeMin {
-		return fmt.Errorf("reservation duration exceeds maximum allowed (%d minutes)",
-			s.config.Inventory.MaxReservationTimeMin)
+	// Durations are capped per item by its category's reservation TTL
+	if req.ReservationDurationMinutes < 0 {
+		return fmt.Errorf("reservation duration cannot be negative")
 	}
 
 	for _, item := range req.Items {
@@ -1104,6 +1513,9 @@ func (s *inventoryService) validateReserveItemsRequest(req ReserveItemsRequest)
 		if item.Quantity <= 0 {
 			return fmt.Errorf("quantity must be positive for all items")
 		}
+		if item.Unit != "" && !item.Unit.IsValid() {
+			return fmt.Errorf("unknown unit of measure %q for item %s", item.Unit, item.SKU)
+		}
 	}
 
 	return nil
@@ -1117,12 +1529,13 @@ func (s *inventoryService) generateReservationID(orderID string) string {
 func (s *inventoryService) releasePartialReservations(orderID string, results []ItemReservationResult) {
 	for _, result := range results {
 		if result.Reserved {
-			item, err := s.repository.FindBySKU(result.SKU)
+			item, err := s.repository.FindBySKU(result.reservedSKU())
 			if err != nil || item == nil {
 				continue
 			}
 
 			if err := item.ReleaseReservation(orderID); err != nil {
+				s.observeStockInvariant(err)
 				s.logger.Error("Failed to release partial reservation",
 					"sku", result.SKU,
 					"orderID", orderID,
@@ -1143,6 +1556,7 @@ func (s *inventoryService) convertDomainToDTO(item *domain.InventoryItem) Invent
 		Name:           item.Name(),
 		Description:    item.Description(),
 		Category:       item.Category(),
+		Unit:           item.Unit(),
 		StockLevel:     item.StockLevel(),
 		ReservedStock:  item.ReservedStock(),
 		TotalStock:     item.TotalStock(),
@@ -1156,5 +1570,6 @@ func (s *inventoryService) convertDomainToDTO(item *domain.InventoryItem) Invent
 		UpdatedAt:      item.UpdatedAt(),
 		Version:        item.Version(),
 		Status:         item.Status(),
+		ReplacementSKU: item.ReplacementSKU(),
 	}
 }
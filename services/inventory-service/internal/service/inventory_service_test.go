@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/fakes"
+)
+
+func newTestInventoryService(repository *fakes.InventoryRepository) InventoryService {
+	cfg := &config.Config{}
+	cfg.Inventory.MaxReservationTimeMin = 30
+	return NewInventoryService(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)), repository)
+}
+
+func TestReserveItemsHoldsStockUntilReleased(t *testing.T) {
+	ctx := context.Background()
+	item := fakes.NewItem().WithStock(10).Build()
+	repository := fakes.NewInventoryRepository(item)
+	inventory := newTestInventoryService(repository)
+
+	reserve := func(orderID string) *ReserveItemsResult {
+		t.Helper()
+		result, err := inventory.ReserveItems(ctx, ReserveItemsRequest{
+			OrderID: orderID,
+			Items:   []ItemReservationRequest{{SKU: item.SKU(), Quantity: 6}},
+		})
+		if err != nil {
+			t.Fatalf("ReserveItems(%s): %v", orderID, err)
+		}
+		return result
+	}
+
+	if result := reserve("order-1"); !result.Success {
+		t.Fatalf("first reservation failed: %s", result.Message)
+	}
+	if result := reserve("order-2"); result.Success {
+		t.Fatal("second reservation succeeded with 4 of 10 left, want it refused")
+	}
+	if stored, _ := repository.Get(item.ID()); stored.ReservedStock() != 6 {
+		t.Fatalf("reserved stock %v after a refused reservation, want 6", stored.ReservedStock())
+	}
+
+	if result, err := inventory.ReleaseReservation(ctx, ReleaseReservationRequest{OrderID: "order-1", Reason: "payment failed"}); err != nil || !result.Success {
+		t.Fatalf("ReleaseReservation: %v, %+v", err, result)
+	}
+	if result := reserve("order-2"); !result.Success {
+		t.Fatalf("reservation after the release failed: %s", result.Message)
+	}
+}
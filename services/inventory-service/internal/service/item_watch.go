@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// MaxWatchedSKUs bounds the number of SKUs a single subscription may name
+const MaxWatchedSKUs = 500
+
+// ErrWatchNotConfigured is returned by WatchItems when no item watcher is configured
+var ErrWatchNotConfigured = errors.New("item watching is not configured")
+
+// ItemChangeType tells subscribers why a change was sent
+type ItemChangeType string
+
+const (
+	ItemChangeSnapshot ItemChangeType = "snapshot" // Current state when the subscription starts
+	ItemChangeUpdated  ItemChangeType = "updated"  // Stock, price or status changed
+	ItemChangeDeleted  ItemChangeType = "deleted"  // Item was removed
+)
+
+// ItemChange is the stock and price state of an item after a change
+type ItemChange struct {
+	Type          ItemChangeType
+	SKU           string
+	Unit          domain.UnitOfMeasure
+	StockLevel    float64
+	ReservedStock float64
+	UnitPrice     domain.Money
+	Status        domain.ItemStatus
+	Version       int
+	ChangedAt     time.Time
+}
+
+// WithItemWatcher publishes every saved or deleted item to the watcher, enabling WatchItems
+func WithItemWatcher(watcher *ItemWatcher) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.watcher = watcher
+		s.repository = &watchedRepository{InventoryRepository: s.repository, watcher: watcher}
+	}
+}
+
+// ItemWatcher fans item changes out to subscribers within this instance
+type ItemWatcher struct {
+	mu          sync.RWMutex
+	subscribers map[*ItemSubscription]struct{}
+}
+
+// NewItemWatcher creates a watcher without subscribers
+func NewItemWatcher() *ItemWatcher {
+	return &ItemWatcher{subscribers: make(map[*ItemSubscription]struct{})}
+}
+
+// Subscribe registers interest in the given SKUs; no SKUs means every item
+func (w *ItemWatcher) Subscribe(skus []string) *ItemSubscription {
+	sub := &ItemSubscription{
+		watcher: w,
+		pending: make(map[string]ItemChange),
+		ready:   make(chan struct{}, 1),
+	}
+	if len(skus) > 0 {
+		sub.skus = make(map[string]bool, len(skus))
+		for _, sku := range skus {
+			sub.skus[sku] = true
+		}
+	}
+
+	w.mu.Lock()
+	w.subscribers[sub] = struct{}{}
+	w.mu.Unlock()
+	return sub
+}
+
+// Publish delivers a change to every subscriber watching its SKU
+func (w *ItemWatcher) Publish(change ItemChange) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	for sub := range w.subscribers {
+		if sub.skus == nil || sub.skus[change.SKU] {
+			sub.push(change)
+		}
+	}
+}
+
+// SubscriberCount returns the number of open subscriptions
+func (w *ItemWatcher) SubscriberCount() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return len(w.subscribers)
+}
+
+// ItemSubscription buffers changes for one subscriber. Only the latest change
+// per SKU is kept, so a slow subscriber skips intermediate states instead of
+// holding up publishers or growing without bound.
+type ItemSubscription struct {
+	watcher *ItemWatcher
+	skus    map[string]bool // nil watches every item
+
+	mu      sync.Mutex
+	pending map[string]ItemChange
+	order   []string // SKUs in the order their first pending change arrived
+	ready   chan struct{}
+}
+
+func (s *ItemSubscription) push(change ItemChange) {
+	s.mu.Lock()
+	previous, exists := s.pending[change.SKU]
+	switch {
+	case !exists:
+		s.order = append(s.order, change.SKU)
+		s.pending[change.SKU] = change
+	case change.Version >= previous.Version || change.Type == ItemChangeDeleted:
+		s.pending[change.SKU] = change
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Next blocks until changes are available and returns them, oldest SKU first
+func (s *ItemSubscription) Next(ctx context.Context) ([]ItemChange, error) {
+	for {
+		s.mu.Lock()
+		if len(s.order) > 0 {
+			changes := make([]ItemChange, 0, len(s.order))
+			for _, sku := range s.order {
+				changes = append(changes, s.pending[sku])
+			}
+			s.order = s.order[:0]
+			clear(s.pending)
+			s.mu.Unlock()
+			return changes, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Close unregisters the subscription
+func (s *ItemSubscription) Close() {
+	s.watcher.mu.Lock()
+	delete(s.watcher.subscribers, s)
+	s.watcher.mu.Unlock()
+}
+
+// WatchItems subscribes to changes of the given SKUs. Unless skipSnapshot is
+// set, the current state of each watched item is queued first; the
+// subscription is registered before the snapshot is read so no change is missed.
+func (s *inventoryService) WatchItems(ctx context.Context, skus []string, skipSnapshot bool) (*ItemSubscription, error) {
+	if s.watcher == nil {
+		return nil, ErrWatchNotConfigured
+	}
+	if len(skus) > MaxWatchedSKUs {
+		return nil, fmt.Errorf("cannot watch more than %d SKUs", MaxWatchedSKUs)
+	}
+
+	sub := s.watcher.Subscribe(skus)
+	if skipSnapshot {
+		return sub, nil
+	}
+
+	var items []*domain.InventoryItem
+	if len(skus) == 0 {
+		all, err := s.repository.Search("")
+		if err != nil {
+			sub.Close()
+			return nil, fmt.Errorf("failed to load items: %w", err)
+		}
+		items = all
+	} else {
+		for _, sku := range skus {
+			item, err := s.repository.FindBySKU(sku)
+			if err != nil {
+				sub.Close()
+				return nil, fmt.Errorf("failed to load item %s: %w", sku, err)
+			}
+			if item != nil {
+				items = append(items, item)
+			}
+		}
+	}
+
+	for _, item := range items {
+		change := itemToChange(item, ItemChangeSnapshot)
+		change.ChangedAt = item.UpdatedAt()
+		sub.push(change)
+	}
+
+	return sub, nil
+}
+
+// watchedRepository publishes successful writes to the item watcher
+type watchedRepository struct {
+	domain.InventoryRepository
+	watcher *ItemWatcher
+}
+
+func (r *watchedRepository) Save(item *domain.InventoryItem) error {
+	if err := r.InventoryRepository.Save(item); err != nil {
+		return err
+	}
+	r.watcher.Publish(itemToChange(item, ItemChangeUpdated))
+	return nil
+}
+
+func (r *watchedRepository) Delete(id string) error {
+	item, err := r.InventoryRepository.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if err := r.InventoryRepository.Delete(id); err != nil {
+		return err
+	}
+	if item != nil {
+		r.watcher.Publish(itemToChange(item, ItemChangeDeleted))
+	}
+	return nil
+}
+
+func itemToChange(item *domain.InventoryItem, changeType ItemChangeType) ItemChange {
+	return ItemChange{
+		Type:          changeType,
+		SKU:           item.SKU(),
+		Unit:          item.Unit(),
+		StockLevel:    item.StockLevel(),
+		ReservedStock: item.ReservedStock(),
+		UnitPrice:     item.UnitPrice(),
+		Status:        item.Status(),
+		Version:       item.Version(),
+		ChangedAt:     time.Now(),
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// WithStockMovementRepository enables the stock movement ledger backed by the given repository
+func WithStockMovementRepository(movements domain.StockMovementRepository) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.movements = movements
+	}
+}
+
+// ErrStockMovementsNotConfigured is returned by ledger operations when no stock movement repository is configured
+var ErrStockMovementsNotConfigured = errors.New("stock movement ledger is not configured")
+
+// Parts consumption DTOs
+
+type ConsumePartsRequest struct {
+	EventID    string // Assembly event reporting the consumption; redelivered events are recorded once
+	OrderID    string
+	AssemblyID string
+	Parts      []ConsumedPart
+	ConsumedAt time.Time
+}
+
+type ConsumedPart struct {
+	SKU           string
+	Quantity      float64 // In the item's unit
+	SerialNumbers []string
+}
+
+type ConsumePartsResult struct {
+	OrderID    string              `json:"order_id"`
+	Recorded   int                 `json:"recorded"`
+	Duplicates int                 `json:"duplicates"` // Parts already recorded for the event
+	Parts      []ConsumedPartDTO   `json:"parts"`
+	Stock      []domain.OrderStock `json:"stock"` // The order's stock after the consumption
+}
+
+type ConsumedPartDTO struct {
+	SKU      string               `json:"sku"`
+	Quantity float64              `json:"quantity"`
+	Unit     domain.UnitOfMeasure `json:"unit,omitempty"`
+	Recorded bool                 `json:"recorded"`
+	Reason   string               `json:"reason,omitempty"`
+}
+
+type StockMovementDTO struct {
+	ID            string               `json:"id"`
+	Type          string               `json:"type"`
+	SKU           string               `json:"sku"`
+	Quantity      float64              `json:"quantity"`
+	Unit          domain.UnitOfMeasure `json:"unit"`
+	SerialNumbers []string             `json:"serial_numbers,omitempty"`
+	Reference     string               `json:"reference,omitempty"`
+	OccurredAt    time.Time            `json:"occurred_at"`
+}
+
+type OrderStockMovementsDTO struct {
+	OrderID   string              `json:"order_id"`
+	Movements []StockMovementDTO  `json:"movements"`
+	Stock     []domain.OrderStock `json:"stock"`
+}
+
+// ConsumeParts books the parts assembly built into an order's rocket out of the
+// stock confirmed for the order. Parts of unknown items are reported rather than
+// failing the whole consumption, since redelivering the event cannot fix them.
+func (s *inventoryService) ConsumeParts(ctx context.Context, req ConsumePartsRequest) (*ConsumePartsResult, error) {
+	if s.movements == nil {
+		return nil, ErrStockMovementsNotConfigured
+	}
+	if req.OrderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+	if req.EventID == "" {
+		return nil, domain.ErrInvalidMovementSource
+	}
+
+	s.logger.Info("Recording parts consumption",
+		"orderID", req.OrderID,
+		"assemblyID", req.AssemblyID,
+		"eventID", req.EventID,
+		"parts", len(req.Parts))
+
+	consumedAt := req.ConsumedAt
+	if consumedAt.IsZero() {
+		consumedAt = time.Now()
+	}
+
+	result := &ConsumePartsResult{OrderID: req.OrderID}
+	for _, part := range req.Parts {
+		dto := ConsumedPartDTO{SKU: part.SKU, Quantity: part.Quantity}
+
+		item, err := s.repository.FindBySKU(part.SKU)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find item %s: %w", part.SKU, err)
+		}
+		if item == nil {
+			dto.Reason = domain.ErrItemNotFound.Error()
+			result.Parts = append(result.Parts, dto)
+			continue
+		}
+		dto.Unit = item.Unit()
+
+		movement, err := domain.NewStockMovement(domain.MovementConsumed, item.SKU(), req.OrderID, req.EventID, part.Quantity, item.Unit())
+		if err != nil {
+			dto.Reason = err.Error()
+			result.Parts = append(result.Parts, dto)
+			continue
+		}
+		movement.SerialNumbers = part.SerialNumbers
+		movement.Reference = req.AssemblyID
+		movement.OccurredAt = consumedAt
+
+		switch err := s.movements.Record(movement); {
+		case errors.Is(err, domain.ErrStockMovementAlreadyExists):
+			result.Duplicates++
+			dto.Reason = "already recorded"
+		case err != nil:
+			return nil, err
+		default:
+			result.Recorded++
+			dto.Recorded = true
+		}
+		result.Parts = append(result.Parts, dto)
+	}
+
+	movements, err := s.movements.FindByOrder(req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	result.Stock = domain.SummarizeOrderStock(movements)
+
+	for _, stock := range result.Stock {
+		if unconfirmed := stock.Unconfirmed(); unconfirmed > 0 {
+			s.logger.Warn("Order consumed more stock than was confirmed",
+				"orderID", req.OrderID,
+				"sku", stock.SKU,
+				"confirmed", stock.Confirmed,
+				"consumed", stock.Consumed,
+				"unconfirmed", unconfirmed)
+		}
+	}
+
+	s.logger.Info("Parts consumption recorded",
+		"orderID", req.OrderID,
+		"recorded", result.Recorded,
+		"duplicates", result.Duplicates)
+
+	return result, nil
+}
+
+// GetOrderStockMovements retrieves the stock movements of an order with its
+// confirmed and consumed stock per item
+func (s *inventoryService) GetOrderStockMovements(ctx context.Context, orderID string) (*OrderStockMovementsDTO, error) {
+	if s.movements == nil {
+		return nil, ErrStockMovementsNotConfigured
+	}
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+
+	movements, err := s.movements.FindByOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &OrderStockMovementsDTO{
+		OrderID:   orderID,
+		Movements: make([]StockMovementDTO, 0, len(movements)),
+		Stock:     domain.SummarizeOrderStock(movements),
+	}
+	for _, movement := range movements {
+		dto.Movements = append(dto.Movements, StockMovementDTO{
+			ID:            movement.ID,
+			Type:          string(movement.Type),
+			SKU:           movement.SKU,
+			Quantity:      movement.Quantity,
+			Unit:          movement.Unit,
+			SerialNumbers: movement.SerialNumbers,
+			Reference:     movement.Reference,
+			OccurredAt:    movement.OccurredAt,
+		})
+	}
+	if dto.Stock == nil {
+		dto.Stock = []domain.OrderStock{}
+	}
+	return dto, nil
+}
+
+// recordConfirmation records stock confirmed for an order in the movement ledger,
+// with the cost of the goods sold. The confirmation itself already succeeded, so a
+// failure is only logged.
+func (s *inventoryService) recordConfirmation(item *domain.InventoryItem, orderID, reservationID string, quantity float64, serialNumbers []string, cost domain.CostOfGoods, confirmedAt time.Time) {
+	if s.movements == nil {
+		return
+	}
+
+	movement, err := domain.NewStockMovement(domain.MovementConfirmed, item.SKU(), orderID, reservationID, quantity, item.Unit())
+	if err == nil {
+		movement.SerialNumbers = serialNumbers
+		movement.Reference = reservationID
+		movement.OccurredAt = confirmedAt
+		movement.Cost = &cost
+		err = s.movements.Record(movement)
+	}
+	if err != nil && !errors.Is(err, domain.ErrStockMovementAlreadyExists) {
+		s.logger.Error("Failed to record confirmed stock movement",
+			"orderID", orderID,
+			"sku", item.SKU(),
+			"error", err)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// ReservationPreemptedEvent tells the owner of a preempted reservation that its stock was taken
+type ReservationPreemptedEvent struct {
+	OrderID            string // Order whose reservation was preempted
+	PreemptedByOrderID string // Expedited order that took the stock
+	ReservationID      string
+	ItemID             string
+	SKU                string
+	Quantity           float64
+	Unit               domain.UnitOfMeasure
+	PreemptedAt        time.Time
+}
+
+// ReservationEventPublisher publishes compensating events for preempted reservations
+// and tells backordered orders that their stock is reserved
+type ReservationEventPublisher interface {
+	PublishReservationPreempted(ctx context.Context, event ReservationPreemptedEvent) error
+	PublishBackorderReserved(ctx context.Context, event BackorderReservedEvent) error
+}
+
+// WithReservationEventPublisher notifies preempted orders through the given publisher.
+// Preemption still happens without a publisher, but the preempted orders then only
+// learn about it when their reservation can no longer be confirmed.
+func WithReservationEventPublisher(publisher ReservationEventPublisher) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.reservationEvents = publisher
+	}
+}
+
+// shouldPreempt decides whether a reservation request may preempt other reservations.
+// Preemption is only attempted when every item can be covered by available plus
+// preemptible stock, so an order that fails anyway does not take stock from others.
+func (s *inventoryService) shouldPreempt(items []ItemReservationRequest, priority domain.ReservationPriority) bool {
+	if !s.config.Inventory.PreemptionEnabled || priority == domain.ReservationPriorityStandard {
+		return false
+	}
+
+	for _, item := range items {
+		inventoryItem, err := s.repository.FindBySKU(item.SKU)
+		if err != nil || inventoryItem == nil {
+			return false
+		}
+		quantity, err := inventoryItem.ToStockUnit(item.Quantity, item.Unit)
+		if err != nil {
+			return false
+		}
+		preemptible := inventoryItem.PreemptibleStock(priority, s.config.Inventory.PreemptionWindow)
+		if quantity > inventoryItem.GetAvailableStock()+preemptible {
+			return false
+		}
+	}
+	return true
+}
+
+// preemptForReservation frees stock for the order by preempting lower-priority
+// reservations nearing expiry, then reserves it
+func (s *inventoryService) preemptForReservation(
+	inventoryItem *domain.InventoryItem,
+	orderID string,
+	quantity float64,
+	durationMinutes int,
+	priority domain.ReservationPriority,
+) (*domain.Reservation, []*domain.Reservation, error) {
+	preempted, err := inventoryItem.PreemptReservations(quantity, priority, s.config.Inventory.PreemptionWindow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reservation, err := inventoryItem.ReserveStock(orderID, quantity, durationMinutes, priority)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reservation, preempted, nil
+}
+
+// publishPreemptions sends a compensating event for every preempted reservation
+func (s *inventoryService) publishPreemptions(
+	ctx context.Context,
+	inventoryItem *domain.InventoryItem,
+	orderID string,
+	preempted []*domain.Reservation,
+) {
+	for _, reservation := range preempted {
+		s.logger.Warn("Reservation preempted by expedited order",
+			"sku", inventoryItem.SKU(),
+			"orderID", reservation.OrderID(),
+			"preemptedByOrderID", orderID,
+			"reservationID", reservation.ID(),
+			"quantity", reservation.Quantity(),
+			"expiresAt", reservation.ExpiresAt())
+
+		if s.reservationEvents == nil {
+			continue
+		}
+
+		event := ReservationPreemptedEvent{
+			OrderID:            reservation.OrderID(),
+			PreemptedByOrderID: orderID,
+			ReservationID:      reservation.ID(),
+			ItemID:             inventoryItem.ID(),
+			SKU:                inventoryItem.SKU(),
+			Quantity:           reservation.Quantity(),
+			Unit:               inventoryItem.Unit(),
+			PreemptedAt:        time.Now(),
+		}
+		if err := s.reservationEvents.PublishReservationPreempted(ctx, event); err != nil {
+			s.logger.Error("Failed to publish reservation preempted event",
+				"orderID", reservation.OrderID(),
+				"sku", inventoryItem.SKU(),
+				"error", err)
+		}
+	}
+}
@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// WithPurchaseOrderRepository enables the warehouse receiving workflow backed by the given repository
+func WithPurchaseOrderRepository(purchaseOrders domain.PurchaseOrderRepository) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.purchaseOrders = purchaseOrders
+	}
+}
+
+// ErrPurchaseOrdersNotConfigured is returned by purchase order operations when no repository is configured
+var ErrPurchaseOrdersNotConfigured = errors.New("purchase orders are not configured")
+
+// Purchase order DTOs
+
+type CreatePurchaseOrderRequest struct {
+	Supplier        string
+	Lines           []domain.PurchaseOrderLine
+	ExpectedArrival *time.Time
+	CreatedBy       string
+}
+
+type MarkPurchaseOrderInTransitRequest struct {
+	ID              string
+	ExpectedArrival *time.Time // Overrides the arrival estimate given at creation
+	TrackingRef     string
+}
+
+type ReceivePurchaseOrderRequest struct {
+	ID         string
+	Lines      []ReceiptLine
+	ReceivedBy string
+}
+
+type ReceiptLine struct {
+	SKU      string
+	Quantity int
+}
+
+type PurchaseOrderDTO struct {
+	ID              string                    `json:"id"`
+	Supplier        string                    `json:"supplier"`
+	Status          string                    `json:"status"`
+	Lines           []PurchaseOrderLineDTO    `json:"lines"`
+	Receipts        []PurchaseOrderReceiptDTO `json:"receipts"`
+	ExpectedArrival *time.Time                `json:"expected_arrival,omitempty"`
+	TrackingRef     string                    `json:"tracking_ref,omitempty"`
+	CreatedBy       string                    `json:"created_by,omitempty"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
+	Version         int                       `json:"version"`
+}
+
+type PurchaseOrderLineDTO struct {
+	SKU                 string  `json:"sku"`
+	OrderedQuantity     int     `json:"ordered_quantity"`
+	ReceivedQuantity    int     `json:"received_quantity"`
+	OutstandingQuantity int     `json:"outstanding_quantity"`
+	UnitCost            float64 `json:"unit_cost,omitempty"`
+	UnitCostMinor       int64   `json:"unit_cost_minor,omitempty"`
+	Currency            string  `json:"currency,omitempty"`
+}
+
+type PurchaseOrderReceiptDTO struct {
+	SKU        string    `json:"sku"`
+	Quantity   int       `json:"quantity"`
+	ReceivedBy string    `json:"received_by,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// CreatePurchaseOrder records a new inbound purchase order
+func (s *inventoryService) CreatePurchaseOrder(ctx context.Context, req CreatePurchaseOrderRequest) (*PurchaseOrderDTO, error) {
+	if s.purchaseOrders == nil {
+		return nil, ErrPurchaseOrdersNotConfigured
+	}
+
+	s.logger.Info("Creating purchase order",
+		"supplier", req.Supplier,
+		"lines", len(req.Lines),
+		"createdBy", req.CreatedBy)
+
+	// Only stocked items can be ordered, at costs in a currency their category allows
+	for _, line := range req.Lines {
+		item, err := s.repository.FindBySKU(line.SKU)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find item: %w", err)
+		}
+		if item == nil {
+			return nil, fmt.Errorf("%w: %s", domain.ErrItemNotFound, line.SKU)
+		}
+		if err := s.checkCurrency(item, line.UnitCost.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	po, err := domain.NewPurchaseOrder(req.Supplier, req.Lines, req.ExpectedArrival, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.purchaseOrders.Create(po); err != nil {
+		return nil, err
+	}
+
+	dto := convertPurchaseOrderToDTO(po)
+	return &dto, nil
+}
+
+// GetPurchaseOrder retrieves a purchase order with its receipts
+func (s *inventoryService) GetPurchaseOrder(ctx context.Context, id string) (*PurchaseOrderDTO, error) {
+	po, err := s.findPurchaseOrder(id)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := convertPurchaseOrderToDTO(po)
+	return &dto, nil
+}
+
+// ListPurchaseOrders retrieves purchase orders, optionally filtered by status
+func (s *inventoryService) ListPurchaseOrders(ctx context.Context, status *domain.PurchaseOrderStatus) ([]PurchaseOrderDTO, error) {
+	if s.purchaseOrders == nil {
+		return nil, ErrPurchaseOrdersNotConfigured
+	}
+
+	orders, err := s.purchaseOrders.FindAll(status)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]PurchaseOrderDTO, 0, len(orders))
+	for _, po := range orders {
+		dtos = append(dtos, convertPurchaseOrderToDTO(po))
+	}
+	return dtos, nil
+}
+
+// MarkPurchaseOrderInTransit records that a purchase order has shipped. Out-of-stock items
+// on the order are flagged as incoming until the goods are received.
+func (s *inventoryService) MarkPurchaseOrderInTransit(ctx context.Context, req MarkPurchaseOrderInTransitRequest) (*PurchaseOrderDTO, error) {
+	po, err := s.findPurchaseOrder(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	loadedVersion := po.Version()
+	if err := po.MarkInTransit(req.ExpectedArrival, req.TrackingRef); err != nil {
+		return nil, err
+	}
+	if err := s.purchaseOrders.Update(po, loadedVersion); err != nil {
+		return nil, err
+	}
+
+	for _, line := range po.Lines() {
+		item, err := s.repository.FindBySKU(line.SKU)
+		if err != nil || item == nil {
+			s.logger.Warn("Failed to flag item as incoming", "sku", line.SKU, "poID", po.ID(), "error", err)
+			continue
+		}
+
+		before := item.Status()
+		item.MarkIncoming()
+		if item.Status() == before {
+			continue
+		}
+		if err := s.repository.Save(item); err != nil {
+			s.logger.Error("Failed to save incoming item status", "sku", line.SKU, "poID", po.ID(), "error", err)
+		}
+	}
+
+	s.logger.Info("Purchase order marked in transit",
+		"poID", po.ID(),
+		"trackingRef", po.TrackingRef(),
+		"expectedArrival", po.ExpectedArrival())
+
+	dto := convertPurchaseOrderToDTO(po)
+	return &dto, nil
+}
+
+// ReceivePurchaseOrder receives (possibly partial) quantities against a purchase order
+// and adds them to stock, at the unit cost of the line if it has one. The receipt is recorded on the purchase order before stock is
+// added, so a concurrent or retried receipt fails on the version check instead of
+// adding the same goods twice.
+func (s *inventoryService) ReceivePurchaseOrder(ctx context.Context, req ReceivePurchaseOrderRequest) (*PurchaseOrderDTO, error) {
+	if len(req.Lines) == 0 {
+		return nil, domain.ErrInvalidQuantity
+	}
+
+	po, err := s.findPurchaseOrder(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	loadedVersion := po.Version()
+	for _, line := range req.Lines {
+		if err := po.Receive(line.SKU, line.Quantity, req.ReceivedBy); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, line.SKU)
+		}
+	}
+	if err := s.purchaseOrders.Update(po, loadedVersion); err != nil {
+		return nil, err
+	}
+
+	reason := fmt.Sprintf("purchase order %s receipt", po.ID())
+	unitCosts := make(map[string]domain.Money, len(po.Lines()))
+	for _, line := range po.Lines() {
+		unitCosts[line.SKU] = line.UnitCost
+	}
+	for _, line := range req.Lines {
+		item, err := s.repository.FindBySKU(line.SKU)
+		if err == nil && item == nil {
+			err = domain.ErrItemNotFound
+		}
+		if err == nil {
+			if unitCost := unitCosts[line.SKU]; unitCost.Currency != "" {
+				err = item.ReceiveStock(float64(line.Quantity), unitCost, reason)
+			} else {
+				err = item.AddStock(float64(line.Quantity), reason)
+			}
+		}
+		if err == nil {
+			err = s.repository.Save(item)
+		}
+		if err != nil {
+			s.observeStockInvariant(err)
+			// The receipt is already recorded; stock must be corrected manually
+			s.logger.Error("Failed to add received stock",
+				"poID", po.ID(),
+				"sku", line.SKU,
+				"quantity", line.Quantity,
+				"error", err)
+			return nil, fmt.Errorf("receipt recorded but stock update failed for %s: %w", line.SKU, err)
+		}
+
+		s.logger.Info("Purchase order stock received",
+			"poID", po.ID(),
+			"sku", line.SKU,
+			"quantity", line.Quantity,
+			"newStock", item.StockLevel())
+	}
+
+	received := make([]string, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		received = append(received, line.SKU)
+	}
+	s.fulfillBackorders(ctx, received...)
+
+	dto := convertPurchaseOrderToDTO(po)
+	return &dto, nil
+}
+
+// CancelPurchaseOrder cancels the outstanding part of a purchase order
+func (s *inventoryService) CancelPurchaseOrder(ctx context.Context, id string) (*PurchaseOrderDTO, error) {
+	po, err := s.findPurchaseOrder(id)
+	if err != nil {
+		return nil, err
+	}
+
+	loadedVersion := po.Version()
+	if err := po.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := s.purchaseOrders.Update(po, loadedVersion); err != nil {
+		return nil, err
+	}
+
+	// Items stay flagged as incoming only while another shipment is on its way
+	for _, line := range po.Lines() {
+		if inbound, err := s.hasInboundPurchaseOrder(line.SKU); err != nil || inbound {
+			continue
+		}
+
+		item, err := s.repository.FindBySKU(line.SKU)
+		if err != nil || item == nil || item.Status() != domain.ItemStatusIncoming {
+			continue
+		}
+		item.ClearIncoming()
+		if err := s.repository.Save(item); err != nil {
+			s.logger.Error("Failed to clear incoming item status", "sku", line.SKU, "poID", po.ID(), "error", err)
+		}
+	}
+
+	s.logger.Info("Purchase order cancelled", "poID", po.ID())
+
+	dto := convertPurchaseOrderToDTO(po)
+	return &dto, nil
+}
+
+// findPurchaseOrder loads a purchase order, translating a missing one into ErrPurchaseOrderNotFound
+func (s *inventoryService) findPurchaseOrder(id string) (*domain.PurchaseOrder, error) {
+	if s.purchaseOrders == nil {
+		return nil, ErrPurchaseOrdersNotConfigured
+	}
+
+	po, err := s.purchaseOrders.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if po == nil {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+	return po, nil
+}
+
+// hasInboundPurchaseOrder reports whether a shipment of the item is still on its way
+func (s *inventoryService) hasInboundPurchaseOrder(sku string) (bool, error) {
+	orders, err := s.purchaseOrders.FindReceivableBySKU(sku)
+	if err != nil {
+		return false, err
+	}
+	for _, po := range orders {
+		if po.IsInbound() && po.OutstandingQuantity(sku) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// incomingStock returns the quantity of an item on open purchase orders and the earliest
+// expected arrival of a shipment that is in transit
+func (s *inventoryService) incomingStock(sku string) (int, *time.Time, error) {
+	if s.purchaseOrders == nil {
+		return 0, nil, nil
+	}
+
+	orders, err := s.purchaseOrders.FindReceivableBySKU(sku)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	quantity := 0
+	var earliest *time.Time
+	for _, po := range orders {
+		quantity += po.OutstandingQuantity(sku)
+
+		arrival := po.ExpectedArrival()
+		if !po.IsInbound() || arrival == nil {
+			continue
+		}
+		if earliest == nil || arrival.Before(*earliest) {
+			earliest = arrival
+		}
+	}
+	return quantity, earliest, nil
+}
+
+// convertPurchaseOrderToDTO converts a domain PurchaseOrder to a DTO
+func convertPurchaseOrderToDTO(po *domain.PurchaseOrder) PurchaseOrderDTO {
+	lines := make([]PurchaseOrderLineDTO, 0, len(po.Lines()))
+	for _, line := range po.Lines() {
+		lines = append(lines, PurchaseOrderLineDTO{
+			SKU:                 line.SKU,
+			OrderedQuantity:     line.OrderedQuantity,
+			ReceivedQuantity:    line.ReceivedQuantity,
+			OutstandingQuantity: po.OutstandingQuantity(line.SKU),
+			UnitCost:            line.UnitCost.Float64(),
+			UnitCostMinor:       line.UnitCost.Minor,
+			Currency:            line.UnitCost.Currency,
+		})
+	}
+
+	receipts := make([]PurchaseOrderReceiptDTO, 0, len(po.Receipts()))
+	for _, receipt := range po.Receipts() {
+		receipts = append(receipts, PurchaseOrderReceiptDTO{
+			SKU:        receipt.SKU,
+			Quantity:   receipt.Quantity,
+			ReceivedBy: receipt.ReceivedBy,
+			ReceivedAt: receipt.ReceivedAt,
+		})
+	}
+
+	return PurchaseOrderDTO{
+		ID:              po.ID(),
+		Supplier:        po.Supplier(),
+		Status:          po.Status().String(),
+		Lines:           lines,
+		Receipts:        receipts,
+		ExpectedArrival: po.ExpectedArrival(),
+		TrackingRef:     po.TrackingRef(),
+		CreatedBy:       po.CreatedBy(),
+		CreatedAt:       po.CreatedAt(),
+		UpdatedAt:       po.UpdatedAt(),
+		Version:         po.Version(),
+	}
+}
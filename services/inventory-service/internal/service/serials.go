@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// WithSerialRepository enables per-unit serial number tracking backed by the given repository
+func WithSerialRepository(serials domain.SerialRepository) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.serials = serials
+	}
+}
+
+// ErrSerialsNotConfigured is returned by serial operations when no serial repository is configured
+var ErrSerialsNotConfigured = errors.New("serial number tracking is not configured")
+
+// serialAllocationAttempts bounds retries when concurrent confirmations race for the same serials
+const serialAllocationAttempts = 3
+
+// Serial number DTOs
+
+type RegisterSerialsRequest struct {
+	SKU           string
+	SerialNumbers []string
+	RegisteredBy  string
+}
+
+type RegisterSerialsResult struct {
+	SKU        string `json:"sku"`
+	Registered int    `json:"registered"`
+	PoolSize   int    `json:"pool_size"` // Serials available for allocation after registration
+}
+
+type SerialNumberDTO struct {
+	SerialNumber string           `json:"serial_number"`
+	SKU          string           `json:"sku"`
+	Status       string           `json:"status"`
+	OrderID      string           `json:"order_id,omitempty"`
+	History      []SerialEventDTO `json:"history"`
+}
+
+type SerialEventDTO struct {
+	Status     string    `json:"status"`
+	OrderID    string    `json:"order_id,omitempty"`
+	Note       string    `json:"note,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// RegisterSerials adds serial numbers to an item's pool and marks the item as serial tracked
+func (s *inventoryService) RegisterSerials(ctx context.Context, req RegisterSerialsRequest) (*RegisterSerialsResult, error) {
+	if s.serials == nil {
+		return nil, ErrSerialsNotConfigured
+	}
+	if len(req.SerialNumbers) == 0 {
+		return nil, domain.ErrInvalidSerialNumber
+	}
+
+	s.logger.Info("Registering serial numbers",
+		"sku", req.SKU,
+		"count", len(req.SerialNumbers),
+		"registeredBy", req.RegisteredBy)
+
+	item, err := s.repository.FindBySKU(req.SKU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find item: %w", err)
+	}
+	if item == nil {
+		return nil, domain.ErrItemNotFound
+	}
+
+	serials := make([]*domain.SerialNumber, 0, len(req.SerialNumbers))
+	seen := make(map[string]bool, len(req.SerialNumbers))
+	for _, value := range req.SerialNumbers {
+		value = strings.TrimSpace(value)
+		if seen[value] {
+			return nil, fmt.Errorf("%w: %s", domain.ErrSerialAlreadyExists, value)
+		}
+		seen[value] = true
+
+		serial, err := domain.NewSerialNumber(value, item.SKU())
+		if err != nil {
+			return nil, err
+		}
+		serials = append(serials, serial)
+	}
+
+	if !item.SerialTracked() {
+		if err := item.SetSerialTracked(true); err != nil {
+			return nil, err
+		}
+		if err := s.repository.Save(item); err != nil {
+			return nil, fmt.Errorf("failed to enable serial tracking: %w", err)
+		}
+	}
+
+	if err := s.serials.Create(serials); err != nil {
+		return nil, err
+	}
+
+	poolSize, err := s.serials.CountAllocatable(item.SKU())
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegisterSerialsResult{
+		SKU:        item.SKU(),
+		Registered: len(serials),
+		PoolSize:   poolSize,
+	}, nil
+}
+
+// GetSerialHistory retrieves a serial number with its full history
+func (s *inventoryService) GetSerialHistory(ctx context.Context, serialNumber string) (*SerialNumberDTO, error) {
+	if s.serials == nil {
+		return nil, ErrSerialsNotConfigured
+	}
+
+	serial, err := s.serials.FindBySerial(serialNumber)
+	if err != nil {
+		return nil, err
+	}
+	if serial == nil {
+		return nil, domain.ErrSerialNotFound
+	}
+
+	dto := convertSerialToDTO(serial)
+	return &dto, nil
+}
+
+// GetOrderSerials retrieves the serial numbers allocated to an order
+func (s *inventoryService) GetOrderSerials(ctx context.Context, orderID string) ([]SerialNumberDTO, error) {
+	if s.serials == nil {
+		return nil, ErrSerialsNotConfigured
+	}
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+
+	serials, err := s.serials.FindByOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]SerialNumberDTO, 0, len(serials))
+	for _, serial := range serials {
+		dtos = append(dtos, convertSerialToDTO(serial))
+	}
+	return dtos, nil
+}
+
+// isSerialTracked reports whether units of the item must be allocated serial numbers
+func (s *inventoryService) isSerialTracked(item *domain.InventoryItem) bool {
+	if s.serials == nil {
+		return false
+	}
+	if item.SerialTracked() {
+		return true
+	}
+	for _, category := range s.config.Inventory.SerialTrackedCategories {
+		if category == item.Category().String() {
+			return true
+		}
+	}
+	return false
+}
+
+// allocateSerials allocates quantity serial numbers of the item to the order.
+// Serials already allocated to the order are reused, so retried confirmations are idempotent.
+// On failure every serial allocated by this call is returned to the pool.
+func (s *inventoryService) allocateSerials(item *domain.InventoryItem, orderID string, quantity int) ([]string, error) {
+	existing, err := s.serials.FindByOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	allocated := make([]string, 0, quantity)
+	for _, serial := range existing {
+		if serial.SKU() == item.SKU() && len(allocated) < quantity {
+			allocated = append(allocated, serial.Serial())
+		}
+	}
+
+	newlyAllocated := make([]*domain.SerialNumber, 0, quantity-len(allocated))
+	for attempt := 0; attempt < serialAllocationAttempts && len(allocated) < quantity; attempt++ {
+		candidates, err := s.serials.FindAllocatable(item.SKU(), quantity-len(allocated))
+		if err != nil {
+			s.returnSerials(newlyAllocated, "allocation rolled back")
+			return nil, err
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		for _, serial := range candidates {
+			if err := serial.Allocate(orderID); err != nil {
+				continue
+			}
+			if err := s.serials.SaveAllocation(serial); err != nil {
+				if errors.Is(err, domain.ErrSerialNotAvailable) {
+					continue // Taken by a concurrent confirmation; fetch more candidates
+				}
+				s.returnSerials(newlyAllocated, "allocation rolled back")
+				return nil, err
+			}
+			newlyAllocated = append(newlyAllocated, serial)
+			allocated = append(allocated, serial.Serial())
+		}
+	}
+
+	if len(allocated) < quantity {
+		s.returnSerials(newlyAllocated, "allocation rolled back")
+		return nil, fmt.Errorf("%w for %s (required: %d, allocated: %d)",
+			domain.ErrInsufficientSerials, item.SKU(), quantity, len(allocated))
+	}
+
+	return allocated, nil
+}
+
+// returnSerials puts allocated serials back into the pool
+func (s *inventoryService) returnSerials(serials []*domain.SerialNumber, note string) {
+	for _, serial := range serials {
+		if err := serial.Return(note); err != nil {
+			continue
+		}
+		if err := s.serials.Save(serial); err != nil {
+			s.logger.Error("Failed to return serial number to pool",
+				"serial", serial.Serial(),
+				"sku", serial.SKU(),
+				"error", err)
+		}
+	}
+}
+
+// returnSerialNumbers returns serials by value, used when a confirmation cannot be persisted
+func (s *inventoryService) returnSerialNumbers(values []string, note string) {
+	serials := make([]*domain.SerialNumber, 0, len(values))
+	for _, value := range values {
+		serial, err := s.serials.FindBySerial(value)
+		if err != nil || serial == nil {
+			continue
+		}
+		serials = append(serials, serial)
+	}
+	s.returnSerials(serials, note)
+}
+
+// convertSerialToDTO converts a domain SerialNumber to a DTO
+func convertSerialToDTO(serial *domain.SerialNumber) SerialNumberDTO {
+	history := make([]SerialEventDTO, 0, len(serial.History()))
+	for _, event := range serial.History() {
+		history = append(history, SerialEventDTO{
+			Status:     event.Status.String(),
+			OrderID:    event.OrderID,
+			Note:       event.Note,
+			OccurredAt: event.OccurredAt,
+		})
+	}
+
+	return SerialNumberDTO{
+		SerialNumber: serial.Serial(),
+		SKU:          serial.SKU(),
+		Status:       serial.Status().String(),
+		OrderID:      serial.OrderID(),
+		History:      history,
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// Stock consistency DTOs
+
+type CheckStockConsistencyRequest struct {
+	AutoRepair     bool    // Repair drifts up to MaxRepairDrift
+	MaxRepairDrift float64 // Largest drift repaired automatically
+}
+
+// ItemStockDrift is an item whose stock levels disagree with its reservations
+type ItemStockDrift struct {
+	domain.StockDrift
+	Repaired bool `json:"repaired"`
+}
+
+// StockConsistencyReport is the outcome of a stock consistency check
+type StockConsistencyReport struct {
+	CheckedAt      time.Time        `json:"checked_at"`
+	Duration       string           `json:"duration"`
+	ItemsChecked   int              `json:"items_checked"`
+	Drifts         []ItemStockDrift `json:"drifts"`
+	Repaired       int              `json:"repaired"`
+	Unrepaired     int              `json:"unrepaired"` // Drifts left for an operator; these raise the alarm
+	RepairFailures int              `json:"repair_failures"`
+}
+
+// CheckStockConsistency compares the stock levels of every item with its active
+// reservations. Small drifts are repaired if asked to; the rest are reported and
+// raise the stock drift alarm until an operator runs RepairItemStock.
+func (s *inventoryService) CheckStockConsistency(ctx context.Context, req CheckStockConsistencyRequest) (*StockConsistencyReport, error) {
+	start := time.Now()
+
+	items, err := s.repository.FindAllForRepair()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list items: %w", err)
+	}
+
+	report := &StockConsistencyReport{
+		CheckedAt:    start.UTC(),
+		ItemsChecked: len(items),
+		Drifts:       []ItemStockDrift{},
+	}
+
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		drift := ItemStockDrift{StockDrift: item.CheckStock()}
+		if !drift.Drifted() {
+			continue
+		}
+
+		if req.AutoRepair && drift.Drift <= req.MaxRepairDrift {
+			item.RepairStock()
+			if err := s.repository.Save(item); err != nil {
+				report.RepairFailures++
+				s.logger.Error("Failed to repair stock drift", "sku", drift.SKU, "error", err)
+			} else {
+				drift.Repaired = true
+				s.logger.Warn("Stock drift repaired",
+					"sku", drift.SKU,
+					"drift", drift.Drift,
+					"before", drift.Levels,
+					"after", drift.Expected)
+			}
+		}
+
+		if drift.Repaired {
+			report.Repaired++
+		} else {
+			report.Unrepaired++
+			s.logger.Error("ALARM: stock drift detected",
+				"alarm", "stock_drift",
+				"sku", drift.SKU,
+				"drift", drift.Drift,
+				"oversold", drift.Oversold,
+				"levels", drift.Levels,
+				"expected", drift.Expected)
+		}
+		report.Drifts = append(report.Drifts, drift)
+	}
+
+	report.Duration = time.Since(start).String()
+	s.lastConsistencyReport.Store(report)
+
+	s.logger.Info("Stock consistency check completed",
+		"itemsChecked", report.ItemsChecked,
+		"drifts", len(report.Drifts),
+		"repaired", report.Repaired,
+		"unrepaired", report.Unrepaired)
+
+	return report, nil
+}
+
+// LastStockConsistencyReport returns the report of the latest consistency check,
+// or nil if none has run since the service started
+func (s *inventoryService) LastStockConsistencyReport() *StockConsistencyReport {
+	return s.lastConsistencyReport.Load()
+}
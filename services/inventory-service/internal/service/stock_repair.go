@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// Stock repair DTOs
+
+type RepairItemStockRequest struct {
+	SKU        string
+	DryRun     bool // Report the repair without saving it
+	RepairedBy string
+}
+
+type RepairItemStockResult struct {
+	SKU    string             `json:"sku"`
+	Repair domain.StockRepair `json:"repair"`
+	DryRun bool               `json:"dry_run"`
+	Saved  bool               `json:"saved"`
+}
+
+// RepairItemStock recomputes an item's stock levels from its reservation ledger.
+// Items whose levels break the invariants are loaded and repaired too; that is
+// what the operation is for.
+func (s *inventoryService) RepairItemStock(ctx context.Context, req RepairItemStockRequest) (*RepairItemStockResult, error) {
+	if req.SKU == "" {
+		return nil, domain.ErrInvalidSKU
+	}
+
+	item, err := s.repository.FindBySKUForRepair(req.SKU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find item: %w", err)
+	}
+	if item == nil {
+		return nil, domain.ErrItemNotFound
+	}
+
+	repair := item.RepairStock()
+	result := &RepairItemStockResult{SKU: item.SKU(), Repair: repair, DryRun: req.DryRun}
+	if !repair.Changed || req.DryRun {
+		return result, nil
+	}
+
+	if err := s.repository.Save(item); err != nil {
+		return nil, fmt.Errorf("failed to save repaired item: %w", err)
+	}
+	result.Saved = true
+
+	s.logger.Warn("Stock levels repaired from reservation ledger",
+		"sku", item.SKU(),
+		"repairedBy", req.RepairedBy,
+		"before", repair.Before,
+		"after", repair.After,
+		"oversold", repair.Oversold)
+
+	return result, nil
+}
+
+// StockInvariantViolations returns how many stock invariant violations were detected
+// since the service started
+func (s *inventoryService) StockInvariantViolations() int64 {
+	return s.invariantViolations.Load()
+}
+
+// observeStockInvariant raises the stock invariant alarm if err reports a violation.
+// A violation means stock levels were corrupted, typically by concurrent writers,
+// and the item needs RepairItemStock.
+func (s *inventoryService) observeStockInvariant(err error) {
+	var violation *domain.StockInvariantError
+	if !errors.As(err, &violation) {
+		return
+	}
+
+	s.invariantViolations.Add(1)
+	s.logger.Error("ALARM: stock invariant violated",
+		"alarm", "stock_invariant",
+		"itemID", violation.ItemID,
+		"sku", violation.SKU,
+		"operation", violation.Operation,
+		"reason", violation.Reason,
+		"available", violation.Levels.Available,
+		"reserved", violation.Levels.Reserved,
+		"total", violation.Levels.Total)
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+)
+
+// WithItemSupplierRepository enables item suppliers backed by the given repository
+func WithItemSupplierRepository(suppliers domain.ItemSupplierRepository) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.suppliers = suppliers
+	}
+}
+
+// WithRestockEventPublisher publishes restock requests of low stock items through the given publisher
+func WithRestockEventPublisher(publisher RestockEventPublisher) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.restockEvents = publisher
+	}
+}
+
+// ErrItemSuppliersNotConfigured is returned by item supplier operations when no supplier repository is configured
+var ErrItemSuppliersNotConfigured = errors.New("item suppliers are not configured")
+
+// defaultRestockRetry is how long a restock request without a supplier lead time
+// is given before the item is requested again
+const defaultRestockRetry = 24 * time.Hour
+
+// RestockRequestedEvent asks purchasing to reorder a low stock item
+type RestockRequestedEvent struct {
+	SKU              string
+	Name             string
+	Quantity         float64 // Raised to the supplier's minimum order quantity
+	Unit             domain.UnitOfMeasure
+	StockLevel       float64
+	MinStockLevel    float64
+	Supplier         string        // Empty when the item has no suppliers
+	SupplierSKU      string        // The supplier's code for the item, if it has its own
+	LeadTime         time.Duration // Expected time from ordering to delivery
+	ExpectedDelivery *time.Time    // When the stock is expected if ordered now; nil without a supplier
+	UnitCost         domain.Money  // The supplier's cost per unit; its currency is empty if not known
+	RequestedAt      time.Time
+}
+
+// RestockEventPublisher publishes restock requests of low stock items
+type RestockEventPublisher interface {
+	PublishRestockRequested(ctx context.Context, event RestockRequestedEvent) error
+}
+
+// Item supplier DTOs
+
+type SaveItemSupplierRequest struct {
+	SKU      string
+	Supplier string
+	Settings domain.ItemSupplierSettings
+}
+
+type ItemSupplierDTO struct {
+	SKU              string    `json:"sku"`
+	Supplier         string    `json:"supplier"`
+	SupplierSKU      string    `json:"supplier_sku,omitempty"`
+	LeadTimeDays     int       `json:"lead_time_days"`
+	MinOrderQuantity float64   `json:"min_order_quantity"`
+	UnitCost         float64   `json:"unit_cost,omitempty"`
+	UnitCostMinor    int64     `json:"unit_cost_minor,omitempty"`
+	Currency         string    `json:"currency,omitempty"`
+	Preferred        bool      `json:"preferred"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	Version          int       `json:"version"`
+}
+
+type RequestRestocksResult struct {
+	Requested []string // SKUs a restock was requested for
+	Skipped   int      // Low stock items already restocking or requested recently
+}
+
+// SaveItemSupplier creates or replaces an item's record of a supplier
+func (s *inventoryService) SaveItemSupplier(ctx context.Context, req SaveItemSupplierRequest) (*ItemSupplierDTO, error) {
+	if s.suppliers == nil {
+		return nil, ErrItemSuppliersNotConfigured
+	}
+
+	item, err := s.repository.FindBySKU(req.SKU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find item: %w", err)
+	}
+	if item == nil {
+		return nil, domain.ErrItemNotFound
+	}
+	if err := s.checkCurrency(item, req.Settings.UnitCost.Currency); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.suppliers.Find(req.SKU, req.Supplier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find item supplier: %w", err)
+	}
+
+	supplier := existing
+	if supplier != nil {
+		err = supplier.Update(req.Settings)
+	} else {
+		supplier, err = domain.NewItemSupplier(req.SKU, req.Supplier, req.Settings)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.suppliers.Save(supplier); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Item supplier saved",
+		"sku", supplier.SKU(),
+		"supplier", supplier.Supplier(),
+		"leadTime", supplier.LeadTime(),
+		"version", supplier.Version())
+	dto := convertItemSupplierToDTO(supplier)
+	return &dto, nil
+}
+
+// GetItemSupplier retrieves an item's record of a supplier
+func (s *inventoryService) GetItemSupplier(ctx context.Context, sku, supplier string) (*ItemSupplierDTO, error) {
+	if s.suppliers == nil {
+		return nil, ErrItemSuppliersNotConfigured
+	}
+
+	record, err := s.suppliers.Find(sku, supplier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find item supplier: %w", err)
+	}
+	if record == nil {
+		return nil, domain.ErrItemSupplierNotFound
+	}
+
+	dto := convertItemSupplierToDTO(record)
+	return &dto, nil
+}
+
+// ListItemSuppliers retrieves the suppliers of an item
+func (s *inventoryService) ListItemSuppliers(ctx context.Context, sku string) ([]ItemSupplierDTO, error) {
+	if s.suppliers == nil {
+		return nil, ErrItemSuppliersNotConfigured
+	}
+
+	suppliers, err := s.suppliers.FindBySKU(sku)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]ItemSupplierDTO, 0, len(suppliers))
+	for _, supplier := range suppliers {
+		dtos = append(dtos, convertItemSupplierToDTO(supplier))
+	}
+	return dtos, nil
+}
+
+// DeleteItemSupplier removes an item's record of a supplier
+func (s *inventoryService) DeleteItemSupplier(ctx context.Context, sku, supplier string) error {
+	if s.suppliers == nil {
+		return ErrItemSuppliersNotConfigured
+	}
+
+	if err := s.suppliers.Delete(sku, supplier); err != nil {
+		return err
+	}
+
+	s.logger.Info("Item supplier deleted", "sku", sku, "supplier", supplier)
+	return nil
+}
+
+// RequestRestocks publishes a restock request for every low stock item that has
+// no stock on the way and was not requested within its supplier's lead time
+func (s *inventoryService) RequestRestocks(ctx context.Context) (*RequestRestocksResult, error) {
+	if s.restockEvents == nil {
+		return &RequestRestocksResult{}, nil
+	}
+
+	items, err := s.repository.FindLowStockItems()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find low stock items: %w", err)
+	}
+
+	result := &RequestRestocksResult{}
+	now := time.Now()
+	for _, item := range items {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if until, ok := s.restockRequests.Load(item.SKU()); ok && now.Before(until.(time.Time)) {
+			result.Skipped++
+			continue
+		}
+		if incoming, _, err := s.incomingStock(item.SKU()); err == nil && incoming > 0 {
+			result.Skipped++
+			continue
+		}
+
+		quantity := s.restockQuantity(item)
+		if quantity <= 0 {
+			continue
+		}
+		event := RestockRequestedEvent{
+			SKU:           item.SKU(),
+			Name:          item.Name(),
+			Quantity:      quantity,
+			Unit:          item.Unit(),
+			StockLevel:    item.StockLevel(),
+			MinStockLevel: item.MinStockLevel(),
+			RequestedAt:   now,
+		}
+		retry := defaultRestockRetry
+		if supplier := s.chooseSupplier(item.SKU()); supplier != nil {
+			settings := supplier.Settings()
+			expected := supplier.ExpectedDelivery(now)
+			event.Quantity = supplier.OrderQuantity(quantity)
+			event.Supplier = supplier.Supplier()
+			event.SupplierSKU = settings.SupplierSKU
+			event.LeadTime = settings.LeadTime
+			event.ExpectedDelivery = &expected
+			event.UnitCost = settings.UnitCost
+			if settings.LeadTime > 0 {
+				retry = settings.LeadTime
+			}
+		}
+
+		if err := s.restockEvents.PublishRestockRequested(ctx, event); err != nil {
+			s.logger.Error("Failed to publish restock requested event", "sku", item.SKU(), "error", err)
+			continue
+		}
+		s.restockRequests.Store(item.SKU(), now.Add(retry))
+		result.Requested = append(result.Requested, item.SKU())
+	}
+
+	if len(result.Requested) > 0 {
+		s.logger.Info("Restocks requested",
+			"requested", len(result.Requested),
+			"skipped", result.Skipped)
+	}
+	return result, nil
+}
+
+// chooseSupplier returns the supplier to restock the item from, or nil when it
+// has none or suppliers are not configured. A failed lookup is reported without
+// a supplier rather than failing the restock.
+func (s *inventoryService) chooseSupplier(sku string) *domain.ItemSupplier {
+	if s.suppliers == nil {
+		return nil
+	}
+
+	suppliers, err := s.suppliers.FindBySKU(sku)
+	if err != nil {
+		s.logger.Warn("Failed to load item suppliers", "sku", sku, "error", err)
+		return nil
+	}
+	return domain.ChooseSupplier(suppliers)
+}
+
+// convertItemSupplierToDTO converts a domain item supplier to a DTO
+func convertItemSupplierToDTO(supplier *domain.ItemSupplier) ItemSupplierDTO {
+	settings := supplier.Settings()
+	return ItemSupplierDTO{
+		SKU:              supplier.SKU(),
+		Supplier:         supplier.Supplier(),
+		SupplierSKU:      settings.SupplierSKU,
+		LeadTimeDays:     int(settings.LeadTime / (24 * time.Hour)),
+		MinOrderQuantity: settings.MinOrderQuantity,
+		UnitCost:         settings.UnitCost.Float64(),
+		UnitCostMinor:    settings.UnitCost.Minor,
+		Currency:         settings.UnitCost.Currency,
+		Preferred:        settings.Preferred,
+		CreatedAt:        supplier.CreatedAt(),
+		UpdatedAt:        supplier.UpdatedAt(),
+		Version:          supplier.Version(),
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+)
+
+// PurgeReservationsResult counts the reservations a test data purge released,
+// or would release on a dry run
+type PurgeReservationsResult struct {
+	Reservations  int
+	AffectedItems []string
+}
+
+// PurgeReservations releases the active reservations of the orders, returning
+// their stock to the available quantity. It is the inventory step of the test
+// data purge saga run by iam-service. Confirmed reservations were consumed by
+// assembly and are left alone.
+func (s *inventoryService) PurgeReservations(ctx context.Context, orderIDs []string, dryRun bool) (*PurgeReservationsResult, error) {
+	orders := make(map[string]bool, len(orderIDs))
+	for _, orderID := range orderIDs {
+		orders[orderID] = true
+	}
+
+	items, err := s.repository.FindAvailableItems()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find items: %w", err)
+	}
+
+	result := &PurgeReservationsResult{}
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var purged []string
+		for _, reservation := range item.GetActiveReservations() {
+			if orders[reservation.OrderID()] {
+				purged = append(purged, reservation.OrderID())
+			}
+		}
+		if len(purged) == 0 {
+			continue
+		}
+
+		result.Reservations += len(purged)
+		result.AffectedItems = append(result.AffectedItems, item.SKU())
+		if dryRun {
+			continue
+		}
+
+		for _, orderID := range purged {
+			if err := item.ReleaseReservation(orderID); err != nil {
+				s.observeStockInvariant(err)
+				return nil, fmt.Errorf("failed to release reservation of order %s on %s: %w", orderID, item.SKU(), err)
+			}
+		}
+		if err := s.repository.Save(item); err != nil {
+			return nil, fmt.Errorf("failed to save item %s: %w", item.SKU(), err)
+		}
+	}
+
+	s.logger.Info("Inventory test data purged",
+		"orders", len(orderIDs),
+		"reservations", result.Reservations,
+		"affectedItems", len(result.AffectedItems),
+		"dryRun", dryRun)
+	return result, nil
+}
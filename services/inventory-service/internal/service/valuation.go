@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// ErrInvalidValuationPeriod is returned when the cost of goods sold period ends before it starts
+var ErrInvalidValuationPeriod = errors.New("cost of goods sold period must end after it starts")
+
+// Valuation DTOs
+
+type ItemCostingDTO struct {
+	SKU         string               `json:"sku"`
+	Unit        domain.UnitOfMeasure `json:"unit"`
+	CostPrice   *domain.Money        `json:"cost_price,omitempty"`   // Unset until the item has a cost
+	AverageCost *domain.Money        `json:"average_cost,omitempty"` // Of the cost layers on hand
+	CostLayers  []CostLayerDTO       `json:"cost_layers"`
+	UnitPrice   domain.Money         `json:"unit_price"` // Sale price, for comparison
+}
+
+type CostLayerDTO struct {
+	Quantity   float64      `json:"quantity"`
+	UnitCost   domain.Money `json:"unit_cost"`
+	ReceivedAt time.Time    `json:"received_at"`
+	Source     string       `json:"source,omitempty"`
+}
+
+type GetInventoryValuationRequest struct {
+	Method   domain.ValuationMethod // Empty means FIFO
+	COGSFrom time.Time              // Start of the cost of goods sold period; zero means the beginning
+	COGSTo   time.Time              // End of the period, exclusive; zero means now
+	Category *domain.ItemCategory   // Nil means every category
+}
+
+type InventoryValuationReport struct {
+	Method         domain.ValuationMethod `json:"method"`
+	Items          []ItemValuationDTO     `json:"items"`
+	InventoryValue []domain.Money         `json:"inventory_value"` // One per currency
+	COGS           []domain.Money         `json:"cogs"`            // One per currency
+	COGSFrom       time.Time              `json:"cogs_from"`
+	COGSTo         time.Time              `json:"cogs_to"`
+	GeneratedAt    time.Time              `json:"generated_at"`
+}
+
+type ItemValuationDTO struct {
+	SKU              string               `json:"sku"`
+	Name             string               `json:"name"`
+	Category         string               `json:"category"`
+	Unit             domain.UnitOfMeasure `json:"unit"`
+	OnHand           float64              `json:"on_hand"`
+	CostedQuantity   float64              `json:"costed_quantity"`
+	Value            *domain.Money        `json:"value,omitempty"` // Unset if no stock is costed
+	UncostedQuantity float64              `json:"uncosted_quantity"`
+	COGSQuantity     float64              `json:"cogs_quantity"` // Confirmed for orders in the period
+	COGS             *domain.Money        `json:"cogs,omitempty"`
+}
+
+// GetItemCosting retrieves the cost price and cost layers of an item
+func (s *inventoryService) GetItemCosting(ctx context.Context, sku string) (*ItemCostingDTO, error) {
+	item, err := s.repository.FindBySKU(sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find item: %w", err)
+	}
+	if item == nil {
+		return nil, domain.ErrItemNotFound
+	}
+	return convertItemCostingToDTO(item), nil
+}
+
+// SetCostPrice sets the cost of one unit of an item, used for stock received
+// without a cost of its own (admin operation)
+func (s *inventoryService) SetCostPrice(ctx context.Context, sku string, costPrice domain.Money) (*ItemCostingDTO, error) {
+	item, err := s.repository.FindBySKU(sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find item: %w", err)
+	}
+	if item == nil {
+		return nil, domain.ErrItemNotFound
+	}
+
+	if err := s.checkCurrency(item, costPrice.Currency); err != nil {
+		return nil, err
+	}
+	if err := item.SetCostPrice(costPrice); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Save(item); err != nil {
+		return nil, fmt.Errorf("failed to save item: %w", err)
+	}
+
+	s.logger.Info("Cost price set",
+		"sku", sku,
+		"costPrice", costPrice.String())
+	return convertItemCostingToDTO(item), nil
+}
+
+// GetInventoryValuation values the stock on hand and the cost of the goods
+// confirmed for orders in a period, under one valuation method. Without a
+// stock movement ledger the report has no cost of goods sold.
+func (s *inventoryService) GetInventoryValuation(ctx context.Context, req GetInventoryValuationRequest) (*InventoryValuationReport, error) {
+	method := req.Method
+	if method == "" {
+		method = domain.ValuationFIFO
+	}
+	if !method.IsValid() {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnknownValuation, req.Method)
+	}
+
+	now := time.Now()
+	from, to := req.COGSFrom, req.COGSTo
+	if to.IsZero() {
+		to = now
+	}
+	if !from.Before(to) {
+		return nil, ErrInvalidValuationPeriod
+	}
+
+	items, err := s.repository.FindAllForRepair()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find items: %w", err)
+	}
+
+	report := &InventoryValuationReport{
+		Method:      method,
+		Items:       make([]ItemValuationDTO, 0, len(items)),
+		COGSFrom:    from,
+		COGSTo:      to,
+		GeneratedAt: now,
+	}
+
+	rows := make(map[string]*ItemValuationDTO, len(items))
+	inventoryValue := make(map[string]int64)
+	for _, item := range items {
+		if req.Category != nil && item.Category() != *req.Category {
+			continue
+		}
+		valuation := item.ValueStock()
+		row := ItemValuationDTO{
+			SKU:              item.SKU(),
+			Name:             item.Name(),
+			Category:         item.Category().String(),
+			Unit:             item.Unit(),
+			OnHand:           valuation.Quantity,
+			CostedQuantity:   valuation.CostedQuantity,
+			UncostedQuantity: item.Unit().Round(valuation.Quantity - valuation.CostedQuantity),
+		}
+		if value := valuation.Value(method); value.Currency != "" {
+			row.Value = &value
+			inventoryValue[value.Currency] += value.Minor
+		}
+		report.Items = append(report.Items, row)
+	}
+	sort.Slice(report.Items, func(i, j int) bool { return report.Items[i].SKU < report.Items[j].SKU })
+	for i := range report.Items {
+		rows[report.Items[i].SKU] = &report.Items[i]
+	}
+
+	cogs := make(map[string]int64)
+	if s.movements != nil {
+		movements, err := s.movements.FindByType(domain.MovementConfirmed, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find stock movements: %w", err)
+		}
+		for _, movement := range movements {
+			row, ok := rows[movement.SKU]
+			if !ok {
+				continue
+			}
+			row.COGSQuantity = row.Unit.Round(row.COGSQuantity + movement.Quantity)
+			if movement.Cost == nil {
+				continue
+			}
+			cost := movement.Cost.Cost(method)
+			if cost.Currency == "" {
+				continue
+			}
+			if row.COGS == nil {
+				row.COGS = &domain.Money{Currency: cost.Currency}
+			}
+			if row.COGS.Currency == cost.Currency {
+				row.COGS.Minor += cost.Minor
+			}
+			cogs[cost.Currency] += cost.Minor
+		}
+	}
+
+	report.InventoryValue = moneyTotals(inventoryValue)
+	report.COGS = moneyTotals(cogs)
+	return report, nil
+}
+
+// moneyTotals converts per-currency minor unit totals into sorted amounts
+func moneyTotals(totals map[string]int64) []domain.Money {
+	amounts := make([]domain.Money, 0, len(totals))
+	for currency, minor := range totals {
+		amounts = append(amounts, money.New(minor, currency))
+	}
+	domain.SortValuation(amounts)
+	return amounts
+}
+
+func convertItemCostingToDTO(item *domain.InventoryItem) *ItemCostingDTO {
+	dto := &ItemCostingDTO{
+		SKU:        item.SKU(),
+		Unit:       item.Unit(),
+		CostLayers: make([]CostLayerDTO, 0, len(item.CostLayers())),
+		UnitPrice:  item.UnitPrice(),
+	}
+	if costPrice := item.CostPrice(); costPrice.Currency != "" {
+		dto.CostPrice = &costPrice
+	}
+	if averageCost := item.AverageCost(); averageCost.Currency != "" && len(item.CostLayers()) > 0 {
+		dto.AverageCost = &averageCost
+	}
+	for _, layer := range item.CostLayers() {
+		dto.CostLayers = append(dto.CostLayers, CostLayerDTO{
+			Quantity:   layer.Quantity,
+			UnitCost:   layer.UnitCost,
+			ReceivedAt: layer.ReceivedAt,
+			Source:     layer.Source,
+		})
+	}
+	return dto
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+	pb "github.com/amiosamu/rocket-science/shared/contracts/proto/inventory/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcerrors"
+)
+
+// CreateBackorder records an order's demand for out-of-stock items
+func (h *InventoryHandler) CreateBackorder(ctx context.Context, req *pb.CreateBackorderRequest) (*pb.CreateBackorderResponse, error) {
+	h.logger.Info("gRPC CreateBackorder called",
+		"orderID", req.OrderId,
+		"shipmentID", req.ShipmentId,
+		"itemCount", len(req.Items))
+
+	if req.OrderId == "" {
+		return nil, grpcerrors.FieldError("order_id", "order ID is required")
+	}
+	if len(req.Items) == 0 {
+		return nil, grpcerrors.FieldError("items", "at least one item is required")
+	}
+	if req.ReservationDurationMinutes <= 0 {
+		return nil, grpcerrors.FieldError("reservation_duration_minutes", "reservation duration must be positive")
+	}
+	if err := validateItemQuantities(req.Items); err != nil {
+		return nil, err
+	}
+
+	items := make([]service.ItemReservationRequest, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = service.ItemReservationRequest{
+			SKU:      item.Sku,
+			Quantity: requestQuantity(item.Quantity, item.DecimalQuantity),
+			Unit:     requestUnit(item.Unit),
+		}
+	}
+
+	backorder, err := h.inventoryService.CreateBackorder(ctx, service.CreateBackorderRequest{
+		OrderID:                    req.OrderId,
+		ShipmentID:                 req.ShipmentId,
+		Items:                      items,
+		ReservationDurationMinutes: int(req.ReservationDurationMinutes),
+		Priority:                   reservationPriority(req.Priority),
+	})
+	if err != nil {
+		return nil, h.backorderError(err)
+	}
+
+	return &pb.CreateBackorderResponse{Backorder: convertBackorderToProto(*backorder)}, nil
+}
+
+// CancelBackorder gives up the open backorder of an order or shipment
+func (h *InventoryHandler) CancelBackorder(ctx context.Context, req *pb.CancelBackorderRequest) (*pb.CancelBackorderResponse, error) {
+	h.logger.Info("gRPC CancelBackorder called",
+		"orderID", req.OrderId,
+		"shipmentID", req.ShipmentId)
+
+	if req.OrderId == "" {
+		return nil, grpcerrors.FieldError("order_id", "order ID is required")
+	}
+
+	backorder, err := h.inventoryService.CancelBackorder(ctx, req.OrderId, req.ShipmentId)
+	if err != nil {
+		return nil, h.backorderError(err)
+	}
+
+	return &pb.CancelBackorderResponse{Backorder: convertBackorderToProto(*backorder)}, nil
+}
+
+// backorderError maps backorder errors to gRPC status codes
+func (h *InventoryHandler) backorderError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrBackordersNotConfigured):
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Is(err, domain.ErrBackorderNotFound), errors.Is(err, domain.ErrItemNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidBackorderTransition), errors.Is(err, domain.ErrBackorderConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrInvalidOrderID), errors.Is(err, domain.ErrInvalidSKU),
+		errors.Is(err, domain.ErrInvalidQuantity), errors.Is(err, domain.ErrBackorderWithoutItems):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		h.logger.Error("Backorder service error", "error", err)
+		return status.Errorf(codes.Internal, "backorder failed: %v", err)
+	}
+}
+
+func convertBackorderToProto(backorder service.BackorderDTO) *pb.Backorder {
+	items := make([]*pb.BackorderItem, 0, len(backorder.Items))
+	for _, item := range backorder.Items {
+		items = append(items, &pb.BackorderItem{
+			Sku:      item.SKU,
+			Quantity: item.Quantity,
+			Unit:     item.Unit,
+		})
+	}
+
+	result := &pb.Backorder{
+		Id:            backorder.ID,
+		OrderId:       backorder.OrderID,
+		ShipmentId:    backorder.ShipmentID,
+		Status:        convertDomainToProtoBackorderStatus(backorder.Status),
+		Items:         items,
+		ReservationId: backorder.ReservationID,
+		CreatedAt:     timestamppb.New(backorder.CreatedAt),
+	}
+	if backorder.ReservedAt != nil {
+		result.ReservedAt = timestamppb.New(*backorder.ReservedAt)
+	}
+	return result
+}
+
+func convertDomainToProtoBackorderStatus(name string) pb.BackorderStatus {
+	backorderStatus, ok := domain.ParseBackorderStatus(name)
+	if !ok {
+		return pb.BackorderStatus_BACKORDER_STATUS_UNSPECIFIED
+	}
+	switch backorderStatus {
+	case domain.BackorderStatusWaiting:
+		return pb.BackorderStatus_BACKORDER_STATUS_WAITING
+	case domain.BackorderStatusReserved:
+		return pb.BackorderStatus_BACKORDER_STATUS_RESERVED
+	case domain.BackorderStatusCancelled:
+		return pb.BackorderStatus_BACKORDER_STATUS_CANCELLED
+	default:
+		return pb.BackorderStatus_BACKORDER_STATUS_UNSPECIFIED
+	}
+}
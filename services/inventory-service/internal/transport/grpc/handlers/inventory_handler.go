@@ -2,15 +2,26 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math"
+	"strings"
+	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
-	pb "github.com/amiosamu/rocket-science/services/inventory-service/proto/inventory"
+	pb "github.com/amiosamu/rocket-science/shared/contracts/proto/inventory/v1"
+	moneyv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/money/v1"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/pagination"
+	"github.com/amiosamu/rocket-science/shared/platform/fxrates"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcerrors"
+	"github.com/amiosamu/rocket-science/shared/platform/version"
 )
 
 // InventoryHandler implements the InventoryServiceServer interface from protobuf
@@ -18,13 +29,15 @@ import (
 type InventoryHandler struct {
 	pb.UnimplementedInventoryServiceServer // Embedding for forward compatibility
 	inventoryService service.InventoryService
+	displayPrices    *service.DisplayPrices
 	logger           *slog.Logger
 }
 
 // NewInventoryHandler creates a new gRPC inventory handler
-func NewInventoryHandler(inventoryService service.InventoryService, logger *slog.Logger) *InventoryHandler {
+func NewInventoryHandler(inventoryService service.InventoryService, displayPrices *service.DisplayPrices, logger *slog.Logger) *InventoryHandler {
 	return &InventoryHandler{
 		inventoryService: inventoryService,
+		displayPrices:    displayPrices,
 		logger:           logger,
 	}
 }
@@ -36,7 +49,7 @@ func (h *InventoryHandler) CheckAvailability(ctx context.Context, req *pb.CheckA
 	// Validate request
 	if err := h.validateCheckAvailabilityRequest(req); err != nil {
 		h.logger.Warn("Invalid CheckAvailability request", "error", err)
-		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+		return nil, err
 	}
 
 	// Convert protobuf to service request
@@ -65,7 +78,7 @@ func (h *InventoryHandler) ReserveItems(ctx context.Context, req *pb.ReserveItem
 	// Validate request
 	if err := h.validateReserveItemsRequest(req); err != nil {
 		h.logger.Warn("Invalid ReserveItems request", "error", err)
-		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+		return nil, err
 	}
 
 	// Convert protobuf to service request
@@ -78,6 +91,12 @@ func (h *InventoryHandler) ReserveItems(ctx context.Context, req *pb.ReserveItem
 		return nil, status.Errorf(codes.Internal, "reservation failed: %v", err)
 	}
 
+	// A shortage fails the call, so clients can retry once the items are restocked
+	if err := h.shortageError(req.OrderId, result); err != nil {
+		h.logger.Warn("Reservation failed for lack of stock", "orderID", req.OrderId, "error", err)
+		return nil, err
+	}
+
 	// Convert service result to protobuf response
 	response := h.convertToReserveItemsResponse(result)
 
@@ -96,7 +115,7 @@ func (h *InventoryHandler) ConfirmReservation(ctx context.Context, req *pb.Confi
 	// Validate request
 	if err := h.validateConfirmReservationRequest(req); err != nil {
 		h.logger.Warn("Invalid ConfirmReservation request", "error", err)
-		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+		return nil, err
 	}
 
 	// Convert protobuf to service request
@@ -129,7 +148,7 @@ func (h *InventoryHandler) ReleaseReservation(ctx context.Context, req *pb.Relea
 	// Validate request
 	if err := h.validateReleaseReservationRequest(req); err != nil {
 		h.logger.Warn("Invalid ReleaseReservation request", "error", err)
-		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+		return nil, err
 	}
 
 	// Convert protobuf to service request
@@ -160,7 +179,7 @@ func (h *InventoryHandler) GetItem(ctx context.Context, req *pb.GetItemRequest)
 	// Validate request
 	if err := h.validateGetItemRequest(req); err != nil {
 		h.logger.Warn("Invalid GetItem request", "error", err)
-		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+		return nil, err
 	}
 
 	// Convert protobuf to service request
@@ -173,8 +192,21 @@ func (h *InventoryHandler) GetItem(ctx context.Context, req *pb.GetItemRequest)
 		return nil, status.Errorf(codes.Internal, "get item failed: %v", err)
 	}
 
+	var items []service.InventoryItemDTO
+	if result.Item != nil {
+		items = append(items, *result.Item)
+	}
+	conversion, err := h.prepareDisplayConversion(ctx, req.DisplayCurrency, items)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert service result to protobuf response
 	response := h.convertToGetItemResponse(result)
+	if response.Item != nil {
+		applyDisplayPrices(conversion, items, []*pb.InventoryItem{response.Item})
+	}
+	response.DisplayRates = convertDisplayRates(conversion)
 
 	h.logger.Debug("GetItem completed", "found", response.Found)
 	return response, nil
@@ -191,13 +223,23 @@ func (h *InventoryHandler) SearchItems(ctx context.Context, req *pb.SearchItemsR
 
 	// Call business service
 	result, err := h.inventoryService.SearchItems(ctx, serviceReq)
+	if errors.Is(err, pagination.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		h.logger.Error("Search items service error", "error", err)
 		return nil, status.Errorf(codes.Internal, "search failed: %v", err)
 	}
 
+	conversion, err := h.prepareDisplayConversion(ctx, req.DisplayCurrency, result.Items)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert service result to protobuf response
 	response := h.convertToSearchItemsResponse(result)
+	applyDisplayPrices(conversion, result.Items, response.Items)
+	response.DisplayRates = convertDisplayRates(conversion)
 
 	h.logger.Debug("SearchItems completed", "itemsFound", len(response.Items))
 	return response, nil
@@ -224,23 +266,59 @@ func (h *InventoryHandler) GetLowStockItems(ctx context.Context, req *pb.GetLowS
 	return response, nil
 }
 
+// GetAvailabilitySummary returns per-category stock counts, reserved quantity and valuation
+func (h *InventoryHandler) GetAvailabilitySummary(ctx context.Context, req *pb.GetAvailabilitySummaryRequest) (*pb.GetAvailabilitySummaryResponse, error) {
+	h.logger.Debug("gRPC GetAvailabilitySummary called")
+
+	result, err := h.inventoryService.GetAvailabilitySummary(ctx)
+	if err != nil {
+		h.logger.Error("Get availability summary service error", "error", err)
+		return nil, status.Errorf(codes.Internal, "get availability summary failed: %v", err)
+	}
+
+	response := &pb.GetAvailabilitySummaryResponse{
+		Categories:  make([]*pb.CategoryAvailability, 0, len(result.Categories)),
+		GeneratedAt: timestamppb.New(result.GeneratedAt),
+	}
+	for _, summary := range result.Categories {
+		valuation := make([]*pb.Money, 0, len(summary.Valuation))
+		for _, amount := range summary.Valuation {
+			valuation = append(valuation, convertMoney(amount))
+		}
+		response.Categories = append(response.Categories, &pb.CategoryAvailability{
+			Category:         h.convertDomainToProtoCategory(summary.Category),
+			ItemCount:        int32(summary.ItemCount),
+			InStockCount:     int32(summary.InStockCount),
+			LowStockCount:    int32(summary.LowStockCount),
+			OutOfStockCount:  int32(summary.OutOfStockCount),
+			ReservedQuantity: summary.ReservedQuantity,
+			Valuation:        valuation,
+		})
+	}
+
+	h.logger.Debug("GetAvailabilitySummary completed", "categories", len(response.Categories))
+	return response, nil
+}
+
 // UpdateStock adds or removes stock (admin operation)
 func (h *InventoryHandler) UpdateStock(ctx context.Context, req *pb.UpdateStockRequest) (*pb.UpdateStockResponse, error) {
 	h.logger.Info("gRPC UpdateStock called", 
 		"sku", req.Sku,
-		"quantityChange", req.QuantityChange,
+		"quantityChange", requestQuantity(req.QuantityChange, req.DecimalQuantityChange),
+		"unit", req.Unit,
 		"updatedBy", req.UpdatedBy)
 
 	// Validate request
 	if err := h.validateUpdateStockRequest(req); err != nil {
 		h.logger.Warn("Invalid UpdateStock request", "error", err)
-		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+		return nil, err
 	}
 
 	// Convert protobuf to service request
 	serviceReq := service.UpdateStockRequest{
 		SKU:            req.Sku,
-		QuantityChange: int(req.QuantityChange),
+		QuantityChange: requestQuantity(req.QuantityChange, req.DecimalQuantityChange),
+		Unit:           requestUnit(req.Unit),
 		Reason:         req.Reason,
 		UpdatedBy:      req.UpdatedBy,
 	}
@@ -273,74 +351,227 @@ func (h *InventoryHandler) GetItemsByCategory(ctx context.Context, req *pb.GetIt
 		return nil, status.Errorf(codes.Internal, "get items by category failed: %v", err)
 	}
 
+	conversion, err := h.prepareDisplayConversion(ctx, req.DisplayCurrency, result.Items)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert service result to protobuf response
 	response := h.convertToGetItemsByCategoryResponse(result)
+	applyDisplayPrices(conversion, result.Items, response.Items)
+	response.DisplayRates = convertDisplayRates(conversion)
 
 	h.logger.Debug("GetItemsByCategory completed", "itemsFound", len(response.Items))
 	return response, nil
 }
 
-// Validation methods
+// GetVersion returns build information for deployment verification
+func (h *InventoryHandler) GetVersion(ctx context.Context, req *pb.GetVersionRequest) (*pb.GetVersionResponse, error) {
+	info := version.Get("inventory-service")
+
+	return &pb.GetVersionResponse{
+		Service:   info.Service,
+		Version:   info.Version,
+		GitCommit: info.GitCommit,
+		BuildTime: info.BuildTime,
+		GoVersion: info.GoVersion,
+		Platform:  info.Platform,
+	}, nil
+}
 
-func (h *InventoryHandler) validateCheckAvailabilityRequest(req *pb.CheckAvailabilityRequest) error {
-	if len(req.Items) == 0 {
-		return status.Error(codes.InvalidArgument, "at least one item is required")
+// GetSerialNumbers returns serial numbers with their history, by serial or by order
+func (h *InventoryHandler) GetSerialNumbers(ctx context.Context, req *pb.GetSerialNumbersRequest) (*pb.GetSerialNumbersResponse, error) {
+	h.logger.Debug("gRPC GetSerialNumbers called",
+		"serialNumber", req.SerialNumber,
+		"orderID", req.OrderId)
+
+	if (req.SerialNumber == "") == (req.OrderId == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of serial_number or order_id is required")
 	}
 
-	for i, item := range req.Items {
-		if item.Sku == "" {
-			return status.Errorf(codes.InvalidArgument, "item %d: SKU is required", i)
+	var serials []service.SerialNumberDTO
+	if req.SerialNumber != "" {
+		serial, err := h.inventoryService.GetSerialHistory(ctx, req.SerialNumber)
+		if err != nil {
+			return nil, h.serialError(err)
 		}
-		if item.Quantity <= 0 {
-			return status.Errorf(codes.InvalidArgument, "item %d: quantity must be positive", i)
+		serials = append(serials, *serial)
+	} else {
+		var err error
+		serials, err = h.inventoryService.GetOrderSerials(ctx, req.OrderId)
+		if err != nil {
+			return nil, h.serialError(err)
 		}
 	}
 
-	return nil
+	response := &pb.GetSerialNumbersResponse{
+		SerialNumbers: make([]*pb.SerialNumber, 0, len(serials)),
+		Message:       "Serial numbers retrieved successfully",
+	}
+	for _, serial := range serials {
+		response.SerialNumbers = append(response.SerialNumbers, h.convertSerialToProto(serial))
+	}
+
+	return response, nil
+}
+
+// serialError maps serial lookup errors to gRPC status codes
+func (h *InventoryHandler) serialError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrSerialsNotConfigured):
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Is(err, domain.ErrSerialNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		h.logger.Error("Get serial numbers service error", "error", err)
+		return status.Errorf(codes.Internal, "get serial numbers failed: %v", err)
+	}
+}
+
+// WatchItems streams stock and price changes of the requested items until the
+// client disconnects or the server shuts down
+func (h *InventoryHandler) WatchItems(req *pb.WatchItemsRequest, stream grpc.ServerStreamingServer[pb.ItemChange]) error {
+	ctx := stream.Context()
+	h.logger.Debug("gRPC WatchItems called",
+		"skuCount", len(req.Skus),
+		"skipSnapshot", req.SkipSnapshot)
+
+	if len(req.Skus) > service.MaxWatchedSKUs {
+		return status.Errorf(codes.InvalidArgument, "cannot watch more than %d SKUs", service.MaxWatchedSKUs)
+	}
+
+	sub, err := h.inventoryService.WatchItems(ctx, req.Skus, req.SkipSnapshot)
+	if err != nil {
+		if errors.Is(err, service.ErrWatchNotConfigured) {
+			return status.Error(codes.Unimplemented, err.Error())
+		}
+		h.logger.Error("Watch items service error", "error", err)
+		return status.Errorf(codes.Internal, "watch items failed: %v", err)
+	}
+	defer sub.Close()
+
+	for {
+		changes, err := sub.Next(ctx)
+		if err != nil {
+			// The client went away or the server is stopping
+			return nil
+		}
+		for _, change := range changes {
+			if err := stream.Send(h.convertItemChangeToProto(change)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// shortageRetryAfter is the retry hint of reservations that failed for lack of stock
+const shortageRetryAfter = time.Minute
+
+// shortageError returns a FailedPrecondition error listing the short items of a
+// failed reservation, or nil if no item was short
+func (h *InventoryHandler) shortageError(orderID string, result *service.ReserveItemsResult) error {
+	if result.Success {
+		return nil
+	}
+
+	var violations []grpcerrors.PreconditionViolation
+	var skus []string
+	for _, item := range result.Results {
+		if item.Shortage {
+			violations = append(violations, grpcerrors.PreconditionViolation{
+				Type:        "STOCK",
+				Subject:     item.SKU,
+				Description: item.Reason,
+			})
+			skus = append(skus, item.SKU)
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return grpcerrors.New(codes.FailedPrecondition, grpcerrors.ReasonInsufficientStock,
+		fmt.Sprintf("insufficient stock for %s", strings.Join(skus, ", ")),
+		grpcerrors.WithMetadata(map[string]string{"order_id": orderID, "skus": strings.Join(skus, ",")}),
+		grpcerrors.WithRetryAfter(shortageRetryAfter),
+		grpcerrors.WithPreconditionFailures(violations...),
+	)
+}
+
+// Validation methods
+
+func (h *InventoryHandler) validateCheckAvailabilityRequest(req *pb.CheckAvailabilityRequest) error {
+	if len(req.Items) == 0 {
+		return grpcerrors.FieldError("items", "at least one item is required")
+	}
+
+	return validateItemQuantities(req.Items)
 }
 
 func (h *InventoryHandler) validateReserveItemsRequest(req *pb.ReserveItemsRequest) error {
 	if req.OrderId == "" {
-		return status.Error(codes.InvalidArgument, "order ID is required")
+		return grpcerrors.FieldError("order_id", "order ID is required")
 	}
 	if len(req.Items) == 0 {
-		return status.Error(codes.InvalidArgument, "at least one item is required")
+		return grpcerrors.FieldError("items", "at least one item is required")
 	}
 	if req.ReservationDurationMinutes <= 0 {
-		return status.Error(codes.InvalidArgument, "reservation duration must be positive")
+		return grpcerrors.FieldError("reservation_duration_minutes", "reservation duration must be positive")
 	}
 
-	for i, item := range req.Items {
-		if item.Sku == "" {
-			return status.Errorf(codes.InvalidArgument, "item %d: SKU is required", i)
+	return validateItemQuantities(req.Items)
+}
+
+// quantityLine is a request line asking for a quantity of a SKU
+type quantityLine interface {
+	GetSku() string
+	GetQuantity() int32
+	GetDecimalQuantity() float64
+	GetUnit() string
+}
+
+// validateItemQuantities checks the SKU and quantity of every line
+func validateItemQuantities[T quantityLine](items []T) error {
+	var violations []grpcerrors.FieldViolation
+	for i, item := range items {
+		if item.GetSku() == "" {
+			violations = append(violations, grpcerrors.FieldViolation{
+				Field:       fmt.Sprintf("items[%d].sku", i),
+				Description: "SKU is required",
+			})
 		}
-		if item.Quantity <= 0 {
-			return status.Errorf(codes.InvalidArgument, "item %d: quantity must be positive", i)
+		if err := validateQuantity(item.GetQuantity(), item.GetDecimalQuantity(), item.GetUnit()); err != nil {
+			violations = append(violations, grpcerrors.FieldViolation{
+				Field:       fmt.Sprintf("items[%d].quantity", i),
+				Description: err.Error(),
+			})
 		}
 	}
-
+	if len(violations) > 0 {
+		return grpcerrors.InvalidArgument(violations[0].Field+": "+violations[0].Description, violations...)
+	}
 	return nil
 }
 
 func (h *InventoryHandler) validateConfirmReservationRequest(req *pb.ConfirmReservationRequest) error {
 	if req.OrderId == "" {
-		return status.Error(codes.InvalidArgument, "order ID is required")
+		return grpcerrors.FieldError("order_id", "order ID is required")
 	}
 	if req.ReservationId == "" {
-		return status.Error(codes.InvalidArgument, "reservation ID is required")
+		return grpcerrors.FieldError("reservation_id", "reservation ID is required")
 	}
 	return nil
 }
 
 func (h *InventoryHandler) validateReleaseReservationRequest(req *pb.ReleaseReservationRequest) error {
 	if req.OrderId == "" {
-		return status.Error(codes.InvalidArgument, "order ID is required")
+		return grpcerrors.FieldError("order_id", "order ID is required")
 	}
 	if req.ReservationId == "" {
-		return status.Error(codes.InvalidArgument, "reservation ID is required")
+		return grpcerrors.FieldError("reservation_id", "reservation ID is required")
 	}
 	if req.Reason == "" {
-		return status.Error(codes.InvalidArgument, "reason is required")
+		return grpcerrors.FieldError("reason", "reason is required")
 	}
 	return nil
 }
@@ -349,30 +580,36 @@ func (h *InventoryHandler) validateGetItemRequest(req *pb.GetItemRequest) error
 	switch req.Identifier.(type) {
 	case *pb.GetItemRequest_ItemId:
 		if req.GetItemId() == "" {
-			return status.Error(codes.InvalidArgument, "item ID cannot be empty")
+			return grpcerrors.FieldError("item_id", "item ID cannot be empty")
 		}
 	case *pb.GetItemRequest_Sku:
 		if req.GetSku() == "" {
-			return status.Error(codes.InvalidArgument, "SKU cannot be empty")
+			return grpcerrors.FieldError("sku", "SKU cannot be empty")
 		}
 	default:
-		return status.Error(codes.InvalidArgument, "either item ID or SKU must be provided")
+		return grpcerrors.InvalidArgument("either item ID or SKU must be provided",
+			grpcerrors.FieldViolation{Field: "item_id", Description: "either item ID or SKU must be provided"},
+			grpcerrors.FieldViolation{Field: "sku", Description: "either item ID or SKU must be provided"})
 	}
 	return nil
 }
 
 func (h *InventoryHandler) validateUpdateStockRequest(req *pb.UpdateStockRequest) error {
 	if req.Sku == "" {
-		return status.Error(codes.InvalidArgument, "SKU is required")
+		return grpcerrors.FieldError("sku", "SKU is required")
+	}
+	change := requestQuantity(req.QuantityChange, req.DecimalQuantityChange)
+	if change == 0 || math.IsNaN(change) || math.IsInf(change, 0) {
+		return grpcerrors.FieldError("quantity_change", "quantity change cannot be zero")
 	}
-	if req.QuantityChange == 0 {
-		return status.Error(codes.InvalidArgument, "quantity change cannot be zero")
+	if unit := requestUnit(req.Unit); unit != "" && !unit.IsValid() {
+		return grpcerrors.FieldError("unit", fmt.Sprintf("unknown unit of measure %q", req.Unit))
 	}
 	if req.Reason == "" {
-		return status.Error(codes.InvalidArgument, "reason is required")
+		return grpcerrors.FieldError("reason", "reason is required")
 	}
 	if req.UpdatedBy == "" {
-		return status.Error(codes.InvalidArgument, "updated by is required")
+		return grpcerrors.FieldError("updated_by", "updated by is required")
 	}
 	return nil
 }
@@ -384,7 +621,8 @@ func (h *InventoryHandler) convertToCheckAvailabilityRequest(req *pb.CheckAvaila
 	for i, item := range req.Items {
 		items[i] = service.ItemAvailabilityCheck{
 			SKU:      item.Sku,
-			Quantity: int(item.Quantity),
+			Quantity: requestQuantity(item.Quantity, item.DecimalQuantity),
+			Unit:     requestUnit(item.Unit),
 		}
 	}
 
@@ -398,7 +636,8 @@ func (h *InventoryHandler) convertToReserveItemsRequest(req *pb.ReserveItemsRequ
 	for i, item := range req.Items {
 		items[i] = service.ItemReservationRequest{
 			SKU:      item.Sku,
-			Quantity: int(item.Quantity),
+			Quantity: requestQuantity(item.Quantity, item.DecimalQuantity),
+			Unit:     requestUnit(item.Unit),
 		}
 	}
 
@@ -406,6 +645,17 @@ func (h *InventoryHandler) convertToReserveItemsRequest(req *pb.ReserveItemsRequ
 		OrderID:                   req.OrderId,
 		Items:                     items,
 		ReservationDurationMinutes: int(req.ReservationDurationMinutes),
+		Priority:                   reservationPriority(req.Priority),
+	}
+}
+
+// reservationPriority maps the wire priority; unknown values fall back to standard
+func reservationPriority(priority pb.ReservationPriority) domain.ReservationPriority {
+	switch priority {
+	case pb.ReservationPriority_RESERVATION_PRIORITY_EXPEDITED:
+		return domain.ReservationPriorityExpedited
+	default:
+		return domain.ReservationPriorityStandard
 	}
 }
 
@@ -424,6 +674,9 @@ func (h *InventoryHandler) convertToGetItemRequest(req *pb.GetItemRequest) servi
 	}
 }
 
+// searchPageLimits are the page sizes of SearchItems
+var searchPageLimits = pagination.Limits{Default: 50, Max: 500}
+
 func (h *InventoryHandler) convertToSearchItemsRequest(req *pb.SearchItemsRequest) service.SearchItemsRequest {
 	serviceReq := service.SearchItemsRequest{
 		Query:         req.Query,
@@ -438,9 +691,16 @@ func (h *InventoryHandler) convertToSearchItemsRequest(req *pb.SearchItemsReques
 		serviceReq.Category = &category
 	}
 
+	// Page requests replace the deprecated limit and offset
+	if page := req.GetPage(); page != nil {
+		serviceReq.Limit = searchPageLimits.PageSize(int(page.GetPageSize()))
+		serviceReq.Offset = 0
+		serviceReq.PageToken = page.GetPageToken()
+	}
+
 	// Set default limit if not provided
 	if serviceReq.Limit <= 0 {
-		serviceReq.Limit = 50
+		serviceReq.Limit = searchPageLimits.Default
 	}
 
 	return serviceReq
@@ -489,10 +749,19 @@ func (h *InventoryHandler) convertToCheckAvailabilityResponse(result *service.Ch
 			Sku:               item.SKU,
 			Name:              item.Name,
 			Available:         item.Available,
-			RequestedQuantity: int32(item.RequestedQuantity),
-			AvailableQuantity: int32(item.AvailableQuantity),
-			ReservedQuantity:  int32(item.ReservedQuantity),
+			RequestedQuantity: wholeUnits(item.RequestedQuantity),
+			AvailableQuantity: wholeUnits(item.AvailableQuantity),
+			ReservedQuantity:  wholeUnits(item.ReservedQuantity),
 			Reason:            item.Reason,
+			Bundle:            item.Bundle,
+			Unit:              string(item.Unit),
+			UnitPrice:         convertMoney(item.UnitPrice),
+			ReplacementSku:    item.ReplacementSKU,
+			Substituted:       item.Substituted,
+
+			DecimalRequestedQuantity: item.RequestedQuantity,
+			DecimalAvailableQuantity: item.AvailableQuantity,
+			DecimalReservedQuantity:  item.ReservedQuantity,
 		}
 	}
 
@@ -509,10 +778,15 @@ func (h *InventoryHandler) convertToReserveItemsResponse(result *service.Reserve
 		results[i] = &pb.ItemReservationResult{
 			Sku:           item.SKU,
 			Name:          item.Name,
-			Reserved:      item.Reserved,
-			Quantity:      int32(item.Quantity),
-			ReservationId: item.ReservationID,
-			Reason:        item.Reason,
+			Reserved:        item.Reserved,
+			Quantity:        wholeUnits(item.Quantity),
+			ReservationId:   item.ReservationID,
+			Reason:          item.Reason,
+			Bundle:          item.Bundle,
+			DecimalQuantity: item.Quantity,
+			Unit:            string(item.Unit),
+			ReplacementSku:  item.ReplacementSKU,
+			Substituted:     item.Substituted,
 		}
 	}
 
@@ -529,11 +803,14 @@ func (h *InventoryHandler) convertToConfirmReservationResponse(result *service.C
 	results := make([]*pb.ItemConfirmationResult, len(result.Results))
 	for i, item := range result.Results {
 		results[i] = &pb.ItemConfirmationResult{
-			Sku:       item.SKU,
-			Name:      item.Name,
-			Confirmed: item.Confirmed,
-			Quantity:  int32(item.Quantity),
-			Reason:    item.Reason,
+			Sku:           item.SKU,
+			Name:          item.Name,
+			Confirmed:       item.Confirmed,
+			Quantity:        wholeUnits(item.Quantity),
+			Reason:          item.Reason,
+			SerialNumbers:   item.SerialNumbers,
+			DecimalQuantity: item.Quantity,
+			Unit:            string(item.Unit),
 		}
 	}
 
@@ -551,9 +828,11 @@ func (h *InventoryHandler) convertToReleaseReservationResponse(result *service.R
 		results[i] = &pb.ItemReleaseResult{
 			Sku:      item.SKU,
 			Name:     item.Name,
-			Released: item.Released,
-			Quantity: int32(item.Quantity),
-			Reason:   item.Reason,
+			Released:        item.Released,
+			Quantity:        wholeUnits(item.Quantity),
+			Reason:          item.Reason,
+			DecimalQuantity: item.Quantity,
+			Unit:            string(item.Unit),
 		}
 	}
 
@@ -589,6 +868,7 @@ func (h *InventoryHandler) convertToSearchItemsResponse(result *service.SearchIt
 		TotalCount: int32(result.TotalCount),
 		HasMore:    result.HasMore,
 		Message:    result.Message,
+		PageInfo:   result.PageInfo.ToProto(),
 	}
 }
 
@@ -597,8 +877,23 @@ func (h *InventoryHandler) convertToGetLowStockItemsResponse(result *service.Get
 	for i, item := range result.Items {
 		items[i] = &pb.LowStockItem{
 			Item:             h.convertInventoryItemToProto(item.Item),
-			ShortageQuantity: int32(item.ShortageQuantity),
+			ShortageQuantity: wholeUnits(item.ShortageQuantity),
 			DaysOfStock:      int32(item.DaysOfStock),
+			IncomingQuantity: int32(item.IncomingQuantity),
+
+			DecimalShortageQuantity: item.ShortageQuantity,
+			RestockQuantity:         item.RestockQuantity,
+		}
+		if item.ExpectedArrival != nil {
+			items[i].ExpectedArrival = timestamppb.New(*item.ExpectedArrival)
+		}
+		if item.Supplier != "" {
+			items[i].Supplier = item.Supplier
+			items[i].SupplierSku = item.SupplierSKU
+			items[i].LeadTimeDays = int32(item.LeadTime / (24 * time.Hour))
+		}
+		if item.ExpectedRestockAt != nil {
+			items[i].ExpectedRestockAt = timestamppb.New(*item.ExpectedRestockAt)
 		}
 	}
 
@@ -612,10 +907,14 @@ func (h *InventoryHandler) convertToGetLowStockItemsResponse(result *service.Get
 func (h *InventoryHandler) convertToUpdateStockResponse(result *service.UpdateStockResult) *pb.UpdateStockResponse {
 	return &pb.UpdateStockResponse{
 		Success:       result.Success,
-		OldStockLevel: int32(result.OldStockLevel),
-		NewStockLevel: int32(result.NewStockLevel),
+		OldStockLevel: wholeUnits(result.OldStockLevel),
+		NewStockLevel: wholeUnits(result.NewStockLevel),
 		UpdatedAt:     timestamppb.New(result.UpdatedAt),
 		Message:       result.Message,
+		Unit:          string(result.Unit),
+
+		DecimalOldStockLevel: result.OldStockLevel,
+		DecimalNewStockLevel: result.NewStockLevel,
 	}
 }
 
@@ -642,15 +941,12 @@ func (h *InventoryHandler) convertInventoryItemToProto(item service.InventoryIte
 		Name:        item.Name,
 		Description: item.Description,
 		Category:    h.convertDomainToProtoCategory(item.Category),
-		StockLevel:  int32(item.StockLevel),
-		ReservedStock: int32(item.ReservedStock),
-		TotalStock:  int32(item.TotalStock),
-		MinStockLevel: int32(item.MinStockLevel),
-		MaxStockLevel: int32(item.MaxStockLevel),
-		UnitPrice: &pb.Money{
-			Amount:   item.UnitPrice.Amount,
-			Currency: item.UnitPrice.Currency,
-		},
+		StockLevel:    wholeUnits(item.StockLevel),
+		ReservedStock: wholeUnits(item.ReservedStock),
+		TotalStock:    wholeUnits(item.TotalStock),
+		MinStockLevel: wholeUnits(item.MinStockLevel),
+		MaxStockLevel: wholeUnits(item.MaxStockLevel),
+		UnitPrice: convertMoney(item.UnitPrice),
 		Weight: item.Weight,
 		Dimensions: &pb.Dimensions{
 			Length: item.Dimensions.Length,
@@ -662,7 +958,94 @@ func (h *InventoryHandler) convertInventoryItemToProto(item service.InventoryIte
 		UpdatedAt:     timestamppb.New(item.UpdatedAt),
 		Version:       int32(item.Version),
 		Status:        h.convertDomainToProtoStatus(item.Status),
+
+		Unit:                 string(item.Unit),
+		DecimalStockLevel:    item.StockLevel,
+		DecimalReservedStock: item.ReservedStock,
+		DecimalTotalStock:    item.TotalStock,
+		DecimalMinStockLevel: item.MinStockLevel,
+		DecimalMaxStockLevel: item.MaxStockLevel,
+
+		ReplacementSku: item.ReplacementSKU,
+	}
+}
+
+func (h *InventoryHandler) convertSerialToProto(serial service.SerialNumberDTO) *pb.SerialNumber {
+	history := make([]*pb.SerialEvent, 0, len(serial.History))
+	for _, event := range serial.History {
+		history = append(history, &pb.SerialEvent{
+			Status:     event.Status,
+			OrderId:    event.OrderID,
+			Note:       event.Note,
+			OccurredAt: timestamppb.New(event.OccurredAt),
+		})
 	}
+
+	return &pb.SerialNumber{
+		SerialNumber: serial.SerialNumber,
+		Sku:          serial.SKU,
+		Status:       serial.Status,
+		OrderId:      serial.OrderID,
+		History:      history,
+	}
+}
+
+func (h *InventoryHandler) convertItemChangeToProto(change service.ItemChange) *pb.ItemChange {
+	changeType := pb.ItemChangeType_ITEM_CHANGE_TYPE_UPDATED
+	switch change.Type {
+	case service.ItemChangeSnapshot:
+		changeType = pb.ItemChangeType_ITEM_CHANGE_TYPE_SNAPSHOT
+	case service.ItemChangeDeleted:
+		changeType = pb.ItemChangeType_ITEM_CHANGE_TYPE_DELETED
+	}
+
+	return &pb.ItemChange{
+		Type:          changeType,
+		Sku:           change.SKU,
+		StockLevel:    wholeUnits(change.StockLevel),
+		ReservedStock: wholeUnits(change.ReservedStock),
+		UnitPrice: convertMoney(change.UnitPrice),
+		Status:    h.convertDomainToProtoStatus(change.Status),
+		Version:   int32(change.Version),
+		ChangedAt: timestamppb.New(change.ChangedAt),
+
+		Unit:                 string(change.Unit),
+		DecimalStockLevel:    change.StockLevel,
+		DecimalReservedStock: change.ReservedStock,
+	}
+}
+
+// Quantity helpers. Requests may carry a decimal quantity and a unit next to the original
+// whole-unit quantity; responses fill both, rounding down for clients that only read int32 fields.
+
+// requestQuantity returns the quantity of a request, preferring the decimal field when set
+func requestQuantity(quantity int32, decimalQuantity float64) float64 {
+	if decimalQuantity != 0 {
+		return decimalQuantity
+	}
+	return float64(quantity)
+}
+
+// requestUnit normalizes the unit of a request; empty means the item's own unit
+func requestUnit(symbol string) domain.UnitOfMeasure {
+	return domain.UnitOfMeasure(strings.ToLower(strings.TrimSpace(symbol)))
+}
+
+// validateQuantity checks the quantity and unit of a request line
+func validateQuantity(quantity int32, decimalQuantity float64, unit string) error {
+	value := requestQuantity(quantity, decimalQuantity)
+	if value <= 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return errors.New("quantity must be positive")
+	}
+	if u := requestUnit(unit); u != "" && !u.IsValid() {
+		return fmt.Errorf("unknown unit of measure %q", unit)
+	}
+	return nil
+}
+
+// wholeUnits rounds a quantity down to whole units for the int32 fields
+func wholeUnits(quantity float64) int32 {
+	return int32(math.Floor(quantity))
 }
 
 func (h *InventoryHandler) convertDomainToProtoCategory(category domain.ItemCategory) pb.ItemCategory {
@@ -726,4 +1109,57 @@ func (h *InventoryHandler) convertDomainToProtoStatus(status domain.ItemStatus)
 	default:
 		return pb.ItemStatus_ITEM_STATUS_UNSPECIFIED
 	}
-}
\ No newline at end of file
+}
+// convertMoney fills both the exact minor units and the legacy float amount
+// prepareDisplayConversion looks up the rates pricing items in the requested
+// display currency, if any
+func (h *InventoryHandler) prepareDisplayConversion(ctx context.Context, currency string, items []service.InventoryItemDTO) (*service.DisplayConversion, error) {
+	conversion, err := h.displayPrices.Prepare(ctx, currency, items)
+	switch {
+	case err == nil:
+		return conversion, nil
+	case errors.Is(err, service.ErrInvalidDisplayCurrency), errors.Is(err, fxrates.ErrUnsupportedCurrency):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrDisplayCurrencyNotConfigured):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		h.logger.Error("Display currency rates unavailable", "currency", currency, "error", err)
+		return nil, status.Errorf(codes.Unavailable, "display currency rates unavailable: %v", err)
+	}
+}
+
+// applyDisplayPrices sets the display price of each converted item; items and
+// converted are in the same order
+func applyDisplayPrices(conversion *service.DisplayConversion, items []service.InventoryItemDTO, converted []*pb.InventoryItem) {
+	if conversion == nil {
+		return
+	}
+	for i, item := range items {
+		converted[i].DisplayPrice = convertMoney(conversion.Convert(item.UnitPrice))
+	}
+}
+
+func convertDisplayRates(conversion *service.DisplayConversion) []*moneyv1.ExchangeRate {
+	if conversion == nil {
+		return nil
+	}
+	rates := conversion.SortedRates()
+	result := make([]*moneyv1.ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		result = append(result, &moneyv1.ExchangeRate{
+			BaseCurrency:  rate.Base,
+			QuoteCurrency: rate.Quote,
+			Rate:          rate.String(),
+			AsOf:          timestamppb.New(rate.AsOf),
+		})
+	}
+	return result
+}
+
+func convertMoney(m domain.Money) *pb.Money {
+	return &pb.Money{
+		Amount:     m.Float64(),
+		Currency:   m.Currency,
+		MinorUnits: m.Minor,
+	}
+}
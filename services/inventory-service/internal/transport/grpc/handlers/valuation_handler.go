@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+	iampb "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+	pb "github.com/amiosamu/rocket-science/shared/contracts/proto/inventory/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+)
+
+// GetInventoryValuation values the stock on hand at cost and reports the cost of
+// goods sold in a period (admin and operator operation)
+func (h *InventoryHandler) GetInventoryValuation(ctx context.Context, req *pb.GetInventoryValuationRequest) (*pb.GetInventoryValuationResponse, error) {
+	h.logger.Debug("gRPC GetInventoryValuation called", "method", req.Method.String())
+
+	if !ctxmeta.HasRole(ctx, iampb.UserRole_USER_ROLE_ADMIN.String()) &&
+		!ctxmeta.HasRole(ctx, iampb.UserRole_USER_ROLE_OPERATOR.String()) {
+		return nil, status.Error(codes.PermissionDenied, "admin or operator role required")
+	}
+
+	serviceReq := service.GetInventoryValuationRequest{Method: convertProtoToDomainValuation(req.Method)}
+	if req.CogsFrom != nil {
+		serviceReq.COGSFrom = req.CogsFrom.AsTime()
+	}
+	if req.CogsTo != nil {
+		serviceReq.COGSTo = req.CogsTo.AsTime()
+	}
+	if req.Category != pb.ItemCategory_ITEM_CATEGORY_UNSPECIFIED {
+		category := h.convertProtoToDomainCategory(req.Category)
+		serviceReq.Category = &category
+	}
+
+	report, err := h.inventoryService.GetInventoryValuation(ctx, serviceReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnknownValuation) || errors.Is(err, service.ErrInvalidValuationPeriod) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		h.logger.Error("Get inventory valuation service error", "error", err)
+		return nil, status.Errorf(codes.Internal, "get inventory valuation failed: %v", err)
+	}
+
+	response := &pb.GetInventoryValuationResponse{
+		Method:         convertDomainToProtoValuation(report.Method),
+		Items:          make([]*pb.ItemValuation, 0, len(report.Items)),
+		InventoryValue: make([]*pb.Money, 0, len(report.InventoryValue)),
+		Cogs:           make([]*pb.Money, 0, len(report.COGS)),
+		CogsFrom:       timestamppb.New(report.COGSFrom),
+		CogsTo:         timestamppb.New(report.COGSTo),
+		GeneratedAt:    timestamppb.New(report.GeneratedAt),
+	}
+	for _, item := range report.Items {
+		row := &pb.ItemValuation{
+			Sku:              item.SKU,
+			Name:             item.Name,
+			Unit:             string(item.Unit),
+			OnHand:           item.OnHand,
+			CostedQuantity:   item.CostedQuantity,
+			UncostedQuantity: item.UncostedQuantity,
+			CogsQuantity:     item.COGSQuantity,
+		}
+		if category, ok := domain.ParseItemCategory(item.Category); ok {
+			row.Category = h.convertDomainToProtoCategory(category)
+		}
+		if item.Value != nil {
+			row.Value = convertMoney(*item.Value)
+		}
+		if item.COGS != nil {
+			row.Cogs = convertMoney(*item.COGS)
+		}
+		response.Items = append(response.Items, row)
+	}
+	for _, amount := range report.InventoryValue {
+		response.InventoryValue = append(response.InventoryValue, convertMoney(amount))
+	}
+	for _, amount := range report.COGS {
+		response.Cogs = append(response.Cogs, convertMoney(amount))
+	}
+
+	h.logger.Debug("GetInventoryValuation completed", "items", len(response.Items))
+	return response, nil
+}
+
+func convertProtoToDomainValuation(method pb.ValuationMethod) domain.ValuationMethod {
+	switch method {
+	case pb.ValuationMethod_VALUATION_METHOD_UNSPECIFIED:
+		return ""
+	case pb.ValuationMethod_VALUATION_METHOD_FIFO:
+		return domain.ValuationFIFO
+	case pb.ValuationMethod_VALUATION_METHOD_AVERAGE:
+		return domain.ValuationAverage
+	default:
+		return domain.ValuationMethod(method.String())
+	}
+}
+
+func convertDomainToProtoValuation(method domain.ValuationMethod) pb.ValuationMethod {
+	if method == domain.ValuationAverage {
+		return pb.ValuationMethod_VALUATION_METHOD_AVERAGE
+	}
+	return pb.ValuationMethod_VALUATION_METHOD_FIFO
+}
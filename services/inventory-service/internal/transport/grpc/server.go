@@ -19,7 +19,13 @@ import (
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/transport/grpc/handlers"
-	pb "github.com/amiosamu/rocket-science/services/inventory-service/proto/inventory"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/compat"
+	pb "github.com/amiosamu/rocket-science/shared/contracts/proto/inventory/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/deadline"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/grpclog"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
 )
 
 // Server represents the gRPC server for the Inventory Service
@@ -29,6 +35,9 @@ type Server struct {
 	inventoryService service.InventoryService
 	grpcServer       *grpc.Server
 	healthServer     *health.Server
+	maintenance      *maintenance.Mode
+	displayPrices    *service.DisplayPrices
+	crashReporter    recovery.Reporter
 }
 
 // NewServer creates a new gRPC server instance with all dependencies
@@ -47,6 +56,19 @@ func (s *Server) Start(ctx context.Context) error {
 		"serviceName", s.config.Observability.ServiceName,
 		"version", s.config.Observability.ServiceVersion)
 
+	// Request logging samples successful calls and redacts sensitive fields
+	logConfig, err := grpclog.FromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid gRPC request logging configuration: %w", err)
+	}
+	requestLogging := grpclog.New(grpclog.FromSlog(s.logger), logConfig)
+
+	// Panics fail the call that raised them instead of the server
+	crashReporter := s.crashReporter
+	if crashReporter == nil {
+		crashReporter = recovery.NewLogReporter(grpclog.FromSlog(s.logger))
+	}
+
 	// Create gRPC server with options
 	s.grpcServer = grpc.NewServer(
 		grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -61,11 +83,12 @@ func (s *Server) Start(ctx context.Context) error {
 			PermitWithoutStream: true,
 		}),
 		// Add interceptors for logging, metrics, tracing
-		grpc.UnaryInterceptor(s.unaryInterceptor),
+		grpc.ChainUnaryInterceptor(recovery.UnaryServerInterceptor(crashReporter), ctxmeta.UnaryServerInterceptor(), requestLogging.UnaryServerInterceptor(), deadline.UnaryServerInterceptor(), compat.Inventory.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(recovery.StreamServerInterceptor(crashReporter), ctxmeta.StreamServerInterceptor(), requestLogging.StreamServerInterceptor()),
 	)
 
 	// Create and register inventory handler
-	inventoryHandler := handlers.NewInventoryHandler(s.inventoryService, s.logger)
+	inventoryHandler := handlers.NewInventoryHandler(s.inventoryService, s.displayPrices, s.logger)
 	pb.RegisterInventoryServiceServer(s.grpcServer, inventoryHandler)
 
 	// Register health check service
@@ -73,6 +96,12 @@ func (s *Server) Start(ctx context.Context) error {
 	s.healthServer.SetServingStatus("inventory.v1.InventoryService", grpc_health_v1.HealthCheckResponse_SERVING)
 	grpc_health_v1.RegisterHealthServer(s.grpcServer, s.healthServer)
 
+	// Report NOT_SERVING while in maintenance so clients route new work elsewhere;
+	// in-flight calls are still served until the instance is stopped
+	if s.maintenance != nil {
+		s.maintenance.OnChange(s.onMaintenanceChange)
+	}
+
 	// Enable gRPC reflection for development/debugging
 	reflection.Register(s.grpcServer)
 
@@ -123,6 +152,16 @@ func (s *Server) Stop() {
 	}
 }
 
+// onMaintenanceChange flips the gRPC health status when maintenance mode is toggled
+func (s *Server) onMaintenanceChange(enabled bool) {
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if enabled {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	s.healthServer.SetServingStatus("inventory.v1.InventoryService", status)
+	s.logger.Info("Maintenance mode changed", "enabled", enabled, "health_status", status.String())
+}
+
 // waitForShutdown waits for shutdown signals or server errors
 func (s *Server) waitForShutdown(ctx context.Context, errChan <-chan error) error {
 	// Create channel for shutdown signals
@@ -142,41 +181,6 @@ func (s *Server) waitForShutdown(ctx context.Context, errChan <-chan error) erro
 	}
 }
 
-// unaryInterceptor provides logging and error handling for gRPC calls
-func (s *Server) unaryInterceptor(
-	ctx context.Context,
-	req interface{},
-	info *grpc.UnaryServerInfo,
-	handler grpc.UnaryHandler,
-) (interface{}, error) {
-	start := time.Now()
-
-	// Log the incoming request
-	s.logger.Info("gRPC request started",
-		"method", info.FullMethod,
-		"duration", "started")
-
-	// Call the handler
-	resp, err := handler(ctx, req)
-	
-	// Calculate duration
-	duration := time.Since(start)
-
-	// Log the result
-	if err != nil {
-		s.logger.Error("gRPC request failed",
-			"method", info.FullMethod,
-			"duration", duration,
-			"error", err)
-	} else {
-		s.logger.Info("gRPC request completed",
-			"method", info.FullMethod,
-			"duration", duration)
-	}
-
-	return resp, err
-}
-
 // HealthCheck provides a simple health check endpoint
 func (s *Server) HealthCheck() error {
 	if s.grpcServer == nil {
@@ -198,13 +202,21 @@ func (s *Server) GetServerInfo() map[string]interface{} {
 		"service_name":    s.config.Observability.ServiceName,
 		"service_version": s.config.Observability.ServiceVersion,
 		"port":            s.config.Server.Port,
-		"health_status":   "serving",
+		"health_status":   s.healthStatus(),
 		"timestamp":       time.Now().UTC(),
 		"database_type":   "mongodb",
 		"database_name":   s.config.Database.DatabaseName,
 	}
 }
 
+// healthStatus returns the externally reported serving status
+func (s *Server) healthStatus() string {
+	if s.maintenance.Enabled() {
+		return "not_serving"
+	}
+	return "serving"
+}
+
 // ServerOption allows for configurable server creation
 type ServerOption func(*Server)
 
@@ -224,6 +236,28 @@ func WithAuthInterceptor() ServerOption {
 	}
 }
 
+// WithMaintenanceMode attaches the service maintenance switch
+func WithMaintenanceMode(mode *maintenance.Mode) ServerOption {
+	return func(s *Server) {
+		s.maintenance = mode
+	}
+}
+
+// WithDisplayPrices enables the display_currency parameter of catalog RPCs
+func WithDisplayPrices(displayPrices *service.DisplayPrices) ServerOption {
+	return func(s *Server) {
+		s.displayPrices = displayPrices
+	}
+}
+
+// WithCrashReporter sets where panics recovered from calls are reported; they
+// are only logged without one
+func WithCrashReporter(reporter recovery.Reporter) ServerOption {
+	return func(s *Server) {
+		s.crashReporter = reporter
+	}
+}
+
 // NewServerWithOptions creates a server with custom options
 func NewServerWithOptions(cfg *config.Config, logger *slog.Logger, inventoryService service.InventoryService, opts ...ServerOption) *Server {
 	server := NewServer(cfg, logger, inventoryService)
@@ -239,6 +273,16 @@ func NewServerWithOptions(cfg *config.Config, logger *slog.Logger, inventoryServ
 func (s *Server) StartBackgroundJobs(ctx context.Context) {
 	// Start expired reservation cleanup job
 	go s.reservationCleanupJob(ctx)
+
+	// Start stock consistency check job, unless disabled
+	if s.config.Inventory.ConsistencyCheckInterval > 0 {
+		go s.consistencyCheckJob(ctx)
+	}
+
+	// Start restock request job, if automatic restocking is enabled
+	if s.config.Inventory.AutoRestockEnabled {
+		go s.restockJob(ctx)
+	}
 	
 	s.logger.Info("Background jobs started")
 }
@@ -272,6 +316,51 @@ func (s *Server) reservationCleanupJob(ctx context.Context) {
 	}
 }
 
+// consistencyCheckJob periodically compares stock levels with reservations,
+// repairing small drifts if configured to
+func (s *Server) consistencyCheckJob(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Inventory.ConsistencyCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Stopping stock consistency check job")
+			return
+		case <-ticker.C:
+			s.logger.Debug("Running stock consistency check job")
+
+			_, err := s.inventoryService.CheckStockConsistency(ctx, service.CheckStockConsistencyRequest{
+				AutoRepair:     s.config.Inventory.ConsistencyAutoRepair,
+				MaxRepairDrift: s.config.Inventory.ConsistencyMaxRepairDrift,
+			})
+			if err != nil && ctx.Err() == nil {
+				s.logger.Error("Stock consistency check failed", "error", err)
+			}
+		}
+	}
+}
+
+// restockJob periodically requests restocks of low stock items from their suppliers
+func (s *Server) restockJob(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Inventory.RestockCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Stopping restock job")
+			return
+		case <-ticker.C:
+			s.logger.Debug("Running restock job")
+
+			if _, err := s.inventoryService.RequestRestocks(ctx); err != nil && ctx.Err() == nil {
+				s.logger.Error("Restock requests failed", "error", err)
+			}
+		}
+	}
+}
+
 // Metrics and monitoring helpers
 
 // GetMetrics returns server metrics for monitoring
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+)
+
+// handleBackorders lists orders waiting for out-of-stock items:
+//
+//	GET /admin/backorders[?status=waiting]  list backorders, oldest first
+func (h *HealthServer) handleBackorders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	var status *domain.BackorderStatus
+	if value := r.URL.Query().Get("status"); value != "" {
+		parsed, ok := domain.ParseBackorderStatus(value)
+		if !ok {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "unknown backorder status"})
+			return
+		}
+		status = &parsed
+	}
+
+	backorders, err := h.inventoryService.ListBackorders(r.Context(), status)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, service.ErrBackordersNotConfigured) {
+			code = http.StatusNotImplemented
+		}
+		h.writeJSONResponse(w, code, map[string]string{"error": err.Error()})
+		return
+	}
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"backorders": backorders,
+		"count":      len(backorders),
+	})
+}
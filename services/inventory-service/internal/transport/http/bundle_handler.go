@@ -0,0 +1,135 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// bundleRequest is the JSON body for creating or replacing a bundle definition
+type bundleRequest struct {
+	SKU            string  `json:"sku"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description"`
+	UnitPrice      float64 `json:"unit_price"`
+	UnitPriceMinor *int64  `json:"unit_price_minor"` // Exact price in minor units, preferred over unit_price
+	Currency       string  `json:"currency"`
+	Components     []struct {
+		SKU      string `json:"sku"`
+		Quantity int    `json:"quantity"`
+	} `json:"components"`
+}
+
+// handleBundles manages kit/bundle definitions:
+//
+//	GET    /admin/bundles        list bundles with availability
+//	POST   /admin/bundles        create or replace a bundle
+//	GET    /admin/bundles/{sku}  get a bundle with availability
+//	PUT    /admin/bundles/{sku}  create or replace a bundle
+//	DELETE /admin/bundles/{sku}  delete a bundle
+func (h *HealthServer) handleBundles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sku := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/bundles"), "/")
+
+	switch {
+	case sku == "" && r.Method == http.MethodGet:
+		bundles, err := h.inventoryService.ListBundles(ctx)
+		if err != nil {
+			h.writeBundleError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"bundles": bundles,
+			"count":   len(bundles),
+		})
+
+	case sku != "" && r.Method == http.MethodGet:
+		bundle, err := h.inventoryService.GetBundle(ctx, sku)
+		if err != nil {
+			h.writeBundleError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, bundle)
+
+	case (sku == "" && r.Method == http.MethodPost) || (sku != "" && r.Method == http.MethodPut):
+		var body bundleRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+		if sku != "" {
+			body.SKU = sku
+		}
+
+		price := money.FromFloat(body.UnitPrice, body.Currency)
+		if body.UnitPriceMinor != nil {
+			price = money.New(*body.UnitPriceMinor, body.Currency)
+		}
+
+		req := service.SaveBundleRequest{
+			SKU:         body.SKU,
+			Name:        body.Name,
+			Description: body.Description,
+			UnitPrice:   price,
+		}
+		for _, component := range body.Components {
+			req.Components = append(req.Components, domain.BundleComponent{
+				SKU:      component.SKU,
+				Quantity: component.Quantity,
+			})
+		}
+
+		bundle, err := h.inventoryService.SaveBundle(ctx, req)
+		if err != nil {
+			h.writeBundleError(w, err)
+			return
+		}
+		h.logger.Info("Bundle saved", "sku", bundle.SKU, "remote_addr", r.RemoteAddr)
+		h.writeJSONResponse(w, http.StatusOK, bundle)
+
+	case sku != "" && r.Method == http.MethodDelete:
+		if err := h.inventoryService.DeleteBundle(ctx, sku); err != nil {
+			h.writeBundleError(w, err)
+			return
+		}
+		h.logger.Info("Bundle deleted", "sku", sku, "remote_addr", r.RemoteAddr)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.Header().Set("Allow", "GET, POST, PUT, DELETE")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// writeBundleError maps bundle errors to HTTP status codes
+func (h *HealthServer) writeBundleError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	message := err.Error()
+	switch {
+	case errors.Is(err, service.ErrBundlesNotConfigured):
+		status = http.StatusNotImplemented
+	case errors.Is(err, domain.ErrBundleNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrBundleSKUConflict):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrBundleComponentNotFound),
+		errors.Is(err, domain.ErrBundleWithoutComponents),
+		errors.Is(err, domain.ErrBundleSelfReference),
+		errors.Is(err, domain.ErrDuplicateBundleComponent),
+		errors.Is(err, domain.ErrInvalidSKU),
+		errors.Is(err, domain.ErrInvalidName),
+		errors.Is(err, domain.ErrInvalidPrice),
+		errors.Is(err, domain.ErrInvalidQuantity):
+		status = http.StatusBadRequest
+	default:
+		h.logger.Error("Bundle request failed", "error", err)
+		message = "internal error"
+	}
+
+	h.writeJSONResponse(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,327 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/fxrates"
+)
+
+const (
+	// defaultCatalogPageSize and maxCatalogPageSize bound GET /catalog/items pages
+	defaultCatalogPageSize = 24
+	maxCatalogPageSize     = 100
+)
+
+// Storefront availability levels; exact stock figures are not exposed publicly
+const (
+	catalogInStock    = "in_stock"
+	catalogLowStock   = "low_stock"
+	catalogOutOfStock = "out_of_stock"
+)
+
+// catalogItem is the public view of an inventory item
+type catalogItem struct {
+	SKU            string            `json:"sku"`
+	Name           string            `json:"name"`
+	Description    string            `json:"description"`
+	Category       string            `json:"category"`
+	Price          catalogPrice      `json:"price"`
+	DisplayPrice   *catalogPrice     `json:"display_price,omitempty"` // Price in the requested display_currency
+	Unit           string            `json:"unit"`                    // Unit the price is per (ea, kg, l, ...)
+	Availability   string            `json:"availability"`
+	WeightKg       float64           `json:"weight_kg,omitempty"`
+	Dimensions     *catalogDimension `json:"dimensions,omitempty"`
+	Specifications map[string]string `json:"specifications,omitempty"`
+}
+
+type catalogPrice struct {
+	Amount     float64 `json:"amount"`
+	MinorUnits int64   `json:"minor_units"` // Exact amount in the currency's minor unit
+	Currency   string  `json:"currency"`
+
+	// Set on display prices converted from another currency
+	Rate     string     `json:"rate,omitempty"`       // Price of one unit of the item's currency
+	RateAsOf *time.Time `json:"rate_as_of,omitempty"` // When the rate was published
+}
+
+type catalogDimension struct {
+	LengthM float64 `json:"length_m"`
+	WidthM  float64 `json:"width_m"`
+	HeightM float64 `json:"height_m"`
+}
+
+type catalogCategory struct {
+	Name      string `json:"name"`
+	ItemCount int    `json:"item_count"`
+}
+
+// handleCatalogCategories lists the categories that have items on sale:
+//
+//	GET /catalog/categories
+func (h *HealthServer) handleCatalogCategories(w http.ResponseWriter, r *http.Request) {
+	if !h.allowCatalogMethod(w, r) {
+		return
+	}
+
+	categories := make([]catalogCategory, 0, len(domain.ItemCategories))
+	for _, category := range domain.ItemCategories {
+		items, err := h.findCatalogItems(r, service.SearchItemsRequest{Category: &category}, "")
+		if err != nil {
+			h.writeCatalogError(w, err)
+			return
+		}
+		if len(items) > 0 {
+			categories = append(categories, catalogCategory{Name: category.String(), ItemCount: len(items)})
+		}
+	}
+
+	h.writeCatalogResponse(w, r, map[string]interface{}{
+		"categories": categories,
+	})
+}
+
+// handleCatalogItems lists items on sale, optionally filtered:
+//
+//	GET /catalog/items?category={name}&q={text}&limit={n}&offset={n}&display_currency={code}
+//
+// Without a category or query only items in stock are listed. With a display
+// currency each item also carries its price converted into that currency.
+func (h *HealthServer) handleCatalogItems(w http.ResponseWriter, r *http.Request) {
+	if !h.allowCatalogMethod(w, r) {
+		return
+	}
+
+	query := r.URL.Query()
+	req := service.SearchItemsRequest{Query: strings.TrimSpace(query.Get("q"))}
+	if name := query.Get("category"); name != "" {
+		category, ok := domain.ParseItemCategory(name)
+		if !ok {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unknown category %q", name)})
+			return
+		}
+		req.Category = &category
+	}
+
+	limit, err := parseCatalogInt(query.Get("limit"), defaultCatalogPageSize)
+	if err != nil || limit <= 0 || limit > maxCatalogPageSize {
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("limit must be between 1 and %d", maxCatalogPageSize),
+		})
+		return
+	}
+	offset, err := parseCatalogInt(query.Get("offset"), 0)
+	if err != nil || offset < 0 {
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "offset must not be negative"})
+		return
+	}
+
+	items, err := h.findCatalogItems(r, req, query.Get("display_currency"))
+	if err != nil {
+		h.writeCatalogError(w, err)
+		return
+	}
+
+	total := len(items)
+	page := items[min(offset, total):min(offset+limit, total)]
+
+	h.writeCatalogResponse(w, r, map[string]interface{}{
+		"items":       page,
+		"total_count": total,
+		"limit":       limit,
+		"offset":      offset,
+		"has_more":    offset+len(page) < total,
+	})
+}
+
+// handleCatalogItem returns a single item on sale:
+//
+//	GET /catalog/items/{sku}?display_currency={code}
+func (h *HealthServer) handleCatalogItem(w http.ResponseWriter, r *http.Request) {
+	if !h.allowCatalogMethod(w, r) {
+		return
+	}
+
+	sku := strings.Trim(strings.TrimPrefix(r.URL.Path, "/catalog/items"), "/")
+	if sku == "" {
+		h.handleCatalogItems(w, r)
+		return
+	}
+
+	result, err := h.inventoryService.GetItem(r.Context(), service.GetItemRequest{SKU: sku})
+	if err != nil {
+		h.writeCatalogError(w, err)
+		return
+	}
+	if !result.Found || result.Item.Status == domain.ItemStatusDiscontinued {
+		h.writeJSONResponse(w, http.StatusNotFound, map[string]string{"error": "item not found"})
+		return
+	}
+
+	conversion, err := h.displayPrices.Prepare(r.Context(), r.URL.Query().Get("display_currency"), []service.InventoryItemDTO{*result.Item})
+	if err != nil {
+		h.writeCatalogError(w, err)
+		return
+	}
+
+	h.writeCatalogResponse(w, r, toCatalogItem(*result.Item, conversion))
+}
+
+// findCatalogItems returns every item matching the search that is on sale,
+// priced in displayCurrency too when given
+func (h *HealthServer) findCatalogItems(r *http.Request, req service.SearchItemsRequest, displayCurrency string) ([]catalogItem, error) {
+	req.Limit = math.MaxInt32
+	result, err := h.inventoryService.SearchItems(r.Context(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	onSale := make([]service.InventoryItemDTO, 0, len(result.Items))
+	for _, item := range result.Items {
+		if item.Status != domain.ItemStatusDiscontinued {
+			onSale = append(onSale, item)
+		}
+	}
+	conversion, err := h.displayPrices.Prepare(r.Context(), displayCurrency, onSale)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]catalogItem, 0, len(onSale))
+	for _, item := range onSale {
+		items = append(items, toCatalogItem(item, conversion))
+	}
+	return items, nil
+}
+
+// allowCatalogMethod rejects anything but GET and HEAD
+func (h *HealthServer) allowCatalogMethod(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+	w.Header().Set("Allow", "GET, HEAD")
+	h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	return false
+}
+
+// writeCatalogResponse writes a cacheable JSON response. The ETag is derived
+// from the body, so clients revalidating with If-None-Match get 304 Not
+// Modified until the catalog data changes.
+func (h *HealthServer) writeCatalogResponse(w http.ResponseWriter, r *http.Request, data interface{}) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		h.writeCatalogError(w, err)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d",
+		int(h.catalog.CacheMaxAge.Seconds()), int(h.catalog.StaleWhileRevalidate.Seconds())))
+	w.Header().Set("Vary", "Accept-Encoding")
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		w.Write(body)
+	}
+}
+
+// writeCatalogError reports a failed lookup without leaking internals
+func (h *HealthServer) writeCatalogError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidDisplayCurrency), errors.Is(err, fxrates.ErrUnsupportedCurrency),
+		errors.Is(err, service.ErrDisplayCurrencyNotConfigured):
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	case errors.Is(err, fxrates.ErrUnavailable):
+		h.logger.Warn("Catalog display currency rates unavailable", "error", err)
+		h.writeJSONResponse(w, http.StatusServiceUnavailable, map[string]string{"error": "display currency rates unavailable"})
+		return
+	}
+
+	h.logger.Error("Catalog request failed", "error", err)
+	h.writeJSONResponse(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
+
+// etagMatches reports whether an If-None-Match header matches the ETag,
+// using the weak comparison required for GET and HEAD
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCatalogInt(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func toCatalogItem(item service.InventoryItemDTO, conversion *service.DisplayConversion) catalogItem {
+	availability := catalogInStock
+	switch {
+	case item.StockLevel <= 0:
+		availability = catalogOutOfStock
+	case item.StockLevel <= item.MinStockLevel:
+		availability = catalogLowStock
+	}
+
+	result := catalogItem{
+		SKU:            item.SKU,
+		Name:           item.Name,
+		Description:    item.Description,
+		Category:       item.Category.String(),
+		Price:          toCatalogPrice(item.UnitPrice),
+		Unit:           string(item.Unit),
+		Availability:   availability,
+		WeightKg:       item.Weight,
+		Specifications: item.Specifications,
+	}
+	if item.Dimensions != (domain.Dimensions{}) {
+		result.Dimensions = &catalogDimension{
+			LengthM: item.Dimensions.Length,
+			WidthM:  item.Dimensions.Width,
+			HeightM: item.Dimensions.Height,
+		}
+	}
+	if conversion != nil {
+		price := toCatalogPrice(conversion.Convert(item.UnitPrice))
+		if rate, ok := conversion.Rates[item.UnitPrice.Currency]; ok {
+			asOf := rate.AsOf.UTC()
+			price.Rate, price.RateAsOf = rate.String(), &asOf
+		}
+		result.DisplayPrice = &price
+	}
+	return result
+}
+
+func toCatalogPrice(price domain.Money) catalogPrice {
+	return catalogPrice{Amount: price.Float64(), MinorUnits: price.Minor, Currency: price.Currency}
+}
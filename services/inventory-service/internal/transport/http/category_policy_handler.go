@@ -0,0 +1,211 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// categoryPolicyRequest is the JSON body for creating or replacing a category policy
+type categoryPolicyRequest struct {
+	MinStockLevel         float64  `json:"min_stock_level"`
+	MaxStockLevel         float64  `json:"max_stock_level"`
+	ReservationTTLMinutes int      `json:"reservation_ttl_minutes"`
+	RestockQuantity       float64  `json:"restock_quantity"`
+	AllowedCurrencies     []string `json:"allowed_currencies"`
+}
+
+// createItemRequest is the JSON body for adding an item to the range
+type createItemRequest struct {
+	SKU            string   `json:"sku"`
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	Category       string   `json:"category"`
+	Unit           string   `json:"unit"`
+	UnitPrice      float64  `json:"unit_price"`
+	UnitPriceMinor *int64   `json:"unit_price_minor"` // Exact price in minor units, preferred over unit_price
+	Currency       string   `json:"currency"`
+	MinStockLevel  *float64 `json:"min_stock_level"` // Defaults to the category policy's level
+	MaxStockLevel  *float64 `json:"max_stock_level"` // Defaults to the category policy's level
+}
+
+// createItemResponse describes a newly added item
+type createItemResponse struct {
+	SKU           string  `json:"sku"`
+	Name          string  `json:"name"`
+	Category      string  `json:"category"`
+	Unit          string  `json:"unit"`
+	UnitPrice     string  `json:"unit_price"`
+	MinStockLevel float64 `json:"min_stock_level"`
+	MaxStockLevel float64 `json:"max_stock_level"`
+}
+
+// handleCategoryPolicies manages the stocking policies of item categories:
+//
+//	GET    /admin/category-policies             list category policies
+//	GET    /admin/category-policies/{category}  get a category policy
+//	PUT    /admin/category-policies/{category}  create or replace a category policy
+//	DELETE /admin/category-policies/{category}  delete a category policy
+func (h *HealthServer) handleCategoryPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/category-policies"), "/")
+
+	if name == "" {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+		policies, err := h.inventoryService.ListCategoryPolicies(ctx)
+		if err != nil {
+			h.writeCategoryPolicyError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"policies": policies,
+			"count":    len(policies),
+		})
+		return
+	}
+
+	category, ok := domain.ParseItemCategory(name)
+	if !ok {
+		h.writeCategoryPolicyError(w, domain.ErrInvalidCategory)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		policy, err := h.inventoryService.GetCategoryPolicy(ctx, category)
+		if err != nil {
+			h.writeCategoryPolicyError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, policy)
+
+	case http.MethodPut:
+		var body categoryPolicyRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		policy, err := h.inventoryService.SaveCategoryPolicy(ctx, service.SaveCategoryPolicyRequest{
+			Category: category,
+			Settings: domain.CategoryPolicySettings{
+				MinStockLevel:     body.MinStockLevel,
+				MaxStockLevel:     body.MaxStockLevel,
+				ReservationTTL:    time.Duration(body.ReservationTTLMinutes) * time.Minute,
+				RestockQuantity:   body.RestockQuantity,
+				AllowedCurrencies: body.AllowedCurrencies,
+			},
+		})
+		if err != nil {
+			h.writeCategoryPolicyError(w, err)
+			return
+		}
+		h.logger.Info("Category policy saved", "category", policy.Category, "remote_addr", r.RemoteAddr)
+		h.writeJSONResponse(w, http.StatusOK, policy)
+
+	case http.MethodDelete:
+		if err := h.inventoryService.DeleteCategoryPolicy(ctx, category); err != nil {
+			h.writeCategoryPolicyError(w, err)
+			return
+		}
+		h.logger.Info("Category policy deleted", "category", category.String(), "remote_addr", r.RemoteAddr)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// handleItems adds items to the range:
+//
+//	POST /admin/items    create an item with its category policy's stock levels
+func (h *HealthServer) handleItems(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	var body createItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	category, ok := domain.ParseItemCategory(body.Category)
+	if !ok {
+		h.writeCategoryPolicyError(w, domain.ErrInvalidCategory)
+		return
+	}
+
+	price := money.FromFloat(body.UnitPrice, body.Currency)
+	if body.UnitPriceMinor != nil {
+		price = money.New(*body.UnitPriceMinor, body.Currency)
+	}
+
+	item, err := h.inventoryService.CreateItem(r.Context(), service.CreateItemRequest{
+		SKU:           body.SKU,
+		Name:          body.Name,
+		Description:   body.Description,
+		Category:      category,
+		Unit:          domain.UnitOfMeasure(body.Unit),
+		UnitPrice:     price,
+		MinStockLevel: body.MinStockLevel,
+		MaxStockLevel: body.MaxStockLevel,
+	})
+	if err != nil {
+		h.writeCategoryPolicyError(w, err)
+		return
+	}
+
+	h.logger.Info("Item created", "sku", item.SKU, "remote_addr", r.RemoteAddr)
+	h.writeJSONResponse(w, http.StatusCreated, createItemResponse{
+		SKU:           item.SKU,
+		Name:          item.Name,
+		Category:      item.Category.String(),
+		Unit:          string(item.Unit),
+		UnitPrice:     item.UnitPrice.String(),
+		MinStockLevel: item.MinStockLevel,
+		MaxStockLevel: item.MaxStockLevel,
+	})
+}
+
+// writeCategoryPolicyError maps category policy and item creation errors to HTTP status codes
+func (h *HealthServer) writeCategoryPolicyError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	message := err.Error()
+	switch {
+	case errors.Is(err, service.ErrCategoryPoliciesNotConfigured):
+		status = http.StatusNotImplemented
+	case errors.Is(err, domain.ErrCategoryPolicyNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrItemAlreadyExists),
+		errors.Is(err, domain.ErrBundleSKUConflict):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidCategory),
+		errors.Is(err, domain.ErrInvalidCategoryPolicy),
+		errors.Is(err, domain.ErrCurrencyNotAllowed),
+		errors.Is(err, domain.ErrInvalidSKU),
+		errors.Is(err, domain.ErrInvalidName),
+		errors.Is(err, domain.ErrInvalidPrice),
+		errors.Is(err, domain.ErrInvalidStockLevel),
+		errors.Is(err, domain.ErrUnknownUnit):
+		status = http.StatusBadRequest
+	default:
+		h.logger.Error("Category policy request failed", "error", err)
+		message = "internal error"
+	}
+
+	h.writeJSONResponse(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+)
+
+// discontinueItemRequest is the JSON body for discontinuing an item
+type discontinueItemRequest struct {
+	SKU            string `json:"sku"`
+	ReplacementSKU string `json:"replacement_sku"`
+	DiscontinuedBy string `json:"discontinued_by"`
+}
+
+// discontinueItemResponse describes a discontinued item
+type discontinueItemResponse struct {
+	SKU            string `json:"sku"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	ReplacementSKU string `json:"replacement_sku,omitempty"`
+}
+
+// handleDiscontinue takes an item out of the range:
+//
+//	POST /admin/discontinue    discontinue an item, optionally naming its replacement
+func (h *HealthServer) handleDiscontinue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	var body discontinueItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	item, err := h.inventoryService.DiscontinueItem(r.Context(), service.DiscontinueItemRequest{
+		SKU:            body.SKU,
+		ReplacementSKU: body.ReplacementSKU,
+		DiscontinuedBy: body.DiscontinuedBy,
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := err.Error()
+		switch {
+		case errors.Is(err, domain.ErrItemNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, domain.ErrInvalidSKU),
+			errors.Is(err, domain.ErrReplacementSelfReference),
+			errors.Is(err, domain.ErrReplacementNotFound),
+			errors.Is(err, domain.ErrReplacementDiscontinued):
+			status = http.StatusBadRequest
+		default:
+			h.logger.Error("Discontinuing item failed", "sku", body.SKU, "error", err)
+			message = "internal error"
+		}
+		h.writeJSONResponse(w, status, map[string]string{"error": message})
+		return
+	}
+
+	h.logger.Info("Item discontinue requested",
+		"sku", item.SKU,
+		"replacement_sku", item.ReplacementSKU,
+		"remote_addr", r.RemoteAddr)
+	h.writeJSONResponse(w, http.StatusOK, discontinueItemResponse{
+		SKU:            item.SKU,
+		Name:           item.Name,
+		Status:         item.Status.String(),
+		ReplacementSKU: item.ReplacementSKU,
+	})
+}
@@ -8,33 +8,47 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
 	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/adminhttp"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
+	"github.com/amiosamu/rocket-science/shared/platform/purge"
+	"github.com/amiosamu/rocket-science/shared/platform/version"
 )
 
 // HealthServer provides HTTP health check endpoints for monitoring and orchestration
 type HealthServer struct {
 	inventoryService service.InventoryService
 	repository       domain.InventoryRepository
+	maintenance      *maintenance.Mode
 	logger           *slog.Logger
 	startTime        time.Time
 	port             string
 	server           *http.Server
+	catalog          config.CatalogConfig
+	displayPrices    *service.DisplayPrices
 }
 
 // NewHealthServer creates a new health server
 func NewHealthServer(
 	inventoryService service.InventoryService,
 	repository domain.InventoryRepository,
+	maintenanceMode *maintenance.Mode,
 	logger *slog.Logger,
 	port string,
+	catalog config.CatalogConfig,
+	displayPrices *service.DisplayPrices,
 ) *HealthServer {
 	return &HealthServer{
 		inventoryService: inventoryService,
 		repository:       repository,
+		maintenance:      maintenanceMode,
 		logger:           logger,
 		startTime:        time.Now(),
 		port:             port,
+		catalog:          catalog,
+		displayPrices:    displayPrices,
 	}
 }
 
@@ -45,6 +59,9 @@ const (
 	HealthStatusHealthy   HealthStatus = "healthy"
 	HealthStatusDegraded  HealthStatus = "degraded"
 	HealthStatusUnhealthy HealthStatus = "unhealthy"
+
+	// HealthStatusMaintenance is reported by readiness while the service drains
+	HealthStatusMaintenance HealthStatus = "maintenance"
 )
 
 // ComponentHealth represents the health of a single component
@@ -97,6 +114,11 @@ type InventoryStatsResponse struct {
 
 // Start starts the HTTP health server
 func (h *HealthServer) Start(ctx context.Context) error {
+	purgeGate, err := purge.GateFromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid test data purge configuration: %w", err)
+	}
+
 	mux := http.NewServeMux()
 
 	// Register health check endpoints
@@ -105,6 +127,31 @@ func (h *HealthServer) Start(ctx context.Context) error {
 	mux.HandleFunc("/live", h.handleLivenessCheck)
 	mux.HandleFunc("/metrics", h.handleMetrics)
 	mux.HandleFunc("/stats", h.handleInventoryStats)
+	mux.Handle("/version", version.Handler("inventory-service"))
+	mux.HandleFunc("/admin/maintenance", h.handleMaintenance)
+	mux.HandleFunc("/admin/bundles", h.handleBundles)
+	mux.HandleFunc("/admin/bundles/", h.handleBundles)
+	mux.HandleFunc("/admin/serials", h.handleSerials)
+	mux.HandleFunc("/admin/serials/", h.handleSerials)
+	mux.HandleFunc("/admin/purchase-orders", h.handlePurchaseOrders)
+	mux.HandleFunc("/admin/purchase-orders/", h.handlePurchaseOrders)
+	mux.HandleFunc("/admin/backorders", h.handleBackorders)
+	mux.HandleFunc("/admin/stock-repair", h.handleStockRepair)
+	mux.HandleFunc("/admin/discontinue", h.handleDiscontinue)
+	mux.HandleFunc("/admin/stock-consistency", h.handleStockConsistency)
+	mux.HandleFunc("/admin/stock-movements", h.handleStockMovements)
+	mux.HandleFunc("/admin/cost-prices/", h.handleCostPrices)
+	mux.HandleFunc("/admin/valuation", h.handleValuation)
+	mux.HandleFunc("/admin/items", h.handleItems)
+	mux.HandleFunc("/admin/category-policies", h.handleCategoryPolicies)
+	mux.HandleFunc("/admin/category-policies/", h.handleCategoryPolicies)
+	mux.HandleFunc("/admin/item-suppliers/", h.handleItemSuppliers)
+	mux.Handle(purge.Path, purge.Handler(purgeGate, purge.ParticipantFunc(h.purgeTestData)))
+
+	// Public storefront catalog; read-only and unauthenticated
+	mux.HandleFunc("/catalog/categories", h.handleCatalogCategories)
+	mux.HandleFunc("/catalog/items", h.handleCatalogItems)
+	mux.HandleFunc("/catalog/items/", h.handleCatalogItem)
 
 	h.server = &http.Server{
 		Addr:         ":" + h.port,
@@ -114,11 +161,23 @@ func (h *HealthServer) Start(ctx context.Context) error {
 		IdleTimeout:  60 * time.Second,
 	}
 
-	h.logger.Info("Starting HTTP health server", "port", h.port)
+	security, err := adminhttp.FromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid health server security configuration: %w", err)
+	}
+	listener, err := security.Listen(h.server)
+	if err != nil {
+		return err
+	}
+	if !security.AuthEnabled() {
+		h.logger.Warn("HTTP health server admin routes are unauthenticated; set HEALTH_ADMIN_TOKEN or HEALTH_TLS_CLIENT_CA_FILE")
+	}
+
+	h.logger.Info("Starting HTTP health server", "address", h.server.Addr, "tls", security.TLSEnabled(), "admin_auth", security.AuthEnabled())
 
 	// Start server in a goroutine
 	go func() {
-		if err := h.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := h.server.Serve(listener); err != nil && err != http.ErrServerClosed {
 			h.logger.Error("HTTP health server failed", "error", err)
 		}
 	}()
@@ -194,6 +253,18 @@ func (h *HealthServer) handleHealthCheck(w http.ResponseWriter, r *http.Request)
 func (h *HealthServer) handleReadinessCheck(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// Take the instance out of rotation while in maintenance mode
+	if h.maintenance.Enabled() {
+		response := SimpleHealthResponse{
+			Status:    HealthStatusMaintenance,
+			Service:   "inventory-service",
+			Timestamp: time.Now().UTC(),
+			Version:   "1.0.0",
+		}
+		h.writeJSONResponse(w, http.StatusServiceUnavailable, response)
+		return
+	}
+
 	// Check critical components only for readiness
 	dbHealth := h.checkDatabase(ctx)
 
@@ -217,6 +288,35 @@ func (h *HealthServer) handleReadinessCheck(w http.ResponseWriter, r *http.Reque
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// handleMaintenance reports and toggles maintenance mode
+func (h *HealthServer) handleMaintenance(w http.ResponseWriter, r *http.Request) {
+	if h.maintenance == nil {
+		h.writeJSONResponse(w, http.StatusNotImplemented, map[string]string{
+			"error": "maintenance mode not configured",
+		})
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		h.logger.Info("Maintenance mode change requested", "method", r.Method, "remote_addr", r.RemoteAddr)
+	}
+
+	h.maintenance.Handler().ServeHTTP(w, r)
+}
+
+// purgeTestData releases the reservations of the orders of a test data purge
+func (h *HealthServer) purgeTestData(ctx context.Context, scope purge.Scope) (*purge.Result, error) {
+	purged, err := h.inventoryService.PurgeReservations(ctx, scope.OrderIDs, scope.DryRun)
+	if err != nil {
+		h.logger.Error("Failed to purge inventory test data", "error", err)
+		return nil, err
+	}
+
+	result := purge.NewResult("inventory-service", scope)
+	result.Deleted["reservations"] = int64(purged.Reservations)
+	return result, nil
+}
+
 // HandleLivenessCheck provides a basic liveness check
 func (h *HealthServer) handleLivenessCheck(w http.ResponseWriter, r *http.Request) {
 	response := SimpleHealthResponse{
@@ -238,6 +338,21 @@ func (h *HealthServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
 		"version":    "1.0.0",
 	}
 
+	// A non-zero count raises the stock invariant alarm; affected items need /admin/stock-repair
+	if h.inventoryService != nil {
+		violations := h.inventoryService.StockInvariantViolations()
+		response["stock_invariant_violations"] = violations
+		response["stock_invariant_alarm"] = violations > 0
+
+		// Drifts the last consistency check couldn't repair; they need /admin/stock-repair too
+		if report := h.inventoryService.LastStockConsistencyReport(); report != nil {
+			response["stock_consistency_checked_at"] = report.CheckedAt.Format(time.RFC3339)
+			response["stock_drift_items"] = len(report.Drifts)
+			response["stock_drift_repaired"] = report.Repaired
+			response["stock_drift_alarm"] = report.Unrepaired > 0
+		}
+	}
+
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
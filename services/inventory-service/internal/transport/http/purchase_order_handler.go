@@ -0,0 +1,216 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// purchaseOrderLineRequest is a SKU/quantity pair in purchase order request bodies.
+// Lines of new purchase orders may carry the agreed unit cost.
+type purchaseOrderLineRequest struct {
+	SKU           string  `json:"sku"`
+	Quantity      int     `json:"quantity"`
+	UnitCost      float64 `json:"unit_cost"`
+	UnitCostMinor *int64  `json:"unit_cost_minor"` // Exact cost in minor units, preferred over unit_cost
+	Currency      string  `json:"currency"`        // Required with a unit cost
+}
+
+// createPurchaseOrderRequest is the JSON body for creating a purchase order
+type createPurchaseOrderRequest struct {
+	Supplier        string                     `json:"supplier"`
+	Lines           []purchaseOrderLineRequest `json:"lines"`
+	ExpectedArrival *time.Time                 `json:"expected_arrival"`
+	CreatedBy       string                     `json:"created_by"`
+}
+
+// inTransitRequest is the JSON body for marking a purchase order as shipped
+type inTransitRequest struct {
+	ExpectedArrival *time.Time `json:"expected_arrival"`
+	TrackingRef     string     `json:"tracking_ref"`
+}
+
+// receiveRequest is the JSON body for receiving goods against a purchase order
+type receiveRequest struct {
+	Lines      []purchaseOrderLineRequest `json:"lines"`
+	ReceivedBy string                     `json:"received_by"`
+}
+
+// handlePurchaseOrders manages inbound purchase orders:
+//
+//	GET  /admin/purchase-orders[?status=in_transit]  list purchase orders
+//	POST /admin/purchase-orders                      create a purchase order
+//	GET  /admin/purchase-orders/{id}                 get a purchase order with its receipts
+//	POST /admin/purchase-orders/{id}/in-transit      mark a purchase order as shipped
+//	POST /admin/purchase-orders/{id}/receive         receive (partial) quantities into stock
+//	POST /admin/purchase-orders/{id}/cancel          cancel the outstanding quantities
+func (h *HealthServer) handlePurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/purchase-orders"), "/")
+	id, action, _ := strings.Cut(path, "/")
+
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		var status *domain.PurchaseOrderStatus
+		if value := r.URL.Query().Get("status"); value != "" {
+			parsed, ok := parsePurchaseOrderStatus(value)
+			if !ok {
+				h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "unknown purchase order status"})
+				return
+			}
+			status = &parsed
+		}
+
+		orders, err := h.inventoryService.ListPurchaseOrders(ctx, status)
+		if err != nil {
+			h.writePurchaseOrderError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"purchase_orders": orders,
+			"count":           len(orders),
+		})
+
+	case id == "" && r.Method == http.MethodPost:
+		var body createPurchaseOrderRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		req := service.CreatePurchaseOrderRequest{
+			Supplier:        body.Supplier,
+			ExpectedArrival: body.ExpectedArrival,
+			CreatedBy:       body.CreatedBy,
+		}
+		for _, line := range body.Lines {
+			poLine := domain.PurchaseOrderLine{SKU: line.SKU, OrderedQuantity: line.Quantity}
+			if line.Currency != "" {
+				poLine.UnitCost = money.FromFloat(line.UnitCost, line.Currency)
+				if line.UnitCostMinor != nil {
+					poLine.UnitCost = money.New(*line.UnitCostMinor, line.Currency)
+				}
+			}
+			req.Lines = append(req.Lines, poLine)
+		}
+
+		po, err := h.inventoryService.CreatePurchaseOrder(ctx, req)
+		if err != nil {
+			h.writePurchaseOrderError(w, err)
+			return
+		}
+		h.logger.Info("Purchase order created", "poID", po.ID, "supplier", po.Supplier, "remote_addr", r.RemoteAddr)
+		h.writeJSONResponse(w, http.StatusCreated, po)
+
+	case id != "" && action == "" && r.Method == http.MethodGet:
+		po, err := h.inventoryService.GetPurchaseOrder(ctx, id)
+		if err != nil {
+			h.writePurchaseOrderError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, po)
+
+	case id != "" && action == "in-transit" && r.Method == http.MethodPost:
+		var body inTransitRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		po, err := h.inventoryService.MarkPurchaseOrderInTransit(ctx, service.MarkPurchaseOrderInTransitRequest{
+			ID:              id,
+			ExpectedArrival: body.ExpectedArrival,
+			TrackingRef:     body.TrackingRef,
+		})
+		if err != nil {
+			h.writePurchaseOrderError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, po)
+
+	case id != "" && action == "receive" && r.Method == http.MethodPost:
+		var body receiveRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		req := service.ReceivePurchaseOrderRequest{ID: id, ReceivedBy: body.ReceivedBy}
+		for _, line := range body.Lines {
+			req.Lines = append(req.Lines, service.ReceiptLine{SKU: line.SKU, Quantity: line.Quantity})
+		}
+
+		po, err := h.inventoryService.ReceivePurchaseOrder(ctx, req)
+		if err != nil {
+			h.writePurchaseOrderError(w, err)
+			return
+		}
+		h.logger.Info("Purchase order goods received",
+			"poID", po.ID,
+			"status", po.Status,
+			"receivedBy", body.ReceivedBy,
+			"remote_addr", r.RemoteAddr)
+		h.writeJSONResponse(w, http.StatusOK, po)
+
+	case id != "" && action == "cancel" && r.Method == http.MethodPost:
+		po, err := h.inventoryService.CancelPurchaseOrder(ctx, id)
+		if err != nil {
+			h.writePurchaseOrderError(w, err)
+			return
+		}
+		h.logger.Info("Purchase order cancelled", "poID", po.ID, "remote_addr", r.RemoteAddr)
+		h.writeJSONResponse(w, http.StatusOK, po)
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// parsePurchaseOrderStatus converts a status name such as "in_transit" to its domain value
+func parsePurchaseOrderStatus(value string) (domain.PurchaseOrderStatus, bool) {
+	for status := domain.PurchaseOrderStatusOpen; status <= domain.PurchaseOrderStatusCancelled; status++ {
+		if status.String() == value {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
+// writePurchaseOrderError maps purchase order errors to HTTP status codes
+func (h *HealthServer) writePurchaseOrderError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	message := err.Error()
+	switch {
+	case errors.Is(err, service.ErrPurchaseOrdersNotConfigured):
+		status = http.StatusNotImplemented
+	case errors.Is(err, domain.ErrPurchaseOrderNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrPurchaseOrderConflict),
+		errors.Is(err, domain.ErrInvalidPurchaseOrderTransition):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrItemNotFound),
+		errors.Is(err, domain.ErrInvalidSupplier),
+		errors.Is(err, domain.ErrPurchaseOrderWithoutLines),
+		errors.Is(err, domain.ErrDuplicatePurchaseOrderLine),
+		errors.Is(err, domain.ErrPurchaseOrderLineNotFound),
+		errors.Is(err, domain.ErrOverReceipt),
+		errors.Is(err, domain.ErrInvalidSKU),
+		errors.Is(err, domain.ErrInvalidQuantity),
+		errors.Is(err, domain.ErrInvalidCostPrice),
+		errors.Is(err, domain.ErrCostCurrencyMismatch),
+		errors.Is(err, domain.ErrCurrencyNotAllowed):
+		status = http.StatusBadRequest
+	default:
+		h.logger.Error("Purchase order request failed", "error", err)
+		message = "internal error"
+	}
+
+	h.writeJSONResponse(w, status, map[string]string{"error": message})
+}
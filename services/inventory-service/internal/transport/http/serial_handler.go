@@ -0,0 +1,107 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+)
+
+// registerSerialsRequest is the JSON body for adding serial numbers to an item's pool
+type registerSerialsRequest struct {
+	SKU           string   `json:"sku"`
+	SerialNumbers []string `json:"serial_numbers"`
+	RegisteredBy  string   `json:"registered_by"`
+}
+
+// handleSerials manages serial numbers of serial-tracked items:
+//
+//	POST /admin/serials                   register serial numbers for an item
+//	GET  /admin/serials?order_id={id}     serials allocated to an order
+//	GET  /admin/serials/{serial}          a serial number with its history
+func (h *HealthServer) handleSerials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	serialNumber := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/serials"), "/")
+
+	switch {
+	case serialNumber == "" && r.Method == http.MethodPost:
+		var body registerSerialsRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		result, err := h.inventoryService.RegisterSerials(ctx, service.RegisterSerialsRequest{
+			SKU:           body.SKU,
+			SerialNumbers: body.SerialNumbers,
+			RegisteredBy:  body.RegisteredBy,
+		})
+		if err != nil {
+			h.writeSerialError(w, err)
+			return
+		}
+		h.logger.Info("Serial numbers registered",
+			"sku", result.SKU,
+			"registered", result.Registered,
+			"remote_addr", r.RemoteAddr)
+		h.writeJSONResponse(w, http.StatusCreated, result)
+
+	case serialNumber == "" && r.Method == http.MethodGet:
+		orderID := r.URL.Query().Get("order_id")
+		if orderID == "" {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "order_id query parameter is required"})
+			return
+		}
+
+		serials, err := h.inventoryService.GetOrderSerials(ctx, orderID)
+		if err != nil {
+			h.writeSerialError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"order_id":       orderID,
+			"serial_numbers": serials,
+			"count":          len(serials),
+		})
+
+	case serialNumber != "" && r.Method == http.MethodGet:
+		serial, err := h.inventoryService.GetSerialHistory(ctx, serialNumber)
+		if err != nil {
+			h.writeSerialError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, serial)
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// writeSerialError maps serial number errors to HTTP status codes
+func (h *HealthServer) writeSerialError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	message := err.Error()
+	switch {
+	case errors.Is(err, service.ErrSerialsNotConfigured):
+		status = http.StatusNotImplemented
+	case errors.Is(err, domain.ErrSerialNotFound), errors.Is(err, domain.ErrItemNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrSerialAlreadyExists):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrSerialUncountableUnit):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrInvalidSerialNumber),
+		errors.Is(err, domain.ErrInvalidSKU),
+		errors.Is(err, domain.ErrInvalidOrderID):
+		status = http.StatusBadRequest
+	default:
+		h.logger.Error("Serial number request failed", "error", err)
+		message = "internal error"
+	}
+
+	h.writeJSONResponse(w, status, map[string]string{"error": message})
+}
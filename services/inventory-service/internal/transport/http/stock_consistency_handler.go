@@ -0,0 +1,63 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+)
+
+// checkConsistencyRequest is the JSON body for running a stock consistency check
+type checkConsistencyRequest struct {
+	AutoRepair     bool    `json:"auto_repair"`
+	MaxRepairDrift float64 `json:"max_repair_drift"`
+}
+
+// handleStockConsistency compares stock levels with reservations across all items:
+//
+//	GET  /admin/stock-consistency    report of the latest check
+//	POST /admin/stock-consistency    run a check now; with auto_repair fix drifts up to max_repair_drift
+func (h *HealthServer) handleStockConsistency(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		report := h.inventoryService.LastStockConsistencyReport()
+		if report == nil {
+			h.writeJSONResponse(w, http.StatusNotFound, map[string]string{"error": "no consistency check has run yet"})
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, report)
+
+	case http.MethodPost:
+		var body checkConsistencyRequest
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+				return
+			}
+		}
+		if body.MaxRepairDrift < 0 {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "max_repair_drift cannot be negative"})
+			return
+		}
+
+		h.logger.Info("Stock consistency check requested",
+			"auto_repair", body.AutoRepair,
+			"max_repair_drift", body.MaxRepairDrift,
+			"remote_addr", r.RemoteAddr)
+
+		report, err := h.inventoryService.CheckStockConsistency(r.Context(), service.CheckStockConsistencyRequest{
+			AutoRepair:     body.AutoRepair,
+			MaxRepairDrift: body.MaxRepairDrift,
+		})
+		if err != nil {
+			h.logger.Error("Stock consistency check failed", "error", err)
+			h.writeJSONResponse(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, report)
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
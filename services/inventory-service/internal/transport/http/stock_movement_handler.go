@@ -0,0 +1,45 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+)
+
+// handleStockMovements reports the stock movement ledger of an order:
+//
+//	GET /admin/stock-movements?order_id={id}    the order's confirmed and consumed stock
+func (h *HealthServer) handleStockMovements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	orderID := r.URL.Query().Get("order_id")
+	if orderID == "" {
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "order_id query parameter is required"})
+		return
+	}
+
+	movements, err := h.inventoryService.GetOrderStockMovements(r.Context(), orderID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := err.Error()
+		switch {
+		case errors.Is(err, service.ErrStockMovementsNotConfigured):
+			status = http.StatusNotImplemented
+		case errors.Is(err, domain.ErrInvalidOrderID):
+			status = http.StatusBadRequest
+		default:
+			h.logger.Error("Stock movement request failed", "orderID", orderID, "error", err)
+			message = "internal error"
+		}
+		h.writeJSONResponse(w, status, map[string]string{"error": message})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, movements)
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+)
+
+// repairStockRequest is the JSON body for repairing an item's stock levels
+type repairStockRequest struct {
+	SKU        string `json:"sku"`
+	DryRun     bool   `json:"dry_run"`
+	RepairedBy string `json:"repaired_by"`
+}
+
+// handleStockRepair recomputes an item's stock levels from its reservation ledger:
+//
+//	POST /admin/stock-repair    repair one item; with dry_run only report the repair
+func (h *HealthServer) handleStockRepair(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	var body repairStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	result, err := h.inventoryService.RepairItemStock(r.Context(), service.RepairItemStockRequest{
+		SKU:        body.SKU,
+		DryRun:     body.DryRun,
+		RepairedBy: body.RepairedBy,
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := err.Error()
+		switch {
+		case errors.Is(err, domain.ErrItemNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, domain.ErrInvalidSKU):
+			status = http.StatusBadRequest
+		default:
+			h.logger.Error("Stock repair failed", "sku", body.SKU, "error", err)
+			message = "internal error"
+		}
+		h.writeJSONResponse(w, status, map[string]string{"error": message})
+		return
+	}
+
+	h.logger.Info("Stock repair requested",
+		"sku", result.SKU,
+		"dry_run", result.DryRun,
+		"changed", result.Repair.Changed,
+		"remote_addr", r.RemoteAddr)
+	h.writeJSONResponse(w, http.StatusOK, result)
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// itemSupplierRequest is the JSON body for creating or replacing an item's supplier
+type itemSupplierRequest struct {
+	SupplierSKU      string  `json:"supplier_sku"`
+	LeadTimeDays     int     `json:"lead_time_days"`
+	MinOrderQuantity float64 `json:"min_order_quantity"`
+	UnitCost         float64 `json:"unit_cost"`
+	UnitCostMinor    *int64  `json:"unit_cost_minor"` // Exact cost in minor units, preferred over unit_cost
+	Currency         string  `json:"currency"`        // Empty when the cost is not known
+	Preferred        bool    `json:"preferred"`
+}
+
+// handleItemSuppliers manages the suppliers items are reordered from:
+//
+//	GET    /admin/item-suppliers/{sku}             list the item's suppliers
+//	GET    /admin/item-suppliers/{sku}/{supplier}  get the item's record of a supplier
+//	PUT    /admin/item-suppliers/{sku}/{supplier}  create or replace the item's record of a supplier
+//	DELETE /admin/item-suppliers/{sku}/{supplier}  delete the item's record of a supplier
+func (h *HealthServer) handleItemSuppliers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sku, supplier, _ := strings.Cut(strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/item-suppliers"), "/"), "/")
+	if sku == "" {
+		h.writeItemSupplierError(w, domain.ErrInvalidSKU)
+		return
+	}
+
+	if supplier == "" {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+		suppliers, err := h.inventoryService.ListItemSuppliers(ctx, sku)
+		if err != nil {
+			h.writeItemSupplierError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"sku":       sku,
+			"suppliers": suppliers,
+			"count":     len(suppliers),
+		})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		record, err := h.inventoryService.GetItemSupplier(ctx, sku, supplier)
+		if err != nil {
+			h.writeItemSupplierError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, record)
+
+	case http.MethodPut:
+		var body itemSupplierRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		var unitCost domain.Money
+		if body.Currency != "" {
+			unitCost = money.FromFloat(body.UnitCost, body.Currency)
+			if body.UnitCostMinor != nil {
+				unitCost = money.New(*body.UnitCostMinor, body.Currency)
+			}
+		}
+
+		record, err := h.inventoryService.SaveItemSupplier(ctx, service.SaveItemSupplierRequest{
+			SKU:      sku,
+			Supplier: supplier,
+			Settings: domain.ItemSupplierSettings{
+				SupplierSKU:      body.SupplierSKU,
+				LeadTime:         time.Duration(body.LeadTimeDays) * 24 * time.Hour,
+				MinOrderQuantity: body.MinOrderQuantity,
+				UnitCost:         unitCost,
+				Preferred:        body.Preferred,
+			},
+		})
+		if err != nil {
+			h.writeItemSupplierError(w, err)
+			return
+		}
+		h.logger.Info("Item supplier saved", "sku", record.SKU, "supplier", record.Supplier, "remote_addr", r.RemoteAddr)
+		h.writeJSONResponse(w, http.StatusOK, record)
+
+	case http.MethodDelete:
+		if err := h.inventoryService.DeleteItemSupplier(ctx, sku, supplier); err != nil {
+			h.writeItemSupplierError(w, err)
+			return
+		}
+		h.logger.Info("Item supplier deleted", "sku", sku, "supplier", supplier, "remote_addr", r.RemoteAddr)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// writeItemSupplierError maps item supplier errors to HTTP status codes
+func (h *HealthServer) writeItemSupplierError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	message := err.Error()
+	switch {
+	case errors.Is(err, service.ErrItemSuppliersNotConfigured):
+		status = http.StatusNotImplemented
+	case errors.Is(err, domain.ErrItemNotFound),
+		errors.Is(err, domain.ErrItemSupplierNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidSKU),
+		errors.Is(err, domain.ErrInvalidSupplier),
+		errors.Is(err, domain.ErrInvalidItemSupplier),
+		errors.Is(err, domain.ErrInvalidCostPrice),
+		errors.Is(err, domain.ErrCurrencyNotAllowed):
+		status = http.StatusBadRequest
+	default:
+		h.logger.Error("Item supplier request failed", "error", err)
+		message = "internal error"
+	}
+
+	h.writeJSONResponse(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/inventory-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// costPriceRequest is the JSON body for setting an item's cost price
+type costPriceRequest struct {
+	CostPrice      float64 `json:"cost_price"`
+	CostPriceMinor *int64  `json:"cost_price_minor"` // Exact cost in minor units, preferred over cost_price
+	Currency       string  `json:"currency"`
+}
+
+// handleCostPrices manages the cost prices of items:
+//
+//	GET /admin/cost-prices/{sku}  get the cost price and cost layers of an item
+//	PUT /admin/cost-prices/{sku}  set the cost price of an item
+func (h *HealthServer) handleCostPrices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sku := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/cost-prices"), "/")
+	if sku == "" {
+		h.writeJSONResponse(w, http.StatusNotFound, map[string]string{"error": "sku is required"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		costing, err := h.inventoryService.GetItemCosting(ctx, sku)
+		if err != nil {
+			h.writeValuationError(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, costing)
+
+	case http.MethodPut:
+		var body costPriceRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		costPrice := money.FromFloat(body.CostPrice, body.Currency)
+		if body.CostPriceMinor != nil {
+			costPrice = money.New(*body.CostPriceMinor, body.Currency)
+		}
+
+		costing, err := h.inventoryService.SetCostPrice(ctx, sku, costPrice)
+		if err != nil {
+			h.writeValuationError(w, err)
+			return
+		}
+		h.logger.Info("Cost price set", "sku", sku, "remote_addr", r.RemoteAddr)
+		h.writeJSONResponse(w, http.StatusOK, costing)
+
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// handleValuation reports the inventory valuation:
+//
+//	GET /admin/valuation?method=fifo|average&from={RFC3339}&to={RFC3339}&category={name}
+//
+// from and to bound the cost of goods sold period; it defaults to everything
+// up to now.
+func (h *HealthServer) handleValuation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	query := r.URL.Query()
+	req := service.GetInventoryValuationRequest{Method: domain.ValuationMethod(query.Get("method"))}
+	for param, target := range map[string]*time.Time{"from": &req.COGSFrom, "to": &req.COGSTo} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": param + " must be an RFC3339 timestamp"})
+			return
+		}
+		*target = parsed
+	}
+	if name := query.Get("category"); name != "" {
+		category, ok := domain.ParseItemCategory(name)
+		if !ok {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "unknown category"})
+			return
+		}
+		req.Category = &category
+	}
+
+	report, err := h.inventoryService.GetInventoryValuation(r.Context(), req)
+	if err != nil {
+		h.writeValuationError(w, err)
+		return
+	}
+	h.writeJSONResponse(w, http.StatusOK, report)
+}
+
+// writeValuationError maps costing and valuation errors to HTTP status codes
+func (h *HealthServer) writeValuationError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	message := err.Error()
+	switch {
+	case errors.Is(err, domain.ErrItemNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidCostPrice),
+		errors.Is(err, domain.ErrUnknownValuation),
+		errors.Is(err, service.ErrInvalidValuationPeriod),
+		errors.Is(err, domain.ErrCurrencyNotAllowed):
+		status = http.StatusBadRequest
+	case errors.Is(err, domain.ErrCostCurrencyMismatch):
+		status = http.StatusConflict
+	default:
+		h.logger.Error("Valuation request failed", "error", err)
+		message = "internal error"
+	}
+
+	h.writeJSONResponse(w, status, map[string]string{"error": message})
+}
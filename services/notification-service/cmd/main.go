@@ -2,24 +2,46 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"os/signal"
-	"syscall"
 
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/container"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/lifecycle"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
 )
 
 func main() {
+	// Layer the environment's config profile between defaults and environment variables
+	flags := platformconfig.RegisterFlags("SERVICE_PORT")
+	restoreOffsetsAt := flag.String("restore-offsets-at", "", "commit the consumer group offsets snapshotted at the RFC 3339 time and exit; stop all consumers first")
+	flag.Parse()
+	profile, err := flags.Apply()
+	if *flags.Validation.Validate {
+		// Report on the configuration instead of starting the service
+		validateConfiguration(profile, err, *flags.Validation.CheckConnectivity).Exit()
+	}
+	if err != nil {
+		log.Fatalf("Failed to load config profile: %v", err)
+	}
+	if *flags.MigrateOnly {
+		// Notification state lives in Redis, which has no schema
+		log.Printf("Notification service has no database migrations")
+		return
+	}
+
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	if err := profile.CheckUnknownKeys(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
 	// Initialize logger
 	logger, err := logging.NewServiceLogger(cfg.Service.Name, cfg.Service.Version, cfg.Logging.Level)
@@ -47,62 +69,103 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Create context for graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	// Start health server
-	if err := cont.HealthServer.Start(ctx); err != nil {
-		logger.Error(ctx, "Failed to start health server", err, nil)
-		os.Exit(1)
+	ctx := context.Background()
+
+	if *restoreOffsetsAt != "" {
+		// Point-in-time recovery of the consumer group instead of starting the service
+		err := restoreOffsets(ctx, cfg, cont.OffsetStore, *restoreOffsetsAt, logger)
+		cont.Close()
+		if err != nil {
+			logger.Error(ctx, "Failed to restore kafka consumer offsets", err, nil)
+			os.Exit(1)
+		}
+		return
 	}
 
-	// Start Kafka consumer
-	if err := cont.KafkaConsumer.Start(ctx); err != nil {
-		logger.Error(ctx, "Failed to start Kafka consumer", err, nil)
-		os.Exit(1)
+	// Register components in start order; they are stopped in reverse
+	runner := lifecycle.NewRunner(lifecycle.FromPlatformLogger(logger))
+	runner.Add(
+		lifecycle.Component{
+			Name: "metrics",
+			Stop: func(ctx context.Context) error { return metrics.Close(metricsCollector) },
+		},
+		lifecycle.Component{
+			Name: "container",
+			Stop: func(ctx context.Context) error { return cont.Close() },
+		},
+		lifecycle.Component{
+			Name:  "health-server",
+			Start: cont.HealthServer.Start,
+			Stop:  cont.HealthServer.Stop,
+		},
+		lifecycle.Component{
+			Name:      "telegram-acknowledgements",
+			DependsOn: []string{"container"},
+			Run: func(ctx context.Context) error {
+				return cont.TelegramService.ListenForAcknowledgements(ctx, cont.DeliveryTracker.Acknowledge)
+			},
+		},
+		lifecycle.Component{
+			Name:      "escalation-engine",
+			DependsOn: []string{"container"},
+			Run:       cont.EscalationEngine.Run,
+		},
+		lifecycle.Component{
+			Name:        "kafka-consumer",
+			DependsOn:   []string{"container"},
+			Start:       cont.KafkaConsumer.Start,
+			Stop:        func(ctx context.Context) error { return cont.KafkaConsumer.Stop() },
+			StopTimeout: cfg.Service.GracefulShutdownTimeout,
+		},
+	)
+	if cont.CacheConsumer != nil {
+		runner.Add(lifecycle.Component{
+			Name:        "iam-cache-invalidation",
+			DependsOn:   []string{"container"},
+			Start:       cont.CacheConsumer.Start,
+			Stop:        func(ctx context.Context) error { return cont.CacheConsumer.Stop() },
+			StopTimeout: cfg.Service.GracefulShutdownTimeout,
+		})
+	}
+	if cont.OffsetSnapshots != nil {
+		runner.Add(lifecycle.Component{
+			Name:      "offset-snapshots",
+			DependsOn: []string{"container"},
+			Run:       cont.OffsetSnapshots.Run,
+			Stop:      func(ctx context.Context) error { return cont.OffsetSnapshots.Close() },
+		})
+	}
+	if cont.AuditLog != nil {
+		runner.Add(lifecycle.Component{
+			Name:      "audit-export",
+			DependsOn: []string{"container"},
+			Run:       cont.AuditLog.Run,
+		})
 	}
 
 	// Record startup metrics
-	cont.Metrics.IncrementCounter("notification_service_started", map[string]string{
+	cont.Metrics.IncrementCounter(ctx, "notification_service_started", map[string]string{
 		"version": cfg.Service.Version,
 	})
 
-	logger.Info(ctx, "Notification service started successfully", map[string]interface{}{
+	logger.Info(ctx, "Starting notification service components", map[string]interface{}{
 		"kafka_topics":    cfg.Kafka.Consumer.Topics,
 		"telegram_bot_id": cont.TelegramService.GetBotInfo().ID,
 		"iam_host":        cfg.IAMClient.Host,
 		"health_port":     "8080",
 	})
 
-	// Setup graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-	// Wait for shutdown signal
-	sig := <-sigChan
-	logger.Info(ctx, "Received shutdown signal", map[string]interface{}{
-		"signal": sig.String(),
-	})
-
-	// Create shutdown context with timeout
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Service.GracefulShutdownTimeout)
-	defer shutdownCancel()
-
-	// Graceful shutdown
-	logger.Info(shutdownCtx, "Shutting down notification service...", nil)
-
-	// Stop the container
-	if err := cont.Close(); err != nil {
-		logger.Error(shutdownCtx, "Error during shutdown", err, nil)
+	// Run until a shutdown signal is received or a component fails
+	if err := runner.Run(ctx); err != nil {
+		logger.Error(ctx, "Error during shutdown", err, nil)
 	}
 
 	// Record shutdown metrics
-	cont.Metrics.IncrementCounter("notification_service_stopped", map[string]string{
+	cont.Metrics.IncrementCounter(ctx, "notification_service_stopped", map[string]string{
 		"version": cfg.Service.Version,
 	})
 
-	logger.Info(shutdownCtx, "Notification service stopped gracefully", nil)
+	logger.Info(ctx, "Notification service stopped gracefully", nil)
 }
 
 // healthCheck performs a simple health check
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/config"
+	kafkaplatform "github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// restoreOffsets commits the consumer group offsets of the latest snapshot taken
+// at or before the RFC 3339 time, so the consumers resume from that point
+func restoreOffsets(ctx context.Context, cfg *config.Config, store kafkaplatform.OffsetSnapshotStore, value string, logger logging.Logger) error {
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("invalid -restore-offsets-at time: %w", err)
+	}
+	if store == nil {
+		return fmt.Errorf("kafka offset snapshots are disabled or Redis is unavailable")
+	}
+
+	groupID := cfg.Kafka.Consumer.GroupID
+	snapshot, err := store.SnapshotAt(ctx, groupID, at)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return fmt.Errorf("no offset snapshot of group %s at or before %s", groupID, at.Format(time.RFC3339))
+	}
+
+	if err := kafkaplatform.RestoreOffsets(cfg.Kafka.Consumer.Brokers, *snapshot); err != nil {
+		return err
+	}
+	logger.Info(ctx, "Kafka consumer offsets restored", map[string]interface{}{
+		"group_id":   snapshot.GroupID,
+		"taken_at":   snapshot.TakenAt,
+		"partitions": len(snapshot.Offsets),
+	})
+	return nil
+}
@@ -4,18 +4,21 @@ go 1.23.2
 
 replace github.com/amiosamu/rocket-science/shared => ../../shared
 
-replace github.com/amiosamu/rocket-science/services/iam-service => ../iam-service
+replace github.com/amiosamu/rocket-science/shared/contracts/proto => ../../shared/contracts/proto
 
 require (
-	github.com/amiosamu/rocket-science/services/iam-service v0.0.0-00010101000000-000000000000
 	github.com/amiosamu/rocket-science/shared v0.0.0-00010101000000-000000000000
+	github.com/amiosamu/rocket-science/shared/contracts/proto v0.0.0-00010101000000-000000000000
 	github.com/go-telegram-bot-api/telegram-bot-api/v5 v5.5.1
+	github.com/redis/go-redis/v9 v9.10.0
 	google.golang.org/grpc v1.73.0
 )
 
 require (
 	github.com/IBM/sarama v1.45.2 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	github.com/eapache/go-resiliency v1.7.0 // indirect
 	github.com/eapache/go-xerial-snappy v0.0.0-20230731223053-c322873962e3 // indirect
 	github.com/eapache/queue v1.1.0 // indirect
@@ -7,18 +7,26 @@ import (
 	"strings"
 	"time"
 
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/database/redis"
 	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
 )
 
 // Config holds all configuration for the notification service
 type Config struct {
-	Service   ServiceConfig   `json:"service"`
-	Kafka     KafkaConfig     `json:"kafka"`
-	Telegram  TelegramConfig  `json:"telegram"`
-	IAMClient IAMClientConfig `json:"iam_client"`
-	Logging   LoggingConfig   `json:"logging"`
-	Metrics   MetricsConfig   `json:"metrics"`
-	Tracing   TracingConfig   `json:"tracing"`
+	Service    ServiceConfig    `json:"service"`
+	Kafka      KafkaConfig      `json:"kafka"`
+	Telegram   TelegramConfig   `json:"telegram"`
+	IAMClient  IAMClientConfig  `json:"iam_client"`
+	Redis      redis.Config     `json:"redis"`
+	Dedup      DedupConfig      `json:"dedup"`
+	Delivery   DeliveryConfig   `json:"delivery"`
+	Escalation EscalationConfig `json:"escalation"`
+	Audit      AuditConfig      `json:"audit"`
+	Logging    LoggingConfig    `json:"logging"`
+	Metrics    MetricsConfig    `json:"metrics"`
+	Tracing    TracingConfig    `json:"tracing"`
 }
 
 // ServiceConfig holds general service configuration
@@ -35,16 +43,24 @@ type ServiceConfig struct {
 // KafkaConfig holds Kafka consumer configuration
 type KafkaConfig struct {
 	Consumer kafka.ConsumerConfig `json:"consumer"`
-	Topics   TopicConfig          `json:"topics"`
-}
+	Topics   topics.Registry      `json:"topics"`
+
+	// RequireNotifyHeader skips events not marked notify=true by their producer. Disable
+	// it while producers that don't set the header yet are still deployed.
+	RequireNotifyHeader bool `json:"require_notify_header"`
 
-// TopicConfig holds topic names for different event types
-type TopicConfig struct {
-	OrderEvents    string `json:"order_events"`
-	PaymentEvents  string `json:"payment_events"`
-	AssemblyEvents string `json:"assembly_events"`
+	// OffsetSnapshots periodically records the consumer group's committed offsets
+	// in Redis for point-in-time recovery and audit; it needs Redis
+	OffsetSnapshots         kafka.OffsetSnapshotConfig `json:"offset_snapshots"`
+	OffsetSnapshotKeyPrefix string                     `json:"offset_snapshot_key_prefix"`
+
+	// Encryption decrypts the payloads producers encrypted on PII-bearing topics
+	Encryption kafka.EncryptionConfig `json:"encryption"`
 }
 
+// UsedTopics are the registered topics the service consumes from
+var UsedTopics = []topics.Topic{topics.OrderEvents, topics.PaymentEvents, topics.AssemblyEvents, topics.UserEvents}
+
 // TelegramConfig holds Telegram bot configuration
 type TelegramConfig struct {
 	BotToken        string        `json:"bot_token"`
@@ -55,6 +71,27 @@ type TelegramConfig struct {
 	MessageLimit    int           `json:"message_limit"`
 	EnableWebhook   bool          `json:"enable_webhook"`
 	WebhookURL      string        `json:"webhook_url"`
+	TestChatID      int64         `json:"test_chat_id"` // Chat that receives template test sends; 0 disables them
+	ProxyURL        string        `json:"proxy_url"`    // Proxy for Bot API calls; HTTPS_PROXY applies when empty
+
+	// The Bot API client stops calling Telegram for BreakerOpenDuration after
+	// BreakerThreshold consecutive failed requests; 0 disables the breaker
+	BreakerThreshold    int           `json:"breaker_threshold"`
+	BreakerOpenDuration time.Duration `json:"breaker_open_duration"`
+
+	// Readiness fails once the Bot API has not answered for UnreachableThreshold.
+	// The API is probed when no send reached it for ProbeInterval.
+	UnreachableThreshold time.Duration `json:"unreachable_threshold"`
+	ProbeInterval        time.Duration `json:"probe_interval"`
+
+	// Send limits kept below Telegram's: GlobalRate messages per second across
+	// chats and one message per ChatInterval to each chat. Past ChatQueueLimit
+	// queued messages, a chat's notifications are folded into one digest.
+	GlobalRate         float64       `json:"global_rate"`
+	ChatInterval       time.Duration `json:"chat_interval"`
+	ChatQueueLimit     int           `json:"chat_queue_limit"`
+	SendWorkers        int           `json:"send_workers"`
+	MaxThrottleRetries int           `json:"max_throttle_retries"` // 429 retry_after waits per message
 }
 
 // IAMClientConfig holds IAM service client configuration
@@ -69,6 +106,45 @@ type IAMClientConfig struct {
 	CertFile    string        `json:"cert_file"`
 	KeyFile     string        `json:"key_file"`
 	CAFile      string        `json:"ca_file"`
+
+	// Chat ID lookups are cached for CacheTTL and dropped earlier when IAM
+	// announces a change to the user on the user events topic
+	CacheEnabled    bool          `json:"cache_enabled"`
+	CacheTTL        time.Duration `json:"cache_ttl"`
+	CacheMaxEntries int           `json:"cache_max_entries"`
+}
+
+// DedupConfig holds notification deduplication configuration
+type DedupConfig struct {
+	Enabled   bool          `json:"enabled"`
+	TTL       time.Duration `json:"ttl"`        // How long an event ID is remembered
+	KeyPrefix string        `json:"key_prefix"` // Redis key prefix for dedup entries
+}
+
+// DeliveryConfig holds delivery and acknowledgement tracking configuration
+type DeliveryConfig struct {
+	Retention time.Duration `json:"retention"`  // How long delivery records are kept
+	KeyPrefix string        `json:"key_prefix"` // Redis key prefix for delivery records
+}
+
+// EscalationConfig holds on-call escalation configuration for operational alerts
+type EscalationConfig struct {
+	CheckInterval     time.Duration `json:"check_interval"`      // How often open alerts are checked for escalation
+	DefaultAckTimeout time.Duration `json:"default_ack_timeout"` // Ack timeout of schedules that don't set one
+	Retention         time.Duration `json:"retention"`           // How long the escalation audit trail is kept
+	KeyPrefix         string        `json:"key_prefix"`          // Redis key prefix for schedules and escalations
+}
+
+// AuditConfig holds the audit trail of consumed events and notification
+// outcomes. Records are spooled to a local file per day; completed days are
+// exported to object storage as gzip-compressed NDJSON, one file per instance.
+type AuditConfig struct {
+	Enabled        bool          `json:"enabled"`
+	SpoolDir       string        `json:"spool_dir"`       // Local directory holding the days not exported yet
+	StorageDir     string        `json:"storage_dir"`     // Object store directory the daily files are exported to
+	InstanceID     string        `json:"instance_id"`     // Names this instance's file of each day
+	ExportInterval time.Duration `json:"export_interval"` // How often completed days are exported
+	Retention      time.Duration `json:"retention"`       // How long exported days are kept
 }
 
 // LoggingConfig holds logging configuration
@@ -122,7 +198,7 @@ func LoadConfig() (*Config, error) {
 				Brokers:            strings.Split(getEnvWithDefault("KAFKA_BROKERS", "localhost:9092"), ","),
 				GroupID:            getEnvWithDefault("KAFKA_GROUP_ID", "notification-service-group"),
 				ClientID:           getEnvWithDefault("KAFKA_CLIENT_ID", "notification-service"),
-				Topics:             []string{}, // Will be populated from topic config
+				Topics:             []string{}, // Will be populated from the topic registry
 				SessionTimeout:     getEnvAsDurationWithDefault("KAFKA_SESSION_TIMEOUT", 30*time.Second),
 				HeartbeatInterval:  getEnvAsDurationWithDefault("KAFKA_HEARTBEAT_INTERVAL", 3*time.Second),
 				RebalanceTimeout:   getEnvAsDurationWithDefault("KAFKA_REBALANCE_TIMEOUT", 60*time.Second),
@@ -135,12 +211,20 @@ func LoadConfig() (*Config, error) {
 				RetryBackoff:       getEnvAsDurationWithDefault("KAFKA_RETRY_BACKOFF", 1*time.Second),
 				EnableDeadLetter:   getEnvAsBoolWithDefault("KAFKA_ENABLE_DEAD_LETTER", true),
 				DeadLetterTopic:    getEnvWithDefault("KAFKA_DEAD_LETTER_TOPIC", "notification-dead-letter"),
+				Membership: kafka.GroupMembership{
+					InstanceID:        getEnvWithDefault("KAFKA_GROUP_INSTANCE_ID", ""),
+					RebalanceStrategy: getEnvWithDefault("KAFKA_REBALANCE_STRATEGY", kafka.RebalanceStrategySticky),
+				},
 			},
-			Topics: TopicConfig{
-				OrderEvents:    getEnvWithDefault("KAFKA_ORDER_EVENTS_TOPIC", "order-events"),
-				PaymentEvents:  getEnvWithDefault("KAFKA_PAYMENT_EVENTS_TOPIC", "payment-events"),
-				AssemblyEvents: getEnvWithDefault("KAFKA_ASSEMBLY_EVENTS_TOPIC", "assembly-events"),
+			Topics:              topics.Load(platformconfig.Getenv),
+			RequireNotifyHeader: getEnvAsBoolWithDefault("KAFKA_REQUIRE_NOTIFY_HEADER", true),
+			Encryption:          kafka.LoadEncryptionConfig(platformconfig.Getenv),
+			OffsetSnapshots: kafka.OffsetSnapshotConfig{
+				Enabled:   getEnvAsBoolWithDefault("KAFKA_OFFSET_SNAPSHOTS_ENABLED", true),
+				Interval:  getEnvAsDurationWithDefault("KAFKA_OFFSET_SNAPSHOT_INTERVAL", time.Minute),
+				Retention: getEnvAsDurationWithDefault("KAFKA_OFFSET_SNAPSHOT_RETENTION", 168*time.Hour),
 			},
+			OffsetSnapshotKeyPrefix: getEnvWithDefault("KAFKA_OFFSET_SNAPSHOT_KEY_PREFIX", "notification:offsets:"),
 		},
 		Telegram: TelegramConfig{
 			BotToken:        getEnvWithDefault("TELEGRAM_BOT_TOKEN", ""),
@@ -151,6 +235,20 @@ func LoadConfig() (*Config, error) {
 			MessageLimit:    getEnvAsIntWithDefault("TELEGRAM_MESSAGE_LIMIT", 4096),
 			EnableWebhook:   getEnvAsBoolWithDefault("TELEGRAM_ENABLE_WEBHOOK", false),
 			WebhookURL:      getEnvWithDefault("TELEGRAM_WEBHOOK_URL", ""),
+			TestChatID:      getEnvAsInt64WithDefault("TELEGRAM_TEST_CHAT_ID", 0),
+			ProxyURL:        getEnvWithDefault("TELEGRAM_PROXY_URL", ""),
+
+			BreakerThreshold:    getEnvAsIntWithDefault("TELEGRAM_BREAKER_THRESHOLD", 5),
+			BreakerOpenDuration: getEnvAsDurationWithDefault("TELEGRAM_BREAKER_OPEN_DURATION", 30*time.Second),
+
+			UnreachableThreshold: getEnvAsDurationWithDefault("TELEGRAM_UNREACHABLE_THRESHOLD", 2*time.Minute),
+			ProbeInterval:        getEnvAsDurationWithDefault("TELEGRAM_PROBE_INTERVAL", 30*time.Second),
+
+			GlobalRate:         getEnvAsFloatWithDefault("TELEGRAM_GLOBAL_RATE", 25),
+			ChatInterval:       getEnvAsDurationWithDefault("TELEGRAM_CHAT_INTERVAL", 1*time.Second),
+			ChatQueueLimit:     getEnvAsIntWithDefault("TELEGRAM_CHAT_QUEUE_LIMIT", 10),
+			SendWorkers:        getEnvAsIntWithDefault("TELEGRAM_SEND_WORKERS", 4),
+			MaxThrottleRetries: getEnvAsIntWithDefault("TELEGRAM_MAX_THROTTLE_RETRIES", 5),
 		},
 		IAMClient: IAMClientConfig{
 			Host:        getEnvWithDefault("IAM_SERVICE_HOST", "localhost"),
@@ -163,6 +261,46 @@ func LoadConfig() (*Config, error) {
 			CertFile:    getEnvWithDefault("IAM_CLIENT_CERT_FILE", ""),
 			KeyFile:     getEnvWithDefault("IAM_CLIENT_KEY_FILE", ""),
 			CAFile:      getEnvWithDefault("IAM_CLIENT_CA_FILE", ""),
+
+			CacheEnabled:    getEnvAsBoolWithDefault("IAM_CACHE_ENABLED", true),
+			CacheTTL:        getEnvAsDurationWithDefault("IAM_CACHE_TTL", 5*time.Minute),
+			CacheMaxEntries: getEnvAsIntWithDefault("IAM_CACHE_MAX_ENTRIES", 10000),
+		},
+		Redis: redis.Config{
+			Host:         getEnvWithDefault("REDIS_HOST", "localhost"),
+			Port:         getEnvAsIntWithDefault("REDIS_PORT", 6379),
+			Password:     getEnvWithDefault("REDIS_PASSWORD", ""),
+			DB:           getEnvAsIntWithDefault("REDIS_DB", 0),
+			PoolSize:     getEnvAsIntWithDefault("REDIS_POOL_SIZE", 10),
+			MinIdleConns: getEnvAsIntWithDefault("REDIS_MIN_IDLE_CONNS", 2),
+			DialTimeout:  getEnvAsDurationWithDefault("REDIS_DIAL_TIMEOUT", 5*time.Second),
+			ReadTimeout:  getEnvAsDurationWithDefault("REDIS_READ_TIMEOUT", 3*time.Second),
+			WriteTimeout: getEnvAsDurationWithDefault("REDIS_WRITE_TIMEOUT", 3*time.Second),
+			IdleTimeout:  getEnvAsDurationWithDefault("REDIS_IDLE_TIMEOUT", 5*time.Minute),
+			MaxRetries:   getEnvAsIntWithDefault("REDIS_MAX_RETRIES", 3),
+		},
+		Dedup: DedupConfig{
+			Enabled:   getEnvAsBoolWithDefault("NOTIFICATION_DEDUP_ENABLED", true),
+			TTL:       getEnvAsDurationWithDefault("NOTIFICATION_DEDUP_TTL", 24*time.Hour),
+			KeyPrefix: getEnvWithDefault("NOTIFICATION_DEDUP_KEY_PREFIX", "notification:dedup:"),
+		},
+		Delivery: DeliveryConfig{
+			Retention: getEnvAsDurationWithDefault("NOTIFICATION_DELIVERY_RETENTION", 7*24*time.Hour),
+			KeyPrefix: getEnvWithDefault("NOTIFICATION_DELIVERY_KEY_PREFIX", "notification:delivery:"),
+		},
+		Escalation: EscalationConfig{
+			CheckInterval:     getEnvAsDurationWithDefault("NOTIFICATION_ESCALATION_CHECK_INTERVAL", 30*time.Second),
+			DefaultAckTimeout: getEnvAsDurationWithDefault("NOTIFICATION_ESCALATION_ACK_TIMEOUT", 15*time.Minute),
+			Retention:         getEnvAsDurationWithDefault("NOTIFICATION_ESCALATION_RETENTION", 30*24*time.Hour),
+			KeyPrefix:         getEnvWithDefault("NOTIFICATION_ESCALATION_KEY_PREFIX", "notification:escalation:"),
+		},
+		Audit: AuditConfig{
+			Enabled:        getEnvAsBoolWithDefault("NOTIFICATION_AUDIT_ENABLED", true),
+			SpoolDir:       getEnvWithDefault("NOTIFICATION_AUDIT_SPOOL_DIR", "./data/notification-audit-spool"),
+			StorageDir:     getEnvWithDefault("NOTIFICATION_AUDIT_STORAGE_DIR", "./data/notification-audit"),
+			InstanceID:     getEnvWithDefault("NOTIFICATION_AUDIT_INSTANCE_ID", hostname()),
+			ExportInterval: getEnvAsDurationWithDefault("NOTIFICATION_AUDIT_EXPORT_INTERVAL", 10*time.Minute),
+			Retention:      getEnvAsDurationWithDefault("NOTIFICATION_AUDIT_RETENTION", 365*24*time.Hour),
 		},
 		Logging: LoggingConfig{
 			Level:        getEnvWithDefault("LOG_LEVEL", "info"),
@@ -195,11 +333,7 @@ func LoadConfig() (*Config, error) {
 	}
 
 	// Populate Kafka topics
-	config.Kafka.Consumer.Topics = []string{
-		config.Kafka.Topics.OrderEvents,
-		config.Kafka.Topics.PaymentEvents,
-		config.Kafka.Topics.AssemblyEvents,
-	}
+	config.Kafka.Consumer.Topics = config.Kafka.Topics.Names(UsedTopics...)
 
 	// Validate configuration
 	if err := config.Validate(); err != nil {
@@ -215,21 +349,76 @@ func (c *Config) Validate() error {
 	if !c.Telegram.DevelopmentMode && c.Telegram.BotToken == "" {
 		return fmt.Errorf("telegram bot token is required")
 	}
+	if c.Telegram.Timeout <= 0 {
+		return fmt.Errorf("telegram timeout must be positive")
+	}
+	if c.Telegram.BreakerThreshold < 0 || (c.Telegram.BreakerThreshold > 0 && c.Telegram.BreakerOpenDuration <= 0) {
+		return fmt.Errorf("telegram breaker threshold cannot be negative and its open duration must be positive")
+	}
+	if c.Telegram.UnreachableThreshold <= 0 || c.Telegram.ProbeInterval <= 0 {
+		return fmt.Errorf("telegram unreachable threshold and probe interval must be positive")
+	}
+	if c.Telegram.GlobalRate <= 0 || c.Telegram.ChatInterval < 0 {
+		return fmt.Errorf("telegram global rate must be positive and chat interval cannot be negative")
+	}
+	if c.Telegram.ChatQueueLimit < 1 || c.Telegram.SendWorkers < 1 || c.Telegram.MaxThrottleRetries < 0 {
+		return fmt.Errorf("telegram chat queue limit and send workers must be at least 1, throttle retries cannot be negative")
+	}
 
 	// Validate Kafka brokers
 	if len(c.Kafka.Consumer.Brokers) == 0 {
 		return fmt.Errorf("kafka brokers are required")
 	}
+	if err := c.Kafka.Consumer.Membership.Validate(); err != nil {
+		return err
+	}
+	if err := c.Kafka.OffsetSnapshots.Validate(); err != nil {
+		return err
+	}
 
 	// Validate topics
-	if c.Kafka.Topics.OrderEvents == "" || c.Kafka.Topics.PaymentEvents == "" || c.Kafka.Topics.AssemblyEvents == "" {
-		return fmt.Errorf("all kafka topics must be configured")
+	if err := c.Kafka.Topics.Validate(); err != nil {
+		return err
+	}
+	if err := c.Kafka.Encryption.Validate(); err != nil {
+		return err
 	}
 
 	// Validate IAM client
 	if c.IAMClient.Host == "" {
 		return fmt.Errorf("IAM service host is required")
 	}
+	if c.IAMClient.CacheEnabled && (c.IAMClient.CacheTTL <= 0 || c.IAMClient.CacheMaxEntries < 1) {
+		return fmt.Errorf("IAM cache TTL must be positive and max entries at least 1")
+	}
+
+	// Validate deduplication window
+	if c.Dedup.Enabled && c.Dedup.TTL <= 0 {
+		return fmt.Errorf("notification dedup TTL must be positive")
+	}
+
+	// Validate delivery tracking retention
+	if c.Delivery.Retention <= 0 {
+		return fmt.Errorf("notification delivery retention must be positive")
+	}
+
+	// Validate escalation timing
+	if c.Escalation.CheckInterval <= 0 || c.Escalation.DefaultAckTimeout <= 0 || c.Escalation.Retention <= 0 {
+		return fmt.Errorf("notification escalation check interval, ack timeout and retention must be positive")
+	}
+
+	// Validate audit export
+	if c.Audit.Enabled {
+		if c.Audit.SpoolDir == "" || c.Audit.StorageDir == "" || c.Audit.InstanceID == "" {
+			return fmt.Errorf("notification audit spool dir, storage dir and instance ID are required")
+		}
+		if strings.ContainsAny(c.Audit.InstanceID, "/\\") {
+			return fmt.Errorf("notification audit instance ID must not contain path separators")
+		}
+		if c.Audit.ExportInterval <= 0 || c.Audit.Retention < 24*time.Hour {
+			return fmt.Errorf("notification audit export interval must be positive and retention at least a day")
+		}
+	}
 
 	return nil
 }
@@ -237,44 +426,71 @@ func (c *Config) Validate() error {
 // Helper functions for environment variable parsing
 
 func getEnvWithDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := platformconfig.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
 func getEnvAsIntWithDefault(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		intValue, err := strconv.Atoi(value)
+		if err == nil {
+			return intValue
+		}
+		platformconfig.InvalidValue(key, value, err)
+	}
+	return defaultValue
+}
+
+func getEnvAsInt64WithDefault(key string, defaultValue int64) int64 {
+	if value := platformconfig.Getenv(key); value != "" {
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err == nil {
 			return intValue
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	return defaultValue
 }
 
 func getEnvAsBoolWithDefault(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
-		if boolValue, err := strconv.ParseBool(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		boolValue, err := strconv.ParseBool(value)
+		if err == nil {
 			return boolValue
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	return defaultValue
 }
 
 func getEnvAsFloatWithDefault(key string, defaultValue float64) float64 {
-	if value := os.Getenv(key); value != "" {
-		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		floatValue, err := strconv.ParseFloat(value, 64)
+		if err == nil {
 			return floatValue
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	return defaultValue
 }
 
 func getEnvAsDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		duration, err := time.ParseDuration(value)
+		if err == nil {
 			return duration
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	return defaultValue
 }
+
+// hostname names this instance, falling back to the service name
+func hostname() string {
+	if name, err := os.Hostname(); err == nil && name != "" {
+		return name
+	}
+	return "notification-service"
+}
@@ -1,33 +1,63 @@
 package container
 
 import (
-	"context"
 	"fmt"
+	nethttp "net/http"
+	"os"
 
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/messaging/kafka"
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/service"
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/transport/grpc/clients"
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/transport/http"
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	"github.com/amiosamu/rocket-science/shared/platform/database/redis"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/events"
 	kafkaplatform "github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/objectstore"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/grpclog"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
 )
 
 // Container holds all service dependencies
 type Container struct {
-	Config          config.Config
-	Logger          logging.Logger
-	Metrics         metrics.Metrics
-	TelegramService service.TelegramServiceInterface
-	IAMClient       *clients.IAMClient
-	EventConsumer   *kafka.EventConsumer
-	KafkaConsumer   *kafkaplatform.Consumer
-	HealthServer    *http.HealthServer
+	Config           config.Config
+	Logger           logging.Logger
+	Metrics          metrics.Metrics
+	TelegramService  service.TelegramServiceInterface
+	IAMClient        *clients.IAMClient
+	RedisConn        *redis.Connection
+	DedupStore       service.DedupStore
+	DeliveryTracker  *service.DeliveryTracker
+	EscalationEngine *service.EscalationEngine
+	AuditLog         *service.AuditLog // Nil when the audit trail is disabled
+	CrashReporter    recovery.Reporter
+	EventConsumer    *kafka.EventConsumer
+	KafkaConsumer    *kafkaplatform.Consumer
+	CacheConsumer    *kafkaplatform.Consumer // Nil when the IAM cache is disabled
+	Maintenance      *maintenance.Mode
+	HealthServer     *http.HealthServer
+
+	// Nil when offset snapshots are disabled or Redis is unavailable
+	OffsetStore     kafkaplatform.OffsetSnapshotStore
+	OffsetSnapshots *kafkaplatform.OffsetSnapshotter
 }
 
 // NewContainer creates a new container with all dependencies
 func NewContainer(cfg config.Config, logger logging.Logger, metrics metrics.Metrics) (*Container, error) {
+	// Create crash reporter for panics recovered from Kafka messages
+	recoveryConfig, err := recovery.FromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("invalid crash reporting configuration: %w", err)
+	}
+	crashReporter, err := recovery.New(recoveryConfig, cfg.Service.Name, grpclog.FromLogger(logger))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create crash reporter: %w", err)
+	}
+
 	// Create Telegram service (real or mock based on configuration)
 	var telegramService service.TelegramServiceInterface
 	if cfg.Telegram.DevelopmentMode {
@@ -48,8 +78,72 @@ func NewContainer(cfg config.Config, logger logging.Logger, metrics metrics.Metr
 		return nil, fmt.Errorf("failed to create IAM client: %w", err)
 	}
 
+	// Connect to Redis for dedup and delivery tracking; fall back to
+	// in-memory stores if Redis is unavailable
+	redisConn, err := redis.NewConnection(cfg.Redis, logger)
+	if err != nil {
+		logger.Warn(nil, "Redis unavailable, using in-memory notification stores", map[string]interface{}{
+			"error": err.Error(),
+		})
+		redisConn = nil
+	}
+
+	// Create dedup store
+	var dedupStore service.DedupStore
+	if cfg.Dedup.Enabled {
+		if redisConn != nil {
+			dedupStore = service.NewRedisDedupStore(redisConn.Client, cfg.Dedup.KeyPrefix, cfg.Dedup.TTL)
+		} else {
+			dedupStore = service.NewMemoryDedupStore(cfg.Dedup.TTL)
+		}
+	}
+
+	// Create delivery tracker
+	var deliveryStore service.DeliveryStore
+	if redisConn != nil {
+		deliveryStore = service.NewRedisDeliveryStore(redisConn.Client, cfg.Delivery.KeyPrefix, cfg.Delivery.Retention)
+	} else {
+		deliveryStore = service.NewMemoryDeliveryStore(cfg.Delivery.Retention)
+	}
+	deliveryTracker := service.NewDeliveryTracker(deliveryStore, logger, metrics)
+
+	// Create escalation engine for operational alerts
+	var escalationStore service.EscalationStore
+	if redisConn != nil {
+		escalationStore = service.NewRedisEscalationStore(redisConn.Client, cfg.Escalation.KeyPrefix, cfg.Escalation.Retention)
+	} else {
+		escalationStore = service.NewMemoryEscalationStore(cfg.Escalation.Retention)
+	}
+	escalationEngine := service.NewEscalationEngine(escalationStore, deliveryTracker, telegramService, cfg.Escalation, logger, metrics)
+
+	// Create template previewer for the admin API
+	templatePreviewer := service.NewTemplatePreviewer(telegramService, cfg.Telegram.TestChatID, logger, metrics)
+
+	// Create the audit trail of consumed events and notification outcomes
+	var auditLog *service.AuditLog
+	if cfg.Audit.Enabled {
+		auditStore, err := objectstore.NewFileStore(cfg.Audit.StorageDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notification audit store: %w", err)
+		}
+		auditLog, err = service.NewAuditLog(auditStore, cfg.Audit, logger, metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notification audit log: %w", err)
+		}
+	}
+
 	// Create event consumer
-	eventConsumer := kafka.NewEventConsumer(cfg, logger, metrics, telegramService, iamClient)
+	eventConsumer := kafka.NewEventConsumer(cfg, logger, metrics, telegramService, iamClient, dedupStore, deliveryTracker, auditLog)
+
+	// Check that the registered topics exist before consuming from them
+	if err := kafkaplatform.VerifyTopics(cfg.Kafka.Consumer.Brokers, cfg.Kafka.Topics, config.UsedTopics...); err != nil {
+		if cfg.Kafka.Topics.Strict() {
+			return nil, fmt.Errorf("kafka topic check failed: %w", err)
+		}
+		logger.Warn(nil, "Kafka topic check failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
 
 	// Create Kafka consumer
 	kafkaConsumer, err := kafkaplatform.NewConsumer(cfg.Kafka.Consumer, logger, metrics)
@@ -57,8 +151,54 @@ func NewContainer(cfg config.Config, logger logging.Logger, metrics metrics.Metr
 		return nil, fmt.Errorf("failed to create Kafka consumer: %w", err)
 	}
 
+	// Decrypt the payloads of encrypted topics such as user events
+	var payloadEncryption *kafkaplatform.PayloadEncryption
+	if cfg.Kafka.Encryption.Enabled() {
+		payloadEncryption, err = cfg.Kafka.Encryption.NewPayloadEncryption(cfg.Kafka.Topics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kafka payload encryption: %w", err)
+		}
+		kafkaConsumer.SetPayloadEncryption(payloadEncryption)
+		eventConsumer.SetPayloadEncryption(payloadEncryption)
+	}
+
+	// Payment events retained from before a schema change are upcast to the current version
+	kafkaConsumer.SetUpcasters(events.NewRegistry(metrics, events.PaymentEventChain()))
+
 	// Register event consumer as message handler
-	kafkaConsumer.RegisterHandler(eventConsumer)
+	kafkaConsumer.RegisterHandler(recovery.Handler(eventConsumer, crashReporter))
+
+	// Consume user events into the IAM cache; every instance has a cache of its
+	// own and so a consumer group of its own, starting from the newest events
+	var cacheConsumer *kafkaplatform.Consumer
+	if cache := iamClient.Cache(); cache != nil {
+		hostname, _ := os.Hostname()
+		cacheConfig := cfg.Kafka.Consumer
+		cacheConfig.GroupID = fmt.Sprintf("%s-iam-cache-%s", cfg.Kafka.Consumer.GroupID, hostname)
+		cacheConfig.Topics = cfg.Kafka.Topics.Names(topics.UserEvents)
+		cacheConfig.InitialOffset = "newest"
+		cacheConfig.EnableDeadLetter = false
+		cacheConfig.Membership.InstanceID = ""
+
+		cacheConsumer, err = kafkaplatform.NewConsumer(cacheConfig, logger, metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create IAM cache consumer: %w", err)
+		}
+		if payloadEncryption != nil {
+			cacheConsumer.SetPayloadEncryption(payloadEncryption)
+		}
+		cacheConsumer.RegisterHandler(recovery.Handler(cache.InvalidationHandler(cfg.Kafka.Topics.Name(topics.UserEvents)), crashReporter))
+	}
+
+	// Create maintenance mode switch; while enabled no new events are consumed
+	maintenanceMode := maintenance.FromEnv()
+	maintenanceMode.OnChange(func(enabled bool) {
+		if enabled {
+			kafkaConsumer.Pause()
+		} else {
+			kafkaConsumer.Resume()
+		}
+	})
 
 	// Create health server
 	healthPort := "8080" // Default health port
@@ -66,10 +206,33 @@ func NewContainer(cfg config.Config, logger logging.Logger, metrics metrics.Metr
 		healthPort = fmt.Sprintf("%d", cfg.Service.HealthPort)
 	}
 
+	// Snapshot the consumer group's offsets; they are only worth keeping
+	// where every replica and a restore can read them
+	var offsetSnapshots kafkaplatform.OffsetSnapshotStore
+	var offsetSnapshotter *kafkaplatform.OffsetSnapshotter
+	var offsetSnapshotHandler nethttp.Handler
+	if cfg.Kafka.OffsetSnapshots.Enabled {
+		if redisConn != nil {
+			offsetSnapshots = service.NewRedisOffsetSnapshotStore(redisConn.Client, cfg.Kafka.OffsetSnapshotKeyPrefix)
+			offsetSnapshotter, err = kafkaplatform.NewOffsetSnapshotter(cfg.Kafka.Consumer.Brokers, cfg.Kafka.Consumer.GroupID, offsetSnapshots, cfg.Kafka.OffsetSnapshots, logger, metrics)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create kafka offset snapshotter: %w", err)
+			}
+			offsetSnapshotHandler = kafkaplatform.OffsetSnapshotHandler(offsetSnapshots, cfg.Kafka.Consumer.GroupID)
+		} else {
+			logger.Warn(nil, "Redis unavailable, kafka offset snapshots disabled", nil)
+		}
+	}
+
 	healthServer := http.NewHealthServer(
 		telegramService,
 		iamClient,
 		kafkaConsumer,
+		deliveryTracker,
+		escalationEngine,
+		templatePreviewer,
+		maintenanceMode,
+		offsetSnapshotHandler,
 		logger,
 		metrics,
 		healthPort,
@@ -80,44 +243,58 @@ func NewContainer(cfg config.Config, logger logging.Logger, metrics metrics.Metr
 		"kafka_topics":    cfg.Kafka.Consumer.Topics,
 		"iam_host":        cfg.IAMClient.Host,
 		"health_port":     healthPort,
+		"dedup_enabled":   cfg.Dedup.Enabled,
+		"audit_enabled":   cfg.Audit.Enabled,
+		"iam_cache":       cfg.IAMClient.CacheEnabled,
 	})
 
 	return &Container{
-		Config:          cfg,
-		Logger:          logger,
-		Metrics:         metrics,
-		TelegramService: telegramService,
-		IAMClient:       iamClient,
-		EventConsumer:   eventConsumer,
-		KafkaConsumer:   kafkaConsumer,
-		HealthServer:    healthServer,
+		Config:           cfg,
+		Logger:           logger,
+		Metrics:          metrics,
+		TelegramService:  telegramService,
+		IAMClient:        iamClient,
+		RedisConn:        redisConn,
+		DedupStore:       dedupStore,
+		DeliveryTracker:  deliveryTracker,
+		EscalationEngine: escalationEngine,
+		AuditLog:         auditLog,
+		CrashReporter:    crashReporter,
+		EventConsumer:    eventConsumer,
+		KafkaConsumer:    kafkaConsumer,
+		CacheConsumer:    cacheConsumer,
+		OffsetStore:      offsetSnapshots,
+		OffsetSnapshots:  offsetSnapshotter,
+		Maintenance:      maintenanceMode,
+		HealthServer:     healthServer,
 	}, nil
 }
 
-// Close cleans up all resources
+// Close releases client connections. The health server and Kafka consumer
+// are started and stopped by the lifecycle runner in main.
 func (c *Container) Close() error {
-	ctx := context.Background()
-
-	// Stop health server
-	if c.HealthServer != nil {
-		if err := c.HealthServer.Stop(ctx); err != nil {
-			c.Logger.Error(nil, "Failed to stop health server", err, nil)
-		}
-	}
-
-	// Stop Kafka consumer
-	if err := c.KafkaConsumer.Stop(); err != nil {
-		c.Logger.Error(nil, "Failed to stop Kafka consumer", err, nil)
-	}
-
 	// Close IAM client
 	if err := c.IAMClient.Close(); err != nil {
 		c.Logger.Error(nil, "Failed to close IAM client", err, nil)
 	}
 
+	// Close Redis connection
+	if c.RedisConn != nil {
+		if err := c.RedisConn.Close(); err != nil {
+			c.Logger.Error(nil, "Failed to close Redis connection", err, nil)
+		}
+	}
+
 	// Close Telegram service
 	c.TelegramService.Close()
 
+	// Close the audit spool file
+	if c.AuditLog != nil {
+		if err := c.AuditLog.Close(); err != nil {
+			c.Logger.Error(nil, "Failed to close notification audit log", err, nil)
+		}
+	}
+
 	c.Logger.Info(nil, "Container closed successfully", nil)
 	return nil
 }
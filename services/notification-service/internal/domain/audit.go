@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// AuditRecordKind tells what an audit record describes
+type AuditRecordKind string
+
+const (
+	AuditRecordEvent        AuditRecordKind = "event"        // A consumed event and how it was handled
+	AuditRecordNotification AuditRecordKind = "notification" // A notification and the outcome of its send
+)
+
+// Outcomes of consumed events
+const (
+	AuditOutcomeProcessed = "processed"
+	AuditOutcomeFailed    = "failed"
+	AuditOutcomeDuplicate = "duplicate"
+	AuditOutcomeInvalid   = "invalid" // The event could not be decoded
+)
+
+// AuditRecord is one line of the notification audit trail, answering what a
+// customer was told and when, and which event it was told about
+type AuditRecord struct {
+	Kind       AuditRecordKind `json:"kind"`
+	RecordedAt time.Time       `json:"recorded_at"`
+
+	// Event the record belongs to; notifications carry the event that caused them
+	EventID   string                 `json:"event_id,omitempty"`
+	EventType string                 `json:"event_type,omitempty"`
+	Source    string                 `json:"source,omitempty"`
+	Topic     string                 `json:"topic,omitempty"`
+	Partition int32                  `json:"partition,omitempty"`
+	Offset    int64                  `json:"offset,omitempty"`
+	EventTime *time.Time             `json:"event_time,omitempty"`
+	EventData map[string]interface{} `json:"event_data,omitempty"`
+	Outcome   string                 `json:"outcome,omitempty"` // Event outcome, e.g. processed or duplicate
+
+	// Notification sent for the event
+	NotificationID string              `json:"notification_id,omitempty"`
+	UserID         string              `json:"user_id,omitempty"`
+	Type           NotificationType    `json:"type,omitempty"`
+	Channel        NotificationChannel `json:"channel,omitempty"`
+	ChatID         int64               `json:"chat_id,omitempty"`
+	Subject        string              `json:"subject,omitempty"`
+	Content        string              `json:"content,omitempty"`
+	Status         NotificationStatus  `json:"status,omitempty"`
+	SentAt         *time.Time          `json:"sent_at,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// NewNotificationAuditRecord describes the outcome of sending a notification
+func NewNotificationAuditRecord(n *Notification, chatID int64) AuditRecord {
+	return AuditRecord{
+		Kind:           AuditRecordNotification,
+		RecordedAt:     time.Now().UTC(),
+		NotificationID: n.ID,
+		UserID:         n.UserID,
+		Type:           n.Type,
+		Channel:        n.Channel,
+		ChatID:         chatID,
+		Subject:        n.Subject,
+		Content:        n.Content,
+		Status:         n.Status,
+		SentAt:         n.SentAt,
+		Error:          n.ErrorMessage,
+	}
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// DeliveryStatus represents the delivery state of a sent notification
+type DeliveryStatus string
+
+const (
+	DeliveryStatusDelivered    DeliveryStatus = "delivered"
+	DeliveryStatusFailed       DeliveryStatus = "failed"
+	DeliveryStatusAcknowledged DeliveryStatus = "acknowledged"
+)
+
+// MetadataTelegramMessageID is the notification metadata key holding the ID
+// of the Telegram message it was sent as
+const MetadataTelegramMessageID = "telegram_message_id"
+
+var (
+	ErrDeliveryNotFound     = errors.New("delivery not found")
+	ErrAckNotRequired       = errors.New("notification does not require acknowledgement")
+	ErrAckWrongRecipient    = errors.New("notification was sent to a different chat")
+	ErrDeliveryNotDelivered = errors.New("notification was not delivered")
+)
+
+// Delivery records the outcome of sending a notification and whether the
+// recipient acknowledged it
+type Delivery struct {
+	NotificationID string               `json:"notification_id"`
+	UserID         string               `json:"user_id"`
+	Type           NotificationType     `json:"type"`
+	Channel        NotificationChannel  `json:"channel"`
+	Priority       NotificationPriority `json:"priority"`
+	Status         DeliveryStatus       `json:"status"`
+	Subject        string               `json:"subject"`
+	ChatID         int64                `json:"chat_id,omitempty"`
+	MessageID      int                  `json:"message_id,omitempty"`
+	RequiresAck    bool                 `json:"requires_ack"`
+	References     map[string]string    `json:"references,omitempty"` // e.g. order_id, assembly_id
+	SentAt         *time.Time           `json:"sent_at,omitempty"`
+	FailedAt       *time.Time           `json:"failed_at,omitempty"`
+	ErrorMessage   string               `json:"error_message,omitempty"`
+	AcknowledgedAt *time.Time           `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string               `json:"acknowledged_by,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// deliveryReferenceKeys are the notification data fields copied to the
+// delivery record so that alerts can be traced back to their subject
+var deliveryReferenceKeys = []string{"order_id", "assembly_id", "payment_id", "sku", "alert_id"}
+
+// RequiresAcknowledgement reports whether the recipient is asked to
+// acknowledge the notification; unacknowledged critical alerts can be escalated
+func (n *Notification) RequiresAcknowledgement() bool {
+	switch n.Type {
+	case NotificationTypeAssemblyFailed, NotificationTypePaymentFailed, NotificationTypeLowStock, NotificationTypeSLABreach:
+		return true
+	}
+	return n.Priority == NotificationPriorityUrgent
+}
+
+// NewDelivery creates a delivery record for a notification
+func NewDelivery(n *Notification) *Delivery {
+	now := time.Now()
+	d := &Delivery{
+		NotificationID: n.ID,
+		UserID:         n.UserID,
+		Type:           n.Type,
+		Channel:        n.Channel,
+		Priority:       n.Priority,
+		Subject:        n.Subject,
+		RequiresAck:    n.RequiresAcknowledgement(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	for _, key := range deliveryReferenceKeys {
+		if value, ok := n.Data[key].(string); ok && value != "" {
+			if d.References == nil {
+				d.References = make(map[string]string)
+			}
+			d.References[key] = value
+		}
+	}
+
+	return d
+}
+
+// MarkDelivered records a successful send
+func (d *Delivery) MarkDelivered(chatID int64, messageID int) {
+	now := time.Now()
+	d.Status = DeliveryStatusDelivered
+	d.ChatID = chatID
+	d.MessageID = messageID
+	d.SentAt = &now
+	d.UpdatedAt = now
+}
+
+// MarkFailed records a failed send
+func (d *Delivery) MarkFailed(errorMsg string) {
+	now := time.Now()
+	d.Status = DeliveryStatusFailed
+	d.FailedAt = &now
+	d.ErrorMessage = errorMsg
+	d.UpdatedAt = now
+}
+
+// Acknowledge records the recipient's acknowledgement. Acknowledging twice
+// keeps the first acknowledgement.
+func (d *Delivery) Acknowledge(by string) error {
+	if !d.RequiresAck {
+		return ErrAckNotRequired
+	}
+	if d.Status == DeliveryStatusAcknowledged {
+		return nil
+	}
+	if d.Status != DeliveryStatusDelivered {
+		return ErrDeliveryNotDelivered
+	}
+
+	now := time.Now()
+	d.Status = DeliveryStatusAcknowledged
+	d.AcknowledgedAt = &now
+	d.AcknowledgedBy = by
+	d.UpdatedAt = now
+	return nil
+}
+
+// AwaitingAck reports whether the delivery still waits for an acknowledgement
+func (d *Delivery) AwaitingAck() bool {
+	return d.RequiresAck && d.Status == DeliveryStatusDelivered
+}
@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Operational alerts are sent to the on-call rotation instead of a customer
+const (
+	NotificationTypeLowStock  NotificationType = "low_stock"
+	NotificationTypeSLABreach NotificationType = "sla_breach"
+)
+
+// OperationalAlertTypes lists the alert types that can be escalated
+var OperationalAlertTypes = []NotificationType{NotificationTypeLowStock, NotificationTypeSLABreach}
+
+// IsOperationalAlert reports whether the type is an escalatable operational alert
+func IsOperationalAlert(t NotificationType) bool {
+	for _, alertType := range OperationalAlertTypes {
+		if t == alertType {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	ErrScheduleNotFound   = errors.New("on-call schedule not found")
+	ErrEscalationNotFound = errors.New("escalation not found")
+	ErrNoScheduleForAlert = errors.New("no on-call schedule handles this alert type")
+)
+
+// Responder is a person in an on-call rotation
+type Responder struct {
+	Name   string `json:"name"`
+	ChatID int64  `json:"chat_id"`
+}
+
+// OnCallSchedule is a rotation of responders. The responder on call changes
+// every HandoffInterval starting at StartsAt; an alert that is not
+// acknowledged within AckTimeout is escalated to the next responder.
+type OnCallSchedule struct {
+	Name            string             `json:"name"`
+	Responders      []Responder        `json:"responders"`
+	StartsAt        time.Time          `json:"starts_at"`
+	HandoffInterval time.Duration      `json:"handoff_interval"`
+	AckTimeout      time.Duration      `json:"ack_timeout"`
+	AlertTypes      []NotificationType `json:"alert_types"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+// Validate checks that the schedule can be used for escalation
+func (s *OnCallSchedule) Validate() error {
+	if s.Name == "" || strings.ContainsAny(s.Name, "/ ") {
+		return fmt.Errorf("schedule name must be non-empty without slashes or spaces")
+	}
+	if len(s.Responders) == 0 {
+		return fmt.Errorf("schedule needs at least one responder")
+	}
+	for _, responder := range s.Responders {
+		if responder.Name == "" || responder.ChatID == 0 {
+			return fmt.Errorf("every responder needs a name and a Telegram chat ID")
+		}
+	}
+	if s.HandoffInterval <= 0 {
+		return fmt.Errorf("handoff interval must be positive")
+	}
+	if s.AckTimeout <= 0 {
+		return fmt.Errorf("ack timeout must be positive")
+	}
+	if len(s.AlertTypes) == 0 {
+		return fmt.Errorf("schedule must handle at least one alert type")
+	}
+	for _, alertType := range s.AlertTypes {
+		if !IsOperationalAlert(alertType) {
+			return fmt.Errorf("unsupported alert type %q", alertType)
+		}
+	}
+	return nil
+}
+
+// Handles reports whether the schedule receives alerts of the given type
+func (s *OnCallSchedule) Handles(t NotificationType) bool {
+	for _, alertType := range s.AlertTypes {
+		if alertType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// OnCallIndex returns the index of the responder on call at the given time
+func (s *OnCallSchedule) OnCallIndex(at time.Time) int {
+	if at.Before(s.StartsAt) {
+		return 0
+	}
+	shifts := int64(at.Sub(s.StartsAt) / s.HandoffInterval)
+	return int(shifts % int64(len(s.Responders)))
+}
+
+// EscalationOrder returns the responders in the order they are paged:
+// whoever is on call first, then the rest of the rotation
+func (s *OnCallSchedule) EscalationOrder(at time.Time) []Responder {
+	start := s.OnCallIndex(at)
+	order := make([]Responder, 0, len(s.Responders))
+	for i := range s.Responders {
+		order = append(order, s.Responders[(start+i)%len(s.Responders)])
+	}
+	return order
+}
+
+// EscalationStatus represents the state of an escalation chain
+type EscalationStatus string
+
+const (
+	EscalationStatusOpen         EscalationStatus = "open"
+	EscalationStatusAcknowledged EscalationStatus = "acknowledged"
+	EscalationStatusExhausted    EscalationStatus = "exhausted" // Nobody in the rotation acknowledged
+)
+
+// EscalationStep records one responder being paged for an alert
+type EscalationStep struct {
+	Level          int        `json:"level"` // 1 is the responder on call
+	Responder      Responder  `json:"responder"`
+	NotificationID string     `json:"notification_id"`
+	NotifiedAt     time.Time  `json:"notified_at"`
+	EscalateAt     time.Time  `json:"escalate_at"` // When the next responder is paged unless acknowledged
+	Error          string     `json:"error,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+}
+
+// Escalation is the audit trail of an operational alert working its way
+// through an on-call rotation
+type Escalation struct {
+	AlertID        string            `json:"alert_id"`
+	Type           NotificationType  `json:"type"`
+	Subject        string            `json:"subject"`
+	Content        string            `json:"content"`
+	References     map[string]string `json:"references,omitempty"`
+	Schedule       string            `json:"schedule"`
+	Order          []Responder       `json:"order"` // Rotation order fixed when the alert was raised
+	Steps          []EscalationStep  `json:"steps"`
+	Status         EscalationStatus  `json:"status"`
+	AcknowledgedBy string            `json:"acknowledged_by,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	ClosedAt       *time.Time        `json:"closed_at,omitempty"`
+}
+
+// NewEscalation opens an escalation for an alert handled by the schedule
+func NewEscalation(alertType NotificationType, subject, content string, references map[string]string, schedule *OnCallSchedule) *Escalation {
+	now := time.Now()
+	return &Escalation{
+		AlertID:    generateAlertID(now),
+		Type:       alertType,
+		Subject:    subject,
+		Content:    content,
+		References: references,
+		Schedule:   schedule.Name,
+		Order:      schedule.EscalationOrder(now),
+		Steps:      []EscalationStep{},
+		Status:     EscalationStatusOpen,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// NextResponder returns the responder to page next, or false when the
+// rotation is exhausted
+func (e *Escalation) NextResponder() (Responder, int, bool) {
+	level := len(e.Steps) + 1
+	if level > len(e.Order) {
+		return Responder{}, 0, false
+	}
+	return e.Order[level-1], level, true
+}
+
+// CurrentStep returns the most recent step, if any
+func (e *Escalation) CurrentStep() *EscalationStep {
+	if len(e.Steps) == 0 {
+		return nil
+	}
+	return &e.Steps[len(e.Steps)-1]
+}
+
+// MarkAcknowledged closes the escalation after a responder acknowledged it
+func (e *Escalation) MarkAcknowledged(stepIndex int, by string, at time.Time) {
+	e.Steps[stepIndex].AcknowledgedAt = &at
+	e.Status = EscalationStatusAcknowledged
+	e.AcknowledgedBy = by
+	e.ClosedAt = &at
+	e.UpdatedAt = at
+}
+
+// MarkExhausted closes the escalation after the whole rotation was paged
+func (e *Escalation) MarkExhausted(at time.Time) {
+	e.Status = EscalationStatusExhausted
+	e.ClosedAt = &at
+	e.UpdatedAt = at
+}
+
+// generateAlertID generates a unique alert ID
+func generateAlertID(now time.Time) string {
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return "alert_" + now.Format("20060102150405") + "_" + hex.EncodeToString(suffix)
+}
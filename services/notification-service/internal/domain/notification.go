@@ -8,12 +8,20 @@ import (
 type NotificationType string
 
 const (
-	NotificationTypeOrderCreated      NotificationType = "order_created"
-	NotificationTypeOrderPaid         NotificationType = "order_paid"
-	NotificationTypePaymentFailed     NotificationType = "payment_failed"
-	NotificationTypeAssemblyStarted   NotificationType = "assembly_started"
-	NotificationTypeAssemblyCompleted NotificationType = "assembly_completed"
-	NotificationTypeAssemblyFailed    NotificationType = "assembly_failed"
+	NotificationTypeOrderCreated           NotificationType = "order_created"
+	NotificationTypeOrderPaid              NotificationType = "order_paid"
+	NotificationTypeOrderApprovalRequested NotificationType = "order_approval_requested" // Sent to operators
+	NotificationTypePaymentFailed          NotificationType = "payment_failed"
+	NotificationTypePaymentRetryScheduled  NotificationType = "payment_retry_scheduled"
+	NotificationTypeOrderBackordered       NotificationType = "order_backordered"
+	NotificationTypeBackorderAvailable     NotificationType = "backorder_available"
+	NotificationTypeRefundStatusChanged    NotificationType = "refund_status_changed"
+	NotificationTypeAssemblyStarted        NotificationType = "assembly_started"
+	NotificationTypeAssemblyCompleted      NotificationType = "assembly_completed"
+	NotificationTypeAssemblyFailed         NotificationType = "assembly_failed"
+	NotificationTypeAccountDeletion        NotificationType = "account_deletion"
+	NotificationTypeLoginLink              NotificationType = "login_link"
+	NotificationTypeFailedLoginAlert       NotificationType = "failed_login_alert"
 )
 
 // NotificationChannel represents the channel for sending notifications
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/domain"
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/service"
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/transport/grpc/clients"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/cloudevents"
 	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
@@ -24,9 +26,12 @@ type EventEnvelope struct {
 	Time        time.Time              `json:"time"`
 	Data        map[string]interface{} `json:"data"`
 	Extensions  map[string]string      `json:"extensions"`
-	SpecVersion string                 `json:"spec_version"`
+	SpecVersion string                 `json:"specversion"`
 }
 
+// EventHandler turns an event into notifications
+type EventHandler func(ctx context.Context, envelope *EventEnvelope) error
+
 // EventConsumer handles consuming and processing events from Kafka
 type EventConsumer struct {
 	config          config.Config
@@ -34,7 +39,12 @@ type EventConsumer struct {
 	metrics         metrics.Metrics
 	telegramService service.TelegramServiceInterface
 	iamClient       *clients.IAMClient
+	dedupStore      service.DedupStore
+	deliveryTracker *service.DeliveryTracker
+	auditLog        *service.AuditLog        // Nil when the audit trail is disabled
+	encryption      *kafka.PayloadEncryption // Decrypts login links; nil without Kafka encryption keys
 	supportedTopics []string
+	handlers        map[string]EventHandler // By event type
 }
 
 // NewEventConsumer creates a new event consumer
@@ -44,21 +54,74 @@ func NewEventConsumer(
 	metrics metrics.Metrics,
 	telegramService service.TelegramServiceInterface,
 	iamClient *clients.IAMClient,
+	dedupStore service.DedupStore,
+	deliveryTracker *service.DeliveryTracker,
+	auditLog *service.AuditLog,
 ) *EventConsumer {
-	supportedTopics := []string{
-		cfg.Kafka.Topics.OrderEvents,
-		cfg.Kafka.Topics.PaymentEvents,
-		cfg.Kafka.Topics.AssemblyEvents,
-	}
+	supportedTopics := cfg.Kafka.Topics.Names(config.UsedTopics...)
 
-	return &EventConsumer{
+	ec := &EventConsumer{
 		config:          cfg,
 		logger:          logger,
 		metrics:         metrics,
 		telegramService: telegramService,
 		iamClient:       iamClient,
+		dedupStore:      dedupStore,
+		deliveryTracker: deliveryTracker,
+		auditLog:        auditLog,
 		supportedTopics: supportedTopics,
+		handlers:        make(map[string]EventHandler),
 	}
+	ec.registerHandlers()
+	return ec
+}
+
+// SetPayloadEncryption decrypts the encrypted fields of events, such as login links
+func (ec *EventConsumer) SetPayloadEncryption(encryption *kafka.PayloadEncryption) {
+	ec.encryption = encryption
+}
+
+// Handle registers the handler of an event type, replacing any earlier one
+func (ec *EventConsumer) Handle(eventType string, handler EventHandler) {
+	ec.handlers[eventType] = handler
+}
+
+// registerHandlers registers the handlers of every event type that notifies a user
+func (ec *EventConsumer) registerHandlers() {
+	ec.Handle("order.created", ec.handleOrderCreatedEvent)
+	ec.Handle("order.paid", ec.handleOrderPaidEvent)
+	ec.Handle("order.cancelled", ec.handleOrderCancelledEvent)
+	ec.Handle("order.approval_requested", ec.handleOrderApprovalRequestedEvent)
+	ec.Handle("order.payment_retry_scheduled", ec.handlePaymentRetryScheduledEvent)
+	ec.Handle("order.backordered", ec.handleBackorderEvent(domain.NotificationTypeOrderBackordered))
+	ec.Handle("order.backorder_available", ec.handleBackorderEvent(domain.NotificationTypeBackorderAvailable))
+	ec.Handle("order.refund_status_changed", ec.handleRefundStatusChangedEvent)
+	ec.Handle("payment.processed", ec.handlePaymentProcessedEvent)
+	ec.Handle("payment.failed", ec.handlePaymentFailedEvent)
+	ec.Handle("assembly.started", ec.handleAssemblyStartedEvent)
+	ec.Handle("assembly.completed", ec.handleAssemblyCompletedEvent)
+	ec.Handle("assembly.failed", ec.handleAssemblyFailedEvent)
+	ec.Handle("user.deletion_requested", ec.handleUserDeletionRequestedEvent)
+	ec.Handle("user.deletion_cancelled", ec.handleUserDeletionCancelledEvent)
+	ec.Handle("user.login_link_requested", ec.handleUserLoginLinkRequestedEvent)
+	ec.Handle("iam.failed-login-burst", ec.handleFailedLoginBurstEvent)
+}
+
+// route picks the handler of a message from its headers alone, so events that notify
+// nobody are skipped without being deserialized. Messages without an event-type header
+// are routed after decoding, by the type in their envelope.
+func (ec *EventConsumer) route(message *kafka.Message) (EventHandler, string) {
+	if ec.config.Kafka.RequireNotifyHeader && !message.Notify() {
+		return nil, "not_notifiable"
+	}
+	if message.EventType == "" {
+		return nil, ""
+	}
+	handler, ok := ec.handlers[message.EventType]
+	if !ok {
+		return nil, "unhandled_type"
+	}
+	return handler, ""
 }
 
 // HandleMessage implements the MessageHandler interface
@@ -67,11 +130,26 @@ func (ec *EventConsumer) HandleMessage(ctx context.Context, message *kafka.Messa
 
 	// Record processing metrics
 	defer func() {
-		ec.metrics.RecordDuration("kafka_message_processing_duration", time.Since(startTime), map[string]string{
+		ec.metrics.RecordDuration(ctx, "kafka_message_processing_duration", time.Since(startTime), map[string]string{
 			"topic": message.Topic,
 		})
 	}()
 
+	handler, skipReason := ec.route(message)
+	if skipReason != "" {
+		ec.logger.Debug(ctx, "Skipping Kafka message", map[string]interface{}{
+			"topic":      message.Topic,
+			"offset":     message.Offset,
+			"event_type": message.EventType,
+			"reason":     skipReason,
+		})
+		ec.metrics.IncrementCounter(ctx, "kafka_messages_filtered_total", map[string]string{
+			"topic":  message.Topic,
+			"reason": skipReason,
+		})
+		return nil
+	}
+
 	ec.logger.Info(ctx, "Processing Kafka message", map[string]interface{}{
 		"topic":      message.Topic,
 		"partition":  message.Partition,
@@ -80,54 +158,83 @@ func (ec *EventConsumer) HandleMessage(ctx context.Context, message *kafka.Messa
 		"event_id":   message.EventID,
 	})
 
-	// Parse the event envelope
+	// Parse the event envelope, a CloudEvent in JSON or Avro format
 	var envelope EventEnvelope
-	if err := json.Unmarshal(message.Value, &envelope); err != nil {
+	if err := decodeEnvelope(message.Headers, message.Value, &envelope); err != nil {
 		ec.logger.Error(ctx, "Failed to unmarshal event envelope", err, map[string]interface{}{
 			"topic":  message.Topic,
 			"offset": message.Offset,
 		})
-		ec.metrics.IncrementCounter("kafka_message_unmarshal_error", map[string]string{
+		ec.metrics.IncrementCounter(ctx, "kafka_message_unmarshal_error", map[string]string{
 			"topic": message.Topic,
 		})
+		ec.auditEvent(ctx, domain.AuditRecord{
+			EventID:   message.EventID,
+			EventType: message.EventType,
+			Topic:     message.Topic,
+			Partition: message.Partition,
+			Offset:    message.Offset,
+			Outcome:   domain.AuditOutcomeInvalid,
+			Error:     err.Error(),
+		})
 		return fmt.Errorf("failed to unmarshal event envelope: %w", err)
 	}
 
-	// Process based on topic
-	var err error
-	switch message.Topic {
-	case ec.config.Kafka.Topics.OrderEvents:
-		err = ec.handleOrderEvent(ctx, &envelope)
-	case ec.config.Kafka.Topics.PaymentEvents:
-		err = ec.handlePaymentEvent(ctx, &envelope)
-	case ec.config.Kafka.Topics.AssemblyEvents:
-		err = ec.handleAssemblyEvent(ctx, &envelope)
-	default:
-		ec.logger.Warn(ctx, "Unknown topic, skipping message", map[string]interface{}{
-			"topic": message.Topic,
-		})
+	if handler == nil {
+		var ok bool
+		if handler, ok = ec.handlers[envelope.Type]; !ok {
+			ec.logger.Debug(ctx, "Unsupported event type", map[string]interface{}{
+				"topic":      message.Topic,
+				"event_type": envelope.Type,
+			})
+			return nil
+		}
+	}
+
+	audit := eventAuditRecord(message, &envelope)
+
+	// Suppress redeliveries of events that already produced a notification
+	if !ec.claimEvent(ctx, message.Topic, &envelope) {
+		audit.Outcome = domain.AuditOutcomeDuplicate
+		ec.auditEvent(ctx, audit)
 		return nil
 	}
 
-	if err != nil {
+	// Notifications sent by the handler are recorded with the event they were sent for
+	ctx = context.WithValue(ctx, auditEventKey{}, audit)
+	ctx = context.WithValue(ctx, eventTimingKey{}, newEventTiming(message, &envelope))
+	if sandbox, _ := envelope.Data["sandbox"].(bool); sandbox {
+		ctx = context.WithValue(ctx, sandboxEventKey{}, true)
+	}
+	if err := handler(ctx, &envelope); err != nil {
+		audit.Outcome = domain.AuditOutcomeFailed
+		audit.Error = err.Error()
+		ec.auditEvent(ctx, audit)
+
+		// Forget the event so that a retry or redelivery can still notify the user
+		ec.releaseEvent(ctx, &envelope)
+
 		ec.logger.Error(ctx, "Failed to process event", err, map[string]interface{}{
 			"topic":      message.Topic,
 			"event_type": envelope.Type,
 			"event_id":   envelope.ID,
 		})
-		ec.metrics.IncrementCounter("kafka_message_processing_error", map[string]string{
+		ec.metrics.IncrementCounter(ctx, "kafka_message_processing_error", map[string]string{
 			"topic":      message.Topic,
 			"event_type": envelope.Type,
 		})
 		return err
 	}
 
+	audit.Outcome = domain.AuditOutcomeProcessed
+	ec.auditEvent(ctx, audit)
+
 	ec.logger.Info(ctx, "Successfully processed event", map[string]interface{}{
 		"topic":      message.Topic,
 		"event_type": envelope.Type,
 		"event_id":   envelope.ID,
 	})
-	ec.metrics.IncrementCounter("kafka_message_processing_success", map[string]string{
+	ec.metrics.IncrementCounter(ctx, "kafka_message_processing_success", map[string]string{
 		"topic":      message.Topic,
 		"event_type": envelope.Type,
 	})
@@ -140,55 +247,6 @@ func (ec *EventConsumer) GetSupportedTopics() []string {
 	return ec.supportedTopics
 }
 
-// handleOrderEvent processes order-related events
-func (ec *EventConsumer) handleOrderEvent(ctx context.Context, envelope *EventEnvelope) error {
-	switch envelope.Type {
-	case "order.created":
-		return ec.handleOrderCreatedEvent(ctx, envelope)
-	case "order.paid":
-		return ec.handleOrderPaidEvent(ctx, envelope)
-	case "order.cancelled":
-		return ec.handleOrderCancelledEvent(ctx, envelope)
-	default:
-		ec.logger.Debug(ctx, "Unsupported order event type", map[string]interface{}{
-			"event_type": envelope.Type,
-		})
-		return nil
-	}
-}
-
-// handlePaymentEvent processes payment-related events
-func (ec *EventConsumer) handlePaymentEvent(ctx context.Context, envelope *EventEnvelope) error {
-	switch envelope.Type {
-	case "payment.processed":
-		return ec.handlePaymentProcessedEvent(ctx, envelope)
-	case "payment.failed":
-		return ec.handlePaymentFailedEvent(ctx, envelope)
-	default:
-		ec.logger.Debug(ctx, "Unsupported payment event type", map[string]interface{}{
-			"event_type": envelope.Type,
-		})
-		return nil
-	}
-}
-
-// handleAssemblyEvent processes assembly-related events
-func (ec *EventConsumer) handleAssemblyEvent(ctx context.Context, envelope *EventEnvelope) error {
-	switch envelope.Type {
-	case "assembly.started":
-		return ec.handleAssemblyStartedEvent(ctx, envelope)
-	case "assembly.completed":
-		return ec.handleAssemblyCompletedEvent(ctx, envelope)
-	case "assembly.failed":
-		return ec.handleAssemblyFailedEvent(ctx, envelope)
-	default:
-		ec.logger.Debug(ctx, "Unsupported assembly event type", map[string]interface{}{
-			"event_type": envelope.Type,
-		})
-		return nil
-	}
-}
-
 // handleOrderCreatedEvent handles order created events
 func (ec *EventConsumer) handleOrderCreatedEvent(ctx context.Context, envelope *EventEnvelope) error {
 	// Extract user ID and order data
@@ -207,9 +265,6 @@ func (ec *EventConsumer) handleOrderCreatedEvent(ctx context.Context, envelope *
 		domain.NotificationChannelTelegram,
 	)
 
-	notification.Subject = "Order Created Successfully! 📦"
-	notification.Content = "Your order has been created successfully!\n\nWe're preparing your rocket parts for assembly."
-
 	// Add order data
 	notification.AddData("order_id", orderID)
 	notification.AddData("total_amount", totalAmount)
@@ -217,6 +272,13 @@ func (ec *EventConsumer) handleOrderCreatedEvent(ctx context.Context, envelope *
 	if items, ok := envelope.Data["items"].([]interface{}); ok {
 		notification.AddData("items", items)
 	}
+	if address := formatShippingAddress(envelope.Data); address != "" {
+		notification.AddData("shipping_address", address)
+	}
+
+	if err := ec.applyTemplate(ctx, notification, "order.created"); err != nil {
+		return err
+	}
 
 	return ec.sendNotification(ctx, notification)
 }
@@ -240,9 +302,6 @@ func (ec *EventConsumer) handleOrderPaidEvent(ctx context.Context, envelope *Eve
 		domain.NotificationChannelTelegram,
 	)
 
-	notification.Subject = "Payment Confirmed! 💳"
-	notification.Content = "Your payment has been processed successfully!\n\nYour rocket assembly will begin shortly."
-
 	// Add payment data
 	notification.AddData("order_id", orderID)
 	notification.AddData("transaction_id", transactionID)
@@ -250,6 +309,10 @@ func (ec *EventConsumer) handleOrderPaidEvent(ctx context.Context, envelope *Eve
 	notification.AddData("currency", currency)
 	notification.AddData("payment_method", paymentMethod)
 
+	if err := ec.applyTemplate(ctx, notification, "order.paid"); err != nil {
+		return err
+	}
+
 	return ec.sendNotification(ctx, notification)
 }
 
@@ -270,17 +333,185 @@ func (ec *EventConsumer) handleOrderCancelledEvent(ctx context.Context, envelope
 		domain.NotificationChannelTelegram,
 	)
 
-	notification.Subject = "Order Cancelled ❌"
-	notification.Content = fmt.Sprintf(
-		"Your order has been cancelled.\n\nReason: %s\n\nIf a refund is required, it will be processed within 3-5 business days.",
-		reason,
-	)
-
 	// Add order data
 	notification.AddData("order_id", orderID)
 	notification.AddData("reason", reason)
 	notification.AddData("refund_required", refundRequired)
 
+	if err := ec.applyTemplate(ctx, notification, "order.cancelled"); err != nil {
+		return err
+	}
+
+	return ec.sendNotification(ctx, notification)
+}
+
+// handleOrderApprovalRequestedEvent asks every approver to decide on a high-value order.
+// Approvers without a reachable chat are skipped; the order stays in the approval queue.
+func (ec *EventConsumer) handleOrderApprovalRequestedEvent(ctx context.Context, envelope *EventEnvelope) error {
+	approverIDs, _ := envelope.Data["approver_ids"].([]interface{})
+	if len(approverIDs) == 0 {
+		ec.logger.Warn(ctx, "Order approval requested without approvers to notify", map[string]interface{}{
+			"order_id": envelope.Data["order_id"],
+		})
+		return nil
+	}
+
+	orderID, _ := envelope.Data["order_id"].(string)
+	totalAmount, _ := envelope.Data["total_amount"].(float64)
+	currency, _ := envelope.Data["currency"].(string)
+	expiresAt, _ := envelope.Data["expires_at"].(string)
+
+	var lastErr error
+	notified := 0
+	for _, id := range approverIDs {
+		approverID, ok := id.(string)
+		if !ok || approverID == "" {
+			continue
+		}
+
+		notification := domain.NewNotification(
+			approverID,
+			domain.NotificationTypeOrderApprovalRequested,
+			domain.NotificationChannelTelegram,
+		)
+		notification.Priority = domain.NotificationPriorityHigh
+
+		notification.AddData("order_id", orderID)
+		notification.AddData("total_amount", totalAmount)
+		notification.AddData("currency", currency)
+		notification.AddData("expires_at", expiresAt)
+
+		if err := ec.applyTemplate(ctx, notification, "order.approval_requested"); err != nil {
+			return err
+		}
+
+		if err := ec.sendNotification(ctx, notification); err != nil {
+			lastErr = err
+			continue
+		}
+		notified++
+	}
+
+	// Redelivering after a partial success would notify the reached approvers twice
+	if notified == 0 && lastErr != nil {
+		return lastErr
+	}
+	return nil
+}
+
+// handlePaymentRetryScheduledEvent tells the customer that the payment of their order
+// failed transiently and is retried
+func (ec *EventConsumer) handlePaymentRetryScheduledEvent(ctx context.Context, envelope *EventEnvelope) error {
+	userID, ok := envelope.Data["user_id"].(string)
+	if !ok {
+		return fmt.Errorf("missing or invalid user_id in payment retry scheduled event")
+	}
+
+	orderID, _ := envelope.Data["order_id"].(string)
+	totalAmount, _ := envelope.Data["total_amount"].(float64)
+	currency, _ := envelope.Data["currency"].(string)
+	attempt, _ := envelope.Data["attempt"].(float64)
+	maxAttempts, _ := envelope.Data["max_attempts"].(float64)
+	nextAttemptAt, _ := envelope.Data["next_attempt_at"].(string)
+
+	notification := domain.NewNotification(
+		userID,
+		domain.NotificationTypePaymentRetryScheduled,
+		domain.NotificationChannelTelegram,
+	)
+
+	notification.AddData("order_id", orderID)
+	notification.AddData("total_amount", totalAmount)
+	notification.AddData("currency", currency)
+	notification.AddData("attempt", int(attempt))
+	notification.AddData("max_attempts", int(maxAttempts))
+	notification.AddData("next_attempt_at", nextAttemptAt)
+
+	if err := ec.applyTemplate(ctx, notification, "order.payment_retry_scheduled"); err != nil {
+		return err
+	}
+
+	return ec.sendNotification(ctx, notification)
+}
+
+// handleBackorderEvent returns the handler telling the customer that items of their
+// order are backordered or, later, that they arrived
+func (ec *EventConsumer) handleBackorderEvent(notificationType domain.NotificationType) EventHandler {
+	return func(ctx context.Context, envelope *EventEnvelope) error {
+		userID, ok := envelope.Data["user_id"].(string)
+		if !ok {
+			return fmt.Errorf("missing or invalid user_id in %s event", envelope.Type)
+		}
+
+		orderID, _ := envelope.Data["order_id"].(string)
+		amount, _ := envelope.Data["amount"].(float64)
+		currency, _ := envelope.Data["currency"].(string)
+
+		var summary []string
+		items, _ := envelope.Data["items"].([]interface{})
+		for _, raw := range items {
+			item, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := item["item_name"].(string)
+			if name == "" {
+				name, _ = item["item_id"].(string)
+			}
+			quantity, _ := item["quantity"].(float64)
+			summary = append(summary, fmt.Sprintf("%d × %s", int(quantity), name))
+		}
+
+		notification := domain.NewNotification(userID, notificationType, domain.NotificationChannelTelegram)
+
+		notification.AddData("order_id", orderID)
+		notification.AddData("item_summary", strings.Join(summary, ", "))
+		notification.AddData("amount", amount)
+		notification.AddData("currency", currency)
+		if shipmentID, ok := envelope.Data["shipment_id"].(string); ok {
+			notification.AddData("shipment_id", shipmentID)
+		}
+
+		if err := ec.applyTemplate(ctx, notification, envelope.Type); err != nil {
+			return err
+		}
+
+		return ec.sendNotification(ctx, notification)
+	}
+}
+
+// handleRefundStatusChangedEvent tells the customer how their refund request progresses:
+// received, approved or rejected by support, and refunded or delayed
+func (ec *EventConsumer) handleRefundStatusChangedEvent(ctx context.Context, envelope *EventEnvelope) error {
+	userID, ok := envelope.Data["user_id"].(string)
+	if !ok {
+		return fmt.Errorf("missing or invalid user_id in refund status changed event")
+	}
+
+	orderID, _ := envelope.Data["order_id"].(string)
+	status, _ := envelope.Data["status"].(string)
+	amount, _ := envelope.Data["amount"].(float64)
+	currency, _ := envelope.Data["currency"].(string)
+	reason, _ := envelope.Data["reason"].(string)
+	note, _ := envelope.Data["note"].(string)
+
+	notification := domain.NewNotification(
+		userID,
+		domain.NotificationTypeRefundStatusChanged,
+		domain.NotificationChannelTelegram,
+	)
+
+	notification.AddData("order_id", orderID)
+	notification.AddData("status", status)
+	notification.AddData("total_amount", amount)
+	notification.AddData("currency", currency)
+	notification.AddData("reason", reason)
+	notification.AddData("note", note)
+
+	if err := ec.applyTemplate(ctx, notification, "order.refund_status_changed"); err != nil {
+		return err
+	}
+
 	return ec.sendNotification(ctx, notification)
 }
 
@@ -310,12 +541,6 @@ func (ec *EventConsumer) handlePaymentProcessedEvent(ctx context.Context, envelo
 		domain.NotificationChannelTelegram,
 	)
 
-	notification.Subject = "Payment Successful! 💰"
-	notification.Content = fmt.Sprintf(
-		"Your payment has been processed successfully!\n\nTransaction ID: %s",
-		transactionID,
-	)
-
 	// Add payment data
 	notification.AddData("payment_id", paymentID)
 	notification.AddData("order_id", orderID)
@@ -324,6 +549,10 @@ func (ec *EventConsumer) handlePaymentProcessedEvent(ctx context.Context, envelo
 	notification.AddData("currency", currency)
 	notification.AddData("payment_method", paymentMethod)
 
+	if err := ec.applyTemplate(ctx, notification, "payment.processed"); err != nil {
+		return err
+	}
+
 	return ec.sendNotification(ctx, notification)
 }
 
@@ -347,12 +576,6 @@ func (ec *EventConsumer) handlePaymentFailedEvent(ctx context.Context, envelope
 		domain.NotificationChannelTelegram,
 	)
 
-	notification.Subject = "Payment Failed ❌"
-	notification.Content = fmt.Sprintf(
-		"Unfortunately, your payment could not be processed.\n\nReason: %s\n\nPlease try again or contact support.",
-		reason,
-	)
-
 	// Add payment data
 	notification.AddData("payment_id", paymentID)
 	notification.AddData("order_id", orderID)
@@ -361,6 +584,10 @@ func (ec *EventConsumer) handlePaymentFailedEvent(ctx context.Context, envelope
 	notification.AddData("reason", reason)
 	notification.AddData("error_code", errorCode)
 
+	if err := ec.applyTemplate(ctx, notification, "payment.failed"); err != nil {
+		return err
+	}
+
 	return ec.sendNotification(ctx, notification)
 }
 
@@ -381,12 +608,6 @@ func (ec *EventConsumer) handleAssemblyStartedEvent(ctx context.Context, envelop
 		domain.NotificationChannelTelegram,
 	)
 
-	notification.Subject = "Rocket Assembly Started! 🔧"
-	notification.Content = fmt.Sprintf(
-		"Great news! We've started assembling your rocket.\n\nEstimated completion time: %.0f seconds",
-		estimatedDuration,
-	)
-
 	// Add assembly data
 	notification.AddData("assembly_id", assemblyID)
 	notification.AddData("order_id", orderID)
@@ -395,6 +616,10 @@ func (ec *EventConsumer) handleAssemblyStartedEvent(ctx context.Context, envelop
 		notification.AddData("components", components)
 	}
 
+	if err := ec.applyTemplate(ctx, notification, "assembly.started"); err != nil {
+		return err
+	}
+
 	return ec.sendNotification(ctx, notification)
 }
 
@@ -416,18 +641,18 @@ func (ec *EventConsumer) handleAssemblyCompletedEvent(ctx context.Context, envel
 		domain.NotificationChannelTelegram,
 	)
 
-	notification.Subject = "Rocket Assembly Complete! 🚀"
-	notification.Content = fmt.Sprintf(
-		"Congratulations! Your rocket has been successfully assembled.\n\nAssembly took %.0f seconds with %s quality.",
-		actualDuration,
-		quality,
-	)
-
 	// Add assembly data
 	notification.AddData("assembly_id", assemblyID)
 	notification.AddData("order_id", orderID)
 	notification.AddData("actual_duration_seconds", int(actualDuration))
 	notification.AddData("quality", quality)
+	if address := formatShippingAddress(envelope.Data); address != "" {
+		notification.AddData("shipping_address", address)
+	}
+
+	if err := ec.applyTemplate(ctx, notification, "assembly.completed"); err != nil {
+		return err
+	}
 
 	return ec.sendNotification(ctx, notification)
 }
@@ -450,12 +675,6 @@ func (ec *EventConsumer) handleAssemblyFailedEvent(ctx context.Context, envelope
 		domain.NotificationChannelTelegram,
 	)
 
-	notification.Subject = "Assembly Failed ⚠️"
-	notification.Content = fmt.Sprintf(
-		"Unfortunately, there was an issue with your rocket assembly.\n\nReason: %s\n\nOur team is working to resolve this issue.",
-		reason,
-	)
-
 	// Add assembly data
 	notification.AddData("assembly_id", assemblyID)
 	notification.AddData("order_id", orderID)
@@ -465,11 +684,251 @@ func (ec *EventConsumer) handleAssemblyFailedEvent(ctx context.Context, envelope
 		notification.AddData("failed_components", failedComponents)
 	}
 
+	if err := ec.applyTemplate(ctx, notification, "assembly.failed"); err != nil {
+		return err
+	}
+
+	return ec.sendNotification(ctx, notification)
+}
+
+// handleUserDeletionRequestedEvent tells a user their account is deleted after the grace period
+func (ec *EventConsumer) handleUserDeletionRequestedEvent(ctx context.Context, envelope *EventEnvelope) error {
+	userID, ok := envelope.Data["user_id"].(string)
+	if !ok {
+		return fmt.Errorf("missing or invalid user_id in user deletion requested event")
+	}
+
+	deletionScheduledAt, _ := envelope.Data["deletion_scheduled_at"].(string)
+	reason, _ := envelope.Data["reason"].(string)
+
+	notification := domain.NewNotification(
+		userID,
+		domain.NotificationTypeAccountDeletion,
+		domain.NotificationChannelTelegram,
+	)
+	notification.Priority = domain.NotificationPriorityHigh
+
+	notification.AddData("deletion_scheduled_at", deletionScheduledAt)
+	notification.AddData("reason", reason)
+
+	if err := ec.applyTemplate(ctx, notification, "user.deletion_requested"); err != nil {
+		return err
+	}
+
+	return ec.sendNotification(ctx, notification)
+}
+
+// handleUserDeletionCancelledEvent tells a user their account is kept
+func (ec *EventConsumer) handleUserDeletionCancelledEvent(ctx context.Context, envelope *EventEnvelope) error {
+	userID, ok := envelope.Data["user_id"].(string)
+	if !ok {
+		return fmt.Errorf("missing or invalid user_id in user deletion cancelled event")
+	}
+
+	notification := domain.NewNotification(
+		userID,
+		domain.NotificationTypeAccountDeletion,
+		domain.NotificationChannelTelegram,
+	)
+
+	if err := ec.applyTemplate(ctx, notification, "user.deletion_cancelled"); err != nil {
+		return err
+	}
+
+	return ec.sendNotification(ctx, notification)
+}
+
+// handleUserLoginLinkRequestedEvent sends a user the password-less login link they asked for
+func (ec *EventConsumer) handleUserLoginLinkRequestedEvent(ctx context.Context, envelope *EventEnvelope) error {
+	userID, ok := envelope.Data["user_id"].(string)
+	if !ok {
+		return fmt.Errorf("missing or invalid user_id in user login link requested event")
+	}
+	encryptedLoginURL, ok := envelope.Data["encrypted_login_url"].(string)
+	if !ok || encryptedLoginURL == "" {
+		return fmt.Errorf("missing or invalid encrypted_login_url in user login link requested event")
+	}
+	if ec.encryption == nil {
+		return fmt.Errorf("cannot decrypt login link: %w", kafka.ErrPayloadEncrypted)
+	}
+	loginURL, err := ec.encryption.DecryptField(ctx, encryptedLoginURL)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt login link: %w", err)
+	}
+
+	expiresAt, _ := envelope.Data["expires_at"].(string)
+
+	notification := domain.NewNotification(
+		userID,
+		domain.NotificationTypeLoginLink,
+		domain.NotificationChannelTelegram,
+	)
+	// A link is only useful before it expires
+	notification.Priority = domain.NotificationPriorityUrgent
+
+	notification.AddData("expires_at", expiresAt)
+
+	// The link logs its holder in: it is rendered into the message sent but kept
+	// out of the notification data, and dropped from the content once sent
+	message, err := ec.renderTemplate(ctx, "user.login_link_requested", withData(notification.Data, "login_url", loginURL))
+	if err != nil {
+		return err
+	}
+	notification.Subject = message.Subject
+	notification.Content = message.Content
+
+	return ec.sendNotification(context.WithValue(ctx, secretContentKey{}, loginURL), notification)
+}
+
+// handleFailedLoginBurstEvent warns a user of failed logins piling up on their account,
+// with a link locking it
+func (ec *EventConsumer) handleFailedLoginBurstEvent(ctx context.Context, envelope *EventEnvelope) error {
+	userID, ok := envelope.Data["user_id"].(string)
+	if !ok {
+		return fmt.Errorf("missing or invalid user_id in failed login burst event")
+	}
+	lockURL, ok := envelope.Data["lock_url"].(string)
+	if !ok || lockURL == "" {
+		return fmt.Errorf("missing or invalid lock_url in failed login burst event")
+	}
+
+	attempts, _ := envelope.Data["attempts"].(float64)
+	windowSeconds, _ := envelope.Data["window_seconds"].(float64)
+	ipAddress, _ := envelope.Data["ip_address"].(string)
+	occurredAt, _ := envelope.Data["occurred_at"].(string)
+	lockExpiresAt, _ := envelope.Data["lock_expires_at"].(string)
+	device, _ := envelope.Data["device"].(string)
+	if device == "" {
+		device = "Unknown device"
+	}
+	location, _ := envelope.Data["location"].(string)
+	if location == "" {
+		location = "Unknown location"
+	}
+
+	notification := domain.NewNotification(
+		userID,
+		domain.NotificationTypeFailedLoginAlert,
+		domain.NotificationChannelTelegram,
+	)
+	// Someone may be guessing the password right now
+	notification.Priority = domain.NotificationPriorityUrgent
+
+	notification.AddData("attempts", int64(attempts))
+	notification.AddData("window_minutes", int64(windowSeconds)/60)
+	notification.AddData("ip_address", ipAddress)
+	notification.AddData("device", device)
+	notification.AddData("location", location)
+	notification.AddData("occurred_at", occurredAt)
+	notification.AddData("lock_url", lockURL)
+	notification.AddData("lock_expires_at", lockExpiresAt)
+
+	if err := ec.applyTemplate(ctx, notification, "iam.failed-login-burst"); err != nil {
+		return err
+	}
+
 	return ec.sendNotification(ctx, notification)
 }
 
+// claimEvent records the event in the dedup store and reports whether it should be processed.
+// Dedup store failures are logged and the event is processed anyway, preferring a possible
+// duplicate over a lost notification.
+func (ec *EventConsumer) claimEvent(ctx context.Context, topic string, envelope *EventEnvelope) bool {
+	if ec.dedupStore == nil || envelope.ID == "" {
+		return true
+	}
+
+	claimed, err := ec.dedupStore.Claim(ctx, envelope.ID)
+	if err != nil {
+		ec.logger.Warn(ctx, "Dedup store unavailable, processing event without deduplication", map[string]interface{}{
+			"event_id": envelope.ID,
+			"error":    err.Error(),
+		})
+		ec.metrics.IncrementCounter(ctx, "notification_dedup_errors_total", map[string]string{
+			"topic": topic,
+		})
+		return true
+	}
+
+	if !claimed {
+		ec.logger.Info(ctx, "Duplicate event suppressed", map[string]interface{}{
+			"topic":      topic,
+			"event_type": envelope.Type,
+			"event_id":   envelope.ID,
+		})
+		ec.metrics.IncrementCounter(ctx, "notification_duplicates_suppressed_total", map[string]string{
+			"topic":      topic,
+			"event_type": envelope.Type,
+		})
+		return false
+	}
+
+	return true
+}
+
+// releaseEvent removes the event from the dedup store after a processing failure
+func (ec *EventConsumer) releaseEvent(ctx context.Context, envelope *EventEnvelope) {
+	if ec.dedupStore == nil || envelope.ID == "" {
+		return
+	}
+
+	if err := ec.dedupStore.Release(ctx, envelope.ID); err != nil {
+		ec.logger.Warn(ctx, "Failed to release dedup entry", map[string]interface{}{
+			"event_id": envelope.ID,
+			"error":    err.Error(),
+		})
+	}
+}
+
+// applyTemplate renders the subject and content of the notification for an event
+// type from its data. A template that fails to render is a bug, not a bad event,
+// but the error is returned so the event is retried once it is fixed.
+func (ec *EventConsumer) applyTemplate(ctx context.Context, notification *domain.Notification, eventType string) error {
+	message, err := ec.renderTemplate(ctx, eventType, notification.Data)
+	if err != nil {
+		return err
+	}
+	notification.Subject = message.Subject
+	notification.Content = message.Content
+	return nil
+}
+
+// renderTemplate renders the message template of an event type with data
+func (ec *EventConsumer) renderTemplate(ctx context.Context, eventType string, data map[string]interface{}) (service.RenderedMessage, error) {
+	tmpl, ok := service.LookupMessageTemplate(eventType)
+	if !ok {
+		return service.RenderedMessage{}, fmt.Errorf("no message template for event type %s", eventType)
+	}
+	message, err := tmpl.Render(data)
+	if err != nil {
+		ec.metrics.IncrementCounter(ctx, "notification_template_errors_total", map[string]string{
+			"event_type": eventType,
+		})
+		return service.RenderedMessage{}, fmt.Errorf("failed to render %s notification: %w", eventType, err)
+	}
+	return message, nil
+}
+
+// withData returns a copy of notification data with one more value, for
+// values rendered into a message but not kept with the notification
+func withData(data map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(data)+1)
+	for k, v := range data {
+		merged[k] = v
+	}
+	merged[key] = value
+	return merged
+}
+
 // sendNotification orchestrates the process of sending a notification
 func (ec *EventConsumer) sendNotification(ctx context.Context, notification *domain.Notification) error {
+	// Notifications about test orders of sandbox tenants are marked as test data
+	if sandbox, _ := ctx.Value(sandboxEventKey{}).(bool); sandbox {
+		notification.AddMetadata("sandbox", "true")
+		notification.Subject = "[TEST] " + notification.Subject
+		notification.Content = "[TEST] " + notification.Content
+	}
+
 	// Get user's Telegram chat ID from IAM service
 	chatID, err := ec.iamClient.GetUserTelegramChatID(ctx, notification.UserID)
 	if err != nil {
@@ -477,7 +936,7 @@ func (ec *EventConsumer) sendNotification(ctx context.Context, notification *dom
 			"user_id": notification.UserID,
 			"error":   err.Error(),
 		})
-		ec.metrics.IncrementCounter("notification_chat_id_lookup_failed", map[string]string{
+		ec.metrics.IncrementCounter(ctx, "notification_chat_id_lookup_failed", map[string]string{
 			"notification_type": string(notification.Type),
 		})
 		return fmt.Errorf("failed to get Telegram chat ID for user %s: %w", notification.UserID, err)
@@ -485,22 +944,33 @@ func (ec *EventConsumer) sendNotification(ctx context.Context, notification *dom
 
 	// Send notification via Telegram (chatID is already int64)
 	err = ec.telegramService.SendNotification(ctx, notification, chatID)
+
+	// A credential in the content is only needed to send it, not to record it
+	if secret, _ := ctx.Value(secretContentKey{}).(string); secret != "" {
+		notification.Content = strings.ReplaceAll(notification.Content, secret, "[redacted]")
+	}
 	if err != nil {
 		notification.MarkAsFailed(err.Error())
+		ec.deliveryTracker.RecordFailed(ctx, notification, err.Error())
+		ec.auditNotification(ctx, notification, chatID)
 		ec.logger.Error(ctx, "Failed to send Telegram notification", err, map[string]interface{}{
 			"notification_id": notification.ID,
 			"user_id":         notification.UserID,
 			"chat_id":         chatID,
 		})
-		ec.metrics.IncrementCounter("notification_send_failed", map[string]string{
+		ec.metrics.IncrementCounter(ctx, "notification_send_failed", map[string]string{
 			"notification_type": string(notification.Type),
 			"channel":           string(notification.Channel),
+			"error_class":       service.ClassifySendError(err),
 		})
 		return fmt.Errorf("failed to send notification: %w", err)
 	}
 
-	// Mark notification as sent
+	// Mark notification as sent and track it until acknowledged
 	notification.MarkAsSent()
+	ec.recordDeliveryLatency(ctx, notification)
+	ec.deliveryTracker.RecordDelivered(ctx, notification, chatID)
+	ec.auditNotification(ctx, notification, chatID)
 
 	ec.logger.Info(ctx, "Notification sent successfully", map[string]interface{}{
 		"notification_id": notification.ID,
@@ -508,10 +978,130 @@ func (ec *EventConsumer) sendNotification(ctx context.Context, notification *dom
 		"type":            notification.Type,
 		"chat_id":         chatID,
 	})
-	ec.metrics.IncrementCounter("notification_send_success", map[string]string{
+	ec.metrics.IncrementCounter(ctx, "notification_send_success", map[string]string{
 		"notification_type": string(notification.Type),
 		"channel":           string(notification.Channel),
 	})
 
 	return nil
 }
+
+// auditEventKey is the context key of the audit record of the event being handled
+type auditEventKey struct{}
+
+// secretContentKey is the context key of a credential in the content of the
+// notification being sent, redacted from it once sent
+type secretContentKey struct{}
+
+// sandboxEventKey marks the context of an event about a sandbox tenant's test order
+type sandboxEventKey struct{}
+
+// eventTimingKey is the context key of the eventTiming of the event being handled
+type eventTimingKey struct{}
+
+// eventTiming is when the event being handled was produced, from which the
+// delivery latency of its notifications is measured
+type eventTiming struct {
+	eventType  string
+	producedAt time.Time
+}
+
+// newEventTiming takes the Kafka record timestamp, falling back to the event's
+// own time for records produced without one
+func newEventTiming(message *kafka.Message, envelope *EventEnvelope) eventTiming {
+	timing := eventTiming{eventType: envelope.Type, producedAt: message.Timestamp}
+	if timing.producedAt.IsZero() || timing.producedAt.Unix() <= 0 {
+		timing.producedAt = envelope.Time
+	}
+	return timing
+}
+
+// recordDeliveryLatency records the time from the event being produced to
+// Telegram acknowledging the notification sent for it, per event type
+func (ec *EventConsumer) recordDeliveryLatency(ctx context.Context, notification *domain.Notification) {
+	timing, ok := ctx.Value(eventTimingKey{}).(eventTiming)
+	if !ok || timing.producedAt.IsZero() || notification.SentAt == nil {
+		return
+	}
+	latency := notification.SentAt.Sub(timing.producedAt)
+	if latency < 0 {
+		latency = 0 // Clock skew between the producer and this service
+	}
+	ec.metrics.RecordDuration(ctx, "notification_delivery_latency", latency, map[string]string{
+		"event_type": timing.eventType,
+	})
+}
+
+// eventAuditRecord describes a consumed event for the audit trail
+func eventAuditRecord(message *kafka.Message, envelope *EventEnvelope) domain.AuditRecord {
+	record := domain.AuditRecord{
+		Kind:      domain.AuditRecordEvent,
+		EventID:   envelope.ID,
+		EventType: envelope.Type,
+		Source:    envelope.Source,
+		Topic:     message.Topic,
+		Partition: message.Partition,
+		Offset:    message.Offset,
+		EventData: envelope.Data,
+	}
+	if !envelope.Time.IsZero() {
+		eventTime := envelope.Time
+		record.EventTime = &eventTime
+	}
+	return record
+}
+
+// auditEvent records the outcome of a consumed event
+func (ec *EventConsumer) auditEvent(ctx context.Context, record domain.AuditRecord) {
+	if ec.auditLog == nil {
+		return
+	}
+	record.Kind = domain.AuditRecordEvent
+	ec.auditLog.Record(ctx, record)
+}
+
+// auditNotification records what was sent to whom, and for which event
+func (ec *EventConsumer) auditNotification(ctx context.Context, notification *domain.Notification, chatID int64) {
+	if ec.auditLog == nil {
+		return
+	}
+	record := domain.NewNotificationAuditRecord(notification, chatID)
+	if event, ok := ctx.Value(auditEventKey{}).(domain.AuditRecord); ok {
+		record.EventID = event.EventID
+		record.EventType = event.EventType
+		record.Topic = event.Topic
+	}
+	ec.auditLog.Record(ctx, record)
+}
+
+// formatShippingAddress renders the shipping address snapshot of an order event on one
+// line, or returns "" when the order does not ship
+func formatShippingAddress(data map[string]interface{}) string {
+	address, ok := data["shipping_address"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	var parts []string
+	for _, field := range []string{"recipient_name", "line1", "line2", "city", "region", "postal_code", "country"} {
+		if value, ok := address[field].(string); ok && value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// decodeEnvelope reads an event envelope. Avro CloudEvents are converted to the
+// JSON form first.
+func decodeEnvelope(headers map[string]string, value []byte, envelope *EventEnvelope) error {
+	if contentType := headers[cloudevents.ContentTypeHeader]; cloudevents.IsAvro(contentType) {
+		event, err := cloudevents.Decode(contentType, value)
+		if err != nil {
+			return err
+		}
+		if value, err = json.Marshal(event); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(value, envelope)
+}
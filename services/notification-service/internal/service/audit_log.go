@@ -0,0 +1,249 @@
+package service
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/platform/objectstore"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// AuditKeyPrefix is the object key prefix of the exported audit files, which are
+// stored as notification-audit/<yyyy-mm-dd>/<instance>.ndjson.gz
+const AuditKeyPrefix = "notification-audit/"
+
+const (
+	auditDayLayout  = "2006-01-02"
+	auditSpoolExt   = ".ndjson"
+	auditExportExt  = ".ndjson.gz"
+	auditDayLength  = 24 * time.Hour
+	auditSpoolPerms = 0o640
+)
+
+// AuditLog keeps the audit trail of consumed events and notification outcomes.
+// Records are appended as NDJSON to a spool file per UTC day; completed days
+// are exported to object storage gzip-compressed and removed from the spool,
+// and exported days are deleted once past the retention period.
+type AuditLog struct {
+	store   objectstore.Store
+	config  config.AuditConfig
+	logger  logging.Logger
+	metrics metrics.Metrics
+
+	mu   sync.Mutex
+	day  string   // Day of the open spool file
+	file *os.File // Open spool file, nil until the first record
+}
+
+// NewAuditLog creates an audit log spooling to the configured directory
+func NewAuditLog(store objectstore.Store, cfg config.AuditConfig, logger logging.Logger, metrics metrics.Metrics) (*AuditLog, error) {
+	if err := os.MkdirAll(cfg.SpoolDir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit spool directory: %w", err)
+	}
+	return &AuditLog{
+		store:   store,
+		config:  cfg,
+		logger:  logger,
+		metrics: metrics,
+	}, nil
+}
+
+// Record appends a record to the spool file of the current day, stamping it
+// with the time it was recorded. Failures are logged and counted but never fail
+// the event being processed.
+func (a *AuditLog) Record(ctx context.Context, record domain.AuditRecord) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Stamped under the lock, so nothing is written to a day once the next began
+	record.RecordedAt = time.Now().UTC()
+	line, err := json.Marshal(record)
+	if err == nil {
+		err = a.write(record.RecordedAt.Format(auditDayLayout), append(line, '\n'))
+	}
+	if err != nil {
+		a.logger.Error(ctx, "Failed to record notification audit record", err, map[string]interface{}{
+			"kind":            record.Kind,
+			"event_id":        record.EventID,
+			"notification_id": record.NotificationID,
+		})
+		a.metrics.IncrementCounter(ctx, "notification_audit_errors_total", map[string]string{"operation": "record"})
+		return
+	}
+	a.metrics.IncrementCounter(ctx, "notification_audit_records_total", map[string]string{"kind": string(record.Kind)})
+}
+
+// write appends a line to the spool file of the day, rolling over to the day's
+// file when the day changed
+func (a *AuditLog) write(day string, line []byte) error {
+	if a.file == nil || a.day != day {
+		if a.file != nil {
+			a.file.Close()
+			a.file = nil
+		}
+		file, err := os.OpenFile(a.spoolPath(day), os.O_CREATE|os.O_APPEND|os.O_WRONLY, auditSpoolPerms)
+		if err != nil {
+			return fmt.Errorf("failed to open audit spool file: %w", err)
+		}
+		a.file, a.day = file, day
+	}
+	_, err := a.file.Write(line)
+	return err
+}
+
+// Run exports completed days every export interval until the context is
+// cancelled, starting with the days left in the spool before a restart
+func (a *AuditLog) Run(ctx context.Context) error {
+	ticker := time.NewTicker(a.config.ExportInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.Export(ctx); err != nil && ctx.Err() == nil {
+			a.logger.Error(ctx, "Notification audit export failed", err, nil)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Export ships the spooled days before today to object storage, then deletes
+// the exported days past the retention period. A day that fails to export
+// stays in the spool and is retried on the next run.
+func (a *AuditLog) Export(ctx context.Context) error {
+	entries, err := os.ReadDir(a.config.SpoolDir)
+	if err != nil {
+		return fmt.Errorf("failed to read audit spool directory: %w", err)
+	}
+
+	today := time.Now().UTC().Format(auditDayLayout)
+	var errs []error
+	for _, entry := range entries {
+		day, ok := strings.CutSuffix(entry.Name(), auditSpoolExt)
+		if entry.IsDir() || !ok || day >= today {
+			continue
+		}
+		if _, err := time.Parse(auditDayLayout, day); err != nil {
+			continue
+		}
+		if err := a.exportDay(ctx, day); err != nil {
+			a.metrics.IncrementCounter(ctx, "notification_audit_errors_total", map[string]string{"operation": "export"})
+			errs = append(errs, fmt.Errorf("day %s: %w", day, err))
+		}
+	}
+
+	if err := a.applyRetention(ctx); err != nil {
+		a.metrics.IncrementCounter(ctx, "notification_audit_errors_total", map[string]string{"operation": "retention"})
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// exportDay compresses a day's spool file into object storage and removes it
+// from the spool. Exporting a day again replaces its file with the same content.
+func (a *AuditLog) exportDay(ctx context.Context, day string) error {
+	a.mu.Lock()
+	if a.day == day && a.file != nil {
+		a.file.Close()
+		a.file, a.day = nil, ""
+	}
+	a.mu.Unlock()
+
+	spoolPath := a.spoolPath(day)
+	spool, err := os.Open(spoolPath)
+	if err != nil {
+		return fmt.Errorf("failed to open audit spool file: %w", err)
+	}
+	defer spool.Close()
+
+	reader, writer := io.Pipe()
+	go func() {
+		compressor := gzip.NewWriter(writer)
+		_, err := io.Copy(compressor, spool)
+		if closeErr := compressor.Close(); err == nil {
+			err = closeErr
+		}
+		writer.CloseWithError(err)
+	}()
+
+	object, err := a.store.Put(ctx, a.exportKey(day), reader)
+	reader.Close()
+	if err != nil {
+		return fmt.Errorf("failed to store audit file: %w", err)
+	}
+
+	spool.Close()
+	if err := os.Remove(spoolPath); err != nil {
+		return fmt.Errorf("failed to remove exported audit spool file: %w", err)
+	}
+
+	a.logger.Info(ctx, "Notification audit day exported", map[string]interface{}{
+		"day":  day,
+		"key":  object.Key,
+		"size": object.Size,
+	})
+	a.metrics.IncrementCounter(ctx, "notification_audit_exports_total", nil)
+	return nil
+}
+
+// applyRetention deletes the exported files of days that ended before the
+// retention period
+func (a *AuditLog) applyRetention(ctx context.Context) error {
+	objects, err := a.store.List(ctx, AuditKeyPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to list audit files: %w", err)
+	}
+
+	cutoff := time.Now().UTC().Add(-a.config.Retention)
+	for _, object := range objects {
+		dayName, _, _ := strings.Cut(strings.TrimPrefix(object.Key, AuditKeyPrefix), "/")
+		day, err := time.Parse(auditDayLayout, dayName)
+		if err != nil || !day.Add(auditDayLength).Before(cutoff) {
+			continue
+		}
+
+		if err := a.store.Delete(ctx, object.Key); err != nil && !errors.Is(err, objectstore.ErrNotFound) {
+			return fmt.Errorf("failed to delete expired audit file %s: %w", object.Key, err)
+		}
+		a.logger.Info(ctx, "Expired notification audit file deleted", map[string]interface{}{
+			"key": object.Key,
+		})
+	}
+	return nil
+}
+
+// Close closes the open spool file; its records are exported once the day is over
+func (a *AuditLog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file, a.day = nil, ""
+	return err
+}
+
+func (a *AuditLog) spoolPath(day string) string {
+	return filepath.Join(a.config.SpoolDir, day+auditSpoolExt)
+}
+
+func (a *AuditLog) exportKey(day string) string {
+	return AuditKeyPrefix + day + "/" + a.config.InstanceID + auditExportExt
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DedupStore remembers which events have already produced a notification so
+// that Kafka redeliveries do not result in duplicate messages to users
+type DedupStore interface {
+	// Claim marks the key as processed and reports whether this is the first time it was seen
+	Claim(ctx context.Context, key string) (bool, error)
+	// Release forgets the key so that a failed event can be retried
+	Release(ctx context.Context, key string) error
+}
+
+// RedisDedupStore is a DedupStore backed by Redis SETNX with a TTL,
+// shared by all notification-service replicas
+type RedisDedupStore struct {
+	client    *redis.Client
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// NewRedisDedupStore creates a new Redis backed dedup store
+func NewRedisDedupStore(client *redis.Client, keyPrefix string, ttl time.Duration) *RedisDedupStore {
+	return &RedisDedupStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
+	}
+}
+
+// Claim implements DedupStore
+func (s *RedisDedupStore) Claim(ctx context.Context, key string) (bool, error) {
+	claimed, err := s.client.SetNX(ctx, s.keyPrefix+key, time.Now().UTC().Format(time.RFC3339), s.ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim dedup key: %w", err)
+	}
+	return claimed, nil
+}
+
+// Release implements DedupStore
+func (s *RedisDedupStore) Release(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.keyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to release dedup key: %w", err)
+	}
+	return nil
+}
+
+// MemoryDedupStore is an in-process DedupStore used when Redis is unavailable.
+// It only protects against redeliveries to the same replica.
+type MemoryDedupStore struct {
+	mu       sync.Mutex
+	entries  map[string]time.Time
+	expiries []dedupExpiry // In claim order, which is expiry order as the TTL is fixed
+	ttl      time.Duration
+}
+
+// dedupExpiry is when a claim of a key expires
+type dedupExpiry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// NewMemoryDedupStore creates a new in-memory dedup store
+func NewMemoryDedupStore(ttl time.Duration) *MemoryDedupStore {
+	return &MemoryDedupStore{
+		entries: make(map[string]time.Time),
+		ttl:     ttl,
+	}
+}
+
+// Claim implements DedupStore
+func (s *MemoryDedupStore) Claim(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if expiresAt, exists := s.entries[key]; exists && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	// Drop the claims expired so far, oldest first, to bound memory usage. A key
+	// released and claimed again keeps its newer expiry.
+	for len(s.expiries) > 0 && now.After(s.expiries[0].expiresAt) {
+		expired := s.expiries[0]
+		if s.entries[expired.key].Equal(expired.expiresAt) {
+			delete(s.entries, expired.key)
+		}
+		s.expiries[0] = dedupExpiry{}
+		s.expiries = s.expiries[1:]
+	}
+
+	expiresAt := now.Add(s.ttl)
+	s.entries[key] = expiresAt
+	s.expiries = append(s.expiries, dedupExpiry{key: key, expiresAt: expiresAt})
+	return true, nil
+}
+
+// Release implements DedupStore
+func (s *MemoryDedupStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/domain"
+)
+
+// maxDeliveryScan bounds how many recent deliveries a filtered list examines
+const maxDeliveryScan = 1000
+
+// DeliveryFilter narrows a delivery listing; zero values match everything
+type DeliveryFilter struct {
+	Status domain.DeliveryStatus
+	UserID string
+	Type   domain.NotificationType
+	Limit  int
+}
+
+func (f DeliveryFilter) matches(d *domain.Delivery) bool {
+	return (f.Status == "" || d.Status == f.Status) &&
+		(f.UserID == "" || d.UserID == f.UserID) &&
+		(f.Type == "" || d.Type == f.Type)
+}
+
+// DeliveryStore persists delivery and acknowledgement state of notifications
+type DeliveryStore interface {
+	// Save creates or replaces a delivery record
+	Save(ctx context.Context, delivery *domain.Delivery) error
+	// Get returns a delivery by notification ID or domain.ErrDeliveryNotFound
+	Get(ctx context.Context, notificationID string) (*domain.Delivery, error)
+	// List returns recent deliveries matching the filter, newest first
+	List(ctx context.Context, filter DeliveryFilter) ([]*domain.Delivery, error)
+	// ListAwaitingAck returns deliveries sent before the given time that still
+	// wait for an acknowledgement, oldest first
+	ListAwaitingAck(ctx context.Context, sentBefore time.Time, limit int) ([]*domain.Delivery, error)
+	// PurgeUsers deletes the deliveries of the users and returns how many
+	// there were; a dry run only counts them
+	PurgeUsers(ctx context.Context, userIDs []string, dryRun bool) (int, error)
+}
+
+// RedisDeliveryStore is a DeliveryStore backed by Redis, shared by all
+// notification-service replicas. Records expire after the retention period.
+type RedisDeliveryStore struct {
+	client    *redis.Client
+	keyPrefix string
+	retention time.Duration
+}
+
+// NewRedisDeliveryStore creates a new Redis backed delivery store
+func NewRedisDeliveryStore(client *redis.Client, keyPrefix string, retention time.Duration) *RedisDeliveryStore {
+	return &RedisDeliveryStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+		retention: retention,
+	}
+}
+
+func (s *RedisDeliveryStore) recordKey(notificationID string) string {
+	return s.keyPrefix + notificationID
+}
+
+func (s *RedisDeliveryStore) indexKey() string {
+	return s.keyPrefix + "index"
+}
+
+func (s *RedisDeliveryStore) awaitingAckKey() string {
+	return s.keyPrefix + "awaiting_ack"
+}
+
+// Save implements DeliveryStore
+func (s *RedisDeliveryStore) Save(ctx context.Context, delivery *domain.Delivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to marshal delivery: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, s.recordKey(delivery.NotificationID), data, s.retention)
+	pipe.ZAdd(ctx, s.indexKey(), redis.Z{Score: float64(delivery.CreatedAt.UnixMilli()), Member: delivery.NotificationID})
+	if delivery.AwaitingAck() {
+		pipe.ZAdd(ctx, s.awaitingAckKey(), redis.Z{Score: float64(delivery.SentAt.UnixMilli()), Member: delivery.NotificationID})
+	} else {
+		pipe.ZRem(ctx, s.awaitingAckKey(), delivery.NotificationID)
+	}
+
+	// Drop index entries whose records have expired
+	cutoff := strconv.FormatInt(time.Now().Add(-s.retention).UnixMilli(), 10)
+	pipe.ZRemRangeByScore(ctx, s.indexKey(), "-inf", "("+cutoff)
+	pipe.ZRemRangeByScore(ctx, s.awaitingAckKey(), "-inf", "("+cutoff)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save delivery: %w", err)
+	}
+	return nil
+}
+
+// Get implements DeliveryStore
+func (s *RedisDeliveryStore) Get(ctx context.Context, notificationID string) (*domain.Delivery, error) {
+	data, err := s.client.Get(ctx, s.recordKey(notificationID)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, domain.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get delivery: %w", err)
+	}
+
+	var delivery domain.Delivery
+	if err := json.Unmarshal(data, &delivery); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+// List implements DeliveryStore
+func (s *RedisDeliveryStore) List(ctx context.Context, filter DeliveryFilter) ([]*domain.Delivery, error) {
+	ids, err := s.client.ZRevRange(ctx, s.indexKey(), 0, maxDeliveryScan-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+
+	deliveries, err := s.load(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.Delivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		if !filter.matches(delivery) {
+			continue
+		}
+		result = append(result, delivery)
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// ListAwaitingAck implements DeliveryStore
+func (s *RedisDeliveryStore) ListAwaitingAck(ctx context.Context, sentBefore time.Time, limit int) ([]*domain.Delivery, error) {
+	ids, err := s.client.ZRangeByScore(ctx, s.awaitingAckKey(), &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(sentBefore.UnixMilli(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unacknowledged deliveries: %w", err)
+	}
+
+	deliveries, err := s.load(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.Delivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		if delivery.AwaitingAck() {
+			result = append(result, delivery)
+		}
+	}
+	return result, nil
+}
+
+// PurgeUsers implements DeliveryStore, scanning the index in pages
+func (s *RedisDeliveryStore) PurgeUsers(ctx context.Context, userIDs []string, dryRun bool) (int, error) {
+	users := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		users[userID] = true
+	}
+
+	var purged []string
+	for start := int64(0); ; start += maxDeliveryScan {
+		ids, err := s.client.ZRange(ctx, s.indexKey(), start, start+maxDeliveryScan-1).Result()
+		if err != nil {
+			return 0, fmt.Errorf("failed to list deliveries: %w", err)
+		}
+		deliveries, err := s.load(ctx, ids)
+		if err != nil {
+			return 0, err
+		}
+		for _, delivery := range deliveries {
+			if users[delivery.UserID] {
+				purged = append(purged, delivery.NotificationID)
+			}
+		}
+		if len(ids) < maxDeliveryScan {
+			break
+		}
+	}
+	if dryRun || len(purged) == 0 {
+		return len(purged), nil
+	}
+
+	keys := make([]string, len(purged))
+	members := make([]interface{}, len(purged))
+	for i, id := range purged {
+		keys[i] = s.recordKey(id)
+		members[i] = id
+	}
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, keys...)
+	pipe.ZRem(ctx, s.indexKey(), members...)
+	pipe.ZRem(ctx, s.awaitingAckKey(), members...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to purge deliveries: %w", err)
+	}
+	return len(purged), nil
+}
+
+// load fetches delivery records in the given order, skipping expired ones
+func (s *RedisDeliveryStore) load(ctx context.Context, ids []string) ([]*domain.Delivery, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = s.recordKey(id)
+	}
+
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deliveries: %w", err)
+	}
+
+	deliveries := make([]*domain.Delivery, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var delivery domain.Delivery
+		if err := json.Unmarshal([]byte(data), &delivery); err != nil {
+			continue
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+// MemoryDeliveryStore is an in-process DeliveryStore used when Redis is
+// unavailable. Acknowledgements only reach the replica that sent the message.
+type MemoryDeliveryStore struct {
+	mu         sync.Mutex
+	deliveries map[string]*domain.Delivery
+	retention  time.Duration
+}
+
+// NewMemoryDeliveryStore creates a new in-memory delivery store
+func NewMemoryDeliveryStore(retention time.Duration) *MemoryDeliveryStore {
+	return &MemoryDeliveryStore{
+		deliveries: make(map[string]*domain.Delivery),
+		retention:  retention,
+	}
+}
+
+// Save implements DeliveryStore
+func (s *MemoryDeliveryStore) Save(ctx context.Context, delivery *domain.Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Opportunistically drop expired records to bound memory usage
+	cutoff := time.Now().Add(-s.retention)
+	for id, existing := range s.deliveries {
+		if existing.CreatedAt.Before(cutoff) {
+			delete(s.deliveries, id)
+		}
+	}
+
+	stored := *delivery
+	s.deliveries[delivery.NotificationID] = &stored
+	return nil
+}
+
+// Get implements DeliveryStore
+func (s *MemoryDeliveryStore) Get(ctx context.Context, notificationID string) (*domain.Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delivery, ok := s.deliveries[notificationID]
+	if !ok {
+		return nil, domain.ErrDeliveryNotFound
+	}
+	result := *delivery
+	return &result, nil
+}
+
+// List implements DeliveryStore
+func (s *MemoryDeliveryStore) List(ctx context.Context, filter DeliveryFilter) ([]*domain.Delivery, error) {
+	all := s.snapshot()
+	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.After(all[j].CreatedAt) })
+
+	result := make([]*domain.Delivery, 0, len(all))
+	for _, delivery := range all {
+		if !filter.matches(delivery) {
+			continue
+		}
+		result = append(result, delivery)
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// ListAwaitingAck implements DeliveryStore
+func (s *MemoryDeliveryStore) ListAwaitingAck(ctx context.Context, sentBefore time.Time, limit int) ([]*domain.Delivery, error) {
+	all := s.snapshot()
+	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.Before(all[j].CreatedAt) })
+
+	result := make([]*domain.Delivery, 0)
+	for _, delivery := range all {
+		if !delivery.AwaitingAck() || delivery.SentAt.After(sentBefore) {
+			continue
+		}
+		result = append(result, delivery)
+		if limit > 0 && len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// PurgeUsers implements DeliveryStore
+func (s *MemoryDeliveryStore) PurgeUsers(ctx context.Context, userIDs []string, dryRun bool) (int, error) {
+	users := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		users[userID] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := 0
+	for id, delivery := range s.deliveries {
+		if !users[delivery.UserID] {
+			continue
+		}
+		purged++
+		if !dryRun {
+			delete(s.deliveries, id)
+		}
+	}
+	return purged, nil
+}
+
+func (s *MemoryDeliveryStore) snapshot() []*domain.Delivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := make([]*domain.Delivery, 0, len(s.deliveries))
+	for _, delivery := range s.deliveries {
+		copied := *delivery
+		all = append(all, &copied)
+	}
+	return all
+}
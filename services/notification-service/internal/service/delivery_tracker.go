@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Acknowledgement is a recipient's confirmation that they saw a notification
+type Acknowledgement struct {
+	NotificationID string
+	ChatID         int64  // Chat the acknowledgement came from; 0 when acknowledged through the API
+	By             string // Telegram username or operator name
+}
+
+// AcknowledgeHandler processes acknowledgements received from a channel
+type AcknowledgeHandler func(ctx context.Context, ack Acknowledgement) (*domain.Delivery, error)
+
+// DeliveryTracker records whether notifications were delivered and acknowledged
+type DeliveryTracker struct {
+	store   DeliveryStore
+	logger  logging.Logger
+	metrics metrics.Metrics
+}
+
+// NewDeliveryTracker creates a new delivery tracker
+func NewDeliveryTracker(store DeliveryStore, logger logging.Logger, metrics metrics.Metrics) *DeliveryTracker {
+	return &DeliveryTracker{
+		store:   store,
+		logger:  logger,
+		metrics: metrics,
+	}
+}
+
+// Store returns the underlying delivery store
+func (t *DeliveryTracker) Store() DeliveryStore {
+	return t.store
+}
+
+// RecordDelivered stores a successful send. Tracking failures are logged but
+// never fail the send itself.
+func (t *DeliveryTracker) RecordDelivered(ctx context.Context, notification *domain.Notification, chatID int64) {
+	delivery := domain.NewDelivery(notification)
+	messageID, _ := strconv.Atoi(notification.Metadata[domain.MetadataTelegramMessageID])
+	delivery.MarkDelivered(chatID, messageID)
+	t.save(ctx, delivery)
+}
+
+// RecordFailed stores a failed send
+func (t *DeliveryTracker) RecordFailed(ctx context.Context, notification *domain.Notification, errorMsg string) {
+	delivery := domain.NewDelivery(notification)
+	delivery.MarkFailed(errorMsg)
+	t.save(ctx, delivery)
+}
+
+// Acknowledge marks a delivered notification as acknowledged. Acknowledgements
+// from a chat must come from the chat the notification was sent to.
+func (t *DeliveryTracker) Acknowledge(ctx context.Context, ack Acknowledgement) (*domain.Delivery, error) {
+	delivery, err := t.store.Get(ctx, ack.NotificationID)
+	if err != nil {
+		return nil, err
+	}
+	if ack.ChatID != 0 && ack.ChatID != delivery.ChatID {
+		return nil, domain.ErrAckWrongRecipient
+	}
+
+	alreadyAcknowledged := delivery.Status == domain.DeliveryStatusAcknowledged
+	if err := delivery.Acknowledge(ack.By); err != nil {
+		return nil, err
+	}
+	if alreadyAcknowledged {
+		return delivery, nil
+	}
+
+	if err := t.store.Save(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	t.logger.Info(ctx, "Notification acknowledged", map[string]interface{}{
+		"notification_id": delivery.NotificationID,
+		"user_id":         delivery.UserID,
+		"type":            delivery.Type,
+		"acknowledged_by": delivery.AcknowledgedBy,
+		"ack_latency":     delivery.AcknowledgedAt.Sub(*delivery.SentAt).String(),
+	})
+	t.metrics.IncrementCounter(ctx, "notification_acknowledged_total", map[string]string{
+		"notification_type": string(delivery.Type),
+	})
+	t.metrics.RecordDuration(ctx, "notification_ack_latency", delivery.AcknowledgedAt.Sub(*delivery.SentAt), map[string]string{
+		"notification_type": string(delivery.Type),
+	})
+
+	return delivery, nil
+}
+
+func (t *DeliveryTracker) save(ctx context.Context, delivery *domain.Delivery) {
+	if err := t.store.Save(ctx, delivery); err != nil {
+		t.logger.Warn(ctx, "Failed to record notification delivery", map[string]interface{}{
+			"notification_id": delivery.NotificationID,
+			"status":          delivery.Status,
+			"error":           err.Error(),
+		})
+		t.metrics.IncrementCounter(ctx, "notification_delivery_tracking_errors_total", nil)
+	}
+}
+
+// PurgeUsers deletes the delivery records of test users, for resetting demo environments
+func (t *DeliveryTracker) PurgeUsers(ctx context.Context, userIDs []string, dryRun bool) (int, error) {
+	purged, err := t.store.PurgeUsers(ctx, userIDs, dryRun)
+	if err != nil {
+		return 0, err
+	}
+	t.logger.Info(ctx, "Notification test data purged", map[string]interface{}{
+		"users":      len(userIDs),
+		"deliveries": purged,
+		"dry_run":    dryRun,
+	})
+	return purged, nil
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// AlertRequest describes an operational alert to be routed to on-call
+type AlertRequest struct {
+	Type       domain.NotificationType
+	Subject    string
+	Content    string
+	References map[string]string // e.g. sku, order_id
+	Schedule   string            // Optional; defaults to the first schedule handling the type
+}
+
+// EscalationEngine pages the on-call responder for operational alerts and
+// escalates through the rotation until someone acknowledges
+type EscalationEngine struct {
+	store           EscalationStore
+	deliveryTracker *DeliveryTracker
+	telegramService TelegramServiceInterface
+	config          config.EscalationConfig
+	owner           string
+	logger          logging.Logger
+	metrics         metrics.Metrics
+}
+
+// NewEscalationEngine creates a new escalation engine
+func NewEscalationEngine(
+	store EscalationStore,
+	deliveryTracker *DeliveryTracker,
+	telegramService TelegramServiceInterface,
+	cfg config.EscalationConfig,
+	logger logging.Logger,
+	metrics metrics.Metrics,
+) *EscalationEngine {
+	hostname, _ := os.Hostname()
+	return &EscalationEngine{
+		store:           store,
+		deliveryTracker: deliveryTracker,
+		telegramService: telegramService,
+		config:          cfg,
+		owner:           hostname + ":" + strconv.Itoa(os.Getpid()),
+		logger:          logger,
+		metrics:         metrics,
+	}
+}
+
+// Store returns the underlying escalation store
+func (e *EscalationEngine) Store() EscalationStore {
+	return e.store
+}
+
+// DefaultAckTimeout returns the ack timeout of schedules that don't set one
+func (e *EscalationEngine) DefaultAckTimeout() time.Duration {
+	return e.config.DefaultAckTimeout
+}
+
+// RaiseAlert opens an escalation for the alert and pages whoever is on call
+func (e *EscalationEngine) RaiseAlert(ctx context.Context, req AlertRequest) (*domain.Escalation, error) {
+	if !domain.IsOperationalAlert(req.Type) {
+		return nil, fmt.Errorf("unsupported alert type %q", req.Type)
+	}
+
+	schedule, err := e.scheduleFor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	escalation := domain.NewEscalation(req.Type, req.Subject, req.Content, req.References, schedule)
+	e.page(ctx, escalation, schedule.AckTimeout)
+	if err := e.store.SaveEscalation(ctx, escalation); err != nil {
+		return nil, err
+	}
+
+	e.logger.Info(ctx, "Operational alert raised", map[string]interface{}{
+		"alert_id":  escalation.AlertID,
+		"type":      escalation.Type,
+		"schedule":  escalation.Schedule,
+		"responder": escalation.Steps[0].Responder.Name,
+	})
+	e.metrics.IncrementCounter(ctx, "notification_alerts_raised_total", map[string]string{
+		"alert_type": string(escalation.Type),
+		"schedule":   escalation.Schedule,
+	})
+
+	return escalation, nil
+}
+
+// Run evaluates open escalations every check interval until the context
+// is cancelled. Only the replica holding the lease escalates.
+func (e *EscalationEngine) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			e.evaluate(ctx)
+		}
+	}
+}
+
+// evaluate closes acknowledged escalations and escalates timed out ones
+func (e *EscalationEngine) evaluate(ctx context.Context) {
+	leader, err := e.store.AcquireLease(ctx, e.owner, 2*e.config.CheckInterval)
+	if err != nil {
+		e.logger.Warn(ctx, "Failed to acquire escalation lease", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	if !leader {
+		return
+	}
+
+	escalations, err := e.store.ListEscalations(ctx, domain.EscalationStatusOpen, 0)
+	if err != nil {
+		e.logger.Error(ctx, "Failed to list open escalations", err, nil)
+		return
+	}
+
+	for _, escalation := range escalations {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := e.advance(ctx, escalation); err != nil {
+			e.logger.Error(ctx, "Failed to advance escalation", err, map[string]interface{}{
+				"alert_id": escalation.AlertID,
+			})
+		}
+	}
+}
+
+// advance moves a single open escalation forward
+func (e *EscalationEngine) advance(ctx context.Context, escalation *domain.Escalation) error {
+	// Any responder acknowledging the alert stops the escalation
+	for i, step := range escalation.Steps {
+		if step.NotificationID == "" {
+			continue
+		}
+		delivery, err := e.deliveryTracker.Store().Get(ctx, step.NotificationID)
+		if err != nil {
+			if errors.Is(err, domain.ErrDeliveryNotFound) {
+				continue
+			}
+			return err
+		}
+		if delivery.Status == domain.DeliveryStatusAcknowledged {
+			escalation.MarkAcknowledged(i, delivery.AcknowledgedBy, *delivery.AcknowledgedAt)
+			e.logger.Info(ctx, "Operational alert acknowledged", map[string]interface{}{
+				"alert_id":        escalation.AlertID,
+				"level":           step.Level,
+				"acknowledged_by": escalation.AcknowledgedBy,
+			})
+			e.metrics.IncrementCounter(ctx, "notification_alerts_acknowledged_total", map[string]string{
+				"alert_type": string(escalation.Type),
+				"level":      strconv.Itoa(step.Level),
+			})
+			return e.store.SaveEscalation(ctx, escalation)
+		}
+	}
+
+	current := escalation.CurrentStep()
+	if current != nil && time.Now().Before(current.EscalateAt) {
+		return nil
+	}
+
+	if _, _, ok := escalation.NextResponder(); !ok {
+		escalation.MarkExhausted(time.Now())
+		e.logger.Warn(ctx, "Operational alert was not acknowledged by anyone on call", map[string]interface{}{
+			"alert_id": escalation.AlertID,
+			"schedule": escalation.Schedule,
+			"levels":   len(escalation.Steps),
+		})
+		e.metrics.IncrementCounter(ctx, "notification_alerts_exhausted_total", map[string]string{
+			"alert_type": string(escalation.Type),
+		})
+		return e.store.SaveEscalation(ctx, escalation)
+	}
+
+	// Use the schedule's current timeout; fall back to the default if the
+	// schedule was deleted after the alert was raised
+	ackTimeout := e.config.DefaultAckTimeout
+	if schedule, err := e.store.GetSchedule(ctx, escalation.Schedule); err == nil {
+		ackTimeout = schedule.AckTimeout
+	}
+
+	e.page(ctx, escalation, ackTimeout)
+	e.metrics.IncrementCounter(ctx, "notification_alerts_escalated_total", map[string]string{
+		"alert_type": string(escalation.Type),
+	})
+	return e.store.SaveEscalation(ctx, escalation)
+}
+
+// page notifies the next responder in the escalation order and records the
+// step. A failed send is recorded too and escalates on the next check.
+func (e *EscalationEngine) page(ctx context.Context, escalation *domain.Escalation, ackTimeout time.Duration) {
+	responder, level, ok := escalation.NextResponder()
+	if !ok {
+		return
+	}
+
+	notification := domain.NewNotification("oncall:"+responder.Name, escalation.Type, domain.NotificationChannelTelegram)
+	notification.Priority = domain.NotificationPriorityUrgent
+	notification.Subject = escalation.Subject
+	if level > 1 {
+		notification.Subject = fmt.Sprintf("[Escalated L%d] %s", level, escalation.Subject)
+	}
+	notification.Content = escalation.Content
+	notification.AddData("alert_id", escalation.AlertID)
+	notification.AddData("escalation_level", level)
+	if sku := escalation.References["sku"]; sku != "" {
+		notification.AddData("sku", sku)
+	}
+	if len(escalation.References) > 0 {
+		notification.AddData("references", escalation.References)
+	}
+
+	now := time.Now()
+	step := domain.EscalationStep{
+		Level:          level,
+		Responder:      responder,
+		NotificationID: notification.ID,
+		NotifiedAt:     now,
+		EscalateAt:     now.Add(ackTimeout),
+	}
+
+	if err := e.telegramService.SendNotification(ctx, notification, responder.ChatID); err != nil {
+		notification.MarkAsFailed(err.Error())
+		e.deliveryTracker.RecordFailed(ctx, notification, err.Error())
+		step.Error = err.Error()
+		step.EscalateAt = now
+		e.logger.Error(ctx, "Failed to page on-call responder", err, map[string]interface{}{
+			"alert_id":  escalation.AlertID,
+			"level":     level,
+			"responder": responder.Name,
+		})
+	} else {
+		notification.MarkAsSent()
+		e.deliveryTracker.RecordDelivered(ctx, notification, responder.ChatID)
+		e.logger.Info(ctx, "Paged on-call responder", map[string]interface{}{
+			"alert_id":        escalation.AlertID,
+			"level":           level,
+			"responder":       responder.Name,
+			"notification_id": notification.ID,
+		})
+	}
+
+	escalation.Steps = append(escalation.Steps, step)
+	escalation.UpdatedAt = now
+}
+
+// scheduleFor resolves the schedule that receives the alert
+func (e *EscalationEngine) scheduleFor(ctx context.Context, req AlertRequest) (*domain.OnCallSchedule, error) {
+	if req.Schedule != "" {
+		schedule, err := e.store.GetSchedule(ctx, req.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		if !schedule.Handles(req.Type) {
+			return nil, domain.ErrNoScheduleForAlert
+		}
+		return schedule, nil
+	}
+
+	schedules, err := e.store.ListSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, schedule := range schedules {
+		if schedule.Handles(req.Type) {
+			return schedule, nil
+		}
+	}
+	return nil, domain.ErrNoScheduleForAlert
+}
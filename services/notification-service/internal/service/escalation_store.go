@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/domain"
+)
+
+// maxEscalationScan bounds how many recent escalations a listing examines
+const maxEscalationScan = 1000
+
+// EscalationStore persists on-call schedules and the audit trail of alert
+// escalations
+type EscalationStore interface {
+	// SaveSchedule creates or replaces an on-call schedule
+	SaveSchedule(ctx context.Context, schedule *domain.OnCallSchedule) error
+	// GetSchedule returns a schedule by name or domain.ErrScheduleNotFound
+	GetSchedule(ctx context.Context, name string) (*domain.OnCallSchedule, error)
+	// ListSchedules returns all schedules ordered by name
+	ListSchedules(ctx context.Context) ([]*domain.OnCallSchedule, error)
+	// DeleteSchedule removes a schedule; open escalations keep their rotation
+	DeleteSchedule(ctx context.Context, name string) error
+
+	// SaveEscalation creates or replaces an escalation
+	SaveEscalation(ctx context.Context, escalation *domain.Escalation) error
+	// GetEscalation returns an escalation by alert ID or domain.ErrEscalationNotFound
+	GetEscalation(ctx context.Context, alertID string) (*domain.Escalation, error)
+	// ListEscalations returns recent escalations, newest first; an empty
+	// status matches every escalation
+	ListEscalations(ctx context.Context, status domain.EscalationStatus, limit int) ([]*domain.Escalation, error)
+
+	// AcquireLease grants the owner exclusive right to escalate alerts for
+	// the TTL, so that only one replica pages responders at a time
+	AcquireLease(ctx context.Context, owner string, ttl time.Duration) (bool, error)
+}
+
+// RedisEscalationStore is an EscalationStore backed by Redis. Schedules are
+// kept until deleted; escalations expire after the retention period.
+type RedisEscalationStore struct {
+	client    *redis.Client
+	keyPrefix string
+	retention time.Duration
+}
+
+// NewRedisEscalationStore creates a new Redis backed escalation store
+func NewRedisEscalationStore(client *redis.Client, keyPrefix string, retention time.Duration) *RedisEscalationStore {
+	return &RedisEscalationStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+		retention: retention,
+	}
+}
+
+func (s *RedisEscalationStore) schedulesKey() string {
+	return s.keyPrefix + "schedules"
+}
+
+func (s *RedisEscalationStore) escalationKey(alertID string) string {
+	return s.keyPrefix + "alert:" + alertID
+}
+
+func (s *RedisEscalationStore) indexKey() string {
+	return s.keyPrefix + "index"
+}
+
+func (s *RedisEscalationStore) openKey() string {
+	return s.keyPrefix + "open"
+}
+
+func (s *RedisEscalationStore) leaseKey() string {
+	return s.keyPrefix + "lease"
+}
+
+// SaveSchedule implements EscalationStore
+func (s *RedisEscalationStore) SaveSchedule(ctx context.Context, schedule *domain.OnCallSchedule) error {
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+	if err := s.client.HSet(ctx, s.schedulesKey(), schedule.Name, data).Err(); err != nil {
+		return fmt.Errorf("failed to save schedule: %w", err)
+	}
+	return nil
+}
+
+// GetSchedule implements EscalationStore
+func (s *RedisEscalationStore) GetSchedule(ctx context.Context, name string) (*domain.OnCallSchedule, error) {
+	data, err := s.client.HGet(ctx, s.schedulesKey(), name).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, domain.ErrScheduleNotFound
+		}
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	var schedule domain.OnCallSchedule
+	if err := json.Unmarshal(data, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schedule: %w", err)
+	}
+	return &schedule, nil
+}
+
+// ListSchedules implements EscalationStore
+func (s *RedisEscalationStore) ListSchedules(ctx context.Context) ([]*domain.OnCallSchedule, error) {
+	values, err := s.client.HGetAll(ctx, s.schedulesKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	schedules := make([]*domain.OnCallSchedule, 0, len(values))
+	for _, data := range values {
+		var schedule domain.OnCallSchedule
+		if err := json.Unmarshal([]byte(data), &schedule); err != nil {
+			continue
+		}
+		schedules = append(schedules, &schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Name < schedules[j].Name })
+	return schedules, nil
+}
+
+// DeleteSchedule implements EscalationStore
+func (s *RedisEscalationStore) DeleteSchedule(ctx context.Context, name string) error {
+	deleted, err := s.client.HDel(ctx, s.schedulesKey(), name).Result()
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule: %w", err)
+	}
+	if deleted == 0 {
+		return domain.ErrScheduleNotFound
+	}
+	return nil
+}
+
+// SaveEscalation implements EscalationStore
+func (s *RedisEscalationStore) SaveEscalation(ctx context.Context, escalation *domain.Escalation) error {
+	data, err := json.Marshal(escalation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal escalation: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, s.escalationKey(escalation.AlertID), data, s.retention)
+	pipe.ZAdd(ctx, s.indexKey(), redis.Z{Score: float64(escalation.CreatedAt.UnixMilli()), Member: escalation.AlertID})
+	if escalation.Status == domain.EscalationStatusOpen {
+		pipe.ZAdd(ctx, s.openKey(), redis.Z{Score: float64(escalation.CreatedAt.UnixMilli()), Member: escalation.AlertID})
+	} else {
+		pipe.ZRem(ctx, s.openKey(), escalation.AlertID)
+	}
+
+	// Drop index entries whose records have expired
+	cutoff := strconv.FormatInt(time.Now().Add(-s.retention).UnixMilli(), 10)
+	pipe.ZRemRangeByScore(ctx, s.indexKey(), "-inf", "("+cutoff)
+	pipe.ZRemRangeByScore(ctx, s.openKey(), "-inf", "("+cutoff)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save escalation: %w", err)
+	}
+	return nil
+}
+
+// GetEscalation implements EscalationStore
+func (s *RedisEscalationStore) GetEscalation(ctx context.Context, alertID string) (*domain.Escalation, error) {
+	data, err := s.client.Get(ctx, s.escalationKey(alertID)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, domain.ErrEscalationNotFound
+		}
+		return nil, fmt.Errorf("failed to get escalation: %w", err)
+	}
+
+	var escalation domain.Escalation
+	if err := json.Unmarshal(data, &escalation); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal escalation: %w", err)
+	}
+	return &escalation, nil
+}
+
+// ListEscalations implements EscalationStore
+func (s *RedisEscalationStore) ListEscalations(ctx context.Context, status domain.EscalationStatus, limit int) ([]*domain.Escalation, error) {
+	key := s.indexKey()
+	if status == domain.EscalationStatusOpen {
+		key = s.openKey()
+	}
+	ids, err := s.client.ZRevRange(ctx, key, 0, maxEscalationScan-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list escalations: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = s.escalationKey(id)
+	}
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load escalations: %w", err)
+	}
+
+	escalations := make([]*domain.Escalation, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var escalation domain.Escalation
+		if err := json.Unmarshal([]byte(data), &escalation); err != nil {
+			continue
+		}
+		if status != "" && escalation.Status != status {
+			continue
+		}
+		escalations = append(escalations, &escalation)
+		if limit > 0 && len(escalations) == limit {
+			break
+		}
+	}
+	return escalations, nil
+}
+
+// AcquireLease implements EscalationStore
+func (s *RedisEscalationStore) AcquireLease(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	acquired, err := s.client.SetNX(ctx, s.leaseKey(), owner, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire escalation lease: %w", err)
+	}
+	if acquired {
+		return true, nil
+	}
+
+	// Renew the lease if this replica already holds it
+	holder, err := s.client.Get(ctx, s.leaseKey()).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read escalation lease: %w", err)
+	}
+	if holder != owner {
+		return false, nil
+	}
+	if err := s.client.PExpire(ctx, s.leaseKey(), ttl).Err(); err != nil {
+		return false, fmt.Errorf("failed to renew escalation lease: %w", err)
+	}
+	return true, nil
+}
+
+// MemoryEscalationStore is an in-process EscalationStore used when Redis is
+// unavailable. Schedules are lost on restart and every replica escalates
+// the alerts it raised itself.
+type MemoryEscalationStore struct {
+	mu          sync.Mutex
+	schedules   map[string]*domain.OnCallSchedule
+	escalations map[string]*domain.Escalation
+	retention   time.Duration
+}
+
+// NewMemoryEscalationStore creates a new in-memory escalation store
+func NewMemoryEscalationStore(retention time.Duration) *MemoryEscalationStore {
+	return &MemoryEscalationStore{
+		schedules:   make(map[string]*domain.OnCallSchedule),
+		escalations: make(map[string]*domain.Escalation),
+		retention:   retention,
+	}
+}
+
+// SaveSchedule implements EscalationStore
+func (s *MemoryEscalationStore) SaveSchedule(ctx context.Context, schedule *domain.OnCallSchedule) error {
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+	var stored domain.OnCallSchedule
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return fmt.Errorf("failed to copy schedule: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedules[schedule.Name] = &stored
+	return nil
+}
+
+// GetSchedule implements EscalationStore
+func (s *MemoryEscalationStore) GetSchedule(ctx context.Context, name string) (*domain.OnCallSchedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, ok := s.schedules[name]
+	if !ok {
+		return nil, domain.ErrScheduleNotFound
+	}
+	result := *schedule
+	return &result, nil
+}
+
+// ListSchedules implements EscalationStore
+func (s *MemoryEscalationStore) ListSchedules(ctx context.Context) ([]*domain.OnCallSchedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules := make([]*domain.OnCallSchedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		copied := *schedule
+		schedules = append(schedules, &copied)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Name < schedules[j].Name })
+	return schedules, nil
+}
+
+// DeleteSchedule implements EscalationStore
+func (s *MemoryEscalationStore) DeleteSchedule(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.schedules[name]; !ok {
+		return domain.ErrScheduleNotFound
+	}
+	delete(s.schedules, name)
+	return nil
+}
+
+// SaveEscalation implements EscalationStore. Escalations are deep copied
+// through JSON since their steps are mutated in place by the engine.
+func (s *MemoryEscalationStore) SaveEscalation(ctx context.Context, escalation *domain.Escalation) error {
+	data, err := json.Marshal(escalation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal escalation: %w", err)
+	}
+	var stored domain.Escalation
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return fmt.Errorf("failed to copy escalation: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Opportunistically drop expired records to bound memory usage
+	cutoff := time.Now().Add(-s.retention)
+	for id, existing := range s.escalations {
+		if existing.CreatedAt.Before(cutoff) {
+			delete(s.escalations, id)
+		}
+	}
+
+	s.escalations[escalation.AlertID] = &stored
+	return nil
+}
+
+// GetEscalation implements EscalationStore
+func (s *MemoryEscalationStore) GetEscalation(ctx context.Context, alertID string) (*domain.Escalation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	escalation, ok := s.escalations[alertID]
+	if !ok {
+		return nil, domain.ErrEscalationNotFound
+	}
+	return copyEscalation(escalation), nil
+}
+
+// ListEscalations implements EscalationStore
+func (s *MemoryEscalationStore) ListEscalations(ctx context.Context, status domain.EscalationStatus, limit int) ([]*domain.Escalation, error) {
+	s.mu.Lock()
+	all := make([]*domain.Escalation, 0, len(s.escalations))
+	for _, escalation := range s.escalations {
+		if status == "" || escalation.Status == status {
+			all = append(all, copyEscalation(escalation))
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.After(all[j].CreatedAt) })
+	if limit > 0 && len(all) > limit {
+		all = all[:limit]
+	}
+	return all, nil
+}
+
+// AcquireLease implements EscalationStore. A single process always holds
+// the lease.
+func (s *MemoryEscalationStore) AcquireLease(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func copyEscalation(escalation *domain.Escalation) *domain.Escalation {
+	copied := *escalation
+	copied.Order = append([]domain.Responder(nil), escalation.Order...)
+	copied.Steps = append([]domain.EscalationStep(nil), escalation.Steps...)
+	return &copied
+}
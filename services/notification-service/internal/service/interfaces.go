@@ -13,5 +13,12 @@ type TelegramServiceInterface interface {
 	SendNotification(ctx context.Context, notification *domain.Notification, chatID int64) error
 	ValidateChatID(ctx context.Context, chatID int64) error
 	GetBotInfo() *tgbotapi.User
+	// FormatMessage returns the message text SendNotification would send
+	FormatMessage(notification *domain.Notification) string
+	// ListenForAcknowledgements passes presses of the Acknowledge button to
+	// the handler until the context is cancelled
+	ListenForAcknowledgements(ctx context.Context, handler AcknowledgeHandler) error
+	// Status reports whether the Bot API is reachable and the send queue depth
+	Status() TelegramStatus
 	Close()
 }
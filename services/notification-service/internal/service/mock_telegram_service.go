@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -47,7 +48,7 @@ func (mts *MockTelegramService) SendNotification(ctx context.Context, notificati
 
 	// Record metrics
 	defer func() {
-		mts.metrics.RecordDuration("notification_telegram_send_duration", time.Since(startTime), nil)
+		mts.metrics.RecordDuration(ctx, "notification_telegram_send_duration", time.Since(startTime), nil)
 	}()
 
 	mts.logger.Info(ctx, "Mock: Sending Telegram notification", map[string]interface{}{
@@ -64,6 +65,7 @@ func (mts *MockTelegramService) SendNotification(ctx context.Context, notificati
 	time.Sleep(100 * time.Millisecond)
 
 	// Simulate successful send
+	notification.AddMetadata(domain.MetadataTelegramMessageID, strconv.FormatInt(time.Now().UnixNano()%1000000, 10))
 	mts.logger.Info(ctx, "Mock: Telegram notification sent successfully", map[string]interface{}{
 		"notification_id": notification.ID,
 		"user_id":         notification.UserID,
@@ -71,10 +73,16 @@ func (mts *MockTelegramService) SendNotification(ctx context.Context, notificati
 		"mock":            true,
 	})
 
-	mts.metrics.IncrementCounter("notification_telegram_send_success", nil)
+	mts.metrics.IncrementCounter(ctx, "notification_telegram_send_success", nil)
 	return nil
 }
 
+// FormatMessage formats the notification like the real service does
+func (mts *MockTelegramService) FormatMessage(notification *domain.Notification) string {
+	formatter := &TelegramService{config: mts.config}
+	return formatter.formatMessage(notification)
+}
+
 // ValidateChatID simulates validating a chat ID
 func (mts *MockTelegramService) ValidateChatID(ctx context.Context, chatID int64) error {
 	mts.logger.Info(ctx, "Mock: Validating chat ID", map[string]interface{}{
@@ -95,6 +103,21 @@ func (mts *MockTelegramService) GetBotInfo() *tgbotapi.User {
 	return &mts.botInfo
 }
 
+// ListenForAcknowledgements does nothing in development mode; use the
+// delivery API to acknowledge notifications instead
+func (mts *MockTelegramService) ListenForAcknowledgements(ctx context.Context, handler AcknowledgeHandler) error {
+	mts.logger.Info(ctx, "Mock: Acknowledgement callbacks are not received, use the delivery API", map[string]interface{}{
+		"mock": true,
+	})
+	<-ctx.Done()
+	return nil
+}
+
+// Status reports the mock as always reachable with nothing queued
+func (mts *MockTelegramService) Status() TelegramStatus {
+	return TelegramStatus{Reachable: true, LastContact: time.Now()}
+}
+
 // Close closes the mock Telegram service
 func (mts *MockTelegramService) Close() {
 	mts.logger.Info(nil, "Mock Telegram service closed", map[string]interface{}{
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+)
+
+// RedisOffsetSnapshotStore keeps the Kafka offset snapshots of each consumer
+// group in a Redis sorted set scored by the snapshot time in milliseconds
+type RedisOffsetSnapshotStore struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// NewRedisOffsetSnapshotStore creates a new Redis backed offset snapshot store
+func NewRedisOffsetSnapshotStore(client *redis.Client, keyPrefix string) *RedisOffsetSnapshotStore {
+	return &RedisOffsetSnapshotStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+	}
+}
+
+// Save implements kafka.OffsetSnapshotStore
+func (s *RedisOffsetSnapshotStore) Save(ctx context.Context, snapshot kafka.OffsetSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal offset snapshot: %w", err)
+	}
+	member := redis.Z{Score: float64(snapshot.TakenAt.UnixMilli()), Member: data}
+	if err := s.client.ZAdd(ctx, s.key(snapshot.GroupID), member).Err(); err != nil {
+		return fmt.Errorf("failed to save offset snapshot: %w", err)
+	}
+	return nil
+}
+
+// SnapshotAt implements kafka.OffsetSnapshotStore
+func (s *RedisOffsetSnapshotStore) SnapshotAt(ctx context.Context, groupID string, at time.Time) (*kafka.OffsetSnapshot, error) {
+	members, err := s.client.ZRevRangeByScore(ctx, s.key(groupID), &redis.ZRangeBy{
+		Max:   strconv.FormatInt(at.UnixMilli(), 10),
+		Min:   "-inf",
+		Count: 1,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offset snapshot: %w", err)
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	var snapshot kafka.OffsetSnapshot
+	if err := json.Unmarshal([]byte(members[0]), &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal offset snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// DeleteBefore implements kafka.OffsetSnapshotStore
+func (s *RedisOffsetSnapshotStore) DeleteBefore(ctx context.Context, groupID string, before time.Time) (int64, error) {
+	deleted, err := s.client.ZRemRangeByScore(ctx, s.key(groupID), "-inf", "("+strconv.FormatInt(before.UnixMilli(), 10)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete offset snapshots: %w", err)
+	}
+	return deleted, nil
+}
+
+func (s *RedisOffsetSnapshotStore) key(groupID string) string {
+	return s.keyPrefix + groupID
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/amiosamu/rocket-science/shared/platform/httpclient"
+)
+
+// Error classes of failed Telegram sends, reported as the error_class label
+const (
+	SendErrorRateLimited = "rate_limited" // 429 after the throttle retries ran out
+	SendErrorBadRequest  = "bad_request"  // Malformed message, e.g. broken Markdown
+	SendErrorForbidden   = "forbidden"    // The user blocked the bot or left the chat
+	SendErrorAuth        = "unauthorized" // The bot token was revoked
+	SendErrorAPI         = "api_error"    // Any other error response of the Bot API
+	SendErrorServer      = "server_error" // 5xx responses of the Bot API
+	SendErrorCircuitOpen = "circuit_open" // The client stopped calling the Bot API for a while
+	SendErrorTimeout     = "timeout"
+	SendErrorNetwork     = "network"
+	SendErrorCanceled    = "canceled"     // The sender stopped waiting
+	SendErrorQueueClosed = "queue_closed" // The service is shutting down
+	SendErrorUnknown     = "unknown"
+)
+
+// ClassifySendError returns the error class of a failed Telegram send
+func ClassifySendError(err error) string {
+	var apiErr *tgbotapi.Error
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &apiErr):
+		switch {
+		case apiErr.Code == 429 || apiErr.RetryAfter > 0:
+			return SendErrorRateLimited
+		case apiErr.Code == 400:
+			return SendErrorBadRequest
+		case apiErr.Code == 401:
+			return SendErrorAuth
+		case apiErr.Code == 403:
+			return SendErrorForbidden
+		case apiErr.Code >= 500:
+			return SendErrorServer
+		default:
+			return SendErrorAPI
+		}
+	case errors.Is(err, ErrSendQueueClosed):
+		return SendErrorQueueClosed
+	case errors.Is(err, httpclient.ErrCircuitOpen):
+		return SendErrorCircuitOpen
+	case errors.Is(err, context.Canceled):
+		return SendErrorCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return SendErrorTimeout
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return SendErrorTimeout
+		}
+		return SendErrorNetwork
+	default:
+		return SendErrorUnknown
+	}
+}
+
+// unreachableClasses are the error classes showing the Bot API did not answer
+var unreachableClasses = map[string]bool{
+	SendErrorServer:      true,
+	SendErrorCircuitOpen: true,
+	SendErrorTimeout:     true,
+	SendErrorNetwork:     true,
+}
+
+// TelegramStatus is the state of the Bot API as seen by the service
+type TelegramStatus struct {
+	Reachable      bool          `json:"reachable"`       // False once unreachable for longer than the threshold
+	UnreachableFor time.Duration `json:"unreachable_for"` // Zero while the API answers
+	LastContact    time.Time     `json:"last_contact"`    // Last answer of the API, zero if none yet
+	QueueDepth     int           `json:"queue_depth"`     // Messages waiting for their turn to be sent
+}
+
+// reachability tracks whether the Bot API answers. Any answer, including an
+// error response, shows it is reachable; network errors, timeouts, server errors
+// and an open circuit breaker count as unreachable until the next answer.
+type reachability struct {
+	mu          sync.Mutex
+	lastContact time.Time
+	downSince   time.Time // First failure since the last answer; zero while reachable
+}
+
+// observe records the outcome of a Bot API call
+func (r *reachability) observe(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if err != nil && unreachableClasses[ClassifySendError(err)] {
+		if r.downSince.IsZero() {
+			r.downSince = now
+		}
+		return
+	}
+	if err == nil || !errors.Is(err, context.Canceled) {
+		r.lastContact = now
+		r.downSince = time.Time{}
+	}
+}
+
+// unreachableFor returns how long the Bot API has not answered
+func (r *reachability) unreachableFor() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.downSince.IsZero() {
+		return 0
+	}
+	return time.Since(r.downSince)
+}
+
+// contactedSince reports whether the Bot API answered within the duration
+func (r *reachability) contactedSince(d time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return !r.lastContact.IsZero() && time.Since(r.lastContact) < d
+}
+
+func (r *reachability) last() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.lastContact
+}
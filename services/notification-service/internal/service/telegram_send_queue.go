@@ -0,0 +1,409 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// ErrSendQueueClosed is returned for messages still queued when the queue closes
+var ErrSendQueueClosed = errors.New("telegram send queue closed")
+
+// maxDigestLines caps the lines listed in a digest; the rest are only counted
+const maxDigestLines = 30
+
+// SendQueueConfig holds the Bot API send limits
+type SendQueueConfig struct {
+	GlobalRate         float64       // Messages per second across all chats
+	ChatInterval       time.Duration // Minimum time between two messages to one chat
+	ChatQueueLimit     int           // Messages queued per chat before overflow goes to a digest
+	Workers            int           // Messages sent concurrently, at most one per chat
+	MaxThrottleRetries int           // 429 responses honored per message before giving up
+}
+
+// SendQueue paces Bot API sends so they stay within Telegram's limits. Messages
+// wait in a queue per chat and chats take turns, so a burst to one chat cannot
+// starve the others. A global token bucket caps the overall rate and each chat
+// gets at most one message per ChatInterval. A 429 response pauses the chat for
+// the retry_after it carries and puts the message back at the head of its queue.
+// Once a chat's queue is full, further messages are folded into one digest
+// message sent after the queue drains.
+type SendQueue struct {
+	config  SendQueueConfig
+	send    func(tgbotapi.Chattable) (tgbotapi.Message, error)
+	global  *tokenBucket
+	logger  logging.Logger
+	metrics metrics.Metrics
+
+	mu     sync.Mutex
+	chats  map[int64]*chatQueue
+	ring   []int64 // Chats with queued messages, in turn order
+	next   int     // Ring position of the next chat to serve
+	closed bool
+
+	wake    chan struct{}
+	workers chan struct{}
+	done    chan struct{}
+}
+
+// chatQueue holds the messages queued for one chat
+type chatQueue struct {
+	chatID      int64
+	pending     []*queuedMessage
+	digest      *queuedMessage // Overflow, sent after pending drains
+	nextAllowed time.Time
+	busy        bool
+}
+
+// queuedMessage is a message and the senders waiting for its result
+type queuedMessage struct {
+	msg        tgbotapi.MessageConfig
+	ctx        context.Context
+	waiters    []chan sendResult
+	lines      []string // Digest entries
+	throttled  int
+	digestSize int
+}
+
+type sendResult struct {
+	message tgbotapi.Message
+	err     error
+}
+
+// NewSendQueue creates a send queue and starts dispatching
+func NewSendQueue(cfg SendQueueConfig, send func(tgbotapi.Chattable) (tgbotapi.Message, error), logger logging.Logger, metrics metrics.Metrics) *SendQueue {
+	q := &SendQueue{
+		config:  cfg,
+		send:    send,
+		global:  newTokenBucket(cfg.GlobalRate, int(cfg.GlobalRate)),
+		logger:  logger,
+		metrics: metrics,
+		chats:   make(map[int64]*chatQueue),
+		wake:    make(chan struct{}, 1),
+		workers: make(chan struct{}, cfg.Workers),
+		done:    make(chan struct{}),
+	}
+	go q.dispatch()
+	return q
+}
+
+// Send queues a message and waits until it is sent. digestLine summarizes the
+// message if it has to be folded into a digest; the digest's result is then
+// returned.
+func (q *SendQueue) Send(ctx context.Context, msg tgbotapi.MessageConfig, digestLine string) (tgbotapi.Message, error) {
+	result := make(chan sendResult, 1)
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return tgbotapi.Message{}, ErrSendQueueClosed
+	}
+	chat := q.chats[msg.ChatID]
+	if chat == nil {
+		chat = &chatQueue{chatID: msg.ChatID}
+		q.chats[msg.ChatID] = chat
+	}
+	if len(chat.pending) == 0 && chat.digest == nil && !chat.busy {
+		q.ring = append(q.ring, msg.ChatID)
+	}
+
+	if len(chat.pending) < q.config.ChatQueueLimit {
+		chat.pending = append(chat.pending, &queuedMessage{msg: msg, ctx: ctx, waiters: []chan sendResult{result}})
+	} else {
+		if chat.digest == nil {
+			chat.digest = &queuedMessage{msg: msg, ctx: context.WithoutCancel(ctx)}
+		}
+		chat.digest.waiters = append(chat.digest.waiters, result)
+		chat.digest.digestSize++
+		if len(chat.digest.lines) < maxDigestLines {
+			chat.digest.lines = append(chat.digest.lines, digestLine)
+		}
+		q.metrics.IncrementCounter(ctx, "notification_telegram_digested_total", nil)
+	}
+	q.setDepthGauge(ctx)
+	q.mu.Unlock()
+	q.signal()
+
+	select {
+	case <-ctx.Done():
+		// The message is skipped when its turn comes
+		return tgbotapi.Message{}, ctx.Err()
+	case res := <-result:
+		return res.message, res.err
+	}
+}
+
+// Close stops dispatching and fails the messages still queued
+func (q *SendQueue) Close() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.closed = true
+	for _, chat := range q.chats {
+		for _, queued := range chat.pending {
+			queued.resolve(sendResult{err: ErrSendQueueClosed})
+		}
+		if chat.digest != nil {
+			chat.digest.resolve(sendResult{err: ErrSendQueueClosed})
+		}
+		chat.pending, chat.digest = nil, nil
+	}
+	q.ring = nil
+	q.mu.Unlock()
+	close(q.done)
+}
+
+// dispatch hands queued messages to workers, one chat at a time in turn
+func (q *SendQueue) dispatch() {
+	for {
+		queued, chat, wait := q.nextMessage()
+		if queued == nil {
+			timer := time.NewTimer(wait)
+			select {
+			case <-q.done:
+				timer.Stop()
+				return
+			case <-q.wake:
+			case <-timer.C:
+			}
+			timer.Stop()
+			continue
+		}
+
+		// Wait for a global token and a free worker
+		if delay := q.global.reserve(); delay > 0 {
+			select {
+			case <-q.done:
+				return
+			case <-time.After(delay):
+			}
+		}
+		select {
+		case <-q.done:
+			return
+		case q.workers <- struct{}{}:
+		}
+
+		go func() {
+			defer func() { <-q.workers }()
+			q.deliver(chat, queued)
+		}()
+	}
+}
+
+// nextMessage takes the next message due, marking its chat busy. When none is
+// due it returns how long to wait before looking again.
+func (q *SendQueue) nextMessage() (*queuedMessage, *chatQueue, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	wait := time.Minute
+	for i := 0; i < len(q.ring); i++ {
+		pos := (q.next + i) % len(q.ring)
+		chat := q.chats[q.ring[pos]]
+		if chat.busy {
+			continue
+		}
+		if until := chat.nextAllowed.Sub(now); until > 0 {
+			wait = min(wait, until)
+			continue
+		}
+
+		queued := chat.pop()
+		if queued == nil {
+			continue
+		}
+		if queued.ctx.Err() != nil {
+			// The sender gave up waiting; look at the chat again on the next pass
+			q.requeueTurn(chat)
+			return nil, nil, 0
+		}
+		chat.busy = true
+		q.next = pos + 1
+		return queued, chat, 0
+	}
+	return nil, nil, wait
+}
+
+// deliver sends a message and settles the chat afterwards
+func (q *SendQueue) deliver(chat *chatQueue, queued *queuedMessage) {
+	msg := queued.msg
+	if queued.digestSize > 0 {
+		msg.Text = digestText(queued)
+		msg.ReplyMarkup = nil
+	}
+
+	sent, err := q.send(msg)
+
+	q.mu.Lock()
+	chat.busy = false
+	chat.nextAllowed = time.Now().Add(q.config.ChatInterval)
+
+	var apiErr *tgbotapi.Error
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 && queued.throttled < q.config.MaxThrottleRetries && !q.closed {
+		queued.throttled++
+		retryAfter := time.Duration(apiErr.RetryAfter) * time.Second
+		chat.nextAllowed = time.Now().Add(retryAfter)
+		if queued.digestSize > 0 {
+			chat.digest = mergeDigest(queued, chat.digest)
+		} else {
+			chat.pending = append([]*queuedMessage{queued}, chat.pending...)
+		}
+		q.metrics.IncrementCounter(queued.ctx, "notification_telegram_rate_limited_total", nil)
+		q.logger.Warn(queued.ctx, "Telegram rate limit hit, pausing chat", map[string]interface{}{
+			"chat_id":     chat.chatID,
+			"retry_after": retryAfter.String(),
+			"attempt":     queued.throttled,
+		})
+		q.mu.Unlock()
+		q.signal()
+		return
+	}
+
+	q.requeueTurn(chat)
+	q.setDepthGauge(queued.ctx)
+	q.mu.Unlock()
+	q.signal()
+
+	if err == nil && queued.digestSize > 0 {
+		q.metrics.IncrementCounter(queued.ctx, "notification_telegram_digests_sent_total", nil)
+	}
+	queued.resolve(sendResult{message: sent, err: err})
+}
+
+// requeueTurn drops a chat without queued messages from the ring. Callers hold q.mu.
+func (q *SendQueue) requeueTurn(chat *chatQueue) {
+	if len(chat.pending) > 0 || chat.digest != nil || chat.busy {
+		return
+	}
+	for i, id := range q.ring {
+		if id == chat.chatID {
+			q.ring = append(q.ring[:i], q.ring[i+1:]...)
+			if q.next > i {
+				q.next--
+			}
+			break
+		}
+	}
+	delete(q.chats, chat.chatID)
+}
+
+// Depth returns the number of messages waiting to be sent
+func (q *SendQueue) Depth() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.depth()
+}
+
+// depth counts the queued messages. Callers hold q.mu.
+func (q *SendQueue) depth() int {
+	depth := 0
+	for _, chat := range q.chats {
+		depth += len(chat.pending)
+		if chat.digest != nil {
+			depth += chat.digest.digestSize
+		}
+	}
+	return depth
+}
+
+// setDepthGauge reports the number of queued messages. Callers hold q.mu.
+func (q *SendQueue) setDepthGauge(ctx context.Context) {
+	q.metrics.SetGauge(ctx, "notification_telegram_send_queue_depth", float64(q.depth()), nil)
+}
+
+func (q *SendQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// pop takes the next message of the chat: queued ones first, then the digest
+func (c *chatQueue) pop() *queuedMessage {
+	if len(c.pending) > 0 {
+		queued := c.pending[0]
+		c.pending = c.pending[1:]
+		return queued
+	}
+	digest := c.digest
+	c.digest = nil
+	return digest
+}
+
+// resolve hands the result to every waiting sender
+func (m *queuedMessage) resolve(result sendResult) {
+	for _, waiter := range m.waiters {
+		waiter <- result
+	}
+}
+
+// mergeDigest folds a digest that was sent back into one collected meanwhile
+func mergeDigest(throttled, collected *queuedMessage) *queuedMessage {
+	if collected == nil {
+		return throttled
+	}
+	throttled.waiters = append(throttled.waiters, collected.waiters...)
+	throttled.digestSize += collected.digestSize
+	for _, line := range collected.lines {
+		if len(throttled.lines) < maxDigestLines {
+			throttled.lines = append(throttled.lines, line)
+		}
+	}
+	return throttled
+}
+
+// digestText lists the notifications folded into a digest
+func digestText(digest *queuedMessage) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "📬 *%d more notifications*\n\n", digest.digestSize)
+	for _, line := range digest.lines {
+		text.WriteString("• " + line + "\n")
+	}
+	if rest := digest.digestSize - len(digest.lines); rest > 0 {
+		fmt.Fprintf(&text, "…and %d more\n", rest)
+	}
+	return text.String()
+}
+
+// tokenBucket is a token bucket refilled continuously at rate tokens per second
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// reserve takes a token and returns how long to wait before using it
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
@@ -2,29 +2,62 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/platform/httpclient"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
 )
 
+// ackCallbackPrefix prefixes the callback data of Acknowledge buttons
+const ackCallbackPrefix = "ack:"
+
+// updatesPollTimeout is how long a getUpdates long poll waits for updates
+const updatesPollTimeout = 30 * time.Second
+
 // TelegramService handles sending notifications via Telegram
 type TelegramService struct {
 	bot     *tgbotapi.BotAPI
+	queue   *SendQueue // Paces sends within Telegram's rate limits
 	config  config.TelegramConfig
 	logger  logging.Logger
 	metrics metrics.Metrics
+
+	// reach tracks whether the Bot API answers, probed while no sends do
+	reach     reachability
+	stopProbe chan struct{}
+	closeOnce sync.Once
+
+	// stopUpdates guards StopReceivingUpdates, which panics when called twice
+	stopUpdates sync.Once
 }
 
 // NewTelegramService creates a new TelegramService instance
 func NewTelegramService(cfg config.TelegramConfig, logger logging.Logger, metrics metrics.Metrics) (*TelegramService, error) {
-	bot, err := tgbotapi.NewBotAPI(cfg.BotToken)
+	// Sends keep their own retries below, which know the Bot API errors; the
+	// client only adds retries of idempotent calls and the circuit breaker
+	clientCfg := httpclient.DefaultConfig("telegram")
+	clientCfg.Timeout = cfg.Timeout + updatesPollTimeout // Long polls hold the request open
+	clientCfg.ProxyURL = cfg.ProxyURL
+	clientCfg.BreakerThreshold = cfg.BreakerThreshold
+	clientCfg.BreakerOpenDuration = cfg.BreakerOpenDuration
+	clientCfg.PerResourceRetryAfter = true // Flood limits are per chat, see SendQueue
+	client, err := httpclient.New(clientCfg, metrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Telegram HTTP client: %w", err)
+	}
+
+	bot, err := tgbotapi.NewBotAPIWithClient(cfg.BotToken, tgbotapi.APIEndpoint, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Telegram bot: %w", err)
 	}
@@ -36,12 +69,77 @@ func NewTelegramService(cfg config.TelegramConfig, logger logging.Logger, metric
 		"bot_id":       bot.Self.ID,
 	})
 
-	return &TelegramService{
-		bot:     bot,
-		config:  cfg,
-		logger:  logger,
-		metrics: metrics,
-	}, nil
+	ts := &TelegramService{
+		bot:       bot,
+		config:    cfg,
+		logger:    logger,
+		metrics:   metrics,
+		stopProbe: make(chan struct{}),
+	}
+	ts.reach.observe(nil) // Creating the bot called getMe
+
+	ts.queue = NewSendQueue(SendQueueConfig{
+		GlobalRate:         cfg.GlobalRate,
+		ChatInterval:       cfg.ChatInterval,
+		ChatQueueLimit:     cfg.ChatQueueLimit,
+		Workers:            cfg.SendWorkers,
+		MaxThrottleRetries: cfg.MaxThrottleRetries,
+	}, ts.send, logger, metrics)
+
+	go ts.probeReachability()
+
+	return ts, nil
+}
+
+// send calls the Bot API, recording whether it answered
+func (ts *TelegramService) send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	sent, err := ts.bot.Send(c)
+	ts.reach.observe(err)
+	return sent, err
+}
+
+// probeReachability calls getMe whenever no send has reached the Bot API for a
+// probe interval, so an outage is noticed while there is nothing to send
+func (ts *TelegramService) probeReachability() {
+	ticker := time.NewTicker(ts.config.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ts.stopProbe:
+			return
+		case <-ticker.C:
+		}
+
+		if !ts.reach.contactedSince(ts.config.ProbeInterval) {
+			_, err := ts.bot.GetMe()
+			ts.reach.observe(err)
+			if err != nil {
+				ts.logger.Warn(nil, "Telegram Bot API probe failed", map[string]interface{}{
+					"error":           err.Error(),
+					"unreachable_for": ts.reach.unreachableFor().String(),
+				})
+			}
+		}
+
+		reachable := 0.0
+		if ts.Status().Reachable {
+			reachable = 1
+		}
+		ts.metrics.SetGauge(nil, "notification_telegram_api_reachable", reachable, nil)
+		ts.metrics.SetGauge(nil, "notification_telegram_api_unreachable_seconds", ts.reach.unreachableFor().Seconds(), nil)
+	}
+}
+
+// Status reports whether the Bot API is reachable and how many messages wait to be sent
+func (ts *TelegramService) Status() TelegramStatus {
+	unreachableFor := ts.reach.unreachableFor()
+	return TelegramStatus{
+		Reachable:      unreachableFor <= ts.config.UnreachableThreshold,
+		UnreachableFor: unreachableFor,
+		LastContact:    ts.reach.last(),
+		QueueDepth:     ts.queue.Depth(),
+	}
 }
 
 // SendNotification sends a notification via Telegram
@@ -50,7 +148,7 @@ func (ts *TelegramService) SendNotification(ctx context.Context, notification *d
 
 	// Record metrics
 	defer func() {
-		ts.metrics.RecordDuration("notification_telegram_send_duration", time.Since(startTime), nil)
+		ts.metrics.RecordDuration(ctx, "notification_telegram_send_duration", time.Since(startTime), nil)
 	}()
 
 	ts.logger.Info(ctx, "Sending Telegram notification", map[string]interface{}{
@@ -73,29 +171,34 @@ func (ts *TelegramService) SendNotification(ctx context.Context, notification *d
 	}
 
 	// Send the message with retry logic
-	err := ts.sendWithRetry(ctx, msg, notification)
+	sent, err := ts.sendWithRetry(ctx, msg, notification)
 	if err != nil {
 		ts.logger.Error(ctx, "Failed to send Telegram notification", err, map[string]interface{}{
 			"notification_id": notification.ID,
 			"user_id":         notification.UserID,
 			"chat_id":         chatID,
 		})
-		ts.metrics.IncrementCounter("notification_telegram_send_error", nil)
+		ts.metrics.IncrementCounter(ctx, "notification_telegram_send_error", map[string]string{
+			"error_class": ClassifySendError(err),
+		})
 		return fmt.Errorf("failed to send Telegram message: %w", err)
 	}
 
+	notification.AddMetadata(domain.MetadataTelegramMessageID, strconv.Itoa(sent.MessageID))
+
 	ts.logger.Info(ctx, "Telegram notification sent successfully", map[string]interface{}{
 		"notification_id": notification.ID,
 		"user_id":         notification.UserID,
 		"chat_id":         chatID,
+		"message_id":      sent.MessageID,
 	})
-	ts.metrics.IncrementCounter("notification_telegram_send_success", nil)
+	ts.metrics.IncrementCounter(ctx, "notification_telegram_send_success", nil)
 
 	return nil
 }
 
 // sendWithRetry sends a message with retry logic
-func (ts *TelegramService) sendWithRetry(ctx context.Context, msg tgbotapi.MessageConfig, notification *domain.Notification) error {
+func (ts *TelegramService) sendWithRetry(ctx context.Context, msg tgbotapi.MessageConfig, notification *domain.Notification) (tgbotapi.Message, error) {
 	var lastErr error
 
 	for attempt := 0; attempt <= ts.config.RetryCount; attempt++ {
@@ -103,7 +206,7 @@ func (ts *TelegramService) sendWithRetry(ctx context.Context, msg tgbotapi.Messa
 			// Wait before retry
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return tgbotapi.Message{}, ctx.Err()
 			case <-time.After(ts.config.RetryDelay * time.Duration(attempt)):
 			}
 
@@ -114,11 +217,11 @@ func (ts *TelegramService) sendWithRetry(ctx context.Context, msg tgbotapi.Messa
 			})
 		}
 
-		// Send the message with timeout
-		_, err := ts.bot.Send(msg)
+		// Wait for the message's turn within the rate limits and send it
+		sent, err := ts.queue.Send(ctx, msg, digestLine(notification))
 
 		if err == nil {
-			return nil
+			return sent, nil
 		}
 
 		lastErr = err
@@ -139,7 +242,15 @@ func (ts *TelegramService) sendWithRetry(ctx context.Context, msg tgbotapi.Messa
 		})
 	}
 
-	return lastErr
+	return tgbotapi.Message{}, lastErr
+}
+
+// digestLine summarizes a notification for a digest of overflowing messages
+func digestLine(notification *domain.Notification) string {
+	if notification.Subject != "" {
+		return notification.Subject
+	}
+	return string(notification.Type)
 }
 
 // isRetryableError checks if an error is retryable
@@ -166,6 +277,11 @@ func (ts *TelegramService) isRetryableError(err error) bool {
 	return false
 }
 
+// FormatMessage returns the message text SendNotification would send
+func (ts *TelegramService) FormatMessage(notification *domain.Notification) string {
+	return ts.formatMessage(notification)
+}
+
 // formatMessage formats the notification content for Telegram
 func (ts *TelegramService) formatMessage(notification *domain.Notification) string {
 	var message strings.Builder
@@ -205,14 +321,28 @@ func (ts *TelegramService) getEmojiForType(notificationType domain.NotificationT
 		return "📦"
 	case domain.NotificationTypeOrderPaid:
 		return "💳"
+	case domain.NotificationTypeOrderApprovalRequested:
+		return "🛂"
 	case domain.NotificationTypePaymentFailed:
 		return "❌"
+	case domain.NotificationTypePaymentRetryScheduled:
+		return "🔁"
+	case domain.NotificationTypeOrderBackordered:
+		return "⏳"
+	case domain.NotificationTypeBackorderAvailable:
+		return "📦"
+	case domain.NotificationTypeRefundStatusChanged:
+		return "💸"
 	case domain.NotificationTypeAssemblyStarted:
 		return "🔧"
 	case domain.NotificationTypeAssemblyCompleted:
 		return "🚀"
 	case domain.NotificationTypeAssemblyFailed:
 		return "⚠️"
+	case domain.NotificationTypeLowStock:
+		return "📉"
+	case domain.NotificationTypeSLABreach:
+		return "⏰"
 	default:
 		return "📢"
 	}
@@ -221,12 +351,37 @@ func (ts *TelegramService) getEmojiForType(notificationType domain.NotificationT
 // addDataToMessage adds additional data to the message based on notification type
 func (ts *TelegramService) addDataToMessage(message *strings.Builder, notification *domain.Notification) {
 	switch notification.Type {
-	case domain.NotificationTypeOrderCreated, domain.NotificationTypeOrderPaid:
+	case domain.NotificationTypeOrderCreated, domain.NotificationTypeOrderPaid, domain.NotificationTypeOrderApprovalRequested,
+		domain.NotificationTypePaymentRetryScheduled, domain.NotificationTypeOrderBackordered, domain.NotificationTypeBackorderAvailable,
+		domain.NotificationTypeRefundStatusChanged:
 		ts.addOrderDataToMessage(message, notification.Data)
 	case domain.NotificationTypePaymentFailed:
 		ts.addPaymentDataToMessage(message, notification.Data)
 	case domain.NotificationTypeAssemblyStarted, domain.NotificationTypeAssemblyCompleted, domain.NotificationTypeAssemblyFailed:
 		ts.addAssemblyDataToMessage(message, notification.Data)
+	case domain.NotificationTypeLowStock, domain.NotificationTypeSLABreach:
+		ts.addAlertDataToMessage(message, notification.Data)
+	}
+}
+
+// addAlertDataToMessage adds operational alert references and the
+// escalation level to the message
+func (ts *TelegramService) addAlertDataToMessage(message *strings.Builder, data map[string]interface{}) {
+	if alertID, ok := data["alert_id"].(string); ok && alertID != "" {
+		message.WriteString(fmt.Sprintf("\n\n*Alert ID:* `%s`", alertID))
+	}
+	if level, ok := data["escalation_level"].(int); ok && level > 1 {
+		message.WriteString(fmt.Sprintf("\n*Escalation level:* %d", level))
+	}
+	if references, ok := data["references"].(map[string]string); ok {
+		keys := make([]string, 0, len(references))
+		for key := range references {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			message.WriteString(fmt.Sprintf("\n• `%s`: `%s`", key, references[key]))
+		}
 	}
 }
 
@@ -262,6 +417,10 @@ func (ts *TelegramService) addOrderDataToMessage(message *strings.Builder, data
 			}
 		}
 	}
+
+	if address, ok := data["shipping_address"].(string); ok && address != "" {
+		message.WriteString(fmt.Sprintf("\n\n*Ships to:* %s", address))
+	}
 }
 
 // addPaymentDataToMessage adds payment-specific data to the message
@@ -323,6 +482,10 @@ func (ts *TelegramService) addAssemblyDataToMessage(message *strings.Builder, da
 			}
 		}
 	}
+
+	if address, ok := data["shipping_address"].(string); ok && address != "" {
+		message.WriteString(fmt.Sprintf("\n\n*Ships to:* %s", address))
+	}
 }
 
 // createInlineKeyboard creates an inline keyboard for certain notification types
@@ -344,6 +507,12 @@ func (ts *TelegramService) createInlineKeyboard(notification *domain.Notificatio
 		}
 	}
 
+	// Critical alerts ask the recipient to confirm they have seen them
+	if notification.RequiresAcknowledgement() {
+		ackButton := tgbotapi.NewInlineKeyboardButtonData("✅ Acknowledge", ackCallbackPrefix+notification.ID)
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, []tgbotapi.InlineKeyboardButton{ackButton})
+	}
+
 	// Return nil if no buttons were added
 	if len(keyboard.InlineKeyboard) == 0 {
 		return nil
@@ -352,6 +521,90 @@ func (ts *TelegramService) createInlineKeyboard(notification *domain.Notificatio
 	return &keyboard
 }
 
+// ListenForAcknowledgements long-polls the bot for Acknowledge button presses.
+// Each press is answered in the chat and, once recorded, the button is removed.
+func (ts *TelegramService) ListenForAcknowledgements(ctx context.Context, handler AcknowledgeHandler) error {
+	if ts.config.EnableWebhook {
+		// Polling is unavailable while a webhook is registered
+		ts.logger.Warn(ctx, "Telegram webhook enabled, acknowledgement callbacks are not polled", nil)
+		<-ctx.Done()
+		return nil
+	}
+
+	updateConfig := tgbotapi.NewUpdate(0)
+	updateConfig.Timeout = int(updatesPollTimeout.Seconds())
+	updateConfig.AllowedUpdates = []string{"callback_query"}
+	updates := ts.bot.GetUpdatesChan(updateConfig)
+
+	for {
+		select {
+		case <-ctx.Done():
+			ts.stopReceivingUpdates()
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if update.CallbackQuery != nil {
+				ts.handleCallback(ctx, update.CallbackQuery, handler)
+			}
+		}
+	}
+}
+
+// handleCallback processes a single inline button press
+func (ts *TelegramService) handleCallback(ctx context.Context, query *tgbotapi.CallbackQuery, handler AcknowledgeHandler) {
+	notificationID, ok := strings.CutPrefix(query.Data, ackCallbackPrefix)
+	if !ok || query.Message == nil {
+		return
+	}
+
+	by := query.From.UserName
+	if by == "" {
+		by = strconv.FormatInt(query.From.ID, 10)
+	}
+
+	reply := "Acknowledged ✅"
+	_, err := handler(ctx, Acknowledgement{
+		NotificationID: notificationID,
+		ChatID:         query.Message.Chat.ID,
+		By:             by,
+	})
+	if err != nil {
+		ts.logger.Warn(ctx, "Failed to record acknowledgement", map[string]interface{}{
+			"notification_id": notificationID,
+			"chat_id":         query.Message.Chat.ID,
+			"error":           err.Error(),
+		})
+		reply = "Could not record acknowledgement, please try again"
+		if errors.Is(err, domain.ErrDeliveryNotFound) || errors.Is(err, domain.ErrAckWrongRecipient) {
+			reply = "This alert can no longer be acknowledged"
+		}
+	}
+
+	if _, err := ts.bot.Request(tgbotapi.NewCallback(query.ID, reply)); err != nil {
+		ts.logger.Warn(ctx, "Failed to answer Telegram callback", map[string]interface{}{
+			"notification_id": notificationID,
+			"error":           err.Error(),
+		})
+	}
+
+	if err == nil {
+		removeButtons := tgbotapi.NewEditMessageReplyMarkup(query.Message.Chat.ID, query.Message.MessageID,
+			tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
+		if _, err := ts.bot.Request(removeButtons); err != nil {
+			ts.logger.Debug(ctx, "Failed to remove Acknowledge button", map[string]interface{}{
+				"notification_id": notificationID,
+				"error":           err.Error(),
+			})
+		}
+	}
+}
+
+func (ts *TelegramService) stopReceivingUpdates() {
+	ts.stopUpdates.Do(ts.bot.StopReceivingUpdates)
+}
+
 // ValidateChatID validates if a chat ID is valid by sending a test message
 func (ts *TelegramService) ValidateChatID(ctx context.Context, chatID int64) error {
 	// Try to get chat information
@@ -376,6 +629,8 @@ func (ts *TelegramService) GetBotInfo() *tgbotapi.User {
 
 // Close closes the Telegram service
 func (ts *TelegramService) Close() {
-	ts.bot.StopReceivingUpdates()
+	ts.stopReceivingUpdates()
+	ts.closeOnce.Do(func() { close(ts.stopProbe) })
+	ts.queue.Close()
 	ts.logger.Info(nil, "Telegram service closed")
 }
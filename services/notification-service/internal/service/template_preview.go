@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Errors returned by template previews
+var (
+	ErrUnknownTemplate     = errors.New("unknown message template")
+	ErrTemplateInvalid     = errors.New("template invalid")
+	ErrTestSendUnavailable = errors.New("test sends are disabled; set TELEGRAM_TEST_CHAT_ID")
+)
+
+// PreviewRequest selects the template to render and the data to render it with
+type PreviewRequest struct {
+	EventType string
+	Subject   string                 // Overrides the template subject, to try a change
+	Content   string                 // Overrides the template content
+	Data      map[string]interface{} // Merged over the sample data of the template
+	Send      bool                   // Also send the message to the test chat
+	SentBy    string
+}
+
+// Preview is a rendered template
+type Preview struct {
+	EventType    string                  `json:"event_type"`
+	Type         domain.NotificationType `json:"notification_type"`
+	Subject      string                  `json:"subject"`
+	Content      string                  `json:"content"`
+	Data         map[string]interface{}  `json:"data"`
+	TelegramText string                  `json:"telegram_text"` // The message as Telegram receives it
+	Sent         bool                    `json:"sent"`
+	ChatID       int64                   `json:"chat_id,omitempty"`
+	MessageID    string                  `json:"message_id,omitempty"`
+}
+
+// TemplatePreviewer renders message templates with sample or given data and sends
+// test messages to a designated chat, so template changes can be checked without
+// producing events. Test sends are not recorded as deliveries.
+type TemplatePreviewer struct {
+	telegramService TelegramServiceInterface
+	testChatID      int64
+	logger          logging.Logger
+	metrics         metrics.Metrics
+}
+
+// NewTemplatePreviewer creates a new template previewer. A testChatID of 0
+// disables test sends.
+func NewTemplatePreviewer(telegramService TelegramServiceInterface, testChatID int64, logger logging.Logger, metrics metrics.Metrics) *TemplatePreviewer {
+	return &TemplatePreviewer{
+		telegramService: telegramService,
+		testChatID:      testChatID,
+		logger:          logger,
+		metrics:         metrics,
+	}
+}
+
+// Preview renders a template and, if asked to, sends it to the test chat
+func (p *TemplatePreviewer) Preview(ctx context.Context, req PreviewRequest) (*Preview, error) {
+	tmpl, ok := LookupMessageTemplate(req.EventType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTemplate, req.EventType)
+	}
+	if req.Subject != "" {
+		tmpl.Subject = req.Subject
+	}
+	if req.Content != "" {
+		tmpl.Content = req.Content
+	}
+	if req.Send && p.testChatID == 0 {
+		return nil, ErrTestSendUnavailable
+	}
+
+	if err := tmpl.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTemplateInvalid, err)
+	}
+
+	data := make(map[string]interface{}, len(tmpl.Sample)+len(req.Data))
+	for key, value := range tmpl.Sample {
+		data[key] = value
+	}
+	for key, value := range req.Data {
+		data[key] = value
+	}
+
+	message, err := tmpl.Render(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTemplateInvalid, err)
+	}
+
+	notification := domain.NewNotification("template-preview", tmpl.Type, domain.NotificationChannelTelegram)
+	notification.Subject = message.Subject
+	notification.Content = message.Content
+	for key, value := range data {
+		notification.AddData(key, value)
+	}
+
+	preview := &Preview{
+		EventType:    req.EventType,
+		Type:         tmpl.Type,
+		Subject:      message.Subject,
+		Content:      message.Content,
+		Data:         data,
+		TelegramText: p.telegramService.FormatMessage(notification),
+	}
+	if !req.Send {
+		return preview, nil
+	}
+
+	notification.Subject = "[TEST] " + notification.Subject
+	if err := p.telegramService.SendNotification(ctx, notification, p.testChatID); err != nil {
+		return nil, fmt.Errorf("failed to send test message: %w", err)
+	}
+	preview.Sent = true
+	preview.ChatID = p.testChatID
+	preview.MessageID = notification.Metadata[domain.MetadataTelegramMessageID]
+
+	p.logger.Info(ctx, "Template test message sent", map[string]interface{}{
+		"event_type": req.EventType,
+		"chat_id":    p.testChatID,
+		"sent_by":    req.SentBy,
+	})
+	p.metrics.IncrementCounter(ctx, "notification_template_test_sends_total", map[string]string{
+		"event_type": req.EventType,
+	})
+
+	return preview, nil
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/domain"
+)
+
+// MessageTemplate renders the subject and content of the notification sent for an
+// event type. Both use text/template syntax over the notification data, e.g.
+// {{.order_id}}; a variable missing from the data is an error.
+type MessageTemplate struct {
+	EventType string                  `json:"event_type"`
+	Type      domain.NotificationType `json:"notification_type"`
+	Subject   string                  `json:"subject"`
+	Content   string                  `json:"content"`
+	Sample    map[string]interface{}  `json:"sample_data"` // Data a typical event produces, for previews
+}
+
+// RenderedMessage is a rendered subject and content
+type RenderedMessage struct {
+	Subject string `json:"subject"`
+	Content string `json:"content"`
+}
+
+// Render executes the template with data
+func (t MessageTemplate) Render(data map[string]interface{}) (RenderedMessage, error) {
+	subject, err := executeTemplate(t.EventType+":subject", t.Subject, data)
+	if err != nil {
+		return RenderedMessage{}, err
+	}
+	content, err := executeTemplate(t.EventType+":content", t.Content, data)
+	if err != nil {
+		return RenderedMessage{}, err
+	}
+	return RenderedMessage{Subject: subject, Content: content}, nil
+}
+
+// Validate checks the template syntax
+func (t MessageTemplate) Validate() error {
+	if _, err := parseTemplate(t.EventType+":subject", t.Subject); err != nil {
+		return err
+	}
+	_, err := parseTemplate(t.EventType+":content", t.Content)
+	return err
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func executeTemplate(name, text string, data map[string]interface{}) (string, error) {
+	tmpl, err := parseTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return out.String(), nil
+}
+
+// messageTemplates are the templates of the events that notify users, by event type
+var messageTemplates = map[string]MessageTemplate{
+	"order.created": {
+		Type:    domain.NotificationTypeOrderCreated,
+		Subject: "Order Created Successfully! 📦",
+		Content: "Your order has been created successfully!\n\nWe're preparing your rocket parts for assembly.",
+		Sample: map[string]interface{}{
+			"order_id":     "order-sample-1",
+			"total_amount": 1299.5,
+			"currency":     "USD",
+		},
+	},
+	"order.paid": {
+		Type:    domain.NotificationTypeOrderPaid,
+		Subject: "Payment Confirmed! 💳",
+		Content: "Your payment has been processed successfully!\n\nYour rocket assembly will begin shortly.",
+		Sample: map[string]interface{}{
+			"order_id":       "order-sample-1",
+			"transaction_id": "txn-sample-1",
+			"amount":         1299.5,
+			"currency":       "USD",
+			"payment_method": "card",
+		},
+	},
+	"order.cancelled": {
+		Type:    domain.NotificationTypeOrderCreated, // Reusing order created type for cancelled
+		Subject: "Order Cancelled ❌",
+		Content: "Your order has been cancelled.\n\nReason: {{.reason}}\n\nIf a refund is required, it will be processed within 3-5 business days.",
+		Sample: map[string]interface{}{
+			"order_id":        "order-sample-1",
+			"reason":          "customer request",
+			"refund_required": true,
+		},
+	},
+	"order.approval_requested": {
+		Type:    domain.NotificationTypeOrderApprovalRequested,
+		Subject: "Order Approval Required",
+		Content: "A high-value order is waiting for your approval before payment.\n\nApprove or reject it before {{.expires_at}}, otherwise it is cancelled.",
+		Sample: map[string]interface{}{
+			"order_id":     "order-sample-1",
+			"total_amount": 250000.0,
+			"currency":     "USD",
+			"expires_at":   "2025-01-01T12:00:00Z",
+		},
+	},
+	"order.payment_retry_scheduled": {
+		Type:    domain.NotificationTypePaymentRetryScheduled,
+		Subject: "Payment Retry Scheduled 🔁",
+		Content: "We could not process the payment of your order right now, so we will try again at {{.next_attempt_at}} (attempt {{.attempt}} of {{.max_attempts}}).\n\nYour order and its items stay reserved in the meantime; there is nothing you need to do.",
+		Sample: map[string]interface{}{
+			"order_id":        "order-sample-1",
+			"total_amount":    1299.5,
+			"currency":        "USD",
+			"attempt":         1,
+			"max_attempts":    5,
+			"next_attempt_at": "2025-01-01T12:00:30Z",
+		},
+	},
+	"order.backordered": {
+		Type:    domain.NotificationTypeOrderBackordered,
+		Subject: "Items Backordered ⏳",
+		Content: "Some items of your order are out of stock: {{.item_summary}}.\n\nWe reserve them for you as soon as they arrive and continue your order automatically; you are only charged for them then.",
+		Sample: map[string]interface{}{
+			"order_id":     "order-sample-1",
+			"item_summary": "1 × RD-180 Engine",
+			"amount":       1299.5,
+			"currency":     "USD",
+		},
+	},
+	"order.backorder_available": {
+		Type:    domain.NotificationTypeBackorderAvailable,
+		Subject: "Backordered Items Arrived 📦",
+		Content: "Good news: {{.item_summary}} arrived and are reserved for your order.\n\nWe are processing the payment and your order continues to assembly.",
+		Sample: map[string]interface{}{
+			"order_id":     "order-sample-1",
+			"item_summary": "1 × RD-180 Engine",
+			"amount":       1299.5,
+			"currency":     "USD",
+		},
+	},
+	"order.refund_status_changed": {
+		Type:    domain.NotificationTypeRefundStatusChanged,
+		Subject: "Refund {{if eq .status \"requested\"}}Requested{{else if eq .status \"approved\"}}Approved{{else if eq .status \"rejected\"}}Declined{{else if eq .status \"refunded\"}}Completed{{else}}Delayed{{end}} 💸",
+		Content: "{{if eq .status \"requested\"}}We received your refund request for your order. Our support team reviews it and gets back to you shortly.{{else if eq .status \"approved\"}}Your refund request was approved. We are refunding your payment now.{{else if eq .status \"rejected\"}}Your refund request was declined: {{.note}}{{else if eq .status \"refunded\"}}Your payment was refunded. It may take a few days to appear on your statement.{{else}}We could not refund your payment yet. Our support team is looking into it; there is nothing you need to do.{{end}}",
+		Sample: map[string]interface{}{
+			"order_id":     "order-sample-1",
+			"status":       "requested",
+			"total_amount": 1299.5,
+			"currency":     "USD",
+			"reason":       "engine arrived damaged",
+			"note":         "",
+		},
+	},
+	"payment.processed": {
+		Type:    domain.NotificationTypeOrderPaid,
+		Subject: "Payment Successful! 💰",
+		Content: "Your payment has been processed successfully!\n\nTransaction ID: {{.transaction_id}}",
+		Sample: map[string]interface{}{
+			"payment_id":     "payment-sample-1",
+			"order_id":       "order-sample-1",
+			"transaction_id": "txn-sample-1",
+			"amount":         1299.5,
+			"currency":       "USD",
+			"payment_method": "card",
+		},
+	},
+	"payment.failed": {
+		Type:    domain.NotificationTypePaymentFailed,
+		Subject: "Payment Failed ❌",
+		Content: "Unfortunately, your payment could not be processed.\n\nReason: {{.reason}}\n\nPlease try again or contact support.",
+		Sample: map[string]interface{}{
+			"payment_id": "payment-sample-1",
+			"order_id":   "order-sample-1",
+			"amount":     1299.5,
+			"currency":   "USD",
+			"reason":     "card declined",
+			"error_code": "CARD_DECLINED",
+		},
+	},
+	"assembly.started": {
+		Type:    domain.NotificationTypeAssemblyStarted,
+		Subject: "Rocket Assembly Started! 🔧",
+		Content: "Great news! We've started assembling your rocket.\n\nEstimated completion time: {{.estimated_duration_seconds}} seconds",
+		Sample: map[string]interface{}{
+			"assembly_id":                "assembly-sample-1",
+			"order_id":                   "order-sample-1",
+			"estimated_duration_seconds": 30,
+		},
+	},
+	"assembly.completed": {
+		Type:    domain.NotificationTypeAssemblyCompleted,
+		Subject: "Rocket Assembly Complete! 🚀",
+		Content: "Congratulations! Your rocket has been successfully assembled.\n\nAssembly took {{.actual_duration_seconds}} seconds with {{.quality}} quality.",
+		Sample: map[string]interface{}{
+			"assembly_id":             "assembly-sample-1",
+			"order_id":                "order-sample-1",
+			"actual_duration_seconds": 32,
+			"quality":                 "high",
+		},
+	},
+	"assembly.failed": {
+		Type:    domain.NotificationTypeAssemblyFailed,
+		Subject: "Assembly Failed ⚠️",
+		Content: "Unfortunately, there was an issue with your rocket assembly.\n\nReason: {{.reason}}\n\nOur team is working to resolve this issue.",
+		Sample: map[string]interface{}{
+			"assembly_id": "assembly-sample-1",
+			"order_id":    "order-sample-1",
+			"reason":      "quality_check_failed",
+			"error_code":  "ASM_002",
+		},
+	},
+	"user.deletion_requested": {
+		Type:    domain.NotificationTypeAccountDeletion,
+		Subject: "Account Deletion Scheduled 🗑️",
+		Content: "Your account is scheduled for deletion on {{.deletion_scheduled_at}}.\n\nChanged your mind? Log in before then and cancel the deletion to keep your account.",
+		Sample: map[string]interface{}{
+			"user_id":               "user-sample-1",
+			"deletion_scheduled_at": "2025-01-15T12:00:00Z",
+			"reason":                "no longer needed",
+		},
+	},
+	"user.deletion_cancelled": {
+		Type:    domain.NotificationTypeAccountDeletion,
+		Subject: "Account Deletion Cancelled ✅",
+		Content: "The deletion of your account has been cancelled.\n\nWelcome back!",
+		Sample: map[string]interface{}{
+			"user_id": "user-sample-1",
+		},
+	},
+	"user.login_link_requested": {
+		Type:    domain.NotificationTypeLoginLink,
+		Subject: "Your Login Link 🔑",
+		Content: "Tap to log in to Rocket Science:\n{{.login_url}}\n\nThe link works once, only in the browser you asked from, until {{.expires_at}}.\n\nDidn't ask for it? Ignore this message, nobody can log in without the link.",
+		Sample: map[string]interface{}{
+			"user_id":    "user-sample-1",
+			"login_url":  "https://rocket-science.example/login/link?token=sample-token",
+			"expires_at": "2025-01-15T12:15:00Z",
+		},
+	},
+	"iam.failed-login-burst": {
+		Type:    domain.NotificationTypeFailedLoginAlert,
+		Subject: "Failed Login Attempts ⚠️",
+		Content: "{{.attempts}} failed attempts to log in to your account in the last {{.window_minutes}} minutes.\n\nLatest attempt at {{.occurred_at}}:\n📱 {{.device}}\n📍 {{.location}} ({{.ip_address}})\n\nNot you? Lock your account and sign out everywhere:\n{{.lock_url}}\n\nThe link works once, until {{.lock_expires_at}}. If it was you, reset your password if you forgot it.",
+		Sample: map[string]interface{}{
+			"user_id":         "user-sample-1",
+			"attempts":        5,
+			"window_minutes":  15,
+			"ip_address":      "203.0.113.7",
+			"device":          "Chrome on Windows (desktop)",
+			"location":        "Berlin, Germany",
+			"occurred_at":     "2025-01-15T12:00:00Z",
+			"lock_url":        "https://rocket-science.example/account/lock?token=sample-token",
+			"lock_expires_at": "2025-01-16T12:00:00Z",
+		},
+	},
+}
+
+// LookupMessageTemplate returns the template of an event type
+func LookupMessageTemplate(eventType string) (MessageTemplate, bool) {
+	tmpl, ok := messageTemplates[eventType]
+	tmpl.EventType = eventType
+	return tmpl, ok
+}
+
+// MessageTemplates returns every template, sorted by event type
+func MessageTemplates() []MessageTemplate {
+	templates := make([]MessageTemplate, 0, len(messageTemplates))
+	for eventType := range messageTemplates {
+		tmpl, _ := LookupMessageTemplate(eventType)
+		templates = append(templates, tmpl)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].EventType < templates[j].EventType })
+	return templates
+}
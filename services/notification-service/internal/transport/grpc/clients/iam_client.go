@@ -9,8 +9,10 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
-	iampb "github.com/amiosamu/rocket-science/services/iam-service/proto/iam"
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/config"
+	iampb "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcclient"
+	"github.com/amiosamu/rocket-science/shared/platform/iamcache"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
 )
@@ -22,12 +24,17 @@ type IAMClient struct {
 	metrics metrics.Metrics
 	conn    *grpc.ClientConn
 	client  iampb.IAMServiceClient
+	cache   *iamcache.Cache // Nil when caching is disabled
 }
 
+// cacheKindTelegramChatID is the cache kind of chat ID lookups
+const cacheKindTelegramChatID = "telegram_chat_id"
+
 // NewIAMClient creates a new IAM client
 func NewIAMClient(cfg config.IAMClientConfig, logger logging.Logger, metrics metrics.Metrics) (*IAMClient, error) {
 	var opts []grpc.DialOption
 	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	opts = append(opts, grpc.WithUnaryInterceptor(grpcclient.UnaryClientInterceptor(grpcclient.DefaultConfig("iam"), metrics)))
 
 	address := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 	conn, err := grpc.Dial(address, opts...)
@@ -42,32 +49,42 @@ func NewIAMClient(cfg config.IAMClientConfig, logger logging.Logger, metrics met
 		"port": cfg.Port,
 	})
 
-	return &IAMClient{
+	iamClient := &IAMClient{
 		config:  cfg,
 		logger:  logger,
 		metrics: metrics,
 		conn:    conn,
 		client:  client,
-	}, nil
+	}
+	if cfg.CacheEnabled {
+		iamClient.cache = iamcache.New(iamcache.Config{TTL: cfg.CacheTTL, MaxEntries: cfg.CacheMaxEntries}, metrics)
+	}
+	return iamClient, nil
 }
 
-// GetUserTelegramChatID retrieves the Telegram chat ID for a user
-func (c *IAMClient) GetUserTelegramChatID(ctx context.Context, userID string) (int64, error) {
-	startTime := time.Now()
-	defer func() {
-		c.metrics.RecordDuration("iam_get_chat_id_duration", time.Since(startTime), nil)
-	}()
+// Cache returns the cache of IAM lookups, or nil when caching is disabled
+func (c *IAMClient) Cache() *iamcache.Cache {
+	return c.cache
+}
 
-	req := &iampb.GetUserTelegramChatIDRequest{
-		UserId: userID,
-	}
+// GetUserTelegramChatID retrieves the Telegram chat ID for a user, from the
+// cache when it holds one
+func (c *IAMClient) GetUserTelegramChatID(ctx context.Context, userID string) (int64, error) {
+	resp, err := iamcache.Lookup(ctx, c.cache, cacheKindTelegramChatID, userID, func(ctx context.Context) (*iampb.GetUserTelegramChatIDResponse, error) {
+		startTime := time.Now()
+		defer func() {
+			c.metrics.RecordDuration(ctx, "iam_get_chat_id_duration", time.Since(startTime), nil)
+		}()
 
-	resp, err := c.client.GetUserTelegramChatID(ctx, req)
+		return c.client.GetUserTelegramChatID(ctx, &iampb.GetUserTelegramChatIDRequest{
+			UserId: userID,
+		})
+	})
 	if err != nil {
 		c.logger.Error(ctx, "Failed to get user Telegram chat ID", err, map[string]interface{}{
 			"user_id": userID,
 		})
-		c.metrics.IncrementCounter("iam_get_chat_id_error", nil)
+		c.metrics.IncrementCounter(ctx, "iam_get_chat_id_error", nil)
 		return 0, fmt.Errorf("failed to get user Telegram chat ID: %w", err)
 	}
 
@@ -78,7 +95,7 @@ func (c *IAMClient) GetUserTelegramChatID(ctx context.Context, userID string) (i
 		return 0, fmt.Errorf("user Telegram chat ID not found")
 	}
 
-	c.metrics.IncrementCounter("iam_get_chat_id_success", nil)
+	c.metrics.IncrementCounter(ctx, "iam_get_chat_id_success", nil)
 
 	chatID, err := strconv.ParseInt(resp.ChatId, 10, 64)
 	if err != nil {
@@ -94,7 +111,7 @@ func (c *IAMClient) UpdateUserTelegramChatID(ctx context.Context, userID string,
 
 	// Record metrics
 	defer func() {
-		c.metrics.RecordDuration("iam_update_chat_id_duration", time.Since(startTime), nil)
+		c.metrics.RecordDuration(ctx, "iam_update_chat_id_duration", time.Since(startTime), nil)
 	}()
 
 	c.logger.Info(ctx, "Updating user Telegram chat ID", map[string]interface{}{
@@ -115,7 +132,7 @@ func (c *IAMClient) UpdateUserTelegramChatID(ctx context.Context, userID string,
 				"user_id": userID,
 				"chat_id": chatID,
 			})
-			c.metrics.IncrementCounter("iam_update_chat_id_error", nil)
+			c.metrics.IncrementCounter(ctx, "iam_update_chat_id_error", nil)
 			return fmt.Errorf("failed to update user Telegram chat ID: %w", err)
 		}
 
@@ -124,7 +141,7 @@ func (c *IAMClient) UpdateUserTelegramChatID(ctx context.Context, userID string,
 				"user_id": userID,
 				"chat_id": chatID,
 			})
-			c.metrics.IncrementCounter("iam_update_chat_id_failed", nil)
+			c.metrics.IncrementCounter(ctx, "iam_update_chat_id_failed", nil)
 			return fmt.Errorf("IAM service failed to update chat ID")
 		}
 
@@ -132,7 +149,7 @@ func (c *IAMClient) UpdateUserTelegramChatID(ctx context.Context, userID string,
 			"user_id": userID,
 			"chat_id": chatID,
 		})
-		c.metrics.IncrementCounter("iam_update_chat_id_success", nil)
+		c.metrics.IncrementCounter(ctx, "iam_update_chat_id_success", nil)
 
 		return nil
 	*/
@@ -142,7 +159,7 @@ func (c *IAMClient) UpdateUserTelegramChatID(ctx context.Context, userID string,
 		"user_id": userID,
 		"chat_id": chatID,
 	})
-	c.metrics.IncrementCounter("iam_update_chat_id_mock", nil)
+	c.metrics.IncrementCounter(ctx, "iam_update_chat_id_mock", nil)
 
 	return nil
 }
@@ -159,7 +176,7 @@ func (c *IAMClient) HealthCheck(ctx context.Context) error {
 		return fmt.Errorf("IAM service connection not ready: %s", state.String())
 	}
 
-	c.metrics.IncrementCounter("iam_health_check_success", nil)
+	c.metrics.IncrementCounter(ctx, "iam_health_check_success", nil)
 	return nil
 }
 
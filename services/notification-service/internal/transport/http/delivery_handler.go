@@ -0,0 +1,153 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/service"
+)
+
+const (
+	defaultDeliveryPageSize = 50
+	maxDeliveryPageSize     = 500
+
+	// deliveryStatusUnacknowledged lists delivered alerts still awaiting acknowledgement
+	deliveryStatusUnacknowledged = "unacknowledged"
+)
+
+// acknowledgeRequest is the body of POST /deliveries/{id}/acknowledge
+type acknowledgeRequest struct {
+	AcknowledgedBy string `json:"acknowledged_by"`
+}
+
+// handleListDeliveries lists recent notification deliveries:
+//
+//	GET /deliveries?status={delivered|failed|acknowledged|unacknowledged}&user_id={id}&type={type}&older_than={duration}&limit={n}
+//
+// older_than only applies to unacknowledged deliveries and selects alerts
+// that have waited at least that long, oldest first.
+func (h *HealthServer) handleListDeliveries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	query := r.URL.Query()
+	limit := defaultDeliveryPageSize
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxDeliveryPageSize {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+			return
+		}
+		limit = parsed
+	}
+
+	var deliveries []*domain.Delivery
+	var err error
+	status := query.Get("status")
+	switch status {
+	case deliveryStatusUnacknowledged:
+		var olderThan time.Duration
+		if value := query.Get("older_than"); value != "" {
+			olderThan, err = time.ParseDuration(value)
+			if err != nil || olderThan < 0 {
+				h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid older_than duration"})
+				return
+			}
+		}
+		deliveries, err = h.deliveryTracker.Store().ListAwaitingAck(r.Context(), time.Now().Add(-olderThan), limit)
+	case "", string(domain.DeliveryStatusDelivered), string(domain.DeliveryStatusFailed), string(domain.DeliveryStatusAcknowledged):
+		deliveries, err = h.deliveryTracker.Store().List(r.Context(), service.DeliveryFilter{
+			Status: domain.DeliveryStatus(status),
+			UserID: query.Get("user_id"),
+			Type:   domain.NotificationType(query.Get("type")),
+			Limit:  limit,
+		})
+	default:
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		return
+	}
+	if err != nil {
+		h.logger.Error(r.Context(), "Failed to list deliveries", err, nil)
+		h.writeJSONResponse(w, http.StatusInternalServerError, map[string]string{"error": "failed to list deliveries"})
+		return
+	}
+
+	if deliveries == nil {
+		deliveries = []*domain.Delivery{}
+	}
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"deliveries": deliveries,
+		"count":      len(deliveries),
+	})
+}
+
+// handleDelivery serves a single delivery:
+//
+//	GET  /deliveries/{notification_id}
+//	POST /deliveries/{notification_id}/acknowledge
+func (h *HealthServer) handleDelivery(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/deliveries/"), "/")
+	notificationID, action, _ := strings.Cut(path, "/")
+	if notificationID == "" {
+		h.handleListDeliveries(w, r)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		delivery, err := h.deliveryTracker.Store().Get(r.Context(), notificationID)
+		if err != nil {
+			h.writeDeliveryError(w, r, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, delivery)
+
+	case action == "acknowledge" && r.Method == http.MethodPost:
+		var req acknowledgeRequest
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+				return
+			}
+		}
+		if req.AcknowledgedBy == "" {
+			req.AcknowledgedBy = "api"
+		}
+
+		delivery, err := h.deliveryTracker.Acknowledge(r.Context(), service.Acknowledgement{
+			NotificationID: notificationID,
+			By:             req.AcknowledgedBy,
+		})
+		if err != nil {
+			h.writeDeliveryError(w, r, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, delivery)
+
+	case action == "" || action == "acknowledge":
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+
+	default:
+		h.writeJSONResponse(w, http.StatusNotFound, map[string]string{"error": "not found"})
+	}
+}
+
+// writeDeliveryError maps delivery tracking errors to HTTP responses
+func (h *HealthServer) writeDeliveryError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrDeliveryNotFound):
+		h.writeJSONResponse(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, domain.ErrAckNotRequired), errors.Is(err, domain.ErrDeliveryNotDelivered):
+		h.writeJSONResponse(w, http.StatusConflict, map[string]string{"error": err.Error()})
+	default:
+		h.logger.Error(r.Context(), "Delivery request failed", err, nil)
+		h.writeJSONResponse(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+}
@@ -0,0 +1,281 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/service"
+)
+
+// scheduleRequest is the body of PUT /oncall/schedules/{name}. Durations use
+// Go syntax, e.g. "168h" or "15m".
+type scheduleRequest struct {
+	Responders      []domain.Responder        `json:"responders"`
+	StartsAt        *time.Time                `json:"starts_at"`
+	HandoffInterval string                    `json:"handoff_interval"`
+	AckTimeout      string                    `json:"ack_timeout"`
+	AlertTypes      []domain.NotificationType `json:"alert_types"`
+}
+
+// scheduleResponse presents a schedule together with who is on call now
+type scheduleResponse struct {
+	Name            string                    `json:"name"`
+	Responders      []domain.Responder        `json:"responders"`
+	StartsAt        time.Time                 `json:"starts_at"`
+	HandoffInterval string                    `json:"handoff_interval"`
+	AckTimeout      string                    `json:"ack_timeout"`
+	AlertTypes      []domain.NotificationType `json:"alert_types"`
+	OnCall          domain.Responder          `json:"on_call"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
+}
+
+// alertRequest is the body of POST /alerts
+type alertRequest struct {
+	Type       domain.NotificationType `json:"type"`
+	Subject    string                  `json:"subject"`
+	Content    string                  `json:"content"`
+	References map[string]string       `json:"references"`
+	Schedule   string                  `json:"schedule"`
+}
+
+func newScheduleResponse(schedule *domain.OnCallSchedule) scheduleResponse {
+	return scheduleResponse{
+		Name:            schedule.Name,
+		Responders:      schedule.Responders,
+		StartsAt:        schedule.StartsAt,
+		HandoffInterval: schedule.HandoffInterval.String(),
+		AckTimeout:      schedule.AckTimeout.String(),
+		AlertTypes:      schedule.AlertTypes,
+		OnCall:          schedule.Responders[schedule.OnCallIndex(time.Now())],
+		UpdatedAt:       schedule.UpdatedAt,
+	}
+}
+
+// handleListSchedules lists on-call schedules:
+//
+//	GET /oncall/schedules
+func (h *HealthServer) handleListSchedules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	schedules, err := h.escalationEngine.Store().ListSchedules(r.Context())
+	if err != nil {
+		h.writeEscalationError(w, r, err)
+		return
+	}
+
+	responses := make([]scheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		responses = append(responses, newScheduleResponse(schedule))
+	}
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"schedules": responses,
+		"count":     len(responses),
+	})
+}
+
+// handleSchedule manages a single on-call schedule:
+//
+//	GET    /oncall/schedules/{name}
+//	PUT    /oncall/schedules/{name}
+//	DELETE /oncall/schedules/{name}
+func (h *HealthServer) handleSchedule(w http.ResponseWriter, r *http.Request) {
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/oncall/schedules/"), "/")
+	if name == "" {
+		h.handleListSchedules(w, r)
+		return
+	}
+
+	store := h.escalationEngine.Store()
+	switch r.Method {
+	case http.MethodGet:
+		schedule, err := store.GetSchedule(r.Context(), name)
+		if err != nil {
+			h.writeEscalationError(w, r, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, newScheduleResponse(schedule))
+
+	case http.MethodPut:
+		var req scheduleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+			return
+		}
+
+		schedule := &domain.OnCallSchedule{
+			Name:       name,
+			Responders: req.Responders,
+			StartsAt:   time.Now().UTC().Truncate(time.Minute),
+			AckTimeout: h.escalationEngine.DefaultAckTimeout(),
+			AlertTypes: req.AlertTypes,
+			UpdatedAt:  time.Now(),
+		}
+		if req.StartsAt != nil {
+			schedule.StartsAt = *req.StartsAt
+		}
+		if len(schedule.AlertTypes) == 0 {
+			schedule.AlertTypes = domain.OperationalAlertTypes
+		}
+		var err error
+		if schedule.HandoffInterval, err = time.ParseDuration(req.HandoffInterval); err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid handoff_interval duration"})
+			return
+		}
+		if req.AckTimeout != "" {
+			if schedule.AckTimeout, err = time.ParseDuration(req.AckTimeout); err != nil {
+				h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid ack_timeout duration"})
+				return
+			}
+		}
+		if err := schedule.Validate(); err != nil {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		if err := store.SaveSchedule(r.Context(), schedule); err != nil {
+			h.writeEscalationError(w, r, err)
+			return
+		}
+		h.logger.Info(r.Context(), "On-call schedule updated", map[string]interface{}{
+			"schedule":    schedule.Name,
+			"responders":  len(schedule.Responders),
+			"ack_timeout": schedule.AckTimeout.String(),
+		})
+		h.writeJSONResponse(w, http.StatusOK, newScheduleResponse(schedule))
+
+	case http.MethodDelete:
+		if err := store.DeleteSchedule(r.Context(), name); err != nil {
+			h.writeEscalationError(w, r, err)
+			return
+		}
+		h.logger.Info(r.Context(), "On-call schedule deleted", map[string]interface{}{
+			"schedule": name,
+		})
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// handleRaiseAlert routes an operational alert to on-call:
+//
+//	POST /alerts
+func (h *HealthServer) handleRaiseAlert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	var req alertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		return
+	}
+	if !domain.IsOperationalAlert(req.Type) {
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "type must be low_stock or sla_breach"})
+		return
+	}
+	if req.Subject == "" {
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "subject is required"})
+		return
+	}
+
+	escalation, err := h.escalationEngine.RaiseAlert(r.Context(), service.AlertRequest{
+		Type:       req.Type,
+		Subject:    req.Subject,
+		Content:    req.Content,
+		References: req.References,
+		Schedule:   req.Schedule,
+	})
+	if err != nil {
+		h.writeEscalationError(w, r, err)
+		return
+	}
+	h.writeJSONResponse(w, http.StatusCreated, escalation)
+}
+
+// handleListEscalations lists the escalation audit trail:
+//
+//	GET /escalations?status={open|acknowledged|exhausted}&limit={n}
+func (h *HealthServer) handleListEscalations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	query := r.URL.Query()
+	limit := defaultDeliveryPageSize
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxDeliveryPageSize {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+			return
+		}
+		limit = parsed
+	}
+
+	status := domain.EscalationStatus(query.Get("status"))
+	switch status {
+	case "", domain.EscalationStatusOpen, domain.EscalationStatusAcknowledged, domain.EscalationStatusExhausted:
+	default:
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		return
+	}
+
+	escalations, err := h.escalationEngine.Store().ListEscalations(r.Context(), status, limit)
+	if err != nil {
+		h.writeEscalationError(w, r, err)
+		return
+	}
+	if escalations == nil {
+		escalations = []*domain.Escalation{}
+	}
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"escalations": escalations,
+		"count":       len(escalations),
+	})
+}
+
+// handleEscalation returns the escalation chain of a single alert:
+//
+//	GET /escalations/{alert_id}
+func (h *HealthServer) handleEscalation(w http.ResponseWriter, r *http.Request) {
+	alertID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/escalations/"), "/")
+	if alertID == "" {
+		h.handleListEscalations(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	escalation, err := h.escalationEngine.Store().GetEscalation(r.Context(), alertID)
+	if err != nil {
+		h.writeEscalationError(w, r, err)
+		return
+	}
+	h.writeJSONResponse(w, http.StatusOK, escalation)
+}
+
+// writeEscalationError maps escalation errors to HTTP responses
+func (h *HealthServer) writeEscalationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrScheduleNotFound), errors.Is(err, domain.ErrEscalationNotFound):
+		h.writeJSONResponse(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, domain.ErrNoScheduleForAlert):
+		h.writeJSONResponse(w, http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	default:
+		h.logger.Error(r.Context(), "Escalation request failed", err, nil)
+		h.writeJSONResponse(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+}
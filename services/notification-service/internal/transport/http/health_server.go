@@ -9,21 +9,30 @@ import (
 
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/service"
 	"github.com/amiosamu/rocket-science/services/notification-service/internal/transport/grpc/clients"
+	"github.com/amiosamu/rocket-science/shared/platform/adminhttp"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
 	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+	"github.com/amiosamu/rocket-science/shared/platform/purge"
+	"github.com/amiosamu/rocket-science/shared/platform/version"
 )
 
 // HealthServer provides HTTP health check endpoints for monitoring and orchestration
 type HealthServer struct {
-	telegramService service.TelegramServiceInterface
-	iamClient       *clients.IAMClient
-	kafkaConsumer   *kafka.Consumer
-	logger          logging.Logger
-	metrics         metrics.Metrics
-	startTime       time.Time
-	port            string
-	server          *http.Server
+	telegramService  service.TelegramServiceInterface
+	iamClient        *clients.IAMClient
+	kafkaConsumer    *kafka.Consumer
+	deliveryTracker  *service.DeliveryTracker
+	escalationEngine *service.EscalationEngine
+	templates        *service.TemplatePreviewer
+	maintenance      *maintenance.Mode
+	offsetSnapshots  http.Handler // Kafka offset snapshot audit; nil when snapshots are disabled
+	logger           logging.Logger
+	metrics          metrics.Metrics
+	startTime        time.Time
+	port             string
+	server           *http.Server
 }
 
 // NewHealthServer creates a new health server
@@ -31,18 +40,28 @@ func NewHealthServer(
 	telegramService service.TelegramServiceInterface,
 	iamClient *clients.IAMClient,
 	kafkaConsumer *kafka.Consumer,
+	deliveryTracker *service.DeliveryTracker,
+	escalationEngine *service.EscalationEngine,
+	templatePreviewer *service.TemplatePreviewer,
+	maintenanceMode *maintenance.Mode,
+	offsetSnapshots http.Handler,
 	logger logging.Logger,
 	metrics metrics.Metrics,
 	port string,
 ) *HealthServer {
 	return &HealthServer{
-		telegramService: telegramService,
-		iamClient:       iamClient,
-		kafkaConsumer:   kafkaConsumer,
-		logger:          logger,
-		metrics:         metrics,
-		startTime:       time.Now(),
-		port:            port,
+		telegramService:  telegramService,
+		iamClient:        iamClient,
+		kafkaConsumer:    kafkaConsumer,
+		deliveryTracker:  deliveryTracker,
+		escalationEngine: escalationEngine,
+		templates:        templatePreviewer,
+		maintenance:      maintenanceMode,
+		offsetSnapshots:  offsetSnapshots,
+		logger:           logger,
+		metrics:          metrics,
+		startTime:        time.Now(),
+		port:             port,
 	}
 }
 
@@ -53,6 +72,9 @@ const (
 	HealthStatusHealthy   HealthStatus = "healthy"
 	HealthStatusDegraded  HealthStatus = "degraded"
 	HealthStatusUnhealthy HealthStatus = "unhealthy"
+
+	// HealthStatusMaintenance is reported by readiness while the service drains
+	HealthStatusMaintenance HealthStatus = "maintenance"
 )
 
 // ComponentHealth represents the health of a single component
@@ -103,6 +125,11 @@ type NotificationStatsResponse struct {
 
 // Start starts the HTTP health server
 func (h *HealthServer) Start(ctx context.Context) error {
+	purgeGate, err := purge.GateFromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid test data purge configuration: %w", err)
+	}
+
 	mux := http.NewServeMux()
 
 	// Register health check endpoints
@@ -110,7 +137,31 @@ func (h *HealthServer) Start(ctx context.Context) error {
 	mux.HandleFunc("/ready", h.handleReadinessCheck)
 	mux.HandleFunc("/live", h.handleLivenessCheck)
 	mux.HandleFunc("/metrics", h.handleMetrics)
+	mux.Handle("/metrics/prometheus", metrics.Handler(h.metrics)) // With trace exemplars on latency histograms
 	mux.HandleFunc("/stats", h.handleNotificationStats)
+	mux.Handle("/version", version.Handler("notification-service"))
+	mux.HandleFunc("/admin/maintenance", h.handleMaintenance)
+	mux.Handle(purge.Path, purge.Handler(purgeGate, purge.ParticipantFunc(h.purgeTestData)))
+
+	// Notification delivery API
+	mux.HandleFunc("/deliveries", h.handleListDeliveries)
+	mux.HandleFunc("/deliveries/", h.handleDelivery)
+
+	// Operational alerts, on-call schedules and the escalation audit trail
+	mux.HandleFunc("/alerts", h.handleRaiseAlert)
+	mux.HandleFunc("/oncall/schedules", h.handleListSchedules)
+	mux.HandleFunc("/oncall/schedules/", h.handleSchedule)
+	mux.HandleFunc("/escalations", h.handleListEscalations)
+	mux.HandleFunc("/escalations/", h.handleEscalation)
+
+	// Message template previews and test sends
+	mux.HandleFunc("/admin/templates", h.handleListTemplates)
+	mux.HandleFunc("/admin/templates/preview", h.handlePreviewTemplate)
+
+	// Committed offsets of the consumer group at a point in time
+	if h.offsetSnapshots != nil {
+		mux.Handle("/admin/kafka/offset-snapshots", h.offsetSnapshots)
+	}
 
 	h.server = &http.Server{
 		Addr:         ":" + h.port,
@@ -120,13 +171,27 @@ func (h *HealthServer) Start(ctx context.Context) error {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	security, err := adminhttp.FromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid health server security configuration: %w", err)
+	}
+	listener, err := security.Listen(h.server)
+	if err != nil {
+		return err
+	}
+	if !security.AuthEnabled() {
+		h.logger.Warn(nil, "HTTP health server admin routes are unauthenticated; set HEALTH_ADMIN_TOKEN or HEALTH_TLS_CLIENT_CA_FILE")
+	}
+
 	h.logger.Info(nil, "Starting HTTP health server", map[string]interface{}{
-		"port": h.port,
+		"address":    h.server.Addr,
+		"tls":        security.TLSEnabled(),
+		"admin_auth": security.AuthEnabled(),
 	})
 
 	// Start server in a goroutine
 	go func() {
-		if err := h.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := h.server.Serve(listener); err != nil && err != http.ErrServerClosed {
 			h.logger.Error(nil, "HTTP health server failed", err, nil)
 		}
 	}()
@@ -190,7 +255,7 @@ func (h *HealthServer) handleHealthCheck(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Update metrics
-	h.updateHealthMetrics(overallStatus, components)
+	h.updateHealthMetrics(r.Context(), overallStatus, components)
 
 	// Log health check
 	h.logger.Debug(nil, "Health check completed", map[string]interface{}{
@@ -206,10 +271,24 @@ func (h *HealthServer) handleHealthCheck(w http.ResponseWriter, r *http.Request)
 func (h *HealthServer) handleReadinessCheck(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// Check critical components only for readiness
+	// Take the instance out of rotation while in maintenance mode
+	if h.maintenance.Enabled() {
+		response := SimpleHealthResponse{
+			Status:    HealthStatusMaintenance,
+			Service:   "notification-service",
+			Timestamp: time.Now().UTC(),
+			Version:   "1.0.0",
+		}
+		h.writeJSONResponse(w, http.StatusServiceUnavailable, response)
+		return
+	}
+
+	// Check critical components only for readiness; an instance that cannot
+	// reach the Bot API would only pile up failed notifications
 	kafkaHealth := h.checkKafkaConsumer(ctx)
+	telegramHealth := h.checkTelegramService(ctx)
 
-	if kafkaHealth.Status == HealthStatusUnhealthy {
+	if kafkaHealth.Status == HealthStatusUnhealthy || telegramHealth.Status == HealthStatusUnhealthy {
 		response := SimpleHealthResponse{
 			Status:    HealthStatusUnhealthy,
 			Service:   "notification-service",
@@ -229,6 +308,42 @@ func (h *HealthServer) handleReadinessCheck(w http.ResponseWriter, r *http.Reque
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// handleMaintenance reports and toggles maintenance mode
+func (h *HealthServer) handleMaintenance(w http.ResponseWriter, r *http.Request) {
+	if h.maintenance == nil {
+		h.writeJSONResponse(w, http.StatusNotImplemented, map[string]string{
+			"error": "maintenance mode not configured",
+		})
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		h.logger.Info(r.Context(), "Maintenance mode change requested", map[string]interface{}{
+			"method":      r.Method,
+			"remote_addr": r.RemoteAddr,
+		})
+	}
+
+	h.maintenance.Handler().ServeHTTP(w, r)
+}
+
+// purgeTestData deletes the deliveries of the users of a test data purge
+func (h *HealthServer) purgeTestData(ctx context.Context, scope purge.Scope) (*purge.Result, error) {
+	if h.deliveryTracker == nil {
+		return nil, fmt.Errorf("delivery tracking not configured")
+	}
+
+	purged, err := h.deliveryTracker.PurgeUsers(ctx, scope.UserIDs, scope.DryRun)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to purge notification test data", err, nil)
+		return nil, err
+	}
+
+	result := purge.NewResult("notification-service", scope)
+	result.Deleted["deliveries"] = int64(purged)
+	return result, nil
+}
+
 // HandleLivenessCheck provides a basic liveness check
 func (h *HealthServer) handleLivenessCheck(w http.ResponseWriter, r *http.Request) {
 	response := SimpleHealthResponse{
@@ -281,6 +396,9 @@ func (h *HealthServer) handleNotificationStats(w http.ResponseWriter, r *http.Re
 	processingMetrics := map[string]interface{}{
 		"note": "Detailed processing metrics available via metrics endpoint",
 	}
+	if h.telegramService != nil {
+		processingMetrics["telegram"] = h.telegramService.Status()
+	}
 
 	response := NotificationStatsResponse{
 		Service:           "notification-service",
@@ -346,10 +464,33 @@ func (h *HealthServer) checkTelegramService(ctx context.Context) ComponentHealth
 		}
 	}
 
+	status := h.telegramService.Status()
 	details := map[string]interface{}{
-		"bot_id":       botInfo.ID,
-		"bot_username": botInfo.UserName,
-		"is_bot":       botInfo.IsBot,
+		"bot_id":          botInfo.ID,
+		"bot_username":    botInfo.UserName,
+		"is_bot":          botInfo.IsBot,
+		"queue_depth":     status.QueueDepth,
+		"unreachable_for": status.UnreachableFor.String(),
+		"last_contact":    status.LastContact,
+	}
+
+	if !status.Reachable {
+		return ComponentHealth{
+			Status:    HealthStatusUnhealthy,
+			Message:   fmt.Sprintf("Telegram Bot API unreachable for %s", status.UnreachableFor.Round(time.Second)),
+			Details:   details,
+			CheckedAt: time.Now().UTC(),
+			Duration:  time.Since(start).String(),
+		}
+	}
+	if status.UnreachableFor > 0 {
+		return ComponentHealth{
+			Status:    HealthStatusDegraded,
+			Message:   fmt.Sprintf("Telegram Bot API not answering for %s", status.UnreachableFor.Round(time.Second)),
+			Details:   details,
+			CheckedAt: time.Now().UTC(),
+			Duration:  time.Since(start).String(),
+		}
 	}
 
 	return ComponentHealth{
@@ -433,7 +574,7 @@ func (h *HealthServer) createSummary(components map[string]ComponentHealth) Heal
 	return summary
 }
 
-func (h *HealthServer) updateHealthMetrics(status HealthStatus, components map[string]ComponentHealth) {
+func (h *HealthServer) updateHealthMetrics(ctx context.Context, status HealthStatus, components map[string]ComponentHealth) {
 	// Update overall health metric
 	healthValue := 1.0
 	if status == HealthStatusDegraded {
@@ -442,7 +583,7 @@ func (h *HealthServer) updateHealthMetrics(status HealthStatus, components map[s
 		healthValue = 0.0
 	}
 
-	h.metrics.RecordValue("service_health", healthValue, map[string]string{
+	h.metrics.SetGauge(ctx, "service_health", healthValue, map[string]string{
 		"service": "notification-service",
 		"status":  string(status),
 	})
@@ -456,7 +597,7 @@ func (h *HealthServer) updateHealthMetrics(status HealthStatus, components map[s
 			componentValue = 0.0
 		}
 
-		h.metrics.RecordValue("component_health", componentValue, map[string]string{
+		h.metrics.SetGauge(ctx, "component_health", componentValue, map[string]string{
 			"service":   "notification-service",
 			"component": name,
 			"status":    string(component.Status),
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/amiosamu/rocket-science/services/notification-service/internal/service"
+)
+
+// previewRequest is the body of POST /admin/templates/preview
+type previewRequest struct {
+	EventType string                 `json:"event_type"`
+	Subject   string                 `json:"subject"` // Optional template overrides, to try a change
+	Content   string                 `json:"content"`
+	Data      map[string]interface{} `json:"data"` // Merged over the sample data
+	Send      bool                   `json:"send"` // Also send to the test chat
+	SentBy    string                 `json:"sent_by"`
+}
+
+// handleListTemplates lists the message templates and their sample data:
+//
+//	GET /admin/templates
+func (h *HealthServer) handleListTemplates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	templates := service.MessageTemplates()
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"templates": templates,
+		"count":     len(templates),
+	})
+}
+
+// handlePreviewTemplate renders a template without producing an event:
+//
+//	POST /admin/templates/preview    render; with send, also deliver to the test chat
+func (h *HealthServer) handlePreviewTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeJSONResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	var req previewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		return
+	}
+	if req.EventType == "" {
+		h.writeJSONResponse(w, http.StatusBadRequest, map[string]string{"error": "event_type is required"})
+		return
+	}
+
+	preview, err := h.templates.Preview(r.Context(), service.PreviewRequest{
+		EventType: req.EventType,
+		Subject:   req.Subject,
+		Content:   req.Content,
+		Data:      req.Data,
+		Send:      req.Send,
+		SentBy:    req.SentBy,
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrUnknownTemplate):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrTemplateInvalid):
+			status = http.StatusUnprocessableEntity
+		case errors.Is(err, service.ErrTestSendUnavailable):
+			status = http.StatusConflict
+		default:
+			h.logger.Error(r.Context(), "Template preview failed", err, map[string]interface{}{
+				"event_type": req.EventType,
+			})
+		}
+		h.writeJSONResponse(w, status, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, preview)
+}
@@ -2,26 +2,42 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"flag"
 	"fmt"
 	"log"
+	gohttp "net/http"
 	"os"
-	"os/signal"
-	"sync"
-	"syscall"
 	"time"
 
 	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/messaging/kafka"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/postgres"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/postgres/migrations"
+	redisRepo "github.com/amiosamu/rocket-science/services/order-service/internal/repository/redis"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/transport/grpc/clients"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/transport/http"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/transport/http/handlers"
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	"github.com/amiosamu/rocket-science/shared/platform/authz"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
 	postgresDB "github.com/amiosamu/rocket-science/shared/platform/database/postgres"
+	redisDB "github.com/amiosamu/rocket-science/shared/platform/database/redis"
+	"github.com/amiosamu/rocket-science/shared/platform/jobs"
+	"github.com/amiosamu/rocket-science/shared/platform/lifecycle"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/events"
+	platformKafka "github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/objectstore"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/grpclog"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/tracing"
+	"github.com/amiosamu/rocket-science/shared/platform/purge"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
+	"github.com/amiosamu/rocket-science/shared/platform/retention"
 )
 
 const (
@@ -31,14 +47,34 @@ const (
 
 func main() {
 	// Create root context
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	ctx := context.Background()
+
+	// Layer the environment's config profile between defaults and environment variables
+	flags := platformconfig.RegisterFlags("SERVER_PORT")
+	sloRules := flag.Bool("slo-rules", false, "print the Prometheus rules of the service level objectives and exit")
+	restoreOffsetsAt := flag.String("restore-offsets-at", "", "commit the consumer group offsets snapshotted at the RFC 3339 time and exit; stop all consumers first")
+	flag.Parse()
+	if *sloRules {
+		printSLORules()
+		return
+	}
+	profile, err := flags.Apply()
+	if *flags.Validation.Validate {
+		// Report on the configuration instead of starting the service
+		validateConfiguration(profile, err, *flags.Validation.CheckConnectivity).Exit()
+	}
+	if err != nil {
+		log.Fatalf("Failed to load config profile: %v", err)
+	}
 
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	if err := profile.CheckUnknownKeys(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
 	// Initialize observability
 	logger, err := logging.NewServiceLogger(serviceName, serviceVersion, cfg.Observability.LogLevel)
@@ -54,7 +90,7 @@ func main() {
 
 	// Initialize metrics
 	logger.Info(ctx, "Initializing metrics...")
-	metrics, err := metrics.NewMetrics(serviceName)
+	serviceMetrics, err := metrics.NewMetrics(serviceName)
 	if err != nil {
 		logger.Error(ctx, "Failed to create metrics", err)
 		os.Exit(1)
@@ -74,9 +110,20 @@ func main() {
 		logger.Info(ctx, "Tracing disabled, using no-op tracer")
 		tracer = tracing.NewNoOpTracer()
 	}
-	defer tracer.Close()
 	logger.Info(ctx, "Tracing initialized successfully")
 
+	// Initialize crash reporting for panics recovered from requests and events
+	recoveryConfig, err := recovery.FromEnv()
+	if err != nil {
+		logger.Error(ctx, "Invalid crash reporting configuration", err)
+		os.Exit(1)
+	}
+	crashReporter, err := recovery.New(recoveryConfig, serviceName, grpclog.FromLogger(logger))
+	if err != nil {
+		logger.Error(ctx, "Failed to create crash reporter", err)
+		os.Exit(1)
+	}
+
 	// Initialize database
 	logger.Info(ctx, "Connecting to database...")
 	dbConfig := postgresDB.Config{
@@ -97,7 +144,6 @@ func main() {
 		logger.Error(ctx, "Failed to connect to database", err)
 		os.Exit(1)
 	}
-	defer dbConn.Close()
 	logger.Info(ctx, "Database connection established")
 
 	// Run database migrations
@@ -107,11 +153,32 @@ func main() {
 		logger.Error(ctx, "Failed to run database migrations", err)
 		os.Exit(1)
 	}
-	logger.Info(ctx, "Database migrations completed")
+	schemaVersion, _, err := migrator.Version(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get database schema version", err)
+		os.Exit(1)
+	}
+	logger.Info(ctx, "Database migrations completed", map[string]interface{}{
+		"schema_version": schemaVersion,
+	})
+	if *flags.MigrateOnly {
+		dbConn.Close()
+		return
+	}
+
+	offsetSnapshots := postgres.NewOffsetSnapshotRepository(dbConn.DB)
+	if *restoreOffsetsAt != "" {
+		// Point-in-time recovery of the consumer group instead of starting the service
+		if err := restoreOffsets(ctx, cfg, offsetSnapshots, *restoreOffsetsAt, logger); err != nil {
+			logger.Error(ctx, "Failed to restore kafka consumer offsets", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// Initialize repository
 	logger.Info(ctx, "Initializing repository...")
-	orderRepo := postgres.NewOrderRepository(dbConn.DB)
+	orderRepo := postgres.NewTracedOrderRepository(postgres.NewOrderRepository(dbConn.DB))
 	logger.Info(ctx, "Repository initialized")
 
 	// Initialize external service clients
@@ -122,12 +189,12 @@ func main() {
 		cfg.GRPC.InventoryService.MaxRetries,
 		cfg.GRPC.InventoryService.RetryInterval,
 		logger,
+		serviceMetrics,
 	)
 	if err != nil {
 		logger.Error(ctx, "Failed to create inventory client", err)
 		os.Exit(1)
 	}
-	defer inventoryClient.Close()
 	logger.Info(ctx, "Inventory client initialized")
 
 	paymentClient, err := clients.NewPaymentGRPCClient(
@@ -136,27 +203,42 @@ func main() {
 		cfg.GRPC.PaymentService.MaxRetries,
 		cfg.GRPC.PaymentService.RetryInterval,
 		logger,
+		serviceMetrics,
 	)
 	if err != nil {
 		logger.Error(ctx, "Failed to create payment client", err)
 		os.Exit(1)
 	}
-	defer paymentClient.Close()
 	logger.Info(ctx, "Payment client initialized")
 
+	// Check that the registered topics exist before producing to or consuming from them
+	if err := platformKafka.VerifyTopics(cfg.Kafka.Brokers, cfg.Kafka.Topics, cfg.Kafka.UsedTopics()...); err != nil {
+		if cfg.Kafka.Topics.Strict() {
+			logger.Error(ctx, "Kafka topic check failed", err)
+			os.Exit(1)
+		}
+		logger.Warn(ctx, "Kafka topic check failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
 	// Initialize Kafka producer
 	logger.Info(ctx, "Initializing Kafka producer...")
 	kafkaProducer, err := kafka.NewProducer(
 		cfg.Kafka.Brokers,
-		cfg.Kafka.PaymentEventsTopic,
+		cfg.Kafka.Topics.Name(topics.PaymentEvents),
 		cfg.Kafka.ProducerRetries,
+		cfg.Kafka.ProducerCompression,
+		cfg.Kafka.ProducerMaxBytes,
 		logger,
 	)
 	if err != nil {
 		logger.Error(ctx, "Failed to create Kafka producer", err)
 		os.Exit(1)
 	}
-	defer kafkaProducer.Close()
+	kafkaProducer.SetOrderEventsTopic(cfg.Kafka.Topics.Name(topics.OrderEvents))
+	kafkaProducer.SetSecurityEventsTopic(cfg.Kafka.Topics.Name(topics.SecurityEvents))
+	kafkaProducer.SetEventFormat(cfg.Kafka.EventFormat)
 	logger.Info(ctx, "Kafka producer initialized")
 
 	// Initialize order service
@@ -167,89 +249,504 @@ func main() {
 		MessageProducer: kafkaProducer,
 	}
 
-	orderService := service.NewOrderService(orderRepo, externalServices, logger, metrics)
-	logger.Info(ctx, "Order service initialized")
+	orderService := service.NewOrderService(orderRepo, externalServices, logger, serviceMetrics)
+	if cfg.Cache.Enabled {
+		orderService.SetOrderCache(service.NewOrderCache(cfg.Cache.OrderTTL, cfg.Cache.MaxEntries))
+	}
+	orderService.SetFulfillmentPolicy(domain.FulfillmentPolicy(cfg.Fulfillment.DefaultPolicy))
+	orderService.SetSagaRepository(postgres.NewSagaRepository(dbConn.DB))
+	orderService.SetSandbox(cfg.Sandbox)
+	logger.Info(ctx, "Order service initialized", map[string]interface{}{
+		"order_cache_enabled": cfg.Cache.Enabled,
+		"order_cache_ttl":     cfg.Cache.OrderTTL.String(),
+		"fulfillment_policy":  cfg.Fulfillment.DefaultPolicy,
+	})
+
+	// Address books, from which new orders take their shipping address
+	addressRepo := postgres.NewAddressRepository(dbConn.DB)
+	addressService := service.NewAddressService(addressRepo, orderService, logger)
+
+	// Deliver order status changes to customer webhooks
+	var webhookService *service.WebhookService
+	if cfg.Webhooks.Enabled {
+		webhookRepo := postgres.NewWebhookRepository(dbConn.DB)
+		webhookService = service.NewWebhookService(webhookRepo, orderRepo, cfg.Webhooks, logger, serviceMetrics)
+		webhookService.SubscribeTo(orderService)
+		logger.Info(ctx, "Order webhooks enabled", map[string]interface{}{
+			"dispatch_interval": cfg.Webhooks.DispatchInterval.String(),
+			"max_attempts":      cfg.Webhooks.MaxAttempts,
+		})
+	}
+
+	// Hold high-value orders for operator approval before payment
+	var approvalService *service.ApprovalService
+	if cfg.Approvals.Enabled {
+		approvalRepo := postgres.NewApprovalRepository(dbConn.DB)
+		approvalService = service.NewApprovalService(approvalRepo, orderService, kafkaProducer, cfg.Approvals, logger, serviceMetrics)
+		logger.Info(ctx, "Order approval enabled", map[string]interface{}{
+			"threshold": cfg.Approvals.Threshold,
+			"ttl":       cfg.Approvals.TTL.String(),
+		})
+	}
+
+	// Let customers request refunds of paid orders, reviewed by support
+	var refundService *service.RefundService
+	if cfg.Refunds.Enabled {
+		refundRepo := postgres.NewRefundRepository(dbConn.DB)
+		refundService = service.NewRefundService(refundRepo, orderService, kafkaProducer, cfg.Refunds, logger, serviceMetrics)
+		logger.Info(ctx, "Order refunds enabled", map[string]interface{}{
+			"window": cfg.Refunds.Window.String(),
+		})
+	}
+
+	// Retry payments that failed transiently instead of failing the order
+	var paymentRetryService *service.PaymentRetryService
+	if cfg.PaymentRetry.Enabled {
+		paymentRetryRepo := postgres.NewPaymentRetryRepository(dbConn.DB)
+		paymentRetryService = service.NewPaymentRetryService(paymentRetryRepo, orderService, kafkaProducer, cfg.PaymentRetry, logger, serviceMetrics)
+		logger.Info(ctx, "Payment retry enabled", map[string]interface{}{
+			"max_attempts":    cfg.PaymentRetry.MaxAttempts,
+			"initial_backoff": cfg.PaymentRetry.InitialBackoff.String(),
+			"max_backoff":     cfg.PaymentRetry.MaxBackoff.String(),
+		})
+	}
+
+	// Create orders in bulk for customers placing many at once
+	var batchService *service.OrderBatchService
+	if cfg.Batches.Enabled {
+		batchRepo := postgres.NewOrderBatchRepository(dbConn.DB)
+		batchService = service.NewOrderBatchService(orderService, batchRepo, cfg.Batches, logger, serviceMetrics)
+		logger.Info(ctx, "Order batches enabled", map[string]interface{}{
+			"max_orders": cfg.Batches.MaxOrders,
+			"workers":    cfg.Batches.Workers,
+		})
+	}
+
+	// Export orders as CSV or XLSX for account managers; large exports run on
+	// the job worker and are downloaded through signed URLs
+	var exportService *service.OrderExportService
+	var jobWorker *jobs.Worker
+	if cfg.Exports.Enabled {
+		exportFiles, err := objectstore.NewFileStore(cfg.Exports.StorageDir)
+		if err != nil {
+			logger.Error(ctx, "Failed to create order export storage", err)
+			os.Exit(1)
+		}
+		signingKey := []byte(cfg.Exports.URLSigningKey)
+		if len(signingKey) == 0 {
+			signingKey = make([]byte, 32)
+			if _, err := rand.Read(signingKey); err != nil {
+				logger.Error(ctx, "Failed to generate order export URL signing key", err)
+				os.Exit(1)
+			}
+			logger.Warn(ctx, "Order export URL signing key not configured, download URLs only work on this replica until it restarts")
+		}
+
+		jobStore := jobs.NewPostgresStore(dbConn.DB)
+		jobWorker = jobs.NewWorker(jobStore, jobs.DefaultWorkerConfig(), logger, serviceMetrics)
+		exportService = service.NewOrderExportService(orderRepo, jobStore, exportFiles, objectstore.NewSigner(signingKey), cfg.Exports, logger, serviceMetrics)
+		exportService.Register(jobWorker)
+		logger.Info(ctx, "Order exports enabled", map[string]interface{}{
+			"sync_max_rows": cfg.Exports.SyncMaxRows,
+			"max_rows":      cfg.Exports.MaxRows,
+			"storage_dir":   cfg.Exports.StorageDir,
+		})
+	}
+
+	// Limit order placements per user and client IP, shared by all replicas
+	// through Redis; users who keep hitting the limit are reported to IAM
+	var orderLimiter *service.OrderRateLimiter
+	var rateLimitConn *redisDB.Connection
+	if limits := cfg.RateLimit; limits.Enabled {
+		redisConfig := redisDB.DefaultConfig()
+		redisConfig.Host = limits.Redis.Host
+		redisConfig.Port = limits.Redis.Port
+		redisConfig.Password = limits.Redis.Password
+		redisConfig.DB = limits.Redis.DB
+		rateLimitConn, err = redisDB.NewConnection(redisConfig, logger)
+		if err != nil {
+			logger.Error(ctx, "Failed to connect to rate limit Redis", err)
+			os.Exit(1)
+		}
+
+		rateLimitStore := redisRepo.NewRateLimitStore(rateLimitConn.Client)
+		orderLimiter = service.NewOrderRateLimiter(rateLimitStore, kafkaProducer, limits, logger, serviceMetrics)
+		logger.Info(ctx, "Order rate limiting enabled", map[string]interface{}{
+			"window":          limits.Window.String(),
+			"per_user":        limits.UserLimit,
+			"per_ip":          limits.IPLimit,
+			"abuse_threshold": limits.AbuseThreshold,
+			"abuse_topic":     cfg.Kafka.Topics.Name(topics.SecurityEvents),
+		})
+	}
+
+	// Publish order creations and status changes for notification-service and reporting
+	orderService.SetEventPublisher(kafkaProducer)
+	orderService.SetBackorderNotifier(kafkaProducer)
+
+	// Build the reporting store from the event streams, in a database of its own
+	var reportingService *service.ReportingService
+	var reportingConn *postgresDB.Connection
+	var projectionConsumer *kafka.ProjectionConsumer
+	if cfg.Reporting.Enabled {
+		reportingDB := cfg.Reporting.Database
+		reportingConn, err = postgresDB.NewConnection(postgresDB.Config{
+			Host:            reportingDB.Host,
+			Port:            reportingDB.Port,
+			User:            reportingDB.User,
+			Password:        reportingDB.Password,
+			DBName:          reportingDB.DBName,
+			SSLMode:         reportingDB.SSLMode,
+			MaxOpenConns:    reportingDB.MaxOpenConns,
+			MaxIdleConns:    reportingDB.MaxIdleConns,
+			ConnMaxLifetime: reportingDB.ConnMaxLifetime,
+			ConnectTimeout:  30 * time.Second,
+		}, logger)
+		if err != nil {
+			logger.Error(ctx, "Failed to connect to reporting database", err)
+			os.Exit(1)
+		}
+
+		reportRepo := postgres.NewReportRepository(reportingConn.DB)
+		if err := reportRepo.EnsureSchema(ctx); err != nil {
+			logger.Error(ctx, "Failed to create reporting schema", err)
+			os.Exit(1)
+		}
+		reportingService = service.NewReportingService(reportRepo, logger, serviceMetrics)
+
+		projectionConsumer, err = kafka.NewProjectionConsumer(
+			cfg.Kafka.Brokers,
+			cfg.Reporting.ConsumerGroup,
+			cfg.Kafka.Topics.Names(topics.OrderEvents, topics.PaymentEvents, topics.PaymentReviewEvents, topics.AssemblyEvents),
+			cfg.Kafka.Membership,
+			reportingService,
+			events.NewRegistry(serviceMetrics, events.PaymentEventChain()),
+			logger,
+			crashReporter,
+		)
+		if err != nil {
+			logger.Error(ctx, "Failed to create Kafka projection consumer", err)
+			os.Exit(1)
+		}
+		logger.Info(ctx, "Order reporting enabled", map[string]interface{}{
+			"database":       reportingDB.Host,
+			"db_name":        reportingDB.DBName,
+			"consumer_group": cfg.Reporting.ConsumerGroup,
+		})
+	}
+
+	// Answer long polls of order statuses from the status changes of every replica
+	var statusConsumer *kafka.StatusChangeConsumer
+	if cfg.StatusWatch.Enabled {
+		orderService.SetStatusWatcher(service.NewStatusWatcher(cfg.StatusWatch.MaxWait, cfg.StatusWatch.MaxWatchers))
+		statusConsumer, err = kafka.NewStatusChangeConsumer(
+			cfg.Kafka.Brokers,
+			cfg.Kafka.Topics.Name(topics.OrderEvents),
+			orderService,
+			logger,
+		)
+		if err != nil {
+			logger.Error(ctx, "Failed to create Kafka status change consumer", err)
+			os.Exit(1)
+		}
+		logger.Info(ctx, "Order status long polling enabled", map[string]interface{}{
+			"max_wait":     cfg.StatusWatch.MaxWait.String(),
+			"max_watchers": cfg.StatusWatch.MaxWatchers,
+		})
+	}
+
+	// Initialize maintenance mode switch
+	maintenanceMode := maintenance.FromEnv()
+	orderService.SetMaintenanceMode(maintenanceMode)
+	if maintenanceMode.Enabled() {
+		logger.Warn(ctx, "Starting in maintenance mode", map[string]interface{}{
+			"reason": maintenanceMode.Status().Reason,
+		})
+	}
 
 	// Initialize Kafka consumer for assembly events
 	logger.Info(ctx, "Initializing Kafka consumer...")
 	kafkaConsumer, err := kafka.NewConsumer(
 		cfg.Kafka.Brokers,
 		cfg.Kafka.ConsumerGroup,
-		[]string{cfg.Kafka.AssemblyEventsTopic},
+		cfg.Kafka.Topics.Names(topics.AssemblyEvents, topics.PaymentReviewEvents, topics.InventoryEvents),
+		cfg.Kafka.Membership,
 		orderService,
 		logger,
+		crashReporter,
 	)
 	if err != nil {
 		logger.Error(ctx, "Failed to create Kafka consumer", err)
 		os.Exit(1)
 	}
-	defer kafkaConsumer.Close()
+	maintenanceMode.OnChange(func(enabled bool) {
+		if enabled {
+			kafkaConsumer.Pause()
+		} else {
+			kafkaConsumer.Resume()
+		}
+	})
 	logger.Info(ctx, "Kafka consumer initialized")
 
 	// Initialize HTTP handlers
 	logger.Info(ctx, "Initializing HTTP handlers...")
 	orderHandler := handlers.NewOrderHandler(orderService, logger)
-	logger.Info(ctx, "HTTP handlers initialized")
+	addressHandler := handlers.NewAddressHandler(addressService, logger)
+	var webhookHandler *handlers.WebhookHandler
+	if webhookService != nil {
+		webhookHandler = handlers.NewWebhookHandler(webhookService, logger)
+	}
+	var approvalHandler *handlers.ApprovalHandler
+	if approvalService != nil {
+		approvalHandler = handlers.NewApprovalHandler(approvalService, logger)
+	}
+	var refundHandler *handlers.RefundHandler
+	if refundService != nil {
+		refundHandler = handlers.NewRefundHandler(refundService, logger)
+	}
+	var reportHandler *handlers.ReportHandler
+	if reportingService != nil {
+		reportHandler = handlers.NewReportHandler(reportingService, logger)
+	}
+	var batchHandler *handlers.BatchHandler
+	if batchService != nil {
+		batchHandler = handlers.NewBatchHandler(batchService, logger)
+	}
+	var exportHandler *handlers.ExportHandler
+	if exportService != nil {
+		exportHandler = handlers.NewExportHandler(exportService, logger)
+	}
+	// Test data purges are refused outside the environments the gate allows
+	purgeGate, err := purge.GateFromEnv()
+	if err != nil {
+		logger.Error(ctx, "Invalid test data purge configuration", err)
+		os.Exit(1)
+	}
+	purgeHandler := purge.Handler(purgeGate, service.NewTestDataPurger(
+		postgres.NewPurgeRepository(dbConn.DB), reportingService, logger, serviceMetrics))
+	logger.Info(ctx, "HTTP handlers initialized", map[string]interface{}{
+		"test_data_purge": purgeGate.Check() == nil,
+	})
 
 	// Initialize health server
 	logger.Info(ctx, "Initializing health server...")
-	healthServer := http.NewHealthServer(dbConn.DB, orderService, logger, metrics)
+	healthServer := http.NewHealthServer(dbConn.DB, orderService, maintenanceMode, logger, serviceMetrics)
 	logger.Info(ctx, "Health server initialized")
 
+	// Initialize authorization; endpoints in report mode admit denied callers
+	// and only log and count them
+	authzConfig, err := authz.FromEnv()
+	if err != nil {
+		logger.Error(ctx, "Invalid authorization configuration", err)
+		os.Exit(1)
+	}
+	authorizer := authz.New(authzConfig, logger, serviceMetrics)
+
 	// Initialize HTTP server
 	logger.Info(ctx, "Initializing HTTP server...")
-	httpServer := http.NewServer(cfg.Server, orderHandler, healthServer, logger, metrics)
+	var offsetHandler gohttp.Handler
+	if cfg.Kafka.OffsetSnapshots.Enabled {
+		offsetHandler = platformKafka.OffsetSnapshotHandler(offsetSnapshots, cfg.Kafka.ConsumerGroup)
+	}
+	httpServer := http.NewServer(cfg.Server, orderHandler, addressHandler, webhookHandler, approvalHandler, refundHandler, reportHandler, batchHandler, exportHandler, orderLimiter, purgeHandler, offsetHandler, healthServer, logger, serviceMetrics, crashReporter, authorizer)
 	logger.Info(ctx, "HTTP server initialized")
 
-	// Setup graceful shutdown
-	var wg sync.WaitGroup
-	shutdownCh := make(chan os.Signal, 1)
-	signal.Notify(shutdownCh, os.Interrupt, syscall.SIGTERM)
-
-	// Start Kafka consumer
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		if err := kafkaConsumer.Start(ctx); err != nil {
-			logger.Error(ctx, "Kafka consumer failed", err)
+	// Register components; connections are closed only after the servers
+	// and consumer that use them have stopped
+	closer := func(close func() error) func(context.Context) error {
+		return func(context.Context) error { return close() }
+	}
+	dependencies := []string{"tracer", "database", "inventory-client", "payment-client", "kafka-producer"}
+	if reportingConn != nil {
+		// Report queries are served until the HTTP server has stopped
+		dependencies = append(dependencies, "reporting-database")
+	}
+	if rateLimitConn != nil {
+		dependencies = append(dependencies, "rate-limit-redis")
+	}
+
+	runner := lifecycle.NewRunner(lifecycle.FromPlatformLogger(logger))
+	runner.Add(
+		lifecycle.Component{Name: "metrics", Stop: closer(func() error { return metrics.Close(serviceMetrics) })},
+		lifecycle.Component{Name: "tracer", Stop: closer(tracer.Close)},
+		lifecycle.Component{Name: "database", Stop: closer(dbConn.Close)},
+		lifecycle.Component{Name: "inventory-client", Stop: closer(inventoryClient.Close)},
+		lifecycle.Component{Name: "payment-client", Stop: closer(paymentClient.Close)},
+		lifecycle.Component{Name: "kafka-producer", Stop: closer(kafkaProducer.Close)},
+		lifecycle.Component{
+			Name:      "kafka-consumer",
+			DependsOn: dependencies,
+			Run:       kafkaConsumer.Start,
+			Stop:      closer(kafkaConsumer.Close),
+		},
+		lifecycle.Component{
+			Name:        "http-server",
+			DependsOn:   dependencies,
+			Run:         httpServer.Start,
+			Stop:        httpServer.Stop,
+			StopTimeout: 30 * time.Second,
+		},
+	)
+
+	if rateLimitConn != nil {
+		runner.Add(lifecycle.Component{Name: "rate-limit-redis", Stop: closer(rateLimitConn.Close)})
+	}
+
+	if webhookService != nil {
+		runner.Add(lifecycle.Component{
+			Name:      "webhook-dispatcher",
+			DependsOn: []string{"database"},
+			Run:       webhookService.Run,
+		})
+	}
+
+	if approvalService != nil {
+		runner.Add(lifecycle.Component{
+			Name:      "approval-expiry",
+			DependsOn: []string{"database", "inventory-client"},
+			Run:       approvalService.Run,
+		})
+	}
+
+	if paymentRetryService != nil {
+		runner.Add(lifecycle.Component{
+			Name:      "payment-retry",
+			DependsOn: []string{"database", "inventory-client", "payment-client", "kafka-producer"},
+			Run:       paymentRetryService.Run,
+		})
+	}
+
+	if batchService != nil {
+		// Batches still running are finished before the order saga's clients close
+		runner.Add(lifecycle.Component{
+			Name:        "order-batches",
+			DependsOn:   []string{"database", "inventory-client", "payment-client", "kafka-producer"},
+			Run:         batchService.Run,
+			StopTimeout: 2 * time.Minute,
+		})
+	}
+
+	if jobWorker != nil {
+		runner.Add(lifecycle.Component{
+			Name:      "job-worker",
+			DependsOn: []string{"database"},
+			Run:       jobWorker.Run,
+		})
+	}
+
+	if projectionConsumer != nil {
+		runner.Add(
+			lifecycle.Component{Name: "reporting-database", Stop: closer(reportingConn.Close)},
+			lifecycle.Component{
+				Name:      "projection-consumer",
+				DependsOn: []string{"reporting-database"},
+				Run:       projectionConsumer.Start,
+				Stop:      closer(projectionConsumer.Close),
+			},
+		)
+	}
+
+	if cfg.Kafka.Health.Enabled {
+		kafkaProbe, err := kafka.NewHealthProbe(cfg.Kafka.Brokers, kafkaProducer, cfg.Kafka.Topics.Name(topics.Heartbeats), cfg.Kafka.Health, logger, serviceMetrics)
+		if err != nil {
+			logger.Error(ctx, "Failed to create Kafka health probe", err)
+			os.Exit(1)
 		}
-	}()
-
-	// Start HTTP server
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		if err := httpServer.Start(ctx); err != nil {
-			logger.Error(ctx, "HTTP server failed", err)
+		healthServer.SetKafkaProbe(kafkaProbe)
+		runner.Add(lifecycle.Component{
+			Name:      "kafka-health-probe",
+			DependsOn: []string{"kafka-producer"},
+			Run:       kafkaProbe.Run,
+			Stop:      closer(kafkaProbe.Close),
+		})
+	}
+
+	if cfg.Kafka.OffsetSnapshots.Enabled {
+		offsetSnapshotter, err := platformKafka.NewOffsetSnapshotter(cfg.Kafka.Brokers, cfg.Kafka.ConsumerGroup, offsetSnapshots, cfg.Kafka.OffsetSnapshots, logger, serviceMetrics)
+		if err != nil {
+			logger.Error(ctx, "Failed to create kafka offset snapshotter", err)
+			os.Exit(1)
 		}
-	}()
+		runner.Add(lifecycle.Component{
+			Name:      "offset-snapshots",
+			DependsOn: []string{"database"},
+			Run:       offsetSnapshotter.Run,
+			Stop:      closer(offsetSnapshotter.Close),
+		})
+	}
 
-	logger.Info(ctx, "Order Service started successfully", map[string]interface{}{
+	if cfg.GRPC.WarmUp.Enabled {
+		// Readiness fails until the clients are connected; after the timeout the
+		// service is ready anyway and the remaining clients connect on first use
+		healthServer.BeginWarmUp()
+		runner.Add(lifecycle.Component{
+			Name:      "grpc-warmup",
+			DependsOn: []string{"inventory-client", "payment-client"},
+			Run: func(ctx context.Context) error {
+				defer healthServer.EndWarmUp()
+				failed := clients.WarmUp(ctx, cfg.GRPC.WarmUp, map[string]clients.WarmUpClient{
+					"inventory": inventoryClient,
+					"payment":   paymentClient,
+				}, logger, serviceMetrics)
+				for name, err := range failed {
+					logger.Error(ctx, "gRPC client not warmed up in time", err, map[string]interface{}{
+						"client":  name,
+						"timeout": cfg.GRPC.WarmUp.Timeout.String(),
+					})
+				}
+				return nil
+			},
+		})
+	}
+
+	if cfg.Retention.Enabled {
+		policies := postgres.RetentionPolicies(cfg.Retention.ProcessedEventsTTL, cfg.Retention.WebhookDeliveriesTTL, cfg.Retention.JobsTTL)
+		purger, err := retention.NewPurger(dbConn.DB, cfg.Retention.Config, policies, logger, serviceMetrics)
+		if err != nil {
+			logger.Error(ctx, "Failed to create retention purger", err)
+			os.Exit(1)
+		}
+		runner.Add(lifecycle.Component{
+			Name:      "retention",
+			DependsOn: []string{"database"},
+			Run:       purger.Run,
+		})
+
+		if reportingConn != nil {
+			policies := []retention.Policy{postgres.ReportRetentionPolicy(cfg.Retention.ProcessedEventsTTL)}
+			reportPurger, err := retention.NewPurger(reportingConn.DB, cfg.Retention.Config, policies, logger, serviceMetrics)
+			if err != nil {
+				logger.Error(ctx, "Failed to create reporting retention purger", err)
+				os.Exit(1)
+			}
+			runner.Add(lifecycle.Component{
+				Name:      "reporting-retention",
+				DependsOn: []string{"reporting-database"},
+				Run:       reportPurger.Run,
+			})
+		}
+	}
+
+	if statusConsumer != nil {
+		runner.Add(lifecycle.Component{
+			Name: "status-change-consumer",
+			Run:  statusConsumer.Start,
+			Stop: closer(statusConsumer.Close),
+		})
+	}
+
+	logger.Info(ctx, "Starting Order Service components", map[string]interface{}{
 		"http_address": fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
 		"database":     cfg.Database.Host,
 		"kafka":        cfg.Kafka.Brokers,
 	})
 
-	// Wait for shutdown signal
-	<-shutdownCh
-	logger.Info(ctx, "Shutdown signal received, stopping service...")
-
-	// Cancel context to stop all components
-	cancel()
-
-	// Create shutdown context with timeout
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer shutdownCancel()
-
-	// Stop HTTP server
-	if err := httpServer.Stop(shutdownCtx); err != nil {
-		logger.Error(shutdownCtx, "Failed to stop HTTP server", err)
+	// Run until a shutdown signal is received or a component fails
+	if err := runner.Run(ctx); err != nil {
+		logger.Error(ctx, "Order Service stopped with errors", err)
+		os.Exit(1)
 	}
 
-	// Stop Kafka consumer (handled by context cancellation)
-	// Wait for all goroutines to finish
-	wg.Wait()
-
 	logger.Info(ctx, "Order Service stopped successfully")
 }
 
@@ -257,17 +754,49 @@ func main() {
 /*
 export SERVER_HOST=0.0.0.0
 export SERVER_PORT=8080
+export SERVER_CSRF_ENABLED=true
+export SERVER_CSRF_SECRET=<at least 32 random bytes, same on every replica>
 export DB_HOST=localhost
 export DB_PORT=5432
 export DB_USER=postgres
 export DB_PASSWORD=password
 export DB_NAME=orders
 export KAFKA_BROKERS=localhost:9092
-export KAFKA_PAYMENT_EVENTS_TOPIC=payment-events
-export KAFKA_ASSEMBLY_EVENTS_TOPIC=assembly-events
 export KAFKA_CONSUMER_GROUP=order-service
+export KAFKA_TOPIC_PREFIX=<optional, e.g. staging.>
+export KAFKA_TOPIC_VALIDATION=strict
+export ORDER_APPROVAL_ENABLED=true
+export ORDER_APPROVAL_THRESHOLD=10000.00
+export ORDER_APPROVAL_TTL=24h
+export ORDER_APPROVERS=<operator user IDs, comma separated>
+export ORDER_PAYMENT_RETRY_ENABLED=true
+export ORDER_PAYMENT_RETRY_MAX_ATTEMPTS=5
+export ORDER_PAYMENT_RETRY_INITIAL_BACKOFF=30s
+export ORDER_PAYMENT_RETRY_MAX_BACKOFF=10m
+export ORDER_BATCH_ENABLED=true
+export ORDER_BATCH_MAX_ORDERS=100
+export ORDER_BATCH_WORKERS=8
+export ORDER_EXPORT_ENABLED=true
+export ORDER_EXPORT_SYNC_MAX_ROWS=1000
+export ORDER_EXPORT_STORAGE_DIR=/var/lib/order-service/exports
+export ORDER_EXPORT_URL_SIGNING_KEY=<at least 32 random bytes, same on every replica>
+export ORDER_EXPORT_PUBLIC_URL=https://api.example.com
+export ORDER_RATE_LIMIT_ENABLED=true
+export ORDER_RATE_LIMIT_REDIS_HOST=localhost
+export ORDER_RATE_LIMIT_PER_USER=10
+export ORDER_RATE_LIMIT_PER_IP=30
+export ORDER_RATE_LIMIT_ROLES=operator=100,admin=0
+export ORDER_ABUSE_THRESHOLD=5
+export AUTHZ_MODE=enforce
+export AUTHZ_ENDPOINT_MODES=<optional, e.g. reports=report,orders.export=report>
+export ORDER_REPORTING_ENABLED=true
+export ORDER_REPORTING_CONSUMER_GROUP=order-reporting
+export REPORTING_DB_HOST=localhost
+export REPORTING_DB_NAME=order_reporting
 export INVENTORY_SERVICE_ADDRESS=localhost:9001
 export PAYMENT_SERVICE_ADDRESS=localhost:9002
 export LOG_LEVEL=info
+export MAINTENANCE_MODE=false
+export SENTRY_DSN=<optional, Sentry project DSN>
 export OTEL_ENDPOINT=http://localhost:4317
 */
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	platformKafka "github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// restoreOffsets commits the consumer group offsets of the latest snapshot taken
+// at or before the RFC 3339 time, so the consumers resume from that point
+func restoreOffsets(ctx context.Context, cfg *config.Config, store platformKafka.OffsetSnapshotStore, value string, logger logging.Logger) error {
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("invalid -restore-offsets-at time: %w", err)
+	}
+
+	snapshot, err := store.SnapshotAt(ctx, cfg.Kafka.ConsumerGroup, at)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return fmt.Errorf("no offset snapshot of group %s at or before %s", cfg.Kafka.ConsumerGroup, at.Format(time.RFC3339))
+	}
+
+	if err := platformKafka.RestoreOffsets(cfg.Kafka.Brokers, *snapshot); err != nil {
+		return err
+	}
+	logger.Info(ctx, "Kafka consumer offsets restored", map[string]interface{}{
+		"group_id":   snapshot.GroupID,
+		"taken_at":   snapshot.TakenAt,
+		"partitions": len(snapshot.Offsets),
+	})
+	return nil
+}
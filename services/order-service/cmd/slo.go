@@ -0,0 +1,22 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/transport/http"
+)
+
+// printSLORules writes the Prometheus recording rules and burn-rate alerts of
+// the service level objectives to stdout
+func printSLORules() {
+	objectives, err := http.ServiceLevelObjectives(nil)
+	if err != nil {
+		log.Fatalf("Invalid service level objectives: %v", err)
+	}
+	rules, err := objectives.PrometheusRulesYAML()
+	if err != nil {
+		log.Fatalf("Failed to render SLO rules: %v", err)
+	}
+	os.Stdout.Write(rules)
+}
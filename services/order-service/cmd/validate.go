@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+)
+
+// validateConfiguration loads the configuration the way startup does and
+// reports every problem found, optionally dialing the configured dependencies
+func validateConfiguration(profile *platformconfig.Profile, profileErr error, checkConnectivity bool) *platformconfig.Report {
+	report := platformconfig.NewReport(serviceName)
+
+	cfg, err := config.Load()
+	report.Check("load configuration", err)
+	report.CheckParsedValues()
+	report.CheckProfile(profile, profileErr)
+
+	if !checkConnectivity {
+		return report
+	}
+	if cfg == nil {
+		report.Skip("connectivity", "configuration did not load")
+		return report
+	}
+
+	report.CheckReachable("postgres", fmt.Sprintf("%s:%d", cfg.Database.Host, cfg.Database.Port))
+	if cfg.Reporting.Enabled {
+		report.CheckReachable("reporting postgres", fmt.Sprintf("%s:%d", cfg.Reporting.Database.Host, cfg.Reporting.Database.Port))
+	}
+	for _, broker := range cfg.Kafka.Brokers {
+		report.CheckReachable("kafka broker", broker)
+	}
+	report.Check("kafka topics", kafka.VerifyTopics(cfg.Kafka.Brokers, cfg.Kafka.Topics, cfg.Kafka.UsedTopics()...))
+	report.CheckReachable("inventory service", cfg.GRPC.InventoryService.Address)
+	report.CheckReachable("payment service", cfg.GRPC.PaymentService.Address)
+
+	return report
+}
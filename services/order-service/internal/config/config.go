@@ -2,10 +2,16 @@ package config
 
 import (
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/cloudevents"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/retention"
 )
 
 // Config holds all configuration for the order service
@@ -14,6 +20,19 @@ type Config struct {
 	Database      DatabaseConfig      `json:"database"`
 	Kafka         KafkaConfig         `json:"kafka"`
 	GRPC          GRPCConfig          `json:"grpc"`
+	Cache         CacheConfig         `json:"cache"`
+	StatusWatch   StatusWatchConfig   `json:"status_watch"`
+	Webhooks      WebhookConfig       `json:"webhooks"`
+	Approvals     ApprovalConfig      `json:"approvals"`
+	Refunds       RefundConfig        `json:"refunds"`
+	Fulfillment   FulfillmentConfig   `json:"fulfillment"`
+	PaymentRetry  PaymentRetryConfig  `json:"payment_retry"`
+	Reporting     ReportingConfig     `json:"reporting"`
+	Batches       BatchConfig         `json:"batches"`
+	Exports       ExportConfig        `json:"exports"`
+	RateLimit     RateLimitConfig     `json:"rate_limit"`
+	Sandbox       SandboxConfig       `json:"sandbox"`
+	Retention     RetentionConfig     `json:"retention"`
 	Observability ObservabilityConfig `json:"observability"`
 }
 
@@ -24,6 +43,28 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `json:"read_timeout"`
 	WriteTimeout time.Duration `json:"write_timeout"`
 	IdleTimeout  time.Duration `json:"idle_timeout"`
+
+	LoadShedding LoadSheddingConfig `json:"load_shedding"`
+	CSRF         CSRFConfig         `json:"csrf"`
+}
+
+// CSRFConfig holds the CSRF protection of requests authenticated by session cookies
+type CSRFConfig struct {
+	Enabled      bool          `json:"enabled"`
+	Secret       string        `json:"-"`
+	CookieName   string        `json:"cookie_name"`
+	TTL          time.Duration `json:"ttl"`
+	SecureCookie bool          `json:"secure_cookie"`
+}
+
+// LoadSheddingConfig holds the adaptive concurrency limit applied to the API
+type LoadSheddingConfig struct {
+	Enabled      bool          `json:"enabled"`
+	InitialLimit int           `json:"initial_limit"`
+	MinLimit     int           `json:"min_limit"`
+	MaxLimit     int           `json:"max_limit"`
+	MaxQueueWait time.Duration `json:"max_queue_wait"`
+	RetryAfter   time.Duration `json:"retry_after"`
 }
 
 // DatabaseConfig holds PostgreSQL database configuration
@@ -41,18 +82,67 @@ type DatabaseConfig struct {
 
 // KafkaConfig holds Kafka configuration
 type KafkaConfig struct {
-	Brokers                []string      `json:"brokers"`
-	PaymentEventsTopic     string        `json:"payment_events_topic"`
-	AssemblyEventsTopic    string        `json:"assembly_events_topic"`
-	ConsumerGroup          string        `json:"consumer_group"`
-	ProducerRetries        int           `json:"producer_retries"`
-	ConsumerSessionTimeout time.Duration `json:"consumer_session_timeout"`
+	Brokers []string        `json:"brokers"`
+	Topics  topics.Registry `json:"topics"`
+	// EventFormat is the CloudEvents format of order events: json, or avro for
+	// consumers that want the binary Avro event format
+	EventFormat            cloudevents.Format `json:"event_format"`
+	ConsumerGroup          string             `json:"consumer_group"`
+	ProducerRetries        int                `json:"producer_retries"`
+	ProducerCompression    string             `json:"producer_compression"` // none, gzip, snappy, lz4 or zstd
+	ProducerMaxBytes       int                `json:"producer_max_bytes"`   // Messages larger than this are rejected before sending
+	ConsumerSessionTimeout time.Duration      `json:"consumer_session_timeout"`
+	// Membership sets static group membership and the rebalance strategy of
+	// the saga and projection consumers
+	Membership kafka.GroupMembership `json:"membership"`
+	// OffsetSnapshots periodically records the consumer group's committed
+	// offsets in Postgres for point-in-time recovery and audit
+	OffsetSnapshots kafka.OffsetSnapshotConfig `json:"offset_snapshots"`
+	// Health probes the produce path with heartbeats and the brokers' metadata;
+	// readiness fails while the probes keep failing
+	Health KafkaHealthConfig `json:"health"`
+}
+
+// KafkaHealthConfig holds the Kafka produce path probe behind readiness
+type KafkaHealthConfig struct {
+	Enabled          bool          `json:"enabled"`
+	Interval         time.Duration `json:"interval"`
+	Timeout          time.Duration `json:"timeout"`           // Bounds one probe, metadata and heartbeat together
+	FailureThreshold int           `json:"failure_threshold"` // Consecutive failed probes before the service is not ready
+	SuccessThreshold int           `json:"success_threshold"` // Consecutive successful probes before it is ready again
+}
+
+// UsedTopics returns the registered topics the service produces to and consumes from
+func (k KafkaConfig) UsedTopics() []topics.Topic {
+	used := []topics.Topic{
+		topics.OrderEvents,
+		topics.PaymentEvents,
+		topics.PaymentReviewEvents,
+		topics.AssemblyEvents,
+		topics.InventoryEvents,
+		topics.SecurityEvents,
+	}
+	if k.Health.Enabled {
+		used = append(used, topics.Heartbeats)
+	}
+	return used
 }
 
 // GRPCConfig holds gRPC clients configuration
 type GRPCConfig struct {
 	InventoryService InventoryServiceConfig `json:"inventory_service"`
 	PaymentService   PaymentServiceConfig   `json:"payment_service"`
+
+	// WarmUp connects the clients at startup; the service reports ready once
+	// they are connected or the warm-up timed out
+	WarmUp WarmUpConfig `json:"warm_up"`
+}
+
+// WarmUpConfig holds the warm-up of the gRPC clients at startup
+type WarmUpConfig struct {
+	Enabled       bool          `json:"enabled"` // Disabled, the service is ready at once and clients connect on first use
+	Timeout       time.Duration `json:"timeout"`
+	RetryInterval time.Duration `json:"retry_interval"` // Wait between attempts to reach a downstream service
 }
 
 // InventoryServiceConfig holds inventory service gRPC client configuration
@@ -71,6 +161,161 @@ type PaymentServiceConfig struct {
 	RetryInterval time.Duration `json:"retry_interval"`
 }
 
+// CacheConfig holds the in-process order cache configuration
+type CacheConfig struct {
+	Enabled    bool          `json:"enabled"`
+	OrderTTL   time.Duration `json:"order_ttl"`
+	MaxEntries int           `json:"max_entries"`
+}
+
+// WebhookConfig holds the delivery settings of customer order webhooks
+type WebhookConfig struct {
+	Enabled          bool          `json:"enabled"`
+	DispatchInterval time.Duration `json:"dispatch_interval"`
+	BatchSize        int           `json:"batch_size"`
+	Timeout          time.Duration `json:"timeout"`
+	MaxAttempts      int           `json:"max_attempts"`
+	InitialBackoff   time.Duration `json:"initial_backoff"`
+	MaxBackoff       time.Duration `json:"max_backoff"`
+	HistoryLimit     int           `json:"history_limit"` // Deliveries returned per webhook
+}
+
+// ApprovalConfig holds the operator approval required for high-value orders
+type ApprovalConfig struct {
+	Enabled        bool          `json:"enabled"`
+	Threshold      string        `json:"threshold"` // Orders above this amount, in major units of the order currency, need approval
+	TTL            time.Duration `json:"ttl"`       // Approvals not decided in time expire and cancel the order
+	ExpiryInterval time.Duration `json:"expiry_interval"`
+	BatchSize      int           `json:"batch_size"` // Expired approvals handled per sweep
+	ApproverIDs    []string      `json:"approver_ids"`
+}
+
+// RefundConfig holds customers' refund requests, which support approves or rejects
+type RefundConfig struct {
+	Enabled bool          `json:"enabled"`
+	Window  time.Duration `json:"window"` // How long after payment a refund may be requested
+}
+
+// FulfillmentConfig holds what happens to orders placed when only some of their
+// items are in stock. Orders may choose a policy of their own.
+type FulfillmentConfig struct {
+	DefaultPolicy string `json:"default_policy"` // all_or_nothing refuses the order; partial backorders the missing items; backorder holds the whole order
+}
+
+// PaymentRetryConfig holds the retry schedule of payments that failed transiently.
+// Declined payments are never retried. The order keeps its inventory reservation
+// while it waits, so the schedule should end before inventory expires reservations.
+type PaymentRetryConfig struct {
+	Enabled        bool          `json:"enabled"`
+	MaxAttempts    int           `json:"max_attempts"` // Retries after the failed payment, before the order fails
+	InitialBackoff time.Duration `json:"initial_backoff"`
+	MaxBackoff     time.Duration `json:"max_backoff"`
+	Interval       time.Duration `json:"interval"`   // How often due retries are looked for
+	BatchSize      int           `json:"batch_size"` // Due retries attempted per sweep
+}
+
+// ReportingConfig holds the order reporting projection, which builds a reporting store
+// from the Kafka event streams in a database of its own
+type ReportingConfig struct {
+	Enabled       bool           `json:"enabled"`
+	ConsumerGroup string         `json:"consumer_group"` // Must differ from the saga consumer group
+	Database      DatabaseConfig `json:"database"`
+}
+
+// StatusWatchConfig holds long polling of order status changes, fed by the order
+// events topic so a poll is answered whichever replica changed the status
+type StatusWatchConfig struct {
+	Enabled     bool          `json:"enabled"`
+	MaxWait     time.Duration `json:"max_wait"`     // Longest a poll is held; below the 30s request timeout
+	MaxWatchers int           `json:"max_watchers"` // Polls held at once; further polls are answered at once
+}
+
+// BatchConfig holds bulk order creation through the batch endpoint
+type BatchConfig struct {
+	Enabled   bool `json:"enabled"`
+	MaxOrders int  `json:"max_orders"` // Orders accepted per batch
+	Workers   int  `json:"workers"`    // Orders of a batch created concurrently
+}
+
+// ExportConfig holds the CSV and XLSX order exports of account managers. Exports
+// up to SyncMaxRows orders are streamed in the response; larger ones run as
+// background jobs whose files are downloaded through signed URLs.
+type ExportConfig struct {
+	Enabled       bool          `json:"enabled"`
+	SyncMaxRows   int           `json:"sync_max_rows"` // Larger exports run in the background
+	MaxRows       int           `json:"max_rows"`      // Larger exports are refused
+	PageSize      int           `json:"page_size"`     // Orders read per query while exporting
+	StorageDir    string        `json:"storage_dir"`   // Shared by all replicas, which serve each other's files
+	URLSigningKey string        `json:"-"`             // Same on every replica; a random key is used when empty
+	URLExpiry     time.Duration `json:"url_expiry"`    // How long signed download URLs stay valid
+	PublicURL     string        `json:"public_url"`    // Base URL of the API as reachable by account managers
+}
+
+// RetentionConfig holds the purge of internal bookkeeping rows once they are
+// older than their table's TTL; a zero TTL keeps a table's rows forever
+type RetentionConfig struct {
+	retention.Config
+
+	// Processed events must outlive any redelivery, or a redelivered event is
+	// applied again; this includes replays from restored offset snapshots
+	ProcessedEventsTTL   time.Duration `json:"processed_events_ttl"`
+	WebhookDeliveriesTTL time.Duration `json:"webhook_deliveries_ttl"` // Finished deliveries only; pending ones are kept
+	JobsTTL              time.Duration `json:"jobs_ttl"`               // Finished jobs only
+}
+
+// RateLimitConfig holds the order placement limits. Orders are counted in Redis
+// over a sliding window, so every replica enforces the same limits.
+type RateLimitConfig struct {
+	Enabled   bool          `json:"enabled"`
+	Redis     RedisConfig   `json:"redis"`
+	Window    time.Duration `json:"window"`
+	UserLimit int           `json:"user_limit"` // Orders per user and window
+	IPLimit   int           `json:"ip_limit"`   // Orders per client IP and window; 0 disables the IP limit
+
+	// RoleLimits and TenantLimits override UserLimit for the users of a role or
+	// tenant; a tenant limit takes precedence and 0 means unlimited
+	RoleLimits   map[string]int `json:"role_limits"`
+	TenantLimits map[string]int `json:"tenant_limits"`
+
+	// FailOpen admits orders while Redis is unavailable instead of rejecting them
+	FailOpen bool `json:"fail_open"`
+
+	// A user rejected AbuseThreshold times within AbuseWindow is reported to IAM
+	// as abusive, which locks the account
+	AbuseThreshold int           `json:"abuse_threshold"`
+	AbuseWindow    time.Duration `json:"abuse_window"`
+}
+
+// SandboxConfig selects the tenants whose orders are test data: their payments
+// are charged by the payment simulator, and the orders are marked as sandbox
+// orders, which reports and metrics leave out and whose notifications are
+// flagged as test messages. A tenant is the client ID of a service client, set
+// by the gateway from its validated token and signed by IAM.
+type SandboxConfig struct {
+	Tenants []string `json:"tenants"`
+}
+
+// IsSandboxTenant tells whether the orders of the tenant are sandbox orders
+func (c SandboxConfig) IsSandboxTenant(tenantID string) bool {
+	if tenantID == "" {
+		return false
+	}
+	for _, tenant := range c.Tenants {
+		if strings.EqualFold(strings.TrimSpace(tenant), tenantID) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedisConfig holds a Redis connection
+type RedisConfig struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Password string `json:"-"`
+	DB       int    `json:"db"`
+}
+
 // ObservabilityConfig holds observability configuration
 type ObservabilityConfig struct {
 	ServiceName    string `json:"service_name"`
@@ -90,6 +335,21 @@ func Load() (*Config, error) {
 			ReadTimeout:  getEnvAsDuration("SERVER_READ_TIMEOUT", "30s"),
 			WriteTimeout: getEnvAsDuration("SERVER_WRITE_TIMEOUT", "30s"),
 			IdleTimeout:  getEnvAsDuration("SERVER_IDLE_TIMEOUT", "120s"),
+			LoadShedding: LoadSheddingConfig{
+				Enabled:      getEnvAsBool("SERVER_LOAD_SHEDDING_ENABLED", true),
+				InitialLimit: getEnvAsInt("SERVER_CONCURRENCY_INITIAL_LIMIT", 100),
+				MinLimit:     getEnvAsInt("SERVER_CONCURRENCY_MIN_LIMIT", 10),
+				MaxLimit:     getEnvAsInt("SERVER_CONCURRENCY_MAX_LIMIT", 1000),
+				MaxQueueWait: getEnvAsDuration("SERVER_MAX_QUEUE_WAIT", "50ms"),
+				RetryAfter:   getEnvAsDuration("SERVER_LOAD_SHEDDING_RETRY_AFTER", "1s"),
+			},
+			CSRF: CSRFConfig{
+				Enabled:      getEnvAsBool("SERVER_CSRF_ENABLED", false),
+				Secret:       getEnv("SERVER_CSRF_SECRET", ""),
+				CookieName:   getEnv("SERVER_CSRF_COOKIE_NAME", "csrf_token"),
+				TTL:          getEnvAsDuration("SERVER_CSRF_TOKEN_TTL", "12h"),
+				SecureCookie: getEnvAsBool("SERVER_CSRF_SECURE_COOKIE", true),
+			},
 		},
 		Database: DatabaseConfig{
 			Host:            getEnv("DB_HOST", "localhost"),
@@ -104,11 +364,29 @@ func Load() (*Config, error) {
 		},
 		Kafka: KafkaConfig{
 			Brokers:                getEnvAsSlice("KAFKA_BROKERS", "localhost:9092"),
-			PaymentEventsTopic:     getEnv("KAFKA_PAYMENT_EVENTS_TOPIC", "payment-events"),
-			AssemblyEventsTopic:    getEnv("KAFKA_ASSEMBLY_EVENTS_TOPIC", "assembly-events"),
+			Topics:                 topics.Load(platformconfig.Getenv),
+			EventFormat:            cloudevents.Format(getEnv("KAFKA_EVENT_FORMAT", "json")),
 			ConsumerGroup:          getEnv("KAFKA_CONSUMER_GROUP", "order-service"),
 			ProducerRetries:        getEnvAsInt("KAFKA_PRODUCER_RETRIES", 3),
+			ProducerCompression:    getEnv("KAFKA_PRODUCER_COMPRESSION", "snappy"),
+			ProducerMaxBytes:       getEnvAsInt("KAFKA_PRODUCER_MAX_MESSAGE_BYTES", 1000000),
 			ConsumerSessionTimeout: getEnvAsDuration("KAFKA_CONSUMER_SESSION_TIMEOUT", "30s"),
+			Membership: kafka.GroupMembership{
+				InstanceID:        getEnv("KAFKA_GROUP_INSTANCE_ID", ""),
+				RebalanceStrategy: getEnv("KAFKA_REBALANCE_STRATEGY", kafka.RebalanceStrategySticky),
+			},
+			OffsetSnapshots: kafka.OffsetSnapshotConfig{
+				Enabled:   getEnvAsBool("KAFKA_OFFSET_SNAPSHOTS_ENABLED", true),
+				Interval:  getEnvAsDuration("KAFKA_OFFSET_SNAPSHOT_INTERVAL", "1m"),
+				Retention: getEnvAsDuration("KAFKA_OFFSET_SNAPSHOT_RETENTION", "168h"),
+			},
+			Health: KafkaHealthConfig{
+				Enabled:          getEnvAsBool("KAFKA_HEALTH_PROBE_ENABLED", true),
+				Interval:         getEnvAsDuration("KAFKA_HEALTH_PROBE_INTERVAL", "15s"),
+				Timeout:          getEnvAsDuration("KAFKA_HEALTH_PROBE_TIMEOUT", "5s"),
+				FailureThreshold: getEnvAsInt("KAFKA_HEALTH_FAILURE_THRESHOLD", 3),
+				SuccessThreshold: getEnvAsInt("KAFKA_HEALTH_SUCCESS_THRESHOLD", 1),
+			},
 		},
 		GRPC: GRPCConfig{
 			InventoryService: InventoryServiceConfig{
@@ -123,6 +401,114 @@ func Load() (*Config, error) {
 				MaxRetries:    getEnvAsInt("PAYMENT_SERVICE_MAX_RETRIES", 3),
 				RetryInterval: getEnvAsDuration("PAYMENT_SERVICE_RETRY_INTERVAL", "1s"),
 			},
+			WarmUp: WarmUpConfig{
+				Enabled:       getEnvAsBool("GRPC_WARMUP_ENABLED", true),
+				Timeout:       getEnvAsDuration("GRPC_WARMUP_TIMEOUT", "30s"),
+				RetryInterval: getEnvAsDuration("GRPC_WARMUP_RETRY_INTERVAL", "1s"),
+			},
+		},
+		Cache: CacheConfig{
+			Enabled:    getEnvAsBool("ORDER_CACHE_ENABLED", true),
+			OrderTTL:   getEnvAsDuration("ORDER_CACHE_TTL", "5s"),
+			MaxEntries: getEnvAsInt("ORDER_CACHE_MAX_ENTRIES", 10000),
+		},
+		Webhooks: WebhookConfig{
+			Enabled:          getEnvAsBool("ORDER_WEBHOOKS_ENABLED", true),
+			DispatchInterval: getEnvAsDuration("ORDER_WEBHOOK_DISPATCH_INTERVAL", "2s"),
+			BatchSize:        getEnvAsInt("ORDER_WEBHOOK_BATCH_SIZE", 50),
+			Timeout:          getEnvAsDuration("ORDER_WEBHOOK_TIMEOUT", "10s"),
+			MaxAttempts:      getEnvAsInt("ORDER_WEBHOOK_MAX_ATTEMPTS", 8),
+			InitialBackoff:   getEnvAsDuration("ORDER_WEBHOOK_INITIAL_BACKOFF", "30s"),
+			MaxBackoff:       getEnvAsDuration("ORDER_WEBHOOK_MAX_BACKOFF", "1h"),
+			HistoryLimit:     getEnvAsInt("ORDER_WEBHOOK_HISTORY_LIMIT", 100),
+		},
+		Approvals: ApprovalConfig{
+			Enabled:        getEnvAsBool("ORDER_APPROVAL_ENABLED", false),
+			Threshold:      getEnv("ORDER_APPROVAL_THRESHOLD", "10000.00"),
+			TTL:            getEnvAsDuration("ORDER_APPROVAL_TTL", "24h"),
+			ExpiryInterval: getEnvAsDuration("ORDER_APPROVAL_EXPIRY_INTERVAL", "1m"),
+			BatchSize:      getEnvAsInt("ORDER_APPROVAL_BATCH_SIZE", 50),
+			ApproverIDs:    getEnvAsSlice("ORDER_APPROVERS", ""),
+		},
+		Refunds: RefundConfig{
+			Enabled: getEnvAsBool("ORDER_REFUNDS_ENABLED", true),
+			Window:  getEnvAsDuration("ORDER_REFUND_WINDOW", "720h"),
+		},
+		Fulfillment: FulfillmentConfig{
+			DefaultPolicy: getEnv("ORDER_FULFILLMENT_POLICY", "all_or_nothing"),
+		},
+		PaymentRetry: PaymentRetryConfig{
+			Enabled:        getEnvAsBool("ORDER_PAYMENT_RETRY_ENABLED", true),
+			MaxAttempts:    getEnvAsInt("ORDER_PAYMENT_RETRY_MAX_ATTEMPTS", 5),
+			InitialBackoff: getEnvAsDuration("ORDER_PAYMENT_RETRY_INITIAL_BACKOFF", "30s"),
+			MaxBackoff:     getEnvAsDuration("ORDER_PAYMENT_RETRY_MAX_BACKOFF", "10m"),
+			Interval:       getEnvAsDuration("ORDER_PAYMENT_RETRY_INTERVAL", "15s"),
+			BatchSize:      getEnvAsInt("ORDER_PAYMENT_RETRY_BATCH_SIZE", 50),
+		},
+		Reporting: ReportingConfig{
+			Enabled:       getEnvAsBool("ORDER_REPORTING_ENABLED", false),
+			ConsumerGroup: getEnv("ORDER_REPORTING_CONSUMER_GROUP", "order-reporting"),
+			Database: DatabaseConfig{
+				Host:            getEnv("REPORTING_DB_HOST", "localhost"),
+				Port:            getEnvAsInt("REPORTING_DB_PORT", 5432),
+				User:            getEnv("REPORTING_DB_USER", "postgres"),
+				Password:        getEnv("REPORTING_DB_PASSWORD", "password"),
+				DBName:          getEnv("REPORTING_DB_NAME", "order_reporting"),
+				SSLMode:         getEnv("REPORTING_DB_SSL_MODE", "disable"),
+				MaxOpenConns:    getEnvAsInt("REPORTING_DB_MAX_OPEN_CONNS", 10),
+				MaxIdleConns:    getEnvAsInt("REPORTING_DB_MAX_IDLE_CONNS", 2),
+				ConnMaxLifetime: getEnvAsDuration("REPORTING_DB_CONN_MAX_LIFETIME", "5m"),
+			},
+		},
+		StatusWatch: StatusWatchConfig{
+			Enabled:     getEnvAsBool("ORDER_STATUS_WATCH_ENABLED", true),
+			MaxWait:     getEnvAsDuration("ORDER_STATUS_WATCH_MAX_WAIT", "25s"),
+			MaxWatchers: getEnvAsInt("ORDER_STATUS_WATCH_MAX_WATCHERS", 10000),
+		},
+		Batches: BatchConfig{
+			Enabled:   getEnvAsBool("ORDER_BATCH_ENABLED", true),
+			MaxOrders: getEnvAsInt("ORDER_BATCH_MAX_ORDERS", 100),
+			Workers:   getEnvAsInt("ORDER_BATCH_WORKERS", 8),
+		},
+		Exports: ExportConfig{
+			Enabled:       getEnvAsBool("ORDER_EXPORT_ENABLED", true),
+			SyncMaxRows:   getEnvAsInt("ORDER_EXPORT_SYNC_MAX_ROWS", 1000),
+			MaxRows:       getEnvAsInt("ORDER_EXPORT_MAX_ROWS", 1000000),
+			PageSize:      getEnvAsInt("ORDER_EXPORT_PAGE_SIZE", 500),
+			StorageDir:    getEnv("ORDER_EXPORT_STORAGE_DIR", "./data/order-exports"),
+			URLSigningKey: getEnv("ORDER_EXPORT_URL_SIGNING_KEY", ""),
+			URLExpiry:     getEnvAsDuration("ORDER_EXPORT_URL_EXPIRY", "1h"),
+			PublicURL:     getEnv("ORDER_EXPORT_PUBLIC_URL", ""),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: getEnvAsBool("ORDER_RATE_LIMIT_ENABLED", false),
+			Redis: RedisConfig{
+				Host:     getEnv("ORDER_RATE_LIMIT_REDIS_HOST", "localhost"),
+				Port:     getEnvAsInt("ORDER_RATE_LIMIT_REDIS_PORT", 6379),
+				Password: getEnv("ORDER_RATE_LIMIT_REDIS_PASSWORD", ""),
+				DB:       getEnvAsInt("ORDER_RATE_LIMIT_REDIS_DB", 0),
+			},
+			Window:         getEnvAsDuration("ORDER_RATE_LIMIT_WINDOW", "1m"),
+			UserLimit:      getEnvAsInt("ORDER_RATE_LIMIT_PER_USER", 10),
+			IPLimit:        getEnvAsInt("ORDER_RATE_LIMIT_PER_IP", 30),
+			RoleLimits:     getEnvAsLimits("ORDER_RATE_LIMIT_ROLES", "operator=100,admin=0"),
+			TenantLimits:   getEnvAsLimits("ORDER_RATE_LIMIT_TENANTS", ""),
+			FailOpen:       getEnvAsBool("ORDER_RATE_LIMIT_FAIL_OPEN", true),
+			AbuseThreshold: getEnvAsInt("ORDER_ABUSE_THRESHOLD", 5),
+			AbuseWindow:    getEnvAsDuration("ORDER_ABUSE_WINDOW", "15m"),
+		},
+		Sandbox: SandboxConfig{
+			Tenants: getEnvAsSlice("ORDER_SANDBOX_TENANTS", ""),
+		},
+		Retention: RetentionConfig{
+			Config: retention.Config{
+				Enabled:   getEnvAsBool("RETENTION_ENABLED", true),
+				Interval:  getEnvAsDuration("RETENTION_INTERVAL", "1h"),
+				BatchSize: getEnvAsInt("RETENTION_BATCH_SIZE", 1000),
+			},
+			ProcessedEventsTTL:   getEnvAsDuration("RETENTION_PROCESSED_EVENTS_TTL", "720h"),
+			WebhookDeliveriesTTL: getEnvAsDuration("RETENTION_WEBHOOK_DELIVERIES_TTL", "720h"),
+			JobsTTL:              getEnvAsDuration("RETENTION_JOBS_TTL", "168h"),
 		},
 		Observability: ObservabilityConfig{
 			ServiceName:    getEnv("SERVICE_NAME", "order-service"),
@@ -134,9 +520,219 @@ func Load() (*Config, error) {
 		},
 	}
 
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	return config, nil
 }
 
+// Validate checks that the configuration is usable
+func (c *Config) Validate() error {
+	if c.Server.Port <= 0 || c.Server.Port > 65535 {
+		return fmt.Errorf("invalid server port: %d", c.Server.Port)
+	}
+	if shed := c.Server.LoadShedding; shed.Enabled {
+		if shed.MinLimit < 1 || shed.MinLimit > shed.MaxLimit {
+			return fmt.Errorf("concurrency limits must satisfy 1 <= min (%d) <= max (%d)", shed.MinLimit, shed.MaxLimit)
+		}
+		if shed.InitialLimit < shed.MinLimit || shed.InitialLimit > shed.MaxLimit {
+			return fmt.Errorf("initial concurrency limit (%d) must be between min (%d) and max (%d)",
+				shed.InitialLimit, shed.MinLimit, shed.MaxLimit)
+		}
+		if shed.MaxQueueWait < 0 || shed.RetryAfter <= 0 {
+			return fmt.Errorf("load shedding queue wait must not be negative and retry-after must be positive")
+		}
+	}
+	if csrf := c.Server.CSRF; csrf.Enabled {
+		if len(csrf.Secret) < 32 {
+			return fmt.Errorf("CSRF secret must be at least 32 bytes")
+		}
+		if csrf.CookieName == "" || csrf.TTL <= 0 {
+			return fmt.Errorf("CSRF cookie name and token TTL must be set")
+		}
+	}
+
+	if c.Database.Host == "" {
+		return fmt.Errorf("database host is required")
+	}
+	if c.Database.DBName == "" {
+		return fmt.Errorf("database name is required")
+	}
+	if c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		return fmt.Errorf("database max idle connections (%d) exceed max open connections (%d)",
+			c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	}
+
+	if len(c.Kafka.Brokers) == 0 || c.Kafka.Brokers[0] == "" {
+		return fmt.Errorf("kafka brokers are required")
+	}
+	if err := c.Kafka.Topics.Validate(); err != nil {
+		return err
+	}
+	if err := c.Kafka.Membership.Validate(); err != nil {
+		return err
+	}
+	if err := c.Kafka.OffsetSnapshots.Validate(); err != nil {
+		return err
+	}
+	if health := c.Kafka.Health; health.Enabled {
+		if health.Interval <= 0 || health.Timeout <= 0 {
+			return fmt.Errorf("kafka health probe interval and timeout must be positive")
+		}
+		if health.Timeout > health.Interval {
+			return fmt.Errorf("kafka health probe timeout (%s) must not exceed the interval (%s)", health.Timeout, health.Interval)
+		}
+		if health.FailureThreshold < 1 || health.SuccessThreshold < 1 {
+			return fmt.Errorf("kafka health failure and success thresholds must be at least 1")
+		}
+	}
+	format, err := cloudevents.ParseFormat(string(c.Kafka.EventFormat))
+	if err != nil {
+		return err
+	}
+	c.Kafka.EventFormat = format
+	if c.Kafka.ConsumerGroup == "" {
+		return fmt.Errorf("kafka consumer group is required")
+	}
+
+	if c.GRPC.InventoryService.Address == "" {
+		return fmt.Errorf("inventory service address is required")
+	}
+	if c.GRPC.PaymentService.Address == "" {
+		return fmt.Errorf("payment service address is required")
+	}
+
+	if c.Cache.Enabled && (c.Cache.OrderTTL <= 0 || c.Cache.MaxEntries <= 0) {
+		return fmt.Errorf("order cache TTL and max entries must be positive when the cache is enabled")
+	}
+
+	if watch := c.StatusWatch; watch.Enabled {
+		if watch.MaxWait <= 0 || watch.MaxWait >= 30*time.Second || watch.MaxWait >= c.Server.WriteTimeout {
+			return fmt.Errorf("status watch max wait must be positive and below the 30s request timeout and the server write timeout")
+		}
+		if watch.MaxWatchers <= 0 {
+			return fmt.Errorf("status watch max watchers must be positive")
+		}
+	}
+
+	if hooks := c.Webhooks; hooks.Enabled {
+		if hooks.DispatchInterval <= 0 || hooks.Timeout <= 0 || hooks.BatchSize <= 0 || hooks.HistoryLimit <= 0 {
+			return fmt.Errorf("webhook dispatch interval, timeout, batch size and history limit must be positive")
+		}
+		if hooks.MaxAttempts < 1 {
+			return fmt.Errorf("webhook max attempts must be at least 1")
+		}
+		if hooks.InitialBackoff <= 0 || hooks.MaxBackoff < hooks.InitialBackoff {
+			return fmt.Errorf("webhook backoff must satisfy 0 < initial (%s) <= max (%s)", hooks.InitialBackoff, hooks.MaxBackoff)
+		}
+	}
+
+	if approvals := c.Approvals; approvals.Enabled {
+		if threshold, err := money.Parse(approvals.Threshold, "USD"); err != nil || threshold.IsNegative() {
+			return fmt.Errorf("invalid order approval threshold %q", approvals.Threshold)
+		}
+		if approvals.TTL <= 0 || approvals.ExpiryInterval <= 0 || approvals.BatchSize <= 0 {
+			return fmt.Errorf("order approval TTL, expiry interval and batch size must be positive")
+		}
+	}
+
+	if refunds := c.Refunds; refunds.Enabled {
+		if refunds.Window <= 0 {
+			return fmt.Errorf("order refund window must be positive")
+		}
+	}
+
+	if policy := c.Fulfillment.DefaultPolicy; policy != "all_or_nothing" && policy != "partial" && policy != "backorder" {
+		return fmt.Errorf("order fulfillment policy must be all_or_nothing, partial or backorder, got %q", policy)
+	}
+
+	if retry := c.PaymentRetry; retry.Enabled {
+		if retry.MaxAttempts < 1 {
+			return fmt.Errorf("payment retry max attempts must be at least 1")
+		}
+		if retry.InitialBackoff <= 0 || retry.MaxBackoff < retry.InitialBackoff {
+			return fmt.Errorf("payment retry backoff must satisfy 0 < initial (%s) <= max (%s)", retry.InitialBackoff, retry.MaxBackoff)
+		}
+		if retry.Interval <= 0 || retry.BatchSize <= 0 {
+			return fmt.Errorf("payment retry interval and batch size must be positive")
+		}
+	}
+
+	if reporting := c.Reporting; reporting.Enabled {
+		if reporting.ConsumerGroup == "" || reporting.ConsumerGroup == c.Kafka.ConsumerGroup {
+			return fmt.Errorf("order reporting needs a consumer group of its own")
+		}
+		if reporting.Database.Host == "" || reporting.Database.DBName == "" {
+			return fmt.Errorf("reporting database host and name are required")
+		}
+		if reporting.Database.MaxIdleConns > reporting.Database.MaxOpenConns {
+			return fmt.Errorf("reporting database max idle connections (%d) exceed max open connections (%d)",
+				reporting.Database.MaxIdleConns, reporting.Database.MaxOpenConns)
+		}
+		if reporting.Database.Host == c.Database.Host && reporting.Database.Port == c.Database.Port &&
+			reporting.Database.DBName == c.Database.DBName {
+			return fmt.Errorf("the reporting database must not be the orders database")
+		}
+	}
+
+	if batches := c.Batches; batches.Enabled {
+		if batches.MaxOrders <= 0 || batches.Workers <= 0 {
+			return fmt.Errorf("order batch max orders and workers must be positive")
+		}
+	}
+
+	if exports := c.Exports; exports.Enabled {
+		if exports.SyncMaxRows < 0 || exports.MaxRows <= 0 || exports.PageSize <= 0 || exports.URLExpiry <= 0 {
+			return fmt.Errorf("order export max rows, page size and URL expiry must be positive and the sync max rows must not be negative")
+		}
+		if exports.SyncMaxRows > exports.MaxRows {
+			return fmt.Errorf("order export sync max rows must not exceed the max rows")
+		}
+		if exports.StorageDir == "" {
+			return fmt.Errorf("order export storage directory is required")
+		}
+	}
+
+	if limits := c.RateLimit; limits.Enabled {
+		if limits.Redis.Host == "" {
+			return fmt.Errorf("order rate limit Redis host is required")
+		}
+		if limits.Window <= 0 || limits.UserLimit <= 0 || limits.IPLimit < 0 {
+			return fmt.Errorf("order rate limit window and per-user limit must be positive and the per-IP limit must not be negative")
+		}
+		for name, limit := range limits.RoleLimits {
+			if limit < 0 {
+				return fmt.Errorf("order rate limit of role %q must not be negative", name)
+			}
+		}
+		for name, limit := range limits.TenantLimits {
+			if limit < 0 {
+				return fmt.Errorf("order rate limit of tenant %q must not be negative", name)
+			}
+		}
+		if limits.AbuseThreshold < 0 || (limits.AbuseThreshold > 0 && limits.AbuseWindow <= 0) {
+			return fmt.Errorf("order abuse threshold must not be negative and needs a positive window")
+		}
+	}
+
+	if warmUp := c.GRPC.WarmUp; warmUp.Enabled && (warmUp.Timeout <= 0 || warmUp.RetryInterval <= 0) {
+		return fmt.Errorf("gRPC client warm-up timeout and retry interval must be positive")
+	}
+
+	if err := c.Retention.Validate(); err != nil {
+		return err
+	}
+	if ret := c.Retention; ret.ProcessedEventsTTL < 0 || ret.WebhookDeliveriesTTL < 0 || ret.JobsTTL < 0 {
+		return fmt.Errorf("retention TTLs must not be negative")
+	}
+	if ttl, snapshots := c.Retention.ProcessedEventsTTL, c.Kafka.OffsetSnapshots; ttl > 0 && snapshots.Enabled && ttl < snapshots.Retention {
+		return fmt.Errorf("processed events TTL (%s) must not be shorter than the kafka offset snapshot retention (%s)", ttl, snapshots.Retention)
+	}
+
+	return nil
+}
+
 // DSN returns the PostgreSQL database connection string
 func (c *DatabaseConfig) DSN() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -146,42 +742,71 @@ func (c *DatabaseConfig) DSN() string {
 // Helper functions for environment variable parsing
 
 func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := platformconfig.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
 func getEnvAsInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		intValue, err := strconv.Atoi(value)
+		if err == nil {
 			return intValue
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	return defaultValue
 }
 
 func getEnvAsBool(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
-		if boolValue, err := strconv.ParseBool(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		boolValue, err := strconv.ParseBool(value)
+		if err == nil {
 			return boolValue
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	return defaultValue
 }
 
 func getEnvAsDuration(key string, defaultValue string) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		duration, err := time.ParseDuration(value)
+		if err == nil {
 			return duration
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	duration, _ := time.ParseDuration(defaultValue)
 	return duration
 }
 
+// getEnvAsLimits parses name=limit pairs, e.g. "operator=100,admin=0"
+func getEnvAsLimits(key string, defaultValue string) map[string]int {
+	value := platformconfig.Getenv(key)
+	if value == "" {
+		value = defaultValue
+	}
+
+	limits := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, limit, found := strings.Cut(pair, "=")
+		parsed, err := strconv.Atoi(strings.TrimSpace(limit))
+		if !found || err != nil {
+			platformconfig.InvalidValue(key, value, fmt.Errorf("invalid limit %q, want name=limit", pair))
+			continue
+		}
+		limits[strings.ToLower(strings.TrimSpace(name))] = parsed
+	}
+	return limits
+}
+
 func getEnvAsSlice(key string, defaultValue string) []string {
-	if value := os.Getenv(key); value != "" {
+	if value := platformconfig.Getenv(key); value != "" {
 		return strings.Split(value, ",")
 	}
 	return strings.Split(defaultValue, ",")
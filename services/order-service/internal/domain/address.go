@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxAddressesPerUser bounds the address book of a user
+const MaxAddressesPerUser = 20
+
+// countryCodePattern matches ISO 3166-1 alpha-2 country codes
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Address is a delivery address. Orders keep a snapshot of the address they ship to,
+// so editing or deleting an address book entry leaves placed orders unchanged.
+type Address struct {
+	RecipientName string `json:"recipient_name" db:"recipient_name"`
+	Line1         string `json:"line1" db:"line1"`
+	Line2         string `json:"line2,omitempty" db:"line2"`
+	City          string `json:"city" db:"city"`
+	Region        string `json:"region,omitempty" db:"region"` // State, province or county
+	PostalCode    string `json:"postal_code" db:"postal_code"`
+	Country       string `json:"country" db:"country"` // ISO 3166-1 alpha-2
+	Phone         string `json:"phone,omitempty" db:"phone"`
+}
+
+// Normalize trims the fields of the address and upper-cases its country code
+func (a *Address) Normalize() {
+	a.RecipientName = strings.TrimSpace(a.RecipientName)
+	a.Line1 = strings.TrimSpace(a.Line1)
+	a.Line2 = strings.TrimSpace(a.Line2)
+	a.City = strings.TrimSpace(a.City)
+	a.Region = strings.TrimSpace(a.Region)
+	a.PostalCode = strings.TrimSpace(a.PostalCode)
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+	a.Phone = strings.TrimSpace(a.Phone)
+}
+
+// Validate checks a normalized address
+func (a Address) Validate() error {
+	required := []struct {
+		name, value string
+	}{
+		{"recipient_name", a.RecipientName},
+		{"line1", a.Line1},
+		{"city", a.City},
+		{"postal_code", a.PostalCode},
+		{"country", a.Country},
+	}
+	for _, field := range required {
+		if field.value == "" {
+			return fmt.Errorf("address %s is required", field.name)
+		}
+	}
+
+	limits := []struct {
+		name, value string
+		max         int
+	}{
+		{"recipient_name", a.RecipientName, 100},
+		{"line1", a.Line1, 200},
+		{"line2", a.Line2, 200},
+		{"city", a.City, 100},
+		{"region", a.Region, 100},
+		{"postal_code", a.PostalCode, 20},
+		{"phone", a.Phone, 32},
+	}
+	for _, field := range limits {
+		if utf8.RuneCountInString(field.value) > field.max {
+			return fmt.Errorf("address %s exceeds %d characters", field.name, field.max)
+		}
+	}
+
+	if !countryCodePattern.MatchString(a.Country) {
+		return fmt.Errorf("address country must be an ISO 3166-1 alpha-2 code, got %q", a.Country)
+	}
+	return nil
+}
+
+// SavedAddress is an entry of a user's address book
+type SavedAddress struct {
+	Address
+	ID        uuid.UUID `json:"id" db:"id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Label     string    `json:"label,omitempty" db:"label"` // e.g. "Launch site"
+	IsDefault bool      `json:"is_default" db:"is_default"` // Ships orders placed without an address
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NewSavedAddress creates an address book entry for the user
+func NewSavedAddress(userID uuid.UUID, label string, address Address, isDefault bool) *SavedAddress {
+	now := time.Now()
+	return &SavedAddress{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Label:     strings.TrimSpace(label),
+		Address:   address,
+		IsDefault: isDefault,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// ApprovalStatus represents the state of an order approval
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "pending"  // Waiting for an operator
+	ApprovalApproved ApprovalStatus = "approved" // Approved by an operator; the order proceeds to payment
+	ApprovalRejected ApprovalStatus = "rejected" // Rejected by an operator; the order is cancelled
+	ApprovalExpired  ApprovalStatus = "expired"  // Nobody decided in time; the order is cancelled
+)
+
+// OrderApproval is an operator sign-off requested for a high-value order before it is paid
+type OrderApproval struct {
+	OrderID     uuid.UUID      `json:"order_id" db:"order_id"`
+	UserID      uuid.UUID      `json:"user_id" db:"user_id"`
+	Amount      money.Money    `json:"amount" db:"-"` // Stored as amount_minor and currency
+	Status      ApprovalStatus `json:"status" db:"status"`
+	RequestedAt time.Time      `json:"requested_at" db:"requested_at"`
+	ExpiresAt   time.Time      `json:"expires_at" db:"expires_at"`
+	DecidedBy   *uuid.UUID     `json:"decided_by,omitempty" db:"decided_by"` // Empty for expired approvals
+	DecidedAt   *time.Time     `json:"decided_at,omitempty" db:"decided_at"`
+	Reason      string         `json:"reason,omitempty" db:"reason"`
+}
+
+// NewOrderApproval creates a pending approval for the order that expires after ttl
+func NewOrderApproval(order *Order, ttl time.Duration) *OrderApproval {
+	now := time.Now()
+	return &OrderApproval{
+		OrderID:     order.ID,
+		UserID:      order.UserID,
+		Amount:      order.TotalAmount,
+		Status:      ApprovalPending,
+		RequestedAt: now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+// IsPending reports whether the approval still waits for a decision
+func (a *OrderApproval) IsPending() bool {
+	return a.Status == ApprovalPending
+}
+
+// Decide records the outcome of the approval. decidedBy is nil for expired approvals.
+func (a *OrderApproval) Decide(status ApprovalStatus, decidedBy *uuid.UUID, reason string) {
+	now := time.Now()
+	a.Status = status
+	a.DecidedBy = decidedBy
+	a.DecidedAt = &now
+	a.Reason = reason
+}
+
+// ApprovalDecisionRequest is an operator's approve or reject decision
+type ApprovalDecisionRequest struct {
+	OperatorID uuid.UUID `json:"-"` // Taken from the authenticated session
+	Reason     string    `json:"reason,omitempty"`
+}
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// FulfillmentPolicy decides what happens to an order when only some of its items are in stock
+type FulfillmentPolicy string
+
+const (
+	FulfillmentAllOrNothing FulfillmentPolicy = "all_or_nothing" // The order is refused
+	FulfillmentPartial      FulfillmentPolicy = "partial"        // Stock on hand ships first, the rest is backordered
+	FulfillmentBackorder    FulfillmentPolicy = "backorder"      // The whole order waits until all its stock arrives
+)
+
+// IsValid reports whether the policy is known
+func (p FulfillmentPolicy) IsValid() bool {
+	return p == FulfillmentAllOrNothing || p == FulfillmentPartial || p == FulfillmentBackorder
+}
+
+// ShipmentStatus represents the progress of one shipment of a partially fulfilled order
+type ShipmentStatus string
+
+const (
+	ShipmentBackordered ShipmentStatus = "backordered" // Waiting for stock; neither reserved nor paid
+	ShipmentPending     ShipmentStatus = "pending"     // Reserved, waiting for payment
+	ShipmentPaid        ShipmentStatus = "paid"        // Paid and handed to assembly
+	ShipmentAssembled   ShipmentStatus = "assembled"
+	ShipmentCancelled   ShipmentStatus = "cancelled" // Backorder given up; never charged
+)
+
+// ShipmentItem is a quantity of an order item sent in one shipment
+type ShipmentItem struct {
+	ItemID   string `json:"item_id"`
+	Quantity int    `json:"quantity"`
+}
+
+// Shipment is a part of an order that is reserved, paid and assembled on its own.
+// Orders are only split into shipments when the partial fulfillment policy lets
+// them proceed without all their stock; the stock of a shipment is reserved under
+// the shipment ID rather than the order ID.
+type Shipment struct {
+	ID            uuid.UUID      `json:"id" db:"id"`
+	OrderID       uuid.UUID      `json:"order_id" db:"order_id"`
+	Sequence      int            `json:"sequence" db:"sequence"` // 1 for the stock on hand at order time
+	Status        ShipmentStatus `json:"status" db:"status"`
+	Items         []ShipmentItem `json:"items" db:"-"`  // Stored as JSONB items
+	Amount        money.Money    `json:"amount" db:"-"` // Stored as amount_minor, in the order currency
+	TransactionID string         `json:"transaction_id,omitempty" db:"transaction_id"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at" db:"updated_at"`
+	PaidAt        *time.Time     `json:"paid_at,omitempty" db:"paid_at"`
+	AssembledAt   *time.Time     `json:"assembled_at,omitempty" db:"assembled_at"`
+}
+
+// IsOpen reports whether the shipment is still to be assembled
+func (s *Shipment) IsOpen() bool {
+	return s.Status != ShipmentAssembled && s.Status != ShipmentCancelled
+}
+
+// ItemRequests returns the items of the shipment in the form inventory reserves them
+func (s *Shipment) ItemRequests() []CreateOrderItemRequest {
+	requests := make([]CreateOrderItemRequest, 0, len(s.Items))
+	for _, item := range s.Items {
+		requests = append(requests, CreateOrderItemRequest{ItemID: item.ItemID, Quantity: item.Quantity})
+	}
+	return requests
+}
+
+// SplitShipments splits the order into the stock available now and a backorder
+// for the rest. available holds the quantity in stock per item. Nothing is split
+// if every item is in stock or none is; it reports whether the order was split.
+func (o *Order) SplitShipments(available map[string]int) bool {
+	now := time.Now()
+	first := Shipment{ID: uuid.New(), OrderID: o.ID, Sequence: 1, Status: ShipmentPending, Amount: money.Zero(o.Currency), CreatedAt: now, UpdatedAt: now}
+	backorder := Shipment{ID: uuid.New(), OrderID: o.ID, Sequence: 2, Status: ShipmentBackordered, Amount: money.Zero(o.Currency), CreatedAt: now, UpdatedAt: now}
+
+	for _, item := range o.Items {
+		inStock := available[item.ItemID]
+		if inStock > item.Quantity {
+			inStock = item.Quantity
+		}
+		if inStock < 0 {
+			inStock = 0
+		}
+		if inStock > 0 {
+			first.Items = append(first.Items, ShipmentItem{ItemID: item.ItemID, Quantity: inStock})
+			first.Amount.Minor += item.UnitPrice.Minor * int64(inStock)
+		}
+		if short := item.Quantity - inStock; short > 0 {
+			backorder.Items = append(backorder.Items, ShipmentItem{ItemID: item.ItemID, Quantity: short})
+			backorder.Amount.Minor += item.UnitPrice.Minor * int64(short)
+		}
+	}
+
+	if len(first.Items) == 0 || len(backorder.Items) == 0 {
+		return false
+	}
+	o.Shipments = []Shipment{first, backorder}
+	return true
+}
+
+// ShipmentAwaitingPayment returns the reserved shipment to be paid next, or nil if
+// the order is not split or none is reserved
+func (o *Order) ShipmentAwaitingPayment() *Shipment {
+	for i := range o.Shipments {
+		if o.Shipments[i].Status == ShipmentPending {
+			return &o.Shipments[i]
+		}
+	}
+	return nil
+}
+
+// Backorder returns the shipment waiting for stock, or nil if there is none
+func (o *Order) Backorder() *Shipment {
+	for i := range o.Shipments {
+		if o.Shipments[i].Status == ShipmentBackordered {
+			return &o.Shipments[i]
+		}
+	}
+	return nil
+}
+
+// Shipment returns the shipment with the given ID, or nil if the order has none
+func (o *Order) Shipment(id uuid.UUID) *Shipment {
+	for i := range o.Shipments {
+		if o.Shipments[i].ID == id {
+			return &o.Shipments[i]
+		}
+	}
+	return nil
+}
+
+// OpenShipments returns how many shipments are still to be assembled
+func (o *Order) OpenShipments() int {
+	open := 0
+	for i := range o.Shipments {
+		if o.Shipments[i].IsOpen() {
+			open++
+		}
+	}
+	return open
+}
+
+// AmountDue returns the amount the next payment charges: the shipment awaiting
+// payment for split orders, the total otherwise
+func (o *Order) AmountDue() money.Money {
+	if shipment := o.ShipmentAwaitingPayment(); shipment != nil {
+		return shipment.Amount
+	}
+	return o.TotalAmount
+}
+
+// ReservationID returns the ID the stock awaiting payment is reserved under: the
+// shipment awaiting payment for split orders, the order otherwise
+func (o *Order) ReservationID() uuid.UUID {
+	if shipment := o.ShipmentAwaitingPayment(); shipment != nil {
+		return shipment.ID
+	}
+	return o.ID
+}
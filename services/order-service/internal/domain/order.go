@@ -1,32 +1,41 @@
 package domain
 
 import (
+	"fmt"
+	"slices"
 	"time"
+
 	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
 )
 
 // OrderStatus represents the current status of an order
 type OrderStatus string
 
 const (
-	StatusPending   OrderStatus = "pending"
-	StatusPaid      OrderStatus = "paid"
-	StatusAssembled OrderStatus = "assembled"
-	StatusCompleted OrderStatus = "completed"
-	StatusCancelled OrderStatus = "cancelled"
-	StatusFailed    OrderStatus = "failed"
+	StatusPending            OrderStatus = "pending"
+	StatusPendingApproval    OrderStatus = "pending_approval" // High-value order waiting for an operator before payment
+	StatusPendingReview      OrderStatus = "pending_review"   // Payment held for manual review
+	StatusBackordered        OrderStatus = "backordered"      // Waiting for out-of-stock items; neither reserved nor paid
+	StatusPaid               OrderStatus = "paid"
+	StatusPartiallyAssembled OrderStatus = "partially_assembled" // Some shipments assembled, others still to come
+	StatusAssembled          OrderStatus = "assembled"
+	StatusCompleted          OrderStatus = "completed"
+	StatusCancelled          OrderStatus = "cancelled"
+	StatusFailed             OrderStatus = "failed"
 )
 
 // OrderItem represents a single item in an order
 type OrderItem struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	OrderID   uuid.UUID `json:"order_id" db:"order_id"`
-	ItemID    string    `json:"item_id" db:"item_id"` // Reference to inventory item
-	ItemName  string    `json:"item_name" db:"item_name"`
-	Quantity  int       `json:"quantity" db:"quantity"`
-	UnitPrice float64   `json:"unit_price" db:"unit_price"`
-	Total     float64   `json:"total" db:"total"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID        uuid.UUID   `json:"id" db:"id"`
+	OrderID   uuid.UUID   `json:"order_id" db:"order_id"`
+	ItemID    string      `json:"item_id" db:"item_id"` // Reference to inventory item
+	ItemName  string      `json:"item_name" db:"item_name"`
+	Quantity  int         `json:"quantity" db:"quantity"`
+	UnitPrice money.Money `json:"unit_price" db:"-"` // Stored as unit_price_minor
+	Total     money.Money `json:"total" db:"-"`      // Stored as total_minor
+	CreatedAt time.Time   `json:"created_at" db:"created_at"`
 }
 
 // Order represents a customer order
@@ -35,19 +44,105 @@ type Order struct {
 	UserID      uuid.UUID   `json:"user_id" db:"user_id"`
 	Status      OrderStatus `json:"status" db:"status"`
 	Items       []OrderItem `json:"items,omitempty"`
-	TotalAmount float64     `json:"total_amount" db:"total_amount"`
+	TotalAmount money.Money `json:"total_amount" db:"-"` // Stored as total_amount_minor
 	Currency    string      `json:"currency" db:"currency"`
 	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time   `json:"updated_at" db:"updated_at"`
 	PaidAt      *time.Time  `json:"paid_at,omitempty" db:"paid_at"`
 	AssembledAt *time.Time  `json:"assembled_at,omitempty" db:"assembled_at"`
 	CompletedAt *time.Time  `json:"completed_at,omitempty" db:"completed_at"`
+
+	// SerialNumbers lists the serialized units allocated to the order once payment is confirmed
+	SerialNumbers []SerialAllocation `json:"serial_numbers,omitempty"`
+
+	// Tags and Attributes carry integrators' own references, e.g. a mission ID
+	Tags       []string        `json:"tags,omitempty" db:"-"`       // Stored as JSONB tags
+	Attributes OrderAttributes `json:"attributes,omitempty" db:"-"` // Stored as JSONB attributes
+
+	// ShippingAddress is the snapshot of the address the order ships to, if any
+	ShippingAddress *Address `json:"shipping_address,omitempty" db:"-"` // Stored as JSONB shipping_address
+
+	// FulfillmentPolicy is the policy the order was placed under; Shipments splits it
+	// when it proceeded without all its stock, and is empty when it ships at once
+	FulfillmentPolicy FulfillmentPolicy `json:"fulfillment_policy,omitempty" db:"fulfillment_policy"`
+	Shipments         []Shipment        `json:"shipments,omitempty" db:"-"`
+
+	// Warnings tell about items inventory reported on when the order was placed
+	Warnings []OrderWarning `json:"warnings,omitempty" db:"-"` // Stored as JSONB warnings
+
+	// Locale is the customer's locale when the order was placed, used for the
+	// messages about it
+	Locale string `json:"locale,omitempty" db:"locale"`
+
+	// RefundStatus is the status of the order's refund case, empty when no refund was requested
+	RefundStatus RefundStatus `json:"refund_status,omitempty" db:"refund_status"`
+
+	// Sandbox orders are test data of a sandbox tenant: they are paid through the
+	// payment simulator and left out of reports and metrics
+	Sandbox bool `json:"sandbox,omitempty" db:"sandbox"`
+}
+
+// OrderWarningCode identifies the kind of an order warning
+type OrderWarningCode string
+
+const (
+	// WarningItemDiscontinued reports an item that was discontinued in favour of a replacement
+	WarningItemDiscontinued OrderWarningCode = "item_discontinued"
+)
+
+// OrderWarning is a structured warning about an item of an order
+type OrderWarning struct {
+	Code              OrderWarningCode `json:"code"`
+	ItemID            string           `json:"item_id"`
+	ReplacementItemID string           `json:"replacement_item_id,omitempty"`
+	Substituted       bool             `json:"substituted"` // The replacement was reserved in place of the item
+	Message           string           `json:"message"`
+}
+
+// Message formats of discontinued item warnings, taking the item and its replacement
+const (
+	DiscontinuedReplacementMessage = "item %s is discontinued; its replacement is %s"
+	DiscontinuedSubstitutedMessage = "item %s is discontinued and was substituted by %s"
+)
+
+// NewDiscontinuedItemWarning warns that an item was discontinued in favour of the
+// replacement, which inventory either suggested or substituted
+func NewDiscontinuedItemWarning(itemID, replacementItemID string, substituted bool) OrderWarning {
+	message := fmt.Sprintf(DiscontinuedReplacementMessage, itemID, replacementItemID)
+	if substituted {
+		message = fmt.Sprintf(DiscontinuedSubstitutedMessage, itemID, replacementItemID)
+	}
+	return OrderWarning{
+		Code:              WarningItemDiscontinued,
+		ItemID:            itemID,
+		ReplacementItemID: replacementItemID,
+		Substituted:       substituted,
+		Message:           message,
+	}
+}
+
+// SerialAllocation is a serialized unit of an inventory item allocated to an order
+type SerialAllocation struct {
+	ItemID       string    `json:"item_id" db:"item_id"`
+	SerialNumber string    `json:"serial_number" db:"serial_number"`
+	AllocatedAt  time.Time `json:"allocated_at" db:"allocated_at"`
 }
 
 // CreateOrderRequest represents the request to create a new order
 type CreateOrderRequest struct {
-	UserID uuid.UUID                `json:"user_id"`
-	Items  []CreateOrderItemRequest `json:"items"`
+	UserID     uuid.UUID                `json:"user_id"`
+	Items      []CreateOrderItemRequest `json:"items"`
+	Expedited  bool                     `json:"expedited,omitempty"` // May preempt standard reservations nearing expiry
+	Tags       []string                 `json:"tags,omitempty"`
+	Attributes OrderAttributes          `json:"attributes,omitempty"`
+
+	// The order ships to the address book entry AddressID or to ShippingAddress, at most
+	// one of them; without either it ships to the user's default address, if any
+	AddressID       *uuid.UUID `json:"address_id,omitempty"`
+	ShippingAddress *Address   `json:"shipping_address,omitempty"`
+
+	// FulfillmentPolicy overrides the service default for items short of stock
+	FulfillmentPolicy FulfillmentPolicy `json:"fulfillment_policy,omitempty"`
 }
 
 // CreateOrderItemRequest represents an item in the create order request
@@ -63,35 +158,47 @@ type UpdateOrderStatusRequest struct {
 
 // OrderFilter represents filters for querying orders
 type OrderFilter struct {
-	UserID *uuid.UUID   `json:"user_id,omitempty"`
-	Status *OrderStatus `json:"status,omitempty"`
-	Limit  int          `json:"limit,omitempty"`
-	Offset int          `json:"offset,omitempty"`
+	UserID    *uuid.UUID     `json:"user_id,omitempty"`
+	Status    *OrderStatus   `json:"status,omitempty"`
+	Limit     int            `json:"limit,omitempty"`
+	Offset    int            `json:"offset,omitempty"` // Deprecated: use PageToken
+	PageToken string         `json:"page_token,omitempty"`
+	After     *OrderPosition `json:"-"` // Set from PageToken; only orders listed after it match
+
+	// Tags and Attributes match orders carrying every tag and attribute value given;
+	// attribute values are text matched as in OrderAttributes.Matches
+	Tags       []string          `json:"tags,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// CreatedFrom and CreatedTo bound the creation time of matching orders; the
+	// range includes CreatedFrom and excludes CreatedTo
+	CreatedFrom *time.Time `json:"created_from,omitempty"`
+	CreatedTo   *time.Time `json:"created_to,omitempty"`
+}
+
+// OrderPosition is the place of an order in lists sorted newest first
+type OrderPosition struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
 }
 
-// CalculateTotal calculates the total amount for the order
-func (o *Order) CalculateTotal() {
-	total := 0.0
+// CalculateTotal calculates the total amount for the order.
+// Every item must be priced in the currency of the order.
+func (o *Order) CalculateTotal() error {
+	total := money.Zero(o.Currency)
 	for _, item := range o.Items {
-		total += item.Total
+		var err error
+		if total, err = total.Add(item.Total); err != nil {
+			return fmt.Errorf("item %s: %w", item.ItemID, err)
+		}
 	}
 	o.TotalAmount = total
+	return nil
 }
 
 // CanUpdateStatus checks if the order status can be updated to the new status
 func (o *Order) CanUpdateStatus(newStatus OrderStatus) bool {
-	switch o.Status {
-	case StatusPending:
-		return newStatus == StatusPaid || newStatus == StatusCancelled || newStatus == StatusFailed
-	case StatusPaid:
-		return newStatus == StatusAssembled || newStatus == StatusCancelled || newStatus == StatusFailed
-	case StatusAssembled:
-		return newStatus == StatusCompleted || newStatus == StatusFailed
-	case StatusCompleted, StatusCancelled, StatusFailed:
-		return false // Terminal states
-	default:
-		return false
-	}
+	return o.Status.CanTransitionTo(newStatus)
 }
 
 // UpdateStatus updates the order status and sets appropriate timestamps
@@ -114,4 +221,61 @@ func (o *Order) UpdateStatus(newStatus OrderStatus) bool {
 	}
 
 	return true
-}
\ No newline at end of file
+}
+
+// Clone returns a deep copy of the order, sharing nothing a caller may modify
+func (o *Order) Clone() *Order {
+	clone := *o
+	clone.PaidAt = cloneTime(o.PaidAt)
+	clone.AssembledAt = cloneTime(o.AssembledAt)
+	clone.CompletedAt = cloneTime(o.CompletedAt)
+	clone.Items = slices.Clone(o.Items)
+	clone.SerialNumbers = slices.Clone(o.SerialNumbers)
+	clone.Tags = slices.Clone(o.Tags)
+	clone.Warnings = slices.Clone(o.Warnings)
+	if o.Attributes != nil {
+		clone.Attributes = cloneValue(map[string]interface{}(o.Attributes)).(map[string]interface{})
+	}
+	if o.ShippingAddress != nil {
+		address := *o.ShippingAddress
+		clone.ShippingAddress = &address
+	}
+	if o.Shipments != nil {
+		clone.Shipments = make([]Shipment, len(o.Shipments))
+		for i, shipment := range o.Shipments {
+			shipment.Items = slices.Clone(shipment.Items)
+			shipment.PaidAt = cloneTime(shipment.PaidAt)
+			shipment.AssembledAt = cloneTime(shipment.AssembledAt)
+			clone.Shipments[i] = shipment
+		}
+	}
+	return &clone
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	return &clone
+}
+
+// cloneValue deep-copies the maps and slices of a decoded JSON value
+func cloneValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			clone[key] = cloneValue(item)
+		}
+		return clone
+	case []interface{}:
+		clone := make([]interface{}, len(v))
+		for i, item := range v {
+			clone[i] = cloneValue(item)
+		}
+		return clone
+	default:
+		return v
+	}
+}
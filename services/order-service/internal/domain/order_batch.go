@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BatchStatus represents the state of an asynchronous order batch
+type BatchStatus string
+
+const (
+	BatchRunning   BatchStatus = "running"   // Orders are still being created
+	BatchCompleted BatchStatus = "completed" // Every order has a result
+)
+
+// BatchOutcome is the result of a single order of a batch
+type BatchOutcome string
+
+const (
+	BatchOrderAccepted          BatchOutcome = "accepted"           // The order was created
+	BatchOrderValidationError   BatchOutcome = "validation_error"   // The order was rejected as invalid
+	BatchOrderInventoryShortage BatchOutcome = "inventory_shortage" // Not enough stock for an item of the order
+	BatchOrderFailed            BatchOutcome = "failed"             // Any other failure, such as a declined payment
+)
+
+// BatchOrderResult is the outcome of the order at Index of a batch request
+type BatchOrderResult struct {
+	Index       int          `json:"index"`
+	Outcome     BatchOutcome `json:"outcome"`
+	OrderID     *uuid.UUID   `json:"order_id,omitempty"`
+	OrderStatus OrderStatus  `json:"order_status,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// OrderBatch tracks an order batch submitted for asynchronous processing. Results are
+// recorded as orders finish, so they are in completion order rather than request order.
+type OrderBatch struct {
+	ID          uuid.UUID          `json:"id" db:"id"`
+	Status      BatchStatus        `json:"status" db:"status"`
+	OrderCount  int                `json:"order_count" db:"order_count"`
+	Results     []BatchOrderResult `json:"results" db:"-"` // Stored as a JSONB array
+	CreatedAt   time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" db:"updated_at"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// NewOrderBatch creates a running batch of orderCount orders
+func NewOrderBatch(orderCount int) *OrderBatch {
+	now := time.Now()
+	return &OrderBatch{
+		ID:         uuid.New(),
+		Status:     BatchRunning,
+		OrderCount: orderCount,
+		Results:    []BatchOrderResult{},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// CountOutcomes counts the results of a batch by outcome
+func CountOutcomes(results []BatchOrderResult) map[BatchOutcome]int {
+	counts := make(map[BatchOutcome]int)
+	for _, result := range results {
+		counts[result.Outcome]++
+	}
+	return counts
+}
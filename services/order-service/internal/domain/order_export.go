@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// OrderExportFormat is the file format of an order export
+type OrderExportFormat string
+
+const (
+	ExportFormatCSV  OrderExportFormat = "csv"
+	ExportFormatXLSX OrderExportFormat = "xlsx"
+)
+
+// IsValid checks if the format is supported
+func (f OrderExportFormat) IsValid() bool {
+	return f == ExportFormatCSV || f == ExportFormatXLSX
+}
+
+// ContentType returns the media type of files in the format
+func (f OrderExportFormat) ContentType() string {
+	if f == ExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// OrderExportRequest selects the orders and columns of an export. Paging fields of
+// the filter are ignored: an export holds every matching order, newest first.
+type OrderExportRequest struct {
+	Filter  OrderFilter       `json:"filter"`
+	Format  OrderExportFormat `json:"format"`
+	Columns []string          `json:"columns"`
+}
+
+// OrderExportFile is the file written by a background export, with a signed URL
+// to download it
+type OrderExportFile struct {
+	Key         string            `json:"key"`
+	Format      OrderExportFormat `json:"format"`
+	ContentType string            `json:"content_type"`
+	Rows        int64             `json:"rows"`
+	Size        int64             `json:"size"`
+	URL         string            `json:"url,omitempty"`
+	URLExpires  *time.Time        `json:"url_expires,omitempty"`
+}
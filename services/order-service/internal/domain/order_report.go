@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// ReportEventType is the kind of event the reporting projection applies
+type ReportEventType string
+
+const (
+	ReportEventOrderCreated      ReportEventType = "order.created"
+	ReportEventStatusChanged     ReportEventType = "order.status.changed"
+	ReportEventPaymentProcessed  ReportEventType = "payment.processed"
+	ReportEventPaymentReviewed   ReportEventType = "payment.reviewed"
+	ReportEventAssemblyCompleted ReportEventType = "assembly.completed"
+	ReportEventAssemblyFailed    ReportEventType = "assembly.failed"
+)
+
+// ReportEvent is an order, payment or assembly event reduced to what the order
+// reports need. Only the fields of its type are set.
+type ReportEvent struct {
+	ID         string // Applied at most once
+	Type       ReportEventType
+	OrderID    uuid.UUID
+	UserID     uuid.UUID
+	OccurredAt time.Time
+
+	Status          OrderStatus // Status changed: the status entered
+	Total           money.Money // Order created
+	ItemCount       int         // Order created: units ordered
+	TransactionID   string      // Payment events
+	PaymentStatus   string      // Payment events
+	AssemblySeconds int         // Assembly completed
+	FailureReason   string      // Assembly failed
+	Sandbox         bool        // Order events: a test order of a sandbox tenant
+}
+
+// OrderReport is the reporting read model of an order: the order joined with
+// the outcome of its payment and assembly. It is built from events only and
+// never read by the order saga.
+type OrderReport struct {
+	OrderID         uuid.UUID   `db:"order_id"`
+	UserID          uuid.UUID   `db:"user_id"`
+	Status          OrderStatus `db:"status"`
+	Total           money.Money `db:"-"` // Stored as total_minor and currency
+	ItemCount       int         `db:"item_count"`
+	TransactionID   string      `db:"transaction_id"`
+	PaymentStatus   string      `db:"payment_status"`
+	AssemblyStatus  string      `db:"assembly_status"` // "completed" or "failed" once assembly has finished
+	AssemblySeconds int         `db:"assembly_seconds"`
+	FailureReason   string      `db:"failure_reason"`
+	Sandbox         bool        `db:"sandbox"` // Test orders are left out of listings and summaries
+	CreatedAt       *time.Time  `db:"created_at"`
+	PaidAt          *time.Time  `db:"paid_at"`
+	AssembledAt     *time.Time  `db:"assembled_at"`
+	CompletedAt     *time.Time  `db:"completed_at"`
+	CancelledAt     *time.Time  `db:"cancelled_at"`
+	FailedAt        *time.Time  `db:"failed_at"`
+	StatusChangedAt time.Time   `db:"status_changed_at"` // Time of the event the status was taken from
+	UpdatedAt       time.Time   `db:"updated_at"`
+}
+
+// NewOrderReport creates the empty report of an order
+func NewOrderReport(orderID uuid.UUID) *OrderReport {
+	return &OrderReport{OrderID: orderID}
+}
+
+// Apply folds an event into the report. Events of the different topics arrive
+// in no particular order, so every field is set independently and the status
+// is only replaced by a status at least as recent.
+func (r *OrderReport) Apply(event ReportEvent) {
+	if r.UserID == uuid.Nil {
+		r.UserID = event.UserID
+	}
+	if event.Sandbox {
+		r.Sandbox = true
+	}
+
+	switch event.Type {
+	case ReportEventOrderCreated:
+		r.Total = event.Total
+		r.ItemCount = event.ItemCount
+		r.CreatedAt = timeOf(event)
+		if r.Status == "" {
+			r.setStatus(StatusPending, event.OccurredAt)
+		}
+	case ReportEventStatusChanged:
+		r.setStatus(event.Status, event.OccurredAt)
+		switch event.Status {
+		case StatusPaid:
+			r.PaidAt = firstTime(r.PaidAt, event)
+		case StatusAssembled:
+			r.AssembledAt = firstTime(r.AssembledAt, event)
+		case StatusCompleted:
+			r.CompletedAt = firstTime(r.CompletedAt, event)
+		case StatusCancelled:
+			r.CancelledAt = firstTime(r.CancelledAt, event)
+		case StatusFailed:
+			r.FailedAt = firstTime(r.FailedAt, event)
+		}
+	case ReportEventPaymentProcessed:
+		r.TransactionID = event.TransactionID
+		r.PaymentStatus = event.PaymentStatus
+		r.PaidAt = firstTime(r.PaidAt, event)
+	case ReportEventPaymentReviewed:
+		r.TransactionID = event.TransactionID
+		r.PaymentStatus = event.PaymentStatus
+		if event.PaymentStatus == "completed" {
+			r.PaidAt = firstTime(r.PaidAt, event)
+		}
+	case ReportEventAssemblyCompleted:
+		r.AssemblyStatus = "completed"
+		r.AssemblySeconds = event.AssemblySeconds
+		r.FailureReason = ""
+		r.AssembledAt = firstTime(r.AssembledAt, event)
+	case ReportEventAssemblyFailed:
+		r.AssemblyStatus = "failed"
+		r.FailureReason = event.FailureReason
+	}
+}
+
+// setStatus replaces the status unless the report already holds a later one
+func (r *OrderReport) setStatus(status OrderStatus, at time.Time) {
+	if r.Status != "" && at.Before(r.StatusChangedAt) {
+		return
+	}
+	r.Status = status
+	r.StatusChangedAt = at
+}
+
+// firstTime keeps a timestamp that is already set, otherwise takes the event's
+func firstTime(current *time.Time, event ReportEvent) *time.Time {
+	if current != nil {
+		return current
+	}
+	return timeOf(event)
+}
+
+func timeOf(event ReportEvent) *time.Time {
+	at := event.OccurredAt
+	return &at
+}
+
+// ReportFilter selects order reports; orders are listed newest first
+type ReportFilter struct {
+	UserID        *uuid.UUID
+	Status        *OrderStatus
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Limit         int
+	Offset        int
+}
+
+// ReportSummary aggregates the order reports of a period
+type ReportSummary struct {
+	From             time.Time
+	To               time.Time
+	OrderCount       int
+	ByStatus         map[OrderStatus]int
+	Revenue          []money.Money // Total of paid orders, one per currency
+	AssemblyFailures int
+	AvgAssemblyTime  time.Duration // Mean assembly duration of assembled orders
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidTransition is matched (via errors.Is) by every rejected status transition
+var ErrInvalidTransition = errors.New("invalid order status transition")
+
+// OrderStatuses lists every status known to the state machine
+var OrderStatuses = []OrderStatus{
+	StatusPending, StatusPendingApproval, StatusPendingReview, StatusBackordered, StatusPaid,
+	StatusPartiallyAssembled, StatusAssembled, StatusCompleted, StatusCancelled, StatusFailed,
+}
+
+// orderTransitions is the order state machine: the statuses each status may move to.
+// Statuses without an entry are terminal.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	StatusPending:            {StatusPaid, StatusPendingApproval, StatusPendingReview, StatusCancelled, StatusFailed},
+	StatusPendingApproval:    {StatusPaid, StatusPendingReview, StatusCancelled, StatusFailed},
+	StatusPendingReview:      {StatusPaid, StatusCancelled, StatusFailed},
+	StatusBackordered:        {StatusPending, StatusCancelled, StatusFailed},
+	StatusPaid:               {StatusAssembled, StatusPartiallyAssembled, StatusCancelled, StatusFailed},
+	StatusPartiallyAssembled: {StatusAssembled, StatusFailed},
+	StatusAssembled:          {StatusCompleted, StatusFailed},
+}
+
+// TransitionError describes a status change the state machine does not allow
+type TransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+// Error implements the error interface
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot update order status from %s to %s", e.From, e.To)
+}
+
+// Is makes errors.Is(err, ErrInvalidTransition) match any TransitionError
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// IsValid reports whether the status is known to the state machine
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case StatusPending, StatusPendingApproval, StatusPendingReview, StatusBackordered, StatusPaid,
+		StatusPartiallyAssembled, StatusAssembled, StatusCompleted, StatusCancelled, StatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTerminal reports whether no further transitions are possible from the status
+func (s OrderStatus) IsTerminal() bool {
+	return len(orderTransitions[s]) == 0
+}
+
+// CanTransitionTo reports whether the state machine allows moving from s to the target status
+func (s OrderStatus) CanTransitionTo(target OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == target {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns a *TransitionError if the state machine does not allow from -> to
+func ValidateTransition(from, to OrderStatus) error {
+	if !from.CanTransitionTo(to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+// StatusTransition is a status change applied to an order
+type StatusTransition struct {
+	From OrderStatus
+	To   OrderStatus
+}
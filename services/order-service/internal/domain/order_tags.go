@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Limits of the tags and custom attributes of an order
+const (
+	MaxOrderTags              = 20
+	MaxOrderTagLength         = 64
+	MaxOrderAttributes        = 20
+	MaxOrderAttributeValueLen = 256
+)
+
+// orderLabelPattern matches tags and attribute names: lowercase letters, digits and
+// "_", "-", ".", ":" or "/", starting with a letter or digit, e.g. "mission:artemis-3"
+var orderLabelPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.:/-]*$`)
+
+// OrderAttributes are typed custom attributes integrators attach to an order, such as
+// {"mission_id": "ART-3", "priority": 2, "export_controlled": true}. Values are
+// strings, numbers or booleans.
+type OrderAttributes map[string]interface{}
+
+// NormalizeOrderTags lowercases, deduplicates and sorts tags, rejecting invalid ones
+func NormalizeOrderTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if err := validateOrderLabel("tag", tag); err != nil {
+			return nil, err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	if len(normalized) > MaxOrderTags {
+		return nil, fmt.Errorf("an order has at most %d tags", MaxOrderTags)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// Validate checks attribute names and that every value is a string, number or boolean
+func (a OrderAttributes) Validate() error {
+	if len(a) > MaxOrderAttributes {
+		return fmt.Errorf("an order has at most %d attributes", MaxOrderAttributes)
+	}
+	for name, value := range a {
+		if err := validateOrderLabel("attribute name", name); err != nil {
+			return err
+		}
+		switch v := value.(type) {
+		case string:
+			if len(v) > MaxOrderAttributeValueLen {
+				return fmt.Errorf("attribute %q is longer than %d characters", name, MaxOrderAttributeValueLen)
+			}
+		case float64, int, int64, bool:
+		default:
+			return fmt.Errorf("attribute %q must be a string, number or boolean", name)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the attribute has the value of a filter given as text:
+// "42" matches the number 42 and the string "42", "true" the boolean and the string
+func (a OrderAttributes) Matches(name, value string) bool {
+	stored, ok := a[name]
+	if !ok {
+		return false
+	}
+	for _, candidate := range AttributeFilterValues(value) {
+		if fmt.Sprint(candidate) == fmt.Sprint(stored) && sameAttributeType(candidate, stored) {
+			return true
+		}
+	}
+	return false
+}
+
+// AttributeFilterValues returns the typed values an attribute filter given as text
+// matches: always the string, plus the number or boolean it parses as
+func AttributeFilterValues(value string) []interface{} {
+	values := []interface{}{value}
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		values = append(values, number)
+	} else if boolean, err := strconv.ParseBool(value); err == nil && (value == "true" || value == "false") {
+		values = append(values, boolean)
+	}
+	return values
+}
+
+// HasTags reports whether the order carries every given tag
+func (o *Order) HasTags(tags []string) bool {
+	for _, wanted := range tags {
+		found := false
+		for _, tag := range o.Tags {
+			if tag == wanted {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func validateOrderLabel(kind, label string) error {
+	if label == "" {
+		return fmt.Errorf("%s cannot be empty", kind)
+	}
+	if len(label) > MaxOrderTagLength {
+		return fmt.Errorf("%s %q is longer than %d characters", kind, label, MaxOrderTagLength)
+	}
+	if !orderLabelPattern.MatchString(label) {
+		return fmt.Errorf("%s %q may only contain lowercase letters, digits and _ - . : /", kind, label)
+	}
+	return nil
+}
+
+func sameAttributeType(a, b interface{}) bool {
+	switch a.(type) {
+	case string:
+		_, ok := b.(string)
+		return ok
+	case bool:
+		_, ok := b.(bool)
+		return ok
+	default:
+		_, isString := b.(string)
+		_, isBool := b.(bool)
+		return !isString && !isBool
+	}
+}
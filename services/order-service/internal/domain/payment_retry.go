@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentRetryStatus represents the state of a payment retry schedule
+type PaymentRetryStatus string
+
+const (
+	PaymentRetryScheduled PaymentRetryStatus = "scheduled" // Waiting for the next attempt
+	PaymentRetrySucceeded PaymentRetryStatus = "succeeded" // A retry was paid or held for review
+	PaymentRetryExhausted PaymentRetryStatus = "exhausted" // Out of attempts; the order failed
+	PaymentRetryDeclined  PaymentRetryStatus = "declined"  // A retry was declined or failed for good; the order failed
+	PaymentRetryCancelled PaymentRetryStatus = "cancelled" // The order left pending before the next attempt
+)
+
+// PaymentRetry is the retry schedule of an order whose payment failed transiently, one per order
+type PaymentRetry struct {
+	OrderID       uuid.UUID          `json:"order_id" db:"order_id"`
+	UserID        uuid.UUID          `json:"user_id" db:"user_id"`
+	Status        PaymentRetryStatus `json:"status" db:"status"`
+	Attempts      int                `json:"attempts" db:"attempts"` // Retries made so far
+	MaxAttempts   int                `json:"max_attempts" db:"max_attempts"`
+	NextAttemptAt time.Time          `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError     string             `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt     time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// NewPaymentRetry schedules the first retry of the order's payment after delay
+func NewPaymentRetry(order *Order, maxAttempts int, delay time.Duration, lastError string) *PaymentRetry {
+	now := time.Now()
+	return &PaymentRetry{
+		OrderID:       order.ID,
+		UserID:        order.UserID,
+		Status:        PaymentRetryScheduled,
+		MaxAttempts:   maxAttempts,
+		NextAttemptAt: now.Add(delay),
+		LastError:     lastError,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// IsScheduled reports whether another attempt is due
+func (r *PaymentRetry) IsScheduled() bool {
+	return r.Status == PaymentRetryScheduled
+}
+
+// Exhausted reports whether every attempt was made
+func (r *PaymentRetry) Exhausted() bool {
+	return r.Attempts >= r.MaxAttempts
+}
+
+// Reschedule records a failed attempt and schedules the next one after delay
+func (r *PaymentRetry) Reschedule(delay time.Duration, lastError string) {
+	now := time.Now()
+	r.NextAttemptAt = now.Add(delay)
+	r.LastError = lastError
+	r.UpdatedAt = now
+}
+
+// Finish ends the schedule with status
+func (r *PaymentRetry) Finish(status PaymentRetryStatus, lastError string) {
+	r.Status = status
+	if lastError != "" {
+		r.LastError = lastError
+	}
+	r.UpdatedAt = time.Now()
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// RefundStatus represents the state of an order's refund case
+type RefundStatus string
+
+const (
+	RefundRequested RefundStatus = "requested" // Waiting for support
+	RefundApproved  RefundStatus = "approved"  // Approved by support; the payment refund is under way
+	RefundRejected  RefundStatus = "rejected"  // Rejected by support; the order stands
+	RefundRefunded  RefundStatus = "refunded"  // Payment refunded and the items returned to stock
+	RefundFailed    RefundStatus = "failed"    // The payment refund failed; support may approve again
+)
+
+// RefundCase is a customer's request to refund an order, reviewed by support
+type RefundCase struct {
+	ID              uuid.UUID    `json:"id" db:"id"`
+	OrderID         uuid.UUID    `json:"order_id" db:"order_id"`
+	UserID          uuid.UUID    `json:"user_id" db:"user_id"`
+	Amount          money.Money  `json:"amount" db:"-"` // Stored as amount_minor and currency
+	Reason          string       `json:"reason" db:"reason"`
+	Status          RefundStatus `json:"status" db:"status"`
+	RequestedAt     time.Time    `json:"requested_at" db:"requested_at"`
+	DecidedBy       *uuid.UUID   `json:"decided_by,omitempty" db:"decided_by"`
+	DecidedAt       *time.Time   `json:"decided_at,omitempty" db:"decided_at"`
+	Note            string       `json:"note,omitempty" db:"note"` // Support's note to the customer; required to reject
+	PaymentRefundID string       `json:"payment_refund_id,omitempty" db:"payment_refund_id"`
+	RefundedAt      *time.Time   `json:"refunded_at,omitempty" db:"refunded_at"`
+	FailureReason   string       `json:"failure_reason,omitempty" db:"failure_reason"`
+}
+
+// NewRefundCase opens a refund case for the full amount of the order
+func NewRefundCase(order *Order, reason string) *RefundCase {
+	return &RefundCase{
+		ID:          uuid.New(),
+		OrderID:     order.ID,
+		UserID:      order.UserID,
+		Amount:      order.TotalAmount,
+		Reason:      reason,
+		Status:      RefundRequested,
+		RequestedAt: time.Now(),
+	}
+}
+
+// IsOpen reports whether support can still decide on the refund
+func (c *RefundCase) IsOpen() bool {
+	return c.Status == RefundRequested || c.Status == RefundFailed
+}
+
+// Decide records support's decision on the refund
+func (c *RefundCase) Decide(status RefundStatus, decidedBy uuid.UUID, note string) {
+	now := time.Now()
+	c.Status = status
+	c.DecidedBy = &decidedBy
+	c.DecidedAt = &now
+	c.Note = note
+	c.FailureReason = ""
+}
+
+// MarkRefunded records the payment refund of an approved case
+func (c *RefundCase) MarkRefunded(paymentRefundID string) {
+	now := time.Now()
+	c.Status = RefundRefunded
+	c.PaymentRefundID = paymentRefundID
+	c.RefundedAt = &now
+}
+
+// MarkFailed records that the payment refund of an approved case failed
+func (c *RefundCase) MarkFailed(reason string) {
+	c.Status = RefundFailed
+	c.FailureReason = reason
+}
+
+// IsRefundable reports whether an order in the status was paid and can be refunded
+func (s OrderStatus) IsRefundable() bool {
+	switch s {
+	case StatusPaid, StatusPartiallyAssembled, StatusAssembled, StatusCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// RefundRequest is a customer's request to refund their order
+type RefundRequest struct {
+	CustomerID uuid.UUID `json:"-"` // Taken from the authenticated session
+	Reason     string    `json:"reason"`
+}
+
+// RefundDecisionRequest is support's approve or reject decision on a refund
+type RefundDecisionRequest struct {
+	OperatorID uuid.UUID `json:"-"` // Taken from the authenticated session
+	Note       string    `json:"note,omitempty"`
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SagaStepName identifies a step of the order saga
+type SagaStepName string
+
+const (
+	SagaStepReserveInventory   SagaStepName = "reserve_inventory"
+	SagaStepApproval           SagaStepName = "approval"
+	SagaStepPayment            SagaStepName = "payment"
+	SagaStepPaymentReview      SagaStepName = "payment_review"
+	SagaStepConfirmReservation SagaStepName = "confirm_reservation"
+	SagaStepPublishPayment     SagaStepName = "publish_payment"
+	SagaStepAssembly           SagaStepName = "assembly"
+	SagaStepReleaseInventory   SagaStepName = "release_inventory" // Compensates reserve_inventory
+)
+
+// IsCompensation reports whether the step undoes an earlier one
+func (n SagaStepName) IsCompensation() bool {
+	return n == SagaStepReleaseInventory
+}
+
+// SagaStepStatus is the outcome recorded for a saga step
+type SagaStepStatus string
+
+const (
+	SagaStepStarted   SagaStepStatus = "started"
+	SagaStepSucceeded SagaStepStatus = "succeeded"
+	SagaStepFailed    SagaStepStatus = "failed"
+	SagaStepWaiting   SagaStepStatus = "waiting"  // Paused on an operator, a reviewer or another service
+	SagaStepRetrying  SagaStepStatus = "retrying" // Failed transiently; another attempt follows
+)
+
+// SagaStepRecord is one entry of the append-only saga history of an order
+type SagaStepRecord struct {
+	ID           uuid.UUID      `json:"id" db:"id"`
+	OrderID      uuid.UUID      `json:"order_id" db:"order_id"`
+	Step         SagaStepName   `json:"step" db:"step"`
+	Status       SagaStepStatus `json:"status" db:"status"`
+	Attempt      int            `json:"attempt" db:"attempt"`
+	Compensation bool           `json:"compensation" db:"compensation"`
+	Error        string         `json:"error,omitempty" db:"error"`
+	RecordedAt   time.Time      `json:"recorded_at" db:"recorded_at"`
+}
+
+// NewSagaStepRecord records the outcome of an attempt of a step, counted from 1
+func NewSagaStepRecord(orderID uuid.UUID, step SagaStepName, status SagaStepStatus, attempt int, cause error) *SagaStepRecord {
+	if attempt < 1 {
+		attempt = 1
+	}
+	record := &SagaStepRecord{
+		ID:           uuid.New(),
+		OrderID:      orderID,
+		Step:         step,
+		Status:       status,
+		Attempt:      attempt,
+		Compensation: step.IsCompensation(),
+		RecordedAt:   time.Now(),
+	}
+	if cause != nil {
+		record.Error = cause.Error()
+	}
+	return record
+}
+
+// SagaStep summarizes the history of one step
+type SagaStep struct {
+	Step         SagaStepName   `json:"step"`
+	Status       SagaStepStatus `json:"status"`   // Of the latest entry
+	Attempts     int            `json:"attempts"` // Highest attempt recorded
+	Compensation bool           `json:"compensation"`
+	LastError    string         `json:"last_error,omitempty"`
+	StartedAt    time.Time      `json:"started_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// SagaTimeline is the saga of an order as shown to operators
+type SagaTimeline struct {
+	OrderID     uuid.UUID         `json:"order_id"`
+	OrderStatus OrderStatus       `json:"order_status"`
+	Steps       []SagaStep        `json:"steps"`   // In the order the steps were first reached
+	History     []*SagaStepRecord `json:"history"` // Every entry, oldest first
+}
+
+// NewSagaTimeline summarizes the saga history of an order, given oldest first
+func NewSagaTimeline(order *Order, history []*SagaStepRecord) *SagaTimeline {
+	timeline := &SagaTimeline{
+		OrderID:     order.ID,
+		OrderStatus: order.Status,
+		Steps:       []SagaStep{},
+		History:     history,
+	}
+
+	index := make(map[SagaStepName]int)
+	for _, record := range history {
+		i, ok := index[record.Step]
+		if !ok {
+			i = len(timeline.Steps)
+			index[record.Step] = i
+			timeline.Steps = append(timeline.Steps, SagaStep{
+				Step:         record.Step,
+				Compensation: record.Compensation,
+				StartedAt:    record.RecordedAt,
+			})
+		}
+
+		step := &timeline.Steps[i]
+		step.Status = record.Status
+		step.UpdatedAt = record.RecordedAt
+		if record.Attempt > step.Attempts {
+			step.Attempts = record.Attempt
+		}
+		if record.Error != "" {
+			step.LastError = record.Error
+		}
+	}
+	return timeline
+}
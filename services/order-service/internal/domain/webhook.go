@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEventOrderStatusChanged is sent whenever a subscribed order changes status
+const WebhookEventOrderStatusChanged = "order.status_changed"
+
+// WebhookDeliveryStatus represents the state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // Waiting for its first or next attempt
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // Endpoint answered with 2xx
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // Gave up after the maximum attempts
+)
+
+// Webhook is a customer endpoint notified about status changes of the customer's orders
+type Webhook struct {
+	ID          uuid.UUID     `json:"id" db:"id"`
+	UserID      uuid.UUID     `json:"user_id" db:"user_id"`
+	URL         string        `json:"url" db:"url"`
+	Secret      string        `json:"-" db:"secret"` // Signs every delivery; only returned on creation
+	Description string        `json:"description,omitempty" db:"description"`
+	Statuses    []OrderStatus `json:"statuses" db:"-"` // Order statuses to notify about; empty means all
+	Active      bool          `json:"active" db:"active"`
+	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// Validate checks that the webhook can be delivered to. Hosts resolving to
+// internal addresses are only refused when delivering, see WebhookAddressAllowed.
+func (w *Webhook) Validate() error {
+	endpoint, err := url.Parse(w.URL)
+	if err != nil || endpoint.Hostname() == "" {
+		return fmt.Errorf("url must be an absolute URL")
+	}
+	if endpoint.Scheme != "https" {
+		return fmt.Errorf("url must use https")
+	}
+	host := strings.ToLower(strings.TrimSuffix(endpoint.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("url must not point to an internal address")
+	}
+	if ip := net.ParseIP(host); ip != nil && !WebhookAddressAllowed(ip) {
+		return fmt.Errorf("url must not point to an internal address")
+	}
+	for _, status := range w.Statuses {
+		if !status.IsValid() {
+			return fmt.Errorf("unknown order status %q", status)
+		}
+	}
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, 100.64.0.0/10, which
+// net.IP doesn't classify as private
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// WebhookAddressAllowed reports whether webhooks may be delivered to the address:
+// customers choose the URL, so loopback, private, link-local (including cloud
+// metadata endpoints), multicast and unspecified addresses are refused.
+func WebhookAddressAllowed(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	if ip4 := ip.To4(); ip4 != nil && (ip4[0] == 0 || sharedAddressSpace.Contains(ip4)) {
+		return false
+	}
+	return true
+}
+
+// Subscribes reports whether the webhook wants to be notified when an order enters the status
+func (w *Webhook) Subscribes(status OrderStatus) bool {
+	if !w.Active {
+		return false
+	}
+	if len(w.Statuses) == 0 {
+		return true
+	}
+	for _, subscribed := range w.Statuses {
+		if subscribed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event sent, or to be sent, to a webhook along with its attempts
+type WebhookDelivery struct {
+	ID               uuid.UUID             `json:"id" db:"id"`
+	WebhookID        uuid.UUID             `json:"webhook_id" db:"webhook_id"`
+	OrderID          uuid.UUID             `json:"order_id" db:"order_id"`
+	EventType        string                `json:"event_type" db:"event_type"`
+	Payload          []byte                `json:"-" db:"payload"`
+	Status           WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts         int                   `json:"attempts" db:"attempts"`
+	NextAttemptAt    *time.Time            `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	LastResponseCode *int                  `json:"last_response_code,omitempty" db:"last_response_code"`
+	LastError        *string               `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt        time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at" db:"updated_at"`
+	DeliveredAt      *time.Time            `json:"delivered_at,omitempty" db:"delivered_at"`
+}
+
+// NewWebhookDelivery creates a delivery due immediately
+func NewWebhookDelivery(webhookID, orderID uuid.UUID, eventType string, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:            uuid.New(),
+		WebhookID:     webhookID,
+		OrderID:       orderID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// RecordSuccess marks the delivery as accepted by the endpoint
+func (d *WebhookDelivery) RecordSuccess(responseCode int) {
+	now := time.Now()
+	d.Attempts++
+	d.Status = WebhookDeliverySucceeded
+	d.LastResponseCode = &responseCode
+	d.LastError = nil
+	d.NextAttemptAt = nil
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// RecordFailure records a failed attempt. The delivery is retried at retryAt, or
+// marked failed when retryAt is nil.
+func (d *WebhookDelivery) RecordFailure(responseCode int, message string, retryAt *time.Time) {
+	d.Attempts++
+	d.LastError = &message
+	d.LastResponseCode = nil
+	if responseCode > 0 {
+		d.LastResponseCode = &responseCode
+	}
+	d.NextAttemptAt = retryAt
+	if retryAt == nil {
+		d.Status = WebhookDeliveryFailed
+	}
+	d.UpdatedAt = time.Now()
+}
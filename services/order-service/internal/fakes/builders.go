@@ -0,0 +1,126 @@
+package fakes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// OrderBuilder builds orders the way the order service creates them, with the total
+// calculated from the items
+type OrderBuilder struct {
+	userID    uuid.UUID
+	currency  string
+	createdAt time.Time
+	items     []domain.OrderItem
+	mutations []func(*domain.Order)
+}
+
+// NewOrder starts building a pending USD order of a random user with no items
+func NewOrder() *OrderBuilder {
+	return &OrderBuilder{
+		userID:    uuid.New(),
+		currency:  "USD",
+		createdAt: time.Now(),
+	}
+}
+
+// ForUser sets the user placing the order
+func (b *OrderBuilder) ForUser(userID uuid.UUID) *OrderBuilder {
+	b.userID = userID
+	return b
+}
+
+// WithCurrency sets the currency; add items after it so they are priced in it
+func (b *OrderBuilder) WithCurrency(currency string) *OrderBuilder {
+	b.currency = currency
+	return b
+}
+
+// WithItem adds an item priced at unitPriceMinor in the order currency
+func (b *OrderBuilder) WithItem(itemID string, quantity int, unitPriceMinor int64) *OrderBuilder {
+	unitPrice := money.New(unitPriceMinor, b.currency)
+	b.items = append(b.items, domain.OrderItem{
+		ID:        uuid.New(),
+		ItemID:    itemID,
+		ItemName:  fmt.Sprintf("Item %s", itemID),
+		Quantity:  quantity,
+		UnitPrice: unitPrice,
+		Total:     unitPrice.Mul(int64(quantity)),
+	})
+	return b
+}
+
+// WithStatus moves the order to the status, setting the timestamps of the statuses
+// on the way like the state machine would
+func (b *OrderBuilder) WithStatus(status domain.OrderStatus) *OrderBuilder {
+	return b.With(func(o *domain.Order) {
+		o.Status = status
+		at := o.CreatedAt
+		switch status {
+		case domain.StatusCompleted:
+			o.CompletedAt = &at
+			fallthrough
+		case domain.StatusAssembled:
+			o.AssembledAt = &at
+			fallthrough
+		case domain.StatusPartiallyAssembled, domain.StatusPaid:
+			o.PaidAt = &at
+		}
+	})
+}
+
+// WithSerials allocates serial numbers of the item to the order
+func (b *OrderBuilder) WithSerials(itemID string, serials ...string) *OrderBuilder {
+	return b.With(func(o *domain.Order) {
+		for _, serial := range serials {
+			o.SerialNumbers = append(o.SerialNumbers, domain.SerialAllocation{
+				ItemID:       itemID,
+				SerialNumber: serial,
+				AllocatedAt:  o.CreatedAt,
+			})
+		}
+	})
+}
+
+// CreatedAt backdates the order
+func (b *OrderBuilder) CreatedAt(t time.Time) *OrderBuilder {
+	b.createdAt = t
+	return b
+}
+
+// With applies an arbitrary change after the order is assembled
+func (b *OrderBuilder) With(fn func(*domain.Order)) *OrderBuilder {
+	b.mutations = append(b.mutations, fn)
+	return b
+}
+
+// Build returns the order. It panics if the items are priced in another currency,
+// as a broken fixture is a bug in the test.
+func (b *OrderBuilder) Build() *domain.Order {
+	order := &domain.Order{
+		ID:        uuid.New(),
+		UserID:    b.userID,
+		Status:    domain.StatusPending,
+		Currency:  b.currency,
+		CreatedAt: b.createdAt,
+		UpdatedAt: b.createdAt,
+		Items:     make([]domain.OrderItem, 0, len(b.items)),
+	}
+	for _, item := range b.items {
+		item.OrderID = order.ID
+		item.CreatedAt = b.createdAt
+		order.Items = append(order.Items, item)
+	}
+	if err := order.CalculateTotal(); err != nil {
+		panic(fmt.Sprintf("fakes: invalid order fixture: %v", err))
+	}
+	for _, fn := range b.mutations {
+		fn(order)
+	}
+	return order
+}
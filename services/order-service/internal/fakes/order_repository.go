@@ -0,0 +1,408 @@
+// Package fakes provides an in-memory order repository and a builder for orders,
+// for unit tests and cross-service behavior tests. The fake follows the semantics of
+// the PostgreSQL repository, including soft deletes, the status state machine and
+// event deduplication, and can be told to fail any operation with FailOn.
+package fakes
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/testing/memstore"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+)
+
+var _ interfaces.OrderRepository = (*OrderRepository)(nil)
+
+// errForeignKey stands in for the PostgreSQL error of serials saved for a missing order
+var errForeignKey = errors.New("insert or update on table \"order_item_serials\" violates foreign key constraint")
+
+// orderRecord is a stored order; deleted orders are kept but hidden, as in PostgreSQL
+type orderRecord struct {
+	order   *domain.Order
+	deleted bool
+}
+
+// OrderRepository is an in-memory interfaces.OrderRepository
+type OrderRepository struct {
+	*memstore.Store[uuid.UUID, orderRecord]
+	events *memstore.Store[string, string]
+}
+
+// NewOrderRepository creates an empty repository seeded with the given orders
+func NewOrderRepository(orders ...*domain.Order) *OrderRepository {
+	r := &OrderRepository{
+		Store:  memstore.New[uuid.UUID](cloneRecord),
+		events: memstore.New[string, string](nil),
+	}
+	for _, order := range orders {
+		r.Put(order.ID, orderRecord{order: order})
+	}
+	return r
+}
+
+// ProcessedEvents returns the IDs of the events recorded by UpdateStatusForEvent
+func (r *OrderRepository) ProcessedEvents() []string {
+	return r.events.Keys()
+}
+
+func (r *OrderRepository) Create(ctx context.Context, order *domain.Order) error {
+	if err := r.Call("Create"); err != nil {
+		return err
+	}
+	if !r.Insert(order.ID, orderRecord{order: order}) {
+		return platformError.NewConflict("order already exists")
+	}
+	return nil
+}
+
+func (r *OrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
+	if err := r.Call("GetByID"); err != nil {
+		return nil, err
+	}
+	record, ok := r.Get(id)
+	if !ok || record.deleted {
+		return nil, platformError.NewNotFound("order not found")
+	}
+	return record.order, nil
+}
+
+func (r *OrderRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Order, error) {
+	if err := r.Call("GetByUserID"); err != nil {
+		return nil, err
+	}
+	orders := r.live(func(order *domain.Order) bool { return order.UserID == userID })
+	return page(orders, limit, offset), nil
+}
+
+func (r *OrderRepository) Update(ctx context.Context, order *domain.Order) error {
+	if err := r.Call("Update"); err != nil {
+		return err
+	}
+	// Only the columns the PostgreSQL update writes are changed
+	return r.modify(order.ID, func(stored *domain.Order) error {
+		stored.Status = order.Status
+		stored.TotalAmount = order.TotalAmount
+		stored.UpdatedAt = order.UpdatedAt
+		stored.PaidAt = order.PaidAt
+		stored.AssembledAt = order.AssembledAt
+		stored.CompletedAt = order.CompletedAt
+		return nil
+	})
+}
+
+func (r *OrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus) (domain.OrderStatus, error) {
+	if err := r.Call("UpdateStatus"); err != nil {
+		return "", err
+	}
+	var from domain.OrderStatus
+	err := r.modify(id, func(order *domain.Order) error {
+		from = order.Status
+		return transition(order, status)
+	})
+	if err != nil {
+		return "", err
+	}
+	return from, nil
+}
+
+func (r *OrderRepository) UpdateStatusForEvent(ctx context.Context, eventID, eventType string, id uuid.UUID, statuses ...domain.OrderStatus) ([]domain.StatusTransition, error) {
+	if err := r.Call("UpdateStatusForEvent"); err != nil {
+		return nil, err
+	}
+	if r.events.Has(eventID) {
+		return nil, nil // Already processed
+	}
+
+	// The transitions apply together or not at all, as in the PostgreSQL transaction
+	transitions := make([]domain.StatusTransition, 0, len(statuses))
+	err := r.modify(id, func(order *domain.Order) error {
+		for _, status := range statuses {
+			from := order.Status
+			if err := transition(order, status); err != nil {
+				return err
+			}
+			transitions = append(transitions, domain.StatusTransition{From: from, To: status})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.events.Put(eventID, eventType)
+	return transitions, nil
+}
+
+func (r *OrderRepository) List(ctx context.Context, filter domain.OrderFilter) ([]*domain.Order, error) {
+	if err := r.Call("List"); err != nil {
+		return nil, err
+	}
+	orders := r.live(func(order *domain.Order) bool {
+		if !matchesFilter(order, filter) {
+			return false
+		}
+		// Keyset paging resumes after the last order of the previous page
+		if after := filter.After; after != nil {
+			return order.CreatedAt.Before(after.CreatedAt) ||
+				(order.CreatedAt.Equal(after.CreatedAt) && compareIDs(order.ID, after.ID) < 0)
+		}
+		return true
+	})
+
+	limit := 50 // default limit, as in PostgreSQL
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+	offset := 0
+	if filter.Offset > 0 && filter.After == nil {
+		offset = filter.Offset
+	}
+	return page(orders, limit, offset), nil
+}
+
+func (r *OrderRepository) Count(ctx context.Context, filter domain.OrderFilter) (int, error) {
+	if err := r.Call("Count"); err != nil {
+		return 0, err
+	}
+	return len(r.live(func(order *domain.Order) bool { return matchesFilter(order, filter) })), nil
+}
+
+func (r *OrderRepository) UpdateTags(ctx context.Context, id uuid.UUID, tags []string, attributes domain.OrderAttributes) error {
+	if err := r.Call("UpdateTags"); err != nil {
+		return err
+	}
+	return r.modify(id, func(order *domain.Order) error {
+		order.Tags = tags
+		order.Attributes = attributes
+		order.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+func (r *OrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.Call("Delete"); err != nil {
+		return err
+	}
+	found, err := r.Modify(id, func(record orderRecord) (orderRecord, error) {
+		if record.deleted {
+			return record, platformError.NewNotFound("order not found")
+		}
+		record.deleted = true
+		record.order.UpdatedAt = time.Now()
+		return record, nil
+	})
+	if !found {
+		return platformError.NewNotFound("order not found")
+	}
+	return err
+}
+
+func (r *OrderRepository) SaveSerialNumbers(ctx context.Context, orderID uuid.UUID, serials []domain.SerialAllocation) error {
+	if err := r.Call("SaveSerialNumbers"); err != nil {
+		return err
+	}
+	if len(serials) == 0 {
+		return nil
+	}
+	// Serial numbers reference the order row, so unlike the other updates this
+	// succeeds for soft deleted orders too
+	found, _ := r.Modify(orderID, func(record orderRecord) (orderRecord, error) {
+		recorded := make(map[string]bool, len(record.order.SerialNumbers))
+		for _, serial := range record.order.SerialNumbers {
+			recorded[serial.SerialNumber] = true
+		}
+		for _, serial := range serials {
+			if !recorded[serial.SerialNumber] {
+				recorded[serial.SerialNumber] = true
+				record.order.SerialNumbers = append(record.order.SerialNumbers, serial)
+			}
+		}
+		sort.Slice(record.order.SerialNumbers, func(i, j int) bool {
+			a, b := record.order.SerialNumbers[i], record.order.SerialNumbers[j]
+			if a.ItemID != b.ItemID {
+				return a.ItemID < b.ItemID
+			}
+			return a.SerialNumber < b.SerialNumber
+		})
+		return record, nil
+	})
+	if !found {
+		return platformError.Wrap(errForeignKey, "failed to insert order serial number")
+	}
+	return nil
+}
+
+func (r *OrderRepository) UpdateShipment(ctx context.Context, shipment *domain.Shipment) error {
+	if err := r.Call("UpdateShipment"); err != nil {
+		return err
+	}
+	found := false
+	// Shipments reference the order row, so this succeeds for soft deleted orders too
+	r.Modify(shipment.OrderID, func(record orderRecord) (orderRecord, error) {
+		if stored := record.order.Shipment(shipment.ID); stored != nil {
+			found = true
+			stored.Status = shipment.Status
+			stored.TransactionID = shipment.TransactionID
+			stored.UpdatedAt = shipment.UpdatedAt
+			stored.PaidAt = cloneTime(shipment.PaidAt)
+			stored.AssembledAt = cloneTime(shipment.AssembledAt)
+		}
+		return record, nil
+	})
+	if !found {
+		return platformError.NewNotFound("shipment not found")
+	}
+	return nil
+}
+
+func (r *OrderRepository) GetOrderMetrics(ctx context.Context) (*interfaces.OrderMetrics, error) {
+	if err := r.Call("GetOrderMetrics"); err != nil {
+		return nil, err
+	}
+	metrics := &interfaces.OrderMetrics{
+		OrdersByStatus: make(map[string]int),
+	}
+
+	year, month, day := time.Now().Date()
+	var totalMinor int64
+	for _, order := range r.live(nil) {
+		metrics.TotalOrders++
+		totalMinor += order.TotalAmount.Minor
+		metrics.OrdersByStatus[string(order.Status)]++
+
+		if y, m, d := order.CreatedAt.Date(); y == year && m == month && d == day {
+			metrics.OrdersToday++
+			metrics.RevenueToday += float64(order.TotalAmount.Minor) / 100
+		}
+	}
+
+	// Amounts are stored in cents and reported in major units
+	metrics.TotalRevenue = float64(totalMinor) / 100
+	if metrics.TotalOrders > 0 {
+		metrics.AverageOrderValue = metrics.TotalRevenue / float64(metrics.TotalOrders)
+	}
+	return metrics, nil
+}
+
+// live returns the orders that are not deleted and match, newest first
+func (r *OrderRepository) live(match func(*domain.Order) bool) []*domain.Order {
+	records := r.Store.List(func(record orderRecord) bool {
+		return !record.deleted && (match == nil || match(record.order))
+	})
+	orders := make([]*domain.Order, 0, len(records))
+	for _, record := range records {
+		orders = append(orders, record.order)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].CreatedAt.Equal(orders[j].CreatedAt) {
+			return orders[i].CreatedAt.After(orders[j].CreatedAt)
+		}
+		return compareIDs(orders[i].ID, orders[j].ID) > 0
+	})
+	return orders
+}
+
+// modify applies fn to a live order and stores the result unless fn fails
+func (r *OrderRepository) modify(id uuid.UUID, fn func(*domain.Order) error) error {
+	found, err := r.Modify(id, func(record orderRecord) (orderRecord, error) {
+		if record.deleted {
+			return record, platformError.NewNotFound("order not found")
+		}
+		return record, fn(record.order)
+	})
+	if !found {
+		return platformError.NewNotFound("order not found")
+	}
+	return err
+}
+
+// transition validates and applies a status change the way the PostgreSQL
+// repository does, returning a conflict for transitions the state machine rejects
+func transition(order *domain.Order, status domain.OrderStatus) error {
+	if err := domain.ValidateTransition(order.Status, status); err != nil {
+		return &platformError.AppError{
+			Type:    platformError.ErrorTypeConflict,
+			Message: "invalid order status transition",
+			Err:     err,
+		}
+	}
+
+	now := time.Now()
+	order.Status = status
+	order.UpdatedAt = now
+	switch status {
+	case domain.StatusPaid:
+		order.PaidAt = &now
+	case domain.StatusAssembled:
+		order.AssembledAt = &now
+	case domain.StatusCompleted:
+		order.CompletedAt = &now
+	}
+	return nil
+}
+
+func matchesFilter(order *domain.Order, filter domain.OrderFilter) bool {
+	if filter.UserID != nil && order.UserID != *filter.UserID {
+		return false
+	}
+	if filter.Status != nil && order.Status != *filter.Status {
+		return false
+	}
+	if !order.HasTags(filter.Tags) {
+		return false
+	}
+	for name, value := range filter.Attributes {
+		if !order.Attributes.Matches(name, value) {
+			return false
+		}
+	}
+	if filter.CreatedFrom != nil && order.CreatedAt.Before(*filter.CreatedFrom) {
+		return false
+	}
+	if filter.CreatedTo != nil && !order.CreatedAt.Before(*filter.CreatedTo) {
+		return false
+	}
+	return true
+}
+
+func page(orders []*domain.Order, limit, offset int) []*domain.Order {
+	if offset >= len(orders) {
+		return []*domain.Order{}
+	}
+	orders = orders[offset:]
+	if limit >= 0 && len(orders) > limit {
+		orders = orders[:limit]
+	}
+	return orders
+}
+
+// compareIDs orders UUIDs bytewise, as PostgreSQL compares the uuid type
+func compareIDs(a, b uuid.UUID) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func cloneRecord(record orderRecord) orderRecord {
+	return orderRecord{order: record.order.Clone(), deleted: record.deleted}
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
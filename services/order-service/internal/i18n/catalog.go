@@ -0,0 +1,139 @@
+package i18n
+
+// catalog holds the translations of the customer-facing messages by locale,
+// keyed by their English text. English needs no translations.
+var catalog = map[string]map[string]string{
+	"en": {},
+
+	"de": {
+		// API errors
+		"Conflict error":                             "Konflikt",
+		"External service error":                     "Fehler eines externen Dienstes",
+		"Internal server error":                      "Interner Serverfehler",
+		"Invalid JSON payload":                       "Ungültige JSON-Nutzdaten",
+		"Invalid address ID":                         "Ungültige Adress-ID",
+		"Invalid batch ID":                           "Ungültige Stapel-ID",
+		"Invalid export ID":                          "Ungültige Export-ID",
+		"Invalid order ID":                           "Ungültige Bestell-ID",
+		"Invalid order status":                       "Ungültiger Bestellstatus",
+		"Invalid request":                            "Ungültige Anfrage",
+		"Invalid since status":                       "Ungültiger Ausgangsstatus",
+		"Invalid user ID":                            "Ungültige Benutzer-ID",
+		"Invalid wait duration":                      "Ungültige Wartezeit",
+		"Invalid webhook ID":                         "Ungültige Webhook-ID",
+		"Invalid %s":                                 "Ungültiger Wert für %s",
+		"Invalid %s time, expected RFC 3339":         "Ungültige Zeit für %s, erwartet wird RFC 3339",
+		"Resource not found":                         "Ressource nicht gefunden",
+		"Service temporarily unavailable":            "Dienst vorübergehend nicht verfügbar",
+		"Validation error":                           "Validierungsfehler",
+		"Missing authentication":                     "Authentifizierung fehlt",
+		"Invalid or missing CSRF token":              "Ungültiges oder fehlendes CSRF-Token",
+		"Order placement is temporarily unavailable": "Bestellungen sind vorübergehend nicht möglich",
+		"Too many orders, retry later":               "Zu viele Bestellungen, bitte später erneut versuchen",
+		"Server is overloaded, retry later":          "Der Server ist überlastet, bitte später erneut versuchen",
+
+		// Order statuses
+		"Order received, awaiting payment":        "Bestellung eingegangen, Zahlung ausstehend",
+		"Awaiting approval":                       "Wartet auf Freigabe",
+		"Payment under review":                    "Zahlung wird geprüft",
+		"Waiting for items to come back in stock": "Wartet auf Wiederverfügbarkeit der Artikel",
+		"Paid, waiting for assembly":              "Bezahlt, wartet auf Montage",
+		"Partially assembled":                     "Teilweise montiert",
+		"Assembled":                               "Montiert",
+		"Completed":                               "Abgeschlossen",
+		"Cancelled":                               "Storniert",
+		"Failed":                                  "Fehlgeschlagen",
+
+		// Order warnings
+		"item %s is discontinued; its replacement is %s":    "Artikel %s wird nicht mehr geführt; Ersatz ist %s",
+		"item %s is discontinued and was substituted by %s": "Artikel %s wird nicht mehr geführt und wurde durch %s ersetzt",
+	},
+
+	"es": {
+		// API errors
+		"Conflict error":                             "Conflicto",
+		"External service error":                     "Error de un servicio externo",
+		"Internal server error":                      "Error interno del servidor",
+		"Invalid JSON payload":                       "Contenido JSON no válido",
+		"Invalid address ID":                         "ID de dirección no válido",
+		"Invalid batch ID":                           "ID de lote no válido",
+		"Invalid export ID":                          "ID de exportación no válido",
+		"Invalid order ID":                           "ID de pedido no válido",
+		"Invalid order status":                       "Estado de pedido no válido",
+		"Invalid request":                            "Solicitud no válida",
+		"Invalid since status":                       "Estado de referencia no válido",
+		"Invalid user ID":                            "ID de usuario no válido",
+		"Invalid wait duration":                      "Tiempo de espera no válido",
+		"Invalid webhook ID":                         "ID de webhook no válido",
+		"Invalid %s":                                 "Valor de %s no válido",
+		"Invalid %s time, expected RFC 3339":         "Hora de %s no válida, se espera RFC 3339",
+		"Resource not found":                         "Recurso no encontrado",
+		"Service temporarily unavailable":            "Servicio no disponible temporalmente",
+		"Validation error":                           "Error de validación",
+		"Missing authentication":                     "Falta la autenticación",
+		"Invalid or missing CSRF token":              "Token CSRF no válido o ausente",
+		"Order placement is temporarily unavailable": "No es posible realizar pedidos temporalmente",
+		"Too many orders, retry later":               "Demasiados pedidos, inténtelo más tarde",
+		"Server is overloaded, retry later":          "El servidor está sobrecargado, inténtelo más tarde",
+
+		// Order statuses
+		"Order received, awaiting payment":        "Pedido recibido, pendiente de pago",
+		"Awaiting approval":                       "Pendiente de aprobación",
+		"Payment under review":                    "Pago en revisión",
+		"Waiting for items to come back in stock": "Esperando la reposición de artículos",
+		"Paid, waiting for assembly":              "Pagado, pendiente de montaje",
+		"Partially assembled":                     "Montado parcialmente",
+		"Assembled":                               "Montado",
+		"Completed":                               "Completado",
+		"Cancelled":                               "Cancelado",
+		"Failed":                                  "Fallido",
+
+		// Order warnings
+		"item %s is discontinued; its replacement is %s":    "el artículo %s está descatalogado; su sustituto es %s",
+		"item %s is discontinued and was substituted by %s": "el artículo %s está descatalogado y se sustituyó por %s",
+	},
+
+	"ru": {
+		// API errors
+		"Conflict error":                             "Конфликт",
+		"External service error":                     "Ошибка внешнего сервиса",
+		"Internal server error":                      "Внутренняя ошибка сервера",
+		"Invalid JSON payload":                       "Некорректный JSON",
+		"Invalid address ID":                         "Некорректный идентификатор адреса",
+		"Invalid batch ID":                           "Некорректный идентификатор пакета",
+		"Invalid export ID":                          "Некорректный идентификатор выгрузки",
+		"Invalid order ID":                           "Некорректный идентификатор заказа",
+		"Invalid order status":                       "Некорректный статус заказа",
+		"Invalid request":                            "Некорректный запрос",
+		"Invalid since status":                       "Некорректный исходный статус",
+		"Invalid user ID":                            "Некорректный идентификатор пользователя",
+		"Invalid wait duration":                      "Некорректное время ожидания",
+		"Invalid webhook ID":                         "Некорректный идентификатор вебхука",
+		"Invalid %s":                                 "Некорректное значение %s",
+		"Invalid %s time, expected RFC 3339":         "Некорректное время %s, ожидается RFC 3339",
+		"Resource not found":                         "Ресурс не найден",
+		"Service temporarily unavailable":            "Сервис временно недоступен",
+		"Validation error":                           "Ошибка проверки данных",
+		"Missing authentication":                     "Требуется аутентификация",
+		"Invalid or missing CSRF token":              "Некорректный или отсутствующий CSRF-токен",
+		"Order placement is temporarily unavailable": "Оформление заказов временно недоступно",
+		"Too many orders, retry later":               "Слишком много заказов, повторите попытку позже",
+		"Server is overloaded, retry later":          "Сервер перегружен, повторите попытку позже",
+
+		// Order statuses
+		"Order received, awaiting payment":        "Заказ принят, ожидает оплаты",
+		"Awaiting approval":                       "Ожидает подтверждения",
+		"Payment under review":                    "Платёж на проверке",
+		"Waiting for items to come back in stock": "Ожидает поступления товаров",
+		"Paid, waiting for assembly":              "Оплачен, ожидает сборки",
+		"Partially assembled":                     "Частично собран",
+		"Assembled":                               "Собран",
+		"Completed":                               "Выполнен",
+		"Cancelled":                               "Отменён",
+		"Failed":                                  "Не выполнен",
+
+		// Order warnings
+		"item %s is discontinued; its replacement is %s":    "товар %s снят с производства; замена — %s",
+		"item %s is discontinued and was substituted by %s": "товар %s снят с производства и заменён на %s",
+	},
+}
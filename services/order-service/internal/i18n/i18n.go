@@ -0,0 +1,94 @@
+// Package i18n localizes the customer-facing strings of order-service: API
+// errors, order status messages and order warnings. Messages are looked up by
+// their English text in the catalog; a message without a translation is served
+// in English.
+//
+// The locale of a request is the caller's profile locale, forwarded by the
+// gateway, or else their Accept-Language preference (see ctxmeta). Orders keep
+// the locale they were placed in, so events about them carry hints in the
+// customer's language even when no request is behind them.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+)
+
+// DefaultLocale is the locale of messages when the caller's locale is unknown or
+// not supported
+const DefaultLocale = "en"
+
+// Resolve returns the supported locale best matching a BCP 47 language tag, e.g.
+// "de" for "de-CH", falling back to DefaultLocale
+func Resolve(tag string) string {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if _, ok := catalog[tag]; ok {
+		return tag
+	}
+	if language, _, found := strings.Cut(tag, "-"); found {
+		if _, ok := catalog[language]; ok {
+			return language
+		}
+	}
+	return DefaultLocale
+}
+
+// FromContext returns the supported locale of the caller
+func FromContext(ctx context.Context) string {
+	locale, _ := ctxmeta.Locale(ctx)
+	return Resolve(locale)
+}
+
+// Translate returns the message in the locale, formatted with args when given
+func Translate(locale, message string, args ...interface{}) string {
+	if translated, ok := catalog[Resolve(locale)][message]; ok {
+		message = translated
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// T translates a message into the caller's locale
+func T(ctx context.Context, message string, args ...interface{}) string {
+	return Translate(FromContext(ctx), message, args...)
+}
+
+// statusMessages describe each order status to customers
+var statusMessages = map[domain.OrderStatus]string{
+	domain.StatusPending:            "Order received, awaiting payment",
+	domain.StatusPendingApproval:    "Awaiting approval",
+	domain.StatusPendingReview:      "Payment under review",
+	domain.StatusBackordered:        "Waiting for items to come back in stock",
+	domain.StatusPaid:               "Paid, waiting for assembly",
+	domain.StatusPartiallyAssembled: "Partially assembled",
+	domain.StatusAssembled:          "Assembled",
+	domain.StatusCompleted:          "Completed",
+	domain.StatusCancelled:          "Cancelled",
+	domain.StatusFailed:             "Failed",
+}
+
+// StatusMessage describes an order status to customers in the locale
+func StatusMessage(locale string, status domain.OrderStatus) string {
+	message, ok := statusMessages[status]
+	if !ok {
+		return string(status)
+	}
+	return Translate(locale, message)
+}
+
+// WarningMessage returns the message of an order warning in the locale
+func WarningMessage(locale string, warning domain.OrderWarning) string {
+	switch {
+	case warning.Code == domain.WarningItemDiscontinued && warning.Substituted:
+		return Translate(locale, domain.DiscontinuedSubstitutedMessage, warning.ItemID, warning.ReplacementItemID)
+	case warning.Code == domain.WarningItemDiscontinued:
+		return Translate(locale, domain.DiscontinuedReplacementMessage, warning.ItemID, warning.ReplacementItemID)
+	}
+	return warning.Message
+}
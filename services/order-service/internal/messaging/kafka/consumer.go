@@ -4,18 +4,28 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/google/uuid"
 
+	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
 	platformErrors "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
 )
 
 // OrderService interface for the consumer (to avoid circular imports)
 type OrderService interface {
-	HandleAssemblyCompleted(ctx context.Context, orderID uuid.UUID) error
+	HandleAssemblyCompleted(ctx context.Context, orderID, shipmentID uuid.UUID, eventID string) error
+	HandlePaymentReviewDecision(ctx context.Context, decision service.PaymentReviewDecision) error
+	HandleReservationPreempted(ctx context.Context, preemption service.ReservationPreemption) error
+	HandleBackorderReserved(ctx context.Context, reservation service.BackorderReservation) error
+	InvalidateOrder(orderID uuid.UUID)
 }
 
 // Consumer handles consuming messages from Kafka topics
@@ -25,14 +35,18 @@ type Consumer struct {
 	handler       *ConsumerHandler
 	logger        logging.Logger
 	ready         chan bool
+	paused        bool
+	mu            sync.Mutex
 }
 
 // NewConsumer creates a new Kafka consumer for assembly events
-func NewConsumer(brokers []string, groupID string, topics []string, orderService OrderService, logger logging.Logger) (*Consumer, error) {
+func NewConsumer(brokers []string, groupID string, topics []string, membership kafka.GroupMembership, orderService OrderService, logger logging.Logger, reporter recovery.Reporter) (*Consumer, error) {
 	config := sarama.NewConfig()
 
 	// Consumer configuration
-	config.Consumer.Group.Rebalance.Strategy = sarama.BalanceStrategyRoundRobin
+	if err := membership.Apply(config); err != nil {
+		return nil, platformErrors.Wrap(err, "invalid Kafka consumer group membership")
+	}
 	config.Consumer.Offsets.Initial = sarama.OffsetNewest
 	config.Consumer.Group.Session.Timeout = 30 * time.Second
 	config.Consumer.Group.Heartbeat.Interval = 3 * time.Second
@@ -50,21 +64,66 @@ func NewConsumer(brokers []string, groupID string, topics []string, orderService
 	handler := &ConsumerHandler{
 		orderService: orderService,
 		logger:       logger,
+		reporter:     reporter,
+		payloads:     &http.Client{Timeout: 30 * time.Second},
 	}
 
 	logger.Info(nil, "Kafka consumer created successfully", map[string]interface{}{
-		"brokers":  brokers,
-		"group_id": groupID,
-		"topics":   topics,
+		"brokers":            brokers,
+		"group_id":           groupID,
+		"topics":             topics,
+		"group_instance_id":  membership.InstanceID,
+		"rebalance_strategy": config.Consumer.Group.Rebalance.GroupStrategies[0].Name(),
 	})
 
-	return &Consumer{
+	consumer := &Consumer{
 		consumerGroup: consumerGroup,
 		topics:        topics,
 		handler:       handler,
 		logger:        logger,
 		ready:         make(chan bool),
-	}, nil
+	}
+	handler.consumer = consumer
+
+	return consumer, nil
+}
+
+// Pause stops fetching new assembly events without leaving the consumer group.
+// Messages already handed to the handler are still processed and committed.
+func (c *Consumer) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.paused {
+		return
+	}
+	c.paused = true
+	c.consumerGroup.PauseAll()
+	c.logger.Info(nil, "Kafka consumer paused", map[string]interface{}{
+		"topics": c.topics,
+	})
+}
+
+// Resume resumes fetching assembly events after a Pause
+func (c *Consumer) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.paused {
+		return
+	}
+	c.paused = false
+	c.consumerGroup.ResumeAll()
+	c.logger.Info(nil, "Kafka consumer resumed", map[string]interface{}{
+		"topics": c.topics,
+	})
+}
+
+// IsPaused reports whether the consumer is currently paused
+func (c *Consumer) IsPaused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
 }
 
 // Start starts consuming messages in a blocking manner
@@ -147,11 +206,19 @@ func (c *Consumer) Close() error {
 type ConsumerHandler struct {
 	orderService OrderService
 	logger       logging.Logger
+	reporter     recovery.Reporter
+	consumer     *Consumer
+	payloads     *http.Client // Fetches payloads offloaded by the producer for their size
 }
 
 // Setup is run at the beginning of a new session, before ConsumeClaim
 func (h *ConsumerHandler) Setup(sarama.ConsumerGroupSession) error {
 	h.logger.Info(nil, "Kafka consumer session setup")
+
+	// Partitions assigned after a rebalance start unpaused, so re-apply
+	if h.consumer != nil && h.consumer.IsPaused() {
+		h.consumer.consumerGroup.PauseAll()
+	}
 	return nil
 }
 
@@ -171,7 +238,10 @@ func (h *ConsumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession, clai
 				return nil
 			}
 
-			if err := h.handleMessage(session.Context(), message); err != nil {
+			err := recovery.Call(session.Context(), h.reporter, recovery.SourceKafka, message.Topic, func(ctx context.Context) error {
+				return h.handleMessage(ctx, message)
+			})
+			if err != nil {
 				h.logger.Error(session.Context(), "Failed to handle message", err, map[string]interface{}{
 					"topic":     message.Topic,
 					"partition": message.Partition,
@@ -199,6 +269,18 @@ func (h *ConsumerHandler) handleMessage(ctx context.Context, message *sarama.Con
 		"timestamp": message.Timestamp,
 	})
 
+	// Handle the event for the same user and under the same request ID as its producer
+	headers := make(map[string]string, len(message.Headers))
+	for _, header := range message.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+	ctx = ctxmeta.NewContext(ctx, ctxmeta.FromKafkaHeaders(headers))
+
+	payload, err := kafka.ResolvePayload(ctx, h.payloads, headers, message.Value)
+	if err != nil {
+		return err
+	}
+
 	// Get event type from headers
 	eventType := h.getHeaderValue(message.Headers, "event-type")
 	eventID := h.getHeaderValue(message.Headers, "event-id")
@@ -210,9 +292,15 @@ func (h *ConsumerHandler) handleMessage(ctx context.Context, message *sarama.Con
 
 	switch eventType {
 	case "assembly.completed":
-		return h.handleAssemblyCompletedEvent(ctx, message.Value, eventID)
+		return h.handleAssemblyCompletedEvent(ctx, payload, eventID)
 	case "assembly.failed":
-		return h.handleAssemblyFailedEvent(ctx, message.Value, eventID)
+		return h.handleAssemblyFailedEvent(ctx, payload, eventID)
+	case PaymentReviewApprovedEventType, PaymentReviewDeclinedEventType:
+		return h.handlePaymentReviewEvent(ctx, payload, eventID)
+	case ReservationPreemptedEventType:
+		return h.handleReservationPreemptedEvent(ctx, payload, eventID)
+	case BackorderReservedEventType:
+		return h.handleBackorderReservedEvent(ctx, payload, eventID)
 	default:
 		h.logger.Warn(ctx, "Unknown event type received", map[string]interface{}{
 			"event_type": eventType,
@@ -233,15 +321,30 @@ func (h *ConsumerHandler) handleAssemblyCompletedEvent(ctx context.Context, data
 	if err != nil {
 		return platformErrors.Wrap(err, "invalid order ID in assembly completed event")
 	}
+	var shipmentID uuid.UUID
+	if event.ShipmentID != "" {
+		if shipmentID, err = uuid.Parse(event.ShipmentID); err != nil {
+			return platformErrors.Wrap(err, "invalid shipment ID in assembly completed event")
+		}
+	}
+
+	// The event ID makes the status update idempotent; prefer the header, fall back to the payload
+	if eventID == "" {
+		eventID = event.EventID
+	}
 
 	h.logger.Info(ctx, "Processing assembly completed event", map[string]interface{}{
 		"order_id":     orderID,
+		"shipment_id":  event.ShipmentID,
 		"event_id":     eventID,
 		"completed_at": event.CompletedAt,
 	})
 
+	// Drop the cached copy even if the status update below fails half-way
+	h.orderService.InvalidateOrder(orderID)
+
 	// Delegate to order service
-	if err := h.orderService.HandleAssemblyCompleted(ctx, orderID); err != nil {
+	if err := h.orderService.HandleAssemblyCompleted(ctx, orderID, shipmentID, eventID); err != nil {
 		h.logger.Error(ctx, "Failed to handle assembly completed event", err, map[string]interface{}{
 			"order_id": orderID,
 			"event_id": eventID,
@@ -270,11 +373,15 @@ func (h *ConsumerHandler) handleAssemblyFailedEvent(ctx context.Context, data []
 	}
 
 	h.logger.Warn(ctx, "Assembly failed event received", map[string]interface{}{
-		"order_id": orderID,
-		"event_id": eventID,
-		"reason":   event.Reason,
+		"order_id":    orderID,
+		"shipment_id": event.ShipmentID,
+		"event_id":    eventID,
+		"reason":      event.Reason,
 	})
 
+	// Make sure pollers see the latest state on their next request
+	h.orderService.InvalidateOrder(orderID)
+
 	// TODO: Implement failure handling logic
 	// For now, just log the event
 	// In a real system, you might want to:
@@ -286,6 +393,135 @@ func (h *ConsumerHandler) handleAssemblyFailedEvent(ctx context.Context, data []
 	return nil
 }
 
+// handlePaymentReviewEvent resumes or fails an order once its payment has been manually reviewed
+func (h *ConsumerHandler) handlePaymentReviewEvent(ctx context.Context, data []byte, eventID string) error {
+	var event PaymentReviewDecisionEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return platformErrors.Wrap(err, "failed to unmarshal payment review event")
+	}
+
+	orderID, err := uuid.Parse(event.OrderID)
+	if err != nil {
+		return platformErrors.Wrap(err, "invalid order ID in payment review event")
+	}
+
+	h.logger.Info(ctx, "Processing payment review event", map[string]interface{}{
+		"order_id":       orderID,
+		"event_id":       eventID,
+		"transaction_id": event.TransactionID,
+		"decision":       event.Decision,
+		"payment_status": event.PaymentStatus,
+	})
+
+	h.orderService.InvalidateOrder(orderID)
+
+	// An approved payment can still be declined by the payment processor
+	decision := service.PaymentReviewDecision{
+		OrderID:       orderID,
+		TransactionID: event.TransactionID,
+		Approved:      event.Decision == "approve" && event.PaymentStatus == "completed",
+		Reason:        event.Reason,
+		ProcessedAt:   event.DecidedAt,
+	}
+	if event.ProcessedAt != nil {
+		decision.ProcessedAt = *event.ProcessedAt
+	}
+
+	if err := h.orderService.HandlePaymentReviewDecision(ctx, decision); err != nil {
+		h.logger.Error(ctx, "Failed to handle payment review event", err, map[string]interface{}{
+			"order_id": orderID,
+			"event_id": eventID,
+		})
+		return platformErrors.Wrap(err, "failed to handle payment review decision")
+	}
+
+	return nil
+}
+
+// handleReservationPreemptedEvent compensates an order whose inventory reservation
+// was preempted by an expedited order
+func (h *ConsumerHandler) handleReservationPreemptedEvent(ctx context.Context, data []byte, eventID string) error {
+	var event ReservationPreemptedEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return platformErrors.Wrap(err, "failed to unmarshal reservation preempted event")
+	}
+
+	orderID, err := uuid.Parse(event.OrderID)
+	if err != nil {
+		return platformErrors.Wrap(err, "invalid order ID in reservation preempted event")
+	}
+
+	h.logger.Warn(ctx, "Reservation preempted event received", map[string]interface{}{
+		"order_id":              orderID,
+		"event_id":              eventID,
+		"sku":                   event.SKU,
+		"preempted_by_order_id": event.PreemptedByOrderID,
+	})
+
+	h.orderService.InvalidateOrder(orderID)
+
+	preemption := service.ReservationPreemption{
+		OrderID:            orderID,
+		PreemptedByOrderID: event.PreemptedByOrderID,
+		SKU:                event.SKU,
+		Quantity:           event.Quantity,
+		PreemptedAt:        event.PreemptedAt,
+	}
+	if err := h.orderService.HandleReservationPreempted(ctx, preemption); err != nil {
+		h.logger.Error(ctx, "Failed to handle reservation preempted event", err, map[string]interface{}{
+			"order_id": orderID,
+			"event_id": eventID,
+		})
+		return platformErrors.Wrap(err, "failed to handle reservation preemption")
+	}
+
+	return nil
+}
+
+// handleBackorderReservedEvent resumes an order whose backordered items inventory reserved
+func (h *ConsumerHandler) handleBackorderReservedEvent(ctx context.Context, data []byte, eventID string) error {
+	var event BackorderReservedEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return platformErrors.Wrap(err, "failed to unmarshal backorder reserved event")
+	}
+
+	orderID, err := uuid.Parse(event.OrderID)
+	if err != nil {
+		return platformErrors.Wrap(err, "invalid order ID in backorder reserved event")
+	}
+	shipmentID := uuid.Nil
+	if event.ShipmentID != "" {
+		if shipmentID, err = uuid.Parse(event.ShipmentID); err != nil {
+			return platformErrors.Wrap(err, "invalid shipment ID in backorder reserved event")
+		}
+	}
+
+	h.logger.Info(ctx, "Backorder reserved event received", map[string]interface{}{
+		"order_id":       orderID,
+		"shipment_id":    event.ShipmentID,
+		"event_id":       eventID,
+		"reservation_id": event.ReservationID,
+	})
+
+	h.orderService.InvalidateOrder(orderID)
+
+	reservation := service.BackorderReservation{
+		OrderID:       orderID,
+		ShipmentID:    shipmentID,
+		ReservationID: event.ReservationID,
+		ReservedAt:    event.ReservedAt,
+	}
+	if err := h.orderService.HandleBackorderReserved(ctx, reservation); err != nil {
+		h.logger.Error(ctx, "Failed to handle backorder reserved event", err, map[string]interface{}{
+			"order_id": orderID,
+			"event_id": eventID,
+		})
+		return platformErrors.Wrap(err, "failed to handle backorder reservation")
+	}
+
+	return nil
+}
+
 // getHeaderValue extracts a header value from Kafka message headers
 func (h *ConsumerHandler) getHeaderValue(headers []*sarama.RecordHeader, key string) string {
 	for _, header := range headers {
@@ -306,6 +542,7 @@ type AssemblyCompletedEvent struct {
 	Version     string    `json:"version"`
 	Source      string    `json:"source"`
 	OrderID     string    `json:"order_id"`
+	ShipmentID  string    `json:"shipment_id"` // Set for one shipment of a partially fulfilled order
 	UserID      string    `json:"user_id"`
 	CompletedAt time.Time `json:"completed_at"`
 	Duration    int       `json:"duration_seconds"` // Assembly duration in seconds
@@ -313,13 +550,52 @@ type AssemblyCompletedEvent struct {
 
 // AssemblyFailedEvent represents an assembly failed event from Assembly Service
 type AssemblyFailedEvent struct {
-	EventID   string    `json:"event_id"`
-	EventType string    `json:"event_type"`
-	EventTime time.Time `json:"event_time"`
-	Version   string    `json:"version"`
-	Source    string    `json:"source"`
-	OrderID   string    `json:"order_id"`
-	UserID    string    `json:"user_id"`
-	Reason    string    `json:"reason"`
-	FailedAt  time.Time `json:"failed_at"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	EventTime  time.Time `json:"event_time"`
+	Version    string    `json:"version"`
+	Source     string    `json:"source"`
+	OrderID    string    `json:"order_id"`
+	ShipmentID string    `json:"shipment_id"`
+	UserID     string    `json:"user_id"`
+	Reason     string    `json:"reason"`
+	FailedAt   time.Time `json:"failed_at"`
+}
+
+// PaymentReviewDecisionEvent represents a manual payment review decision published by payment-service
+type PaymentReviewDecisionEvent struct {
+	TransactionID string     `json:"transaction_id"`
+	OrderID       string     `json:"order_id"`
+	UserID        string     `json:"user_id"`
+	Decision      string     `json:"decision"`       // "approve" or "decline"
+	PaymentStatus string     `json:"payment_status"` // Payment status after the decision
+	Amount        float64    `json:"amount"`
+	AmountMinor   int64      `json:"amount_minor"` // Exact amount in the currency's minor unit
+	Currency      string     `json:"currency"`
+	Reviewer      string     `json:"reviewer"`
+	Reason        string     `json:"reason,omitempty"`
+	DecidedAt     time.Time  `json:"decided_at"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+}
+
+// ReservationPreemptedEvent represents a reservation preempted by an expedited order, published by inventory-service
+type ReservationPreemptedEvent struct {
+	OrderID            string    `json:"order_id"`
+	PreemptedByOrderID string    `json:"preempted_by_order_id"`
+	ReservationID      string    `json:"reservation_id"`
+	ItemID             string    `json:"item_id"`
+	SKU                string    `json:"sku"`
+	Quantity           float64   `json:"quantity"`
+	Unit               string    `json:"unit"`
+	PreemptedAt        time.Time `json:"preempted_at"`
+}
+
+// BackorderReservedEvent tells that inventory-service reserved the stock of a backorder
+type BackorderReservedEvent struct {
+	BackorderID   string    `json:"backorder_id"`
+	OrderID       string    `json:"order_id"`
+	ShipmentID    string    `json:"shipment_id,omitempty"` // Set for the backordered shipment of a split order
+	ReservationID string    `json:"reservation_id"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	ReservedAt    time.Time `json:"reserved_at"`
 }
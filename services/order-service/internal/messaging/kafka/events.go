@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
 )
 
 // MessagingCoordinator manages both Kafka producer and consumer
@@ -16,12 +18,14 @@ type MessagingCoordinator struct {
 }
 
 // NewMessagingCoordinator creates a new messaging coordinator with producer and consumer
-func NewMessagingCoordinator(cfg config.KafkaConfig, orderService OrderService, logger logging.Logger) (*MessagingCoordinator, error) {
+func NewMessagingCoordinator(cfg config.KafkaConfig, orderService OrderService, logger logging.Logger, reporter recovery.Reporter) (*MessagingCoordinator, error) {
 	// Create producer for payment events
 	producer, err := NewProducer(
 		cfg.Brokers,
-		cfg.PaymentEventsTopic,
+		cfg.Topics.Name(topics.PaymentEvents),
 		cfg.ProducerRetries,
+		cfg.ProducerCompression,
+		cfg.ProducerMaxBytes,
 		logger,
 	)
 	if err != nil {
@@ -32,9 +36,11 @@ func NewMessagingCoordinator(cfg config.KafkaConfig, orderService OrderService,
 	consumer, err := NewConsumer(
 		cfg.Brokers,
 		cfg.ConsumerGroup,
-		[]string{cfg.AssemblyEventsTopic},
+		cfg.Topics.Names(topics.AssemblyEvents, topics.PaymentReviewEvents, topics.InventoryEvents),
+		cfg.Membership,
 		orderService,
 		logger,
+		reporter,
 	)
 	if err != nil {
 		// Clean up producer if consumer creation fails
@@ -43,8 +49,8 @@ func NewMessagingCoordinator(cfg config.KafkaConfig, orderService OrderService,
 	}
 
 	logger.Info(nil, "Messaging coordinator created successfully", map[string]interface{}{
-		"payment_topic":  cfg.PaymentEventsTopic,
-		"assembly_topic": cfg.AssemblyEventsTopic,
+		"payment_topic":  cfg.Topics.Name(topics.PaymentEvents),
+		"assembly_topic": cfg.Topics.Name(topics.AssemblyEvents),
 		"consumer_group": cfg.ConsumerGroup,
 		"brokers":        cfg.Brokers,
 	})
@@ -59,17 +65,17 @@ func NewMessagingCoordinator(cfg config.KafkaConfig, orderService OrderService,
 // StartConsumer starts the Kafka consumer in a separate goroutine
 func (mc *MessagingCoordinator) StartConsumer(ctx context.Context) <-chan error {
 	errChan := make(chan error, 1)
-	
+
 	go func() {
 		defer close(errChan)
-		
+
 		mc.logger.Info(ctx, "Starting Kafka consumer")
 		if err := mc.Consumer.Start(ctx); err != nil {
 			mc.logger.Error(ctx, "Kafka consumer failed", err)
 			errChan <- err
 		}
 	}()
-	
+
 	return errChan
 }
 
@@ -97,21 +103,28 @@ func (mc *MessagingCoordinator) Close() error {
 	return nil
 }
 
-// Topic names for reference
-const (
-	PaymentEventsTopic  = "payment-events"
-	AssemblyEventsTopic = "assembly-events"
-	OrderEventsTopic    = "order-events"
-)
+// OrderEventsSource is the CloudEvents source of order lifecycle events
+const OrderEventsSource = "/rocket-science/order-service"
 
 // Event types for reference
 const (
-	PaymentProcessedEventType   = "payment.processed"
-	PaymentFailedEventType      = "payment.failed"
-	AssemblyCompletedEventType  = "assembly.completed"
-	AssemblyFailedEventType     = "assembly.failed"
-	OrderStatusChangedEventType = "order.status.changed"
-	OrderCreatedEventType       = "order.created"
+	PaymentProcessedEventType       = "payment.processed"
+	PaymentFailedEventType          = "payment.failed"
+	PaymentReviewApprovedEventType  = "payment.review.approved"
+	PaymentReviewDeclinedEventType  = "payment.review.declined"
+	AssemblyCompletedEventType      = "assembly.completed"
+	AssemblyFailedEventType         = "assembly.failed"
+	OrderStatusChangedEventType     = "order.status.changed"
+	OrderCreatedEventType           = "order.created"
+	OrderApprovalRequestedEventType = "order.approval_requested"
+	PaymentRetryScheduledEventType  = "order.payment_retry_scheduled"
+	OrderTagsChangedEventType       = "order.tags.changed"
+	ReservationPreemptedEventType   = "inventory.reservation.preempted"
+	BackorderReservedEventType      = "inventory.backorder.reserved"
+	OrderBackorderedEventType       = "order.backordered"
+	BackorderAvailableEventType     = "order.backorder_available"
+	OrderAbuseDetectedEventType     = "order.abuse_detected"
+	RefundStatusChangedEventType    = "order.refund_status_changed"
 )
 
 // Health check for messaging components
@@ -122,7 +135,7 @@ func (mc *MessagingCoordinator) HealthCheck() map[string]interface{} {
 
 	// Add more specific health checks here if needed
 	// For example, checking if Kafka brokers are reachable
-	
+
 	return health
 }
 
@@ -134,10 +147,10 @@ func (mc *MessagingCoordinator) GetMessageStats() map[string]interface{} {
 	// - Failed message count
 	// - Last message timestamp
 	// - Consumer lag
-	
+
 	return map[string]interface{}{
 		"producer_active": mc.Producer != nil,
 		"consumer_active": mc.Consumer != nil,
 		// Add more stats as needed
 	}
-}
\ No newline at end of file
+}
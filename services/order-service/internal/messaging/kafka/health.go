@@ -0,0 +1,208 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	platformErrors "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// ProbeStatus is the outcome of the Kafka produce path probes so far
+type ProbeStatus struct {
+	Ready               bool      `json:"ready"`
+	Checked             bool      `json:"checked"` // False until the first probe finished
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+	Brokers             int       `json:"brokers"` // Brokers known from the latest metadata
+	CheckedAt           time.Time `json:"checked_at,omitempty"`
+	LastSuccessAt       time.Time `json:"last_success_at,omitempty"`
+}
+
+// HealthProbe periodically checks that orders' events can reach Kafka: it
+// refreshes the brokers' metadata for the heartbeat topic and sends a heartbeat
+// through the producer the saga publishes with. Accepting orders whose events
+// can't be published stalls their saga, so readiness follows the probe.
+//
+// The service is not ready until the first probe succeeded. After that,
+// FailureThreshold consecutive failures make it not ready and SuccessThreshold
+// consecutive successes make it ready again, so a single slow probe doesn't
+// flap readiness.
+type HealthProbe struct {
+	client   sarama.Client
+	producer *Producer
+	topic    string
+	config   config.KafkaHealthConfig
+	logger   logging.Logger
+	metrics  metrics.Metrics
+
+	inFlight  atomic.Bool // A probe that timed out may still be running
+	mu        sync.RWMutex
+	status    ProbeStatus
+	successes int
+}
+
+// NewHealthProbe creates a probe of the producer's path to the heartbeat topic
+func NewHealthProbe(brokers []string, producer *Producer, topic string, cfg config.KafkaHealthConfig, logger logging.Logger, metrics metrics.Metrics) (*HealthProbe, error) {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.ClientID = "order-service-health"
+	saramaConfig.Net.DialTimeout = cfg.Timeout
+	saramaConfig.Net.ReadTimeout = cfg.Timeout
+	saramaConfig.Net.WriteTimeout = cfg.Timeout
+	saramaConfig.Metadata.Retry.Max = 1
+	saramaConfig.Metadata.RefreshFrequency = 0 // Refreshed by each probe
+
+	client, err := sarama.NewClient(brokers, saramaConfig)
+	if err != nil {
+		return nil, platformErrors.Wrap(err, "failed to create Kafka health probe client")
+	}
+
+	return &HealthProbe{
+		client:   client,
+		producer: producer,
+		topic:    topic,
+		config:   cfg,
+		logger:   logger,
+		metrics:  metrics,
+	}, nil
+}
+
+// Run probes the produce path every interval until the context is cancelled
+func (p *HealthProbe) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.record(ctx, p.Probe(ctx))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Probe checks the brokers' metadata and sends a heartbeat, within the timeout
+func (p *HealthProbe) Probe(ctx context.Context) error {
+	if !p.inFlight.CompareAndSwap(false, true) {
+		return fmt.Errorf("previous kafka probe has not finished")
+	}
+
+	start := time.Now()
+	result := make(chan error, 1)
+	go func() {
+		defer p.inFlight.Store(false)
+		result <- p.check(ctx)
+	}()
+
+	timer := time.NewTimer(p.config.Timeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-timer.C:
+		err = fmt.Errorf("kafka probe timed out after %s", p.config.Timeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.metrics.RecordDuration(ctx, "kafka_health_probe_duration", time.Since(start), nil)
+	return err
+}
+
+func (p *HealthProbe) check(ctx context.Context) error {
+	if err := p.client.RefreshMetadata(p.topic); err != nil {
+		return platformErrors.Wrap(err, "failed to refresh kafka metadata")
+	}
+	if _, err := p.client.Controller(); err != nil {
+		return platformErrors.Wrap(err, "kafka cluster has no reachable controller")
+	}
+	// The producer partitions manually and sends unpartitioned messages to partition 0
+	if _, err := p.client.Leader(p.topic, 0); err != nil {
+		return platformErrors.Wrap(err, "heartbeat topic has no leader")
+	}
+	return p.producer.SendHeartbeat(ctx, p.topic)
+}
+
+// record applies the thresholds to the outcome of a probe
+func (p *HealthProbe) record(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return // Stopping; the probe was cut short
+	}
+
+	p.mu.Lock()
+	wasReady := p.status.Ready
+	first := !p.status.Checked
+	now := time.Now().UTC()
+	p.status.Checked = true
+	p.status.CheckedAt = now
+	p.status.Brokers = len(p.client.Brokers())
+	if err != nil {
+		p.successes = 0
+		p.status.ConsecutiveFailures++
+		p.status.LastError = err.Error()
+		if p.status.ConsecutiveFailures >= p.config.FailureThreshold {
+			p.status.Ready = false
+		}
+	} else {
+		p.successes++
+		p.status.ConsecutiveFailures = 0
+		p.status.LastError = ""
+		p.status.LastSuccessAt = now
+		if first || p.successes >= p.config.SuccessThreshold {
+			p.status.Ready = true
+		}
+	}
+	status := p.status
+	p.mu.Unlock()
+
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	p.metrics.IncrementCounter(ctx, "kafka_health_probes_total", map[string]string{"result": result})
+	ready := 0.0
+	if status.Ready {
+		ready = 1
+	}
+	p.metrics.SetGauge(ctx, "kafka_produce_path_ready", ready, nil)
+
+	fields := map[string]interface{}{
+		"topic":                p.topic,
+		"consecutive_failures": status.ConsecutiveFailures,
+		"brokers":              status.Brokers,
+	}
+	switch {
+	case wasReady && !status.Ready:
+		p.logger.Error(ctx, "Kafka produce path failing, reporting not ready", err, fields)
+	case !wasReady && status.Ready:
+		p.logger.Info(ctx, "Kafka produce path healthy, reporting ready", fields)
+	case err != nil:
+		p.logger.Warn(ctx, "Kafka health probe failed", map[string]interface{}{
+			"topic":                p.topic,
+			"consecutive_failures": status.ConsecutiveFailures,
+			"error":                err.Error(),
+		})
+	}
+}
+
+// Status returns the outcome of the probes so far
+func (p *HealthProbe) Status() ProbeStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.status
+}
+
+// Close releases the metadata connection; the producer is closed by its owner
+func (p *HealthProbe) Close() error {
+	return p.client.Close()
+}
@@ -3,25 +3,38 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/google/uuid"
 
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/i18n"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/deadline"
 	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/cloudevents"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/events"
+	platformKafka "github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 )
 
 // Producer handles publishing messages to Kafka topics
 type Producer struct {
-	producer sarama.SyncProducer
-	topic    string
-	logger   logging.Logger
+	producer            sarama.SyncProducer
+	topic               string
+	orderEventsTopic    string // Order lifecycle events consumed by notification-service
+	securityEventsTopic string // Order abuse events consumed by IAM
+	eventFormat         cloudevents.Format
+	maxMessageBytes     int // Larger messages are rejected before they reach the broker
+	logger              logging.Logger
 }
 
 // NewProducer creates a new Kafka producer for payment events
-func NewProducer(brokers []string, topic string, retries int, logger logging.Logger) (*Producer, error) {
+func NewProducer(brokers []string, topic string, retries int, compression string, maxMessageBytes int, logger logging.Logger) (*Producer, error) {
 	config := sarama.NewConfig()
 	
 	// Producer configuration for reliability
@@ -31,7 +44,14 @@ func NewProducer(brokers []string, topic string, retries int, logger logging.Log
 	config.Producer.Return.Errors = true
 	
 	// Performance optimizations
-	config.Producer.Compression = sarama.CompressionSnappy
+	codec, err := platformKafka.CompressionCodec(compression)
+	if err != nil {
+		return nil, err
+	}
+	config.Producer.Compression = codec
+	if maxMessageBytes > 0 {
+		config.Producer.MaxMessageBytes = maxMessageBytes
+	}
 	config.Producer.Flush.Frequency = 500 * time.Millisecond
 	config.Producer.Flush.Messages = 100
 	
@@ -48,15 +68,18 @@ func NewProducer(brokers []string, topic string, retries int, logger logging.Log
 	}
 
 	logger.Info(nil, "Kafka producer created successfully", map[string]interface{}{
-		"brokers": brokers,
-		"topic":   topic,
-		"retries": retries,
+		"brokers":     brokers,
+		"topic":       topic,
+		"retries":     retries,
+		"compression": compression,
 	})
 
 	return &Producer{
-		producer: producer,
-		topic:    topic,
-		logger:   logger,
+		producer:        producer,
+		topic:           topic,
+		eventFormat:     cloudevents.FormatJSON,
+		maxMessageBytes: maxMessageBytes,
+		logger:          logger,
 	}, nil
 }
 
@@ -69,7 +92,7 @@ func (p *Producer) PublishPaymentEvent(ctx context.Context, event service.Paymen
 			EventID:   uuid.New().String(),
 			EventType: "payment.processed",
 			EventTime: time.Now().UTC(),
-			Version:   "1.0",
+			Version:   strconv.Itoa(events.PaymentEventVersion),
 			Source:    "order-service",
 		},
 	}
@@ -111,7 +134,10 @@ func (p *Producer) PublishPaymentEvent(ctx context.Context, event service.Paymen
 	}
 
 	// Publish message
-	partition, offset, err := p.producer.SendMessage(message)
+	message.Headers = withDeadline(ctx, message.Headers)
+	message.Headers = withRequestMetadata(ctx, message.Headers)
+
+	partition, offset, err := p.send(message)
 	if err != nil {
 		p.logger.Error(ctx, "Failed to publish payment event", err, map[string]interface{}{
 			"order_id":  event.OrderID,
@@ -134,63 +160,564 @@ func (p *Producer) PublishPaymentEvent(ctx context.Context, event service.Paymen
 	return nil
 }
 
-// PublishOrderStatusEvent publishes order status change events (for future use)
-func (p *Producer) PublishOrderStatusEvent(ctx context.Context, orderID uuid.UUID, oldStatus, newStatus string) error {
-	eventWithMetadata := OrderStatusEventMessage{
-		OrderStatusEvent: OrderStatusEvent{
-			OrderID:   orderID,
-			OldStatus: oldStatus,
-			NewStatus: newStatus,
+// SetOrderEventsTopic sets the topic order lifecycle events such as approval requests are published to
+func (p *Producer) SetOrderEventsTopic(topic string) {
+	p.orderEventsTopic = topic
+}
+
+// SetSecurityEventsTopic sets the topic order abuse events are published to
+func (p *Producer) SetSecurityEventsTopic(topic string) {
+	p.securityEventsTopic = topic
+}
+
+// SetEventFormat sets the CloudEvents format order lifecycle events are written in
+func (p *Producer) SetEventFormat(format cloudevents.Format) {
+	p.eventFormat = format
+}
+
+// PublishApprovalRequested asks the approvers, via notification-service, to decide on a held order
+func (p *Producer) PublishApprovalRequested(ctx context.Context, event service.ApprovalRequestedEvent) error {
+	approverIDs := make([]interface{}, len(event.ApproverIDs))
+	for i, id := range event.ApproverIDs {
+		approverIDs[i] = id
+	}
+
+	envelope := OrderEventEnvelope{
+		ID:      uuid.New().String(),
+		Type:    OrderApprovalRequestedEventType,
+		Source:  OrderEventsSource,
+		Subject: event.OrderID.String(),
+		Time:    time.Now().UTC(),
+		Data: map[string]interface{}{
+			"order_id":           event.OrderID.String(),
+			"user_id":            event.UserID.String(),
+			"total_amount":       event.TotalAmount,
+			"total_amount_minor": event.TotalAmountMinor,
+			"currency":           event.Currency,
+			"approver_ids":       approverIDs,
+			"expires_at":         event.ExpiresAt.UTC().Format(time.RFC3339),
 		},
-		EventMetadata: EventMetadata{
-			EventID:   uuid.New().String(),
-			EventType: "order.status.changed",
-			EventTime: time.Now().UTC(),
-			Version:   "1.0",
-			Source:    "order-service",
+		SpecVersion: cloudevents.SpecVersion,
+	}
+
+	partition, offset, err := p.sendOrderEvent(ctx, envelope, true)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to publish approval requested event", err, map[string]interface{}{
+			"order_id": event.OrderID,
+			"topic":    p.orderEventsTopic,
+		})
+		return errors.Wrap(err, "failed to publish approval requested event")
+	}
+
+	p.logger.Info(ctx, "Approval requested event published", map[string]interface{}{
+		"order_id":  event.OrderID,
+		"event_id":  envelope.ID,
+		"topic":     p.orderEventsTopic,
+		"partition": partition,
+		"offset":    offset,
+		"approvers": len(event.ApproverIDs),
+	})
+
+	return nil
+}
+
+// PublishPaymentRetryScheduled tells the customer, via notification-service, that the
+// payment of their order failed and is retried
+func (p *Producer) PublishPaymentRetryScheduled(ctx context.Context, event service.PaymentRetryScheduledEvent) error {
+	envelope := OrderEventEnvelope{
+		ID:      uuid.New().String(),
+		Type:    PaymentRetryScheduledEventType,
+		Source:  OrderEventsSource,
+		Subject: event.OrderID.String(),
+		Time:    time.Now().UTC(),
+		Data: map[string]interface{}{
+			"order_id":           event.OrderID.String(),
+			"user_id":            event.UserID.String(),
+			"total_amount":       event.TotalAmount,
+			"total_amount_minor": event.TotalAmountMinor,
+			"currency":           event.Currency,
+			"attempt":            event.Attempt,
+			"max_attempts":       event.MaxAttempts,
+			"next_attempt_at":    event.NextAttemptAt.UTC().Format(time.RFC3339),
 		},
+		SpecVersion: cloudevents.SpecVersion,
 	}
 
-	data, err := json.Marshal(eventWithMetadata)
+	partition, offset, err := p.sendOrderEvent(ctx, envelope, true)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to publish payment retry scheduled event", err, map[string]interface{}{
+			"order_id": event.OrderID,
+			"topic":    p.orderEventsTopic,
+		})
+		return errors.Wrap(err, "failed to publish payment retry scheduled event")
+	}
+
+	p.logger.Info(ctx, "Payment retry scheduled event published", map[string]interface{}{
+		"order_id":  event.OrderID,
+		"event_id":  envelope.ID,
+		"topic":     p.orderEventsTopic,
+		"partition": partition,
+		"offset":    offset,
+		"attempt":   event.Attempt,
+	})
+
+	return nil
+}
+
+// PublishRefundStatusChanged tells the customer, via notification-service, how their
+// refund request progresses
+func (p *Producer) PublishRefundStatusChanged(ctx context.Context, event service.RefundEvent) error {
+	envelope := OrderEventEnvelope{
+		ID:      uuid.New().String(),
+		Type:    RefundStatusChangedEventType,
+		Source:  OrderEventsSource,
+		Subject: event.OrderID.String(),
+		Time:    time.Now().UTC(),
+		Data: map[string]interface{}{
+			"order_id":     event.OrderID.String(),
+			"user_id":      event.UserID.String(),
+			"status":       string(event.Status),
+			"amount":       event.Amount,
+			"amount_minor": event.AmountMinor,
+			"currency":     event.Currency,
+			"reason":       event.Reason,
+			"note":         event.Note,
+		},
+		SpecVersion: cloudevents.SpecVersion,
+	}
+
+	partition, offset, err := p.sendOrderEvent(ctx, envelope, true)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to publish refund status changed event", err, map[string]interface{}{
+			"order_id": event.OrderID,
+			"topic":    p.orderEventsTopic,
+		})
+		return errors.Wrap(err, "failed to publish refund status changed event")
+	}
+
+	p.logger.Info(ctx, "Refund status changed event published", map[string]interface{}{
+		"order_id":  event.OrderID,
+		"event_id":  envelope.ID,
+		"topic":     p.orderEventsTopic,
+		"partition": partition,
+		"offset":    offset,
+		"status":    event.Status,
+	})
+
+	return nil
+}
+
+// PublishOrderBackordered tells the customer, via notification-service, that items of
+// their order wait for stock
+func (p *Producer) PublishOrderBackordered(ctx context.Context, event service.BackorderEvent) error {
+	return p.publishBackorderEvent(ctx, OrderBackorderedEventType, event)
+}
+
+// PublishBackorderAvailable tells the customer, via notification-service, that the
+// backordered items of their order arrived and the order continues
+func (p *Producer) PublishBackorderAvailable(ctx context.Context, event service.BackorderEvent) error {
+	return p.publishBackorderEvent(ctx, BackorderAvailableEventType, event)
+}
+
+// publishBackorderEvent publishes a backorder notification on the order events topic
+func (p *Producer) publishBackorderEvent(ctx context.Context, eventType string, event service.BackorderEvent) error {
+	items := make([]interface{}, len(event.Items))
+	for i, item := range event.Items {
+		items[i] = map[string]interface{}{
+			"item_id":   item.ItemID,
+			"item_name": item.ItemName,
+			"quantity":  item.Quantity,
+		}
+	}
+
+	data := map[string]interface{}{
+		"order_id":     event.OrderID.String(),
+		"user_id":      event.UserID.String(),
+		"items":        items,
+		"amount":       event.Amount,
+		"amount_minor": event.AmountMinor,
+		"currency":     event.Currency,
+	}
+	if event.ShipmentID != nil {
+		data["shipment_id"] = event.ShipmentID.String()
+	}
+
+	envelope := OrderEventEnvelope{
+		ID:          uuid.New().String(),
+		Type:        eventType,
+		Source:      OrderEventsSource,
+		Subject:     event.OrderID.String(),
+		Time:        time.Now().UTC(),
+		Data:        data,
+		SpecVersion: cloudevents.SpecVersion,
+	}
+
+	partition, offset, err := p.sendOrderEvent(ctx, envelope, true)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to publish backorder event", err, map[string]interface{}{
+			"order_id":   event.OrderID,
+			"event_type": eventType,
+			"topic":      p.orderEventsTopic,
+		})
+		return errors.Wrap(err, "failed to publish backorder event")
+	}
+
+	p.logger.Info(ctx, "Backorder event published", map[string]interface{}{
+		"order_id":   event.OrderID,
+		"event_id":   envelope.ID,
+		"event_type": eventType,
+		"topic":      p.orderEventsTopic,
+		"partition":  partition,
+		"offset":     offset,
+	})
+
+	return nil
+}
+
+// PublishOrderCreated announces a saved order, before its payment
+func (p *Producer) PublishOrderCreated(ctx context.Context, order *domain.Order) error {
+	items := make([]interface{}, len(order.Items))
+	itemCount := 0
+	for i, item := range order.Items {
+		items[i] = map[string]interface{}{
+			"item_id":          item.ItemID,
+			"item_name":        item.ItemName,
+			"quantity":         item.Quantity,
+			"unit_price":       item.UnitPrice.Float64(),
+			"unit_price_minor": item.UnitPrice.Minor,
+		}
+		itemCount += item.Quantity
+	}
+
+	envelope := OrderEventEnvelope{
+		ID:      uuid.New().String(),
+		Type:    OrderCreatedEventType,
+		Source:  OrderEventsSource,
+		Subject: order.ID.String(),
+		Time:    order.CreatedAt.UTC(),
+		Data: map[string]interface{}{
+			"order_id":           order.ID.String(),
+			"user_id":            order.UserID.String(),
+			"status":             string(order.Status),
+			"total_amount":       order.TotalAmount.Float64(),
+			"total_amount_minor": order.TotalAmount.Minor,
+			"currency":           order.TotalAmount.Currency,
+			"item_count":         itemCount,
+			"items":              items,
+			"tags":               orderTags(order),
+			"attributes":         orderAttributes(order),
+			"locale":             order.Locale,
+			"status_message":     i18n.StatusMessage(order.Locale, order.Status),
+			"sandbox":            order.Sandbox,
+		},
+		SpecVersion: cloudevents.SpecVersion,
+	}
+	if order.ShippingAddress != nil {
+		envelope.Data["shipping_address"] = order.ShippingAddress
+	}
+
+	partition, offset, err := p.sendOrderEvent(ctx, envelope, true)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to publish order created event", err, map[string]interface{}{
+			"order_id": order.ID,
+			"topic":    p.orderEventsTopic,
+		})
+		return errors.Wrap(err, "failed to publish order created event")
+	}
+
+	p.logger.Info(ctx, "Order created event published", map[string]interface{}{
+		"order_id":  order.ID,
+		"event_id":  envelope.ID,
+		"partition": partition,
+		"offset":    offset,
+	})
+
+	return nil
+}
+
+// PublishOrderStatusChanged announces an order status change
+func (p *Producer) PublishOrderStatusChanged(ctx context.Context, event service.OrderStatusChangedEvent) error {
+	envelope := OrderEventEnvelope{
+		ID:      uuid.New().String(),
+		Type:    OrderStatusChangedEventType,
+		Source:  OrderEventsSource,
+		Subject: event.OrderID.String(),
+		Time:    event.ChangedAt,
+		Data: map[string]interface{}{
+			"order_id":       event.OrderID.String(),
+			"from_status":    string(event.FromStatus),
+			"status":         string(event.Status),
+			"locale":         event.Locale,
+			"status_message": i18n.StatusMessage(event.Locale, event.Status),
+			"sandbox":        event.Sandbox,
+		},
+		SpecVersion: cloudevents.SpecVersion,
+	}
+
+	partition, offset, err := p.sendOrderEvent(ctx, envelope, false)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to publish order status event", err, map[string]interface{}{
+			"order_id": event.OrderID,
+			"topic":    p.orderEventsTopic,
+		})
+		return errors.Wrap(err, "failed to publish order status event")
+	}
+
+	p.logger.Info(ctx, "Order status event published", map[string]interface{}{
+		"order_id":    event.OrderID,
+		"from_status": event.FromStatus,
+		"status":      event.Status,
+		"partition":   partition,
+		"offset":      offset,
+	})
+
+	return nil
+}
+
+// PublishOrderTagsChanged announces new tags and custom attributes of an order
+func (p *Producer) PublishOrderTagsChanged(ctx context.Context, order *domain.Order) error {
+	envelope := OrderEventEnvelope{
+		ID:      uuid.New().String(),
+		Type:    OrderTagsChangedEventType,
+		Source:  OrderEventsSource,
+		Subject: order.ID.String(),
+		Time:    order.UpdatedAt.UTC(),
+		Data: map[string]interface{}{
+			"order_id":   order.ID.String(),
+			"user_id":    order.UserID.String(),
+			"status":     string(order.Status),
+			"tags":       orderTags(order),
+			"attributes": orderAttributes(order),
+		},
+		SpecVersion: cloudevents.SpecVersion,
+	}
+
+	partition, offset, err := p.sendOrderEvent(ctx, envelope, false)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to publish order tags changed event", err, map[string]interface{}{
+			"order_id": order.ID,
+			"topic":    p.orderEventsTopic,
+		})
+		return errors.Wrap(err, "failed to publish order tags changed event")
+	}
+
+	p.logger.Info(ctx, "Order tags changed event published", map[string]interface{}{
+		"order_id":  order.ID,
+		"event_id":  envelope.ID,
+		"partition": partition,
+		"offset":    offset,
+	})
+
+	return nil
+}
+
+// PublishOrderAbuseDetected reports a user who keeps exceeding the order placement
+// limits, for IAM to lock the account. The event is always JSON and keyed by user ID.
+func (p *Producer) PublishOrderAbuseDetected(ctx context.Context, event service.OrderAbuseEvent) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"user_id":        event.UserID,
+		"tenant_id":      event.TenantID,
+		"client_ip":      event.ClientIP,
+		"rejections":     event.Rejections,
+		"window_seconds": int64(event.Window.Seconds()),
+		"detected_at":    event.DetectedAt.Format(time.RFC3339),
+	})
 	if err != nil {
-		return errors.Wrap(err, "failed to marshal order status event")
+		return errors.Wrap(err, "failed to marshal order abuse event data")
+	}
+	cloudEvent := &cloudevents.Event{
+		SpecVersion:     cloudevents.SpecVersion,
+		ID:              uuid.New().String(),
+		Source:          OrderEventsSource,
+		Type:            OrderAbuseDetectedEventType,
+		Subject:         event.UserID,
+		Time:            event.DetectedAt,
+		DataContentType: "application/json",
+		Data:            data,
+	}
+	value, contentType, err := cloudEvent.Encode(cloudevents.FormatJSON)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode order abuse event")
 	}
 
 	message := &sarama.ProducerMessage{
-		Topic:     p.topic,
-		Key:       sarama.StringEncoder(orderID.String()),
-		Value:     sarama.ByteEncoder(data),
-		Timestamp: eventWithMetadata.EventMetadata.EventTime, // Fixed: access through EventMetadata
+		Topic:     p.securityEventsTopic,
+		Key:       sarama.StringEncoder(event.UserID),
+		Value:     sarama.ByteEncoder(value),
+		Timestamp: event.DetectedAt,
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(cloudevents.ContentTypeHeader), Value: []byte(contentType)},
+			{Key: []byte(platformKafka.EventTypeHeader), Value: []byte(OrderAbuseDetectedEventType)},
+			{Key: []byte(platformKafka.EventIDHeader), Value: []byte(cloudEvent.ID)},
+			{Key: []byte(platformKafka.EventSourceHeader), Value: []byte("order-service")},
+		},
+	}
+	message.Headers = withRequestMetadata(ctx, message.Headers)
+
+	partition, offset, err := p.send(message)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to publish order abuse event", err, map[string]interface{}{
+			"user_id": event.UserID,
+			"topic":   p.securityEventsTopic,
+		})
+		return errors.Wrap(err, "failed to publish order abuse event")
+	}
+
+	p.logger.Info(ctx, "Order abuse event published", map[string]interface{}{
+		"user_id":    event.UserID,
+		"event_id":   cloudEvent.ID,
+		"rejections": event.Rejections,
+		"partition":  partition,
+		"offset":     offset,
+	})
+
+	return nil
+}
+
+// orderTags returns the tags of an order for event data, an empty list rather than null
+func orderTags(order *domain.Order) []string {
+	if order.Tags == nil {
+		return []string{}
+	}
+	return order.Tags
+}
+
+// orderAttributes returns the attributes of an order for event data, an empty object rather than null
+func orderAttributes(order *domain.Order) domain.OrderAttributes {
+	if order.Attributes == nil {
+		return domain.OrderAttributes{}
+	}
+	return order.Attributes
+}
+
+// sendOrderEvent publishes an envelope to the order events topic, keyed by its order so
+// the events of an order stay in order. The envelope is written as a structured-mode
+// CloudEvent in the configured format. Notify marks events notification-service turns
+// into user notifications; it skips the others without decoding them.
+func (p *Producer) sendOrderEvent(ctx context.Context, envelope OrderEventEnvelope, notify bool) (int32, int64, error) {
+	data, err := json.Marshal(envelope.Data)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to marshal order event data")
+	}
+	event := &cloudevents.Event{
+		SpecVersion:     envelope.SpecVersion,
+		ID:              envelope.ID,
+		Source:          envelope.Source,
+		Type:            envelope.Type,
+		Subject:         envelope.Subject,
+		Time:            envelope.Time,
+		DataContentType: "application/json",
+		Data:            data,
+	}
+	value, contentType, err := event.Encode(p.eventFormat)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to encode order event")
+	}
+
+	message := &sarama.ProducerMessage{
+		Topic:     p.orderEventsTopic,
+		Key:       sarama.StringEncoder(envelope.Subject),
+		Value:     sarama.ByteEncoder(value),
+		Timestamp: envelope.Time,
 		Headers: []sarama.RecordHeader{
+			{
+				Key:   []byte(cloudevents.ContentTypeHeader),
+				Value: []byte(contentType),
+			},
 			{
 				Key:   []byte("event-type"),
-				Value: []byte(eventWithMetadata.EventMetadata.EventType), // Fixed: access through EventMetadata
+				Value: []byte(envelope.Type),
 			},
 			{
 				Key:   []byte("event-id"),
-				Value: []byte(eventWithMetadata.EventMetadata.EventID), // Fixed: access through EventMetadata
+				Value: []byte(envelope.ID),
 			},
 			{
 				Key:   []byte("order-id"),
-				Value: []byte(orderID.String()),
+				Value: []byte(envelope.Subject),
 			},
 		},
 	}
+	if notify {
+		message.Headers = append(message.Headers, sarama.RecordHeader{
+			Key:   []byte(platformKafka.NotifyHeader),
+			Value: []byte("true"),
+		})
+	}
 
-	partition, offset, err := p.producer.SendMessage(message)
-	if err != nil {
-		p.logger.Error(ctx, "Failed to publish order status event", err)
-		return errors.Wrap(err, "failed to publish order status event")
+	message.Headers = withDeadline(ctx, message.Headers)
+	message.Headers = withRequestMetadata(ctx, message.Headers)
+
+	return p.send(message)
+}
+
+// send checks the message against the maximum message size before sending it, so
+// an oversized message fails with ErrMessageTooLarge instead of at the broker
+func (p *Producer) send(message *sarama.ProducerMessage) (int32, int64, error) {
+	if err := platformKafka.CheckMessageSize(message, p.maxMessageBytes); err != nil {
+		return 0, 0, err
+	}
+	return p.producer.SendMessage(message)
+}
+
+// withDeadline appends the caller's budget to the headers, so the next saga step can
+// tell whether the client is still waiting
+func withDeadline(ctx context.Context, headers []sarama.RecordHeader) []sarama.RecordHeader {
+	value, ok := deadline.Header(ctx)
+	if !ok {
+		return headers
 	}
+	return append(headers, sarama.RecordHeader{
+		Key:   []byte(deadline.KafkaHeader),
+		Value: []byte(value),
+	})
+}
 
-	p.logger.Info(ctx, "Order status event published", map[string]interface{}{
-		"order_id":   orderID,
-		"old_status": oldStatus,
-		"new_status": newStatus,
-		"partition":  partition,
-		"offset":     offset,
+// withRequestMetadata appends the request ID, locale and caller identity carried in
+// the context, so consumers act for the same user and log under the same request
+func withRequestMetadata(ctx context.Context, headers []sarama.RecordHeader) []sarama.RecordHeader {
+	metadata := ctxmeta.KafkaHeaders(ctx)
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		headers = append(headers, sarama.RecordHeader{
+			Key:   []byte(key),
+			Value: []byte(metadata[key]),
+		})
+	}
+	return headers
+}
+
+// SendHeartbeat sends a heartbeat to the topic through the producer that publishes
+// the saga's events, so a broken produce path fails it like it fails an order
+func (p *Producer) SendHeartbeat(ctx context.Context, topic string) error {
+	sentAt := time.Now().UTC()
+	value, err := json.Marshal(map[string]interface{}{
+		"service": "order-service",
+		"sent_at": sentAt,
 	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal heartbeat")
+	}
 
+	message := &sarama.ProducerMessage{
+		Topic:     topic,
+		Key:       sarama.StringEncoder("order-service"),
+		Value:     sarama.ByteEncoder(value),
+		Timestamp: sentAt,
+		Headers: []sarama.RecordHeader{
+			{
+				Key:   []byte("event-type"),
+				Value: []byte("heartbeat"),
+			},
+		},
+	}
+	if _, _, err := p.send(message); err != nil {
+		return errors.Wrap(err, "failed to send heartbeat")
+	}
 	return nil
 }
 
@@ -224,15 +751,14 @@ type PaymentEventMessage struct {
 	EventMetadata EventMetadata `json:"metadata"`
 }
 
-// OrderStatusEvent represents an order status change
-type OrderStatusEvent struct {
-	OrderID   uuid.UUID `json:"order_id"`
-	OldStatus string    `json:"old_status"`
-	NewStatus string    `json:"new_status"`
+// OrderEventEnvelope is the CloudEvents envelope of events on the order events topic
+type OrderEventEnvelope struct {
+	ID              string                 `json:"id"`
+	Type            string                 `json:"type"`
+	Source          string                 `json:"source"`
+	Subject         string                 `json:"subject"`
+	Time            time.Time              `json:"time"`
+	DataContentType string                 `json:"datacontenttype,omitempty"`
+	Data            map[string]interface{} `json:"data"`
+	SpecVersion     string                 `json:"specversion"`
 }
-
-// OrderStatusEventMessage represents an order status event with metadata
-type OrderStatusEventMessage struct {
-	OrderStatusEvent OrderStatusEvent `json:"order_status"`
-	EventMetadata    EventMetadata    `json:"metadata"`
-}
\ No newline at end of file
@@ -0,0 +1,75 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/IBM/sarama"
+
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/cloudevents"
+	platformKafka "github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/testing/kafkatest"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/fakes"
+)
+
+const orderEventsTopic = "order-events"
+
+func newTestProducer(broker *kafkatest.Broker) *Producer {
+	return &Producer{
+		producer:         broker.SyncProducer(),
+		orderEventsTopic: orderEventsTopic,
+		eventFormat:      cloudevents.FormatJSON,
+		logger:           logging.NewNoOpLogger(),
+	}
+}
+
+func TestPublishOrderCreatedInOrderCurrencyMinorUnits(t *testing.T) {
+	broker := kafkatest.NewBroker()
+	order := fakes.NewOrder().WithCurrency("JPY").WithItem("fuel-rp1", 3, 500).Build()
+
+	if err := newTestProducer(broker).PublishOrderCreated(context.Background(), order); err != nil {
+		t.Fatalf("PublishOrderCreated: %v", err)
+	}
+
+	message := broker.Last(orderEventsTopic)
+	if message == nil {
+		t.Fatal("no order created event published")
+	}
+	if message.Key != order.ID.String() || message.EventType != OrderCreatedEventType {
+		t.Fatalf("published key %q type %q, want %q %q", message.Key, message.EventType, order.ID, OrderCreatedEventType)
+	}
+	if message.Headers[platformKafka.NotifyHeader] != "true" {
+		t.Fatal("order created event not flagged to notify the user")
+	}
+
+	event, err := cloudevents.Decode(message.Headers[cloudevents.ContentTypeHeader], message.Value)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	var data struct {
+		TotalAmountMinor int64  `json:"total_amount_minor"`
+		Currency         string `json:"currency"`
+		ItemCount        int    `json:"item_count"`
+	}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		t.Fatalf("unmarshal event data: %v", err)
+	}
+	if data.TotalAmountMinor != 1500 || data.Currency != "JPY" || data.ItemCount != 3 {
+		t.Fatalf("published total %d %s for %d items, want 1500 JPY for 3", data.TotalAmountMinor, data.Currency, data.ItemCount)
+	}
+}
+
+func TestPublishOrderCreatedFailsWhenTheBrokerDoes(t *testing.T) {
+	broker := kafkatest.NewBroker()
+	broker.FailOn(orderEventsTopic, sarama.ErrNotLeaderForPartition)
+
+	if err := newTestProducer(broker).PublishOrderCreated(context.Background(), fakes.NewOrder().WithItem("engine-rd180", 1, 1250).Build()); err == nil {
+		t.Fatal("PublishOrderCreated succeeded while the broker failed")
+	}
+	if messages := broker.Messages(orderEventsTopic); len(messages) != 0 {
+		t.Fatalf("%d messages recorded for a failed publish, want none", len(messages))
+	}
+}
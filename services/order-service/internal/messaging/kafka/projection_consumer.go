@@ -0,0 +1,351 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+	platformErrors "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/cloudevents"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/events"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
+)
+
+// Projector applies events to the order reporting store
+type Projector interface {
+	Project(ctx context.Context, event domain.ReportEvent) error
+}
+
+// ProjectionConsumer feeds the order, payment and assembly topics into the reporting
+// projection. It has its own consumer group, so it reads every event independently of
+// the saga consumer, and starts from the oldest retained offset so a new reporting
+// store is built from the history still on the topics.
+type ProjectionConsumer struct {
+	consumerGroup sarama.ConsumerGroup
+	topics        []string
+	projector     Projector
+	upcasters     *events.Registry
+	logger        logging.Logger
+	reporter      recovery.Reporter
+}
+
+// NewProjectionConsumer creates a new Kafka consumer for the reporting projection
+func NewProjectionConsumer(brokers []string, groupID string, topics []string, membership kafka.GroupMembership, projector Projector, upcasters *events.Registry, logger logging.Logger, reporter recovery.Reporter) (*ProjectionConsumer, error) {
+	config := sarama.NewConfig()
+	if err := membership.Apply(config); err != nil {
+		return nil, platformErrors.Wrap(err, "invalid Kafka projection consumer group membership")
+	}
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	config.Consumer.Group.Session.Timeout = 30 * time.Second
+	config.Consumer.Group.Heartbeat.Interval = 3 * time.Second
+	config.Consumer.Offsets.AutoCommit.Enable = true
+	config.Consumer.Offsets.AutoCommit.Interval = 1 * time.Second
+
+	consumerGroup, err := sarama.NewConsumerGroup(brokers, groupID, config)
+	if err != nil {
+		return nil, platformErrors.Wrap(err, "failed to create Kafka projection consumer group")
+	}
+
+	logger.Info(nil, "Kafka projection consumer created successfully", map[string]interface{}{
+		"brokers":            brokers,
+		"group_id":           groupID,
+		"topics":             topics,
+		"group_instance_id":  membership.InstanceID,
+		"rebalance_strategy": config.Consumer.Group.Rebalance.GroupStrategies[0].Name(),
+	})
+
+	return &ProjectionConsumer{
+		consumerGroup: consumerGroup,
+		topics:        topics,
+		projector:     projector,
+		upcasters:     upcasters,
+		logger:        logger,
+		reporter:      reporter,
+	}, nil
+}
+
+// Start consumes events until the context is cancelled. A session ended by a failed
+// projection is restarted after a pause and resumes at the first unprojected event.
+func (c *ProjectionConsumer) Start(ctx context.Context) error {
+	c.logger.Info(ctx, "Starting Kafka projection consumer", map[string]interface{}{
+		"topics": c.topics,
+	})
+
+	for {
+		err := c.consumerGroup.Consume(ctx, c.topics, c)
+		if errors.Is(err, sarama.ErrClosedConsumerGroup) || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			c.logger.Error(ctx, "Error consuming from Kafka", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// Close closes the Kafka projection consumer
+func (c *ProjectionConsumer) Close() error {
+	if err := c.consumerGroup.Close(); err != nil {
+		c.logger.Error(nil, "Failed to close Kafka projection consumer", err)
+		return err
+	}
+	c.logger.Info(nil, "Kafka projection consumer closed successfully")
+	return nil
+}
+
+// Setup is run at the beginning of a new session, before ConsumeClaim
+func (c *ProjectionConsumer) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// Cleanup is run at the end of a session, once all ConsumeClaim goroutines have exited
+func (c *ProjectionConsumer) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim projects the messages of a claim in order. Messages that cannot be
+// decoded are logged and skipped; a message the store fails to apply ends the session
+// unmarked, so it is redelivered instead of leaving a gap in the reports.
+func (c *ProjectionConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case message := <-claim.Messages():
+			if message == nil {
+				return nil
+			}
+
+			ctx := session.Context()
+			event, ok, err := decodeReportEvent(ctx, message, c.upcasters)
+			if err != nil {
+				c.logger.Error(ctx, "Skipping undecodable event", err, map[string]interface{}{
+					"topic":     message.Topic,
+					"partition": message.Partition,
+					"offset":    message.Offset,
+				})
+			}
+			if ok {
+				err := recovery.Call(ctx, c.reporter, recovery.SourceKafka, message.Topic, func(ctx context.Context) error {
+					return c.projector.Project(ctx, event)
+				})
+				if err != nil {
+					c.logger.Error(ctx, "Failed to project event", err, map[string]interface{}{
+						"topic":      message.Topic,
+						"partition":  message.Partition,
+						"offset":     message.Offset,
+						"event_type": event.Type,
+						"order_id":   event.OrderID,
+					})
+					return err
+				}
+			}
+
+			session.MarkMessage(message, "")
+
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// decodeReportEvent maps a message to a report event, upcasting payment events of older
+// versions. Event types the reports don't use are reported as not ok without an error.
+func decodeReportEvent(ctx context.Context, message *sarama.ConsumerMessage, upcasters *events.Registry) (domain.ReportEvent, bool, error) {
+	eventType := headerValue(message.Headers, "event-type")
+	event := domain.ReportEvent{
+		ID:         headerValue(message.Headers, "event-id"),
+		OccurredAt: message.Timestamp,
+	}
+	if event.ID == "" {
+		// Redeliveries of a message keep its position, so it identifies the event
+		event.ID = fmt.Sprintf("%s/%d/%d", message.Topic, message.Partition, message.Offset)
+	}
+
+	var orderID, userID string
+	switch eventType {
+	case OrderCreatedEventType, OrderStatusChangedEventType:
+		envelope, err := decodeOrderEvent(message)
+		if err != nil {
+			return event, false, err
+		}
+		orderID, _ = envelope.Data["order_id"].(string)
+		userID, _ = envelope.Data["user_id"].(string)
+		event.Sandbox, _ = envelope.Data["sandbox"].(bool)
+		if !envelope.Time.IsZero() {
+			event.OccurredAt = envelope.Time
+		}
+
+		if eventType == OrderCreatedEventType {
+			event.Type = domain.ReportEventOrderCreated
+			minor, _ := envelope.Data["total_amount_minor"].(float64)
+			currency, _ := envelope.Data["currency"].(string)
+			itemCount, _ := envelope.Data["item_count"].(float64)
+			event.Total = money.New(int64(minor), currency)
+			event.ItemCount = int(itemCount)
+		} else {
+			event.Type = domain.ReportEventStatusChanged
+			status, _ := envelope.Data["status"].(string)
+			event.Status = domain.OrderStatus(status)
+		}
+
+	case PaymentProcessedEventType:
+		value, _, err := upcasters.Upcast(ctx, eventType, headerValue(message.Headers, events.VersionHeader), message.Value)
+		if err != nil {
+			return event, false, err
+		}
+		var payment PaymentEventMessage
+		if err := json.Unmarshal(value, &payment); err != nil {
+			return event, false, platformErrors.Wrap(err, "failed to unmarshal payment event")
+		}
+		event.Type = domain.ReportEventPaymentProcessed
+		orderID, userID = payment.OrderID.String(), payment.UserID.String()
+		event.TransactionID = payment.TransactionID
+		event.PaymentStatus = "completed"
+		if !payment.ProcessedAt.IsZero() {
+			event.OccurredAt = payment.ProcessedAt
+		}
+
+	case PaymentReviewApprovedEventType, PaymentReviewDeclinedEventType:
+		var review PaymentReviewDecisionEvent
+		if err := json.Unmarshal(message.Value, &review); err != nil {
+			return event, false, platformErrors.Wrap(err, "failed to unmarshal payment review event")
+		}
+		event.Type = domain.ReportEventPaymentReviewed
+		orderID, userID = review.OrderID, review.UserID
+		event.TransactionID = review.TransactionID
+		event.PaymentStatus = review.PaymentStatus
+		event.OccurredAt = review.DecidedAt
+		if review.ProcessedAt != nil {
+			event.OccurredAt = *review.ProcessedAt
+		}
+
+	case AssemblyCompletedEventType, AssemblyFailedEventType:
+		assembly, err := decodeAssemblyEvent(message.Value)
+		if err != nil {
+			return event, false, err
+		}
+		orderID, userID = assembly.OrderID, assembly.UserID
+		if eventType == AssemblyCompletedEventType {
+			event.Type = domain.ReportEventAssemblyCompleted
+			event.AssemblySeconds = assembly.Duration
+		} else {
+			event.Type = domain.ReportEventAssemblyFailed
+			event.FailureReason = assembly.Reason
+		}
+		if !assembly.Time.IsZero() {
+			event.OccurredAt = assembly.Time
+		}
+
+	default:
+		return event, false, nil
+	}
+
+	parsedOrderID, err := uuid.Parse(orderID)
+	if err != nil {
+		return event, false, platformErrors.Wrap(err, "invalid order ID in "+eventType+" event")
+	}
+	event.OrderID = parsedOrderID
+	event.UserID, _ = uuid.Parse(userID)
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = message.Timestamp
+	}
+	return event, true, nil
+}
+
+// assemblyOutcome is the part of an assembly completed or failed event the reports use
+type assemblyOutcome struct {
+	OrderID  string
+	UserID   string
+	Duration int
+	Reason   string
+	Time     time.Time
+}
+
+// decodeAssemblyEvent reads an assembly event, either in the envelope assembly-service
+// publishes, with the event under data, or in the flat form of AssemblyCompletedEvent
+// and AssemblyFailedEvent
+func decodeAssemblyEvent(value []byte) (assemblyOutcome, error) {
+	var envelope struct {
+		Timestamp time.Time `json:"timestamp"`
+		Data      *struct {
+			OrderID  string `json:"order_id"`
+			UserID   string `json:"user_id"`
+			Duration int    `json:"actual_duration_seconds"`
+			Reason   string `json:"reason"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(value, &envelope); err != nil {
+		return assemblyOutcome{}, platformErrors.Wrap(err, "failed to unmarshal assembly event")
+	}
+	if envelope.Data != nil {
+		return assemblyOutcome{
+			OrderID:  envelope.Data.OrderID,
+			UserID:   envelope.Data.UserID,
+			Duration: envelope.Data.Duration,
+			Reason:   envelope.Data.Reason,
+			Time:     envelope.Timestamp,
+		}, nil
+	}
+
+	var flat struct {
+		AssemblyCompletedEvent
+		Reason   string    `json:"reason"`
+		FailedAt time.Time `json:"failed_at"`
+	}
+	if err := json.Unmarshal(value, &flat); err != nil {
+		return assemblyOutcome{}, platformErrors.Wrap(err, "failed to unmarshal assembly event")
+	}
+	outcome := assemblyOutcome{
+		OrderID:  flat.OrderID,
+		UserID:   flat.UserID,
+		Duration: flat.Duration,
+		Reason:   flat.Reason,
+		Time:     flat.CompletedAt,
+	}
+	if outcome.Time.IsZero() {
+		outcome.Time = flat.FailedAt
+	}
+	return outcome, nil
+}
+
+// headerValue extracts a header value from Kafka message headers
+func headerValue(headers []*sarama.RecordHeader, key string) string {
+	for _, header := range headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// decodeOrderEvent reads an order lifecycle event in either CloudEvents format
+func decodeOrderEvent(message *sarama.ConsumerMessage) (OrderEventEnvelope, error) {
+	var envelope OrderEventEnvelope
+	value := message.Value
+	if contentType := headerValue(message.Headers, cloudevents.ContentTypeHeader); cloudevents.IsAvro(contentType) {
+		event, err := cloudevents.Decode(contentType, value)
+		if err != nil {
+			return envelope, platformErrors.Wrap(err, "failed to decode order event")
+		}
+		if value, err = json.Marshal(event); err != nil {
+			return envelope, platformErrors.Wrap(err, "failed to marshal order event")
+		}
+	}
+	if err := json.Unmarshal(value, &envelope); err != nil {
+		return envelope, platformErrors.Wrap(err, "failed to unmarshal order event")
+	}
+	return envelope, nil
+}
@@ -0,0 +1,135 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	platformErrors "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// StatusObserver is told about every order status change on the order events topic
+type StatusObserver interface {
+	ObserveStatusChange(orderID uuid.UUID, status domain.OrderStatus)
+}
+
+// StatusChangeConsumer feeds the status changes on the order events topic to long
+// polls of order statuses. Every replica must see every change, so it reads all
+// partitions without a consumer group, starting at the newest offset: polls only
+// wait for changes made after they started.
+type StatusChangeConsumer struct {
+	consumer sarama.Consumer
+	topic    string
+	observer StatusObserver
+	logger   logging.Logger
+}
+
+// NewStatusChangeConsumer creates a new Kafka consumer for order status changes
+func NewStatusChangeConsumer(brokers []string, topic string, observer StatusObserver, logger logging.Logger) (*StatusChangeConsumer, error) {
+	config := sarama.NewConfig()
+	config.Consumer.Return.Errors = true
+
+	consumer, err := sarama.NewConsumer(brokers, config)
+	if err != nil {
+		return nil, platformErrors.Wrap(err, "failed to create Kafka status change consumer")
+	}
+
+	logger.Info(nil, "Kafka status change consumer created successfully", map[string]interface{}{
+		"brokers": brokers,
+		"topic":   topic,
+	})
+
+	return &StatusChangeConsumer{
+		consumer: consumer,
+		topic:    topic,
+		observer: observer,
+		logger:   logger,
+	}, nil
+}
+
+// Start consumes the partitions the topic has at start until the context is cancelled
+func (c *StatusChangeConsumer) Start(ctx context.Context) error {
+	partitions, err := c.consumer.Partitions(c.topic)
+	if err != nil {
+		return platformErrors.Wrap(err, "failed to list order events partitions")
+	}
+
+	c.logger.Info(ctx, "Starting Kafka status change consumer", map[string]interface{}{
+		"topic":      c.topic,
+		"partitions": len(partitions),
+	})
+
+	var wg sync.WaitGroup
+	for _, partition := range partitions {
+		partitionConsumer, err := c.consumer.ConsumePartition(c.topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return platformErrors.Wrap(err, "failed to consume order events partition")
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer partitionConsumer.Close()
+			c.consumePartition(ctx, partitionConsumer)
+		}()
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// consumePartition hands the status changes of one partition to the observer
+func (c *StatusChangeConsumer) consumePartition(ctx context.Context, partitionConsumer sarama.PartitionConsumer) {
+	for {
+		select {
+		case message, ok := <-partitionConsumer.Messages():
+			if !ok {
+				return
+			}
+			if headerValue(message.Headers, "event-type") != OrderStatusChangedEventType {
+				continue
+			}
+
+			envelope, err := decodeOrderEvent(message)
+			if err != nil {
+				c.logger.Error(ctx, "Skipping undecodable order event", err, map[string]interface{}{
+					"partition": message.Partition,
+					"offset":    message.Offset,
+				})
+				continue
+			}
+			orderID, _ := envelope.Data["order_id"].(string)
+			status, _ := envelope.Data["status"].(string)
+			id, err := uuid.Parse(orderID)
+			if err != nil || status == "" {
+				continue
+			}
+			c.observer.ObserveStatusChange(id, domain.OrderStatus(status))
+
+		case consumerErr, ok := <-partitionConsumer.Errors():
+			if !ok {
+				return
+			}
+			c.logger.Error(ctx, "Kafka status change consumer error", consumerErr.Err, map[string]interface{}{
+				"partition": consumerErr.Partition,
+			})
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Close closes the Kafka status change consumer
+func (c *StatusChangeConsumer) Close() error {
+	if err := c.consumer.Close(); err != nil {
+		c.logger.Error(nil, "Failed to close Kafka status change consumer", err)
+		return err
+	}
+	c.logger.Info(nil, "Kafka status change consumer closed successfully")
+	return nil
+}
@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// AddressRepository defines the interface for address book data access operations.
+// Addresses are scoped to their user: one user's ID never finds another's address.
+type AddressRepository interface {
+	// Create stores a new address. A default address replaces the user's previous default.
+	Create(ctx context.Context, address *domain.SavedAddress) error
+
+	// GetByID retrieves an address of the user
+	GetByID(ctx context.Context, userID, addressID uuid.UUID) (*domain.SavedAddress, error)
+
+	// GetDefault retrieves the default address of the user
+	GetDefault(ctx context.Context, userID uuid.UUID) (*domain.SavedAddress, error)
+
+	// ListByUser returns the addresses of the user, oldest first
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.SavedAddress, error)
+
+	// CountByUser returns the number of addresses of the user
+	CountByUser(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// Update stores the changes to an address. A default address replaces the user's previous default.
+	Update(ctx context.Context, address *domain.SavedAddress) error
+
+	// Delete removes an address of the user
+	Delete(ctx context.Context, userID, addressID uuid.UUID) error
+}
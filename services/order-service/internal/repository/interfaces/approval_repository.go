@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// ApprovalRepository defines the interface for order approval data access operations
+type ApprovalRepository interface {
+	// Create stores a new approval request
+	Create(ctx context.Context, approval *domain.OrderApproval) error
+
+	// GetByOrderID retrieves the approval of an order
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) (*domain.OrderApproval, error)
+
+	// ListPending returns up to limit approvals awaiting a decision, oldest first
+	ListPending(ctx context.Context, limit int) ([]*domain.OrderApproval, error)
+
+	// ListExpired returns up to limit pending approvals whose deadline passed before now
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.OrderApproval, error)
+
+	// Decide records the decision of a pending approval. It returns a conflict error
+	// when the approval was decided in the meantime.
+	Decide(ctx context.Context, approval *domain.OrderApproval) error
+}
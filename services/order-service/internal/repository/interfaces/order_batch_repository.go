@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// OrderBatchRepository defines the interface for asynchronous order batch data access operations
+type OrderBatchRepository interface {
+	// Create stores a new batch
+	Create(ctx context.Context, batch *domain.OrderBatch) error
+
+	// GetByID retrieves a batch with the results recorded so far
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.OrderBatch, error)
+
+	// AddResult records the outcome of an order of the batch
+	AddResult(ctx context.Context, id uuid.UUID, result domain.BatchOrderResult) error
+
+	// Complete marks the batch completed
+	Complete(ctx context.Context, id uuid.UUID) error
+}
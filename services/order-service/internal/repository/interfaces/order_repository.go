@@ -20,8 +20,14 @@ type OrderRepository interface {
 	// Update updates an existing order (including items if modified)
 	Update(ctx context.Context, order *domain.Order) error
 	
-	// UpdateStatus updates only the status and related timestamps of an order
-	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus) error
+	// UpdateStatus moves an order to a new status, enforcing the order state machine, and
+	// returns the previous status. Disallowed transitions fail with a conflict error
+	// matching domain.ErrInvalidTransition.
+	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus) (domain.OrderStatus, error)
+	
+	// UpdateStatusForEvent applies the status updates triggered by an event in one transaction,
+	// recording the event ID so a redelivered event is skipped. Returns no transitions for duplicates.
+	UpdateStatusForEvent(ctx context.Context, eventID, eventType string, id uuid.UUID, statuses ...domain.OrderStatus) ([]domain.StatusTransition, error)
 	
 	// List retrieves orders based on filter criteria with pagination
 	List(ctx context.Context, filter domain.OrderFilter) ([]*domain.Order, error)
@@ -29,9 +35,18 @@ type OrderRepository interface {
 	// Count returns the total number of orders matching the filter criteria
 	Count(ctx context.Context, filter domain.OrderFilter) (int, error)
 	
+	// UpdateTags replaces the tags and attributes of an order
+	UpdateTags(ctx context.Context, id uuid.UUID, tags []string, attributes domain.OrderAttributes) error
+	
 	// Delete soft deletes an order (sets deleted_at timestamp)
 	Delete(ctx context.Context, id uuid.UUID) error
 	
+	// SaveSerialNumbers records the serial numbers allocated to an order, ignoring ones already recorded
+	SaveSerialNumbers(ctx context.Context, orderID uuid.UUID, serials []domain.SerialAllocation) error
+	
+	// UpdateShipment records the progress of a shipment of a partially fulfilled order
+	UpdateShipment(ctx context.Context, shipment *domain.Shipment) error
+	
 	// GetOrderMetrics returns aggregated metrics for monitoring and analytics
 	GetOrderMetrics(ctx context.Context) (*OrderMetrics, error)
 }
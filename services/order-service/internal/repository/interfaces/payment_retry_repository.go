@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// PaymentRetryRepository defines the interface for payment retry schedule data access operations
+type PaymentRetryRepository interface {
+	// Create stores a new retry schedule
+	Create(ctx context.Context, retry *domain.PaymentRetry) error
+
+	// ListDue returns up to limit scheduled retries whose next attempt is due at now, earliest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.PaymentRetry, error)
+
+	// Claim counts an attempt of a scheduled retry due at now. It returns a conflict error
+	// when another instance claimed the attempt or the schedule ended in the meantime.
+	Claim(ctx context.Context, retry *domain.PaymentRetry, now time.Time) error
+
+	// Update stores the outcome of an attempt
+	Update(ctx context.Context, retry *domain.PaymentRetry) error
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// PurgeRepository deletes the order data of test users, for resetting demo
+// environments. Soft deleted orders are included.
+type PurgeRepository interface {
+	// PurgeUsers permanently deletes the orders, webhooks and addresses of the users, with
+	// everything that refers to them, in one transaction. A dry run only counts.
+	PurgeUsers(ctx context.Context, userIDs []uuid.UUID, dryRun bool) (*UserDataPurge, error)
+}
+
+// UserDataPurge reports the order data deleted for a set of users
+type UserDataPurge struct {
+	OrderIDs []uuid.UUID      `json:"order_ids"`
+	Deleted  map[string]int64 `json:"deleted"` // Rows per table
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitStore counts events over sliding windows shared by all replicas
+type RateLimitStore interface {
+	// Hit records an event under key unless limit events were recorded within the
+	// window. When the event is rejected it returns how long until the oldest
+	// event leaves the window.
+	Hit(ctx context.Context, key string, limit int, window time.Duration) (allowed bool, retryAfter time.Duration, err error)
+
+	// Count records an event under key and returns the events within the window
+	Count(ctx context.Context, key string, window time.Duration) (int64, error)
+
+	// Reset forgets the events recorded under key
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// RefundRepository defines the interface for order refund data access operations.
+// Writes keep the order's refund status in step with its refund case.
+type RefundRepository interface {
+	// Create stores a new refund case. It returns a conflict error when the order
+	// already has one.
+	Create(ctx context.Context, refund *domain.RefundCase) error
+
+	// GetByOrderID retrieves the refund case of an order
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) (*domain.RefundCase, error)
+
+	// ListOpen returns up to limit refund cases awaiting a decision by support, oldest first
+	ListOpen(ctx context.Context, limit int) ([]*domain.RefundCase, error)
+
+	// Update records a change of the refund case made from the given status. It
+	// returns a conflict error when the case changed status in the meantime.
+	Update(ctx context.Context, refund *domain.RefundCase, from domain.RefundStatus) error
+}
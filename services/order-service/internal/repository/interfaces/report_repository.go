@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// ReportRepository defines the interface for the order reporting store. The store is a
+// projection of the event streams, separate from the transactional orders database.
+type ReportRepository interface {
+	// Apply folds an event into its order report. Events already applied are skipped
+	// and reported with false.
+	Apply(ctx context.Context, event domain.ReportEvent) (bool, error)
+
+	// GetByOrderID retrieves the report of an order
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) (*domain.OrderReport, error)
+
+	// List retrieves the order reports matching the filter, newest first
+	List(ctx context.Context, filter domain.ReportFilter) ([]*domain.OrderReport, error)
+
+	// Summary aggregates the reports of the orders created in [from, to)
+	Summary(ctx context.Context, from, to time.Time) (*domain.ReportSummary, error)
+
+	// Purge deletes the reports of the users and orders, for resetting demo
+	// environments, and returns how many there were. A dry run only counts.
+	Purge(ctx context.Context, userIDs, orderIDs []uuid.UUID, dryRun bool) (int64, error)
+}
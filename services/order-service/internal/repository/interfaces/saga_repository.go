@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// SagaRepository defines the interface for order saga history data access operations
+type SagaRepository interface {
+	// Record appends an entry to the saga history of an order
+	Record(ctx context.Context, record *domain.SagaStepRecord) error
+
+	// ListByOrder returns the saga history of an order, oldest first
+	ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*domain.SagaStepRecord, error)
+}
@@ -0,0 +1,44 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// WebhookRepository defines the interface for webhook and delivery data access operations
+type WebhookRepository interface {
+	// Create stores a new webhook
+	Create(ctx context.Context, webhook *domain.Webhook) error
+
+	// GetByID retrieves a webhook owned by the user
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*domain.Webhook, error)
+
+	// GetForDelivery retrieves a webhook regardless of its owner, for the dispatcher
+	GetForDelivery(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+
+	// ListByUser retrieves all webhooks owned by the user
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Webhook, error)
+
+	// Update updates the URL, description, statuses and active flag of a webhook
+	Update(ctx context.Context, webhook *domain.Webhook) error
+
+	// Delete removes a webhook owned by the user along with its delivery history
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+
+	// CreateDelivery stores a new delivery
+	CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// ClaimDueDeliveries returns up to limit pending deliveries whose next attempt is due and
+	// pushes their next attempt back by lease, so concurrent dispatchers don't send them twice
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error)
+
+	// UpdateDelivery records the outcome of a delivery attempt
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// ListDeliveries returns the most recent deliveries of a webhook, newest first
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*domain.WebhookDelivery, error)
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+)
+
+const addressColumns = `id, user_id, label, recipient_name, line1, line2, city, region, postal_code,
+	country, phone, is_default, created_at, updated_at`
+
+// AddressRepository implements the AddressRepository interface using PostgreSQL
+type AddressRepository struct {
+	db *sqlx.DB
+}
+
+// NewAddressRepository creates a new PostgreSQL address repository
+func NewAddressRepository(db *sqlx.DB) interfaces.AddressRepository {
+	return &AddressRepository{
+		db: db,
+	}
+}
+
+// Create stores a new address, clearing the user's previous default if it is the default
+func (r *AddressRepository) Create(ctx context.Context, address *domain.SavedAddress) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return platformError.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := clearDefaultAddress(ctx, tx, address); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO user_addresses (` + addressColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err = tx.ExecContext(ctx, query,
+		address.ID, address.UserID, address.Label, address.RecipientName, address.Line1, address.Line2,
+		address.City, address.Region, address.PostalCode, address.Country, address.Phone,
+		address.IsDefault, address.CreatedAt, address.UpdatedAt)
+	if err != nil {
+		return platformError.Wrap(err, "failed to insert address")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return platformError.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// GetByID retrieves an address of the user
+func (r *AddressRepository) GetByID(ctx context.Context, userID, addressID uuid.UUID) (*domain.SavedAddress, error) {
+	query := `SELECT ` + addressColumns + ` FROM user_addresses WHERE id = $1 AND user_id = $2`
+
+	var address domain.SavedAddress
+	if err := r.db.GetContext(ctx, &address, query, addressID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, platformError.NewNotFound("address not found")
+		}
+		return nil, platformError.Wrap(err, "failed to get address")
+	}
+	return &address, nil
+}
+
+// GetDefault retrieves the default address of the user
+func (r *AddressRepository) GetDefault(ctx context.Context, userID uuid.UUID) (*domain.SavedAddress, error) {
+	query := `SELECT ` + addressColumns + ` FROM user_addresses WHERE user_id = $1 AND is_default`
+
+	var address domain.SavedAddress
+	if err := r.db.GetContext(ctx, &address, query, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, platformError.NewNotFound("default address not found")
+		}
+		return nil, platformError.Wrap(err, "failed to get default address")
+	}
+	return &address, nil
+}
+
+// ListByUser returns the addresses of the user, oldest first
+func (r *AddressRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.SavedAddress, error) {
+	query := `SELECT ` + addressColumns + ` FROM user_addresses WHERE user_id = $1 ORDER BY created_at`
+
+	addresses := []*domain.SavedAddress{}
+	if err := r.db.SelectContext(ctx, &addresses, query, userID); err != nil {
+		return nil, platformError.Wrap(err, "failed to list addresses")
+	}
+	return addresses, nil
+}
+
+// CountByUser returns the number of addresses of the user
+func (r *AddressRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM user_addresses WHERE user_id = $1`, userID); err != nil {
+		return 0, platformError.Wrap(err, "failed to count addresses")
+	}
+	return count, nil
+}
+
+// Update stores the changes to an address, clearing the user's previous default if it is the default
+func (r *AddressRepository) Update(ctx context.Context, address *domain.SavedAddress) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return platformError.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := clearDefaultAddress(ctx, tx, address); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE user_addresses
+		SET label = $3, recipient_name = $4, line1 = $5, line2 = $6, city = $7, region = $8,
+			postal_code = $9, country = $10, phone = $11, is_default = $12, updated_at = $13
+		WHERE id = $1 AND user_id = $2`
+
+	result, err := tx.ExecContext(ctx, query,
+		address.ID, address.UserID, address.Label, address.RecipientName, address.Line1, address.Line2,
+		address.City, address.Region, address.PostalCode, address.Country, address.Phone,
+		address.IsDefault, address.UpdatedAt)
+	if err != nil {
+		return platformError.Wrap(err, "failed to update address")
+	}
+	if err := requireRowAffected(result, "address not found"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return platformError.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// Delete removes an address of the user
+func (r *AddressRepository) Delete(ctx context.Context, userID, addressID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_addresses WHERE id = $1 AND user_id = $2`, addressID, userID)
+	if err != nil {
+		return platformError.Wrap(err, "failed to delete address")
+	}
+	return requireRowAffected(result, "address not found")
+}
+
+// clearDefaultAddress unsets the user's other default address before address becomes the
+// default, keeping the one default per user the unique index allows
+func clearDefaultAddress(ctx context.Context, tx *sqlx.Tx, address *domain.SavedAddress) error {
+	if !address.IsDefault {
+		return nil
+	}
+
+	query := `
+		UPDATE user_addresses
+		SET is_default = FALSE, updated_at = $3
+		WHERE user_id = $1 AND id <> $2 AND is_default`
+
+	if _, err := tx.ExecContext(ctx, query, address.UserID, address.ID, address.UpdatedAt); err != nil {
+		return platformError.Wrap(err, "failed to clear default address")
+	}
+	return nil
+}
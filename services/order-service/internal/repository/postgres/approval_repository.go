@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+const approvalColumns = `order_id, user_id, amount_minor, currency, status, requested_at, expires_at,
+	decided_by, decided_at, reason`
+
+// approvalRow maps the order_approvals table, whose amount is stored in minor units
+type approvalRow struct {
+	domain.OrderApproval
+	AmountMinor int64  `db:"amount_minor"`
+	Currency    string `db:"currency"`
+}
+
+func (row *approvalRow) toDomain() *domain.OrderApproval {
+	approval := row.OrderApproval
+	approval.Amount = money.New(row.AmountMinor, row.Currency)
+	return &approval
+}
+
+// ApprovalRepository implements the ApprovalRepository interface using PostgreSQL
+type ApprovalRepository struct {
+	db *sqlx.DB
+}
+
+// NewApprovalRepository creates a new PostgreSQL order approval repository
+func NewApprovalRepository(db *sqlx.DB) interfaces.ApprovalRepository {
+	return &ApprovalRepository{
+		db: db,
+	}
+}
+
+// Create stores a new approval request
+func (r *ApprovalRepository) Create(ctx context.Context, approval *domain.OrderApproval) error {
+	query := `
+		INSERT INTO order_approvals (` + approvalColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		approval.OrderID, approval.UserID, approval.Amount.Minor, approval.Amount.Currency,
+		approval.Status, approval.RequestedAt, approval.ExpiresAt,
+		approval.DecidedBy, approval.DecidedAt, approval.Reason)
+	if err != nil {
+		return platformError.Wrap(err, "failed to insert order approval")
+	}
+	return nil
+}
+
+// GetByOrderID retrieves the approval of an order
+func (r *ApprovalRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) (*domain.OrderApproval, error) {
+	query := `SELECT ` + approvalColumns + ` FROM order_approvals WHERE order_id = $1`
+
+	var row approvalRow
+	if err := r.db.GetContext(ctx, &row, query, orderID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, platformError.NewNotFound("order approval not found")
+		}
+		return nil, platformError.Wrap(err, "failed to get order approval")
+	}
+	return row.toDomain(), nil
+}
+
+// ListPending returns up to limit approvals awaiting a decision, oldest first
+func (r *ApprovalRepository) ListPending(ctx context.Context, limit int) ([]*domain.OrderApproval, error) {
+	query := `
+		SELECT ` + approvalColumns + ` FROM order_approvals
+		WHERE status = $1
+		ORDER BY requested_at
+		LIMIT $2`
+
+	return r.list(ctx, query, domain.ApprovalPending, limit)
+}
+
+// ListExpired returns up to limit pending approvals whose deadline passed before now
+func (r *ApprovalRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.OrderApproval, error) {
+	query := `
+		SELECT ` + approvalColumns + ` FROM order_approvals
+		WHERE status = $1 AND expires_at <= $2
+		ORDER BY expires_at
+		LIMIT $3`
+
+	return r.list(ctx, query, domain.ApprovalPending, now, limit)
+}
+
+// Decide records the decision of a pending approval. It returns a conflict error
+// when the approval was decided in the meantime.
+func (r *ApprovalRepository) Decide(ctx context.Context, approval *domain.OrderApproval) error {
+	query := `
+		UPDATE order_approvals
+		SET status = $2, decided_by = $3, decided_at = $4, reason = $5
+		WHERE order_id = $1 AND status = 'pending'`
+
+	result, err := r.db.ExecContext(ctx, query,
+		approval.OrderID, approval.Status, approval.DecidedBy, approval.DecidedAt, approval.Reason)
+	if err != nil {
+		return platformError.Wrap(err, "failed to record order approval decision")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return platformError.Wrap(err, "failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return platformError.NewConflict("order approval has already been decided")
+	}
+	return nil
+}
+
+func (r *ApprovalRepository) list(ctx context.Context, query string, args ...interface{}) ([]*domain.OrderApproval, error) {
+	rows := []approvalRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, platformError.Wrap(err, "failed to list order approvals")
+	}
+
+	approvals := make([]*domain.OrderApproval, len(rows))
+	for i := range rows {
+		approvals[i] = rows[i].toDomain()
+	}
+	return approvals, nil
+}
@@ -34,7 +34,8 @@ CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items(order_id);
 CREATE INDEX IF NOT EXISTS idx_order_items_item_id ON order_items(item_id);
 
 -- Add constraint for order status validation
-ALTER TABLE orders ADD CONSTRAINT IF NOT EXISTS check_order_status 
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS check_order_status;
+ALTER TABLE orders ADD CONSTRAINT check_order_status
     CHECK (status IN ('pending', 'paid', 'assembled', 'completed', 'cancelled', 'failed'));
 
 -- Create trigger function to automatically update updated_at timestamp
@@ -54,5 +55,6 @@ CREATE TRIGGER update_orders_updated_at
     EXECUTE FUNCTION update_updated_at_column();
 
 -- Create indexes for metrics queries (performance optimization)
-CREATE INDEX IF NOT EXISTS idx_orders_created_date ON orders(DATE(created_at)) WHERE deleted_at IS NULL;
+-- DATE() of a TIMESTAMPTZ depends on the session time zone and cannot be indexed, so days are UTC
+CREATE INDEX IF NOT EXISTS idx_orders_created_date ON orders(((created_at AT TIME ZONE 'UTC')::date)) WHERE deleted_at IS NULL;
 CREATE INDEX IF NOT EXISTS idx_orders_status_created ON orders(status, created_at) WHERE deleted_at IS NULL;
\ No newline at end of file
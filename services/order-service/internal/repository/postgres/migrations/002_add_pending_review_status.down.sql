@@ -0,0 +1,6 @@
+-- Orders still awaiting review cannot be represented without the status
+UPDATE orders SET status = 'failed' WHERE status = 'pending_review';
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS check_order_status;
+ALTER TABLE orders ADD CONSTRAINT check_order_status
+    CHECK (status IN ('pending', 'paid', 'assembled', 'completed', 'cancelled', 'failed'));
@@ -0,0 +1,4 @@
+-- Allow orders to wait for manual payment review
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS check_order_status;
+ALTER TABLE orders ADD CONSTRAINT check_order_status
+    CHECK (status IN ('pending', 'pending_review', 'paid', 'assembled', 'completed', 'cancelled', 'failed'));
@@ -0,0 +1,10 @@
+-- Serial numbers of serial-tracked items allocated to an order at reservation confirmation
+CREATE TABLE IF NOT EXISTS order_item_serials (
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    item_id VARCHAR(255) NOT NULL,
+    serial_number VARCHAR(255) NOT NULL,
+    allocated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (order_id, serial_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_item_serials_serial_number ON order_item_serials(serial_number);
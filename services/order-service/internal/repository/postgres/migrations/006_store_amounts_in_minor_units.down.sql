@@ -0,0 +1,18 @@
+-- Amounts in currencies with thousandths are rounded to the hundredths DECIMAL(10,2) holds
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS unit_price DECIMAL(10,2) NOT NULL DEFAULT 0.00 CHECK (unit_price >= 0);
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS total DECIMAL(10,2) NOT NULL DEFAULT 0.00 CHECK (total >= 0);
+UPDATE order_items SET unit_price = unit_price_minor::numeric / units.per_major, total = total_minor::numeric / units.per_major
+FROM (SELECT id, CASE
+    WHEN UPPER(currency) IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+    WHEN UPPER(currency) IN ('CLP', 'ISK', 'JPY', 'KRW', 'PYG', 'UGX', 'VND', 'XAF', 'XOF') THEN 1
+    ELSE 100 END AS per_major FROM orders) AS units
+WHERE units.id = order_items.order_id;
+ALTER TABLE order_items DROP COLUMN IF EXISTS unit_price_minor;
+ALTER TABLE order_items DROP COLUMN IF EXISTS total_minor;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS total_amount DECIMAL(10,2) NOT NULL DEFAULT 0.00;
+UPDATE orders SET total_amount = total_amount_minor::numeric / CASE
+    WHEN UPPER(currency) IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+    WHEN UPPER(currency) IN ('CLP', 'ISK', 'JPY', 'KRW', 'PYG', 'UGX', 'VND', 'XAF', 'XOF') THEN 1
+    ELSE 100 END;
+ALTER TABLE orders DROP COLUMN IF EXISTS total_amount_minor;
@@ -0,0 +1,20 @@
+-- Store money as integer minor units instead of DECIMAL, so totals are summed exactly.
+-- A minor unit is a hundredth of the order currency except for the currencies the
+-- money package lists: none for e.g. JPY, a thousandth for e.g. KWD.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS total_amount_minor BIGINT NOT NULL DEFAULT 0;
+UPDATE orders SET total_amount_minor = ROUND(total_amount * CASE
+    WHEN UPPER(currency) IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+    WHEN UPPER(currency) IN ('CLP', 'ISK', 'JPY', 'KRW', 'PYG', 'UGX', 'VND', 'XAF', 'XOF') THEN 1
+    ELSE 100 END);
+ALTER TABLE orders DROP COLUMN IF EXISTS total_amount;
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS unit_price_minor BIGINT NOT NULL DEFAULT 0 CHECK (unit_price_minor >= 0);
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS total_minor BIGINT NOT NULL DEFAULT 0 CHECK (total_minor >= 0);
+UPDATE order_items SET unit_price_minor = ROUND(unit_price * units.per_major), total_minor = ROUND(total * units.per_major)
+FROM (SELECT id, CASE
+    WHEN UPPER(currency) IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+    WHEN UPPER(currency) IN ('CLP', 'ISK', 'JPY', 'KRW', 'PYG', 'UGX', 'VND', 'XAF', 'XOF') THEN 1
+    ELSE 100 END AS per_major FROM orders) AS units
+WHERE units.id = order_items.order_id;
+ALTER TABLE order_items DROP COLUMN IF EXISTS unit_price;
+ALTER TABLE order_items DROP COLUMN IF EXISTS total;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS order_approvals;
+
+-- Orders still awaiting approval cannot be represented without the status
+UPDATE orders SET status = 'cancelled' WHERE status = 'pending_approval';
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS check_order_status;
+ALTER TABLE orders ADD CONSTRAINT check_order_status
+    CHECK (status IN ('pending', 'pending_review', 'paid', 'assembled', 'completed', 'cancelled', 'failed'));
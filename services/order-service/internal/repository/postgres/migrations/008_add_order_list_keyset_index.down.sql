@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orders_user_created_at_id;
+DROP INDEX IF EXISTS idx_orders_created_at_id;
@@ -0,0 +1,3 @@
+-- Order listings page by (created_at, id), newest first
+CREATE INDEX IF NOT EXISTS idx_orders_created_at_id ON orders(created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_user_created_at_id ON orders(user_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
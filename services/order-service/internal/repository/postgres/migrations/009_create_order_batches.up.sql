@@ -0,0 +1,12 @@
+-- Order batches submitted for asynchronous processing, with the result of
+-- each order appended as it finishes
+CREATE TABLE IF NOT EXISTS order_batches (
+    id UUID PRIMARY KEY,
+    status VARCHAR(20) NOT NULL DEFAULT 'running'
+        CHECK (status IN ('running', 'completed')),
+    order_count INTEGER NOT NULL CHECK (order_count > 0),
+    results JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
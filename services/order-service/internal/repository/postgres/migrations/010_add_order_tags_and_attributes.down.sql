@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_orders_attributes;
+DROP INDEX IF EXISTS idx_orders_tags;
+ALTER TABLE orders DROP COLUMN IF EXISTS attributes;
+ALTER TABLE orders DROP COLUMN IF EXISTS tags;
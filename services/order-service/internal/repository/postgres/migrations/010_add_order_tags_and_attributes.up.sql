@@ -0,0 +1,7 @@
+-- Integrator tags and typed custom attributes, e.g. ["mission:artemis-3"] and
+-- {"mission_id": "ART-3"}. GIN indexes serve the containment (@>) filters of order listings.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX IF NOT EXISTS idx_orders_tags ON orders USING GIN (tags jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_orders_attributes ON orders USING GIN (attributes jsonb_path_ops);
@@ -0,0 +1,23 @@
+-- Address books of the users, with at most one default address per user
+CREATE TABLE IF NOT EXISTS user_addresses (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    label VARCHAR(50) NOT NULL DEFAULT '',
+    recipient_name VARCHAR(100) NOT NULL,
+    line1 VARCHAR(200) NOT NULL,
+    line2 VARCHAR(200) NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL,
+    region VARCHAR(100) NOT NULL DEFAULT '',
+    postal_code VARCHAR(20) NOT NULL,
+    country CHAR(2) NOT NULL,
+    phone VARCHAR(32) NOT NULL DEFAULT '',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_addresses_user_id ON user_addresses(user_id, created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_addresses_default ON user_addresses(user_id) WHERE is_default;
+
+-- Snapshot of the address an order ships to; NULL for orders without delivery
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_address JSONB;
@@ -0,0 +1,22 @@
+-- Background jobs of the shared job framework (shared/platform/jobs), such as
+-- large order exports; kept in sync with jobs.Schema
+CREATE TABLE IF NOT EXISTS jobs (
+    id UUID PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    state VARCHAR(20) NOT NULL CHECK (state IN ('queued', 'running', 'succeeded', 'failed')),
+    payload JSONB NOT NULL,
+    result JSONB,
+    progress_done BIGINT NOT NULL DEFAULT 0,
+    progress_total BIGINT NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_by VARCHAR(255) NOT NULL DEFAULT '',
+    locked_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_due ON jobs (type, run_at) WHERE state IN ('queued', 'running');
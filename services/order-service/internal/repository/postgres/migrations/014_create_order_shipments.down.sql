@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS order_shipments;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS fulfillment_policy;
+
+-- Partially assembled orders cannot be represented without the status; their
+-- assembled shipments already went out, so they are kept as paid
+UPDATE orders SET status = 'paid' WHERE status = 'partially_assembled';
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS check_order_status;
+ALTER TABLE orders ADD CONSTRAINT check_order_status
+    CHECK (status IN ('pending', 'pending_approval', 'pending_review', 'paid', 'assembled', 'completed', 'cancelled', 'failed'));
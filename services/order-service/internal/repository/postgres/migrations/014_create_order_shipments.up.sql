@@ -0,0 +1,26 @@
+-- Orders may proceed with the items in stock and backorder the rest
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS check_order_status;
+ALTER TABLE orders ADD CONSTRAINT check_order_status
+    CHECK (status IN ('pending', 'pending_approval', 'pending_review', 'paid', 'partially_assembled', 'assembled', 'completed', 'cancelled', 'failed'));
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS fulfillment_policy VARCHAR(20) NOT NULL DEFAULT 'all_or_nothing'
+    CHECK (fulfillment_policy IN ('all_or_nothing', 'partial'));
+
+-- Parts of partially fulfilled orders, each reserved, paid and assembled on its own
+CREATE TABLE IF NOT EXISTS order_shipments (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    sequence INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL
+        CHECK (status IN ('backordered', 'pending', 'paid', 'assembled', 'cancelled')),
+    items JSONB NOT NULL,
+    amount_minor BIGINT NOT NULL,
+    transaction_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    paid_at TIMESTAMP WITH TIME ZONE,
+    assembled_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (order_id, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_shipments_backordered ON order_shipments(created_at) WHERE status = 'backordered';
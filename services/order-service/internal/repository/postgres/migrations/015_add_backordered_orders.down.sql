@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_orders_backordered;
+
+-- Backordered orders were neither reserved nor charged; they are kept as pending
+-- for operators to settle
+UPDATE orders SET status = 'pending' WHERE status = 'backordered';
+UPDATE orders SET fulfillment_policy = 'partial' WHERE fulfillment_policy = 'backorder';
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_fulfillment_policy_check;
+ALTER TABLE orders ADD CONSTRAINT orders_fulfillment_policy_check
+    CHECK (fulfillment_policy IN ('all_or_nothing', 'partial'));
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS check_order_status;
+ALTER TABLE orders ADD CONSTRAINT check_order_status
+    CHECK (status IN ('pending', 'pending_approval', 'pending_review', 'paid', 'partially_assembled', 'assembled', 'completed', 'cancelled', 'failed'));
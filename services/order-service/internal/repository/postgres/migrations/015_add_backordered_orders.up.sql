@@ -0,0 +1,10 @@
+-- Orders may wait for out-of-stock items as a whole and resume once inventory reserves them
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS check_order_status;
+ALTER TABLE orders ADD CONSTRAINT check_order_status
+    CHECK (status IN ('pending', 'pending_approval', 'pending_review', 'backordered', 'paid', 'partially_assembled', 'assembled', 'completed', 'cancelled', 'failed'));
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_fulfillment_policy_check;
+ALTER TABLE orders ADD CONSTRAINT orders_fulfillment_policy_check
+    CHECK (fulfillment_policy IN ('all_or_nothing', 'partial', 'backorder'));
+
+CREATE INDEX IF NOT EXISTS idx_orders_backordered ON orders(created_at) WHERE status = 'backordered';
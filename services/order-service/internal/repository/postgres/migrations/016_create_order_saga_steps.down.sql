@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_order_saga_steps_order;
+DROP TABLE IF EXISTS order_saga_steps;
@@ -0,0 +1,15 @@
+-- Append-only history of the order saga: one row per step transition, including
+-- retries and compensations, kept for operators to inspect a stuck or failed order
+CREATE TABLE IF NOT EXISTS order_saga_steps (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    step VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL
+        CHECK (status IN ('started', 'succeeded', 'failed', 'waiting', 'retrying')),
+    attempt INTEGER NOT NULL DEFAULT 1 CHECK (attempt > 0),
+    compensation BOOLEAN NOT NULL DEFAULT FALSE,
+    error TEXT NOT NULL DEFAULT '',
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_saga_steps_order ON order_saga_steps(order_id, recorded_at);
@@ -0,0 +1,3 @@
+-- Structured warnings about items of an order, e.g. discontinued items and their
+-- replacements; NULL for orders placed without warnings
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS warnings JSONB;
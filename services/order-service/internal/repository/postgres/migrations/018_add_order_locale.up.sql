@@ -0,0 +1,3 @@
+-- Locale the customer placed the order in, for messages about it sent without a
+-- request behind them; orders placed before default to English
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT 'en';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS order_refunds;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS refund_status;
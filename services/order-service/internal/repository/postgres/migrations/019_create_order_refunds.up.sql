@@ -0,0 +1,23 @@
+-- Status of the order's refund case, empty while no refund was requested
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS refund_status VARCHAR(20) NOT NULL DEFAULT '';
+
+-- Customers' refund requests, reviewed by support, one per order
+CREATE TABLE IF NOT EXISTS order_refunds (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    amount_minor BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'requested'
+        CHECK (status IN ('requested', 'approved', 'rejected', 'refunded', 'failed')),
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    decided_by UUID,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    note TEXT NOT NULL DEFAULT '',
+    payment_refund_id TEXT NOT NULL DEFAULT '',
+    refunded_at TIMESTAMP WITH TIME ZONE,
+    failure_reason TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_refunds_open ON order_refunds(requested_at) WHERE status IN ('requested', 'failed');
@@ -0,0 +1,8 @@
+-- Committed offsets of the order consumer group over time, for point-in-time
+-- recovery and audit of which events had been processed
+CREATE TABLE IF NOT EXISTS kafka_offset_snapshots (
+    group_id VARCHAR(255) NOT NULL,
+    taken_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    offsets JSONB NOT NULL DEFAULT '[]',
+    PRIMARY KEY (group_id, taken_at)
+);
@@ -0,0 +1,4 @@
+-- Finished webhook deliveries and jobs are purged once older than their
+-- retention TTL; these indexes find them without scanning the tables
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON webhook_deliveries(created_at) WHERE status <> 'pending';
+CREATE INDEX IF NOT EXISTS idx_jobs_finished_at ON jobs(finished_at) WHERE state IN ('succeeded', 'failed');
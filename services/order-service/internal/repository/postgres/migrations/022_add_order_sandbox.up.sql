@@ -0,0 +1,3 @@
+-- Test orders of sandbox tenants, paid through the payment simulator and left
+-- out of reports; orders placed before are live orders
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS sandbox BOOLEAN NOT NULL DEFAULT FALSE;
@@ -3,16 +3,33 @@ package migrations
 import (
 	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
 )
 
-
+//go:embed *.sql
 var migrationFiles embed.FS
 
+// ErrDirty is returned while a migration is marked dirty: the migrator stopped
+// in the middle of it, so the schema has to be checked by hand and the version
+// set with Force before migrating again
+var ErrDirty = errors.New("database is dirty")
+
+// lockID is the key of the advisory lock held while migrating, so replicas
+// deployed together run the migrations one after another
+const lockID int64 = 7318264501
+
+// migration is a pair of <version>_<name>.up.sql and .down.sql files
+type migration struct {
+	version int
+	name    string // File name without the .up.sql suffix, as recorded in schema_migrations
+}
+
 // Migrator handles database migrations
 type Migrator struct {
 	db *sqlx.DB
@@ -25,78 +42,312 @@ func NewMigrator(db *sqlx.DB) *Migrator {
 
 // Up runs all pending migrations
 func (m *Migrator) Up(ctx context.Context) error {
-	// Create migrations table if it doesn't exist
-	if err := m.createMigrationsTable(ctx); err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
+	return m.up(ctx, 0)
+}
+
+// UpSteps runs at most steps pending migrations
+func (m *Migrator) UpSteps(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive")
 	}
+	return m.up(ctx, steps)
+}
 
-	// Get list of migration files
-	upFiles, err := m.getUpMigrationFiles()
+// up applies pending migrations in order, at most limit of them unless limit is 0
+func (m *Migrator) up(ctx context.Context, limit int) error {
+	migrations, err := loadMigrations()
 	if err != nil {
 		return fmt.Errorf("failed to get migration files: %w", err)
 	}
 
-	// Get already applied migrations
-	appliedMigrations, err := m.getAppliedMigrations(ctx)
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		appliedMigrations, err := m.getAppliedMigrations(ctx, conn)
+		if err != nil {
+			return fmt.Errorf("failed to get applied migrations: %w", err)
+		}
+
+		applied := 0
+		for _, mig := range migrations {
+			// Skip if already applied
+			if appliedMigrations[mig.name] {
+				continue
+			}
+			if limit > 0 && applied == limit {
+				break
+			}
+
+			if err := m.applyMigration(ctx, conn, mig.name); err != nil {
+				return fmt.Errorf("failed to apply migration %s: %w", mig.name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+}
+
+// Down rolls back the last steps applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive")
+	}
+
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		// Get applied migrations in reverse order
+		appliedMigrations, err := m.getAppliedMigrationsInOrder(ctx, conn, true)
+		if err != nil {
+			return fmt.Errorf("failed to get applied migrations: %w", err)
+		}
+
+		// Limit to requested steps
+		if steps > len(appliedMigrations) {
+			steps = len(appliedMigrations)
+		}
+
+		for _, name := range appliedMigrations[:steps] {
+			if err := m.rollbackMigration(ctx, conn, name); err != nil {
+				return fmt.Errorf("failed to rollback migration %s: %w", name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// MigrateTo migrates the database up or down to version, applying the missing
+// migrations up to it and rolling back the applied ones above it. Version 0
+// rolls back every migration.
+func (m *Migrator) MigrateTo(ctx context.Context, version int) error {
+	migrations, err := loadMigrations()
 	if err != nil {
-		return fmt.Errorf("failed to get applied migrations: %w", err)
+		return fmt.Errorf("failed to get migration files: %w", err)
+	}
+	if version != 0 && !hasVersion(migrations, version) {
+		return fmt.Errorf("unknown migration version %d", version)
 	}
 
-	// Apply pending migrations
-	for _, file := range upFiles {
-		migrationName := m.getMigrationName(file)
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		appliedMigrations, err := m.getAppliedMigrationsInOrder(ctx, conn, true)
+		if err != nil {
+			return fmt.Errorf("failed to get applied migrations: %w", err)
+		}
 
-		// Skip if already applied
-		if appliedMigrations[migrationName] {
-			continue
+		applied := make(map[string]bool, len(appliedMigrations))
+		for _, name := range appliedMigrations {
+			applied[name] = true
+
+			appliedVersion, err := parseVersion(name)
+			if err != nil {
+				return err
+			}
+			if appliedVersion <= version {
+				continue
+			}
+			if err := m.rollbackMigration(ctx, conn, name); err != nil {
+				return fmt.Errorf("failed to rollback migration %s: %w", name, err)
+			}
 		}
 
-		if err := m.applyMigration(ctx, file, migrationName); err != nil {
-			return fmt.Errorf("failed to apply migration %s: %w", migrationName, err)
+		for _, mig := range migrations {
+			if mig.version > version || applied[mig.name] {
+				continue
+			}
+			if err := m.applyMigration(ctx, conn, mig.name); err != nil {
+				return fmt.Errorf("failed to apply migration %s: %w", mig.name, err)
+			}
 		}
+		return nil
+	})
+}
+
+// Version returns the highest applied migration version, 0 when none is
+// applied, and whether a migration is marked dirty
+func (m *Migrator) Version(ctx context.Context) (int, bool, error) {
+	if err := m.createMigrationsTable(ctx, m.db); err != nil {
+		return 0, false, fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
-	return nil
+	var rows []struct {
+		Migration string `db:"migration"`
+		Dirty     bool   `db:"dirty"`
+	}
+	if err := m.db.SelectContext(ctx, &rows, "SELECT migration, dirty FROM schema_migrations"); err != nil {
+		return 0, false, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	version, dirty := 0, false
+	for _, row := range rows {
+		rowVersion, err := parseVersion(row.Migration)
+		if err != nil {
+			return 0, false, err
+		}
+		if rowVersion > version {
+			version = rowVersion
+		}
+		dirty = dirty || row.Dirty
+	}
+	return version, dirty, nil
+}
+
+// Force records the migrations up to version as applied and the ones above it
+// as not applied, clearing the dirty mark, without running any migration. It is
+// used once the schema of a dirty database was repaired by hand.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to get migration files: %w", err)
+	}
+	if version != 0 && !hasVersion(migrations, version) {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	return m.lock(ctx, func(conn *sqlx.Conn) error {
+		tx, err := conn.BeginTxx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+			return fmt.Errorf("failed to clear migration records: %w", err)
+		}
+		for _, mig := range migrations {
+			if mig.version > version {
+				break
+			}
+			if _, err := tx.ExecContext(ctx,
+				"INSERT INTO schema_migrations (migration) VALUES ($1)",
+				mig.name); err != nil {
+				return fmt.Errorf("failed to record migration %s: %w", mig.name, err)
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit forced version: %w", err)
+		}
+
+		fmt.Printf("Forced migration version: %d\n", version)
+		return nil
+	})
+}
+
+// withLock runs fn holding the migration lock, refusing to migrate a dirty database
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	return m.lock(ctx, func(conn *sqlx.Conn) error {
+		var dirty []string
+		if err := conn.SelectContext(ctx, &dirty,
+			"SELECT migration FROM schema_migrations WHERE dirty ORDER BY migration"); err != nil {
+			return fmt.Errorf("failed to check for dirty migrations: %w", err)
+		}
+		if len(dirty) > 0 {
+			return fmt.Errorf("%w: migration %s did not complete", ErrDirty, strings.Join(dirty, ", "))
+		}
+		return fn(conn)
+	})
+}
+
+// lock runs fn on a connection holding the migration advisory lock, waiting
+// for a replica migrating at the same time to finish first. The lock belongs
+// to the session, so the migrations run on the same connection.
+func (m *Migrator) lock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// The context may be cancelled already, the lock must be released regardless
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockID); err != nil {
+			fmt.Printf("Failed to release migration lock: %v\n", err)
+		}
+	}()
+
+	// Create migrations table if it doesn't exist
+	if err := m.createMigrationsTable(ctx, conn); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	return fn(conn)
 }
 
 // createMigrationsTable creates the migrations tracking table
-func (m *Migrator) createMigrationsTable(ctx context.Context) error {
+func (m *Migrator) createMigrationsTable(ctx context.Context, db sqlx.ExecerContext) error {
 	query := `
 		CREATE TABLE IF NOT EXISTS schema_migrations (
 			id SERIAL PRIMARY KEY,
 			migration VARCHAR(255) NOT NULL UNIQUE,
-			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
-		)`
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			dirty BOOLEAN NOT NULL DEFAULT FALSE
+		);
+		ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS dirty BOOLEAN NOT NULL DEFAULT FALSE`
 
-	_, err := m.db.ExecContext(ctx, query)
+	_, err := db.ExecContext(ctx, query)
 	return err
 }
 
-// getUpMigrationFiles returns all .up.sql files sorted by name
-func (m *Migrator) getUpMigrationFiles() ([]string, error) {
+// loadMigrations returns the embedded migrations sorted by version. Every up
+// migration needs a down migration.
+func loadMigrations() ([]migration, error) {
 	entries, err := migrationFiles.ReadDir(".")
 	if err != nil {
 		return nil, err
 	}
 
-	var upFiles []string
+	var migrations []migration
+	versions := make(map[int]string)
 	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".up.sql") {
-			upFiles = append(upFiles, entry.Name())
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".up.sql") {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".up.sql")
+		version, err := parseVersion(name)
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := versions[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, name, version)
+		}
+		if _, err := migrationFiles.ReadFile(name + ".down.sql"); err != nil {
+			return nil, fmt.Errorf("migration %s has no down migration", name)
 		}
+
+		versions[version] = name
+		migrations = append(migrations, migration{version: version, name: name})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
+
+// parseVersion extracts the version of a migration from its numeric prefix
+func parseVersion(name string) (int, error) {
+	prefix, _, _ := strings.Cut(name, "_")
+	version, err := strconv.Atoi(prefix)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("migration %s has no version prefix", name)
 	}
+	return version, nil
+}
 
-	// Sort files to ensure consistent order
-	sort.Strings(upFiles)
-	return upFiles, nil
+func hasVersion(migrations []migration, version int) bool {
+	for _, mig := range migrations {
+		if mig.version == version {
+			return true
+		}
+	}
+	return false
 }
 
 // getAppliedMigrations returns a map of already applied migrations
-func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[string]bool, error) {
+func (m *Migrator) getAppliedMigrations(ctx context.Context, conn *sqlx.Conn) (map[string]bool, error) {
 	query := "SELECT migration FROM schema_migrations"
 
 	var migrations []string
-	err := m.db.SelectContext(ctx, &migrations, query)
+	err := conn.SelectContext(ctx, &migrations, query)
 	if err != nil {
 		return nil, err
 	}
@@ -109,129 +360,103 @@ func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[string]bool, e
 	return appliedMigrations, nil
 }
 
-// getMigrationName extracts migration name from filename
-func (m *Migrator) getMigrationName(filename string) string {
-	// Remove .up.sql suffix
-	name := strings.TrimSuffix(filename, ".up.sql")
-	return name
+// getAppliedMigrationsInOrder returns applied migrations in order. Migrations
+// are ordered by name rather than applied_at: migrations applied by the same run
+// can share a timestamp, and the zero-padded names sort in the order they apply.
+func (m *Migrator) getAppliedMigrationsInOrder(ctx context.Context, conn *sqlx.Conn, reverse bool) ([]string, error) {
+	orderBy := "ASC"
+	if reverse {
+		orderBy = "DESC"
+	}
+
+	query := fmt.Sprintf("SELECT migration FROM schema_migrations ORDER BY migration %s", orderBy)
+
+	var migrations []string
+	err := conn.SelectContext(ctx, &migrations, query)
+	return migrations, err
 }
 
-// applyMigration applies a single migration file
-func (m *Migrator) applyMigration(ctx context.Context, filename, migrationName string) error {
-	// Read migration file
+// applyMigration applies a single migration. The migration is recorded as
+// dirty before it runs, so a migrator stopped midway leaves the mark behind;
+// a migration that fails is rolled back with its transaction and unrecorded.
+func (m *Migrator) applyMigration(ctx context.Context, conn *sqlx.Conn, migrationName string) error {
+	filename := migrationName + ".up.sql"
 	content, err := migrationFiles.ReadFile(filename)
 	if err != nil {
 		return fmt.Errorf("failed to read migration file %s: %w", filename, err)
 	}
 
-	// Start transaction
-	tx, err := m.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	// Execute migration SQL
-	_, err = tx.ExecContext(ctx, string(content))
-	if err != nil {
-		return fmt.Errorf("failed to execute migration SQL: %w", err)
+	if _, err := conn.ExecContext(ctx,
+		"INSERT INTO schema_migrations (migration, dirty) VALUES ($1, TRUE)",
+		migrationName); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
 	}
 
-	// Record migration as applied
-	_, err = tx.ExecContext(ctx,
-		"INSERT INTO schema_migrations (migration) VALUES ($1)",
+	err = m.execMigration(ctx, conn, content,
+		"UPDATE schema_migrations SET dirty = FALSE, applied_at = NOW() WHERE migration = $1",
 		migrationName)
 	if err != nil {
-		return fmt.Errorf("failed to record migration: %w", err)
-	}
-
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit migration: %w", err)
+		if _, cleanupErr := conn.ExecContext(context.Background(),
+			"DELETE FROM schema_migrations WHERE migration = $1 AND dirty",
+			migrationName); cleanupErr != nil {
+			return fmt.Errorf("%w (migration left dirty: %v)", err, cleanupErr)
+		}
+		return err
 	}
 
 	fmt.Printf("Applied migration: %s\n", migrationName)
 	return nil
 }
 
-// Down runs migrations down (rollback) - for development use
-func (m *Migrator) Down(ctx context.Context, steps int) error {
-	if steps <= 0 {
-		return fmt.Errorf("steps must be positive")
-	}
-
-	// Get applied migrations in reverse order
-	appliedMigrations, err := m.getAppliedMigrationsInOrder(ctx, true)
+// rollbackMigration rolls back a single migration, marking it dirty while the
+// down migration runs
+func (m *Migrator) rollbackMigration(ctx context.Context, conn *sqlx.Conn, migrationName string) error {
+	filename := migrationName + ".down.sql"
+	content, err := migrationFiles.ReadFile(filename)
 	if err != nil {
-		return fmt.Errorf("failed to get applied migrations: %w", err)
+		return fmt.Errorf("failed to read down migration file %s: %w", filename, err)
 	}
 
-	// Limit to requested steps
-	if steps > len(appliedMigrations) {
-		steps = len(appliedMigrations)
+	if _, err := conn.ExecContext(ctx,
+		"UPDATE schema_migrations SET dirty = TRUE WHERE migration = $1",
+		migrationName); err != nil {
+		return fmt.Errorf("failed to mark migration dirty: %w", err)
 	}
 
-	// Rollback migrations
-	for i := 0; i < steps; i++ {
-		migration := appliedMigrations[i]
-		downFile := migration + ".down.sql"
-
-		if err := m.rollbackMigration(ctx, downFile, migration); err != nil {
-			return fmt.Errorf("failed to rollback migration %s: %w", migration, err)
+	err = m.execMigration(ctx, conn, content,
+		"DELETE FROM schema_migrations WHERE migration = $1",
+		migrationName)
+	if err != nil {
+		if _, cleanupErr := conn.ExecContext(context.Background(),
+			"UPDATE schema_migrations SET dirty = FALSE WHERE migration = $1",
+			migrationName); cleanupErr != nil {
+			return fmt.Errorf("%w (migration left dirty: %v)", err, cleanupErr)
 		}
+		return err
 	}
 
+	fmt.Printf("Rolled back migration: %s\n", migrationName)
 	return nil
 }
 
-// getAppliedMigrationsInOrder returns applied migrations in order
-func (m *Migrator) getAppliedMigrationsInOrder(ctx context.Context, reverse bool) ([]string, error) {
-	orderBy := "ASC"
-	if reverse {
-		orderBy = "DESC"
-	}
-
-	query := fmt.Sprintf("SELECT migration FROM schema_migrations ORDER BY applied_at %s", orderBy)
-
-	var migrations []string
-	err := m.db.SelectContext(ctx, &migrations, query)
-	return migrations, err
-}
-
-// rollbackMigration rolls back a single migration
-func (m *Migrator) rollbackMigration(ctx context.Context, filename, migrationName string) error {
-	// Read down migration file
-	content, err := migrationFiles.ReadFile(filename)
-	if err != nil {
-		return fmt.Errorf("failed to read down migration file %s: %w", filename, err)
-	}
-
-	// Start transaction
-	tx, err := m.db.BeginTxx(ctx, nil)
+// execMigration executes migration SQL and the statement updating its record
+// in one transaction
+func (m *Migrator) execMigration(ctx context.Context, conn *sqlx.Conn, content []byte, record, migrationName string) error {
+	tx, err := conn.BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	// Execute down migration SQL
-	_, err = tx.ExecContext(ctx, string(content))
-	if err != nil {
-		return fmt.Errorf("failed to execute down migration SQL: %w", err)
+	if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+		return fmt.Errorf("failed to execute migration SQL: %w", err)
 	}
-
-	// Remove migration record
-	_, err = tx.ExecContext(ctx,
-		"DELETE FROM schema_migrations WHERE migration = $1",
-		migrationName)
-	if err != nil {
-		return fmt.Errorf("failed to remove migration record: %w", err)
+	if _, err := tx.ExecContext(ctx, record, migrationName); err != nil {
+		return fmt.Errorf("failed to update migration record: %w", err)
 	}
 
-	// Commit transaction
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit rollback: %w", err)
+		return fmt.Errorf("failed to commit migration: %w", err)
 	}
-
-	fmt.Printf("Rolled back migration: %s\n", migrationName)
 	return nil
 }
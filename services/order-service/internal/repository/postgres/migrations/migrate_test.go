@@ -0,0 +1,472 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+const (
+	orderID       = "0b5c3f1e-7d1a-4e39-9a57-4f0d1c2b3a01"
+	reviewOrderID = "0b5c3f1e-7d1a-4e39-9a57-4f0d1c2b3a02"
+	approvalID    = "0b5c3f1e-7d1a-4e39-9a57-4f0d1c2b3a03"
+	userID        = "6f1e2d3c-4b5a-4978-8695-a4b3c2d1e0f9"
+	webhookID     = "9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d"
+	deliveryID    = "9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6e"
+	batchID       = "2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f"
+	addressID     = "3d4e5f6a-7b8c-4d9e-8f0a-1b2c3d4e5f6a"
+	jobID         = "4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a7b"
+	shipmentID    = "5f6a7b8c-9d0e-4f1a-8b2c-3d4e5f6a7b8c"
+	backorderID   = "0b5c3f1e-7d1a-4e39-9a57-4f0d1c2b3a04"
+	sagaStepID    = "6a7b8c9d-0e1f-4a2b-9c3d-4e5f6a7b8c9d"
+	refundID      = "7b8c9d0e-1f2a-4b3c-8d4e-5f6a7b8c9d0e"
+	yenOrderID    = "0b5c3f1e-7d1a-4e39-9a57-4f0d1c2b3a05"
+)
+
+// migrationFixture inserts representative data after a migration was applied
+// and checks the data survived its rollback in the shape of the previous schema
+type migrationFixture struct {
+	seed      func(t *testing.T, db *sqlx.DB)
+	afterDown func(t *testing.T, db *sqlx.DB)
+}
+
+// fixtures holds one entry per migration; a migration without one fails the test
+var fixtures = map[string]migrationFixture{
+	"001_create_orders_tables": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO orders (id, user_id, status, total_amount) VALUES ($1, $2, 'paid', 12.34)`, orderID, userID)
+			mustExec(t, db, `INSERT INTO order_items (order_id, item_id, item_name, quantity, unit_price, total)
+				VALUES ($1, 'engine-rd180', 'RD-180 Engine', 2, 6.17, 12.34)`, orderID)
+			mustExec(t, db, `INSERT INTO orders (id, user_id, status, total_amount, currency) VALUES ($1, $2, 'paid', 1500, 'JPY')`, yenOrderID, userID)
+			mustExec(t, db, `INSERT INTO order_items (order_id, item_id, item_name, quantity, unit_price, total)
+				VALUES ($1, 'fuel-rp1', 'RP-1 Fuel', 3, 500, 1500)`, yenOrderID)
+		},
+	},
+	"002_add_pending_review_status": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO orders (id, user_id, status) VALUES ($1, $2, 'pending_review')`, reviewOrderID, userID)
+		},
+		afterDown: func(t *testing.T, db *sqlx.DB) {
+			expectValue(t, db, "failed", `SELECT status FROM orders WHERE id = $1`, reviewOrderID)
+		},
+	},
+	"003_add_order_item_serials": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO order_item_serials (order_id, item_id, serial_number) VALUES ($1, 'engine-rd180', 'SN-0001')`, orderID)
+		},
+	},
+	"004_create_processed_events": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO processed_events (event_id, event_type, order_id) VALUES ('evt-1', 'payment.processed', $1)`, orderID)
+		},
+	},
+	"005_create_webhooks": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO webhooks (id, user_id, url, secret, statuses) VALUES ($1, $2, 'https://example.com/hook', 'secret', '{paid}')`, webhookID, userID)
+			mustExec(t, db, `INSERT INTO webhook_deliveries (id, webhook_id, order_id, event_type, payload) VALUES ($1, $2, $3, 'order.paid', '\x7b7d')`, deliveryID, webhookID, orderID)
+		},
+	},
+	"006_store_amounts_in_minor_units": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			expectValue(t, db, "1234", `SELECT total_amount_minor::text FROM orders WHERE id = $1`, orderID)
+			expectValue(t, db, "617", `SELECT unit_price_minor::text FROM order_items WHERE order_id = $1`, orderID)
+			// Yen have no minor unit
+			expectValue(t, db, "1500", `SELECT total_amount_minor::text FROM orders WHERE id = $1`, yenOrderID)
+			expectValue(t, db, "500", `SELECT unit_price_minor::text FROM order_items WHERE order_id = $1`, yenOrderID)
+		},
+		afterDown: func(t *testing.T, db *sqlx.DB) {
+			expectValue(t, db, "12.34", `SELECT total_amount::text FROM orders WHERE id = $1`, orderID)
+			expectValue(t, db, "6.17", `SELECT unit_price::text FROM order_items WHERE order_id = $1`, orderID)
+			expectValue(t, db, "1500.00", `SELECT total_amount::text FROM orders WHERE id = $1`, yenOrderID)
+			expectValue(t, db, "500.00", `SELECT unit_price::text FROM order_items WHERE order_id = $1`, yenOrderID)
+		},
+	},
+	"007_create_order_approvals": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO orders (id, user_id, status, total_amount_minor) VALUES ($1, $2, 'pending_approval', 5000000)`, approvalID, userID)
+			mustExec(t, db, `INSERT INTO order_approvals (order_id, user_id, amount_minor, currency, expires_at)
+				VALUES ($1, $2, 5000000, 'USD', NOW() + INTERVAL '1 day')`, approvalID, userID)
+		},
+		afterDown: func(t *testing.T, db *sqlx.DB) {
+			expectValue(t, db, "cancelled", `SELECT status FROM orders WHERE id = $1`, approvalID)
+		},
+	},
+	"008_add_order_list_keyset_index": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			expectValue(t, db, "4", `SELECT COUNT(*)::text FROM orders WHERE deleted_at IS NULL`)
+		},
+	},
+	"009_create_order_batches": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO order_batches (id, order_count, results) VALUES ($1, 1, $2)`,
+				batchID, fmt.Sprintf(`[{"index": 0, "order_id": %q}]`, orderID))
+		},
+	},
+	"010_add_order_tags_and_attributes": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `UPDATE orders SET tags = '["mission:artemis-3"]', attributes = '{"mission_id": "ART-3"}' WHERE id = $1`, orderID)
+			expectValue(t, db, "1", `SELECT COUNT(*)::text FROM orders WHERE tags @> '["mission:artemis-3"]'`)
+		},
+	},
+	"011_create_order_payment_retries": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO order_payment_retries (order_id, user_id, attempts, max_attempts, next_attempt_at, last_error)
+				VALUES ($1, $2, 1, 5, NOW() + INTERVAL '1 minute', 'payment service unavailable')`, orderID, userID)
+		},
+	},
+	"012_create_user_addresses": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO user_addresses (id, user_id, label, recipient_name, line1, city, postal_code, country, is_default)
+				VALUES ($1, $2, 'Launch site', 'Ada Lovelace', '1 Rocket Road', 'Hawthorne', '90250', 'US', TRUE)`, addressID, userID)
+			mustExec(t, db, `UPDATE orders SET shipping_address = '{"recipient_name": "Ada Lovelace", "country": "US"}' WHERE id = $1`, orderID)
+		},
+	},
+	"013_create_jobs": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO jobs (id, type, state, payload, run_at, created_at, updated_at)
+				VALUES ($1, 'order_export', 'queued', '{"format": "csv"}', NOW(), NOW(), NOW())`, jobID)
+		},
+	},
+	"014_create_order_shipments": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `UPDATE orders SET status = 'partially_assembled', fulfillment_policy = 'partial' WHERE id = $1`, orderID)
+			mustExec(t, db, `INSERT INTO order_shipments (id, order_id, sequence, status, items, amount_minor)
+				VALUES ($1, $2, 2, 'backordered', '[{"item_id": "engine-rd180", "quantity": 1}]', 617)`, shipmentID, orderID)
+		},
+		afterDown: func(t *testing.T, db *sqlx.DB) {
+			expectValue(t, db, "paid", `SELECT status FROM orders WHERE id = $1`, orderID)
+		},
+	},
+	"015_add_backordered_orders": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO orders (id, user_id, status, fulfillment_policy) VALUES ($1, $2, 'backordered', 'backorder')`, backorderID, userID)
+		},
+		afterDown: func(t *testing.T, db *sqlx.DB) {
+			expectValue(t, db, "pending", `SELECT status FROM orders WHERE id = $1`, backorderID)
+			expectValue(t, db, "partial", `SELECT fulfillment_policy FROM orders WHERE id = $1`, backorderID)
+		},
+	},
+	"016_create_order_saga_steps": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO order_saga_steps (id, order_id, step, status, attempt, error)
+				VALUES ($1, $2, 'payment', 'retrying', 2, 'payment service unavailable')`, sagaStepID, orderID)
+		},
+	},
+	"017_add_order_warnings": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `UPDATE orders SET warnings = '[{"code": "item_discontinued", "item_id": "engine-rd180", "replacement_item_id": "engine-rd181", "substituted": true}]' WHERE id = $1`, orderID)
+		},
+	},
+	"018_add_order_locale": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `UPDATE orders SET locale = 'de' WHERE id = $1`, orderID)
+		},
+	},
+	"019_create_order_refunds": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `UPDATE orders SET refund_status = 'requested' WHERE id = $1`, orderID)
+			mustExec(t, db, `INSERT INTO order_refunds (id, order_id, user_id, amount_minor, currency, reason)
+				VALUES ($1, $2, $3, 1234, 'USD', 'engine arrived damaged')`, refundID, orderID, userID)
+		},
+	},
+	"020_create_kafka_offset_snapshots": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `INSERT INTO kafka_offset_snapshots (group_id, taken_at, offsets)
+				VALUES ('order-service', NOW(), '[{"topic": "payment.processed", "partition": 0, "offset": 42}]')`)
+		},
+	},
+	"021_add_retention_indexes": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `UPDATE webhook_deliveries SET status = 'succeeded', delivered_at = NOW() WHERE id = $1`, deliveryID)
+			mustExec(t, db, `UPDATE jobs SET state = 'succeeded', finished_at = NOW() WHERE id = $1`, jobID)
+		},
+	},
+	"022_add_order_sandbox": {
+		seed: func(t *testing.T, db *sqlx.DB) {
+			mustExec(t, db, `UPDATE orders SET sandbox = TRUE WHERE id = $1`, orderID)
+		},
+	},
+}
+
+// TestMigrationsUpAndDown applies every migration one at a time with
+// representative data, rolls them all back checking each rollback restores the
+// previous schema, then applies them again
+func TestMigrationsUpAndDown(t *testing.T) {
+	db := startPostgres(t)
+	ctx := context.Background()
+	migrator := NewMigrator(db)
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+
+	// Schema before each migration, to compare against after its rollback
+	schemas := make([]string, len(migrations))
+	for i, mig := range migrations {
+		fixture, ok := fixtures[mig.name]
+		if !ok {
+			t.Fatalf("migration %s has no fixture in migrate_test.go", mig.name)
+		}
+
+		schemas[i] = schema(t, db)
+		if err := migrator.UpSteps(ctx, 1); err != nil {
+			t.Fatalf("up %s: %v", mig.name, err)
+		}
+		expectVersion(t, migrator, mig.version)
+		fixture.seed(t, db)
+	}
+
+	// Applying again is a no-op
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up with nothing pending: %v", err)
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		mig := migrations[i]
+		if err := migrator.Down(ctx, 1); err != nil {
+			t.Fatalf("down %s: %v", mig.name, err)
+		}
+		if got := schema(t, db); got != schemas[i] {
+			t.Fatalf("down %s did not restore the previous schema:\n%s", mig.name, diffLines(schemas[i], got))
+		}
+		if afterDown := fixtures[mig.name].afterDown; afterDown != nil {
+			afterDown(t, db)
+		}
+	}
+	expectVersion(t, migrator, 0)
+
+	// A fully rolled back database migrates up again
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up after rollback: %v", err)
+	}
+	expectVersion(t, migrator, migrations[len(migrations)-1].version)
+}
+
+// TestMigrateTo migrates to a version in between and back, and refuses to
+// migrate a dirty database until the version is forced
+func TestMigrateTo(t *testing.T) {
+	db := startPostgres(t)
+	ctx := context.Background()
+	migrator := NewMigrator(db)
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	latest := migrations[len(migrations)-1].version
+	middle := migrations[len(migrations)/2].version
+
+	for _, version := range []int{middle, latest, middle, 0, latest} {
+		if err := migrator.MigrateTo(ctx, version); err != nil {
+			t.Fatalf("migrate to %d: %v", version, err)
+		}
+		expectVersion(t, migrator, version)
+	}
+	if err := migrator.MigrateTo(ctx, latest+1); err == nil {
+		t.Fatal("migrate to an unknown version succeeded")
+	}
+
+	// A migrator stopped in the middle of the latest migration
+	mustExec(t, db, `UPDATE schema_migrations SET dirty = TRUE WHERE migration = $1`, migrations[len(migrations)-1].name)
+	if _, dirty, err := migrator.Version(ctx); err != nil || !dirty {
+		t.Fatalf("version reported dirty=%v, err=%v; want dirty", dirty, err)
+	}
+	if err := migrator.Up(ctx); !errors.Is(err, ErrDirty) {
+		t.Fatalf("up on a dirty database returned %v, want ErrDirty", err)
+	}
+	if err := migrator.Down(ctx, 1); !errors.Is(err, ErrDirty) {
+		t.Fatalf("down on a dirty database returned %v, want ErrDirty", err)
+	}
+
+	if err := migrator.Force(ctx, latest); err != nil {
+		t.Fatalf("force %d: %v", latest, err)
+	}
+	expectVersion(t, migrator, latest)
+	if err := migrator.Down(ctx, 1); err != nil {
+		t.Fatalf("down after force: %v", err)
+	}
+}
+
+// TestConcurrentMigrators runs migrators side by side, as replicas deployed
+// together do; the advisory lock lets each migration apply exactly once
+func TestConcurrentMigrators(t *testing.T) {
+	db := startPostgres(t)
+	ctx := context.Background()
+
+	errs := make(chan error, 4)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			errs <- NewMigrator(db).Up(ctx)
+		}()
+	}
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("concurrent up: %v", err)
+		}
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	expectVersion(t, NewMigrator(db), migrations[len(migrations)-1].version)
+	expectValue(t, db, fmt.Sprint(len(migrations)), `SELECT COUNT(*)::text FROM schema_migrations`)
+}
+
+// startPostgres starts an ephemeral PostgreSQL container and connects to it.
+// The test is skipped when Docker is not available.
+func startPostgres(t *testing.T) *sqlx.DB {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping database test in short mode")
+	}
+
+	pool, err := dockertest.NewPool(os.Getenv("DOCKER_HOST"))
+	if err == nil {
+		err = pool.Client.Ping()
+	}
+	if err != nil {
+		t.Skipf("docker not available: %v", err)
+	}
+	pool.MaxWait = 2 * time.Minute
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "postgres",
+		Tag:        "15-alpine",
+		Env: []string{
+			"POSTGRES_USER=rocket_user",
+			"POSTGRES_PASSWORD=rocket_password",
+			"POSTGRES_DB=rocket_orders",
+		},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		t.Fatalf("failed to start postgres: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := pool.Purge(resource); err != nil {
+			t.Logf("failed to remove postgres container: %v", err)
+		}
+	})
+	// Reaped by Docker should the test binary be killed before cleanup
+	resource.Expire(300)
+
+	dsn := fmt.Sprintf("postgres://rocket_user:rocket_password@%s/rocket_orders?sslmode=disable",
+		resource.GetHostPort("5432/tcp"))
+
+	var db *sqlx.DB
+	err = pool.Retry(func() error {
+		var err error
+		db, err = sqlx.Connect("postgres", dsn)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to postgres: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// schema describes the tables, columns, constraints, indexes, triggers and
+// functions of the public schema, one per line in a stable order
+func schema(t *testing.T, db *sqlx.DB) string {
+	t.Helper()
+	query := `
+		SELECT line FROM (
+			SELECT 'column ' || table_name || '.' || column_name || ' ' || data_type || ' nullable=' || is_nullable AS line
+			FROM information_schema.columns
+			WHERE table_schema = 'public' AND table_name <> 'schema_migrations'
+			UNION ALL
+			SELECT 'constraint ' || conrelid::regclass || '.' || conname || ' ' || pg_get_constraintdef(oid)
+			FROM pg_constraint
+			WHERE connamespace = 'public'::regnamespace AND conrelid::regclass::text <> 'schema_migrations'
+			UNION ALL
+			SELECT 'index ' || indexdef
+			FROM pg_indexes
+			WHERE schemaname = 'public' AND tablename <> 'schema_migrations'
+			UNION ALL
+			SELECT 'trigger ' || tgrelid::regclass || '.' || tgname
+			FROM pg_trigger
+			WHERE NOT tgisinternal
+			UNION ALL
+			SELECT 'function ' || proname
+			FROM pg_proc
+			WHERE pronamespace = 'public'::regnamespace
+		) objects
+		ORDER BY line`
+
+	var lines []string
+	if err := db.Select(&lines, query); err != nil {
+		t.Fatalf("failed to describe schema: %v", err)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func expectVersion(t *testing.T, migrator *Migrator, want int) {
+	t.Helper()
+	version, dirty, err := migrator.Version(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get version: %v", err)
+	}
+	if version != want || dirty {
+		t.Fatalf("version %d (dirty=%v), want %d", version, dirty, want)
+	}
+}
+
+func mustExec(t *testing.T, db *sqlx.DB, query string, args ...interface{}) {
+	t.Helper()
+	if _, err := db.Exec(query, args...); err != nil {
+		t.Fatalf("failed to execute %q: %v", query, err)
+	}
+}
+
+func expectValue(t *testing.T, db *sqlx.DB, want, query string, args ...interface{}) {
+	t.Helper()
+	var got string
+	if err := db.Get(&got, query, args...); err != nil {
+		t.Fatalf("failed to query %q: %v", query, err)
+	}
+	if got != want {
+		t.Fatalf("%q returned %q, want %q", query, got, want)
+	}
+}
+
+// diffLines lists the lines only in want (-) or only in got (+)
+func diffLines(want, got string) string {
+	wantLines := make(map[string]bool)
+	for _, line := range strings.Split(want, "\n") {
+		wantLines[line] = true
+	}
+	gotLines := make(map[string]bool)
+	for _, line := range strings.Split(got, "\n") {
+		gotLines[line] = true
+	}
+
+	var diff []string
+	for line := range wantLines {
+		if !gotLines[line] {
+			diff = append(diff, "- "+line)
+		}
+	}
+	for line := range gotLines {
+		if !wantLines[line] {
+			diff = append(diff, "+ "+line)
+		}
+	}
+	return strings.Join(diff, "\n")
+}
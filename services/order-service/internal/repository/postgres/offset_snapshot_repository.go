@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/jmoiron/sqlx"
+)
+
+// OffsetSnapshotRepository stores snapshots of Kafka consumer group offsets in PostgreSQL
+type OffsetSnapshotRepository struct {
+	db *sqlx.DB
+}
+
+// NewOffsetSnapshotRepository creates a new PostgreSQL offset snapshot repository
+func NewOffsetSnapshotRepository(db *sqlx.DB) kafka.OffsetSnapshotStore {
+	return &OffsetSnapshotRepository{
+		db: db,
+	}
+}
+
+// Save stores a snapshot
+func (r *OffsetSnapshotRepository) Save(ctx context.Context, snapshot kafka.OffsetSnapshot) error {
+	offsets, err := json.Marshal(snapshot.Offsets)
+	if err != nil {
+		return platformError.Wrap(err, "failed to marshal offsets")
+	}
+
+	query := `
+		INSERT INTO kafka_offset_snapshots (group_id, taken_at, offsets)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (group_id, taken_at) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, snapshot.GroupID, snapshot.TakenAt, offsets); err != nil {
+		return platformError.Wrap(err, "failed to insert offset snapshot")
+	}
+	return nil
+}
+
+// SnapshotAt returns the latest snapshot of the group taken at or before at, or nil when there is none
+func (r *OffsetSnapshotRepository) SnapshotAt(ctx context.Context, groupID string, at time.Time) (*kafka.OffsetSnapshot, error) {
+	query := `
+		SELECT taken_at, offsets FROM kafka_offset_snapshots
+		WHERE group_id = $1 AND taken_at <= $2
+		ORDER BY taken_at DESC
+		LIMIT 1`
+
+	var row struct {
+		TakenAt time.Time `db:"taken_at"`
+		Offsets []byte    `db:"offsets"`
+	}
+	if err := r.db.GetContext(ctx, &row, query, groupID, at); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, platformError.Wrap(err, "failed to get offset snapshot")
+	}
+
+	snapshot := &kafka.OffsetSnapshot{GroupID: groupID, TakenAt: row.TakenAt.UTC()}
+	if err := json.Unmarshal(row.Offsets, &snapshot.Offsets); err != nil {
+		return nil, platformError.Wrap(err, "failed to unmarshal offsets")
+	}
+	return snapshot, nil
+}
+
+// DeleteBefore deletes the snapshots of the group taken before the time
+func (r *OffsetSnapshotRepository) DeleteBefore(ctx context.Context, groupID string, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM kafka_offset_snapshots WHERE group_id = $1 AND taken_at < $2`, groupID, before)
+	if err != nil {
+		return 0, platformError.Wrap(err, "failed to delete offset snapshots")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, platformError.Wrap(err, "failed to get rows affected")
+	}
+	return rowsAffected, nil
+}
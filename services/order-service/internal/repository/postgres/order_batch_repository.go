@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+)
+
+const orderBatchColumns = `id, status, order_count, results, created_at, updated_at, completed_at`
+
+// orderBatchRow maps the order_batches table, whose results are a JSONB array
+type orderBatchRow struct {
+	domain.OrderBatch
+	ResultsJSON []byte `db:"results"`
+}
+
+// OrderBatchRepository implements the OrderBatchRepository interface using PostgreSQL
+type OrderBatchRepository struct {
+	db *sqlx.DB
+}
+
+// NewOrderBatchRepository creates a new PostgreSQL order batch repository
+func NewOrderBatchRepository(db *sqlx.DB) interfaces.OrderBatchRepository {
+	return &OrderBatchRepository{
+		db: db,
+	}
+}
+
+// Create stores a new batch
+func (r *OrderBatchRepository) Create(ctx context.Context, batch *domain.OrderBatch) error {
+	results, err := json.Marshal(batch.Results)
+	if err != nil {
+		return platformError.Wrap(err, "failed to marshal order batch results")
+	}
+
+	query := `
+		INSERT INTO order_batches (` + orderBatchColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		batch.ID, batch.Status, batch.OrderCount, results, batch.CreatedAt, batch.UpdatedAt, batch.CompletedAt)
+	if err != nil {
+		return platformError.Wrap(err, "failed to insert order batch")
+	}
+	return nil
+}
+
+// GetByID retrieves a batch with the results recorded so far
+func (r *OrderBatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.OrderBatch, error) {
+	query := `SELECT ` + orderBatchColumns + ` FROM order_batches WHERE id = $1`
+
+	var row orderBatchRow
+	if err := r.db.GetContext(ctx, &row, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, platformError.NewNotFound("order batch not found")
+		}
+		return nil, platformError.Wrap(err, "failed to get order batch")
+	}
+
+	batch := row.OrderBatch
+	if err := json.Unmarshal(row.ResultsJSON, &batch.Results); err != nil {
+		return nil, platformError.Wrap(err, "failed to unmarshal order batch results")
+	}
+	return &batch, nil
+}
+
+// AddResult appends the outcome of an order to the results of the batch
+func (r *OrderBatchRepository) AddResult(ctx context.Context, id uuid.UUID, result domain.BatchOrderResult) error {
+	resultJSON, err := json.Marshal([]domain.BatchOrderResult{result})
+	if err != nil {
+		return platformError.Wrap(err, "failed to marshal order batch result")
+	}
+
+	query := `
+		UPDATE order_batches
+		SET results = results || $2::jsonb, updated_at = $3
+		WHERE id = $1`
+
+	return r.update(ctx, query, id, resultJSON, time.Now())
+}
+
+// Complete marks the batch completed
+func (r *OrderBatchRepository) Complete(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	query := `
+		UPDATE order_batches
+		SET status = $2, updated_at = $3, completed_at = $3
+		WHERE id = $1`
+
+	return r.update(ctx, query, id, domain.BatchCompleted, now)
+}
+
+func (r *OrderBatchRepository) update(ctx context.Context, query string, args ...interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return platformError.Wrap(err, "failed to update order batch")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return platformError.Wrap(err, "failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return platformError.NewNotFound("order batch not found")
+	}
+	return nil
+}
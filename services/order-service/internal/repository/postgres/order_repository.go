@@ -3,15 +3,19 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
 	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/i18n"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
 )
 
@@ -20,6 +24,73 @@ type OrderRepository struct {
 	db *sqlx.DB
 }
 
+// orderRow is an orders row; amounts are stored as integer minor units and the
+// tags, attributes, shipping address and warnings as JSONB
+type orderRow struct {
+	domain.Order
+	TotalAmountMinor    int64  `db:"total_amount_minor"`
+	TagsJSON            []byte `db:"tags"`
+	AttributesJSON      []byte `db:"attributes"`
+	ShippingAddressJSON []byte `db:"shipping_address"`
+	WarningsJSON        []byte `db:"warnings"`
+}
+
+func (r *orderRow) toDomain() *domain.Order {
+	order := r.Order
+	order.TotalAmount = money.New(r.TotalAmountMinor, order.Currency)
+	// The columns are JSONB written by marshalOrderTags, so they always decode
+	json.Unmarshal(r.TagsJSON, &order.Tags)
+	json.Unmarshal(r.AttributesJSON, &order.Attributes)
+	if len(order.Tags) == 0 {
+		order.Tags = nil
+	}
+	if len(order.Attributes) == 0 {
+		order.Attributes = nil
+	}
+	if r.ShippingAddressJSON != nil {
+		order.ShippingAddress = &domain.Address{}
+		json.Unmarshal(r.ShippingAddressJSON, order.ShippingAddress)
+	}
+	if r.WarningsJSON != nil {
+		json.Unmarshal(r.WarningsJSON, &order.Warnings)
+	}
+	return &order
+}
+
+// marshalOrderTags encodes tags and attributes for the JSONB columns, writing empty
+// values as [] and {} so containment filters never meet a JSON null
+func marshalOrderTags(tags []string, attributes domain.OrderAttributes) ([]byte, []byte, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	if attributes == nil {
+		attributes = domain.OrderAttributes{}
+	}
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return nil, nil, platformError.Wrap(err, "failed to marshal order tags")
+	}
+	attributesJSON, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, nil, platformError.Wrap(err, "failed to marshal order attributes")
+	}
+	return tagsJSON, attributesJSON, nil
+}
+
+// shipmentRow is an order_shipments row; the amount is in the minor unit of the order currency
+type shipmentRow struct {
+	domain.Shipment
+	ItemsJSON   []byte `db:"items"`
+	AmountMinor int64  `db:"amount_minor"`
+}
+
+// orderItemRow is an order_items row; prices are in the minor unit of the order currency
+type orderItemRow struct {
+	domain.OrderItem
+	UnitPriceMinor int64 `db:"unit_price_minor"`
+	TotalMinor     int64 `db:"total_minor"`
+}
+
 // NewOrderRepository creates a new PostgreSQL order repository
 func NewOrderRepository(db *sqlx.DB) interfaces.OrderRepository {
 	return &OrderRepository{
@@ -29,6 +100,37 @@ func NewOrderRepository(db *sqlx.DB) interfaces.OrderRepository {
 
 // Create creates a new order with its items in a transaction
 func (r *OrderRepository) Create(ctx context.Context, order *domain.Order) error {
+	tagsJSON, attributesJSON, err := marshalOrderTags(order.Tags, order.Attributes)
+	if err != nil {
+		return err
+	}
+
+	// Orders without a shipping address keep a NULL column
+	var shippingAddressJSON []byte
+	if order.ShippingAddress != nil {
+		if shippingAddressJSON, err = json.Marshal(order.ShippingAddress); err != nil {
+			return platformError.Wrap(err, "failed to marshal shipping address")
+		}
+	}
+
+	// Orders without warnings keep a NULL column
+	var warningsJSON []byte
+	if len(order.Warnings) > 0 {
+		if warningsJSON, err = json.Marshal(order.Warnings); err != nil {
+			return platformError.Wrap(err, "failed to marshal order warnings")
+		}
+	}
+
+	policy := order.FulfillmentPolicy
+	if policy == "" {
+		policy = domain.FulfillmentAllOrNothing
+	}
+
+	locale := order.Locale
+	if locale == "" {
+		locale = i18n.DefaultLocale
+	}
+
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return platformError.Wrap(err, "failed to begin transaction")
@@ -37,12 +139,12 @@ func (r *OrderRepository) Create(ctx context.Context, order *domain.Order) error
 
 	// Insert order
 	orderQuery := `
-		INSERT INTO orders (id, user_id, status, total_amount, currency, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+		INSERT INTO orders (id, user_id, status, total_amount_minor, currency, created_at, updated_at, tags, attributes, shipping_address, fulfillment_policy, warnings, locale, sandbox)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
 
 	_, err = tx.ExecContext(ctx, orderQuery,
-		order.ID, order.UserID, order.Status, order.TotalAmount,
-		order.Currency, order.CreatedAt, order.UpdatedAt)
+		order.ID, order.UserID, order.Status, order.TotalAmount.Minor,
+		order.Currency, order.CreatedAt, order.UpdatedAt, tagsJSON, attributesJSON, shippingAddressJSON, policy, warningsJSON, locale, order.Sandbox)
 	if err != nil {
 		return platformError.Wrap(err, "failed to insert order")
 	}
@@ -50,19 +152,37 @@ func (r *OrderRepository) Create(ctx context.Context, order *domain.Order) error
 	// Insert order items
 	if len(order.Items) > 0 {
 		itemQuery := `
-			INSERT INTO order_items (id, order_id, item_id, item_name, quantity, unit_price, total, created_at)
+			INSERT INTO order_items (id, order_id, item_id, item_name, quantity, unit_price_minor, total_minor, created_at)
 			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
 
 		for _, item := range order.Items {
 			_, err = tx.ExecContext(ctx, itemQuery,
 				item.ID, item.OrderID, item.ItemID, item.ItemName,
-				item.Quantity, item.UnitPrice, item.Total, item.CreatedAt)
+				item.Quantity, item.UnitPrice.Minor, item.Total.Minor, item.CreatedAt)
 			if err != nil {
 				return platformError.Wrap(err, "failed to insert order item")
 			}
 		}
 	}
 
+	// Insert the shipments of a partially fulfilled order
+	shipmentQuery := `
+		INSERT INTO order_shipments (id, order_id, sequence, status, items, amount_minor, transaction_id, created_at, updated_at, paid_at, assembled_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	for _, shipment := range order.Shipments {
+		itemsJSON, err := json.Marshal(shipment.Items)
+		if err != nil {
+			return platformError.Wrap(err, "failed to marshal shipment items")
+		}
+		_, err = tx.ExecContext(ctx, shipmentQuery,
+			shipment.ID, order.ID, shipment.Sequence, shipment.Status, itemsJSON, shipment.Amount.Minor,
+			shipment.TransactionID, shipment.CreatedAt, shipment.UpdatedAt, shipment.PaidAt, shipment.AssembledAt)
+		if err != nil {
+			return platformError.Wrap(err, "failed to insert order shipment")
+		}
+	}
+
 	return tx.Commit()
 }
 
@@ -70,67 +190,75 @@ func (r *OrderRepository) Create(ctx context.Context, order *domain.Order) error
 func (r *OrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
 	// Get order
 	orderQuery := `
-		SELECT id, user_id, status, total_amount, currency, created_at, updated_at,
-			   paid_at, assembled_at, completed_at
+		SELECT id, user_id, status, total_amount_minor, currency, created_at, updated_at,
+			   paid_at, assembled_at, completed_at, tags, attributes, shipping_address, fulfillment_policy, warnings, locale, refund_status, sandbox
 		FROM orders 
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	order := &domain.Order{}
-	err := r.db.GetContext(ctx, order, orderQuery, id)
+	row := orderRow{}
+	err := r.db.GetContext(ctx, &row, orderQuery, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, platformError.NewNotFound("order not found")
 		}
 		return nil, platformError.Wrap(err, "failed to get order")
 	}
+	order := row.toDomain()
 
 	// Get order items
-	itemsQuery := `
-		SELECT id, order_id, item_id, item_name, quantity, unit_price, total, created_at
-		FROM order_items
-		WHERE order_id = $1
-		ORDER BY created_at`
+	order.Items, err = r.getOrderItems(ctx, order.ID, order.Currency)
+	if err != nil {
+		return nil, err
+	}
 
-	items := []domain.OrderItem{}
-	err = r.db.SelectContext(ctx, &items, itemsQuery, id)
+	order.SerialNumbers, err = r.getSerialNumbers(ctx, id)
 	if err != nil {
-		return nil, platformError.Wrap(err, "failed to get order items")
+		return nil, err
 	}
 
-	order.Items = items
+	order.Shipments, err = r.getShipments(ctx, id, order.Currency)
+	if err != nil {
+		return nil, err
+	}
 	return order, nil
 }
 
 // GetByUserID retrieves orders for a specific user with pagination
 func (r *OrderRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Order, error) {
 	query := `
-		SELECT id, user_id, status, total_amount, currency, created_at, updated_at,
-			   paid_at, assembled_at, completed_at
+		SELECT id, user_id, status, total_amount_minor, currency, created_at, updated_at,
+			   paid_at, assembled_at, completed_at, tags, attributes, shipping_address, fulfillment_policy, warnings, locale, refund_status, sandbox
 		FROM orders 
 		WHERE user_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3`
 
-	orders := []*domain.Order{}
-	err := r.db.SelectContext(ctx, &orders, query, userID, limit, offset)
+	rows := []orderRow{}
+	err := r.db.SelectContext(ctx, &rows, query, userID, limit, offset)
 	if err != nil {
 		return nil, platformError.Wrap(err, "failed to get orders by user ID")
 	}
 
 	// Load items for each order
-	for _, order := range orders {
-		items := []domain.OrderItem{}
-		itemsQuery := `
-			SELECT id, order_id, item_id, item_name, quantity, unit_price, total, created_at
-			FROM order_items
-			WHERE order_id = $1
-			ORDER BY created_at`
-
-		err = r.db.SelectContext(ctx, &items, itemsQuery, order.ID)
+	orders := make([]*domain.Order, 0, len(rows))
+	for i := range rows {
+		order := rows[i].toDomain()
+		orders = append(orders, order)
+
+		order.Items, err = r.getOrderItems(ctx, order.ID, order.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		order.SerialNumbers, err = r.getSerialNumbers(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		order.Shipments, err = r.getShipments(ctx, order.ID, order.Currency)
 		if err != nil {
-			return nil, platformError.Wrap(err, "failed to get order items")
+			return nil, err
 		}
-		order.Items = items
 	}
 
 	return orders, nil
@@ -140,12 +268,12 @@ func (r *OrderRepository) GetByUserID(ctx context.Context, userID uuid.UUID, lim
 func (r *OrderRepository) Update(ctx context.Context, order *domain.Order) error {
 	query := `
 		UPDATE orders 
-		SET status = $2, total_amount = $3, updated_at = $4,
+		SET status = $2, total_amount_minor = $3, updated_at = $4,
 			paid_at = $5, assembled_at = $6, completed_at = $7
 		WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.db.ExecContext(ctx, query,
-		order.ID, order.Status, order.TotalAmount, order.UpdatedAt,
+		order.ID, order.Status, order.TotalAmount.Minor, order.UpdatedAt,
 		order.PaidAt, order.AssembledAt, order.CompletedAt)
 	if err != nil {
 		return platformError.Wrap(err, "failed to update order")
@@ -163,33 +291,107 @@ func (r *OrderRepository) Update(ctx context.Context, order *domain.Order) error
 	return nil
 }
 
-// UpdateStatus updates only the status and related timestamps of an order
-func (r *OrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus) error {
-	now := time.Now()
+// UpdateStatus moves an order to a new status, enforcing the order state machine.
+// The current status is read under a row lock so concurrent updates cannot skip the check.
+func (r *OrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus) (domain.OrderStatus, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", platformError.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	from, err := r.transitionStatus(ctx, tx, id, status)
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", platformError.Wrap(err, "failed to commit transaction")
+	}
+	return from, nil
+}
+
+// UpdateStatusForEvent applies the status updates triggered by an event exactly once.
+// The event ID is recorded in processed_events in the same transaction as the updates;
+// if it was already recorded nothing is changed and no transitions are returned.
+func (r *OrderRepository) UpdateStatusForEvent(ctx context.Context, eventID, eventType string, id uuid.UUID, statuses ...domain.OrderStatus) ([]domain.StatusTransition, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, platformError.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
 
 	query := `
-		UPDATE orders 
-		SET status = $2, updated_at = $3,
-			paid_at = CASE WHEN $2 = 'paid' THEN $4 ELSE paid_at END,
-			assembled_at = CASE WHEN $2 = 'assembled' THEN $4 ELSE assembled_at END,
-			completed_at = CASE WHEN $2 = 'completed' THEN $4 ELSE completed_at END
-		WHERE id = $1 AND deleted_at IS NULL`
+		INSERT INTO processed_events (event_id, event_type, order_id, processed_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id) DO NOTHING`
 
-	result, err := r.db.ExecContext(ctx, query, id, status, now, now)
+	result, err := tx.ExecContext(ctx, query, eventID, eventType, id, time.Now())
 	if err != nil {
-		return platformError.Wrap(err, "failed to update order status")
+		return nil, platformError.Wrap(err, "failed to record processed event")
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return platformError.Wrap(err, "failed to get rows affected")
+		return nil, platformError.Wrap(err, "failed to get rows affected")
 	}
-
 	if rowsAffected == 0 {
-		return platformError.NewNotFound("order not found")
+		return nil, nil // Already processed
 	}
 
-	return nil
+	transitions := make([]domain.StatusTransition, 0, len(statuses))
+	for _, status := range statuses {
+		from, err := r.transitionStatus(ctx, tx, id, status)
+		if err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, domain.StatusTransition{From: from, To: status})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, platformError.Wrap(err, "failed to commit transaction")
+	}
+	return transitions, nil
+}
+
+// transitionStatus locks the order row, validates the transition and updates the status
+// and related timestamps within the given transaction. Returns the previous status.
+func (r *OrderRepository) transitionStatus(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, status domain.OrderStatus) (domain.OrderStatus, error) {
+	var from domain.OrderStatus
+	err := tx.GetContext(ctx, &from, `
+		SELECT status FROM orders
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", platformError.NewNotFound("order not found")
+		}
+		return "", platformError.Wrap(err, "failed to lock order")
+	}
+
+	if err := domain.ValidateTransition(from, status); err != nil {
+		return "", &platformError.AppError{
+			Type:    platformError.ErrorTypeConflict,
+			Message: "invalid order status transition",
+			Err:     err,
+		}
+	}
+
+	now := time.Now()
+
+	query := `
+		UPDATE orders 
+		SET status = $2, updated_at = $3,
+			paid_at = CASE WHEN $2 = 'paid' THEN $4 ELSE paid_at END,
+			assembled_at = CASE WHEN $2 = 'assembled' THEN $4 ELSE assembled_at END,
+			completed_at = CASE WHEN $2 = 'completed' THEN $4 ELSE completed_at END
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	if _, err := tx.ExecContext(ctx, query, id, status, now, now); err != nil {
+		return "", platformError.Wrap(err, "failed to update order status")
+	}
+
+	return from, nil
 }
 
 // List retrieves orders based on filter criteria with pagination
@@ -210,47 +412,73 @@ func (r *OrderRepository) List(ctx context.Context, filter domain.OrderFilter) (
 		argIndex++
 	}
 
+	conditions, conditionArgs, err := tagConditions(filter, argIndex)
+	if err != nil {
+		return nil, err
+	}
+	whereClause = append(whereClause, conditions...)
+	args = append(args, conditionArgs...)
+	argIndex += len(conditionArgs)
+
+	conditions, conditionArgs = createdConditions(filter, argIndex)
+	whereClause = append(whereClause, conditions...)
+	args = append(args, conditionArgs...)
+	argIndex += len(conditionArgs)
+
+	// Keyset paging resumes after the last order of the previous page
+	if filter.After != nil {
+		whereClause = append(whereClause, fmt.Sprintf("(created_at, id) < ($%d, $%d)", argIndex, argIndex+1))
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+		argIndex += 2
+	}
+
 	limit := 50 // default limit
 	if filter.Limit > 0 {
 		limit = filter.Limit
 	}
 
 	offset := 0
-	if filter.Offset > 0 {
+	if filter.Offset > 0 && filter.After == nil {
 		offset = filter.Offset
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, user_id, status, total_amount, currency, created_at, updated_at,
-			   paid_at, assembled_at, completed_at
+		SELECT id, user_id, status, total_amount_minor, currency, created_at, updated_at,
+			   paid_at, assembled_at, completed_at, tags, attributes, shipping_address, fulfillment_policy, warnings, locale, refund_status, sandbox
 		FROM orders 
 		WHERE %s
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 		LIMIT $%d OFFSET $%d`,
 		strings.Join(whereClause, " AND "), argIndex, argIndex+1)
 
 	args = append(args, limit, offset)
 
-	orders := []*domain.Order{}
-	err := r.db.SelectContext(ctx, &orders, query, args...)
+	rows := []orderRow{}
+	err = r.db.SelectContext(ctx, &rows, query, args...)
 	if err != nil {
 		return nil, platformError.Wrap(err, "failed to list orders")
 	}
 
 	// Load items for each order
-	for _, order := range orders {
-		items := []domain.OrderItem{}
-		itemsQuery := `
-			SELECT id, order_id, item_id, item_name, quantity, unit_price, total, created_at
-			FROM order_items
-			WHERE order_id = $1
-			ORDER BY created_at`
-
-		err = r.db.SelectContext(ctx, &items, itemsQuery, order.ID)
+	orders := make([]*domain.Order, 0, len(rows))
+	for i := range rows {
+		order := rows[i].toDomain()
+		orders = append(orders, order)
+
+		order.Items, err = r.getOrderItems(ctx, order.ID, order.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		order.SerialNumbers, err = r.getSerialNumbers(ctx, order.ID)
 		if err != nil {
-			return nil, platformError.Wrap(err, "failed to get order items")
+			return nil, err
+		}
+
+		order.Shipments, err = r.getShipments(ctx, order.ID, order.Currency)
+		if err != nil {
+			return nil, err
 		}
-		order.Items = items
 	}
 
 	return orders, nil
@@ -274,6 +502,18 @@ func (r *OrderRepository) Count(ctx context.Context, filter domain.OrderFilter)
 		argIndex++
 	}
 
+	conditions, conditionArgs, err := tagConditions(filter, argIndex)
+	if err != nil {
+		return 0, err
+	}
+	whereClause = append(whereClause, conditions...)
+	args = append(args, conditionArgs...)
+	argIndex += len(conditionArgs)
+
+	conditions, conditionArgs = createdConditions(filter, argIndex)
+	whereClause = append(whereClause, conditions...)
+	args = append(args, conditionArgs...)
+
 	query := fmt.Sprintf(`
 		SELECT COUNT(*) 
 		FROM orders 
@@ -281,7 +521,7 @@ func (r *OrderRepository) Count(ctx context.Context, filter domain.OrderFilter)
 		strings.Join(whereClause, " AND "))
 
 	var count int
-	err := r.db.GetContext(ctx, &count, query, args...)
+	err = r.db.GetContext(ctx, &count, query, args...)
 	if err != nil {
 		return 0, platformError.Wrap(err, "failed to count orders")
 	}
@@ -289,6 +529,94 @@ func (r *OrderRepository) Count(ctx context.Context, filter domain.OrderFilter)
 	return count, nil
 }
 
+// tagConditions returns the containment conditions of the tag and attribute filters,
+// numbering their arguments from argIndex. An attribute filter matches any of the
+// typed values its text stands for.
+func tagConditions(filter domain.OrderFilter, argIndex int) ([]string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+
+	if len(filter.Tags) > 0 {
+		tagsJSON, err := json.Marshal(filter.Tags)
+		if err != nil {
+			return nil, nil, platformError.Wrap(err, "failed to marshal tag filter")
+		}
+		conditions = append(conditions, fmt.Sprintf("tags @> $%d::jsonb", argIndex))
+		args = append(args, tagsJSON)
+		argIndex++
+	}
+
+	names := make([]string, 0, len(filter.Attributes))
+	for name := range filter.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var alternatives []string
+		for _, value := range domain.AttributeFilterValues(filter.Attributes[name]) {
+			valueJSON, err := json.Marshal(map[string]interface{}{name: value})
+			if err != nil {
+				return nil, nil, platformError.Wrap(err, "failed to marshal attribute filter")
+			}
+			alternatives = append(alternatives, fmt.Sprintf("attributes @> $%d::jsonb", argIndex))
+			args = append(args, valueJSON)
+			argIndex++
+		}
+		conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+	}
+
+	return conditions, args, nil
+}
+
+// createdConditions returns the conditions of the creation time range of the
+// filter, numbering their arguments from argIndex
+func createdConditions(filter domain.OrderFilter, argIndex int) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argIndex))
+		args = append(args, *filter.CreatedFrom)
+		argIndex++
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", argIndex))
+		args = append(args, *filter.CreatedTo)
+	}
+
+	return conditions, args
+}
+
+// UpdateTags replaces the tags and attributes of an order
+func (r *OrderRepository) UpdateTags(ctx context.Context, id uuid.UUID, tags []string, attributes domain.OrderAttributes) error {
+	tagsJSON, attributesJSON, err := marshalOrderTags(tags, attributes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE orders 
+		SET tags = $2, attributes = $3, updated_at = $4
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, tagsJSON, attributesJSON, time.Now())
+	if err != nil {
+		return platformError.Wrap(err, "failed to update order tags")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return platformError.Wrap(err, "failed to get rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return platformError.NewNotFound("order not found")
+	}
+
+	return nil
+}
+
 // Delete soft deletes an order
 func (r *OrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `
@@ -313,17 +641,135 @@ func (r *OrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// SaveSerialNumbers records the serial numbers allocated to an order, ignoring ones already recorded
+func (r *OrderRepository) SaveSerialNumbers(ctx context.Context, orderID uuid.UUID, serials []domain.SerialAllocation) error {
+	if len(serials) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return platformError.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO order_item_serials (order_id, item_id, serial_number, allocated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (order_id, serial_number) DO NOTHING`
+
+	for _, serial := range serials {
+		_, err = tx.ExecContext(ctx, query, orderID, serial.ItemID, serial.SerialNumber, serial.AllocatedAt)
+		if err != nil {
+			return platformError.Wrap(err, "failed to insert order serial number")
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UpdateShipment records the status, payment and assembly of a shipment
+func (r *OrderRepository) UpdateShipment(ctx context.Context, shipment *domain.Shipment) error {
+	query := `
+		UPDATE order_shipments
+		SET status = $2, transaction_id = $3, updated_at = $4, paid_at = $5, assembled_at = $6
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		shipment.ID, shipment.Status, shipment.TransactionID, shipment.UpdatedAt, shipment.PaidAt, shipment.AssembledAt)
+	if err != nil {
+		return platformError.Wrap(err, "failed to update order shipment")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return platformError.Wrap(err, "failed to get rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return platformError.NewNotFound("shipment not found")
+	}
+
+	return nil
+}
+
+// getShipments retrieves the shipments of an order in sequence; orders shipped at once have none
+func (r *OrderRepository) getShipments(ctx context.Context, orderID uuid.UUID, currency string) ([]domain.Shipment, error) {
+	query := `
+		SELECT id, order_id, sequence, status, items, amount_minor, transaction_id,
+			   created_at, updated_at, paid_at, assembled_at
+		FROM order_shipments
+		WHERE order_id = $1
+		ORDER BY sequence`
+
+	rows := []shipmentRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, orderID); err != nil {
+		return nil, platformError.Wrap(err, "failed to get order shipments")
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	shipments := make([]domain.Shipment, 0, len(rows))
+	for _, row := range rows {
+		shipment := row.Shipment
+		shipment.Amount = money.New(row.AmountMinor, currency)
+		// The column is JSONB written by Create, so it always decodes
+		json.Unmarshal(row.ItemsJSON, &shipment.Items)
+		shipments = append(shipments, shipment)
+	}
+	return shipments, nil
+}
+
+// getOrderItems retrieves the items of an order, priced in the order currency
+func (r *OrderRepository) getOrderItems(ctx context.Context, orderID uuid.UUID, currency string) ([]domain.OrderItem, error) {
+	query := `
+		SELECT id, order_id, item_id, item_name, quantity, unit_price_minor, total_minor, created_at
+		FROM order_items
+		WHERE order_id = $1
+		ORDER BY created_at`
+
+	rows := []orderItemRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, orderID); err != nil {
+		return nil, platformError.Wrap(err, "failed to get order items")
+	}
+
+	items := make([]domain.OrderItem, 0, len(rows))
+	for _, row := range rows {
+		item := row.OrderItem
+		item.UnitPrice = money.New(row.UnitPriceMinor, currency)
+		item.Total = money.New(row.TotalMinor, currency)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// getSerialNumbers retrieves the serial numbers allocated to an order
+func (r *OrderRepository) getSerialNumbers(ctx context.Context, orderID uuid.UUID) ([]domain.SerialAllocation, error) {
+	query := `
+		SELECT item_id, serial_number, allocated_at
+		FROM order_item_serials
+		WHERE order_id = $1
+		ORDER BY item_id, serial_number`
+
+	serials := []domain.SerialAllocation{}
+	if err := r.db.SelectContext(ctx, &serials, query, orderID); err != nil {
+		return nil, platformError.Wrap(err, "failed to get order serial numbers")
+	}
+	return serials, nil
+}
+
 // GetOrderMetrics returns aggregated metrics for monitoring and analytics
 func (r *OrderRepository) GetOrderMetrics(ctx context.Context) (*interfaces.OrderMetrics, error) {
 	metrics := &interfaces.OrderMetrics{
 		OrdersByStatus: make(map[string]int),
 	}
 
-	// Get total orders and revenue
+	// Get total orders and revenue; amounts are stored in cents and reported in major units
 	totalQuery := `
 		SELECT COUNT(*) as total_orders, 
-			   COALESCE(SUM(total_amount), 0) as total_revenue,
-			   COALESCE(AVG(total_amount), 0) as average_order_value
+			   COALESCE(SUM(total_amount_minor), 0) / 100.0 as total_revenue,
+			   COALESCE(AVG(total_amount_minor), 0) / 100.0 as average_order_value
 		FROM orders 
 		WHERE deleted_at IS NULL`
 
@@ -367,7 +813,7 @@ func (r *OrderRepository) GetOrderMetrics(ctx context.Context) (*interfaces.Orde
 	// Get today's metrics
 	todayQuery := `
 		SELECT COUNT(*) as orders_today, 
-			   COALESCE(SUM(total_amount), 0) as revenue_today
+			   COALESCE(SUM(total_amount_minor), 0) / 100.0 as revenue_today
 		FROM orders 
 		WHERE deleted_at IS NULL 
 		AND DATE(created_at) = CURRENT_DATE`
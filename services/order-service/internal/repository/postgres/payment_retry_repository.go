@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+)
+
+const paymentRetryColumns = `order_id, user_id, status, attempts, max_attempts, next_attempt_at, last_error,
+	created_at, updated_at`
+
+// PaymentRetryRepository implements the PaymentRetryRepository interface using PostgreSQL
+type PaymentRetryRepository struct {
+	db *sqlx.DB
+}
+
+// NewPaymentRetryRepository creates a new PostgreSQL payment retry repository
+func NewPaymentRetryRepository(db *sqlx.DB) interfaces.PaymentRetryRepository {
+	return &PaymentRetryRepository{
+		db: db,
+	}
+}
+
+// Create stores a new retry schedule
+func (r *PaymentRetryRepository) Create(ctx context.Context, retry *domain.PaymentRetry) error {
+	query := `
+		INSERT INTO order_payment_retries (` + paymentRetryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		retry.OrderID, retry.UserID, retry.Status, retry.Attempts, retry.MaxAttempts,
+		retry.NextAttemptAt, retry.LastError, retry.CreatedAt, retry.UpdatedAt)
+	if err != nil {
+		return platformError.Wrap(err, "failed to insert payment retry")
+	}
+	return nil
+}
+
+// ListDue returns up to limit scheduled retries whose next attempt is due at now, earliest first
+func (r *PaymentRetryRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.PaymentRetry, error) {
+	query := `
+		SELECT ` + paymentRetryColumns + ` FROM order_payment_retries
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at
+		LIMIT $3`
+
+	retries := []*domain.PaymentRetry{}
+	if err := r.db.SelectContext(ctx, &retries, query, domain.PaymentRetryScheduled, now, limit); err != nil {
+		return nil, platformError.Wrap(err, "failed to list due payment retries")
+	}
+	return retries, nil
+}
+
+// Claim counts an attempt of a scheduled retry due at now. It returns a conflict error
+// when another instance claimed the attempt or the schedule ended in the meantime.
+func (r *PaymentRetryRepository) Claim(ctx context.Context, retry *domain.PaymentRetry, now time.Time) error {
+	query := `
+		UPDATE order_payment_retries
+		SET attempts = attempts + 1, updated_at = $2
+		WHERE order_id = $1 AND status = 'scheduled' AND attempts = $3 AND next_attempt_at <= $2`
+
+	result, err := r.db.ExecContext(ctx, query, retry.OrderID, now, retry.Attempts)
+	if err != nil {
+		return platformError.Wrap(err, "failed to claim payment retry")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return platformError.Wrap(err, "failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return platformError.NewConflict("payment retry has already been claimed")
+	}
+
+	retry.Attempts++
+	retry.UpdatedAt = now
+	return nil
+}
+
+// Update stores the outcome of an attempt
+func (r *PaymentRetryRepository) Update(ctx context.Context, retry *domain.PaymentRetry) error {
+	query := `
+		UPDATE order_payment_retries
+		SET status = $2, next_attempt_at = $3, last_error = $4, updated_at = $5
+		WHERE order_id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		retry.OrderID, retry.Status, retry.NextAttemptAt, retry.LastError, retry.UpdatedAt)
+	if err != nil {
+		return platformError.Wrap(err, "failed to update payment retry")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return platformError.Wrap(err, "failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return platformError.NewNotFound("payment retry not found")
+	}
+	return nil
+}
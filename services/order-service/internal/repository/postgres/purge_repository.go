@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+)
+
+// purgeCounts count the rows a purge deletes, keyed by table. Items, serials,
+// shipments, approvals, payment retries and refunds go with their orders and webhook
+// deliveries with their webhooks through the foreign key cascades.
+var purgeCounts = []struct {
+	table string
+	query string
+}{
+	{"order_items", `SELECT COUNT(*) FROM order_items WHERE order_id = ANY($1::uuid[])`},
+	{"order_item_serials", `SELECT COUNT(*) FROM order_item_serials WHERE order_id = ANY($1::uuid[])`},
+	{"order_shipments", `SELECT COUNT(*) FROM order_shipments WHERE order_id = ANY($1::uuid[])`},
+	{"order_approvals", `SELECT COUNT(*) FROM order_approvals WHERE order_id = ANY($1::uuid[])`},
+	{"order_payment_retries", `SELECT COUNT(*) FROM order_payment_retries WHERE order_id = ANY($1::uuid[])`},
+	{"order_refunds", `SELECT COUNT(*) FROM order_refunds WHERE order_id = ANY($1::uuid[])`},
+	{"processed_events", `SELECT COUNT(*) FROM processed_events WHERE order_id = ANY($1::uuid[])`},
+}
+
+// PurgeRepository implements the PurgeRepository interface using PostgreSQL
+type PurgeRepository struct {
+	db *sqlx.DB
+}
+
+// NewPurgeRepository creates a new PostgreSQL purge repository
+func NewPurgeRepository(db *sqlx.DB) interfaces.PurgeRepository {
+	return &PurgeRepository{
+		db: db,
+	}
+}
+
+// PurgeUsers deletes the orders, webhooks and addresses of the users
+func (r *PurgeRepository) PurgeUsers(ctx context.Context, userIDs []uuid.UUID, dryRun bool) (*interfaces.UserDataPurge, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, platformError.Wrap(err, "failed to begin purge transaction")
+	}
+	defer tx.Rollback()
+
+	users := uuidArray(userIDs)
+	purge := &interfaces.UserDataPurge{Deleted: make(map[string]int64)}
+
+	// Locked so orders placed meanwhile wait for the purge rather than lose their items
+	if err := tx.SelectContext(ctx, &purge.OrderIDs,
+		`SELECT id FROM orders WHERE user_id = ANY($1::uuid[]) ORDER BY id FOR UPDATE`, users); err != nil {
+		return nil, platformError.Wrap(err, "failed to find orders to purge")
+	}
+	orders := uuidArray(purge.OrderIDs)
+	purge.Deleted["orders"] = int64(len(purge.OrderIDs))
+
+	for _, count := range purgeCounts {
+		var rows int64
+		if err := tx.GetContext(ctx, &rows, count.query, orders); err != nil {
+			return nil, platformError.Wrap(err, "failed to count "+count.table+" to purge")
+		}
+		purge.Deleted[count.table] = rows
+	}
+
+	var webhooks, deliveries, addresses int64
+	if err := tx.GetContext(ctx, &webhooks, `SELECT COUNT(*) FROM webhooks WHERE user_id = ANY($1::uuid[])`, users); err != nil {
+		return nil, platformError.Wrap(err, "failed to count webhooks to purge")
+	}
+	if err := tx.GetContext(ctx, &deliveries, `
+		SELECT COUNT(*) FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE w.user_id = ANY($1::uuid[])`, users); err != nil {
+		return nil, platformError.Wrap(err, "failed to count webhook deliveries to purge")
+	}
+	purge.Deleted["webhooks"] = webhooks
+	purge.Deleted["webhook_deliveries"] = deliveries
+
+	if err := tx.GetContext(ctx, &addresses, `SELECT COUNT(*) FROM user_addresses WHERE user_id = ANY($1::uuid[])`, users); err != nil {
+		return nil, platformError.Wrap(err, "failed to count addresses to purge")
+	}
+	purge.Deleted["user_addresses"] = addresses
+
+	if dryRun {
+		return purge, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM processed_events WHERE order_id = ANY($1::uuid[])`, orders); err != nil {
+		return nil, platformError.Wrap(err, "failed to purge processed events")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM orders WHERE id = ANY($1::uuid[])`, orders); err != nil {
+		return nil, platformError.Wrap(err, "failed to purge orders")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM webhooks WHERE user_id = ANY($1::uuid[])`, users); err != nil {
+		return nil, platformError.Wrap(err, "failed to purge webhooks")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_addresses WHERE user_id = ANY($1::uuid[])`, users); err != nil {
+		return nil, platformError.Wrap(err, "failed to purge addresses")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, platformError.Wrap(err, "failed to commit purge")
+	}
+	return purge, nil
+}
+
+func uuidArray(ids []uuid.UUID) pq.StringArray {
+	array := make(pq.StringArray, len(ids))
+	for i, id := range ids {
+		array[i] = id.String()
+	}
+	return array
+}
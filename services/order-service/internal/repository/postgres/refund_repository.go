@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+const refundColumns = `id, order_id, user_id, amount_minor, currency, reason, status, requested_at,
+	decided_by, decided_at, note, payment_refund_id, refunded_at, failure_reason`
+
+// refundRow maps the order_refunds table, whose amount is stored in minor units
+type refundRow struct {
+	domain.RefundCase
+	AmountMinor int64  `db:"amount_minor"`
+	Currency    string `db:"currency"`
+}
+
+func (row *refundRow) toDomain() *domain.RefundCase {
+	refund := row.RefundCase
+	refund.Amount = money.New(row.AmountMinor, row.Currency)
+	return &refund
+}
+
+// RefundRepository implements the RefundRepository interface using PostgreSQL
+type RefundRepository struct {
+	db *sqlx.DB
+}
+
+// NewRefundRepository creates a new PostgreSQL order refund repository
+func NewRefundRepository(db *sqlx.DB) interfaces.RefundRepository {
+	return &RefundRepository{
+		db: db,
+	}
+}
+
+// Create stores a new refund case. It returns a conflict error when the order
+// already has one.
+func (r *RefundRepository) Create(ctx context.Context, refund *domain.RefundCase) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return platformError.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO order_refunds (` + refundColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (order_id) DO NOTHING`
+
+	result, err := tx.ExecContext(ctx, query,
+		refund.ID, refund.OrderID, refund.UserID, refund.Amount.Minor, refund.Amount.Currency,
+		refund.Reason, refund.Status, refund.RequestedAt, refund.DecidedBy, refund.DecidedAt,
+		refund.Note, refund.PaymentRefundID, refund.RefundedAt, refund.FailureReason)
+	if err != nil {
+		return platformError.Wrap(err, "failed to insert order refund")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return platformError.Wrap(err, "failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return platformError.NewConflict("a refund has already been requested for the order")
+	}
+
+	if err := setOrderRefundStatus(ctx, tx, refund); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return platformError.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// GetByOrderID retrieves the refund case of an order
+func (r *RefundRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) (*domain.RefundCase, error) {
+	query := `SELECT ` + refundColumns + ` FROM order_refunds WHERE order_id = $1`
+
+	var row refundRow
+	if err := r.db.GetContext(ctx, &row, query, orderID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, platformError.NewNotFound("order refund not found")
+		}
+		return nil, platformError.Wrap(err, "failed to get order refund")
+	}
+	return row.toDomain(), nil
+}
+
+// ListOpen returns up to limit refund cases awaiting a decision by support, oldest first
+func (r *RefundRepository) ListOpen(ctx context.Context, limit int) ([]*domain.RefundCase, error) {
+	query := `
+		SELECT ` + refundColumns + ` FROM order_refunds
+		WHERE status IN ($1, $2)
+		ORDER BY requested_at
+		LIMIT $3`
+
+	rows := []refundRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, domain.RefundRequested, domain.RefundFailed, limit); err != nil {
+		return nil, platformError.Wrap(err, "failed to list order refunds")
+	}
+
+	refunds := make([]*domain.RefundCase, len(rows))
+	for i := range rows {
+		refunds[i] = rows[i].toDomain()
+	}
+	return refunds, nil
+}
+
+// Update records a change of the refund case made from the given status. It
+// returns a conflict error when the case changed status in the meantime.
+func (r *RefundRepository) Update(ctx context.Context, refund *domain.RefundCase, from domain.RefundStatus) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return platformError.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE order_refunds
+		SET status = $3, decided_by = $4, decided_at = $5, note = $6,
+			payment_refund_id = $7, refunded_at = $8, failure_reason = $9
+		WHERE order_id = $1 AND status = $2`
+
+	result, err := tx.ExecContext(ctx, query,
+		refund.OrderID, from, refund.Status, refund.DecidedBy, refund.DecidedAt, refund.Note,
+		refund.PaymentRefundID, refund.RefundedAt, refund.FailureReason)
+	if err != nil {
+		return platformError.Wrap(err, "failed to update order refund")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return platformError.Wrap(err, "failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return platformError.NewConflict("order refund has changed in the meantime")
+	}
+
+	if err := setOrderRefundStatus(ctx, tx, refund); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return platformError.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// setOrderRefundStatus mirrors the status of the refund case on its order
+func setOrderRefundStatus(ctx context.Context, tx *sqlx.Tx, refund *domain.RefundCase) error {
+	query := `UPDATE orders SET refund_status = $2 WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, query, refund.OrderID, refund.Status); err != nil {
+		return platformError.Wrap(err, "failed to update order refund status")
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// reportSchema creates the reporting store. The store is a projection that can be
+// dropped and rebuilt by replaying the topics, so it is created in place rather than
+// through the migrations of the orders database.
+const reportSchema = `
+	CREATE TABLE IF NOT EXISTS order_reports (
+		order_id UUID PRIMARY KEY,
+		user_id UUID,
+		status VARCHAR(50) NOT NULL DEFAULT '',
+		total_minor BIGINT NOT NULL DEFAULT 0,
+		currency CHAR(3) NOT NULL DEFAULT '',
+		item_count INTEGER NOT NULL DEFAULT 0,
+		transaction_id VARCHAR(255) NOT NULL DEFAULT '',
+		payment_status VARCHAR(50) NOT NULL DEFAULT '',
+		assembly_status VARCHAR(50) NOT NULL DEFAULT '',
+		assembly_seconds INTEGER NOT NULL DEFAULT 0,
+		failure_reason TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP WITH TIME ZONE,
+		paid_at TIMESTAMP WITH TIME ZONE,
+		assembled_at TIMESTAMP WITH TIME ZONE,
+		completed_at TIMESTAMP WITH TIME ZONE,
+		cancelled_at TIMESTAMP WITH TIME ZONE,
+		failed_at TIMESTAMP WITH TIME ZONE,
+		status_changed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+		updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+	);
+
+	ALTER TABLE order_reports ADD COLUMN IF NOT EXISTS sandbox BOOLEAN NOT NULL DEFAULT FALSE;
+
+	CREATE INDEX IF NOT EXISTS idx_order_reports_created_at ON order_reports(created_at DESC, order_id DESC);
+	CREATE INDEX IF NOT EXISTS idx_order_reports_user_id ON order_reports(user_id, created_at DESC);
+	CREATE INDEX IF NOT EXISTS idx_order_reports_status ON order_reports(status, created_at DESC);
+
+	CREATE TABLE IF NOT EXISTS report_processed_events (
+		event_id VARCHAR(255) PRIMARY KEY,
+		event_type VARCHAR(100) NOT NULL,
+		order_id UUID NOT NULL,
+		processed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_report_processed_events_processed_at ON report_processed_events(processed_at);`
+
+const reportColumns = `order_id, user_id, status, total_minor, currency, item_count, transaction_id,
+	payment_status, assembly_status, assembly_seconds, failure_reason, created_at, paid_at,
+	assembled_at, completed_at, cancelled_at, failed_at, status_changed_at, updated_at, sandbox`
+
+// reportRow maps the order_reports table, whose total is stored in minor units
+type reportRow struct {
+	domain.OrderReport
+	TotalMinor int64  `db:"total_minor"`
+	Currency   string `db:"currency"`
+}
+
+func (row *reportRow) toDomain() *domain.OrderReport {
+	report := row.OrderReport
+	report.Total = money.New(row.TotalMinor, strings.TrimSpace(row.Currency))
+	return &report
+}
+
+// ReportRepository implements the ReportRepository interface using PostgreSQL
+type ReportRepository struct {
+	db *sqlx.DB
+}
+
+// NewReportRepository creates a new PostgreSQL order report repository on the reporting database
+func NewReportRepository(db *sqlx.DB) *ReportRepository {
+	return &ReportRepository{
+		db: db,
+	}
+}
+
+var _ interfaces.ReportRepository = (*ReportRepository)(nil)
+
+// EnsureSchema creates the reporting tables if they don't exist
+func (r *ReportRepository) EnsureSchema(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, reportSchema); err != nil {
+		return platformError.Wrap(err, "failed to create reporting schema")
+	}
+	return nil
+}
+
+// Apply folds an event into its order report exactly once. The event ID is recorded in
+// the same transaction as the report, and the report row is locked while it is updated.
+func (r *ReportRepository) Apply(ctx context.Context, event domain.ReportEvent) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, platformError.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO report_processed_events (event_id, event_type, order_id, processed_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id) DO NOTHING`,
+		event.ID, string(event.Type), event.OrderID, time.Now())
+	if err != nil {
+		return false, platformError.Wrap(err, "failed to record processed event")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, platformError.Wrap(err, "failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return false, nil // Already applied
+	}
+
+	var row reportRow
+	report := domain.NewOrderReport(event.OrderID)
+	err = tx.GetContext(ctx, &row, `SELECT `+reportColumns+` FROM order_reports WHERE order_id = $1 FOR UPDATE`, event.OrderID)
+	switch {
+	case err == nil:
+		report = row.toDomain()
+	case err != sql.ErrNoRows:
+		return false, platformError.Wrap(err, "failed to get order report")
+	}
+
+	report.Apply(event)
+	report.UpdatedAt = time.Now()
+
+	// The user is unknown until an event carrying it arrives
+	var userID interface{}
+	if report.UserID != uuid.Nil {
+		userID = report.UserID
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO order_reports (`+reportColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		ON CONFLICT (order_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id, status = EXCLUDED.status, total_minor = EXCLUDED.total_minor,
+			currency = EXCLUDED.currency, item_count = EXCLUDED.item_count,
+			transaction_id = EXCLUDED.transaction_id, payment_status = EXCLUDED.payment_status,
+			assembly_status = EXCLUDED.assembly_status, assembly_seconds = EXCLUDED.assembly_seconds,
+			failure_reason = EXCLUDED.failure_reason, created_at = EXCLUDED.created_at,
+			paid_at = EXCLUDED.paid_at, assembled_at = EXCLUDED.assembled_at,
+			completed_at = EXCLUDED.completed_at, cancelled_at = EXCLUDED.cancelled_at,
+			failed_at = EXCLUDED.failed_at, status_changed_at = EXCLUDED.status_changed_at,
+			updated_at = EXCLUDED.updated_at, sandbox = EXCLUDED.sandbox`,
+		report.OrderID, userID, report.Status, report.Total.Minor, report.Total.Currency, report.ItemCount,
+		report.TransactionID, report.PaymentStatus, report.AssemblyStatus, report.AssemblySeconds,
+		report.FailureReason, report.CreatedAt, report.PaidAt, report.AssembledAt, report.CompletedAt,
+		report.CancelledAt, report.FailedAt, report.StatusChangedAt, report.UpdatedAt, report.Sandbox)
+	if err != nil {
+		return false, platformError.Wrap(err, "failed to save order report")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, platformError.Wrap(err, "failed to commit transaction")
+	}
+	return true, nil
+}
+
+// GetByOrderID retrieves the report of an order
+func (r *ReportRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) (*domain.OrderReport, error) {
+	var row reportRow
+	if err := r.db.GetContext(ctx, &row, `SELECT `+reportColumns+` FROM order_reports WHERE order_id = $1`, orderID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, platformError.NewNotFound("order report not found")
+		}
+		return nil, platformError.Wrap(err, "failed to get order report")
+	}
+	return row.toDomain(), nil
+}
+
+// List retrieves the order reports matching the filter, newest first. Test orders
+// of sandbox tenants are not listed.
+func (r *ReportRepository) List(ctx context.Context, filter domain.ReportFilter) ([]*domain.OrderReport, error) {
+	conditions := []string{"NOT sandbox"}
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.UserID != nil {
+		where("user_id = $%d", *filter.UserID)
+	}
+	if filter.Status != nil {
+		where("status = $%d", string(*filter.Status))
+	}
+	if filter.CreatedAfter != nil {
+		where("created_at >= $%d", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		where("created_at < $%d", *filter.CreatedBefore)
+	}
+
+	query := `SELECT ` + reportColumns + ` FROM order_reports WHERE ` + strings.Join(conditions, " AND ")
+	args = append(args, filter.Limit, filter.Offset)
+	query += fmt.Sprintf(` ORDER BY created_at DESC NULLS LAST, order_id DESC LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	rows := []reportRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, platformError.Wrap(err, "failed to list order reports")
+	}
+
+	reports := make([]*domain.OrderReport, len(rows))
+	for i := range rows {
+		reports[i] = rows[i].toDomain()
+	}
+	return reports, nil
+}
+
+// Summary aggregates the reports of the orders created in [from, to). Revenue counts
+// every order that was paid, in the currency it was paid in. Test orders of sandbox
+// tenants are left out.
+func (r *ReportRepository) Summary(ctx context.Context, from, to time.Time) (*domain.ReportSummary, error) {
+	summary := &domain.ReportSummary{
+		From:     from,
+		To:       to,
+		ByStatus: make(map[domain.OrderStatus]int),
+	}
+
+	var statusCounts []struct {
+		Status string `db:"status"`
+		Count  int    `db:"count"`
+	}
+	if err := r.db.SelectContext(ctx, &statusCounts, `
+		SELECT status, COUNT(*) AS count FROM order_reports
+		WHERE created_at >= $1 AND created_at < $2 AND NOT sandbox
+		GROUP BY status`, from, to); err != nil {
+		return nil, platformError.Wrap(err, "failed to count order reports")
+	}
+	for _, count := range statusCounts {
+		summary.ByStatus[domain.OrderStatus(count.Status)] = count.Count
+		summary.OrderCount += count.Count
+	}
+
+	var revenue []struct {
+		Currency string `db:"currency"`
+		Minor    int64  `db:"minor"`
+	}
+	if err := r.db.SelectContext(ctx, &revenue, `
+		SELECT currency, SUM(total_minor) AS minor FROM order_reports
+		WHERE created_at >= $1 AND created_at < $2 AND paid_at IS NOT NULL AND NOT sandbox
+		GROUP BY currency ORDER BY currency`, from, to); err != nil {
+		return nil, platformError.Wrap(err, "failed to sum order revenue")
+	}
+	for _, amount := range revenue {
+		summary.Revenue = append(summary.Revenue, money.New(amount.Minor, strings.TrimSpace(amount.Currency)))
+	}
+
+	var assembly struct {
+		Failures   int     `db:"failures"`
+		AvgSeconds float64 `db:"avg_seconds"`
+	}
+	if err := r.db.GetContext(ctx, &assembly, `
+		SELECT COUNT(*) FILTER (WHERE assembly_status = 'failed') AS failures,
+			COALESCE(AVG(assembly_seconds) FILTER (WHERE assembly_status = 'completed'), 0) AS avg_seconds
+		FROM order_reports
+		WHERE created_at >= $1 AND created_at < $2 AND NOT sandbox`, from, to); err != nil {
+		return nil, platformError.Wrap(err, "failed to aggregate assembly outcomes")
+	}
+	summary.AssemblyFailures = assembly.Failures
+	summary.AvgAssemblyTime = time.Duration(assembly.AvgSeconds * float64(time.Second))
+
+	return summary, nil
+}
+
+// Purge deletes the reports of the users and orders along with their processed events
+func (r *ReportRepository) Purge(ctx context.Context, userIDs, orderIDs []uuid.UUID, dryRun bool) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, platformError.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var reportOrderIDs []uuid.UUID
+	if err := tx.SelectContext(ctx, &reportOrderIDs, `
+		SELECT order_id FROM order_reports
+		WHERE user_id = ANY($1::uuid[]) OR order_id = ANY($2::uuid[])
+		FOR UPDATE`, uuidArray(userIDs), uuidArray(orderIDs)); err != nil {
+		return 0, platformError.Wrap(err, "failed to find order reports to purge")
+	}
+	if dryRun || len(reportOrderIDs) == 0 {
+		return int64(len(reportOrderIDs)), nil
+	}
+
+	orders := uuidArray(reportOrderIDs)
+	if _, err := tx.ExecContext(ctx, `DELETE FROM report_processed_events WHERE order_id = ANY($1::uuid[])`, orders); err != nil {
+		return 0, platformError.Wrap(err, "failed to purge report processed events")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM order_reports WHERE order_id = ANY($1::uuid[])`, orders); err != nil {
+		return 0, platformError.Wrap(err, "failed to purge order reports")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, platformError.Wrap(err, "failed to commit transaction")
+	}
+	return int64(len(reportOrderIDs)), nil
+}
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/platform/jobs"
+	"github.com/amiosamu/rocket-science/shared/platform/retention"
+)
+
+// RetentionPolicies are the retention policies of the order database's
+// bookkeeping tables: processed events, webhook deliveries and jobs
+func RetentionPolicies(processedEventsTTL, webhookDeliveriesTTL, jobsTTL time.Duration) []retention.Policy {
+	return []retention.Policy{
+		{
+			Table:      "processed_events",
+			TimeColumn: "processed_at",
+			TTL:        processedEventsTTL,
+		},
+		{
+			Table:      "webhook_deliveries",
+			TimeColumn: "created_at",
+			TTL:        webhookDeliveriesTTL,
+			Condition:  "status <> 'pending'",
+		},
+		jobs.RetentionPolicy(jobsTTL),
+	}
+}
+
+// ReportRetentionPolicy is the retention policy of the events applied to the
+// reporting database's projections, which are deduplicated like processed events
+func ReportRetentionPolicy(ttl time.Duration) retention.Policy {
+	return retention.Policy{
+		Table:      "report_processed_events",
+		TimeColumn: "processed_at",
+		TTL:        ttl,
+	}
+}
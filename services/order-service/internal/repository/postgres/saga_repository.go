@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+)
+
+const sagaStepColumns = `id, order_id, step, status, attempt, compensation, error, recorded_at`
+
+// SagaRepository implements the SagaRepository interface using PostgreSQL
+type SagaRepository struct {
+	db *sqlx.DB
+}
+
+// NewSagaRepository creates a new PostgreSQL saga history repository
+func NewSagaRepository(db *sqlx.DB) interfaces.SagaRepository {
+	return &SagaRepository{
+		db: db,
+	}
+}
+
+// Record appends an entry to the saga history of an order
+func (r *SagaRepository) Record(ctx context.Context, record *domain.SagaStepRecord) error {
+	query := `
+		INSERT INTO order_saga_steps (` + sagaStepColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		record.ID, record.OrderID, record.Step, record.Status, record.Attempt,
+		record.Compensation, record.Error, record.RecordedAt)
+	if err != nil {
+		return platformError.Wrap(err, "failed to insert saga step")
+	}
+	return nil
+}
+
+// ListByOrder returns the saga history of an order, oldest first
+func (r *SagaRepository) ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*domain.SagaStepRecord, error) {
+	query := `
+		SELECT ` + sagaStepColumns + ` FROM order_saga_steps
+		WHERE order_id = $1
+		ORDER BY recorded_at, id`
+
+	records := []*domain.SagaStepRecord{}
+	if err := r.db.SelectContext(ctx, &records, query, orderID); err != nil {
+		return nil, platformError.Wrap(err, "failed to list saga steps")
+	}
+	return records, nil
+}
@@ -0,0 +1,256 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+)
+
+// TracedOrderRepository records a client span for every order repository call, with
+// the operation, a summary of the statements it runs and the rows it returned or
+// wrote. Query parameters and user IDs are never recorded.
+type TracedOrderRepository struct {
+	repo   interfaces.OrderRepository
+	tracer trace.Tracer
+}
+
+// NewTracedOrderRepository wraps an order repository with query tracing
+func NewTracedOrderRepository(repo interfaces.OrderRepository) interfaces.OrderRepository {
+	return &TracedOrderRepository{
+		repo:   repo,
+		tracer: otel.Tracer("order-service"),
+	}
+}
+
+// startSpan starts the span of a repository call
+func (r *TracedOrderRepository) startSpan(ctx context.Context, method, operation, statement string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append([]attribute.KeyValue{
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", operation),
+		attribute.String("db.sql.table", "orders"),
+		attribute.String("db.statement.summary", statement),
+	}, attrs...)
+	return r.tracer.Start(ctx, "OrderRepository."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// endSpan records the outcome of a repository call and ends its span
+func endSpan(span trace.Span, rows int, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(attribute.Int("db.rows", rows))
+	}
+	span.End()
+}
+
+// Create creates a new order with its items in a transaction
+func (r *TracedOrderRepository) Create(ctx context.Context, order *domain.Order) error {
+	ctx, span := r.startSpan(ctx, "Create", "INSERT", "INSERT orders, order_items, order_shipments",
+		attribute.Int("order.item_count", len(order.Items)),
+	)
+	err := r.repo.Create(ctx, order)
+	endSpan(span, 1+len(order.Items)+len(order.Shipments), err)
+	return err
+}
+
+// GetByID retrieves an order by its ID, including all items
+func (r *TracedOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
+	ctx, span := r.startSpan(ctx, "GetByID", "SELECT", "SELECT orders, order_items, order_item_serials WHERE id",
+		attribute.String("order_id", id.String()),
+	)
+	order, err := r.repo.GetByID(ctx, id)
+	endSpan(span, orderRows(order), err)
+	return order, err
+}
+
+// GetByUserID retrieves orders for a specific user with pagination
+func (r *TracedOrderRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Order, error) {
+	ctx, span := r.startSpan(ctx, "GetByUserID", "SELECT", "SELECT orders WHERE user_id ORDER BY created_at LIMIT OFFSET",
+		attribute.Int("db.limit", limit),
+		attribute.Int("db.offset", offset),
+	)
+	orders, err := r.repo.GetByUserID(ctx, userID, limit, offset)
+	endSpan(span, len(orders), err)
+	return orders, err
+}
+
+// Update updates an existing order (including items if modified)
+func (r *TracedOrderRepository) Update(ctx context.Context, order *domain.Order) error {
+	ctx, span := r.startSpan(ctx, "Update", "UPDATE", "UPDATE orders WHERE id",
+		attribute.String("order_id", order.ID.String()),
+	)
+	err := r.repo.Update(ctx, order)
+	endSpan(span, 1, err)
+	return err
+}
+
+// UpdateStatus moves an order to a new status and returns the previous status
+func (r *TracedOrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus) (domain.OrderStatus, error) {
+	ctx, span := r.startSpan(ctx, "UpdateStatus", "UPDATE", "SELECT orders FOR UPDATE; UPDATE orders SET status",
+		attribute.String("order_id", id.String()),
+		attribute.String("order.status", string(status)),
+	)
+	previous, err := r.repo.UpdateStatus(ctx, id, status)
+	endSpan(span, 1, err)
+	return previous, err
+}
+
+// UpdateStatusForEvent applies the status updates triggered by an event in one transaction
+func (r *TracedOrderRepository) UpdateStatusForEvent(ctx context.Context, eventID, eventType string, id uuid.UUID, statuses ...domain.OrderStatus) ([]domain.StatusTransition, error) {
+	ctx, span := r.startSpan(ctx, "UpdateStatusForEvent", "UPDATE", "INSERT processed_events; SELECT orders FOR UPDATE; UPDATE orders SET status",
+		attribute.String("order_id", id.String()),
+		attribute.String("event_type", eventType),
+		attribute.Int("order.status_count", len(statuses)),
+	)
+	transitions, err := r.repo.UpdateStatusForEvent(ctx, eventID, eventType, id, statuses...)
+	if err == nil {
+		span.SetAttributes(attribute.Bool("event.duplicate", len(transitions) == 0 && len(statuses) > 0))
+	}
+	endSpan(span, len(transitions), err)
+	return transitions, err
+}
+
+// List retrieves orders based on filter criteria with pagination
+func (r *TracedOrderRepository) List(ctx context.Context, filter domain.OrderFilter) ([]*domain.Order, error) {
+	ctx, span := r.startSpan(ctx, "List", "SELECT", "SELECT orders WHERE "+filterSummary(filter)+" ORDER BY created_at LIMIT",
+		filterAttributes(filter)...,
+	)
+	orders, err := r.repo.List(ctx, filter)
+	endSpan(span, len(orders), err)
+	return orders, err
+}
+
+// Count returns the total number of orders matching the filter criteria
+func (r *TracedOrderRepository) Count(ctx context.Context, filter domain.OrderFilter) (int, error) {
+	ctx, span := r.startSpan(ctx, "Count", "SELECT", "SELECT COUNT orders WHERE "+filterSummary(filter),
+		filterAttributes(filter)...,
+	)
+	count, err := r.repo.Count(ctx, filter)
+	if err == nil {
+		span.SetAttributes(attribute.Int("db.count", count))
+	}
+	endSpan(span, 1, err)
+	return count, err
+}
+
+// UpdateTags replaces the tags and attributes of an order
+func (r *TracedOrderRepository) UpdateTags(ctx context.Context, id uuid.UUID, tags []string, attributes domain.OrderAttributes) error {
+	ctx, span := r.startSpan(ctx, "UpdateTags", "UPDATE", "UPDATE orders SET tags, attributes WHERE id",
+		attribute.String("order_id", id.String()),
+		attribute.Int("order.tag_count", len(tags)),
+		attribute.Int("order.attribute_count", len(attributes)),
+	)
+	err := r.repo.UpdateTags(ctx, id, tags, attributes)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete soft deletes an order
+func (r *TracedOrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.startSpan(ctx, "Delete", "UPDATE", "UPDATE orders SET deleted_at WHERE id",
+		attribute.String("order_id", id.String()),
+	)
+	err := r.repo.Delete(ctx, id)
+	endSpan(span, 1, err)
+	return err
+}
+
+// SaveSerialNumbers records the serial numbers allocated to an order
+func (r *TracedOrderRepository) SaveSerialNumbers(ctx context.Context, orderID uuid.UUID, serials []domain.SerialAllocation) error {
+	ctx, span := r.startSpan(ctx, "SaveSerialNumbers", "INSERT", "INSERT order_item_serials ON CONFLICT DO NOTHING",
+		attribute.String("order_id", orderID.String()),
+	)
+	err := r.repo.SaveSerialNumbers(ctx, orderID, serials)
+	endSpan(span, len(serials), err)
+	return err
+}
+
+// UpdateShipment records the progress of a shipment of a partially fulfilled order
+func (r *TracedOrderRepository) UpdateShipment(ctx context.Context, shipment *domain.Shipment) error {
+	ctx, span := r.startSpan(ctx, "UpdateShipment", "UPDATE", "UPDATE order_shipments",
+		attribute.String("order_id", shipment.OrderID.String()),
+	)
+	err := r.repo.UpdateShipment(ctx, shipment)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetOrderMetrics returns aggregated metrics for monitoring and analytics
+func (r *TracedOrderRepository) GetOrderMetrics(ctx context.Context) (*interfaces.OrderMetrics, error) {
+	ctx, span := r.startSpan(ctx, "GetOrderMetrics", "SELECT", "SELECT COUNT, SUM orders GROUP BY status")
+	metrics, err := r.repo.GetOrderMetrics(ctx)
+	rows := 0
+	if metrics != nil {
+		rows = len(metrics.OrdersByStatus)
+	}
+	endSpan(span, rows, err)
+	return metrics, err
+}
+
+// orderRows counts the rows loaded for an order: the order, its items and serial numbers
+func orderRows(order *domain.Order) int {
+	if order == nil {
+		return 0
+	}
+	return 1 + len(order.Items) + len(order.SerialNumbers)
+}
+
+// filterSummary names the conditions a filter applies, without their values
+func filterSummary(filter domain.OrderFilter) string {
+	summary := "deleted_at IS NULL"
+	if filter.UserID != nil {
+		summary += " AND user_id"
+	}
+	if filter.Status != nil {
+		summary += " AND status"
+	}
+	if len(filter.Tags) > 0 {
+		summary += " AND tags @>"
+	}
+	for range filter.Attributes {
+		summary += " AND attributes @>"
+	}
+	if filter.CreatedFrom != nil {
+		summary += " AND created_at >="
+	}
+	if filter.CreatedTo != nil {
+		summary += " AND created_at <"
+	}
+	if filter.After != nil {
+		summary += " AND (created_at, id) <"
+	}
+	return summary
+}
+
+// filterAttributes describes a filter for a span. The user ID is reduced to
+// whether one was given.
+func filterAttributes(filter domain.OrderFilter) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.Bool("db.filter.user", filter.UserID != nil),
+		attribute.Bool("db.filter.keyset", filter.After != nil),
+		attribute.Bool("db.filter.created_range", filter.CreatedFrom != nil || filter.CreatedTo != nil),
+		attribute.Int("db.limit", filter.Limit),
+		attribute.Int("db.offset", filter.Offset),
+	}
+	if filter.Status != nil {
+		attrs = append(attrs, attribute.String("db.filter.status", string(*filter.Status)))
+	}
+	if len(filter.Tags) > 0 {
+		attrs = append(attrs, attribute.StringSlice("db.filter.tags", filter.Tags))
+	}
+	if len(filter.Attributes) > 0 {
+		attrs = append(attrs, attribute.Int("db.filter.attribute_count", len(filter.Attributes)))
+	}
+	return attrs
+}
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+)
+
+const webhookColumns = `id, user_id, url, secret, description, statuses, active, created_at, updated_at`
+
+const webhookDeliveryColumns = `id, webhook_id, order_id, event_type, payload, status, attempts,
+	next_attempt_at, last_response_code, last_error, created_at, updated_at, delivered_at`
+
+// webhookRow maps the webhooks table, whose statuses are stored as a text array
+type webhookRow struct {
+	domain.Webhook
+	StatusList pq.StringArray `db:"statuses"`
+}
+
+func (row *webhookRow) toDomain() *domain.Webhook {
+	webhook := row.Webhook
+	webhook.Statuses = make([]domain.OrderStatus, len(row.StatusList))
+	for i, status := range row.StatusList {
+		webhook.Statuses[i] = domain.OrderStatus(status)
+	}
+	return &webhook
+}
+
+func statusArray(statuses []domain.OrderStatus) pq.StringArray {
+	array := make(pq.StringArray, len(statuses))
+	for i, status := range statuses {
+		array[i] = string(status)
+	}
+	return array
+}
+
+// WebhookRepository implements the WebhookRepository interface using PostgreSQL
+type WebhookRepository struct {
+	db *sqlx.DB
+}
+
+// NewWebhookRepository creates a new PostgreSQL webhook repository
+func NewWebhookRepository(db *sqlx.DB) interfaces.WebhookRepository {
+	return &WebhookRepository{
+		db: db,
+	}
+}
+
+// Create stores a new webhook
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	query := `
+		INSERT INTO webhooks (` + webhookColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		webhook.ID, webhook.UserID, webhook.URL, webhook.Secret, webhook.Description,
+		statusArray(webhook.Statuses), webhook.Active, webhook.CreatedAt, webhook.UpdatedAt)
+	if err != nil {
+		return platformError.Wrap(err, "failed to insert webhook")
+	}
+	return nil
+}
+
+// GetByID retrieves a webhook owned by the user
+func (r *WebhookRepository) GetByID(ctx context.Context, userID, id uuid.UUID) (*domain.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1 AND user_id = $2`
+
+	var row webhookRow
+	if err := r.db.GetContext(ctx, &row, query, id, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, platformError.NewNotFound("webhook not found")
+		}
+		return nil, platformError.Wrap(err, "failed to get webhook")
+	}
+	return row.toDomain(), nil
+}
+
+// GetForDelivery retrieves a webhook regardless of its owner, for the dispatcher
+func (r *WebhookRepository) GetForDelivery(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	var row webhookRow
+	if err := r.db.GetContext(ctx, &row, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, platformError.NewNotFound("webhook not found")
+		}
+		return nil, platformError.Wrap(err, "failed to get webhook")
+	}
+	return row.toDomain(), nil
+}
+
+// ListByUser retrieves all webhooks owned by the user
+func (r *WebhookRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE user_id = $1 ORDER BY created_at`
+
+	rows := []webhookRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, platformError.Wrap(err, "failed to list webhooks")
+	}
+
+	webhooks := make([]*domain.Webhook, len(rows))
+	for i := range rows {
+		webhooks[i] = rows[i].toDomain()
+	}
+	return webhooks, nil
+}
+
+// Update updates the URL, description, statuses and active flag of a webhook
+func (r *WebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET url = $3, description = $4, statuses = $5, active = $6, updated_at = $7
+		WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query,
+		webhook.ID, webhook.UserID, webhook.URL, webhook.Description,
+		statusArray(webhook.Statuses), webhook.Active, webhook.UpdatedAt)
+	if err != nil {
+		return platformError.Wrap(err, "failed to update webhook")
+	}
+	return requireRowAffected(result, "webhook not found")
+}
+
+// Delete removes a webhook owned by the user along with its delivery history
+func (r *WebhookRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return platformError.Wrap(err, "failed to delete webhook")
+	}
+	return requireRowAffected(result, "webhook not found")
+}
+
+// CreateDelivery stores a new delivery
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (` + webhookDeliveryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.ID, delivery.WebhookID, delivery.OrderID, delivery.EventType, delivery.Payload,
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastResponseCode,
+		delivery.LastError, delivery.CreatedAt, delivery.UpdatedAt, delivery.DeliveredAt)
+	if err != nil {
+		return platformError.Wrap(err, "failed to insert webhook delivery")
+	}
+	return nil
+}
+
+// ClaimDueDeliveries returns up to limit due pending deliveries and pushes their next attempt back
+// by lease. Rows locked by another dispatcher are skipped.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	now := time.Now()
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	deliveries := []*domain.WebhookDelivery{}
+	if err := r.db.SelectContext(ctx, &deliveries, query, now, now.Add(lease), limit); err != nil {
+		return nil, platformError.Wrap(err, "failed to claim webhook deliveries")
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery records the outcome of a delivery attempt
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_response_code = $5,
+			last_error = $6, updated_at = $7, delivered_at = $8
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt,
+		delivery.LastResponseCode, delivery.LastError, delivery.UpdatedAt, delivery.DeliveredAt)
+	if err != nil {
+		return platformError.Wrap(err, "failed to update webhook delivery")
+	}
+	return requireRowAffected(result, "webhook delivery not found")
+}
+
+// ListDeliveries returns the most recent deliveries of a webhook, newest first
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	deliveries := []*domain.WebhookDelivery{}
+	if err := r.db.SelectContext(ctx, &deliveries, query, webhookID, limit); err != nil {
+		return nil, platformError.Wrap(err, "failed to list webhook deliveries")
+	}
+	return deliveries, nil
+}
+
+// requireRowAffected turns an update or delete that matched nothing into a not found error
+func requireRowAffected(result sql.Result, notFound string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return platformError.Wrap(err, "failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return platformError.NewNotFound(notFound)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+)
+
+const rateLimitKeyPrefix = "order_rate:"
+
+// slidingWindowScript keeps the events of a key in a sorted set scored by their
+// time in milliseconds. Expired events are trimmed, and the new event is added
+// only while fewer than the limit (ARGV[2], 0 for none) remain. The Redis clock
+// is used so replicas with skewed clocks share the same window. It returns
+// {1, events in the window} when the event was added, or {0, milliseconds until
+// the oldest event expires}.
+var slidingWindowScript = redis.NewScript(`
+local now = redis.call('TIME')
+local nowMs = tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', nowMs - window)
+local count = redis.call('ZCARD', KEYS[1])
+if limit > 0 and count >= limit then
+	local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+	return {0, tonumber(oldest[2]) + window - nowMs}
+end
+
+redis.call('ZADD', KEYS[1], nowMs, ARGV[3])
+redis.call('PEXPIRE', KEYS[1], window)
+return {1, count + 1}
+`)
+
+// RateLimitStore implements the RateLimitStore interface with Redis sorted sets
+type RateLimitStore struct {
+	client *redis.Client
+}
+
+// NewRateLimitStore creates a new Redis rate limit store
+func NewRateLimitStore(client *redis.Client) interfaces.RateLimitStore {
+	return &RateLimitStore{
+		client: client,
+	}
+}
+
+// Hit records an event unless the limit is reached within the window
+func (s *RateLimitStore) Hit(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	added, value, err := s.run(ctx, key, limit, window)
+	if err != nil {
+		return false, 0, err
+	}
+	if !added {
+		return false, time.Duration(value) * time.Millisecond, nil
+	}
+	return true, 0, nil
+}
+
+// Count records an event and returns the events within the window
+func (s *RateLimitStore) Count(ctx context.Context, key string, window time.Duration) (int64, error) {
+	_, count, err := s.run(ctx, key, 0, window)
+	return count, err
+}
+
+// Reset forgets the events of key
+func (s *RateLimitStore) Reset(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, rateLimitKeyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to reset rate limit %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *RateLimitStore) run(ctx context.Context, key string, limit int, window time.Duration) (bool, int64, error) {
+	// Events recorded in the same millisecond need distinct members
+	result, err := slidingWindowScript.Run(ctx, s.client, []string{rateLimitKeyPrefix + key},
+		window.Milliseconds(), limit, uuid.NewString()).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to check rate limit %s: %w", key, err)
+	}
+	if len(result) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit result for %s: %v", key, result)
+	}
+	return result[0] == 1, result[1], nil
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// maxAddressLabelLength bounds the label of an address book entry
+const maxAddressLabelLength = 50
+
+// AddressUpdate holds the address book entry fields to change; nil fields are left as they are
+type AddressUpdate struct {
+	Label     *string
+	Address   *domain.Address
+	IsDefault *bool
+}
+
+// AddressService manages the address books of the users and resolves the address an
+// order ships to
+type AddressService struct {
+	repo   interfaces.AddressRepository
+	logger logging.Logger
+}
+
+// NewAddressService creates a new address service and attaches it to order creation
+func NewAddressService(repo interfaces.AddressRepository, orders *OrderService, logger logging.Logger) *AddressService {
+	s := &AddressService{
+		repo:   repo,
+		logger: logger,
+	}
+	orders.addresses = s
+	return s
+}
+
+// CreateAddress adds an address to the user's address book. The first address of a user
+// becomes the default.
+func (s *AddressService) CreateAddress(ctx context.Context, userID uuid.UUID, label string, address domain.Address, isDefault bool) (*domain.SavedAddress, error) {
+	if userID == uuid.Nil {
+		return nil, errors.NewValidation("user_id is required")
+	}
+
+	saved := domain.NewSavedAddress(userID, label, address, isDefault)
+	if err := validateSavedAddress(saved); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= domain.MaxAddressesPerUser {
+		return nil, errors.NewConflict(fmt.Sprintf("address book is full, at most %d addresses", domain.MaxAddressesPerUser))
+	}
+	if count == 0 {
+		saved.IsDefault = true
+	}
+
+	if err := s.repo.Create(ctx, saved); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Address created", map[string]interface{}{
+		"address_id": saved.ID,
+		"user_id":    userID,
+		"is_default": saved.IsDefault,
+	})
+	return saved, nil
+}
+
+// GetAddress retrieves an address of the user
+func (s *AddressService) GetAddress(ctx context.Context, userID, addressID uuid.UUID) (*domain.SavedAddress, error) {
+	return s.repo.GetByID(ctx, userID, addressID)
+}
+
+// ListAddresses retrieves the user's address book
+func (s *AddressService) ListAddresses(ctx context.Context, userID uuid.UUID) ([]*domain.SavedAddress, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// UpdateAddress applies the update to an address of the user. Orders already placed keep
+// the address they were placed with.
+func (s *AddressService) UpdateAddress(ctx context.Context, userID, addressID uuid.UUID, update AddressUpdate) (*domain.SavedAddress, error) {
+	saved, err := s.repo.GetByID(ctx, userID, addressID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Label != nil {
+		saved.Label = *update.Label
+	}
+	if update.Address != nil {
+		saved.Address = *update.Address
+	}
+	if update.IsDefault != nil {
+		// The default can be moved to another address but not unset
+		if saved.IsDefault && !*update.IsDefault {
+			return nil, errors.NewValidation("make another address the default instead")
+		}
+		saved.IsDefault = *update.IsDefault
+	}
+	if err := validateSavedAddress(saved); err != nil {
+		return nil, err
+	}
+
+	saved.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, saved); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// DeleteAddress removes an address of the user. Orders already placed keep their snapshot
+// of it; once the default is deleted, orders without an address do not ship until another
+// address is made the default.
+func (s *AddressService) DeleteAddress(ctx context.Context, userID, addressID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, userID, addressID); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "Address deleted", map[string]interface{}{
+		"address_id": addressID,
+		"user_id":    userID,
+	})
+	return nil
+}
+
+// resolve returns the address a new order ships to: the selected address book entry, the
+// inline address or else the user's default. It returns nil when the user has none, or
+// when the address book is not enabled.
+func (s *AddressService) resolve(ctx context.Context, req domain.CreateOrderRequest) (*domain.Address, error) {
+	if req.AddressID != nil && req.ShippingAddress != nil {
+		return nil, errors.NewValidation("address_id and shipping_address are mutually exclusive")
+	}
+
+	if req.ShippingAddress != nil {
+		address := *req.ShippingAddress
+		address.Normalize()
+		if err := address.Validate(); err != nil {
+			return nil, errors.NewValidation(err.Error())
+		}
+		return &address, nil
+	}
+
+	if s == nil {
+		if req.AddressID != nil {
+			return nil, errors.NewValidation("address book is not available")
+		}
+		return nil, nil
+	}
+
+	if req.AddressID != nil {
+		saved, err := s.repo.GetByID(ctx, req.UserID, *req.AddressID)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, errors.NewValidation("address " + req.AddressID.String() + " not found")
+			}
+			return nil, err
+		}
+		return &saved.Address, nil
+	}
+
+	saved, err := s.repo.GetDefault(ctx, req.UserID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &saved.Address, nil
+}
+
+// validateSavedAddress normalizes and validates an address book entry
+func validateSavedAddress(saved *domain.SavedAddress) error {
+	saved.Normalize()
+	saved.Label = strings.TrimSpace(saved.Label)
+	if err := saved.Address.Validate(); err != nil {
+		return errors.NewValidation(err.Error())
+	}
+	if utf8.RuneCountInString(saved.Label) > maxAddressLabelLength {
+		return errors.NewValidation(fmt.Sprintf("label exceeds %d characters", maxAddressLabelLength))
+	}
+	return nil
+}
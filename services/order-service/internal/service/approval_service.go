@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// ApprovalNotifier tells the approvers that an order waits for them
+type ApprovalNotifier interface {
+	PublishApprovalRequested(ctx context.Context, event ApprovalRequestedEvent) error
+}
+
+// ApprovalRequestedEvent is published when a high-value order is held for operator approval
+type ApprovalRequestedEvent struct {
+	OrderID          uuid.UUID `json:"order_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	TotalAmount      float64   `json:"total_amount"`
+	TotalAmountMinor int64     `json:"total_amount_minor"` // Exact total in the currency's minor unit
+	Currency         string    `json:"currency"`
+	ApproverIDs      []string  `json:"approver_ids"` // Operators to notify
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// ApprovalService holds orders above the configured amount until an operator approves
+// them. Approved orders continue to payment; rejected and expired ones are cancelled
+// and their inventory reservation released.
+type ApprovalService struct {
+	repo      interfaces.ApprovalRepository
+	orders    *OrderService
+	notifier  ApprovalNotifier
+	config    config.ApprovalConfig
+	approvers []string
+	logger    logging.Logger
+	metrics   metrics.Metrics
+	tracer    trace.Tracer
+}
+
+// NewApprovalService creates a new approval service and attaches it to the order saga
+func NewApprovalService(
+	repo interfaces.ApprovalRepository,
+	orders *OrderService,
+	notifier ApprovalNotifier,
+	cfg config.ApprovalConfig,
+	logger logging.Logger,
+	metrics metrics.Metrics,
+) *ApprovalService {
+	approvers := make([]string, 0, len(cfg.ApproverIDs))
+	for _, id := range cfg.ApproverIDs {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+		if _, err := uuid.Parse(id); err != nil {
+			logger.Warn(context.Background(), "Ignoring invalid order approver ID", map[string]interface{}{
+				"approver_id": id,
+			})
+			continue
+		}
+		approvers = append(approvers, id)
+	}
+
+	s := &ApprovalService{
+		repo:      repo,
+		orders:    orders,
+		notifier:  notifier,
+		config:    cfg,
+		approvers: approvers,
+		logger:    logger,
+		metrics:   metrics,
+		tracer:    otel.Tracer("order-service"),
+	}
+	orders.approvals = s
+	return s
+}
+
+// Required reports whether the order is above the approval threshold. The threshold is
+// read in the order's currency, so it is compared without any exchange rate.
+func (s *ApprovalService) Required(order *domain.Order) bool {
+	if s == nil {
+		return false
+	}
+	threshold, err := money.Parse(s.config.Threshold, order.Currency)
+	if err != nil {
+		return false
+	}
+	return order.TotalAmount.Minor > threshold.Minor
+}
+
+// ListPending returns up to limit orders waiting for approval, oldest first
+func (s *ApprovalService) ListPending(ctx context.Context, limit int) ([]*domain.OrderApproval, error) {
+	return s.repo.ListPending(ctx, limit)
+}
+
+// GetApproval returns the approval of an order
+func (s *ApprovalService) GetApproval(ctx context.Context, orderID uuid.UUID) (*domain.OrderApproval, error) {
+	return s.repo.GetByOrderID(ctx, orderID)
+}
+
+// Approve records the operator's approval and resumes the saga with payment
+func (s *ApprovalService) Approve(ctx context.Context, orderID uuid.UUID, req domain.ApprovalDecisionRequest) (*domain.Order, error) {
+	ctx, span := s.tracer.Start(ctx, "ApprovalService.Approve")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("order_id", orderID.String()),
+		attribute.String("operator_id", req.OperatorID.String()),
+	)
+
+	order, err := s.decide(ctx, orderID, domain.ApprovalApproved, req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Order approved, proceeding to payment", map[string]interface{}{
+		"order_id":    orderID,
+		"operator_id": req.OperatorID,
+	})
+	s.orders.recordSagaStep(ctx, orderID, domain.SagaStepApproval, domain.SagaStepSucceeded, 1, nil)
+
+	return s.orders.payOrder(ctx, order)
+}
+
+// Reject records the operator's rejection and cancels the order
+func (s *ApprovalService) Reject(ctx context.Context, orderID uuid.UUID, req domain.ApprovalDecisionRequest) (*domain.Order, error) {
+	ctx, span := s.tracer.Start(ctx, "ApprovalService.Reject")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("order_id", orderID.String()),
+		attribute.String("operator_id", req.OperatorID.String()),
+	)
+
+	if req.Reason == "" {
+		return nil, errors.NewValidation("reason is required to reject an order")
+	}
+
+	if _, err := s.decide(ctx, orderID, domain.ApprovalRejected, req); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	s.logger.Warn(ctx, "Order rejected by operator, cancelling", map[string]interface{}{
+		"order_id":    orderID,
+		"operator_id": req.OperatorID,
+		"reason":      req.Reason,
+	})
+	s.orders.recordSagaStep(ctx, orderID, domain.SagaStepApproval, domain.SagaStepFailed, 1, fmt.Errorf("rejected: %s", req.Reason))
+
+	if err := s.orders.cancelUnapprovedOrder(ctx, orderID); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return s.orders.repo.GetByID(ctx, orderID)
+}
+
+// Run cancels orders whose approval expired until the context is cancelled
+func (s *ApprovalService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.ExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.expire(ctx)
+		}
+	}
+}
+
+// hold parks a freshly created order until an operator decides on it. The inventory
+// reservation is kept, so approval does not race other orders for the stock.
+func (s *ApprovalService) hold(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	approval := domain.NewOrderApproval(order, s.config.TTL)
+	if err := s.repo.Create(ctx, approval); err != nil {
+		s.logger.Error(ctx, "Failed to request order approval", err, map[string]interface{}{
+			"order_id": order.ID,
+		})
+		// Without an approval on record the order could never be paid, so it is failed
+		s.orders.handlePaymentFailure(ctx, order.ID)
+		return nil, errors.Wrap(err, "failed to request order approval")
+	}
+
+	if err := s.orders.updateOrderStatus(ctx, order.ID, domain.StatusPendingApproval); err != nil {
+		return nil, errors.Wrap(err, "failed to hold order for approval")
+	}
+
+	s.orders.recordSagaStep(ctx, order.ID, domain.SagaStepApproval, domain.SagaStepWaiting, 1, nil)
+	s.metrics.IncrementCounter(ctx, "orders_held_for_approval_total", map[string]string{
+		"currency": order.Currency,
+	})
+	s.logger.Info(ctx, "Order held for operator approval", map[string]interface{}{
+		"order_id":     order.ID,
+		"total_amount": order.TotalAmount.String(),
+		"threshold":    s.config.Threshold,
+		"expires_at":   approval.ExpiresAt,
+	})
+
+	event := ApprovalRequestedEvent{
+		OrderID:          order.ID,
+		UserID:           order.UserID,
+		TotalAmount:      order.TotalAmount.Float64(),
+		TotalAmountMinor: order.TotalAmount.Minor,
+		Currency:         order.Currency,
+		ApproverIDs:      s.approvers,
+		ExpiresAt:        approval.ExpiresAt,
+	}
+	if err := s.notifier.PublishApprovalRequested(ctx, event); err != nil {
+		// The order is listed in the approval queue either way
+		s.logger.Error(ctx, "Failed to notify approvers", err, map[string]interface{}{
+			"order_id": order.ID,
+		})
+	}
+
+	updatedOrder, err := s.orders.repo.GetByID(ctx, order.ID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to retrieve order held for approval", err)
+		order.Status = domain.StatusPendingApproval
+		return order, nil
+	}
+	return updatedOrder, nil
+}
+
+// decide records an operator decision on an order that is still waiting for approval
+func (s *ApprovalService) decide(ctx context.Context, orderID uuid.UUID, status domain.ApprovalStatus, req domain.ApprovalDecisionRequest) (*domain.Order, error) {
+	if req.OperatorID == uuid.Nil {
+		return nil, errors.NewValidation("operator is required")
+	}
+
+	approval, err := s.repo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !approval.IsPending() {
+		return nil, errors.NewConflict(fmt.Sprintf("order approval is already %s", approval.Status))
+	}
+
+	order, err := s.orders.repo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.StatusPendingApproval {
+		return nil, errors.NewConflict(fmt.Sprintf("order is %s, not awaiting approval", order.Status))
+	}
+
+	approval.Decide(status, &req.OperatorID, req.Reason)
+	if err := s.repo.Decide(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncrementCounter(ctx, "order_approvals_decided_total", map[string]string{
+		"decision": string(status),
+	})
+	return order, nil
+}
+
+// expire cancels the orders of approvals nobody decided on in time
+func (s *ApprovalService) expire(ctx context.Context) {
+	approvals, err := s.repo.ListExpired(ctx, time.Now(), s.config.BatchSize)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list expired order approvals", err)
+		return
+	}
+
+	for _, approval := range approvals {
+		approval.Decide(domain.ApprovalExpired, nil, "approval expired")
+		if err := s.repo.Decide(ctx, approval); err != nil {
+			// Decided by an operator in the meantime
+			continue
+		}
+
+		s.metrics.IncrementCounter(ctx, "order_approvals_decided_total", map[string]string{
+			"decision": string(domain.ApprovalExpired),
+		})
+		s.logger.Warn(ctx, "Order approval expired, cancelling order", map[string]interface{}{
+			"order_id":   approval.OrderID,
+			"expires_at": approval.ExpiresAt,
+		})
+		s.orders.recordSagaStep(ctx, approval.OrderID, domain.SagaStepApproval, domain.SagaStepFailed, 1, fmt.Errorf("approval expired"))
+
+		if err := s.orders.cancelUnapprovedOrder(ctx, approval.OrderID); err != nil {
+			s.logger.Error(ctx, "Failed to cancel order with expired approval", err, map[string]interface{}{
+				"order_id": approval.OrderID,
+			})
+		}
+	}
+}
@@ -0,0 +1,540 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+)
+
+// SetFulfillmentPolicy sets the policy of orders that don't choose one
+func (s *OrderService) SetFulfillmentPolicy(policy domain.FulfillmentPolicy) {
+	s.fulfillmentPolicy = policy
+}
+
+// fulfillmentPolicyFor returns the policy a new order is placed under
+func (s *OrderService) fulfillmentPolicyFor(req domain.CreateOrderRequest) domain.FulfillmentPolicy {
+	if req.FulfillmentPolicy != "" {
+		return req.FulfillmentPolicy
+	}
+	if s.fulfillmentPolicy != "" {
+		return s.fulfillmentPolicy
+	}
+	return domain.FulfillmentAllOrNothing
+}
+
+// reservedItems returns the items reserved when the order is placed: the first
+// shipment of a split order, every item otherwise
+func reservedItems(order *domain.Order, req domain.CreateOrderRequest) []domain.CreateOrderItemRequest {
+	if shipment := order.ShipmentAwaitingPayment(); shipment != nil {
+		return shipment.ItemRequests()
+	}
+	return req.Items
+}
+
+// markShipmentPaid records the payment of the shipment awaiting it, if the order is split
+func (s *OrderService) markShipmentPaid(ctx context.Context, order *domain.Order, shipment *domain.Shipment, paymentResult *PaymentResult) {
+	if shipment == nil {
+		return
+	}
+
+	now := time.Now()
+	shipment.Status = domain.ShipmentPaid
+	shipment.TransactionID = paymentResult.TransactionID
+	shipment.PaidAt = &now
+	shipment.UpdatedAt = now
+	if err := s.repo.UpdateShipment(ctx, shipment); err != nil {
+		s.logger.Error(ctx, "Failed to record shipment payment", err, map[string]interface{}{
+			"order_id":    order.ID,
+			"shipment_id": shipment.ID,
+		})
+	}
+	s.cache.Invalidate(order.ID)
+}
+
+// assembleShipment records the assembly of one shipment of a split order and returns
+// the order statuses it leads to: partially assembled while other shipments are
+// open, assembled and completed once none is. done reports that the order status
+// is left as it is, e.g. for a shipment already recorded as assembled.
+func (s *OrderService) assembleShipment(ctx context.Context, orderID, shipmentID uuid.UUID) (statuses []domain.OrderStatus, done bool, err error) {
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	shipment := order.Shipment(shipmentID)
+	if shipment == nil {
+		s.logger.Warn(ctx, "Ignoring assembly of unknown shipment", map[string]interface{}{
+			"order_id":    orderID,
+			"shipment_id": shipmentID,
+		})
+		return nil, true, nil
+	}
+	if shipment.Status == domain.ShipmentAssembled {
+		s.metrics.IncrementCounter(ctx, "order_events_duplicate_total", map[string]string{
+			"event_type": "assembly.completed",
+		})
+		return nil, true, nil
+	}
+
+	now := time.Now()
+	shipment.Status = domain.ShipmentAssembled
+	shipment.AssembledAt = &now
+	shipment.UpdatedAt = now
+	if err := s.repo.UpdateShipment(ctx, shipment); err != nil {
+		return nil, false, err
+	}
+	s.cache.Invalidate(orderID)
+
+	s.logger.Info(ctx, "Shipment assembled", map[string]interface{}{
+		"order_id":       orderID,
+		"shipment_id":    shipmentID,
+		"sequence":       shipment.Sequence,
+		"open_shipments": order.OpenShipments(),
+	})
+
+	if order.OpenShipments() == 0 {
+		return []domain.OrderStatus{domain.StatusAssembled, domain.StatusCompleted}, false, nil
+	}
+	if order.Status == domain.StatusPartiallyAssembled {
+		return nil, true, nil
+	}
+	return []domain.OrderStatus{domain.StatusPartiallyAssembled}, false, nil
+}
+
+// BackorderNotifier tells the customer that items of their order are backordered and,
+// later, that they arrived
+type BackorderNotifier interface {
+	PublishOrderBackordered(ctx context.Context, event BackorderEvent) error
+	PublishBackorderAvailable(ctx context.Context, event BackorderEvent) error
+}
+
+// BackorderEvent describes the backordered items of an order: all of them, or those of
+// its backordered shipment when ShipmentID is set
+type BackorderEvent struct {
+	OrderID     uuid.UUID         `json:"order_id"`
+	UserID      uuid.UUID         `json:"user_id"`
+	ShipmentID  *uuid.UUID        `json:"shipment_id,omitempty"`
+	Items       []BackorderedItem `json:"items"`
+	Amount      float64           `json:"amount"`
+	AmountMinor int64             `json:"amount_minor"` // Exact amount in the currency's minor unit
+	Currency    string            `json:"currency"`
+}
+
+// BackorderedItem is a quantity of an order item waiting for stock
+type BackorderedItem struct {
+	ItemID   string `json:"item_id"`
+	ItemName string `json:"item_name"`
+	Quantity int    `json:"quantity"`
+}
+
+// BackorderReservation tells that inventory reserved the stock of a backorder
+type BackorderReservation struct {
+	OrderID       uuid.UUID
+	ShipmentID    uuid.UUID // uuid.Nil when the whole order was backordered
+	ReservationID string
+	ReservedAt    time.Time
+}
+
+// SetBackorderNotifier enables customer notifications about backorders
+func (s *OrderService) SetBackorderNotifier(notifier BackorderNotifier) {
+	s.backorderNotifier = notifier
+}
+
+// createBackorderedOrder saves an order waiting for all its items and has inventory
+// reserve them once they arrive; HandleBackorderReserved then resumes the saga
+func (s *OrderService) createBackorderedOrder(ctx context.Context, order *domain.Order, req domain.CreateOrderRequest) (*domain.Order, error) {
+	span := trace.SpanFromContext(ctx)
+
+	if err := s.repo.Create(ctx, order); err != nil {
+		span.RecordError(err)
+		s.logger.Error(ctx, "Failed to create order in database", err)
+		return nil, errors.Wrap(err, "failed to create order")
+	}
+	s.publishOrderCreated(ctx, order)
+
+	// Without the inventory backorder the order waits for FulfillBackorder
+	s.requestBackorder(ctx, order.ID, uuid.Nil, req.Items, req.Expedited)
+	s.notifyBackorder(ctx, order, nil, false)
+
+	s.metrics.IncrementCounter(ctx, "order_backorders_total", map[string]string{
+		"outcome": "created",
+	})
+	s.logger.Info(ctx, "Order backordered", map[string]interface{}{
+		"order_id":     order.ID,
+		"user_id":      order.UserID,
+		"total_amount": order.TotalAmount.String(),
+	})
+
+	return order, nil
+}
+
+// requestBackorder has inventory reserve backordered items once they are in stock.
+// Failures are logged only: the backorder can still be fulfilled manually.
+func (s *OrderService) requestBackorder(ctx context.Context, orderID, shipmentID uuid.UUID, items []domain.CreateOrderItemRequest, expedited bool) {
+	if err := s.externalServices.InventoryClient.CreateBackorder(ctx, orderID, shipmentID, items, expedited); err != nil {
+		s.metrics.IncrementCounter(ctx, "order_backorder_requests_failed_total", nil)
+		s.logger.Error(ctx, "Failed to create inventory backorder", err, map[string]interface{}{
+			"order_id":    orderID,
+			"shipment_id": shipmentID,
+		})
+	}
+}
+
+// cancelInventoryBackorder gives up the inventory backorder of an order or shipment,
+// releasing its stock if inventory already reserved it
+func (s *OrderService) cancelInventoryBackorder(ctx context.Context, orderID, shipmentID uuid.UUID) {
+	err := s.externalServices.InventoryClient.CancelBackorder(ctx, orderID, shipmentID)
+	if err != nil && !errors.IsNotFound(err) {
+		s.logger.Error(ctx, "Failed to cancel inventory backorder", err, map[string]interface{}{
+			"order_id":    orderID,
+			"shipment_id": shipmentID,
+		})
+	}
+}
+
+// requestShipmentBackorder is the transition hook handing the backordered shipment of
+// a split order to inventory once its first shipment is paid, so the backorder is
+// never paid before the rest of the order
+func (s *OrderService) requestShipmentBackorder(ctx context.Context, orderID uuid.UUID, transition domain.StatusTransition) {
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to load order for backorder", err, map[string]interface{}{
+			"order_id": orderID,
+		})
+		return
+	}
+	if shipment := order.Backorder(); shipment != nil {
+		s.requestBackorder(ctx, orderID, shipment.ID, shipment.ItemRequests(), false)
+	}
+}
+
+// cancelBackordersOfOrder is the transition hook giving up the inventory backorders of
+// a cancelled or failed order
+func (s *OrderService) cancelBackordersOfOrder(ctx context.Context, orderID uuid.UUID, transition domain.StatusTransition) {
+	if transition.From == domain.StatusBackordered {
+		s.cancelInventoryBackorder(ctx, orderID, uuid.Nil)
+		return
+	}
+
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to load order for backorder cancellation", err, map[string]interface{}{
+			"order_id": orderID,
+		})
+		return
+	}
+	if shipment := order.Backorder(); shipment != nil {
+		s.cancelInventoryBackorder(ctx, orderID, shipment.ID)
+	}
+}
+
+// HandleBackorderReserved resumes a backordered order once inventory reserved its
+// stock: a whole order continues to approval or payment, a backordered shipment is
+// paid and handed to assembly. Redelivered events find the order or shipment past
+// backordered and are skipped.
+func (s *OrderService) HandleBackorderReserved(ctx context.Context, reservation BackorderReservation) error {
+	ctx, span := s.tracer.Start(ctx, "OrderService.HandleBackorderReserved")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("order_id", reservation.OrderID.String()),
+		attribute.String("shipment_id", reservation.ShipmentID.String()),
+	)
+
+	order, err := s.repo.GetByID(ctx, reservation.OrderID)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if reservation.ShipmentID == uuid.Nil {
+		return s.resumeBackorderedOrder(ctx, order)
+	}
+
+	shipment := order.Shipment(reservation.ShipmentID)
+	if shipment == nil || shipment.Status != domain.ShipmentBackordered {
+		s.metrics.IncrementCounter(ctx, "order_events_duplicate_total", map[string]string{
+			"event_type": "inventory.backorder.reserved",
+		})
+		return nil
+	}
+	switch order.Status {
+	case domain.StatusPaid, domain.StatusPartiallyAssembled:
+	default:
+		s.logger.Warn(ctx, "Ignoring backorder reservation of unpaid order", map[string]interface{}{
+			"order_id":    order.ID,
+			"shipment_id": shipment.ID,
+			"status":      order.Status,
+		})
+		return nil
+	}
+
+	s.notifyBackorder(ctx, order, shipment, true)
+	if _, err := s.payBackorder(ctx, order, shipment); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// resumeBackorderedOrder continues the saga of a whole backordered order whose stock
+// inventory reserved under the order ID
+func (s *OrderService) resumeBackorderedOrder(ctx context.Context, order *domain.Order) error {
+	if order.Status != domain.StatusBackordered {
+		s.metrics.IncrementCounter(ctx, "order_events_duplicate_total", map[string]string{
+			"event_type": "inventory.backorder.reserved",
+		})
+		return nil
+	}
+
+	if err := s.updateOrderStatus(ctx, order.ID, domain.StatusPending); err != nil {
+		return err
+	}
+	order.Status = domain.StatusPending
+	s.notifyBackorder(ctx, order, nil, true)
+
+	s.metrics.IncrementCounter(ctx, "order_backorders_total", map[string]string{
+		"outcome": "reserved",
+	})
+	s.logger.Info(ctx, "Backordered order resumed", map[string]interface{}{
+		"order_id": order.ID,
+	})
+
+	if s.approvals.Required(order) {
+		_, err := s.approvals.hold(ctx, order)
+		return err
+	}
+	_, err := s.payOrder(ctx, order)
+	return err
+}
+
+// FulfillBackorder reserves, pays and hands to assembly the backordered items of an
+// order once they are back in stock, without waiting for inventory to reserve them.
+// The backorder stays open if they are not. If the payment of a partially fulfilled
+// order's backorder fails, it stays open for another manual attempt only.
+func (s *OrderService) FulfillBackorder(ctx context.Context, orderID uuid.UUID) (*domain.Order, error) {
+	ctx, span := s.tracer.Start(ctx, "OrderService.FulfillBackorder")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("order_id", orderID.String()))
+
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	var items []domain.CreateOrderItemRequest
+	shipment := order.Backorder()
+	switch {
+	case order.Status == domain.StatusBackordered:
+		for _, item := range order.Items {
+			items = append(items, domain.CreateOrderItemRequest{ItemID: item.ItemID, Quantity: item.Quantity})
+		}
+	case shipment == nil:
+		return nil, errors.NewConflict("order has no backordered items")
+	case order.Status == domain.StatusPaid, order.Status == domain.StatusPartiallyAssembled:
+		items = shipment.ItemRequests()
+	default:
+		return nil, errors.NewConflict(fmt.Sprintf("backorders of %s orders cannot be fulfilled", order.Status))
+	}
+
+	inventoryItems, err := s.externalServices.InventoryClient.CheckAvailability(ctx, items)
+	if err != nil {
+		span.RecordError(err)
+		return nil, errors.Wrap(err, "failed to check inventory availability")
+	}
+	available := make(map[string]int, len(inventoryItems))
+	for _, item := range inventoryItems {
+		available[item.ID] = item.Available
+	}
+	for _, item := range items {
+		if available[item.ItemID] < item.Quantity {
+			return nil, &errors.AppError{
+				Type: errors.ErrorTypeValidation,
+				Message: fmt.Sprintf("item %s (requested: %d, available: %d)",
+					item.ItemID, item.Quantity, available[item.ItemID]),
+				Err: ErrInsufficientInventory,
+			}
+		}
+	}
+
+	// A whole order is reserved under its ID, a backorder under its shipment ID. The
+	// inventory backorder would otherwise reserve the same items again.
+	reservationID, shipmentID := orderID, uuid.Nil
+	if order.Status != domain.StatusBackordered {
+		reservationID, shipmentID = shipment.ID, shipment.ID
+	}
+	s.cancelInventoryBackorder(ctx, orderID, shipmentID)
+
+	if err := s.externalServices.InventoryClient.ReserveItems(ctx, reservationID, items, false); err != nil {
+		span.RecordError(err)
+		return nil, errors.Wrap(err, "failed to reserve inventory items")
+	}
+
+	if order.Status == domain.StatusBackordered {
+		if err := s.resumeBackorderedOrder(ctx, order); err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		return s.repo.GetByID(ctx, orderID)
+	}
+
+	if _, err := s.payBackorder(ctx, order, shipment); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, orderID)
+}
+
+// payBackorder pays the backordered shipment of a partially fulfilled order, whose
+// stock is reserved under the shipment ID, and hands it to assembly. The reservation
+// is released and the shipment stays backordered if the payment fails.
+func (s *OrderService) payBackorder(ctx context.Context, order *domain.Order, shipment *domain.Shipment) (*PaymentResult, error) {
+	// The backorder becomes the shipment awaiting payment
+	shipment.Status = domain.ShipmentPending
+
+	paymentResult, err := s.processPaymentWithRetry(ctx, order)
+	if err == nil && paymentResult.PendingReview {
+		err = fmt.Errorf("%w: backorder payment held for review", ErrPaymentDeclined)
+	}
+	if err != nil {
+		shipment.Status = domain.ShipmentBackordered
+		s.releaseReservation(ctx, shipment.ID)
+		s.metrics.IncrementCounter(ctx, "order_backorders_total", map[string]string{
+			"outcome": "payment_failed",
+		})
+		return nil, errors.Wrap(err, "backorder payment failed")
+	}
+
+	s.confirmInventoryReservation(ctx, order)
+	if err := s.publishPaymentEvent(ctx, order, paymentResult); err != nil {
+		s.logger.Error(ctx, "Failed to publish backorder payment event", err)
+	}
+	s.markShipmentPaid(ctx, order, shipment, paymentResult)
+
+	s.metrics.IncrementCounter(ctx, "order_backorders_total", map[string]string{
+		"outcome": "fulfilled",
+	})
+	s.logger.Info(ctx, "Backorder fulfilled", map[string]interface{}{
+		"order_id":       order.ID,
+		"shipment_id":    shipment.ID,
+		"amount":         shipment.Amount.String(),
+		"transaction_id": paymentResult.TransactionID,
+	})
+
+	return paymentResult, nil
+}
+
+// CancelBackorder gives up the backordered items of an order; they were never
+// charged. A whole backordered order is cancelled; a partially fulfilled order whose
+// other shipments are all assembled is completed.
+func (s *OrderService) CancelBackorder(ctx context.Context, orderID uuid.UUID) (*domain.Order, error) {
+	ctx, span := s.tracer.Start(ctx, "OrderService.CancelBackorder")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("order_id", orderID.String()))
+
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// The cancellation hook gives up the inventory backorder
+	if order.Status == domain.StatusBackordered {
+		if err := s.updateOrderStatus(ctx, orderID, domain.StatusCancelled); err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		s.metrics.IncrementCounter(ctx, "order_backorders_total", map[string]string{
+			"outcome": "cancelled",
+		})
+		return s.repo.GetByID(ctx, orderID)
+	}
+
+	shipment := order.Backorder()
+	if shipment == nil {
+		return nil, errors.NewConflict("order has no backordered items")
+	}
+
+	now := time.Now()
+	shipment.Status = domain.ShipmentCancelled
+	shipment.UpdatedAt = now
+	if err := s.repo.UpdateShipment(ctx, shipment); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	s.cache.Invalidate(orderID)
+	s.cancelInventoryBackorder(ctx, orderID, shipment.ID)
+
+	s.metrics.IncrementCounter(ctx, "order_backorders_total", map[string]string{
+		"outcome": "cancelled",
+	})
+	s.logger.Info(ctx, "Backorder cancelled", map[string]interface{}{
+		"order_id":    orderID,
+		"shipment_id": shipment.ID,
+		"amount":      shipment.Amount.String(),
+	})
+
+	if order.Status == domain.StatusPartiallyAssembled && order.OpenShipments() == 0 {
+		for _, status := range []domain.OrderStatus{domain.StatusAssembled, domain.StatusCompleted} {
+			if err := s.updateOrderStatus(ctx, orderID, status); err != nil {
+				span.RecordError(err)
+				return nil, err
+			}
+		}
+	}
+
+	return s.repo.GetByID(ctx, orderID)
+}
+
+// notifyBackorder tells the customer that items of their order are backordered or,
+// with available set, that they arrived. Failures are logged only.
+func (s *OrderService) notifyBackorder(ctx context.Context, order *domain.Order, shipment *domain.Shipment, available bool) {
+	if s.backorderNotifier == nil {
+		return
+	}
+
+	event := BackorderEvent{
+		OrderID:     order.ID,
+		UserID:      order.UserID,
+		Amount:      order.TotalAmount.Float64(),
+		AmountMinor: order.TotalAmount.Minor,
+		Currency:    order.Currency,
+	}
+	names := make(map[string]string, len(order.Items))
+	for _, item := range order.Items {
+		names[item.ItemID] = item.ItemName
+	}
+	if shipment != nil {
+		event.ShipmentID = &shipment.ID
+		event.Amount = shipment.Amount.Float64()
+		event.AmountMinor = shipment.Amount.Minor
+		for _, item := range shipment.Items {
+			event.Items = append(event.Items, BackorderedItem{ItemID: item.ItemID, ItemName: names[item.ItemID], Quantity: item.Quantity})
+		}
+	} else {
+		for _, item := range order.Items {
+			event.Items = append(event.Items, BackorderedItem{ItemID: item.ItemID, ItemName: item.ItemName, Quantity: item.Quantity})
+		}
+	}
+
+	publish := s.backorderNotifier.PublishOrderBackordered
+	if available {
+		publish = s.backorderNotifier.PublishBackorderAvailable
+	}
+	if err := publish(ctx, event); err != nil {
+		s.logger.Error(ctx, "Failed to notify customer of backorder", err, map[string]interface{}{
+			"order_id":  order.ID,
+			"available": available,
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/platform/concurrency"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// OrderBatchService creates orders in bulk for customers placing many orders at once.
+// The orders of a batch are independent: each runs the whole order saga through
+// CreateOrder on a bounded worker pool and succeeds or fails on its own.
+type OrderBatchService struct {
+	orders  *OrderService
+	repo    interfaces.OrderBatchRepository
+	config  config.BatchConfig
+	logger  logging.Logger
+	metrics metrics.Metrics
+
+	mu       sync.Mutex
+	closed   bool // Set on shutdown; no new asynchronous batches are accepted
+	inFlight sync.WaitGroup
+}
+
+// NewOrderBatchService creates a new order batch service
+func NewOrderBatchService(orders *OrderService, repo interfaces.OrderBatchRepository, cfg config.BatchConfig, logger logging.Logger, metrics metrics.Metrics) *OrderBatchService {
+	return &OrderBatchService{
+		orders:  orders,
+		repo:    repo,
+		config:  cfg,
+		logger:  logger,
+		metrics: metrics,
+	}
+}
+
+// Process creates the orders of a batch and returns their results in request order
+func (s *OrderBatchService) Process(ctx context.Context, reqs []domain.CreateOrderRequest) ([]domain.BatchOrderResult, error) {
+	if err := s.validateBatch(reqs); err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.BatchOrderResult, len(reqs))
+	s.run(ctx, reqs, func(result domain.BatchOrderResult) {
+		results[result.Index] = result
+	})
+
+	s.logger.Info(ctx, "Order batch processed", map[string]interface{}{
+		"orders":   len(reqs),
+		"outcomes": domain.CountOutcomes(results),
+	})
+	return results, nil
+}
+
+// Submit stores a batch and creates its orders in the background. Progress is read
+// with GetBatch. Batches still running at shutdown are finished before the service
+// stops, so an order is never abandoned half way through the saga.
+func (s *OrderBatchService) Submit(ctx context.Context, reqs []domain.CreateOrderRequest) (*domain.OrderBatch, error) {
+	if err := s.validateBatch(reqs); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errors.NewUnavailable("order service is shutting down, retry the batch later")
+	}
+
+	batch := domain.NewOrderBatch(len(reqs))
+	if err := s.repo.Create(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	s.inFlight.Add(1)
+	go s.processBatch(context.WithoutCancel(ctx), batch.ID, reqs)
+
+	s.logger.Info(ctx, "Order batch submitted", map[string]interface{}{
+		"batch_id": batch.ID,
+		"orders":   len(reqs),
+	})
+	return batch, nil
+}
+
+// GetBatch returns an asynchronous batch with the results recorded so far
+func (s *OrderBatchService) GetBatch(ctx context.Context, id uuid.UUID) (*domain.OrderBatch, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// Run waits for shutdown, then for the asynchronous batches still being processed
+func (s *OrderBatchService) Run(ctx context.Context) error {
+	<-ctx.Done()
+
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.inFlight.Wait()
+	return nil
+}
+
+// processBatch creates the orders of a submitted batch, recording each result as the
+// order finishes
+func (s *OrderBatchService) processBatch(ctx context.Context, batchID uuid.UUID, reqs []domain.CreateOrderRequest) {
+	defer s.inFlight.Done()
+
+	s.run(ctx, reqs, func(result domain.BatchOrderResult) {
+		if err := s.repo.AddResult(ctx, batchID, result); err != nil {
+			s.logger.Error(ctx, "Failed to record order batch result", err, map[string]interface{}{
+				"batch_id": batchID,
+				"index":    result.Index,
+				"order_id": result.OrderID,
+			})
+		}
+	})
+
+	if err := s.repo.Complete(ctx, batchID); err != nil {
+		s.logger.Error(ctx, "Failed to complete order batch", err, map[string]interface{}{
+			"batch_id": batchID,
+		})
+		return
+	}
+	s.logger.Info(ctx, "Order batch completed", map[string]interface{}{
+		"batch_id": batchID,
+		"orders":   len(reqs),
+	})
+}
+
+// run creates the orders on at most config.Workers goroutines. record is called
+// concurrently, once per order.
+func (s *OrderBatchService) run(ctx context.Context, reqs []domain.CreateOrderRequest, record func(domain.BatchOrderResult)) {
+	indexes := make([]int, len(reqs))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	// Failed orders are recorded rather than returned, so only panics come back
+	if err := concurrency.ForAll(ctx, indexes, s.config.Workers, func(ctx context.Context, i int) error {
+		record(s.createOrder(ctx, i, reqs[i]))
+		return nil
+	}); err != nil {
+		s.logger.Error(ctx, "Order batch worker panicked", err)
+	}
+}
+
+// createOrder creates one order of a batch and classifies the outcome
+func (s *OrderBatchService) createOrder(ctx context.Context, index int, req domain.CreateOrderRequest) domain.BatchOrderResult {
+	result := domain.BatchOrderResult{Index: index}
+
+	order, err := s.orders.CreateOrder(ctx, req)
+	switch {
+	case err == nil:
+		result.Outcome = domain.BatchOrderAccepted
+		result.OrderID = &order.ID
+		result.OrderStatus = order.Status
+	case stdErrors.Is(err, ErrInsufficientInventory):
+		result.Outcome = domain.BatchOrderInventoryShortage
+	case errors.IsValidation(err):
+		result.Outcome = domain.BatchOrderValidationError
+	default:
+		result.Outcome = domain.BatchOrderFailed
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	s.metrics.IncrementCounter(ctx, "order_batch_orders_total", map[string]string{
+		"outcome": string(result.Outcome),
+	})
+	return result
+}
+
+func (s *OrderBatchService) validateBatch(reqs []domain.CreateOrderRequest) error {
+	if len(reqs) == 0 {
+		return errors.NewValidation("a batch needs at least one order")
+	}
+	if len(reqs) > s.config.MaxOrders {
+		return errors.NewValidation(fmt.Sprintf("a batch can hold at most %d orders", s.config.MaxOrders))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// OrderCache is a short-lived in-process cache for single order lookups.
+// It absorbs status polling on GET /orders/{id}; entries are invalidated on
+// every local status change and on assembly events from Kafka, and the TTL
+// bounds staleness for changes made by other replicas.
+type OrderCache struct {
+	mu         sync.RWMutex
+	entries    map[uuid.UUID]orderCacheEntry
+	ttl        time.Duration
+	maxEntries int
+}
+
+type orderCacheEntry struct {
+	order     *domain.Order
+	expiresAt time.Time
+}
+
+// NewOrderCache creates a new order cache
+func NewOrderCache(ttl time.Duration, maxEntries int) *OrderCache {
+	return &OrderCache{
+		entries:    make(map[uuid.UUID]orderCacheEntry),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+	}
+}
+
+// Get returns a copy of a cached order if present and not expired
+func (c *OrderCache) Get(id uuid.UUID) (*domain.Order, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	entry, exists := c.entries[id]
+	c.mu.RUnlock()
+
+	if !exists || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.order.Clone(), true
+}
+
+// Set stores a copy of an order in the cache, so changes the caller makes to
+// the order afterwards don't leak into it
+func (c *OrderCache) Set(order *domain.Order) {
+	if c == nil || order == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		for id, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+		// Still full of live entries: skip caching rather than evicting hot orders
+		if len(c.entries) >= c.maxEntries {
+			return
+		}
+	}
+
+	c.entries[order.ID] = orderCacheEntry{
+		order:     order.Clone(),
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// Invalidate removes an order from the cache
+func (c *OrderCache) Invalidate(id uuid.UUID) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	delete(c.entries, id)
+	c.mu.Unlock()
+}
+
+// Len returns the number of cached entries, including expired ones not yet purged
+func (c *OrderCache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/i18n"
+)
+
+// OrderEventPublisher publishes the order lifecycle to the order events topic, read by
+// notification-service and the reporting projection
+type OrderEventPublisher interface {
+	PublishOrderCreated(ctx context.Context, order *domain.Order) error
+	PublishOrderStatusChanged(ctx context.Context, event OrderStatusChangedEvent) error
+	PublishOrderTagsChanged(ctx context.Context, order *domain.Order) error
+}
+
+// OrderStatusChangedEvent is published whenever an order moves between statuses
+type OrderStatusChangedEvent struct {
+	OrderID    uuid.UUID          `json:"order_id"`
+	FromStatus domain.OrderStatus `json:"from_status"`
+	Status     domain.OrderStatus `json:"status"`
+	ChangedAt  time.Time          `json:"changed_at"`
+	Locale     string             `json:"locale"`            // Of the customer, for the status message
+	Sandbox    bool               `json:"sandbox,omitempty"` // Test order of a sandbox tenant
+}
+
+// SetEventPublisher publishes order creations and every status change from now on
+func (s *OrderService) SetEventPublisher(publisher OrderEventPublisher) {
+	s.events = publisher
+	for _, status := range domain.OrderStatuses {
+		s.OnTransition(status, s.publishStatusChanged)
+	}
+}
+
+// publishOrderCreated announces a saved order. Like transition hooks it must not fail
+// the order, so a failed publish is only logged.
+func (s *OrderService) publishOrderCreated(ctx context.Context, order *domain.Order) {
+	if s.events == nil {
+		return
+	}
+	if err := s.events.PublishOrderCreated(ctx, order); err != nil {
+		s.logger.Error(ctx, "Failed to publish order created event", err, map[string]interface{}{
+			"order_id": order.ID,
+		})
+	}
+}
+
+// publishStatusChanged is the transition hook announcing a status change
+func (s *OrderService) publishStatusChanged(ctx context.Context, orderID uuid.UUID, transition domain.StatusTransition) {
+	event := OrderStatusChangedEvent{
+		OrderID:    orderID,
+		FromStatus: transition.From,
+		Status:     transition.To,
+		ChangedAt:  time.Now().UTC(),
+		Locale:     i18n.FromContext(ctx),
+	}
+	// The order keeps the customer's locale; the caller may be someone else, or
+	// no one at all for background transitions
+	if order, err := s.GetOrder(ctx, orderID); err == nil {
+		if order.Locale != "" {
+			event.Locale = order.Locale
+		}
+		event.Sandbox = order.Sandbox
+	}
+	if err := s.events.PublishOrderStatusChanged(ctx, event); err != nil {
+		s.logger.Error(ctx, "Failed to publish order status changed event", err, map[string]interface{}{
+			"order_id": orderID,
+			"status":   transition.To,
+		})
+	}
+}
@@ -0,0 +1,413 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/jobs"
+	"github.com/amiosamu/rocket-science/shared/platform/objectstore"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// OrderExportJobType is the job type of background order exports
+const OrderExportJobType = "order_export"
+
+// OrderExportFilesPath is the API path signed export downloads are served under
+const OrderExportFilesPath = "/api/v1/orders/exports/files/"
+
+// exportColumn is a column account managers can select for an export
+type exportColumn struct {
+	name    string
+	numeric bool
+	value   func(order *domain.Order) string
+}
+
+// orderExportColumns are the selectable columns, in the order of the default selection
+var orderExportColumns = []exportColumn{
+	{name: "id", value: func(o *domain.Order) string { return o.ID.String() }},
+	{name: "user_id", value: func(o *domain.Order) string { return o.UserID.String() }},
+	{name: "status", value: func(o *domain.Order) string { return string(o.Status) }},
+	{name: "total_amount", numeric: true, value: func(o *domain.Order) string { return o.TotalAmount.Amount() }},
+	{name: "currency", value: func(o *domain.Order) string { return o.Currency }},
+	{name: "item_count", numeric: true, value: func(o *domain.Order) string { return strconv.Itoa(itemCount(o)) }},
+	{name: "created_at", value: func(o *domain.Order) string { return formatExportTime(&o.CreatedAt) }},
+	{name: "paid_at", value: func(o *domain.Order) string { return formatExportTime(o.PaidAt) }},
+	{name: "completed_at", value: func(o *domain.Order) string { return formatExportTime(o.CompletedAt) }},
+	{name: "items", value: exportItems},
+	{name: "tags", value: func(o *domain.Order) string { return strings.Join(o.Tags, ";") }},
+	{name: "assembled_at", value: func(o *domain.Order) string { return formatExportTime(o.AssembledAt) }},
+	{name: "updated_at", value: func(o *domain.Order) string { return formatExportTime(&o.UpdatedAt) }},
+	{name: "shipping_country", value: func(o *domain.Order) string {
+		if o.ShippingAddress == nil {
+			return ""
+		}
+		return o.ShippingAddress.Country
+	}},
+	{name: "shipping_city", value: func(o *domain.Order) string {
+		if o.ShippingAddress == nil {
+			return ""
+		}
+		return o.ShippingAddress.City
+	}},
+}
+
+// defaultExportColumns is the number of leading columns exported when none are selected
+const defaultExportColumns = 9
+
+// orderExportPayload is the payload of an order export job
+type orderExportPayload struct {
+	Request     domain.OrderExportRequest `json:"request"`
+	RequestedBy string                    `json:"requested_by,omitempty"`
+}
+
+// OrderExportJob is a background export as polled by its requester
+type OrderExportJob struct {
+	*jobs.Status
+	Download *domain.OrderExportFile `json:"download,omitempty"` // Set once the export succeeded
+}
+
+// OrderExportService exports the orders matching a filter as CSV or XLSX for
+// account managers. Small exports are streamed to the caller; large ones run on
+// the job worker, which writes the file to object storage for download through
+// a signed URL.
+type OrderExportService struct {
+	repo    interfaces.OrderRepository
+	jobs    jobs.Store
+	files   objectstore.Store
+	signer  *objectstore.Signer
+	config  config.ExportConfig
+	logger  logging.Logger
+	metrics metrics.Metrics
+}
+
+// NewOrderExportService creates a new order export service
+func NewOrderExportService(repo interfaces.OrderRepository, jobStore jobs.Store, files objectstore.Store, signer *objectstore.Signer, cfg config.ExportConfig, logger logging.Logger, metrics metrics.Metrics) *OrderExportService {
+	return &OrderExportService{
+		repo:    repo,
+		jobs:    jobStore,
+		files:   files,
+		signer:  signer,
+		config:  cfg,
+		logger:  logger,
+		metrics: metrics,
+	}
+}
+
+// Register registers the export job with worker
+func (s *OrderExportService) Register(worker *jobs.Worker) {
+	worker.Register(OrderExportJobType, s.runExport, jobs.DefaultRetryPolicy())
+}
+
+// Prepare validates an export request, filling in the default format and
+// columns, and counts the orders it matches. Exports above the configured
+// maximum are refused.
+func (s *OrderExportService) Prepare(ctx context.Context, req *domain.OrderExportRequest) (int, error) {
+	if req.Format == "" {
+		req.Format = domain.ExportFormatCSV
+	}
+	if !req.Format.IsValid() {
+		return 0, errors.NewValidation(fmt.Sprintf("unsupported export format %q, use csv or xlsx", req.Format))
+	}
+	if _, err := selectExportColumns(req.Columns); err != nil {
+		return 0, err
+	}
+	if len(req.Columns) == 0 {
+		for _, column := range orderExportColumns[:defaultExportColumns] {
+			req.Columns = append(req.Columns, column.name)
+		}
+	}
+	req.Filter.Limit, req.Filter.Offset, req.Filter.PageToken, req.Filter.After = 0, 0, "", nil
+
+	rows, err := s.repo.Count(ctx, req.Filter)
+	if err != nil {
+		return 0, err
+	}
+	if rows > s.config.MaxRows {
+		return 0, errors.NewValidation(fmt.Sprintf("export matches %d orders, more than the %d allowed; narrow the filters", rows, s.config.MaxRows))
+	}
+	return rows, nil
+}
+
+// Streamable reports whether an export of rows orders is small enough to stream
+// in the response
+func (s *OrderExportService) Streamable(rows int) bool {
+	return rows <= s.config.SyncMaxRows
+}
+
+// Stream writes a prepared export to w
+func (s *OrderExportService) Stream(ctx context.Context, w io.Writer, req domain.OrderExportRequest) error {
+	start := time.Now()
+	rows, err := s.write(ctx, w, req, nil)
+	s.recordExport(ctx, req.Format, "sync", rows, start, err)
+	return err
+}
+
+// Submit queues a prepared export to run in the background
+func (s *OrderExportService) Submit(ctx context.Context, req domain.OrderExportRequest, requestedBy string) (*OrderExportJob, error) {
+	job, err := jobs.Enqueue(ctx, s.jobs, OrderExportJobType, orderExportPayload{
+		Request:     req,
+		RequestedBy: requestedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Order export submitted", map[string]interface{}{
+		"job_id":       job.ID,
+		"format":       req.Format,
+		"requested_by": requestedBy,
+	})
+	return &OrderExportJob{Status: jobs.NewStatus(job)}, nil
+}
+
+// GetExport returns a background export, with a freshly signed download URL
+// once its file is written
+func (s *OrderExportService) GetExport(ctx context.Context, id uuid.UUID) (*OrderExportJob, error) {
+	job, err := s.jobs.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Type != OrderExportJobType {
+		return nil, errors.NewNotFound(fmt.Sprintf("order export %s not found", id))
+	}
+
+	export := &OrderExportJob{Status: jobs.NewStatus(job)}
+	if job.State == jobs.StateSucceeded {
+		var file domain.OrderExportFile
+		if err := export.decodeResult(&file); err != nil {
+			return nil, err
+		}
+		signed := s.signer.SignURL(s.downloadBaseURL(), file.Key, s.config.URLExpiry)
+		file.URL, file.URLExpires = signed.URL, &signed.ExpiresAt
+		export.Download = &file
+	}
+	return export, nil
+}
+
+// DownloadHandler serves export files to holders of a signed URL; it is mounted
+// at OrderExportFilesPath
+func (s *OrderExportService) DownloadHandler() http.Handler {
+	return objectstore.Handler(s.files, s.signer, OrderExportFilesPath)
+}
+
+// runExport writes the file of an export job to object storage, reporting the
+// orders exported so far as its progress
+func (s *OrderExportService) runExport(ctx context.Context, exec *jobs.Execution) error {
+	var payload orderExportPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	req := payload.Request
+	if _, err := selectExportColumns(req.Columns); err != nil || !req.Format.IsValid() {
+		return jobs.Permanent(fmt.Errorf("invalid export request: format %q, columns %v", req.Format, req.Columns))
+	}
+
+	start := time.Now()
+	total, err := s.repo.Count(ctx, req.Filter)
+	if err != nil {
+		return err
+	}
+
+	// The file is streamed to storage as it is written
+	reader, writer := io.Pipe()
+	written := make(chan int64, 1)
+	go func() {
+		rows, err := s.write(ctx, writer, req, func(done int64) {
+			if err := exec.ReportProgress(ctx, done, int64(total)); err != nil {
+				s.logger.Warn(ctx, "Failed to report order export progress", map[string]interface{}{
+					"job_id": exec.Job.ID,
+					"error":  err.Error(),
+				})
+			}
+		})
+		written <- rows
+		writer.CloseWithError(err)
+	}()
+
+	object, err := s.files.Put(ctx, exportKey(exec.Job, req.Format), reader)
+	reader.CloseWithError(err) // Unblocks the writer when storing failed
+	rows := <-written
+	s.recordExport(ctx, req.Format, "async", rows, start, err)
+	if err != nil {
+		return fmt.Errorf("failed to store order export: %w", err)
+	}
+
+	s.logger.Info(ctx, "Order export written", map[string]interface{}{
+		"job_id":       exec.Job.ID,
+		"key":          object.Key,
+		"rows":         rows,
+		"size":         object.Size,
+		"requested_by": payload.RequestedBy,
+	})
+	return exec.SetResult(domain.OrderExportFile{
+		Key:         object.Key,
+		Format:      req.Format,
+		ContentType: object.ContentType,
+		Rows:        rows,
+		Size:        object.Size,
+	})
+}
+
+// write writes the header and the orders of an export to w, newest first, reading
+// them a page at a time. progress, if set, is called after each page.
+func (s *OrderExportService) write(ctx context.Context, w io.Writer, req domain.OrderExportRequest, progress func(done int64)) (int64, error) {
+	columns, err := selectExportColumns(req.Columns)
+	if err != nil {
+		return 0, err
+	}
+	rw, err := newRowWriter(w, req.Format)
+	if err != nil {
+		return 0, err
+	}
+
+	cells := make([]exportCell, len(columns))
+	for i, column := range columns {
+		cells[i] = exportCell{Text: column.name}
+	}
+	if err := rw.WriteRow(cells); err != nil {
+		return 0, err
+	}
+
+	filter := req.Filter
+	filter.Limit = s.config.PageSize
+	var rows int64
+	for {
+		orders, err := s.repo.List(ctx, filter)
+		if err != nil {
+			return rows, err
+		}
+		for _, order := range orders {
+			for i, column := range columns {
+				cells[i] = exportCell{Text: column.value(order), Numeric: column.numeric}
+			}
+			if err := rw.WriteRow(cells); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+		if progress != nil {
+			progress(rows)
+		}
+		if len(orders) < filter.Limit {
+			break
+		}
+
+		// Keyset paging resumes after the last order of the page
+		last := orders[len(orders)-1]
+		filter.After = &domain.OrderPosition{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return rows, rw.Close()
+}
+
+func (s *OrderExportService) recordExport(ctx context.Context, format domain.OrderExportFormat, mode string, rows int64, start time.Time, err error) {
+	labels := map[string]string{"format": string(format), "mode": mode, "status": "success"}
+	if err != nil {
+		labels["status"] = "error"
+		s.logger.Error(ctx, "Order export failed", err, map[string]interface{}{
+			"format": format,
+			"mode":   mode,
+			"rows":   rows,
+		})
+	}
+	s.metrics.IncrementCounter(ctx, "order_exports_total", labels)
+	s.metrics.AddCounter(ctx, "order_export_rows_total", rows, map[string]string{"format": string(format), "mode": mode})
+	s.metrics.RecordDuration(ctx, "order_export_duration", time.Since(start), labels)
+}
+
+// downloadBaseURL is the public URL export downloads are served under; a
+// relative path when no public URL is configured
+func (s *OrderExportService) downloadBaseURL() string {
+	return strings.TrimSuffix(s.config.PublicURL, "/") + OrderExportFilesPath
+}
+
+// decodeResult reads the export file recorded as the result of the job
+func (e *OrderExportJob) decodeResult(file *domain.OrderExportFile) error {
+	if len(e.Result) == 0 {
+		return fmt.Errorf("order export %s has no result", e.ID)
+	}
+	if err := json.Unmarshal(e.Result, file); err != nil {
+		return errors.Wrap(err, "failed to decode order export result")
+	}
+	return nil
+}
+
+// selectExportColumns returns the named columns, or an error naming the ones that don't exist
+func selectExportColumns(names []string) ([]exportColumn, error) {
+	if len(names) == 0 {
+		return orderExportColumns[:defaultExportColumns], nil
+	}
+
+	columns := make([]exportColumn, 0, len(names))
+	var unknown []string
+	for _, name := range names {
+		found := false
+		for _, column := range orderExportColumns {
+			if column.name == name {
+				columns = append(columns, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, errors.NewValidation(fmt.Sprintf("unknown export columns %s, choose from %s",
+			strings.Join(unknown, ", "), strings.Join(OrderExportColumnNames(), ", ")))
+	}
+	return columns, nil
+}
+
+// OrderExportColumnNames returns the names of the selectable export columns
+func OrderExportColumnNames() []string {
+	names := make([]string, len(orderExportColumns))
+	for i, column := range orderExportColumns {
+		names[i] = column.name
+	}
+	return names
+}
+
+// exportKey is the object key of the file of an export job, e.g.
+// "order-exports/2024-05-01/<job ID>.xlsx"
+func exportKey(job *jobs.Job, format domain.OrderExportFormat) string {
+	return fmt.Sprintf("order-exports/%s/%s.%s", job.CreatedAt.UTC().Format("2006-01-02"), job.ID, format)
+}
+
+// exportItems lists the items of an order as "quantity x item ID"
+func exportItems(order *domain.Order) string {
+	items := make([]string, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = fmt.Sprintf("%d x %s", item.Quantity, item.ItemID)
+	}
+	return strings.Join(items, ";")
+}
+
+func itemCount(order *domain.Order) int {
+	count := 0
+	for _, item := range order.Items {
+		count += item.Quantity
+	}
+	return count
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// exportCell is a cell of an exported row
+type exportCell struct {
+	Text    string
+	Numeric bool // Written as a number to spreadsheets; Text holds a decimal
+}
+
+// rowWriter streams the rows of an export file
+type rowWriter interface {
+	WriteRow(cells []exportCell) error
+	// Close finishes the file; it does not close the underlying writer
+	Close() error
+}
+
+// newRowWriter returns a writer of format files on w
+func newRowWriter(w io.Writer, format domain.OrderExportFormat) (rowWriter, error) {
+	switch format {
+	case domain.ExportFormatCSV:
+		return &csvRowWriter{writer: csv.NewWriter(w)}, nil
+	case domain.ExportFormatXLSX:
+		return newXLSXRowWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// csvRowWriter writes RFC 4180 CSV
+type csvRowWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func (c *csvRowWriter) WriteRow(cells []exportCell) error {
+	c.record = c.record[:0]
+	for _, cell := range cells {
+		text := cell.Text
+		if !cell.Numeric {
+			text = neutralizeFormula(text)
+		}
+		c.record = append(c.record, text)
+	}
+	return c.writer.Write(c.record)
+}
+
+func (c *csvRowWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// neutralizeFormula keeps spreadsheets from evaluating text that looks like a
+// formula, such as a tag starting with "=", by prefixing it with a quote
+func neutralizeFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// xlsxRowWriter writes a single-sheet Office Open XML workbook. The sheet is
+// streamed with inline strings, so no shared string table is held in memory.
+type xlsxRowWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+}
+
+// The fixed parts of the workbook around its sheet
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Orders" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+func newXLSXRowWriter(w io.Writer) (*xlsxRowWriter, error) {
+	archive := zip.NewWriter(w)
+	for _, part := range []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	} {
+		if err := writeZipPart(archive, part.name, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	// The sheet is the last part, so its rows go straight into the archive
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	x := &xlsxRowWriter{archive: archive, sheet: bufio.NewWriter(sheet)}
+	if _, err := x.sheet.WriteString(xlsxSheetStart); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func (x *xlsxRowWriter) WriteRow(cells []exportCell) error {
+	x.sheet.WriteString("<row>")
+	for _, cell := range cells {
+		if cell.Numeric && cell.Text != "" {
+			x.sheet.WriteString("<c><v>")
+			xml.EscapeText(x.sheet, []byte(cell.Text))
+			x.sheet.WriteString("</v></c>")
+			continue
+		}
+		x.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		// Characters XML cannot hold are replaced rather than breaking the sheet
+		if err := xml.EscapeText(x.sheet, []byte(cell.Text)); err != nil {
+			return err
+		}
+		x.sheet.WriteString("</t></is></c>")
+	}
+	_, err := x.sheet.WriteString("</row>")
+	return err
+}
+
+func (x *xlsxRowWriter) Close() error {
+	if _, err := x.sheet.WriteString(xlsxSheetEnd); err != nil {
+		return err
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.archive.Close()
+}
+
+func writeZipPart(archive *zip.Writer, name, body string) error {
+	part, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(part, body)
+	return err
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Scopes of the order placement limits
+const (
+	RateLimitScopeUser = "user"
+	RateLimitScopeIP   = "ip"
+)
+
+// AbusePublisher reports users who keep exceeding the order placement limits
+type AbusePublisher interface {
+	PublishOrderAbuseDetected(ctx context.Context, event OrderAbuseEvent) error
+}
+
+// OrderAbuseEvent is published when a user was rejected by the order placement
+// limit AbuseThreshold times within the abuse window. IAM locks the account.
+type OrderAbuseEvent struct {
+	UserID     string        `json:"user_id"`
+	TenantID   string        `json:"tenant_id,omitempty"`
+	ClientIP   string        `json:"client_ip,omitempty"`
+	Rejections int64         `json:"rejections"`
+	Window     time.Duration `json:"window"`
+	DetectedAt time.Time     `json:"detected_at"`
+}
+
+// OrderPlacer identifies the caller placing an order
+type OrderPlacer struct {
+	UserID   string // Empty for callers the gateway did not authenticate
+	TenantID string
+	Roles    []string
+	ClientIP string
+}
+
+// RateLimitDecision is the outcome of an order placement check
+type RateLimitDecision struct {
+	Allowed    bool
+	Scope      string // Scope of the exceeded limit
+	Limit      int
+	RetryAfter time.Duration
+}
+
+// OrderRateLimiter limits how many orders a user and a client IP place within a
+// sliding window. Users rejected again and again are reported as abusive.
+type OrderRateLimiter struct {
+	store     interfaces.RateLimitStore
+	publisher AbusePublisher
+	config    config.RateLimitConfig
+	logger    logging.Logger
+	metrics   metrics.Metrics
+}
+
+// NewOrderRateLimiter creates a new order rate limiter
+func NewOrderRateLimiter(
+	store interfaces.RateLimitStore,
+	publisher AbusePublisher,
+	cfg config.RateLimitConfig,
+	logger logging.Logger,
+	metrics metrics.Metrics,
+) *OrderRateLimiter {
+	return &OrderRateLimiter{
+		store:     store,
+		publisher: publisher,
+		config:    cfg,
+		logger:    logger,
+		metrics:   metrics,
+	}
+}
+
+// Allow records an order placement by placer and reports whether it is within
+// the limits. The IP limit is checked first, so a client cycling through
+// accounts is held back as well. Store errors are returned only when the limiter
+// does not fail open.
+func (l *OrderRateLimiter) Allow(ctx context.Context, placer OrderPlacer) (RateLimitDecision, error) {
+	if l.config.IPLimit > 0 && placer.ClientIP != "" {
+		decision, err := l.hit(ctx, RateLimitScopeIP, "ip:"+placer.ClientIP, l.config.IPLimit)
+		if err != nil || !decision.Allowed {
+			return decision, err
+		}
+	}
+
+	if placer.UserID == "" {
+		return RateLimitDecision{Allowed: true}, nil
+	}
+	limit := l.UserLimit(placer)
+	if limit == 0 {
+		return RateLimitDecision{Allowed: true}, nil
+	}
+
+	decision, err := l.hit(ctx, RateLimitScopeUser, "user:"+placer.UserID, limit)
+	if err != nil || decision.Allowed {
+		return decision, err
+	}
+
+	l.recordRejection(ctx, placer)
+	return decision, nil
+}
+
+// UserLimit returns the orders per window allowed to placer, 0 for unlimited.
+// A tenant limit takes precedence over role limits, and the most generous
+// limit of the placer's roles applies.
+func (l *OrderRateLimiter) UserLimit(placer OrderPlacer) int {
+	if limit, ok := l.config.TenantLimits[strings.ToLower(placer.TenantID)]; ok && placer.TenantID != "" {
+		return limit
+	}
+
+	limit, found := 0, false
+	for _, role := range placer.Roles {
+		roleLimit, ok := l.config.RoleLimits[strings.ToLower(role)]
+		if !ok {
+			continue
+		}
+		if roleLimit == 0 {
+			return 0
+		}
+		if !found || roleLimit > limit {
+			limit, found = roleLimit, true
+		}
+	}
+	if found {
+		return limit
+	}
+	return l.config.UserLimit
+}
+
+func (l *OrderRateLimiter) hit(ctx context.Context, scope, key string, limit int) (RateLimitDecision, error) {
+	allowed, retryAfter, err := l.store.Hit(ctx, key, limit, l.config.Window)
+	if err != nil {
+		l.metrics.IncrementCounter(ctx, "order_rate_limit_errors_total", nil)
+		if l.config.FailOpen {
+			l.logger.Warn(ctx, "Order rate limit unavailable, admitting order", map[string]interface{}{
+				"scope": scope,
+				"error": err.Error(),
+			})
+			return RateLimitDecision{Allowed: true}, nil
+		}
+		return RateLimitDecision{}, err
+	}
+	if allowed {
+		return RateLimitDecision{Allowed: true}, nil
+	}
+
+	l.metrics.IncrementCounter(ctx, "order_rate_limit_rejections_total", map[string]string{"scope": scope})
+	return RateLimitDecision{Scope: scope, Limit: limit, RetryAfter: retryAfter}, nil
+}
+
+// recordRejection counts a user's rejections over the abuse window and reports
+// the user once the threshold is reached. The count starts over after a report,
+// so a user who carries on is reported again.
+func (l *OrderRateLimiter) recordRejection(ctx context.Context, placer OrderPlacer) {
+	if l.config.AbuseThreshold == 0 {
+		return
+	}
+
+	key := "abuse:" + placer.UserID
+	rejections, err := l.store.Count(ctx, key, l.config.AbuseWindow)
+	if err != nil {
+		l.logger.Error(ctx, "Failed to count order rate limit rejections", err, map[string]interface{}{
+			"user_id": placer.UserID,
+		})
+		return
+	}
+	if rejections < int64(l.config.AbuseThreshold) {
+		return
+	}
+
+	if err := l.store.Reset(ctx, key); err != nil {
+		l.logger.Error(ctx, "Failed to reset order rate limit rejections", err, map[string]interface{}{
+			"user_id": placer.UserID,
+		})
+	}
+
+	l.metrics.IncrementCounter(ctx, "order_abuse_detected_total", nil)
+	l.logger.Warn(ctx, "Order placement abuse detected", map[string]interface{}{
+		"user_id":    placer.UserID,
+		"tenant_id":  placer.TenantID,
+		"client_ip":  placer.ClientIP,
+		"rejections": rejections,
+		"window":     l.config.AbuseWindow.String(),
+	})
+
+	event := OrderAbuseEvent{
+		UserID:     placer.UserID,
+		TenantID:   placer.TenantID,
+		ClientIP:   placer.ClientIP,
+		Rejections: rejections,
+		Window:     l.config.AbuseWindow,
+		DetectedAt: time.Now().UTC(),
+	}
+	if err := l.publisher.PublishOrderAbuseDetected(ctx, event); err != nil {
+		l.logger.Error(ctx, "Failed to publish order abuse event", err, map[string]interface{}{
+			"user_id": placer.UserID,
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+)
+
+// SetSagaRepository enables recording the saga history of orders
+func (s *OrderService) SetSagaRepository(repo interfaces.SagaRepository) {
+	s.saga = repo
+}
+
+// GetSagaTimeline returns the recorded saga steps of an order
+func (s *OrderService) GetSagaTimeline(ctx context.Context, orderID uuid.UUID) (*domain.SagaTimeline, error) {
+	ctx, span := s.tracer.Start(ctx, "OrderService.GetSagaTimeline")
+	defer span.End()
+
+	if s.saga == nil {
+		return nil, errors.NewUnavailable("saga history is not recorded")
+	}
+
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	history, err := s.saga.ListByOrder(ctx, orderID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return domain.NewSagaTimeline(order, history), nil
+}
+
+// recordSagaStep appends an attempt's outcome to the saga history of an order. Failures
+// are logged only: the history is for operators and must not fail the saga itself.
+func (s *OrderService) recordSagaStep(ctx context.Context, orderID uuid.UUID, step domain.SagaStepName, status domain.SagaStepStatus, attempt int, cause error) {
+	if s.saga == nil {
+		return
+	}
+
+	record := domain.NewSagaStepRecord(orderID, step, status, attempt, cause)
+	if err := s.saga.Record(ctx, record); err != nil {
+		s.metrics.IncrementCounter(ctx, "order_saga_record_failures_total", map[string]string{
+			"step": string(step),
+		})
+		s.logger.Error(ctx, "Failed to record saga step", err, map[string]interface{}{
+			"order_id": orderID,
+			"step":     step,
+			"status":   status,
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	stdErrors "errors"
 	"fmt"
 	"time"
 
@@ -10,9 +11,15 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/i18n"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/pagination"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
 	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
 )
@@ -27,13 +34,26 @@ type ExternalServices struct {
 // InventoryClient defines the interface for inventory service communication
 type InventoryClient interface {
 	CheckAvailability(ctx context.Context, items []domain.CreateOrderItemRequest) ([]InventoryItem, error)
-	ReserveItems(ctx context.Context, orderID uuid.UUID, items []domain.CreateOrderItemRequest) error
+	ReserveItems(ctx context.Context, orderID uuid.UUID, items []domain.CreateOrderItemRequest, expedited bool) error
 	ReleaseReservation(ctx context.Context, orderID uuid.UUID) error
+	ConfirmReservation(ctx context.Context, orderID uuid.UUID) ([]domain.SerialAllocation, error)
+
+	// CreateBackorder has inventory reserve the items of a backordered order, or of its
+	// backordered shipment when shipmentID is set, once they are in stock
+	CreateBackorder(ctx context.Context, orderID, shipmentID uuid.UUID, items []domain.CreateOrderItemRequest, expedited bool) error
+	CancelBackorder(ctx context.Context, orderID, shipmentID uuid.UUID) error
+
+	// ReturnItems puts the items of a refunded order back in stock
+	ReturnItems(ctx context.Context, orderID uuid.UUID, items []domain.OrderItem) error
 }
 
 // PaymentClient defines the interface for payment service communication
 type PaymentClient interface {
-	ProcessPayment(ctx context.Context, orderID uuid.UUID, amount float64, currency string) (*PaymentResult, error)
+	// ProcessPayment charges an order; sandbox orders are charged by the payment simulator
+	ProcessPayment(ctx context.Context, orderID uuid.UUID, amount money.Money, sandbox bool) (*PaymentResult, error)
+
+	// RefundOrder refunds the completed payments of an order in full
+	RefundOrder(ctx context.Context, orderID uuid.UUID, reason, requestedBy string) (*RefundResult, error)
 }
 
 // MessageProducer defines the interface for message publishing to Kafka
@@ -43,10 +63,14 @@ type MessageProducer interface {
 
 // InventoryItem represents an item from inventory service
 type InventoryItem struct {
-	ID        string  `json:"id"`
-	Name      string  `json:"name"`
-	Price     float64 `json:"price"`
-	Available int     `json:"available"`
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Price     money.Money `json:"price"` // Price per unit; no currency when inventory reported none
+	Available int         `json:"available"`
+
+	// ReplacementSKU is the replacement of a discontinued item, checked in its place when Substituted
+	ReplacementSKU string `json:"replacement_sku,omitempty"`
+	Substituted    bool   `json:"substituted,omitempty"`
 }
 
 // PaymentResult represents the result of a payment operation
@@ -54,6 +78,33 @@ type PaymentResult struct {
 	TransactionID string    `json:"transaction_id"`
 	Status        string    `json:"status"`
 	ProcessedAt   time.Time `json:"processed_at"`
+	PendingReview bool      `json:"pending_review"` // Payment was held for manual review
+}
+
+// RefundResult represents the result of a refund operation
+type RefundResult struct {
+	RefundID       string      `json:"refund_id"`
+	TransactionIDs string      `json:"transaction_ids"` // The refunded transactions, comma separated
+	Amount         money.Money `json:"amount"`
+	ProcessedAt    time.Time   `json:"processed_at"`
+}
+
+// PaymentReviewDecision is the outcome of a manual payment review received from payment-service
+type PaymentReviewDecision struct {
+	OrderID       uuid.UUID
+	TransactionID string
+	Approved      bool // Approved by the reviewer and completed by the payment processor
+	Reason        string
+	ProcessedAt   time.Time
+}
+
+// ReservationPreemption tells that inventory released an order's reservation for an expedited order
+type ReservationPreemption struct {
+	OrderID            uuid.UUID
+	PreemptedByOrderID string
+	SKU                string
+	Quantity           float64
+	PreemptedAt        time.Time
 }
 
 // PaymentEvent represents a payment event for Kafka
@@ -61,10 +112,24 @@ type PaymentEvent struct {
 	OrderID       uuid.UUID `json:"order_id"`
 	UserID        uuid.UUID `json:"user_id"`
 	Amount        float64   `json:"amount"`
+	AmountMinor   int64     `json:"amount_minor"` // Exact amount in the currency's minor unit
 	Currency      string    `json:"currency"`
 	TransactionID string    `json:"transaction_id"`
 	ProcessedAt   time.Time `json:"processed_at"`
 	EventType     string    `json:"event_type"`
+
+	// ShipmentID is set for one shipment of a partially fulfilled order; the
+	// amount is then that of the shipment
+	ShipmentID *uuid.UUID `json:"shipment_id,omitempty"`
+
+	// SerialNumbers carries the serialized units allocated to the order into assembly
+	SerialNumbers []domain.SerialAllocation `json:"serial_numbers,omitempty"`
+
+	// Sandbox marks the payment of a sandbox tenant's test order; nothing was charged
+	Sandbox bool `json:"sandbox,omitempty"`
+
+	// ShippingAddress is the delivery address snapshot of the order, if it ships
+	ShippingAddress *domain.Address `json:"shipping_address,omitempty"`
 }
 
 // OrderService handles order business logic and orchestrates all operations
@@ -74,6 +139,19 @@ type OrderService struct {
 	logger           logging.Logger
 	metrics          metrics.Metrics
 	tracer           trace.Tracer
+	maintenance      *maintenance.Mode
+	cache            *OrderCache
+	approvals        *ApprovalService
+	paymentRetries   *PaymentRetryService
+	addresses        *AddressService
+	events           OrderEventPublisher       // nil unless order events are published
+	saga             interfaces.SagaRepository // nil unless the saga history is recorded
+	statusWatcher    *StatusWatcher            // nil unless order statuses can be long polled
+	transitionHooks  map[domain.OrderStatus][]TransitionHook
+
+	fulfillmentPolicy domain.FulfillmentPolicy // Of orders that don't choose one; all or nothing if unset
+	backorderNotifier BackorderNotifier        // nil unless customers are told about backorders
+	sandbox           config.SandboxConfig     // Tenants whose orders are test data
 }
 
 // NewOrderService creates a new order service with all dependencies
@@ -83,13 +161,35 @@ func NewOrderService(
 	logger logging.Logger,
 	metrics metrics.Metrics,
 ) *OrderService {
-	return &OrderService{
+	s := &OrderService{
 		repo:             repo,
 		externalServices: externalServices,
 		logger:           logger,
 		metrics:          metrics,
 		tracer:           otel.Tracer("order-service"),
 	}
+	s.registerDefaultTransitionHooks()
+	return s
+}
+
+// SetMaintenanceMode attaches the maintenance switch consulted before accepting new orders
+func (s *OrderService) SetMaintenanceMode(mode *maintenance.Mode) {
+	s.maintenance = mode
+}
+
+// SetSandbox selects the tenants whose new orders are sandbox orders
+func (s *OrderService) SetSandbox(sandbox config.SandboxConfig) {
+	s.sandbox = sandbox
+}
+
+// SetOrderCache attaches the in-process cache used for single order lookups
+func (s *OrderService) SetOrderCache(cache *OrderCache) {
+	s.cache = cache
+}
+
+// InvalidateOrder drops any cached copy of the order, e.g. when a status event is received
+func (s *OrderService) InvalidateOrder(orderID uuid.UUID) {
+	s.cache.Invalidate(orderID)
 }
 
 // CreateOrder creates a new order with full workflow: inventory check → payment → events
@@ -107,11 +207,30 @@ func (s *OrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRe
 		"items_count": len(req.Items),
 	})
 
+	// New orders are refused while draining for a deploy; orders already in flight continue
+	if s.maintenance.Enabled() {
+		s.metrics.IncrementCounter(ctx, "orders_rejected_maintenance_total", map[string]string{
+			"service": "order-service",
+		})
+		return nil, errors.NewUnavailable("order service is in maintenance mode, retry later")
+	}
+
 	// Step 1: Validate request
 	if err := s.validateCreateOrderRequest(req); err != nil {
 		span.RecordError(err)
 		return nil, errors.Wrap(err, "invalid create order request")
 	}
+	tags, err := validateOrderTags(req.Tags, req.Attributes)
+	if err != nil {
+		span.RecordError(err)
+		return nil, errors.Wrap(err, "invalid create order request")
+	}
+	req.Tags = tags
+	shippingAddress, err := s.addresses.resolve(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, errors.Wrap(err, "invalid create order request")
+	}
 
 	// Step 2: Check inventory availability
 	inventoryItems, err := s.externalServices.InventoryClient.CheckAvailability(ctx, req.Items)
@@ -127,9 +246,19 @@ func (s *OrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRe
 		span.RecordError(err)
 		return nil, errors.Wrap(err, "failed to build order")
 	}
+	order.ShippingAddress = shippingAddress
+	order.Locale = i18n.FromContext(ctx)
+	if tenantID, ok := ctxmeta.TenantID(ctx); ok {
+		order.Sandbox = s.sandbox.IsSandboxTenant(tenantID)
+	}
 
-	// Step 4: Reserve inventory items
-	if err := s.externalServices.InventoryClient.ReserveItems(ctx, order.ID, req.Items); err != nil {
+	// Backordered orders reserve nothing until inventory has their stock
+	if order.Status == domain.StatusBackordered {
+		return s.createBackorderedOrder(ctx, order, req)
+	}
+
+	// Step 4: Reserve inventory items; split orders reserve their first shipment only
+	if err := s.externalServices.InventoryClient.ReserveItems(ctx, order.ReservationID(), reservedItems(order, req), req.Expedited); err != nil {
 		span.RecordError(err)
 		s.logger.Error(ctx, "Failed to reserve inventory items", err)
 		return nil, errors.Wrap(err, "failed to reserve inventory items")
@@ -140,19 +269,51 @@ func (s *OrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRe
 		span.RecordError(err)
 		s.logger.Error(ctx, "Failed to create order in database", err)
 		// Release inventory reservation on database failure
-		s.releaseInventoryReservation(ctx, order.ID)
+		s.releaseReservation(ctx, order.ReservationID())
 		return nil, errors.Wrap(err, "failed to create order")
 	}
+	s.recordSagaStep(ctx, order.ID, domain.SagaStepReserveInventory, domain.SagaStepSucceeded, 1, nil)
+	s.publishOrderCreated(ctx, order)
+
+	// High-value orders wait for an operator; approval resumes the saga at payment
+	if s.approvals.Required(order) {
+		return s.approvals.hold(ctx, order)
+	}
+
+	return s.payOrder(ctx, order)
+}
+
+// payOrder runs the payment half of the saga for a saved order with reserved inventory
+func (s *OrderService) payOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	span := trace.SpanFromContext(ctx)
 
 	// Step 6: Process payment
 	paymentResult, err := s.processPaymentWithRetry(ctx, order)
 	if err != nil {
 		span.RecordError(err)
 		s.logger.Error(ctx, "Failed to process payment", err)
+		// Transient failures are retried later while the order stays pending
+		if IsRetryablePaymentError(err) && s.paymentRetries.schedule(ctx, order, err) {
+			s.recordSagaStep(ctx, order.ID, domain.SagaStepPayment, domain.SagaStepRetrying, 1, err)
+			return s.paymentRetries.pendingOrder(ctx, order)
+		}
 		// Update order status to failed and release reservation
+		s.recordSagaStep(ctx, order.ID, domain.SagaStepPayment, domain.SagaStepFailed, 1, err)
 		s.handlePaymentFailure(ctx, order.ID)
 		return nil, errors.Wrap(err, "payment processing failed")
 	}
+	s.recordSagaStep(ctx, order.ID, domain.SagaStepPayment, domain.SagaStepSucceeded, 1, nil)
+
+	return s.completePayment(ctx, order, paymentResult)
+}
+
+// completePayment runs the rest of the saga for an order whose payment went through
+func (s *OrderService) completePayment(ctx context.Context, order *domain.Order, paymentResult *PaymentResult) (*domain.Order, error) {
+	// Payments held for manual review pause the saga until payment-service publishes the decision
+	if paymentResult.PendingReview {
+		return s.holdOrderForReview(ctx, order, paymentResult)
+	}
+	shipment := order.ShipmentAwaitingPayment()
 
 	// Step 7: Update order status to paid
 	if err := s.updateOrderStatus(ctx, order.ID, domain.StatusPaid); err != nil {
@@ -160,16 +321,20 @@ func (s *OrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRe
 		// Continue execution as payment was successful
 	}
 
-	// Step 8: Publish payment event to Kafka
+	// Step 8: Confirm the reservation, allocating serial numbers of serial-tracked items
+	s.confirmInventoryReservation(ctx, order)
+
+	// Step 9: Publish payment event to Kafka
 	if err := s.publishPaymentEvent(ctx, order, paymentResult); err != nil {
 		s.logger.Error(ctx, "Failed to publish payment event", err)
 		// Log error but don't fail the order creation since payment succeeded
 	}
+	s.markShipmentPaid(ctx, order, shipment, paymentResult)
 
-	// Step 9: Update metrics
-	s.updateOrderCreationMetrics(order)
+	// Step 10: Update metrics
+	s.updateOrderCreationMetrics(ctx, order)
 
-	// Step 10: Get updated order with new status
+	// Step 11: Get updated order with new status
 	updatedOrder, err := s.repo.GetByID(ctx, order.ID)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to retrieve updated order", err)
@@ -179,7 +344,7 @@ func (s *OrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRe
 	s.logger.Info(ctx, "Order created successfully", map[string]interface{}{
 		"order_id":       order.ID,
 		"user_id":        order.UserID,
-		"total_amount":   order.TotalAmount,
+		"total_amount":   order.TotalAmount.String(),
 		"transaction_id": paymentResult.TransactionID,
 		"status":         updatedOrder.Status,
 	})
@@ -194,12 +359,23 @@ func (s *OrderService) GetOrder(ctx context.Context, id uuid.UUID) (*domain.Orde
 
 	span.SetAttributes(attribute.String("order_id", id.String()))
 
+	if order, ok := s.cache.Get(id); ok {
+		span.SetAttributes(attribute.Bool("cache_hit", true))
+		s.metrics.IncrementCounter(ctx, "order_cache_hits_total", nil)
+		return order, nil
+	}
+	if s.cache != nil {
+		s.metrics.IncrementCounter(ctx, "order_cache_misses_total", nil)
+	}
+
 	order, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		span.RecordError(err)
 		return nil, err
 	}
 
+	s.cache.Set(order)
+
 	s.logger.Debug(ctx, "Order retrieved", map[string]interface{}{
 		"order_id": id,
 		"status":   order.Status,
@@ -235,18 +411,82 @@ func (s *OrderService) GetUserOrders(ctx context.Context, userID uuid.UUID, limi
 	return orders, nil
 }
 
-// ListOrders retrieves orders based on filter criteria
-func (s *OrderService) ListOrders(ctx context.Context, filter domain.OrderFilter) ([]*domain.Order, error) {
+// ListOrders retrieves a page of orders matching the filter, newest first
+func (s *OrderService) ListOrders(ctx context.Context, filter domain.OrderFilter) ([]*domain.Order, pagination.PageInfo, error) {
 	ctx, span := s.tracer.Start(ctx, "OrderService.ListOrders")
 	defer span.End()
 
+	cursor, err := pagination.DecodeToken(filter.PageToken, orderListFingerprint(filter))
+	if err != nil {
+		return nil, pagination.PageInfo{}, errors.NewValidation(err.Error())
+	}
+	if len(cursor.After) > 0 {
+		if filter.After, err = decodeOrderPosition(cursor.After); err != nil {
+			return nil, pagination.PageInfo{}, errors.NewValidation(err.Error())
+		}
+		filter.Offset = 0
+	}
+
+	pageSize := filter.Limit
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	// One extra order tells whether another page follows
+	filter.Limit = pageSize + 1
+
 	orders, err := s.repo.List(ctx, filter)
 	if err != nil {
 		span.RecordError(err)
-		return nil, err
+		return nil, pagination.PageInfo{}, err
 	}
 
-	return orders, nil
+	orders, hasMore := pagination.Trim(orders, pageSize)
+	pageInfo := pagination.KeysetPage(cursor, pageSize, hasMore, func() []string {
+		last := orders[len(orders)-1]
+		return []string{last.CreatedAt.Format(time.RFC3339Nano), last.ID.String()}
+	})
+	return orders, pageInfo, nil
+}
+
+// orderListFingerprint identifies the filters an order page token was issued for
+func orderListFingerprint(filter domain.OrderFilter) string {
+	userID, status := "", ""
+	if filter.UserID != nil {
+		userID = filter.UserID.String()
+	}
+	if filter.Status != nil {
+		status = string(*filter.Status)
+	}
+	parts := []string{"orders", userID, status, tagFilterFingerprint(filter)}
+	// Only ranged listings add the range, so tokens of other listings stay valid
+	if filter.CreatedFrom != nil || filter.CreatedTo != nil {
+		parts = append(parts, formatOptionalTime(filter.CreatedFrom), formatOptionalTime(filter.CreatedTo))
+	}
+	return pagination.Fingerprint(parts...)
+}
+
+// formatOptionalTime formats t for a fingerprint, or returns "" when t is nil
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// decodeOrderPosition reads the keyset position of a page token
+func decodeOrderPosition(after []string) (*domain.OrderPosition, error) {
+	if len(after) != 2 {
+		return nil, fmt.Errorf("%w: malformed position", pagination.ErrInvalidPageToken)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, after[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed position", pagination.ErrInvalidPageToken)
+	}
+	id, err := uuid.Parse(after[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed position", pagination.ErrInvalidPageToken)
+	}
+	return &domain.OrderPosition{CreatedAt: createdAt, ID: id}, nil
 }
 
 // UpdateOrderStatus updates the status of an order with validation
@@ -259,50 +499,144 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, id uuid.UUID, stat
 		attribute.String("status", string(status)),
 	)
 
-	// Get current order to validate status transition
-	currentOrder, err := s.repo.GetByID(ctx, id)
-	if err != nil {
-		return err
-	}
-
-	// Validate status transition
-	if !currentOrder.CanUpdateStatus(status) {
-		return errors.NewValidation(fmt.Sprintf("cannot update order status from %s to %s", currentOrder.Status, status))
+	if !status.IsValid() {
+		return errors.NewValidation(fmt.Sprintf("unknown order status %q", status))
 	}
 
+	// The transition itself is validated against the state machine under a row lock
 	return s.updateOrderStatus(ctx, id, status)
 }
 
-// HandleAssemblyCompleted handles the assembly completed event from Kafka
-func (s *OrderService) HandleAssemblyCompleted(ctx context.Context, orderID uuid.UUID) error {
+// HandleAssemblyCompleted handles the assembly completed event from Kafka.
+// When the event carries an ID, redelivered copies are detected and skipped.
+// shipmentID is set when one shipment of a partially fulfilled order was assembled.
+func (s *OrderService) HandleAssemblyCompleted(ctx context.Context, orderID, shipmentID uuid.UUID, eventID string) error {
 	ctx, span := s.tracer.Start(ctx, "OrderService.HandleAssemblyCompleted")
 	defer span.End()
 
-	span.SetAttributes(attribute.String("order_id", orderID.String()))
+	span.SetAttributes(
+		attribute.String("order_id", orderID.String()),
+		attribute.String("event_id", eventID),
+	)
 
 	s.logger.Info(ctx, "Processing assembly completed event", map[string]interface{}{
+		"order_id":    orderID,
+		"shipment_id": shipmentID,
+		"event_id":    eventID,
+	})
+
+	statuses := []domain.OrderStatus{domain.StatusAssembled, domain.StatusCompleted}
+	if shipmentID != uuid.Nil {
+		var done bool
+		var err error
+		statuses, done, err = s.assembleShipment(ctx, orderID, shipmentID)
+		if err != nil {
+			span.RecordError(err)
+			s.logger.Error(ctx, "Failed to record shipment assembly", err)
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+
+	if eventID != "" {
+		if err := s.applyEventStatuses(ctx, eventID, "assembly.completed", orderID, statuses...); err != nil {
+			return err
+		}
+		s.recordSagaStep(ctx, orderID, domain.SagaStepAssembly, domain.SagaStepSucceeded, 1, nil)
+		return nil
+	}
+
+	for i, status := range statuses {
+		if err := s.updateOrderStatus(ctx, orderID, status); err != nil {
+			span.RecordError(err)
+			if i == 0 && stdErrors.Is(err, domain.ErrInvalidTransition) {
+				return s.rejectEventTransition(ctx, orderID, "assembly.completed", err)
+			}
+			s.logger.Error(ctx, "Failed to update order status after assembly", err, map[string]interface{}{
+				"order_id": orderID,
+				"status":   status,
+			})
+			return err
+		}
+	}
+
+	s.recordSagaStep(ctx, orderID, domain.SagaStepAssembly, domain.SagaStepSucceeded, 1, nil)
+
+	s.logger.Info(ctx, "Order assembly recorded", map[string]interface{}{
 		"order_id": orderID,
+		"status":   statuses[len(statuses)-1],
 	})
 
-	// Update order status to assembled
-	if err := s.updateOrderStatus(ctx, orderID, domain.StatusAssembled); err != nil {
+	return nil
+}
+
+// HandlePaymentReviewDecision resumes or fails an order held for manual payment review
+func (s *OrderService) HandlePaymentReviewDecision(ctx context.Context, decision PaymentReviewDecision) error {
+	ctx, span := s.tracer.Start(ctx, "OrderService.HandlePaymentReviewDecision")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("order_id", decision.OrderID.String()),
+		attribute.Bool("approved", decision.Approved),
+	)
+
+	order, err := s.repo.GetByID(ctx, decision.OrderID)
+	if err != nil {
 		span.RecordError(err)
-		s.logger.Error(ctx, "Failed to update order status to assembled", err)
 		return err
 	}
 
-	// Automatically mark as completed (in real system might have more steps)
-	if err := s.updateOrderStatus(ctx, orderID, domain.StatusCompleted); err != nil {
+	// Redelivered or stale decisions are ignored once the order has moved on
+	if order.Status != domain.StatusPendingReview {
+		s.logger.Warn(ctx, "Ignoring payment review decision for order not awaiting review", map[string]interface{}{
+			"order_id":       decision.OrderID,
+			"transaction_id": decision.TransactionID,
+			"status":         order.Status,
+		})
+		return nil
+	}
+
+	s.metrics.IncrementCounter(ctx, "order_payment_reviews_total", map[string]string{
+		"approved": fmt.Sprintf("%t", decision.Approved),
+	})
+
+	if !decision.Approved {
+		s.logger.Warn(ctx, "Payment declined after manual review, failing order", map[string]interface{}{
+			"order_id":       decision.OrderID,
+			"transaction_id": decision.TransactionID,
+			"reason":         decision.Reason,
+		})
+		s.recordSagaStep(ctx, decision.OrderID, domain.SagaStepPaymentReview, domain.SagaStepFailed, 1, fmt.Errorf("declined by reviewer: %s", decision.Reason))
+		s.handlePaymentFailure(ctx, decision.OrderID)
+		return nil
+	}
+
+	if err := s.updateOrderStatus(ctx, decision.OrderID, domain.StatusPaid); err != nil {
 		span.RecordError(err)
-		s.logger.Error(ctx, "Failed to update order status to completed", err)
 		return err
 	}
+	s.recordSagaStep(ctx, decision.OrderID, domain.SagaStepPaymentReview, domain.SagaStepSucceeded, 1, nil)
 
-	// Update completion metrics
-	s.metrics.IncrementCounter("orders_completed_total", nil)
+	shipment := order.ShipmentAwaitingPayment()
+	s.confirmInventoryReservation(ctx, order)
 
-	s.logger.Info(ctx, "Order marked as completed", map[string]interface{}{
-		"order_id": orderID,
+	paymentResult := &PaymentResult{
+		TransactionID: decision.TransactionID,
+		Status:        "completed",
+		ProcessedAt:   decision.ProcessedAt,
+	}
+	if err := s.publishPaymentEvent(ctx, order, paymentResult); err != nil {
+		// Same as CreateOrder: the payment succeeded, so the order is not failed on publish errors
+		span.RecordError(err)
+		s.logger.Error(ctx, "Failed to publish payment event after review", err)
+	}
+	s.markShipmentPaid(ctx, order, shipment, paymentResult)
+
+	s.logger.Info(ctx, "Payment approved after manual review, order resumed", map[string]interface{}{
+		"order_id":       decision.OrderID,
+		"transaction_id": decision.TransactionID,
 	})
 
 	return nil
@@ -336,9 +670,22 @@ func (s *OrderService) validateCreateOrderRequest(req domain.CreateOrderRequest)
 		}
 	}
 
+	if req.FulfillmentPolicy != "" && !req.FulfillmentPolicy.IsValid() {
+		return errors.NewValidation(fmt.Sprintf("unknown fulfillment policy %q", req.FulfillmentPolicy))
+	}
+
 	return nil
 }
 
+// ErrInsufficientInventory is wrapped by the validation error returned when an item of
+// a new order is short of stock, so callers can tell a shortage from invalid input.
+// The error reads "item <id> (requested: n, available: m): insufficient inventory".
+var ErrInsufficientInventory = stdErrors.New("insufficient inventory")
+
+// ErrPaymentDeclined is wrapped by the error returned when the payment processor or risk
+// assessment declined an order's payment. Declines are final and never retried.
+var ErrPaymentDeclined = stdErrors.New("payment declined")
+
 func (s *OrderService) buildOrderFromRequest(req domain.CreateOrderRequest, inventoryItems []InventoryItem) (*domain.Order, error) {
 	// Create map for quick inventory lookup
 	inventoryMap := make(map[string]InventoryItem)
@@ -347,27 +694,51 @@ func (s *OrderService) buildOrderFromRequest(req domain.CreateOrderRequest, inve
 	}
 
 	order := &domain.Order{
-		ID:        uuid.New(),
-		UserID:    req.UserID,
-		Status:    domain.StatusPending,
-		Currency:  "USD",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-		Items:     make([]domain.OrderItem, 0, len(req.Items)),
+		ID:         uuid.New(),
+		UserID:     req.UserID,
+		Status:     domain.StatusPending,
+		Currency:   "USD",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+		Items:      make([]domain.OrderItem, 0, len(req.Items)),
+		Tags:       req.Tags,
+		Attributes: req.Attributes,
+
+		FulfillmentPolicy: s.fulfillmentPolicyFor(req),
 	}
 
+	// Under the partial policy a shortage backorders the missing items, unless
+	// nothing at all is in stock; under the backorder policy the whole order waits
+	var shortage error
+	available := make(map[string]int, len(req.Items))
+
 	for _, reqItem := range req.Items {
 		inventoryItem, exists := inventoryMap[reqItem.ItemID]
 		if !exists {
 			return nil, errors.NewValidation(fmt.Sprintf("item %s not found in inventory", reqItem.ItemID))
 		}
 
-		if inventoryItem.Available < reqItem.Quantity {
-			return nil, errors.NewValidation(fmt.Sprintf("insufficient quantity for item %s (requested: %d, available: %d)", 
-				reqItem.ItemID, reqItem.Quantity, inventoryItem.Available))
+		available[reqItem.ItemID] = inventoryItem.Available
+		if inventoryItem.Available < reqItem.Quantity && shortage == nil {
+			shortage = &errors.AppError{
+				Type: errors.ErrorTypeValidation,
+				Message: fmt.Sprintf("item %s (requested: %d, available: %d)",
+					reqItem.ItemID, reqItem.Quantity, inventoryItem.Available),
+				Err: ErrInsufficientInventory,
+			}
+			if order.FulfillmentPolicy == domain.FulfillmentAllOrNothing {
+				return nil, shortage
+			}
 		}
 
-		total := float64(reqItem.Quantity) * inventoryItem.Price
+		unitPrice := inventoryItem.Price
+		if unitPrice.Currency == "" {
+			unitPrice = money.New(unitPrice.Minor, order.Currency)
+		}
+		if unitPrice.Currency != order.Currency {
+			return nil, errors.NewValidation(fmt.Sprintf("item %s is priced in %s, orders are placed in %s",
+				reqItem.ItemID, unitPrice.Currency, order.Currency))
+		}
 
 		orderItem := domain.OrderItem{
 			ID:        uuid.New(),
@@ -375,15 +746,30 @@ func (s *OrderService) buildOrderFromRequest(req domain.CreateOrderRequest, inve
 			ItemID:    reqItem.ItemID,
 			ItemName:  inventoryItem.Name,
 			Quantity:  reqItem.Quantity,
-			UnitPrice: inventoryItem.Price,
-			Total:     total,
+			UnitPrice: unitPrice,
+			Total:     unitPrice.Mul(int64(reqItem.Quantity)),
 			CreatedAt: time.Now(),
 		}
 
 		order.Items = append(order.Items, orderItem)
+
+		if inventoryItem.ReplacementSKU != "" {
+			order.Warnings = append(order.Warnings, domain.NewDiscontinuedItemWarning(
+				reqItem.ItemID, inventoryItem.ReplacementSKU, inventoryItem.Substituted))
+		}
 	}
 
-	order.CalculateTotal()
+	if err := order.CalculateTotal(); err != nil {
+		return nil, errors.NewValidation(err.Error())
+	}
+	if shortage != nil {
+		switch {
+		case order.FulfillmentPolicy == domain.FulfillmentBackorder:
+			order.Status = domain.StatusBackordered
+		case !order.SplitShipments(available):
+			return nil, shortage
+		}
+	}
 	return order, nil
 }
 
@@ -392,12 +778,20 @@ func (s *OrderService) processPaymentWithRetry(ctx context.Context, order *domai
 	var lastErr error
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		result, err := s.externalServices.PaymentClient.ProcessPayment(ctx, order.ID, order.TotalAmount, order.Currency)
+		result, err := s.externalServices.PaymentClient.ProcessPayment(ctx, order.ID, order.AmountDue(), order.Sandbox)
 		if err == nil {
 			return result, nil
 		}
 
+		// Declined payments stay declined
+		if !IsRetryablePaymentError(err) {
+			return nil, err
+		}
+
 		lastErr = err
+		if attempt < maxRetries {
+			s.recordSagaStep(ctx, order.ID, domain.SagaStepPayment, domain.SagaStepRetrying, attempt, err)
+		}
 		s.logger.Warn(ctx, "Payment attempt failed", map[string]interface{}{
 			"order_id": order.ID,
 			"attempt":  attempt,
@@ -413,33 +807,172 @@ func (s *OrderService) processPaymentWithRetry(ctx context.Context, order *domai
 }
 
 func (s *OrderService) publishPaymentEvent(ctx context.Context, order *domain.Order, paymentResult *PaymentResult) error {
+	amount := order.AmountDue()
 	event := PaymentEvent{
-		OrderID:       order.ID,
-		UserID:        order.UserID,
-		Amount:        order.TotalAmount,
-		Currency:      order.Currency,
-		TransactionID: paymentResult.TransactionID,
-		ProcessedAt:   paymentResult.ProcessedAt,
-		EventType:     "payment.processed",
+		OrderID:         order.ID,
+		UserID:          order.UserID,
+		Amount:          amount.Float64(),
+		AmountMinor:     amount.Minor,
+		Currency:        order.Currency,
+		TransactionID:   paymentResult.TransactionID,
+		ProcessedAt:     paymentResult.ProcessedAt,
+		EventType:       "payment.processed",
+		SerialNumbers:   order.SerialNumbers,
+		ShippingAddress: order.ShippingAddress,
+		Sandbox:         order.Sandbox,
+	}
+	if shipment := order.ShipmentAwaitingPayment(); shipment != nil {
+		event.ShipmentID = &shipment.ID
+	}
+
+	if err := s.externalServices.MessageProducer.PublishPaymentEvent(ctx, event); err != nil {
+		s.recordSagaStep(ctx, order.ID, domain.SagaStepPublishPayment, domain.SagaStepFailed, 1, err)
+		return err
+	}
+	s.recordSagaStep(ctx, order.ID, domain.SagaStepPublishPayment, domain.SagaStepSucceeded, 1, nil)
+	// Assembly starts on the payment event and reports back when done
+	s.recordSagaStep(ctx, order.ID, domain.SagaStepAssembly, domain.SagaStepWaiting, 1, nil)
+	return nil
+}
+
+// applyEventStatuses applies the status updates triggered by an event exactly once
+func (s *OrderService) applyEventStatuses(ctx context.Context, eventID, eventType string, orderID uuid.UUID, statuses ...domain.OrderStatus) error {
+	transitions, err := s.repo.UpdateStatusForEvent(ctx, eventID, eventType, orderID, statuses...)
+	if err != nil {
+		if stdErrors.Is(err, domain.ErrInvalidTransition) {
+			return s.rejectEventTransition(ctx, orderID, eventType, err)
+		}
+		s.logger.Error(ctx, "Failed to apply event status updates", err, map[string]interface{}{
+			"order_id":   orderID,
+			"event_id":   eventID,
+			"event_type": eventType,
+		})
+		return err
+	}
+	s.cache.Invalidate(orderID)
+
+	if len(transitions) == 0 {
+		s.metrics.IncrementCounter(ctx, "order_events_duplicate_total", map[string]string{
+			"event_type": eventType,
+		})
+		s.logger.Warn(ctx, "Skipping already processed event", map[string]interface{}{
+			"order_id":   orderID,
+			"event_id":   eventID,
+			"event_type": eventType,
+		})
+		return nil
 	}
 
-	return s.externalServices.MessageProducer.PublishPaymentEvent(ctx, event)
+	for _, transition := range transitions {
+		s.afterTransition(ctx, orderID, transition)
+	}
+
+	return nil
+}
+
+// rejectEventTransition drops an event whose status change the state machine does not allow
+// (e.g. assembly completing for an order cancelled in the meantime). Redelivering the event
+// cannot succeed, so it is acknowledged rather than retried.
+func (s *OrderService) rejectEventTransition(ctx context.Context, orderID uuid.UUID, eventType string, err error) error {
+	s.metrics.IncrementCounter(ctx, "order_transitions_rejected_total", map[string]string{
+		"event_type": eventType,
+	})
+	s.logger.Warn(ctx, "Ignoring event with disallowed status transition", map[string]interface{}{
+		"order_id":   orderID,
+		"event_type": eventType,
+		"error":      err.Error(),
+	})
+	return nil
 }
 
 func (s *OrderService) updateOrderStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus) error {
-	if err := s.repo.UpdateStatus(ctx, id, status); err != nil {
+	from, err := s.repo.UpdateStatus(ctx, id, status)
+	if err != nil {
 		return err
 	}
+	s.cache.Invalidate(id)
+
+	s.afterTransition(ctx, id, domain.StatusTransition{From: from, To: status})
+	return nil
+}
+
+func (s *OrderService) holdOrderForReview(ctx context.Context, order *domain.Order, paymentResult *PaymentResult) (*domain.Order, error) {
+	if err := s.updateOrderStatus(ctx, order.ID, domain.StatusPendingReview); err != nil {
+		return nil, errors.Wrap(err, "failed to hold order for payment review")
+	}
 
-	s.metrics.IncrementCounter("order_status_updates_total", map[string]string{
-		"status": string(status),
+	s.recordSagaStep(ctx, order.ID, domain.SagaStepPaymentReview, domain.SagaStepWaiting, 1, nil)
+	s.metrics.IncrementCounter(ctx, "orders_held_for_review_total", nil)
+	s.logger.Warn(ctx, "Order held for manual payment review", map[string]interface{}{
+		"order_id":       order.ID,
+		"transaction_id": paymentResult.TransactionID,
+		"total_amount":   order.TotalAmount.String(),
 	})
 
-	s.logger.Info(ctx, "Order status updated", map[string]interface{}{
-		"order_id": id,
-		"status":   status,
+	updatedOrder, err := s.repo.GetByID(ctx, order.ID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to retrieve order held for review", err)
+		order.Status = domain.StatusPendingReview
+		return order, nil
+	}
+	return updatedOrder, nil
+}
+
+// cancelUnapprovedOrder cancels an order whose approval was rejected or expired
+func (s *OrderService) cancelUnapprovedOrder(ctx context.Context, orderID uuid.UUID) error {
+	if err := s.updateOrderStatus(ctx, orderID, domain.StatusCancelled); err != nil {
+		return errors.Wrap(err, "failed to cancel unapproved order")
+	}
+	s.releaseInventoryReservation(ctx, orderID)
+	return nil
+}
+
+// HandleReservationPreempted fails an order that lost its inventory reservation to an
+// expedited order before it was paid. Orders past payment already consumed their stock.
+func (s *OrderService) HandleReservationPreempted(ctx context.Context, preemption ReservationPreemption) error {
+	ctx, span := s.tracer.Start(ctx, "OrderService.HandleReservationPreempted")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("order_id", preemption.OrderID.String()),
+		attribute.String("sku", preemption.SKU),
+	)
+
+	order, err := s.repo.GetByID(ctx, preemption.OrderID)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	switch order.Status {
+	case domain.StatusPending, domain.StatusPendingApproval, domain.StatusPendingReview:
+	default:
+		s.metrics.IncrementCounter(ctx, "order_reservation_preemptions_total", map[string]string{
+			"outcome": "ignored",
+		})
+		s.logger.Warn(ctx, "Ignoring reservation preemption for order past reservation", map[string]interface{}{
+			"order_id": preemption.OrderID,
+			"sku":      preemption.SKU,
+			"status":   order.Status,
+		})
+		return nil
+	}
+
+	s.metrics.IncrementCounter(ctx, "order_reservation_preemptions_total", map[string]string{
+		"outcome": "failed",
+	})
+	s.logger.Warn(ctx, "Inventory reservation preempted by expedited order, failing order", map[string]interface{}{
+		"order_id":              preemption.OrderID,
+		"sku":                   preemption.SKU,
+		"quantity":              preemption.Quantity,
+		"preempted_by_order_id": preemption.PreemptedByOrderID,
+		"preempted_at":          preemption.PreemptedAt,
 	})
+	s.recordSagaStep(ctx, preemption.OrderID, domain.SagaStepReserveInventory, domain.SagaStepFailed, 1,
+		fmt.Errorf("reservation of %s preempted by order %s", preemption.SKU, preemption.PreemptedByOrderID))
 
+	// Release the order's reservations on its other items too
+	s.handlePaymentFailure(ctx, preemption.OrderID)
 	return nil
 }
 
@@ -453,17 +986,69 @@ func (s *OrderService) handlePaymentFailure(ctx context.Context, orderID uuid.UU
 	s.releaseInventoryReservation(ctx, orderID)
 }
 
+// confirmInventoryReservation confirms the reservation of a paid order and records the serial
+// numbers allocated to it. Failures are logged only: the payment already succeeded.
+func (s *OrderService) confirmInventoryReservation(ctx context.Context, order *domain.Order) {
+	serials, err := s.externalServices.InventoryClient.ConfirmReservation(ctx, order.ReservationID())
+	if err != nil {
+		s.metrics.IncrementCounter(ctx, "order_reservation_confirm_failures_total", nil)
+		s.logger.Error(ctx, "Failed to confirm inventory reservation", err, map[string]interface{}{
+			"order_id": order.ID,
+		})
+		s.recordSagaStep(ctx, order.ID, domain.SagaStepConfirmReservation, domain.SagaStepFailed, 1, err)
+		return
+	}
+	s.recordSagaStep(ctx, order.ID, domain.SagaStepConfirmReservation, domain.SagaStepSucceeded, 1, nil)
+	if len(serials) == 0 {
+		return
+	}
+
+	if err := s.repo.SaveSerialNumbers(ctx, order.ID, serials); err != nil {
+		s.logger.Error(ctx, "Failed to save order serial numbers", err, map[string]interface{}{
+			"order_id": order.ID,
+		})
+	}
+	s.cache.Invalidate(order.ID)
+	order.SerialNumbers = serials
+
+	s.logger.Info(ctx, "Serial numbers allocated to order", map[string]interface{}{
+		"order_id":       order.ID,
+		"serial_numbers": len(serials),
+	})
+}
+
+// releaseInventoryReservation releases the reservation of an order that failed or was
+// cancelled before payment, held under the shipment awaiting payment for split orders
 func (s *OrderService) releaseInventoryReservation(ctx context.Context, orderID uuid.UUID) {
-	if err := s.externalServices.InventoryClient.ReleaseReservation(ctx, orderID); err != nil {
+	reservationID := orderID
+	if order, err := s.repo.GetByID(ctx, orderID); err == nil {
+		reservationID = order.ReservationID()
+	}
+	if err := s.releaseReservation(ctx, reservationID); err != nil {
+		s.recordSagaStep(ctx, orderID, domain.SagaStepReleaseInventory, domain.SagaStepFailed, 1, err)
+		return
+	}
+	s.recordSagaStep(ctx, orderID, domain.SagaStepReleaseInventory, domain.SagaStepSucceeded, 1, nil)
+}
+
+func (s *OrderService) releaseReservation(ctx context.Context, reservationID uuid.UUID) error {
+	err := s.externalServices.InventoryClient.ReleaseReservation(ctx, reservationID)
+	if err != nil {
 		s.logger.Error(ctx, "Failed to release inventory reservation", err)
 	}
+	return err
 }
 
-func (s *OrderService) updateOrderCreationMetrics(order *domain.Order) {
-	s.metrics.IncrementCounter("orders_created_total", map[string]string{
+func (s *OrderService) updateOrderCreationMetrics(ctx context.Context, order *domain.Order) {
+	if order.Sandbox {
+		// Test orders stay out of the business metrics
+		s.metrics.IncrementCounter(ctx, "sandbox_orders_created_total", nil)
+		return
+	}
+	s.metrics.IncrementCounter(ctx, "orders_created_total", map[string]string{
 		"status": string(order.Status),
 	})
-	s.metrics.RecordValue("orders_total_amount", order.TotalAmount, map[string]string{
+	s.metrics.RecordValue(ctx, "orders_total_amount", order.TotalAmount.Float64(), map[string]string{
 		"currency": order.Currency,
 	})
 }
\ No newline at end of file
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// TransitionHook runs after an order has moved between statuses. Hooks run after the
+// transition is committed, so they must not fail the operation that triggered it.
+type TransitionHook func(ctx context.Context, orderID uuid.UUID, transition domain.StatusTransition)
+
+// OnTransition registers a hook that runs whenever an order enters the given status
+func (s *OrderService) OnTransition(to domain.OrderStatus, hook TransitionHook) {
+	if s.transitionHooks == nil {
+		s.transitionHooks = make(map[domain.OrderStatus][]TransitionHook)
+	}
+	s.transitionHooks[to] = append(s.transitionHooks[to], hook)
+}
+
+// registerDefaultTransitionHooks installs the hooks every order service runs
+func (s *OrderService) registerDefaultTransitionHooks() {
+	s.OnTransition(domain.StatusCompleted, func(ctx context.Context, orderID uuid.UUID, transition domain.StatusTransition) {
+		s.metrics.IncrementCounter(ctx, "orders_completed_total", nil)
+	})
+	s.OnTransition(domain.StatusPaid, s.requestShipmentBackorder)
+	s.OnTransition(domain.StatusCancelled, s.cancelBackordersOfOrder)
+	s.OnTransition(domain.StatusFailed, s.cancelBackordersOfOrder)
+}
+
+// afterTransition records a committed transition and runs the hooks registered for its target status
+func (s *OrderService) afterTransition(ctx context.Context, orderID uuid.UUID, transition domain.StatusTransition) {
+	s.metrics.IncrementCounter(ctx, "order_status_updates_total", map[string]string{
+		"status": string(transition.To),
+	})
+
+	s.logger.Info(ctx, "Order status updated", map[string]interface{}{
+		"order_id":    orderID,
+		"status":      transition.To,
+		"from_status": transition.From,
+	})
+
+	for _, hook := range s.transitionHooks[transition.To] {
+		hook(ctx, orderID, transition)
+	}
+}
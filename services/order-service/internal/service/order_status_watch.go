@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+)
+
+// StatusWatcher is the in-process pub/sub behind long polls of order statuses.
+// It is fed with every status change on the order events topic, so a poll held
+// by one replica is answered when another replica changes the status.
+type StatusWatcher struct {
+	mu          sync.Mutex
+	watchers    map[uuid.UUID]map[chan domain.OrderStatus]struct{}
+	count       int
+	maxWait     time.Duration
+	maxWatchers int
+}
+
+// NewStatusWatcher creates a status watcher holding at most maxWatchers polls at
+// once, each for at most maxWait
+func NewStatusWatcher(maxWait time.Duration, maxWatchers int) *StatusWatcher {
+	return &StatusWatcher{
+		watchers:    make(map[uuid.UUID]map[chan domain.OrderStatus]struct{}),
+		maxWait:     maxWait,
+		maxWatchers: maxWatchers,
+	}
+}
+
+// Subscribe returns a channel receiving the status changes of an order and the
+// function ending the subscription. The channel is nil when no watcher is
+// configured or too many polls are held already.
+func (w *StatusWatcher) Subscribe(orderID uuid.UUID) (<-chan domain.OrderStatus, func()) {
+	if w == nil {
+		return nil, func() {}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.count >= w.maxWatchers {
+		return nil, func() {}
+	}
+
+	// A poll only needs the first change, so one buffered change never blocks Publish
+	ch := make(chan domain.OrderStatus, 1)
+	if w.watchers[orderID] == nil {
+		w.watchers[orderID] = make(map[chan domain.OrderStatus]struct{})
+	}
+	w.watchers[orderID][ch] = struct{}{}
+	w.count++
+
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if _, ok := w.watchers[orderID][ch]; !ok {
+			return
+		}
+		delete(w.watchers[orderID], ch)
+		if len(w.watchers[orderID]) == 0 {
+			delete(w.watchers, orderID)
+		}
+		w.count--
+	}
+}
+
+// Publish hands a status change to the polls waiting on the order
+func (w *StatusWatcher) Publish(orderID uuid.UUID, status domain.OrderStatus) {
+	if w == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch := range w.watchers[orderID] {
+		select {
+		case ch <- status:
+		default:
+		}
+	}
+}
+
+// Len returns the number of polls held
+func (w *StatusWatcher) Len() int {
+	if w == nil {
+		return 0
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.count
+}
+
+// SetStatusWatcher enables long polling of order statuses
+func (s *OrderService) SetStatusWatcher(watcher *StatusWatcher) {
+	s.statusWatcher = watcher
+}
+
+// ObserveStatusChange is told about every status change published on the order
+// events topic, including those made by other replicas. It drops the cached order
+// and answers the polls waiting on it.
+func (s *OrderService) ObserveStatusChange(orderID uuid.UUID, status domain.OrderStatus) {
+	s.cache.Invalidate(orderID)
+	s.statusWatcher.Publish(orderID, status)
+}
+
+// WaitForStatusChange returns the order once its status differs from since, or
+// when wait, capped at the watcher's maximum, has passed; changed tells which. An
+// empty since waits for a change of the current status. Without a status watcher,
+// or with too many polls held, it answers at once like a plain status lookup.
+func (s *OrderService) WaitForStatusChange(ctx context.Context, orderID uuid.UUID, since domain.OrderStatus, wait time.Duration) (*domain.Order, bool, error) {
+	ctx, span := s.tracer.Start(ctx, "OrderService.WaitForStatusChange")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("order_id", orderID.String()))
+
+	// Subscribe before reading, so a change between the read and the wait is not missed
+	changes, unsubscribe := s.statusWatcher.Subscribe(orderID)
+	defer unsubscribe()
+
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, false, err
+	}
+	if since == "" {
+		since = order.Status
+	}
+	if order.Status != since || order.Status.IsTerminal() || changes == nil || wait <= 0 {
+		return order, order.Status != since, nil
+	}
+
+	wait = min(wait, s.statusWatcher.maxWait)
+	start := time.Now()
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case status := <-changes:
+			if status == since {
+				continue
+			}
+			order, err = s.repo.GetByID(ctx, orderID)
+			if err != nil {
+				span.RecordError(err)
+				return nil, false, err
+			}
+			s.observeStatusWait(ctx, "changed", start)
+			return order, order.Status != since, nil
+
+		case <-timer.C:
+			s.observeStatusWait(ctx, "timeout", start)
+			return order, false, nil
+
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+}
+
+// observeStatusWait records how a held poll ended
+func (s *OrderService) observeStatusWait(ctx context.Context, outcome string, start time.Time) {
+	labels := map[string]string{"outcome": outcome}
+	s.metrics.IncrementCounter(ctx, "order_status_polls_total", labels)
+	s.metrics.RecordDuration(ctx, "order_status_poll_wait_seconds", time.Since(start), labels)
+}
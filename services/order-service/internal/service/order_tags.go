@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+)
+
+// SetOrderTags replaces the tags and custom attributes of an order and announces the
+// change on the order events topic
+func (s *OrderService) SetOrderTags(ctx context.Context, id uuid.UUID, tags []string, attributes domain.OrderAttributes) (*domain.Order, error) {
+	ctx, span := s.tracer.Start(ctx, "OrderService.SetOrderTags")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("order_id", id.String()),
+		attribute.Int("tags_count", len(tags)),
+		attribute.Int("attributes_count", len(attributes)),
+	)
+
+	tags, err := validateOrderTags(tags, attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateTags(ctx, id, tags, attributes); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	s.cache.Invalidate(id)
+
+	order, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	s.metrics.IncrementCounter(ctx, "order_tags_updated_total", map[string]string{
+		"service": "order-service",
+	})
+	s.logger.Info(ctx, "Order tags updated", map[string]interface{}{
+		"order_id":   id,
+		"tags":       order.Tags,
+		"attributes": len(order.Attributes),
+	})
+
+	s.publishOrderTagsChanged(ctx, order)
+	return order, nil
+}
+
+// validateOrderTags normalizes the tags and checks the attributes of an order
+func validateOrderTags(tags []string, attributes domain.OrderAttributes) ([]string, error) {
+	tags, err := domain.NormalizeOrderTags(tags)
+	if err != nil {
+		return nil, errors.NewValidation(err.Error())
+	}
+	if err := attributes.Validate(); err != nil {
+		return nil, errors.NewValidation(err.Error())
+	}
+	return tags, nil
+}
+
+// publishOrderTagsChanged announces new tags and attributes; like the other order
+// events a failed publish is only logged
+func (s *OrderService) publishOrderTagsChanged(ctx context.Context, order *domain.Order) {
+	if s.events == nil {
+		return
+	}
+	if err := s.events.PublishOrderTagsChanged(ctx, order); err != nil {
+		s.logger.Error(ctx, "Failed to publish order tags changed event", err, map[string]interface{}{
+			"order_id": order.ID,
+		})
+	}
+}
+
+// tagFilterFingerprint identifies the tag and attribute filters of an order listing
+func tagFilterFingerprint(filter domain.OrderFilter) string {
+	parts := append([]string(nil), filter.Tags...)
+	sort.Strings(parts)
+	names := make([]string, 0, len(filter.Attributes))
+	for name := range filter.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parts = append(parts, name+"="+filter.Attributes[name])
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// IsRetryablePaymentError reports whether a payment failed transiently, e.g. payment-service
+// was unavailable or timed out, so a later attempt may succeed. Declines are not retryable.
+func IsRetryablePaymentError(err error) bool {
+	return !stdErrors.Is(err, ErrPaymentDeclined) && errors.IsUnavailable(err)
+}
+
+// PaymentRetryNotifier tells the customer that the payment of their order is retried
+type PaymentRetryNotifier interface {
+	PublishPaymentRetryScheduled(ctx context.Context, event PaymentRetryScheduledEvent) error
+}
+
+// PaymentRetryScheduledEvent is published when a payment failed transiently and is retried later
+type PaymentRetryScheduledEvent struct {
+	OrderID          uuid.UUID `json:"order_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	TotalAmount      float64   `json:"total_amount"`
+	TotalAmountMinor int64     `json:"total_amount_minor"` // Exact total in the currency's minor unit
+	Currency         string    `json:"currency"`
+	Attempt          int       `json:"attempt"` // The retry about to be made, from 1
+	MaxAttempts      int       `json:"max_attempts"`
+	NextAttemptAt    time.Time `json:"next_attempt_at"`
+}
+
+// PaymentRetryService retries the payment of orders that failed transiently on an
+// exponential backoff schedule. The order stays pending with its inventory reserved in
+// the meantime; it fails once the attempts run out or the payment is declined.
+type PaymentRetryService struct {
+	repo     interfaces.PaymentRetryRepository
+	orders   *OrderService
+	notifier PaymentRetryNotifier
+	config   config.PaymentRetryConfig
+	logger   logging.Logger
+	metrics  metrics.Metrics
+	tracer   trace.Tracer
+}
+
+// NewPaymentRetryService creates a new payment retry service and attaches it to the order saga
+func NewPaymentRetryService(
+	repo interfaces.PaymentRetryRepository,
+	orders *OrderService,
+	notifier PaymentRetryNotifier,
+	cfg config.PaymentRetryConfig,
+	logger logging.Logger,
+	metrics metrics.Metrics,
+) *PaymentRetryService {
+	s := &PaymentRetryService{
+		repo:     repo,
+		orders:   orders,
+		notifier: notifier,
+		config:   cfg,
+		logger:   logger,
+		metrics:  metrics,
+		tracer:   otel.Tracer("order-service"),
+	}
+	orders.paymentRetries = s
+	return s
+}
+
+// Run makes the due payment retries until the context is cancelled
+func (s *PaymentRetryService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.retryDue(ctx)
+		}
+	}
+}
+
+// schedule parks an order whose payment failed transiently until its first retry. It
+// returns false when retries are disabled or the schedule could not be stored, in which
+// case the caller fails the order.
+func (s *PaymentRetryService) schedule(ctx context.Context, order *domain.Order, cause error) bool {
+	if s == nil {
+		return false
+	}
+
+	retry := domain.NewPaymentRetry(order, s.config.MaxAttempts, s.backoff(1), cause.Error())
+	if err := s.repo.Create(ctx, retry); err != nil {
+		s.logger.Error(ctx, "Failed to schedule payment retry", err, map[string]interface{}{
+			"order_id": order.ID,
+		})
+		return false
+	}
+
+	s.metrics.IncrementCounter(ctx, "order_payment_retries_total", map[string]string{
+		"outcome": "scheduled",
+	})
+	s.logger.Warn(ctx, "Payment failed transiently, retry scheduled", map[string]interface{}{
+		"order_id":        order.ID,
+		"max_attempts":    retry.MaxAttempts,
+		"next_attempt_at": retry.NextAttemptAt,
+		"error":           cause.Error(),
+	})
+
+	s.notify(ctx, order, retry)
+	return true
+}
+
+// pendingOrder returns the order waiting for its payment retry
+func (s *PaymentRetryService) pendingOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	updatedOrder, err := s.orders.repo.GetByID(ctx, order.ID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to retrieve order awaiting payment retry", err)
+		return order, nil
+	}
+	return updatedOrder, nil
+}
+
+// retryDue makes the retries whose next attempt is due. Each attempt is claimed first,
+// so instances sweeping concurrently do not charge an order twice.
+func (s *PaymentRetryService) retryDue(ctx context.Context) {
+	retries, err := s.repo.ListDue(ctx, time.Now(), s.config.BatchSize)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list due payment retries", err)
+		return
+	}
+
+	for _, retry := range retries {
+		if err := s.repo.Claim(ctx, retry, time.Now()); err != nil {
+			// Claimed by another instance or ended in the meantime
+			continue
+		}
+		s.attempt(ctx, retry)
+	}
+}
+
+// attempt retries the payment of a claimed schedule and records the outcome
+func (s *PaymentRetryService) attempt(ctx context.Context, retry *domain.PaymentRetry) {
+	ctx, span := s.tracer.Start(ctx, "PaymentRetryService.attempt")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("order_id", retry.OrderID.String()),
+		attribute.Int("attempt", retry.Attempts),
+	)
+
+	order, err := s.orders.repo.GetByID(ctx, retry.OrderID)
+	if err != nil {
+		span.RecordError(err)
+		if errors.IsNotFound(err) {
+			s.finish(ctx, retry, domain.PaymentRetryCancelled, "order not found")
+			return
+		}
+		s.reschedule(ctx, nil, retry, err)
+		return
+	}
+
+	// Orders cancelled or failed while waiting, e.g. after losing their reservation, are not charged
+	switch order.Status {
+	case domain.StatusPending, domain.StatusPendingApproval:
+	default:
+		s.logger.Warn(ctx, "Order left pending before its payment retry, cancelling retry", map[string]interface{}{
+			"order_id": order.ID,
+			"status":   order.Status,
+		})
+		s.finish(ctx, retry, domain.PaymentRetryCancelled, "order is "+string(order.Status))
+		return
+	}
+
+	paymentResult, err := s.orders.externalServices.PaymentClient.ProcessPayment(ctx, order.ID, order.AmountDue(), order.Sandbox)
+	switch {
+	case err == nil:
+		s.finish(ctx, retry, domain.PaymentRetrySucceeded, "")
+		s.orders.recordSagaStep(ctx, order.ID, domain.SagaStepPayment, domain.SagaStepSucceeded, retry.Attempts+1, nil)
+		s.logger.Info(ctx, "Payment retry succeeded", map[string]interface{}{
+			"order_id":       order.ID,
+			"attempt":        retry.Attempts,
+			"transaction_id": paymentResult.TransactionID,
+		})
+		if _, err := s.orders.completePayment(ctx, order, paymentResult); err != nil {
+			s.logger.Error(ctx, "Failed to complete retried payment", err, map[string]interface{}{
+				"order_id": order.ID,
+			})
+		}
+
+	case IsRetryablePaymentError(err) && !retry.Exhausted():
+		span.RecordError(err)
+		s.reschedule(ctx, order, retry, err)
+
+	default:
+		span.RecordError(err)
+		status := domain.PaymentRetryExhausted
+		if !IsRetryablePaymentError(err) {
+			status = domain.PaymentRetryDeclined
+		}
+		s.finish(ctx, retry, status, err.Error())
+		s.orders.recordSagaStep(ctx, order.ID, domain.SagaStepPayment, domain.SagaStepFailed, retry.Attempts+1, err)
+		s.logger.Warn(ctx, "Payment retry failed, failing order", map[string]interface{}{
+			"order_id": order.ID,
+			"attempt":  retry.Attempts,
+			"status":   status,
+			"error":    err.Error(),
+		})
+		s.orders.handlePaymentFailure(ctx, order.ID)
+	}
+}
+
+// reschedule schedules the next attempt after a transient failure. order is nil when it
+// could not be loaded, in which case the customer is not notified again.
+func (s *PaymentRetryService) reschedule(ctx context.Context, order *domain.Order, retry *domain.PaymentRetry, cause error) {
+	if retry.Exhausted() {
+		s.finish(ctx, retry, domain.PaymentRetryExhausted, cause.Error())
+		s.orders.recordSagaStep(ctx, retry.OrderID, domain.SagaStepPayment, domain.SagaStepFailed, retry.Attempts+1, cause)
+		s.orders.handlePaymentFailure(ctx, retry.OrderID)
+		return
+	}
+
+	retry.Reschedule(s.backoff(retry.Attempts+1), cause.Error())
+	if err := s.repo.Update(ctx, retry); err != nil {
+		s.logger.Error(ctx, "Failed to reschedule payment retry", err, map[string]interface{}{
+			"order_id": retry.OrderID,
+		})
+		return
+	}
+
+	s.orders.recordSagaStep(ctx, retry.OrderID, domain.SagaStepPayment, domain.SagaStepRetrying, retry.Attempts+1, cause)
+	s.metrics.IncrementCounter(ctx, "order_payment_retries_total", map[string]string{
+		"outcome": "rescheduled",
+	})
+	s.logger.Warn(ctx, "Payment retry failed transiently, rescheduled", map[string]interface{}{
+		"order_id":        retry.OrderID,
+		"attempt":         retry.Attempts,
+		"next_attempt_at": retry.NextAttemptAt,
+		"error":           cause.Error(),
+	})
+
+	if order != nil {
+		s.notify(ctx, order, retry)
+	}
+}
+
+// finish ends the schedule of a retry
+func (s *PaymentRetryService) finish(ctx context.Context, retry *domain.PaymentRetry, status domain.PaymentRetryStatus, lastError string) {
+	retry.Finish(status, lastError)
+	if err := s.repo.Update(ctx, retry); err != nil {
+		s.logger.Error(ctx, "Failed to record payment retry outcome", err, map[string]interface{}{
+			"order_id": retry.OrderID,
+			"status":   status,
+		})
+	}
+	s.metrics.IncrementCounter(ctx, "order_payment_retries_total", map[string]string{
+		"outcome": string(status),
+	})
+}
+
+// notify tells the customer when their payment is retried next
+func (s *PaymentRetryService) notify(ctx context.Context, order *domain.Order, retry *domain.PaymentRetry) {
+	event := PaymentRetryScheduledEvent{
+		OrderID:          order.ID,
+		UserID:           order.UserID,
+		TotalAmount:      order.TotalAmount.Float64(),
+		TotalAmountMinor: order.TotalAmount.Minor,
+		Currency:         order.Currency,
+		Attempt:          retry.Attempts + 1,
+		MaxAttempts:      retry.MaxAttempts,
+		NextAttemptAt:    retry.NextAttemptAt,
+	}
+	if err := s.notifier.PublishPaymentRetryScheduled(ctx, event); err != nil {
+		// The retry is made either way
+		s.logger.Error(ctx, "Failed to notify customer of payment retry", err, map[string]interface{}{
+			"order_id": order.ID,
+		})
+	}
+}
+
+// backoff returns the delay before the given retry, doubling from the initial backoff
+func (s *PaymentRetryService) backoff(retry int) time.Duration {
+	delay := s.config.InitialBackoff
+	for i := 1; i < retry && delay < s.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.config.MaxBackoff {
+		delay = s.config.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// RefundNotifier tells the customer how their refund request progresses
+type RefundNotifier interface {
+	PublishRefundStatusChanged(ctx context.Context, event RefundEvent) error
+}
+
+// RefundEvent is published whenever the refund case of an order changes status
+type RefundEvent struct {
+	OrderID     uuid.UUID           `json:"order_id"`
+	UserID      uuid.UUID           `json:"user_id"`
+	Status      domain.RefundStatus `json:"status"`
+	Amount      float64             `json:"amount"`
+	AmountMinor int64               `json:"amount_minor"` // Exact amount in the currency's minor unit
+	Currency    string              `json:"currency"`
+	Reason      string              `json:"reason"`         // The customer's reason for the request
+	Note        string              `json:"note,omitempty"` // Support's note on the decision
+}
+
+// RefundService handles customers' refund requests of paid orders. Support reviews
+// each request: approval refunds the order's payments and returns its items to
+// stock, rejection leaves the order as it is. The customer is notified at each step.
+type RefundService struct {
+	repo     interfaces.RefundRepository
+	orders   *OrderService
+	notifier RefundNotifier
+	config   config.RefundConfig
+	logger   logging.Logger
+	metrics  metrics.Metrics
+	tracer   trace.Tracer
+}
+
+// NewRefundService creates a new refund service
+func NewRefundService(
+	repo interfaces.RefundRepository,
+	orders *OrderService,
+	notifier RefundNotifier,
+	cfg config.RefundConfig,
+	logger logging.Logger,
+	metrics metrics.Metrics,
+) *RefundService {
+	return &RefundService{
+		repo:     repo,
+		orders:   orders,
+		notifier: notifier,
+		config:   cfg,
+		logger:   logger,
+		metrics:  metrics,
+		tracer:   otel.Tracer("order-service"),
+	}
+}
+
+// RequestRefund opens a refund case for a paid order of the customer. Orders of
+// other customers are reported as not found.
+func (s *RefundService) RequestRefund(ctx context.Context, orderID uuid.UUID, req domain.RefundRequest) (*domain.RefundCase, error) {
+	ctx, span := s.tracer.Start(ctx, "RefundService.RequestRefund")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("order_id", orderID.String()),
+		attribute.String("user_id", req.CustomerID.String()),
+	)
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		return nil, errors.NewValidation("reason is required to request a refund")
+	}
+
+	order, err := s.orders.repo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != req.CustomerID {
+		return nil, errors.NewNotFound("order not found")
+	}
+	if !order.Status.IsRefundable() {
+		return nil, errors.NewConflict(fmt.Sprintf("order is %s and cannot be refunded", order.Status))
+	}
+
+	paidAt := order.CreatedAt
+	if order.PaidAt != nil {
+		paidAt = *order.PaidAt
+	}
+	if time.Since(paidAt) > s.config.Window {
+		return nil, errors.NewConflict(fmt.Sprintf("refunds can only be requested within %s of payment", s.config.Window))
+	}
+
+	refund := domain.NewRefundCase(order, req.Reason)
+	if err := s.repo.Create(ctx, refund); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	s.orders.InvalidateOrder(orderID)
+
+	s.metrics.IncrementCounter(ctx, "order_refunds_requested_total", map[string]string{
+		"currency": order.Currency,
+	})
+	s.logger.Info(ctx, "Order refund requested", map[string]interface{}{
+		"order_id": orderID,
+		"user_id":  req.CustomerID,
+		"amount":   refund.Amount.String(),
+	})
+	s.notify(ctx, refund)
+
+	return refund, nil
+}
+
+// GetRefund returns the refund case of an order
+func (s *RefundService) GetRefund(ctx context.Context, orderID uuid.UUID) (*domain.RefundCase, error) {
+	return s.repo.GetByOrderID(ctx, orderID)
+}
+
+// ListOpen returns up to limit refund cases waiting for support, oldest first
+func (s *RefundService) ListOpen(ctx context.Context, limit int) ([]*domain.RefundCase, error) {
+	return s.repo.ListOpen(ctx, limit)
+}
+
+// Approve records support's approval, refunds the order's payments and returns its
+// items to stock. A failed payment refund leaves the case failed, to be approved again.
+func (s *RefundService) Approve(ctx context.Context, orderID uuid.UUID, req domain.RefundDecisionRequest) (*domain.RefundCase, error) {
+	ctx, span := s.tracer.Start(ctx, "RefundService.Approve")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("order_id", orderID.String()),
+		attribute.String("operator_id", req.OperatorID.String()),
+	)
+
+	refund, err := s.decide(ctx, orderID, domain.RefundApproved, req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Order refund approved, refunding payment", map[string]interface{}{
+		"order_id":    orderID,
+		"operator_id": req.OperatorID,
+	})
+
+	result, err := s.orders.externalServices.PaymentClient.RefundOrder(ctx, orderID, refund.Reason, req.OperatorID.String())
+	if err != nil {
+		span.RecordError(err)
+		s.logger.Error(ctx, "Failed to refund order payment", err, map[string]interface{}{
+			"order_id": orderID,
+		})
+		refund.MarkFailed(err.Error())
+		s.update(ctx, refund, domain.RefundApproved)
+		return nil, errors.Wrap(err, "failed to refund order payment")
+	}
+
+	order, err := s.orders.repo.GetByID(ctx, orderID)
+	if err == nil {
+		err = s.orders.externalServices.InventoryClient.ReturnItems(ctx, orderID, order.Items)
+	}
+	if err != nil {
+		// The money is back with the customer either way; stock can be corrected by hand
+		s.logger.Error(ctx, "Failed to return refunded items to stock", err, map[string]interface{}{
+			"order_id": orderID,
+		})
+	}
+
+	refund.MarkRefunded(result.RefundID)
+	s.update(ctx, refund, domain.RefundApproved)
+
+	s.logger.Info(ctx, "Order refunded", map[string]interface{}{
+		"order_id":  orderID,
+		"refund_id": result.RefundID,
+		"amount":    result.Amount.String(),
+	})
+	return refund, nil
+}
+
+// Reject records support's rejection of the refund; the note tells the customer why
+func (s *RefundService) Reject(ctx context.Context, orderID uuid.UUID, req domain.RefundDecisionRequest) (*domain.RefundCase, error) {
+	ctx, span := s.tracer.Start(ctx, "RefundService.Reject")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("order_id", orderID.String()),
+		attribute.String("operator_id", req.OperatorID.String()),
+	)
+
+	if strings.TrimSpace(req.Note) == "" {
+		return nil, errors.NewValidation("note is required to reject a refund")
+	}
+
+	refund, err := s.decide(ctx, orderID, domain.RefundRejected, req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Order refund rejected", map[string]interface{}{
+		"order_id":    orderID,
+		"operator_id": req.OperatorID,
+		"note":        req.Note,
+	})
+	return refund, nil
+}
+
+// decide records support's decision on a refund case that is still open
+func (s *RefundService) decide(ctx context.Context, orderID uuid.UUID, status domain.RefundStatus, req domain.RefundDecisionRequest) (*domain.RefundCase, error) {
+	if req.OperatorID == uuid.Nil {
+		return nil, errors.NewValidation("operator is required")
+	}
+
+	refund, err := s.repo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !refund.IsOpen() {
+		return nil, errors.NewConflict(fmt.Sprintf("order refund is already %s", refund.Status))
+	}
+
+	from := refund.Status
+	refund.Decide(status, req.OperatorID, req.Note)
+	if err := s.repo.Update(ctx, refund, from); err != nil {
+		return nil, err
+	}
+	s.orders.InvalidateOrder(orderID)
+
+	s.metrics.IncrementCounter(ctx, "order_refunds_decided_total", map[string]string{
+		"decision": string(status),
+	})
+	s.notify(ctx, refund)
+	return refund, nil
+}
+
+// update records the outcome of an approved refund's payment refund
+func (s *RefundService) update(ctx context.Context, refund *domain.RefundCase, from domain.RefundStatus) {
+	if err := s.repo.Update(ctx, refund, from); err != nil {
+		s.logger.Error(ctx, "Failed to record order refund outcome", err, map[string]interface{}{
+			"order_id": refund.OrderID,
+			"status":   refund.Status,
+		})
+		return
+	}
+	s.orders.InvalidateOrder(refund.OrderID)
+
+	s.metrics.IncrementCounter(ctx, "order_refunds_completed_total", map[string]string{
+		"status": string(refund.Status),
+	})
+	s.notify(ctx, refund)
+}
+
+// notify tells the customer about the refund's new status
+func (s *RefundService) notify(ctx context.Context, refund *domain.RefundCase) {
+	event := RefundEvent{
+		OrderID:     refund.OrderID,
+		UserID:      refund.UserID,
+		Status:      refund.Status,
+		Amount:      refund.Amount.Float64(),
+		AmountMinor: refund.Amount.Minor,
+		Currency:    refund.Amount.Currency,
+		Reason:      refund.Reason,
+		Note:        refund.Note,
+	}
+	if err := s.notifier.PublishRefundStatusChanged(ctx, event); err != nil {
+		// The status is on the order either way
+		s.logger.Error(ctx, "Failed to notify customer of refund status", err, map[string]interface{}{
+			"order_id": refund.OrderID,
+			"status":   refund.Status,
+		})
+	}
+}
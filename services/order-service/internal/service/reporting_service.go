@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Limits of order report queries
+const (
+	DefaultReportLimit = 50
+	MaxReportLimit     = 500
+	MaxSummaryPeriod   = 366 * 24 * time.Hour
+)
+
+// ReportingService maintains the order reporting store from the order, payment and
+// assembly events and answers analytics queries from it, so reporting never reads the
+// transactional orders database. Reports are eventually consistent with the orders.
+type ReportingService struct {
+	repo    interfaces.ReportRepository
+	logger  logging.Logger
+	metrics metrics.Metrics
+}
+
+// NewReportingService creates a new reporting service
+func NewReportingService(repo interfaces.ReportRepository, logger logging.Logger, metrics metrics.Metrics) *ReportingService {
+	return &ReportingService{
+		repo:    repo,
+		logger:  logger,
+		metrics: metrics,
+	}
+}
+
+// Project applies an event to the report of its order. Redelivered events are skipped.
+func (s *ReportingService) Project(ctx context.Context, event domain.ReportEvent) error {
+	if event.ID == "" || event.OrderID == uuid.Nil {
+		return errors.NewValidation("report events need an event ID and an order ID")
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	applied, err := s.repo.Apply(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	outcome := "applied"
+	if !applied {
+		outcome = "duplicate"
+	}
+	s.metrics.IncrementCounter(ctx, "order_report_events_total", map[string]string{
+		"type":    string(event.Type),
+		"outcome": outcome,
+	})
+	s.logger.Debug(ctx, "Order report event projected", map[string]interface{}{
+		"order_id":   event.OrderID,
+		"event_id":   event.ID,
+		"event_type": event.Type,
+		"outcome":    outcome,
+	})
+	return nil
+}
+
+// GetOrderReport returns the report of an order
+func (s *ReportingService) GetOrderReport(ctx context.Context, orderID uuid.UUID) (*domain.OrderReport, error) {
+	return s.repo.GetByOrderID(ctx, orderID)
+}
+
+// ListOrderReports returns the order reports matching the filter, newest first
+func (s *ReportingService) ListOrderReports(ctx context.Context, filter domain.ReportFilter) ([]*domain.OrderReport, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultReportLimit
+	}
+	if filter.Limit > MaxReportLimit {
+		return nil, errors.NewValidation("limit cannot exceed 500")
+	}
+	if filter.Offset < 0 {
+		return nil, errors.NewValidation("offset cannot be negative")
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return nil, errors.NewValidation("from must be before to")
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// Summary aggregates the orders created in [from, to): counts by status, revenue per
+// currency and assembly outcomes
+func (s *ReportingService) Summary(ctx context.Context, from, to time.Time) (*domain.ReportSummary, error) {
+	if !from.Before(to) {
+		return nil, errors.NewValidation("from must be before to")
+	}
+	if to.Sub(from) > MaxSummaryPeriod {
+		return nil, errors.NewValidation("summary period cannot exceed 366 days")
+	}
+	return s.repo.Summary(ctx, from, to)
+}
+
+// PurgeReports deletes the reports of the users and orders of a test data purge
+func (s *ReportingService) PurgeReports(ctx context.Context, userIDs, orderIDs []uuid.UUID, dryRun bool) (int64, error) {
+	return s.repo.Purge(ctx, userIDs, orderIDs, dryRun)
+}
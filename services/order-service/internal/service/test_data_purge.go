@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+	"github.com/amiosamu/rocket-science/shared/platform/purge"
+)
+
+// TestDataPurger is the order step of the test data purge saga run by
+// iam-service. Its dry run is also the saga's discovery step: it reports the
+// orders of the users, which payment-service and inventory-service look up
+// their data by before the orders are gone.
+type TestDataPurger struct {
+	repo      interfaces.PurgeRepository
+	reporting *ReportingService // nil when order reporting is disabled
+	logger    logging.Logger
+	metrics   metrics.Metrics
+}
+
+// NewTestDataPurger creates the order participant of test data purges
+func NewTestDataPurger(repo interfaces.PurgeRepository, reporting *ReportingService, logger logging.Logger, metrics metrics.Metrics) *TestDataPurger {
+	return &TestDataPurger{
+		repo:      repo,
+		reporting: reporting,
+		logger:    logger,
+		metrics:   metrics,
+	}
+}
+
+// Purge implements purge.Participant, deleting the orders, webhooks and addresses of the
+// scope's users and their order reports
+func (p *TestDataPurger) Purge(ctx context.Context, scope purge.Scope) (*purge.Result, error) {
+	userIDs, err := parseUUIDs(scope.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+	orderIDs, err := parseUUIDs(scope.OrderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := p.repo.PurgeUsers(ctx, userIDs, scope.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	result := purge.NewResult("order-service", scope)
+	for table, rows := range deleted.Deleted {
+		result.Deleted[table] = rows
+	}
+	for _, id := range deleted.OrderIDs {
+		result.OrderIDs = append(result.OrderIDs, id.String())
+	}
+
+	if p.reporting != nil {
+		reports, err := p.reporting.PurgeReports(ctx, userIDs, append(orderIDs, deleted.OrderIDs...), scope.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		result.Deleted["order_reports"] = reports
+	}
+
+	if !scope.DryRun {
+		p.metrics.IncrementCounter(ctx, "order_test_data_purges_total", nil)
+	}
+	p.logger.Info(ctx, "Order test data purged", map[string]interface{}{
+		"users":   len(userIDs),
+		"orders":  len(deleted.OrderIDs),
+		"deleted": result.Deleted,
+		"dry_run": scope.DryRun,
+	})
+	return result, nil
+}
+
+func parseUUIDs(values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, errors.NewValidation(fmt.Sprintf("invalid ID %q", value))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,442 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/repository/interfaces"
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Headers sent with every webhook delivery. Receivers verify the signature, an HMAC-SHA256
+// of "<timestamp>.<body>" keyed with the webhook secret, and deduplicate on the delivery ID.
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature" // t=<unix timestamp>,v1=<hex hmac>
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookIDHeader        = "X-Webhook-Id"
+)
+
+// WebhookEvent is the JSON body POSTed to webhook endpoints
+type WebhookEvent struct {
+	ID        uuid.UUID        `json:"id"` // Delivery ID, stable across retries
+	Type      string           `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      WebhookOrderData `json:"data"`
+}
+
+// WebhookOrderData describes the order status change carried by a webhook event
+type WebhookOrderData struct {
+	OrderID     uuid.UUID          `json:"order_id"`
+	UserID      uuid.UUID          `json:"user_id"`
+	FromStatus  domain.OrderStatus `json:"from_status"`
+	Status      domain.OrderStatus `json:"status"`
+	TotalAmount float64            `json:"total_amount"`
+	TotalMinor  int64              `json:"total_amount_minor"` // Exact total in the currency's minor unit
+	Currency    string             `json:"currency"`
+	AssembledAt *time.Time         `json:"assembled_at,omitempty"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+}
+
+// WebhookUpdate holds the webhook fields to change; nil fields are left as they are
+type WebhookUpdate struct {
+	URL         *string
+	Description *string
+	Statuses    *[]domain.OrderStatus
+	Active      *bool
+}
+
+// WebhookService manages customer webhooks and delivers order status changes to them
+type WebhookService struct {
+	repo    interfaces.WebhookRepository
+	orders  interfaces.OrderRepository
+	client  *http.Client
+	config  config.WebhookConfig
+	logger  logging.Logger
+	metrics metrics.Metrics
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	repo interfaces.WebhookRepository,
+	orders interfaces.OrderRepository,
+	cfg config.WebhookConfig,
+	logger logging.Logger,
+	metrics metrics.Metrics,
+) *WebhookService {
+	return &WebhookService{
+		repo:    repo,
+		orders:  orders,
+		client:  newWebhookClient(cfg.Timeout),
+		config:  cfg,
+		logger:  logger,
+		metrics: metrics,
+	}
+}
+
+// SubscribeTo enqueues a delivery to the order owner's webhooks on every status change
+func (s *WebhookService) SubscribeTo(orderService *OrderService) {
+	for _, status := range domain.OrderStatuses {
+		orderService.OnTransition(status, s.enqueue)
+	}
+}
+
+// CreateWebhook registers a webhook for the user. The returned webhook carries the generated
+// signing secret, which is not returned again.
+func (s *WebhookService) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	if webhook.UserID == uuid.Nil {
+		return nil, errors.NewValidation("user_id is required")
+	}
+	if err := webhook.Validate(); err != nil {
+		return nil, errors.NewValidation(err.Error())
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate webhook secret")
+	}
+
+	now := time.Now()
+	webhook.ID = uuid.New()
+	webhook.Secret = secret
+	webhook.Active = true
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	if err := s.repo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Webhook created", map[string]interface{}{
+		"webhook_id": webhook.ID,
+		"user_id":    webhook.UserID,
+		"statuses":   webhook.Statuses,
+	})
+	return webhook, nil
+}
+
+// GetWebhook retrieves a webhook owned by the user
+func (s *WebhookService) GetWebhook(ctx context.Context, userID, id uuid.UUID) (*domain.Webhook, error) {
+	return s.repo.GetByID(ctx, userID, id)
+}
+
+// ListWebhooks retrieves the user's webhooks
+func (s *WebhookService) ListWebhooks(ctx context.Context, userID uuid.UUID) ([]*domain.Webhook, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// UpdateWebhook applies the update to a webhook owned by the user
+func (s *WebhookService) UpdateWebhook(ctx context.Context, userID, id uuid.UUID, update WebhookUpdate) (*domain.Webhook, error) {
+	webhook, err := s.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.URL != nil {
+		webhook.URL = *update.URL
+	}
+	if update.Description != nil {
+		webhook.Description = *update.Description
+	}
+	if update.Statuses != nil {
+		webhook.Statuses = *update.Statuses
+	}
+	if update.Active != nil {
+		webhook.Active = *update.Active
+	}
+	if err := webhook.Validate(); err != nil {
+		return nil, errors.NewValidation(err.Error())
+	}
+
+	webhook.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook removes a webhook owned by the user; pending deliveries are dropped
+func (s *WebhookService) DeleteWebhook(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.repo.Delete(ctx, userID, id); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "Webhook deleted", map[string]interface{}{
+		"webhook_id": id,
+		"user_id":    userID,
+	})
+	return nil
+}
+
+// ListDeliveries returns the recent delivery history of a webhook owned by the user
+func (s *WebhookService) ListDeliveries(ctx context.Context, userID, id uuid.UUID) ([]*domain.WebhookDelivery, error) {
+	if _, err := s.repo.GetByID(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDeliveries(ctx, id, s.config.HistoryLimit)
+}
+
+// Run delivers due webhook events every dispatch interval until the context is cancelled
+func (s *WebhookService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.DispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.dispatch(ctx)
+		}
+	}
+}
+
+// enqueue records a delivery for every webhook of the order owner subscribed to the new status.
+// It runs as a transition hook, so failures are logged rather than returned.
+func (s *WebhookService) enqueue(ctx context.Context, orderID uuid.UUID, transition domain.StatusTransition) {
+	order, err := s.orders.GetByID(ctx, orderID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to load order for webhooks", err, map[string]interface{}{
+			"order_id": orderID,
+		})
+		return
+	}
+
+	webhooks, err := s.repo.ListByUser(ctx, order.UserID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list webhooks for order status change", err, map[string]interface{}{
+			"order_id": orderID,
+			"user_id":  order.UserID,
+		})
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(transition.To) {
+			continue
+		}
+
+		delivery := domain.NewWebhookDelivery(webhook.ID, orderID, domain.WebhookEventOrderStatusChanged, nil)
+		delivery.Payload, err = json.Marshal(WebhookEvent{
+			ID:        delivery.ID,
+			Type:      delivery.EventType,
+			CreatedAt: delivery.CreatedAt.UTC(),
+			Data: WebhookOrderData{
+				OrderID:     order.ID,
+				UserID:      order.UserID,
+				FromStatus:  transition.From,
+				Status:      transition.To,
+				TotalAmount: order.TotalAmount.Float64(),
+				TotalMinor:  order.TotalAmount.Minor,
+				Currency:    order.Currency,
+				AssembledAt: order.AssembledAt,
+				CompletedAt: order.CompletedAt,
+			},
+		})
+		if err != nil {
+			s.logger.Error(ctx, "Failed to encode webhook event", err, map[string]interface{}{
+				"webhook_id": webhook.ID,
+				"order_id":   orderID,
+			})
+			continue
+		}
+
+		if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+			s.logger.Error(ctx, "Failed to enqueue webhook delivery", err, map[string]interface{}{
+				"webhook_id": webhook.ID,
+				"order_id":   orderID,
+			})
+			continue
+		}
+
+		s.metrics.IncrementCounter(ctx, "order_webhook_deliveries_enqueued_total", map[string]string{
+			"status": string(transition.To),
+		})
+	}
+}
+
+// dispatch claims a batch of due deliveries and attempts each of them
+func (s *WebhookService) dispatch(ctx context.Context) {
+	// Claimed deliveries are hidden from other replicas for longer than an attempt can take
+	deliveries, err := s.repo.ClaimDueDeliveries(ctx, s.config.BatchSize, 2*s.config.Timeout)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to claim webhook deliveries", err)
+		return
+	}
+
+	webhooks := make(map[uuid.UUID]*domain.Webhook)
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return
+		}
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = s.repo.GetForDelivery(ctx, delivery.WebhookID)
+			if err != nil {
+				s.logger.Error(ctx, "Failed to load webhook for delivery", err, map[string]interface{}{
+					"webhook_id":  delivery.WebhookID,
+					"delivery_id": delivery.ID,
+				})
+				continue
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		s.attempt(ctx, webhook, delivery)
+	}
+}
+
+// attempt POSTs a delivery to its webhook and records the outcome, scheduling a retry
+// with exponential backoff until the maximum number of attempts is reached
+func (s *WebhookService) attempt(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) {
+	outcome := "succeeded"
+	if !webhook.Active {
+		// Events queued before the webhook was disabled are dropped, not retried
+		outcome = "dropped"
+		delivery.RecordFailure(0, "webhook is disabled", nil)
+	} else if responseCode, sendErr := s.send(ctx, webhook, delivery); sendErr == nil {
+		delivery.RecordSuccess(responseCode)
+	} else {
+		var retryAt *time.Time
+		if delivery.Attempts+1 < s.config.MaxAttempts {
+			next := time.Now().Add(s.backoff(delivery.Attempts + 1))
+			retryAt = &next
+			outcome = "retrying"
+		} else {
+			outcome = "failed"
+		}
+		delivery.RecordFailure(responseCode, sendErr.Error(), retryAt)
+
+		s.logger.Warn(ctx, "Webhook delivery attempt failed", map[string]interface{}{
+			"webhook_id":    webhook.ID,
+			"delivery_id":   delivery.ID,
+			"attempt":       delivery.Attempts,
+			"response_code": responseCode,
+			"error":         sendErr.Error(),
+			"next_attempt":  retryAt,
+		})
+	}
+
+	if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+		s.logger.Error(ctx, "Failed to record webhook delivery attempt", err, map[string]interface{}{
+			"delivery_id": delivery.ID,
+		})
+	}
+
+	s.metrics.IncrementCounter(ctx, "order_webhook_delivery_attempts_total", map[string]string{
+		"outcome": outcome,
+	})
+}
+
+// send POSTs the signed delivery payload and returns the response code. Any non-2xx
+// response is an error.
+func (s *WebhookService) send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error) {
+	if err := webhook.Validate(); err != nil {
+		return 0, fmt.Errorf("webhook is not deliverable: %w", err) // Registered before https was required
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "rocket-science-order-webhooks/1.0")
+	req.Header.Set(WebhookIDHeader, webhook.ID.String())
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookSignatureHeader, "t="+timestamp+",v1="+SignWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// newWebhookClient creates the client delivering to customer endpoints. The
+// customer chooses the URL, so the address is checked when dialing, after DNS
+// resolution, which also stops a host from rebinding to an internal address
+// once validated. Redirects are not followed and proxies are not used.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !domain.WebhookAddressAllowed(ip) {
+				return fmt.Errorf("webhook address %s is not allowed", host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse // Answered with the 3xx status, a failed attempt
+		},
+	}
+}
+
+// backoff returns the delay before the given retry, doubling from the initial backoff
+func (s *WebhookService) backoff(retry int) time.Duration {
+	delay := s.config.InitialBackoff
+	for i := 1; i < retry && delay < s.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.config.MaxBackoff {
+		delay = s.config.MaxBackoff
+	}
+	return delay
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<payload>" keyed with the secret
+func SignWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
@@ -2,6 +2,8 @@ package clients
 
 import (
 	"context"
+	stdErrors "errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,12 +12,19 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 
-	inventorypb "github.com/amiosamu/rocket-science/services/inventory-service/proto/inventory"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
-	paymentpb "github.com/amiosamu/rocket-science/services/payment-service/proto/payment"
+	inventorypb "github.com/amiosamu/rocket-science/shared/contracts/proto/inventory/v1"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+	paymentpb "github.com/amiosamu/rocket-science/shared/contracts/proto/payment/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/backoff"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/deadline"
 	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcclient"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcerrors"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
 )
 
 // InventoryGRPCClient implements the InventoryClient interface using gRPC
@@ -29,7 +38,7 @@ type InventoryGRPCClient struct {
 }
 
 // NewInventoryGRPCClient creates a new inventory gRPC client
-func NewInventoryGRPCClient(address string, timeout time.Duration, maxRetries int, retryDelay time.Duration, logger logging.Logger) (*InventoryGRPCClient, error) {
+func NewInventoryGRPCClient(address string, timeout time.Duration, maxRetries int, retryDelay time.Duration, logger logging.Logger, metrics metrics.Metrics) (*InventoryGRPCClient, error) {
 	logger.Info(context.Background(), "Connecting to inventory service", map[string]interface{}{
 		"address": address,
 		"timeout": timeout,
@@ -38,6 +47,7 @@ func NewInventoryGRPCClient(address string, timeout time.Duration, maxRetries in
 	// Setup gRPC connection with options (remove WithBlock to prevent hanging)
 	conn, err := grpc.Dial(address,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(ctxmeta.UnaryClientInterceptor(), holdOffInterceptor("inventory", metrics)),
 		// Remove grpc.WithBlock() and grpc.WithTimeout() to prevent startup hanging
 		// Connection will be established lazily when first RPC is made
 	)
@@ -95,8 +105,11 @@ func (c *InventoryGRPCClient) CheckAvailability(ctx context.Context, items []dom
 		inventoryItems = append(inventoryItems, service.InventoryItem{
 			ID:        result.Sku,
 			Name:      result.Name,
-			Price:     0.0, // Price not available in response
+			Price:     inventoryPrice(result.UnitPrice),
 			Available: int(result.AvailableQuantity),
+
+			ReplacementSKU: result.ReplacementSku,
+			Substituted:    result.Substituted,
 		})
 	}
 
@@ -107,8 +120,20 @@ func (c *InventoryGRPCClient) CheckAvailability(ctx context.Context, items []dom
 	return inventoryItems, nil
 }
 
+// inventoryPrice converts an inventory price, falling back to the float amount of
+// inventory servers that do not send minor units yet
+func inventoryPrice(price *inventorypb.Money) money.Money {
+	if price == nil {
+		return money.Money{}
+	}
+	if price.MinorUnits == 0 && price.Amount != 0 {
+		return money.FromFloat(price.Amount, price.Currency)
+	}
+	return money.New(price.MinorUnits, price.Currency)
+}
+
 // ReserveItems reserves items in inventory for an order
-func (c *InventoryGRPCClient) ReserveItems(ctx context.Context, orderID uuid.UUID, items []domain.CreateOrderItemRequest) error {
+func (c *InventoryGRPCClient) ReserveItems(ctx context.Context, orderID uuid.UUID, items []domain.CreateOrderItemRequest, expedited bool) error {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
@@ -125,20 +150,27 @@ func (c *InventoryGRPCClient) ReserveItems(ctx context.Context, orderID uuid.UUI
 		OrderId: orderID.String(),
 		Items:   grpcItems,
 	}
+	if expedited {
+		req.Priority = inventorypb.ReservationPriority_RESERVATION_PRIORITY_EXPEDITED
+	}
 
 	c.logger.Debug(ctx, "Reserving inventory items", map[string]interface{}{
 		"order_id":    orderID,
 		"items_count": len(items),
+		"expedited":   expedited,
 	})
 
 	// Execute with retry logic
-	_, err := c.executeReserveWithRetry(ctx, func() (*inventorypb.ReserveItemsResponse, error) {
+	resp, err := c.executeReserveWithRetry(ctx, func() (*inventorypb.ReserveItemsResponse, error) {
 		return c.client.ReserveItems(ctx, req)
 	})
 	if err != nil {
 		c.logger.Error(ctx, "Failed to reserve inventory items", err)
 		return c.handleGRPCError(err, "reserve items")
 	}
+	if !resp.Success {
+		return errors.NewValidation("inventory reservation failed: " + resp.Message)
+	}
 
 	c.logger.Info(ctx, "Inventory items reserved successfully", map[string]interface{}{
 		"order_id": orderID,
@@ -176,16 +208,176 @@ func (c *InventoryGRPCClient) ReleaseReservation(ctx context.Context, orderID uu
 	return nil
 }
 
+// backorderReservationMinutes is how long inventory holds a backorder's stock once it
+// arrived; order-service pays the order as soon as it hears of the reservation
+const backorderReservationMinutes = 30
+
+// CreateBackorder asks inventory to reserve out-of-stock items for an order, or for one
+// shipment of it, once they arrive. Inventory answers retries with the backorder it
+// already created.
+func (c *InventoryGRPCClient) CreateBackorder(ctx context.Context, orderID, shipmentID uuid.UUID, items []domain.CreateOrderItemRequest, expedited bool) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	grpcItems := make([]*inventorypb.ItemReservationRequest, 0, len(items))
+	for _, item := range items {
+		grpcItems = append(grpcItems, &inventorypb.ItemReservationRequest{
+			Sku:      item.ItemID,
+			Quantity: int32(item.Quantity),
+		})
+	}
+
+	req := &inventorypb.CreateBackorderRequest{
+		OrderId:                    orderID.String(),
+		Items:                      grpcItems,
+		ReservationDurationMinutes: backorderReservationMinutes,
+	}
+	if shipmentID != uuid.Nil {
+		req.ShipmentId = shipmentID.String()
+	}
+	if expedited {
+		req.Priority = inventorypb.ReservationPriority_RESERVATION_PRIORITY_EXPEDITED
+	}
+
+	resp, err := c.client.CreateBackorder(ctx, req)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to create inventory backorder", err)
+		return c.handleGRPCError(err, "create backorder")
+	}
+
+	c.logger.Info(ctx, "Inventory backorder created", map[string]interface{}{
+		"order_id":     orderID,
+		"shipment_id":  shipmentID,
+		"backorder_id": resp.GetBackorder().GetId(),
+		"status":       resp.GetBackorder().GetStatus().String(),
+	})
+
+	return nil
+}
+
+// CancelBackorder gives up the inventory backorder of an order, or of one shipment of it,
+// releasing its stock if it was already reserved
+func (c *InventoryGRPCClient) CancelBackorder(ctx context.Context, orderID, shipmentID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req := &inventorypb.CancelBackorderRequest{OrderId: orderID.String()}
+	if shipmentID != uuid.Nil {
+		req.ShipmentId = shipmentID.String()
+	}
+
+	if _, err := c.client.CancelBackorder(ctx, req); err != nil {
+		c.logger.Error(ctx, "Failed to cancel inventory backorder", err)
+		return c.handleGRPCError(err, "cancel backorder")
+	}
+
+	c.logger.Info(ctx, "Inventory backorder cancelled", map[string]interface{}{
+		"order_id":    orderID,
+		"shipment_id": shipmentID,
+	})
+
+	return nil
+}
+
+// ReturnItems puts the items of a refunded order back in stock, one stock update per item
+func (c *InventoryGRPCClient) ReturnItems(ctx context.Context, orderID uuid.UUID, items []domain.OrderItem) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	for _, item := range items {
+		req := &inventorypb.UpdateStockRequest{
+			Sku:                   item.ItemID,
+			QuantityChange:        int32(item.Quantity),
+			DecimalQuantityChange: float64(item.Quantity),
+			Reason:                fmt.Sprintf("refund of order %s", orderID),
+			UpdatedBy:             "order-service",
+		}
+
+		resp, err := c.client.UpdateStock(ctx, req)
+		if err != nil {
+			c.logger.Error(ctx, "Failed to return item to stock", err)
+			return c.handleGRPCError(err, "return items")
+		}
+		if !resp.Success {
+			return errors.NewConflict(fmt.Sprintf("returning %s to stock failed: %s", item.ItemID, resp.Message))
+		}
+	}
+
+	c.logger.Info(ctx, "Refunded items returned to stock", map[string]interface{}{
+		"order_id": orderID,
+		"items":    len(items),
+	})
+
+	return nil
+}
+
+// ConfirmReservation confirms the reservation of a paid order and returns the serial numbers
+// allocated to it for serial-tracked items
+func (c *InventoryGRPCClient) ConfirmReservation(ctx context.Context, orderID uuid.UUID) ([]domain.SerialAllocation, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// Reservations are tracked per order, so the order ID doubles as the reservation reference
+	req := &inventorypb.ConfirmReservationRequest{
+		OrderId:       orderID.String(),
+		ReservationId: orderID.String(),
+	}
+
+	c.logger.Debug(ctx, "Confirming inventory reservation", map[string]interface{}{
+		"order_id": orderID,
+	})
+
+	// Execute with retry logic
+	resp, err := c.executeConfirmWithRetry(ctx, func() (*inventorypb.ConfirmReservationResponse, error) {
+		return c.client.ConfirmReservation(ctx, req)
+	})
+	if err != nil {
+		c.logger.Error(ctx, "Failed to confirm inventory reservation", err)
+		return nil, c.handleGRPCError(err, "confirm reservation")
+	}
+
+	if !resp.Success {
+		return nil, errors.NewConflict(fmt.Sprintf("reservation confirmation failed: %s", resp.Message))
+	}
+
+	var serials []domain.SerialAllocation
+	allocatedAt := time.Now()
+	if resp.ConfirmedAt != nil {
+		allocatedAt = resp.ConfirmedAt.AsTime()
+	}
+	for _, result := range resp.Results {
+		for _, serialNumber := range result.SerialNumbers {
+			serials = append(serials, domain.SerialAllocation{
+				ItemID:       result.Sku,
+				SerialNumber: serialNumber,
+				AllocatedAt:  allocatedAt,
+			})
+		}
+	}
+
+	c.logger.Info(ctx, "Inventory reservation confirmed successfully", map[string]interface{}{
+		"order_id":       orderID,
+		"serial_numbers": len(serials),
+	})
+
+	return serials, nil
+}
+
 // executeWithRetry executes a function with retry logic for inventory operations
 func (c *InventoryGRPCClient) executeWithRetry(ctx context.Context, fn func() (*inventorypb.CheckAvailabilityResponse, error)) (*inventorypb.CheckAvailabilityResponse, error) {
 	var lastErr error
 
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
 		if attempt > 0 {
+			wait, ok := retryWait(lastErr, c.retryDelay, attempt)
+			// Give up once the server or the caller's budget doesn't allow the wait
+			if !ok || !deadline.Allows(ctx, wait) {
+				return nil, lastErr
+			}
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(c.retryDelay * time.Duration(attempt)):
+			case <-time.After(wait):
 				// Continue with retry
 			}
 		}
@@ -197,12 +389,9 @@ func (c *InventoryGRPCClient) executeWithRetry(ctx context.Context, fn func() (*
 
 		lastErr = err
 
-		// Don't retry on certain error types
-		if st, ok := status.FromError(err); ok {
-			switch st.Code() {
-			case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists:
-				return nil, err // Don't retry these errors
-			}
+		// Retry transient failures and those the server marks retryable
+		if !grpcerrors.IsRetryable(err) {
+			return nil, err
 		}
 
 		c.logger.Warn(ctx, "Inventory service call failed, retrying", map[string]interface{}{
@@ -220,10 +409,15 @@ func (c *InventoryGRPCClient) executeReserveWithRetry(ctx context.Context, fn fu
 
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
 		if attempt > 0 {
+			wait, ok := retryWait(lastErr, c.retryDelay, attempt)
+			// Give up once the server or the caller's budget doesn't allow the wait
+			if !ok || !deadline.Allows(ctx, wait) {
+				return nil, lastErr
+			}
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(c.retryDelay * time.Duration(attempt)):
+			case <-time.After(wait):
 				// Continue with retry
 			}
 		}
@@ -235,12 +429,9 @@ func (c *InventoryGRPCClient) executeReserveWithRetry(ctx context.Context, fn fu
 
 		lastErr = err
 
-		// Don't retry on certain error types
-		if st, ok := status.FromError(err); ok {
-			switch st.Code() {
-			case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists:
-				return nil, err // Don't retry these errors
-			}
+		// Retry transient failures and those the server marks retryable
+		if !grpcerrors.IsRetryable(err) {
+			return nil, err
 		}
 
 		c.logger.Warn(ctx, "Inventory service call failed, retrying", map[string]interface{}{
@@ -258,10 +449,15 @@ func (c *InventoryGRPCClient) executeReleaseWithRetry(ctx context.Context, fn fu
 
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
 		if attempt > 0 {
+			wait, ok := retryWait(lastErr, c.retryDelay, attempt)
+			// Give up once the server or the caller's budget doesn't allow the wait
+			if !ok || !deadline.Allows(ctx, wait) {
+				return nil, lastErr
+			}
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(c.retryDelay * time.Duration(attempt)):
+			case <-time.After(wait):
 				// Continue with retry
 			}
 		}
@@ -273,12 +469,49 @@ func (c *InventoryGRPCClient) executeReleaseWithRetry(ctx context.Context, fn fu
 
 		lastErr = err
 
-		// Don't retry on certain error types
-		if st, ok := status.FromError(err); ok {
-			switch st.Code() {
-			case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists:
-				return nil, err // Don't retry these errors
+		// Retry transient failures and those the server marks retryable
+		if !grpcerrors.IsRetryable(err) {
+			return nil, err
+		}
+
+		c.logger.Warn(ctx, "Inventory service call failed, retrying", map[string]interface{}{
+			"attempt": attempt + 1,
+			"error":   err.Error(),
+		})
+	}
+
+	return nil, lastErr
+}
+
+// executeConfirmWithRetry executes confirm operations with retry logic
+func (c *InventoryGRPCClient) executeConfirmWithRetry(ctx context.Context, fn func() (*inventorypb.ConfirmReservationResponse, error)) (*inventorypb.ConfirmReservationResponse, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			wait, ok := retryWait(lastErr, c.retryDelay, attempt)
+			// Give up once the server or the caller's budget doesn't allow the wait
+			if !ok || !deadline.Allows(ctx, wait) {
+				return nil, lastErr
 			}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+				// Continue with retry
+			}
+		}
+
+		resp, err := fn()
+		if err == nil {
+			return resp, nil
+		}
+
+		lastErr = err
+
+		// Retry transient failures and those the server marks retryable
+		if !grpcerrors.IsRetryable(err) {
+			return nil, err
 		}
 
 		c.logger.Warn(ctx, "Inventory service call failed, retrying", map[string]interface{}{
@@ -301,7 +534,7 @@ type PaymentGRPCClient struct {
 }
 
 // NewPaymentGRPCClient creates a new payment gRPC client
-func NewPaymentGRPCClient(address string, timeout time.Duration, maxRetries int, retryDelay time.Duration, logger logging.Logger) (*PaymentGRPCClient, error) {
+func NewPaymentGRPCClient(address string, timeout time.Duration, maxRetries int, retryDelay time.Duration, logger logging.Logger, metrics metrics.Metrics) (*PaymentGRPCClient, error) {
 	logger.Info(context.Background(), "Connecting to payment service", map[string]interface{}{
 		"address": address,
 		"timeout": timeout,
@@ -310,6 +543,7 @@ func NewPaymentGRPCClient(address string, timeout time.Duration, maxRetries int,
 	// Setup gRPC connection with options (remove WithBlock to prevent hanging)
 	conn, err := grpc.Dial(address,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(ctxmeta.UnaryClientInterceptor(), holdOffInterceptor("payment", metrics)),
 		// Remove grpc.WithBlock() and grpc.WithTimeout() to prevent startup hanging
 		// Connection will be established lazily when first RPC is made
 	)
@@ -330,20 +564,23 @@ func NewPaymentGRPCClient(address string, timeout time.Duration, maxRetries int,
 }
 
 // ProcessPayment processes payment for an order
-func (c *PaymentGRPCClient) ProcessPayment(ctx context.Context, orderID uuid.UUID, amount float64, currency string) (*service.PaymentResult, error) {
+func (c *PaymentGRPCClient) ProcessPayment(ctx context.Context, orderID uuid.UUID, amount money.Money, sandbox bool) (*service.PaymentResult, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	req := &paymentpb.ProcessPaymentRequest{
-		OrderId:  orderID.String(),
-		Amount:   amount,
-		Currency: currency,
+		OrderId:     orderID.String(),
+		Amount:      amount.Float64(),
+		Currency:    amount.Currency,
+		ExactAmount: amount.ToProto(),
+		Sandbox:     sandbox,
 	}
 
 	c.logger.Debug(ctx, "Processing payment", map[string]interface{}{
 		"order_id": orderID,
-		"amount":   amount,
-		"currency": currency,
+		"amount":   amount.Amount(),
+		"currency": amount.Currency,
+		"sandbox":  sandbox,
 	})
 
 	// Execute with retry logic
@@ -355,6 +592,17 @@ func (c *PaymentGRPCClient) ProcessPayment(ctx context.Context, orderID uuid.UUI
 		return nil, c.handleGRPCError(err, "process payment")
 	}
 
+	pendingReview := resp.Status == paymentpb.PaymentStatus_PAYMENT_STATUS_PENDING_REVIEW
+	if !resp.Success && !pendingReview {
+		c.logger.Warn(ctx, "Payment declined", map[string]interface{}{
+			"order_id":       orderID,
+			"transaction_id": resp.TransactionId,
+			"status":         resp.Status.String(),
+			"reason":         resp.Message,
+		})
+		return nil, &errors.AppError{Type: errors.ErrorTypeExternal, Message: "payment declined: " + resp.Message, Err: service.ErrPaymentDeclined}
+	}
+
 	processedAt := time.Now()
 	if resp.ProcessedAt != nil {
 		processedAt = resp.ProcessedAt.AsTime()
@@ -364,6 +612,7 @@ func (c *PaymentGRPCClient) ProcessPayment(ctx context.Context, orderID uuid.UUI
 		TransactionID: resp.TransactionId,
 		Status:        resp.Status.String(),
 		ProcessedAt:   processedAt,
+		PendingReview: pendingReview,
 	}
 
 	c.logger.Info(ctx, "Payment processed successfully", map[string]interface{}{
@@ -375,16 +624,67 @@ func (c *PaymentGRPCClient) ProcessPayment(ctx context.Context, orderID uuid.UUI
 	return result, nil
 }
 
+// RefundOrder refunds the completed payments of an order in full
+func (c *PaymentGRPCClient) RefundOrder(ctx context.Context, orderID uuid.UUID, reason, requestedBy string) (*service.RefundResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req := &paymentpb.RefundPaymentRequest{
+		OrderId:     orderID.String(),
+		Reason:      reason,
+		RequestedBy: requestedBy,
+	}
+
+	resp, err := c.client.RefundPayment(ctx, req)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to refund payment", err)
+		return nil, c.handleGRPCError(err, "refund payment")
+	}
+
+	if !resp.Success {
+		c.logger.Warn(ctx, "Payment refund failed", map[string]interface{}{
+			"order_id": orderID,
+			"reason":   resp.Message,
+		})
+		return nil, errors.NewExternal("payment refund failed: " + resp.Message)
+	}
+
+	processedAt := time.Now()
+	if resp.ProcessedAt != nil {
+		processedAt = resp.ProcessedAt.AsTime()
+	}
+
+	result := &service.RefundResult{
+		RefundID:       resp.RefundId,
+		TransactionIDs: resp.OriginalTransactionId,
+		Amount:         money.FromProto(resp.ExactRefundedAmount),
+		ProcessedAt:    processedAt,
+	}
+
+	c.logger.Info(ctx, "Payment refunded successfully", map[string]interface{}{
+		"order_id":  orderID,
+		"refund_id": result.RefundID,
+		"amount":    result.Amount.String(),
+	})
+
+	return result, nil
+}
+
 // executePaymentWithRetry executes payment operations with retry logic
 func (c *PaymentGRPCClient) executePaymentWithRetry(ctx context.Context, fn func() (*paymentpb.ProcessPaymentResponse, error)) (*paymentpb.ProcessPaymentResponse, error) {
 	var lastErr error
 
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
 		if attempt > 0 {
+			wait, ok := retryWait(lastErr, c.retryDelay, attempt)
+			// Give up once the server or the caller's budget doesn't allow the wait
+			if !ok || !deadline.Allows(ctx, wait) {
+				return nil, lastErr
+			}
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(c.retryDelay * time.Duration(attempt)):
+			case <-time.After(wait):
 				// Continue with retry
 			}
 		}
@@ -396,12 +696,9 @@ func (c *PaymentGRPCClient) executePaymentWithRetry(ctx context.Context, fn func
 
 		lastErr = err
 
-		// Don't retry on certain error types
-		if st, ok := status.FromError(err); ok {
-			switch st.Code() {
-			case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists:
-				return nil, err // Don't retry these errors
-			}
+		// Retry transient failures and those the server marks retryable
+		if !grpcerrors.IsRetryable(err) {
+			return nil, err
 		}
 
 		c.logger.Warn(ctx, "Payment service call failed, retrying", map[string]interface{}{
@@ -428,9 +725,33 @@ func (c *PaymentGRPCClient) Close() error {
 	return nil
 }
 
+// maxRetryBackoff caps the backoff between the attempts of a call; longer retry
+// hints of the server are honored up to backoff.DefaultMaxRetryAfter
+const maxRetryBackoff = 5 * time.Second
+
+// retryWait returns the jittered exponential backoff from retryDelay before attempt,
+// stretched to the retry hint of the last error when the server asked for a longer
+// wait. ok is false when the server asked to wait too long to retry at all.
+func retryWait(lastErr error, retryDelay time.Duration, attempt int) (time.Duration, bool) {
+	hint, _ := grpcerrors.RetryDelay(lastErr)
+	policy := backoff.Policy{BaseDelay: retryDelay, MaxDelay: maxRetryBackoff}
+	return policy.Delay(attempt, hint)
+}
+
+// holdOffInterceptor fails calls fast while the service asked clients to wait.
+// The clients retry on their own, so the interceptor doesn't.
+func holdOffInterceptor(name string, metrics metrics.Metrics) grpc.UnaryClientInterceptor {
+	cfg := grpcclient.DefaultConfig(name)
+	cfg.MaxRetries = 0
+	return grpcclient.UnaryClientInterceptor(cfg, metrics)
+}
+
 // handleGRPCError converts gRPC errors to domain errors
 func (c *InventoryGRPCClient) handleGRPCError(err error, operation string) error {
 	if st, ok := status.FromError(err); ok {
+		if grpcerrors.Reason(err) == grpcerrors.ReasonInsufficientStock {
+			return &errors.AppError{Type: errors.ErrorTypeValidation, Message: st.Message(), Err: service.ErrInsufficientInventory}
+		}
 		switch st.Code() {
 		case codes.NotFound:
 			return errors.NewNotFound(st.Message())
@@ -449,8 +770,16 @@ func (c *InventoryGRPCClient) handleGRPCError(err error, operation string) error
 	return errors.Wrap(err, "inventory service "+operation+" failed")
 }
 
+// handleGRPCError converts gRPC errors to domain errors. Failures worth retrying later are
+// unavailable errors, so the order saga schedules a payment retry for them.
 func (c *PaymentGRPCClient) handleGRPCError(err error, operation string) error {
 	if st, ok := status.FromError(err); ok {
+		if st.Code() == codes.DeadlineExceeded {
+			return errors.NewUnavailable("payment service timeout")
+		}
+		if grpcerrors.IsRetryable(err) {
+			return errors.NewUnavailable("payment service unavailable: " + st.Message())
+		}
 		switch st.Code() {
 		case codes.NotFound:
 			return errors.NewNotFound(st.Message())
@@ -458,13 +787,12 @@ func (c *PaymentGRPCClient) handleGRPCError(err error, operation string) error {
 			return errors.NewValidation(st.Message())
 		case codes.FailedPrecondition:
 			return errors.NewValidation(st.Message())
-		case codes.Unavailable:
-			return errors.NewInternal("payment service unavailable")
-		case codes.DeadlineExceeded:
-			return errors.NewInternal("payment service timeout")
 		default:
 			return errors.NewInternal("payment service error: " + st.Message())
 		}
 	}
+	if stdErrors.Is(err, context.DeadlineExceeded) {
+		return errors.NewUnavailable("payment service timeout")
+	}
 	return errors.Wrap(err, "payment service "+operation+" failed")
 }
@@ -0,0 +1,109 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	inventorypb "github.com/amiosamu/rocket-science/shared/contracts/proto/inventory/v1"
+	paymentpb "github.com/amiosamu/rocket-science/shared/contracts/proto/payment/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// WarmUpClient is a downstream client whose connection can be established
+// ahead of the first request
+type WarmUpClient interface {
+	WarmUp(ctx context.Context) error
+}
+
+// WarmUp connects to the inventory service and checks it is serving
+func (c *InventoryGRPCClient) WarmUp(ctx context.Context) error {
+	return warmUpConn(ctx, c.conn, inventorypb.InventoryService_ServiceDesc.ServiceName)
+}
+
+// WarmUp connects to the payment service and checks it is serving
+func (c *PaymentGRPCClient) WarmUp(ctx context.Context) error {
+	return warmUpConn(ctx, c.conn, paymentpb.PaymentService_ServiceDesc.ServiceName)
+}
+
+// warmUpConn dials the connection, which is otherwise established lazily by
+// the first RPC, waits until it is ready and asks the server's health service
+// whether the service is serving
+func warmUpConn(ctx context.Context, conn *grpc.ClientConn, service string) error {
+	for state := conn.GetState(); state != connectivity.Ready; state = conn.GetState() {
+		if state == connectivity.Idle {
+			conn.Connect()
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("connection still %s: %w", state, ctx.Err())
+		}
+	}
+
+	response, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	if response.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%s is %s", service, response.Status)
+	}
+	return nil
+}
+
+// WarmUp warms up every client, retrying each until it succeeds or the
+// warm-up timeout passes. It returns the clients that could not be warmed up
+// by then; requests to them pay for the connection when they are first made.
+func WarmUp(ctx context.Context, cfg config.WarmUpConfig, clients map[string]WarmUpClient, logger logging.Logger, metrics metrics.Metrics) map[string]error {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	type outcome struct {
+		name string
+		err  error
+	}
+	outcomes := make(chan outcome, len(clients))
+	start := time.Now()
+	for name, client := range clients {
+		go func() {
+			outcomes <- outcome{name, warmUpWithRetry(ctx, client, cfg.RetryInterval)}
+		}()
+	}
+
+	failed := make(map[string]error)
+	for range clients {
+		result := <-outcomes
+		if result.err != nil {
+			failed[result.name] = result.err
+			metrics.IncrementCounter(ctx, "grpc_client_warmup_failures_total", map[string]string{
+				"client": result.name,
+			})
+			continue
+		}
+		logger.Info(ctx, "gRPC client warmed up", map[string]interface{}{
+			"client":   result.name,
+			"duration": time.Since(start).String(),
+		})
+	}
+	return failed
+}
+
+// warmUpWithRetry warms up the client until it succeeds or the context ends
+func warmUpWithRetry(ctx context.Context, client WarmUpClient, retryInterval time.Duration) error {
+	for {
+		err := client.WarmUp(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(retryInterval):
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// AddressHandler handles HTTP requests for user address books
+type AddressHandler struct {
+	addressService *service.AddressService
+	responder      *OrderHandler // Shares the JSON and error responses of the order API
+	logger         logging.Logger
+}
+
+// NewAddressHandler creates a new address handler
+func NewAddressHandler(addressService *service.AddressService, logger logging.Logger) *AddressHandler {
+	return &AddressHandler{
+		addressService: addressService,
+		responder:      &OrderHandler{logger: logger},
+		logger:         logger,
+	}
+}
+
+// CreateAddress handles POST /users/{userID}/addresses
+func (h *AddressHandler) CreateAddress(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req CreateAddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid JSON payload", err)
+		return
+	}
+
+	address, err := h.addressService.CreateAddress(r.Context(), userID, req.Label, req.Address, req.IsDefault)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusCreated, address)
+}
+
+// ListAddresses handles GET /users/{userID}/addresses
+func (h *AddressHandler) ListAddresses(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	addresses, err := h.addressService.ListAddresses(r.Context(), userID)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, AddressListResponse{Addresses: addresses})
+}
+
+// GetAddress handles GET /users/{userID}/addresses/{addressID}
+func (h *AddressHandler) GetAddress(w http.ResponseWriter, r *http.Request) {
+	userID, addressID, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	address, err := h.addressService.GetAddress(r.Context(), userID, addressID)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, address)
+}
+
+// UpdateAddress handles PATCH /users/{userID}/addresses/{addressID}
+func (h *AddressHandler) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	userID, addressID, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateAddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid JSON payload", err)
+		return
+	}
+
+	address, err := h.addressService.UpdateAddress(r.Context(), userID, addressID, service.AddressUpdate{
+		Label:     req.Label,
+		Address:   req.Address,
+		IsDefault: req.IsDefault,
+	})
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, address)
+}
+
+// DeleteAddress handles DELETE /users/{userID}/addresses/{addressID}
+func (h *AddressHandler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	userID, addressID, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.addressService.DeleteAddress(r.Context(), userID, addressID); err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AddressHandler) parseUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid user ID", err)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func (h *AddressHandler) parseIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.parseUserID(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	addressID, err := uuid.Parse(chi.URLParam(r, "addressID"))
+	if err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid address ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, addressID, true
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/i18n"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/transport/http/middleware"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// ApprovalHandler handles the operator endpoints of the high-value order approval queue
+type ApprovalHandler struct {
+	approvalService *service.ApprovalService
+	responder       *OrderHandler // Shares the JSON and error responses of the order API
+	logger          logging.Logger
+}
+
+// NewApprovalHandler creates a new approval handler
+func NewApprovalHandler(approvalService *service.ApprovalService, logger logging.Logger) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService: approvalService,
+		responder:       &OrderHandler{logger: logger},
+		logger:          logger,
+	}
+}
+
+// ListPendingApprovals handles GET /approvals
+func (h *ApprovalHandler) ListPendingApprovals(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsedLimit, err := strconv.Atoi(l); err == nil && parsedLimit > 0 && parsedLimit <= 500 {
+			limit = parsedLimit
+		}
+	}
+
+	approvals, err := h.approvalService.ListPending(r.Context(), limit)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+
+	response := ApprovalListResponse{Approvals: make([]ApprovalResponse, len(approvals))}
+	for i, approval := range approvals {
+		response.Approvals[i] = convertApprovalToResponse(approval)
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, response)
+}
+
+// GetApproval handles GET /orders/{id}/approval
+func (h *ApprovalHandler) GetApproval(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := h.parseOrderID(w, r)
+	if !ok {
+		return
+	}
+
+	approval, err := h.approvalService.GetApproval(r.Context(), orderID)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, convertApprovalToResponse(approval))
+}
+
+// ApproveOrder handles POST /orders/{id}/approve. The order proceeds to payment.
+func (h *ApprovalHandler) ApproveOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, req, ok := h.parseDecision(w, r)
+	if !ok {
+		return
+	}
+
+	order, err := h.approvalService.Approve(r.Context(), orderID, req)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, h.responder.convertOrderToResponse(order, i18n.FromContext(r.Context())))
+}
+
+// RejectOrder handles POST /orders/{id}/reject. The order is cancelled.
+func (h *ApprovalHandler) RejectOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, req, ok := h.parseDecision(w, r)
+	if !ok {
+		return
+	}
+
+	order, err := h.approvalService.Reject(r.Context(), orderID, req)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, h.responder.convertOrderToResponse(order, i18n.FromContext(r.Context())))
+}
+
+func (h *ApprovalHandler) parseOrderID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid order ID", err)
+		return uuid.Nil, false
+	}
+	return orderID, true
+}
+
+func (h *ApprovalHandler) parseDecision(w http.ResponseWriter, r *http.Request) (uuid.UUID, domain.ApprovalDecisionRequest, bool) {
+	orderID, ok := h.parseOrderID(w, r)
+	if !ok {
+		return uuid.Nil, domain.ApprovalDecisionRequest{}, false
+	}
+
+	// The body is optional for approvals
+	var req ApprovalDecisionRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid JSON payload", err)
+			return uuid.Nil, domain.ApprovalDecisionRequest{}, false
+		}
+	}
+
+	operatorID, _ := middleware.UserIDFromContext(r.Context())
+	return orderID, domain.ApprovalDecisionRequest{OperatorID: operatorID, Reason: req.Reason}, true
+}
+
+func convertApprovalToResponse(approval *domain.OrderApproval) ApprovalResponse {
+	response := ApprovalResponse{
+		OrderID:          approval.OrderID,
+		UserID:           approval.UserID,
+		TotalAmount:      approval.Amount.Float64(),
+		TotalAmountMinor: approval.Amount.Minor,
+		Currency:         approval.Amount.Currency,
+		Status:           approval.Status,
+		RequestedAt:      approval.RequestedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ExpiresAt:        approval.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		DecidedBy:        approval.DecidedBy,
+		Reason:           approval.Reason,
+	}
+	if approval.DecidedAt != nil {
+		decidedAt := approval.DecidedAt.Format("2006-01-02T15:04:05Z07:00")
+		response.DecidedAt = &decidedAt
+	}
+	return response
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// BatchHandler handles bulk order creation
+type BatchHandler struct {
+	batchService *service.OrderBatchService
+	responder    *OrderHandler // Shares the JSON and error responses of the order API
+	logger       logging.Logger
+}
+
+// NewBatchHandler creates a new batch handler
+func NewBatchHandler(batchService *service.OrderBatchService, logger logging.Logger) *BatchHandler {
+	return &BatchHandler{
+		batchService: batchService,
+		responder:    &OrderHandler{logger: logger},
+		logger:       logger,
+	}
+}
+
+// CreateBatch handles POST /orders/batch. Orders are created independently, so a batch
+// succeeds even when some of its orders don't. Synchronous batches answer with the
+// result of every order and are bound by the request timeout; large batches should
+// be submitted with async, which answers 202 with a batch to poll.
+func (h *BatchHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req BatchOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error(ctx, "Failed to decode batch order request", err)
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid JSON payload", err)
+		return
+	}
+
+	orders := make([]domain.CreateOrderRequest, len(req.Orders))
+	for i, order := range req.Orders {
+		orders[i] = domain.CreateOrderRequest{
+			UserID: order.UserID,
+			Items:  make([]domain.CreateOrderItemRequest, len(order.Items)),
+		}
+		for j, item := range order.Items {
+			orders[i].Items[j] = domain.CreateOrderItemRequest{
+				ItemID:   item.ItemID,
+				Quantity: item.Quantity,
+			}
+		}
+	}
+
+	if req.Async {
+		batch, err := h.batchService.Submit(ctx, orders)
+		if err != nil {
+			h.responder.handleServiceError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", "/api/v1/orders/batches/"+batch.ID.String())
+		h.responder.respondWithJSON(w, http.StatusAccepted, h.convertBatchToResponse(batch))
+		return
+	}
+
+	results, err := h.batchService.Process(ctx, orders)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+
+	h.responder.respondWithJSON(w, http.StatusOK, BatchOrderResponse{
+		Results: h.convertResultsToResponse(results),
+		Counts:  h.countOutcomes(results),
+	})
+}
+
+// GetBatch handles GET /orders/batches/{batchID}
+func (h *BatchHandler) GetBatch(w http.ResponseWriter, r *http.Request) {
+	batchID, err := uuid.Parse(chi.URLParam(r, "batchID"))
+	if err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid batch ID", err)
+		return
+	}
+
+	batch, err := h.batchService.GetBatch(r.Context(), batchID)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+
+	h.responder.respondWithJSON(w, http.StatusOK, h.convertBatchToResponse(batch))
+}
+
+func (h *BatchHandler) convertBatchToResponse(batch *domain.OrderBatch) BatchStatusResponse {
+	return BatchStatusResponse{
+		BatchID:     batch.ID,
+		Status:      string(batch.Status),
+		OrderCount:  batch.OrderCount,
+		Processed:   len(batch.Results),
+		Results:     h.convertResultsToResponse(batch.Results),
+		Counts:      h.countOutcomes(batch.Results),
+		CreatedAt:   batch.CreatedAt,
+		CompletedAt: batch.CompletedAt,
+	}
+}
+
+func (h *BatchHandler) convertResultsToResponse(results []domain.BatchOrderResult) []BatchOrderResultResponse {
+	response := make([]BatchOrderResultResponse, len(results))
+	for i, result := range results {
+		response[i] = BatchOrderResultResponse{
+			Index:       result.Index,
+			Outcome:     string(result.Outcome),
+			OrderID:     result.OrderID,
+			OrderStatus: string(result.OrderStatus),
+			Error:       result.Error,
+		}
+	}
+	return response
+}
+
+func (h *BatchHandler) countOutcomes(results []domain.BatchOrderResult) map[string]int {
+	counts := make(map[string]int)
+	for outcome, count := range domain.CountOutcomes(results) {
+		counts[string(outcome)] = count
+	}
+	return counts
+}
@@ -1,16 +1,29 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/pagination"
 )
 
 // Request DTOs
 
 // CreateOrderRequest represents the HTTP request to create a new order
 type CreateOrderRequest struct {
-	UserID uuid.UUID                  `json:"user_id" validate:"required"`
-	Items  []CreateOrderItemRequest   `json:"items" validate:"required,min=1"`
+	UserID     uuid.UUID                  `json:"user_id" validate:"required"`
+	Items      []CreateOrderItemRequest   `json:"items" validate:"required,min=1"`
+	Expedited  bool                       `json:"expedited,omitempty"`
+	Tags       []string                   `json:"tags,omitempty"`
+	Attributes domain.OrderAttributes     `json:"attributes,omitempty"` // String, number or boolean values
+
+	// At most one of them; without either the order ships to the user's default address
+	AddressID       *uuid.UUID      `json:"address_id,omitempty"`
+	ShippingAddress *domain.Address `json:"shipping_address,omitempty"`
+
+	// all_or_nothing or partial; the service default applies when empty
+	FulfillmentPolicy domain.FulfillmentPolicy `json:"fulfillment_policy,omitempty"`
 }
 
 // CreateOrderItemRequest represents an item in the create order request
@@ -24,31 +37,75 @@ type UpdateOrderStatusRequest struct {
 	Status domain.OrderStatus `json:"status" validate:"required"`
 }
 
+// SetOrderTagsRequest replaces the tags and custom attributes of an order
+type SetOrderTagsRequest struct {
+	Tags       []string               `json:"tags"`
+	Attributes domain.OrderAttributes `json:"attributes"`
+}
+
 // Response DTOs
 
 // OrderResponse represents an order in HTTP responses
 type OrderResponse struct {
-	ID          uuid.UUID           `json:"id"`
-	UserID      uuid.UUID           `json:"user_id"`
-	Status      string              `json:"status"`
-	Items       []OrderItemResponse `json:"items"`
-	TotalAmount float64             `json:"total_amount"`
-	Currency    string              `json:"currency"`
-	CreatedAt   string              `json:"created_at"`
-	UpdatedAt   string              `json:"updated_at"`
-	PaidAt      *string             `json:"paid_at,omitempty"`
-	AssembledAt *string             `json:"assembled_at,omitempty"`
-	CompletedAt *string             `json:"completed_at,omitempty"`
+	ID               uuid.UUID              `json:"id"`
+	UserID           uuid.UUID              `json:"user_id"`
+	Status           string                 `json:"status"`
+	StatusMessage    string                 `json:"status_message"` // In the caller's locale
+	Items            []OrderItemResponse    `json:"items"`
+	TotalAmount      float64                `json:"total_amount"`
+	TotalAmountMinor int64                  `json:"total_amount_minor"` // Exact total in the currency's minor unit
+	Currency         string                 `json:"currency"`
+	CreatedAt        string                 `json:"created_at"`
+	UpdatedAt        string                 `json:"updated_at"`
+	PaidAt           *string                `json:"paid_at,omitempty"`
+	AssembledAt      *string                `json:"assembled_at,omitempty"`
+	CompletedAt      *string                `json:"completed_at,omitempty"`
+	Tags             []string               `json:"tags"`
+	Attributes       domain.OrderAttributes `json:"attributes"`
+	ShippingAddress  *domain.Address        `json:"shipping_address,omitempty"`
+
+	FulfillmentPolicy string             `json:"fulfillment_policy"`
+	Shipments         []ShipmentResponse `json:"shipments,omitempty"` // Only for partially fulfilled orders
+
+	Warnings []domain.OrderWarning `json:"warnings,omitempty"`
+
+	RefundStatus domain.RefundStatus `json:"refund_status,omitempty"` // Empty when no refund was requested
+
+	Sandbox bool `json:"sandbox,omitempty"` // Test order of a sandbox tenant; nothing was charged
+}
+
+// OrderStatusResponse represents the status of an order in long poll responses
+type OrderStatusResponse struct {
+	OrderID       uuid.UUID `json:"order_id"`
+	Status        string    `json:"status"`
+	StatusMessage string    `json:"status_message"` // In the caller's locale
+	UpdatedAt     string    `json:"updated_at"`
+	Changed       bool      `json:"changed"`
+}
+
+// ShipmentResponse represents a shipment of a partially fulfilled order in HTTP responses
+type ShipmentResponse struct {
+	ID            uuid.UUID             `json:"id"`
+	Sequence      int                   `json:"sequence"`
+	Status        string                `json:"status"`
+	Items         []domain.ShipmentItem `json:"items"`
+	Amount        float64               `json:"amount"`
+	AmountMinor   int64                 `json:"amount_minor"`
+	TransactionID string                `json:"transaction_id,omitempty"`
+	PaidAt        *string               `json:"paid_at,omitempty"`
+	AssembledAt   *string               `json:"assembled_at,omitempty"`
 }
 
 // OrderItemResponse represents an order item in HTTP responses
 type OrderItemResponse struct {
-	ID        uuid.UUID `json:"id"`
-	ItemID    string    `json:"item_id"`
-	ItemName  string    `json:"item_name"`
-	Quantity  int       `json:"quantity"`
-	UnitPrice float64   `json:"unit_price"`
-	Total     float64   `json:"total"`
+	ID             uuid.UUID `json:"id"`
+	ItemID         string    `json:"item_id"`
+	ItemName       string    `json:"item_name"`
+	Quantity       int       `json:"quantity"`
+	UnitPrice      float64   `json:"unit_price"`
+	UnitPriceMinor int64     `json:"unit_price_minor"` // Exact unit price in the currency's minor unit
+	Total          float64   `json:"total"`
+	TotalMinor     int64     `json:"total_minor"`
 }
 
 // UserOrdersResponse represents the response for user orders endpoint
@@ -59,8 +116,9 @@ type UserOrdersResponse struct {
 
 // OrderListResponse represents the response for orders list endpoint
 type OrderListResponse struct {
-	Orders []OrderResponse `json:"orders"`
-	Filter FilterResponse  `json:"filter"`
+	Orders   []OrderResponse     `json:"orders"`
+	Filter   FilterResponse      `json:"filter"`
+	PageInfo pagination.PageInfo `json:"page_info"`
 }
 
 // PaginationResponse represents pagination information
@@ -72,10 +130,14 @@ type PaginationResponse struct {
 
 // FilterResponse represents applied filters
 type FilterResponse struct {
-	UserID *uuid.UUID           `json:"user_id,omitempty"`
-	Status *domain.OrderStatus  `json:"status,omitempty"`
-	Limit  int                  `json:"limit"`
-	Offset int                  `json:"offset"`
+	UserID      *uuid.UUID          `json:"user_id,omitempty"`
+	Status      *domain.OrderStatus `json:"status,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Attributes  map[string]string   `json:"attributes,omitempty"`
+	CreatedFrom *time.Time          `json:"created_from,omitempty"`
+	CreatedTo   *time.Time          `json:"created_to,omitempty"`
+	Limit       int                 `json:"limit"`
+	Offset      int                 `json:"offset"`
 }
 
 // MetricsResponse represents order metrics
@@ -108,4 +170,205 @@ type SuccessResponse struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
-}
\ No newline at end of file
+}
+// CreateWebhookRequest represents the request to register an order webhook
+type CreateWebhookRequest struct {
+	URL         string               `json:"url" validate:"required,url"`
+	Description string               `json:"description,omitempty"`
+	Statuses    []domain.OrderStatus `json:"statuses,omitempty"` // Empty subscribes to every status
+}
+
+// UpdateWebhookRequest represents the request to update an order webhook; omitted fields are unchanged
+type UpdateWebhookRequest struct {
+	URL         *string               `json:"url,omitempty"`
+	Description *string               `json:"description,omitempty"`
+	Statuses    *[]domain.OrderStatus `json:"statuses,omitempty"`
+	Active      *bool                 `json:"active,omitempty"`
+}
+
+// WebhookResponse represents an order webhook in HTTP responses
+type WebhookResponse struct {
+	ID          uuid.UUID            `json:"id"`
+	UserID      uuid.UUID            `json:"user_id"`
+	URL         string               `json:"url"`
+	Description string               `json:"description,omitempty"`
+	Statuses    []domain.OrderStatus `json:"statuses"`
+	Active      bool                 `json:"active"`
+	CreatedAt   string               `json:"created_at"`
+	UpdatedAt   string               `json:"updated_at"`
+}
+
+// CreateWebhookResponse includes the signing secret, which is only returned on creation
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+// WebhookListResponse represents the response for the webhooks list endpoint
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+// WebhookDeliveriesResponse represents the delivery history of a webhook
+type WebhookDeliveriesResponse struct {
+	Deliveries []*domain.WebhookDelivery `json:"deliveries"`
+}
+
+// CreateAddressRequest represents the request to add an address to a user's address book
+type CreateAddressRequest struct {
+	domain.Address
+	Label     string `json:"label,omitempty"`
+	IsDefault bool   `json:"is_default,omitempty"` // The first address is always the default
+}
+
+// UpdateAddressRequest represents the request to update an address book entry; omitted
+// fields are unchanged and a given address replaces the stored one
+type UpdateAddressRequest struct {
+	Label     *string         `json:"label,omitempty"`
+	Address   *domain.Address `json:"address,omitempty"`
+	IsDefault *bool           `json:"is_default,omitempty"`
+}
+
+// AddressListResponse represents the response for the address book endpoint
+type AddressListResponse struct {
+	Addresses []*domain.SavedAddress `json:"addresses"`
+}
+
+// ApprovalDecisionRequest represents an operator's approve or reject decision; rejections need a reason
+type ApprovalDecisionRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// ApprovalResponse represents an order approval in HTTP responses
+type ApprovalResponse struct {
+	OrderID          uuid.UUID             `json:"order_id"`
+	UserID           uuid.UUID             `json:"user_id"`
+	TotalAmount      float64               `json:"total_amount"`
+	TotalAmountMinor int64                 `json:"total_amount_minor"`
+	Currency         string                `json:"currency"`
+	Status           domain.ApprovalStatus `json:"status"`
+	RequestedAt      string                `json:"requested_at"`
+	ExpiresAt        string                `json:"expires_at"`
+	DecidedBy        *uuid.UUID            `json:"decided_by,omitempty"`
+	DecidedAt        *string               `json:"decided_at,omitempty"`
+	Reason           string                `json:"reason,omitempty"`
+}
+
+// ApprovalListResponse represents the response for the approval queue endpoint
+type ApprovalListResponse struct {
+	Approvals []ApprovalResponse `json:"approvals"`
+}
+
+// RefundRequestBody represents a customer's request to refund their order
+type RefundRequestBody struct {
+	Reason string `json:"reason"`
+}
+
+// RefundDecisionBody represents support's approve or reject decision on a refund; rejections need a note
+type RefundDecisionBody struct {
+	Note string `json:"note,omitempty"`
+}
+
+// RefundResponse represents the refund case of an order in HTTP responses
+type RefundResponse struct {
+	OrderID         uuid.UUID           `json:"order_id"`
+	UserID          uuid.UUID           `json:"user_id"`
+	Amount          float64             `json:"amount"`
+	AmountMinor     int64               `json:"amount_minor"`
+	Currency        string              `json:"currency"`
+	Reason          string              `json:"reason"`
+	Status          domain.RefundStatus `json:"status"`
+	RequestedAt     string              `json:"requested_at"`
+	DecidedBy       *uuid.UUID          `json:"decided_by,omitempty"`
+	DecidedAt       *string             `json:"decided_at,omitempty"`
+	Note            string              `json:"note,omitempty"`
+	PaymentRefundID string              `json:"payment_refund_id,omitempty"`
+	RefundedAt      *string             `json:"refunded_at,omitempty"`
+	FailureReason   string              `json:"failure_reason,omitempty"`
+}
+
+// RefundListResponse represents the response for the refund queue endpoint
+type RefundListResponse struct {
+	Refunds []RefundResponse `json:"refunds"`
+}
+
+// OrderReportResponse represents an order report in HTTP responses
+type OrderReportResponse struct {
+	OrderID          uuid.UUID          `json:"order_id"`
+	UserID           *uuid.UUID         `json:"user_id,omitempty"`
+	Status           domain.OrderStatus `json:"status"`
+	TotalAmount      float64            `json:"total_amount"`
+	TotalAmountMinor int64              `json:"total_amount_minor"`
+	Currency         string             `json:"currency"`
+	ItemCount        int                `json:"item_count"`
+	TransactionID    string             `json:"transaction_id,omitempty"`
+	PaymentStatus    string             `json:"payment_status,omitempty"`
+	AssemblyStatus   string             `json:"assembly_status,omitempty"`
+	AssemblySeconds  int                `json:"assembly_seconds,omitempty"`
+	FailureReason    string             `json:"failure_reason,omitempty"`
+	CreatedAt        *time.Time         `json:"created_at,omitempty"`
+	PaidAt           *time.Time         `json:"paid_at,omitempty"`
+	AssembledAt      *time.Time         `json:"assembled_at,omitempty"`
+	CompletedAt      *time.Time         `json:"completed_at,omitempty"`
+	CancelledAt      *time.Time         `json:"cancelled_at,omitempty"`
+	FailedAt         *time.Time         `json:"failed_at,omitempty"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+// OrderReportListResponse represents the response for the order report list endpoint
+type OrderReportListResponse struct {
+	Reports []OrderReportResponse `json:"reports"`
+	Limit   int                   `json:"limit"`
+	Offset  int                   `json:"offset"`
+}
+
+// ReportSummaryResponse represents the aggregated order reports of a period
+type ReportSummaryResponse struct {
+	From                   time.Time                  `json:"from"`
+	To                     time.Time                  `json:"to"`
+	OrderCount             int                        `json:"order_count"`
+	ByStatus               map[domain.OrderStatus]int `json:"by_status"`
+	Revenue                []RevenueResponse          `json:"revenue"`
+	AssemblyFailures       int                        `json:"assembly_failures"`
+	AvgAssemblyTimeSeconds float64                    `json:"avg_assembly_time_seconds"`
+}
+
+// RevenueResponse represents the revenue of a period in one currency
+type RevenueResponse struct {
+	Amount      float64 `json:"amount"`
+	AmountMinor int64   `json:"amount_minor"`
+	Currency    string  `json:"currency"`
+}
+
+// BatchOrderRequest represents the request to create a batch of orders
+type BatchOrderRequest struct {
+	Orders []CreateOrderRequest `json:"orders"`
+	Async  bool                 `json:"async"` // Return a batch ID at once and create the orders in the background
+}
+
+// BatchOrderResultResponse represents the outcome of one order of a batch
+type BatchOrderResultResponse struct {
+	Index       int        `json:"index"`
+	Outcome     string     `json:"outcome"`
+	OrderID     *uuid.UUID `json:"order_id,omitempty"`
+	OrderStatus string     `json:"order_status,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// BatchOrderResponse represents the results of a batch, in request order
+type BatchOrderResponse struct {
+	Results []BatchOrderResultResponse `json:"results"`
+	Counts  map[string]int             `json:"counts"`
+}
+
+// BatchStatusResponse represents the progress of an asynchronous batch
+type BatchStatusResponse struct {
+	BatchID     uuid.UUID                  `json:"batch_id"`
+	Status      string                     `json:"status"`
+	OrderCount  int                        `json:"order_count"`
+	Processed   int                        `json:"processed"`
+	Results     []BatchOrderResultResponse `json:"results"`
+	Counts      map[string]int             `json:"counts"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	CompletedAt *time.Time                 `json:"completed_at,omitempty"`
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// ExportHandler handles the CSV and XLSX order exports of account managers
+type ExportHandler struct {
+	exportService *service.OrderExportService
+	responder     *OrderHandler // Shares the filters, JSON and error responses of the order API
+	logger        logging.Logger
+}
+
+// NewExportHandler creates a new export handler
+func NewExportHandler(exportService *service.OrderExportService, logger logging.Logger) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		responder:     &OrderHandler{logger: logger},
+		logger:        logger,
+	}
+}
+
+// ExportOrders handles GET /orders/export. It takes the filters of GET /orders,
+// a format of csv (default) or xlsx and a comma separated list of columns. Small
+// exports are streamed as an attachment; exports above the configured row count,
+// or requested with async=true, answer 202 with a job to poll for the download URL.
+func (h *ExportHandler) ExportOrders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	req := domain.OrderExportRequest{
+		Filter: h.responder.parseOrderFilter(r),
+		Format: domain.OrderExportFormat(strings.ToLower(query.Get("format"))),
+	}
+	for _, column := range strings.Split(query.Get("columns"), ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			req.Columns = append(req.Columns, column)
+		}
+	}
+
+	rows, err := h.exportService.Prepare(ctx, &req)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+
+	async, _ := strconv.ParseBool(query.Get("async"))
+	if async || !h.exportService.Streamable(rows) {
+		requestedBy, _ := ctxmeta.UserID(ctx)
+		export, err := h.exportService.Submit(ctx, req, requestedBy)
+		if err != nil {
+			h.responder.handleServiceError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", "/api/v1/orders/exports/"+export.ID.String())
+		h.responder.respondWithJSON(w, http.StatusAccepted, export)
+		return
+	}
+
+	filename := fmt.Sprintf("orders-%s.%s", time.Now().UTC().Format("20060102T150405Z"), req.Format)
+	w.Header().Set("Content-Type", req.Format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+
+	// The status is sent already; a failure truncates the file and is only logged
+	if err := h.exportService.Stream(ctx, w, req); err != nil {
+		h.logger.Error(ctx, "Order export stream aborted", err, map[string]interface{}{
+			"rows": rows,
+		})
+	}
+}
+
+// GetExport handles GET /orders/exports/{exportID}
+func (h *ExportHandler) GetExport(w http.ResponseWriter, r *http.Request) {
+	exportID, err := uuid.Parse(chi.URLParam(r, "exportID"))
+	if err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid export ID", err)
+		return
+	}
+
+	export, err := h.exportService.GetExport(r.Context(), exportID)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+
+	h.responder.respondWithJSON(w, http.StatusOK, export)
+}
+
+// DownloadExport serves the files of background exports to holders of a signed URL
+func (h *ExportHandler) DownloadExport() http.Handler {
+	return h.exportService.DownloadHandler()
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -11,8 +13,11 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/i18n"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/pagination"
 	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/tracing"
 )
@@ -43,20 +48,28 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	var req CreateOrderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.logger.Error(ctx, "Failed to decode create order request", err)
-		h.respondWithError(w, http.StatusBadRequest, "Invalid JSON payload", err)
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid JSON payload", err)
 		return
 	}
 
 	// Validate request
 	if err := h.validateCreateOrderRequest(req); err != nil {
-		h.respondWithError(w, http.StatusBadRequest, "Invalid request", err)
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
 	// Convert to domain request
 	domainReq := domain.CreateOrderRequest{
-		UserID: req.UserID,
-		Items:  make([]domain.CreateOrderItemRequest, len(req.Items)),
+		UserID:     req.UserID,
+		Items:      make([]domain.CreateOrderItemRequest, len(req.Items)),
+		Expedited:  req.Expedited,
+		Tags:       req.Tags,
+		Attributes: req.Attributes,
+
+		AddressID:       req.AddressID,
+		ShippingAddress: req.ShippingAddress,
+
+		FulfillmentPolicy: req.FulfillmentPolicy,
 	}
 
 	for i, item := range req.Items {
@@ -69,17 +82,17 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	// Create order
 	order, err := h.orderService.CreateOrder(ctx, domainReq)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
 	// Convert to response
-	response := h.convertOrderToResponse(order)
+	response := h.convertOrderToResponse(order, i18n.FromContext(r.Context()))
 
 	h.logger.Info(ctx, "Order created successfully", map[string]interface{}{
 		"order_id": order.ID,
 		"user_id":  order.UserID,
-		"total":    order.TotalAmount,
+		"total":    order.TotalAmount.String(),
 	})
 
 	h.respondWithJSON(w, http.StatusCreated, response)
@@ -91,7 +104,7 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 
 	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		h.respondWithError(w, http.StatusBadRequest, "Invalid order ID", err)
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid order ID", err)
 		return
 	}
 
@@ -99,12 +112,56 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 
 	order, err := h.orderService.GetOrder(ctx, orderID)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
-	response := h.convertOrderToResponse(order)
-	h.respondWithJSON(w, http.StatusOK, response)
+	response := h.convertOrderToResponse(order, i18n.FromContext(r.Context()))
+	h.respondWithETag(w, r, response)
+}
+
+// GetOrderStatus handles GET /orders/{id}/status. With ?wait=30s the request is
+// held until the status differs from ?since (the current status by default) or
+// the wait passes; changed in the response tells which.
+func (h *OrderHandler) GetOrderStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid order ID", err)
+		return
+	}
+
+	var wait time.Duration
+	if raw := r.URL.Query().Get("wait"); raw != "" {
+		wait, err = time.ParseDuration(raw)
+		if err != nil || wait < 0 {
+			h.respondWithError(w, r, http.StatusBadRequest, "Invalid wait duration", err)
+			return
+		}
+	}
+
+	since := r.URL.Query().Get("since")
+	if since != "" && !h.isValidOrderStatus(since) {
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid since status", nil)
+		return
+	}
+
+	tracing.AddSpanAttributes(ctx, tracing.OrderIDKey.String(orderID.String()))
+
+	order, changed, err := h.orderService.WaitForStatusChange(ctx, orderID, domain.OrderStatus(since), wait)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, OrderStatusResponse{
+		OrderID:       order.ID,
+		Status:        string(order.Status),
+		StatusMessage: i18n.StatusMessage(i18n.FromContext(ctx), order.Status),
+		UpdatedAt:     order.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Changed:       changed,
+	})
 }
 
 // GetUserOrders handles GET /users/{userID}/orders
@@ -113,7 +170,7 @@ func (h *OrderHandler) GetUserOrders(w http.ResponseWriter, r *http.Request) {
 
 	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
 	if err != nil {
-		h.respondWithError(w, http.StatusBadRequest, "Invalid user ID", err)
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid user ID", err)
 		return
 	}
 
@@ -124,7 +181,7 @@ func (h *OrderHandler) GetUserOrders(w http.ResponseWriter, r *http.Request) {
 
 	orders, err := h.orderService.GetUserOrders(ctx, userID, limit, offset)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
@@ -139,7 +196,7 @@ func (h *OrderHandler) GetUserOrders(w http.ResponseWriter, r *http.Request) {
 	}
 
 	for i, order := range orders {
-		response.Orders[i] = h.convertOrderToResponse(order)
+		response.Orders[i] = h.convertOrderToResponse(order, i18n.FromContext(r.Context()))
 	}
 
 	h.respondWithJSON(w, http.StatusOK, response)
@@ -152,9 +209,9 @@ func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
 	filter := h.parseOrderFilter(r)
 
-	orders, err := h.orderService.ListOrders(ctx, filter)
+	orders, pageInfo, err := h.orderService.ListOrders(ctx, filter)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
@@ -162,15 +219,20 @@ func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 	response := OrderListResponse{
 		Orders: make([]OrderResponse, len(orders)),
 		Filter: FilterResponse{
-			UserID: filter.UserID,
-			Status: filter.Status,
-			Limit:  filter.Limit,
-			Offset: filter.Offset,
+			UserID:      filter.UserID,
+			Status:      filter.Status,
+			Tags:        filter.Tags,
+			Attributes:  filter.Attributes,
+			CreatedFrom: filter.CreatedFrom,
+			CreatedTo:   filter.CreatedTo,
+			Limit:       filter.Limit,
+			Offset:      filter.Offset,
 		},
+		PageInfo: pageInfo,
 	}
 
 	for i, order := range orders {
-		response.Orders[i] = h.convertOrderToResponse(order)
+		response.Orders[i] = h.convertOrderToResponse(order, i18n.FromContext(r.Context()))
 	}
 
 	h.respondWithJSON(w, http.StatusOK, response)
@@ -182,25 +244,25 @@ func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 
 	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		h.respondWithError(w, http.StatusBadRequest, "Invalid order ID", err)
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid order ID", err)
 		return
 	}
 
 	var req UpdateOrderStatusRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondWithError(w, http.StatusBadRequest, "Invalid JSON payload", err)
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid JSON payload", err)
 		return
 	}
 
 	// Validate status
 	if !h.isValidOrderStatus(string(req.Status)) {
-		h.respondWithError(w, http.StatusBadRequest, "Invalid order status", nil)
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid order status", nil)
 		return
 	}
 
 	err = h.orderService.UpdateOrderStatus(ctx, orderID, domain.OrderStatus(req.Status))
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
@@ -219,13 +281,106 @@ func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 	h.respondWithJSON(w, http.StatusOK, response)
 }
 
+// SetOrderTags handles PUT /orders/{id}/tags, replacing the order's tags and attributes
+func (h *OrderHandler) SetOrderTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid order ID", err)
+		return
+	}
+
+	tracing.AddSpanAttributes(ctx, tracing.OrderIDKey.String(orderID.String()))
+
+	var req SetOrderTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid JSON payload", err)
+		return
+	}
+
+	order, err := h.orderService.SetOrderTags(ctx, orderID, req.Tags, req.Attributes)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, h.convertOrderToResponse(order, i18n.FromContext(r.Context())))
+}
+
+// FulfillBackorder handles POST /orders/{id}/backorder/fulfill, paying and assembling
+// the backordered items of a partially fulfilled order once they are in stock
+func (h *OrderHandler) FulfillBackorder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid order ID", err)
+		return
+	}
+
+	tracing.AddSpanAttributes(ctx, tracing.OrderIDKey.String(orderID.String()))
+
+	order, err := h.orderService.FulfillBackorder(ctx, orderID)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, h.convertOrderToResponse(order, i18n.FromContext(r.Context())))
+}
+
+// CancelBackorder handles DELETE /orders/{id}/backorder, giving up the backordered
+// items of a partially fulfilled order
+func (h *OrderHandler) CancelBackorder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid order ID", err)
+		return
+	}
+
+	tracing.AddSpanAttributes(ctx, tracing.OrderIDKey.String(orderID.String()))
+
+	order, err := h.orderService.CancelBackorder(ctx, orderID)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, h.convertOrderToResponse(order, i18n.FromContext(r.Context())))
+}
+
+// GetOrderSaga handles GET /admin/orders/{id}/saga, showing operators the recorded
+// saga steps of an order with their attempts, errors and compensations
+func (h *OrderHandler) GetOrderSaga(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid order ID", err)
+		return
+	}
+
+	tracing.AddSpanAttributes(ctx, tracing.OrderIDKey.String(orderID.String()))
+
+	timeline, err := h.orderService.GetSagaTimeline(ctx, orderID)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, timeline)
+}
+
 // GetOrderMetrics handles GET /orders/metrics
 func (h *OrderHandler) GetOrderMetrics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	metrics, err := h.orderService.GetOrderMetrics(ctx)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleServiceError(w, r, err)
 		return
 	}
 
@@ -293,6 +448,9 @@ func (h *OrderHandler) parsePaginationParams(r *http.Request) (limit, offset int
 	return limit, offset
 }
 
+// orderPageLimits are the page sizes of GET /orders
+var orderPageLimits = pagination.Limits{Default: 50, Max: 100}
+
 func (h *OrderHandler) parseOrderFilter(r *http.Request) domain.OrderFilter {
 	filter := domain.OrderFilter{}
 
@@ -309,8 +467,41 @@ func (h *OrderHandler) parseOrderFilter(r *http.Request) domain.OrderFilter {
 		filter.Status = &status
 	}
 
-	// Parse pagination
+	// Parse tag filters, e.g. ?tag=mission:artemis-3&attr.mission_id=ART-3
+	for _, tag := range r.URL.Query()["tag"] {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+	for key, values := range r.URL.Query() {
+		name, ok := strings.CutPrefix(key, "attr.")
+		if !ok || name == "" || len(values) == 0 {
+			continue
+		}
+		if filter.Attributes == nil {
+			filter.Attributes = make(map[string]string)
+		}
+		filter.Attributes[name] = values[0]
+	}
+
+	// Parse the creation time range, e.g. ?created_from=2024-05-01T00:00:00Z
+	if createdFrom, err := time.Parse(time.RFC3339, r.URL.Query().Get("created_from")); err == nil {
+		filter.CreatedFrom = &createdFrom
+	}
+	if createdTo, err := time.Parse(time.RFC3339, r.URL.Query().Get("created_to")); err == nil {
+		filter.CreatedTo = &createdTo
+	}
+
+	// Parse pagination; page_size and page_token replace limit and offset
 	filter.Limit, filter.Offset = h.parsePaginationParams(r)
+	if pageSize := r.URL.Query().Get("page_size"); pageSize != "" {
+		size, _ := strconv.Atoi(pageSize)
+		filter.Limit = orderPageLimits.PageSize(size)
+	}
+	if pageToken := r.URL.Query().Get("page_token"); pageToken != "" {
+		filter.PageToken = pageToken
+		filter.Offset = 0
+	}
 
 	return filter
 }
@@ -318,7 +509,11 @@ func (h *OrderHandler) parseOrderFilter(r *http.Request) domain.OrderFilter {
 func (h *OrderHandler) isValidOrderStatus(status string) bool {
 	validStatuses := []string{
 		string(domain.StatusPending),
+		string(domain.StatusPendingApproval),
+		string(domain.StatusPendingReview),
+		string(domain.StatusBackordered),
 		string(domain.StatusPaid),
+		string(domain.StatusPartiallyAssembled),
 		string(domain.StatusAssembled),
 		string(domain.StatusCompleted),
 		string(domain.StatusCancelled),
@@ -333,16 +528,37 @@ func (h *OrderHandler) isValidOrderStatus(status string) bool {
 	return false
 }
 
-func (h *OrderHandler) convertOrderToResponse(order *domain.Order) OrderResponse {
+// convertOrderToResponse converts an order, with its status and warning messages
+// in the locale
+func (h *OrderHandler) convertOrderToResponse(order *domain.Order, locale string) OrderResponse {
 	response := OrderResponse{
-		ID:          order.ID,
-		UserID:      order.UserID,
-		Status:      string(order.Status),
-		TotalAmount: order.TotalAmount,
-		Currency:    order.Currency,
-		CreatedAt:   order.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:   order.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		Items:       make([]OrderItemResponse, len(order.Items)),
+		ID:               order.ID,
+		UserID:           order.UserID,
+		Status:           string(order.Status),
+		StatusMessage:    i18n.StatusMessage(locale, order.Status),
+		TotalAmount:      order.TotalAmount.Float64(),
+		TotalAmountMinor: order.TotalAmount.Minor,
+		Currency:         order.Currency,
+		CreatedAt:        order.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:        order.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Items:            make([]OrderItemResponse, len(order.Items)),
+		Tags:             order.Tags,
+		Attributes:       order.Attributes,
+		ShippingAddress:  order.ShippingAddress,
+
+		FulfillmentPolicy: string(order.FulfillmentPolicy),
+		RefundStatus:      order.RefundStatus,
+		Sandbox:           order.Sandbox,
+	}
+	for _, warning := range order.Warnings {
+		warning.Message = i18n.WarningMessage(locale, warning)
+		response.Warnings = append(response.Warnings, warning)
+	}
+	if response.Tags == nil {
+		response.Tags = []string{}
+	}
+	if response.Attributes == nil {
+		response.Attributes = domain.OrderAttributes{}
 	}
 
 	// Add optional timestamps
@@ -362,13 +578,36 @@ func (h *OrderHandler) convertOrderToResponse(order *domain.Order) OrderResponse
 	// Convert items
 	for i, item := range order.Items {
 		response.Items[i] = OrderItemResponse{
-			ID:        item.ID,
-			ItemID:    item.ItemID,
-			ItemName:  item.ItemName,
-			Quantity:  item.Quantity,
-			UnitPrice: item.UnitPrice,
-			Total:     item.Total,
+			ID:             item.ID,
+			ItemID:         item.ItemID,
+			ItemName:       item.ItemName,
+			Quantity:       item.Quantity,
+			UnitPrice:      item.UnitPrice.Float64(),
+			UnitPriceMinor: item.UnitPrice.Minor,
+			Total:          item.Total.Float64(),
+			TotalMinor:     item.Total.Minor,
+		}
+	}
+
+	for _, shipment := range order.Shipments {
+		shipmentResponse := ShipmentResponse{
+			ID:            shipment.ID,
+			Sequence:      shipment.Sequence,
+			Status:        string(shipment.Status),
+			Items:         shipment.Items,
+			Amount:        shipment.Amount.Float64(),
+			AmountMinor:   shipment.Amount.Minor,
+			TransactionID: shipment.TransactionID,
+		}
+		if shipment.PaidAt != nil {
+			paidAt := shipment.PaidAt.Format("2006-01-02T15:04:05Z07:00")
+			shipmentResponse.PaidAt = &paidAt
+		}
+		if shipment.AssembledAt != nil {
+			assembledAt := shipment.AssembledAt.Format("2006-01-02T15:04:05Z07:00")
+			shipmentResponse.AssembledAt = &assembledAt
 		}
+		response.Shipments = append(response.Shipments, shipmentResponse)
 	}
 
 	return response
@@ -387,12 +626,59 @@ func (h *OrderHandler) respondWithJSON(w http.ResponseWriter, statusCode int, pa
 	w.Write(response)
 }
 
-func (h *OrderHandler) respondWithError(w http.ResponseWriter, statusCode int, message string, err error) {
+// respondWithETag writes the payload with an ETag derived from its content and
+// answers 304 Not Modified when the client's If-None-Match already matches
+func (h *OrderHandler) respondWithETag(w http.ResponseWriter, r *http.Request, payload interface{}) {
+	response, err := json.Marshal(payload)
+	if err != nil {
+		h.logger.Error(nil, "Failed to marshal JSON response", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(response)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("Vary", "Accept-Language") // Messages are localized
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(response)
+}
+
+// etagMatches reports whether an If-None-Match header value matches the given ETag,
+// using the weak comparison required for GET requests
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// respondWithError answers with the message in the caller's locale; logs keep it
+// in English
+func (h *OrderHandler) respondWithError(w http.ResponseWriter, r *http.Request, statusCode int, message string, err error) {
+	locale := i18n.FromContext(r.Context())
 	errorResponse := ErrorResponse{
-		Error:   message,
+		Error:   i18n.Translate(locale, message),
 		Code:    statusCode,
 		Details: "",
 	}
+	w.Header().Set("Content-Language", locale)
 
 	if err != nil {
 		errorResponse.Details = err.Error()
@@ -402,18 +688,21 @@ func (h *OrderHandler) respondWithError(w http.ResponseWriter, statusCode int, m
 	h.respondWithJSON(w, statusCode, errorResponse)
 }
 
-func (h *OrderHandler) handleServiceError(w http.ResponseWriter, err error) {
+func (h *OrderHandler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
 	switch {
 	case errors.IsNotFound(err):
-		h.respondWithError(w, http.StatusNotFound, "Resource not found", err)
+		h.respondWithError(w, r, http.StatusNotFound, "Resource not found", err)
 	case errors.IsValidation(err):
-		h.respondWithError(w, http.StatusBadRequest, "Validation error", err)
+		h.respondWithError(w, r, http.StatusBadRequest, "Validation error", err)
 	case errors.IsConflict(err):
-		h.respondWithError(w, http.StatusConflict, "Conflict error", err)
+		h.respondWithError(w, r, http.StatusConflict, "Conflict error", err)
 	case errors.IsExternal(err):
-		h.respondWithError(w, http.StatusBadGateway, "External service error", err)
+		h.respondWithError(w, r, http.StatusBadGateway, "External service error", err)
+	case errors.IsUnavailable(err):
+		maintenance.SetRetryAfter(w)
+		h.respondWithError(w, r, http.StatusServiceUnavailable, "Service temporarily unavailable", err)
 	default:
 		h.logger.Error(nil, "Internal server error", err)
-		h.respondWithError(w, http.StatusInternalServerError, "Internal server error", nil)
+		h.respondWithError(w, r, http.StatusInternalServerError, "Internal server error", nil)
 	}
 }
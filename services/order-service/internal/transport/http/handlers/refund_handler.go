@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/transport/http/middleware"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// RefundHandler handles customers' refund requests and their review by support
+type RefundHandler struct {
+	refundService *service.RefundService
+	responder     *OrderHandler // Shares the JSON and error responses of the order API
+	logger        logging.Logger
+}
+
+// NewRefundHandler creates a new refund handler
+func NewRefundHandler(refundService *service.RefundService, logger logging.Logger) *RefundHandler {
+	return &RefundHandler{
+		refundService: refundService,
+		responder:     &OrderHandler{logger: logger},
+		logger:        logger,
+	}
+}
+
+// RequestRefund handles POST /orders/{id}/refund-request for the customer who placed the order
+func (h *RefundHandler) RequestRefund(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := h.parseOrderID(w, r)
+	if !ok {
+		return
+	}
+
+	customerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		h.responder.respondWithError(w, r, http.StatusUnauthorized, "Missing authentication", nil)
+		return
+	}
+
+	var req RefundRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid JSON payload", err)
+		return
+	}
+
+	refund, err := h.refundService.RequestRefund(r.Context(), orderID, domain.RefundRequest{
+		CustomerID: customerID,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusCreated, convertRefundToResponse(refund))
+}
+
+// ListOpenRefunds handles GET /refunds
+func (h *RefundHandler) ListOpenRefunds(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsedLimit, err := strconv.Atoi(l); err == nil && parsedLimit > 0 && parsedLimit <= 500 {
+			limit = parsedLimit
+		}
+	}
+
+	refunds, err := h.refundService.ListOpen(r.Context(), limit)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+
+	response := RefundListResponse{Refunds: make([]RefundResponse, len(refunds))}
+	for i, refund := range refunds {
+		response.Refunds[i] = convertRefundToResponse(refund)
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, response)
+}
+
+// GetRefund handles GET /orders/{id}/refund
+func (h *RefundHandler) GetRefund(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := h.parseOrderID(w, r)
+	if !ok {
+		return
+	}
+
+	refund, err := h.refundService.GetRefund(r.Context(), orderID)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, convertRefundToResponse(refund))
+}
+
+// ApproveRefund handles POST /orders/{id}/refund/approve. The payment is refunded
+// and the items returned to stock.
+func (h *RefundHandler) ApproveRefund(w http.ResponseWriter, r *http.Request) {
+	orderID, req, ok := h.parseDecision(w, r)
+	if !ok {
+		return
+	}
+
+	refund, err := h.refundService.Approve(r.Context(), orderID, req)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, convertRefundToResponse(refund))
+}
+
+// RejectRefund handles POST /orders/{id}/refund/reject. The order stands.
+func (h *RefundHandler) RejectRefund(w http.ResponseWriter, r *http.Request) {
+	orderID, req, ok := h.parseDecision(w, r)
+	if !ok {
+		return
+	}
+
+	refund, err := h.refundService.Reject(r.Context(), orderID, req)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, convertRefundToResponse(refund))
+}
+
+func (h *RefundHandler) parseOrderID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid order ID", err)
+		return uuid.Nil, false
+	}
+	return orderID, true
+}
+
+func (h *RefundHandler) parseDecision(w http.ResponseWriter, r *http.Request) (uuid.UUID, domain.RefundDecisionRequest, bool) {
+	orderID, ok := h.parseOrderID(w, r)
+	if !ok {
+		return uuid.Nil, domain.RefundDecisionRequest{}, false
+	}
+
+	// The body is optional for approvals
+	var req RefundDecisionBody
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid JSON payload", err)
+			return uuid.Nil, domain.RefundDecisionRequest{}, false
+		}
+	}
+
+	operatorID, _ := middleware.UserIDFromContext(r.Context())
+	return orderID, domain.RefundDecisionRequest{OperatorID: operatorID, Note: req.Note}, true
+}
+
+func convertRefundToResponse(refund *domain.RefundCase) RefundResponse {
+	response := RefundResponse{
+		OrderID:         refund.OrderID,
+		UserID:          refund.UserID,
+		Amount:          refund.Amount.Float64(),
+		AmountMinor:     refund.Amount.Minor,
+		Currency:        refund.Amount.Currency,
+		Reason:          refund.Reason,
+		Status:          refund.Status,
+		RequestedAt:     refund.RequestedAt.Format("2006-01-02T15:04:05Z07:00"),
+		DecidedBy:       refund.DecidedBy,
+		Note:            refund.Note,
+		PaymentRefundID: refund.PaymentRefundID,
+		FailureReason:   refund.FailureReason,
+	}
+	if refund.DecidedAt != nil {
+		decidedAt := refund.DecidedAt.Format("2006-01-02T15:04:05Z07:00")
+		response.DecidedAt = &decidedAt
+	}
+	if refund.RefundedAt != nil {
+		refundedAt := refund.RefundedAt.Format("2006-01-02T15:04:05Z07:00")
+		response.RefundedAt = &refundedAt
+	}
+	return response
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/i18n"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// defaultSummaryPeriod is summarized when a summary request has no from
+const defaultSummaryPeriod = 30 * 24 * time.Hour
+
+// ReportHandler handles the order reporting endpoints, served from the reporting store
+type ReportHandler struct {
+	reportingService *service.ReportingService
+	responder        *OrderHandler // Shares the JSON and error responses of the order API
+	logger           logging.Logger
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(reportingService *service.ReportingService, logger logging.Logger) *ReportHandler {
+	return &ReportHandler{
+		reportingService: reportingService,
+		responder:        &OrderHandler{logger: logger},
+		logger:           logger,
+	}
+}
+
+// ListOrderReports handles GET /reports/orders. Orders can be filtered by user_id,
+// status and a from/to creation period in RFC 3339.
+func (h *ReportHandler) ListOrderReports(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.ReportFilter{}
+
+	if userID := query.Get("user_id"); userID != "" {
+		parsed, err := uuid.Parse(userID)
+		if err != nil {
+			h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid user ID", err)
+			return
+		}
+		filter.UserID = &parsed
+	}
+	if status := query.Get("status"); status != "" {
+		if !h.responder.isValidOrderStatus(status) {
+			h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid order status", nil)
+			return
+		}
+		orderStatus := domain.OrderStatus(status)
+		filter.Status = &orderStatus
+	}
+
+	var ok bool
+	if filter.CreatedAfter, ok = h.parseTime(w, r, "from"); !ok {
+		return
+	}
+	if filter.CreatedBefore, ok = h.parseTime(w, r, "to"); !ok {
+		return
+	}
+	if filter.Limit, ok = h.parseInt(w, r, "limit"); !ok {
+		return
+	}
+	if filter.Offset, ok = h.parseInt(w, r, "offset"); !ok {
+		return
+	}
+
+	reports, err := h.reportingService.ListOrderReports(r.Context(), filter)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+
+	response := OrderReportListResponse{
+		Reports: make([]OrderReportResponse, len(reports)),
+		Limit:   filter.Limit,
+		Offset:  filter.Offset,
+	}
+	if response.Limit == 0 {
+		response.Limit = service.DefaultReportLimit
+	}
+	for i, report := range reports {
+		response.Reports[i] = convertReportToResponse(report)
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, response)
+}
+
+// GetOrderReport handles GET /reports/orders/{id}
+func (h *ReportHandler) GetOrderReport(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid order ID", err)
+		return
+	}
+
+	report, err := h.reportingService.GetOrderReport(r.Context(), orderID)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, convertReportToResponse(report))
+}
+
+// GetSummary handles GET /reports/summary. The period defaults to the last 30 days.
+func (h *ReportHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	from, ok := h.parseTime(w, r, "from")
+	if !ok {
+		return
+	}
+	to, ok := h.parseTime(w, r, "to")
+	if !ok {
+		return
+	}
+	if to == nil {
+		now := time.Now().UTC()
+		to = &now
+	}
+	if from == nil {
+		start := to.Add(-defaultSummaryPeriod)
+		from = &start
+	}
+
+	summary, err := h.reportingService.Summary(r.Context(), *from, *to)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+
+	response := ReportSummaryResponse{
+		From:                   summary.From,
+		To:                     summary.To,
+		OrderCount:             summary.OrderCount,
+		ByStatus:               summary.ByStatus,
+		Revenue:                make([]RevenueResponse, len(summary.Revenue)),
+		AssemblyFailures:       summary.AssemblyFailures,
+		AvgAssemblyTimeSeconds: summary.AvgAssemblyTime.Seconds(),
+	}
+	for i, amount := range summary.Revenue {
+		response.Revenue[i] = RevenueResponse{
+			Amount:      amount.Float64(),
+			AmountMinor: amount.Minor,
+			Currency:    amount.Currency,
+		}
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, response)
+}
+
+// parseTime parses an optional RFC 3339 query parameter
+func (h *ReportHandler) parseTime(w http.ResponseWriter, r *http.Request, name string) (*time.Time, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, true
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, i18n.T(r.Context(), "Invalid %s time, expected RFC 3339", name), err)
+		return nil, false
+	}
+	return &parsed, true
+}
+
+// parseInt parses an optional integer query parameter
+func (h *ReportHandler) parseInt(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, true
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, i18n.T(r.Context(), "Invalid %s", name), err)
+		return 0, false
+	}
+	return parsed, true
+}
+
+func convertReportToResponse(report *domain.OrderReport) OrderReportResponse {
+	response := OrderReportResponse{
+		OrderID:          report.OrderID,
+		Status:           report.Status,
+		TotalAmount:      report.Total.Float64(),
+		TotalAmountMinor: report.Total.Minor,
+		Currency:         report.Total.Currency,
+		ItemCount:        report.ItemCount,
+		TransactionID:    report.TransactionID,
+		PaymentStatus:    report.PaymentStatus,
+		AssemblyStatus:   report.AssemblyStatus,
+		AssemblySeconds:  report.AssemblySeconds,
+		FailureReason:    report.FailureReason,
+		CreatedAt:        report.CreatedAt,
+		PaidAt:           report.PaidAt,
+		AssembledAt:      report.AssembledAt,
+		CompletedAt:      report.CompletedAt,
+		CancelledAt:      report.CancelledAt,
+		FailedAt:         report.FailedAt,
+		UpdatedAt:        report.UpdatedAt,
+	}
+	if report.UserID != uuid.Nil {
+		userID := report.UserID
+		response.UserID = &userID
+	}
+	return response
+}
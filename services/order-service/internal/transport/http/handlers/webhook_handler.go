@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// WebhookHandler handles HTTP requests for customer order webhooks
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+	responder      *OrderHandler // Shares the JSON and error responses of the order API
+	logger         logging.Logger
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService *service.WebhookService, logger logging.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		responder:      &OrderHandler{logger: logger},
+		logger:         logger,
+	}
+}
+
+// CreateWebhook handles POST /users/{userID}/webhooks
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid JSON payload", err)
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(r.Context(), &domain.Webhook{
+		UserID:      userID,
+		URL:         req.URL,
+		Description: req.Description,
+		Statuses:    req.Statuses,
+	})
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+
+	// The signing secret is only ever returned here
+	response := CreateWebhookResponse{
+		WebhookResponse: convertWebhookToResponse(webhook),
+		Secret:          webhook.Secret,
+	}
+	h.responder.respondWithJSON(w, http.StatusCreated, response)
+}
+
+// ListWebhooks handles GET /users/{userID}/webhooks
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(r.Context(), userID)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+
+	response := WebhookListResponse{Webhooks: make([]WebhookResponse, len(webhooks))}
+	for i, webhook := range webhooks {
+		response.Webhooks[i] = convertWebhookToResponse(webhook)
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, response)
+}
+
+// GetWebhook handles GET /users/{userID}/webhooks/{webhookID}
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, webhookID, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(r.Context(), userID, webhookID)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, convertWebhookToResponse(webhook))
+}
+
+// UpdateWebhook handles PATCH /users/{userID}/webhooks/{webhookID}
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, webhookID, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid JSON payload", err)
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(r.Context(), userID, webhookID, service.WebhookUpdate{
+		URL:         req.URL,
+		Description: req.Description,
+		Statuses:    req.Statuses,
+		Active:      req.Active,
+	})
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, convertWebhookToResponse(webhook))
+}
+
+// DeleteWebhook handles DELETE /users/{userID}/webhooks/{webhookID}
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, webhookID, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(r.Context(), userID, webhookID); err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /users/{userID}/webhooks/{webhookID}/deliveries
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID, webhookID, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), userID, webhookID)
+	if err != nil {
+		h.responder.handleServiceError(w, r, err)
+		return
+	}
+	h.responder.respondWithJSON(w, http.StatusOK, WebhookDeliveriesResponse{Deliveries: deliveries})
+}
+
+func (h *WebhookHandler) parseUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid user ID", err)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func (h *WebhookHandler) parseIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.parseUserID(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	webhookID, err := uuid.Parse(chi.URLParam(r, "webhookID"))
+	if err != nil {
+		h.responder.respondWithError(w, r, http.StatusBadRequest, "Invalid webhook ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, webhookID, true
+}
+
+func convertWebhookToResponse(webhook *domain.Webhook) WebhookResponse {
+	statuses := webhook.Statuses
+	if statuses == nil {
+		statuses = []domain.OrderStatus{}
+	}
+	return WebhookResponse{
+		ID:          webhook.ID,
+		UserID:      webhook.UserID,
+		URL:         webhook.URL,
+		Description: webhook.Description,
+		Statuses:    statuses,
+		Active:      webhook.Active,
+		CreatedAt:   webhook.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   webhook.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
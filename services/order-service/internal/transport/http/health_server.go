@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 	"time"
 
+	"github.com/amiosamu/rocket-science/services/order-service/internal/messaging/kafka"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
 	"github.com/jmoiron/sqlx"
@@ -19,19 +22,33 @@ type HealthServer struct {
 	orderService *service.OrderService
 	logger       logging.Logger
 	metrics      metrics.Metrics
+	maintenance  *maintenance.Mode
 	startTime    time.Time
+
+	// Set while the gRPC clients warm up, which keeps the service not ready
+	warmingUp atomic.Bool
+
+	// Probes the Kafka produce path; nil when the probe is disabled
+	kafkaProbe KafkaProbe
+}
+
+// KafkaProbe reports whether the Kafka produce path works
+type KafkaProbe interface {
+	Status() kafka.ProbeStatus
 }
 
 // NewHealthServer creates a new health server
 func NewHealthServer(
 	db *sqlx.DB,
 	orderService *service.OrderService,
+	maintenanceMode *maintenance.Mode,
 	logger logging.Logger,
 	metrics metrics.Metrics,
 ) *HealthServer {
 	return &HealthServer{
 		db:           db,
 		orderService: orderService,
+		maintenance:  maintenanceMode,
 		logger:       logger,
 		metrics:      metrics,
 		startTime:    time.Now(),
@@ -45,8 +62,29 @@ const (
 	HealthStatusHealthy   HealthStatus = "healthy"
 	HealthStatusDegraded  HealthStatus = "degraded"
 	HealthStatusUnhealthy HealthStatus = "unhealthy"
+
+	// HealthStatusMaintenance is reported by readiness while the service drains
+	HealthStatusMaintenance HealthStatus = "maintenance"
+
+	// HealthStatusWarmingUp is reported by readiness until the gRPC clients are connected
+	HealthStatusWarmingUp HealthStatus = "warming_up"
 )
 
+// BeginWarmUp reports the service not ready until EndWarmUp is called
+func (h *HealthServer) BeginWarmUp() {
+	h.warmingUp.Store(true)
+}
+
+// EndWarmUp reports the service ready again, as far as the warm-up is concerned
+func (h *HealthServer) EndWarmUp() {
+	h.warmingUp.Store(false)
+}
+
+// SetKafkaProbe makes readiness and the Kafka producer component follow the probe
+func (h *HealthServer) SetKafkaProbe(probe KafkaProbe) {
+	h.kafkaProbe = probe
+}
+
 // ComponentHealth represents the health of a single component
 type ComponentHealth struct {
 	Status    HealthStatus `json:"status"`
@@ -127,7 +165,7 @@ func (h *HealthServer) HandleHealthCheck(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Update metrics
-	h.updateHealthMetrics(overallStatus, components)
+	h.updateHealthMetrics(ctx, overallStatus, components)
 
 	// Log health check
 	h.logger.Debug(ctx, "Health check completed", map[string]interface{}{
@@ -143,10 +181,36 @@ func (h *HealthServer) HandleHealthCheck(w http.ResponseWriter, r *http.Request)
 func (h *HealthServer) HandleReadinessCheck(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// Check critical components only for readiness
+	// Take the instance out of rotation while in maintenance mode
+	if h.maintenance.Enabled() {
+		response := SimpleHealthResponse{
+			Status:    HealthStatusMaintenance,
+			Service:   "order-service",
+			Timestamp: time.Now().UTC(),
+			Version:   "1.0.0",
+		}
+		h.writeJSONResponse(w, http.StatusServiceUnavailable, response)
+		return
+	}
+
+	// Keep traffic away until the gRPC clients are connected
+	if h.warmingUp.Load() {
+		response := SimpleHealthResponse{
+			Status:    HealthStatusWarmingUp,
+			Service:   "order-service",
+			Timestamp: time.Now().UTC(),
+			Version:   "1.0.0",
+		}
+		h.writeJSONResponse(w, http.StatusServiceUnavailable, response)
+		return
+	}
+
+	// Check critical components only for readiness; orders accepted while their
+	// events can't be published would stall in the saga
 	dbHealth := h.checkDatabase(ctx)
+	kafkaHealth := h.checkKafkaProducer(ctx)
 
-	if dbHealth.Status == HealthStatusUnhealthy {
+	if dbHealth.Status == HealthStatusUnhealthy || kafkaHealth.Status == HealthStatusUnhealthy {
 		response := SimpleHealthResponse{
 			Status:    HealthStatusUnhealthy,
 			Service:   "order-service",
@@ -194,6 +258,25 @@ func (h *HealthServer) HandleMetrics(w http.ResponseWriter, r *http.Request) {
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// HandleMaintenance exposes the maintenance mode admin endpoint
+func (h *HealthServer) HandleMaintenance(w http.ResponseWriter, r *http.Request) {
+	if h.maintenance == nil {
+		h.writeJSONResponse(w, http.StatusNotImplemented, map[string]string{
+			"error": "maintenance mode not configured",
+		})
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		h.logger.Info(r.Context(), "Maintenance mode change requested", map[string]interface{}{
+			"method":      r.Method,
+			"remote_addr": r.RemoteAddr,
+		})
+	}
+
+	h.maintenance.Handler().ServeHTTP(w, r)
+}
+
 // Health check implementations for each component
 
 func (h *HealthServer) checkDatabase(ctx context.Context) ComponentHealth {
@@ -285,15 +368,38 @@ func (h *HealthServer) checkPaymentService(ctx context.Context) ComponentHealth
 func (h *HealthServer) checkKafkaProducer(ctx context.Context) ComponentHealth {
 	start := time.Now()
 
-	// This would normally check Kafka producer health
-	// For now, we'll simulate a basic check
+	if h.kafkaProbe == nil {
+		return ComponentHealth{
+			Status:  HealthStatusHealthy,
+			Message: "Kafka producer assumed healthy",
+			Details: map[string]interface{}{
+				"note": "Kafka health probe disabled (KAFKA_HEALTH_PROBE_ENABLED)",
+			},
+			CheckedAt: time.Now().UTC(),
+			Duration:  time.Since(start).String(),
+		}
+	}
+
+	// The probe runs in the background, so health checks don't load the brokers
+	probe := h.kafkaProbe.Status()
+	status := HealthStatusHealthy
+	message := "Kafka produce path healthy"
+	switch {
+	case !probe.Checked:
+		status = HealthStatusUnhealthy
+		message = "Kafka produce path not probed yet"
+	case !probe.Ready:
+		status = HealthStatusUnhealthy
+		message = fmt.Sprintf("Kafka produce path failing: %s", probe.LastError)
+	case probe.ConsecutiveFailures > 0:
+		status = HealthStatusDegraded
+		message = fmt.Sprintf("Kafka probe failing, below the failure threshold: %s", probe.LastError)
+	}
 
 	return ComponentHealth{
-		Status:  HealthStatusHealthy,
-		Message: "Kafka producer assumed healthy",
-		Details: map[string]interface{}{
-			"note": "Health check implementation depends on Kafka producer health metrics",
-		},
+		Status:    status,
+		Message:   message,
+		Details:   probe,
 		CheckedAt: time.Now().UTC(),
 		Duration:  time.Since(start).String(),
 	}
@@ -365,7 +471,7 @@ func (h *HealthServer) createSummary(components map[string]ComponentHealth) Heal
 	return summary
 }
 
-func (h *HealthServer) updateHealthMetrics(status HealthStatus, components map[string]ComponentHealth) {
+func (h *HealthServer) updateHealthMetrics(ctx context.Context, status HealthStatus, components map[string]ComponentHealth) {
 	// Update overall health metric
 	healthValue := 1.0
 	if status == HealthStatusDegraded {
@@ -374,7 +480,7 @@ func (h *HealthServer) updateHealthMetrics(status HealthStatus, components map[s
 		healthValue = 0.0
 	}
 
-	h.metrics.RecordValue("service_health", healthValue, map[string]string{
+	h.metrics.SetGauge(ctx, "service_health", healthValue, map[string]string{
 		"service": "order-service",
 		"status":  string(status),
 	})
@@ -388,7 +494,7 @@ func (h *HealthServer) updateHealthMetrics(status HealthStatus, components map[s
 			componentValue = 0.0
 		}
 
-		h.metrics.RecordValue("component_health", componentValue, map[string]string{
+		h.metrics.SetGauge(ctx, "component_health", componentValue, map[string]string{
 			"service":   "order-service",
 			"component": name,
 			"status":    string(component.Status),
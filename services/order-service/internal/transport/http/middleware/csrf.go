@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Headers involved in CSRF protection. The session headers are set by the API gateway
+// after it validated the caller's session with IAM.
+const (
+	CSRFHeader          = "X-CSRF-Token"
+	SessionTokenHeader  = "X-Session-Token"
+	SessionSourceHeader = "X-Session-Source" // "cookie" or "bearer"
+
+	// sessionCookie carries the IAM session token of browser clients
+	sessionCookie = "session_token"
+)
+
+// CSRFOptions configures CSRF protection
+type CSRFOptions struct {
+	Secret       []byte        // Signs tokens; must be shared by all replicas
+	CookieName   string        // Cookie the token is issued in
+	TTL          time.Duration // Tokens are rejected after this long
+	SecureCookie bool          // Only send the cookie over HTTPS
+}
+
+// CSRF protects cookie-authenticated requests with the double-submit cookie pattern.
+// A token is issued in a cookie the page's scripts can read, and every unsafe request
+// must echo it in the X-CSRF-Token header; a cross-site form can send the cookie but
+// cannot read it. Tokens are signed over the IAM session they were issued for, so a
+// token planted in the victim's cookie jar from another session is rejected too.
+type CSRF struct {
+	opts CSRFOptions
+	now  func() time.Time
+}
+
+// NewCSRF creates CSRF protection with the given options
+func NewCSRF(opts CSRFOptions) *CSRF {
+	return &CSRF{opts: opts, now: time.Now}
+}
+
+// Issue returns a token for the session and when it expires
+func (c *CSRF) Issue(session string) (string, time.Time) {
+	expiresAt := c.now().Add(c.opts.TTL)
+
+	payload := make([]byte, 8+16)
+	binary.BigEndian.PutUint64(payload, uint64(expiresAt.Unix()))
+	rand.Read(payload[8:])
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(c.sign(session, payload)), expiresAt
+}
+
+// Verify reports whether the token was issued for the session and has not expired
+func (c *CSRF) Verify(session, token string) bool {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != 8+16 {
+		return false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, c.sign(session, payload)) {
+		return false
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload)), 0)
+	return c.now().Before(expiresAt)
+}
+
+func (c *CSRF) sign(session string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.opts.Secret)
+	mac.Write([]byte(session))
+	mac.Write([]byte{0})
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// HandleIssue handles GET /csrf-token. It sets the token cookie and returns the token,
+// so clients may also read it from the response.
+func (c *CSRF) HandleIssue(w http.ResponseWriter, r *http.Request) {
+	session := sessionFromRequest(r)
+	if session == "" {
+		http.Error(w, errorBody(w, r, "Missing authentication", http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	token, expiresAt := c.Issue(session)
+	http.SetCookie(w, &http.Cookie{
+		Name:     c.opts.CookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  expiresAt,
+		MaxAge:   int(c.opts.TTL.Seconds()),
+		Secure:   c.opts.SecureCookie,
+		HttpOnly: false, // The page must read it to echo it in the header
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"csrf_token": token,
+		"header":     CSRFHeader,
+		"expires_at": expiresAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// CSRFMiddleware rejects unsafe requests authenticated by a session cookie unless they
+// carry a valid CSRF token in both the cookie and the X-CSRF-Token header. Requests
+// authenticated with a bearer token carry no ambient credentials and pass through.
+func CSRFMiddleware(csrf *CSRF, logger logging.Logger, metrics metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isSafeMethod(r.Method) || !isCookieAuthenticated(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reason := csrf.check(r)
+			if reason == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			metrics.IncrementCounter(r.Context(), "http_csrf_rejections_total", map[string]string{
+				"reason": reason,
+			})
+			logger.Warn(r.Context(), "Rejected request without a valid CSRF token", map[string]interface{}{
+				"method": r.Method,
+				"path":   r.URL.Path,
+				"reason": reason,
+			})
+			http.Error(w, errorBody(w, r, "Invalid or missing CSRF token", http.StatusForbidden), http.StatusForbidden)
+		})
+	}
+}
+
+// check returns why the request fails CSRF validation, or "" when it passes
+func (c *CSRF) check(r *http.Request) string {
+	header := r.Header.Get(CSRFHeader)
+	cookie, err := r.Cookie(c.opts.CookieName)
+	switch {
+	case header == "":
+		return "missing_header"
+	case err != nil || cookie.Value == "":
+		return "missing_cookie"
+	case subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) != 1:
+		return "mismatch"
+	case !c.Verify(sessionFromRequest(r), header):
+		return "invalid"
+	}
+	return ""
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// isCookieAuthenticated reports whether the browser attached the caller's credentials
+// on its own. Behind the gateway the session source header says so; without it, a
+// session cookie and no Authorization header mean the same.
+func isCookieAuthenticated(r *http.Request) bool {
+	if source := r.Header.Get(SessionSourceHeader); source != "" {
+		return source == "cookie"
+	}
+	if r.Header.Get("Authorization") != "" {
+		return false
+	}
+	cookie, err := r.Cookie(sessionCookie)
+	return err == nil && cookie.Value != ""
+}
+
+// sessionFromRequest returns the IAM session token the request is authenticated with
+func sessionFromRequest(r *http.Request) string {
+	if session := r.Header.Get(SessionTokenHeader); session != "" {
+		return session
+	}
+	if cookie, err := r.Cookie(sessionCookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+const (
+	// shortRTTAlpha weights recent latency samples; longRTTAlpha tracks the
+	// baseline latency over roughly the last 600 requests
+	shortRTTAlpha = 0.2
+	longRTTAlpha  = 2.0 / 601
+
+	// rttTolerance allows latency to grow by half over the baseline before
+	// the limit starts shrinking
+	rttTolerance = 1.5
+
+	// limitSmoothing dampens limit changes between samples
+	limitSmoothing = 0.2
+)
+
+// LoadSheddingOptions configures the adaptive concurrency limit
+type LoadSheddingOptions struct {
+	InitialLimit int           // Concurrent requests allowed before any latency is observed
+	MinLimit     int           // The limit never drops below this
+	MaxLimit     int           // The limit never grows above this
+	MaxQueueWait time.Duration // How long a request may wait for a slot before it is shed
+	RetryAfter   time.Duration // Sent to shed clients in the Retry-After header
+
+	// Exempt selects requests that bypass the limiter, e.g. long polls that are
+	// idle while held and would otherwise skew the observed latency
+	Exempt func(r *http.Request) bool
+}
+
+// ConcurrencyLimiter bounds the number of in-flight requests with a limit
+// that adapts to observed latency (a gradient algorithm): while latency stays
+// near its long-term baseline the limit grows, and when it rises - requests
+// queueing on the database or the payment path - the limit shrinks in
+// proportion, so excess requests are rejected early instead of piling up.
+type ConcurrencyLimiter struct {
+	mu       sync.Mutex
+	limit    float64
+	minLimit float64
+	maxLimit float64
+	inFlight int
+	waiters  []chan struct{}
+	shortRTT float64 // Seconds, exponentially weighted
+	longRTT  float64 // Seconds, exponentially weighted
+}
+
+// NewConcurrencyLimiter creates a limiter starting at the initial limit
+func NewConcurrencyLimiter(initialLimit, minLimit, maxLimit int) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		limit:    float64(initialLimit),
+		minLimit: float64(minLimit),
+		maxLimit: float64(maxLimit),
+	}
+}
+
+// Acquire takes a slot, waiting up to maxWait for one to free up. It returns
+// false when the request should be shed.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context, maxWait time.Duration) bool {
+	l.mu.Lock()
+	if l.inFlight < int(l.limit) {
+		l.inFlight++
+		l.mu.Unlock()
+		return true
+	}
+	if maxWait <= 0 {
+		l.mu.Unlock()
+		return false
+	}
+
+	// Release hands the slot over directly, so a woken waiter already holds it
+	ready := make(chan struct{})
+	l.waiters = append(l.waiters, ready)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(maxWait)
+	defer timer.Stop()
+
+	select {
+	case <-ready:
+		return true
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, waiter := range l.waiters {
+		if waiter == ready {
+			l.waiters = append(l.waiters[:i], l.waiters[i+1:]...)
+			return false
+		}
+	}
+	// The slot was handed over while timing out; give it back
+	l.releaseLocked()
+	return false
+}
+
+// Release frees a slot and records the request latency
+func (l *ConcurrencyLimiter) Release(rtt time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.update(rtt.Seconds())
+	l.releaseLocked()
+}
+
+// releaseLocked frees a slot, handing it to waiters while the limit allows
+func (l *ConcurrencyLimiter) releaseLocked() {
+	l.inFlight--
+	for len(l.waiters) > 0 && l.inFlight < int(l.limit) {
+		waiter := l.waiters[0]
+		l.waiters = l.waiters[1:]
+		l.inFlight++
+		close(waiter)
+	}
+}
+
+// update applies one latency sample to the limit
+func (l *ConcurrencyLimiter) update(rtt float64) {
+	if l.longRTT == 0 {
+		l.shortRTT, l.longRTT = rtt, rtt
+		return
+	}
+	l.shortRTT += shortRTTAlpha * (rtt - l.shortRTT)
+	l.longRTT += longRTTAlpha * (rtt - l.longRTT)
+
+	// After a sustained latency increase the baseline catches up with it;
+	// pull it back down so recovery is detected quickly
+	if l.longRTT/l.shortRTT > 2 {
+		l.longRTT *= 0.95
+	}
+
+	// Don't grow the limit when it isn't being used
+	if float64(l.inFlight) < l.limit/2 {
+		return
+	}
+
+	gradient := math.Max(0.5, math.Min(1.0, rttTolerance*l.longRTT/l.shortRTT))
+	queueSize := math.Sqrt(l.limit)
+	newLimit := l.limit*gradient + queueSize
+	newLimit = l.limit*(1-limitSmoothing) + newLimit*limitSmoothing
+	l.limit = math.Max(l.minLimit, math.Min(l.maxLimit, newLimit))
+}
+
+// Limit returns the current concurrency limit
+func (l *ConcurrencyLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// InFlight returns the number of requests holding a slot
+func (l *ConcurrencyLimiter) InFlight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inFlight
+}
+
+// LoadSheddingMiddleware rejects requests with 429 Too Many Requests and a
+// Retry-After header when the adaptive concurrency limit is reached and no
+// slot frees up within the maximum queue wait
+func LoadSheddingMiddleware(opts LoadSheddingOptions, logger logging.Logger, metrics metrics.Metrics) func(http.Handler) http.Handler {
+	limiter := NewConcurrencyLimiter(opts.InitialLimit, opts.MinLimit, opts.MaxLimit)
+	retryAfter := strconv.Itoa(int(math.Ceil(opts.RetryAfter.Seconds())))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if opts.Exempt != nil && opts.Exempt(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			queued := time.Now()
+			if !limiter.Acquire(r.Context(), opts.MaxQueueWait) {
+				metrics.IncrementCounter(r.Context(), "http_requests_shed_total", map[string]string{
+					"method": r.Method,
+				})
+				logger.Warn(r.Context(), "Request shed by concurrency limit", map[string]interface{}{
+					"method":    r.Method,
+					"path":      r.URL.Path,
+					"limit":     limiter.Limit(),
+					"in_flight": limiter.InFlight(),
+				})
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Retry-After", retryAfter)
+				body := errorBody(w, r, "Server is overloaded, retry later", http.StatusTooManyRequests)
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(body))
+				return
+			}
+
+			start := time.Now()
+			metrics.RecordDuration(r.Context(), "http_request_queue_duration_seconds", start.Sub(queued), nil)
+			defer func() {
+				limiter.Release(time.Since(start))
+				metrics.SetGauge(r.Context(), "http_concurrency_limit", float64(limiter.Limit()), nil)
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
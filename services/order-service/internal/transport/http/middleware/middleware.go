@@ -2,9 +2,9 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
-	"runtime/debug"
 	"strings"
 	"time"
 
@@ -15,6 +15,8 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/amiosamu/rocket-science/services/order-service/internal/i18n"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/tracing"
@@ -44,18 +46,14 @@ func LoggingMiddleware(logger logging.Logger) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
-			// Generate request ID if not present
-			requestID := r.Header.Get("X-Request-ID")
-			if requestID == "" {
+			// The request ID is assigned by ctxmeta.Middleware; generate one if it did not run
+			requestID, ok := ctxmeta.RequestID(r.Context())
+			if !ok {
 				requestID = uuid.New().String()
+				w.Header().Set(ctxmeta.RequestIDHeader, requestID)
+				r = r.WithContext(ctxmeta.WithRequestID(r.Context(), requestID))
 			}
-
-			// Add request ID to response header
-			w.Header().Set("X-Request-ID", requestID)
-
-			// Add request ID to context
-			ctx := context.WithValue(r.Context(), "request_id", requestID)
-			r = r.WithContext(ctx)
+			ctx := r.Context()
 
 			// Wrap response writer to capture status code and size
 			wrapped := &responseWriter{
@@ -110,15 +108,10 @@ func TracingMiddleware(serviceName string) func(http.Handler) http.Handler {
 			)
 
 			// Add request ID if present
-			if requestID := r.Header.Get("X-Request-ID"); requestID != "" {
+			if requestID, ok := ctxmeta.RequestID(ctx); ok {
 				span.SetAttributes(attribute.String("request.id", requestID))
 			}
 
-			// Add trace ID to context for logging
-			if span.SpanContext().IsValid() {
-				ctx = context.WithValue(ctx, "trace_id", span.SpanContext().TraceID().String())
-			}
-
 			// Wrap response writer to capture status code
 			wrapped := &responseWriter{
 				ResponseWriter: w,
@@ -165,48 +158,19 @@ func MetricsMiddleware(metrics metrics.Metrics) func(http.Handler) http.Handler
 				"status": fmt.Sprintf("%d", wrapped.statusCode),
 			}
 
-			metrics.IncrementCounter("http_requests_total", labels)
-			metrics.RecordDuration("http_request_duration_seconds", duration, labels)
-			metrics.RecordValue("http_request_size_bytes", float64(r.ContentLength), labels)
-			metrics.RecordValue("http_response_size_bytes", float64(wrapped.bytesWritten), labels)
+			metrics.IncrementCounter(r.Context(), "http_requests_total", labels)
+			metrics.RecordDuration(r.Context(), "http_request_duration_seconds", duration, labels)
+			metrics.RecordValue(r.Context(), "http_request_size_bytes", float64(r.ContentLength), labels)
+			metrics.RecordValue(r.Context(), "http_response_size_bytes", float64(wrapped.bytesWritten), labels)
 
 			// Record status code specific metrics
 			if wrapped.statusCode >= 400 {
-				metrics.IncrementCounter("http_requests_errors_total", labels)
+				metrics.IncrementCounter(r.Context(), "http_requests_errors_total", labels)
 			}
 		})
 	}
 }
 
-// RecoveryMiddleware recovers from panics and logs them
-func RecoveryMiddleware(logger logging.Logger) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			defer func() {
-				if err := recover(); err != nil {
-					// Log the panic with stack trace
-					logger.Error(r.Context(), "HTTP handler panic", fmt.Errorf("panic: %v", err), map[string]interface{}{
-						"method":     r.Method,
-						"path":       r.URL.Path,
-						"request_id": r.Header.Get("X-Request-ID"),
-						"stack":      string(debug.Stack()),
-					})
-
-					// Record error in span if available
-					tracing.RecordError(r.Context(), fmt.Errorf("panic: %v", err))
-
-					// Return 500 error
-					w.Header().Set("Content-Type", "application/json")
-					w.WriteHeader(http.StatusInternalServerError)
-					w.Write([]byte(`{"error": "Internal server error", "code": 500}`))
-				}
-			}()
-
-			next.ServeHTTP(w, r)
-		})
-	}
-}
-
 // CORSMiddleware handles Cross-Origin Resource Sharing
 func CORSMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -227,8 +191,8 @@ func CORSMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 			}
 
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID, X-Trace-ID")
-			w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, X-Trace-ID")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID, X-Trace-ID, If-None-Match, X-CSRF-Token")
+			w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, X-Trace-ID, ETag")
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 			w.Header().Set("Access-Control-Max-Age", "3600")
 
@@ -290,18 +254,31 @@ func ContentTypeMiddleware() func(http.Handler) http.Handler {
 	}
 }
 
-// RateLimitMiddleware implements basic rate limiting
-func RateLimitMiddleware(requestsPerMinute int) func(http.Handler) http.Handler {
-	// This is a simple in-memory rate limiter
-	// In production, you'd use Redis or a more sophisticated solution
-
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// TODO: Implement rate limiting logic
-			// For now, just pass through
-			next.ServeHTTP(w, r)
-		})
+// UserIDFromContext returns the caller authenticated by the authz middleware
+func UserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	value, ok := ctxmeta.UserID(ctx)
+	if !ok {
+		return uuid.Nil, false
 	}
+	userID, err := uuid.Parse(value)
+	return userID, err == nil
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctxmeta.RequestID(ctx)
+	return requestID
+}
+
+// errorBody returns the JSON error body of a rejected request, with the message in the
+// caller's locale, and sets the Content-Language of the response
+func errorBody(w http.ResponseWriter, r *http.Request, message string, code int) string {
+	locale := i18n.FromContext(r.Context())
+	w.Header().Set("Content-Language", locale)
+	body, _ := json.Marshal(map[string]interface{}{
+		"error": i18n.Translate(locale, message),
+		"code":  code,
+	})
+	return string(body)
 }
 
 // AuthMiddleware validates authentication (basic implementation)
@@ -314,7 +291,7 @@ func AuthMiddleware() func(http.Handler) http.Handler {
 				// Check Authorization header for Bearer token
 				authHeader := r.Header.Get("Authorization")
 				if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-					http.Error(w, `{"error": "Missing authentication", "code": 401}`, http.StatusUnauthorized)
+					http.Error(w, errorBody(w, r, "Missing authentication", http.StatusUnauthorized), http.StatusUnauthorized)
 					return
 				}
 				// Extract token from Bearer header
@@ -322,7 +299,7 @@ func AuthMiddleware() func(http.Handler) http.Handler {
 			}
 
 			// Add user context (simplified)
-			ctx := context.WithValue(r.Context(), "session_id", sessionID)
+			ctx := ctxmeta.WithSessionID(r.Context(), sessionID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
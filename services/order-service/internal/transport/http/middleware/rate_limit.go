@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// OrderRateLimitMiddleware rejects order placements over the per-user or
+// per-IP limit with 429 Too Many Requests and a Retry-After header. The user,
+// tenant and roles are the ones validated by the API gateway; the client IP is
+// the one resolved by the RealIP middleware.
+func OrderRateLimitMiddleware(limiter *service.OrderRateLimiter, logger logging.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			placer := service.OrderPlacer{
+				Roles:    ctxmeta.Roles(ctx),
+				ClientIP: clientIP(r),
+			}
+			placer.UserID, _ = ctxmeta.UserID(ctx)
+			placer.TenantID, _ = ctxmeta.TenantID(ctx)
+
+			decision, err := limiter.Allow(ctx, placer)
+			if err != nil {
+				logger.Error(ctx, "Order rate limit check failed", err)
+				http.Error(w, errorBody(w, r, "Order placement is temporarily unavailable", http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+			if !decision.Allowed {
+				logger.Warn(ctx, "Order placement rate limited", map[string]interface{}{
+					"scope":     decision.Scope,
+					"limit":     decision.Limit,
+					"user_id":   placer.UserID,
+					"client_ip": placer.ClientIP,
+				})
+
+				retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+				if retryAfter < 1 {
+					retryAfter = 1
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				body := errorBody(w, r, "Too many orders, retry later", http.StatusTooManyRequests)
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(body))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns the host of the request's remote address
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -4,43 +4,89 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
 	"github.com/amiosamu/rocket-science/services/order-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/order-service/internal/service"
 	"github.com/amiosamu/rocket-science/services/order-service/internal/transport/http/handlers"
 	customMiddleware "github.com/amiosamu/rocket-science/services/order-service/internal/transport/http/middleware"
+	"github.com/amiosamu/rocket-science/shared/platform/adminhttp"
+	"github.com/amiosamu/rocket-science/shared/platform/authz"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/deadline"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/slo"
+	"github.com/amiosamu/rocket-science/shared/platform/purge"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
+	"github.com/amiosamu/rocket-science/shared/platform/version"
 )
 
 // Server represents the HTTP server
 type Server struct {
-	server       *http.Server
-	router       *chi.Mux
-	logger       logging.Logger
-	metrics      metrics.Metrics
-	orderHandler *handlers.OrderHandler
-	healthServer *HealthServer
-	config       config.ServerConfig
+	server          *http.Server
+	router          *chi.Mux
+	logger          logging.Logger
+	metrics         metrics.Metrics
+	crashReporter   recovery.Reporter
+	authorizer      *authz.Authorizer // Guards operator and admin routes
+	orderHandler    *handlers.OrderHandler
+	addressHandler  *handlers.AddressHandler
+	webhookHandler  *handlers.WebhookHandler  // nil when order webhooks are disabled
+	approvalHandler *handlers.ApprovalHandler // nil when order approval is disabled
+	refundHandler   *handlers.RefundHandler   // nil when order refunds are disabled
+	reportHandler   *handlers.ReportHandler   // nil when order reporting is disabled
+	batchHandler    *handlers.BatchHandler    // nil when order batches are disabled
+	exportHandler   *handlers.ExportHandler   // nil when order exports are disabled
+	orderLimiter    *service.OrderRateLimiter // nil when order rate limiting is disabled
+	purgeHandler    http.Handler              // Test data purge endpoint, refusing purges its gate does not allow
+	offsetHandler   http.Handler              // Kafka offset snapshot audit endpoint; nil when snapshots are disabled
+	healthServer    *HealthServer
+	config          config.ServerConfig
 }
 
 // NewServer creates a new HTTP server
 func NewServer(
 	cfg config.ServerConfig,
 	orderHandler *handlers.OrderHandler,
+	addressHandler *handlers.AddressHandler,
+	webhookHandler *handlers.WebhookHandler,
+	approvalHandler *handlers.ApprovalHandler,
+	refundHandler *handlers.RefundHandler,
+	reportHandler *handlers.ReportHandler,
+	batchHandler *handlers.BatchHandler,
+	exportHandler *handlers.ExportHandler,
+	orderLimiter *service.OrderRateLimiter,
+	purgeHandler http.Handler,
+	offsetHandler http.Handler,
 	healthServer *HealthServer,
 	logger logging.Logger,
 	metrics metrics.Metrics,
+	crashReporter recovery.Reporter,
+	authorizer *authz.Authorizer,
 ) *Server {
 	server := &Server{
-		logger:       logger,
-		metrics:      metrics,
-		orderHandler: orderHandler,
-		healthServer: healthServer,
-		config:       cfg,
+		logger:          logger,
+		metrics:         metrics,
+		crashReporter:   crashReporter,
+		authorizer:      authorizer,
+		orderHandler:    orderHandler,
+		addressHandler:  addressHandler,
+		webhookHandler:  webhookHandler,
+		approvalHandler: approvalHandler,
+		refundHandler:   refundHandler,
+		reportHandler:   reportHandler,
+		batchHandler:    batchHandler,
+		exportHandler:   exportHandler,
+		orderLimiter:    orderLimiter,
+		purgeHandler:    purgeHandler,
+		offsetHandler:   offsetHandler,
+		healthServer:    healthServer,
+		config:          cfg,
 	}
 
 	server.setupRoutes()
@@ -56,13 +102,21 @@ func (s *Server) setupRoutes() {
 	// Apply Chi built-in middleware
 	s.router.Use(middleware.RequestID)
 	s.router.Use(middleware.RealIP)
-	s.router.Use(middleware.Recoverer)
+	s.router.Use(recovery.Middleware(s.crashReporter))
 	s.router.Use(middleware.Timeout(30 * time.Second))
+	s.router.Use(deadline.Middleware(30 * time.Second)) // Honor shorter client budgets
 
 	// Apply custom middleware
+	s.router.Use(ctxmeta.Middleware()) // Request ID and locale for logs and downstream calls
+	s.router.Use(s.authorizer.Identity) // Caller and tenant only when signed by IAM
 	s.router.Use(customMiddleware.LoggingMiddleware(s.logger))
 	s.router.Use(customMiddleware.TracingMiddleware("order-service"))
 	s.router.Use(customMiddleware.MetricsMiddleware(s.metrics))
+	if objectives, err := ServiceLevelObjectives(s.metrics); err != nil {
+		s.logger.Error(context.Background(), "Invalid service level objectives, SLO metrics disabled", err)
+	} else {
+		s.router.Use(slo.HTTPMiddleware(objectives, routeEndpoint))
+	}
 	s.router.Use(customMiddleware.SecurityHeadersMiddleware())
 	s.router.Use(customMiddleware.CORSMiddleware([]string{"*"})) // Configure appropriately for production
 	s.router.Use(customMiddleware.ContentTypeMiddleware())
@@ -72,6 +126,31 @@ func (s *Server) setupRoutes() {
 		s.router.Get("/health", s.healthServer.HandleHealthCheck)
 		s.router.Get("/ready", s.healthServer.HandleReadinessCheck)
 		s.router.Get("/live", s.healthServer.HandleLivenessCheck)
+
+		// Maintenance mode admin endpoint. It is served on the API port, which
+		// doesn't verify client certificates, so it requires the admin token of
+		// the health server settings; without one it is left unregistered
+		// rather than open.
+		if security, err := adminhttp.FromEnv(); err != nil {
+			s.logger.Error(context.Background(), "Invalid admin security configuration, admin endpoints disabled", err)
+		} else if security.AdminToken == "" {
+			s.logger.Warn(context.Background(), "Admin endpoints disabled; set HEALTH_ADMIN_TOKEN to enable them")
+		} else {
+			security.ProtectedPaths = []string{"/admin/"} // Whatever the health server protects
+			s.router.Group(func(r chi.Router) {
+				r.Use(security.Protect)
+				r.Get("/admin/maintenance", s.healthServer.HandleMaintenance)
+				r.Post("/admin/maintenance", s.healthServer.HandleMaintenance)
+				r.Delete("/admin/maintenance", s.healthServer.HandleMaintenance)
+				r.Get("/admin/orders/{id}/saga", s.orderHandler.GetOrderSaga)
+				if s.purgeHandler != nil {
+					r.Method(http.MethodPost, purge.Path, s.purgeHandler)
+				}
+				if s.offsetHandler != nil {
+					r.Method(http.MethodGet, "/admin/kafka/offset-snapshots", s.offsetHandler)
+				}
+			})
+		}
 	} else {
 		// Fallback to basic health check
 		s.router.Get("/health", s.orderHandler.HealthCheck)
@@ -79,26 +158,85 @@ func (s *Server) setupRoutes() {
 		s.router.Get("/live", s.orderHandler.HealthCheck)
 	}
 
+	// Build information for deployment verification
+	s.router.Method(http.MethodGet, "/version", version.Handler("order-service"))
+
 	// API v1 routes
 	s.router.Route("/api/v1", func(r chi.Router) {
+		// Shed load before it reaches Postgres and the payment path; health
+		// and admin endpoints stay reachable under overload
+		if shed := s.config.LoadShedding; shed.Enabled {
+			r.Use(customMiddleware.LoadSheddingMiddleware(customMiddleware.LoadSheddingOptions{
+				InitialLimit: shed.InitialLimit,
+				MinLimit:     shed.MinLimit,
+				MaxLimit:     shed.MaxLimit,
+				MaxQueueWait: shed.MaxQueueWait,
+				RetryAfter:   shed.RetryAfter,
+				Exempt:       isStatusLongPoll,
+			}, s.logger, s.metrics))
+		}
+
 		// Apply authentication middleware to API routes (when implemented)
 		// r.Use(customMiddleware.AuthMiddleware())
 
+		s.setupCSRF(r)
+
 		s.setupOrderRoutes(r)
+		s.setupAddressRoutes(r)
+		s.setupWebhookRoutes(r)
+		s.setupApprovalRoutes(r)
+		s.setupRefundRoutes(r)
+		s.setupReportRoutes(r)
 		s.setupMetricsRoutes(r)
 	})
 }
 
+// isStatusLongPoll reports whether the request is a long poll of an order status,
+// GET /api/v1/orders/{id}/status?wait=...
+func isStatusLongPoll(r *http.Request) bool {
+	return r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/status") && r.URL.Query().Has("wait")
+}
+
+// setupCSRF protects browser clients authenticated by a session cookie against
+// cross-site request forgery and serves the tokens they echo back
+func (s *Server) setupCSRF(r chi.Router) {
+	cfg := s.config.CSRF
+	if !cfg.Enabled {
+		return
+	}
+
+	csrf := customMiddleware.NewCSRF(customMiddleware.CSRFOptions{
+		Secret:       []byte(cfg.Secret),
+		CookieName:   cfg.CookieName,
+		TTL:          cfg.TTL,
+		SecureCookie: cfg.SecureCookie,
+	})
+	r.Use(customMiddleware.CSRFMiddleware(csrf, s.logger, s.metrics))
+	r.Get("/csrf-token", csrf.HandleIssue)
+
+	s.logger.Info(nil, "CSRF protection enabled", map[string]interface{}{
+		"routes": []string{"GET /api/v1/csrf-token"},
+	})
+}
+
 // setupOrderRoutes configures order-specific routes
 func (s *Server) setupOrderRoutes(r chi.Router) {
 	r.Route("/orders", func(r chi.Router) {
-		r.Post("/", s.orderHandler.CreateOrder)
+		r.With(s.orderRateLimit()...).Post("/", s.orderHandler.CreateOrder)
 		r.Get("/", s.orderHandler.ListOrders)
 		r.Get("/metrics", s.orderHandler.GetOrderMetrics)
+		s.setupBatchRoutes(r)
+		s.setupExportRoutes(r)
 
 		r.Route("/{id}", func(r chi.Router) {
 			r.Get("/", s.orderHandler.GetOrder)
+			r.Get("/status", s.orderHandler.GetOrderStatus)
 			r.Patch("/status", s.orderHandler.UpdateOrderStatus)
+			r.With(s.authorizer.RequireRole("orders.tags", "admin")).Put("/tags", s.orderHandler.SetOrderTags)
+			r.Post("/backorder/fulfill", s.orderHandler.FulfillBackorder)
+			r.Delete("/backorder", s.orderHandler.CancelBackorder)
+			s.setupOrderApprovalRoutes(r)
+			s.setupOrderRefundRoutes(r)
 		})
 	})
 
@@ -112,13 +250,221 @@ func (s *Server) setupOrderRoutes(r chi.Router) {
 			"POST /api/v1/orders",
 			"GET /api/v1/orders",
 			"GET /api/v1/orders/{id}",
+			"GET /api/v1/orders/{id}/status",
 			"PATCH /api/v1/orders/{id}/status",
+			"PUT /api/v1/orders/{id}/tags",
+			"POST /api/v1/orders/{id}/backorder/fulfill",
+			"DELETE /api/v1/orders/{id}/backorder",
 			"GET /api/v1/users/{userID}/orders",
 			"GET /api/v1/orders/metrics",
 		},
 	})
 }
 
+// orderRateLimit returns the middleware limiting order placements, none when
+// rate limiting is disabled
+func (s *Server) orderRateLimit() []func(http.Handler) http.Handler {
+	if s.orderLimiter == nil {
+		return nil
+	}
+	return []func(http.Handler) http.Handler{customMiddleware.OrderRateLimitMiddleware(s.orderLimiter, s.logger)}
+}
+
+// setupBatchRoutes configures bulk order creation under /orders
+func (s *Server) setupBatchRoutes(r chi.Router) {
+	if s.batchHandler == nil {
+		return
+	}
+
+	r.With(s.orderRateLimit()...).Post("/batch", s.batchHandler.CreateBatch) // A batch counts as one placement
+	r.Get("/batches/{batchID}", s.batchHandler.GetBatch)
+
+	s.logger.Info(nil, "Batch routes configured", map[string]interface{}{
+		"routes": []string{
+			"POST /api/v1/orders/batch",
+			"GET /api/v1/orders/batches/{batchID}",
+		},
+	})
+}
+
+// setupExportRoutes configures the order exports of account managers under /orders
+func (s *Server) setupExportRoutes(r chi.Router) {
+	if s.exportHandler == nil {
+		return
+	}
+
+	r.With(s.operatorOnly("orders.export")).Get("/export", s.exportHandler.ExportOrders)
+	r.With(s.operatorOnly("orders.export_status")).Get("/exports/{exportID}", s.exportHandler.GetExport)
+	// Downloads are authorized by their signed URL, so they open in a browser
+	r.Handle("/exports/files/*", s.exportHandler.DownloadExport())
+
+	s.logger.Info(nil, "Export routes configured", map[string]interface{}{
+		"routes": []string{
+			"GET /api/v1/orders/export",
+			"GET /api/v1/orders/exports/{exportID}",
+			"GET /api/v1/orders/exports/files/*",
+		},
+	})
+}
+
+// setupAddressRoutes configures the user address book routes
+func (s *Server) setupAddressRoutes(r chi.Router) {
+	r.Route("/users/{userID}/addresses", func(r chi.Router) {
+		r.Use(s.ownerOrAdmin("addresses"))
+		r.Post("/", s.addressHandler.CreateAddress)
+		r.Get("/", s.addressHandler.ListAddresses)
+
+		r.Route("/{addressID}", func(r chi.Router) {
+			r.Get("/", s.addressHandler.GetAddress)
+			r.Patch("/", s.addressHandler.UpdateAddress)
+			r.Delete("/", s.addressHandler.DeleteAddress)
+		})
+	})
+
+	s.logger.Info(nil, "Address routes configured", map[string]interface{}{
+		"routes": []string{
+			"POST /api/v1/users/{userID}/addresses",
+			"GET /api/v1/users/{userID}/addresses",
+			"GET /api/v1/users/{userID}/addresses/{addressID}",
+			"PATCH /api/v1/users/{userID}/addresses/{addressID}",
+			"DELETE /api/v1/users/{userID}/addresses/{addressID}",
+		},
+	})
+}
+
+// setupWebhookRoutes configures the customer order webhook routes
+func (s *Server) setupWebhookRoutes(r chi.Router) {
+	if s.webhookHandler == nil {
+		return
+	}
+
+	r.Route("/users/{userID}/webhooks", func(r chi.Router) {
+		r.Use(s.ownerOrAdmin("webhooks"))
+		r.Post("/", s.webhookHandler.CreateWebhook)
+		r.Get("/", s.webhookHandler.ListWebhooks)
+
+		r.Route("/{webhookID}", func(r chi.Router) {
+			r.Get("/", s.webhookHandler.GetWebhook)
+			r.Patch("/", s.webhookHandler.UpdateWebhook)
+			r.Delete("/", s.webhookHandler.DeleteWebhook)
+			r.Get("/deliveries", s.webhookHandler.ListDeliveries)
+		})
+	})
+
+	s.logger.Info(nil, "Webhook routes configured", map[string]interface{}{
+		"routes": []string{
+			"POST /api/v1/users/{userID}/webhooks",
+			"GET /api/v1/users/{userID}/webhooks",
+			"GET /api/v1/users/{userID}/webhooks/{webhookID}",
+			"PATCH /api/v1/users/{userID}/webhooks/{webhookID}",
+			"DELETE /api/v1/users/{userID}/webhooks/{webhookID}",
+			"GET /api/v1/users/{userID}/webhooks/{webhookID}/deliveries",
+		},
+	})
+}
+
+// setupApprovalRoutes configures the operator approval queue of high-value orders
+func (s *Server) setupApprovalRoutes(r chi.Router) {
+	if s.approvalHandler == nil {
+		return
+	}
+
+	r.With(s.operatorOnly("approvals.list")).Get("/approvals", s.approvalHandler.ListPendingApprovals)
+
+	s.logger.Info(nil, "Approval routes configured", map[string]interface{}{
+		"routes": []string{
+			"GET /api/v1/approvals",
+			"GET /api/v1/orders/{id}/approval",
+			"POST /api/v1/orders/{id}/approve",
+			"POST /api/v1/orders/{id}/reject",
+		},
+	})
+}
+
+// setupOrderApprovalRoutes configures the approval routes of a single order
+func (s *Server) setupOrderApprovalRoutes(r chi.Router) {
+	if s.approvalHandler == nil {
+		return
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Use(s.operatorOnly("orders.approval"))
+		r.Get("/approval", s.approvalHandler.GetApproval)
+		r.Post("/approve", s.approvalHandler.ApproveOrder)
+		r.Post("/reject", s.approvalHandler.RejectOrder)
+	})
+}
+
+// setupRefundRoutes configures the support queue of customers' refund requests
+func (s *Server) setupRefundRoutes(r chi.Router) {
+	if s.refundHandler == nil {
+		return
+	}
+
+	r.With(s.operatorOnly("refunds.list")).Get("/refunds", s.refundHandler.ListOpenRefunds)
+
+	s.logger.Info(nil, "Refund routes configured", map[string]interface{}{
+		"routes": []string{
+			"POST /api/v1/orders/{id}/refund-request",
+			"GET /api/v1/refunds",
+			"GET /api/v1/orders/{id}/refund",
+			"POST /api/v1/orders/{id}/refund/approve",
+			"POST /api/v1/orders/{id}/refund/reject",
+		},
+	})
+}
+
+// setupOrderRefundRoutes configures the refund routes of a single order: the
+// customer's request and its review by support
+func (s *Server) setupOrderRefundRoutes(r chi.Router) {
+	if s.refundHandler == nil {
+		return
+	}
+
+	r.Post("/refund-request", s.refundHandler.RequestRefund)
+	r.Route("/refund", func(r chi.Router) {
+		r.Use(s.operatorOnly("orders.refund"))
+		r.Get("/", s.refundHandler.GetRefund)
+		r.Post("/approve", s.refundHandler.ApproveRefund)
+		r.Post("/reject", s.refundHandler.RejectRefund)
+	})
+}
+
+// setupReportRoutes configures the operator order reporting routes
+func (s *Server) setupReportRoutes(r chi.Router) {
+	if s.reportHandler == nil {
+		return
+	}
+
+	r.Route("/reports", func(r chi.Router) {
+		r.Use(s.operatorOnly("reports"))
+		r.Get("/orders", s.reportHandler.ListOrderReports)
+		r.Get("/orders/{id}", s.reportHandler.GetOrderReport)
+		r.Get("/summary", s.reportHandler.GetSummary)
+	})
+
+	s.logger.Info(nil, "Report routes configured", map[string]interface{}{
+		"routes": []string{
+			"GET /api/v1/reports/orders",
+			"GET /api/v1/reports/orders/{id}",
+			"GET /api/v1/reports/summary",
+		},
+	})
+}
+
+// ownerOrAdmin restricts the routes of an endpoint under /users/{userID} to
+// that user and admins
+func (s *Server) ownerOrAdmin(endpoint string) func(http.Handler) http.Handler {
+	return s.authorizer.RequireOwner(endpoint, func(r *http.Request) string {
+		return chi.URLParam(r, "userID")
+	}, "admin")
+}
+
+// operatorOnly restricts the routes of an endpoint to operators and admins
+func (s *Server) operatorOnly(endpoint string) func(http.Handler) http.Handler {
+	return s.authorizer.RequireRole(endpoint, "operator", "admin")
+}
+
 // setupMetricsRoutes configures metrics and monitoring routes
 func (s *Server) setupMetricsRoutes(r chi.Router) {
 	// Additional monitoring endpoints
@@ -127,10 +473,13 @@ func (s *Server) setupMetricsRoutes(r chi.Router) {
 	} else {
 		r.Get("/metrics", s.handlePrometheusMetrics)
 	}
+	// Prometheus exposition, with trace exemplars on latency histograms
+	r.Handle("/metrics/prometheus", metrics.Handler(s.metrics))
 
 	s.logger.Info(nil, "Metrics routes configured", map[string]interface{}{
 		"routes": []string{
 			"GET /api/v1/metrics",
+			"GET /api/v1/metrics/prometheus",
 		},
 	})
 }
@@ -189,8 +538,7 @@ func (s *Server) GetRouter() *chi.Mux {
 
 // handlePrometheusMetrics exposes metrics in Prometheus format
 func (s *Server) handlePrometheusMetrics(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement Prometheus metrics exposition
-	// For now, return basic metrics from our metrics interface
+	// Prometheus exposition is served on /metrics/prometheus
 
 	if metricsData, ok := s.metrics.(interface{ GetMetrics() map[string]interface{} }); ok {
 		data := metricsData.GetMetrics()
@@ -241,11 +589,3 @@ func (s *Server) EnableAuthMiddleware() {
 	// TODO: Implement when auth middleware is ready
 	s.logger.Info(nil, "Authentication middleware enabled")
 }
-
-// EnableRateLimitMiddleware enables rate limiting
-func (s *Server) EnableRateLimitMiddleware(requestsPerMinute int) {
-	// TODO: Implement rate limiting with Chi
-	s.logger.Info(nil, "Rate limiting middleware enabled", map[string]interface{}{
-		"requests_per_minute": requestsPerMinute,
-	})
-}
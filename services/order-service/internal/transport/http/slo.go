@@ -0,0 +1,39 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/slo"
+)
+
+// Service level objectives of the order API. Endpoints are the method and chi
+// route pattern of the request. The Prometheus rules in
+// infrastructure/monitoring/prometheus/rules are generated from these with
+// `order-service --slo-rules`; regenerate them after changing an objective.
+var serviceLevelObjectives = []slo.Objective{
+	{Name: "api-availability", Kind: slo.KindAvailability, Target: 0.995},
+	{Name: "create-order-availability", Endpoint: "POST /api/v1/orders/", Kind: slo.KindAvailability, Target: 0.999},
+	{Name: "create-order-latency", Endpoint: "POST /api/v1/orders/", Kind: slo.KindLatency, Target: 0.99, Threshold: 2 * time.Second},
+	{Name: "get-order-latency", Endpoint: "GET /api/v1/orders/{id}/", Kind: slo.KindLatency, Target: 0.99, Threshold: 300 * time.Millisecond},
+	{Name: "list-orders-latency", Endpoint: "GET /api/v1/orders/", Kind: slo.KindLatency, Target: 0.99, Threshold: 500 * time.Millisecond},
+	{Name: "list-user-orders-latency", Endpoint: "GET /api/v1/users/{userID}/orders", Kind: slo.KindLatency, Target: 0.99, Threshold: 500 * time.Millisecond},
+}
+
+// ServiceLevelObjectives returns the objectives of the order API, recording
+// into m
+func ServiceLevelObjectives(m metrics.Metrics) (*slo.Set, error) {
+	return slo.NewSet("order-service", m, serviceLevelObjectives...)
+}
+
+// routeEndpoint names a request by its method and matched route pattern
+func routeEndpoint(r *http.Request) string {
+	pattern := "unmatched"
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		pattern = rctx.RoutePattern()
+	}
+	return r.Method + " " + pattern
+}
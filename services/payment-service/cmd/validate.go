@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/config"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+)
+
+// validateConfiguration loads the configuration the way startup does and
+// reports every problem found, optionally dialing the configured dependencies
+func validateConfiguration(profile *platformconfig.Profile, profileErr error, checkConnectivity bool) *platformconfig.Report {
+	report := platformconfig.NewReport(serviceName)
+
+	cfg, err := config.Load()
+	report.Check("load configuration", err)
+	report.CheckParsedValues()
+	report.CheckProfile(profile, profileErr)
+
+	if !checkConnectivity {
+		return report
+	}
+	if cfg == nil {
+		report.Skip("connectivity", "configuration did not load")
+		return report
+	}
+
+	if len(cfg.Kafka.Brokers) == 0 {
+		report.Skip("kafka broker", "event publishing disabled")
+	}
+	for _, broker := range cfg.Kafka.Brokers {
+		report.CheckReachable("kafka broker", broker)
+	}
+	if len(cfg.Kafka.Brokers) > 0 {
+		report.Check("kafka topics", kafka.VerifyTopics(cfg.Kafka.Brokers, cfg.Kafka.Topics, config.UsedTopics...))
+	}
+
+	return report
+}
@@ -2,15 +2,22 @@ package config
 
 import (
 	"fmt"
-	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	platformconfig "github.com/amiosamu/rocket-science/shared/platform/config"
 )
 
 // Config holds all configuration for the Payment Service
 type Config struct {
 	Server        ServerConfig
 	Payment       PaymentConfig
+	Risk          RiskConfig
+	Ledger        LedgerConfig
+	Settlement    SettlementConfig
+	Kafka         KafkaConfig
 	Observability ObservabilityConfig
 }
 
@@ -29,6 +36,46 @@ type PaymentConfig struct {
 	MaxAmount        float64
 }
 
+// RiskConfig contains risk scoring rules applied before a payment is processed
+type RiskConfig struct {
+	Enabled                bool
+	ReviewAmount           float64       // Payments above this amount are flagged for review (0 disables)
+	DeclineAmount          float64       // Payments above this amount are declined (0 disables)
+	VelocityWindow         time.Duration // Window used to count payments per user
+	VelocityReviewCount    int           // More payments than this within the window are flagged (0 disables)
+	VelocityDeclineCount   int           // More payments than this within the window are declined (0 disables)
+	DenylistUsers          []string      // User IDs whose payments are always declined
+	DenylistPaymentMethods []string      // e.g. "card:1234", "account:987654", "wallet:user@example.com"
+}
+
+// LedgerConfig contains the double-entry ledger settings
+type LedgerConfig struct {
+	Tenant        string  // Tenant the payments of this deployment are booked to
+	FeePercent    float64 // Processor fee as a percentage of each charge
+	FeeFixedMinor int64   // Fixed processor fee per charge, in the minor unit of the payment currency
+}
+
+// SettlementConfig contains the daily settlement export settings
+type SettlementConfig struct {
+	Enabled       bool          // Export the previous day's settlement batch once a day
+	ExportAt      time.Duration // Time after midnight UTC at which the daily export runs
+	StorageDir    string        // Directory the settlement files are stored in
+	URLSigningKey string        // Secret signing download URLs; a random key is used when empty
+	URLExpiry     time.Duration // How long signed download URLs stay valid
+	PublicURL     string        // Base URL of the health server as reachable by finance, e.g. "https://payments.internal:8081"
+	DebtorName    string        // Name of the account holder paying out the settlements in pain.001 files
+	CreditorName  string        // Name of the account holder receiving the settlements in pain.001 files
+}
+
+// KafkaConfig contains Kafka settings for publishing payment events
+type KafkaConfig struct {
+	Brokers []string // Empty disables event publishing
+	Topics  topics.Registry
+}
+
+// UsedTopics are the registered topics the service produces to
+var UsedTopics = []topics.Topic{topics.PaymentReviewEvents}
+
 // ObservabilityConfig contains observability settings
 type ObservabilityConfig struct {
 	LogLevel       string
@@ -52,6 +99,35 @@ func Load() (*Config, error) {
 			SuccessRate:      parseFloatOrDefault("PAYMENT_SUCCESS_RATE", "0.95"),
 			MaxAmount:        parseFloatOrDefault("PAYMENT_MAX_AMOUNT", "1000000.0"),
 		},
+		Risk: RiskConfig{
+			Enabled:                parseBoolOrDefault("PAYMENT_RISK_ENABLED", "true"),
+			ReviewAmount:           parseFloatOrDefault("PAYMENT_RISK_REVIEW_AMOUNT", "50000.0"),
+			DeclineAmount:          parseFloatOrDefault("PAYMENT_RISK_DECLINE_AMOUNT", "0"),
+			VelocityWindow:         parseDurationOrDefault("PAYMENT_RISK_VELOCITY_WINDOW", "10m"),
+			VelocityReviewCount:    parseIntOrDefault("PAYMENT_RISK_VELOCITY_REVIEW_COUNT", "5"),
+			VelocityDeclineCount:   parseIntOrDefault("PAYMENT_RISK_VELOCITY_DECLINE_COUNT", "20"),
+			DenylistUsers:          parseListOrDefault("PAYMENT_RISK_DENYLIST_USERS", ""),
+			DenylistPaymentMethods: parseListOrDefault("PAYMENT_RISK_DENYLIST_PAYMENT_METHODS", ""),
+		},
+		Ledger: LedgerConfig{
+			Tenant:        getEnvOrDefault("PAYMENT_LEDGER_TENANT", "default"),
+			FeePercent:    parseFloatOrDefault("PAYMENT_FEE_PERCENT", "2.9"),
+			FeeFixedMinor: int64(parseIntOrDefault("PAYMENT_FEE_FIXED_MINOR", "30")),
+		},
+		Settlement: SettlementConfig{
+			Enabled:       parseBoolOrDefault("PAYMENT_SETTLEMENT_EXPORT_ENABLED", "true"),
+			ExportAt:      parseDurationOrDefault("PAYMENT_SETTLEMENT_EXPORT_AT", "1h"),
+			StorageDir:    getEnvOrDefault("PAYMENT_SETTLEMENT_STORAGE_DIR", "./data/settlements"),
+			URLSigningKey: getEnvOrDefault("PAYMENT_SETTLEMENT_URL_SIGNING_KEY", ""),
+			URLExpiry:     parseDurationOrDefault("PAYMENT_SETTLEMENT_URL_EXPIRY", "15m"),
+			PublicURL:     getEnvOrDefault("PAYMENT_SETTLEMENT_PUBLIC_URL", ""),
+			DebtorName:    getEnvOrDefault("PAYMENT_SETTLEMENT_DEBTOR_NAME", "Payment processor"),
+			CreditorName:  getEnvOrDefault("PAYMENT_SETTLEMENT_CREDITOR_NAME", "Rocket Science"),
+		},
+		Kafka: KafkaConfig{
+			Brokers: parseListOrDefault("KAFKA_BROKERS", ""),
+			Topics:  topics.Load(platformconfig.Getenv),
+		},
 		Observability: ObservabilityConfig{
 			LogLevel:       getEnvOrDefault("LOG_LEVEL", "info"),
 			MetricsEnabled: parseBoolOrDefault("METRICS_ENABLED", "true"),
@@ -86,23 +162,53 @@ func (c *Config) validate() error {
 		return fmt.Errorf("payment processing time cannot be negative")
 	}
 
+	if c.Risk.ReviewAmount < 0 || c.Risk.DeclineAmount < 0 {
+		return fmt.Errorf("risk amount limits cannot be negative")
+	}
+
+	if c.Risk.ReviewAmount > 0 && c.Risk.DeclineAmount > 0 && c.Risk.DeclineAmount < c.Risk.ReviewAmount {
+		return fmt.Errorf("risk decline amount must not be lower than the review amount")
+	}
+
+	if c.Ledger.Tenant == "" {
+		return fmt.Errorf("ledger tenant cannot be empty")
+	}
+
+	if c.Ledger.FeePercent < 0 || c.Ledger.FeePercent >= 100 || c.Ledger.FeeFixedMinor < 0 {
+		return fmt.Errorf("payment fees must be non-negative and below 100%%")
+	}
+
+	if c.Settlement.ExportAt < 0 || c.Settlement.ExportAt >= 24*time.Hour {
+		return fmt.Errorf("settlement export time must be within the day")
+	}
+
+	if c.Settlement.URLExpiry <= 0 {
+		return fmt.Errorf("settlement URL expiry must be positive")
+	}
+
+	if err := c.Kafka.Topics.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // Helper functions for environment variable parsing
 
 func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := platformconfig.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
 func parseIntOrDefault(key string, defaultValue string) int {
-	if value := os.Getenv(key); value != "" {
-		if parsed, err := strconv.Atoi(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err == nil {
 			return parsed
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	if parsed, err := strconv.Atoi(defaultValue); err == nil {
 		return parsed
@@ -111,10 +217,12 @@ func parseIntOrDefault(key string, defaultValue string) int {
 }
 
 func parseFloatOrDefault(key string, defaultValue string) float64 {
-	if value := os.Getenv(key); value != "" {
-		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err == nil {
 			return parsed
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	if parsed, err := strconv.ParseFloat(defaultValue, 64); err == nil {
 		return parsed
@@ -123,10 +231,12 @@ func parseFloatOrDefault(key string, defaultValue string) float64 {
 }
 
 func parseBoolOrDefault(key string, defaultValue string) bool {
-	if value := os.Getenv(key); value != "" {
-		if parsed, err := strconv.ParseBool(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err == nil {
 			return parsed
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	if parsed, err := strconv.ParseBool(defaultValue); err == nil {
 		return parsed
@@ -135,13 +245,30 @@ func parseBoolOrDefault(key string, defaultValue string) bool {
 }
 
 func parseDurationOrDefault(key string, defaultValue string) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if parsed, err := time.ParseDuration(value); err == nil {
+	if value := platformconfig.Getenv(key); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err == nil {
 			return parsed
 		}
+		platformconfig.InvalidValue(key, value, err)
 	}
 	if parsed, err := time.ParseDuration(defaultValue); err == nil {
 		return parsed
 	}
 	return 30 * time.Second
 }
+
+func parseListOrDefault(key string, defaultValue string) []string {
+	value := getEnvOrDefault(key, defaultValue)
+	if value == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -2,14 +2,24 @@ package container
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log/slog"
 	"os"
 
 	"github.com/amiosamu/rocket-science/services/payment-service/internal/config"
+	paymentKafka "github.com/amiosamu/rocket-science/services/payment-service/internal/messaging/kafka"
 	"github.com/amiosamu/rocket-science/services/payment-service/internal/service"
 	grpcTransport "github.com/amiosamu/rocket-science/services/payment-service/internal/transport/grpc"
 	httpTransport "github.com/amiosamu/rocket-science/services/payment-service/internal/transport/http"
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/objectstore"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/grpclog"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
 )
 
 // Container manages all dependencies for the Payment Service
@@ -19,18 +29,27 @@ type Container struct {
 	config *config.Config
 
 	// Infrastructure
-	logger *slog.Logger
+	logger        *slog.Logger
+	maintenance   *maintenance.Mode
+	crashReporter recovery.Reporter
 
 	// Business Services
 	paymentService service.PaymentService
+	reviewQueue    service.ReviewQueue
+	ledger         service.Ledger
+	settlements    *service.SettlementExporter
+
+	// Messaging
+	reviewProducer *paymentKafka.ReviewEventProducer
 
 	// Transport Layer
 	grpcServer   *grpcTransport.Server
 	healthServer *httpTransport.HealthServer
 
 	// Lifecycle management
-	initialized bool
-	started     bool
+	stopSettlements context.CancelFunc
+	initialized     bool
+	started         bool
 }
 
 // ContainerOptions allows customization of container initialization
@@ -89,12 +108,22 @@ func (c *Container) Initialize() error {
 		"service", c.config.Observability.ServiceName,
 		"version", c.config.Observability.ServiceVersion)
 
+	// Panics recovered from gRPC calls are reported here
+	if err := c.initializeCrashReporter(); err != nil {
+		return fmt.Errorf("failed to initialize crash reporter: %w", err)
+	}
+
 	// Step 3: Initialize business services
 	if err := c.initializeServices(); err != nil {
 		return fmt.Errorf("failed to initialize services: %w", err)
 	}
 
-	// Step 4: Initialize transport layer
+	// Step 4: Initialize settlement export
+	if err := c.initializeSettlements(); err != nil {
+		return fmt.Errorf("failed to initialize settlement export: %w", err)
+	}
+
+	// Step 5: Initialize transport layer
 	if err := c.initializeTransport(); err != nil {
 		return fmt.Errorf("failed to initialize transport: %w", err)
 	}
@@ -127,6 +156,13 @@ func (c *Container) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start gRPC server: %w", err)
 	}
 
+	// Export the previous day's settlement files once a day
+	if c.config.Settlement.Enabled {
+		exportCtx, cancel := context.WithCancel(context.Background())
+		c.stopSettlements = cancel
+		go c.settlements.Run(exportCtx)
+	}
+
 	c.started = true
 	return nil
 }
@@ -139,6 +175,12 @@ func (c *Container) Stop() {
 
 	c.logger.Info("Stopping Payment Service")
 
+	// Stop the daily settlement export
+	if c.stopSettlements != nil {
+		c.stopSettlements()
+		c.stopSettlements = nil
+	}
+
 	// Stop gRPC server
 	if c.grpcServer != nil {
 		c.grpcServer.Stop()
@@ -149,6 +191,13 @@ func (c *Container) Stop() {
 		c.healthServer.Stop()
 	}
 
+	// Close Kafka producer after the servers so in-flight reviews can publish
+	if c.reviewProducer != nil {
+		if err := c.reviewProducer.Close(); err != nil {
+			c.logger.Error("Failed to close review event producer", "error", err)
+		}
+	}
+
 	c.logger.Info("Payment Service stopped successfully")
 	c.started = false
 }
@@ -168,6 +217,26 @@ func (c *Container) GetPaymentService() service.PaymentService {
 	return c.paymentService
 }
 
+// GetReviewQueue provides access to the manual review queue
+func (c *Container) GetReviewQueue() service.ReviewQueue {
+	return c.reviewQueue
+}
+
+// GetLedger provides access to the double-entry ledger
+func (c *Container) GetLedger() service.Ledger {
+	return c.ledger
+}
+
+// GetSettlementExporter provides access to the settlement export
+func (c *Container) GetSettlementExporter() *service.SettlementExporter {
+	return c.settlements
+}
+
+// GetMaintenanceMode provides access to the maintenance mode switch
+func (c *Container) GetMaintenanceMode() *maintenance.Mode {
+	return c.maintenance
+}
+
 // GetGRPCServer provides access to the gRPC server
 func (c *Container) GetGRPCServer() *grpcTransport.Server {
 	return c.grpcServer
@@ -275,15 +344,111 @@ func (c *Container) initializeLogger() error {
 	return nil
 }
 
+// initializeCrashReporter sets up the reporter of recovered panics
+func (c *Container) initializeCrashReporter() error {
+	recoveryConfig, err := recovery.FromEnv()
+	if err != nil {
+		return err
+	}
+
+	c.crashReporter, err = recovery.New(recoveryConfig, c.config.Observability.ServiceName, grpclog.FromSlog(c.logger))
+	if err != nil {
+		return err
+	}
+
+	c.logger.Debug("Crash reporter initialized", "sentry", recoveryConfig.SentryDSN != "")
+	return nil
+}
+
 // initializeServices creates all business services with their dependencies
 func (c *Container) initializeServices() error {
 	c.logger.Debug("Initializing business services")
 
 	// Create payment service with dependencies
 	// The service factory handles all internal wiring (repository, etc.)
-	c.paymentService = service.NewPaymentService(c.config, c.logger)
+	c.reviewQueue = service.NewInMemoryReviewQueue()
+	c.ledger = service.NewInMemoryLedger()
+	opts := []service.PaymentServiceOption{
+		service.WithReviewQueue(c.reviewQueue),
+		service.WithLedger(c.ledger),
+	}
+
+	// Review decisions are published to Kafka so order-service can resume held orders
+	if len(c.config.Kafka.Brokers) > 0 {
+		if err := kafka.VerifyTopics(c.config.Kafka.Brokers, c.config.Kafka.Topics, config.UsedTopics...); err != nil {
+			if c.config.Kafka.Topics.Strict() {
+				return fmt.Errorf("kafka topic check failed: %w", err)
+			}
+			c.logger.Warn("Kafka topic check failed", "error", err)
+		}
 
-	c.logger.Debug("Business services initialized successfully")
+		producer, err := c.newReviewEventProducer()
+		if err != nil {
+			return fmt.Errorf("failed to create review event producer: %w", err)
+		}
+		c.reviewProducer = producer
+		opts = append(opts, service.WithReviewEventPublisher(producer))
+	} else {
+		c.logger.Warn("Kafka brokers not configured, manual review decisions will not be published")
+	}
+
+	c.paymentService = service.NewPaymentService(c.config, c.logger, opts...)
+
+	c.logger.Debug("Business services initialized successfully",
+		"risk_enabled", c.config.Risk.Enabled)
+	return nil
+}
+
+// newReviewEventProducer creates the Kafka producer for manual review decisions
+func (c *Container) newReviewEventProducer() (*paymentKafka.ReviewEventProducer, error) {
+	sharedLogger, err := logging.NewServiceLogger(
+		c.config.Observability.ServiceName,
+		c.config.Observability.ServiceVersion,
+		c.config.Observability.LogLevel,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	sharedMetrics, err := metrics.NewMetrics(c.config.Observability.ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics: %w", err)
+	}
+
+	producerConfig := kafka.DefaultProducerConfig()
+	producerConfig.Brokers = c.config.Kafka.Brokers
+	producerConfig.ClientID = c.config.Observability.ServiceName
+
+	producer, err := kafka.NewProducer(producerConfig, sharedLogger, sharedMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	topic := c.config.Kafka.Topics.Name(topics.PaymentReviewEvents)
+	c.logger.Info("Review event producer created",
+		"brokers", c.config.Kafka.Brokers,
+		"topic", topic)
+
+	return paymentKafka.NewReviewEventProducer(producer, topic, c.logger), nil
+}
+
+// initializeSettlements sets up the settlement exporter on its object store
+func (c *Container) initializeSettlements() error {
+	store, err := objectstore.NewFileStore(c.config.Settlement.StorageDir)
+	if err != nil {
+		return err
+	}
+
+	signingKey := []byte(c.config.Settlement.URLSigningKey)
+	if len(signingKey) == 0 {
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			return fmt.Errorf("failed to generate URL signing key: %w", err)
+		}
+		c.logger.Warn("Settlement URL signing key not configured, signed URLs will not survive a restart")
+	}
+
+	c.settlements = service.NewSettlementExporter(c.config, c.logger, c.ledger, store, objectstore.NewSigner(signingKey))
 	return nil
 }
 
@@ -291,11 +456,19 @@ func (c *Container) initializeServices() error {
 func (c *Container) initializeTransport() error {
 	c.logger.Debug("Initializing transport layer")
 
+	// Maintenance mode switch shared by the gRPC and health servers
+	c.maintenance = maintenance.FromEnv()
+	if c.maintenance.Enabled() {
+		c.logger.Warn("Starting in maintenance mode", "reason", c.maintenance.Status().Reason)
+	}
+
 	// Create gRPC server with all dependencies
-	c.grpcServer = grpcTransport.NewServer(c.config, c.logger, c.paymentService)
+	c.grpcServer = grpcTransport.NewServerWithOptions(c.config, c.logger, c.paymentService,
+		grpcTransport.WithMaintenanceMode(c.maintenance),
+		grpcTransport.WithCrashReporter(c.crashReporter))
 
 	// Create health server
-	c.healthServer = httpTransport.NewHealthServer(c.logger, c.config, c.paymentService)
+	c.healthServer = httpTransport.NewHealthServer(c.logger, c.config, c.paymentService, c.maintenance, c.reviewQueue, c.ledger, c.settlements)
 
 	c.logger.Debug("Transport layer initialized successfully")
 	return nil
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ledger errors
+var (
+	ErrUnbalancedEntry = errors.New("journal entry debits and credits do not balance")
+	ErrInvalidEntry    = errors.New("invalid journal entry")
+)
+
+// AccountType classifies ledger accounts; it decides on which side an account's
+// balance normally sits
+type AccountType string
+
+const (
+	AccountTypeAsset         AccountType = "asset"
+	AccountTypeRevenue       AccountType = "revenue"
+	AccountTypeContraRevenue AccountType = "contra_revenue" // Reduces revenue, e.g. refunds
+	AccountTypeExpense       AccountType = "expense"
+)
+
+// DebitNormal reports whether debits increase the account's balance
+func (t AccountType) DebitNormal() bool {
+	return t != AccountTypeRevenue
+}
+
+// Account is an account of the chart of accounts
+type Account struct {
+	Code string      `json:"code"`
+	Name string      `json:"name"`
+	Type AccountType `json:"type"`
+}
+
+// Chart of accounts. Money the processor collected for us but has not paid out
+// sits in processor clearing.
+var (
+	AccountProcessorClearing = Account{Code: "1100", Name: "Processor clearing", Type: AccountTypeAsset}
+	AccountSalesRevenue      = Account{Code: "4000", Name: "Sales revenue", Type: AccountTypeRevenue}
+	AccountRefunds           = Account{Code: "4100", Name: "Refunds", Type: AccountTypeContraRevenue}
+	AccountProcessingFees    = Account{Code: "6100", Name: "Payment processing fees", Type: AccountTypeExpense}
+)
+
+// Accounts lists the chart of accounts
+func Accounts() []Account {
+	return []Account{AccountProcessorClearing, AccountSalesRevenue, AccountRefunds, AccountProcessingFees}
+}
+
+// AccountByCode returns the account with the code
+func AccountByCode(code string) (Account, bool) {
+	for _, account := range Accounts() {
+		if account.Code == code {
+			return account, true
+		}
+	}
+	return Account{}, false
+}
+
+// EntryKind says what a journal entry records
+type EntryKind string
+
+const (
+	EntryKindCharge EntryKind = "charge"
+	EntryKindRefund EntryKind = "refund"
+	EntryKindFee    EntryKind = "fee"
+)
+
+// JournalLine debits or credits one account. Exactly one of Debit and Credit is set.
+type JournalLine struct {
+	Account string // Account code
+	Debit   Money
+	Credit  Money
+}
+
+// Debit returns a line debiting the account
+func Debit(account Account, amount Money) JournalLine {
+	return JournalLine{Account: account.Code, Debit: amount, Credit: Money{Currency: amount.Currency}}
+}
+
+// Credit returns a line crediting the account
+func Credit(account Account, amount Money) JournalLine {
+	return JournalLine{Account: account.Code, Debit: Money{Currency: amount.Currency}, Credit: amount}
+}
+
+// JournalEntry is a balanced set of journal lines recording one financial event
+// of an order. Entries are immutable once posted; mistakes are corrected with
+// further entries.
+type JournalEntry struct {
+	ID            string
+	Tenant        string
+	OrderID       string
+	TransactionID string
+	Kind          EntryKind
+	Description   string
+	Currency      string
+	Lines         []JournalLine
+	PostedAt      time.Time
+}
+
+// NewJournalEntry creates a journal entry, checking that its lines are in one
+// currency, use known accounts and balance
+func NewJournalEntry(tenant, orderID, transactionID string, kind EntryKind, description string, lines ...JournalLine) (*JournalEntry, error) {
+	if tenant == "" || orderID == "" {
+		return nil, fmt.Errorf("%w: tenant and order ID are required", ErrInvalidEntry)
+	}
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("%w: at least two lines are required", ErrInvalidEntry)
+	}
+
+	currency := lines[0].Debit.Currency
+	var debits, credits int64
+	for _, line := range lines {
+		if _, ok := AccountByCode(line.Account); !ok {
+			return nil, fmt.Errorf("%w: unknown account %q", ErrInvalidEntry, line.Account)
+		}
+		if line.Debit.Currency != currency || line.Credit.Currency != currency {
+			return nil, fmt.Errorf("%w: lines must share one currency", ErrInvalidEntry)
+		}
+		if line.Debit.IsNegative() || line.Credit.IsNegative() || line.Debit.IsZero() == line.Credit.IsZero() {
+			return nil, fmt.Errorf("%w: each line must either debit or credit a positive amount", ErrInvalidEntry)
+		}
+		debits += line.Debit.Minor
+		credits += line.Credit.Minor
+	}
+	if debits != credits {
+		return nil, fmt.Errorf("%w: debits %d, credits %d", ErrUnbalancedEntry, debits, credits)
+	}
+
+	return &JournalEntry{
+		ID:            uuid.New().String(),
+		Tenant:        tenant,
+		OrderID:       orderID,
+		TransactionID: transactionID,
+		Kind:          kind,
+		Description:   description,
+		Currency:      currency,
+		Lines:         lines,
+		PostedAt:      time.Now().UTC(),
+	}, nil
+}
+
+// AccountBalance is the balance of an account in one currency, positive on the
+// account's normal side
+type AccountBalance struct {
+	Account Account
+	Debits  Money
+	Credits Money
+	Balance Money
+}
+
+// Balances totals the entries per account and currency, in chart of accounts order
+func Balances(entries []*JournalEntry) []AccountBalance {
+	type key struct{ account, currency string }
+	totals := make(map[key]*AccountBalance)
+	var currencies []string
+	seen := make(map[string]bool)
+
+	for _, entry := range entries {
+		if !seen[entry.Currency] {
+			seen[entry.Currency] = true
+			currencies = append(currencies, entry.Currency)
+		}
+		for _, line := range entry.Lines {
+			k := key{line.Account, entry.Currency}
+			total, exists := totals[k]
+			if !exists {
+				account, _ := AccountByCode(line.Account)
+				total = &AccountBalance{
+					Account: account,
+					Debits:  Money{Currency: entry.Currency},
+					Credits: Money{Currency: entry.Currency},
+				}
+				totals[k] = total
+			}
+			total.Debits.Minor += line.Debit.Minor
+			total.Credits.Minor += line.Credit.Minor
+		}
+	}
+
+	var balances []AccountBalance
+	for _, account := range Accounts() {
+		for _, currency := range currencies {
+			total, exists := totals[key{account.Code, currency}]
+			if !exists {
+				continue
+			}
+			balance := total.Debits.Minor - total.Credits.Minor
+			if !account.Type.DebitNormal() {
+				balance = -balance
+			}
+			total.Balance = Money{Minor: balance, Currency: currency}
+			balances = append(balances, *total)
+		}
+	}
+	return balances
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
 )
 
 // Payment represents the core payment entity in our domain
@@ -45,6 +47,7 @@ const (
 	PaymentStatusCancelled                     // Payment was cancelled by user/system
 	PaymentStatusRefunded                      // Payment was fully refunded
 	PaymentStatusPartiallyRefunded             // Payment was partially refunded
+	PaymentStatusPendingReview                 // Payment is held for manual review
 )
 
 // String provides human-readable status names
@@ -62,31 +65,20 @@ func (ps PaymentStatus) String() string {
 		return "refunded"
 	case PaymentStatusPartiallyRefunded:
 		return "partially_refunded"
+	case PaymentStatusPendingReview:
+		return "pending_review"
 	default:
 		return "unknown"
 	}
 }
 
 // Money is a value object that encapsulates amount and currency
-// Value objects are immutable and compared by value, not identity
-type Money struct {
-	Amount   float64 // The monetary amount
-	Currency string  // Currency code (e.g., "USD", "EUR")
-}
-
-// IsValid checks if the money value makes business sense
-func (m Money) IsValid() bool {
-	return m.Amount >= 0 && m.Currency != "" && len(m.Currency) == 3
-}
-
-// Equals compares two Money values for equality
-func (m Money) Equals(other Money) bool {
-	return m.Amount == other.Amount && m.Currency == other.Currency
-}
+// Amounts are exact minor units (cents), so comparisons and refunds never drift
+type Money = money.Money
 
-// String provides a formatted representation of money
-func (m Money) String() string {
-	return fmt.Sprintf("%.2f %s", m.Amount, m.Currency)
+// isValidMoney checks if the money value makes business sense
+func isValidMoney(m Money) bool {
+	return !m.IsNegative() && money.ValidCurrency(m.Currency)
 }
 
 // PaymentMethod represents how the payment was made
@@ -178,10 +170,10 @@ func NewPayment(orderID, userID string, amount Money, paymentMethod PaymentMetho
 	if userID == "" {
 		return nil, ErrInvalidUserID
 	}
-	if !amount.IsValid() {
+	if !isValidMoney(amount) {
 		return nil, ErrInvalidAmount
 	}
-	if amount.Amount <= 0 {
+	if !amount.IsPositive() {
 		return nil, ErrInvalidAmount
 	}
 
@@ -251,6 +243,66 @@ func (p *Payment) markAsFailed(reason string) error {
 	return nil
 }
 
+// Decline rejects a pending payment before it reaches the payment processor,
+// e.g. when risk assessment deems it too risky
+func (p *Payment) Decline(reason string) error {
+	return p.markAsFailed(reason)
+}
+
+// HoldForReview parks a pending payment until a reviewer approves or declines it
+func (p *Payment) HoldForReview(reason string) error {
+	if p.status != PaymentStatusPending {
+		return ErrPaymentNotPending
+	}
+
+	p.status = PaymentStatusPendingReview
+	p.message = reason
+
+	return nil
+}
+
+// ApproveReview releases a payment held for review so it can be processed
+func (p *Payment) ApproveReview(reviewer string) error {
+	if p.status != PaymentStatusPendingReview {
+		return ErrPaymentNotPendingReview
+	}
+
+	p.status = PaymentStatusPending
+	p.message = fmt.Sprintf("Approved by %s after manual review", reviewer)
+	p.metadata["reviewed_by"] = reviewer
+
+	return nil
+}
+
+// DeclineReview fails a payment held for review
+func (p *Payment) DeclineReview(reviewer, reason string) error {
+	if p.status != PaymentStatusPendingReview {
+		return ErrPaymentNotPendingReview
+	}
+
+	p.status = PaymentStatusFailed
+	p.message = fmt.Sprintf("Declined by %s after manual review: %s", reviewer, reason)
+	p.metadata["reviewed_by"] = reviewer
+
+	return nil
+}
+
+// MarkSandbox marks the payment as test data of a sandbox tenant. Sandbox
+// payments are always charged by the simulator and kept off the books.
+func (p *Payment) MarkSandbox() {
+	p.metadata["sandbox"] = "true"
+}
+
+// IsSandbox reports whether the payment is test data of a sandbox tenant
+func (p *Payment) IsSandbox() bool {
+	return p.metadata["sandbox"] == "true"
+}
+
+// SetMetadata attaches a metadata value to the payment
+func (p *Payment) SetMetadata(key, value string) {
+	p.metadata[key] = value
+}
+
 // Cancel cancels a pending payment
 func (p *Payment) Cancel(reason string) error {
 	if p.status != PaymentStatusPending {
@@ -269,7 +321,7 @@ func (p *Payment) Refund(amount Money, reason string) error {
 		return ErrCannotRefundNonCompletedPayment
 	}
 	
-	if !amount.IsValid() || amount.Amount <= 0 {
+	if !isValidMoney(amount) || !amount.IsPositive() {
 		return ErrInvalidRefundAmount
 	}
 	
@@ -279,12 +331,12 @@ func (p *Payment) Refund(amount Money, reason string) error {
 	
 	// For simplicity, we'll just change status
 	// In real systems, you'd track refund amounts
-	if amount.Amount >= p.amount.Amount {
+	if amount.Minor >= p.amount.Minor {
 		p.status = PaymentStatusRefunded
 		p.message = fmt.Sprintf("Fully refunded: %s", reason)
 	} else {
 		p.status = PaymentStatusPartiallyRefunded
-		p.message = fmt.Sprintf("Partially refunded %.2f %s: %s", amount.Amount, amount.Currency, reason)
+		p.message = fmt.Sprintf("Partially refunded %s: %s", amount, reason)
 	}
 	
 	return nil
@@ -303,12 +355,18 @@ func (p *Payment) Message() string { return p.message }
 func (p *Payment) CreatedAt() time.Time { return p.createdAt }
 func (p *Payment) ProcessedAt() *time.Time { return p.processedAt }
 func (p *Payment) Description() string { return p.description }
+func (p *Payment) Metadata(key string) string { return p.metadata[key] }
 
 // IsCompleted is a convenience method for checking if payment succeeded
 func (p *Payment) IsCompleted() bool {
 	return p.status == PaymentStatusCompleted
 }
 
+// IsPendingReview is a convenience method for checking if payment awaits manual review
+func (p *Payment) IsPendingReview() bool {
+	return p.status == PaymentStatusPendingReview
+}
+
 // IsFailed is a convenience method for checking if payment failed
 func (p *Payment) IsFailed() bool {
 	return p.status == PaymentStatusFailed
@@ -321,6 +379,7 @@ var (
 	ErrInvalidUserID                     = errors.New("user ID cannot be empty")
 	ErrInvalidAmount                     = errors.New("amount must be positive and have valid currency")
 	ErrPaymentNotPending                 = errors.New("payment is not in pending status")
+	ErrPaymentNotPendingReview           = errors.New("payment is not awaiting manual review")
 	ErrInvalidStatusTransition           = errors.New("invalid payment status transition")
 	ErrCannotCancelNonPendingPayment     = errors.New("can only cancel pending payments")
 	ErrCannotRefundNonCompletedPayment   = errors.New("can only refund completed or partially refunded payments")
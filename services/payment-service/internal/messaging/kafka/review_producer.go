@@ -0,0 +1,71 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+)
+
+// Review decision event types consumed by order-service
+const (
+	EventTypeReviewApproved = "payment.review.approved"
+	EventTypeReviewDeclined = "payment.review.declined"
+)
+
+// ReviewEventProducer publishes manual review decisions to Kafka
+type ReviewEventProducer struct {
+	producer *kafka.Producer
+	topic    string
+	logger   *slog.Logger
+}
+
+// NewReviewEventProducer creates a new review decision producer on top of the shared Kafka producer
+func NewReviewEventProducer(producer *kafka.Producer, topic string, logger *slog.Logger) *ReviewEventProducer {
+	return &ReviewEventProducer{
+		producer: producer,
+		topic:    topic,
+		logger:   logger.With("component", "review_event_producer"),
+	}
+}
+
+// PublishReviewDecision implements service.ReviewEventPublisher
+func (p *ReviewEventProducer) PublishReviewDecision(ctx context.Context, event service.ReviewDecisionEvent) error {
+	eventType := EventTypeReviewApproved
+	if event.Decision == service.ReviewDecisionDecline {
+		eventType = EventTypeReviewDeclined
+	}
+	eventID := uuid.New().String()
+
+	headers := map[string]string{
+		"event-type":     eventType,
+		"event-id":       eventID,
+		"event-version":  "1.0",
+		"source-service": "payment-service",
+		"order-id":       event.OrderID,
+	}
+
+	// Partition by order ID so decisions are ordered with other events for the same order
+	if err := p.producer.SendMessage(ctx, p.topic, event.OrderID, event, headers); err != nil {
+		return fmt.Errorf("failed to publish review decision: %w", err)
+	}
+
+	p.logger.Info("Review decision published",
+		"eventType", eventType,
+		"eventID", eventID,
+		"topic", p.topic,
+		"orderID", event.OrderID,
+		"transactionID", event.TransactionID,
+		"paymentStatus", event.PaymentStatus)
+
+	return nil
+}
+
+// Close closes the underlying Kafka producer
+func (p *ReviewEventProducer) Close() error {
+	return p.producer.Close()
+}
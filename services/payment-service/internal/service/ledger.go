@@ -0,0 +1,198 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/domain"
+)
+
+// LedgerFilter selects journal entries; empty fields match everything
+type LedgerFilter struct {
+	Tenant  string
+	OrderID string
+	Since   time.Time // Entries posted at or after
+	Until   time.Time // Entries posted before
+}
+
+func (f LedgerFilter) matches(entry *domain.JournalEntry) bool {
+	return (f.Tenant == "" || entry.Tenant == f.Tenant) &&
+		(f.OrderID == "" || entry.OrderID == f.OrderID) &&
+		(f.Since.IsZero() || !entry.PostedAt.Before(f.Since)) &&
+		(f.Until.IsZero() || entry.PostedAt.Before(f.Until))
+}
+
+// Ledger is the double-entry journal of the service's charges, refunds and fees
+type Ledger interface {
+	// Post appends a balanced journal entry
+	Post(entry *domain.JournalEntry) error
+
+	// Entries returns the matching entries, oldest first
+	Entries(filter LedgerFilter) ([]*domain.JournalEntry, error)
+
+	// Balances returns the account balances over the matching entries
+	Balances(filter LedgerFilter) ([]domain.AccountBalance, error)
+
+	// DeleteByOrderID removes the entries of an order. The journal is append
+	// only; test data purges are the one exception.
+	DeleteByOrderID(orderID string) (int, error)
+}
+
+// inMemoryLedger is the in-memory Ledger, matching the service's in-memory payment storage
+type inMemoryLedger struct {
+	entries []*domain.JournalEntry
+	mutex   sync.RWMutex
+}
+
+// NewInMemoryLedger creates a new in-memory ledger
+func NewInMemoryLedger() Ledger {
+	return &inMemoryLedger{}
+}
+
+func (l *inMemoryLedger) Post(entry *domain.JournalEntry) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.entries = append(l.entries, entry)
+	return nil
+}
+
+func (l *inMemoryLedger) Entries(filter LedgerFilter) ([]*domain.JournalEntry, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	var entries []*domain.JournalEntry
+	for _, entry := range l.entries {
+		if filter.matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].PostedAt.Before(entries[j].PostedAt)
+	})
+	return entries, nil
+}
+
+func (l *inMemoryLedger) Balances(filter LedgerFilter) ([]domain.AccountBalance, error) {
+	entries, err := l.Entries(filter)
+	if err != nil {
+		return nil, err
+	}
+	return domain.Balances(entries), nil
+}
+
+func (l *inMemoryLedger) DeleteByOrderID(orderID string) (int, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	kept := l.entries[:0]
+	for _, entry := range l.entries {
+		if entry.OrderID != orderID {
+			kept = append(kept, entry)
+		}
+	}
+	deleted := len(l.entries) - len(kept)
+	for i := len(kept); i < len(l.entries); i++ {
+		l.entries[i] = nil
+	}
+	l.entries = kept
+	return deleted, nil
+}
+
+// recordCharge books a completed payment and the processor's fee on it.
+// The payment is already saved, so ledger failures are logged rather than returned.
+func (s *paymentService) recordCharge(payment *domain.Payment) {
+	if payment.IsSandbox() {
+		return // Nothing was charged; test data stays out of the ledger and settlements
+	}
+	amount := payment.Amount()
+	s.post(payment, domain.EntryKindCharge,
+		fmt.Sprintf("Charge for order %s", payment.OrderID()),
+		domain.Debit(domain.AccountProcessorClearing, amount),
+		domain.Credit(domain.AccountSalesRevenue, amount))
+
+	if fee := s.processingFee(amount); fee.IsPositive() {
+		s.post(payment, domain.EntryKindFee,
+			fmt.Sprintf("Processing fee for order %s", payment.OrderID()),
+			domain.Debit(domain.AccountProcessingFees, fee),
+			domain.Credit(domain.AccountProcessorClearing, fee))
+	}
+}
+
+// recordRefund books a refund of a payment. Processing fees are not returned
+// on refunds, so none are reversed.
+func (s *paymentService) recordRefund(payment *domain.Payment, amount domain.Money, reason string) {
+	if payment.IsSandbox() {
+		return
+	}
+	s.post(payment, domain.EntryKindRefund,
+		fmt.Sprintf("Refund for order %s: %s", payment.OrderID(), reason),
+		domain.Debit(domain.AccountRefunds, amount),
+		domain.Credit(domain.AccountProcessorClearing, amount))
+}
+
+func (s *paymentService) post(payment *domain.Payment, kind domain.EntryKind, description string, lines ...domain.JournalLine) {
+	entry, err := domain.NewJournalEntry(s.config.Ledger.Tenant, payment.OrderID(), payment.TransactionID(), kind, description, lines...)
+	if err == nil {
+		err = s.ledger.Post(entry)
+	}
+	if err != nil {
+		s.logger.Error("Failed to post journal entry",
+			"transactionID", payment.TransactionID(),
+			"orderID", payment.OrderID(),
+			"kind", kind,
+			"error", err)
+	}
+}
+
+// processingFee is the processor's fee on a charge, never more than the charge itself
+func (s *paymentService) processingFee(amount domain.Money) domain.Money {
+	fee := int64(math.Round(float64(amount.Minor)*s.config.Ledger.FeePercent/100)) + s.config.Ledger.FeeFixedMinor
+	if fee > amount.Minor {
+		fee = amount.Minor
+	}
+	return domain.Money{Minor: fee, Currency: amount.Currency}
+}
+
+// ledgerExportHeader lists the columns of the journal export
+var ledgerExportHeader = []string{
+	"entry_id", "posted_at", "tenant", "order_id", "transaction_id", "kind",
+	"account_code", "account_name", "debit", "credit", "currency", "description",
+}
+
+// ExportJournalCSV writes the entries as CSV, one row per journal line, for
+// import into accounting tools
+func ExportJournalCSV(w io.Writer, entries []*domain.JournalEntry) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(ledgerExportHeader); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		for _, line := range entry.Lines {
+			account, _ := domain.AccountByCode(line.Account)
+			if err := writer.Write([]string{
+				entry.ID,
+				entry.PostedAt.Format(time.RFC3339Nano),
+				entry.Tenant,
+				entry.OrderID,
+				entry.TransactionID,
+				string(entry.Kind),
+				account.Code,
+				account.Name,
+				line.Debit.Amount(),
+				line.Credit.Amount(),
+				entry.Currency,
+				entry.Description,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/domain"
+)
+
+// refundOrderPayments refunds every completed payment of an order in full, for
+// refunds of a whole order whose caller does not track its transactions. A
+// split order may have been charged once per shipment.
+func (s *paymentService) refundOrderPayments(req RefundPaymentRequest) (*RefundPaymentResult, error) {
+	payments, err := s.repository.FindByOrderID(req.OrderID)
+	if err != nil {
+		s.logger.Error("Error finding order payments for refund", "orderID", req.OrderID, "error", err)
+		return nil, fmt.Errorf("failed to find order payments: %w", err)
+	}
+
+	var refundable []*domain.Payment
+	for _, payment := range payments {
+		if payment.Status() == domain.PaymentStatusCompleted {
+			refundable = append(refundable, payment)
+		}
+	}
+	if len(refundable) == 0 {
+		return &RefundPaymentResult{
+			Success: false,
+			Message: "No completed payment to refund for the order",
+		}, nil
+	}
+
+	refunded := domain.Money{Currency: refundable[0].Amount().Currency}
+	transactionIDs := make([]string, 0, len(refundable))
+	for _, payment := range refundable {
+		amount := payment.Amount()
+		total, err := refunded.Add(amount)
+		if err != nil {
+			return &RefundPaymentResult{
+				Success: false,
+				Message: fmt.Sprintf("Order was paid in several currencies: %v", err),
+			}, nil
+		}
+
+		if err := payment.Refund(amount, req.Reason); err != nil {
+			s.logger.Warn("Order payment refund failed", "error", err, "transactionID", payment.TransactionID())
+			return &RefundPaymentResult{
+				Success:        false,
+				RefundedAmount: refunded,
+				Message:        err.Error(),
+			}, nil
+		}
+		if err := s.repository.Save(payment); err != nil {
+			s.logger.Error("Failed to save refunded payment", "error", err)
+			return nil, fmt.Errorf("failed to save refunded payment: %w", err)
+		}
+		s.recordRefund(payment, amount, req.Reason)
+
+		refunded = total
+		transactionIDs = append(transactionIDs, payment.TransactionID())
+	}
+
+	s.logger.Info("Order refund processed successfully",
+		"orderID", req.OrderID,
+		"transactions", len(transactionIDs),
+		"refundAmount", refunded.String(),
+		"requestedBy", req.RequestedBy)
+
+	orderRef := req.OrderID
+	if len(orderRef) > 8 {
+		orderRef = orderRef[:8]
+	}
+	return &RefundPaymentResult{
+		Success:               true,
+		RefundID:              fmt.Sprintf("ref_%d_%s", time.Now().Unix(), orderRef),
+		OriginalTransactionID: strings.Join(transactionIDs, ","),
+		RefundedAmount:        refunded,
+		Message:               "Refund processed successfully",
+		ProcessedAt:           time.Now(),
+	}, nil
+}
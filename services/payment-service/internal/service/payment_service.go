@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/amiosamu/rocket-science/services/payment-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/payment-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
 )
 
 // PaymentService defines the interface for payment operations
@@ -25,6 +28,12 @@ type PaymentService interface {
 	
 	// GetPaymentsByOrderID retrieves all payments for an order
 	GetPaymentsByOrderID(ctx context.Context, orderID string) ([]*domain.Payment, error)
+
+	// ReviewPayment applies a manual review decision to a payment held for review
+	ReviewPayment(ctx context.Context, req ReviewPaymentRequest) (*ReviewPaymentResult, error)
+
+	// PurgeOrders deletes the payments of test orders, for resetting demo environments
+	PurgeOrders(ctx context.Context, orderIDs []string, dryRun bool) (*PurgeOrdersResult, error)
 }
 
 // Service DTOs - Data Transfer Objects for the service layer
@@ -34,10 +43,10 @@ type PaymentService interface {
 type ProcessPaymentRequest struct {
 	OrderID       string
 	UserID        string
-	Amount        float64
-	Currency      string
+	Amount        domain.Money
 	PaymentMethod PaymentMethodDTO
 	Description   string
+	Sandbox       bool // Test payment of a sandbox tenant
 }
 
 type ProcessPaymentResult struct {
@@ -46,8 +55,7 @@ type ProcessPaymentResult struct {
 	Message       string
 	Status        string
 	ProcessedAt   time.Time
-	Amount        float64
-	Currency      string
+	Amount        domain.Money
 }
 
 type GetPaymentStatusRequest struct {
@@ -60,8 +68,7 @@ type GetPaymentStatusResult struct {
 	TransactionID string
 	OrderID       string
 	Status        string
-	Amount        float64
-	Currency      string
+	Amount        domain.Money
 	CreatedAt     time.Time
 	ProcessedAt   *time.Time
 	Message       string
@@ -69,7 +76,8 @@ type GetPaymentStatusResult struct {
 
 type RefundPaymentRequest struct {
 	TransactionID string
-	Amount        float64
+	OrderID       string       // Without TransactionID, the completed payments of the order are refunded in full
+	Amount        domain.Money // An empty currency means the currency of the payment
 	Reason        string
 	RequestedBy   string
 }
@@ -78,11 +86,26 @@ type RefundPaymentResult struct {
 	Success               bool
 	RefundID              string
 	OriginalTransactionID string
-	RefundedAmount        float64
+	RefundedAmount        domain.Money
 	Message               string
 	ProcessedAt           time.Time
 }
 
+type ReviewPaymentRequest struct {
+	TransactionID string
+	Decision      string // "approve" or "decline"
+	Reviewer      string
+	Reason        string
+}
+
+type ReviewPaymentResult struct {
+	Success       bool
+	TransactionID string
+	Status        string
+	Message       string
+	ProcessedAt   *time.Time
+}
+
 type PaymentMethodDTO struct {
 	Type            string
 	CreditCard      *CreditCardDTO
@@ -113,9 +136,44 @@ type DigitalWalletDTO struct {
 
 // paymentService is the concrete implementation of PaymentService
 type paymentService struct {
-	config     *config.Config
-	logger     *slog.Logger
-	repository PaymentRepository  // We'll implement this as in-memory for now
+	config       *config.Config
+	logger       *slog.Logger
+	repository   PaymentRepository  // We'll implement this as in-memory for now
+	riskAssessor RiskAssessor
+	reviewQueue  ReviewQueue
+	publisher    ReviewEventPublisher
+	ledger       Ledger
+}
+
+// PaymentServiceOption customizes the payment service
+type PaymentServiceOption func(*paymentService)
+
+// WithRiskAssessor replaces the rules-based risk assessor
+func WithRiskAssessor(assessor RiskAssessor) PaymentServiceOption {
+	return func(s *paymentService) {
+		s.riskAssessor = assessor
+	}
+}
+
+// WithReviewEventPublisher sets the publisher notified of manual review decisions
+func WithReviewEventPublisher(publisher ReviewEventPublisher) PaymentServiceOption {
+	return func(s *paymentService) {
+		s.publisher = publisher
+	}
+}
+
+// WithReviewQueue sets the queue receiving payments flagged for manual review
+func WithReviewQueue(queue ReviewQueue) PaymentServiceOption {
+	return func(s *paymentService) {
+		s.reviewQueue = queue
+	}
+}
+
+// WithLedger sets the ledger charges, refunds and fees are booked to
+func WithLedger(ledger Ledger) PaymentServiceOption {
+	return func(s *paymentService) {
+		s.ledger = ledger
+	}
 }
 
 // PaymentRepository interface for payment persistence
@@ -125,15 +183,30 @@ type PaymentRepository interface {
 	FindByID(id string) (*domain.Payment, error)
 	FindByTransactionID(transactionID string) (*domain.Payment, error)
 	FindByOrderID(orderID string) ([]*domain.Payment, error)
+	DeleteByOrderID(orderID string) (int, error)
 }
 
 // NewPaymentService creates a new payment service with dependencies
-func NewPaymentService(cfg *config.Config, logger *slog.Logger) PaymentService {
-	return &paymentService{
-		config:     cfg,
-		logger:     logger,
-		repository: NewInMemoryPaymentRepository(), // In-memory implementation
+func NewPaymentService(cfg *config.Config, logger *slog.Logger, opts ...PaymentServiceOption) PaymentService {
+	s := &paymentService{
+		config:      cfg,
+		logger:      logger,
+		repository:  NewInMemoryPaymentRepository(), // In-memory implementation
+		reviewQueue: NewInMemoryReviewQueue(),
+		ledger:      NewInMemoryLedger(),
 	}
+
+	if cfg.Risk.Enabled {
+		s.riskAssessor = NewRulesRiskAssessor(cfg.Risk)
+	} else {
+		s.riskAssessor = noopRiskAssessor{}
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // ProcessPayment implements the main payment processing workflow
@@ -141,8 +214,7 @@ func (s *paymentService) ProcessPayment(ctx context.Context, req ProcessPaymentR
 	s.logger.Info("Processing payment",
 		"orderID", req.OrderID,
 		"userID", req.UserID,
-		"amount", req.Amount,
-		"currency", req.Currency)
+		"amount", req.Amount.String())
 
 	// Validate request
 	if err := s.validateProcessPaymentRequest(req); err != nil {
@@ -154,12 +226,6 @@ func (s *paymentService) ProcessPayment(ctx context.Context, req ProcessPaymentR
 		}, nil // Return nil error since this is a business validation failure
 	}
 
-	// Convert DTO to domain objects
-	money := domain.Money{
-		Amount:   req.Amount,
-		Currency: req.Currency,
-	}
-
 	paymentMethod, err := s.convertPaymentMethodToDomain(req.PaymentMethod)
 	if err != nil {
 		s.logger.Error("Invalid payment method", "error", err)
@@ -171,7 +237,7 @@ func (s *paymentService) ProcessPayment(ctx context.Context, req ProcessPaymentR
 	}
 
 	// Create domain payment object
-	payment, err := domain.NewPayment(req.OrderID, req.UserID, money, paymentMethod, req.Description)
+	payment, err := domain.NewPayment(req.OrderID, req.UserID, req.Amount, paymentMethod, req.Description)
 	if err != nil {
 		s.logger.Error("Failed to create payment", "error", err)
 		return &ProcessPaymentResult{
@@ -180,6 +246,45 @@ func (s *paymentService) ProcessPayment(ctx context.Context, req ProcessPaymentR
 			Status:  "failed",
 		}, nil
 	}
+	if req.Sandbox {
+		payment.MarkSandbox()
+	}
+
+	// Assess risk before the payment reaches the processor
+	assessment := s.riskAssessor.Assess(ctx, RiskInput{
+		OrderID:       req.OrderID,
+		UserID:        req.UserID,
+		Amount:        req.Amount,
+		PaymentMethod: req.PaymentMethod,
+	})
+	payment.SetMetadata("risk_score", strconv.Itoa(assessment.Score))
+	payment.SetMetadata("risk_decision", string(assessment.Decision))
+
+	if assessment.Decision == RiskDecisionDecline {
+		s.logger.Warn("Payment declined by risk assessment",
+			"transactionID", payment.TransactionID(),
+			"orderID", req.OrderID,
+			"userID", req.UserID,
+			"riskScore", assessment.Score,
+			"reasons", assessment.Reasons)
+
+		payment.Decline(fmt.Sprintf("Declined by risk assessment: %s", strings.Join(assessment.Reasons, "; ")))
+		if err := s.repository.Save(payment); err != nil {
+			s.logger.Error("Failed to save declined payment", "error", err)
+			return nil, fmt.Errorf("failed to save payment: %w", err)
+		}
+		return s.convertPaymentToProcessResult(payment), nil
+	}
+
+	if assessment.Decision == RiskDecisionReview {
+		payment.HoldForReview(fmt.Sprintf("Held for manual review: %s", strings.Join(assessment.Reasons, "; ")))
+		if err := s.repository.Save(payment); err != nil {
+			s.logger.Error("Failed to save payment held for review", "error", err)
+			return nil, fmt.Errorf("failed to save payment: %w", err)
+		}
+		s.flagForReview(payment, assessment)
+		return s.convertPaymentToProcessResult(payment), nil
+	}
 
 	// Save the payment in pending state
 	if err := s.repository.Save(payment); err != nil {
@@ -187,17 +292,12 @@ func (s *paymentService) ProcessPayment(ctx context.Context, req ProcessPaymentR
 		return nil, fmt.Errorf("failed to save payment: %w", err)
 	}
 
-	// Process the payment using domain logic with configuration
-	processingTime := s.config.Payment.ProcessingTimeMs
-	successRate := s.config.Payment.SuccessRate
-
 	s.logger.Info("Starting payment processing",
 		"transactionID", payment.TransactionID(),
-		"processingTimeMs", processingTime,
-		"successRate", successRate)
+		"sandbox", payment.IsSandbox())
 
 	// This is where the business logic happens
-	err = payment.Process(processingTime, successRate)
+	s.charge(payment)
 
 	// Update the payment state after processing
 	if err := s.repository.Save(payment); err != nil {
@@ -207,6 +307,7 @@ func (s *paymentService) ProcessPayment(ctx context.Context, req ProcessPaymentR
 
 	// Log the result
 	if payment.IsCompleted() {
+		s.recordCharge(payment)
 		s.logger.Info("Payment processed successfully",
 			"transactionID", payment.TransactionID(),
 			"amount", payment.Amount().String())
@@ -263,9 +364,13 @@ func (s *paymentService) GetPaymentStatus(ctx context.Context, req GetPaymentSta
 func (s *paymentService) RefundPayment(ctx context.Context, req RefundPaymentRequest) (*RefundPaymentResult, error) {
 	s.logger.Info("Processing refund",
 		"transactionID", req.TransactionID,
-		"amount", req.Amount,
+		"amount", req.Amount.String(),
 		"reason", req.Reason)
 
+	if req.TransactionID == "" {
+		return s.refundOrderPayments(req)
+	}
+
 	// Find the original payment
 	payment, err := s.repository.FindByTransactionID(req.TransactionID)
 	if err != nil {
@@ -280,10 +385,10 @@ func (s *paymentService) RefundPayment(ctx context.Context, req RefundPaymentReq
 		}, nil
 	}
 
-	// Create refund money object
-	refundMoney := domain.Money{
-		Amount:   req.Amount,
-		Currency: payment.Amount().Currency,
+	// Refunds without a currency are in the currency of the payment
+	refundMoney := req.Amount
+	if refundMoney.Currency == "" {
+		refundMoney = money.FromFloat(req.Amount.Float64(), payment.Amount().Currency)
 	}
 
 	// Process refund using domain logic
@@ -302,9 +407,11 @@ func (s *paymentService) RefundPayment(ctx context.Context, req RefundPaymentReq
 		return nil, fmt.Errorf("failed to save refunded payment: %w", err)
 	}
 
+	s.recordRefund(payment, refundMoney, req.Reason)
+
 	s.logger.Info("Refund processed successfully",
 		"transactionID", req.TransactionID,
-		"refundAmount", req.Amount)
+		"refundAmount", refundMoney.String())
 
 	// Generate refund ID (in real systems, this might be from payment processor)
 	refundID := fmt.Sprintf("ref_%d_%s", time.Now().Unix(), payment.TransactionID()[:8])
@@ -313,7 +420,7 @@ func (s *paymentService) RefundPayment(ctx context.Context, req RefundPaymentReq
 		Success:               true,
 		RefundID:              refundID,
 		OriginalTransactionID: payment.TransactionID(),
-		RefundedAmount:        req.Amount,
+		RefundedAmount:        refundMoney,
 		Message:               "Refund processed successfully",
 		ProcessedAt:           time.Now(),
 	}, nil
@@ -332,6 +439,34 @@ func (s *paymentService) GetPaymentsByOrderID(ctx context.Context, orderID strin
 	return payments, nil
 }
 
+// flagForReview puts a payment held for review on the manual review queue
+func (s *paymentService) flagForReview(payment *domain.Payment, assessment RiskAssessment) {
+	item := ReviewItem{
+		TransactionID: payment.TransactionID(),
+		OrderID:       payment.OrderID(),
+		UserID:        payment.UserID(),
+		Amount:        payment.Amount().Float64(),
+		AmountMinor:   payment.Amount().Minor,
+		Currency:      payment.Amount().Currency,
+		RiskScore:     assessment.Score,
+		Reasons:       assessment.Reasons,
+		FlaggedAt:     time.Now(),
+	}
+
+	if err := s.reviewQueue.Add(item); err != nil {
+		s.logger.Error("Failed to add payment to review queue",
+			"transactionID", payment.TransactionID(),
+			"error", err)
+		return
+	}
+
+	s.logger.Warn("Payment flagged for manual review",
+		"transactionID", payment.TransactionID(),
+		"orderID", payment.OrderID(),
+		"riskScore", assessment.Score,
+		"reasons", assessment.Reasons)
+}
+
 // Validation methods
 
 func (s *paymentService) validateProcessPaymentRequest(req ProcessPaymentRequest) error {
@@ -341,18 +476,18 @@ func (s *paymentService) validateProcessPaymentRequest(req ProcessPaymentRequest
 	if req.UserID == "" {
 		return fmt.Errorf("user ID is required")
 	}
-	if req.Amount <= 0 {
+	if !req.Amount.IsPositive() {
 		return fmt.Errorf("amount must be positive")
 	}
-	if req.Amount > s.config.Payment.MaxAmount {
-		return fmt.Errorf("amount exceeds maximum allowed: %.2f", s.config.Payment.MaxAmount)
-	}
-	if req.Currency == "" {
+	if req.Amount.Currency == "" {
 		return fmt.Errorf("currency is required")
 	}
-	if len(req.Currency) != 3 {
+	if len(req.Amount.Currency) != 3 {
 		return fmt.Errorf("currency must be 3 characters")
 	}
+	if maxAmount := money.FromFloat(s.config.Payment.MaxAmount, req.Amount.Currency); req.Amount.Minor > maxAmount.Minor {
+		return fmt.Errorf("amount exceeds maximum allowed: %s", maxAmount)
+	}
 	return nil
 }
 
@@ -421,8 +556,7 @@ func (s *paymentService) convertPaymentToProcessResult(payment *domain.Payment)
 		Message:       payment.Message(),
 		Status:        payment.Status().String(),
 		ProcessedAt:   processedAt,
-		Amount:        payment.Amount().Amount,
-		Currency:      payment.Amount().Currency,
+		Amount:        payment.Amount(),
 	}
 }
 
@@ -432,8 +566,7 @@ func (s *paymentService) convertPaymentToStatusResult(payment *domain.Payment) *
 		TransactionID: payment.TransactionID(),
 		OrderID:       payment.OrderID(),
 		Status:        payment.Status().String(),
-		Amount:        payment.Amount().Amount,
-		Currency:      payment.Amount().Currency,
+		Amount:        payment.Amount(),
 		CreatedAt:     payment.CreatedAt(),
 		ProcessedAt:   payment.ProcessedAt(),
 		Message:       payment.Message(),
@@ -497,4 +630,18 @@ func (r *inMemoryPaymentRepository) FindByOrderID(orderID string) ([]*domain.Pay
 		}
 	}
 	return result, nil
-}
\ No newline at end of file
+}
+
+func (r *inMemoryPaymentRepository) DeleteByOrderID(orderID string) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	deleted := 0
+	for transactionID, payment := range r.payments {
+		if payment.OrderID() == orderID {
+			delete(r.payments, transactionID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,18 @@
+package service
+
+import "github.com/amiosamu/rocket-science/services/payment-service/internal/domain"
+
+// SimulatorProvider names the simulated payment processor. Sandbox payments are
+// always charged by it, so integrators can test end to end without real charges.
+const SimulatorProvider = "simulator"
+
+// charge runs a pending payment through its payment provider. The simulator is
+// the only provider so far; the payment is saved by the caller.
+func (s *paymentService) charge(payment *domain.Payment) {
+	payment.SetMetadata("provider", SimulatorProvider)
+	if err := payment.Process(s.config.Payment.ProcessingTimeMs, s.config.Payment.SuccessRate); err != nil {
+		s.logger.Warn("Payment could not be processed",
+			"transactionID", payment.TransactionID(),
+			"error", err)
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/domain"
+)
+
+// Review decisions accepted by ReviewPayment
+const (
+	ReviewDecisionApprove = "approve"
+	ReviewDecisionDecline = "decline"
+)
+
+// ReviewDecisionEvent is published when a reviewer approves or declines a held payment.
+// PaymentStatus is the payment status after the decision: an approved payment can
+// still be declined by the payment processor.
+type ReviewDecisionEvent struct {
+	TransactionID string     `json:"transaction_id"`
+	OrderID       string     `json:"order_id"`
+	UserID        string     `json:"user_id"`
+	Decision      string     `json:"decision"`
+	PaymentStatus string     `json:"payment_status"`
+	Amount        float64    `json:"amount"`
+	AmountMinor   int64      `json:"amount_minor"` // Exact amount in the currency's minor unit
+	Currency      string     `json:"currency"`
+	Reviewer      string     `json:"reviewer"`
+	Reason        string     `json:"reason,omitempty"`
+	DecidedAt     time.Time  `json:"decided_at"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+}
+
+// ReviewEventPublisher publishes manual review decisions so that the order saga can resume
+type ReviewEventPublisher interface {
+	PublishReviewDecision(ctx context.Context, event ReviewDecisionEvent) error
+}
+
+// ReviewPayment applies a manual review decision to a payment held for review
+func (s *paymentService) ReviewPayment(ctx context.Context, req ReviewPaymentRequest) (*ReviewPaymentResult, error) {
+	s.logger.Info("Reviewing payment",
+		"transactionID", req.TransactionID,
+		"decision", req.Decision,
+		"reviewer", req.Reviewer)
+
+	if req.Decision != ReviewDecisionApprove && req.Decision != ReviewDecisionDecline {
+		return &ReviewPaymentResult{
+			Success:       false,
+			TransactionID: req.TransactionID,
+			Message:       fmt.Sprintf("Invalid review decision: %q", req.Decision),
+		}, nil
+	}
+
+	payment, err := s.repository.FindByTransactionID(req.TransactionID)
+	if err != nil {
+		s.logger.Error("Error finding payment for review", "error", err)
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	if payment == nil {
+		return &ReviewPaymentResult{
+			Success:       false,
+			TransactionID: req.TransactionID,
+			Message:       "Payment not found",
+		}, nil
+	}
+
+	if !payment.IsPendingReview() {
+		return &ReviewPaymentResult{
+			Success:       false,
+			TransactionID: req.TransactionID,
+			Status:        payment.Status().String(),
+			Message:       domain.ErrPaymentNotPendingReview.Error(),
+		}, nil
+	}
+
+	if req.Decision == ReviewDecisionApprove {
+		if err := payment.ApproveReview(req.Reviewer); err != nil {
+			return nil, fmt.Errorf("failed to approve payment: %w", err)
+		}
+		// Approved payments go through the regular processor flow
+		s.charge(payment)
+	} else {
+		if err := payment.DeclineReview(req.Reviewer, req.Reason); err != nil {
+			return nil, fmt.Errorf("failed to decline payment: %w", err)
+		}
+	}
+
+	if err := s.repository.Save(payment); err != nil {
+		s.logger.Error("Failed to save reviewed payment", "error", err)
+		return nil, fmt.Errorf("failed to save reviewed payment: %w", err)
+	}
+
+	if payment.IsCompleted() {
+		s.recordCharge(payment)
+	}
+
+	if _, err := s.reviewQueue.Remove(payment.TransactionID()); err != nil {
+		s.logger.Error("Failed to remove payment from review queue",
+			"transactionID", payment.TransactionID(),
+			"error", err)
+	}
+
+	s.logger.Info("Payment review completed",
+		"transactionID", payment.TransactionID(),
+		"decision", req.Decision,
+		"reviewer", req.Reviewer,
+		"status", payment.Status().String())
+
+	s.publishReviewDecision(ctx, payment, req)
+
+	return &ReviewPaymentResult{
+		Success:       true,
+		TransactionID: payment.TransactionID(),
+		Status:        payment.Status().String(),
+		Message:       payment.Message(),
+		ProcessedAt:   payment.ProcessedAt(),
+	}, nil
+}
+
+// publishReviewDecision notifies downstream services of a review decision.
+// The decision is already persisted, so publishing failures are logged rather than returned.
+func (s *paymentService) publishReviewDecision(ctx context.Context, payment *domain.Payment, req ReviewPaymentRequest) {
+	if s.publisher == nil {
+		s.logger.Warn("No review event publisher configured, decision not published",
+			"transactionID", payment.TransactionID())
+		return
+	}
+
+	event := ReviewDecisionEvent{
+		TransactionID: payment.TransactionID(),
+		OrderID:       payment.OrderID(),
+		UserID:        payment.UserID(),
+		Decision:      req.Decision,
+		PaymentStatus: payment.Status().String(),
+		Amount:        payment.Amount().Float64(),
+		AmountMinor:   payment.Amount().Minor,
+		Currency:      payment.Amount().Currency,
+		Reviewer:      req.Reviewer,
+		Reason:        req.Reason,
+		DecidedAt:     time.Now().UTC(),
+		ProcessedAt:   payment.ProcessedAt(),
+	}
+
+	if err := s.publisher.PublishReviewDecision(ctx, event); err != nil {
+		s.logger.Error("Failed to publish review decision",
+			"transactionID", payment.TransactionID(),
+			"orderID", payment.OrderID(),
+			"error", err)
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReviewItem is a payment flagged by risk assessment for manual review
+type ReviewItem struct {
+	TransactionID string    `json:"transaction_id"`
+	OrderID       string    `json:"order_id"`
+	UserID        string    `json:"user_id"`
+	Amount        float64   `json:"amount"`
+	AmountMinor   int64     `json:"amount_minor"` // Exact amount in the currency's minor unit
+	Currency      string    `json:"currency"`
+	RiskScore     int       `json:"risk_score"`
+	Reasons       []string  `json:"reasons"`
+	FlaggedAt     time.Time `json:"flagged_at"`
+}
+
+// ReviewQueue holds payments awaiting manual review
+type ReviewQueue interface {
+	// Add puts a flagged payment on the queue
+	Add(item ReviewItem) error
+
+	// Get returns a queued payment by transaction ID, or nil if it is not queued
+	Get(transactionID string) (*ReviewItem, error)
+
+	// List returns all queued payments, oldest first
+	List() ([]ReviewItem, error)
+
+	// Remove takes a payment off the queue, reporting whether it was queued
+	Remove(transactionID string) (bool, error)
+}
+
+// inMemoryReviewQueue is the in-memory ReviewQueue, matching the service's in-memory payment storage
+type inMemoryReviewQueue struct {
+	items map[string]ReviewItem
+	mutex sync.RWMutex
+}
+
+// NewInMemoryReviewQueue creates a new in-memory review queue
+func NewInMemoryReviewQueue() ReviewQueue {
+	return &inMemoryReviewQueue{
+		items: make(map[string]ReviewItem),
+	}
+}
+
+func (q *inMemoryReviewQueue) Add(item ReviewItem) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.items[item.TransactionID] = item
+	return nil
+}
+
+func (q *inMemoryReviewQueue) Get(transactionID string) (*ReviewItem, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	item, exists := q.items[transactionID]
+	if !exists {
+		return nil, nil
+	}
+	return &item, nil
+}
+
+func (q *inMemoryReviewQueue) List() ([]ReviewItem, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	items := make([]ReviewItem, 0, len(q.items))
+	for _, item := range q.items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].FlaggedAt.Before(items[j].FlaggedAt)
+	})
+	return items, nil
+}
+
+func (q *inMemoryReviewQueue) Remove(transactionID string) (bool, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if _, exists := q.items[transactionID]; !exists {
+		return false, nil
+	}
+	delete(q.items, transactionID)
+	return true, nil
+}
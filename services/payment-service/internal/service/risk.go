@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/config"
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// RiskDecision is the outcome of a risk assessment
+type RiskDecision string
+
+const (
+	RiskDecisionApprove RiskDecision = "approve" // Payment may proceed
+	RiskDecisionReview  RiskDecision = "review"  // Payment is flagged for manual review
+	RiskDecisionDecline RiskDecision = "decline" // Payment is declined outright
+)
+
+// RiskInput carries the payment attributes a risk assessor can inspect
+type RiskInput struct {
+	OrderID       string
+	UserID        string
+	Amount        domain.Money
+	PaymentMethod PaymentMethodDTO
+}
+
+// RiskAssessment is the result of assessing a payment
+type RiskAssessment struct {
+	Decision RiskDecision
+	Score    int      // 0 (no risk) to 100 (certain fraud)
+	Reasons  []string // Human-readable rule hits
+}
+
+// RiskAssessor is the pluggable risk-assessment step run before a payment is processed
+type RiskAssessor interface {
+	Assess(ctx context.Context, input RiskInput) RiskAssessment
+}
+
+// noopRiskAssessor approves every payment; used when risk scoring is disabled
+type noopRiskAssessor struct{}
+
+func (noopRiskAssessor) Assess(ctx context.Context, input RiskInput) RiskAssessment {
+	return RiskAssessment{Decision: RiskDecisionApprove}
+}
+
+// RulesRiskAssessor is the default rules-based RiskAssessor.
+// It checks amount limits, per-user payment velocity and a denylist of users and payment instruments.
+type RulesRiskAssessor struct {
+	config        config.RiskConfig
+	deniedUsers   map[string]bool
+	deniedMethods map[string]bool
+
+	mu        sync.Mutex
+	history   map[string][]time.Time // userID -> recent payment attempts
+	lastSweep time.Time              // Users without attempts in the window are dropped once per window
+}
+
+// NewRulesRiskAssessor creates a rules-based risk assessor from configuration
+func NewRulesRiskAssessor(cfg config.RiskConfig) *RulesRiskAssessor {
+	assessor := &RulesRiskAssessor{
+		config:        cfg,
+		deniedUsers:   make(map[string]bool),
+		deniedMethods: make(map[string]bool),
+		history:       make(map[string][]time.Time),
+	}
+
+	for _, userID := range cfg.DenylistUsers {
+		assessor.deniedUsers[strings.TrimSpace(userID)] = true
+	}
+	for _, method := range cfg.DenylistPaymentMethods {
+		assessor.deniedMethods[strings.ToLower(strings.TrimSpace(method))] = true
+	}
+
+	return assessor
+}
+
+// Assess implements RiskAssessor
+func (a *RulesRiskAssessor) Assess(ctx context.Context, input RiskInput) RiskAssessment {
+	assessment := RiskAssessment{Decision: RiskDecisionApprove}
+
+	// Denylist
+	if a.deniedUsers[input.UserID] {
+		assessment.flag(RiskDecisionDecline, 100, "user is on the denylist")
+	}
+	for _, identifier := range paymentMethodIdentifiers(input.PaymentMethod) {
+		if a.deniedMethods[identifier] {
+			assessment.flag(RiskDecisionDecline, 100, "payment method is on the denylist")
+			break
+		}
+	}
+
+	// Amount limits
+	// The limits are configured in major units of the payment's currency
+	declineLimit := money.FromFloat(a.config.DeclineAmount, input.Amount.Currency)
+	reviewLimit := money.FromFloat(a.config.ReviewAmount, input.Amount.Currency)
+	switch {
+	case declineLimit.IsPositive() && input.Amount.Minor > declineLimit.Minor:
+		assessment.flag(RiskDecisionDecline, 90, fmt.Sprintf("amount %s exceeds decline limit %s", input.Amount, declineLimit))
+	case reviewLimit.IsPositive() && input.Amount.Minor > reviewLimit.Minor:
+		assessment.flag(RiskDecisionReview, 60, fmt.Sprintf("amount %s exceeds review limit %s", input.Amount, reviewLimit))
+	}
+
+	// Velocity per user
+	attempts := a.recordAttempt(input.UserID)
+	switch {
+	case a.config.VelocityDeclineCount > 0 && attempts > a.config.VelocityDeclineCount:
+		assessment.flag(RiskDecisionDecline, 90, fmt.Sprintf("%d payments within %s exceeds decline limit %d", attempts, a.config.VelocityWindow, a.config.VelocityDeclineCount))
+	case a.config.VelocityReviewCount > 0 && attempts > a.config.VelocityReviewCount:
+		assessment.flag(RiskDecisionReview, 50, fmt.Sprintf("%d payments within %s exceeds review limit %d", attempts, a.config.VelocityWindow, a.config.VelocityReviewCount))
+	}
+
+	return assessment
+}
+
+// recordAttempt records a payment attempt for the user and returns the number of attempts in the velocity window
+func (a *RulesRiskAssessor) recordAttempt(userID string) int {
+	if a.config.VelocityWindow <= 0 {
+		return 0
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-a.config.VelocityWindow)
+
+	recent := a.history[userID][:0]
+	for _, attemptedAt := range a.history[userID] {
+		if attemptedAt.After(cutoff) {
+			recent = append(recent, attemptedAt)
+		}
+	}
+	recent = append(recent, now)
+	a.history[userID] = recent
+
+	if now.Sub(a.lastSweep) >= a.config.VelocityWindow {
+		for user, attempts := range a.history {
+			if !attempts[len(attempts)-1].After(cutoff) {
+				delete(a.history, user)
+			}
+		}
+		a.lastSweep = now
+	}
+
+	return len(recent)
+}
+
+// flag records a rule hit, keeping the most severe decision and the highest score
+func (r *RiskAssessment) flag(decision RiskDecision, score int, reason string) {
+	if decision == RiskDecisionDecline || (decision == RiskDecisionReview && r.Decision == RiskDecisionApprove) {
+		r.Decision = decision
+	}
+	if score > r.Score {
+		r.Score = score
+	}
+	r.Reasons = append(r.Reasons, reason)
+}
+
+// paymentMethodIdentifiers returns the values of a payment method that can be denylisted
+func paymentMethodIdentifiers(method PaymentMethodDTO) []string {
+	var identifiers []string
+	if method.CreditCard != nil && method.CreditCard.MaskedNumber != "" {
+		number := method.CreditCard.MaskedNumber
+		if len(number) > 4 {
+			number = number[len(number)-4:]
+		}
+		identifiers = append(identifiers, "card:"+number)
+	}
+	if method.BankTransfer != nil && method.BankTransfer.AccountNumber != "" {
+		identifiers = append(identifiers, "account:"+strings.ToLower(method.BankTransfer.AccountNumber))
+	}
+	if method.DigitalWallet != nil {
+		if method.DigitalWallet.Email != "" {
+			identifiers = append(identifiers, "wallet:"+strings.ToLower(method.DigitalWallet.Email))
+		}
+		if method.DigitalWallet.WalletID != "" {
+			identifiers = append(identifiers, "wallet:"+strings.ToLower(method.DigitalWallet.WalletID))
+		}
+	}
+	return identifiers
+}
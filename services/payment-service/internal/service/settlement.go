@@ -0,0 +1,325 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/domain"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+)
+
+// SettlementDateLayout is the layout of settlement dates, e.g. "2024-05-01"
+const SettlementDateLayout = "2006-01-02"
+
+// SettlementRecord is the settlement of one transaction on one day: what the
+// processor collected for it, minus refunds and the processor's fees
+type SettlementRecord struct {
+	TransactionID string
+	OrderID       string
+	Currency      string
+	Gross         domain.Money // Charges
+	Refunds       domain.Money
+	Fees          domain.Money
+	Net           domain.Money // Gross - Refunds - Fees; negative when an earlier charge was refunded
+}
+
+// SettlementTotal sums the records of a batch in one currency
+type SettlementTotal struct {
+	Currency     string
+	Transactions int
+	Gross        domain.Money
+	Refunds      domain.Money
+	Fees         domain.Money
+	Net          domain.Money
+}
+
+// SettlementBatch is the settlement of a tenant's payments for one day (UTC)
+type SettlementBatch struct {
+	Tenant    string
+	Date      time.Time // Midnight UTC of the settled day
+	CreatedAt time.Time
+	Records   []SettlementRecord // In order of the transactions' first entry of the day
+	Totals    []SettlementTotal  // In order of the currencies' first record
+}
+
+// ID identifies the batch, e.g. "STL-default-20240501"
+func (b *SettlementBatch) ID() string {
+	return fmt.Sprintf("STL-%s-%s", b.Tenant, b.Date.Format("20060102"))
+}
+
+// SettlementDay returns midnight UTC of the day t falls on in UTC
+func SettlementDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// BuildSettlementBatch settles the tenant's day from the ledger
+func BuildSettlementBatch(ledger Ledger, tenant string, day time.Time) (*SettlementBatch, error) {
+	day = SettlementDay(day)
+	entries, err := ledger.Entries(LedgerFilter{
+		Tenant: tenant,
+		Since:  day,
+		Until:  day.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal entries: %w", err)
+	}
+
+	batch := &SettlementBatch{Tenant: tenant, Date: day, CreatedAt: time.Now().UTC()}
+
+	type key struct{ transactionID, currency string }
+	index := make(map[key]int)
+	for _, entry := range entries {
+		k := key{entry.TransactionID, entry.Currency}
+		i, exists := index[k]
+		if !exists {
+			zero := money.Zero(entry.Currency)
+			batch.Records = append(batch.Records, SettlementRecord{
+				TransactionID: entry.TransactionID,
+				OrderID:       entry.OrderID,
+				Currency:      entry.Currency,
+				Gross:         zero,
+				Refunds:       zero,
+				Fees:          zero,
+				Net:           zero,
+			})
+			i = len(batch.Records) - 1
+			index[k] = i
+		}
+
+		record := &batch.Records[i]
+		amount := entryAmount(entry)
+		switch entry.Kind {
+		case domain.EntryKindCharge:
+			record.Gross.Minor += amount
+			record.Net.Minor += amount
+		case domain.EntryKindRefund:
+			record.Refunds.Minor += amount
+			record.Net.Minor -= amount
+		case domain.EntryKindFee:
+			record.Fees.Minor += amount
+			record.Net.Minor -= amount
+		}
+	}
+
+	totals := make(map[string]int)
+	for _, record := range batch.Records {
+		i, exists := totals[record.Currency]
+		if !exists {
+			zero := money.Zero(record.Currency)
+			batch.Totals = append(batch.Totals, SettlementTotal{
+				Currency: record.Currency, Gross: zero, Refunds: zero, Fees: zero, Net: zero,
+			})
+			i = len(batch.Totals) - 1
+			totals[record.Currency] = i
+		}
+		total := &batch.Totals[i]
+		total.Transactions++
+		total.Gross.Minor += record.Gross.Minor
+		total.Refunds.Minor += record.Refunds.Minor
+		total.Fees.Minor += record.Fees.Minor
+		total.Net.Minor += record.Net.Minor
+	}
+
+	return batch, nil
+}
+
+// entryAmount is the amount a balanced entry moves, its total debits
+func entryAmount(entry *domain.JournalEntry) int64 {
+	var amount int64
+	for _, line := range entry.Lines {
+		amount += line.Debit.Minor
+	}
+	return amount
+}
+
+// settlementCSVHeader lists the columns of the settlement CSV
+var settlementCSVHeader = []string{
+	"batch_id", "settlement_date", "tenant", "transaction_id", "order_id",
+	"currency", "gross", "refunds", "fees", "net",
+}
+
+// RenderSettlementCSV writes the batch as CSV, one row per transaction
+func RenderSettlementCSV(w io.Writer, batch *SettlementBatch) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(settlementCSVHeader); err != nil {
+		return err
+	}
+	date := batch.Date.Format(SettlementDateLayout)
+	for _, record := range batch.Records {
+		if err := writer.Write([]string{
+			batch.ID(),
+			date,
+			batch.Tenant,
+			record.TransactionID,
+			record.OrderID,
+			record.Currency,
+			record.Gross.Amount(),
+			record.Refunds.Amount(),
+			record.Fees.Amount(),
+			record.Net.Amount(),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// pain001Namespace is the ISO 20022 customer credit transfer initiation schema
+// the settlement XML follows
+const pain001Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.09"
+
+type pain001Document struct {
+	XMLName    xml.Name          `xml:"Document"`
+	Namespace  string            `xml:"xmlns,attr"`
+	Initiation pain001Initiation `xml:"CstmrCdtTrfInitn"`
+}
+
+type pain001Initiation struct {
+	GroupHeader  pain001GroupHeader   `xml:"GrpHdr"`
+	PaymentInfos []pain001PaymentInfo `xml:"PmtInf"`
+}
+
+type pain001GroupHeader struct {
+	MessageID       string       `xml:"MsgId"`
+	CreatedAt       string       `xml:"CreDtTm"`
+	Transactions    int          `xml:"NbOfTxs"`
+	ControlSum      string       `xml:"CtrlSum"`
+	InitiatingParty pain001Party `xml:"InitgPty"`
+}
+
+type pain001PaymentInfo struct {
+	PaymentInfoID   string               `xml:"PmtInfId"`
+	PaymentMethod   string               `xml:"PmtMtd"`
+	Transactions    int                  `xml:"NbOfTxs"`
+	ControlSum      string               `xml:"CtrlSum"`
+	ExecutionDate   pain001Date          `xml:"ReqdExctnDt"`
+	Debtor          pain001Party         `xml:"Dbtr"`
+	CreditTransfers []pain001Transaction `xml:"CdtTrfTxInf"`
+}
+
+type pain001Date struct {
+	Date string `xml:"Dt"`
+}
+
+type pain001Party struct {
+	Name string `xml:"Nm"`
+}
+
+type pain001Transaction struct {
+	PaymentID  pain001PaymentID  `xml:"PmtId"`
+	Amount     pain001Amount     `xml:"Amt"`
+	Creditor   pain001Party      `xml:"Cdtr"`
+	Remittance pain001Remittance `xml:"RmtInf"`
+}
+
+type pain001PaymentID struct {
+	EndToEndID string `xml:"EndToEndId"`
+}
+
+type pain001Amount struct {
+	Instructed pain001InstructedAmount `xml:"InstdAmt"`
+}
+
+type pain001InstructedAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type pain001Remittance struct {
+	Unstructured string `xml:"Ustrd"`
+}
+
+// SettlementParties names the account holders of the settlement credit transfers
+type SettlementParties struct {
+	Debtor   string // Pays out the settlement, e.g. the payment processor
+	Creditor string // Receives the settlement
+}
+
+// RenderSettlementXML writes the batch as a pain.001-like credit transfer
+// initiation: one payment information block per currency, with one credit
+// transfer per transaction whose net settlement is positive. Transactions that
+// net to zero or less, such as refunds of charges settled on earlier days, are
+// offset by the processor and only appear in the CSV.
+func RenderSettlementXML(w io.Writer, batch *SettlementBatch, parties SettlementParties) error {
+	executionDate := batch.Date.AddDate(0, 0, 1).Format(SettlementDateLayout)
+	document := pain001Document{
+		Namespace: pain001Namespace,
+		Initiation: pain001Initiation{
+			GroupHeader: pain001GroupHeader{
+				MessageID:       batch.ID(),
+				CreatedAt:       batch.CreatedAt.Format("2006-01-02T15:04:05Z"),
+				InitiatingParty: pain001Party{Name: parties.Creditor},
+			},
+		},
+	}
+
+	var groupSum []domain.Money
+	for _, total := range batch.Totals {
+		info := pain001PaymentInfo{
+			PaymentInfoID: fmt.Sprintf("%s-%s", batch.ID(), total.Currency),
+			PaymentMethod: "TRF",
+			ExecutionDate: pain001Date{Date: executionDate},
+			Debtor:        pain001Party{Name: parties.Debtor},
+		}
+		var infoSum []domain.Money
+		for _, record := range batch.Records {
+			if record.Currency != total.Currency || !record.Net.IsPositive() {
+				continue
+			}
+			info.CreditTransfers = append(info.CreditTransfers, pain001Transaction{
+				PaymentID: pain001PaymentID{EndToEndID: record.TransactionID},
+				Amount: pain001Amount{Instructed: pain001InstructedAmount{
+					Currency: record.Currency,
+					Value:    record.Net.Amount(),
+				}},
+				Creditor:   pain001Party{Name: parties.Creditor},
+				Remittance: pain001Remittance{Unstructured: fmt.Sprintf("Settlement %s order %s", batch.Date.Format(SettlementDateLayout), record.OrderID)},
+			})
+			infoSum = append(infoSum, record.Net)
+		}
+		if len(info.CreditTransfers) == 0 {
+			continue
+		}
+		info.Transactions = len(info.CreditTransfers)
+		info.ControlSum = decimalSum(infoSum)
+		document.Initiation.PaymentInfos = append(document.Initiation.PaymentInfos, info)
+		document.Initiation.GroupHeader.Transactions += info.Transactions
+		groupSum = append(groupSum, infoSum...)
+	}
+	document.Initiation.GroupHeader.ControlSum = decimalSum(groupSum)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// decimalSum adds up amounts in major units regardless of their currency, as
+// pain.001 control sums do
+func decimalSum(amounts []domain.Money) string {
+	sum := new(big.Rat)
+	scale := 2
+	for _, amount := range amounts {
+		value, ok := new(big.Rat).SetString(amount.Amount())
+		if !ok {
+			continue
+		}
+		sum.Add(sum, value)
+		if exponent := money.Exponent(amount.Currency); exponent > scale {
+			scale = exponent
+		}
+	}
+	return sum.FloatString(scale)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/config"
+	"github.com/amiosamu/rocket-science/shared/platform/objectstore"
+)
+
+// SettlementFilesPath is the health server path signed settlement downloads are served under
+const SettlementFilesPath = "/settlements/files/"
+
+// Settlement file formats
+const (
+	SettlementFormatCSV     = "csv"
+	SettlementFormatPain001 = "pain.001"
+)
+
+// SettlementFile is a stored settlement file with a signed URL to download it
+type SettlementFile struct {
+	Format      string
+	Key         string
+	ContentType string
+	Size        int64
+	CreatedAt   time.Time
+	URL         string
+	URLExpires  time.Time
+}
+
+// SettlementExport is the result of exporting, or looking up, a day's settlement files
+type SettlementExport struct {
+	BatchID string
+	Tenant  string
+	Date    time.Time
+	Batch   *SettlementBatch // Only set when the files were just exported
+	Files   []SettlementFile
+}
+
+// SettlementExporter writes the daily settlement files to object storage and
+// hands them out through signed URLs
+type SettlementExporter struct {
+	config *config.Config
+	logger *slog.Logger
+	ledger Ledger
+	store  objectstore.Store
+	signer *objectstore.Signer
+}
+
+// NewSettlementExporter creates an exporter settling the ledger of the configured tenant
+func NewSettlementExporter(cfg *config.Config, logger *slog.Logger, ledger Ledger, store objectstore.Store, signer *objectstore.Signer) *SettlementExporter {
+	return &SettlementExporter{
+		config: cfg,
+		logger: logger.With("component", "settlement_exporter"),
+		ledger: ledger,
+		store:  store,
+		signer: signer,
+	}
+}
+
+// Export settles the day and stores its CSV and pain.001 files, replacing files
+// of an earlier export of the same day
+func (e *SettlementExporter) Export(ctx context.Context, day time.Time) (*SettlementExport, error) {
+	tenant := e.config.Ledger.Tenant
+	batch, err := BuildSettlementBatch(e.ledger, tenant, day)
+	if err != nil {
+		return nil, err
+	}
+
+	var csvFile, xmlFile bytes.Buffer
+	if err := RenderSettlementCSV(&csvFile, batch); err != nil {
+		return nil, fmt.Errorf("failed to render settlement CSV: %w", err)
+	}
+	parties := SettlementParties{Debtor: e.config.Settlement.DebtorName, Creditor: e.config.Settlement.CreditorName}
+	if err := RenderSettlementXML(&xmlFile, batch, parties); err != nil {
+		return nil, fmt.Errorf("failed to render settlement XML: %w", err)
+	}
+
+	export := &SettlementExport{BatchID: batch.ID(), Tenant: tenant, Date: batch.Date, Batch: batch}
+	for _, file := range []struct {
+		format string
+		body   *bytes.Buffer
+	}{
+		{SettlementFormatCSV, &csvFile},
+		{SettlementFormatPain001, &xmlFile},
+	} {
+		object, err := e.store.Put(ctx, settlementKey(tenant, batch.Date, file.format), file.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store %s settlement file: %w", file.format, err)
+		}
+		export.Files = append(export.Files, e.settlementFile(file.format, object))
+	}
+
+	e.logger.Info("Settlement exported",
+		"batchID", batch.ID(),
+		"date", batch.Date.Format(SettlementDateLayout),
+		"transactions", len(batch.Records))
+	return export, nil
+}
+
+// Files returns signed URLs for the day's stored settlement files
+func (e *SettlementExporter) Files(ctx context.Context, day time.Time) (*SettlementExport, error) {
+	tenant := e.config.Ledger.Tenant
+	day = SettlementDay(day)
+	export := &SettlementExport{
+		BatchID: (&SettlementBatch{Tenant: tenant, Date: day}).ID(),
+		Tenant:  tenant,
+		Date:    day,
+	}
+	for _, format := range []string{SettlementFormatCSV, SettlementFormatPain001} {
+		object, err := e.store.Stat(ctx, settlementKey(tenant, day, format))
+		if errors.Is(err, objectstore.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up %s settlement file: %w", format, err)
+		}
+		export.Files = append(export.Files, e.settlementFile(format, object))
+	}
+	return export, nil
+}
+
+// DownloadHandler serves the settlement files to holders of a signed URL; it is
+// mounted at SettlementFilesPath
+func (e *SettlementExporter) DownloadHandler() http.Handler {
+	return objectstore.Handler(e.store, e.signer, SettlementFilesPath)
+}
+
+// Run exports the previous day's settlement every day at the configured time
+// until the context is canceled. Days missed while the service was down are not
+// caught up; export them on demand.
+func (e *SettlementExporter) Run(ctx context.Context) {
+	e.logger.Info("Daily settlement export scheduled",
+		"exportAt", e.config.Settlement.ExportAt.String(),
+		"storageDir", e.config.Settlement.StorageDir)
+
+	for {
+		next := nextSettlementRun(time.Now(), e.config.Settlement.ExportAt)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if _, err := e.Export(ctx, next.AddDate(0, 0, -1)); err != nil {
+			e.logger.Error("Daily settlement export failed",
+				"date", SettlementDay(next.AddDate(0, 0, -1)).Format(SettlementDateLayout),
+				"error", err)
+		}
+	}
+}
+
+// nextSettlementRun returns the first daily run time after now
+func nextSettlementRun(now time.Time, exportAt time.Duration) time.Time {
+	next := SettlementDay(now).Add(exportAt)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+func (e *SettlementExporter) settlementFile(format string, object objectstore.Object) SettlementFile {
+	signed := e.signer.SignURL(e.downloadBaseURL(), object.Key, e.config.Settlement.URLExpiry)
+	return SettlementFile{
+		Format:      format,
+		Key:         object.Key,
+		ContentType: object.ContentType,
+		Size:        object.Size,
+		CreatedAt:   object.ModifiedAt,
+		URL:         signed.URL,
+		URLExpires:  signed.ExpiresAt,
+	}
+}
+
+// downloadBaseURL is the public URL settlement downloads are served under; a
+// relative path when no public URL is configured
+func (e *SettlementExporter) downloadBaseURL() string {
+	return strings.TrimSuffix(e.config.Settlement.PublicURL, "/") + SettlementFilesPath
+}
+
+// settlementKey is the object key of a settlement file, e.g.
+// "settlements/default/2024-05-01/settlement-default-2024-05-01.csv"
+func settlementKey(tenant string, day time.Time, format string) string {
+	date := day.Format(SettlementDateLayout)
+	extension := "csv"
+	if format == SettlementFormatPain001 {
+		extension = "pain001.xml"
+	}
+	return fmt.Sprintf("settlements/%s/%s/settlement-%s-%s.%s", tenant, date, tenant, date, extension)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+)
+
+// PurgeOrdersResult counts what a test data purge deleted, or would delete on a dry run
+type PurgeOrdersResult struct {
+	Payments       int `json:"payments"`
+	JournalEntries int `json:"journal_entries"`
+	ReviewItems    int `json:"review_items"`
+}
+
+// PurgeOrders deletes the payments of the orders with their journal entries
+// and review queue items. It is the payment step of the test data purge saga
+// run by iam-service, so orders without payments are skipped.
+func (s *paymentService) PurgeOrders(ctx context.Context, orderIDs []string, dryRun bool) (*PurgeOrdersResult, error) {
+	result := &PurgeOrdersResult{}
+	for _, orderID := range orderIDs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		payments, err := s.repository.FindByOrderID(orderID)
+		if err != nil {
+			return nil, err
+		}
+		entries, err := s.ledger.Entries(LedgerFilter{OrderID: orderID})
+		if err != nil {
+			return nil, err
+		}
+		result.Payments += len(payments)
+		result.JournalEntries += len(entries)
+
+		for _, payment := range payments {
+			item, err := s.reviewQueue.Get(payment.TransactionID())
+			if err != nil {
+				return nil, err
+			}
+			if item == nil {
+				continue
+			}
+			result.ReviewItems++
+			if !dryRun {
+				if _, err := s.reviewQueue.Remove(payment.TransactionID()); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if dryRun {
+			continue
+		}
+		if _, err := s.ledger.DeleteByOrderID(orderID); err != nil {
+			return nil, err
+		}
+		if _, err := s.repository.DeleteByOrderID(orderID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("Payment test data purged",
+		"orders", len(orderIDs),
+		"payments", result.Payments,
+		"journalEntries", result.JournalEntries,
+		"reviewItems", result.ReviewItems,
+		"dryRun", dryRun)
+	return result, nil
+}
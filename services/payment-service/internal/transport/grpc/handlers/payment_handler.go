@@ -2,14 +2,19 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/domain"
 	"github.com/amiosamu/rocket-science/services/payment-service/internal/service"
-	pb "github.com/amiosamu/rocket-science/services/payment-service/proto/payment"
+	"github.com/amiosamu/rocket-science/shared/contracts/proto/money"
+	pb "github.com/amiosamu/rocket-science/shared/contracts/proto/payment/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcerrors"
+	"github.com/amiosamu/rocket-science/shared/platform/version"
 )
 
 // PaymentHandler implements the PaymentServiceServer interface from protobuf
@@ -33,19 +38,19 @@ func (h *PaymentHandler) ProcessPayment(ctx context.Context, req *pb.ProcessPaym
 	h.logger.Info("gRPC ProcessPayment called",
 		"orderID", req.OrderId,
 		"userID", req.UserId,
-		"amount", req.Amount)
+		"amount", processAmount(req).String())
 
 	// Validate required fields
 	if err := h.validateProcessPaymentRequest(req); err != nil {
 		h.logger.Warn("Invalid ProcessPayment request", "error", err)
-		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+		return nil, err
 	}
 
 	// Convert protobuf request to service DTO
 	serviceReq, err := h.convertToServiceProcessRequest(req)
 	if err != nil {
 		h.logger.Error("Failed to convert protobuf request", "error", err)
-		return nil, status.Errorf(codes.InvalidArgument, "invalid request format: %v", err)
+		return nil, err
 	}
 
 	// Call business service
@@ -75,7 +80,9 @@ func (h *PaymentHandler) GetPaymentStatus(ctx context.Context, req *pb.GetPaymen
 	// Validate that at least one identifier is provided
 	if req.TransactionId == "" && req.OrderId == "" {
 		h.logger.Warn("GetPaymentStatus: no identifier provided")
-		return nil, status.Errorf(codes.InvalidArgument, "either transaction_id or order_id must be provided")
+		return nil, grpcerrors.InvalidArgument("either transaction_id or order_id must be provided",
+			grpcerrors.FieldViolation{Field: "transaction_id", Description: "either transaction_id or order_id must be provided"},
+			grpcerrors.FieldViolation{Field: "order_id", Description: "either transaction_id or order_id must be provided"})
 	}
 
 	// Convert to service request
@@ -102,19 +109,21 @@ func (h *PaymentHandler) GetPaymentStatus(ctx context.Context, req *pb.GetPaymen
 func (h *PaymentHandler) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
 	h.logger.Info("gRPC RefundPayment called",
 		"transactionID", req.TransactionId,
-		"amount", req.Amount,
+		"orderID", req.OrderId,
+		"amount", refundAmount(req).String(),
 		"reason", req.Reason)
 
 	// Validate request
 	if err := h.validateRefundPaymentRequest(req); err != nil {
 		h.logger.Warn("Invalid RefundPayment request", "error", err)
-		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+		return nil, err
 	}
 
 	// Convert to service request
 	serviceReq := service.RefundPaymentRequest{
 		TransactionID: req.TransactionId,
-		Amount:        req.Amount,
+		OrderID:       req.OrderId,
+		Amount:        refundAmount(req),
 		Reason:        req.Reason,
 		RequestedBy:   req.RequestedBy,
 	}
@@ -136,42 +145,115 @@ func (h *PaymentHandler) RefundPayment(ctx context.Context, req *pb.RefundPaymen
 	return response, nil
 }
 
+// ReviewPayment handles manual review decisions via gRPC
+func (h *PaymentHandler) ReviewPayment(ctx context.Context, req *pb.ReviewPaymentRequest) (*pb.ReviewPaymentResponse, error) {
+	h.logger.Info("gRPC ReviewPayment called",
+		"transactionID", req.TransactionId,
+		"decision", req.Decision.String(),
+		"reviewer", req.Reviewer)
+
+	// Validate request
+	if err := h.validateReviewPaymentRequest(req); err != nil {
+		h.logger.Warn("Invalid ReviewPayment request", "error", err)
+		return nil, err
+	}
+
+	decision := service.ReviewDecisionApprove
+	if req.Decision == pb.ReviewDecision_REVIEW_DECISION_DECLINE {
+		decision = service.ReviewDecisionDecline
+	}
+
+	// Call business service
+	result, err := h.paymentService.ReviewPayment(ctx, service.ReviewPaymentRequest{
+		TransactionID: req.TransactionId,
+		Decision:      decision,
+		Reviewer:      req.Reviewer,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		h.logger.Error("Review service error", "error", err)
+		return nil, status.Errorf(codes.Internal, "payment review failed: %v", err)
+	}
+
+	// Convert service result to protobuf response
+	response := h.convertToReviewPaymentResponse(result)
+
+	h.logger.Info("ReviewPayment completed",
+		"success", response.Success,
+		"status", response.Status)
+
+	return response, nil
+}
+
+// GetVersion returns build information for deployment verification
+func (h *PaymentHandler) GetVersion(ctx context.Context, req *pb.GetVersionRequest) (*pb.GetVersionResponse, error) {
+	info := version.Get("payment-service")
+
+	return &pb.GetVersionResponse{
+		Service:   info.Service,
+		Version:   info.Version,
+		GitCommit: info.GitCommit,
+		BuildTime: info.BuildTime,
+		GoVersion: info.GoVersion,
+		Platform:  info.Platform,
+	}, nil
+}
+
 // Validation methods
 
 func (h *PaymentHandler) validateProcessPaymentRequest(req *pb.ProcessPaymentRequest) error {
 	if req.OrderId == "" {
-		return status.Error(codes.InvalidArgument, "order_id is required")
+		return grpcerrors.FieldError("order_id", "order_id is required")
 	}
 	if req.UserId == "" {
-		return status.Error(codes.InvalidArgument, "user_id is required")
+		return grpcerrors.FieldError("user_id", "user_id is required")
 	}
-	if req.Amount <= 0 {
-		return status.Error(codes.InvalidArgument, "amount must be positive")
+	amount := processAmount(req)
+	if !amount.IsPositive() {
+		return grpcerrors.FieldError("amount", "amount must be positive")
 	}
-	if req.Currency == "" {
-		return status.Error(codes.InvalidArgument, "currency is required")
+	if amount.Currency == "" {
+		return grpcerrors.FieldError("currency", "currency is required")
 	}
 	if req.PaymentMethod == nil {
-		return status.Error(codes.InvalidArgument, "payment_method is required")
+		return grpcerrors.FieldError("payment_method", "payment_method is required")
 	}
 	return nil
 }
 
 func (h *PaymentHandler) validateRefundPaymentRequest(req *pb.RefundPaymentRequest) error {
-	if req.TransactionId == "" {
-		return status.Error(codes.InvalidArgument, "transaction_id is required")
+	if req.TransactionId == "" && req.OrderId == "" {
+		return grpcerrors.FieldError("transaction_id", "transaction_id or order_id is required")
 	}
-	if req.Amount <= 0 {
-		return status.Error(codes.InvalidArgument, "amount must be positive")
+	// Order refunds are always for the full amount of the order's payments
+	if req.TransactionId != "" && !refundAmount(req).IsPositive() {
+		return grpcerrors.FieldError("amount", "amount must be positive")
 	}
 	if req.Reason == "" {
-		return status.Error(codes.InvalidArgument, "reason is required")
+		return grpcerrors.FieldError("reason", "reason is required")
 	}
 	return nil
 }
 
 // Conversion methods: Protobuf -> Service DTOs
 
+// processAmount prefers the exact minor-unit amount and falls back to the legacy double
+func processAmount(req *pb.ProcessPaymentRequest) domain.Money {
+	if req.ExactAmount != nil {
+		return money.FromProto(req.ExactAmount)
+	}
+	return money.FromFloat(req.Amount, req.Currency)
+}
+
+// refundAmount prefers the exact minor-unit amount. The legacy double carries no
+// currency, so the service resolves it against the currency of the payment.
+func refundAmount(req *pb.RefundPaymentRequest) domain.Money {
+	if req.ExactAmount != nil {
+		return money.FromProto(req.ExactAmount)
+	}
+	return money.FromFloat(req.Amount, "")
+}
+
 func (h *PaymentHandler) convertToServiceProcessRequest(req *pb.ProcessPaymentRequest) (service.ProcessPaymentRequest, error) {
 	paymentMethod, err := h.convertPaymentMethodToService(req.PaymentMethod)
 	if err != nil {
@@ -181,10 +263,10 @@ func (h *PaymentHandler) convertToServiceProcessRequest(req *pb.ProcessPaymentRe
 	return service.ProcessPaymentRequest{
 		OrderID:       req.OrderId,
 		UserID:        req.UserId,
-		Amount:        req.Amount,
-		Currency:      req.Currency,
+		Amount:        processAmount(req),
 		PaymentMethod: paymentMethod,
 		Description:   req.Description,
+		Sandbox:       req.GetSandbox(),
 	}, nil
 }
 
@@ -192,7 +274,7 @@ func (h *PaymentHandler) convertPaymentMethodToService(pm *pb.PaymentMethod) (se
 	switch pm.Type {
 	case pb.PaymentType_PAYMENT_TYPE_CREDIT_CARD:
 		if pm.CreditCard == nil {
-			return service.PaymentMethodDTO{}, status.Error(codes.InvalidArgument, "credit_card details required")
+			return service.PaymentMethodDTO{}, grpcerrors.FieldError("payment_method.credit_card", "credit_card details required")
 		}
 		return service.PaymentMethodDTO{
 			Type: "credit_card",
@@ -207,7 +289,7 @@ func (h *PaymentHandler) convertPaymentMethodToService(pm *pb.PaymentMethod) (se
 
 	case pb.PaymentType_PAYMENT_TYPE_BANK_TRANSFER:
 		if pm.BankTransfer == nil {
-			return service.PaymentMethodDTO{}, status.Error(codes.InvalidArgument, "bank_transfer details required")
+			return service.PaymentMethodDTO{}, grpcerrors.FieldError("payment_method.bank_transfer", "bank_transfer details required")
 		}
 		return service.PaymentMethodDTO{
 			Type: "bank_transfer",
@@ -221,7 +303,7 @@ func (h *PaymentHandler) convertPaymentMethodToService(pm *pb.PaymentMethod) (se
 
 	case pb.PaymentType_PAYMENT_TYPE_DIGITAL_WALLET:
 		if pm.DigitalWallet == nil {
-			return service.PaymentMethodDTO{}, status.Error(codes.InvalidArgument, "digital_wallet details required")
+			return service.PaymentMethodDTO{}, grpcerrors.FieldError("payment_method.digital_wallet", "digital_wallet details required")
 		}
 		return service.PaymentMethodDTO{
 			Type: "digital_wallet",
@@ -233,23 +315,43 @@ func (h *PaymentHandler) convertPaymentMethodToService(pm *pb.PaymentMethod) (se
 		}, nil
 
 	default:
-		return service.PaymentMethodDTO{}, status.Errorf(codes.InvalidArgument, "unsupported payment method type: %v", pm.Type)
+		return service.PaymentMethodDTO{}, grpcerrors.FieldError("payment_method.type", fmt.Sprintf("unsupported payment method type: %v", pm.Type))
 	}
 }
 
+func (h *PaymentHandler) validateReviewPaymentRequest(req *pb.ReviewPaymentRequest) error {
+	if req.TransactionId == "" {
+		return grpcerrors.FieldError("transaction_id", "transaction_id is required")
+	}
+	if req.Reviewer == "" {
+		return grpcerrors.FieldError("reviewer", "reviewer is required")
+	}
+	switch req.Decision {
+	case pb.ReviewDecision_REVIEW_DECISION_APPROVE:
+	case pb.ReviewDecision_REVIEW_DECISION_DECLINE:
+		if req.Reason == "" {
+			return grpcerrors.FieldError("reason", "reason is required when declining")
+		}
+	default:
+		return grpcerrors.FieldError("decision", "decision must be approve or decline")
+	}
+	return nil
+}
+
 // Conversion methods: Service DTOs -> Protobuf responses
 
 func (h *PaymentHandler) convertToProcessPaymentResponse(result *service.ProcessPaymentResult) *pb.ProcessPaymentResponse {
 	status := h.convertStatusToProto(result.Status)
 
 	return &pb.ProcessPaymentResponse{
-		Success:         result.Success,
-		TransactionId:   result.TransactionID,
-		Message:         result.Message,
-		Status:          status,
-		ProcessedAt:     timestamppb.New(result.ProcessedAt),
-		ProcessedAmount: result.Amount,
-		Currency:        result.Currency,
+		Success:              result.Success,
+		TransactionId:        result.TransactionID,
+		Message:              result.Message,
+		Status:               status,
+		ProcessedAt:          timestamppb.New(result.ProcessedAt),
+		ProcessedAmount:      result.Amount.Float64(),
+		Currency:             result.Amount.Currency,
+		ExactProcessedAmount: result.Amount.ToProto(),
 	}
 }
 
@@ -267,8 +369,9 @@ func (h *PaymentHandler) convertToGetPaymentStatusResponse(result *service.GetPa
 		TransactionId: result.TransactionID,
 		OrderId:       result.OrderID,
 		Status:        status,
-		Amount:        result.Amount,
-		Currency:      result.Currency,
+		Amount:        result.Amount.Float64(),
+		Currency:      result.Amount.Currency,
+		ExactAmount:   result.Amount.ToProto(),
 		CreatedAt:     timestamppb.New(result.CreatedAt),
 		Message:       result.Message,
 	}
@@ -285,12 +388,28 @@ func (h *PaymentHandler) convertToRefundPaymentResponse(result *service.RefundPa
 		Success:               result.Success,
 		RefundId:              result.RefundID,
 		OriginalTransactionId: result.OriginalTransactionID,
-		RefundedAmount:        result.RefundedAmount,
+		RefundedAmount:        result.RefundedAmount.Float64(),
+		ExactRefundedAmount:   result.RefundedAmount.ToProto(),
 		Message:               result.Message,
 		ProcessedAt:           timestamppb.New(result.ProcessedAt),
 	}
 }
 
+func (h *PaymentHandler) convertToReviewPaymentResponse(result *service.ReviewPaymentResult) *pb.ReviewPaymentResponse {
+	response := &pb.ReviewPaymentResponse{
+		Success:       result.Success,
+		TransactionId: result.TransactionID,
+		Status:        h.convertStatusToProto(result.Status),
+		Message:       result.Message,
+	}
+
+	if result.ProcessedAt != nil {
+		response.ProcessedAt = timestamppb.New(*result.ProcessedAt)
+	}
+
+	return response
+}
+
 func (h *PaymentHandler) convertStatusToProto(statusStr string) pb.PaymentStatus {
 	switch statusStr {
 	case "pending":
@@ -305,6 +424,8 @@ func (h *PaymentHandler) convertStatusToProto(statusStr string) pb.PaymentStatus
 		return pb.PaymentStatus_PAYMENT_STATUS_REFUNDED
 	case "partially_refunded":
 		return pb.PaymentStatus_PAYMENT_STATUS_PARTIAL_REFUND
+	case "pending_review":
+		return pb.PaymentStatus_PAYMENT_STATUS_PENDING_REVIEW
 	default:
 		return pb.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
 	}
@@ -19,7 +19,12 @@ import (
 	"github.com/amiosamu/rocket-science/services/payment-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/payment-service/internal/service"
 	"github.com/amiosamu/rocket-science/services/payment-service/internal/transport/grpc/handlers"
-	pb "github.com/amiosamu/rocket-science/services/payment-service/proto/payment"
+	pb "github.com/amiosamu/rocket-science/shared/contracts/proto/payment/v1"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/deadline"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/grpclog"
+	"github.com/amiosamu/rocket-science/shared/platform/recovery"
 )
 
 // Server represents the gRPC server for the Payment Service
@@ -29,6 +34,8 @@ type Server struct {
 	paymentService service.PaymentService
 	grpcServer     *grpc.Server
 	healthServer   *health.Server
+	maintenance    *maintenance.Mode
+	crashReporter  recovery.Reporter
 }
 
 // NewServer creates a new gRPC server instance with all dependencies
@@ -47,6 +54,19 @@ func (s *Server) Start(ctx context.Context) error {
 		"serviceName", s.config.Observability.ServiceName,
 		"version", s.config.Observability.ServiceVersion)
 
+	// Request logging samples successful calls and redacts sensitive fields
+	logConfig, err := grpclog.FromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid gRPC request logging configuration: %w", err)
+	}
+	requestLogging := grpclog.New(grpclog.FromSlog(s.logger), logConfig)
+
+	// Panics fail the call that raised them instead of the server
+	crashReporter := s.crashReporter
+	if crashReporter == nil {
+		crashReporter = recovery.NewLogReporter(grpclog.FromSlog(s.logger))
+	}
+
 	// Create gRPC server with options
 	s.grpcServer = grpc.NewServer(
 		grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -61,7 +81,8 @@ func (s *Server) Start(ctx context.Context) error {
 			PermitWithoutStream: true,
 		}),
 		// Add interceptors for logging, metrics, tracing
-		grpc.UnaryInterceptor(s.unaryInterceptor),
+		grpc.ChainUnaryInterceptor(recovery.UnaryServerInterceptor(crashReporter), ctxmeta.UnaryServerInterceptor(), requestLogging.UnaryServerInterceptor(), deadline.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(recovery.StreamServerInterceptor(crashReporter)),
 	)
 
 	// Create and register payment handler
@@ -73,6 +94,12 @@ func (s *Server) Start(ctx context.Context) error {
 	s.healthServer.SetServingStatus("payment.v1.PaymentService", grpc_health_v1.HealthCheckResponse_SERVING)
 	grpc_health_v1.RegisterHealthServer(s.grpcServer, s.healthServer)
 
+	// Report NOT_SERVING while in maintenance so clients route new work elsewhere;
+	// in-flight calls are still served until the instance is stopped
+	if s.maintenance != nil {
+		s.maintenance.OnChange(s.onMaintenanceChange)
+	}
+
 	// Enable gRPC reflection for development/debugging
 	reflection.Register(s.grpcServer)
 
@@ -123,6 +150,16 @@ func (s *Server) Stop() {
 	}
 }
 
+// onMaintenanceChange flips the gRPC health status when maintenance mode is toggled
+func (s *Server) onMaintenanceChange(enabled bool) {
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if enabled {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	s.healthServer.SetServingStatus("payment.v1.PaymentService", status)
+	s.logger.Info("Maintenance mode changed", "enabled", enabled, "health_status", status.String())
+}
+
 // waitForShutdown waits for shutdown signals or server errors
 func (s *Server) waitForShutdown(ctx context.Context, errChan <-chan error) error {
 	// Create channel for shutdown signals
@@ -142,41 +179,6 @@ func (s *Server) waitForShutdown(ctx context.Context, errChan <-chan error) erro
 	}
 }
 
-// unaryInterceptor provides logging and error handling for gRPC calls
-func (s *Server) unaryInterceptor(
-	ctx context.Context,
-	req interface{},
-	info *grpc.UnaryServerInfo,
-	handler grpc.UnaryHandler,
-) (interface{}, error) {
-	start := time.Now()
-
-	// Log the incoming request
-	s.logger.Info("gRPC request started",
-		"method", info.FullMethod,
-		"duration", "started")
-
-	// Call the handler
-	resp, err := handler(ctx, req)
-	
-	// Calculate duration
-	duration := time.Since(start)
-
-	// Log the result
-	if err != nil {
-		s.logger.Error("gRPC request failed",
-			"method", info.FullMethod,
-			"duration", duration,
-			"error", err)
-	} else {
-		s.logger.Info("gRPC request completed",
-			"method", info.FullMethod,
-			"duration", duration)
-	}
-
-	return resp, err
-}
-
 // HealthCheck provides a simple health check endpoint
 func (s *Server) HealthCheck() error {
 	if s.grpcServer == nil {
@@ -197,11 +199,19 @@ func (s *Server) GetServerInfo() map[string]interface{} {
 		"service_name":    s.config.Observability.ServiceName,
 		"service_version": s.config.Observability.ServiceVersion,
 		"port":            s.config.Server.Port,
-		"health_status":   "serving",
+		"health_status":   s.healthStatus(),
 		"timestamp":       time.Now().UTC(),
 	}
 }
 
+// healthStatus returns the externally reported serving status
+func (s *Server) healthStatus() string {
+	if s.maintenance.Enabled() {
+		return "not_serving"
+	}
+	return "serving"
+}
+
 // ServerOption allows for configurable server creation
 type ServerOption func(*Server)
 
@@ -213,6 +223,21 @@ func WithCustomInterceptors(interceptors ...grpc.UnaryServerInterceptor) ServerO
 	}
 }
 
+// WithMaintenanceMode attaches the service maintenance switch
+func WithMaintenanceMode(mode *maintenance.Mode) ServerOption {
+	return func(s *Server) {
+		s.maintenance = mode
+	}
+}
+
+// WithCrashReporter sets where panics recovered from calls are reported; they
+// are only logged without one
+func WithCrashReporter(reporter recovery.Reporter) ServerOption {
+	return func(s *Server) {
+		s.crashReporter = reporter
+	}
+}
+
 // NewServerWithOptions creates a server with custom options
 func NewServerWithOptions(cfg *config.Config, logger *slog.Logger, paymentService service.PaymentService, opts ...ServerOption) *Server {
 	server := NewServer(cfg, logger, paymentService)
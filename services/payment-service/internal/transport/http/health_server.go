@@ -11,6 +11,10 @@ import (
 
 	"github.com/amiosamu/rocket-science/services/payment-service/internal/config"
 	"github.com/amiosamu/rocket-science/services/payment-service/internal/service"
+	"github.com/amiosamu/rocket-science/shared/platform/adminhttp"
+	"github.com/amiosamu/rocket-science/shared/platform/maintenance"
+	"github.com/amiosamu/rocket-science/shared/platform/purge"
+	"github.com/amiosamu/rocket-science/shared/platform/version"
 )
 
 // HealthServer provides HTTP health check endpoints for monitoring
@@ -18,6 +22,10 @@ type HealthServer struct {
 	logger         *slog.Logger
 	config         *config.Config
 	paymentService service.PaymentService
+	maintenance    *maintenance.Mode
+	reviewQueue    service.ReviewQueue
+	ledger         service.Ledger
+	settlements    *service.SettlementExporter
 	server         *http.Server
 	startTime      time.Time
 }
@@ -34,11 +42,15 @@ type HealthResponse struct {
 }
 
 // NewHealthServer creates a new health check server
-func NewHealthServer(logger *slog.Logger, cfg *config.Config, paymentService service.PaymentService) *HealthServer {
+func NewHealthServer(logger *slog.Logger, cfg *config.Config, paymentService service.PaymentService, maintenanceMode *maintenance.Mode, reviewQueue service.ReviewQueue, ledger service.Ledger, settlements *service.SettlementExporter) *HealthServer {
 	return &HealthServer{
 		logger:         logger.With("component", "health_server"),
 		config:         cfg,
 		paymentService: paymentService,
+		maintenance:    maintenanceMode,
+		reviewQueue:    reviewQueue,
+		ledger:         ledger,
+		settlements:    settlements,
 		startTime:      time.Now(),
 	}
 }
@@ -50,6 +62,11 @@ func (h *HealthServer) Start() error {
 		port = "8081" // Default health port
 	}
 
+	purgeGate, err := purge.GateFromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid test data purge configuration: %w", err)
+	}
+
 	mux := http.NewServeMux()
 
 	// Register health endpoints
@@ -58,6 +75,16 @@ func (h *HealthServer) Start() error {
 	mux.HandleFunc("/live", h.livenessHandler)
 	mux.HandleFunc("/metrics", h.metricsHandler)
 	mux.HandleFunc("/stats", h.statsHandler)
+	mux.Handle("/version", version.Handler("payment-service"))
+	mux.HandleFunc("/admin/maintenance", h.maintenanceHandler)
+	mux.HandleFunc("/admin/review-queue", h.reviewQueueHandler)
+	mux.HandleFunc("/admin/review-queue/", h.reviewQueueHandler)
+	mux.HandleFunc("/admin/ledger/", h.ledgerHandler)
+	mux.HandleFunc("/admin/settlements/", h.settlementHandler)
+	mux.Handle(purge.Path, purge.Handler(purgeGate, purge.ParticipantFunc(h.purgeTestData)))
+	if h.settlements != nil {
+		mux.Handle(service.SettlementFilesPath, h.settlements.DownloadHandler())
+	}
 
 	h.server = &http.Server{
 		Addr:         ":" + port,
@@ -67,10 +94,22 @@ func (h *HealthServer) Start() error {
 		IdleTimeout:  15 * time.Second,
 	}
 
-	h.logger.Info("Starting health server", "port", port)
+	security, err := adminhttp.FromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid health server security configuration: %w", err)
+	}
+	listener, err := security.Listen(h.server)
+	if err != nil {
+		return err
+	}
+	if !security.AuthEnabled() {
+		h.logger.Warn("Health server admin routes are unauthenticated; set HEALTH_ADMIN_TOKEN or HEALTH_TLS_CLIENT_CA_FILE")
+	}
+
+	h.logger.Info("Starting health server", "address", h.server.Addr, "tls", security.TLSEnabled(), "admin_auth", security.AuthEnabled())
 
 	go func() {
-		if err := h.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := h.server.Serve(listener); err != nil && err != http.ErrServerClosed {
 			h.logger.Error("Health server error", "error", err)
 		}
 	}()
@@ -159,6 +198,10 @@ func (h *HealthServer) readinessHandler(w http.ResponseWriter, r *http.Request)
 		status = "not ready"
 		statusCode = http.StatusServiceUnavailable
 	}
+	if h.maintenance.Enabled() {
+		status = "maintenance"
+		statusCode = http.StatusServiceUnavailable
+	}
 
 	response := HealthResponse{
 		Service:   "payment-service",
@@ -172,6 +215,35 @@ func (h *HealthServer) readinessHandler(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(response)
 }
 
+// maintenanceHandler reports and toggles maintenance mode
+func (h *HealthServer) maintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	if h.maintenance == nil {
+		http.Error(w, "maintenance mode not configured", http.StatusNotImplemented)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		h.logger.Info("Maintenance mode change requested", "method", r.Method, "remote_addr", r.RemoteAddr)
+	}
+
+	h.maintenance.Handler().ServeHTTP(w, r)
+}
+
+// purgeTestData deletes the payments of the orders of a test data purge
+func (h *HealthServer) purgeTestData(ctx context.Context, scope purge.Scope) (*purge.Result, error) {
+	purged, err := h.paymentService.PurgeOrders(ctx, scope.OrderIDs, scope.DryRun)
+	if err != nil {
+		h.logger.Error("Failed to purge payment test data", "error", err)
+		return nil, err
+	}
+
+	result := purge.NewResult("payment-service", scope)
+	result.Deleted["payments"] = int64(purged.Payments)
+	result.Deleted["journal_entries"] = int64(purged.JournalEntries)
+	result.Deleted["review_items"] = int64(purged.ReviewItems)
+	return result, nil
+}
+
 // livenessHandler checks if the service is alive
 func (h *HealthServer) livenessHandler(w http.ResponseWriter, r *http.Request) {
 	h.setCORSHeaders(w)
@@ -245,6 +317,7 @@ func (h *HealthServer) statsHandler(w http.ResponseWriter, r *http.Request) {
 			"processing_time_ms": h.config.Payment.ProcessingTimeMs,
 			"success_rate":       h.config.Payment.SuccessRate,
 			"max_amount":         h.config.Payment.MaxAmount,
+			"risk_enabled":       h.config.Risk.Enabled,
 			"metrics_enabled":    h.config.Observability.MetricsEnabled,
 			"tracing_enabled":    h.config.Observability.TracingEnabled,
 		},
@@ -0,0 +1,181 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/domain"
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/service"
+)
+
+// journalLineResponse is a journal line with amounts as decimal strings
+type journalLineResponse struct {
+	AccountCode string `json:"account_code"`
+	AccountName string `json:"account_name"`
+	Debit       string `json:"debit"`
+	Credit      string `json:"credit"`
+}
+
+// journalEntryResponse is the JSON form of a journal entry
+type journalEntryResponse struct {
+	ID            string                `json:"id"`
+	Tenant        string                `json:"tenant"`
+	OrderID       string                `json:"order_id"`
+	TransactionID string                `json:"transaction_id"`
+	Kind          string                `json:"kind"`
+	Description   string                `json:"description"`
+	Currency      string                `json:"currency"`
+	Lines         []journalLineResponse `json:"lines"`
+	PostedAt      time.Time             `json:"posted_at"`
+}
+
+// accountBalanceResponse is the JSON form of an account balance
+type accountBalanceResponse struct {
+	AccountCode  string `json:"account_code"`
+	AccountName  string `json:"account_name"`
+	AccountType  string `json:"account_type"`
+	Currency     string `json:"currency"`
+	Debits       string `json:"debits"`
+	Credits      string `json:"credits"`
+	Balance      string `json:"balance"`
+	BalanceMinor int64  `json:"balance_minor"` // Exact balance in the currency's minor unit
+}
+
+// ledgerHandler serves the ledger admin API. Entries, balances and the export
+// accept tenant, order_id, since and until (RFC 3339) query filters.
+//
+//	GET /admin/ledger/accounts  lists the chart of accounts
+//	GET /admin/ledger/entries   lists journal entries, oldest first
+//	GET /admin/ledger/balances  returns account balances per currency
+//	GET /admin/ledger/export    downloads the journal as CSV, one row per line
+func (h *HealthServer) ledgerHandler(w http.ResponseWriter, r *http.Request) {
+	if h.ledger == nil {
+		h.writeJSON(w, http.StatusNotImplemented, map[string]string{"error": "ledger not configured"})
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		h.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	resource := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/ledger"), "/")
+	if resource == "accounts" {
+		h.writeJSON(w, http.StatusOK, map[string]interface{}{"accounts": domain.Accounts()})
+		return
+	}
+
+	filter, err := ledgerFilterFromQuery(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	switch resource {
+	case "entries":
+		entries, err := h.ledger.Entries(filter)
+		if err != nil {
+			h.logger.Error("Failed to list journal entries", "error", err)
+			h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list journal entries"})
+			return
+		}
+		response := make([]journalEntryResponse, 0, len(entries))
+		for _, entry := range entries {
+			response = append(response, toJournalEntryResponse(entry))
+		}
+		h.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"entries": response,
+			"count":   len(response),
+		})
+
+	case "balances":
+		balances, err := h.ledger.Balances(filter)
+		if err != nil {
+			h.logger.Error("Failed to compute ledger balances", "error", err)
+			h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to compute ledger balances"})
+			return
+		}
+		response := make([]accountBalanceResponse, 0, len(balances))
+		for _, balance := range balances {
+			response = append(response, accountBalanceResponse{
+				AccountCode:  balance.Account.Code,
+				AccountName:  balance.Account.Name,
+				AccountType:  string(balance.Account.Type),
+				Currency:     balance.Balance.Currency,
+				Debits:       balance.Debits.Amount(),
+				Credits:      balance.Credits.Amount(),
+				Balance:      balance.Balance.Amount(),
+				BalanceMinor: balance.Balance.Minor,
+			})
+		}
+		h.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"tenant":   filter.Tenant,
+			"order_id": filter.OrderID,
+			"balances": response,
+		})
+
+	case "export":
+		entries, err := h.ledger.Entries(filter)
+		if err != nil {
+			h.logger.Error("Failed to export journal", "error", err)
+			h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to export journal"})
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="journal-%s.csv"`, time.Now().UTC().Format("20060102T150405Z")))
+		if err := service.ExportJournalCSV(w, entries); err != nil {
+			// Headers are already sent; the truncated download is all we can signal
+			h.logger.Error("Failed to write journal export", "error", err)
+		}
+
+	default:
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown ledger resource"})
+	}
+}
+
+// ledgerFilterFromQuery reads the ledger filters from the query string
+func ledgerFilterFromQuery(r *http.Request) (service.LedgerFilter, error) {
+	query := r.URL.Query()
+	filter := service.LedgerFilter{
+		Tenant:  query.Get("tenant"),
+		OrderID: query.Get("order_id"),
+	}
+	for name, target := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s: expected an RFC 3339 timestamp", name)
+		}
+		*target = parsed
+	}
+	return filter, nil
+}
+
+func toJournalEntryResponse(entry *domain.JournalEntry) journalEntryResponse {
+	lines := make([]journalLineResponse, 0, len(entry.Lines))
+	for _, line := range entry.Lines {
+		account, _ := domain.AccountByCode(line.Account)
+		lines = append(lines, journalLineResponse{
+			AccountCode: account.Code,
+			AccountName: account.Name,
+			Debit:       line.Debit.Amount(),
+			Credit:      line.Credit.Amount(),
+		})
+	}
+	return journalEntryResponse{
+		ID:            entry.ID,
+		Tenant:        entry.Tenant,
+		OrderID:       entry.OrderID,
+		TransactionID: entry.TransactionID,
+		Kind:          string(entry.Kind),
+		Description:   entry.Description,
+		Currency:      entry.Currency,
+		Lines:         lines,
+		PostedAt:      entry.PostedAt,
+	}
+}
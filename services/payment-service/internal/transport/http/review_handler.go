@@ -0,0 +1,139 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/service"
+)
+
+// reviewDecisionRequest is the body of approve/decline requests
+type reviewDecisionRequest struct {
+	Reviewer string `json:"reviewer"`
+	Reason   string `json:"reason"`
+}
+
+// reviewQueueHandler serves the manual review queue admin API.
+//
+//	GET    /admin/review-queue                          lists payments held for review
+//	GET    /admin/review-queue/{transaction_id}         returns a single held payment
+//	POST   /admin/review-queue/{transaction_id}/approve approves and processes a held payment
+//	POST   /admin/review-queue/{transaction_id}/decline declines a held payment
+//	DELETE /admin/review-queue/{transaction_id}         removes an entry without a decision
+func (h *HealthServer) reviewQueueHandler(w http.ResponseWriter, r *http.Request) {
+	if h.reviewQueue == nil {
+		h.writeJSON(w, http.StatusNotImplemented, map[string]string{"error": "review queue not configured"})
+		return
+	}
+
+	transactionID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/review-queue"), "/")
+
+	if r.Method == http.MethodPost {
+		if id, ok := strings.CutSuffix(transactionID, "/approve"); ok {
+			h.reviewDecision(w, r, id, service.ReviewDecisionApprove)
+			return
+		}
+		if id, ok := strings.CutSuffix(transactionID, "/decline"); ok {
+			h.reviewDecision(w, r, id, service.ReviewDecisionDecline)
+			return
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodGet && transactionID == "":
+		items, err := h.reviewQueue.List()
+		if err != nil {
+			h.logger.Error("Failed to list review queue", "error", err)
+			h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list review queue"})
+			return
+		}
+		h.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"items": items,
+			"count": len(items),
+		})
+
+	case r.Method == http.MethodGet:
+		item, err := h.reviewQueue.Get(transactionID)
+		if err != nil {
+			h.logger.Error("Failed to get review item", "transactionID", transactionID, "error", err)
+			h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to get review item"})
+			return
+		}
+		if item == nil {
+			h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "payment is not in the review queue"})
+			return
+		}
+		h.writeJSON(w, http.StatusOK, item)
+
+	case r.Method == http.MethodDelete && transactionID != "":
+		removed, err := h.reviewQueue.Remove(transactionID)
+		if err != nil {
+			h.logger.Error("Failed to remove review item", "transactionID", transactionID, "error", err)
+			h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to remove review item"})
+			return
+		}
+		if !removed {
+			h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "payment is not in the review queue"})
+			return
+		}
+		h.logger.Info("Payment dismissed from review queue", "transactionID", transactionID, "remote_addr", r.RemoteAddr)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		h.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// reviewDecision applies an approve or decline decision to a held payment
+func (h *HealthServer) reviewDecision(w http.ResponseWriter, r *http.Request, transactionID, decision string) {
+	var body reviewDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON payload"})
+		return
+	}
+	if body.Reviewer == "" {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "reviewer is required"})
+		return
+	}
+	if decision == service.ReviewDecisionDecline && body.Reason == "" {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "reason is required when declining"})
+		return
+	}
+
+	result, err := h.paymentService.ReviewPayment(r.Context(), service.ReviewPaymentRequest{
+		TransactionID: transactionID,
+		Decision:      decision,
+		Reviewer:      body.Reviewer,
+		Reason:        body.Reason,
+	})
+	if err != nil {
+		h.logger.Error("Failed to review payment", "transactionID", transactionID, "error", err)
+		h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to review payment"})
+		return
+	}
+
+	statusCode := http.StatusOK
+	if !result.Success {
+		statusCode = http.StatusConflict
+		if result.Status == "" {
+			statusCode = http.StatusNotFound
+		}
+	}
+
+	h.writeJSON(w, statusCode, map[string]interface{}{
+		"success":        result.Success,
+		"transaction_id": result.TransactionID,
+		"status":         result.Status,
+		"message":        result.Message,
+		"processed_at":   result.ProcessedAt,
+	})
+}
+
+// writeJSON writes a JSON response with the given status code
+func (h *HealthServer) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
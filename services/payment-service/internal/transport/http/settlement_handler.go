@@ -0,0 +1,140 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/services/payment-service/internal/service"
+)
+
+// settlementFileResponse is the JSON form of a stored settlement file
+type settlementFileResponse struct {
+	Format       string    `json:"format"`
+	Key          string    `json:"key"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	CreatedAt    time.Time `json:"created_at"`
+	URL          string    `json:"url"`
+	URLExpiresAt time.Time `json:"url_expires_at"`
+}
+
+// settlementTotalResponse is the JSON form of a settlement total
+type settlementTotalResponse struct {
+	Currency     string `json:"currency"`
+	Transactions int    `json:"transactions"`
+	Gross        string `json:"gross"`
+	Refunds      string `json:"refunds"`
+	Fees         string `json:"fees"`
+	Net          string `json:"net"`
+}
+
+// settlementHandler serves the settlement admin API. Dates are settlement days
+// in UTC; export defaults to yesterday.
+//
+//	POST /admin/settlements/export?date=YYYY-MM-DD  exports the day's settlement files
+//	GET  /admin/settlements/YYYY-MM-DD              returns signed URLs for the day's files
+func (h *HealthServer) settlementHandler(w http.ResponseWriter, r *http.Request) {
+	if h.settlements == nil {
+		h.writeJSON(w, http.StatusNotImplemented, map[string]string{"error": "settlement export not configured"})
+		return
+	}
+
+	resource := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/settlements"), "/")
+	if resource == "export" {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			h.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+
+		day := time.Now().UTC().AddDate(0, 0, -1)
+		if value := r.URL.Query().Get("date"); value != "" {
+			parsed, err := parseSettlementDate(value)
+			if err != nil {
+				h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+			day = parsed
+		}
+
+		export, err := h.settlements.Export(r.Context(), day)
+		if err != nil {
+			h.logger.Error("Failed to export settlement", "date", day.Format(service.SettlementDateLayout), "error", err)
+			h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to export settlement"})
+			return
+		}
+		h.writeJSON(w, http.StatusOK, toSettlementExportResponse(export))
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		h.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+	day, err := parseSettlementDate(resource)
+	if err != nil {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown settlement resource"})
+		return
+	}
+
+	export, err := h.settlements.Files(r.Context(), day)
+	if err != nil {
+		h.logger.Error("Failed to look up settlement files", "date", resource, "error", err)
+		h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to look up settlement files"})
+		return
+	}
+	if len(export.Files) == 0 {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "no settlement exported for " + resource})
+		return
+	}
+	h.writeJSON(w, http.StatusOK, toSettlementExportResponse(export))
+}
+
+func parseSettlementDate(value string) (time.Time, error) {
+	day, err := time.Parse(service.SettlementDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: expected YYYY-MM-DD")
+	}
+	return day, nil
+}
+
+func toSettlementExportResponse(export *service.SettlementExport) map[string]interface{} {
+	files := make([]settlementFileResponse, 0, len(export.Files))
+	for _, file := range export.Files {
+		files = append(files, settlementFileResponse{
+			Format:       file.Format,
+			Key:          file.Key,
+			ContentType:  file.ContentType,
+			Size:         file.Size,
+			CreatedAt:    file.CreatedAt,
+			URL:          file.URL,
+			URLExpiresAt: file.URLExpires,
+		})
+	}
+
+	response := map[string]interface{}{
+		"batch_id": export.BatchID,
+		"tenant":   export.Tenant,
+		"date":     export.Date.Format(service.SettlementDateLayout),
+		"files":    files,
+	}
+	if export.Batch != nil {
+		totals := make([]settlementTotalResponse, 0, len(export.Batch.Totals))
+		for _, total := range export.Batch.Totals {
+			totals = append(totals, settlementTotalResponse{
+				Currency:     total.Currency,
+				Transactions: total.Transactions,
+				Gross:        total.Gross.Amount(),
+				Refunds:      total.Refunds.Amount(),
+				Fees:         total.Fees.Amount(),
+				Net:          total.Net.Amount(),
+			})
+		}
+		response["transactions"] = len(export.Batch.Records)
+		response["totals"] = totals
+	}
+	return response
+}
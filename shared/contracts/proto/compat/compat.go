@@ -0,0 +1,157 @@
+// Package compat guards the wire compatibility of the service API contracts.
+//
+// The fields of each checked API are recorded in a snapshot under snapshots/.
+// Check compares a snapshot with the generated descriptors and reports every
+// field that was removed without reserving its number and name, renumbered,
+// renamed or changed type, any of which breaks clients built against the
+// snapshot. The package test runs Check for every API in Snapshots; refresh the
+// snapshots with `make proto-compat-snapshot` once additions are merged.
+//
+// Fields are retired gracefully: the replacement is added next to the old
+// field, the old one is marked deprecated and listed in Renames so servers
+// accept and return both names, and after the deprecation window the old field
+// is removed and its number and name reserved.
+package compat
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	iamv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+	inventoryv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/inventory/v1"
+)
+
+// Snapshots maps the snapshot file of each checked API to its descriptor
+var Snapshots = map[string]protoreflect.FileDescriptor{
+	"snapshots/iam.v1.txt":       iamv1.File_iam_v1_iam_proto,
+	"snapshots/inventory.v1.txt": inventoryv1.File_inventory_v1_inventory_proto,
+}
+
+// Field is a message field as recorded in a snapshot
+type Field struct {
+	Message protoreflect.FullName
+	Name    protoreflect.Name
+	Number  protoreflect.FieldNumber
+	Type    string // e.g. "string", "repeated iam.v1.Role" or "map<string, string>"
+}
+
+func (f Field) String() string {
+	return fmt.Sprintf("%s.%s = %d %s", f.Message, f.Name, f.Number, f.Type)
+}
+
+// Violation is a change to a snapshot field that breaks existing clients
+type Violation struct {
+	Field  Field // As recorded in the snapshot
+	Reason string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s.%s (%d): %s", v.Field.Message, v.Field.Name, v.Field.Number, v.Reason)
+}
+
+// Check reports the fields of previous that current no longer serves
+// compatibly. Fields added in current are never violations.
+func Check(previous []Field, current protoreflect.FileDescriptor) []Violation {
+	var violations []Violation
+	for _, field := range previous {
+		message := findMessage(current, field.Message)
+		if message == nil {
+			violations = append(violations, Violation{Field: field, Reason: "message removed"})
+			continue
+		}
+
+		fields := message.Fields()
+		if fd := fields.ByNumber(field.Number); fd != nil {
+			if fd.Name() != field.Name {
+				violations = append(violations, Violation{Field: field, Reason: fmt.Sprintf("field renamed to %s", fd.Name())})
+			} else if typ := fieldType(fd); typ != field.Type {
+				violations = append(violations, Violation{Field: field, Reason: fmt.Sprintf("type changed to %s", typ)})
+			}
+			continue
+		}
+		if fd := fields.ByName(field.Name); fd != nil {
+			violations = append(violations, Violation{Field: field, Reason: fmt.Sprintf("field renumbered to %d", fd.Number())})
+			continue
+		}
+		if !message.ReservedRanges().Has(field.Number) || !message.ReservedNames().Has(field.Name) {
+			violations = append(violations, Violation{Field: field, Reason: "field removed without reserving its number and name"})
+		}
+	}
+	return violations
+}
+
+// Fields lists the fields of every message in the file, nested ones included,
+// sorted by message and number
+func Fields(file protoreflect.FileDescriptor) []Field {
+	var fields []Field
+	var walk func(messages protoreflect.MessageDescriptors)
+	walk = func(messages protoreflect.MessageDescriptors) {
+		for i := 0; i < messages.Len(); i++ {
+			message := messages.Get(i)
+			if message.IsMapEntry() {
+				continue
+			}
+			for j := 0; j < message.Fields().Len(); j++ {
+				fd := message.Fields().Get(j)
+				fields = append(fields, Field{
+					Message: message.FullName(),
+					Name:    fd.Name(),
+					Number:  fd.Number(),
+					Type:    fieldType(fd),
+				})
+			}
+			walk(message.Messages())
+		}
+	}
+	walk(file.Messages())
+
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].Message != fields[j].Message {
+			return fields[i].Message < fields[j].Message
+		}
+		return fields[i].Number < fields[j].Number
+	})
+	return fields
+}
+
+// findMessage looks up a message of the file by its full name
+func findMessage(file protoreflect.FileDescriptor, name protoreflect.FullName) protoreflect.MessageDescriptor {
+	var find func(messages protoreflect.MessageDescriptors) protoreflect.MessageDescriptor
+	find = func(messages protoreflect.MessageDescriptors) protoreflect.MessageDescriptor {
+		for i := 0; i < messages.Len(); i++ {
+			message := messages.Get(i)
+			if message.FullName() == name {
+				return message
+			}
+			if nested := find(message.Messages()); nested != nil {
+				return nested
+			}
+		}
+		return nil
+	}
+	return find(file.Messages())
+}
+
+// fieldType describes the wire type of a field
+func fieldType(fd protoreflect.FieldDescriptor) string {
+	if fd.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", kindName(fd.MapKey()), kindName(fd.MapValue()))
+	}
+	if fd.IsList() {
+		return "repeated " + kindName(fd)
+	}
+	return kindName(fd)
+}
+
+func kindName(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(fd.Message().FullName())
+	case protoreflect.EnumKind:
+		return string(fd.Enum().FullName())
+	default:
+		return fd.Kind().String()
+	}
+}
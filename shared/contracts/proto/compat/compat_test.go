@@ -0,0 +1,203 @@
+package compat
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	iamv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/iam/v1"
+	inventoryv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/inventory/v1"
+)
+
+var update = flag.Bool("update", false, "rewrite the snapshots from the current contracts")
+
+// TestContractsCompatible fails when a contract no longer serves a field of its
+// snapshot, or when fields were added without refreshing the snapshot
+func TestContractsCompatible(t *testing.T) {
+	for path, file := range Snapshots {
+		t.Run(string(file.Package()), func(t *testing.T) {
+			if *update {
+				var buf bytes.Buffer
+				if err := WriteSnapshot(&buf, file); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			snapshot, err := os.Open(path)
+			if err != nil {
+				t.Fatalf("missing snapshot, run make proto-compat-snapshot: %v", err)
+			}
+			defer snapshot.Close()
+			previous, err := ReadSnapshot(snapshot)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, v := range Check(previous, file) {
+				t.Errorf("breaking change: %s", v)
+			}
+
+			recorded := make(map[string]bool, len(previous))
+			for _, field := range previous {
+				recorded[field.String()] = true
+			}
+			for _, field := range Fields(file) {
+				if !recorded[field.String()] {
+					t.Errorf("field %s is not in the snapshot, run make proto-compat-snapshot", field)
+				}
+			}
+		})
+	}
+}
+
+func TestRenamesValid(t *testing.T) {
+	for file, rs := range map[protoreflect.FileDescriptor]Renames{
+		iamv1.File_iam_v1_iam_proto:                   IAM,
+		inventoryv1.File_inventory_v1_inventory_proto: Inventory,
+	} {
+		if err := rs.Validate(file); err != nil {
+			t.Errorf("%s renames: %v", file.Package(), err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	previous := []Field{
+		{Message: "compat.test.Item", Name: "id", Number: 1, Type: "string"},
+		{Message: "compat.test.Item", Name: "name", Number: 2, Type: "string"},
+		{Message: "compat.test.Item", Name: "count", Number: 3, Type: "int32"},
+		{Message: "compat.test.Item", Name: "tags", Number: 4, Type: "repeated string"},
+		{Message: "compat.test.Item", Name: "note", Number: 5, Type: "string"},
+		{Message: "compat.test.Gone", Name: "id", Number: 1, Type: "string"},
+	}
+
+	// name renumbered, count retyped, tags renamed, note removed and reserved
+	labels := testField("labels", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	labels.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	current := testFile(t, &descriptorpb.DescriptorProto{
+		Name: proto.String("Item"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			testField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+			testField("name", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+			testField("count", 3, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+			labels,
+		},
+		ReservedRange: []*descriptorpb.DescriptorProto_ReservedRange{{Start: proto.Int32(5), End: proto.Int32(6)}},
+		ReservedName:  []string{"note"},
+	})
+
+	want := map[string]string{
+		"compat.test.Item.name":  "field renumbered to 6",
+		"compat.test.Item.count": "type changed to int64",
+		"compat.test.Item.tags":  "field renamed to labels",
+		"compat.test.Gone.id":    "message removed",
+	}
+	got := map[string]string{}
+	for _, v := range Check(previous, current) {
+		got[string(v.Field.Message)+"."+string(v.Field.Name)] = v.Reason
+	}
+	for field, reason := range want {
+		if !strings.HasPrefix(got[field], reason) {
+			t.Errorf("%s: got %q, want %q", field, got[field], reason)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got violations %v, want %v", got, want)
+	}
+
+	// Dropping the reservation makes the removal a violation
+	unreserved := testFile(t, &descriptorpb.DescriptorProto{
+		Name:  proto.String("Item"),
+		Field: []*descriptorpb.FieldDescriptorProto{testField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING)},
+	})
+	violations := Check(previous[4:5], unreserved)
+	if len(violations) != 1 || !strings.Contains(violations[0].Reason, "without reserving") {
+		t.Errorf("removed field: got %v", violations)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	for _, file := range Snapshots {
+		var buf bytes.Buffer
+		if err := WriteSnapshot(&buf, file); err != nil {
+			t.Fatal(err)
+		}
+		fields, err := ReadSnapshot(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(fields) != len(Fields(file)) || len(Check(fields, file)) != 0 {
+			t.Errorf("%s: snapshot does not round trip", file.Path())
+		}
+	}
+}
+
+func TestRenamesUpgradeAndDowngrade(t *testing.T) {
+	old := testField("old_name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	old.Options = &descriptorpb.FieldOptions{Deprecated: proto.Bool(true)}
+	file := testFile(t, &descriptorpb.DescriptorProto{
+		Name: proto.String("Item"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			old,
+			testField("new_name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		},
+	})
+	descriptor := file.Messages().ByName("Item")
+	oldField, newField := descriptor.Fields().ByName("old_name"), descriptor.Fields().ByName("new_name")
+	rs := Renames{{Message: "compat.test.Item", Old: "old_name", New: "new_name"}}
+	if err := rs.Validate(file); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	request := dynamicpb.NewMessage(descriptor)
+	request.Set(oldField, protoreflect.ValueOfString("booster"))
+	if used := rs.Upgrade(request); len(used) != 1 {
+		t.Errorf("Upgrade: got %d renames used, want 1", len(used))
+	}
+	if got := request.Get(newField).String(); got != "booster" {
+		t.Errorf("Upgrade: new_name = %q, want booster", got)
+	}
+
+	response := dynamicpb.NewMessage(descriptor)
+	response.Set(newField, protoreflect.ValueOfString("engine"))
+	rs.Downgrade(response)
+	if got := response.Get(oldField).String(); got != "engine" {
+		t.Errorf("Downgrade: old_name = %q, want engine", got)
+	}
+}
+
+// testFile builds a file of package compat.test holding the message
+func testFile(t *testing.T, message *descriptorpb.DescriptorProto) protoreflect.FileDescriptor {
+	t.Helper()
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:        proto.String("compat/test.proto"),
+		Package:     proto.String("compat.test"),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{message},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func testField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Type:     typ.Enum(),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		JsonName: proto.String(name),
+	}
+}
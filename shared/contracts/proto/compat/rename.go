@@ -0,0 +1,154 @@
+package compat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// DeprecatedFieldsHeader is the response header listing the deprecated field
+// names a request used, so clients can find what to migrate
+const DeprecatedFieldsHeader = "x-deprecated-fields"
+
+// Rename is a field being replaced by a new field of the same type. Until Old
+// is removed, requests may set either field and responses carry both.
+type Rename struct {
+	Message protoreflect.FullName
+	Old     protoreflect.Name // Marked deprecated in the contract
+	New     protoreflect.Name
+	Until   string // End of the deprecation window, e.g. "2026-12-31"
+}
+
+func (r Rename) String() string {
+	return string(r.Message) + "." + string(r.Old)
+}
+
+// Renames are the renames of an API within their deprecation window
+type Renames []Rename
+
+// Renames in their deprecation window, per API. Remove an entry together with
+// its old field once the window has passed.
+var (
+	IAM       Renames
+	Inventory Renames
+)
+
+// Validate checks that every rename names two fields of the same type in a
+// message of the file, the old one marked deprecated
+func (rs Renames) Validate(file protoreflect.FileDescriptor) error {
+	for _, r := range rs {
+		message := findMessage(file, r.Message)
+		if message == nil {
+			return fmt.Errorf("rename %s: message not found", r)
+		}
+		old, new := message.Fields().ByName(r.Old), message.Fields().ByName(r.New)
+		switch {
+		case old == nil || new == nil:
+			return fmt.Errorf("rename %s: fields %s and %s must both exist", r, r.Old, r.New)
+		case fieldType(old) != fieldType(new):
+			return fmt.Errorf("rename %s: %s is %s but %s is %s", r, r.Old, fieldType(old), r.New, fieldType(new))
+		case !isDeprecated(old):
+			return fmt.Errorf("rename %s: %s must be marked deprecated", r, r.Old)
+		}
+	}
+	return nil
+}
+
+// Upgrade copies each renamed field set only under its old name to the new
+// name, in the message and every message nested in it, and returns the renames
+// whose old name was used
+func (rs Renames) Upgrade(message proto.Message) []Rename {
+	var used []Rename
+	rs.walk(message.ProtoReflect(), func(m protoreflect.Message, r Rename, old, new protoreflect.FieldDescriptor) {
+		if m.Has(old) {
+			used = append(used, r)
+			if !m.Has(new) {
+				m.Set(new, m.Get(old))
+			}
+		}
+	})
+	return used
+}
+
+// Downgrade copies each renamed field to its old name, in the message and every
+// message nested in it, for clients still reading the old name
+func (rs Renames) Downgrade(message proto.Message) {
+	rs.walk(message.ProtoReflect(), func(m protoreflect.Message, _ Rename, old, new protoreflect.FieldDescriptor) {
+		if m.Has(new) {
+			m.Set(old, m.Get(new))
+		}
+	})
+}
+
+// UnaryServerInterceptor serves both names of the renamed fields: requests are
+// upgraded before the handler sees them and responses downgraded before they
+// are sent. Requests using an old name get DeprecatedFieldsHeader.
+func (rs Renames) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if len(rs) == 0 {
+			return handler(ctx, req)
+		}
+
+		if message, ok := req.(proto.Message); ok {
+			if used := rs.Upgrade(message); len(used) > 0 {
+				names := make([]string, len(used))
+				for i, r := range used {
+					names[i] = r.String()
+				}
+				_ = grpc.SetHeader(ctx, metadata.Pairs(DeprecatedFieldsHeader, strings.Join(names, ",")))
+			}
+		}
+
+		resp, err := handler(ctx, req)
+		if message, ok := resp.(proto.Message); ok && err == nil {
+			rs.Downgrade(message)
+		}
+		return resp, err
+	}
+}
+
+// walk calls fn for every rename of the message and of each message nested in it
+func (rs Renames) walk(m protoreflect.Message, fn func(m protoreflect.Message, r Rename, old, new protoreflect.FieldDescriptor)) {
+	fields := m.Descriptor().Fields()
+	for _, r := range rs {
+		if r.Message != m.Descriptor().FullName() {
+			continue
+		}
+		old, new := fields.ByName(r.Old), fields.ByName(r.New)
+		if old != nil && new != nil {
+			fn(m, r, old, new)
+		}
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					rs.walk(value.Message(), fn)
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				for i := 0; i < v.List().Len(); i++ {
+					rs.walk(v.List().Get(i).Message(), fn)
+				}
+			}
+		case fd.Message() != nil:
+			rs.walk(v.Message(), fn)
+		}
+		return true
+	})
+}
+
+func isDeprecated(fd protoreflect.FieldDescriptor) bool {
+	options, _ := fd.Options().(*descriptorpb.FieldOptions)
+	return options.GetDeprecated()
+}
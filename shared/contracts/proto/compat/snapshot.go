@@ -0,0 +1,71 @@
+package compat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// snapshotHeader opens every snapshot file
+const snapshotHeader = "# Fields served by %s; generated by `make proto-compat-snapshot`, do not edit.\n"
+
+// WriteSnapshot writes the fields of a file as a snapshot, one field per line
+func WriteSnapshot(w io.Writer, file protoreflect.FileDescriptor) error {
+	if _, err := fmt.Fprintf(w, snapshotHeader, file.Path()); err != nil {
+		return err
+	}
+	for _, field := range Fields(file) {
+		if _, err := fmt.Fprintln(w, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadSnapshot reads the fields of a snapshot written by WriteSnapshot
+func ReadSnapshot(r io.Reader) ([]Field, error) {
+	var fields []Field
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		field, err := parseField(text)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot line %d: %w", line, err)
+		}
+		fields = append(fields, field)
+	}
+	return fields, scanner.Err()
+}
+
+// parseField parses a line such as "iam.v1.User.id = 1 string"
+func parseField(text string) (Field, error) {
+	path, rest, ok := strings.Cut(text, " = ")
+	if !ok {
+		return Field{}, fmt.Errorf("missing \" = \" in %q", text)
+	}
+	number, typ, ok := strings.Cut(rest, " ")
+	if !ok {
+		return Field{}, fmt.Errorf("missing field type in %q", text)
+	}
+	dot := strings.LastIndex(path, ".")
+	if dot < 0 {
+		return Field{}, fmt.Errorf("invalid field path %q", path)
+	}
+	n, err := strconv.ParseInt(number, 10, 32)
+	if err != nil {
+		return Field{}, fmt.Errorf("invalid field number %q", number)
+	}
+	return Field{
+		Message: protoreflect.FullName(path[:dot]),
+		Name:    protoreflect.Name(path[dot+1:]),
+		Number:  protoreflect.FieldNumber(n),
+		Type:    typ,
+	}, nil
+}
@@ -0,0 +1,19 @@
+// Package proto holds the gRPC API contracts of the Rocket Science services
+// and the Go code generated from them. Each service API is a versioned
+// package under this module:
+//
+//	iam/v1        IAMService        (package iamv1)
+//	inventory/v1  InventoryService  (package inventoryv1)
+//	payment/v1    PaymentService    (package paymentv1)
+//	money/v1      Money message     (package moneyv1)
+//	pagination/v1 Page messages     (package paginationv1)
+//
+// Servers and clients import the same generated package, so a client never
+// needs the serving module as a dependency. Package money wraps money.v1 in
+// an exact minor-unit value type for use in service code, and package
+// pagination wraps pagination.v1 with page token encoding. Regenerate with
+// `make proto-gen` and check compatibility with `make proto-breaking` before
+// merging changes; package compat additionally checks the IAM and inventory
+// fields against snapshots and serves renamed fields under both names during
+// their deprecation window.
+package proto
@@ -0,0 +1,4776 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: inventory/v1/inventory.proto
+
+package inventoryv1
+
+import (
+	v1 "github.com/amiosamu/rocket-science/shared/contracts/proto/money/v1"
+	v11 "github.com/amiosamu/rocket-science/shared/contracts/proto/pagination/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ItemChangeType distinguishes snapshots from live changes
+type ItemChangeType int32
+
+const (
+	ItemChangeType_ITEM_CHANGE_TYPE_UNSPECIFIED ItemChangeType = 0
+	ItemChangeType_ITEM_CHANGE_TYPE_SNAPSHOT    ItemChangeType = 1 // Current state when the stream starts
+	ItemChangeType_ITEM_CHANGE_TYPE_UPDATED     ItemChangeType = 2 // Stock, price or status changed
+	ItemChangeType_ITEM_CHANGE_TYPE_DELETED     ItemChangeType = 3 // Item was removed from inventory
+)
+
+// Enum value maps for ItemChangeType.
+var (
+	ItemChangeType_name = map[int32]string{
+		0: "ITEM_CHANGE_TYPE_UNSPECIFIED",
+		1: "ITEM_CHANGE_TYPE_SNAPSHOT",
+		2: "ITEM_CHANGE_TYPE_UPDATED",
+		3: "ITEM_CHANGE_TYPE_DELETED",
+	}
+	ItemChangeType_value = map[string]int32{
+		"ITEM_CHANGE_TYPE_UNSPECIFIED": 0,
+		"ITEM_CHANGE_TYPE_SNAPSHOT":    1,
+		"ITEM_CHANGE_TYPE_UPDATED":     2,
+		"ITEM_CHANGE_TYPE_DELETED":     3,
+	}
+)
+
+func (x ItemChangeType) Enum() *ItemChangeType {
+	p := new(ItemChangeType)
+	*p = x
+	return p
+}
+
+func (x ItemChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ItemChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[0].Descriptor()
+}
+
+func (ItemChangeType) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[0]
+}
+
+func (x ItemChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ItemChangeType.Descriptor instead.
+func (ItemChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+// ItemCategory enum for different types of rocket parts
+type ItemCategory int32
+
+const (
+	ItemCategory_ITEM_CATEGORY_UNSPECIFIED  ItemCategory = 0
+	ItemCategory_ITEM_CATEGORY_ENGINES      ItemCategory = 1 // Rocket engines
+	ItemCategory_ITEM_CATEGORY_FUEL_TANKS   ItemCategory = 2 // Fuel storage tanks
+	ItemCategory_ITEM_CATEGORY_NAVIGATION   ItemCategory = 3 // Navigation systems
+	ItemCategory_ITEM_CATEGORY_STRUCTURAL   ItemCategory = 4 // Structural components
+	ItemCategory_ITEM_CATEGORY_ELECTRONICS  ItemCategory = 5 // Electronic systems
+	ItemCategory_ITEM_CATEGORY_LIFE_SUPPORT ItemCategory = 6 // Life support systems
+	ItemCategory_ITEM_CATEGORY_PAYLOAD      ItemCategory = 7 // Payload components
+	ItemCategory_ITEM_CATEGORY_LANDING_GEAR ItemCategory = 8 // Landing gear systems
+)
+
+// Enum value maps for ItemCategory.
+var (
+	ItemCategory_name = map[int32]string{
+		0: "ITEM_CATEGORY_UNSPECIFIED",
+		1: "ITEM_CATEGORY_ENGINES",
+		2: "ITEM_CATEGORY_FUEL_TANKS",
+		3: "ITEM_CATEGORY_NAVIGATION",
+		4: "ITEM_CATEGORY_STRUCTURAL",
+		5: "ITEM_CATEGORY_ELECTRONICS",
+		6: "ITEM_CATEGORY_LIFE_SUPPORT",
+		7: "ITEM_CATEGORY_PAYLOAD",
+		8: "ITEM_CATEGORY_LANDING_GEAR",
+	}
+	ItemCategory_value = map[string]int32{
+		"ITEM_CATEGORY_UNSPECIFIED":  0,
+		"ITEM_CATEGORY_ENGINES":      1,
+		"ITEM_CATEGORY_FUEL_TANKS":   2,
+		"ITEM_CATEGORY_NAVIGATION":   3,
+		"ITEM_CATEGORY_STRUCTURAL":   4,
+		"ITEM_CATEGORY_ELECTRONICS":  5,
+		"ITEM_CATEGORY_LIFE_SUPPORT": 6,
+		"ITEM_CATEGORY_PAYLOAD":      7,
+		"ITEM_CATEGORY_LANDING_GEAR": 8,
+	}
+)
+
+func (x ItemCategory) Enum() *ItemCategory {
+	p := new(ItemCategory)
+	*p = x
+	return p
+}
+
+func (x ItemCategory) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ItemCategory) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[1].Descriptor()
+}
+
+func (ItemCategory) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[1]
+}
+
+func (x ItemCategory) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ItemCategory.Descriptor instead.
+func (ItemCategory) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+// ValuationMethod enum for costing stock leaving inventory
+type ValuationMethod int32
+
+const (
+	ValuationMethod_VALUATION_METHOD_UNSPECIFIED ValuationMethod = 0
+	ValuationMethod_VALUATION_METHOD_FIFO        ValuationMethod = 1 // Oldest receipts are used up first
+	ValuationMethod_VALUATION_METHOD_AVERAGE     ValuationMethod = 2 // Moving average cost of the stock on hand
+)
+
+// Enum value maps for ValuationMethod.
+var (
+	ValuationMethod_name = map[int32]string{
+		0: "VALUATION_METHOD_UNSPECIFIED",
+		1: "VALUATION_METHOD_FIFO",
+		2: "VALUATION_METHOD_AVERAGE",
+	}
+	ValuationMethod_value = map[string]int32{
+		"VALUATION_METHOD_UNSPECIFIED": 0,
+		"VALUATION_METHOD_FIFO":        1,
+		"VALUATION_METHOD_AVERAGE":     2,
+	}
+)
+
+func (x ValuationMethod) Enum() *ValuationMethod {
+	p := new(ValuationMethod)
+	*p = x
+	return p
+}
+
+func (x ValuationMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ValuationMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[2].Descriptor()
+}
+
+func (ValuationMethod) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[2]
+}
+
+func (x ValuationMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ValuationMethod.Descriptor instead.
+func (ValuationMethod) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+// BackorderStatus enum for backorder lifecycle states
+type BackorderStatus int32
+
+const (
+	BackorderStatus_BACKORDER_STATUS_UNSPECIFIED BackorderStatus = 0
+	BackorderStatus_BACKORDER_STATUS_WAITING     BackorderStatus = 1 // Waiting for stock
+	BackorderStatus_BACKORDER_STATUS_RESERVED    BackorderStatus = 2 // Stock reserved for the order
+	BackorderStatus_BACKORDER_STATUS_CANCELLED   BackorderStatus = 3
+)
+
+// Enum value maps for BackorderStatus.
+var (
+	BackorderStatus_name = map[int32]string{
+		0: "BACKORDER_STATUS_UNSPECIFIED",
+		1: "BACKORDER_STATUS_WAITING",
+		2: "BACKORDER_STATUS_RESERVED",
+		3: "BACKORDER_STATUS_CANCELLED",
+	}
+	BackorderStatus_value = map[string]int32{
+		"BACKORDER_STATUS_UNSPECIFIED": 0,
+		"BACKORDER_STATUS_WAITING":     1,
+		"BACKORDER_STATUS_RESERVED":    2,
+		"BACKORDER_STATUS_CANCELLED":   3,
+	}
+)
+
+func (x BackorderStatus) Enum() *BackorderStatus {
+	p := new(BackorderStatus)
+	*p = x
+	return p
+}
+
+func (x BackorderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BackorderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[3].Descriptor()
+}
+
+func (BackorderStatus) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[3]
+}
+
+func (x BackorderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BackorderStatus.Descriptor instead.
+func (BackorderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+// ReservationPriority enum for reservation preemption
+type ReservationPriority int32
+
+const (
+	ReservationPriority_RESERVATION_PRIORITY_STANDARD  ReservationPriority = 0 // Regular orders
+	ReservationPriority_RESERVATION_PRIORITY_EXPEDITED ReservationPriority = 1 // May preempt standard reservations nearing expiry
+)
+
+// Enum value maps for ReservationPriority.
+var (
+	ReservationPriority_name = map[int32]string{
+		0: "RESERVATION_PRIORITY_STANDARD",
+		1: "RESERVATION_PRIORITY_EXPEDITED",
+	}
+	ReservationPriority_value = map[string]int32{
+		"RESERVATION_PRIORITY_STANDARD":  0,
+		"RESERVATION_PRIORITY_EXPEDITED": 1,
+	}
+)
+
+func (x ReservationPriority) Enum() *ReservationPriority {
+	p := new(ReservationPriority)
+	*p = x
+	return p
+}
+
+func (x ReservationPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReservationPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[4].Descriptor()
+}
+
+func (ReservationPriority) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[4]
+}
+
+func (x ReservationPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReservationPriority.Descriptor instead.
+func (ReservationPriority) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+// ItemStatus enum for item lifecycle states
+type ItemStatus int32
+
+const (
+	ItemStatus_ITEM_STATUS_UNSPECIFIED  ItemStatus = 0
+	ItemStatus_ITEM_STATUS_ACTIVE       ItemStatus = 1 // Available for orders
+	ItemStatus_ITEM_STATUS_DISCONTINUED ItemStatus = 2 // No longer available
+	ItemStatus_ITEM_STATUS_OUT_OF_STOCK ItemStatus = 3 // Temporarily out of stock
+	ItemStatus_ITEM_STATUS_BACKORDERED  ItemStatus = 4 // On backorder
+	ItemStatus_ITEM_STATUS_INCOMING     ItemStatus = 5 // New stock incoming
+)
+
+// Enum value maps for ItemStatus.
+var (
+	ItemStatus_name = map[int32]string{
+		0: "ITEM_STATUS_UNSPECIFIED",
+		1: "ITEM_STATUS_ACTIVE",
+		2: "ITEM_STATUS_DISCONTINUED",
+		3: "ITEM_STATUS_OUT_OF_STOCK",
+		4: "ITEM_STATUS_BACKORDERED",
+		5: "ITEM_STATUS_INCOMING",
+	}
+	ItemStatus_value = map[string]int32{
+		"ITEM_STATUS_UNSPECIFIED":  0,
+		"ITEM_STATUS_ACTIVE":       1,
+		"ITEM_STATUS_DISCONTINUED": 2,
+		"ITEM_STATUS_OUT_OF_STOCK": 3,
+		"ITEM_STATUS_BACKORDERED":  4,
+		"ITEM_STATUS_INCOMING":     5,
+	}
+)
+
+func (x ItemStatus) Enum() *ItemStatus {
+	p := new(ItemStatus)
+	*p = x
+	return p
+}
+
+func (x ItemStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ItemStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[5].Descriptor()
+}
+
+func (ItemStatus) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[5]
+}
+
+func (x ItemStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ItemStatus.Descriptor instead.
+func (ItemStatus) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{5}
+}
+
+// CheckAvailabilityRequest contains items to check for availability
+type CheckAvailabilityRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Items         []*ItemAvailabilityCheck `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // Items to check
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityRequest) Reset() {
+	*x = CheckAvailabilityRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckAvailabilityRequest) GetItems() []*ItemAvailabilityCheck {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// ItemAvailabilityCheck represents a single item availability check.
+// Set decimal_quantity for items not stocked in whole pieces; it takes precedence over quantity.
+type ItemAvailabilityCheck struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Sku             string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`                                                  // Item SKU to check
+	Quantity        int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`                                       // Quantity needed, in whole units
+	DecimalQuantity float64                `protobuf:"fixed64,3,opt,name=decimal_quantity,json=decimalQuantity,proto3" json:"decimal_quantity,omitempty"` // Quantity needed, may be fractional (e.g. 1250.5 liters)
+	Unit            string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`                                                // Unit of the quantity (ea, g, kg, t, ml, l, m3); empty means the item's unit
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ItemAvailabilityCheck) Reset() {
+	*x = ItemAvailabilityCheck{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemAvailabilityCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemAvailabilityCheck) ProtoMessage() {}
+
+func (x *ItemAvailabilityCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemAvailabilityCheck.ProtoReflect.Descriptor instead.
+func (*ItemAvailabilityCheck) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ItemAvailabilityCheck) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ItemAvailabilityCheck) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ItemAvailabilityCheck) GetDecimalQuantity() float64 {
+	if x != nil {
+		return x.DecimalQuantity
+	}
+	return 0
+}
+
+func (x *ItemAvailabilityCheck) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// CheckAvailabilityResponse contains availability results
+type CheckAvailabilityResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	AllAvailable  bool                      `protobuf:"varint,1,opt,name=all_available,json=allAvailable,proto3" json:"all_available,omitempty"` // True if all items are available
+	Results       []*ItemAvailabilityResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`                                // Per-item availability results
+	Message       string                    `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                                // Summary message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityResponse) Reset() {
+	*x = CheckAvailabilityResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityResponse) ProtoMessage() {}
+
+func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckAvailabilityResponse) GetAllAvailable() bool {
+	if x != nil {
+		return x.AllAvailable
+	}
+	return false
+}
+
+func (x *CheckAvailabilityResponse) GetResults() []*ItemAvailabilityResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *CheckAvailabilityResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ItemAvailabilityResult contains availability info for a single item
+type ItemAvailabilityResult struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Sku                      string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`                                                                                // Item SKU
+	Name                     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                                              // Item name
+	Available                bool                   `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`                                                                   // Whether requested quantity is available
+	RequestedQuantity        int32                  `protobuf:"varint,4,opt,name=requested_quantity,json=requestedQuantity,proto3" json:"requested_quantity,omitempty"`                          // Quantity requested
+	AvailableQuantity        int32                  `protobuf:"varint,5,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`                          // Quantity available
+	ReservedQuantity         int32                  `protobuf:"varint,6,opt,name=reserved_quantity,json=reservedQuantity,proto3" json:"reserved_quantity,omitempty"`                             // Quantity currently reserved
+	Reason                   string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`                                                                          // Reason if not available
+	Bundle                   bool                   `protobuf:"varint,8,opt,name=bundle,proto3" json:"bundle,omitempty"`                                                                         // True if the SKU is a kit whose availability comes from its components
+	Unit                     string                 `protobuf:"bytes,9,opt,name=unit,proto3" json:"unit,omitempty"`                                                                              // Unit of the quantities below, the item's unit
+	DecimalRequestedQuantity float64                `protobuf:"fixed64,10,opt,name=decimal_requested_quantity,json=decimalRequestedQuantity,proto3" json:"decimal_requested_quantity,omitempty"` // Quantity requested, converted into unit
+	DecimalAvailableQuantity float64                `protobuf:"fixed64,11,opt,name=decimal_available_quantity,json=decimalAvailableQuantity,proto3" json:"decimal_available_quantity,omitempty"` // Quantity available, not rounded down like available_quantity
+	DecimalReservedQuantity  float64                `protobuf:"fixed64,12,opt,name=decimal_reserved_quantity,json=decimalReservedQuantity,proto3" json:"decimal_reserved_quantity,omitempty"`    // Quantity currently reserved, not rounded down like reserved_quantity
+	UnitPrice                *Money                 `protobuf:"bytes,13,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`                                                  // Price per unit of the item or kit
+	ReplacementSku           string                 `protobuf:"bytes,14,opt,name=replacement_sku,json=replacementSku,proto3" json:"replacement_sku,omitempty"`                                   // Replacement of a discontinued item, if any
+	Substituted              bool                   `protobuf:"varint,15,opt,name=substituted,proto3" json:"substituted,omitempty"`                                                              // The replacement was checked in place of the item
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *ItemAvailabilityResult) Reset() {
+	*x = ItemAvailabilityResult{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemAvailabilityResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemAvailabilityResult) ProtoMessage() {}
+
+func (x *ItemAvailabilityResult) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemAvailabilityResult.ProtoReflect.Descriptor instead.
+func (*ItemAvailabilityResult) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ItemAvailabilityResult) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ItemAvailabilityResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ItemAvailabilityResult) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *ItemAvailabilityResult) GetRequestedQuantity() int32 {
+	if x != nil {
+		return x.RequestedQuantity
+	}
+	return 0
+}
+
+func (x *ItemAvailabilityResult) GetAvailableQuantity() int32 {
+	if x != nil {
+		return x.AvailableQuantity
+	}
+	return 0
+}
+
+func (x *ItemAvailabilityResult) GetReservedQuantity() int32 {
+	if x != nil {
+		return x.ReservedQuantity
+	}
+	return 0
+}
+
+func (x *ItemAvailabilityResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ItemAvailabilityResult) GetBundle() bool {
+	if x != nil {
+		return x.Bundle
+	}
+	return false
+}
+
+func (x *ItemAvailabilityResult) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *ItemAvailabilityResult) GetDecimalRequestedQuantity() float64 {
+	if x != nil {
+		return x.DecimalRequestedQuantity
+	}
+	return 0
+}
+
+func (x *ItemAvailabilityResult) GetDecimalAvailableQuantity() float64 {
+	if x != nil {
+		return x.DecimalAvailableQuantity
+	}
+	return 0
+}
+
+func (x *ItemAvailabilityResult) GetDecimalReservedQuantity() float64 {
+	if x != nil {
+		return x.DecimalReservedQuantity
+	}
+	return 0
+}
+
+func (x *ItemAvailabilityResult) GetUnitPrice() *Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *ItemAvailabilityResult) GetReplacementSku() string {
+	if x != nil {
+		return x.ReplacementSku
+	}
+	return ""
+}
+
+func (x *ItemAvailabilityResult) GetSubstituted() bool {
+	if x != nil {
+		return x.Substituted
+	}
+	return false
+}
+
+// ReserveItemsRequest creates reservations for order items
+type ReserveItemsRequest struct {
+	state                      protoimpl.MessageState    `protogen:"open.v1"`
+	OrderId                    string                    `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                                                             // Order identifier
+	Items                      []*ItemReservationRequest `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`                                                                                // Items to reserve
+	ReservationDurationMinutes int32                     `protobuf:"varint,3,opt,name=reservation_duration_minutes,json=reservationDurationMinutes,proto3" json:"reservation_duration_minutes,omitempty"` // How long to hold reservations
+	Priority                   ReservationPriority       `protobuf:"varint,4,opt,name=priority,proto3,enum=inventory.v1.ReservationPriority" json:"priority,omitempty"`                                   // Expedited orders may preempt standard reservations
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *ReserveItemsRequest) Reset() {
+	*x = ReserveItemsRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveItemsRequest) ProtoMessage() {}
+
+func (x *ReserveItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveItemsRequest.ProtoReflect.Descriptor instead.
+func (*ReserveItemsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReserveItemsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReserveItemsRequest) GetItems() []*ItemReservationRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReserveItemsRequest) GetReservationDurationMinutes() int32 {
+	if x != nil {
+		return x.ReservationDurationMinutes
+	}
+	return 0
+}
+
+func (x *ReserveItemsRequest) GetPriority() ReservationPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return ReservationPriority_RESERVATION_PRIORITY_STANDARD
+}
+
+// ItemReservationRequest represents a single item reservation.
+// Set decimal_quantity for items not stocked in whole pieces; it takes precedence over quantity.
+type ItemReservationRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Sku             string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`                                                  // Item SKU
+	Quantity        int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`                                       // Quantity to reserve, in whole units
+	DecimalQuantity float64                `protobuf:"fixed64,3,opt,name=decimal_quantity,json=decimalQuantity,proto3" json:"decimal_quantity,omitempty"` // Quantity to reserve, may be fractional
+	Unit            string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`                                                // Unit of the quantity; empty means the item's unit
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ItemReservationRequest) Reset() {
+	*x = ItemReservationRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemReservationRequest) ProtoMessage() {}
+
+func (x *ItemReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemReservationRequest.ProtoReflect.Descriptor instead.
+func (*ItemReservationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ItemReservationRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ItemReservationRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ItemReservationRequest) GetDecimalQuantity() float64 {
+	if x != nil {
+		return x.DecimalQuantity
+	}
+	return 0
+}
+
+func (x *ItemReservationRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// ReserveItemsResponse contains reservation results
+type ReserveItemsResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Success       bool                     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                                 // Whether all reservations succeeded
+	ReservationId string                   `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // Unique reservation identifier
+	Results       []*ItemReservationResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`                                  // Per-item reservation results
+	ExpiresAt     *timestamppb.Timestamp   `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`             // When reservations expire
+	Message       string                   `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`                                  // Result message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveItemsResponse) Reset() {
+	*x = ReserveItemsResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveItemsResponse) ProtoMessage() {}
+
+func (x *ReserveItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveItemsResponse.ProtoReflect.Descriptor instead.
+func (*ReserveItemsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReserveItemsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReserveItemsResponse) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReserveItemsResponse) GetResults() []*ItemReservationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ReserveItemsResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ReserveItemsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ItemReservationResult contains reservation info for a single item
+type ItemReservationResult struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Sku             string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`                                                  // Item SKU
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                // Item name
+	Reserved        bool                   `protobuf:"varint,3,opt,name=reserved,proto3" json:"reserved,omitempty"`                                       // Whether reservation succeeded
+	Quantity        int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`                                       // Quantity reserved
+	ReservationId   string                 `protobuf:"bytes,5,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`         // Individual reservation ID
+	Reason          string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`                                            // Reason if reservation failed
+	Bundle          bool                   `protobuf:"varint,7,opt,name=bundle,proto3" json:"bundle,omitempty"`                                           // True for a kit line reserved through its component items
+	DecimalQuantity float64                `protobuf:"fixed64,8,opt,name=decimal_quantity,json=decimalQuantity,proto3" json:"decimal_quantity,omitempty"` // Quantity reserved, not rounded down like quantity
+	Unit            string                 `protobuf:"bytes,9,opt,name=unit,proto3" json:"unit,omitempty"`                                                // Unit of the quantity, the item's unit
+	ReplacementSku  string                 `protobuf:"bytes,10,opt,name=replacement_sku,json=replacementSku,proto3" json:"replacement_sku,omitempty"`     // Replacement of a discontinued item, if any
+	Substituted     bool                   `protobuf:"varint,11,opt,name=substituted,proto3" json:"substituted,omitempty"`                                // The replacement was reserved in place of the item
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ItemReservationResult) Reset() {
+	*x = ItemReservationResult{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemReservationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemReservationResult) ProtoMessage() {}
+
+func (x *ItemReservationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemReservationResult.ProtoReflect.Descriptor instead.
+func (*ItemReservationResult) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ItemReservationResult) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ItemReservationResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ItemReservationResult) GetReserved() bool {
+	if x != nil {
+		return x.Reserved
+	}
+	return false
+}
+
+func (x *ItemReservationResult) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ItemReservationResult) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ItemReservationResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ItemReservationResult) GetBundle() bool {
+	if x != nil {
+		return x.Bundle
+	}
+	return false
+}
+
+func (x *ItemReservationResult) GetDecimalQuantity() float64 {
+	if x != nil {
+		return x.DecimalQuantity
+	}
+	return 0
+}
+
+func (x *ItemReservationResult) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *ItemReservationResult) GetReplacementSku() string {
+	if x != nil {
+		return x.ReplacementSku
+	}
+	return ""
+}
+
+func (x *ItemReservationResult) GetSubstituted() bool {
+	if x != nil {
+		return x.Substituted
+	}
+	return false
+}
+
+// ConfirmReservationRequest confirms reserved items
+type ConfirmReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                   // Order identifier
+	ReservationId string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // Reservation to confirm
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmReservationRequest) Reset() {
+	*x = ConfirmReservationRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmReservationRequest) ProtoMessage() {}
+
+func (x *ConfirmReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmReservationRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmReservationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ConfirmReservationRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ConfirmReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+// ConfirmReservationResponse contains confirmation result
+type ConfirmReservationResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Success       bool                      `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                           // Whether confirmation succeeded
+	Results       []*ItemConfirmationResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`                            // Per-item confirmation results
+	ConfirmedAt   *timestamppb.Timestamp    `protobuf:"bytes,3,opt,name=confirmed_at,json=confirmedAt,proto3" json:"confirmed_at,omitempty"` // When confirmed
+	Message       string                    `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                            // Result message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmReservationResponse) Reset() {
+	*x = ConfirmReservationResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmReservationResponse) ProtoMessage() {}
+
+func (x *ConfirmReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmReservationResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmReservationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConfirmReservationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfirmReservationResponse) GetResults() []*ItemConfirmationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ConfirmReservationResponse) GetConfirmedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConfirmedAt
+	}
+	return nil
+}
+
+func (x *ConfirmReservationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ItemConfirmationResult contains confirmation info for a single item
+type ItemConfirmationResult struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Sku             string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`                                                  // Item SKU
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                // Item name
+	Confirmed       bool                   `protobuf:"varint,3,opt,name=confirmed,proto3" json:"confirmed,omitempty"`                                     // Whether confirmation succeeded
+	Quantity        int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`                                       // Quantity confirmed
+	Reason          string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`                                            // Reason if confirmation failed
+	SerialNumbers   []string               `protobuf:"bytes,6,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`         // Serial numbers allocated to the order (serial-tracked items only)
+	DecimalQuantity float64                `protobuf:"fixed64,7,opt,name=decimal_quantity,json=decimalQuantity,proto3" json:"decimal_quantity,omitempty"` // Quantity confirmed, not rounded down like quantity
+	Unit            string                 `protobuf:"bytes,8,opt,name=unit,proto3" json:"unit,omitempty"`                                                // Unit of the quantity, the item's unit
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ItemConfirmationResult) Reset() {
+	*x = ItemConfirmationResult{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemConfirmationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemConfirmationResult) ProtoMessage() {}
+
+func (x *ItemConfirmationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemConfirmationResult.ProtoReflect.Descriptor instead.
+func (*ItemConfirmationResult) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ItemConfirmationResult) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ItemConfirmationResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ItemConfirmationResult) GetConfirmed() bool {
+	if x != nil {
+		return x.Confirmed
+	}
+	return false
+}
+
+func (x *ItemConfirmationResult) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ItemConfirmationResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ItemConfirmationResult) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *ItemConfirmationResult) GetDecimalQuantity() float64 {
+	if x != nil {
+		return x.DecimalQuantity
+	}
+	return 0
+}
+
+func (x *ItemConfirmationResult) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// ReleaseReservationRequest releases reserved items
+type ReleaseReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                   // Order identifier
+	ReservationId string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // Reservation to release
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                    // Reason for release
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReleaseReservationRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReleaseReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReleaseReservationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ReleaseReservationResponse contains release result
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                        // Whether release succeeded
+	Results       []*ItemReleaseResult   `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`                         // Per-item release results
+	ReleasedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=released_at,json=releasedAt,proto3" json:"released_at,omitempty"` // When released
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                         // Result message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReleaseReservationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReleaseReservationResponse) GetResults() []*ItemReleaseResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ReleaseReservationResponse) GetReleasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleasedAt
+	}
+	return nil
+}
+
+func (x *ReleaseReservationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// CreateBackorderRequest backorders out-of-stock items of an order
+type CreateBackorderRequest struct {
+	state                      protoimpl.MessageState    `protogen:"open.v1"`
+	OrderId                    string                    `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                                                             // Order identifier
+	ShipmentId                 string                    `protobuf:"bytes,2,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`                                                    // Backordered shipment of a split order; empty for the whole order
+	Items                      []*ItemReservationRequest `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`                                                                                // Items to reserve once in stock
+	ReservationDurationMinutes int32                     `protobuf:"varint,4,opt,name=reservation_duration_minutes,json=reservationDurationMinutes,proto3" json:"reservation_duration_minutes,omitempty"` // How long to hold the reservation once made
+	Priority                   ReservationPriority       `protobuf:"varint,5,opt,name=priority,proto3,enum=inventory.v1.ReservationPriority" json:"priority,omitempty"`                                   // Priority of the reservation once made
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *CreateBackorderRequest) Reset() {
+	*x = CreateBackorderRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBackorderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBackorderRequest) ProtoMessage() {}
+
+func (x *CreateBackorderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBackorderRequest.ProtoReflect.Descriptor instead.
+func (*CreateBackorderRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateBackorderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateBackorderRequest) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+func (x *CreateBackorderRequest) GetItems() []*ItemReservationRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateBackorderRequest) GetReservationDurationMinutes() int32 {
+	if x != nil {
+		return x.ReservationDurationMinutes
+	}
+	return 0
+}
+
+func (x *CreateBackorderRequest) GetPriority() ReservationPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return ReservationPriority_RESERVATION_PRIORITY_STANDARD
+}
+
+// CreateBackorderResponse contains the created backorder
+type CreateBackorderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backorder     *Backorder             `protobuf:"bytes,1,opt,name=backorder,proto3" json:"backorder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBackorderResponse) Reset() {
+	*x = CreateBackorderResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBackorderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBackorderResponse) ProtoMessage() {}
+
+func (x *CreateBackorderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBackorderResponse.ProtoReflect.Descriptor instead.
+func (*CreateBackorderResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateBackorderResponse) GetBackorder() *Backorder {
+	if x != nil {
+		return x.Backorder
+	}
+	return nil
+}
+
+// CancelBackorderRequest cancels the open backorder of an order or shipment
+type CancelBackorderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`          // Order identifier
+	ShipmentId    string                 `protobuf:"bytes,2,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"` // Backordered shipment of a split order; empty for the whole order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBackorderRequest) Reset() {
+	*x = CancelBackorderRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBackorderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBackorderRequest) ProtoMessage() {}
+
+func (x *CancelBackorderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBackorderRequest.ProtoReflect.Descriptor instead.
+func (*CancelBackorderRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelBackorderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelBackorderRequest) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+// CancelBackorderResponse contains the cancelled backorder
+type CancelBackorderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backorder     *Backorder             `protobuf:"bytes,1,opt,name=backorder,proto3" json:"backorder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBackorderResponse) Reset() {
+	*x = CancelBackorderResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBackorderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBackorderResponse) ProtoMessage() {}
+
+func (x *CancelBackorderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBackorderResponse.ProtoReflect.Descriptor instead.
+func (*CancelBackorderResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CancelBackorderResponse) GetBackorder() *Backorder {
+	if x != nil {
+		return x.Backorder
+	}
+	return nil
+}
+
+// Backorder is an order's demand for items that were out of stock
+type Backorder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ShipmentId    string                 `protobuf:"bytes,3,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	Status        BackorderStatus        `protobuf:"varint,4,opt,name=status,proto3,enum=inventory.v1.BackorderStatus" json:"status,omitempty"`
+	Items         []*BackorderItem       `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	ReservationId string                 `protobuf:"bytes,6,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // Set once reserved
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReservedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=reserved_at,json=reservedAt,proto3" json:"reserved_at,omitempty"` // Set once reserved
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Backorder) Reset() {
+	*x = Backorder{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Backorder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backorder) ProtoMessage() {}
+
+func (x *Backorder) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backorder.ProtoReflect.Descriptor instead.
+func (*Backorder) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Backorder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Backorder) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Backorder) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+func (x *Backorder) GetStatus() BackorderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BackorderStatus_BACKORDER_STATUS_UNSPECIFIED
+}
+
+func (x *Backorder) GetItems() []*BackorderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Backorder) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *Backorder) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Backorder) GetReservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReservedAt
+	}
+	return nil
+}
+
+// BackorderItem is the quantity of one item a backorder waits for
+type BackorderItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Unit          string                 `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"` // Unit of the quantity; empty means the item's unit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackorderItem) Reset() {
+	*x = BackorderItem{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackorderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackorderItem) ProtoMessage() {}
+
+func (x *BackorderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackorderItem.ProtoReflect.Descriptor instead.
+func (*BackorderItem) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BackorderItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *BackorderItem) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *BackorderItem) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// ItemReleaseResult contains release info for a single item
+type ItemReleaseResult struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Sku             string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`                                                  // Item SKU
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                // Item name
+	Released        bool                   `protobuf:"varint,3,opt,name=released,proto3" json:"released,omitempty"`                                       // Whether release succeeded
+	Quantity        int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`                                       // Quantity released back to stock
+	Reason          string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`                                            // Reason if release failed
+	DecimalQuantity float64                `protobuf:"fixed64,6,opt,name=decimal_quantity,json=decimalQuantity,proto3" json:"decimal_quantity,omitempty"` // Quantity released, not rounded down like quantity
+	Unit            string                 `protobuf:"bytes,7,opt,name=unit,proto3" json:"unit,omitempty"`                                                // Unit of the quantity, the item's unit
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ItemReleaseResult) Reset() {
+	*x = ItemReleaseResult{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemReleaseResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemReleaseResult) ProtoMessage() {}
+
+func (x *ItemReleaseResult) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemReleaseResult.ProtoReflect.Descriptor instead.
+func (*ItemReleaseResult) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ItemReleaseResult) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ItemReleaseResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ItemReleaseResult) GetReleased() bool {
+	if x != nil {
+		return x.Released
+	}
+	return false
+}
+
+func (x *ItemReleaseResult) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ItemReleaseResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ItemReleaseResult) GetDecimalQuantity() float64 {
+	if x != nil {
+		return x.DecimalQuantity
+	}
+	return 0
+}
+
+func (x *ItemReleaseResult) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// GetItemRequest retrieves a specific item
+type GetItemRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Identifier:
+	//
+	//	*GetItemRequest_ItemId
+	//	*GetItemRequest_Sku
+	Identifier      isGetItemRequest_Identifier `protobuf_oneof:"identifier"`
+	DisplayCurrency string                      `protobuf:"bytes,3,opt,name=display_currency,json=displayCurrency,proto3" json:"display_currency,omitempty"` // Also price the item in this ISO 4217 currency (optional)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetItemRequest) Reset() {
+	*x = GetItemRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemRequest) ProtoMessage() {}
+
+func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemRequest.ProtoReflect.Descriptor instead.
+func (*GetItemRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetItemRequest) GetIdentifier() isGetItemRequest_Identifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetItemRequest) GetItemId() string {
+	if x != nil {
+		if x, ok := x.Identifier.(*GetItemRequest_ItemId); ok {
+			return x.ItemId
+		}
+	}
+	return ""
+}
+
+func (x *GetItemRequest) GetSku() string {
+	if x != nil {
+		if x, ok := x.Identifier.(*GetItemRequest_Sku); ok {
+			return x.Sku
+		}
+	}
+	return ""
+}
+
+func (x *GetItemRequest) GetDisplayCurrency() string {
+	if x != nil {
+		return x.DisplayCurrency
+	}
+	return ""
+}
+
+type isGetItemRequest_Identifier interface {
+	isGetItemRequest_Identifier()
+}
+
+type GetItemRequest_ItemId struct {
+	ItemId string `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3,oneof"` // Get by item ID
+}
+
+type GetItemRequest_Sku struct {
+	Sku string `protobuf:"bytes,2,opt,name=sku,proto3,oneof"` // Get by SKU
+}
+
+func (*GetItemRequest_ItemId) isGetItemRequest_Identifier() {}
+
+func (*GetItemRequest_Sku) isGetItemRequest_Identifier() {}
+
+// GetItemResponse contains item details
+type GetItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`                                  // Whether item was found
+	Item          *InventoryItem         `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`                                     // Item details
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                               // Result message
+	DisplayRates  []*v1.ExchangeRate     `protobuf:"bytes,4,rep,name=display_rates,json=displayRates,proto3" json:"display_rates,omitempty"` // Rates used for display prices, one per price currency
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemResponse) Reset() {
+	*x = GetItemResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemResponse) ProtoMessage() {}
+
+func (x *GetItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemResponse.ProtoReflect.Descriptor instead.
+func (*GetItemResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetItemResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetItemResponse) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *GetItemResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetItemResponse) GetDisplayRates() []*v1.ExchangeRate {
+	if x != nil {
+		return x.DisplayRates
+	}
+	return nil
+}
+
+// SearchItemsRequest searches for items
+type SearchItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                                       // Search query (name, description, SKU)
+	Category      ItemCategory           `protobuf:"varint,2,opt,name=category,proto3,enum=inventory.v1.ItemCategory" json:"category,omitempty"` // Filter by category (optional)
+	AvailableOnly bool                   `protobuf:"varint,3,opt,name=available_only,json=availableOnly,proto3" json:"available_only,omitempty"` // Only return items with stock
+	// Deprecated: Marked as deprecated in inventory/v1/inventory.proto.
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // Maximum results to return; use page
+	// Deprecated: Marked as deprecated in inventory/v1/inventory.proto.
+	Offset          int32            `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`                                         // Pagination offset; use page
+	Page            *v11.PageRequest `protobuf:"bytes,6,opt,name=page,proto3" json:"page,omitempty"`                                              // Takes precedence over limit and offset
+	DisplayCurrency string           `protobuf:"bytes,7,opt,name=display_currency,json=displayCurrency,proto3" json:"display_currency,omitempty"` // Also price the items in this ISO 4217 currency (optional)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SearchItemsRequest) Reset() {
+	*x = SearchItemsRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchItemsRequest) ProtoMessage() {}
+
+func (x *SearchItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchItemsRequest.ProtoReflect.Descriptor instead.
+func (*SearchItemsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SearchItemsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchItemsRequest) GetCategory() ItemCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ItemCategory_ITEM_CATEGORY_UNSPECIFIED
+}
+
+func (x *SearchItemsRequest) GetAvailableOnly() bool {
+	if x != nil {
+		return x.AvailableOnly
+	}
+	return false
+}
+
+// Deprecated: Marked as deprecated in inventory/v1/inventory.proto.
+func (x *SearchItemsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in inventory/v1/inventory.proto.
+func (x *SearchItemsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchItemsRequest) GetPage() *v11.PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *SearchItemsRequest) GetDisplayCurrency() string {
+	if x != nil {
+		return x.DisplayCurrency
+	}
+	return ""
+}
+
+// SearchItemsResponse contains search results
+type SearchItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*InventoryItem       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`                                   // Found items
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`      // Total items matching criteria
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`               // Whether more results exist
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                               // Result message
+	PageInfo      *v11.PageInfo          `protobuf:"bytes,5,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`             // Items are sorted by SKU
+	DisplayRates  []*v1.ExchangeRate     `protobuf:"bytes,6,rep,name=display_rates,json=displayRates,proto3" json:"display_rates,omitempty"` // Rates used for display prices, one per price currency
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchItemsResponse) Reset() {
+	*x = SearchItemsResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchItemsResponse) ProtoMessage() {}
+
+func (x *SearchItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchItemsResponse.ProtoReflect.Descriptor instead.
+func (*SearchItemsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SearchItemsResponse) GetItems() []*InventoryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *SearchItemsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SearchItemsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *SearchItemsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SearchItemsResponse) GetPageInfo() *v11.PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+func (x *SearchItemsResponse) GetDisplayRates() []*v1.ExchangeRate {
+	if x != nil {
+		return x.DisplayRates
+	}
+	return nil
+}
+
+// GetLowStockItemsRequest retrieves items below threshold
+type GetLowStockItemsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Category          ItemCategory           `protobuf:"varint,1,opt,name=category,proto3,enum=inventory.v1.ItemCategory" json:"category,omitempty"`             // Filter by category (optional)
+	ThresholdOverride int32                  `protobuf:"varint,2,opt,name=threshold_override,json=thresholdOverride,proto3" json:"threshold_override,omitempty"` // Override default threshold
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetLowStockItemsRequest) Reset() {
+	*x = GetLowStockItemsRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLowStockItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLowStockItemsRequest) ProtoMessage() {}
+
+func (x *GetLowStockItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLowStockItemsRequest.ProtoReflect.Descriptor instead.
+func (*GetLowStockItemsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetLowStockItemsRequest) GetCategory() ItemCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ItemCategory_ITEM_CATEGORY_UNSPECIFIED
+}
+
+func (x *GetLowStockItemsRequest) GetThresholdOverride() int32 {
+	if x != nil {
+		return x.ThresholdOverride
+	}
+	return 0
+}
+
+// GetLowStockItemsResponse contains low stock items
+type GetLowStockItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*LowStockItem        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`                              // Items below threshold
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Total low stock items
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                          // Result message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLowStockItemsResponse) Reset() {
+	*x = GetLowStockItemsResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLowStockItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLowStockItemsResponse) ProtoMessage() {}
+
+func (x *GetLowStockItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLowStockItemsResponse.ProtoReflect.Descriptor instead.
+func (*GetLowStockItemsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetLowStockItemsResponse) GetItems() []*LowStockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetLowStockItemsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetLowStockItemsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// LowStockItem represents an item below stock threshold
+type LowStockItem struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Item                    *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`                                                                          // Item details
+	ShortageQuantity        int32                  `protobuf:"varint,2,opt,name=shortage_quantity,json=shortageQuantity,proto3" json:"shortage_quantity,omitempty"`                         // How much below minimum
+	DaysOfStock             int32                  `protobuf:"varint,3,opt,name=days_of_stock,json=daysOfStock,proto3" json:"days_of_stock,omitempty"`                                      // Estimated days until out of stock
+	IncomingQuantity        int32                  `protobuf:"varint,4,opt,name=incoming_quantity,json=incomingQuantity,proto3" json:"incoming_quantity,omitempty"`                         // Quantity on purchase orders not yet received
+	ExpectedArrival         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expected_arrival,json=expectedArrival,proto3" json:"expected_arrival,omitempty"`                             // Earliest expected arrival of incoming stock
+	DecimalShortageQuantity float64                `protobuf:"fixed64,6,opt,name=decimal_shortage_quantity,json=decimalShortageQuantity,proto3" json:"decimal_shortage_quantity,omitempty"` // How much below minimum, not rounded down like shortage_quantity
+	RestockQuantity         float64                `protobuf:"fixed64,7,opt,name=restock_quantity,json=restockQuantity,proto3" json:"restock_quantity,omitempty"`                           // Quantity to reorder, per the category stocking policy and the supplier's minimum order
+	Supplier                string                 `protobuf:"bytes,8,opt,name=supplier,proto3" json:"supplier,omitempty"`                                                                  // Supplier to reorder from; empty when the item has none
+	SupplierSku             string                 `protobuf:"bytes,9,opt,name=supplier_sku,json=supplierSku,proto3" json:"supplier_sku,omitempty"`                                         // The supplier's code for the item, if it has its own
+	LeadTimeDays            int32                  `protobuf:"varint,10,opt,name=lead_time_days,json=leadTimeDays,proto3" json:"lead_time_days,omitempty"`                                  // The supplier's time from ordering to delivery
+	ExpectedRestockAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=expected_restock_at,json=expectedRestockAt,proto3" json:"expected_restock_at,omitempty"`                    // When stock reordered now is expected; unset without a supplier
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *LowStockItem) Reset() {
+	*x = LowStockItem{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LowStockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LowStockItem) ProtoMessage() {}
+
+func (x *LowStockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LowStockItem.ProtoReflect.Descriptor instead.
+func (*LowStockItem) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LowStockItem) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *LowStockItem) GetShortageQuantity() int32 {
+	if x != nil {
+		return x.ShortageQuantity
+	}
+	return 0
+}
+
+func (x *LowStockItem) GetDaysOfStock() int32 {
+	if x != nil {
+		return x.DaysOfStock
+	}
+	return 0
+}
+
+func (x *LowStockItem) GetIncomingQuantity() int32 {
+	if x != nil {
+		return x.IncomingQuantity
+	}
+	return 0
+}
+
+func (x *LowStockItem) GetExpectedArrival() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpectedArrival
+	}
+	return nil
+}
+
+func (x *LowStockItem) GetDecimalShortageQuantity() float64 {
+	if x != nil {
+		return x.DecimalShortageQuantity
+	}
+	return 0
+}
+
+func (x *LowStockItem) GetRestockQuantity() float64 {
+	if x != nil {
+		return x.RestockQuantity
+	}
+	return 0
+}
+
+func (x *LowStockItem) GetSupplier() string {
+	if x != nil {
+		return x.Supplier
+	}
+	return ""
+}
+
+func (x *LowStockItem) GetSupplierSku() string {
+	if x != nil {
+		return x.SupplierSku
+	}
+	return ""
+}
+
+func (x *LowStockItem) GetLeadTimeDays() int32 {
+	if x != nil {
+		return x.LeadTimeDays
+	}
+	return 0
+}
+
+func (x *LowStockItem) GetExpectedRestockAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpectedRestockAt
+	}
+	return nil
+}
+
+// UpdateStockRequest adds or removes stock.
+// Set decimal_quantity_change for fractional changes; it takes precedence over quantity_change.
+type UpdateStockRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Sku                   string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`                                                                      // Item SKU
+	QuantityChange        int32                  `protobuf:"varint,2,opt,name=quantity_change,json=quantityChange,proto3" json:"quantity_change,omitempty"`                         // Positive to add, negative to remove
+	Reason                string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                                                // Reason for stock change
+	UpdatedBy             string                 `protobuf:"bytes,4,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`                                         // Who made the change
+	DecimalQuantityChange float64                `protobuf:"fixed64,5,opt,name=decimal_quantity_change,json=decimalQuantityChange,proto3" json:"decimal_quantity_change,omitempty"` // Positive to add, negative to remove; may be fractional
+	Unit                  string                 `protobuf:"bytes,6,opt,name=unit,proto3" json:"unit,omitempty"`                                                                    // Unit of the change; empty means the item's unit
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *UpdateStockRequest) Reset() {
+	*x = UpdateStockRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStockRequest) ProtoMessage() {}
+
+func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStockRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStockRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateStockRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetQuantityChange() int32 {
+	if x != nil {
+		return x.QuantityChange
+	}
+	return 0
+}
+
+func (x *UpdateStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetDecimalQuantityChange() float64 {
+	if x != nil {
+		return x.DecimalQuantityChange
+	}
+	return 0
+}
+
+func (x *UpdateStockRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// UpdateStockResponse contains stock update result
+type UpdateStockResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Success              bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                                                            // Whether update succeeded
+	OldStockLevel        int32                  `protobuf:"varint,2,opt,name=old_stock_level,json=oldStockLevel,proto3" json:"old_stock_level,omitempty"`                         // Stock level before update
+	NewStockLevel        int32                  `protobuf:"varint,3,opt,name=new_stock_level,json=newStockLevel,proto3" json:"new_stock_level,omitempty"`                         // Stock level after update
+	UpdatedAt            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                                        // When updated
+	Message              string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`                                                             // Result message
+	DecimalOldStockLevel float64                `protobuf:"fixed64,6,opt,name=decimal_old_stock_level,json=decimalOldStockLevel,proto3" json:"decimal_old_stock_level,omitempty"` // Stock level before update, not rounded down
+	DecimalNewStockLevel float64                `protobuf:"fixed64,7,opt,name=decimal_new_stock_level,json=decimalNewStockLevel,proto3" json:"decimal_new_stock_level,omitempty"` // Stock level after update, not rounded down
+	Unit                 string                 `protobuf:"bytes,8,opt,name=unit,proto3" json:"unit,omitempty"`                                                                   // Unit of the stock levels, the item's unit
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UpdateStockResponse) Reset() {
+	*x = UpdateStockResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStockResponse) ProtoMessage() {}
+
+func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStockResponse.ProtoReflect.Descriptor instead.
+func (*UpdateStockResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateStockResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateStockResponse) GetOldStockLevel() int32 {
+	if x != nil {
+		return x.OldStockLevel
+	}
+	return 0
+}
+
+func (x *UpdateStockResponse) GetNewStockLevel() int32 {
+	if x != nil {
+		return x.NewStockLevel
+	}
+	return 0
+}
+
+func (x *UpdateStockResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *UpdateStockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UpdateStockResponse) GetDecimalOldStockLevel() float64 {
+	if x != nil {
+		return x.DecimalOldStockLevel
+	}
+	return 0
+}
+
+func (x *UpdateStockResponse) GetDecimalNewStockLevel() float64 {
+	if x != nil {
+		return x.DecimalNewStockLevel
+	}
+	return 0
+}
+
+func (x *UpdateStockResponse) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// GetItemsByCategoryRequest retrieves items by category
+type GetItemsByCategoryRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Category        ItemCategory           `protobuf:"varint,1,opt,name=category,proto3,enum=inventory.v1.ItemCategory" json:"category,omitempty"`      // Category to retrieve
+	AvailableOnly   bool                   `protobuf:"varint,2,opt,name=available_only,json=availableOnly,proto3" json:"available_only,omitempty"`      // Only return items with stock
+	Limit           int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                           // Maximum results to return
+	Offset          int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`                                         // Pagination offset
+	DisplayCurrency string                 `protobuf:"bytes,5,opt,name=display_currency,json=displayCurrency,proto3" json:"display_currency,omitempty"` // Also price the items in this ISO 4217 currency (optional)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetItemsByCategoryRequest) Reset() {
+	*x = GetItemsByCategoryRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemsByCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemsByCategoryRequest) ProtoMessage() {}
+
+func (x *GetItemsByCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemsByCategoryRequest.ProtoReflect.Descriptor instead.
+func (*GetItemsByCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetItemsByCategoryRequest) GetCategory() ItemCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ItemCategory_ITEM_CATEGORY_UNSPECIFIED
+}
+
+func (x *GetItemsByCategoryRequest) GetAvailableOnly() bool {
+	if x != nil {
+		return x.AvailableOnly
+	}
+	return false
+}
+
+func (x *GetItemsByCategoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetItemsByCategoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetItemsByCategoryRequest) GetDisplayCurrency() string {
+	if x != nil {
+		return x.DisplayCurrency
+	}
+	return ""
+}
+
+// GetItemsByCategoryResponse contains category items
+type GetItemsByCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*InventoryItem       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`                                   // Items in category
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`      // Total items in category
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`               // Whether more results exist
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                               // Result message
+	DisplayRates  []*v1.ExchangeRate     `protobuf:"bytes,5,rep,name=display_rates,json=displayRates,proto3" json:"display_rates,omitempty"` // Rates used for display prices, one per price currency
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemsByCategoryResponse) Reset() {
+	*x = GetItemsByCategoryResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemsByCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemsByCategoryResponse) ProtoMessage() {}
+
+func (x *GetItemsByCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemsByCategoryResponse.ProtoReflect.Descriptor instead.
+func (*GetItemsByCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetItemsByCategoryResponse) GetItems() []*InventoryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetItemsByCategoryResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetItemsByCategoryResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *GetItemsByCategoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetItemsByCategoryResponse) GetDisplayRates() []*v1.ExchangeRate {
+	if x != nil {
+		return x.DisplayRates
+	}
+	return nil
+}
+
+// GetAvailabilitySummaryRequest requests the availability summary of all categories
+type GetAvailabilitySummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailabilitySummaryRequest) Reset() {
+	*x = GetAvailabilitySummaryRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailabilitySummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailabilitySummaryRequest) ProtoMessage() {}
+
+func (x *GetAvailabilitySummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailabilitySummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetAvailabilitySummaryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{31}
+}
+
+// GetAvailabilitySummaryResponse contains one summary per category that has items
+type GetAvailabilitySummaryResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Categories    []*CategoryAvailability `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp  `protobuf:"bytes,2,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailabilitySummaryResponse) Reset() {
+	*x = GetAvailabilitySummaryResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailabilitySummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailabilitySummaryResponse) ProtoMessage() {}
+
+func (x *GetAvailabilitySummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailabilitySummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailabilitySummaryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetAvailabilitySummaryResponse) GetCategories() []*CategoryAvailability {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *GetAvailabilitySummaryResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+// CategoryAvailability summarizes the stock of a category. Every item counts in exactly
+// one of the in-stock, low-stock and out-of-stock buckets; discontinued items are left out.
+type CategoryAvailability struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Category         ItemCategory           `protobuf:"varint,1,opt,name=category,proto3,enum=inventory.v1.ItemCategory" json:"category,omitempty"`
+	ItemCount        int32                  `protobuf:"varint,2,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`                       // Items in the category
+	InStockCount     int32                  `protobuf:"varint,3,opt,name=in_stock_count,json=inStockCount,proto3" json:"in_stock_count,omitempty"`            // Items with available stock above the minimum level
+	LowStockCount    int32                  `protobuf:"varint,4,opt,name=low_stock_count,json=lowStockCount,proto3" json:"low_stock_count,omitempty"`         // Items with some available stock, at or below the minimum level
+	OutOfStockCount  int32                  `protobuf:"varint,5,opt,name=out_of_stock_count,json=outOfStockCount,proto3" json:"out_of_stock_count,omitempty"` // Items with no available stock
+	ReservedQuantity float64                `protobuf:"fixed64,6,opt,name=reserved_quantity,json=reservedQuantity,proto3" json:"reserved_quantity,omitempty"` // Stock held by active reservations
+	Valuation        []*Money               `protobuf:"bytes,7,rep,name=valuation,proto3" json:"valuation,omitempty"`                                         // Stock on hand times unit price, one per currency
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CategoryAvailability) Reset() {
+	*x = CategoryAvailability{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryAvailability) ProtoMessage() {}
+
+func (x *CategoryAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryAvailability.ProtoReflect.Descriptor instead.
+func (*CategoryAvailability) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CategoryAvailability) GetCategory() ItemCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ItemCategory_ITEM_CATEGORY_UNSPECIFIED
+}
+
+func (x *CategoryAvailability) GetItemCount() int32 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+func (x *CategoryAvailability) GetInStockCount() int32 {
+	if x != nil {
+		return x.InStockCount
+	}
+	return 0
+}
+
+func (x *CategoryAvailability) GetLowStockCount() int32 {
+	if x != nil {
+		return x.LowStockCount
+	}
+	return 0
+}
+
+func (x *CategoryAvailability) GetOutOfStockCount() int32 {
+	if x != nil {
+		return x.OutOfStockCount
+	}
+	return 0
+}
+
+func (x *CategoryAvailability) GetReservedQuantity() float64 {
+	if x != nil {
+		return x.ReservedQuantity
+	}
+	return 0
+}
+
+func (x *CategoryAvailability) GetValuation() []*Money {
+	if x != nil {
+		return x.Valuation
+	}
+	return nil
+}
+
+// GetInventoryValuationRequest selects the valuation method, the cost of goods sold
+// period and optionally a category
+type GetInventoryValuationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        ValuationMethod        `protobuf:"varint,1,opt,name=method,proto3,enum=inventory.v1.ValuationMethod" json:"method,omitempty"`  // Unspecified means FIFO
+	CogsFrom      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=cogs_from,json=cogsFrom,proto3" json:"cogs_from,omitempty"`                 // Start of the period; unset means the beginning
+	CogsTo        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=cogs_to,json=cogsTo,proto3" json:"cogs_to,omitempty"`                       // End of the period, exclusive; unset means now
+	Category      ItemCategory           `protobuf:"varint,4,opt,name=category,proto3,enum=inventory.v1.ItemCategory" json:"category,omitempty"` // Unspecified means every category
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInventoryValuationRequest) Reset() {
+	*x = GetInventoryValuationRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInventoryValuationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInventoryValuationRequest) ProtoMessage() {}
+
+func (x *GetInventoryValuationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInventoryValuationRequest.ProtoReflect.Descriptor instead.
+func (*GetInventoryValuationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetInventoryValuationRequest) GetMethod() ValuationMethod {
+	if x != nil {
+		return x.Method
+	}
+	return ValuationMethod_VALUATION_METHOD_UNSPECIFIED
+}
+
+func (x *GetInventoryValuationRequest) GetCogsFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CogsFrom
+	}
+	return nil
+}
+
+func (x *GetInventoryValuationRequest) GetCogsTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CogsTo
+	}
+	return nil
+}
+
+func (x *GetInventoryValuationRequest) GetCategory() ItemCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ItemCategory_ITEM_CATEGORY_UNSPECIFIED
+}
+
+// GetInventoryValuationResponse contains one valuation per item and totals per currency
+type GetInventoryValuationResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Method         ValuationMethod        `protobuf:"varint,1,opt,name=method,proto3,enum=inventory.v1.ValuationMethod" json:"method,omitempty"`
+	Items          []*ItemValuation       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	InventoryValue []*Money               `protobuf:"bytes,3,rep,name=inventory_value,json=inventoryValue,proto3" json:"inventory_value,omitempty"` // Stock on hand at cost, one per currency
+	Cogs           []*Money               `protobuf:"bytes,4,rep,name=cogs,proto3" json:"cogs,omitempty"`                                           // Cost of goods sold in the period, one per currency
+	CogsFrom       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=cogs_from,json=cogsFrom,proto3" json:"cogs_from,omitempty"`
+	CogsTo         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=cogs_to,json=cogsTo,proto3" json:"cogs_to,omitempty"`
+	GeneratedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetInventoryValuationResponse) Reset() {
+	*x = GetInventoryValuationResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInventoryValuationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInventoryValuationResponse) ProtoMessage() {}
+
+func (x *GetInventoryValuationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInventoryValuationResponse.ProtoReflect.Descriptor instead.
+func (*GetInventoryValuationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetInventoryValuationResponse) GetMethod() ValuationMethod {
+	if x != nil {
+		return x.Method
+	}
+	return ValuationMethod_VALUATION_METHOD_UNSPECIFIED
+}
+
+func (x *GetInventoryValuationResponse) GetItems() []*ItemValuation {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetInventoryValuationResponse) GetInventoryValue() []*Money {
+	if x != nil {
+		return x.InventoryValue
+	}
+	return nil
+}
+
+func (x *GetInventoryValuationResponse) GetCogs() []*Money {
+	if x != nil {
+		return x.Cogs
+	}
+	return nil
+}
+
+func (x *GetInventoryValuationResponse) GetCogsFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CogsFrom
+	}
+	return nil
+}
+
+func (x *GetInventoryValuationResponse) GetCogsTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CogsTo
+	}
+	return nil
+}
+
+func (x *GetInventoryValuationResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+// ItemValuation values the stock of one item at cost
+type ItemValuation struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Sku              string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Category         ItemCategory           `protobuf:"varint,3,opt,name=category,proto3,enum=inventory.v1.ItemCategory" json:"category,omitempty"`
+	Unit             string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	OnHand           float64                `protobuf:"fixed64,5,opt,name=on_hand,json=onHand,proto3" json:"on_hand,omitempty"`                               // Stock on hand, reserved included
+	CostedQuantity   float64                `protobuf:"fixed64,6,opt,name=costed_quantity,json=costedQuantity,proto3" json:"costed_quantity,omitempty"`       // Stock on hand with a known cost
+	Value            *Money                 `protobuf:"bytes,7,opt,name=value,proto3" json:"value,omitempty"`                                                 // Unset if no stock is costed
+	UncostedQuantity float64                `protobuf:"fixed64,8,opt,name=uncosted_quantity,json=uncostedQuantity,proto3" json:"uncosted_quantity,omitempty"` // Stock on hand without a known cost
+	CogsQuantity     float64                `protobuf:"fixed64,9,opt,name=cogs_quantity,json=cogsQuantity,proto3" json:"cogs_quantity,omitempty"`             // Confirmed for orders in the period
+	Cogs             *Money                 `protobuf:"bytes,10,opt,name=cogs,proto3" json:"cogs,omitempty"`                                                  // Unset if no confirmed stock is costed
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ItemValuation) Reset() {
+	*x = ItemValuation{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemValuation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemValuation) ProtoMessage() {}
+
+func (x *ItemValuation) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemValuation.ProtoReflect.Descriptor instead.
+func (*ItemValuation) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ItemValuation) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ItemValuation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ItemValuation) GetCategory() ItemCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ItemCategory_ITEM_CATEGORY_UNSPECIFIED
+}
+
+func (x *ItemValuation) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *ItemValuation) GetOnHand() float64 {
+	if x != nil {
+		return x.OnHand
+	}
+	return 0
+}
+
+func (x *ItemValuation) GetCostedQuantity() float64 {
+	if x != nil {
+		return x.CostedQuantity
+	}
+	return 0
+}
+
+func (x *ItemValuation) GetValue() *Money {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ItemValuation) GetUncostedQuantity() float64 {
+	if x != nil {
+		return x.UncostedQuantity
+	}
+	return 0
+}
+
+func (x *ItemValuation) GetCogsQuantity() float64 {
+	if x != nil {
+		return x.CogsQuantity
+	}
+	return 0
+}
+
+func (x *ItemValuation) GetCogs() *Money {
+	if x != nil {
+		return x.Cogs
+	}
+	return nil
+}
+
+// GetVersionRequest requests build information of the running service
+type GetVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionRequest) Reset() {
+	*x = GetVersionRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionRequest) ProtoMessage() {}
+
+func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetVersionRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{37}
+}
+
+// GetVersionResponse contains build information used for deployment verification
+type GetVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`                      // Service name
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                      // Release version
+	GitCommit     string                 `protobuf:"bytes,3,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"` // Git commit the binary was built from
+	BuildTime     string                 `protobuf:"bytes,4,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"` // Build timestamp (RFC 3339)
+	GoVersion     string                 `protobuf:"bytes,5,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"` // Go toolchain version
+	Platform      string                 `protobuf:"bytes,6,opt,name=platform,proto3" json:"platform,omitempty"`                    // OS/architecture
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionResponse) Reset() {
+	*x = GetVersionResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionResponse) ProtoMessage() {}
+
+func (x *GetVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetVersionResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetVersionResponse) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetBuildTime() string {
+	if x != nil {
+		return x.BuildTime
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+// GetSerialNumbersRequest looks up serial numbers; exactly one of the fields must be set
+type GetSerialNumbersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SerialNumber  string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"` // Look up a single serial number
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                // Look up all serials allocated to an order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSerialNumbersRequest) Reset() {
+	*x = GetSerialNumbersRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSerialNumbersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSerialNumbersRequest) ProtoMessage() {}
+
+func (x *GetSerialNumbersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSerialNumbersRequest.ProtoReflect.Descriptor instead.
+func (*GetSerialNumbersRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetSerialNumbersRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *GetSerialNumbersRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// GetSerialNumbersResponse contains the matching serial numbers
+type GetSerialNumbersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SerialNumbers []*SerialNumber        `protobuf:"bytes,1,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"` // Matching serial numbers
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                  // Result message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSerialNumbersResponse) Reset() {
+	*x = GetSerialNumbersResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSerialNumbersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSerialNumbersResponse) ProtoMessage() {}
+
+func (x *GetSerialNumbersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSerialNumbersResponse.ProtoReflect.Descriptor instead.
+func (*GetSerialNumbersResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetSerialNumbersResponse) GetSerialNumbers() []*SerialNumber {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *GetSerialNumbersResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// SerialNumber is a single serialized unit of an inventory item
+type SerialNumber struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SerialNumber  string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"` // Serial number
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`                                       // Item SKU
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                                 // available, allocated or returned
+	OrderId       string                 `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                // Order the unit is allocated to, if any
+	History       []*SerialEvent         `protobuf:"bytes,5,rep,name=history,proto3" json:"history,omitempty"`                               // State changes, oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SerialNumber) Reset() {
+	*x = SerialNumber{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SerialNumber) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SerialNumber) ProtoMessage() {}
+
+func (x *SerialNumber) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SerialNumber.ProtoReflect.Descriptor instead.
+func (*SerialNumber) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SerialNumber) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *SerialNumber) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *SerialNumber) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SerialNumber) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SerialNumber) GetHistory() []*SerialEvent {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+// SerialEvent is an entry in a serial number's history
+type SerialEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                           // Status after the change
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`          // Order involved in the change, if any
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`                               // Free-form note
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"` // When the change happened
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SerialEvent) Reset() {
+	*x = SerialEvent{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SerialEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SerialEvent) ProtoMessage() {}
+
+func (x *SerialEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SerialEvent.ProtoReflect.Descriptor instead.
+func (*SerialEvent) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SerialEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SerialEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SerialEvent) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *SerialEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// WatchItemsRequest selects the items to watch
+type WatchItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skus          []string               `protobuf:"bytes,1,rep,name=skus,proto3" json:"skus,omitempty"`                                      // SKUs to watch; empty watches every item
+	SkipSnapshot  bool                   `protobuf:"varint,2,opt,name=skip_snapshot,json=skipSnapshot,proto3" json:"skip_snapshot,omitempty"` // Only send changes, not the current state
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchItemsRequest) Reset() {
+	*x = WatchItemsRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchItemsRequest) ProtoMessage() {}
+
+func (x *WatchItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchItemsRequest.ProtoReflect.Descriptor instead.
+func (*WatchItemsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *WatchItemsRequest) GetSkus() []string {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+func (x *WatchItemsRequest) GetSkipSnapshot() bool {
+	if x != nil {
+		return x.SkipSnapshot
+	}
+	return false
+}
+
+// ItemChange is the stock and price state of an item after a change
+type ItemChange struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Type                 ItemChangeType         `protobuf:"varint,1,opt,name=type,proto3,enum=inventory.v1.ItemChangeType" json:"type,omitempty"`                                // Why the change was sent
+	Sku                  string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`                                                                    // Item SKU
+	StockLevel           int32                  `protobuf:"varint,3,opt,name=stock_level,json=stockLevel,proto3" json:"stock_level,omitempty"`                                   // Available stock
+	ReservedStock        int32                  `protobuf:"varint,4,opt,name=reserved_stock,json=reservedStock,proto3" json:"reserved_stock,omitempty"`                          // Reserved stock
+	UnitPrice            *Money                 `protobuf:"bytes,5,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`                                       // Price per unit
+	Status               ItemStatus             `protobuf:"varint,6,opt,name=status,proto3,enum=inventory.v1.ItemStatus" json:"status,omitempty"`                                // Current status
+	Version              int32                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`                                                           // Item version; changes are sent in increasing order
+	ChangedAt            *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`                                       // When the change happened
+	Unit                 string                 `protobuf:"bytes,9,opt,name=unit,proto3" json:"unit,omitempty"`                                                                  // Unit of the stock levels, the item's unit
+	DecimalStockLevel    float64                `protobuf:"fixed64,10,opt,name=decimal_stock_level,json=decimalStockLevel,proto3" json:"decimal_stock_level,omitempty"`          // Available stock, not rounded down like stock_level
+	DecimalReservedStock float64                `protobuf:"fixed64,11,opt,name=decimal_reserved_stock,json=decimalReservedStock,proto3" json:"decimal_reserved_stock,omitempty"` // Reserved stock, not rounded down like reserved_stock
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ItemChange) Reset() {
+	*x = ItemChange{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemChange) ProtoMessage() {}
+
+func (x *ItemChange) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemChange.ProtoReflect.Descriptor instead.
+func (*ItemChange) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ItemChange) GetType() ItemChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ItemChangeType_ITEM_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *ItemChange) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ItemChange) GetStockLevel() int32 {
+	if x != nil {
+		return x.StockLevel
+	}
+	return 0
+}
+
+func (x *ItemChange) GetReservedStock() int32 {
+	if x != nil {
+		return x.ReservedStock
+	}
+	return 0
+}
+
+func (x *ItemChange) GetUnitPrice() *Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *ItemChange) GetStatus() ItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ItemStatus_ITEM_STATUS_UNSPECIFIED
+}
+
+func (x *ItemChange) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ItemChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *ItemChange) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *ItemChange) GetDecimalStockLevel() float64 {
+	if x != nil {
+		return x.DecimalStockLevel
+	}
+	return 0
+}
+
+func (x *ItemChange) GetDecimalReservedStock() float64 {
+	if x != nil {
+		return x.DecimalReservedStock
+	}
+	return 0
+}
+
+// InventoryItem represents a rocket part in inventory. Stock levels are kept in the item's
+// unit of measure; the int32 levels are rounded down to whole units for older clients.
+type InventoryItem struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                                    // Unique identifier
+	Sku                  string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`                                                                                                  // Stock Keeping Unit
+	Name                 string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                                                                                // Human-readable name
+	Description          string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`                                                                                  // Detailed description
+	Category             ItemCategory           `protobuf:"varint,5,opt,name=category,proto3,enum=inventory.v1.ItemCategory" json:"category,omitempty"`                                                        // Item category
+	StockLevel           int32                  `protobuf:"varint,6,opt,name=stock_level,json=stockLevel,proto3" json:"stock_level,omitempty"`                                                                 // Available stock
+	ReservedStock        int32                  `protobuf:"varint,7,opt,name=reserved_stock,json=reservedStock,proto3" json:"reserved_stock,omitempty"`                                                        // Reserved stock
+	TotalStock           int32                  `protobuf:"varint,8,opt,name=total_stock,json=totalStock,proto3" json:"total_stock,omitempty"`                                                                 // Total stock
+	MinStockLevel        int32                  `protobuf:"varint,9,opt,name=min_stock_level,json=minStockLevel,proto3" json:"min_stock_level,omitempty"`                                                      // Minimum threshold
+	MaxStockLevel        int32                  `protobuf:"varint,10,opt,name=max_stock_level,json=maxStockLevel,proto3" json:"max_stock_level,omitempty"`                                                     // Maximum capacity
+	UnitPrice            *Money                 `protobuf:"bytes,11,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`                                                                    // Price per unit
+	Weight               float64                `protobuf:"fixed64,12,opt,name=weight,proto3" json:"weight,omitempty"`                                                                                         // Weight in kg
+	Dimensions           *Dimensions            `protobuf:"bytes,13,opt,name=dimensions,proto3" json:"dimensions,omitempty"`                                                                                   // Physical dimensions
+	Specifications       map[string]string      `protobuf:"bytes,14,rep,name=specifications,proto3" json:"specifications,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Technical specs
+	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                                                                    // Creation timestamp
+	UpdatedAt            *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                                                                    // Last update timestamp
+	Version              int32                  `protobuf:"varint,17,opt,name=version,proto3" json:"version,omitempty"`                                                                                        // Version for optimistic locking
+	Status               ItemStatus             `protobuf:"varint,18,opt,name=status,proto3,enum=inventory.v1.ItemStatus" json:"status,omitempty"`                                                             // Current status
+	Unit                 string                 `protobuf:"bytes,19,opt,name=unit,proto3" json:"unit,omitempty"`                                                                                               // Unit of measure stock is kept in (ea, kg, l, ...)
+	DecimalStockLevel    float64                `protobuf:"fixed64,20,opt,name=decimal_stock_level,json=decimalStockLevel,proto3" json:"decimal_stock_level,omitempty"`                                        // Available stock
+	DecimalReservedStock float64                `protobuf:"fixed64,21,opt,name=decimal_reserved_stock,json=decimalReservedStock,proto3" json:"decimal_reserved_stock,omitempty"`                               // Reserved stock
+	DecimalTotalStock    float64                `protobuf:"fixed64,22,opt,name=decimal_total_stock,json=decimalTotalStock,proto3" json:"decimal_total_stock,omitempty"`                                        // Total stock
+	DecimalMinStockLevel float64                `protobuf:"fixed64,23,opt,name=decimal_min_stock_level,json=decimalMinStockLevel,proto3" json:"decimal_min_stock_level,omitempty"`                             // Minimum threshold
+	DecimalMaxStockLevel float64                `protobuf:"fixed64,24,opt,name=decimal_max_stock_level,json=decimalMaxStockLevel,proto3" json:"decimal_max_stock_level,omitempty"`                             // Maximum capacity
+	DisplayPrice         *Money                 `protobuf:"bytes,25,opt,name=display_price,json=displayPrice,proto3" json:"display_price,omitempty"`                                                           // unit_price in the requested display currency, if any
+	ReplacementSku       string                 `protobuf:"bytes,26,opt,name=replacement_sku,json=replacementSku,proto3" json:"replacement_sku,omitempty"`                                                     // Item superseding a discontinued one, if any
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *InventoryItem) Reset() {
+	*x = InventoryItem{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryItem) ProtoMessage() {}
+
+func (x *InventoryItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryItem.ProtoReflect.Descriptor instead.
+func (*InventoryItem) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *InventoryItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InventoryItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *InventoryItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InventoryItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *InventoryItem) GetCategory() ItemCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ItemCategory_ITEM_CATEGORY_UNSPECIFIED
+}
+
+func (x *InventoryItem) GetStockLevel() int32 {
+	if x != nil {
+		return x.StockLevel
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetReservedStock() int32 {
+	if x != nil {
+		return x.ReservedStock
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetTotalStock() int32 {
+	if x != nil {
+		return x.TotalStock
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetMinStockLevel() int32 {
+	if x != nil {
+		return x.MinStockLevel
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetMaxStockLevel() int32 {
+	if x != nil {
+		return x.MaxStockLevel
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetUnitPrice() *Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *InventoryItem) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetDimensions() *Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *InventoryItem) GetSpecifications() map[string]string {
+	if x != nil {
+		return x.Specifications
+	}
+	return nil
+}
+
+func (x *InventoryItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *InventoryItem) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *InventoryItem) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetStatus() ItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ItemStatus_ITEM_STATUS_UNSPECIFIED
+}
+
+func (x *InventoryItem) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *InventoryItem) GetDecimalStockLevel() float64 {
+	if x != nil {
+		return x.DecimalStockLevel
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetDecimalReservedStock() float64 {
+	if x != nil {
+		return x.DecimalReservedStock
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetDecimalTotalStock() float64 {
+	if x != nil {
+		return x.DecimalTotalStock
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetDecimalMinStockLevel() float64 {
+	if x != nil {
+		return x.DecimalMinStockLevel
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetDecimalMaxStockLevel() float64 {
+	if x != nil {
+		return x.DecimalMaxStockLevel
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetDisplayPrice() *Money {
+	if x != nil {
+		return x.DisplayPrice
+	}
+	return nil
+}
+
+func (x *InventoryItem) GetReplacementSku() string {
+	if x != nil {
+		return x.ReplacementSku
+	}
+	return ""
+}
+
+// Money represents currency amounts
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`                          // Monetary amount (deprecated: rounded, use minor_units)
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`                        // Currency code (e.g., "USD")
+	MinorUnits    int64                  `protobuf:"varint,3,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"` // Exact amount in the currency's minor unit (e.g., cents)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Money) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Money) GetMinorUnits() int64 {
+	if x != nil {
+		return x.MinorUnits
+	}
+	return 0
+}
+
+// Dimensions represents physical dimensions
+type Dimensions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Length        float64                `protobuf:"fixed64,1,opt,name=length,proto3" json:"length,omitempty"` // Length in meters
+	Width         float64                `protobuf:"fixed64,2,opt,name=width,proto3" json:"width,omitempty"`   // Width in meters
+	Height        float64                `protobuf:"fixed64,3,opt,name=height,proto3" json:"height,omitempty"` // Height in meters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dimensions) Reset() {
+	*x = Dimensions{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dimensions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dimensions) ProtoMessage() {}
+
+func (x *Dimensions) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dimensions.ProtoReflect.Descriptor instead.
+func (*Dimensions) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Dimensions) GetLength() float64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *Dimensions) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Dimensions) GetHeight() float64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+var File_inventory_v1_inventory_proto protoreflect.FileDescriptor
+
+const file_inventory_v1_inventory_proto_rawDesc = "" +
+	"\n" +
+	"\x1cinventory/v1/inventory.proto\x12\finventory.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x14money/v1/money.proto\x1a\x1epagination/v1/pagination.proto\"U\n" +
+	"\x18CheckAvailabilityRequest\x129\n" +
+	"\x05items\x18\x01 \x03(\v2#.inventory.v1.ItemAvailabilityCheckR\x05items\"\x84\x01\n" +
+	"\x15ItemAvailabilityCheck\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12)\n" +
+	"\x10decimal_quantity\x18\x03 \x01(\x01R\x0fdecimalQuantity\x12\x12\n" +
+	"\x04unit\x18\x04 \x01(\tR\x04unit\"\x9a\x01\n" +
+	"\x19CheckAvailabilityResponse\x12#\n" +
+	"\rall_available\x18\x01 \x01(\bR\fallAvailable\x12>\n" +
+	"\aresults\x18\x02 \x03(\v2$.inventory.v1.ItemAvailabilityResultR\aresults\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xe2\x04\n" +
+	"\x16ItemAvailabilityResult\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tavailable\x18\x03 \x01(\bR\tavailable\x12-\n" +
+	"\x12requested_quantity\x18\x04 \x01(\x05R\x11requestedQuantity\x12-\n" +
+	"\x12available_quantity\x18\x05 \x01(\x05R\x11availableQuantity\x12+\n" +
+	"\x11reserved_quantity\x18\x06 \x01(\x05R\x10reservedQuantity\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x16\n" +
+	"\x06bundle\x18\b \x01(\bR\x06bundle\x12\x12\n" +
+	"\x04unit\x18\t \x01(\tR\x04unit\x12<\n" +
+	"\x1adecimal_requested_quantity\x18\n" +
+	" \x01(\x01R\x18decimalRequestedQuantity\x12<\n" +
+	"\x1adecimal_available_quantity\x18\v \x01(\x01R\x18decimalAvailableQuantity\x12:\n" +
+	"\x19decimal_reserved_quantity\x18\f \x01(\x01R\x17decimalReservedQuantity\x122\n" +
+	"\n" +
+	"unit_price\x18\r \x01(\v2\x13.inventory.v1.MoneyR\tunitPrice\x12'\n" +
+	"\x0freplacement_sku\x18\x0e \x01(\tR\x0ereplacementSku\x12 \n" +
+	"\vsubstituted\x18\x0f \x01(\bR\vsubstituted\"\xed\x01\n" +
+	"\x13ReserveItemsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12:\n" +
+	"\x05items\x18\x02 \x03(\v2$.inventory.v1.ItemReservationRequestR\x05items\x12@\n" +
+	"\x1creservation_duration_minutes\x18\x03 \x01(\x05R\x1areservationDurationMinutes\x12=\n" +
+	"\bpriority\x18\x04 \x01(\x0e2!.inventory.v1.ReservationPriorityR\bpriority\"\x85\x01\n" +
+	"\x16ItemReservationRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12)\n" +
+	"\x10decimal_quantity\x18\x03 \x01(\x01R\x0fdecimalQuantity\x12\x12\n" +
+	"\x04unit\x18\x04 \x01(\tR\x04unit\"\xeb\x01\n" +
+	"\x14ReserveItemsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\x12=\n" +
+	"\aresults\x18\x03 \x03(\v2#.inventory.v1.ItemReservationResultR\aresults\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\xd6\x02\n" +
+	"\x15ItemReservationResult\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\breserved\x18\x03 \x01(\bR\breserved\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12%\n" +
+	"\x0ereservation_id\x18\x05 \x01(\tR\rreservationId\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06bundle\x18\a \x01(\bR\x06bundle\x12)\n" +
+	"\x10decimal_quantity\x18\b \x01(\x01R\x0fdecimalQuantity\x12\x12\n" +
+	"\x04unit\x18\t \x01(\tR\x04unit\x12'\n" +
+	"\x0freplacement_sku\x18\n" +
+	" \x01(\tR\x0ereplacementSku\x12 \n" +
+	"\vsubstituted\x18\v \x01(\bR\vsubstituted\"]\n" +
+	"\x19ConfirmReservationRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\"\xcf\x01\n" +
+	"\x1aConfirmReservationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12>\n" +
+	"\aresults\x18\x02 \x03(\v2$.inventory.v1.ItemConfirmationResultR\aresults\x12=\n" +
+	"\fconfirmed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vconfirmedAt\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\xf6\x01\n" +
+	"\x16ItemConfirmationResult\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tconfirmed\x18\x03 \x01(\bR\tconfirmed\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12%\n" +
+	"\x0eserial_numbers\x18\x06 \x03(\tR\rserialNumbers\x12)\n" +
+	"\x10decimal_quantity\x18\a \x01(\x01R\x0fdecimalQuantity\x12\x12\n" +
+	"\x04unit\x18\b \x01(\tR\x04unit\"u\n" +
+	"\x19ReleaseReservationRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xc8\x01\n" +
+	"\x1aReleaseReservationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x129\n" +
+	"\aresults\x18\x02 \x03(\v2\x1f.inventory.v1.ItemReleaseResultR\aresults\x12;\n" +
+	"\vreleased_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"releasedAt\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\x91\x02\n" +
+	"\x16CreateBackorderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vshipment_id\x18\x02 \x01(\tR\n" +
+	"shipmentId\x12:\n" +
+	"\x05items\x18\x03 \x03(\v2$.inventory.v1.ItemReservationRequestR\x05items\x12@\n" +
+	"\x1creservation_duration_minutes\x18\x04 \x01(\x05R\x1areservationDurationMinutes\x12=\n" +
+	"\bpriority\x18\x05 \x01(\x0e2!.inventory.v1.ReservationPriorityR\bpriority\"P\n" +
+	"\x17CreateBackorderResponse\x125\n" +
+	"\tbackorder\x18\x01 \x01(\v2\x17.inventory.v1.BackorderR\tbackorder\"T\n" +
+	"\x16CancelBackorderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vshipment_id\x18\x02 \x01(\tR\n" +
+	"shipmentId\"P\n" +
+	"\x17CancelBackorderResponse\x125\n" +
+	"\tbackorder\x18\x01 \x01(\v2\x17.inventory.v1.BackorderR\tbackorder\"\xe0\x02\n" +
+	"\tBackorder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vshipment_id\x18\x03 \x01(\tR\n" +
+	"shipmentId\x125\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1d.inventory.v1.BackorderStatusR\x06status\x121\n" +
+	"\x05items\x18\x05 \x03(\v2\x1b.inventory.v1.BackorderItemR\x05items\x12%\n" +
+	"\x0ereservation_id\x18\x06 \x01(\tR\rreservationId\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vreserved_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reservedAt\"Q\n" +
+	"\rBackorderItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\"\xc8\x01\n" +
+	"\x11ItemReleaseResult\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\breleased\x18\x03 \x01(\bR\breleased\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12)\n" +
+	"\x10decimal_quantity\x18\x06 \x01(\x01R\x0fdecimalQuantity\x12\x12\n" +
+	"\x04unit\x18\a \x01(\tR\x04unit\"x\n" +
+	"\x0eGetItemRequest\x12\x19\n" +
+	"\aitem_id\x18\x01 \x01(\tH\x00R\x06itemId\x12\x12\n" +
+	"\x03sku\x18\x02 \x01(\tH\x00R\x03sku\x12)\n" +
+	"\x10display_currency\x18\x03 \x01(\tR\x0fdisplayCurrencyB\f\n" +
+	"\n" +
+	"identifier\"\xaf\x01\n" +
+	"\x0fGetItemResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12/\n" +
+	"\x04item\x18\x02 \x01(\v2\x1b.inventory.v1.InventoryItemR\x04item\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12;\n" +
+	"\rdisplay_rates\x18\x04 \x03(\v2\x16.money.v1.ExchangeRateR\fdisplayRates\"\x9a\x02\n" +
+	"\x12SearchItemsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x126\n" +
+	"\bcategory\x18\x02 \x01(\x0e2\x1a.inventory.v1.ItemCategoryR\bcategory\x12%\n" +
+	"\x0eavailable_only\x18\x03 \x01(\bR\ravailableOnly\x12\x18\n" +
+	"\x05limit\x18\x04 \x01(\x05B\x02\x18\x01R\x05limit\x12\x1a\n" +
+	"\x06offset\x18\x05 \x01(\x05B\x02\x18\x01R\x06offset\x12.\n" +
+	"\x04page\x18\x06 \x01(\v2\x1a.pagination.v1.PageRequestR\x04page\x12)\n" +
+	"\x10display_currency\x18\a \x01(\tR\x0fdisplayCurrency\"\x91\x02\n" +
+	"\x13SearchItemsResponse\x121\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.inventory.v1.InventoryItemR\x05items\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x124\n" +
+	"\tpage_info\x18\x05 \x01(\v2\x17.pagination.v1.PageInfoR\bpageInfo\x12;\n" +
+	"\rdisplay_rates\x18\x06 \x03(\v2\x16.money.v1.ExchangeRateR\fdisplayRates\"\x80\x01\n" +
+	"\x17GetLowStockItemsRequest\x126\n" +
+	"\bcategory\x18\x01 \x01(\x0e2\x1a.inventory.v1.ItemCategoryR\bcategory\x12-\n" +
+	"\x12threshold_override\x18\x02 \x01(\x05R\x11thresholdOverride\"\x87\x01\n" +
+	"\x18GetLowStockItemsResponse\x120\n" +
+	"\x05items\x18\x01 \x03(\v2\x1a.inventory.v1.LowStockItemR\x05items\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x9c\x04\n" +
+	"\fLowStockItem\x12/\n" +
+	"\x04item\x18\x01 \x01(\v2\x1b.inventory.v1.InventoryItemR\x04item\x12+\n" +
+	"\x11shortage_quantity\x18\x02 \x01(\x05R\x10shortageQuantity\x12\"\n" +
+	"\rdays_of_stock\x18\x03 \x01(\x05R\vdaysOfStock\x12+\n" +
+	"\x11incoming_quantity\x18\x04 \x01(\x05R\x10incomingQuantity\x12E\n" +
+	"\x10expected_arrival\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0fexpectedArrival\x12:\n" +
+	"\x19decimal_shortage_quantity\x18\x06 \x01(\x01R\x17decimalShortageQuantity\x12)\n" +
+	"\x10restock_quantity\x18\a \x01(\x01R\x0frestockQuantity\x12\x1a\n" +
+	"\bsupplier\x18\b \x01(\tR\bsupplier\x12!\n" +
+	"\fsupplier_sku\x18\t \x01(\tR\vsupplierSku\x12$\n" +
+	"\x0elead_time_days\x18\n" +
+	" \x01(\x05R\fleadTimeDays\x12J\n" +
+	"\x13expected_restock_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x11expectedRestockAt\"\xd2\x01\n" +
+	"\x12UpdateStockRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12'\n" +
+	"\x0fquantity_change\x18\x02 \x01(\x05R\x0equantityChange\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x04 \x01(\tR\tupdatedBy\x126\n" +
+	"\x17decimal_quantity_change\x18\x05 \x01(\x01R\x15decimalQuantityChange\x12\x12\n" +
+	"\x04unit\x18\x06 \x01(\tR\x04unit\"\xd6\x02\n" +
+	"\x13UpdateStockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12&\n" +
+	"\x0fold_stock_level\x18\x02 \x01(\x05R\roldStockLevel\x12&\n" +
+	"\x0fnew_stock_level\x18\x03 \x01(\x05R\rnewStockLevel\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x125\n" +
+	"\x17decimal_old_stock_level\x18\x06 \x01(\x01R\x14decimalOldStockLevel\x125\n" +
+	"\x17decimal_new_stock_level\x18\a \x01(\x01R\x14decimalNewStockLevel\x12\x12\n" +
+	"\x04unit\x18\b \x01(\tR\x04unit\"\xd3\x01\n" +
+	"\x19GetItemsByCategoryRequest\x126\n" +
+	"\bcategory\x18\x01 \x01(\x0e2\x1a.inventory.v1.ItemCategoryR\bcategory\x12%\n" +
+	"\x0eavailable_only\x18\x02 \x01(\bR\ravailableOnly\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12)\n" +
+	"\x10display_currency\x18\x05 \x01(\tR\x0fdisplayCurrency\"\xe2\x01\n" +
+	"\x1aGetItemsByCategoryResponse\x121\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.inventory.v1.InventoryItemR\x05items\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12;\n" +
+	"\rdisplay_rates\x18\x05 \x03(\v2\x16.money.v1.ExchangeRateR\fdisplayRates\"\x1f\n" +
+	"\x1dGetAvailabilitySummaryRequest\"\xa3\x01\n" +
+	"\x1eGetAvailabilitySummaryResponse\x12B\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\".inventory.v1.CategoryAvailabilityR\n" +
+	"categories\x12=\n" +
+	"\fgenerated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\"\xc8\x02\n" +
+	"\x14CategoryAvailability\x126\n" +
+	"\bcategory\x18\x01 \x01(\x0e2\x1a.inventory.v1.ItemCategoryR\bcategory\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\x02 \x01(\x05R\titemCount\x12$\n" +
+	"\x0ein_stock_count\x18\x03 \x01(\x05R\finStockCount\x12&\n" +
+	"\x0flow_stock_count\x18\x04 \x01(\x05R\rlowStockCount\x12+\n" +
+	"\x12out_of_stock_count\x18\x05 \x01(\x05R\x0foutOfStockCount\x12+\n" +
+	"\x11reserved_quantity\x18\x06 \x01(\x01R\x10reservedQuantity\x121\n" +
+	"\tvaluation\x18\a \x03(\v2\x13.inventory.v1.MoneyR\tvaluation\"\xfb\x01\n" +
+	"\x1cGetInventoryValuationRequest\x125\n" +
+	"\x06method\x18\x01 \x01(\x0e2\x1d.inventory.v1.ValuationMethodR\x06method\x127\n" +
+	"\tcogs_from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bcogsFrom\x123\n" +
+	"\acogs_to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06cogsTo\x126\n" +
+	"\bcategory\x18\x04 \x01(\x0e2\x1a.inventory.v1.ItemCategoryR\bcategory\"\x9d\x03\n" +
+	"\x1dGetInventoryValuationResponse\x125\n" +
+	"\x06method\x18\x01 \x01(\x0e2\x1d.inventory.v1.ValuationMethodR\x06method\x121\n" +
+	"\x05items\x18\x02 \x03(\v2\x1b.inventory.v1.ItemValuationR\x05items\x12<\n" +
+	"\x0finventory_value\x18\x03 \x03(\v2\x13.inventory.v1.MoneyR\x0einventoryValue\x12'\n" +
+	"\x04cogs\x18\x04 \x03(\v2\x13.inventory.v1.MoneyR\x04cogs\x127\n" +
+	"\tcogs_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bcogsFrom\x123\n" +
+	"\acogs_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x06cogsTo\x12=\n" +
+	"\fgenerated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\"\xe9\x02\n" +
+	"\rItemValuation\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x126\n" +
+	"\bcategory\x18\x03 \x01(\x0e2\x1a.inventory.v1.ItemCategoryR\bcategory\x12\x12\n" +
+	"\x04unit\x18\x04 \x01(\tR\x04unit\x12\x17\n" +
+	"\aon_hand\x18\x05 \x01(\x01R\x06onHand\x12'\n" +
+	"\x0fcosted_quantity\x18\x06 \x01(\x01R\x0ecostedQuantity\x12)\n" +
+	"\x05value\x18\a \x01(\v2\x13.inventory.v1.MoneyR\x05value\x12+\n" +
+	"\x11uncosted_quantity\x18\b \x01(\x01R\x10uncostedQuantity\x12#\n" +
+	"\rcogs_quantity\x18\t \x01(\x01R\fcogsQuantity\x12'\n" +
+	"\x04cogs\x18\n" +
+	" \x01(\v2\x13.inventory.v1.MoneyR\x04cogs\"\x13\n" +
+	"\x11GetVersionRequest\"\xc1\x01\n" +
+	"\x12GetVersionResponse\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"git_commit\x18\x03 \x01(\tR\tgitCommit\x12\x1d\n" +
+	"\n" +
+	"build_time\x18\x04 \x01(\tR\tbuildTime\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x05 \x01(\tR\tgoVersion\x12\x1a\n" +
+	"\bplatform\x18\x06 \x01(\tR\bplatform\"Y\n" +
+	"\x17GetSerialNumbersRequest\x12#\n" +
+	"\rserial_number\x18\x01 \x01(\tR\fserialNumber\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"w\n" +
+	"\x18GetSerialNumbersResponse\x12A\n" +
+	"\x0eserial_numbers\x18\x01 \x03(\v2\x1a.inventory.v1.SerialNumberR\rserialNumbers\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xad\x01\n" +
+	"\fSerialNumber\x12#\n" +
+	"\rserial_number\x18\x01 \x01(\tR\fserialNumber\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x19\n" +
+	"\border_id\x18\x04 \x01(\tR\aorderId\x123\n" +
+	"\ahistory\x18\x05 \x03(\v2\x19.inventory.v1.SerialEventR\ahistory\"\x91\x01\n" +
+	"\vSerialEvent\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"L\n" +
+	"\x11WatchItemsRequest\x12\x12\n" +
+	"\x04skus\x18\x01 \x03(\tR\x04skus\x12#\n" +
+	"\rskip_snapshot\x18\x02 \x01(\bR\fskipSnapshot\"\xcd\x03\n" +
+	"\n" +
+	"ItemChange\x120\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1c.inventory.v1.ItemChangeTypeR\x04type\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x1f\n" +
+	"\vstock_level\x18\x03 \x01(\x05R\n" +
+	"stockLevel\x12%\n" +
+	"\x0ereserved_stock\x18\x04 \x01(\x05R\rreservedStock\x122\n" +
+	"\n" +
+	"unit_price\x18\x05 \x01(\v2\x13.inventory.v1.MoneyR\tunitPrice\x120\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x18.inventory.v1.ItemStatusR\x06status\x12\x18\n" +
+	"\aversion\x18\a \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"changed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\x12\x12\n" +
+	"\x04unit\x18\t \x01(\tR\x04unit\x12.\n" +
+	"\x13decimal_stock_level\x18\n" +
+	" \x01(\x01R\x11decimalStockLevel\x124\n" +
+	"\x16decimal_reserved_stock\x18\v \x01(\x01R\x14decimalReservedStock\"\xb7\t\n" +
+	"\rInventoryItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x126\n" +
+	"\bcategory\x18\x05 \x01(\x0e2\x1a.inventory.v1.ItemCategoryR\bcategory\x12\x1f\n" +
+	"\vstock_level\x18\x06 \x01(\x05R\n" +
+	"stockLevel\x12%\n" +
+	"\x0ereserved_stock\x18\a \x01(\x05R\rreservedStock\x12\x1f\n" +
+	"\vtotal_stock\x18\b \x01(\x05R\n" +
+	"totalStock\x12&\n" +
+	"\x0fmin_stock_level\x18\t \x01(\x05R\rminStockLevel\x12&\n" +
+	"\x0fmax_stock_level\x18\n" +
+	" \x01(\x05R\rmaxStockLevel\x122\n" +
+	"\n" +
+	"unit_price\x18\v \x01(\v2\x13.inventory.v1.MoneyR\tunitPrice\x12\x16\n" +
+	"\x06weight\x18\f \x01(\x01R\x06weight\x128\n" +
+	"\n" +
+	"dimensions\x18\r \x01(\v2\x18.inventory.v1.DimensionsR\n" +
+	"dimensions\x12W\n" +
+	"\x0especifications\x18\x0e \x03(\v2/.inventory.v1.InventoryItem.SpecificationsEntryR\x0especifications\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\x11 \x01(\x05R\aversion\x120\n" +
+	"\x06status\x18\x12 \x01(\x0e2\x18.inventory.v1.ItemStatusR\x06status\x12\x12\n" +
+	"\x04unit\x18\x13 \x01(\tR\x04unit\x12.\n" +
+	"\x13decimal_stock_level\x18\x14 \x01(\x01R\x11decimalStockLevel\x124\n" +
+	"\x16decimal_reserved_stock\x18\x15 \x01(\x01R\x14decimalReservedStock\x12.\n" +
+	"\x13decimal_total_stock\x18\x16 \x01(\x01R\x11decimalTotalStock\x125\n" +
+	"\x17decimal_min_stock_level\x18\x17 \x01(\x01R\x14decimalMinStockLevel\x125\n" +
+	"\x17decimal_max_stock_level\x18\x18 \x01(\x01R\x14decimalMaxStockLevel\x128\n" +
+	"\rdisplay_price\x18\x19 \x01(\v2\x13.inventory.v1.MoneyR\fdisplayPrice\x12'\n" +
+	"\x0freplacement_sku\x18\x1a \x01(\tR\x0ereplacementSku\x1aA\n" +
+	"\x13SpecificationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x1f\n" +
+	"\vminor_units\x18\x03 \x01(\x03R\n" +
+	"minorUnits\"R\n" +
+	"\n" +
+	"Dimensions\x12\x16\n" +
+	"\x06length\x18\x01 \x01(\x01R\x06length\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x01R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x01R\x06height*\x8d\x01\n" +
+	"\x0eItemChangeType\x12 \n" +
+	"\x1cITEM_CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19ITEM_CHANGE_TYPE_SNAPSHOT\x10\x01\x12\x1c\n" +
+	"\x18ITEM_CHANGE_TYPE_UPDATED\x10\x02\x12\x1c\n" +
+	"\x18ITEM_CHANGE_TYPE_DELETED\x10\x03*\x9c\x02\n" +
+	"\fItemCategory\x12\x1d\n" +
+	"\x19ITEM_CATEGORY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ITEM_CATEGORY_ENGINES\x10\x01\x12\x1c\n" +
+	"\x18ITEM_CATEGORY_FUEL_TANKS\x10\x02\x12\x1c\n" +
+	"\x18ITEM_CATEGORY_NAVIGATION\x10\x03\x12\x1c\n" +
+	"\x18ITEM_CATEGORY_STRUCTURAL\x10\x04\x12\x1d\n" +
+	"\x19ITEM_CATEGORY_ELECTRONICS\x10\x05\x12\x1e\n" +
+	"\x1aITEM_CATEGORY_LIFE_SUPPORT\x10\x06\x12\x19\n" +
+	"\x15ITEM_CATEGORY_PAYLOAD\x10\a\x12\x1e\n" +
+	"\x1aITEM_CATEGORY_LANDING_GEAR\x10\b*l\n" +
+	"\x0fValuationMethod\x12 \n" +
+	"\x1cVALUATION_METHOD_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15VALUATION_METHOD_FIFO\x10\x01\x12\x1c\n" +
+	"\x18VALUATION_METHOD_AVERAGE\x10\x02*\x90\x01\n" +
+	"\x0fBackorderStatus\x12 \n" +
+	"\x1cBACKORDER_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18BACKORDER_STATUS_WAITING\x10\x01\x12\x1d\n" +
+	"\x19BACKORDER_STATUS_RESERVED\x10\x02\x12\x1e\n" +
+	"\x1aBACKORDER_STATUS_CANCELLED\x10\x03*\\\n" +
+	"\x13ReservationPriority\x12!\n" +
+	"\x1dRESERVATION_PRIORITY_STANDARD\x10\x00\x12\"\n" +
+	"\x1eRESERVATION_PRIORITY_EXPEDITED\x10\x01*\xb4\x01\n" +
+	"\n" +
+	"ItemStatus\x12\x1b\n" +
+	"\x17ITEM_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12ITEM_STATUS_ACTIVE\x10\x01\x12\x1c\n" +
+	"\x18ITEM_STATUS_DISCONTINUED\x10\x02\x12\x1c\n" +
+	"\x18ITEM_STATUS_OUT_OF_STOCK\x10\x03\x12\x1b\n" +
+	"\x17ITEM_STATUS_BACKORDERED\x10\x04\x12\x18\n" +
+	"\x14ITEM_STATUS_INCOMING\x10\x052\x83\f\n" +
+	"\x10InventoryService\x12d\n" +
+	"\x11CheckAvailability\x12&.inventory.v1.CheckAvailabilityRequest\x1a'.inventory.v1.CheckAvailabilityResponse\x12U\n" +
+	"\fReserveItems\x12!.inventory.v1.ReserveItemsRequest\x1a\".inventory.v1.ReserveItemsResponse\x12g\n" +
+	"\x12ConfirmReservation\x12'.inventory.v1.ConfirmReservationRequest\x1a(.inventory.v1.ConfirmReservationResponse\x12g\n" +
+	"\x12ReleaseReservation\x12'.inventory.v1.ReleaseReservationRequest\x1a(.inventory.v1.ReleaseReservationResponse\x12^\n" +
+	"\x0fCreateBackorder\x12$.inventory.v1.CreateBackorderRequest\x1a%.inventory.v1.CreateBackorderResponse\x12^\n" +
+	"\x0fCancelBackorder\x12$.inventory.v1.CancelBackorderRequest\x1a%.inventory.v1.CancelBackorderResponse\x12F\n" +
+	"\aGetItem\x12\x1c.inventory.v1.GetItemRequest\x1a\x1d.inventory.v1.GetItemResponse\x12R\n" +
+	"\vSearchItems\x12 .inventory.v1.SearchItemsRequest\x1a!.inventory.v1.SearchItemsResponse\x12a\n" +
+	"\x10GetLowStockItems\x12%.inventory.v1.GetLowStockItemsRequest\x1a&.inventory.v1.GetLowStockItemsResponse\x12R\n" +
+	"\vUpdateStock\x12 .inventory.v1.UpdateStockRequest\x1a!.inventory.v1.UpdateStockResponse\x12g\n" +
+	"\x12GetItemsByCategory\x12'.inventory.v1.GetItemsByCategoryRequest\x1a(.inventory.v1.GetItemsByCategoryResponse\x12s\n" +
+	"\x16GetAvailabilitySummary\x12+.inventory.v1.GetAvailabilitySummaryRequest\x1a,.inventory.v1.GetAvailabilitySummaryResponse\x12O\n" +
+	"\n" +
+	"GetVersion\x12\x1f.inventory.v1.GetVersionRequest\x1a .inventory.v1.GetVersionResponse\x12a\n" +
+	"\x10GetSerialNumbers\x12%.inventory.v1.GetSerialNumbersRequest\x1a&.inventory.v1.GetSerialNumbersResponse\x12p\n" +
+	"\x15GetInventoryValuation\x12*.inventory.v1.GetInventoryValuationRequest\x1a+.inventory.v1.GetInventoryValuationResponse\x12I\n" +
+	"\n" +
+	"WatchItems\x12\x1f.inventory.v1.WatchItemsRequest\x1a\x18.inventory.v1.ItemChange0\x01BTZRgithub.com/amiosamu/rocket-science/shared/contracts/proto/inventory/v1;inventoryv1b\x06proto3"
+
+var (
+	file_inventory_v1_inventory_proto_rawDescOnce sync.Once
+	file_inventory_v1_inventory_proto_rawDescData []byte
+)
+
+func file_inventory_v1_inventory_proto_rawDescGZIP() []byte {
+	file_inventory_v1_inventory_proto_rawDescOnce.Do(func() {
+		file_inventory_v1_inventory_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_inventory_v1_inventory_proto_rawDesc), len(file_inventory_v1_inventory_proto_rawDesc)))
+	})
+	return file_inventory_v1_inventory_proto_rawDescData
+}
+
+var file_inventory_v1_inventory_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_inventory_v1_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
+var file_inventory_v1_inventory_proto_goTypes = []any{
+	(ItemChangeType)(0),                    // 0: inventory.v1.ItemChangeType
+	(ItemCategory)(0),                      // 1: inventory.v1.ItemCategory
+	(ValuationMethod)(0),                   // 2: inventory.v1.ValuationMethod
+	(BackorderStatus)(0),                   // 3: inventory.v1.BackorderStatus
+	(ReservationPriority)(0),               // 4: inventory.v1.ReservationPriority
+	(ItemStatus)(0),                        // 5: inventory.v1.ItemStatus
+	(*CheckAvailabilityRequest)(nil),       // 6: inventory.v1.CheckAvailabilityRequest
+	(*ItemAvailabilityCheck)(nil),          // 7: inventory.v1.ItemAvailabilityCheck
+	(*CheckAvailabilityResponse)(nil),      // 8: inventory.v1.CheckAvailabilityResponse
+	(*ItemAvailabilityResult)(nil),         // 9: inventory.v1.ItemAvailabilityResult
+	(*ReserveItemsRequest)(nil),            // 10: inventory.v1.ReserveItemsRequest
+	(*ItemReservationRequest)(nil),         // 11: inventory.v1.ItemReservationRequest
+	(*ReserveItemsResponse)(nil),           // 12: inventory.v1.ReserveItemsResponse
+	(*ItemReservationResult)(nil),          // 13: inventory.v1.ItemReservationResult
+	(*ConfirmReservationRequest)(nil),      // 14: inventory.v1.ConfirmReservationRequest
+	(*ConfirmReservationResponse)(nil),     // 15: inventory.v1.ConfirmReservationResponse
+	(*ItemConfirmationResult)(nil),         // 16: inventory.v1.ItemConfirmationResult
+	(*ReleaseReservationRequest)(nil),      // 17: inventory.v1.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil),     // 18: inventory.v1.ReleaseReservationResponse
+	(*CreateBackorderRequest)(nil),         // 19: inventory.v1.CreateBackorderRequest
+	(*CreateBackorderResponse)(nil),        // 20: inventory.v1.CreateBackorderResponse
+	(*CancelBackorderRequest)(nil),         // 21: inventory.v1.CancelBackorderRequest
+	(*CancelBackorderResponse)(nil),        // 22: inventory.v1.CancelBackorderResponse
+	(*Backorder)(nil),                      // 23: inventory.v1.Backorder
+	(*BackorderItem)(nil),                  // 24: inventory.v1.BackorderItem
+	(*ItemReleaseResult)(nil),              // 25: inventory.v1.ItemReleaseResult
+	(*GetItemRequest)(nil),                 // 26: inventory.v1.GetItemRequest
+	(*GetItemResponse)(nil),                // 27: inventory.v1.GetItemResponse
+	(*SearchItemsRequest)(nil),             // 28: inventory.v1.SearchItemsRequest
+	(*SearchItemsResponse)(nil),            // 29: inventory.v1.SearchItemsResponse
+	(*GetLowStockItemsRequest)(nil),        // 30: inventory.v1.GetLowStockItemsRequest
+	(*GetLowStockItemsResponse)(nil),       // 31: inventory.v1.GetLowStockItemsResponse
+	(*LowStockItem)(nil),                   // 32: inventory.v1.LowStockItem
+	(*UpdateStockRequest)(nil),             // 33: inventory.v1.UpdateStockRequest
+	(*UpdateStockResponse)(nil),            // 34: inventory.v1.UpdateStockResponse
+	(*GetItemsByCategoryRequest)(nil),      // 35: inventory.v1.GetItemsByCategoryRequest
+	(*GetItemsByCategoryResponse)(nil),     // 36: inventory.v1.GetItemsByCategoryResponse
+	(*GetAvailabilitySummaryRequest)(nil),  // 37: inventory.v1.GetAvailabilitySummaryRequest
+	(*GetAvailabilitySummaryResponse)(nil), // 38: inventory.v1.GetAvailabilitySummaryResponse
+	(*CategoryAvailability)(nil),           // 39: inventory.v1.CategoryAvailability
+	(*GetInventoryValuationRequest)(nil),   // 40: inventory.v1.GetInventoryValuationRequest
+	(*GetInventoryValuationResponse)(nil),  // 41: inventory.v1.GetInventoryValuationResponse
+	(*ItemValuation)(nil),                  // 42: inventory.v1.ItemValuation
+	(*GetVersionRequest)(nil),              // 43: inventory.v1.GetVersionRequest
+	(*GetVersionResponse)(nil),             // 44: inventory.v1.GetVersionResponse
+	(*GetSerialNumbersRequest)(nil),        // 45: inventory.v1.GetSerialNumbersRequest
+	(*GetSerialNumbersResponse)(nil),       // 46: inventory.v1.GetSerialNumbersResponse
+	(*SerialNumber)(nil),                   // 47: inventory.v1.SerialNumber
+	(*SerialEvent)(nil),                    // 48: inventory.v1.SerialEvent
+	(*WatchItemsRequest)(nil),              // 49: inventory.v1.WatchItemsRequest
+	(*ItemChange)(nil),                     // 50: inventory.v1.ItemChange
+	(*InventoryItem)(nil),                  // 51: inventory.v1.InventoryItem
+	(*Money)(nil),                          // 52: inventory.v1.Money
+	(*Dimensions)(nil),                     // 53: inventory.v1.Dimensions
+	nil,                                    // 54: inventory.v1.InventoryItem.SpecificationsEntry
+	(*timestamppb.Timestamp)(nil),          // 55: google.protobuf.Timestamp
+	(*v1.ExchangeRate)(nil),                // 56: money.v1.ExchangeRate
+	(*v11.PageRequest)(nil),                // 57: pagination.v1.PageRequest
+	(*v11.PageInfo)(nil),                   // 58: pagination.v1.PageInfo
+}
+var file_inventory_v1_inventory_proto_depIdxs = []int32{
+	7,  // 0: inventory.v1.CheckAvailabilityRequest.items:type_name -> inventory.v1.ItemAvailabilityCheck
+	9,  // 1: inventory.v1.CheckAvailabilityResponse.results:type_name -> inventory.v1.ItemAvailabilityResult
+	52, // 2: inventory.v1.ItemAvailabilityResult.unit_price:type_name -> inventory.v1.Money
+	11, // 3: inventory.v1.ReserveItemsRequest.items:type_name -> inventory.v1.ItemReservationRequest
+	4,  // 4: inventory.v1.ReserveItemsRequest.priority:type_name -> inventory.v1.ReservationPriority
+	13, // 5: inventory.v1.ReserveItemsResponse.results:type_name -> inventory.v1.ItemReservationResult
+	55, // 6: inventory.v1.ReserveItemsResponse.expires_at:type_name -> google.protobuf.Timestamp
+	16, // 7: inventory.v1.ConfirmReservationResponse.results:type_name -> inventory.v1.ItemConfirmationResult
+	55, // 8: inventory.v1.ConfirmReservationResponse.confirmed_at:type_name -> google.protobuf.Timestamp
+	25, // 9: inventory.v1.ReleaseReservationResponse.results:type_name -> inventory.v1.ItemReleaseResult
+	55, // 10: inventory.v1.ReleaseReservationResponse.released_at:type_name -> google.protobuf.Timestamp
+	11, // 11: inventory.v1.CreateBackorderRequest.items:type_name -> inventory.v1.ItemReservationRequest
+	4,  // 12: inventory.v1.CreateBackorderRequest.priority:type_name -> inventory.v1.ReservationPriority
+	23, // 13: inventory.v1.CreateBackorderResponse.backorder:type_name -> inventory.v1.Backorder
+	23, // 14: inventory.v1.CancelBackorderResponse.backorder:type_name -> inventory.v1.Backorder
+	3,  // 15: inventory.v1.Backorder.status:type_name -> inventory.v1.BackorderStatus
+	24, // 16: inventory.v1.Backorder.items:type_name -> inventory.v1.BackorderItem
+	55, // 17: inventory.v1.Backorder.created_at:type_name -> google.protobuf.Timestamp
+	55, // 18: inventory.v1.Backorder.reserved_at:type_name -> google.protobuf.Timestamp
+	51, // 19: inventory.v1.GetItemResponse.item:type_name -> inventory.v1.InventoryItem
+	56, // 20: inventory.v1.GetItemResponse.display_rates:type_name -> money.v1.ExchangeRate
+	1,  // 21: inventory.v1.SearchItemsRequest.category:type_name -> inventory.v1.ItemCategory
+	57, // 22: inventory.v1.SearchItemsRequest.page:type_name -> pagination.v1.PageRequest
+	51, // 23: inventory.v1.SearchItemsResponse.items:type_name -> inventory.v1.InventoryItem
+	58, // 24: inventory.v1.SearchItemsResponse.page_info:type_name -> pagination.v1.PageInfo
+	56, // 25: inventory.v1.SearchItemsResponse.display_rates:type_name -> money.v1.ExchangeRate
+	1,  // 26: inventory.v1.GetLowStockItemsRequest.category:type_name -> inventory.v1.ItemCategory
+	32, // 27: inventory.v1.GetLowStockItemsResponse.items:type_name -> inventory.v1.LowStockItem
+	51, // 28: inventory.v1.LowStockItem.item:type_name -> inventory.v1.InventoryItem
+	55, // 29: inventory.v1.LowStockItem.expected_arrival:type_name -> google.protobuf.Timestamp
+	55, // 30: inventory.v1.LowStockItem.expected_restock_at:type_name -> google.protobuf.Timestamp
+	55, // 31: inventory.v1.UpdateStockResponse.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 32: inventory.v1.GetItemsByCategoryRequest.category:type_name -> inventory.v1.ItemCategory
+	51, // 33: inventory.v1.GetItemsByCategoryResponse.items:type_name -> inventory.v1.InventoryItem
+	56, // 34: inventory.v1.GetItemsByCategoryResponse.display_rates:type_name -> money.v1.ExchangeRate
+	39, // 35: inventory.v1.GetAvailabilitySummaryResponse.categories:type_name -> inventory.v1.CategoryAvailability
+	55, // 36: inventory.v1.GetAvailabilitySummaryResponse.generated_at:type_name -> google.protobuf.Timestamp
+	1,  // 37: inventory.v1.CategoryAvailability.category:type_name -> inventory.v1.ItemCategory
+	52, // 38: inventory.v1.CategoryAvailability.valuation:type_name -> inventory.v1.Money
+	2,  // 39: inventory.v1.GetInventoryValuationRequest.method:type_name -> inventory.v1.ValuationMethod
+	55, // 40: inventory.v1.GetInventoryValuationRequest.cogs_from:type_name -> google.protobuf.Timestamp
+	55, // 41: inventory.v1.GetInventoryValuationRequest.cogs_to:type_name -> google.protobuf.Timestamp
+	1,  // 42: inventory.v1.GetInventoryValuationRequest.category:type_name -> inventory.v1.ItemCategory
+	2,  // 43: inventory.v1.GetInventoryValuationResponse.method:type_name -> inventory.v1.ValuationMethod
+	42, // 44: inventory.v1.GetInventoryValuationResponse.items:type_name -> inventory.v1.ItemValuation
+	52, // 45: inventory.v1.GetInventoryValuationResponse.inventory_value:type_name -> inventory.v1.Money
+	52, // 46: inventory.v1.GetInventoryValuationResponse.cogs:type_name -> inventory.v1.Money
+	55, // 47: inventory.v1.GetInventoryValuationResponse.cogs_from:type_name -> google.protobuf.Timestamp
+	55, // 48: inventory.v1.GetInventoryValuationResponse.cogs_to:type_name -> google.protobuf.Timestamp
+	55, // 49: inventory.v1.GetInventoryValuationResponse.generated_at:type_name -> google.protobuf.Timestamp
+	1,  // 50: inventory.v1.ItemValuation.category:type_name -> inventory.v1.ItemCategory
+	52, // 51: inventory.v1.ItemValuation.value:type_name -> inventory.v1.Money
+	52, // 52: inventory.v1.ItemValuation.cogs:type_name -> inventory.v1.Money
+	47, // 53: inventory.v1.GetSerialNumbersResponse.serial_numbers:type_name -> inventory.v1.SerialNumber
+	48, // 54: inventory.v1.SerialNumber.history:type_name -> inventory.v1.SerialEvent
+	55, // 55: inventory.v1.SerialEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	0,  // 56: inventory.v1.ItemChange.type:type_name -> inventory.v1.ItemChangeType
+	52, // 57: inventory.v1.ItemChange.unit_price:type_name -> inventory.v1.Money
+	5,  // 58: inventory.v1.ItemChange.status:type_name -> inventory.v1.ItemStatus
+	55, // 59: inventory.v1.ItemChange.changed_at:type_name -> google.protobuf.Timestamp
+	1,  // 60: inventory.v1.InventoryItem.category:type_name -> inventory.v1.ItemCategory
+	52, // 61: inventory.v1.InventoryItem.unit_price:type_name -> inventory.v1.Money
+	53, // 62: inventory.v1.InventoryItem.dimensions:type_name -> inventory.v1.Dimensions
+	54, // 63: inventory.v1.InventoryItem.specifications:type_name -> inventory.v1.InventoryItem.SpecificationsEntry
+	55, // 64: inventory.v1.InventoryItem.created_at:type_name -> google.protobuf.Timestamp
+	55, // 65: inventory.v1.InventoryItem.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 66: inventory.v1.InventoryItem.status:type_name -> inventory.v1.ItemStatus
+	52, // 67: inventory.v1.InventoryItem.display_price:type_name -> inventory.v1.Money
+	6,  // 68: inventory.v1.InventoryService.CheckAvailability:input_type -> inventory.v1.CheckAvailabilityRequest
+	10, // 69: inventory.v1.InventoryService.ReserveItems:input_type -> inventory.v1.ReserveItemsRequest
+	14, // 70: inventory.v1.InventoryService.ConfirmReservation:input_type -> inventory.v1.ConfirmReservationRequest
+	17, // 71: inventory.v1.InventoryService.ReleaseReservation:input_type -> inventory.v1.ReleaseReservationRequest
+	19, // 72: inventory.v1.InventoryService.CreateBackorder:input_type -> inventory.v1.CreateBackorderRequest
+	21, // 73: inventory.v1.InventoryService.CancelBackorder:input_type -> inventory.v1.CancelBackorderRequest
+	26, // 74: inventory.v1.InventoryService.GetItem:input_type -> inventory.v1.GetItemRequest
+	28, // 75: inventory.v1.InventoryService.SearchItems:input_type -> inventory.v1.SearchItemsRequest
+	30, // 76: inventory.v1.InventoryService.GetLowStockItems:input_type -> inventory.v1.GetLowStockItemsRequest
+	33, // 77: inventory.v1.InventoryService.UpdateStock:input_type -> inventory.v1.UpdateStockRequest
+	35, // 78: inventory.v1.InventoryService.GetItemsByCategory:input_type -> inventory.v1.GetItemsByCategoryRequest
+	37, // 79: inventory.v1.InventoryService.GetAvailabilitySummary:input_type -> inventory.v1.GetAvailabilitySummaryRequest
+	43, // 80: inventory.v1.InventoryService.GetVersion:input_type -> inventory.v1.GetVersionRequest
+	45, // 81: inventory.v1.InventoryService.GetSerialNumbers:input_type -> inventory.v1.GetSerialNumbersRequest
+	40, // 82: inventory.v1.InventoryService.GetInventoryValuation:input_type -> inventory.v1.GetInventoryValuationRequest
+	49, // 83: inventory.v1.InventoryService.WatchItems:input_type -> inventory.v1.WatchItemsRequest
+	8,  // 84: inventory.v1.InventoryService.CheckAvailability:output_type -> inventory.v1.CheckAvailabilityResponse
+	12, // 85: inventory.v1.InventoryService.ReserveItems:output_type -> inventory.v1.ReserveItemsResponse
+	15, // 86: inventory.v1.InventoryService.ConfirmReservation:output_type -> inventory.v1.ConfirmReservationResponse
+	18, // 87: inventory.v1.InventoryService.ReleaseReservation:output_type -> inventory.v1.ReleaseReservationResponse
+	20, // 88: inventory.v1.InventoryService.CreateBackorder:output_type -> inventory.v1.CreateBackorderResponse
+	22, // 89: inventory.v1.InventoryService.CancelBackorder:output_type -> inventory.v1.CancelBackorderResponse
+	27, // 90: inventory.v1.InventoryService.GetItem:output_type -> inventory.v1.GetItemResponse
+	29, // 91: inventory.v1.InventoryService.SearchItems:output_type -> inventory.v1.SearchItemsResponse
+	31, // 92: inventory.v1.InventoryService.GetLowStockItems:output_type -> inventory.v1.GetLowStockItemsResponse
+	34, // 93: inventory.v1.InventoryService.UpdateStock:output_type -> inventory.v1.UpdateStockResponse
+	36, // 94: inventory.v1.InventoryService.GetItemsByCategory:output_type -> inventory.v1.GetItemsByCategoryResponse
+	38, // 95: inventory.v1.InventoryService.GetAvailabilitySummary:output_type -> inventory.v1.GetAvailabilitySummaryResponse
+	44, // 96: inventory.v1.InventoryService.GetVersion:output_type -> inventory.v1.GetVersionResponse
+	46, // 97: inventory.v1.InventoryService.GetSerialNumbers:output_type -> inventory.v1.GetSerialNumbersResponse
+	41, // 98: inventory.v1.InventoryService.GetInventoryValuation:output_type -> inventory.v1.GetInventoryValuationResponse
+	50, // 99: inventory.v1.InventoryService.WatchItems:output_type -> inventory.v1.ItemChange
+	84, // [84:100] is the sub-list for method output_type
+	68, // [68:84] is the sub-list for method input_type
+	68, // [68:68] is the sub-list for extension type_name
+	68, // [68:68] is the sub-list for extension extendee
+	0,  // [0:68] is the sub-list for field type_name
+}
+
+func init() { file_inventory_v1_inventory_proto_init() }
+func file_inventory_v1_inventory_proto_init() {
+	if File_inventory_v1_inventory_proto != nil {
+		return
+	}
+	file_inventory_v1_inventory_proto_msgTypes[20].OneofWrappers = []any{
+		(*GetItemRequest_ItemId)(nil),
+		(*GetItemRequest_Sku)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_v1_inventory_proto_rawDesc), len(file_inventory_v1_inventory_proto_rawDesc)),
+			NumEnums:      6,
+			NumMessages:   49,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_inventory_v1_inventory_proto_goTypes,
+		DependencyIndexes: file_inventory_v1_inventory_proto_depIdxs,
+		EnumInfos:         file_inventory_v1_inventory_proto_enumTypes,
+		MessageInfos:      file_inventory_v1_inventory_proto_msgTypes,
+	}.Build()
+	File_inventory_v1_inventory_proto = out.File
+	file_inventory_v1_inventory_proto_goTypes = nil
+	file_inventory_v1_inventory_proto_depIdxs = nil
+}
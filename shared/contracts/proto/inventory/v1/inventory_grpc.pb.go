@@ -2,9 +2,9 @@
 // versions:
 // - protoc-gen-go-grpc v1.5.1
 // - protoc             v5.29.3
-// source: proto/inventory/inventory.proto
+// source: inventory/v1/inventory.proto
 
-package inventory
+package inventoryv1
 
 import (
 	context "context"
@@ -19,15 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InventoryService_CheckAvailability_FullMethodName  = "/inventory.v1.InventoryService/CheckAvailability"
-	InventoryService_ReserveItems_FullMethodName       = "/inventory.v1.InventoryService/ReserveItems"
-	InventoryService_ConfirmReservation_FullMethodName = "/inventory.v1.InventoryService/ConfirmReservation"
-	InventoryService_ReleaseReservation_FullMethodName = "/inventory.v1.InventoryService/ReleaseReservation"
-	InventoryService_GetItem_FullMethodName            = "/inventory.v1.InventoryService/GetItem"
-	InventoryService_SearchItems_FullMethodName        = "/inventory.v1.InventoryService/SearchItems"
-	InventoryService_GetLowStockItems_FullMethodName   = "/inventory.v1.InventoryService/GetLowStockItems"
-	InventoryService_UpdateStock_FullMethodName        = "/inventory.v1.InventoryService/UpdateStock"
-	InventoryService_GetItemsByCategory_FullMethodName = "/inventory.v1.InventoryService/GetItemsByCategory"
+	InventoryService_CheckAvailability_FullMethodName      = "/inventory.v1.InventoryService/CheckAvailability"
+	InventoryService_ReserveItems_FullMethodName           = "/inventory.v1.InventoryService/ReserveItems"
+	InventoryService_ConfirmReservation_FullMethodName     = "/inventory.v1.InventoryService/ConfirmReservation"
+	InventoryService_ReleaseReservation_FullMethodName     = "/inventory.v1.InventoryService/ReleaseReservation"
+	InventoryService_CreateBackorder_FullMethodName        = "/inventory.v1.InventoryService/CreateBackorder"
+	InventoryService_CancelBackorder_FullMethodName        = "/inventory.v1.InventoryService/CancelBackorder"
+	InventoryService_GetItem_FullMethodName                = "/inventory.v1.InventoryService/GetItem"
+	InventoryService_SearchItems_FullMethodName            = "/inventory.v1.InventoryService/SearchItems"
+	InventoryService_GetLowStockItems_FullMethodName       = "/inventory.v1.InventoryService/GetLowStockItems"
+	InventoryService_UpdateStock_FullMethodName            = "/inventory.v1.InventoryService/UpdateStock"
+	InventoryService_GetItemsByCategory_FullMethodName     = "/inventory.v1.InventoryService/GetItemsByCategory"
+	InventoryService_GetAvailabilitySummary_FullMethodName = "/inventory.v1.InventoryService/GetAvailabilitySummary"
+	InventoryService_GetVersion_FullMethodName             = "/inventory.v1.InventoryService/GetVersion"
+	InventoryService_GetSerialNumbers_FullMethodName       = "/inventory.v1.InventoryService/GetSerialNumbers"
+	InventoryService_GetInventoryValuation_FullMethodName  = "/inventory.v1.InventoryService/GetInventoryValuation"
+	InventoryService_WatchItems_FullMethodName             = "/inventory.v1.InventoryService/WatchItems"
 )
 
 // InventoryServiceClient is the client API for InventoryService service.
@@ -44,6 +51,12 @@ type InventoryServiceClient interface {
 	ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*ConfirmReservationResponse, error)
 	// ReleaseReservation releases reserved items (if payment fails)
 	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error)
+	// CreateBackorder records an order's demand for out-of-stock items. The items are
+	// reserved for the order as soon as enough stock arrives, and an
+	// inventory.backorder.reserved event is published.
+	CreateBackorder(ctx context.Context, in *CreateBackorderRequest, opts ...grpc.CallOption) (*CreateBackorderResponse, error)
+	// CancelBackorder gives up an open backorder, releasing its stock if it was reserved
+	CancelBackorder(ctx context.Context, in *CancelBackorderRequest, opts ...grpc.CallOption) (*CancelBackorderResponse, error)
 	// GetItem retrieves details of a specific inventory item
 	GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*GetItemResponse, error)
 	// SearchItems searches for items by name, SKU, or category
@@ -54,6 +67,20 @@ type InventoryServiceClient interface {
 	UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*UpdateStockResponse, error)
 	// GetItemsByCategory retrieves items in a specific category
 	GetItemsByCategory(ctx context.Context, in *GetItemsByCategoryRequest, opts ...grpc.CallOption) (*GetItemsByCategoryResponse, error)
+	// GetAvailabilitySummary returns per-category stock counts, reserved quantity and
+	// valuation, aggregated by the service rather than from every item
+	GetAvailabilitySummary(ctx context.Context, in *GetAvailabilitySummaryRequest, opts ...grpc.CallOption) (*GetAvailabilitySummaryResponse, error)
+	// GetVersion returns build information for deployment verification
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	// GetSerialNumbers returns serial numbers with their history, by serial or by order
+	GetSerialNumbers(ctx context.Context, in *GetSerialNumbersRequest, opts ...grpc.CallOption) (*GetSerialNumbersResponse, error)
+	// GetInventoryValuation values the stock on hand at cost and reports the cost of
+	// goods confirmed for orders in a period. Restricted to admins and operators.
+	GetInventoryValuation(ctx context.Context, in *GetInventoryValuationRequest, opts ...grpc.CallOption) (*GetInventoryValuationResponse, error)
+	// WatchItems streams stock and price changes of the given items. The current
+	// state of every watched item is sent first. The stream ends when the server
+	// recycles the connection; clients re-subscribe and receive a fresh snapshot.
+	WatchItems(ctx context.Context, in *WatchItemsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ItemChange], error)
 }
 
 type inventoryServiceClient struct {
@@ -104,6 +131,26 @@ func (c *inventoryServiceClient) ReleaseReservation(ctx context.Context, in *Rel
 	return out, nil
 }
 
+func (c *inventoryServiceClient) CreateBackorder(ctx context.Context, in *CreateBackorderRequest, opts ...grpc.CallOption) (*CreateBackorderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBackorderResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CreateBackorder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CancelBackorder(ctx context.Context, in *CancelBackorderRequest, opts ...grpc.CallOption) (*CancelBackorderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelBackorderResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CancelBackorder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryServiceClient) GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*GetItemResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetItemResponse)
@@ -154,6 +201,65 @@ func (c *inventoryServiceClient) GetItemsByCategory(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *inventoryServiceClient) GetAvailabilitySummary(ctx context.Context, in *GetAvailabilitySummaryRequest, opts ...grpc.CallOption) (*GetAvailabilitySummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAvailabilitySummaryResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetAvailabilitySummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersionResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetSerialNumbers(ctx context.Context, in *GetSerialNumbersRequest, opts ...grpc.CallOption) (*GetSerialNumbersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSerialNumbersResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetSerialNumbers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetInventoryValuation(ctx context.Context, in *GetInventoryValuationRequest, opts ...grpc.CallOption) (*GetInventoryValuationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInventoryValuationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetInventoryValuation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) WatchItems(ctx context.Context, in *WatchItemsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ItemChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InventoryService_ServiceDesc.Streams[0], InventoryService_WatchItems_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchItemsRequest, ItemChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_WatchItemsClient = grpc.ServerStreamingClient[ItemChange]
+
 // InventoryServiceServer is the server API for InventoryService service.
 // All implementations must embed UnimplementedInventoryServiceServer
 // for forward compatibility.
@@ -168,6 +274,12 @@ type InventoryServiceServer interface {
 	ConfirmReservation(context.Context, *ConfirmReservationRequest) (*ConfirmReservationResponse, error)
 	// ReleaseReservation releases reserved items (if payment fails)
 	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error)
+	// CreateBackorder records an order's demand for out-of-stock items. The items are
+	// reserved for the order as soon as enough stock arrives, and an
+	// inventory.backorder.reserved event is published.
+	CreateBackorder(context.Context, *CreateBackorderRequest) (*CreateBackorderResponse, error)
+	// CancelBackorder gives up an open backorder, releasing its stock if it was reserved
+	CancelBackorder(context.Context, *CancelBackorderRequest) (*CancelBackorderResponse, error)
 	// GetItem retrieves details of a specific inventory item
 	GetItem(context.Context, *GetItemRequest) (*GetItemResponse, error)
 	// SearchItems searches for items by name, SKU, or category
@@ -178,6 +290,20 @@ type InventoryServiceServer interface {
 	UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error)
 	// GetItemsByCategory retrieves items in a specific category
 	GetItemsByCategory(context.Context, *GetItemsByCategoryRequest) (*GetItemsByCategoryResponse, error)
+	// GetAvailabilitySummary returns per-category stock counts, reserved quantity and
+	// valuation, aggregated by the service rather than from every item
+	GetAvailabilitySummary(context.Context, *GetAvailabilitySummaryRequest) (*GetAvailabilitySummaryResponse, error)
+	// GetVersion returns build information for deployment verification
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
+	// GetSerialNumbers returns serial numbers with their history, by serial or by order
+	GetSerialNumbers(context.Context, *GetSerialNumbersRequest) (*GetSerialNumbersResponse, error)
+	// GetInventoryValuation values the stock on hand at cost and reports the cost of
+	// goods confirmed for orders in a period. Restricted to admins and operators.
+	GetInventoryValuation(context.Context, *GetInventoryValuationRequest) (*GetInventoryValuationResponse, error)
+	// WatchItems streams stock and price changes of the given items. The current
+	// state of every watched item is sent first. The stream ends when the server
+	// recycles the connection; clients re-subscribe and receive a fresh snapshot.
+	WatchItems(*WatchItemsRequest, grpc.ServerStreamingServer[ItemChange]) error
 	mustEmbedUnimplementedInventoryServiceServer()
 }
 
@@ -200,6 +326,12 @@ func (UnimplementedInventoryServiceServer) ConfirmReservation(context.Context, *
 func (UnimplementedInventoryServiceServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservation not implemented")
 }
+func (UnimplementedInventoryServiceServer) CreateBackorder(context.Context, *CreateBackorderRequest) (*CreateBackorderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBackorder not implemented")
+}
+func (UnimplementedInventoryServiceServer) CancelBackorder(context.Context, *CancelBackorderRequest) (*CancelBackorderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBackorder not implemented")
+}
 func (UnimplementedInventoryServiceServer) GetItem(context.Context, *GetItemRequest) (*GetItemResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetItem not implemented")
 }
@@ -215,6 +347,21 @@ func (UnimplementedInventoryServiceServer) UpdateStock(context.Context, *UpdateS
 func (UnimplementedInventoryServiceServer) GetItemsByCategory(context.Context, *GetItemsByCategoryRequest) (*GetItemsByCategoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetItemsByCategory not implemented")
 }
+func (UnimplementedInventoryServiceServer) GetAvailabilitySummary(context.Context, *GetAvailabilitySummaryRequest) (*GetAvailabilitySummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailabilitySummary not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetSerialNumbers(context.Context, *GetSerialNumbersRequest) (*GetSerialNumbersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSerialNumbers not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetInventoryValuation(context.Context, *GetInventoryValuationRequest) (*GetInventoryValuationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInventoryValuation not implemented")
+}
+func (UnimplementedInventoryServiceServer) WatchItems(*WatchItemsRequest, grpc.ServerStreamingServer[ItemChange]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchItems not implemented")
+}
 func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
 func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
 
@@ -308,6 +455,42 @@ func _InventoryService_ReleaseReservation_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_CreateBackorder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBackorderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateBackorder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateBackorder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateBackorder(ctx, req.(*CreateBackorderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CancelBackorder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBackorderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CancelBackorder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CancelBackorder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CancelBackorder(ctx, req.(*CancelBackorderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InventoryService_GetItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetItemRequest)
 	if err := dec(in); err != nil {
@@ -398,6 +581,89 @@ func _InventoryService_GetItemsByCategory_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_GetAvailabilitySummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvailabilitySummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetAvailabilitySummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetAvailabilitySummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetAvailabilitySummary(ctx, req.(*GetAvailabilitySummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetSerialNumbers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSerialNumbersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetSerialNumbers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetSerialNumbers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetSerialNumbers(ctx, req.(*GetSerialNumbersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetInventoryValuation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInventoryValuationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetInventoryValuation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetInventoryValuation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetInventoryValuation(ctx, req.(*GetInventoryValuationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_WatchItems_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchItemsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryServiceServer).WatchItems(m, &grpc.GenericServerStream[WatchItemsRequest, ItemChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_WatchItemsServer = grpc.ServerStreamingServer[ItemChange]
+
 // InventoryService_ServiceDesc is the grpc.ServiceDesc for InventoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -421,6 +687,14 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseReservation",
 			Handler:    _InventoryService_ReleaseReservation_Handler,
 		},
+		{
+			MethodName: "CreateBackorder",
+			Handler:    _InventoryService_CreateBackorder_Handler,
+		},
+		{
+			MethodName: "CancelBackorder",
+			Handler:    _InventoryService_CancelBackorder_Handler,
+		},
 		{
 			MethodName: "GetItem",
 			Handler:    _InventoryService_GetItem_Handler,
@@ -441,7 +715,29 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetItemsByCategory",
 			Handler:    _InventoryService_GetItemsByCategory_Handler,
 		},
+		{
+			MethodName: "GetAvailabilitySummary",
+			Handler:    _InventoryService_GetAvailabilitySummary_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _InventoryService_GetVersion_Handler,
+		},
+		{
+			MethodName: "GetSerialNumbers",
+			Handler:    _InventoryService_GetSerialNumbers_Handler,
+		},
+		{
+			MethodName: "GetInventoryValuation",
+			Handler:    _InventoryService_GetInventoryValuation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchItems",
+			Handler:       _InventoryService_WatchItems_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "proto/inventory/inventory.proto",
+	Metadata: "inventory/v1/inventory.proto",
 }
@@ -0,0 +1,282 @@
+// Package money provides the exact money type shared by the services. Amounts
+// are held as integers in the minor unit of their currency, which keeps order
+// totals, payments and refunds free of floating point rounding. Money crosses
+// gRPC boundaries as money.v1.Money (see ToProto and FromProto).
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	moneyv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/money/v1"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("money: currencies do not match")
+	ErrInvalidAmount    = errors.New("money: invalid amount")
+	ErrInvalidCurrency  = errors.New("money: invalid currency code")
+)
+
+// exponents lists the ISO 4217 currencies whose minor unit is not a hundredth
+var exponents = map[string]int{
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLP": 0, "ISK": 0, "JPY": 0, "KRW": 0, "PYG": 0, "UGX": 0, "VND": 0, "XAF": 0, "XOF": 0,
+}
+
+// Exponent returns the number of decimal places of the currency's minor unit
+func Exponent(currency string) int {
+	if exponent, ok := exponents[strings.ToUpper(currency)]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// ValidCurrency reports whether code looks like an ISO 4217 currency code
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Money is an exact amount of money in the minor unit of its currency
+type Money struct {
+	Minor    int64  // Amount in minor units, e.g. 1250 for 12.50 USD
+	Currency string // ISO 4217 currency code
+}
+
+// New returns an amount given in minor units
+func New(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: strings.ToUpper(currency)}
+}
+
+// Zero returns a zero amount in the currency
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// Parse parses a decimal amount such as "12.50" or "-3". Digits beyond the
+// currency's minor unit are rounded half away from zero.
+func Parse(amount, currency string) (Money, error) {
+	s := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	for _, part := range []string{whole, fraction} {
+		if strings.Trim(part, "0123456789") != "" {
+			return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+		}
+	}
+
+	exponent := Exponent(currency)
+	roundUp := false
+	if len(fraction) > exponent {
+		roundUp = fraction[exponent] >= '5'
+		fraction = fraction[:exponent]
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	digits := strings.TrimLeft(whole+fraction, "0")
+	if digits == "" {
+		digits = "0"
+	}
+	minor, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	if roundUp {
+		minor++
+	}
+	if negative {
+		minor = -minor
+	}
+	return New(minor, currency), nil
+}
+
+// FromFloat converts a legacy floating point amount. The float's shortest decimal
+// representation is used, so 0.285 becomes 29 cents rather than 28.
+func FromFloat(amount float64, currency string) Money {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Zero(currency)
+	}
+	m, err := Parse(strconv.FormatFloat(amount, 'f', -1, 64), currency)
+	if err != nil {
+		// Only reachable for amounts beyond the int64 range of minor units
+		if amount < 0 {
+			return New(math.MinInt64, currency)
+		}
+		return New(math.MaxInt64, currency)
+	}
+	return m
+}
+
+// FromProto converts the wire representation; nil is a zero amount without currency
+func FromProto(m *moneyv1.Money) Money {
+	if m == nil {
+		return Money{}
+	}
+	return New(m.GetMinorUnits(), m.GetCurrency())
+}
+
+// ToProto converts to the wire representation
+func (m Money) ToProto() *moneyv1.Money {
+	return &moneyv1.Money{MinorUnits: m.Minor, Currency: m.Currency}
+}
+
+// Float64 returns the amount in major units. It is meant for legacy fields,
+// metrics and display only; never compute with the result.
+func (m Money) Float64() float64 {
+	return float64(m.Minor) / math.Pow10(Exponent(m.Currency))
+}
+
+// Amount formats the amount in major units with the currency's decimal places, e.g. "12.50"
+func (m Money) Amount() string {
+	exponent := Exponent(m.Currency)
+	minor := m.Minor
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(absUint(minor), 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// String formats the amount with its currency, e.g. "12.50 USD"
+func (m Money) String() string {
+	return m.Amount() + " " + m.Currency
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool { return m.Minor == 0 }
+
+// IsNegative reports whether the amount is below zero
+func (m Money) IsNegative() bool { return m.Minor < 0 }
+
+// IsPositive reports whether the amount is above zero
+func (m Money) IsPositive() bool { return m.Minor > 0 }
+
+// Equals reports whether both amounts and currencies are equal
+func (m Money) Equals(other Money) bool {
+	return m.Minor == other.Minor && m.Currency == other.Currency
+}
+
+// Add returns the sum of two amounts of the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return New(m.Minor+other.Minor, m.Currency), nil
+}
+
+// Sub returns the difference of two amounts of the same currency
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return New(m.Minor-other.Minor, m.Currency), nil
+}
+
+// Compare returns -1, 0 or 1 as m is less than, equal to or greater than other
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Minor < other.Minor:
+		return -1, nil
+	case m.Minor > other.Minor:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Mul multiplies the amount by a whole quantity, e.g. a unit price by the units ordered
+func (m Money) Mul(quantity int64) Money {
+	return New(m.Minor*quantity, m.Currency)
+}
+
+// MulQuantity multiplies the amount by a fractional quantity, e.g. a price per
+// kilogram by 12.5 kg, rounding half away from zero to the minor unit
+func (m Money) MulQuantity(quantity float64) Money {
+	return New(int64(math.Round(float64(m.Minor)*quantity)), m.Currency)
+}
+
+// Sum adds up amounts of the given currency
+func Sum(currency string, amounts ...Money) (Money, error) {
+	total := Zero(currency)
+	for _, amount := range amounts {
+		var err error
+		if total, err = total.Add(amount); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if m.Currency != other.Currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return nil
+}
+
+func absUint(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+	return uint64(v)
+}
+
+// Convert returns the amount in currency at rate, the price of one unit of the
+// amount's currency in currency, rounded half away from zero to the minor unit.
+// Conversions are meant for display; orders and payments stay in the price's
+// own currency.
+func (m Money) Convert(currency string, rate *big.Rat) Money {
+	value := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Minor), rate)
+	shift := Exponent(currency) - Exponent(m.Currency)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(absInt(shift))), nil))
+	if shift >= 0 {
+		value.Mul(value, scale)
+	} else {
+		value.Quo(value, scale)
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(value.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(value.Sign())))
+	}
+	if !quotient.IsInt64() {
+		if quotient.Sign() < 0 {
+			return New(math.MinInt64, currency)
+		}
+		return New(math.MaxInt64, currency)
+	}
+	return New(quotient.Int64(), currency)
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,173 @@
+// Package pagination provides the page tokens and page metadata shared by the
+// list APIs of the services. A page token is an opaque, URL-safe encoding of a
+// Cursor bound to the query it was issued for, so a token cannot be replayed
+// against different filters. PageInfo is returned as-is in HTTP responses and
+// crosses gRPC boundaries as pagination.v1.PageInfo (see ToProto).
+package pagination
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	paginationv1 "github.com/amiosamu/rocket-science/shared/contracts/proto/pagination/v1"
+)
+
+// ErrInvalidPageToken is matched (via errors.Is) by every rejected page token
+var ErrInvalidPageToken = errors.New("pagination: invalid page token")
+
+// tokenVersion prefixes encoded cursors so the format can change without
+// misreading tokens issued by an older release
+const tokenVersion = "v1."
+
+// Limits are the page sizes a list API applies
+type Limits struct {
+	Default int // Used when the client asks for no particular size
+	Max     int // Larger requests are capped to this
+}
+
+// PageSize returns the page size to apply for the requested one
+func (l Limits) PageSize(requested int) int {
+	switch {
+	case requested <= 0:
+		return l.Default
+	case requested > l.Max:
+		return l.Max
+	default:
+		return requested
+	}
+}
+
+// Cursor is the position a page token points at. Lists sorted on a unique key
+// resume after the sort key values of the last item returned (keyset paging);
+// lists without one skip the items already returned.
+type Cursor struct {
+	After  []string `json:"a,omitempty"` // Sort key values of the last item on the previous page
+	Offset int      `json:"o,omitempty"` // Items returned on the previous pages
+	Query  string   `json:"q"`           // Fingerprint of the query the token was issued for
+}
+
+// IsFirstPage reports whether the cursor points at the start of the list
+func (c Cursor) IsFirstPage() bool {
+	return len(c.After) == 0 && c.Offset == 0
+}
+
+// Fingerprint identifies a query by its filters and sort order. Pass the same
+// parts, in the same order, when issuing and when decoding tokens.
+func Fingerprint(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// EncodeToken encodes a cursor as a page token
+func EncodeToken(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return tokenVersion + base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeToken decodes a page token issued for the query with the given
+// fingerprint. An empty token is the first page.
+func DecodeToken(token, query string) (Cursor, error) {
+	if token == "" {
+		return Cursor{Query: query}, nil
+	}
+
+	encoded, ok := strings.CutPrefix(token, tokenVersion)
+	if !ok {
+		return Cursor{}, fmt.Errorf("%w: unknown format", ErrInvalidPageToken)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: malformed", ErrInvalidPageToken)
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Cursor{}, fmt.Errorf("%w: malformed", ErrInvalidPageToken)
+	}
+	if c.Query != query {
+		return Cursor{}, fmt.Errorf("%w: issued for a different query", ErrInvalidPageToken)
+	}
+	if c.Offset < 0 {
+		return Cursor{}, fmt.Errorf("%w: negative offset", ErrInvalidPageToken)
+	}
+	return c, nil
+}
+
+// PageInfo describes a returned page and how to fetch the next one
+type PageInfo struct {
+	NextPageToken string `json:"next_page_token,omitempty"` // Empty on the last page
+	HasMore       bool   `json:"has_more"`
+	PageSize      int    `json:"page_size"`
+	TotalCount    *int64 `json:"total_count,omitempty"` // Set when the list counts matching items
+}
+
+// WithTotal sets the number of items matching the query
+func (p PageInfo) WithTotal(total int) PageInfo {
+	count := int64(total)
+	p.TotalCount = &count
+	return p
+}
+
+// ToProto converts to the wire representation
+func (p PageInfo) ToProto() *paginationv1.PageInfo {
+	info := &paginationv1.PageInfo{
+		NextPageToken: p.NextPageToken,
+		HasMore:       p.HasMore,
+		PageSize:      int32(p.PageSize),
+	}
+	if p.TotalCount != nil {
+		total := *p.TotalCount
+		info.TotalCount = &total
+	}
+	return info
+}
+
+// FromProto converts the wire representation; nil is an empty last page
+func FromProto(p *paginationv1.PageInfo) PageInfo {
+	if p == nil {
+		return PageInfo{}
+	}
+	info := PageInfo{
+		NextPageToken: p.GetNextPageToken(),
+		HasMore:       p.GetHasMore(),
+		PageSize:      int(p.GetPageSize()),
+	}
+	if p.TotalCount != nil {
+		total := p.GetTotalCount()
+		info.TotalCount = &total
+	}
+	return info
+}
+
+// Trim cuts items fetched with one extra item (pageSize+1) down to the page and
+// reports whether another page follows
+func Trim[T any](items []T, pageSize int) ([]T, bool) {
+	if len(items) > pageSize {
+		return items[:pageSize], true
+	}
+	return items, false
+}
+
+// KeysetPage builds the page info of a keyset-paged list. afterLast returns the
+// sort key values of the last item on the page.
+func KeysetPage(cursor Cursor, pageSize int, hasMore bool, afterLast func() []string) PageInfo {
+	info := PageInfo{HasMore: hasMore, PageSize: pageSize}
+	if hasMore {
+		info.NextPageToken = EncodeToken(Cursor{After: afterLast(), Query: cursor.Query})
+	}
+	return info
+}
+
+// OffsetPage builds the page info of an offset-paged list that returned count items
+func OffsetPage(cursor Cursor, pageSize, count int, hasMore bool) PageInfo {
+	info := PageInfo{HasMore: hasMore, PageSize: pageSize}
+	if hasMore {
+		info.NextPageToken = EncodeToken(Cursor{Offset: cursor.Offset + count, Query: cursor.Query})
+	}
+	return info
+}
@@ -2,11 +2,12 @@
 // versions:
 // 	protoc-gen-go v1.36.6
 // 	protoc        v5.29.3
-// source: proto/payment/payment.proto
+// source: payment/v1/payment.proto
 
-package payment
+package paymentv1
 
 import (
+	v1 "github.com/amiosamu/rocket-science/shared/contracts/proto/money/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -59,11 +60,11 @@ func (x PaymentType) String() string {
 }
 
 func (PaymentType) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_payment_payment_proto_enumTypes[0].Descriptor()
+	return file_payment_v1_payment_proto_enumTypes[0].Descriptor()
 }
 
 func (PaymentType) Type() protoreflect.EnumType {
-	return &file_proto_payment_payment_proto_enumTypes[0]
+	return &file_payment_v1_payment_proto_enumTypes[0]
 }
 
 func (x PaymentType) Number() protoreflect.EnumNumber {
@@ -72,7 +73,7 @@ func (x PaymentType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PaymentType.Descriptor instead.
 func (PaymentType) EnumDescriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{0}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{0}
 }
 
 // PaymentStatus enum for tracking payment states
@@ -86,6 +87,7 @@ const (
 	PaymentStatus_PAYMENT_STATUS_CANCELLED      PaymentStatus = 4 // Payment was cancelled
 	PaymentStatus_PAYMENT_STATUS_REFUNDED       PaymentStatus = 5 // Payment was refunded
 	PaymentStatus_PAYMENT_STATUS_PARTIAL_REFUND PaymentStatus = 6 // Payment was partially refunded
+	PaymentStatus_PAYMENT_STATUS_PENDING_REVIEW PaymentStatus = 7 // Payment is held for manual review
 )
 
 // Enum value maps for PaymentStatus.
@@ -98,6 +100,7 @@ var (
 		4: "PAYMENT_STATUS_CANCELLED",
 		5: "PAYMENT_STATUS_REFUNDED",
 		6: "PAYMENT_STATUS_PARTIAL_REFUND",
+		7: "PAYMENT_STATUS_PENDING_REVIEW",
 	}
 	PaymentStatus_value = map[string]int32{
 		"PAYMENT_STATUS_UNSPECIFIED":    0,
@@ -107,6 +110,7 @@ var (
 		"PAYMENT_STATUS_CANCELLED":      4,
 		"PAYMENT_STATUS_REFUNDED":       5,
 		"PAYMENT_STATUS_PARTIAL_REFUND": 6,
+		"PAYMENT_STATUS_PENDING_REVIEW": 7,
 	}
 )
 
@@ -121,11 +125,11 @@ func (x PaymentStatus) String() string {
 }
 
 func (PaymentStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_payment_payment_proto_enumTypes[1].Descriptor()
+	return file_payment_v1_payment_proto_enumTypes[1].Descriptor()
 }
 
 func (PaymentStatus) Type() protoreflect.EnumType {
-	return &file_proto_payment_payment_proto_enumTypes[1]
+	return &file_payment_v1_payment_proto_enumTypes[1]
 }
 
 func (x PaymentStatus) Number() protoreflect.EnumNumber {
@@ -134,7 +138,57 @@ func (x PaymentStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PaymentStatus.Descriptor instead.
 func (PaymentStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{1}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{1}
+}
+
+// ReviewDecision enum for manual review outcomes
+type ReviewDecision int32
+
+const (
+	ReviewDecision_REVIEW_DECISION_UNSPECIFIED ReviewDecision = 0
+	ReviewDecision_REVIEW_DECISION_APPROVE     ReviewDecision = 1
+	ReviewDecision_REVIEW_DECISION_DECLINE     ReviewDecision = 2
+)
+
+// Enum value maps for ReviewDecision.
+var (
+	ReviewDecision_name = map[int32]string{
+		0: "REVIEW_DECISION_UNSPECIFIED",
+		1: "REVIEW_DECISION_APPROVE",
+		2: "REVIEW_DECISION_DECLINE",
+	}
+	ReviewDecision_value = map[string]int32{
+		"REVIEW_DECISION_UNSPECIFIED": 0,
+		"REVIEW_DECISION_APPROVE":     1,
+		"REVIEW_DECISION_DECLINE":     2,
+	}
+)
+
+func (x ReviewDecision) Enum() *ReviewDecision {
+	p := new(ReviewDecision)
+	*p = x
+	return p
+}
+
+func (x ReviewDecision) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewDecision) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_v1_payment_proto_enumTypes[2].Descriptor()
+}
+
+func (ReviewDecision) Type() protoreflect.EnumType {
+	return &file_payment_v1_payment_proto_enumTypes[2]
+}
+
+func (x ReviewDecision) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewDecision.Descriptor instead.
+func (ReviewDecision) EnumDescriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{2}
 }
 
 // ProcessPaymentRequest contains payment processing details
@@ -142,17 +196,19 @@ type ProcessPaymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                   // Unique order identifier
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                      // User making the payment
-	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`                                  // Payment amount in USD
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`                                  // Payment amount (deprecated: use exact_amount)
 	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`                                // Currency code (e.g., "USD")
 	PaymentMethod *PaymentMethod         `protobuf:"bytes,5,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"` // Payment method details
 	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`                          // Payment description
+	ExactAmount   *v1.Money              `protobuf:"bytes,7,opt,name=exact_amount,json=exactAmount,proto3" json:"exact_amount,omitempty"`       // Exact payment amount; takes precedence over amount and currency
+	Sandbox       bool                   `protobuf:"varint,8,opt,name=sandbox,proto3" json:"sandbox,omitempty"`                                 // Test payment of a sandbox tenant, charged by the payment simulator
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProcessPaymentRequest) Reset() {
 	*x = ProcessPaymentRequest{}
-	mi := &file_proto_payment_payment_proto_msgTypes[0]
+	mi := &file_payment_v1_payment_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -164,7 +220,7 @@ func (x *ProcessPaymentRequest) String() string {
 func (*ProcessPaymentRequest) ProtoMessage() {}
 
 func (x *ProcessPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[0]
+	mi := &file_payment_v1_payment_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -177,7 +233,7 @@ func (x *ProcessPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessPaymentRequest.ProtoReflect.Descriptor instead.
 func (*ProcessPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{0}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *ProcessPaymentRequest) GetOrderId() string {
@@ -222,23 +278,38 @@ func (x *ProcessPaymentRequest) GetDescription() string {
 	return ""
 }
 
+func (x *ProcessPaymentRequest) GetExactAmount() *v1.Money {
+	if x != nil {
+		return x.ExactAmount
+	}
+	return nil
+}
+
+func (x *ProcessPaymentRequest) GetSandbox() bool {
+	if x != nil {
+		return x.Sandbox
+	}
+	return false
+}
+
 // ProcessPaymentResponse contains payment processing result
 type ProcessPaymentResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                                         // Whether payment was successful
-	TransactionId   string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`         // Unique transaction identifier
-	Message         string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                                          // Success or error message
-	Status          PaymentStatus          `protobuf:"varint,4,opt,name=status,proto3,enum=payment.v1.PaymentStatus" json:"status,omitempty"`             // Payment status
-	ProcessedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`               // When payment was processed
-	ProcessedAmount float64                `protobuf:"fixed64,6,opt,name=processed_amount,json=processedAmount,proto3" json:"processed_amount,omitempty"` // Actually processed amount
-	Currency        string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`                                        // Currency used
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Success              bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                                                        // Whether payment was successful
+	TransactionId        string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`                        // Unique transaction identifier
+	Message              string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                                                         // Success or error message
+	Status               PaymentStatus          `protobuf:"varint,4,opt,name=status,proto3,enum=payment.v1.PaymentStatus" json:"status,omitempty"`                            // Payment status
+	ProcessedAt          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`                              // When payment was processed
+	ProcessedAmount      float64                `protobuf:"fixed64,6,opt,name=processed_amount,json=processedAmount,proto3" json:"processed_amount,omitempty"`                // Actually processed amount
+	Currency             string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`                                                       // Currency used
+	ExactProcessedAmount *v1.Money              `protobuf:"bytes,8,opt,name=exact_processed_amount,json=exactProcessedAmount,proto3" json:"exact_processed_amount,omitempty"` // Exact processed amount
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *ProcessPaymentResponse) Reset() {
 	*x = ProcessPaymentResponse{}
-	mi := &file_proto_payment_payment_proto_msgTypes[1]
+	mi := &file_payment_v1_payment_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -250,7 +321,7 @@ func (x *ProcessPaymentResponse) String() string {
 func (*ProcessPaymentResponse) ProtoMessage() {}
 
 func (x *ProcessPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[1]
+	mi := &file_payment_v1_payment_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -263,7 +334,7 @@ func (x *ProcessPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessPaymentResponse.ProtoReflect.Descriptor instead.
 func (*ProcessPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{1}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *ProcessPaymentResponse) GetSuccess() bool {
@@ -315,6 +386,13 @@ func (x *ProcessPaymentResponse) GetCurrency() string {
 	return ""
 }
 
+func (x *ProcessPaymentResponse) GetExactProcessedAmount() *v1.Money {
+	if x != nil {
+		return x.ExactProcessedAmount
+	}
+	return nil
+}
+
 // GetPaymentStatusRequest for checking payment status
 type GetPaymentStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -326,7 +404,7 @@ type GetPaymentStatusRequest struct {
 
 func (x *GetPaymentStatusRequest) Reset() {
 	*x = GetPaymentStatusRequest{}
-	mi := &file_proto_payment_payment_proto_msgTypes[2]
+	mi := &file_payment_v1_payment_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -338,7 +416,7 @@ func (x *GetPaymentStatusRequest) String() string {
 func (*GetPaymentStatusRequest) ProtoMessage() {}
 
 func (x *GetPaymentStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[2]
+	mi := &file_payment_v1_payment_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -351,7 +429,7 @@ func (x *GetPaymentStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetPaymentStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{2}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetPaymentStatusRequest) GetTransactionId() string {
@@ -380,13 +458,14 @@ type GetPaymentStatusResponse struct {
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`             // When payment was created
 	ProcessedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`       // When payment was processed
 	Message       string                 `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`                                  // Status message
+	ExactAmount   *v1.Money              `protobuf:"bytes,10,opt,name=exact_amount,json=exactAmount,proto3" json:"exact_amount,omitempty"`      // Exact payment amount
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetPaymentStatusResponse) Reset() {
 	*x = GetPaymentStatusResponse{}
-	mi := &file_proto_payment_payment_proto_msgTypes[3]
+	mi := &file_payment_v1_payment_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -398,7 +477,7 @@ func (x *GetPaymentStatusResponse) String() string {
 func (*GetPaymentStatusResponse) ProtoMessage() {}
 
 func (x *GetPaymentStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[3]
+	mi := &file_payment_v1_payment_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -411,7 +490,7 @@ func (x *GetPaymentStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentStatusResponse.ProtoReflect.Descriptor instead.
 func (*GetPaymentStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{3}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetPaymentStatusResponse) GetFound() bool {
@@ -477,6 +556,13 @@ func (x *GetPaymentStatusResponse) GetMessage() string {
 	return ""
 }
 
+func (x *GetPaymentStatusResponse) GetExactAmount() *v1.Money {
+	if x != nil {
+		return x.ExactAmount
+	}
+	return nil
+}
+
 // RefundPaymentRequest for processing refunds
 type RefundPaymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -484,13 +570,15 @@ type RefundPaymentRequest struct {
 	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`                                  // Refund amount (can be partial)
 	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                    // Refund reason
 	RequestedBy   string                 `protobuf:"bytes,4,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`       // User requesting refund
+	ExactAmount   *v1.Money              `protobuf:"bytes,5,opt,name=exact_amount,json=exactAmount,proto3" json:"exact_amount,omitempty"`       // Exact refund amount; takes precedence over amount
+	OrderId       string                 `protobuf:"bytes,6,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                   // Without transaction_id, refunds the completed payments of the order in full
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RefundPaymentRequest) Reset() {
 	*x = RefundPaymentRequest{}
-	mi := &file_proto_payment_payment_proto_msgTypes[4]
+	mi := &file_payment_v1_payment_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -502,7 +590,7 @@ func (x *RefundPaymentRequest) String() string {
 func (*RefundPaymentRequest) ProtoMessage() {}
 
 func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[4]
+	mi := &file_payment_v1_payment_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,7 +603,7 @@ func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
 func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{4}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RefundPaymentRequest) GetTransactionId() string {
@@ -546,6 +634,20 @@ func (x *RefundPaymentRequest) GetRequestedBy() string {
 	return ""
 }
 
+func (x *RefundPaymentRequest) GetExactAmount() *v1.Money {
+	if x != nil {
+		return x.ExactAmount
+	}
+	return nil
+}
+
+func (x *RefundPaymentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
 // RefundPaymentResponse contains refund processing result
 type RefundPaymentResponse struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
@@ -555,13 +657,14 @@ type RefundPaymentResponse struct {
 	RefundedAmount        float64                `protobuf:"fixed64,4,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`                      // Actually refunded amount
 	Message               string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`                                                            // Success or error message
 	ProcessedAt           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`                                 // When refund was processed
+	ExactRefundedAmount   *v1.Money              `protobuf:"bytes,7,opt,name=exact_refunded_amount,json=exactRefundedAmount,proto3" json:"exact_refunded_amount,omitempty"`       // Exact refunded amount
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
 
 func (x *RefundPaymentResponse) Reset() {
 	*x = RefundPaymentResponse{}
-	mi := &file_proto_payment_payment_proto_msgTypes[5]
+	mi := &file_payment_v1_payment_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -573,7 +676,7 @@ func (x *RefundPaymentResponse) String() string {
 func (*RefundPaymentResponse) ProtoMessage() {}
 
 func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[5]
+	mi := &file_payment_v1_payment_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -586,7 +689,7 @@ func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefundPaymentResponse.ProtoReflect.Descriptor instead.
 func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{5}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *RefundPaymentResponse) GetSuccess() bool {
@@ -631,6 +734,281 @@ func (x *RefundPaymentResponse) GetProcessedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *RefundPaymentResponse) GetExactRefundedAmount() *v1.Money {
+	if x != nil {
+		return x.ExactRefundedAmount
+	}
+	return nil
+}
+
+// ReviewPaymentRequest records a manual review decision for a flagged payment
+type ReviewPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`  // Transaction held for review
+	Decision      ReviewDecision         `protobuf:"varint,2,opt,name=decision,proto3,enum=payment.v1.ReviewDecision" json:"decision,omitempty"` // Approve or decline
+	Reviewer      string                 `protobuf:"bytes,3,opt,name=reviewer,proto3" json:"reviewer,omitempty"`                                 // Who made the decision
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                     // Decision rationale (required when declining)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPaymentRequest) Reset() {
+	*x = ReviewPaymentRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPaymentRequest) ProtoMessage() {}
+
+func (x *ReviewPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPaymentRequest.ProtoReflect.Descriptor instead.
+func (*ReviewPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReviewPaymentRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ReviewPaymentRequest) GetDecision() ReviewDecision {
+	if x != nil {
+		return x.Decision
+	}
+	return ReviewDecision_REVIEW_DECISION_UNSPECIFIED
+}
+
+func (x *ReviewPaymentRequest) GetReviewer() string {
+	if x != nil {
+		return x.Reviewer
+	}
+	return ""
+}
+
+func (x *ReviewPaymentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ReviewPaymentResponse contains the payment state after the review decision
+type ReviewPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                                 // Whether the decision was applied
+	TransactionId string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Transaction identifier
+	Status        PaymentStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=payment.v1.PaymentStatus" json:"status,omitempty"`     // Payment status after the decision
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                                  // Result message
+	ProcessedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`       // When the payment was processed (approved payments only)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPaymentResponse) Reset() {
+	*x = ReviewPaymentResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPaymentResponse) ProtoMessage() {}
+
+func (x *ReviewPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPaymentResponse.ProtoReflect.Descriptor instead.
+func (*ReviewPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReviewPaymentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReviewPaymentResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ReviewPaymentResponse) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *ReviewPaymentResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReviewPaymentResponse) GetProcessedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ProcessedAt
+	}
+	return nil
+}
+
+// GetVersionRequest requests build information of the running service
+type GetVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionRequest) Reset() {
+	*x = GetVersionRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionRequest) ProtoMessage() {}
+
+func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetVersionRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{8}
+}
+
+// GetVersionResponse contains build information used for deployment verification
+type GetVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`                      // Service name
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                      // Release version
+	GitCommit     string                 `protobuf:"bytes,3,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"` // Git commit the binary was built from
+	BuildTime     string                 `protobuf:"bytes,4,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"` // Build timestamp (RFC 3339)
+	GoVersion     string                 `protobuf:"bytes,5,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"` // Go toolchain version
+	Platform      string                 `protobuf:"bytes,6,opt,name=platform,proto3" json:"platform,omitempty"`                    // OS/architecture
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionResponse) Reset() {
+	*x = GetVersionResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionResponse) ProtoMessage() {}
+
+func (x *GetVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetVersionResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetVersionResponse) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetBuildTime() string {
+	if x != nil {
+		return x.BuildTime
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
 // PaymentMethod represents different payment options
 type PaymentMethod struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -644,7 +1022,7 @@ type PaymentMethod struct {
 
 func (x *PaymentMethod) Reset() {
 	*x = PaymentMethod{}
-	mi := &file_proto_payment_payment_proto_msgTypes[6]
+	mi := &file_payment_v1_payment_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -656,7 +1034,7 @@ func (x *PaymentMethod) String() string {
 func (*PaymentMethod) ProtoMessage() {}
 
 func (x *PaymentMethod) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[6]
+	mi := &file_payment_v1_payment_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,7 +1047,7 @@ func (x *PaymentMethod) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PaymentMethod.ProtoReflect.Descriptor instead.
 func (*PaymentMethod) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{6}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PaymentMethod) GetType() PaymentType {
@@ -714,7 +1092,7 @@ type CreditCard struct {
 
 func (x *CreditCard) Reset() {
 	*x = CreditCard{}
-	mi := &file_proto_payment_payment_proto_msgTypes[7]
+	mi := &file_payment_v1_payment_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -726,7 +1104,7 @@ func (x *CreditCard) String() string {
 func (*CreditCard) ProtoMessage() {}
 
 func (x *CreditCard) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[7]
+	mi := &file_payment_v1_payment_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -739,7 +1117,7 @@ func (x *CreditCard) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreditCard.ProtoReflect.Descriptor instead.
 func (*CreditCard) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{7}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreditCard) GetMaskedNumber() string {
@@ -790,7 +1168,7 @@ type BankTransfer struct {
 
 func (x *BankTransfer) Reset() {
 	*x = BankTransfer{}
-	mi := &file_proto_payment_payment_proto_msgTypes[8]
+	mi := &file_payment_v1_payment_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -802,7 +1180,7 @@ func (x *BankTransfer) String() string {
 func (*BankTransfer) ProtoMessage() {}
 
 func (x *BankTransfer) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[8]
+	mi := &file_payment_v1_payment_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -815,7 +1193,7 @@ func (x *BankTransfer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BankTransfer.ProtoReflect.Descriptor instead.
 func (*BankTransfer) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{8}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BankTransfer) GetBankName() string {
@@ -858,7 +1236,7 @@ type DigitalWallet struct {
 
 func (x *DigitalWallet) Reset() {
 	*x = DigitalWallet{}
-	mi := &file_proto_payment_payment_proto_msgTypes[9]
+	mi := &file_payment_v1_payment_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -870,7 +1248,7 @@ func (x *DigitalWallet) String() string {
 func (*DigitalWallet) ProtoMessage() {}
 
 func (x *DigitalWallet) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[9]
+	mi := &file_payment_v1_payment_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -883,7 +1261,7 @@ func (x *DigitalWallet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DigitalWallet.ProtoReflect.Descriptor instead.
 func (*DigitalWallet) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{9}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DigitalWallet) GetProvider() string {
@@ -907,19 +1285,21 @@ func (x *DigitalWallet) GetEmail() string {
 	return ""
 }
 
-var File_proto_payment_payment_proto protoreflect.FileDescriptor
+var File_payment_v1_payment_proto protoreflect.FileDescriptor
 
-const file_proto_payment_payment_proto_rawDesc = "" +
+const file_payment_v1_payment_proto_rawDesc = "" +
 	"\n" +
-	"\x1bproto/payment/payment.proto\x12\n" +
-	"payment.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe3\x01\n" +
+	"\x18payment/v1/payment.proto\x12\n" +
+	"payment.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x14money/v1/money.proto\"\xb1\x02\n" +
 	"\x15ProcessPaymentRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
 	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
 	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12@\n" +
 	"\x0epayment_method\x18\x05 \x01(\v2\x19.payment.v1.PaymentMethodR\rpaymentMethod\x12 \n" +
-	"\vdescription\x18\x06 \x01(\tR\vdescription\"\xac\x02\n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x122\n" +
+	"\fexact_amount\x18\a \x01(\v2\x0f.money.v1.MoneyR\vexactAmount\x12\x18\n" +
+	"\asandbox\x18\b \x01(\bR\asandbox\"\xf3\x02\n" +
 	"\x16ProcessPaymentResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12%\n" +
 	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12\x18\n" +
@@ -927,10 +1307,11 @@ const file_proto_payment_payment_proto_rawDesc = "" +
 	"\x06status\x18\x04 \x01(\x0e2\x19.payment.v1.PaymentStatusR\x06status\x12=\n" +
 	"\fprocessed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\x12)\n" +
 	"\x10processed_amount\x18\x06 \x01(\x01R\x0fprocessedAmount\x12\x1a\n" +
-	"\bcurrency\x18\a \x01(\tR\bcurrency\"[\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12E\n" +
+	"\x16exact_processed_amount\x18\b \x01(\v2\x0f.money.v1.MoneyR\x14exactProcessedAmount\"[\n" +
 	"\x17GetPaymentStatusRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x19\n" +
-	"\border_id\x18\x02 \x01(\tR\aorderId\"\xed\x02\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"\xa1\x03\n" +
 	"\x18GetPaymentStatusResponse\x12\x14\n" +
 	"\x05found\x18\x01 \x01(\bR\x05found\x12%\n" +
 	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12\x19\n" +
@@ -941,19 +1322,46 @@ const file_proto_payment_payment_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
 	"\fprocessed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\x12\x18\n" +
-	"\amessage\x18\t \x01(\tR\amessage\"\x90\x01\n" +
+	"\amessage\x18\t \x01(\tR\amessage\x122\n" +
+	"\fexact_amount\x18\n" +
+	" \x01(\v2\x0f.money.v1.MoneyR\vexactAmount\"\xdf\x01\n" +
 	"\x14RefundPaymentRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x16\n" +
 	"\x06reason\x18\x03 \x01(\tR\x06reason\x12!\n" +
-	"\frequested_by\x18\x04 \x01(\tR\vrequestedBy\"\x88\x02\n" +
+	"\frequested_by\x18\x04 \x01(\tR\vrequestedBy\x122\n" +
+	"\fexact_amount\x18\x05 \x01(\v2\x0f.money.v1.MoneyR\vexactAmount\x12\x19\n" +
+	"\border_id\x18\x06 \x01(\tR\aorderId\"\xcd\x02\n" +
 	"\x15RefundPaymentResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1b\n" +
 	"\trefund_id\x18\x02 \x01(\tR\brefundId\x126\n" +
 	"\x17original_transaction_id\x18\x03 \x01(\tR\x15originalTransactionId\x12'\n" +
 	"\x0frefunded_amount\x18\x04 \x01(\x01R\x0erefundedAmount\x12\x18\n" +
 	"\amessage\x18\x05 \x01(\tR\amessage\x12=\n" +
-	"\fprocessed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\"\xf6\x01\n" +
+	"\fprocessed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\x12C\n" +
+	"\x15exact_refunded_amount\x18\a \x01(\v2\x0f.money.v1.MoneyR\x13exactRefundedAmount\"\xa9\x01\n" +
+	"\x14ReviewPaymentRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x126\n" +
+	"\bdecision\x18\x02 \x01(\x0e2\x1a.payment.v1.ReviewDecisionR\bdecision\x12\x1a\n" +
+	"\breviewer\x18\x03 \x01(\tR\breviewer\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xe4\x01\n" +
+	"\x15ReviewPaymentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x121\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x19.payment.v1.PaymentStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12=\n" +
+	"\fprocessed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\"\x13\n" +
+	"\x11GetVersionRequest\"\xc1\x01\n" +
+	"\x12GetVersionResponse\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"git_commit\x18\x03 \x01(\tR\tgitCommit\x12\x1d\n" +
+	"\n" +
+	"build_time\x18\x04 \x01(\tR\tbuildTime\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x05 \x01(\tR\tgoVersion\x12\x1a\n" +
+	"\bplatform\x18\x06 \x01(\tR\bplatform\"\xf6\x01\n" +
 	"\rPaymentMethod\x12+\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x17.payment.v1.PaymentTypeR\x04type\x127\n" +
 	"\vcredit_card\x18\x02 \x01(\v2\x16.payment.v1.CreditCardR\n" +
@@ -981,7 +1389,7 @@ const file_proto_payment_payment_proto_rawDesc = "" +
 	"\x18PAYMENT_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18PAYMENT_TYPE_CREDIT_CARD\x10\x01\x12\x1e\n" +
 	"\x1aPAYMENT_TYPE_BANK_TRANSFER\x10\x02\x12\x1f\n" +
-	"\x1bPAYMENT_TYPE_DIGITAL_WALLET\x10\x03*\xe2\x01\n" +
+	"\x1bPAYMENT_TYPE_DIGITAL_WALLET\x10\x03*\x85\x02\n" +
 	"\rPaymentStatus\x12\x1e\n" +
 	"\x1aPAYMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16PAYMENT_STATUS_PENDING\x10\x01\x12\x1c\n" +
@@ -989,87 +1397,113 @@ const file_proto_payment_payment_proto_rawDesc = "" +
 	"\x15PAYMENT_STATUS_FAILED\x10\x03\x12\x1c\n" +
 	"\x18PAYMENT_STATUS_CANCELLED\x10\x04\x12\x1b\n" +
 	"\x17PAYMENT_STATUS_REFUNDED\x10\x05\x12!\n" +
-	"\x1dPAYMENT_STATUS_PARTIAL_REFUND\x10\x062\x9e\x02\n" +
+	"\x1dPAYMENT_STATUS_PARTIAL_REFUND\x10\x06\x12!\n" +
+	"\x1dPAYMENT_STATUS_PENDING_REVIEW\x10\a*k\n" +
+	"\x0eReviewDecision\x12\x1f\n" +
+	"\x1bREVIEW_DECISION_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17REVIEW_DECISION_APPROVE\x10\x01\x12\x1b\n" +
+	"\x17REVIEW_DECISION_DECLINE\x10\x022\xc1\x03\n" +
 	"\x0ePaymentService\x12W\n" +
 	"\x0eProcessPayment\x12!.payment.v1.ProcessPaymentRequest\x1a\".payment.v1.ProcessPaymentResponse\x12]\n" +
 	"\x10GetPaymentStatus\x12#.payment.v1.GetPaymentStatusRequest\x1a$.payment.v1.GetPaymentStatusResponse\x12T\n" +
-	"\rRefundPayment\x12 .payment.v1.RefundPaymentRequest\x1a!.payment.v1.RefundPaymentResponseBKZIgithub.com/amiosamu/rocket-science/services/payment-service/proto/paymentb\x06proto3"
+	"\rRefundPayment\x12 .payment.v1.RefundPaymentRequest\x1a!.payment.v1.RefundPaymentResponse\x12T\n" +
+	"\rReviewPayment\x12 .payment.v1.ReviewPaymentRequest\x1a!.payment.v1.ReviewPaymentResponse\x12K\n" +
+	"\n" +
+	"GetVersion\x12\x1d.payment.v1.GetVersionRequest\x1a\x1e.payment.v1.GetVersionResponseBPZNgithub.com/amiosamu/rocket-science/shared/contracts/proto/payment/v1;paymentv1b\x06proto3"
 
 var (
-	file_proto_payment_payment_proto_rawDescOnce sync.Once
-	file_proto_payment_payment_proto_rawDescData []byte
+	file_payment_v1_payment_proto_rawDescOnce sync.Once
+	file_payment_v1_payment_proto_rawDescData []byte
 )
 
-func file_proto_payment_payment_proto_rawDescGZIP() []byte {
-	file_proto_payment_payment_proto_rawDescOnce.Do(func() {
-		file_proto_payment_payment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_payment_payment_proto_rawDesc), len(file_proto_payment_payment_proto_rawDesc)))
+func file_payment_v1_payment_proto_rawDescGZIP() []byte {
+	file_payment_v1_payment_proto_rawDescOnce.Do(func() {
+		file_payment_v1_payment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payment_v1_payment_proto_rawDesc), len(file_payment_v1_payment_proto_rawDesc)))
 	})
-	return file_proto_payment_payment_proto_rawDescData
+	return file_payment_v1_payment_proto_rawDescData
 }
 
-var file_proto_payment_payment_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_payment_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
-var file_proto_payment_payment_proto_goTypes = []any{
+var file_payment_v1_payment_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_payment_v1_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_payment_v1_payment_proto_goTypes = []any{
 	(PaymentType)(0),                 // 0: payment.v1.PaymentType
 	(PaymentStatus)(0),               // 1: payment.v1.PaymentStatus
-	(*ProcessPaymentRequest)(nil),    // 2: payment.v1.ProcessPaymentRequest
-	(*ProcessPaymentResponse)(nil),   // 3: payment.v1.ProcessPaymentResponse
-	(*GetPaymentStatusRequest)(nil),  // 4: payment.v1.GetPaymentStatusRequest
-	(*GetPaymentStatusResponse)(nil), // 5: payment.v1.GetPaymentStatusResponse
-	(*RefundPaymentRequest)(nil),     // 6: payment.v1.RefundPaymentRequest
-	(*RefundPaymentResponse)(nil),    // 7: payment.v1.RefundPaymentResponse
-	(*PaymentMethod)(nil),            // 8: payment.v1.PaymentMethod
-	(*CreditCard)(nil),               // 9: payment.v1.CreditCard
-	(*BankTransfer)(nil),             // 10: payment.v1.BankTransfer
-	(*DigitalWallet)(nil),            // 11: payment.v1.DigitalWallet
-	(*timestamppb.Timestamp)(nil),    // 12: google.protobuf.Timestamp
-}
-var file_proto_payment_payment_proto_depIdxs = []int32{
-	8,  // 0: payment.v1.ProcessPaymentRequest.payment_method:type_name -> payment.v1.PaymentMethod
-	1,  // 1: payment.v1.ProcessPaymentResponse.status:type_name -> payment.v1.PaymentStatus
-	12, // 2: payment.v1.ProcessPaymentResponse.processed_at:type_name -> google.protobuf.Timestamp
-	1,  // 3: payment.v1.GetPaymentStatusResponse.status:type_name -> payment.v1.PaymentStatus
-	12, // 4: payment.v1.GetPaymentStatusResponse.created_at:type_name -> google.protobuf.Timestamp
-	12, // 5: payment.v1.GetPaymentStatusResponse.processed_at:type_name -> google.protobuf.Timestamp
-	12, // 6: payment.v1.RefundPaymentResponse.processed_at:type_name -> google.protobuf.Timestamp
-	0,  // 7: payment.v1.PaymentMethod.type:type_name -> payment.v1.PaymentType
-	9,  // 8: payment.v1.PaymentMethod.credit_card:type_name -> payment.v1.CreditCard
-	10, // 9: payment.v1.PaymentMethod.bank_transfer:type_name -> payment.v1.BankTransfer
-	11, // 10: payment.v1.PaymentMethod.digital_wallet:type_name -> payment.v1.DigitalWallet
-	2,  // 11: payment.v1.PaymentService.ProcessPayment:input_type -> payment.v1.ProcessPaymentRequest
-	4,  // 12: payment.v1.PaymentService.GetPaymentStatus:input_type -> payment.v1.GetPaymentStatusRequest
-	6,  // 13: payment.v1.PaymentService.RefundPayment:input_type -> payment.v1.RefundPaymentRequest
-	3,  // 14: payment.v1.PaymentService.ProcessPayment:output_type -> payment.v1.ProcessPaymentResponse
-	5,  // 15: payment.v1.PaymentService.GetPaymentStatus:output_type -> payment.v1.GetPaymentStatusResponse
-	7,  // 16: payment.v1.PaymentService.RefundPayment:output_type -> payment.v1.RefundPaymentResponse
-	14, // [14:17] is the sub-list for method output_type
-	11, // [11:14] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
-}
-
-func init() { file_proto_payment_payment_proto_init() }
-func file_proto_payment_payment_proto_init() {
-	if File_proto_payment_payment_proto != nil {
+	(ReviewDecision)(0),              // 2: payment.v1.ReviewDecision
+	(*ProcessPaymentRequest)(nil),    // 3: payment.v1.ProcessPaymentRequest
+	(*ProcessPaymentResponse)(nil),   // 4: payment.v1.ProcessPaymentResponse
+	(*GetPaymentStatusRequest)(nil),  // 5: payment.v1.GetPaymentStatusRequest
+	(*GetPaymentStatusResponse)(nil), // 6: payment.v1.GetPaymentStatusResponse
+	(*RefundPaymentRequest)(nil),     // 7: payment.v1.RefundPaymentRequest
+	(*RefundPaymentResponse)(nil),    // 8: payment.v1.RefundPaymentResponse
+	(*ReviewPaymentRequest)(nil),     // 9: payment.v1.ReviewPaymentRequest
+	(*ReviewPaymentResponse)(nil),    // 10: payment.v1.ReviewPaymentResponse
+	(*GetVersionRequest)(nil),        // 11: payment.v1.GetVersionRequest
+	(*GetVersionResponse)(nil),       // 12: payment.v1.GetVersionResponse
+	(*PaymentMethod)(nil),            // 13: payment.v1.PaymentMethod
+	(*CreditCard)(nil),               // 14: payment.v1.CreditCard
+	(*BankTransfer)(nil),             // 15: payment.v1.BankTransfer
+	(*DigitalWallet)(nil),            // 16: payment.v1.DigitalWallet
+	(*v1.Money)(nil),                 // 17: money.v1.Money
+	(*timestamppb.Timestamp)(nil),    // 18: google.protobuf.Timestamp
+}
+var file_payment_v1_payment_proto_depIdxs = []int32{
+	13, // 0: payment.v1.ProcessPaymentRequest.payment_method:type_name -> payment.v1.PaymentMethod
+	17, // 1: payment.v1.ProcessPaymentRequest.exact_amount:type_name -> money.v1.Money
+	1,  // 2: payment.v1.ProcessPaymentResponse.status:type_name -> payment.v1.PaymentStatus
+	18, // 3: payment.v1.ProcessPaymentResponse.processed_at:type_name -> google.protobuf.Timestamp
+	17, // 4: payment.v1.ProcessPaymentResponse.exact_processed_amount:type_name -> money.v1.Money
+	1,  // 5: payment.v1.GetPaymentStatusResponse.status:type_name -> payment.v1.PaymentStatus
+	18, // 6: payment.v1.GetPaymentStatusResponse.created_at:type_name -> google.protobuf.Timestamp
+	18, // 7: payment.v1.GetPaymentStatusResponse.processed_at:type_name -> google.protobuf.Timestamp
+	17, // 8: payment.v1.GetPaymentStatusResponse.exact_amount:type_name -> money.v1.Money
+	17, // 9: payment.v1.RefundPaymentRequest.exact_amount:type_name -> money.v1.Money
+	18, // 10: payment.v1.RefundPaymentResponse.processed_at:type_name -> google.protobuf.Timestamp
+	17, // 11: payment.v1.RefundPaymentResponse.exact_refunded_amount:type_name -> money.v1.Money
+	2,  // 12: payment.v1.ReviewPaymentRequest.decision:type_name -> payment.v1.ReviewDecision
+	1,  // 13: payment.v1.ReviewPaymentResponse.status:type_name -> payment.v1.PaymentStatus
+	18, // 14: payment.v1.ReviewPaymentResponse.processed_at:type_name -> google.protobuf.Timestamp
+	0,  // 15: payment.v1.PaymentMethod.type:type_name -> payment.v1.PaymentType
+	14, // 16: payment.v1.PaymentMethod.credit_card:type_name -> payment.v1.CreditCard
+	15, // 17: payment.v1.PaymentMethod.bank_transfer:type_name -> payment.v1.BankTransfer
+	16, // 18: payment.v1.PaymentMethod.digital_wallet:type_name -> payment.v1.DigitalWallet
+	3,  // 19: payment.v1.PaymentService.ProcessPayment:input_type -> payment.v1.ProcessPaymentRequest
+	5,  // 20: payment.v1.PaymentService.GetPaymentStatus:input_type -> payment.v1.GetPaymentStatusRequest
+	7,  // 21: payment.v1.PaymentService.RefundPayment:input_type -> payment.v1.RefundPaymentRequest
+	9,  // 22: payment.v1.PaymentService.ReviewPayment:input_type -> payment.v1.ReviewPaymentRequest
+	11, // 23: payment.v1.PaymentService.GetVersion:input_type -> payment.v1.GetVersionRequest
+	4,  // 24: payment.v1.PaymentService.ProcessPayment:output_type -> payment.v1.ProcessPaymentResponse
+	6,  // 25: payment.v1.PaymentService.GetPaymentStatus:output_type -> payment.v1.GetPaymentStatusResponse
+	8,  // 26: payment.v1.PaymentService.RefundPayment:output_type -> payment.v1.RefundPaymentResponse
+	10, // 27: payment.v1.PaymentService.ReviewPayment:output_type -> payment.v1.ReviewPaymentResponse
+	12, // 28: payment.v1.PaymentService.GetVersion:output_type -> payment.v1.GetVersionResponse
+	24, // [24:29] is the sub-list for method output_type
+	19, // [19:24] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_payment_v1_payment_proto_init() }
+func file_payment_v1_payment_proto_init() {
+	if File_payment_v1_payment_proto != nil {
 		return
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_payment_payment_proto_rawDesc), len(file_proto_payment_payment_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   10,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_v1_payment_proto_rawDesc), len(file_payment_v1_payment_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_proto_payment_payment_proto_goTypes,
-		DependencyIndexes: file_proto_payment_payment_proto_depIdxs,
-		EnumInfos:         file_proto_payment_payment_proto_enumTypes,
-		MessageInfos:      file_proto_payment_payment_proto_msgTypes,
+		GoTypes:           file_payment_v1_payment_proto_goTypes,
+		DependencyIndexes: file_payment_v1_payment_proto_depIdxs,
+		EnumInfos:         file_payment_v1_payment_proto_enumTypes,
+		MessageInfos:      file_payment_v1_payment_proto_msgTypes,
 	}.Build()
-	File_proto_payment_payment_proto = out.File
-	file_proto_payment_payment_proto_goTypes = nil
-	file_proto_payment_payment_proto_depIdxs = nil
+	File_payment_v1_payment_proto = out.File
+	file_payment_v1_payment_proto_goTypes = nil
+	file_payment_v1_payment_proto_depIdxs = nil
 }
@@ -2,9 +2,9 @@
 // versions:
 // - protoc-gen-go-grpc v1.5.1
 // - protoc             v5.29.3
-// source: proto/payment/payment.proto
+// source: payment/v1/payment.proto
 
-package payment
+package paymentv1
 
 import (
 	context "context"
@@ -22,6 +22,8 @@ const (
 	PaymentService_ProcessPayment_FullMethodName   = "/payment.v1.PaymentService/ProcessPayment"
 	PaymentService_GetPaymentStatus_FullMethodName = "/payment.v1.PaymentService/GetPaymentStatus"
 	PaymentService_RefundPayment_FullMethodName    = "/payment.v1.PaymentService/RefundPayment"
+	PaymentService_ReviewPayment_FullMethodName    = "/payment.v1.PaymentService/ReviewPayment"
+	PaymentService_GetVersion_FullMethodName       = "/payment.v1.PaymentService/GetVersion"
 )
 
 // PaymentServiceClient is the client API for PaymentService service.
@@ -36,6 +38,10 @@ type PaymentServiceClient interface {
 	GetPaymentStatus(ctx context.Context, in *GetPaymentStatusRequest, opts ...grpc.CallOption) (*GetPaymentStatusResponse, error)
 	// RefundPayment processes a refund for a payment
 	RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundPaymentResponse, error)
+	// ReviewPayment approves or declines a payment held for manual review
+	ReviewPayment(ctx context.Context, in *ReviewPaymentRequest, opts ...grpc.CallOption) (*ReviewPaymentResponse, error)
+	// GetVersion returns build information for deployment verification
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
 }
 
 type paymentServiceClient struct {
@@ -76,6 +82,26 @@ func (c *paymentServiceClient) RefundPayment(ctx context.Context, in *RefundPaym
 	return out, nil
 }
 
+func (c *paymentServiceClient) ReviewPayment(ctx context.Context, in *ReviewPaymentRequest, opts ...grpc.CallOption) (*ReviewPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewPaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ReviewPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersionResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServiceServer is the server API for PaymentService service.
 // All implementations must embed UnimplementedPaymentServiceServer
 // for forward compatibility.
@@ -88,6 +114,10 @@ type PaymentServiceServer interface {
 	GetPaymentStatus(context.Context, *GetPaymentStatusRequest) (*GetPaymentStatusResponse, error)
 	// RefundPayment processes a refund for a payment
 	RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error)
+	// ReviewPayment approves or declines a payment held for manual review
+	ReviewPayment(context.Context, *ReviewPaymentRequest) (*ReviewPaymentResponse, error)
+	// GetVersion returns build information for deployment verification
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
 	mustEmbedUnimplementedPaymentServiceServer()
 }
 
@@ -107,6 +137,12 @@ func (UnimplementedPaymentServiceServer) GetPaymentStatus(context.Context, *GetP
 func (UnimplementedPaymentServiceServer) RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefundPayment not implemented")
 }
+func (UnimplementedPaymentServiceServer) ReviewPayment(context.Context, *ReviewPaymentRequest) (*ReviewPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewPayment not implemented")
+}
+func (UnimplementedPaymentServiceServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
 func (UnimplementedPaymentServiceServer) mustEmbedUnimplementedPaymentServiceServer() {}
 func (UnimplementedPaymentServiceServer) testEmbeddedByValue()                        {}
 
@@ -182,6 +218,42 @@ func _PaymentService_RefundPayment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_ReviewPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ReviewPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ReviewPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ReviewPayment(ctx, req.(*ReviewPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -201,7 +273,15 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefundPayment",
 			Handler:    _PaymentService_RefundPayment_Handler,
 		},
+		{
+			MethodName: "ReviewPayment",
+			Handler:    _PaymentService_ReviewPayment_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _PaymentService_GetVersion_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "proto/payment/payment.proto",
+	Metadata: "payment/v1/payment.proto",
 }
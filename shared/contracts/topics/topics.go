@@ -0,0 +1,170 @@
+// Package topics is the registry of the Kafka topics services exchange events on.
+//
+// Services refer to topics by their registered Topic instead of configuring each
+// name on their own, so a producer and its consumers cannot drift apart. A
+// deployment only chooses a prefix, which keeps the topics of several
+// environments apart on a shared cluster, and how strictly startup checks that the
+// registered topics exist on the brokers.
+package topics
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Topic is a registered topic, named without the environment prefix
+type Topic string
+
+// Registered topics, with the services producing and consuming them
+const (
+	// OrderEvents carries order lifecycle events from order-service to
+	// notification-service and the order reporting projection
+	OrderEvents Topic = "order-events"
+
+	// PaymentEvents carries payment outcomes from order-service to
+	// assembly-service, notification-service and the order reporting projection
+	PaymentEvents Topic = "payment-events"
+
+	// PaymentReviewEvents carries manual payment review decisions from
+	// payment-service to order-service
+	PaymentReviewEvents Topic = "payment-review-events"
+
+	// AssemblyEvents carries assembly started, completed and failed events from
+	// assembly-service to order-service and notification-service
+	AssemblyEvents Topic = "assembly-events"
+
+	// AssemblyPartsConsumed carries the parts used by finished assemblies from
+	// assembly-service to inventory-service
+	AssemblyPartsConsumed Topic = "assembly.parts-consumed"
+
+	// InventoryEvents carries reservation events from inventory-service to
+	// order-service
+	InventoryEvents Topic = "inventory-events"
+
+	// InventoryItemsChanged carries the full state of inventory items, keyed by
+	// SKU, from inventory-service to data warehouse sync. The topic is compacted,
+	// so it keeps the latest state of every item; deleted items are tombstones.
+	InventoryItemsChanged Topic = "inventory.items-changed"
+
+	// UserEvents carries account events from iam-service to notification-service
+	UserEvents Topic = "user-events"
+
+	// SecurityEvents carries order abuse reports from order-service to iam-service
+	SecurityEvents Topic = "security-events"
+
+	// Heartbeats carries the probes order-service sends to check its produce
+	// path. Nothing consumes them, so a short retention is enough.
+	Heartbeats Topic = "service.heartbeats"
+)
+
+// All returns every registered topic
+func All() []Topic {
+	return []Topic{
+		OrderEvents,
+		PaymentEvents,
+		PaymentReviewEvents,
+		AssemblyEvents,
+		AssemblyPartsConsumed,
+		InventoryEvents,
+		InventoryItemsChanged,
+		UserEvents,
+		SecurityEvents,
+		Heartbeats,
+	}
+}
+
+// Validation modes deciding what startup does about registered topics missing
+// on the brokers
+const (
+	ValidationOff    = "off"    // Don't check the brokers
+	ValidationWarn   = "warn"   // Log missing topics, e.g. while brokers create topics on first use
+	ValidationStrict = "strict" // Refuse to start with missing topics
+)
+
+// Environment variables read by Load
+const (
+	PrefixEnv     = "KAFKA_TOPIC_PREFIX"
+	ValidationEnv = "KAFKA_TOPIC_VALIDATION"
+)
+
+// topicNamePattern matches the topic names accepted by Kafka
+var topicNamePattern = regexp.MustCompile(`^[0-9a-zA-Z._-]{1,249}$`)
+
+// Registry resolves registered topics to their names on the brokers
+type Registry struct {
+	Prefix     string `json:"prefix"`     // Prepended to every topic name, e.g. "staging."
+	Validation string `json:"validation"` // off, warn or strict
+}
+
+// Load reads the registry settings through getenv, which service config loaders
+// pass their own environment lookup to
+func Load(getenv func(string) string) Registry {
+	registry := Registry{
+		Prefix:     getenv(PrefixEnv),
+		Validation: getenv(ValidationEnv),
+	}
+	if registry.Validation == "" {
+		registry.Validation = ValidationWarn
+	}
+	return registry
+}
+
+// Name returns the name of a topic on the brokers
+func (r Registry) Name(topic Topic) string {
+	return r.Prefix + string(topic)
+}
+
+// Names returns the names of topics on the brokers
+func (r Registry) Names(topics ...Topic) []string {
+	names := make([]string, len(topics))
+	for i, topic := range topics {
+		names[i] = r.Name(topic)
+	}
+	return names
+}
+
+// Strict reports whether startup must fail on missing topics
+func (r Registry) Strict() bool {
+	return r.Validation == ValidationStrict
+}
+
+// Validate checks the validation mode and that the prefix yields valid topic names
+func (r Registry) Validate() error {
+	switch r.Validation {
+	case ValidationOff, ValidationWarn, ValidationStrict:
+	default:
+		return fmt.Errorf("invalid kafka topic validation %q: use off, warn or strict", r.Validation)
+	}
+	for _, topic := range All() {
+		if name := r.Name(topic); !topicNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid kafka topic prefix %q: topic %q must be up to 249 letters, digits, '.', '_' and '-'", r.Prefix, name)
+		}
+	}
+	return nil
+}
+
+// Missing returns the names of topics that are not among the broker's topics
+func (r Registry) Missing(brokerTopics []string, topics ...Topic) []string {
+	existing := make(map[string]bool, len(brokerTopics))
+	for _, name := range brokerTopics {
+		existing[name] = true
+	}
+
+	var missing []string
+	for _, name := range r.Names(topics...) {
+		if !existing[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// MissingTopicsError reports registered topics that do not exist on the brokers
+type MissingTopicsError struct {
+	Topics []string
+}
+
+func (e *MissingTopicsError) Error() string {
+	return "kafka topics missing on the brokers: " + strings.Join(e.Topics, ", ")
+}
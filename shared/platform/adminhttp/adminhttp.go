@@ -0,0 +1,245 @@
+// Package adminhttp hardens the health servers of the services, which also
+// serve the admin and debug routes. Probes, metrics and the other routes stay
+// open; protected routes require a bearer token or a verified client
+// certificate once either is configured. The server can be bound to a single
+// address, e.g. localhost, and served over TLS.
+package adminhttp
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultProtectedPaths are the path prefixes protected unless configured otherwise
+var DefaultProtectedPaths = []string{"/admin/", "/debug/"}
+
+// Config configures a health server
+type Config struct {
+	// BindAddress is the host the server listens on; empty listens on all interfaces
+	BindAddress string
+
+	// TLSCertFile and TLSKeyFile serve the server over TLS when both are set
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// ClientCAFile accepts client certificates signed by these CAs on
+	// protected routes. Certificates are optional so probes keep working.
+	ClientCAFile string
+
+	// AdminToken accepts "Authorization: Bearer <token>" on protected routes
+	AdminToken string
+
+	// ProtectedPaths are the path prefixes requiring authentication
+	ProtectedPaths []string
+}
+
+// FromEnv reads the configuration from the environment:
+//
+//	HEALTH_BIND_ADDRESS        host to listen on, e.g. 127.0.0.1
+//	HEALTH_LOCALHOST_ONLY      true listens on 127.0.0.1 only
+//	HEALTH_TLS_CERT_FILE       server certificate (PEM)
+//	HEALTH_TLS_KEY_FILE        server private key (PEM)
+//	HEALTH_TLS_CLIENT_CA_FILE  CAs of accepted admin client certificates (PEM)
+//	HEALTH_ADMIN_TOKEN         bearer token of the admin routes
+//	HEALTH_ADMIN_TOKEN_FILE    file holding the token, e.g. a mounted secret
+//	HEALTH_PROTECTED_PATHS     comma separated path prefixes, default /admin/,/debug/
+func FromEnv() (Config, error) {
+	cfg := Config{
+		BindAddress:    strings.TrimSpace(os.Getenv("HEALTH_BIND_ADDRESS")),
+		TLSCertFile:    os.Getenv("HEALTH_TLS_CERT_FILE"),
+		TLSKeyFile:     os.Getenv("HEALTH_TLS_KEY_FILE"),
+		ClientCAFile:   os.Getenv("HEALTH_TLS_CLIENT_CA_FILE"),
+		AdminToken:     os.Getenv("HEALTH_ADMIN_TOKEN"),
+		ProtectedPaths: DefaultProtectedPaths,
+	}
+
+	if value := os.Getenv("HEALTH_LOCALHOST_ONLY"); value != "" {
+		localhostOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid HEALTH_LOCALHOST_ONLY %q: %w", value, err)
+		}
+		if localhostOnly {
+			if cfg.BindAddress != "" && !isLoopback(cfg.BindAddress) {
+				return Config{}, fmt.Errorf("HEALTH_LOCALHOST_ONLY conflicts with HEALTH_BIND_ADDRESS %q", cfg.BindAddress)
+			}
+			if cfg.BindAddress == "" {
+				cfg.BindAddress = "127.0.0.1"
+			}
+		}
+	}
+
+	if path := os.Getenv("HEALTH_ADMIN_TOKEN_FILE"); path != "" {
+		if cfg.AdminToken != "" {
+			return Config{}, fmt.Errorf("HEALTH_ADMIN_TOKEN and HEALTH_ADMIN_TOKEN_FILE are mutually exclusive")
+		}
+		token, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to read admin token file: %w", err)
+		}
+		cfg.AdminToken = strings.TrimSpace(string(token))
+	}
+
+	if value := os.Getenv("HEALTH_PROTECTED_PATHS"); value != "" {
+		cfg.ProtectedPaths = nil
+		for _, path := range strings.Split(value, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				cfg.ProtectedPaths = append(cfg.ProtectedPaths, path)
+			}
+		}
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// Validate checks the configuration for consistency
+func (c Config) Validate() error {
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("TLS certificate and key files must be set together")
+	}
+	if c.ClientCAFile != "" && !c.TLSEnabled() {
+		return fmt.Errorf("client certificate authentication requires TLS")
+	}
+	if c.AdminToken != "" && len(c.AdminToken) < 16 {
+		return fmt.Errorf("admin token must be at least 16 characters")
+	}
+	for _, path := range c.ProtectedPaths {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("protected path %q must start with /", path)
+		}
+	}
+	return nil
+}
+
+// TLSEnabled reports whether the server is served over TLS
+func (c Config) TLSEnabled() bool {
+	return c.TLSCertFile != ""
+}
+
+// AuthEnabled reports whether protected routes require authentication
+func (c Config) AuthEnabled() bool {
+	return c.AdminToken != "" || c.ClientCAFile != ""
+}
+
+// Address returns the listen address of the server on port
+func (c Config) Address(port string) string {
+	return net.JoinHostPort(c.BindAddress, port)
+}
+
+// Listen secures server and opens its listener: the handler is wrapped with
+// Protect, the host of server.Addr is replaced by the bind address and the
+// listener is wrapped with TLS when configured. The caller serves with
+// server.Serve, so bind errors surface at startup.
+func (c Config) Listen(server *http.Server) (net.Listener, error) {
+	_, port, err := net.SplitHostPort(server.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid health server address %q: %w", server.Addr, err)
+	}
+	server.Addr = c.Address(port)
+	server.Handler = c.Protect(server.Handler)
+
+	if c.TLSEnabled() {
+		tlsConfig, err := c.TLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		server.TLSConfig = tlsConfig
+	}
+
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", server.Addr, err)
+	}
+	if server.TLSConfig != nil {
+		listener = tls.NewListener(listener, server.TLSConfig)
+	}
+	return listener, nil
+}
+
+// TLSConfig loads the server certificate and the client CAs
+func (c Config) TLSConfig() (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(c.TLSCertFile, c.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load health server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+	}
+	if c.ClientCAFile != "" {
+		pem, err := os.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", c.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return tlsConfig, nil
+}
+
+// Protect requires authentication on the protected paths when a token or a
+// client CA is configured; otherwise next is returned unchanged
+func (c Config) Protect(next http.Handler) http.Handler {
+	if !c.AuthEnabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !c.isProtected(r.URL.Path) || c.authorized(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		status := http.StatusUnauthorized
+		if c.AdminToken != "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			if r.Header.Get("Authorization") != "" {
+				status = http.StatusForbidden
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]string{"error": "admin authentication required"})
+	})
+}
+
+func (c Config) isProtected(path string) bool {
+	for _, prefix := range c.ProtectedPaths {
+		if strings.HasPrefix(path, prefix) || path+"/" == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// authorized accepts a matching bearer token or a client certificate verified
+// against the client CAs
+func (c Config) authorized(r *http.Request) bool {
+	if c.AdminToken != "" {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(c.AdminToken)) == 1 {
+			return true
+		}
+	}
+	return c.ClientCAFile != "" && r.TLS != nil && len(r.TLS.VerifiedChains) > 0
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,253 @@
+// Package authz guards HTTP endpoints with role and permission checks on the
+// caller identity the gateway passes on in the X-User-* headers. The headers are
+// only trusted along with IAM's signature of them, see IdentitySignatureHeader;
+// without it the caller is unauthenticated.
+//
+// Every guarded endpoint has a name and runs in one of two modes. Enforce
+// rejects callers that fail the check; report lets them through and only logs
+// and counts the request it would have denied, so enforcement can be rolled out
+// to an endpoint once the reports show no legitimate caller would break.
+// Ownership checks are always enforced: admitting a caller to another user's
+// resources would leak them, whatever the reports are meant to show.
+package authz
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Modes of a guarded endpoint
+const (
+	ModeEnforce = "enforce" // Reject callers failing the check
+	ModeReport  = "report"  // Admit them, logging and counting the would-be denial
+)
+
+// Reasons of a denial, reported as the reason label
+const (
+	ReasonUnauthenticated = "unauthenticated"
+	ReasonForbidden       = "forbidden"
+)
+
+// Config selects the mode of every guarded endpoint
+type Config struct {
+	// Mode applies to endpoints without an entry in Endpoints
+	Mode string
+
+	// Endpoints overrides the mode by endpoint name
+	Endpoints map[string]string
+
+	// IdentityKey verifies IAM's signature of the identity headers
+	IdentityKey []byte
+}
+
+// FromEnv reads the configuration from the environment:
+//
+//	AUTHZ_MODE            enforce or report, default enforce
+//	AUTHZ_ENDPOINT_MODES  comma separated endpoint=mode overrides, e.g. "orders.export=report"
+//	GATEWAY_IDENTITY_KEY  key IAM signs identities with, required
+func FromEnv() (Config, error) {
+	cfg := Config{
+		Mode:        ModeEnforce,
+		Endpoints:   make(map[string]string),
+		IdentityKey: []byte(os.Getenv(IdentityKeyEnv)),
+	}
+	if len(cfg.IdentityKey) < MinIdentityKeyLength {
+		return Config{}, fmt.Errorf("%s must be set to at least %d characters", IdentityKeyEnv, MinIdentityKeyLength)
+	}
+
+	if value := os.Getenv("AUTHZ_MODE"); value != "" {
+		if !validMode(value) {
+			return Config{}, fmt.Errorf("invalid AUTHZ_MODE %q: use enforce or report", value)
+		}
+		cfg.Mode = value
+	}
+
+	if value := os.Getenv("AUTHZ_ENDPOINT_MODES"); value != "" {
+		for _, entry := range strings.Split(value, ",") {
+			if entry = strings.TrimSpace(entry); entry == "" {
+				continue
+			}
+			endpoint, mode, ok := strings.Cut(entry, "=")
+			endpoint, mode = strings.TrimSpace(endpoint), strings.TrimSpace(mode)
+			if !ok || endpoint == "" || !validMode(mode) {
+				return Config{}, fmt.Errorf("invalid AUTHZ_ENDPOINT_MODES entry %q: use <endpoint>=enforce or <endpoint>=report", entry)
+			}
+			cfg.Endpoints[endpoint] = mode
+		}
+	}
+
+	return cfg, nil
+}
+
+func validMode(mode string) bool {
+	return mode == ModeEnforce || mode == ModeReport
+}
+
+// ModeFor returns the mode of an endpoint
+func (c Config) ModeFor(endpoint string) string {
+	if mode, ok := c.Endpoints[endpoint]; ok {
+		return mode
+	}
+	if c.Mode == "" {
+		return ModeEnforce
+	}
+	return c.Mode
+}
+
+// Authorizer creates the middleware guarding the endpoints of a service
+type Authorizer struct {
+	config  Config
+	logger  logging.Logger
+	metrics metrics.Metrics
+}
+
+// New creates an authorizer
+func New(cfg Config, logger logging.Logger, metrics metrics.Metrics) *Authorizer {
+	return &Authorizer{
+		config:  cfg,
+		logger:  logger,
+		metrics: metrics,
+	}
+}
+
+// Identity drops the caller identity ctxmeta.Middleware read from the headers
+// of a request IAM didn't sign, so handlers and services reading the user or
+// the tenant from the context (e.g. to select sandbox behavior) only see ones
+// the gateway validated
+func (a *Authorizer) Identity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !VerifyIdentity(a.config.IdentityKey, r.Header, time.Now()) {
+			ctx := ctxmeta.WithUserID(r.Context(), "")
+			ctx = ctxmeta.WithTenantID(ctx, "")
+			ctx = ctxmeta.WithRoles(ctx)
+			ctx = ctxmeta.WithPermissions(ctx)
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole admits only callers whose gateway-validated role is one of roles,
+// and stores their user ID in the request context
+func (a *Authorizer) RequireRole(endpoint string, roles ...string) func(http.Handler) http.Handler {
+	required := "role " + strings.Join(roles, "|")
+	return a.guard(endpoint, required, false, func(ctx context.Context, _ *http.Request) bool {
+		return hasAnyRole(ctx, roles)
+	})
+}
+
+// RequirePermission admits only callers whose session's permission snapshot,
+// passed on by the gateway, grants the action on the resource, and stores their
+// user ID in the request context
+func (a *Authorizer) RequirePermission(endpoint, resource, action string) func(http.Handler) http.Handler {
+	required := "permission " + resource + "." + action
+	return a.guard(endpoint, required, false, func(ctx context.Context, _ *http.Request) bool {
+		return ctxmeta.HasPermission(ctx, resource, action)
+	})
+}
+
+// RequireOwner admits only the user owning the requested resources, whose ID
+// owner returns from the request (e.g. a URL parameter), and callers with one
+// of roles, e.g. admins acting on behalf of a user. It stores the caller's user
+// ID in the request context. Denials are enforced in either mode.
+func (a *Authorizer) RequireOwner(endpoint string, owner func(r *http.Request) string, roles ...string) func(http.Handler) http.Handler {
+	required := "owner"
+	if len(roles) > 0 {
+		required += " or role " + strings.Join(roles, "|")
+	}
+	return a.guard(endpoint, required, true, func(ctx context.Context, r *http.Request) bool {
+		userID, _ := ctxmeta.UserID(ctx)
+		ownerID, err := uuid.Parse(owner(r))
+		return (err == nil && ownerID.String() == userID) || hasAnyRole(ctx, roles)
+	})
+}
+
+func hasAnyRole(ctx context.Context, roles []string) bool {
+	for _, role := range roles {
+		if ctxmeta.HasRole(ctx, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// guard runs allowed on the caller identity and applies the endpoint's mode to
+// a denial; always enforced denials ignore the mode
+func (a *Authorizer) guard(endpoint, required string, alwaysEnforced bool, allowed func(ctx context.Context, r *http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			reason := ""
+			if userID, err := uuid.Parse(r.Header.Get(ctxmeta.UserIDHeader)); err != nil || !VerifyIdentity(a.config.IdentityKey, r.Header, time.Now()) {
+				reason = ReasonUnauthenticated
+			} else {
+				ctx = ctxmeta.WithUserID(ctx, userID.String())
+				ctx = ctxmeta.WithRoles(ctx, strings.Split(r.Header.Get(ctxmeta.RolesHeader), ",")...)
+				ctx = ctxmeta.WithPermissions(ctx, strings.Split(r.Header.Get(ctxmeta.PermissionsHeader), ",")...)
+				if !allowed(ctx, r) {
+					reason = ReasonForbidden
+				}
+			}
+
+			if reason != "" {
+				mode := a.config.ModeFor(endpoint)
+				if alwaysEnforced {
+					mode = ModeEnforce
+				}
+				a.recordDenial(ctx, r, endpoint, required, reason, mode)
+				if mode == ModeEnforce {
+					writeDenial(w, reason)
+					return
+				}
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// recordDenial counts a denial and logs the caller it denied or would have denied
+func (a *Authorizer) recordDenial(ctx context.Context, r *http.Request, endpoint, required, reason, mode string) {
+	a.metrics.IncrementCounter(ctx, "authz_denials_total", map[string]string{
+		"endpoint": endpoint,
+		"reason":   reason,
+		"mode":     mode,
+	})
+
+	fields := map[string]interface{}{
+		"endpoint": endpoint,
+		"method":   r.Method,
+		"path":     r.URL.Path,
+		"required": required,
+		"reason":   reason,
+		"mode":     mode,
+		"user_id":  r.Header.Get(ctxmeta.UserIDHeader),
+		"roles":    ctxmeta.Roles(ctx),
+	}
+	if requestID, ok := ctxmeta.RequestID(ctx); ok {
+		fields["request_id"] = requestID
+	}
+
+	if mode == ModeReport {
+		a.logger.Warn(ctx, "Authorization would deny request", fields)
+	} else {
+		a.logger.Info(ctx, "Authorization denied request", fields)
+	}
+}
+
+func writeDenial(w http.ResponseWriter, reason string) {
+	if reason == ReasonUnauthenticated {
+		http.Error(w, `{"error": "Missing authentication", "code": 401}`, http.StatusUnauthorized)
+		return
+	}
+	http.Error(w, `{"error": "Insufficient permissions", "code": 403}`, http.StatusForbidden)
+}
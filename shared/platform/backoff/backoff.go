@@ -0,0 +1,138 @@
+// Package backoff paces the retries of service clients: capped exponential
+// backoff with jitter that honors the retry hints of servers shedding load,
+// the Retry-After header of HTTP responses and the RetryInfo of gRPC errors.
+// A server that asks clients to wait longer than they are willing to is not
+// retried, and a HoldOff keeps the client from calling it until the wait is over
+// rather than hammering a service that is rejecting requests.
+package backoff
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxRetryAfter is the longest server retry hint clients wait out before a retry
+const DefaultMaxRetryAfter = 30 * time.Second
+
+// Policy computes the waits between the attempts of a call
+type Policy struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration // Caps the computed backoff, not server hints
+
+	// MaxRetryAfter is the longest server hint waited out; a call asked to wait
+	// longer is not retried. Zero means DefaultMaxRetryAfter.
+	MaxRetryAfter time.Duration
+}
+
+// HoldOff returns how long to hold calls off for a server hint: the hint,
+// capped at MaxRetryAfter so that a server asking for a long or hostile wait
+// doesn't stop the client's calls for longer than it would wait for one
+func (p Policy) HoldOff(hint time.Duration) time.Duration {
+	if maxRetryAfter := p.maxRetryAfter(); hint > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return hint
+}
+
+// Delay returns the wait before a retry (attempt counts from 1): exponential
+// backoff from BaseDelay, capped at MaxDelay, with full jitter over its upper
+// half. A longer server hint is honored; ok is false when the hint is longer
+// than MaxRetryAfter, and the call should not be retried.
+func (p Policy) Delay(attempt int, hint time.Duration) (delay time.Duration, ok bool) {
+	delay = p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay > 0 {
+		delay = delay/2 + rand.N(delay/2+1)
+	}
+
+	if hint > delay {
+		if hint > p.maxRetryAfter() {
+			return hint, false
+		}
+		delay = hint
+	}
+	return delay, true
+}
+
+func (p Policy) maxRetryAfter() time.Duration {
+	if p.MaxRetryAfter <= 0 {
+		return DefaultMaxRetryAfter
+	}
+	return p.MaxRetryAfter
+}
+
+// Sleep waits for the delay, returning early with the context's error
+func Sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RetryAfter returns the Retry-After hint of a response, in seconds or as an
+// HTTP date, and whether it has one
+func RetryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if delay := at.Sub(now); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// HoldOff tracks until when a server shedding load asked its clients to wait.
+// The zero value holds nothing off.
+type HoldOff struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// Extend holds calls off for at least the delay, which callers cap with
+// Policy.HoldOff. It reports whether this
+// started a hold-off, rather than extending one already in place.
+func (h *HoldOff) Extend(delay time.Duration) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	started := !now.Before(h.until)
+	if until := now.Add(delay); until.After(h.until) {
+		h.until = until
+	}
+	return started && delay > 0
+}
+
+// Remaining returns how long calls are still held off; zero when they aren't
+func (h *HoldOff) Remaining() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if remaining := time.Until(h.until); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
@@ -0,0 +1,23 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOversizedHintIsNotWaitedOut(t *testing.T) {
+	policy := Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, MaxRetryAfter: 30 * time.Second}
+
+	if _, ok := policy.Delay(1, time.Hour); ok {
+		t.Fatal("Delay: an hour long hint was accepted, want the call not retried")
+	}
+	if delay, ok := policy.Delay(1, 10*time.Second); !ok || delay != 10*time.Second {
+		t.Fatalf("Delay: got %v, %v for a 10s hint, want 10s, true", delay, ok)
+	}
+
+	var holdOff HoldOff
+	holdOff.Extend(policy.HoldOff(time.Hour))
+	if remaining := holdOff.Remaining(); remaining <= 0 || remaining > policy.MaxRetryAfter {
+		t.Fatalf("HoldOff: holding off for %v after an hour long hint, want at most %v", remaining, policy.MaxRetryAfter)
+	}
+}
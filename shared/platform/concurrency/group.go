@@ -0,0 +1,120 @@
+// Package concurrency runs goroutines with bounded parallelism, panic recovery
+// and context cancellation: a Group for ad hoc tasks, ForEach and Map for
+// worker pools over a slice, and pipeline stages connected by channels.
+//
+// Everything started on a Group shares its context, which is cancelled by the
+// first task to fail or panic; Wait returns that first error. A panic is
+// recovered into a *PanicError rather than crashing the service.
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError is returned in place of a panic recovered from a task
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("recovered from panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value when it was an error
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Group runs tasks on their own goroutines, at most limit at a time
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	slots  chan struct{} // nil when unbounded
+
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	err     error
+}
+
+// NewGroup creates a group running at most limit tasks at a time; a limit of 0
+// or less does not bound it. The returned context is cancelled when a task
+// fails or panics, when Wait returns, or when ctx is cancelled.
+func NewGroup(ctx context.Context, limit int) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &Group{ctx: ctx, cancel: cancel}
+	if limit > 0 {
+		g.slots = make(chan struct{}, limit)
+	}
+	return g, ctx
+}
+
+// Go runs task on a new goroutine once a slot is free. When the group's context
+// is cancelled before then, the task is not run.
+func (g *Group) Go(task func(ctx context.Context) error) {
+	if g.slots != nil {
+		select {
+		case g.slots <- struct{}{}:
+		case <-g.ctx.Done():
+			return
+		}
+	}
+	g.start(task)
+}
+
+// TryGo runs task only if a slot is free right away, reporting whether it did
+func (g *Group) TryGo(task func(ctx context.Context) error) bool {
+	if g.slots != nil {
+		select {
+		case g.slots <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	g.start(task)
+	return true
+}
+
+// Wait blocks until every started task returned, then returns the first error
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(nil)
+	return g.err
+}
+
+// start runs task on a new goroutine holding the slot taken for it
+func (g *Group) start(task func(ctx context.Context) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.slots != nil {
+			defer func() { <-g.slots }()
+		}
+		if err := Safe(g.ctx, task); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+func (g *Group) fail(err error) {
+	g.errOnce.Do(func() {
+		g.err = err
+		g.cancel(err)
+	})
+}
+
+// Safe calls task, recovering a panic into a *PanicError
+func Safe(ctx context.Context, task func(ctx context.Context) error) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = &PanicError{Value: value, Stack: debug.Stack()}
+		}
+	}()
+	return task(ctx)
+}
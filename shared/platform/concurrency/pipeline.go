@@ -0,0 +1,98 @@
+package concurrency
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Source starts a pipeline on group, emitting items in order. The channel is
+// closed once every item was emitted or the group's context is cancelled.
+func Source[T any](group *Group, items []T) <-chan T {
+	out := make(chan T)
+	group.Go(func(ctx context.Context) error {
+		defer close(out)
+		for _, item := range items {
+			select {
+			case out <- item:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		return nil
+	})
+	return out
+}
+
+// Stage transforms the items of in with fn on workers goroutines of group
+// (at least one), emitting the results in completion order. An error from fn
+// fails the group, cancelling every stage. The channel is closed once in is
+// drained or the group's context is cancelled.
+//
+// The stage's workers take their slots when Stage is called, so a group bounded
+// by a limit needs one slot per source, stage worker and sink. Readers outside
+// the group select on its context too: a stage cancelled before its workers
+// started never closes its channel.
+func Stage[In, Out any](group *Group, in <-chan In, workers int, fn func(ctx context.Context, item In) (Out, error)) <-chan Out {
+	if workers < 1 {
+		workers = 1
+	}
+
+	out := make(chan Out)
+	var running atomic.Int32
+	running.Store(int32(workers))
+	for w := 0; w < workers; w++ {
+		group.Go(func(ctx context.Context) error {
+			// The last worker out closes the stage
+			defer func() {
+				if running.Add(-1) == 0 {
+					close(out)
+				}
+			}()
+
+			for {
+				var item In
+				var ok bool
+				select {
+				case item, ok = <-in:
+					if !ok {
+						return nil
+					}
+				case <-ctx.Done():
+					return nil
+				}
+
+				result, err := fn(ctx, item)
+				if err != nil {
+					return err
+				}
+
+				select {
+				case out <- result:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		})
+	}
+	return out
+}
+
+// Sink ends a pipeline on group, calling fn for every item of in. An error from
+// fn fails the group.
+func Sink[T any](group *Group, in <-chan T, fn func(ctx context.Context, item T) error) {
+	group.Go(func(ctx context.Context) error {
+		for {
+			select {
+			case item, ok := <-in:
+				if !ok {
+					return nil
+				}
+				if err := fn(ctx, item); err != nil {
+					return err
+				}
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ForEach calls fn for every item on at most workers goroutines, stopping at
+// the first error, which it returns. Items not yet started when fn fails or ctx
+// is cancelled are skipped; a cancelled ctx is returned as its error.
+func ForEach[T any](ctx context.Context, items []T, workers int, fn func(ctx context.Context, item T) error) error {
+	group, groupCtx := NewGroup(ctx, poolSize(workers, len(items)))
+	for _, item := range items {
+		if groupCtx.Err() != nil {
+			break
+		}
+		group.Go(func(ctx context.Context) error {
+			return fn(ctx, item)
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// ForAll calls fn for every item on at most workers goroutines whatever the
+// outcome of the others, returning the errors joined. Every item is called even
+// once ctx is cancelled, so each gets an outcome; fn sees the cancellation. Use
+// it for fan-outs where one failure must not hold back the rest.
+func ForAll[T any](ctx context.Context, items []T, workers int, fn func(ctx context.Context, item T) error) error {
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	// Tasks never fail the group and its context outlives ctx, so no item is skipped
+	group, _ := NewGroup(context.WithoutCancel(ctx), poolSize(workers, len(items)))
+	for _, item := range items {
+		group.Go(func(context.Context) error {
+			if err := Safe(ctx, func(ctx context.Context) error { return fn(ctx, item) }); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	group.Wait()
+	return errors.Join(errs...)
+}
+
+// Map calls fn for every item on at most workers goroutines and returns the
+// results in the order of the items, or the first error
+func Map[T, R any](ctx context.Context, items []T, workers int, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	err := ForEach(ctx, indexes, workers, func(ctx context.Context, i int) error {
+		result, err := fn(ctx, items[i])
+		if err != nil {
+			return err
+		}
+		results[i] = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// poolSize bounds workers by the number of items; workers of 0 or less run
+// every item at once
+func poolSize(workers, items int) int {
+	if workers <= 0 || workers > items {
+		return items
+	}
+	return workers
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// LogLevelEnv is the log level key every service reads
+const LogLevelEnv = "LOG_LEVEL"
+
+// Flags holds the command-line switches every service binary accepts, so that
+// operational overrides don't require editing env files. The port and log level
+// overrides are exported to the environment before the profile is applied, so
+// they take precedence over both: defaults < profile < environment < flags.
+type Flags struct {
+	ConfigPath  *string
+	Validation  ValidationFlags
+	MigrateOnly *bool
+
+	port     *int
+	logLevel *string
+	portKey  string
+}
+
+// RegisterFlags registers --config, --port, --log-level, --validate-config (or
+// --validate-only), --check-connectivity and --migrate-only on the default flag
+// set. portKey is the environment variable the service reads its port from.
+func RegisterFlags(portKey string) *Flags {
+	f := &Flags{
+		ConfigPath:  ConfigFlag(),
+		Validation:  RegisterValidationFlags(),
+		MigrateOnly: flag.Bool("migrate-only", false, "run the database migrations and exit"),
+		port:        flag.Int("port", 0, "port to serve on, overriding $"+portKey),
+		logLevel:    flag.String("log-level", "", "log level (debug, info, warn, error), overriding $"+LogLevelEnv),
+		portKey:     portKey,
+	}
+	flag.BoolVar(f.Validation.Validate, "validate-only", false, "same as --validate-config")
+	return f
+}
+
+// Apply exports the port and log level overrides to the environment and then
+// applies the config profile, see ApplyProfile. Call it after flag.Parse.
+func (f *Flags) Apply() (*Profile, error) {
+	if *f.port != 0 {
+		if *f.port < 0 || *f.port > 65535 {
+			return nil, fmt.Errorf("invalid --port %d: must be between 1 and 65535", *f.port)
+		}
+		os.Setenv(f.portKey, strconv.Itoa(*f.port))
+	}
+	if *f.logLevel != "" {
+		os.Setenv(LogLevelEnv, *f.logLevel)
+	}
+
+	return ApplyProfile(*f.ConfigPath)
+}
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration is layered: a service's built-in defaults are overridden by a
+// per-environment YAML profile, which is in turn overridden by environment
+// variables. Profile keys use the same names as the environment variables;
+// nested mappings are joined with underscores, so
+//
+//	kafka:
+//	  brokers: [kafka-1:9092, kafka-2:9092]
+//	db:
+//	  host: orders-db
+//
+// sets KAFKA_BROKERS=kafka-1:9092,kafka-2:9092 and DB_HOST=orders-db.
+
+const (
+	// ConfigFileEnv selects the profile file when the --config flag is not given
+	ConfigFileEnv = "CONFIG_FILE"
+
+	// ProfileDir holds per-environment profiles named <ENVIRONMENT>.yaml
+	ProfileDir = "config"
+)
+
+// commonKeys are read by shared packages rather than a service's config loader
+var commonKeys = []string{"ENVIRONMENT", ConfigFileEnv, "MAINTENANCE_MODE", "MAINTENANCE_REASON"}
+
+// readKeys records every key looked up through Getenv, so that profile keys no
+// service code reads can be reported as unknown
+var readKeys sync.Map
+
+// Getenv returns the value of a configuration key and records that the key is
+// known to the service. Service config loaders read keys through it instead of
+// os.Getenv so that misspelled profile keys are detected.
+func Getenv(key string) string {
+	readKeys.Store(key, true)
+	return os.Getenv(key)
+}
+
+// Profile is a configuration file layered between defaults and environment variables
+type Profile struct {
+	Path   string
+	values map[string]string
+}
+
+// ConfigFlag registers the --config flag on the default flag set
+func ConfigFlag() *string {
+	return flag.String("config", "", "path to a YAML config profile (default: $"+ConfigFileEnv+" or "+ProfileDir+"/<ENVIRONMENT>.yaml if present)")
+}
+
+// ApplyProfile resolves the profile file, loads it and exports its values to
+// the process environment for keys that are not already set, so that every
+// reader of the environment sees defaults < profile < environment variables.
+// An explicitly requested file must exist; the per-environment default is
+// optional. Returns nil when no profile is used.
+func ApplyProfile(path string) (*Profile, error) {
+	explicit := true
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+	if path == "" {
+		explicit = false
+		environment := os.Getenv("ENVIRONMENT")
+		if environment == "" {
+			environment = "development"
+		}
+		path = filepath.Join(ProfileDir, environment+".yaml")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config profile %s: %w", path, err)
+	}
+
+	profile, err := ParseProfile(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range profile.values {
+		if _, set := os.LookupEnv(key); !set {
+			os.Setenv(key, value)
+		}
+	}
+
+	return profile, nil
+}
+
+// ParseProfile parses YAML profile data
+func ParseProfile(path string, data []byte) (*Profile, error) {
+	var document map[string]interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse config profile %s: %w", path, err)
+	}
+
+	profile := &Profile{Path: path, values: make(map[string]string)}
+	if err := flatten(profile.values, "", document); err != nil {
+		return nil, fmt.Errorf("invalid config profile %s: %w", path, err)
+	}
+
+	return profile, nil
+}
+
+// Keys returns the environment variable names set by the profile, sorted
+func (p *Profile) Keys() []string {
+	if p == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(p.values))
+	for key := range p.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CheckUnknownKeys fails if the profile sets keys the service never read.
+// Call it after the service configuration has been loaded; keys that are
+// read outside the config loader can be passed as known.
+func (p *Profile) CheckUnknownKeys(known ...string) error {
+	if p == nil {
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(known)+len(commonKeys))
+	for _, key := range append(known, commonKeys...) {
+		allowed[key] = true
+	}
+
+	var unknown []string
+	for _, key := range p.Keys() {
+		if _, read := readKeys.Load(key); !read && !allowed[key] {
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("config profile %s contains unknown keys: %s", p.Path, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// flatten converts nested YAML mappings into upper-case, underscore-joined keys
+func flatten(out map[string]string, prefix string, node map[string]interface{}) error {
+	for name, value := range node {
+		key := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok {
+			if err := flatten(out, key, nested); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, duplicate := out[key]; duplicate {
+			return fmt.Errorf("key %s is set more than once", key)
+		}
+
+		switch v := value.(type) {
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				if _, nested := item.(map[string]interface{}); nested {
+					return fmt.Errorf("key %s: lists may only contain scalar values", key)
+				}
+				items = append(items, fmt.Sprint(item))
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDialTimeout bounds each connectivity dry-run
+const DefaultDialTimeout = 3 * time.Second
+
+// invalidValues records keys whose values could not be parsed; loaders fall
+// back to the default for them, which is only surfaced by validation
+var invalidValues sync.Map
+
+// InvalidValue records that a configuration value could not be parsed. Service
+// config loaders call it from their parse helpers before falling back to the default.
+func InvalidValue(key, value string, err error) {
+	invalidValues.Store(key, fmt.Sprintf("%s=%q (%v)", key, value, err))
+}
+
+// ValidationFlags holds the command-line switches for configuration validation
+type ValidationFlags struct {
+	Validate          *bool
+	CheckConnectivity *bool
+}
+
+// RegisterValidationFlags registers --validate-config and --check-connectivity on the default flag set
+func RegisterValidationFlags() ValidationFlags {
+	return ValidationFlags{
+		Validate:          flag.Bool("validate-config", false, "load and validate the configuration, print a report and exit"),
+		CheckConnectivity: flag.Bool("check-connectivity", false, "with --validate-config, also dial the configured dependencies"),
+	}
+}
+
+// checkResult is a single line of a validation report
+type checkResult struct {
+	name    string
+	err     error
+	skipped string
+}
+
+// Report collects the results of configuration checks for a service
+type Report struct {
+	service string
+	results []checkResult
+}
+
+// NewReport creates an empty validation report
+func NewReport(service string) *Report {
+	return &Report{service: service}
+}
+
+// Check records the outcome of a check; a nil error means it passed
+func (r *Report) Check(name string, err error) {
+	r.results = append(r.results, checkResult{name: name, err: err})
+}
+
+// Skip records a check that was not run
+func (r *Report) Skip(name, reason string) {
+	r.results = append(r.results, checkResult{name: name, skipped: reason})
+}
+
+// CheckProfile records whether the config profile loaded and only sets known
+// keys. Call it after the service configuration has been loaded.
+func (r *Report) CheckProfile(profile *Profile, loadErr error, known ...string) {
+	switch {
+	case loadErr != nil:
+		r.Check("config profile", loadErr)
+	case profile == nil:
+		r.Skip("config profile", "no profile file, using defaults and environment variables")
+	default:
+		r.Check("config profile "+profile.Path, profile.CheckUnknownKeys(known...))
+	}
+}
+
+// CheckParsedValues records a failure for every value a loader could not parse
+func (r *Report) CheckParsedValues() {
+	var problems []string
+	invalidValues.Range(func(_, problem interface{}) bool {
+		problems = append(problems, problem.(string))
+		return true
+	})
+	sort.Strings(problems)
+
+	var err error
+	if len(problems) > 0 {
+		err = fmt.Errorf("unparsable values, defaults would be used: %s", strings.Join(problems, "; "))
+	}
+	r.Check("value formats", err)
+}
+
+// CheckReachable dials a TCP address to verify the dependency is reachable
+func (r *Report) CheckReachable(name, address string) {
+	if address == "" {
+		r.Skip(name, "no address configured")
+		return
+	}
+
+	conn, err := net.DialTimeout("tcp", address, DefaultDialTimeout)
+	if err != nil {
+		r.Check(name+" ("+address+")", err)
+		return
+	}
+	conn.Close()
+	r.Check(name+" ("+address+")", nil)
+}
+
+// OK reports whether every check passed
+func (r *Report) OK() bool {
+	for _, result := range r.results {
+		if result.err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Write prints the report in a human-readable form
+func (r *Report) Write(w io.Writer) {
+	fmt.Fprintf(w, "Configuration report for %s\n", r.service)
+
+	failed := 0
+	for _, result := range r.results {
+		switch {
+		case result.err != nil:
+			failed++
+			fmt.Fprintf(w, "  FAIL  %s: %v\n", result.name, result.err)
+		case result.skipped != "":
+			fmt.Fprintf(w, "  SKIP  %s: %s\n", result.name, result.skipped)
+		default:
+			fmt.Fprintf(w, "  OK    %s\n", result.name)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(w, "%d of %d checks failed\n", failed, len(r.results))
+		return
+	}
+	fmt.Fprintf(w, "All %d checks passed\n", len(r.results))
+}
+
+// Exit prints the report to stdout and exits non-zero if any check failed
+func (r *Report) Exit() {
+	r.Write(os.Stdout)
+	if !r.OK() {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
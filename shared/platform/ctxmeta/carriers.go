@@ -0,0 +1,225 @@
+package ctxmeta
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// HTTP headers carrying request metadata. The identity headers are set by the API
+// gateway after it validated the caller's session with IAM.
+const (
+	UserIDHeader      = "X-User-ID"
+	TenantIDHeader    = "X-Tenant-ID"
+	RolesHeader       = "X-User-Role"        // Comma-separated
+	PermissionsHeader = "X-User-Permissions" // Comma-separated
+	SessionIDHeader   = "X-Session-ID"
+	RequestIDHeader   = "X-Request-ID"
+	LocaleHeader      = "Accept-Language"
+
+	// ProfileLocaleHeader carries the locale of the caller's profile, which takes
+	// precedence over their Accept-Language
+	ProfileLocaleHeader = "X-User-Locale"
+)
+
+// gRPC metadata keys carrying request metadata. The session ID is not propagated:
+// x-session-id is a credential that downstream services validate with IAM.
+const (
+	UserIDMetadataKey    = "x-user-id"
+	TenantIDMetadataKey  = "x-tenant-id"
+	RolesMetadataKey     = "x-user-role"
+	RequestIDMetadataKey = "x-request-id"
+	LocaleMetadataKey    = "accept-language"
+)
+
+// Kafka headers carrying request metadata, so consumers act for the same user and
+// log under the same request ID
+const (
+	UserIDKafkaHeader    = "user-id"
+	TenantIDKafkaHeader  = "tenant-id"
+	RolesKafkaHeader     = "user-roles"
+	RequestIDKafkaHeader = "request-id"
+	LocaleKafkaHeader    = "locale"
+)
+
+// FromHTTPHeader returns the metadata carried in HTTP headers
+func FromHTTPHeader(header http.Header) Metadata {
+	m := Metadata{
+		UserID:      header.Get(UserIDHeader),
+		TenantID:    header.Get(TenantIDHeader),
+		Roles:       splitList(header.Get(RolesHeader)),
+		Permissions: splitList(header.Get(PermissionsHeader)),
+		SessionID:   header.Get(SessionIDHeader),
+		RequestID:   header.Get(RequestIDHeader),
+		Locale:      header.Get(ProfileLocaleHeader),
+	}
+	if m.Locale == "" {
+		m.Locale = preferredLocale(header.Get(LocaleHeader))
+	}
+	return m
+}
+
+// SetHTTPHeader sets the headers for the metadata carried in the context, for a
+// request to another service
+func SetHTTPHeader(ctx context.Context, header http.Header) {
+	m := FromContext(ctx)
+	setIfPresent := func(name, value string) {
+		if value != "" {
+			header.Set(name, value)
+		}
+	}
+	setIfPresent(UserIDHeader, m.UserID)
+	setIfPresent(TenantIDHeader, m.TenantID)
+	setIfPresent(RolesHeader, strings.Join(m.Roles, ","))
+	setIfPresent(RequestIDHeader, m.RequestID)
+	setIfPresent(LocaleHeader, m.Locale)
+}
+
+// Middleware carries the request ID and locale of incoming requests in their
+// context. Requests without an ID get a new one, which is echoed in the response.
+func Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			m := FromHTTPHeader(r.Header)
+			if m.RequestID == "" {
+				m.RequestID = uuid.New().String()
+			}
+			w.Header().Set(RequestIDHeader, m.RequestID)
+
+			ctx := NewContext(r.Context(), Metadata{RequestID: m.RequestID, Locale: m.Locale})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// FromIncomingGRPC returns the metadata carried in the incoming gRPC metadata
+func FromIncomingGRPC(ctx context.Context) Metadata {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Metadata{}
+	}
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	return Metadata{
+		UserID:    first(UserIDMetadataKey),
+		TenantID:  first(TenantIDMetadataKey),
+		Roles:     splitList(first(RolesMetadataKey)),
+		RequestID: first(RequestIDMetadataKey),
+		Locale:    preferredLocale(first(LocaleMetadataKey)),
+	}
+}
+
+// AppendToOutgoingGRPC returns a context whose outgoing gRPC metadata carries the
+// metadata of the context
+func AppendToOutgoingGRPC(ctx context.Context) context.Context {
+	m := FromContext(ctx)
+	pairs := make([]string, 0, 10)
+	appendIfPresent := func(key, value string) {
+		if value != "" {
+			pairs = append(pairs, key, value)
+		}
+	}
+	appendIfPresent(UserIDMetadataKey, m.UserID)
+	appendIfPresent(TenantIDMetadataKey, m.TenantID)
+	appendIfPresent(RolesMetadataKey, strings.Join(m.Roles, ","))
+	appendIfPresent(RequestIDMetadataKey, m.RequestID)
+	appendIfPresent(LocaleMetadataKey, m.Locale)
+	if len(pairs) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// UnaryServerInterceptor carries the request ID and locale of incoming calls in
+// their context, giving calls without an ID a new one
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(incomingContext(ctx), req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &stream{ServerStream: ss, ctx: incomingContext(ss.Context())})
+	}
+}
+
+// UnaryClientInterceptor propagates the metadata of the context to the called service
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(AppendToOutgoingGRPC(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func incomingContext(ctx context.Context) context.Context {
+	m := FromIncomingGRPC(ctx)
+	if m.RequestID == "" {
+		m.RequestID = uuid.New().String()
+	}
+	return NewContext(ctx, Metadata{RequestID: m.RequestID, Locale: m.Locale})
+}
+
+type stream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *stream) Context() context.Context {
+	return s.ctx
+}
+
+// KafkaHeaders returns the Kafka headers for the metadata carried in the context
+func KafkaHeaders(ctx context.Context) map[string]string {
+	m := FromContext(ctx)
+	headers := make(map[string]string)
+	setIfPresent := func(name, value string) {
+		if value != "" {
+			headers[name] = value
+		}
+	}
+	setIfPresent(UserIDKafkaHeader, m.UserID)
+	setIfPresent(TenantIDKafkaHeader, m.TenantID)
+	setIfPresent(RolesKafkaHeader, strings.Join(m.Roles, ","))
+	setIfPresent(RequestIDKafkaHeader, m.RequestID)
+	setIfPresent(LocaleKafkaHeader, m.Locale)
+	return headers
+}
+
+// FromKafkaHeaders returns the metadata carried in Kafka message headers
+func FromKafkaHeaders(headers map[string]string) Metadata {
+	return Metadata{
+		UserID:    headers[UserIDKafkaHeader],
+		TenantID:  headers[TenantIDKafkaHeader],
+		Roles:     splitList(headers[RolesKafkaHeader]),
+		RequestID: headers[RequestIDKafkaHeader],
+		Locale:    headers[LocaleKafkaHeader],
+	}
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// preferredLocale returns the first language tag of an Accept-Language value,
+// e.g. "de-CH" for "de-CH, de;q=0.9, en;q=0.8"
+func preferredLocale(value string) string {
+	tag, _, _ := strings.Cut(value, ",")
+	tag, _, _ = strings.Cut(tag, ";")
+	tag = strings.TrimSpace(tag)
+	if tag == "*" {
+		return ""
+	}
+	return tag
+}
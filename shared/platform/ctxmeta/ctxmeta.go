@@ -0,0 +1,200 @@
+// Package ctxmeta carries request metadata (the caller's user ID, tenant ID, roles,
+// permissions and session, the request ID and the locale) in a context, and across
+// service boundaries in HTTP headers, gRPC metadata and Kafka headers. Permissions are
+// not propagated further: downstream services authorize on the roles.
+//
+// Identity fields are only ever set by authentication: an auth interceptor that
+// validated the caller's token, or middleware behind the gateway that validated the
+// session. The inbound middleware and interceptors of this package only carry over
+// the request ID and locale, so a client cannot claim an identity by sending headers.
+package ctxmeta
+
+import (
+	"context"
+	"strings"
+)
+
+type key int
+
+const (
+	userIDKey key = iota
+	tenantIDKey
+	rolesKey
+	permissionsKey
+	sessionIDKey
+	requestIDKey
+	localeKey
+)
+
+// Metadata is the request metadata carried in a context
+type Metadata struct {
+	UserID      string
+	TenantID    string
+	Roles       []string
+	Permissions []string // Permission snapshot of the session, e.g. "orders.read" or "inventory.*"
+	SessionID   string
+	RequestID   string
+	Locale      string // BCP 47 language tag, e.g. "en-US"
+}
+
+// FromContext returns all metadata carried in the context
+func FromContext(ctx context.Context) Metadata {
+	m := Metadata{}
+	m.UserID, _ = UserID(ctx)
+	m.TenantID, _ = TenantID(ctx)
+	m.Roles = Roles(ctx)
+	m.Permissions = Permissions(ctx)
+	m.SessionID, _ = SessionID(ctx)
+	m.RequestID, _ = RequestID(ctx)
+	m.Locale, _ = Locale(ctx)
+	return m
+}
+
+// NewContext returns a context carrying the non-empty fields of m. Fields m leaves
+// empty keep the value the parent context carries.
+func NewContext(ctx context.Context, m Metadata) context.Context {
+	if m.UserID != "" {
+		ctx = WithUserID(ctx, m.UserID)
+	}
+	if m.TenantID != "" {
+		ctx = WithTenantID(ctx, m.TenantID)
+	}
+	if len(m.Roles) > 0 {
+		ctx = WithRoles(ctx, m.Roles...)
+	}
+	if len(m.Permissions) > 0 {
+		ctx = WithPermissions(ctx, m.Permissions...)
+	}
+	if m.SessionID != "" {
+		ctx = WithSessionID(ctx, m.SessionID)
+	}
+	if m.RequestID != "" {
+		ctx = WithRequestID(ctx, m.RequestID)
+	}
+	if m.Locale != "" {
+		ctx = WithLocale(ctx, m.Locale)
+	}
+	return ctx
+}
+
+// WithUserID returns a context carrying the authenticated user's ID
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserID returns the authenticated user's ID, if any
+func UserID(ctx context.Context) (string, bool) {
+	return stringValue(ctx, userIDKey)
+}
+
+// WithTenantID returns a context carrying the tenant the request acts for
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey, tenantID)
+}
+
+// TenantID returns the tenant the request acts for, if any
+func TenantID(ctx context.Context) (string, bool) {
+	return stringValue(ctx, tenantIDKey)
+}
+
+// WithRoles returns a context carrying the authenticated user's roles, lowercased
+func WithRoles(ctx context.Context, roles ...string) context.Context {
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if role = strings.ToLower(strings.TrimSpace(role)); role != "" {
+			normalized = append(normalized, role)
+		}
+	}
+	return context.WithValue(ctx, rolesKey, normalized)
+}
+
+// Roles returns the authenticated user's roles; nil when the caller is not authenticated
+func Roles(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	roles, _ := ctx.Value(rolesKey).([]string)
+	return roles
+}
+
+// HasRole reports whether the authenticated user holds the role, compared case-insensitively
+func HasRole(ctx context.Context, role string) bool {
+	role = strings.ToLower(role)
+	for _, held := range Roles(ctx) {
+		if held == role {
+			return true
+		}
+	}
+	return false
+}
+
+// WithPermissions returns a context carrying the permission snapshot of the
+// authenticated session
+func WithPermissions(ctx context.Context, permissions ...string) context.Context {
+	normalized := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		if permission = strings.TrimSpace(permission); permission != "" {
+			normalized = append(normalized, permission)
+		}
+	}
+	return context.WithValue(ctx, permissionsKey, normalized)
+}
+
+// Permissions returns the permission snapshot of the authenticated session; nil
+// when the caller is not authenticated or no snapshot was passed on
+func Permissions(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	permissions, _ := ctx.Value(permissionsKey).([]string)
+	return permissions
+}
+
+// HasPermission reports whether the permission snapshot grants the action on the
+// resource, either directly or through a "resource.*" or "*" wildcard
+func HasPermission(ctx context.Context, resource, action string) bool {
+	for _, held := range Permissions(ctx) {
+		if held == "*" || held == resource+".*" || held == resource+"."+action {
+			return true
+		}
+	}
+	return false
+}
+
+// WithSessionID returns a context carrying the authenticated session's ID
+func WithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionIDKey, sessionID)
+}
+
+// SessionID returns the authenticated session's ID, if any
+func SessionID(ctx context.Context) (string, bool) {
+	return stringValue(ctx, sessionIDKey)
+}
+
+// WithRequestID returns a context carrying the request ID used to correlate logs
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the request ID, if any
+func RequestID(ctx context.Context) (string, bool) {
+	return stringValue(ctx, requestIDKey)
+}
+
+// WithLocale returns a context carrying the caller's preferred locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+// Locale returns the caller's preferred locale, if any
+func Locale(ctx context.Context) (string, bool) {
+	return stringValue(ctx, localeKey)
+}
+
+func stringValue(ctx context.Context, k key) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	value, ok := ctx.Value(k).(string)
+	return value, ok && value != ""
+}
@@ -0,0 +1,160 @@
+// Package deadline carries a request's time budget across service boundaries. The
+// budget a client grants at the edge bounds the HTTP handler's context; gRPC sends
+// the context deadline as grpc-timeout on its own, and Kafka saga steps carry it in
+// a message header so consumers can tell whether the caller is still waiting.
+package deadline
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Headers that carry a time budget
+const (
+	// RequestTimeoutHeader lets HTTP clients state how long they will wait, either as
+	// a Go duration ("2s", "750ms") or as a number of milliseconds
+	RequestTimeoutHeader = "X-Request-Timeout"
+
+	// EnvoyTimeoutHeader is set by Envoy to the route timeout it enforces, in milliseconds
+	EnvoyTimeoutHeader = "X-Envoy-Expected-Rq-Timeout-Ms"
+
+	// KafkaHeader carries the absolute deadline of a saga step, in RFC 3339 format
+	KafkaHeader = "x-deadline"
+)
+
+// FromRequest returns the budget the caller granted the request, or zero if it stated
+// none. When both headers are present the smaller budget wins.
+func FromRequest(r *http.Request) time.Duration {
+	var budget time.Duration
+	for _, value := range []string{r.Header.Get(RequestTimeoutHeader), r.Header.Get(EnvoyTimeoutHeader)} {
+		if parsed := parseBudget(value); parsed > 0 && (budget == 0 || parsed < budget) {
+			budget = parsed
+		}
+	}
+	return budget
+}
+
+func parseBudget(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Duration(ms) * time.Millisecond
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return d
+	}
+	return 0
+}
+
+// Middleware bounds every request's context by the budget its caller granted, capped
+// at max. Requests without a stated budget get max.
+func Middleware(max time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			budget := FromRequest(r)
+			if budget <= 0 || budget > max {
+				budget = max
+			}
+
+			ctx, cancel := WithDeadline(r.Context(), time.Now().Add(budget))
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+type propagatedKey struct{}
+
+// WithDeadline bounds the context by deadline and marks it as the caller's budget, to
+// be carried on to Kafka by Header. Deadlines set with plain context.WithTimeout, such
+// as per-call client timeouts, stay local to the process.
+func WithDeadline(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	return Attach(ctx, deadline), cancel
+}
+
+// Attach marks deadline as the caller's budget without bounding the context. Consumers
+// use it for saga steps that must run to completion but still relay the budget.
+func Attach(ctx context.Context, deadline time.Time) context.Context {
+	return context.WithValue(ctx, propagatedKey{}, deadline)
+}
+
+// Propagated returns the caller's budget attached to the context, if any
+func Propagated(ctx context.Context) (time.Time, bool) {
+	deadline, ok := ctx.Value(propagatedKey{}).(time.Time)
+	return deadline, ok
+}
+
+// Remaining returns how much time is left before the context's deadline. It returns
+// ok false when the context has no deadline.
+func Remaining(ctx context.Context) (remaining time.Duration, ok bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return time.Until(deadline), true
+}
+
+// Allows reports whether the context leaves at least d before its deadline. Contexts
+// without a deadline always allow it.
+func Allows(ctx context.Context, d time.Duration) bool {
+	remaining, ok := Remaining(ctx)
+	return !ok || remaining >= d
+}
+
+// UnaryServerInterceptor rejects calls whose deadline passed before they reached the
+// handler, so a server does not start work nobody is waiting for. The deadline of
+// accepted calls is marked as the caller's budget.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			return handler(ctx, req)
+		}
+		if !time.Now().Before(deadline) {
+			return nil, status.Errorf(codes.DeadlineExceeded, "deadline exceeded before %s started", info.FullMethod)
+		}
+		return handler(Attach(ctx, deadline), req)
+	}
+}
+
+// Header returns the Kafka header value for the caller's budget attached to the
+// context, or ok false when there is none
+func Header(ctx context.Context) (value string, ok bool) {
+	deadline, ok := Propagated(ctx)
+	if !ok {
+		return "", false
+	}
+	return Format(deadline), true
+}
+
+// Format renders a deadline the way it is carried in Kafka headers
+func Format(deadline time.Time) string {
+	return deadline.UTC().Format(time.RFC3339Nano)
+}
+
+// FromHeaders returns the deadline carried in Kafka message headers, if any
+func FromHeaders(headers map[string]string) (time.Time, bool) {
+	value, exists := headers[KafkaHeader]
+	if !exists {
+		return time.Time{}, false
+	}
+	deadline, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return deadline, true
+}
+
+// Expired reports whether the deadline carried in Kafka message headers has passed
+func Expired(headers map[string]string) bool {
+	deadline, ok := FromHeaders(headers)
+	return ok && !time.Now().Before(deadline)
+}
@@ -12,6 +12,10 @@ const (
 	ErrorTypeConflict   = "conflict"
 	ErrorTypeInternal   = "internal"
 	ErrorTypeExternal   = "external"
+
+	// ErrorTypeUnavailable marks a transient refusal (e.g. maintenance mode);
+	// callers are expected to retry later
+	ErrorTypeUnavailable = "unavailable"
 )
 
 // AppError represents an application error with type and context
@@ -87,6 +91,14 @@ func NewExternal(message string) *AppError {
 	}
 }
 
+// NewUnavailable creates a new retryable service unavailable error
+func NewUnavailable(message string) *AppError {
+	return &AppError{
+		Type:    ErrorTypeUnavailable,
+		Message: message,
+	}
+}
+
 // Wrap wraps an existing error with a message
 func Wrap(err error, message string) *AppError {
 	if err == nil {
@@ -137,6 +149,11 @@ func IsExternal(err error) bool {
 	return hasErrorType(err, ErrorTypeExternal)
 }
 
+// IsUnavailable checks if error is a retryable service unavailable error
+func IsUnavailable(err error) bool {
+	return hasErrorType(err, ErrorTypeUnavailable)
+}
+
 // hasErrorType checks if the error has the specified type
 func hasErrorType(err error, errorType string) bool {
 	if err == nil {
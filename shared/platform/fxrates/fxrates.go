@@ -0,0 +1,197 @@
+// Package fxrates provides currency exchange rates for displaying prices in a
+// customer's local currency. Rates are fetched as a table against one base
+// currency, from a JSON endpoint or a static list, and cached; cross rates
+// between two non-base currencies are derived from the table.
+//
+// Rates are exact rationals (math/big) rather than floats, so converted money
+// amounts are free of binary floating point rounding.
+package fxrates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exchange rate errors
+var (
+	ErrUnsupportedCurrency = errors.New("fxrates: unsupported currency")
+	ErrUnavailable         = errors.New("fxrates: rates unavailable")
+)
+
+// Rate is the price of one unit of Base in Quote
+type Rate struct {
+	Base  string
+	Quote string
+	Value *big.Rat
+	AsOf  time.Time // When the source published the rate
+}
+
+// String formats the rate as a decimal with up to 10 places, e.g. "0.9213"
+func (r Rate) String() string {
+	formatted := r.Value.FloatString(10)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, ".")
+}
+
+// Provider looks up exchange rates
+type Provider interface {
+	// Rate returns the price of one unit of base in quote. Unknown currencies
+	// fail with ErrUnsupportedCurrency.
+	Rate(ctx context.Context, base, quote string) (Rate, error)
+}
+
+// Table lists the rates of currencies against a base currency
+type Table struct {
+	Base  string
+	Rates map[string]*big.Rat // Price of one unit of Base in each currency
+	AsOf  time.Time
+}
+
+// Rate derives the rate between two currencies of the table
+func (t *Table) Rate(base, quote string) (Rate, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	rate := Rate{Base: base, Quote: quote, AsOf: t.AsOf}
+	if base == quote {
+		rate.Value = big.NewRat(1, 1)
+		return rate, nil
+	}
+
+	baseRate, err := t.against(base)
+	if err != nil {
+		return Rate{}, err
+	}
+	quoteRate, err := t.against(quote)
+	if err != nil {
+		return Rate{}, err
+	}
+	rate.Value = new(big.Rat).Quo(quoteRate, baseRate)
+	return rate, nil
+}
+
+// against returns the price of one unit of the table's base in currency
+func (t *Table) against(currency string) (*big.Rat, error) {
+	if currency == t.Base {
+		return big.NewRat(1, 1), nil
+	}
+	rate, ok := t.Rates[currency]
+	if !ok || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+	return rate, nil
+}
+
+// Source fetches the current rate table
+type Source interface {
+	Fetch(ctx context.Context) (*Table, error)
+}
+
+// Cache is a Provider over a Source, refetching the table once it is older than
+// the TTL. When a refetch fails the last table keeps being served until it is
+// MaxStale old, so a flaky source does not take display prices down.
+type Cache struct {
+	source   Source
+	ttl      time.Duration
+	maxStale time.Duration
+
+	mu        sync.Mutex
+	table     *Table
+	fetchedAt time.Time
+}
+
+// NewCache creates a provider caching the tables of source for ttl, serving a
+// stale table for up to maxStale when the source fails
+func NewCache(source Source, ttl, maxStale time.Duration) *Cache {
+	return &Cache{source: source, ttl: ttl, maxStale: maxStale}
+}
+
+// Rate implements Provider
+func (c *Cache) Rate(ctx context.Context, base, quote string) (Rate, error) {
+	table, err := c.current(ctx)
+	if err != nil {
+		return Rate{}, err
+	}
+	return table.Rate(base, quote)
+}
+
+// current returns the cached table, refetching it when expired. Concurrent
+// callers wait for a single refetch.
+func (c *Cache) current(ctx context.Context) (*Table, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.table != nil && now.Sub(c.fetchedAt) < c.ttl {
+		return c.table, nil
+	}
+
+	table, err := c.source.Fetch(ctx)
+	if err == nil {
+		c.table, c.fetchedAt = table, now
+		return table, nil
+	}
+	if c.table != nil && now.Sub(c.fetchedAt) < c.ttl+c.maxStale {
+		return c.table, nil
+	}
+	return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+}
+
+// StaticSource serves a fixed table, e.g. rates set in the configuration
+type StaticSource struct {
+	table *Table
+}
+
+// NewStaticSource creates a source of fixed rates against base, given as
+// decimals keyed by currency code
+func NewStaticSource(base string, rates map[string]string, asOf time.Time) (*StaticSource, error) {
+	table, err := parseTable(base, rates, asOf)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticSource{table: table}, nil
+}
+
+// Fetch implements Source
+func (s *StaticSource) Fetch(context.Context) (*Table, error) {
+	return s.table, nil
+}
+
+// parseTable validates and parses decimal rates against base
+func parseTable(base string, rates map[string]string, asOf time.Time) (*Table, error) {
+	table := &Table{
+		Base:  strings.ToUpper(strings.TrimSpace(base)),
+		Rates: make(map[string]*big.Rat, len(rates)),
+		AsOf:  asOf,
+	}
+	if !validCurrency(table.Base) {
+		return nil, fmt.Errorf("invalid base currency %q", base)
+	}
+	for currency, value := range rates {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if !validCurrency(currency) {
+			return nil, fmt.Errorf("invalid currency %q", currency)
+		}
+		rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid rate %q for %s", value, currency)
+		}
+		table.Rates[currency] = rate
+	}
+	return table, nil
+}
+
+func validCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+package fxrates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// HTTPSource fetches rate tables from a JSON endpoint answering
+//
+//	{"base": "USD", "timestamp": 1718000000, "rates": {"EUR": 0.9213, "GBP": 0.7861}}
+//
+// as served by most exchange rate APIs. The timestamp is in Unix seconds;
+// without one the fetch time is used.
+type HTTPSource struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSource creates a source fetching from url
+func NewHTTPSource(url string, timeout time.Duration) *HTTPSource {
+	return &HTTPSource{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+// Fetch implements Source
+func (s *HTTPSource) Fetch(ctx context.Context) (*Table, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rates request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch rates: status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Base      string                 `json:"base"`
+		Timestamp int64                  `json:"timestamp"`
+		Rates     map[string]json.Number `json:"rates"`
+	}
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber() // Keeps the rates exact
+	if err := decoder.Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode rates: %w", err)
+	}
+
+	asOf := time.Now().UTC()
+	if body.Timestamp > 0 {
+		asOf = time.Unix(body.Timestamp, 0).UTC()
+	}
+	rates := make(map[string]string, len(body.Rates))
+	for currency, rate := range body.Rates {
+		rates[currency] = rate.String()
+	}
+	return parseTable(body.Base, rates, asOf)
+}
+
+// FromEnv creates the provider configured in the environment, or returns nil
+// when no rates are configured:
+//
+//	FX_RATES_URL        JSON endpoint of the rates, see HTTPSource
+//	FX_RATES            static rates used without a URL, e.g. "EUR=0.92,GBP=0.79"
+//	FX_RATES_BASE       base currency of the static rates, default USD
+//	FX_RATES_TTL        how long fetched rates are cached, default 1h
+//	FX_RATES_MAX_STALE  how long cached rates are served past the TTL while the source fails, default 24h
+//	FX_RATES_TIMEOUT    timeout of a fetch, default 5s
+func FromEnv() (Provider, error) {
+	ttl, err := envDuration("FX_RATES_TTL", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	maxStale, err := envDuration("FX_RATES_MAX_STALE", 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := envDuration("FX_RATES_TIMEOUT", 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	if url := os.Getenv("FX_RATES_URL"); url != "" {
+		return NewCache(NewHTTPSource(url, timeout), ttl, maxStale), nil
+	}
+
+	value := os.Getenv("FX_RATES")
+	if value == "" {
+		return nil, nil
+	}
+	rates := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		currency, rate, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid FX_RATES entry %q: want CURRENCY=rate", pair)
+		}
+		rates[currency] = rate
+	}
+	base := os.Getenv("FX_RATES_BASE")
+	if base == "" {
+		base = "USD"
+	}
+	source, err := NewStaticSource(base, rates, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("invalid FX_RATES: %w", err)
+	}
+	return NewCache(source, ttl, maxStale), nil
+}
+
+func envDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid %s %q", key, value)
+	}
+	return duration, nil
+}
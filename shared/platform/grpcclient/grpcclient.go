@@ -0,0 +1,136 @@
+// Package grpcclient provides the retry interceptor of the gRPC clients services
+// use to call each other. Calls a server rejected while shedding load,
+// ResourceExhausted from its rate limiter or Unavailable in maintenance mode,
+// are retried with jittered exponential backoff that honors the server's
+// RetryInfo. While a server asked to wait, calls fail fast without being sent.
+// Metrics:
+//
+//	grpc_client_retries_total{client, method, code}
+//	grpc_client_circuit_open_total{client, cause}
+//	grpc_client_circuit_rejected_total{client, method}
+//
+// Only rejected calls are retried: they were not processed, so retrying is safe
+// whether or not the method is idempotent.
+package grpcclient
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amiosamu/rocket-science/shared/platform/backoff"
+	"github.com/amiosamu/rocket-science/shared/platform/deadline"
+	"github.com/amiosamu/rocket-science/shared/platform/grpcerrors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Config configures the retry interceptor of a client
+type Config struct {
+	// Name identifies the client in metrics, e.g. "inventory"
+	Name string
+
+	// MaxRetries is the number of retries after the first attempt. Zero only
+	// holds calls off while the server asked to wait, for clients that retry
+	// on their own.
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+
+	// MaxRetryAfter is the longest RetryInfo delay waited out before a retry. A
+	// call asked to wait longer fails with the server's error.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultConfig returns the configuration used unless a client overrides it
+func DefaultConfig(name string) Config {
+	return Config{
+		Name:           name,
+		MaxRetries:     2,
+		RetryBaseDelay: 200 * time.Millisecond,
+		RetryMaxDelay:  5 * time.Second,
+		MaxRetryAfter:  backoff.DefaultMaxRetryAfter,
+	}
+}
+
+type retrier struct {
+	config  Config
+	backoff backoff.Policy
+	holdOff backoff.HoldOff
+	metrics metrics.Metrics
+}
+
+// UnaryClientInterceptor retries the calls of a client rejected by a server
+// shedding load. metrics may be nil.
+func UnaryClientInterceptor(cfg Config, m metrics.Metrics) grpc.UnaryClientInterceptor {
+	r := &retrier{
+		config: cfg,
+		backoff: backoff.Policy{
+			BaseDelay:     cfg.RetryBaseDelay,
+			MaxDelay:      cfg.RetryMaxDelay,
+			MaxRetryAfter: cfg.MaxRetryAfter,
+		},
+		metrics: m,
+	}
+	return r.intercept
+}
+
+func (r *retrier) intercept(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	for attempt := 0; ; attempt++ {
+		if remaining := r.holdOff.Remaining(); remaining > 0 {
+			r.increment(ctx, "grpc_client_circuit_rejected_total", map[string]string{"client": r.config.Name, "method": method})
+			return grpcerrors.New(codes.Unavailable, grpcerrors.ReasonUnavailable,
+				r.config.Name+" asked clients to wait before calling again", grpcerrors.WithRetryAfter(remaining))
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || !shedding(err) || ctx.Err() != nil {
+			return err
+		}
+
+		hint, hinted := grpcerrors.RetryDelay(err)
+		if hinted && r.holdOff.Extend(r.backoff.HoldOff(hint)) {
+			r.increment(ctx, "grpc_client_circuit_open_total", map[string]string{"client": r.config.Name, "cause": "retry_after"})
+		}
+		if attempt == r.config.MaxRetries {
+			return err
+		}
+		delay, ok := r.backoff.Delay(attempt+1, hint)
+		if !ok || !deadline.Allows(ctx, delay) {
+			return err // The wait is longer than the client or the caller allows
+		}
+		if sleepErr := backoff.Sleep(ctx, delay); sleepErr != nil {
+			return err
+		}
+		r.increment(ctx, "grpc_client_retries_total", map[string]string{
+			"client": r.config.Name,
+			"method": method,
+			"code":   status.Code(err).String(),
+		})
+	}
+}
+
+// shedding reports whether a call was rejected by a server shedding load
+func shedding(err error) bool {
+	switch status.Code(err) {
+	case codes.ResourceExhausted, codes.Unavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *retrier) increment(ctx context.Context, name string, labels map[string]string) {
+	if r.metrics != nil {
+		r.metrics.IncrementCounter(ctx, name, labels)
+	}
+}
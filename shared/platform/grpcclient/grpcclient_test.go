@@ -0,0 +1,46 @@
+package grpcclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amiosamu/rocket-science/shared/platform/grpcerrors"
+)
+
+// A server asking for a wait longer than MaxRetryAfter is not retried, and holds
+// calls off for MaxRetryAfter only
+func TestOversizedRetryInfoIsCapped(t *testing.T) {
+	cfg := DefaultConfig("test")
+	cfg.MaxRetryAfter = time.Second
+	intercept := UnaryClientInterceptor(cfg, nil)
+
+	calls := 0
+	rejected := grpcerrors.New(codes.ResourceExhausted, grpcerrors.ReasonRateLimited, "slow down",
+		grpcerrors.WithRetryAfter(24*time.Hour))
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		calls++
+		return rejected
+	}
+
+	err := intercept(context.Background(), "/test.Service/Call", nil, nil, nil, invoker)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("first call: got %v, want the server's ResourceExhausted", err)
+	}
+	if calls != 1 {
+		t.Fatalf("first call: sent %d times, want 1", calls)
+	}
+
+	err = intercept(context.Background(), "/test.Service/Call", nil, nil, nil, invoker)
+	if status.Code(err) != codes.Unavailable || calls != 1 {
+		t.Fatalf("held off call: got %v after %d calls, want Unavailable without sending it", err, calls)
+	}
+	remaining, ok := grpcerrors.RetryDelay(err)
+	if !ok || remaining <= 0 || remaining > cfg.MaxRetryAfter {
+		t.Fatalf("held off call: retry after %v, want at most %v", remaining, cfg.MaxRetryAfter)
+	}
+}
@@ -0,0 +1,238 @@
+// Package grpcerrors builds gRPC errors carrying google.rpc error details and reads
+// them back on the client side. Servers attach an ErrorInfo reason, retry hints and
+// field violations, so clients can tell a retryable condition such as a stock
+// shortage from a permanent validation error without matching message strings.
+package grpcerrors
+
+import (
+	stdErrors "errors"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+)
+
+// Domain is the ErrorInfo domain of errors raised by rocket-science services
+const Domain = "rocket-science"
+
+// ErrorInfo reasons shared across services
+const (
+	ReasonValidation        = "VALIDATION_FAILED"
+	ReasonNotFound          = "NOT_FOUND"
+	ReasonConflict          = "CONFLICT"
+	ReasonUnavailable       = "UNAVAILABLE"
+	ReasonInternal          = "INTERNAL"
+	ReasonInsufficientStock = "INSUFFICIENT_STOCK"
+	ReasonRateLimited       = "RATE_LIMITED"
+)
+
+// FieldViolation describes an invalid field of a request
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// PreconditionViolation describes a failed precondition, e.g. the stock of a SKU
+type PreconditionViolation struct {
+	Type        string // e.g. "STOCK"
+	Subject     string // e.g. the SKU
+	Description string
+}
+
+// Option adds a detail to an error built by New
+type Option func(*details)
+
+type details struct {
+	metadata      map[string]string
+	retryAfter    time.Duration
+	violations    []FieldViolation
+	preconditions []PreconditionViolation
+}
+
+// WithMetadata adds metadata to the ErrorInfo of the error
+func WithMetadata(metadata map[string]string) Option {
+	return func(d *details) {
+		d.metadata = metadata
+	}
+}
+
+// WithRetryAfter adds a RetryInfo telling clients to retry after delay
+func WithRetryAfter(delay time.Duration) Option {
+	return func(d *details) {
+		d.retryAfter = delay
+	}
+}
+
+// WithFieldViolations adds a BadRequest listing invalid request fields
+func WithFieldViolations(violations ...FieldViolation) Option {
+	return func(d *details) {
+		d.violations = append(d.violations, violations...)
+	}
+}
+
+// WithPreconditionFailures adds a PreconditionFailure listing failed preconditions
+func WithPreconditionFailures(violations ...PreconditionViolation) Option {
+	return func(d *details) {
+		d.preconditions = append(d.preconditions, violations...)
+	}
+}
+
+// New creates a gRPC error with code and message, an ErrorInfo with reason and the
+// details of opts
+func New(code codes.Code, reason, message string, opts ...Option) error {
+	var d details
+	for _, opt := range opts {
+		opt(&d)
+	}
+
+	msgs := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: reason, Domain: Domain, Metadata: d.metadata},
+	}
+	if d.retryAfter > 0 {
+		msgs = append(msgs, &errdetails.RetryInfo{RetryDelay: durationpb.New(d.retryAfter)})
+	}
+	if len(d.violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range d.violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations,
+				&errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Description})
+		}
+		msgs = append(msgs, badRequest)
+	}
+	if len(d.preconditions) > 0 {
+		failure := &errdetails.PreconditionFailure{}
+		for _, v := range d.preconditions {
+			failure.Violations = append(failure.Violations,
+				&errdetails.PreconditionFailure_Violation{Type: v.Type, Subject: v.Subject, Description: v.Description})
+		}
+		msgs = append(msgs, failure)
+	}
+
+	st, err := status.New(code, message).WithDetails(msgs...)
+	if err != nil {
+		// Only fails for unmarshalable details; fall back to the bare status
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
+
+// InvalidArgument creates a validation error listing the invalid fields
+func InvalidArgument(message string, violations ...FieldViolation) error {
+	return New(codes.InvalidArgument, ReasonValidation, message, WithFieldViolations(violations...))
+}
+
+// FieldError creates a validation error for a single invalid field
+func FieldError(field, description string) error {
+	return InvalidArgument(description, FieldViolation{Field: field, Description: description})
+}
+
+// FromError converts a platform error into a gRPC error with a matching code and
+// reason. Errors that already carry a gRPC status are returned as they are.
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var appErr *errors.AppError
+	if !stdErrors.As(err, &appErr) {
+		return New(codes.Internal, ReasonInternal, err.Error())
+	}
+
+	switch appErr.Type {
+	case errors.ErrorTypeValidation:
+		return New(codes.InvalidArgument, ReasonValidation, err.Error())
+	case errors.ErrorTypeNotFound:
+		return New(codes.NotFound, ReasonNotFound, err.Error())
+	case errors.ErrorTypeConflict:
+		return New(codes.AlreadyExists, ReasonConflict, err.Error())
+	case errors.ErrorTypeUnavailable:
+		return New(codes.Unavailable, ReasonUnavailable, err.Error(), WithRetryAfter(DefaultRetryAfter))
+	default:
+		return New(codes.Internal, ReasonInternal, err.Error())
+	}
+}
+
+// DefaultRetryAfter is the retry hint of unavailable errors converted by FromError
+const DefaultRetryAfter = 5 * time.Second
+
+// Reason returns the ErrorInfo reason of err, or "" if it has none
+func Reason(err error) string {
+	if info := errorInfo(err); info != nil {
+		return info.GetReason()
+	}
+	return ""
+}
+
+// Metadata returns the ErrorInfo metadata of err
+func Metadata(err error) map[string]string {
+	if info := errorInfo(err); info != nil {
+		return info.GetMetadata()
+	}
+	return nil
+}
+
+// RetryDelay returns the RetryInfo delay of err and whether it has one
+func RetryDelay(err error) (time.Duration, bool) {
+	for _, detail := range statusDetails(err) {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// FieldViolations returns the invalid fields listed by err
+func FieldViolations(err error) []FieldViolation {
+	var violations []FieldViolation
+	for _, detail := range statusDetails(err) {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range badRequest.GetFieldViolations() {
+				violations = append(violations, FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		}
+	}
+	return violations
+}
+
+// IsRetryable reports whether the call that failed with err may succeed when
+// repeated. A RetryInfo makes any error retryable; otherwise only transient codes are.
+func IsRetryable(err error) bool {
+	if _, ok := RetryDelay(err); ok {
+		return true
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+func errorInfo(err error) *errdetails.ErrorInfo {
+	for _, detail := range statusDetails(err) {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+func statusDetails(err error) []interface{} {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	return st.Details()
+}
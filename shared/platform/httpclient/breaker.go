@@ -0,0 +1,122 @@
+package httpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit
+// breaker of the client is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// breakerState is the state of a circuit breaker
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker opens after a run of consecutive failures and rejects
+// requests until openDuration has passed. It then lets a single probe
+// through: a success closes the breaker, a failure opens it again.
+type circuitBreaker struct {
+	threshold    int
+	openDuration time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(threshold int, openDuration time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, openDuration: openDuration}
+}
+
+// allow reports whether a request may be sent
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if time.Since(b.openedAt) < b.openDuration {
+			return false
+		}
+		b.state = stateHalfOpen
+		b.probing = true
+		return true
+	case stateHalfOpen:
+		// Only the probe goes through until it has finished
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record counts the outcome of a request and returns the state change, if any
+func (b *circuitBreaker) record(failed bool) (from, to breakerState, changed bool) {
+	if b == nil {
+		return stateClosed, stateClosed, false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from = b.state
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		b.state = stateClosed
+		return from, b.state, from != b.state
+	}
+
+	b.failures++
+	if b.state == stateHalfOpen || b.failures >= b.threshold {
+		b.state = stateOpen
+		b.openedAt = time.Now()
+	}
+	return from, b.state, from != b.state
+}
+
+// release ends a request without counting its outcome, letting another
+// probe through when it was the probe
+func (b *circuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// current returns the state of the breaker
+func (b *circuitBreaker) current() breakerState {
+	if b == nil {
+		return stateClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
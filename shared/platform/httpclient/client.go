@@ -0,0 +1,332 @@
+// Package httpclient provides the instrumented HTTP client used for calls to
+// third-party APIs. Requests get a timeout, retries with jittered exponential
+// backoff that honors Retry-After, a circuit breaker, proxy support, a client
+// span with propagated trace context, and metrics:
+//
+//	http_client_requests_total{client, method, host, status}
+//	http_client_request_duration_seconds{client, method, host, status}
+//	http_client_retries_total{client, method, host}
+//	http_client_circuit_state_changes_total{client, from, to}
+//	http_client_circuit_open_total{client, cause}
+//	http_client_circuit_rejected_total{client}
+//
+// The status label is the response code, or "error" when no response was
+// received. The circuit opens after a run of failures (cause "failures") or
+// when a server shedding load answers 429 or 503 with a Retry-After (cause
+// "retry_after"); requests are then rejected without being sent.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/amiosamu/rocket-science/shared/platform/backoff"
+	"github.com/amiosamu/rocket-science/shared/platform/deadline"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Config configures a client
+type Config struct {
+	// Name identifies the client in metrics and spans, e.g. "telegram"
+	Name string
+
+	// Timeout bounds a single attempt, including reading the response body
+	Timeout time.Duration
+
+	// MaxRetries is the number of retries after the first attempt. Only
+	// idempotent requests are retried unless RetryNonIdempotent is set.
+	MaxRetries         int
+	RetryBaseDelay     time.Duration
+	RetryMaxDelay      time.Duration
+	RetryNonIdempotent bool
+
+	// MaxRetryAfter is the longest Retry-After waited out before a retry. A
+	// response asking for a longer wait is returned without retrying.
+	MaxRetryAfter time.Duration
+
+	// PerResourceRetryAfter is set for APIs whose 429s limit a single resource,
+	// such as a chat, rather than the client: their Retry-After is honored by
+	// retries but doesn't hold off the client's other requests
+	PerResourceRetryAfter bool
+
+	// The circuit breaker opens after BreakerThreshold consecutive failures
+	// (transport errors and 5xx responses) and rejects requests for
+	// BreakerOpenDuration. A threshold of 0 disables it.
+	BreakerThreshold    int
+	BreakerOpenDuration time.Duration
+
+	// ProxyURL routes requests through a proxy; when empty the HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY environment variables apply
+	ProxyURL string
+}
+
+// DefaultConfig returns the configuration used unless a client overrides it
+func DefaultConfig(name string) Config {
+	return Config{
+		Name:                name,
+		Timeout:             10 * time.Second,
+		MaxRetries:          2,
+		RetryBaseDelay:      200 * time.Millisecond,
+		RetryMaxDelay:       5 * time.Second,
+		MaxRetryAfter:       backoff.DefaultMaxRetryAfter,
+		BreakerThreshold:    5,
+		BreakerOpenDuration: 30 * time.Second,
+	}
+}
+
+// Client sends HTTP requests to an external API
+type Client struct {
+	config  Config
+	http    *http.Client
+	backoff backoff.Policy
+	breaker *circuitBreaker
+	holdOff backoff.HoldOff // Set by servers shedding load
+	tracer  trace.Tracer
+	metrics metrics.Metrics
+}
+
+// New creates a client. metrics may be nil.
+func New(cfg Config, m metrics.Metrics) (*Client, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("HTTP client name is required")
+	}
+	if cfg.Timeout <= 0 {
+		return nil, fmt.Errorf("HTTP client %s: timeout must be positive", cfg.Name)
+	}
+	if cfg.MaxRetries < 0 || cfg.BreakerThreshold < 0 {
+		return nil, fmt.Errorf("HTTP client %s: retries and breaker threshold cannot be negative", cfg.Name)
+	}
+	if cfg.BreakerThreshold > 0 && cfg.BreakerOpenDuration <= 0 {
+		return nil, fmt.Errorf("HTTP client %s: breaker open duration must be positive", cfg.Name)
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP client %s: invalid proxy URL: %w", cfg.Name, err)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+
+	client := &Client{
+		config: cfg,
+		http:   &http.Client{Timeout: cfg.Timeout, Transport: transport},
+		backoff: backoff.Policy{
+			BaseDelay:     cfg.RetryBaseDelay,
+			MaxDelay:      cfg.RetryMaxDelay,
+			MaxRetryAfter: cfg.MaxRetryAfter,
+		},
+		tracer:  otel.Tracer("httpclient"),
+		metrics: m,
+	}
+	if cfg.BreakerThreshold > 0 {
+		client.breaker = newCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerOpenDuration)
+	}
+	return client, nil
+}
+
+// Name returns the name of the client
+func (c *Client) Name() string {
+	return c.config.Name
+}
+
+// CircuitState returns "closed", "open" or "half_open"
+func (c *Client) CircuitState() string {
+	if c.holdOff.Remaining() > 0 {
+		return stateOpen.String()
+	}
+	return c.breaker.current().String()
+}
+
+// Do sends a request, retrying transport errors and 429, 502, 503 and 504
+// responses. A Retry-After longer than the backoff is waited out, up to
+// MaxRetryAfter, and no retry is made past the deadline of the context.
+// Requests with a body are only retried when the body can be re-read
+// (http.NewRequest sets this up for in-memory bodies). The response of the
+// last attempt is returned as is, whatever its status.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx, span := c.tracer.Start(req.Context(), fmt.Sprintf("HTTP %s %s", req.Method, c.config.Name),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", req.Method),
+			attribute.String("net.peer.name", req.URL.Hostname()),
+			attribute.String("http.client", c.config.Name),
+		),
+	)
+	defer span.End()
+
+	retries := 0
+	if c.retryable(req) {
+		retries = c.config.MaxRetries
+	}
+
+	var resp *http.Response
+	var err error
+	attempt := 0
+	for ; ; attempt++ {
+		resp, err = c.attempt(ctx, req, attempt)
+		hint := c.recordRetryAfter(ctx, resp)
+		if !retryableResult(resp, err) || attempt == retries {
+			break
+		}
+		delay, ok := c.backoff.Delay(attempt+1, hint)
+		if !ok || !deadline.Allows(ctx, delay) {
+			break // The wait is longer than the client or the caller allows
+		}
+		if resp != nil {
+			// Drain so the connection can be reused for the retry
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := backoff.Sleep(ctx, delay); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		c.increment(ctx, "http_client_retries_total", c.labels(req, ""))
+	}
+
+	span.SetAttributes(attribute.Int("http.retries", attempt))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+// attempt sends the request once through the circuit breaker
+func (c *Client) attempt(ctx context.Context, req *http.Request, attempt int) (*http.Response, error) {
+	if remaining := c.holdOff.Remaining(); remaining > 0 {
+		c.increment(ctx, "http_client_circuit_rejected_total", map[string]string{"client": c.config.Name})
+		return nil, fmt.Errorf("%s: %w, server asked to retry in %s", c.config.Name, ErrCircuitOpen, remaining.Round(time.Millisecond))
+	}
+	if !c.breaker.allow() {
+		c.increment(ctx, "http_client_circuit_rejected_total", map[string]string{"client": c.config.Name})
+		return nil, fmt.Errorf("%s: %w", c.config.Name, ErrCircuitOpen)
+	}
+
+	out := req.Clone(ctx)
+	if attempt > 0 && req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		out.Body = body
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(out.Header))
+
+	start := time.Now()
+	resp, err := c.http.Do(out)
+
+	status := "error"
+	if resp != nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	labels := c.labels(req, status)
+	c.increment(ctx, "http_client_requests_total", labels)
+	if c.metrics != nil {
+		c.metrics.RecordDuration(ctx, "http_client_request_duration_seconds", time.Since(start), labels)
+	}
+
+	// A request the caller cancelled says nothing about the remote API
+	if err != nil && ctx.Err() != nil {
+		c.breaker.release()
+		return resp, err
+	}
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+	if from, to, changed := c.breaker.record(failed); changed {
+		c.increment(ctx, "http_client_circuit_state_changes_total", map[string]string{
+			"client": c.config.Name,
+			"from":   from.String(),
+			"to":     to.String(),
+		})
+		if to == stateOpen {
+			c.increment(ctx, "http_client_circuit_open_total", map[string]string{"client": c.config.Name, "cause": "failures"})
+		}
+	}
+	return resp, err
+}
+
+// recordRetryAfter holds requests off while a server shedding load asked to
+// wait, and returns the wait it asked for
+func (c *Client) recordRetryAfter(ctx context.Context, resp *http.Response) time.Duration {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0
+	}
+	hint, ok := backoff.RetryAfter(resp, time.Now())
+	if !ok {
+		return 0
+	}
+	if !c.config.PerResourceRetryAfter && c.holdOff.Extend(c.backoff.HoldOff(hint)) {
+		c.increment(ctx, "http_client_circuit_open_total", map[string]string{"client": c.config.Name, "cause": "retry_after"})
+	}
+	return hint
+}
+
+// retryable reports whether the request may be sent more than once
+func (c *Client) retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if c.config.RetryNonIdempotent {
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryableResult reports whether an attempt failed in a way worth retrying
+func retryableResult(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, ErrCircuitOpen)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Client) labels(req *http.Request, status string) map[string]string {
+	labels := map[string]string{
+		"client": c.config.Name,
+		"method": req.Method,
+		"host":   req.URL.Hostname(),
+	}
+	if status != "" {
+		labels["status"] = status
+	}
+	return labels
+}
+
+func (c *Client) increment(ctx context.Context, name string, labels map[string]string) {
+	if c.metrics != nil {
+		c.metrics.IncrementCounter(ctx, name, labels)
+	}
+}
@@ -0,0 +1,208 @@
+// Package iamcache caches IAM lookups of user data, such as profiles and
+// Telegram chat IDs, in the services that read them.
+//
+// Entries live for a TTL and are dropped early when IAM announces a change to
+// their user: IAM keys every event on its user events topic by user ID, so
+// InvalidationHandler drops a user's entries on any message keyed by them.
+// Each instance keeps its own cache and must therefore consume the topic in a
+// consumer group of its own. Concurrent misses of one entry share one IAM call,
+// which keeps bursts of notifications to one user from fanning out to IAM.
+package iamcache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Config holds the cache settings
+type Config struct {
+	TTL        time.Duration `json:"ttl"`         // How long an entry is served without an invalidation
+	MaxEntries int           `json:"max_entries"` // Entries kept across all users and kinds
+}
+
+// Cache holds IAM lookups per user and kind of lookup
+type Cache struct {
+	config  Config
+	metrics metrics.Metrics
+
+	mu         sync.Mutex
+	users      map[string]map[string]entry // By user ID, then kind
+	size       int
+	calls      map[callKey]*call
+	generation uint64 // Bumped by invalidations, so loads racing one are not cached
+}
+
+type entry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+type callKey struct {
+	userID string
+	kind   string
+}
+
+// call is a load in flight that concurrent misses wait for
+type call struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// New creates an empty cache
+func New(config Config, metrics metrics.Metrics) *Cache {
+	return &Cache{
+		config:  config,
+		metrics: metrics,
+		users:   make(map[string]map[string]entry),
+		calls:   make(map[callKey]*call),
+	}
+}
+
+// Lookup returns the cached value of a kind of lookup for a user, calling load
+// on a miss. Errors are not cached. A nil cache always calls load.
+func Lookup[T any](ctx context.Context, c *Cache, kind, userID string, load func(context.Context) (T, error)) (T, error) {
+	if c == nil {
+		return load(ctx)
+	}
+
+	value, err := c.get(ctx, kind, userID, func(ctx context.Context) (interface{}, error) {
+		return load(ctx)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.(T), nil
+}
+
+func (c *Cache) get(ctx context.Context, kind, userID string, load func(context.Context) (interface{}, error)) (interface{}, error) {
+	labels := map[string]string{"kind": kind}
+	key := callKey{userID: userID, kind: kind}
+
+	c.mu.Lock()
+	if cached, ok := c.users[userID][kind]; ok && time.Now().Before(cached.expiresAt) {
+		c.mu.Unlock()
+		c.metrics.IncrementCounter(ctx, "iam_cache_hits_total", labels)
+		return cached.value, nil
+	}
+	if inFlight, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		c.metrics.IncrementCounter(ctx, "iam_cache_shared_loads_total", labels)
+		select {
+		case <-inFlight.done:
+			return inFlight.value, inFlight.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	pending := &call{done: make(chan struct{})}
+	c.calls[key] = pending
+	generation := c.generation
+	c.mu.Unlock()
+
+	c.metrics.IncrementCounter(ctx, "iam_cache_misses_total", labels)
+	// The load is shared, so one caller giving up must not fail the others
+	pending.value, pending.err = load(context.WithoutCancel(ctx))
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	if pending.err == nil && c.generation == generation {
+		c.store(userID, kind, pending.value)
+	}
+	c.mu.Unlock()
+	close(pending.done)
+
+	return pending.value, pending.err
+}
+
+// store caches a value, making room when the cache is full. Callers hold c.mu.
+func (c *Cache) store(userID, kind string, value interface{}) {
+	entries := c.users[userID]
+	if entries == nil {
+		entries = make(map[string]entry)
+		c.users[userID] = entries
+	}
+	if _, replaced := entries[kind]; !replaced {
+		if c.size >= c.config.MaxEntries {
+			c.evict()
+		}
+		c.size++
+	}
+	entries[kind] = entry{value: value, expiresAt: time.Now().Add(c.config.TTL)}
+}
+
+// evict drops expired entries, or the entries of some user if none expired.
+// Callers hold c.mu.
+func (c *Cache) evict() {
+	now := time.Now()
+	for userID, entries := range c.users {
+		for kind, cached := range entries {
+			if !now.Before(cached.expiresAt) {
+				delete(entries, kind)
+				c.size--
+			}
+		}
+		if len(entries) == 0 {
+			delete(c.users, userID)
+		}
+	}
+	if c.size < c.config.MaxEntries {
+		return
+	}
+	for userID := range c.users {
+		c.drop(userID)
+		return
+	}
+}
+
+// drop removes the entries of a user. Callers hold c.mu.
+func (c *Cache) drop(userID string) {
+	c.size -= len(c.users[userID])
+	delete(c.users, userID)
+}
+
+// Invalidate drops the entries of a user, including loads still in flight
+func (c *Cache) Invalidate(userID string) {
+	c.mu.Lock()
+	c.drop(userID)
+	c.generation++
+	c.mu.Unlock()
+}
+
+// Size returns the number of cached entries
+func (c *Cache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// InvalidationHandler returns a Kafka message handler dropping the entries of
+// the user each message of the IAM user events topic is keyed by
+func (c *Cache) InvalidationHandler(topic string) kafka.MessageHandler {
+	return &invalidationHandler{cache: c, topic: topic}
+}
+
+type invalidationHandler struct {
+	cache *Cache
+	topic string
+}
+
+func (h *invalidationHandler) HandleMessage(ctx context.Context, message *kafka.Message) error {
+	if message.Key == "" {
+		return nil
+	}
+	h.cache.Invalidate(message.Key)
+	h.cache.metrics.IncrementCounter(ctx, "iam_cache_invalidations_total", map[string]string{
+		"event_type": message.EventType,
+	})
+	return nil
+}
+
+func (h *invalidationHandler) GetSupportedTopics() []string {
+	return []string{h.topic}
+}
@@ -0,0 +1,140 @@
+// Package jobs runs background jobs with persisted status, shared by services that
+// need long-running work such as imports, exports, reconciliation and purges.
+//
+// Jobs are enqueued into a Store and executed by a Worker, which claims them under
+// a lease: a job whose worker dies is claimed again once its lease expires. Failed
+// attempts are retried with the backoff of the RetryPolicy registered for the job
+// type, and handlers report progress that GetJobStatus exposes to clients.
+package jobs
+
+import (
+	"encoding/json"
+	stdErrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+)
+
+// State is the lifecycle state of a job
+type State string
+
+const (
+	StateQueued    State = "queued"    // Waiting for its run time or a free worker
+	StateRunning   State = "running"   // Claimed by a worker
+	StateSucceeded State = "succeeded" // Finished; Result holds the output of the handler
+	StateFailed    State = "failed"    // Out of attempts or failed permanently
+)
+
+// Finished reports whether the job will not run again
+func (s State) Finished() bool {
+	return s == StateSucceeded || s == StateFailed
+}
+
+// Job is a unit of background work of a registered type
+type Job struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	Type          string          `json:"type" db:"type"`
+	State         State           `json:"state" db:"state"`
+	Payload       json.RawMessage `json:"payload" db:"payload"`
+	Result        json.RawMessage `json:"result,omitempty" db:"-"` // Stored as nullable JSONB
+	ProgressDone  int64           `json:"progress_done" db:"progress_done"`
+	ProgressTotal int64           `json:"progress_total" db:"progress_total"` // 0 while unknown
+	Attempts      int             `json:"attempts" db:"attempts"`
+	LastError     string          `json:"last_error,omitempty" db:"last_error"`
+	RunAt         time.Time       `json:"run_at" db:"run_at"` // Earliest time of the next attempt
+	LockedBy      string          `json:"-" db:"locked_by"`
+	LockedUntil   *time.Time      `json:"-" db:"locked_until"` // Lease of the claiming worker
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
+	StartedAt     *time.Time      `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt    *time.Time      `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// EnqueueOption customizes a job before it is stored
+type EnqueueOption func(*Job)
+
+// RunAt delays the first attempt of a job until t
+func RunAt(t time.Time) EnqueueOption {
+	return func(j *Job) {
+		j.RunAt = t
+	}
+}
+
+// NewJob creates a queued job of jobType with payload marshaled as JSON
+func NewJob(jobType string, payload interface{}, opts ...EnqueueOption) (*Job, error) {
+	if jobType == "" {
+		return nil, errors.NewValidation("job type is required")
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal job payload")
+	}
+
+	now := time.Now().UTC()
+	job := &Job{
+		ID:        uuid.New(),
+		Type:      jobType,
+		State:     StateQueued,
+		Payload:   data,
+		RunAt:     now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	return job, nil
+}
+
+// RetryPolicy decides whether and when a failed job runs again
+type RetryPolicy struct {
+	MaxAttempts    int           // Attempts including the first
+	InitialBackoff time.Duration // Delay after the first failure, doubled after every further one
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy returns the policy of job types registered without one
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     30 * time.Minute,
+	}
+}
+
+// Backoff returns the delay before the attempt following attempt
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
+
+// permanentError marks a failure that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying; the job fails at once
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether a handler error fails its job without retries.
+// Validation errors are permanent too, since the same payload fails again.
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return stdErrors.As(err, &permanent) || errors.IsValidation(err)
+}
@@ -0,0 +1,112 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+)
+
+// Enqueue creates a job of jobType with payload and stores it
+func Enqueue(ctx context.Context, store Store, jobType string, payload interface{}, opts ...EnqueueOption) (*Job, error) {
+	job, err := NewJob(jobType, payload, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Status is the client view of a job, as served by job status endpoints
+type Status struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	State      State           `json:"state"`
+	Progress   Progress        `json:"progress"`
+	Attempts   int             `json:"attempts"`
+	Error      string          `json:"error,omitempty"` // Error of the last failed attempt
+	Result     json.RawMessage `json:"result,omitempty"`
+	NextRunAt  *time.Time      `json:"next_run_at,omitempty"` // Set while the job waits for a retry
+	CreatedAt  time.Time       `json:"created_at"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+}
+
+// Progress reports how much of a job is done
+type Progress struct {
+	Done    int64   `json:"done"`
+	Total   int64   `json:"total"`             // 0 while unknown
+	Percent float64 `json:"percent,omitempty"` // Set once the total is known
+}
+
+// GetJobStatus returns the status of a job, or a not found error
+func GetJobStatus(ctx context.Context, store Store, id uuid.UUID) (*Status, error) {
+	job, err := store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return NewStatus(job), nil
+}
+
+// NewStatus builds the client view of job
+func NewStatus(job *Job) *Status {
+	status := &Status{
+		ID:         job.ID,
+		Type:       job.Type,
+		State:      job.State,
+		Progress:   Progress{Done: job.ProgressDone, Total: job.ProgressTotal},
+		Attempts:   job.Attempts,
+		Error:      job.LastError,
+		Result:     job.Result,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	if job.ProgressTotal > 0 {
+		status.Progress.Percent = float64(job.ProgressDone) * 100 / float64(job.ProgressTotal)
+	}
+	if job.State == StateSucceeded {
+		status.Progress.Percent = 100
+	}
+	if job.State == StateQueued && job.Attempts > 0 {
+		runAt := job.RunAt
+		status.NextRunAt = &runAt
+	}
+	return status
+}
+
+// StatusHandler returns an HTTP handler serving the status of the job whose ID
+// jobID extracts from the request, e.g. a chi URL parameter
+func StatusHandler(store Store, jobID func(r *http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(jobID(r))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid job ID"})
+			return
+		}
+
+		status, err := GetJobStatus(r.Context(), store, id)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				writeJSON(w, http.StatusNotFound, map[string]string{"error": "job not found"})
+				return
+			}
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to get job status"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, status)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,241 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stdErrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/retention"
+)
+
+// ErrLeaseLost is returned when a worker updates a job it no longer holds, because
+// its lease expired and another worker claimed the job
+var ErrLeaseLost = stdErrors.New("job lease lost")
+
+// Store persists jobs. Updates of a running job are made by the worker holding its
+// lease and fail with ErrLeaseLost for any other worker.
+type Store interface {
+	// Enqueue stores a new queued job
+	Enqueue(ctx context.Context, job *Job) error
+
+	// Get retrieves a job by ID
+	Get(ctx context.Context, id uuid.UUID) (*Job, error)
+
+	// Claim leases the next due job of one of types to workerID until lease ends. Jobs
+	// whose lease expired are claimed again. It returns nil when no job is due.
+	Claim(ctx context.Context, types []string, workerID string, lease time.Duration) (*Job, error)
+
+	// ExtendLease keeps a running job leased to workerID
+	ExtendLease(ctx context.Context, id uuid.UUID, workerID string, lease time.Duration) error
+
+	// UpdateProgress records how much of a running job is done
+	UpdateProgress(ctx context.Context, id uuid.UUID, workerID string, done, total int64) error
+
+	// Complete marks a running job succeeded with result
+	Complete(ctx context.Context, id uuid.UUID, workerID string, result json.RawMessage) error
+
+	// Fail records a failed attempt. The job is queued again for retryAt, or failed
+	// for good when retryAt is nil.
+	Fail(ctx context.Context, id uuid.UUID, workerID string, reason string, retryAt *time.Time) error
+
+	// Release queues a running job again without counting the attempt, for workers
+	// shutting down in the middle of it
+	Release(ctx context.Context, id uuid.UUID, workerID string) error
+}
+
+// Schema creates the jobs table. Services apply it with their own migrations or
+// with EnsureSchema.
+const Schema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	id UUID PRIMARY KEY,
+	type VARCHAR(100) NOT NULL,
+	state VARCHAR(20) NOT NULL CHECK (state IN ('queued', 'running', 'succeeded', 'failed')),
+	payload JSONB NOT NULL,
+	result JSONB,
+	progress_done BIGINT NOT NULL DEFAULT 0,
+	progress_total BIGINT NOT NULL DEFAULT 0,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT '',
+	run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	locked_by VARCHAR(255) NOT NULL DEFAULT '',
+	locked_until TIMESTAMP WITH TIME ZONE,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	started_at TIMESTAMP WITH TIME ZONE,
+	finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_due ON jobs (type, run_at) WHERE state IN ('queued', 'running');
+`
+
+// RetentionPolicy deletes jobs that finished longer than ttl ago; queued and
+// running jobs are kept whatever their age
+func RetentionPolicy(ttl time.Duration) retention.Policy {
+	return retention.Policy{
+		Table:      "jobs",
+		TimeColumn: "finished_at",
+		TTL:        ttl,
+		Condition:  "state IN ('succeeded', 'failed')",
+	}
+}
+
+const jobColumns = `id, type, state, payload, result, progress_done, progress_total, attempts,
+	last_error, run_at, locked_by, locked_until, created_at, updated_at, started_at, finished_at`
+
+// jobRow maps the jobs table, whose result is NULL until a job succeeds
+type jobRow struct {
+	Job
+	ResultJSON []byte `db:"result"`
+}
+
+func (r *jobRow) job() *Job {
+	job := r.Job
+	job.Result = r.ResultJSON
+	return &job
+}
+
+// PostgresStore implements Store using PostgreSQL. Workers claim jobs with
+// FOR UPDATE SKIP LOCKED, so any number of replicas can share the table.
+type PostgresStore struct {
+	db *sqlx.DB
+}
+
+// NewPostgresStore creates a new PostgreSQL job store
+func NewPostgresStore(db *sqlx.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// EnsureSchema creates the jobs table if it doesn't exist
+func (s *PostgresStore) EnsureSchema(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, Schema); err != nil {
+		return errors.Wrap(err, "failed to create jobs table")
+	}
+	return nil
+}
+
+// Enqueue stores a new queued job
+func (s *PostgresStore) Enqueue(ctx context.Context, job *Job) error {
+	query := `
+		INSERT INTO jobs (id, type, state, payload, run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := s.db.ExecContext(ctx, query,
+		job.ID, job.Type, job.State, []byte(job.Payload), job.RunAt, job.CreatedAt, job.UpdatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert job")
+	}
+	return nil
+}
+
+// Get retrieves a job by ID
+func (s *PostgresStore) Get(ctx context.Context, id uuid.UUID) (*Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+	var row jobRow
+	if err := s.db.GetContext(ctx, &row, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFound("job not found")
+		}
+		return nil, errors.Wrap(err, "failed to get job")
+	}
+	return row.job(), nil
+}
+
+// Claim leases the next due job of one of types to workerID
+func (s *PostgresStore) Claim(ctx context.Context, types []string, workerID string, lease time.Duration) (*Job, error) {
+	now := time.Now().UTC()
+	query := `
+		UPDATE jobs
+		SET state = 'running', attempts = attempts + 1, locked_by = $2, locked_until = $3,
+			started_at = COALESCE(started_at, $4), updated_at = $4
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE type = ANY($1)
+				AND ((state = 'queued' AND run_at <= $4) OR (state = 'running' AND locked_until < $4))
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	var row jobRow
+	if err := s.db.GetContext(ctx, &row, query, pq.Array(types), workerID, now.Add(lease), now); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to claim job")
+	}
+	return row.job(), nil
+}
+
+// ExtendLease keeps a running job leased to workerID
+func (s *PostgresStore) ExtendLease(ctx context.Context, id uuid.UUID, workerID string, lease time.Duration) error {
+	now := time.Now().UTC()
+	return s.updateRunning(ctx, `locked_until = $3, updated_at = $4`, id, workerID, now.Add(lease), now)
+}
+
+// UpdateProgress records how much of a running job is done
+func (s *PostgresStore) UpdateProgress(ctx context.Context, id uuid.UUID, workerID string, done, total int64) error {
+	return s.updateRunning(ctx, `progress_done = $3, progress_total = $4, updated_at = $5`,
+		id, workerID, done, total, time.Now().UTC())
+}
+
+// Complete marks a running job succeeded with result
+func (s *PostgresStore) Complete(ctx context.Context, id uuid.UUID, workerID string, result json.RawMessage) error {
+	now := time.Now().UTC()
+	var resultJSON []byte
+	if len(result) > 0 {
+		resultJSON = result
+	}
+	return s.updateRunning(ctx, `
+		state = 'succeeded', result = $3, last_error = '', locked_by = '', locked_until = NULL,
+		updated_at = $4, finished_at = $4`,
+		id, workerID, resultJSON, now)
+}
+
+// Fail records a failed attempt, queueing the job again for retryAt if set
+func (s *PostgresStore) Fail(ctx context.Context, id uuid.UUID, workerID string, reason string, retryAt *time.Time) error {
+	now := time.Now().UTC()
+	if retryAt != nil {
+		return s.updateRunning(ctx, `
+			state = 'queued', last_error = $3, run_at = $4, locked_by = '', locked_until = NULL, updated_at = $5`,
+			id, workerID, reason, *retryAt, now)
+	}
+	return s.updateRunning(ctx, `
+		state = 'failed', last_error = $3, locked_by = '', locked_until = NULL, updated_at = $4, finished_at = $4`,
+		id, workerID, reason, now)
+}
+
+// Release queues a running job again without counting the attempt
+func (s *PostgresStore) Release(ctx context.Context, id uuid.UUID, workerID string) error {
+	now := time.Now().UTC()
+	return s.updateRunning(ctx, `
+		state = 'queued', attempts = attempts - 1, run_at = $3, locked_by = '', locked_until = NULL, updated_at = $3`,
+		id, workerID, now)
+}
+
+// updateRunning applies set to a job that is running under the lease of workerID.
+// The job ID and worker ID are the first two query arguments.
+func (s *PostgresStore) updateRunning(ctx context.Context, set string, id uuid.UUID, workerID string, args ...interface{}) error {
+	query := `UPDATE jobs SET ` + set + ` WHERE id = $1 AND locked_by = $2 AND state = 'running'`
+
+	result, err := s.db.ExecContext(ctx, query, append([]interface{}{id, workerID}, args...)...)
+	if err != nil {
+		return errors.Wrap(err, "failed to update job")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Handler runs one attempt of a job. Returning an error retries the job under the
+// RetryPolicy of its type, unless the error is permanent.
+type Handler func(ctx context.Context, exec *Execution) error
+
+// Execution is the attempt of a job handed to its handler
+type Execution struct {
+	Job    *Job
+	worker *Worker
+	result json.RawMessage
+}
+
+// Decode unmarshals the payload of the job into v
+func (e *Execution) Decode(v interface{}) error {
+	if err := json.Unmarshal(e.Job.Payload, v); err != nil {
+		return Permanent(errors.Wrap(err, "failed to decode job payload"))
+	}
+	return nil
+}
+
+// ReportProgress records that done of total units of the job are complete. Pass a
+// total of 0 while it isn't known yet.
+func (e *Execution) ReportProgress(ctx context.Context, done, total int64) error {
+	if err := e.worker.store.UpdateProgress(ctx, e.Job.ID, e.worker.config.ID, done, total); err != nil {
+		return err
+	}
+	e.Job.ProgressDone, e.Job.ProgressTotal = done, total
+	return nil
+}
+
+// SetResult sets the output stored with the job when the handler succeeds
+func (e *Execution) SetResult(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal job result")
+	}
+	e.result = data
+	return nil
+}
+
+// WorkerConfig holds the job worker configuration
+type WorkerConfig struct {
+	ID           string        // Identifies the worker holding a lease; unique per process
+	Concurrency  int           // Jobs run at the same time
+	PollInterval time.Duration // Wait before looking for jobs again when none are due
+	Lease        time.Duration // Jobs of a worker that stops renewing are claimed again after this
+}
+
+// DefaultWorkerConfig returns a worker configuration identified by host and process
+func DefaultWorkerConfig() WorkerConfig {
+	host, _ := os.Hostname()
+	return WorkerConfig{
+		ID:           fmt.Sprintf("%s-%d", host, os.Getpid()),
+		Concurrency:  4,
+		PollInterval: time.Second,
+		Lease:        time.Minute,
+	}
+}
+
+type registration struct {
+	handler Handler
+	policy  RetryPolicy
+}
+
+// Worker claims jobs of its registered types from a Store and runs them
+type Worker struct {
+	store    Store
+	config   WorkerConfig
+	handlers map[string]registration
+	types    []string
+	logger   logging.Logger
+	metrics  metrics.Metrics
+}
+
+// NewWorker creates a new job worker
+func NewWorker(store Store, cfg WorkerConfig, logger logging.Logger, metrics metrics.Metrics) *Worker {
+	defaults := DefaultWorkerConfig()
+	if cfg.ID == "" {
+		cfg.ID = defaults.ID
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaults.Concurrency
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaults.PollInterval
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaults.Lease
+	}
+
+	return &Worker{
+		store:    store,
+		config:   cfg,
+		handlers: make(map[string]registration),
+		logger:   logger,
+		metrics:  metrics,
+	}
+}
+
+// Register sets the handler and retry policy of a job type. It must be called
+// before Run.
+func (w *Worker) Register(jobType string, handler Handler, policy RetryPolicy) {
+	if policy.MaxAttempts <= 0 {
+		policy = DefaultRetryPolicy()
+	}
+	if _, exists := w.handlers[jobType]; !exists {
+		w.types = append(w.types, jobType)
+	}
+	w.handlers[jobType] = registration{handler: handler, policy: policy}
+}
+
+// Run claims and runs jobs until ctx is cancelled. Jobs still running then are
+// cancelled and released, to be claimed again by another worker.
+func (w *Worker) Run(ctx context.Context) error {
+	if len(w.types) == 0 {
+		return fmt.Errorf("job worker %s has no registered job types", w.config.ID)
+	}
+
+	w.logger.Info(ctx, "Job worker started", map[string]interface{}{
+		"worker_id":   w.config.ID,
+		"job_types":   w.types,
+		"concurrency": w.config.Concurrency,
+	})
+
+	slots := make(chan struct{}, w.config.Concurrency)
+	var running sync.WaitGroup
+	defer running.Wait()
+
+	for {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil
+		}
+
+		job, err := w.store.Claim(ctx, w.types, w.config.ID, w.config.Lease)
+		if err != nil || job == nil {
+			<-slots
+			if err != nil && ctx.Err() == nil {
+				w.logger.Error(ctx, "Failed to claim job", err, map[string]interface{}{
+					"worker_id": w.config.ID,
+				})
+			}
+			select {
+			case <-time.After(w.config.PollInterval):
+				continue
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		running.Add(1)
+		go func() {
+			defer running.Done()
+			defer func() { <-slots }()
+			w.execute(ctx, job)
+		}()
+	}
+}
+
+// execute runs one attempt of job, renewing its lease until the handler returns
+func (w *Worker) execute(ctx context.Context, job *Job) {
+	reg := w.handlers[job.Type]
+	labels := map[string]string{"job_type": job.Type}
+	fields := map[string]interface{}{
+		"job_id":   job.ID,
+		"job_type": job.Type,
+		"attempt":  job.Attempts,
+	}
+
+	// Claimed again after a worker died in its last attempt
+	if job.Attempts > reg.policy.MaxAttempts {
+		w.recordAttempt(ctx, fields, w.store.Fail(context.WithoutCancel(ctx), job.ID, w.config.ID,
+			"lease expired during the last attempt", nil))
+		w.metrics.IncrementCounter(ctx, "jobs_failed_total", labels)
+		return
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	leaseLost := make(chan struct{})
+	go w.renewLease(jobCtx, job, cancel, leaseLost)
+
+	exec := &Execution{Job: job, worker: w}
+	start := time.Now()
+	err := w.runHandler(jobCtx, reg.handler, exec)
+	w.metrics.RecordDuration(ctx, "job_duration", time.Since(start), labels)
+	cancel()
+
+	// Updates outlive shutdown so the attempt is recorded either way
+	storeCtx := context.WithoutCancel(ctx)
+	select {
+	case <-leaseLost:
+		w.logger.Warn(ctx, "Job lease lost, another worker owns the job", fields)
+		return
+	default:
+	}
+
+	switch {
+	case err == nil:
+		w.recordAttempt(ctx, fields, w.store.Complete(storeCtx, job.ID, w.config.ID, exec.result))
+		w.metrics.IncrementCounter(ctx, "jobs_succeeded_total", labels)
+	case ctx.Err() != nil:
+		w.recordAttempt(ctx, fields, w.store.Release(storeCtx, job.ID, w.config.ID))
+		w.logger.Info(ctx, "Job released on shutdown", fields)
+	case IsPermanent(err) || job.Attempts >= reg.policy.MaxAttempts:
+		w.recordAttempt(ctx, fields, w.store.Fail(storeCtx, job.ID, w.config.ID, err.Error(), nil))
+		w.metrics.IncrementCounter(ctx, "jobs_failed_total", labels)
+		w.logger.Error(ctx, "Job failed", err, fields)
+	default:
+		retryAt := time.Now().UTC().Add(reg.policy.Backoff(job.Attempts))
+		w.recordAttempt(ctx, fields, w.store.Fail(storeCtx, job.ID, w.config.ID, err.Error(), &retryAt))
+		w.metrics.IncrementCounter(ctx, "jobs_retried_total", labels)
+		w.logger.Warn(ctx, "Job attempt failed, retrying", map[string]interface{}{
+			"job_id":   job.ID,
+			"job_type": job.Type,
+			"attempt":  job.Attempts,
+			"retry_at": retryAt,
+			"error":    err.Error(),
+		})
+	}
+}
+
+// runHandler calls handler, turning a panic into a failed attempt
+func (w *Worker) runHandler(ctx context.Context, handler Handler, exec *Execution) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, exec)
+}
+
+// renewLease extends the lease of job every third of its duration. When the lease
+// is lost the handler is cancelled and leaseLost closed.
+func (w *Worker) renewLease(ctx context.Context, job *Job, cancel context.CancelFunc, leaseLost chan<- struct{}) {
+	ticker := time.NewTicker(w.config.Lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := w.store.ExtendLease(ctx, job.ID, w.config.ID, w.config.Lease)
+			if stdErrors.Is(err, ErrLeaseLost) {
+				close(leaseLost)
+				cancel()
+				return
+			}
+			if err != nil && ctx.Err() == nil {
+				w.logger.Error(ctx, "Failed to extend job lease", err, map[string]interface{}{
+					"job_id": job.ID,
+				})
+			}
+		}
+	}
+}
+
+// recordAttempt logs a failure to store the outcome of an attempt; the job is then
+// claimed again once its lease expires
+func (w *Worker) recordAttempt(ctx context.Context, fields map[string]interface{}, err error) {
+	if err != nil {
+		w.logger.Error(ctx, "Failed to record job attempt", err, fields)
+	}
+}
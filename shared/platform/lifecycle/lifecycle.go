@@ -0,0 +1,278 @@
+// Package lifecycle runs the long-lived parts of a service (servers, consumers,
+// connections) as named components. Components are started in dependency
+// order and stopped in reverse order when the process receives a shutdown
+// signal, the parent context is cancelled or any component fails, each with
+// its own stop timeout.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// DefaultStopTimeout bounds how long a component may take to stop when it
+// does not set its own StopTimeout
+const DefaultStopTimeout = 10 * time.Second
+
+// Component is a unit of the service managed by the runner. All functions are
+// optional; a component with only Stop is a resource that just needs closing.
+type Component struct {
+	// Name identifies the component in logs, errors and DependsOn lists
+	Name string
+
+	// DependsOn lists components that must be started before this one and
+	// stopped after it
+	DependsOn []string
+
+	// Start brings the component up and must return once it is running.
+	// The context stays valid until the component is being stopped.
+	Start func(ctx context.Context) error
+
+	// Run is a blocking loop executed in the background after Start, e.g. a
+	// server's Serve call. Returning an error before shutdown stops the service.
+	// The context is cancelled when the component is stopped, before Stop runs.
+	Run func(ctx context.Context) error
+
+	// Stop shuts the component down; the context expires after StopTimeout
+	Stop func(ctx context.Context) error
+
+	// StopTimeout bounds Stop and the return of Run (default DefaultStopTimeout)
+	StopTimeout time.Duration
+}
+
+// Runner starts and stops a set of components
+type Runner struct {
+	logger             Logger
+	components         []Component
+	signals            []os.Signal
+	defaultStopTimeout time.Duration
+}
+
+// Option customises a Runner
+type Option func(*Runner)
+
+// WithSignals replaces the signals that trigger shutdown (default SIGINT and SIGTERM)
+func WithSignals(signals ...os.Signal) Option {
+	return func(r *Runner) {
+		r.signals = signals
+	}
+}
+
+// WithDefaultStopTimeout sets the stop timeout for components that do not set their own
+func WithDefaultStopTimeout(timeout time.Duration) Option {
+	return func(r *Runner) {
+		if timeout > 0 {
+			r.defaultStopTimeout = timeout
+		}
+	}
+}
+
+// NewRunner creates a runner that logs through the given logger
+func NewRunner(logger Logger, opts ...Option) *Runner {
+	r := &Runner{
+		logger:             logger,
+		signals:            []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		defaultStopTimeout: DefaultStopTimeout,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Add registers components. Components without dependencies between them
+// start in the order they were added.
+func (r *Runner) Add(components ...Component) *Runner {
+	r.components = append(r.components, components...)
+	return r
+}
+
+// running tracks a started component
+type running struct {
+	component Component
+	cancel    context.CancelFunc
+	done      chan struct{} // closed when Run returns; nil without Run
+}
+
+// Run starts all components, blocks until shutdown is requested and then stops
+// them. It returns the error that caused the shutdown, if any, joined with
+// errors from stopping components.
+func (r *Runner) Run(ctx context.Context) error {
+	ordered, err := r.order()
+	if err != nil {
+		return err
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, r.signals...)
+	defer signal.Stop(sigCh)
+
+	failures := make(chan error, len(ordered))
+	started := make([]*running, 0, len(ordered))
+
+	for _, component := range ordered {
+		rc, err := r.start(ctx, component, failures)
+		if err != nil {
+			r.logger.Error("Component failed to start", "component", component.Name, "error", err)
+			return errors.Join(err, r.stopAll(started))
+		}
+		started = append(started, rc)
+	}
+
+	r.logger.Info("All components started", "components", len(started))
+
+	var cause error
+	select {
+	case sig := <-sigCh:
+		r.logger.Info("Received shutdown signal", "signal", sig.String())
+	case <-ctx.Done():
+		r.logger.Info("Context cancelled, shutting down")
+	case cause = <-failures:
+		r.logger.Error("Component failed, shutting down", "error", cause)
+	}
+
+	return errors.Join(cause, r.stopAll(started))
+}
+
+// start starts a single component and launches its Run loop
+func (r *Runner) start(ctx context.Context, component Component, failures chan<- error) (*running, error) {
+	componentCtx, cancel := context.WithCancel(ctx)
+	rc := &running{component: component, cancel: cancel}
+
+	if component.Start != nil {
+		if err := component.Start(componentCtx); err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to start %s: %w", component.Name, err)
+		}
+	}
+
+	if component.Run != nil {
+		rc.done = make(chan struct{})
+		go func() {
+			defer close(rc.done)
+			err := component.Run(componentCtx)
+			if componentCtx.Err() != nil {
+				return // Stopped on purpose
+			}
+			if err != nil {
+				failures <- fmt.Errorf("%s failed: %w", component.Name, err)
+				return
+			}
+			r.logger.Info("Component finished", "component", component.Name)
+		}()
+	}
+
+	r.logger.Info("Component started", "component", component.Name)
+	return rc, nil
+}
+
+// stopAll stops started components in reverse start order
+func (r *Runner) stopAll(started []*running) error {
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		if err := r.stop(started[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stop stops a single component, waiting at most its stop timeout
+func (r *Runner) stop(rc *running) error {
+	component := rc.component
+	timeout := component.StopTimeout
+	if timeout <= 0 {
+		timeout = r.defaultStopTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	r.logger.Info("Stopping component", "component", component.Name, "timeout", timeout.String())
+
+	stopped := make(chan error, 1)
+	go func() {
+		rc.cancel()
+		var err error
+		if component.Stop != nil {
+			err = component.Stop(ctx)
+		}
+		if rc.done != nil {
+			<-rc.done
+		}
+		stopped <- err
+	}()
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			r.logger.Error("Component stopped with error", "component", component.Name, "error", err)
+			return fmt.Errorf("failed to stop %s: %w", component.Name, err)
+		}
+		r.logger.Info("Component stopped", "component", component.Name)
+		return nil
+	case <-ctx.Done():
+		r.logger.Error("Component did not stop in time", "component", component.Name, "timeout", timeout.String())
+		return fmt.Errorf("timed out stopping %s after %s", component.Name, timeout)
+	}
+}
+
+// order sorts components so that every component comes after its dependencies,
+// keeping registration order otherwise
+func (r *Runner) order() ([]Component, error) {
+	byName := make(map[string]Component, len(r.components))
+	for _, component := range r.components {
+		if component.Name == "" {
+			return nil, errors.New("lifecycle: component name is required")
+		}
+		if _, exists := byName[component.Name]; exists {
+			return nil, fmt.Errorf("lifecycle: duplicate component %q", component.Name)
+		}
+		byName[component.Name] = component
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(r.components))
+	ordered := make([]Component, 0, len(r.components))
+
+	var visit func(name, from string) error
+	visit = func(name, from string) error {
+		component, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("lifecycle: %q depends on unknown component %q", from, name)
+		}
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("lifecycle: dependency cycle through %q", name)
+		}
+
+		state[name] = visiting
+		for _, dependency := range component.DependsOn {
+			if err := visit(dependency, name); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		ordered = append(ordered, component)
+		return nil
+	}
+
+	for _, component := range r.components {
+		if err := visit(component.Name, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,51 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// Logger is the logging the runner needs. *slog.Logger satisfies it directly;
+// platform loggers can be adapted with FromPlatformLogger.
+type Logger interface {
+	Info(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// platformLogger adapts a platform logging.Logger to Logger
+type platformLogger struct {
+	logger logging.Logger
+}
+
+// FromPlatformLogger adapts a platform logger for use by the runner
+func FromPlatformLogger(logger logging.Logger) Logger {
+	return platformLogger{logger: logger}
+}
+
+func (l platformLogger) Info(msg string, args ...any) {
+	fields, _ := toFields(args)
+	l.logger.Info(context.Background(), msg, fields)
+}
+
+func (l platformLogger) Error(msg string, args ...any) {
+	fields, err := toFields(args)
+	l.logger.Error(context.Background(), msg, err, fields)
+}
+
+// toFields converts slog-style key/value pairs to a field map, pulling out the
+// value of the "error" key so it can be passed to Logger.Error
+func toFields(args []any) (map[string]interface{}, error) {
+	fields := make(map[string]interface{}, len(args)/2)
+	var err error
+	for i := 0; i+1 < len(args); i += 2 {
+		key := fmt.Sprint(args[i])
+		if e, ok := args[i+1].(error); ok && key == "error" {
+			err = e
+			continue
+		}
+		fields[key] = args[i+1]
+	}
+	return fields, err
+}
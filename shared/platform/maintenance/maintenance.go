@@ -0,0 +1,173 @@
+package maintenance
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRetryAfter is the Retry-After hint returned to clients whose requests
+// are rejected while a service is in maintenance mode
+const DefaultRetryAfter = 30 * time.Second
+
+// Listener is notified whenever maintenance mode is switched on or off
+type Listener func(enabled bool)
+
+// Status describes the current maintenance state of a service
+type Status struct {
+	Enabled bool       `json:"enabled"`
+	Reason  string     `json:"reason,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+// Mode is a process-wide maintenance switch. While enabled, services report
+// not-ready, stop pulling new Kafka messages and reject new work with a
+// retryable error, while work that is already in flight is allowed to drain.
+type Mode struct {
+	mu        sync.RWMutex
+	enabled   bool
+	reason    string
+	since     time.Time
+	listeners []Listener
+}
+
+// NewMode creates a new maintenance switch in the given initial state
+func NewMode(enabled bool, reason string) *Mode {
+	m := &Mode{}
+	if enabled {
+		m.enabled = true
+		m.reason = reason
+		m.since = time.Now().UTC()
+	}
+	return m
+}
+
+// FromEnv creates a maintenance switch initialised from the MAINTENANCE_MODE
+// and MAINTENANCE_REASON environment variables
+func FromEnv() *Mode {
+	enabled, _ := strconv.ParseBool(os.Getenv("MAINTENANCE_MODE"))
+	return NewMode(enabled, os.Getenv("MAINTENANCE_REASON"))
+}
+
+// OnChange registers a listener that is called after every state transition.
+// If the mode is already enabled the listener is invoked immediately so that
+// late subscribers (e.g. consumers created after startup) converge.
+func (m *Mode) OnChange(listener Listener) {
+	m.mu.Lock()
+	m.listeners = append(m.listeners, listener)
+	enabled := m.enabled
+	m.mu.Unlock()
+
+	if enabled {
+		listener(true)
+	}
+}
+
+// Enable switches maintenance mode on
+func (m *Mode) Enable(reason string) {
+	m.set(true, reason)
+}
+
+// Disable switches maintenance mode off
+func (m *Mode) Disable() {
+	m.set(false, "")
+}
+
+// Enabled reports whether maintenance mode is currently on
+func (m *Mode) Enabled() bool {
+	if m == nil {
+		return false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.enabled
+}
+
+// Status returns a snapshot of the current maintenance state
+func (m *Mode) Status() Status {
+	if m == nil {
+		return Status{}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	status := Status{Enabled: m.enabled, Reason: m.reason}
+	if m.enabled {
+		since := m.since
+		status.Since = &since
+	}
+	return status
+}
+
+func (m *Mode) set(enabled bool, reason string) {
+	m.mu.Lock()
+	if m.enabled == enabled {
+		if enabled {
+			m.reason = reason
+		}
+		m.mu.Unlock()
+		return
+	}
+
+	m.enabled = enabled
+	m.reason = reason
+	if enabled {
+		m.since = time.Now().UTC()
+	} else {
+		m.since = time.Time{}
+	}
+	listeners := make([]Listener, len(m.listeners))
+	copy(listeners, m.listeners)
+	m.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(enabled)
+	}
+}
+
+// Handler returns an HTTP handler for the maintenance admin endpoint.
+//
+//	GET    returns the current status
+//	POST   enables maintenance mode, with an optional {"reason": "..."} body
+//	DELETE disables maintenance mode
+func (m *Mode) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var body struct {
+				Reason string `json:"reason"`
+			}
+			if r.ContentLength != 0 {
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON payload"})
+					return
+				}
+			}
+			m.Enable(body.Reason)
+		case http.MethodDelete:
+			m.Disable()
+		default:
+			w.Header().Set("Allow", "GET, POST, DELETE")
+			writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, m.Status())
+	})
+}
+
+// SetRetryAfter sets the Retry-After header used when rejecting requests
+// during maintenance
+func SetRetryAfter(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(DefaultRetryAfter.Seconds())))
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,223 @@
+package cloudevents
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AvroSchema is the schema of the CloudEvents Avro event format. Attributes are
+// a map of context attribute name to value; timestamps are RFC 3339 strings.
+// This producer always writes data as bytes holding the JSON payload
+// described by datacontenttype.
+const AvroSchema = `{
+  "namespace": "io.cloudevents",
+  "type": "record",
+  "name": "CloudEvent",
+  "version": "1.0",
+  "doc": "Avro Event Format for CloudEvents",
+  "fields": [
+    {"name": "attribute", "type": {"type": "map", "values": ["null", "boolean", "int", "string", "bytes"]}},
+    {"name": "data", "type": [
+      "bytes", "null", "boolean",
+      {"type": "map", "values": ["null", "boolean",
+        {"type": "record", "name": "CloudEventData", "doc": "Representation of a JSON Value",
+         "fields": [{"name": "value", "type": {"type": "map", "values": "CloudEventData"}}]},
+        "double", "string"]},
+      {"type": "array", "items": "CloudEventData"},
+      "double", "string"
+    ]}
+  ]
+}`
+
+// Union branch indexes of the schema
+const (
+	attributeNull   = 0
+	attributeString = 3
+	attributeBytes  = 4
+
+	dataBytes  = 0
+	dataNull   = 1
+	dataString = 6
+)
+
+var errAvroTruncated = errors.New("truncated Avro CloudEvent")
+
+// encodeAvro writes the event as an Avro binary CloudEvent record
+func (e *Event) encodeAvro() []byte {
+	attributes := [][2]string{
+		{"specversion", e.SpecVersion},
+		{"id", e.ID},
+		{"source", e.Source},
+		{"type", e.Type},
+		{"time", e.Time.Format(time.RFC3339Nano)},
+	}
+	for _, optional := range [][2]string{
+		{"subject", e.Subject},
+		{"datacontenttype", e.DataContentType},
+		{"dataschema", e.DataSchema},
+	} {
+		if optional[1] != "" {
+			attributes = append(attributes, optional)
+		}
+	}
+
+	var out []byte
+	// A map is written as one block of entries followed by an empty block
+	out = appendLong(out, int64(len(attributes)))
+	for _, attribute := range attributes {
+		out = appendString(out, attribute[0])
+		out = appendLong(out, attributeString)
+		out = appendString(out, attribute[1])
+	}
+	out = appendLong(out, 0)
+
+	if e.Data == nil {
+		return appendLong(out, dataNull)
+	}
+	out = appendLong(out, dataBytes)
+	return appendBytes(out, e.Data)
+}
+
+// decodeAvro reads an Avro binary CloudEvent record
+func (e *Event) decodeAvro(value []byte) error {
+	r := &avroReader{buf: value}
+
+	for {
+		count, err := r.long()
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			// Negative block counts are followed by the block size in bytes
+			count = -count
+			if _, err := r.long(); err != nil {
+				return err
+			}
+		}
+		for i := int64(0); i < count; i++ {
+			name, err := r.bytes()
+			if err != nil {
+				return err
+			}
+			branch, err := r.long()
+			if err != nil {
+				return err
+			}
+			var attribute string
+			switch branch {
+			case attributeNull:
+				continue
+			case attributeString, attributeBytes:
+				raw, err := r.bytes()
+				if err != nil {
+					return err
+				}
+				attribute = string(raw)
+			default:
+				return fmt.Errorf("unsupported Avro CloudEvent attribute %s of union branch %d", name, branch)
+			}
+			if err := e.setAttribute(string(name), attribute); err != nil {
+				return err
+			}
+		}
+	}
+
+	branch, err := r.long()
+	if err != nil {
+		return err
+	}
+	switch branch {
+	case dataNull:
+	case dataBytes:
+		data, err := r.bytes()
+		if err != nil {
+			return err
+		}
+		e.Data = json.RawMessage(data)
+	case dataString:
+		data, err := r.bytes()
+		if err != nil {
+			return err
+		}
+		e.Data, _ = json.Marshal(string(data))
+	default:
+		return fmt.Errorf("unsupported Avro CloudEvent data of union branch %d", branch)
+	}
+	return nil
+}
+
+func (e *Event) setAttribute(name, value string) error {
+	switch name {
+	case "specversion":
+		e.SpecVersion = value
+	case "id":
+		e.ID = value
+	case "source":
+		e.Source = value
+	case "type":
+		e.Type = value
+	case "subject":
+		e.Subject = value
+	case "datacontenttype":
+		e.DataContentType = value
+	case "dataschema":
+		e.DataSchema = value
+	case "time":
+		at, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return fmt.Errorf("invalid CloudEvent time %q: %w", value, err)
+		}
+		e.Time = at
+	}
+	// Extension attributes are not used by this platform
+	return nil
+}
+
+// appendLong writes a zig-zag varint
+func appendLong(out []byte, value int64) []byte {
+	return binary.AppendVarint(out, value)
+}
+
+func appendBytes(out, value []byte) []byte {
+	out = appendLong(out, int64(len(value)))
+	return append(out, value...)
+}
+
+func appendString(out []byte, value string) []byte {
+	out = appendLong(out, int64(len(value)))
+	return append(out, value...)
+}
+
+// avroReader reads Avro binary primitives
+type avroReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *avroReader) long() (int64, error) {
+	value, n := binary.Varint(r.buf[r.pos:])
+	if n <= 0 {
+		return 0, errAvroTruncated
+	}
+	r.pos += n
+	return value, nil
+}
+
+func (r *avroReader) bytes() ([]byte, error) {
+	length, err := r.long()
+	if err != nil {
+		return nil, err
+	}
+	if length < 0 || int64(len(r.buf)-r.pos) < length {
+		return nil, errAvroTruncated
+	}
+	value := r.buf[r.pos : r.pos+int(length)]
+	r.pos += int(length)
+	return value, nil
+}
@@ -0,0 +1,42 @@
+package events
+
+import (
+	"fmt"
+	"math"
+)
+
+// PaymentProcessedEventType is the type of the payment events order-service
+// publishes once an order is paid
+const PaymentProcessedEventType = "payment.processed"
+
+// PaymentEventVersion is the current version of payment events:
+//
+//  1. order_id, user_id, amount (a float), transaction_id and processed_at
+//  2. adds currency and amount_minor, the exact amount in the currency's minor
+//     unit; payments before it were all in US dollars
+const PaymentEventVersion = 2
+
+// PaymentEventChain upcasts payment events to PaymentEventVersion
+func PaymentEventChain() *Chain {
+	return NewChain(PaymentProcessedEventType, PaymentEventVersion).
+		Step(1, paymentEventV1ToV2)
+}
+
+// paymentEventV1ToV2 derives the exact amount of a version 1 payment in cents.
+// Producers wrote amount_minor and currency before they stamped version 2, so
+// fields already present are kept.
+func paymentEventV1ToV2(payload map[string]interface{}) error {
+	if _, ok := payload["currency"]; !ok {
+		payload["currency"] = "USD"
+	}
+	if _, ok := payload["amount_minor"]; ok {
+		return nil
+	}
+
+	amount, ok := payload["amount"].(float64)
+	if !ok {
+		return fmt.Errorf("payment event has no numeric amount")
+	}
+	payload["amount_minor"] = int64(math.Round(amount * 100))
+	return nil
+}
@@ -0,0 +1,149 @@
+// Package events versions event payloads and migrates old versions to the
+// current schema when they are consumed ("upcasting"). Producers stamp the
+// version of the payload in the event-version header; a consumer runs the
+// payload of an older version through the chain of upcasters registered for its
+// event type, each migrating one version to the next, so handlers only ever see
+// the current schema. Messages retained on a topic from before a schema change
+// stay readable without every consumer knowing every version.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// VersionHeader carries the schema version of an event's payload. Events
+// without it are version 1.
+const VersionHeader = "event-version"
+
+// Upcaster migrates a decoded JSON payload from one version to the next in place
+type Upcaster func(payload map[string]interface{}) error
+
+// Chain holds the upcasters of an event type, by the version they migrate from
+type Chain struct {
+	eventType string
+	current   int
+	steps     map[int]Upcaster
+}
+
+// NewChain creates the chain of an event type whose producers write the current version
+func NewChain(eventType string, current int) *Chain {
+	return &Chain{eventType: eventType, current: current, steps: make(map[int]Upcaster)}
+}
+
+// Step registers the upcaster from a version to the next one
+func (c *Chain) Step(from int, upcaster Upcaster) *Chain {
+	if from < 1 || from >= c.current {
+		panic(fmt.Sprintf("events: %s upcaster from version %d is outside versions 1 to %d", c.eventType, from, c.current))
+	}
+	c.steps[from] = upcaster
+	return c
+}
+
+// EventType is the event type the chain upcasts
+func (c *Chain) EventType() string {
+	return c.eventType
+}
+
+// Current is the version producers write and upcasting ends at
+func (c *Chain) Current() int {
+	return c.current
+}
+
+// upcast migrates a payload of the version to the current one
+func (c *Chain) upcast(version int, payload []byte) ([]byte, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal(payload, &document); err != nil {
+		return nil, fmt.Errorf("failed to decode %s payload of version %d: %w", c.eventType, version, err)
+	}
+
+	for v := version; v < c.current; v++ {
+		step, ok := c.steps[v]
+		if !ok {
+			return nil, fmt.Errorf("no %s upcaster from version %d", c.eventType, v)
+		}
+		if err := step(document); err != nil {
+			return nil, fmt.Errorf("failed to upcast %s from version %d: %w", c.eventType, v, err)
+		}
+	}
+
+	upcast, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode upcast %s payload: %w", c.eventType, err)
+	}
+	return upcast, nil
+}
+
+// Registry holds the upcaster chains of a consumer by event type
+type Registry struct {
+	chains  map[string]*Chain
+	metrics metrics.Metrics
+}
+
+// NewRegistry creates a registry of the chains, recording upcasts in metrics
+func NewRegistry(m metrics.Metrics, chains ...*Chain) *Registry {
+	r := &Registry{chains: make(map[string]*Chain, len(chains)), metrics: m}
+	for _, chain := range chains {
+		r.chains[chain.eventType] = chain
+	}
+	return r
+}
+
+// Upcast migrates the payload of an event to the current version of its type
+// and returns it with that version. Payloads of the current version, of a newer
+// one and of event types without a chain are returned unchanged.
+func (r *Registry) Upcast(ctx context.Context, eventType, version string, payload []byte) ([]byte, string, error) {
+	if r == nil {
+		return payload, version, nil
+	}
+	chain, ok := r.chains[eventType]
+	if !ok {
+		return payload, version, nil
+	}
+
+	from, err := ParseVersion(version)
+	if err != nil {
+		r.count(ctx, "event_upcast_failures_total", eventType, version)
+		return nil, version, err
+	}
+	if from >= chain.current {
+		return payload, version, nil
+	}
+
+	upcast, err := chain.upcast(from, payload)
+	if err != nil {
+		r.count(ctx, "event_upcast_failures_total", eventType, version)
+		return nil, version, err
+	}
+	r.count(ctx, "event_upcasts_total", eventType, version)
+	return upcast, strconv.Itoa(chain.current), nil
+}
+
+func (r *Registry) count(ctx context.Context, name, eventType, version string) {
+	if r.metrics == nil {
+		return
+	}
+	r.metrics.IncrementCounter(ctx, name, map[string]string{
+		"event_type":   eventType,
+		"from_version": version,
+	})
+}
+
+// ParseVersion reads the major version of an event-version header, which older
+// producers wrote as "1.0". An empty header is version 1.
+func ParseVersion(version string) (int, error) {
+	if version == "" {
+		return 1, nil
+	}
+	major, _, _ := strings.Cut(version, ".")
+	v, err := strconv.Atoi(major)
+	if err != nil || v < 1 {
+		return 0, fmt.Errorf("invalid event version %q", version)
+	}
+	return v, nil
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
 
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/deadline"
 	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/events"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
 )
@@ -32,6 +36,10 @@ type ConsumerConfig struct {
 	RetryBackoff         time.Duration `json:"retry_backoff"`
 	EnableDeadLetter     bool          `json:"enable_dead_letter"`
 	DeadLetterTopic      string        `json:"dead_letter_topic"`
+	HonorDeadlines       bool          `json:"honor_deadlines"` // Skip messages whose caller stopped waiting
+
+	// Membership sets static group membership and the rebalance strategy
+	Membership GroupMembership `json:"membership"`
 }
 
 // DefaultConsumerConfig returns default consumer configuration
@@ -53,6 +61,8 @@ func DefaultConsumerConfig() ConsumerConfig {
 		RetryBackoff:         1 * time.Second,
 		EnableDeadLetter:     false,
 		DeadLetterTopic:      "",
+		HonorDeadlines:       false,
+		Membership:           DefaultGroupMembership(),
 	}
 }
 
@@ -88,11 +98,15 @@ type Consumer struct {
 	logger        logging.Logger
 	metrics       metrics.Metrics
 	handlers      map[string]MessageHandler
+	payloads      *http.Client // Fetches offloaded payloads
+	encryption    *PayloadEncryption // Decrypts encrypted payloads
+	upcasters     *events.Registry   // Migrates old event versions to the current schema
 	ready         chan bool
 	ctx           context.Context
 	cancel        context.CancelFunc
 	wg            sync.WaitGroup
 	running       bool
+	paused        bool
 	mu            sync.RWMutex
 }
 
@@ -105,7 +119,9 @@ func NewConsumer(config ConsumerConfig, logger logging.Logger, metrics metrics.M
 	saramaConfig.Consumer.Return.Errors = true
 	
 	// Group configuration
-	saramaConfig.Consumer.Group.Rebalance.Strategy = sarama.BalanceStrategyRoundRobin
+	if err := config.Membership.Apply(saramaConfig); err != nil {
+		return nil, platformError.Wrap(err, "invalid Kafka consumer group membership")
+	}
 	saramaConfig.Consumer.Group.Session.Timeout = config.SessionTimeout
 	saramaConfig.Consumer.Group.Heartbeat.Interval = config.HeartbeatInterval
 	saramaConfig.Consumer.Group.Rebalance.Timeout = config.RebalanceTimeout
@@ -135,6 +151,7 @@ func NewConsumer(config ConsumerConfig, logger logging.Logger, metrics metrics.M
 		logger:        logger,
 		metrics:       metrics,
 		handlers:      make(map[string]MessageHandler),
+		payloads:      &http.Client{Timeout: config.MaxProcessingTime},
 		ready:         make(chan bool),
 		ctx:           ctx,
 		cancel:        cancel,
@@ -150,6 +167,8 @@ func NewConsumer(config ConsumerConfig, logger logging.Logger, metrics metrics.M
 		"initial_offset":     config.InitialOffset,
 		"auto_commit":        config.EnableAutoCommit,
 		"concurrency_level":  config.ConcurrencyLevel,
+		"group_instance_id":  config.Membership.InstanceID,
+		"rebalance_strategy": saramaConfig.Consumer.Group.Rebalance.GroupStrategies[0].Name(),
 	})
 
 	return consumer, nil
@@ -169,6 +188,12 @@ func (c *Consumer) RegisterHandler(handler MessageHandler) {
 	}
 }
 
+// SetUpcasters upcasts the payloads of older event versions before handlers see
+// them, by the event-type and event-version headers of the message
+func (c *Consumer) SetUpcasters(upcasters *events.Registry) {
+	c.upcasters = upcasters
+}
+
 // Start starts the consumer
 func (c *Consumer) Start(ctx context.Context) error {
 	c.mu.Lock()
@@ -266,6 +291,47 @@ func (c *Consumer) Stop() error {
 	return nil
 }
 
+// Pause stops fetching new messages from all assigned partitions while
+// letting messages that are already being processed finish. The consumer
+// stays in its group, so no rebalance is triggered.
+func (c *Consumer) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.paused {
+		return
+	}
+	c.paused = true
+	c.consumerGroup.PauseAll()
+
+	c.logger.Info(nil, "Kafka consumer paused", map[string]interface{}{
+		"group_id": c.config.GroupID,
+	})
+}
+
+// Resume resumes fetching messages after a Pause
+func (c *Consumer) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.paused {
+		return
+	}
+	c.paused = false
+	c.consumerGroup.ResumeAll()
+
+	c.logger.Info(nil, "Kafka consumer resumed", map[string]interface{}{
+		"group_id": c.config.GroupID,
+	})
+}
+
+// IsPaused reports whether the consumer is currently paused
+func (c *Consumer) IsPaused() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.paused
+}
+
 // HealthCheck performs a health check on the consumer
 func (c *Consumer) HealthCheck(ctx context.Context) error {
 	c.mu.RLock()
@@ -334,6 +400,7 @@ func (c *Consumer) GetStats() map[string]interface{} {
 	
 	return map[string]interface{}{
 		"running":              c.running,
+		"paused":               c.paused,
 		"brokers":              c.config.Brokers,
 		"group_id":             c.config.GroupID,
 		"client_id":            c.config.ClientID,
@@ -359,7 +426,7 @@ func (c *Consumer) handleErrors() {
 			if err != nil {
 				// Type assert to sarama.ConsumerError to access Topic and Partition
 				if consumerErr, ok := err.(*sarama.ConsumerError); ok {
-					c.metrics.IncrementCounter("kafka_consumer_errors_total", map[string]string{
+					c.metrics.IncrementCounter(c.ctx, "kafka_consumer_errors_total", map[string]string{
 						"group_id": c.config.GroupID,
 						"topic":    consumerErr.Topic,
 					})
@@ -369,7 +436,7 @@ func (c *Consumer) handleErrors() {
 					})
 				} else {
 					// Fallback for other error types
-					c.metrics.IncrementCounter("kafka_consumer_errors_total", map[string]string{
+					c.metrics.IncrementCounter(c.ctx, "kafka_consumer_errors_total", map[string]string{
 						"group_id": c.config.GroupID,
 						"topic":    "unknown",
 					})
@@ -387,10 +454,10 @@ func (c *Consumer) processMessage(ctx context.Context, message *sarama.ConsumerM
 	msg := c.convertMessage(message)
 	
 	// Record metrics
-	c.metrics.IncrementCounter("kafka_consumer_messages_total", map[string]string{
+	c.metrics.IncrementCounter(ctx, "kafka_consumer_messages_total", map[string]string{
 		"topic": msg.Topic,
 	})
-	c.metrics.RecordValue("kafka_consumer_message_size_bytes", float64(len(msg.Value)), map[string]string{
+	c.metrics.RecordValue(ctx, "kafka_consumer_message_size_bytes", float64(len(msg.Value)), map[string]string{
 		"topic": msg.Topic,
 	})
 
@@ -406,16 +473,66 @@ func (c *Consumer) processMessage(ctx context.Context, message *sarama.ConsumerM
 		return nil // Don't fail on missing handlers
 	}
 
+	// Relay the caller's budget to the messages the handler produces. Saga steps
+	// that must complete regardless only honor it when configured to.
+	if msgDeadline, ok := deadline.FromHeaders(msg.Headers); ok {
+		if c.config.HonorDeadlines && !time.Now().Before(msgDeadline) {
+			c.skipExpired(ctx, msg, msgDeadline)
+			return nil
+		}
+		ctx = deadline.Attach(ctx, msgDeadline)
+		if c.config.HonorDeadlines {
+			var cancelDeadline context.CancelFunc
+			ctx, cancelDeadline = context.WithDeadline(ctx, msgDeadline)
+			defer cancelDeadline()
+		}
+	}
+
+	// Handle the message for the same user and under the same request ID as its producer
+	ctx = ctxmeta.NewContext(ctx, ctxmeta.FromKafkaHeaders(msg.Headers))
+
 	// Process message with timeout
 	processCtx, cancel := context.WithTimeout(ctx, c.config.MaxProcessingTime)
 	defer cancel()
 
+	// Handlers see the payload of an offloaded message, not the pointer to it
+	payload, err := ResolvePayload(processCtx, c.payloads, msg.Headers, msg.Value)
+	if err != nil {
+		c.metrics.IncrementCounter(ctx, "kafka_consumer_messages_processed_total", map[string]string{
+			"topic":  msg.Topic,
+			"status": "payload_unavailable",
+		})
+		return err
+	}
+
+	payload, err = DecryptPayload(processCtx, c.encryption, msg.Headers, payload)
+	if err != nil {
+		c.metrics.IncrementCounter(ctx, "kafka_consumer_messages_processed_total", map[string]string{
+			"topic":  msg.Topic,
+			"status": "decryption_failed",
+		})
+		return err
+	}
+
+	payload, version, err := c.upcasters.Upcast(processCtx, msg.EventType, msg.Headers[events.VersionHeader], payload)
+	if err != nil {
+		c.metrics.IncrementCounter(ctx, "kafka_consumer_messages_processed_total", map[string]string{
+			"topic":  msg.Topic,
+			"status": "upcast_failed",
+		})
+		return err
+	}
+	msg.Value = payload
+	if version != "" {
+		msg.Headers[events.VersionHeader] = version
+	}
+
 	// Process with retry logic
 	var lastErr error
 	for attempt := 0; attempt <= c.config.RetryAttempts; attempt++ {
 		err := handler.HandleMessage(processCtx, msg)
 		if err == nil {
-			c.metrics.IncrementCounter("kafka_consumer_messages_processed_total", map[string]string{
+			c.metrics.IncrementCounter(ctx, "kafka_consumer_messages_processed_total", map[string]string{
 				"topic":  msg.Topic,
 				"status": "success",
 			})
@@ -447,7 +564,7 @@ func (c *Consumer) processMessage(ctx context.Context, message *sarama.ConsumerM
 	}
 
 	// Record failure metrics
-	c.metrics.IncrementCounter("kafka_consumer_messages_processed_total", map[string]string{
+	c.metrics.IncrementCounter(ctx, "kafka_consumer_messages_processed_total", map[string]string{
 		"topic":  msg.Topic,
 		"status": "failed",
 	})
@@ -468,6 +585,25 @@ func (c *Consumer) processMessage(ctx context.Context, message *sarama.ConsumerM
 	return lastErr
 }
 
+// skipExpired drops a message whose deadline passed before it was consumed
+func (c *Consumer) skipExpired(ctx context.Context, msg *Message, msgDeadline time.Time) {
+	c.metrics.IncrementCounter(ctx, "kafka_consumer_messages_processed_total", map[string]string{
+		"topic":  msg.Topic,
+		"status": "deadline_exceeded",
+	})
+	c.logger.Warn(ctx, "Skipping message past its deadline", map[string]interface{}{
+		"topic":     msg.Topic,
+		"key":       msg.Key,
+		"partition": msg.Partition,
+		"offset":    msg.Offset,
+		"deadline":  deadline.Format(msgDeadline),
+	})
+
+	if c.config.EnableDeadLetter && c.config.DeadLetterTopic != "" {
+		c.sendToDeadLetter(ctx, msg, context.DeadlineExceeded)
+	}
+}
+
 func (c *Consumer) convertMessage(message *sarama.ConsumerMessage) *Message {
 	// Convert headers
 	headers := make(map[string]string)
@@ -486,13 +622,13 @@ func (c *Consumer) convertMessage(message *sarama.ConsumerMessage) *Message {
 	}
 
 	// Extract standard event headers
-	if eventType, exists := headers["event-type"]; exists {
+	if eventType, exists := headers[EventTypeHeader]; exists {
 		msg.EventType = eventType
 	}
-	if eventID, exists := headers["event-id"]; exists {
+	if eventID, exists := headers[EventIDHeader]; exists {
 		msg.EventID = eventID
 	}
-	if eventSource, exists := headers["event-source"]; exists {
+	if eventSource, exists := headers[EventSourceHeader]; exists {
 		msg.EventSource = eventSource
 	}
 
@@ -521,6 +657,12 @@ type consumerGroupHandler struct {
 
 func (h *consumerGroupHandler) Setup(sarama.ConsumerGroupSession) error {
 	close(h.ready)
+
+	// Partitions assigned after a rebalance start unpaused, so re-apply
+	if h.consumer.IsPaused() {
+		h.consumer.consumerGroup.PauseAll()
+	}
+
 	h.consumer.logger.Info(nil, "Consumer group session setup complete")
 	return nil
 }
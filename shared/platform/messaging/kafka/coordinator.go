@@ -412,7 +412,7 @@ func (c *Coordinator) performHealthCheck() {
 		if status != "healthy" {
 			healthValue = 0.0
 		}
-		c.metrics.SetGauge("kafka_coordinator_health", healthValue, nil)
+		c.metrics.SetGauge(ctx, "kafka_coordinator_health", healthValue, nil)
 	}
 
 	// Log health status
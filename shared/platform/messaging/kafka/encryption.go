@@ -0,0 +1,484 @@
+package kafka
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+)
+
+// Headers of a message whose payload was encrypted with a data key. The message
+// value is then the AES-GCM nonce followed by the ciphertext.
+const (
+	EncryptionKeyHeader     = "encryption-key-id"   // Master key the data key is wrapped with
+	EncryptionDataKeyHeader = "encryption-data-key" // Wrapped data key, base64
+)
+
+// Environment variables read by LoadEncryptionConfig
+const (
+	EncryptTopicsEnv        = "KAFKA_ENCRYPT_TOPICS"          // Registered topics whose payloads are encrypted, comma-separated
+	EncryptionKeysEnv       = "KAFKA_ENCRYPTION_KEYS"         // "<key id>:<base64 32-byte key>" entries, comma-separated
+	EncryptionActiveKeyEnv  = "KAFKA_ENCRYPTION_ACTIVE_KEY"   // Master key new data keys are wrapped with
+	EncryptionDataKeyTTLEnv = "KAFKA_ENCRYPTION_DATA_KEY_TTL" // How long a producer reuses a data key
+)
+
+// dataKeySize is the AES-256 key length in bytes, of master and data keys alike
+const dataKeySize = 32
+
+// defaultDataKeyTTL is how long a producer reuses a data key unless configured
+const defaultDataKeyTTL = 5 * time.Minute
+
+// maxUnwrappedKeys bounds the data keys a consumer keeps unwrapped
+const maxUnwrappedKeys = 1024
+
+var (
+	// ErrUnknownEncryptionKey is returned for a data key wrapped with a master
+	// key the provider doesn't have
+	ErrUnknownEncryptionKey = errors.New("unknown payload encryption key")
+
+	// ErrPayloadEncrypted is returned for an encrypted message consumed without
+	// a key provider to decrypt it
+	ErrPayloadEncrypted = errors.New("message payload is encrypted and no decryption keys are configured")
+)
+
+// KeyProvider issues and unwraps the data keys payloads are encrypted with, the
+// way a KMS does: master keys never leave the provider.
+type KeyProvider interface {
+	// GenerateDataKey returns a new 32-byte data key, the key wrapped with the
+	// active master key, and the ID of that master key
+	GenerateDataKey(ctx context.Context) (plaintext, wrapped []byte, keyID string, err error)
+
+	// DecryptDataKey unwraps a data key wrapped with the identified master key
+	DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// EncryptionConfig selects the topics whose payloads are encrypted, and the
+// master keys of a StaticKeyProvider. Consumers only need the keys: they decrypt
+// every encrypted message, whichever topic it came from.
+type EncryptionConfig struct {
+	Topics      []topics.Topic `json:"topics"`
+	Keys        string         `json:"-"`
+	ActiveKeyID string         `json:"active_key_id"`
+	DataKeyTTL  time.Duration  `json:"data_key_ttl"` // How long a producer reuses a data key
+
+	loadErr error // Setting that failed to parse, reported by Validate
+}
+
+// LoadEncryptionConfig reads the payload encryption settings through getenv,
+// which service config loaders pass their own environment lookup to
+func LoadEncryptionConfig(getenv func(string) string) EncryptionConfig {
+	config := EncryptionConfig{
+		Keys:        getenv(EncryptionKeysEnv),
+		ActiveKeyID: getenv(EncryptionActiveKeyEnv),
+		DataKeyTTL:  defaultDataKeyTTL,
+	}
+	for _, topic := range strings.Split(getenv(EncryptTopicsEnv), ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			config.Topics = append(config.Topics, topics.Topic(topic))
+		}
+	}
+	if raw := getenv(EncryptionDataKeyTTLEnv); raw != "" {
+		ttl, err := time.ParseDuration(raw)
+		if err != nil {
+			config.loadErr = fmt.Errorf("invalid %s %q: %w", EncryptionDataKeyTTLEnv, raw, err)
+		}
+		config.DataKeyTTL = ttl
+	}
+	return config
+}
+
+// Enabled reports whether keys are configured
+func (c EncryptionConfig) Enabled() bool {
+	return c.Keys != ""
+}
+
+// Validate checks that encrypted topics are registered and that the keys parse,
+// with the active key among them when topics are encrypted
+func (c EncryptionConfig) Validate() error {
+	if c.loadErr != nil {
+		return c.loadErr
+	}
+	registered := make(map[topics.Topic]bool)
+	for _, topic := range topics.All() {
+		registered[topic] = true
+	}
+	for _, topic := range c.Topics {
+		if !registered[topic] {
+			return fmt.Errorf("cannot encrypt unregistered kafka topic %q", topic)
+		}
+	}
+	if c.DataKeyTTL < 0 {
+		return fmt.Errorf("kafka encryption data key TTL cannot be negative")
+	}
+
+	if !c.Enabled() {
+		if len(c.Topics) > 0 {
+			return fmt.Errorf("%s is required to encrypt kafka topics", EncryptionKeysEnv)
+		}
+		return nil
+	}
+	keys, err := ParseEncryptionKeys(c.Keys)
+	if err != nil {
+		return err
+	}
+	if _, ok := keys[c.ActiveKeyID]; len(c.Topics) > 0 && !ok {
+		return fmt.Errorf("active kafka encryption key %q is not among the keys", c.ActiveKeyID)
+	}
+	return nil
+}
+
+// NewPayloadEncryption creates the payload encryption of the configuration, with
+// the keys held by a StaticKeyProvider. The registry resolves the topic names.
+func (c EncryptionConfig) NewPayloadEncryption(registry topics.Registry) (*PayloadEncryption, error) {
+	keys, err := ParseEncryptionKeys(c.Keys)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := NewStaticKeyProvider(c.ActiveKeyID, keys)
+	if err != nil {
+		return nil, err
+	}
+	return NewPayloadEncryption(provider, registry.Names(c.Topics...), c.DataKeyTTL), nil
+}
+
+// ParseEncryptionKeys parses "<key id>:<base64 key>" entries separated by commas
+// or newlines
+func ParseEncryptionKeys(raw string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for _, entry := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == '\n' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid kafka encryption key entry: expected <key id>:<base64 key>")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("kafka encryption key %q is not valid base64: %w", id, err)
+		}
+		keys[id] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no kafka encryption keys configured")
+	}
+	return keys, nil
+}
+
+// StaticKeyProvider wraps data keys with master keys from configuration. Keys
+// are rotated by adding a new active key; the old one stays until no message
+// wrapped with it is left to consume.
+type StaticKeyProvider struct {
+	activeID string
+	aeads    map[string]cipher.AEAD
+}
+
+// NewStaticKeyProvider creates a provider of 32-byte master keys by key ID. An
+// empty activeID creates a provider that only unwraps.
+func NewStaticKeyProvider(activeID string, keys map[string][]byte) (*StaticKeyProvider, error) {
+	if _, ok := keys[activeID]; activeID != "" && !ok {
+		return nil, fmt.Errorf("active kafka encryption key %q is not among the keys", activeID)
+	}
+
+	provider := &StaticKeyProvider{activeID: activeID, aeads: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("kafka encryption key %q: %w", id, err)
+		}
+		provider.aeads[id] = aead
+	}
+	return provider, nil
+}
+
+// GenerateDataKey creates a random data key wrapped with the active master key.
+// The key ID is bound as additional data.
+func (p *StaticKeyProvider) GenerateDataKey(ctx context.Context) ([]byte, []byte, string, error) {
+	aead, ok := p.aeads[p.activeID]
+	if !ok {
+		return nil, nil, "", fmt.Errorf("no active kafka encryption key")
+	}
+
+	plaintext := make([]byte, dataKeySize)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, nil, "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	wrapped, err := seal(aead, plaintext, []byte(p.activeID))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return plaintext, wrapped, p.activeID, nil
+}
+
+// DecryptDataKey unwraps a data key with the identified master key
+func (p *StaticKeyProvider) DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := p.aeads[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEncryptionKey, keyID)
+	}
+	return open(aead, wrapped, []byte(keyID))
+}
+
+// PayloadEncryption envelope-encrypts the payloads of selected topics and
+// decrypts encrypted payloads. A producer reuses a data key for the data key
+// TTL, and a consumer keeps the data keys it unwrapped, so the key provider is
+// not called for every message.
+type PayloadEncryption struct {
+	provider   KeyProvider
+	topics     map[string]bool
+	dataKeyTTL time.Duration
+
+	mu        sync.Mutex
+	current   *dataKey
+	unwrapped map[string]cipher.AEAD // By key ID and wrapped data key
+}
+
+// dataKey is a data key in use by a producer
+type dataKey struct {
+	aead      cipher.AEAD
+	keyID     string
+	wrapped   string // Base64, as sent in EncryptionDataKeyHeader
+	expiresAt time.Time
+}
+
+// NewPayloadEncryption encrypts the payloads of the named topics with data keys
+// of the provider. A zero data key TTL generates a data key per message.
+func NewPayloadEncryption(provider KeyProvider, encryptedTopics []string, dataKeyTTL time.Duration) *PayloadEncryption {
+	e := &PayloadEncryption{
+		provider:   provider,
+		topics:     make(map[string]bool, len(encryptedTopics)),
+		dataKeyTTL: dataKeyTTL,
+		unwrapped:  make(map[string]cipher.AEAD),
+	}
+	for _, topic := range encryptedTopics {
+		e.topics[topic] = true
+	}
+	return e
+}
+
+// Encrypts reports whether payloads sent to the topic are encrypted
+func (e *PayloadEncryption) Encrypts(topic string) bool {
+	return e.topics[topic]
+}
+
+// Encrypt encrypts a payload with the current data key and returns the headers
+// consumers decrypt it with
+func (e *PayloadEncryption) Encrypt(ctx context.Context, payload []byte) ([]byte, map[string]string, error) {
+	key, err := e.dataKey(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ciphertext, err := seal(key.aead, payload, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ciphertext, map[string]string{
+		EncryptionKeyHeader:     key.keyID,
+		EncryptionDataKeyHeader: key.wrapped,
+	}, nil
+}
+
+// dataKey returns the current data key, generating a new one once it expired
+func (e *PayloadEncryption) dataKey(ctx context.Context) (*dataKey, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.current != nil && time.Now().Before(e.current.expiresAt) {
+		return e.current, nil
+	}
+
+	plaintext, wrapped, keyID, err := e.provider.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	aead, err := newAEAD(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	key := &dataKey{
+		aead:      aead,
+		keyID:     keyID,
+		wrapped:   base64.StdEncoding.EncodeToString(wrapped),
+		expiresAt: time.Now().Add(e.dataKeyTTL),
+	}
+	if e.dataKeyTTL > 0 {
+		e.current = key
+	}
+	return key, nil
+}
+
+// EncryptField encrypts a single value of a payload, such as a credential that
+// must stay unreadable on topics whose payloads aren't encrypted. The result,
+// "<key id>:<base64 wrapped data key>:<base64 ciphertext>", is decrypted by
+// DecryptField.
+func (e *PayloadEncryption) EncryptField(ctx context.Context, plaintext string) (string, error) {
+	key, err := e.dataKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(key.aead, []byte(plaintext), []byte(key.keyID))
+	if err != nil {
+		return "", err
+	}
+	return key.keyID + ":" + key.wrapped + ":" + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptField decrypts a value encrypted by EncryptField
+func (e *PayloadEncryption) DecryptField(ctx context.Context, value string) (string, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid encrypted field: expected <key id>:<data key>:<ciphertext>")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted field ciphertext: %w", err)
+	}
+	aead, err := e.unwrap(ctx, parts[0], parts[1])
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, ciphertext, []byte(parts[0]))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// decrypt decrypts a payload encrypted with the data key of the headers
+func (e *PayloadEncryption) decrypt(ctx context.Context, headers map[string]string, value []byte) ([]byte, error) {
+	aead, err := e.unwrap(ctx, headers[EncryptionKeyHeader], headers[EncryptionDataKeyHeader])
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, value, nil)
+}
+
+// unwrap returns the cipher of a wrapped data key, unwrapping it with the key
+// provider unless it was unwrapped before
+func (e *PayloadEncryption) unwrap(ctx context.Context, keyID, encodedKey string) (cipher.AEAD, error) {
+	e.mu.Lock()
+	aead, ok := e.unwrapped[keyID+":"+encodedKey]
+	e.mu.Unlock()
+
+	if !ok {
+		wrapped, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid wrapped data key: %w", err)
+		}
+		plaintext, err := e.provider.DecryptDataKey(ctx, keyID, wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+		}
+		if aead, err = newAEAD(plaintext); err != nil {
+			return nil, err
+		}
+
+		e.mu.Lock()
+		if len(e.unwrapped) >= maxUnwrappedKeys {
+			clear(e.unwrapped)
+		}
+		e.unwrapped[keyID+":"+encodedKey] = aead
+		e.mu.Unlock()
+	}
+
+	return aead, nil
+}
+
+// Encrypted reports whether the producer encrypted the message's payload
+func (m *Message) Encrypted() bool {
+	_, ok := m.Headers[EncryptionKeyHeader]
+	return ok
+}
+
+// DecryptPayload returns the plaintext payload of a message, decrypting it when
+// the producer encrypted it. Consumers not built on Consumer call it themselves,
+// after ResolvePayload.
+func DecryptPayload(ctx context.Context, encryption *PayloadEncryption, headers map[string]string, value []byte) ([]byte, error) {
+	if _, ok := headers[EncryptionKeyHeader]; !ok {
+		return value, nil
+	}
+	if encryption == nil {
+		return nil, ErrPayloadEncrypted
+	}
+	return encryption.decrypt(ctx, headers, value)
+}
+
+// SetPayloadEncryption encrypts the payloads of the topics the encryption selects
+func (p *Producer) SetPayloadEncryption(encryption *PayloadEncryption) {
+	p.encryption = encryption
+}
+
+// encryptValue encrypts the payload of a message to an encrypted topic and adds
+// the encryption headers, leaving the caller's headers untouched
+func (p *Producer) encryptValue(ctx context.Context, topic string, data []byte, headers map[string]string) ([]byte, map[string]string, error) {
+	if p.encryption == nil || !p.encryption.Encrypts(topic) {
+		return data, headers, nil
+	}
+
+	ciphertext, encryptionHeaders, err := p.encryption.Encrypt(ctx, data)
+	if err != nil {
+		p.metrics.IncrementCounter(ctx, "kafka_producer_errors_total", map[string]string{
+			"topic": topic,
+			"error": "encryption_failed",
+		})
+		return nil, nil, platformError.Wrap(err, "failed to encrypt message payload")
+	}
+
+	merged := make(map[string]string, len(headers)+len(encryptionHeaders))
+	for k, v := range headers {
+		merged[k] = v
+	}
+	for k, v := range encryptionHeaders {
+		merged[k] = v
+	}
+	return ciphertext, merged, nil
+}
+
+// SetPayloadEncryption decrypts encrypted payloads before handlers see them.
+// Without it, encrypted messages fail with ErrPayloadEncrypted.
+func (c *Consumer) SetPayloadEncryption(encryption *PayloadEncryption) {
+	c.encryption = encryption
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", dataKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts with a random nonce, which it prepends to the ciphertext
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts a ciphertext written by seal
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted payload is too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
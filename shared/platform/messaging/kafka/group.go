@@ -0,0 +1,100 @@
+package kafka
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/IBM/sarama"
+)
+
+// Rebalance strategies assigning partitions to the members of a consumer group
+const (
+	RebalanceStrategyRange      = "range"
+	RebalanceStrategyRoundRobin = "roundrobin"
+	RebalanceStrategySticky     = "sticky"
+)
+
+// groupInstanceIDPattern matches the group instance IDs accepted by Kafka
+var groupInstanceIDPattern = regexp.MustCompile(`^[0-9a-zA-Z._-]{1,249}$`)
+
+// GroupMembership controls how a consumer joins its consumer group
+type GroupMembership struct {
+	// InstanceID makes the consumer a static member of its group (KIP-345): a
+	// replica restarting under the same ID within the session timeout gets its
+	// partitions back without a rebalance. Must be unique within the group and
+	// stable across restarts, e.g. the pod name of a StatefulSet. Empty joins
+	// as a dynamic member.
+	InstanceID string `json:"instance_id"`
+
+	// RebalanceStrategy is range, roundrobin or sticky. Sticky keeps the
+	// partitions of the remaining members in place when the group rebalances.
+	// The client implements the eager rebalance protocol only, so the
+	// cooperative-sticky strategy is not available.
+	//
+	// Members also offer roundrobin, the strategy consumers used before it was
+	// configurable, after the chosen strategy. The group uses the first strategy
+	// every member offers, so during a rolling deploy from roundrobin the new
+	// members join the old ones on roundrobin, and the group switches to the
+	// chosen strategy once the last roundrobin-only member is gone. A deploy
+	// switching between sticky and range runs the group on roundrobin while
+	// both are present.
+	RebalanceStrategy string `json:"rebalance_strategy"`
+}
+
+// DefaultGroupMembership returns a dynamic membership with the sticky strategy
+func DefaultGroupMembership() GroupMembership {
+	return GroupMembership{
+		RebalanceStrategy: RebalanceStrategySticky,
+	}
+}
+
+// Validate checks the instance ID and the rebalance strategy
+func (m GroupMembership) Validate() error {
+	if m.InstanceID != "" && (!groupInstanceIDPattern.MatchString(m.InstanceID) || m.InstanceID == "." || m.InstanceID == "..") {
+		return fmt.Errorf("invalid consumer group instance ID %q: use up to 249 letters, digits, '.', '_' and '-'", m.InstanceID)
+	}
+	if _, err := m.balanceStrategy(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Apply configures the consumer group membership of saramaConfig. Static
+// membership needs Kafka 2.3 or later, so the protocol version is raised to
+// 2.3 when an instance ID is set.
+func (m GroupMembership) Apply(saramaConfig *sarama.Config) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	strategy, _ := m.balanceStrategy()
+	strategies := []sarama.BalanceStrategy{strategy}
+	if strategy.Name() != sarama.RoundRobinBalanceStrategyName {
+		strategies = append(strategies, sarama.NewBalanceStrategyRoundRobin()) // Lets roundrobin members migrate, see RebalanceStrategy
+	}
+	saramaConfig.Consumer.Group.Rebalance.Strategy = nil
+	saramaConfig.Consumer.Group.Rebalance.GroupStrategies = strategies
+
+	if m.InstanceID != "" {
+		saramaConfig.Consumer.Group.InstanceId = m.InstanceID
+		if !saramaConfig.Version.IsAtLeast(sarama.V2_3_0_0) {
+			saramaConfig.Version = sarama.V2_3_0_0
+		}
+	}
+	return nil
+}
+
+func (m GroupMembership) balanceStrategy() (sarama.BalanceStrategy, error) {
+	switch m.RebalanceStrategy {
+	case RebalanceStrategySticky, "":
+		return sarama.NewBalanceStrategySticky(), nil
+	case RebalanceStrategyRoundRobin:
+		return sarama.NewBalanceStrategyRoundRobin(), nil
+	case RebalanceStrategyRange:
+		return sarama.NewBalanceStrategyRange(), nil
+	case "cooperative-sticky":
+		return nil, fmt.Errorf("rebalance strategy cooperative-sticky is not supported by the Kafka client, use sticky")
+	default:
+		return nil, fmt.Errorf("unknown rebalance strategy %q: use range, roundrobin or sticky", m.RebalanceStrategy)
+	}
+}
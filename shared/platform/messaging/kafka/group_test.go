@@ -0,0 +1,37 @@
+package kafka
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+func TestApplyOffersRoundRobinAfterTheStrategy(t *testing.T) {
+	tests := []struct {
+		strategy string
+		want     []string
+	}{
+		{"", []string{sarama.StickyBalanceStrategyName, sarama.RoundRobinBalanceStrategyName}},
+		{RebalanceStrategySticky, []string{sarama.StickyBalanceStrategyName, sarama.RoundRobinBalanceStrategyName}},
+		{RebalanceStrategyRange, []string{sarama.RangeBalanceStrategyName, sarama.RoundRobinBalanceStrategyName}},
+		{RebalanceStrategyRoundRobin, []string{sarama.RoundRobinBalanceStrategyName}},
+	}
+	for _, tt := range tests {
+		saramaConfig := sarama.NewConfig()
+		if err := (GroupMembership{RebalanceStrategy: tt.strategy}).Apply(saramaConfig); err != nil {
+			t.Fatalf("Apply(%q): %v", tt.strategy, err)
+		}
+
+		var got []string
+		for _, strategy := range saramaConfig.Consumer.Group.Rebalance.GroupStrategies {
+			got = append(got, strategy.Name())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Apply(%q): group strategies %v, want %v", tt.strategy, got, tt.want)
+		}
+		if saramaConfig.Consumer.Group.Rebalance.Strategy != nil {
+			t.Errorf("Apply(%q): deprecated single strategy left set", tt.strategy)
+		}
+	}
+}
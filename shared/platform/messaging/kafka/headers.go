@@ -0,0 +1,15 @@
+package kafka
+
+// Standard event headers. Producers set them on every event so consumers can route
+// and filter messages without deserializing them.
+const (
+	EventTypeHeader   = "event-type"
+	EventIDHeader     = "event-id"
+	EventSourceHeader = "event-source"
+	NotifyHeader      = "notify" // "true" on events meant to notify a user
+)
+
+// Notify reports whether the producer marked the message as meant to notify a user
+func (m *Message) Notify() bool {
+	return m.Headers[NotifyHeader] == "true"
+}
@@ -0,0 +1,306 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// OffsetSnapshotConfig controls the periodic snapshots of a consumer group's
+// committed offsets, kept outside Kafka for point-in-time recovery and audit
+type OffsetSnapshotConfig struct {
+	Enabled   bool          `json:"enabled"`
+	Interval  time.Duration `json:"interval"`  // How often the committed offsets are read
+	Retention time.Duration `json:"retention"` // Snapshots older than this are deleted
+}
+
+// Validate checks the interval and retention of enabled snapshots
+func (c OffsetSnapshotConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Interval <= 0 || c.Retention <= 0 {
+		return fmt.Errorf("kafka offset snapshot interval and retention must be positive")
+	}
+	if c.Retention < c.Interval {
+		return fmt.Errorf("kafka offset snapshot retention (%s) must not be shorter than the interval (%s)", c.Retention, c.Interval)
+	}
+	return nil
+}
+
+// PartitionOffset is the next offset a consumer group reads from a partition;
+// every earlier message of the partition had been processed
+type PartitionOffset struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	Offset    int64  `json:"offset"`
+}
+
+// OffsetSnapshot records the committed offsets of a consumer group at a point in time
+type OffsetSnapshot struct {
+	GroupID string            `json:"group_id"`
+	TakenAt time.Time         `json:"taken_at"`
+	Offsets []PartitionOffset `json:"offsets"` // Sorted by topic and partition
+}
+
+// sameOffsets reports whether both snapshots record the same offsets
+func (s *OffsetSnapshot) sameOffsets(other *OffsetSnapshot) bool {
+	if s == nil || other == nil || len(s.Offsets) != len(other.Offsets) {
+		return false
+	}
+	for i := range s.Offsets {
+		if s.Offsets[i] != other.Offsets[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// OffsetSnapshotStore persists offset snapshots outside Kafka
+type OffsetSnapshotStore interface {
+	// Save stores a snapshot
+	Save(ctx context.Context, snapshot OffsetSnapshot) error
+
+	// SnapshotAt returns the latest snapshot of the group taken at or before at,
+	// or nil when there is none
+	SnapshotAt(ctx context.Context, groupID string, at time.Time) (*OffsetSnapshot, error)
+
+	// DeleteBefore deletes the snapshots of the group taken before the time and
+	// returns how many were deleted
+	DeleteBefore(ctx context.Context, groupID string, before time.Time) (int64, error)
+}
+
+// OffsetSnapshotter periodically reads the committed offsets of a consumer group
+// and stores them as a snapshot when they changed. Offsets are committed only
+// for processed messages, so a snapshot tells exactly which events had been
+// processed at its time, whichever replica processed them. Every replica may
+// run a snapshotter; a replica finding the offsets unchanged stores nothing.
+type OffsetSnapshotter struct {
+	admin   sarama.ClusterAdmin
+	groupID string
+	store   OffsetSnapshotStore
+	config  OffsetSnapshotConfig
+	logger  logging.Logger
+	metrics metrics.Metrics
+}
+
+// NewOffsetSnapshotter creates a snapshotter of the consumer group's offsets
+func NewOffsetSnapshotter(brokers []string, groupID string, store OffsetSnapshotStore, cfg OffsetSnapshotConfig, logger logging.Logger, metrics metrics.Metrics) (*OffsetSnapshotter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.ClientID = groupID + "-offset-snapshots"
+
+	client, err := sarama.NewClient(brokers, saramaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to kafka brokers for offset snapshots: %w", err)
+	}
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to create kafka admin for offset snapshots: %w", err)
+	}
+
+	return &OffsetSnapshotter{
+		admin:   admin,
+		groupID: groupID,
+		store:   store,
+		config:  cfg,
+		logger:  logger,
+		metrics: metrics,
+	}, nil
+}
+
+// Run snapshots the offsets every interval and deletes the snapshots that left
+// the retention window, until the context is cancelled
+func (s *OffsetSnapshotter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := s.Snapshot(ctx); err != nil {
+				s.metrics.IncrementCounter(ctx, "kafka_offset_snapshot_failures_total", map[string]string{
+					"group_id": s.groupID,
+				})
+				s.logger.Error(ctx, "Failed to snapshot kafka consumer offsets", err, map[string]interface{}{
+					"group_id": s.groupID,
+				})
+			}
+			s.prune(ctx)
+		}
+	}
+}
+
+// Snapshot reads the committed offsets of the group and stores them unless they
+// are those of the latest snapshot. It returns the snapshot describing the
+// offsets now, stored or not.
+func (s *OffsetSnapshotter) Snapshot(ctx context.Context) (*OffsetSnapshot, error) {
+	response, err := s.admin.ListConsumerGroupOffsets(s.groupID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list committed offsets: %w", err)
+	}
+
+	snapshot := &OffsetSnapshot{GroupID: s.groupID, TakenAt: time.Now().UTC()}
+	for topic, partitions := range response.Blocks {
+		for partition, block := range partitions {
+			if block == nil || block.Offset < 0 {
+				continue // Nothing committed yet
+			}
+			snapshot.Offsets = append(snapshot.Offsets, PartitionOffset{Topic: topic, Partition: partition, Offset: block.Offset})
+		}
+	}
+	sort.Slice(snapshot.Offsets, func(i, j int) bool {
+		a, b := snapshot.Offsets[i], snapshot.Offsets[j]
+		if a.Topic != b.Topic {
+			return a.Topic < b.Topic
+		}
+		return a.Partition < b.Partition
+	})
+
+	latest, err := s.store.SnapshotAt(ctx, s.groupID, snapshot.TakenAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the latest offset snapshot: %w", err)
+	}
+	if latest.sameOffsets(snapshot) {
+		return snapshot, nil
+	}
+
+	if err := s.store.Save(ctx, *snapshot); err != nil {
+		return nil, fmt.Errorf("failed to save offset snapshot: %w", err)
+	}
+	s.metrics.IncrementCounter(ctx, "kafka_offset_snapshots_total", map[string]string{
+		"group_id": s.groupID,
+	})
+	s.logger.Debug(ctx, "Kafka consumer offsets snapshotted", map[string]interface{}{
+		"group_id":   s.groupID,
+		"partitions": len(snapshot.Offsets),
+	})
+	return snapshot, nil
+}
+
+// prune deletes the snapshots older than the retention window. The latest
+// snapshot before the window is kept, so any time within the window resolves
+// to the offsets in force then.
+func (s *OffsetSnapshotter) prune(ctx context.Context) {
+	cutoff := time.Now().Add(-s.config.Retention)
+	boundary, err := s.store.SnapshotAt(ctx, s.groupID, cutoff)
+	if err != nil || boundary == nil {
+		return
+	}
+
+	deleted, err := s.store.DeleteBefore(ctx, s.groupID, boundary.TakenAt)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to delete expired kafka offset snapshots", err, map[string]interface{}{
+			"group_id": s.groupID,
+		})
+		return
+	}
+	if deleted > 0 {
+		s.metrics.AddCounter(ctx, "kafka_offset_snapshots_deleted_total", deleted, map[string]string{
+			"group_id": s.groupID,
+		})
+	}
+}
+
+// Close releases the Kafka connection
+func (s *OffsetSnapshotter) Close() error {
+	return s.admin.Close() // Also closes the client
+}
+
+// RestoreOffsets commits the offsets of a snapshot for its consumer group, so the
+// group resumes from where it was at the snapshot's time. Events processed since
+// are consumed again. The group must have no active members, or they would
+// overwrite the restored offsets; stop its consumers first.
+func RestoreOffsets(brokers []string, snapshot OffsetSnapshot) error {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.ClientID = snapshot.GroupID + "-offset-restore"
+	saramaConfig.Consumer.Offsets.AutoCommit.Enable = false
+
+	client, err := sarama.NewClient(brokers, saramaConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to kafka brokers to restore offsets: %w", err)
+	}
+	defer client.Close()
+
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		return fmt.Errorf("failed to create kafka admin to restore offsets: %w", err)
+	}
+	groups, err := admin.DescribeConsumerGroups([]string{snapshot.GroupID})
+	if err != nil {
+		return fmt.Errorf("failed to describe consumer group %s: %w", snapshot.GroupID, err)
+	}
+	for _, group := range groups {
+		if len(group.Members) > 0 {
+			return fmt.Errorf("consumer group %s has %d active members; stop them before restoring offsets", snapshot.GroupID, len(group.Members))
+		}
+	}
+
+	manager, err := sarama.NewOffsetManagerFromClient(snapshot.GroupID, client)
+	if err != nil {
+		return fmt.Errorf("failed to create offset manager: %w", err)
+	}
+	for _, offset := range snapshot.Offsets {
+		partitionManager, err := manager.ManagePartition(offset.Topic, offset.Partition)
+		if err != nil {
+			manager.Close()
+			return fmt.Errorf("failed to manage offsets of %s/%d: %w", offset.Topic, offset.Partition, err)
+		}
+		partitionManager.ResetOffset(offset.Offset, fmt.Sprintf("restored from snapshot of %s", snapshot.TakenAt.Format(time.RFC3339)))
+		defer partitionManager.AsyncClose()
+	}
+	manager.Commit()
+	return manager.Close()
+}
+
+// OffsetSnapshotHandler serves the snapshot of the consumer group's offsets in
+// force at a point in time, GET ?at=<RFC 3339 time>, by default now
+func OffsetSnapshotHandler(store OffsetSnapshotStore, groupID string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(map[string]string{"error": "method not allowed"})
+			return
+		}
+
+		at := time.Now()
+		if value := r.URL.Query().Get("at"); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "at must be an RFC 3339 time"})
+				return
+			}
+			at = parsed
+		}
+
+		snapshot, err := store.SnapshotAt(r.Context(), groupID, at)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": "failed to read offset snapshots"})
+			return
+		}
+		if snapshot == nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": "no offset snapshot at or before the time"})
+			return
+		}
+		json.NewEncoder(w).Encode(snapshot)
+	})
+}
@@ -0,0 +1,148 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/IBM/sarama"
+
+	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
+)
+
+// Headers of a message whose payload exceeded the producer's MaxMessageBytes and
+// was offloaded to object storage. The message value is then a PayloadPointer.
+const (
+	PayloadRefHeader  = "payload-ref"  // URL the payload is fetched from
+	PayloadSizeHeader = "payload-size" // Size of the offloaded payload in bytes
+)
+
+// ErrMessageTooLarge is returned for a message larger than the producer's
+// MaxMessageBytes when no overflow store is configured
+var ErrMessageTooLarge = errors.New("message exceeds the maximum message size")
+
+// OverflowStore keeps the payloads of messages too large to send
+type OverflowStore interface {
+	// Offload stores the payload of a message for the topic and returns the URL
+	// consumers fetch it from
+	Offload(ctx context.Context, topic string, payload []byte) (string, error)
+}
+
+// PayloadPointer is the value of a message whose payload was offloaded, for
+// consumers reading the value without resolving it
+type PayloadPointer struct {
+	PayloadRef  string `json:"payload_ref"`
+	PayloadSize int    `json:"payload_size"`
+}
+
+// Offloaded reports whether the producer offloaded the message's payload
+func (m *Message) Offloaded() bool {
+	_, ok := m.Headers[PayloadRefHeader]
+	return ok
+}
+
+// CheckMessageSize returns ErrMessageTooLarge for a message that would exceed
+// maxBytes on the wire; a maxBytes of 0 leaves the message unchecked
+func CheckMessageSize(message *sarama.ProducerMessage, maxBytes int) error {
+	if size := message.ByteSize(2); maxBytes > 0 && size > maxBytes {
+		return fmt.Errorf("%w: %d bytes, at most %d", ErrMessageTooLarge, size, maxBytes)
+	}
+	return nil
+}
+
+// SetOverflowStore offloads messages larger than MaxMessageBytes to the store
+// and sends a pointer to them instead; without one such messages are rejected
+func (p *Producer) SetOverflowStore(store OverflowStore) {
+	p.overflow = store
+}
+
+// fitMessage checks the message against MaxMessageBytes before it is sent, so an
+// oversized message fails here instead of at the broker. With an overflow store
+// the payload is offloaded and the message carries a pointer to it.
+func (p *Producer) fitMessage(ctx context.Context, message *sarama.ProducerMessage) error {
+	sizeErr := CheckMessageSize(message, p.config.MaxMessageBytes)
+	if sizeErr == nil {
+		return nil
+	}
+	size := message.ByteSize(2)
+
+	payload, err := message.Value.Encode()
+	if err != nil {
+		return platformError.Wrap(err, "failed to encode message value")
+	}
+
+	if p.overflow == nil {
+		p.metrics.IncrementCounter(ctx, "kafka_producer_oversized_messages_total", map[string]string{
+			"topic":  message.Topic,
+			"action": "rejected",
+		})
+		p.logger.Error(ctx, "Kafka message exceeds the maximum message size", sizeErr, map[string]interface{}{
+			"topic":             message.Topic,
+			"size":              size,
+			"max_message_bytes": p.config.MaxMessageBytes,
+		})
+		return sizeErr
+	}
+
+	ref, err := p.overflow.Offload(ctx, message.Topic, payload)
+	if err != nil {
+		p.metrics.IncrementCounter(ctx, "kafka_producer_oversized_messages_total", map[string]string{
+			"topic":  message.Topic,
+			"action": "offload_failed",
+		})
+		return platformError.Wrap(err, "failed to offload oversized message payload")
+	}
+
+	pointer, err := json.Marshal(PayloadPointer{PayloadRef: ref, PayloadSize: len(payload)})
+	if err != nil {
+		return platformError.Wrap(err, "failed to marshal payload pointer")
+	}
+	message.Value = sarama.ByteEncoder(pointer)
+	message.Headers = append(message.Headers,
+		sarama.RecordHeader{Key: []byte(PayloadRefHeader), Value: []byte(ref)},
+		sarama.RecordHeader{Key: []byte(PayloadSizeHeader), Value: []byte(strconv.Itoa(len(payload)))},
+	)
+
+	p.metrics.IncrementCounter(ctx, "kafka_producer_oversized_messages_total", map[string]string{
+		"topic":  message.Topic,
+		"action": "offloaded",
+	})
+	p.logger.Warn(ctx, "Offloaded oversized Kafka message payload", map[string]interface{}{
+		"topic":             message.Topic,
+		"size":              size,
+		"max_message_bytes": p.config.MaxMessageBytes,
+	})
+	return nil
+}
+
+// ResolvePayload returns the payload of a message, fetching it when the producer
+// offloaded it. Consumers not built on Consumer call it themselves.
+func ResolvePayload(ctx context.Context, client *http.Client, headers map[string]string, value []byte) ([]byte, error) {
+	ref, ok := headers[PayloadRefHeader]
+	if !ok {
+		return value, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ref, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload reference: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch offloaded payload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch offloaded payload: status %d", resp.StatusCode)
+	}
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offloaded payload: %w", err)
+	}
+	return payload, nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/IBM/sarama"
 	"github.com/google/uuid"
 
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/deadline"
 	platformError "github.com/amiosamu/rocket-science/shared/platform/errors"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
 	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
@@ -22,7 +24,8 @@ type ProducerConfig struct {
 	RetryBackoff       time.Duration `json:"retry_backoff"`
 	FlushFrequency     time.Duration `json:"flush_frequency"`
 	FlushMessages      int           `json:"flush_messages"`
-	CompressionType    string        `json:"compression_type"`
+	CompressionType    string        `json:"compression_type"`  // none, gzip, snappy, lz4 or zstd
+	CompressionLevel   int           `json:"compression_level"` // Codec default when 0
 	IdempotentProducer bool          `json:"idempotent_producer"`
 	RequiredAcks       int           `json:"required_acks"`
 	MaxMessageBytes    int           `json:"max_message_bytes"`
@@ -46,6 +49,25 @@ func DefaultProducerConfig() ProducerConfig {
 	}
 }
 
+// compressionCodecs are the compression types of ProducerConfig; snappy when unset
+var compressionCodecs = map[string]sarama.CompressionCodec{
+	"":       sarama.CompressionSnappy,
+	"none":   sarama.CompressionNone,
+	"gzip":   sarama.CompressionGZIP,
+	"snappy": sarama.CompressionSnappy,
+	"lz4":    sarama.CompressionLZ4,
+	"zstd":   sarama.CompressionZSTD,
+}
+
+// CompressionCodec returns the codec of a compression type; an empty type is snappy
+func CompressionCodec(compressionType string) (sarama.CompressionCodec, error) {
+	codec, ok := compressionCodecs[compressionType]
+	if !ok {
+		return sarama.CompressionNone, platformError.NewValidation(fmt.Sprintf("unknown Kafka compression type %q", compressionType))
+	}
+	return codec, nil
+}
+
 // Producer provides a high-level Kafka producer interface
 type Producer struct {
 	producer      sarama.SyncProducer
@@ -53,6 +75,8 @@ type Producer struct {
 	config        ProducerConfig
 	logger        logging.Logger
 	metrics       metrics.Metrics
+	overflow      OverflowStore      // nil rejects messages over MaxMessageBytes
+	encryption    *PayloadEncryption // nil sends every payload in plaintext
 	closed        bool
 }
 
@@ -90,19 +114,14 @@ func NewProducer(config ProducerConfig, logger logging.Logger, metrics metrics.M
 	}
 
 	// Compression
-	switch config.CompressionType {
-	case "none":
-		saramaConfig.Producer.Compression = sarama.CompressionNone
-	case "gzip":
-		saramaConfig.Producer.Compression = sarama.CompressionGZIP
-	case "snappy":
-		saramaConfig.Producer.Compression = sarama.CompressionSnappy
-	case "lz4":
-		saramaConfig.Producer.Compression = sarama.CompressionLZ4
-	case "zstd":
-		saramaConfig.Producer.Compression = sarama.CompressionZSTD
-	default:
-		saramaConfig.Producer.Compression = sarama.CompressionSnappy
+	compression, err := CompressionCodec(config.CompressionType)
+	if err != nil {
+		return nil, err
+	}
+	saramaConfig.Producer.Compression = compression
+	saramaConfig.Producer.CompressionLevel = sarama.CompressionLevelDefault
+	if config.CompressionLevel != 0 {
+		saramaConfig.Producer.CompressionLevel = config.CompressionLevel
 	}
 
 	// Batching configuration
@@ -145,6 +164,7 @@ func NewProducer(config ProducerConfig, logger logging.Logger, metrics metrics.M
 		"compression":   config.CompressionType,
 		"idempotent":    config.IdempotentProducer,
 		"required_acks": config.RequiredAcks,
+		"max_bytes":     config.MaxMessageBytes,
 	})
 
 	return producer, nil
@@ -162,8 +182,14 @@ func (p *Producer) SendMessage(ctx context.Context, topic, key string, value int
 		return platformError.Wrap(err, "failed to serialize message value")
 	}
 
+	// Encrypt the payloads of topics carrying personal data
+	data, headers, err = p.encryptValue(ctx, topic, data, headers)
+	if err != nil {
+		return err
+	}
+
 	// Build headers
-	messageHeaders := p.buildHeaders(headers)
+	messageHeaders := p.buildHeaders(ctx, headers)
 
 	// Create message
 	message := &sarama.ProducerMessage{
@@ -173,11 +199,14 @@ func (p *Producer) SendMessage(ctx context.Context, topic, key string, value int
 		Headers:   messageHeaders,
 		Timestamp: time.Now(),
 	}
+	if err := p.fitMessage(ctx, message); err != nil {
+		return err
+	}
 
 	// Send message
 	partition, offset, err := p.producer.SendMessage(message)
 	if err != nil {
-		p.metrics.IncrementCounter("kafka_producer_errors_total", map[string]string{
+		p.metrics.IncrementCounter(ctx, "kafka_producer_errors_total", map[string]string{
 			"topic": topic,
 			"error": "send_failed",
 		})
@@ -189,10 +218,10 @@ func (p *Producer) SendMessage(ctx context.Context, topic, key string, value int
 	}
 
 	// Record metrics
-	p.metrics.IncrementCounter("kafka_producer_messages_total", map[string]string{
+	p.metrics.IncrementCounter(ctx, "kafka_producer_messages_total", map[string]string{
 		"topic": topic,
 	})
-	p.metrics.RecordValue("kafka_producer_message_size_bytes", float64(len(data)), map[string]string{
+	p.metrics.RecordValue(ctx, "kafka_producer_message_size_bytes", float64(len(data)), map[string]string{
 		"topic": topic,
 	})
 
@@ -219,8 +248,14 @@ func (p *Producer) SendMessageAsync(ctx context.Context, topic, key string, valu
 		return platformError.Wrap(err, "failed to serialize message value")
 	}
 
+	// Encrypt the payloads of topics carrying personal data
+	data, headers, err = p.encryptValue(ctx, topic, data, headers)
+	if err != nil {
+		return err
+	}
+
 	// Build headers
-	messageHeaders := p.buildHeaders(headers)
+	messageHeaders := p.buildHeaders(ctx, headers)
 
 	// Create message
 	message := &sarama.ProducerMessage{
@@ -230,6 +265,9 @@ func (p *Producer) SendMessageAsync(ctx context.Context, topic, key string, valu
 		Headers:   messageHeaders,
 		Timestamp: time.Now(),
 	}
+	if err := p.fitMessage(ctx, message); err != nil {
+		return err
+	}
 
 	// Send message asynchronously
 	select {
@@ -382,6 +420,8 @@ func (p *Producer) GetStats() map[string]interface{} {
 		"flush_frequency":   p.config.FlushFrequency,
 		"flush_messages":    p.config.FlushMessages,
 		"max_message_bytes": p.config.MaxMessageBytes,
+		"overflow":          p.overflow != nil,
+		"encryption":        p.encryption != nil,
 	}
 }
 
@@ -399,7 +439,7 @@ func (p *Producer) serializeValue(value interface{}) ([]byte, error) {
 	}
 }
 
-func (p *Producer) buildHeaders(headers map[string]string) []sarama.RecordHeader {
+func (p *Producer) buildHeaders(ctx context.Context, headers map[string]string) []sarama.RecordHeader {
 	var recordHeaders []sarama.RecordHeader
 
 	// Add custom headers
@@ -426,6 +466,27 @@ func (p *Producer) buildHeaders(headers map[string]string) []sarama.RecordHeader
 		Value: []byte(uuid.New().String()),
 	})
 
+	// Carry the caller's budget on to the next saga step, unless the message
+	// already relays one
+	if _, exists := headers[deadline.KafkaHeader]; !exists {
+		if value, ok := deadline.Header(ctx); ok {
+			recordHeaders = append(recordHeaders, sarama.RecordHeader{
+				Key:   []byte(deadline.KafkaHeader),
+				Value: []byte(value),
+			})
+		}
+	}
+
+	// Carry the request metadata on, unless the message sets the header itself
+	for k, v := range ctxmeta.KafkaHeaders(ctx) {
+		if _, exists := headers[k]; !exists {
+			recordHeaders = append(recordHeaders, sarama.RecordHeader{
+				Key:   []byte(k),
+				Value: []byte(v),
+			})
+		}
+	}
+
 	return recordHeaders
 }
 
@@ -447,7 +508,7 @@ func (p *Producer) handleAsyncErrors() {
 					keyStr = "no_key"
 				}
 
-				p.metrics.IncrementCounter("kafka_producer_async_errors_total", map[string]string{
+				p.metrics.IncrementCounter(context.Background(), "kafka_producer_async_errors_total", map[string]string{
 					"topic": err.Msg.Topic,
 				})
 				p.logger.Error(nil, "Async Kafka producer error", err.Err, map[string]interface{}{
@@ -459,7 +520,7 @@ func (p *Producer) handleAsyncErrors() {
 			}
 		case success := <-p.asyncProducer.Successes():
 			if success != nil {
-				p.metrics.IncrementCounter("kafka_producer_async_messages_total", map[string]string{
+				p.metrics.IncrementCounter(context.Background(), "kafka_producer_async_messages_total", map[string]string{
 					"topic": success.Topic,
 				})
 				p.logger.Debug(nil, "Async Kafka message sent successfully", map[string]interface{}{
@@ -507,10 +568,10 @@ func NewEvent(eventType, source, subject string, data interface{}) *Event {
 // SendEvent sends a standardized event
 func (p *Producer) SendEvent(ctx context.Context, topic string, event *Event) error {
 	headers := map[string]string{
-		"event-type":   event.Type,
-		"event-id":     event.ID,
-		"event-source": event.Source,
-		"content-type": "application/json",
+		EventTypeHeader:   event.Type,
+		EventIDHeader:     event.ID,
+		EventSourceHeader: event.Source,
+		"content-type":    "application/json",
 	}
 
 	return p.SendMessage(ctx, topic, event.Subject, event, headers)
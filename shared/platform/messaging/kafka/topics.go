@@ -0,0 +1,93 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/amiosamu/rocket-science/shared/contracts/topics"
+)
+
+// topicMetadataTimeout bounds reading the topic list from the brokers
+const topicMetadataTimeout = 10 * time.Second
+
+// VerifyTopics checks that the registered topics a service produces to or
+// consumes from exist on the brokers, returning a *topics.MissingTopicsError
+// naming those that don't. Nothing is checked when the registry's validation is
+// off. Callers decide what a failure means through registry.Strict().
+func VerifyTopics(brokers []string, registry topics.Registry, used ...topics.Topic) error {
+	if registry.Validation == topics.ValidationOff || len(used) == 0 {
+		return nil
+	}
+
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.ClientID = "topic-registry-check"
+	saramaConfig.Net.DialTimeout = topicMetadataTimeout
+	saramaConfig.Net.ReadTimeout = topicMetadataTimeout
+	saramaConfig.Metadata.Retry.Max = 1
+
+	client, err := sarama.NewClient(brokers, saramaConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to kafka brokers to verify topics: %w", err)
+	}
+	defer client.Close()
+
+	brokerTopics, err := client.Topics()
+	if err != nil {
+		return fmt.Errorf("failed to list kafka topics: %w", err)
+	}
+
+	if missing := registry.Missing(brokerTopics, used...); len(missing) > 0 {
+		return &topics.MissingTopicsError{Topics: missing}
+	}
+	return nil
+}
+
+// EnsureCompactedTopic creates a log-compacted topic unless it exists, for
+// topics that hold the latest state per key instead of a history of events.
+// Brokers auto-create topics with the delete cleanup policy on first use, so an
+// existing topic that is not compacted is reported as an error.
+func EnsureCompactedTopic(brokers []string, name string, partitions int32, replicationFactor int16) error {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.ClientID = "topic-registry-check"
+	saramaConfig.Net.DialTimeout = topicMetadataTimeout
+	saramaConfig.Net.ReadTimeout = topicMetadataTimeout
+	saramaConfig.Metadata.Retry.Max = 1
+
+	admin, err := sarama.NewClusterAdmin(brokers, saramaConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to kafka brokers to create topic %s: %w", name, err)
+	}
+	defer admin.Close()
+
+	compact := "compact"
+	err = admin.CreateTopic(name, &sarama.TopicDetail{
+		NumPartitions:     partitions,
+		ReplicationFactor: replicationFactor,
+		ConfigEntries:     map[string]*string{"cleanup.policy": &compact},
+	}, false)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sarama.ErrTopicAlreadyExists) {
+		return fmt.Errorf("failed to create kafka topic %s: %w", name, err)
+	}
+
+	entries, err := admin.DescribeConfig(sarama.ConfigResource{
+		Type:        sarama.TopicResource,
+		Name:        name,
+		ConfigNames: []string{"cleanup.policy"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe kafka topic %s: %w", name, err)
+	}
+	for _, entry := range entries {
+		if entry.Name == "cleanup.policy" && !strings.Contains(entry.Value, compact) {
+			return fmt.Errorf("kafka topic %s has cleanup.policy=%s, it must be compacted", name, entry.Value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package objectstore
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Handler serves objects to holders of a signed URL. It is mounted at prefix, the
+// path of the base URL the signer signed, e.g. "/files/".
+func Handler(store Store, signer *Signer, prefix string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, prefix)
+		query := r.URL.Query()
+		switch err := signer.Verify(key, query.Get(ExpiresParam), query.Get(SignatureParam)); {
+		case errors.Is(err, ErrURLExpired):
+			http.Error(w, "link expired", http.StatusGone)
+			return
+		case err != nil:
+			http.Error(w, "invalid signature", http.StatusForbidden)
+			return
+		}
+
+		body, object, err := store.Get(r.Context(), key)
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			http.Error(w, "failed to read object", http.StatusInternalServerError)
+			return
+		}
+		defer body.Close()
+
+		w.Header().Set("Content-Type", object.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(object.Size, 10))
+		w.Header().Set("Last-Modified", object.ModifiedAt.Format(http.TimeFormat))
+		w.Header().Set("Cache-Control", "private, no-store")
+		if r.Method == http.MethodHead {
+			return
+		}
+		io.Copy(w, body)
+	})
+}
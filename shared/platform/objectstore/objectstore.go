@@ -0,0 +1,191 @@
+// Package objectstore stores generated files, such as exports and reports, under
+// slash-separated keys, and hands them out through expiring signed URLs so
+// callers can download them without service credentials.
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Object store errors
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Object describes a stored object
+type Object struct {
+	Key         string
+	ContentType string
+	Size        int64
+	ModifiedAt  time.Time
+}
+
+// Store holds objects under slash-separated keys such as "settlements/2024-05-01/batch.csv"
+type Store interface {
+	// Put stores the body under the key, replacing any existing object
+	Put(ctx context.Context, key string, body io.Reader) (Object, error)
+
+	// Get opens the object; the caller closes the returned reader
+	Get(ctx context.Context, key string) (io.ReadCloser, Object, error)
+
+	// Stat describes the object without opening it
+	Stat(ctx context.Context, key string) (Object, error)
+
+	// List describes the objects whose keys start with the prefix, sorted by key
+	List(ctx context.Context, prefix string) ([]Object, error)
+
+	// Delete removes the object
+	Delete(ctx context.Context, key string) error
+}
+
+// FileStore is a Store on a local directory. Objects are written to a temporary
+// file first, so readers never see a partially written object.
+type FileStore struct {
+	root string
+}
+
+// NewFileStore creates a store under the directory, creating it if needed
+func NewFileStore(root string) (*FileStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create object store directory: %w", err)
+	}
+	return &FileStore{root: root}, nil
+}
+
+func (s *FileStore) Put(ctx context.Context, key string, body io.Reader) (Object, error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return Object{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o750); err != nil {
+		return Object{}, fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".upload-*")
+	if err != nil {
+		return Object{}, fmt.Errorf("failed to create object: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return Object{}, fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return Object{}, fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return Object{}, fmt.Errorf("failed to store object: %w", err)
+	}
+	return s.Stat(ctx, key)
+}
+
+func (s *FileStore) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	object, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, Object{}, err
+	}
+	filename, _ := s.path(key)
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, Object{}, fmt.Errorf("failed to open object: %w", err)
+	}
+	return file, object, nil
+}
+
+func (s *FileStore) Stat(ctx context.Context, key string) (Object, error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return Object{}, err
+	}
+	info, err := os.Stat(filename)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && info.IsDir()) {
+		return Object{}, ErrNotFound
+	}
+	if err != nil {
+		return Object{}, fmt.Errorf("failed to stat object: %w", err)
+	}
+	return Object{
+		Key:         key,
+		ContentType: ContentType(key),
+		Size:        info.Size(),
+		ModifiedAt:  info.ModTime().UTC(),
+	}, nil
+}
+
+func (s *FileStore) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	err := filepath.WalkDir(s.root, func(filename string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, filename)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, Object{
+			Key:         key,
+			ContentType: ContentType(key),
+			Size:        info.Size(),
+			ModifiedAt:  info.ModTime().UTC(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filename); errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	} else if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+// path maps a key to a file below the root, rejecting keys that would escape it
+func (s *FileStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// ContentType guesses the content type of an object from its key's extension
+func ContentType(key string) string {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,89 @@
+package objectstore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Signed URL errors
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrURLExpired       = errors.New("signed URL expired")
+)
+
+// Query parameters of signed URLs
+const (
+	ExpiresParam   = "expires"
+	SignatureParam = "signature"
+)
+
+// Signer signs object URLs with HMAC-SHA256, binding the key and expiry so a
+// URL cannot be reused for another object or after it expired
+type Signer struct {
+	secret []byte
+	now    func() time.Time
+}
+
+// NewSigner creates a signer with the secret shared by whoever verifies the URLs
+func NewSigner(secret []byte) *Signer {
+	return &Signer{secret: secret, now: time.Now}
+}
+
+// SignedURL is a URL granting time-limited access to one object
+type SignedURL struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+// SignURL returns a URL for the object below baseURL, e.g.
+// "https://host/files/" + key + "?expires=...&signature=...", valid for ttl
+func (s *Signer) SignURL(baseURL, key string, ttl time.Duration) SignedURL {
+	expiresAt := s.now().Add(ttl).Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set(ExpiresParam, expires)
+	query.Set(SignatureParam, s.signature(key, expires))
+
+	return SignedURL{
+		URL:       strings.TrimSuffix(baseURL, "/") + "/" + escapeKey(key) + "?" + query.Encode(),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// Verify checks the expires and signature query values of a signed URL for the key
+func (s *Signer) Verify(key, expires, signature string) error {
+	if !hmac.Equal([]byte(signature), []byte(s.signature(key, expires))) {
+		return ErrInvalidSignature
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !s.now().Before(time.Unix(unix, 0)) {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+func (s *Signer) signature(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,228 @@
+// Package grpclog logs a summary of every gRPC call a server handles: method,
+// status, duration and, when enabled, the request and response with their
+// sensitive fields redacted. Successful calls are sampled; failed calls are
+// always logged.
+package grpclog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+)
+
+// Config configures the request logging of a gRPC server
+type Config struct {
+	// SampleRate is the share of successful calls logged, from 0 to 1
+	SampleRate float64
+
+	// LogPayloads adds the redacted request and response to the entries
+	LogPayloads bool
+
+	// MaxPayloadBytes truncates logged payloads; 0 does not truncate
+	MaxPayloadBytes int
+
+	// SensitiveFields are the field names redacted from payloads
+	SensitiveFields []string
+}
+
+// DefaultConfig logs every call with its redacted payloads
+func DefaultConfig() Config {
+	return Config{
+		SampleRate:      1,
+		LogPayloads:     true,
+		MaxPayloadBytes: 2048,
+		SensitiveFields: DefaultSensitiveFields,
+	}
+}
+
+// FromEnv reads the configuration from the environment:
+//
+//	GRPC_LOG_SAMPLE_RATE        share of successful calls logged, default 1
+//	GRPC_LOG_PAYLOADS           false leaves requests and responses out, default true
+//	GRPC_LOG_MAX_PAYLOAD_BYTES  payload truncation, default 2048
+//	GRPC_LOG_REDACT_FIELDS      comma separated field names redacted on top of the defaults
+func FromEnv() (Config, error) {
+	cfg := DefaultConfig()
+
+	if value := os.Getenv("GRPC_LOG_SAMPLE_RATE"); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return Config{}, fmt.Errorf("invalid GRPC_LOG_SAMPLE_RATE %q: must be between 0 and 1", value)
+		}
+		cfg.SampleRate = rate
+	}
+
+	if value := os.Getenv("GRPC_LOG_PAYLOADS"); value != "" {
+		logPayloads, err := strconv.ParseBool(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid GRPC_LOG_PAYLOADS %q: %w", value, err)
+		}
+		cfg.LogPayloads = logPayloads
+	}
+
+	if value := os.Getenv("GRPC_LOG_MAX_PAYLOAD_BYTES"); value != "" {
+		maxBytes, err := strconv.Atoi(value)
+		if err != nil || maxBytes < 0 {
+			return Config{}, fmt.Errorf("invalid GRPC_LOG_MAX_PAYLOAD_BYTES %q", value)
+		}
+		cfg.MaxPayloadBytes = maxBytes
+	}
+
+	if value := os.Getenv("GRPC_LOG_REDACT_FIELDS"); value != "" {
+		cfg.SensitiveFields = append([]string{}, DefaultSensitiveFields...)
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				cfg.SensitiveFields = append(cfg.SensitiveFields, field)
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// Logger writes the entries of the interceptors; err is nil for successful calls
+type Logger interface {
+	Log(ctx context.Context, message string, err error, fields map[string]interface{})
+}
+
+// FromLogger adapts a platform logger
+func FromLogger(logger logging.Logger) Logger {
+	return platformLogger{logger: logger}
+}
+
+// FromSlog adapts a slog logger
+func FromSlog(logger *slog.Logger) Logger {
+	return slogLogger{logger: logger}
+}
+
+type platformLogger struct {
+	logger logging.Logger
+}
+
+func (l platformLogger) Log(ctx context.Context, message string, err error, fields map[string]interface{}) {
+	if err != nil {
+		l.logger.Error(ctx, message, err, fields)
+		return
+	}
+	l.logger.Info(ctx, message, fields)
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) Log(ctx context.Context, message string, err error, fields map[string]interface{}) {
+	level := slog.LevelInfo
+	args := make([]interface{}, 0, 2*len(fields)+2)
+	for key, value := range fields {
+		args = append(args, key, value)
+	}
+	if err != nil {
+		level = slog.LevelError
+		args = append(args, "error", err)
+	}
+	l.logger.Log(ctx, level, message, args...)
+}
+
+// Interceptors log the calls of a gRPC server
+type Interceptors struct {
+	logger   Logger
+	config   Config
+	redactor *Redactor
+}
+
+// New creates the logging interceptors
+func New(logger Logger, cfg Config) *Interceptors {
+	return &Interceptors{
+		logger:   logger,
+		config:   cfg,
+		redactor: NewRedactor(cfg.SensitiveFields, cfg.MaxPayloadBytes),
+	}
+}
+
+// UnaryServerInterceptor logs a summary of every sampled or failed unary call
+func (i *Interceptors) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		if err == nil && !i.sampled() {
+			return resp, err
+		}
+
+		fields := i.fields(ctx, info.FullMethod, time.Since(start), err)
+		if i.config.LogPayloads {
+			if request := i.redactor.Summarize(req); request != "" {
+				fields["request"] = request
+			}
+			if err == nil {
+				if response := i.redactor.Summarize(resp); response != "" {
+					fields["response"] = response
+				}
+			}
+		}
+
+		if err != nil {
+			i.logger.Log(ctx, "gRPC request failed", i.redactedError(err), fields)
+		} else {
+			i.logger.Log(ctx, "gRPC request completed", nil, fields)
+		}
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor logs a summary of every sampled or failed stream.
+// Stream messages are not logged.
+func (i *Interceptors) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		if err == nil && !i.sampled() {
+			return err
+		}
+
+		ctx := stream.Context()
+		fields := i.fields(ctx, info.FullMethod, time.Since(start), err)
+		if err != nil {
+			i.logger.Log(ctx, "gRPC stream failed", i.redactedError(err), fields)
+		} else {
+			i.logger.Log(ctx, "gRPC stream completed", nil, fields)
+		}
+		return err
+	}
+}
+
+func (i *Interceptors) sampled() bool {
+	return i.config.SampleRate >= 1 || (i.config.SampleRate > 0 && rand.Float64() < i.config.SampleRate)
+}
+
+func (i *Interceptors) fields(ctx context.Context, method string, duration time.Duration, err error) map[string]interface{} {
+	fields := map[string]interface{}{
+		"method":      method,
+		"duration_ms": duration.Milliseconds(),
+		"status":      status.Code(err).String(),
+	}
+	if userID, ok := ctxmeta.UserID(ctx); ok {
+		fields["user_id"] = userID
+	}
+	if err == nil && i.config.SampleRate < 1 {
+		fields["sample_rate"] = i.config.SampleRate
+	}
+	return fields
+}
+
+// redactedError keeps the code of err but redacts emails from its message
+func (i *Interceptors) redactedError(err error) error {
+	st := status.Convert(err)
+	return status.Error(st.Code(), i.redactor.Text(st.Message()))
+}
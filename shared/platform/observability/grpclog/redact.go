@@ -0,0 +1,115 @@
+package grpclog
+
+import (
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Redacted replaces the value of sensitive string fields
+const Redacted = "[REDACTED]"
+
+// DefaultSensitiveFields are the field names redacted unless configured
+// otherwise. A field matches a name when it is the name or ends with it after
+// an underscore, so "token" covers access_token and refresh_token. Session
+// IDs are credentials too.
+var DefaultSensitiveFields = []string{"password", "token", "secret", "session_id", "email", "chat_id"}
+
+// emailPattern finds email addresses in free text such as error messages
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// Redactor summarizes messages for logging with their sensitive fields
+// redacted. Fields marked with the debug_redact option are always redacted.
+type Redactor struct {
+	fields   []string
+	maxBytes int
+}
+
+// NewRedactor creates a redactor of the named fields, truncating summaries to
+// maxBytes; a maxBytes of 0 does not truncate
+func NewRedactor(fields []string, maxBytes int) *Redactor {
+	normalized := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.ToLower(strings.TrimSpace(field)); field != "" {
+			normalized = append(normalized, field)
+		}
+	}
+	return &Redactor{fields: normalized, maxBytes: maxBytes}
+}
+
+// Summarize returns the redacted JSON of msg, or "" if msg is not a protobuf
+// message
+func (r *Redactor) Summarize(msg interface{}) string {
+	message, ok := msg.(proto.Message)
+	if !ok || message == nil {
+		return ""
+	}
+
+	clone := proto.Clone(message)
+	if clone == nil {
+		return ""
+	}
+	r.redact(clone.ProtoReflect())
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(clone)
+	if err != nil {
+		return ""
+	}
+	if r.maxBytes > 0 && len(data) > r.maxBytes {
+		return string(data[:r.maxBytes]) + "...(truncated)"
+	}
+	return string(data)
+}
+
+// Text redacts email addresses from free text such as error messages
+func (r *Redactor) Text(text string) string {
+	return emailPattern.ReplaceAllString(text, Redacted)
+}
+
+// redact clears the sensitive fields of message in place, descending into
+// nested messages, lists and maps
+func (r *Redactor) redact(message protoreflect.Message) {
+	message.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if r.sensitive(fd) {
+			if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+				message.Set(fd, protoreflect.ValueOfString(Redacted))
+			} else {
+				message.Clear(fd)
+			}
+			return true
+		}
+
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				r.redact(list.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			value.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				r.redact(v.Message())
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			r.redact(value.Message())
+		}
+		return true
+	})
+}
+
+func (r *Redactor) sensitive(fd protoreflect.FieldDescriptor) bool {
+	if options, ok := fd.Options().(*descriptorpb.FieldOptions); ok && options.GetDebugRedact() {
+		return true
+	}
+	name := strings.ToLower(string(fd.Name()))
+	for _, field := range r.fields {
+		if name == field || strings.HasSuffix(name, "_"+field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+// Package labels defines the standard labels every service attaches to its
+// metrics and traces, so dashboards can separate staging from production, one
+// region from another and the tenants of dedicated deployments. They are set
+// once for all services in the environment:
+//
+//	ENVIRONMENT      deployment environment, default development
+//	REGION           region the service runs in, left out when empty
+//	TENANT           tenant a dedicated deployment serves, left out when empty
+//	SERVICE_VERSION  version of the service, default the build version
+package labels
+
+import (
+	"os"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+
+	"github.com/amiosamu/rocket-science/shared/platform/version"
+)
+
+// Attribute keys of the standard labels. Prometheus exposes them with dots
+// replaced by underscores, e.g. deployment_environment.
+var (
+	EnvironmentKey    = semconv.DeploymentEnvironmentKey
+	RegionKey         = semconv.CloudRegionKey
+	TenantKey         = attribute.Key("tenant.id")
+	ServiceVersionKey = semconv.ServiceVersionKey
+)
+
+// Standard holds the standard labels of a running service
+type Standard struct {
+	Environment    string
+	Region         string
+	Tenant         string
+	ServiceVersion string
+}
+
+// FromEnv reads the standard labels from the environment
+func FromEnv() Standard {
+	labels := Standard{
+		Environment:    os.Getenv("ENVIRONMENT"),
+		Region:         os.Getenv("REGION"),
+		Tenant:         os.Getenv("TENANT"),
+		ServiceVersion: os.Getenv("SERVICE_VERSION"),
+	}
+	if labels.Environment == "" {
+		labels.Environment = "development"
+	}
+	if labels.ServiceVersion == "" {
+		labels.ServiceVersion = version.Version
+	}
+	return labels
+}
+
+// Attributes returns the labels as resource attributes, leaving out empty ones
+func (s Standard) Attributes() []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 4)
+	for _, attr := range []attribute.KeyValue{
+		EnvironmentKey.String(s.Environment),
+		RegionKey.String(s.Region),
+		TenantKey.String(s.Tenant),
+		ServiceVersionKey.String(s.ServiceVersion),
+	} {
+		if attr.Value.AsString() != "" {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// Filter keeps the standard labels among resource attributes, e.g. to copy
+// them onto every exported metric series
+func Filter(kv attribute.KeyValue) bool {
+	switch kv.Key {
+	case EnvironmentKey, RegionKey, TenantKey, ServiceVersionKey:
+		return true
+	}
+	return false
+}
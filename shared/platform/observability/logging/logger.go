@@ -6,6 +6,10 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
 )
 
 // Logger defines the interface for logging
@@ -138,31 +142,17 @@ func getTraceIDFromContext(ctx context.Context) string {
 		return ""
 	}
 	
-	// Try to get trace ID from context
-	if traceID, ok := ctx.Value("trace_id").(string); ok {
-		return traceID
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return ""
 	}
-	
-	// Could also extract from OpenTelemetry span context here
-	// span := trace.SpanFromContext(ctx)
-	// if span.SpanContext().IsValid() {
-	//     return span.SpanContext().TraceID().String()
-	// }
-	
-	return ""
+	return spanContext.TraceID().String()
 }
 
 // getRequestIDFromContext extracts request ID from context
 func getRequestIDFromContext(ctx context.Context) string {
-	if ctx == nil {
-		return ""
-	}
-	
-	if requestID, ok := ctx.Value("request_id").(string); ok {
-		return requestID
-	}
-	
-	return ""
+	requestID, _ := ctxmeta.RequestID(ctx)
+	return requestID
 }
 
 // NoOpLogger is a logger that does nothing (useful for testing)
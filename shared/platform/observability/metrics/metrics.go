@@ -1,22 +1,49 @@
+// Package metrics records service metrics: counters, histograms and gauges.
+// NewMetrics exports them through OpenTelemetry, over OTLP to the collector
+// and as a Prometheus scrape endpoint, and keeps an in-memory copy for the
+// services' JSON /metrics endpoints.
+//
+// Every call takes the request context. Histogram observations made while a
+// sampled trace is active carry the trace as an exemplar, linking a latency
+// spike on a dashboard to the traces behind it.
 package metrics
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Metrics defines the interface for metrics collection
 type Metrics interface {
-	IncrementCounter(name string, labels map[string]string)
-	RecordValue(name string, value float64, labels map[string]string)
-	RecordDuration(name string, duration time.Duration, labels map[string]string)
-	SetGauge(name string, value float64, labels map[string]string)
+	// IncrementCounter adds one to a counter
+	IncrementCounter(ctx context.Context, name string, labels map[string]string)
+	// AddCounter adds delta, which must not be negative, to a counter
+	AddCounter(ctx context.Context, name string, delta int64, labels map[string]string)
+	// RecordValue records a value in a histogram
+	RecordValue(ctx context.Context, name string, value float64, labels map[string]string)
+	// RecordDuration records a duration in seconds in a latency histogram
+	RecordDuration(ctx context.Context, name string, duration time.Duration, labels map[string]string)
+	// SetGauge sets the current value of a gauge
+	SetGauge(ctx context.Context, name string, value float64, labels map[string]string)
 }
 
+// Histogram bucket upper bounds
+var (
+	// DefaultBuckets suit values recorded with RecordValue
+	DefaultBuckets = []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+	// LatencyBuckets suit durations in seconds recorded with RecordDuration
+	LatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+)
+
 // InMemoryMetrics implements Metrics interface with in-memory storage
-// This is a simple implementation for development/testing
-// In production, you'd use Prometheus, StatsD, or other metrics systems
+// This is a simple implementation for development/testing, and backs the JSON
+// /metrics endpoints of the services next to the OpenTelemetry export
 type InMemoryMetrics struct {
 	serviceName string
 	counters    map[string]*Counter
@@ -43,75 +70,75 @@ type Gauge struct {
 
 // Histogram represents a histogram metric
 type Histogram struct {
-	Name    string            `json:"name"`
-	Help    string            `json:"help"`
-	Labels  map[string]string `json:"labels"`
-	Count   int64             `json:"count"`
-	Sum     float64           `json:"sum"`
-	Buckets map[string]int64  `json:"buckets"`
+	Name     string            `json:"name"`
+	Help     string            `json:"help"`
+	Labels   map[string]string `json:"labels"`
+	Count    int64             `json:"count"`
+	Sum      float64           `json:"sum"`
+	Buckets  map[string]int64  `json:"buckets"` // Cumulative counts keyed by upper bound ("le")
+	Exemplar *Exemplar         `json:"exemplar,omitempty"`
+
+	bounds []float64
 }
 
-// NewMetrics creates a new metrics instance
-func NewMetrics(serviceName string) (Metrics, error) {
+// Exemplar links a histogram observation to the trace it was made in
+type Exemplar struct {
+	TraceID   string    `json:"trace_id"`
+	SpanID    string    `json:"span_id"`
+	Value     float64   `json:"value"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewInMemoryMetrics creates a metrics instance keeping metrics in memory only
+func NewInMemoryMetrics(serviceName string) *InMemoryMetrics {
 	return &InMemoryMetrics{
 		serviceName: serviceName,
 		counters:    make(map[string]*Counter),
 		gauges:      make(map[string]*Gauge),
 		histograms:  make(map[string]*Histogram),
-	}, nil
+	}
 }
 
 // IncrementCounter increments a counter metric
-func (m *InMemoryMetrics) IncrementCounter(name string, labels map[string]string) {
+func (m *InMemoryMetrics) IncrementCounter(ctx context.Context, name string, labels map[string]string) {
+	m.AddCounter(ctx, name, 1, labels)
+}
+
+// AddCounter adds delta to a counter metric
+func (m *InMemoryMetrics) AddCounter(ctx context.Context, name string, delta int64, labels map[string]string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	
+
 	key := m.metricKey(name, labels)
-	
+
 	if counter, exists := m.counters[key]; exists {
-		counter.Value++
+		counter.Value += delta
 	} else {
 		m.counters[key] = &Counter{
 			Name:   name,
 			Labels: m.copyLabels(labels),
-			Value:  1,
+			Value:  delta,
 		}
 	}
 }
 
 // RecordValue records a value for a histogram metric
-func (m *InMemoryMetrics) RecordValue(name string, value float64, labels map[string]string) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	
-	key := m.metricKey(name, labels)
-	
-	if histogram, exists := m.histograms[key]; exists {
-		histogram.Count++
-		histogram.Sum += value
-	} else {
-		m.histograms[key] = &Histogram{
-			Name:    name,
-			Labels:  m.copyLabels(labels),
-			Count:   1,
-			Sum:     value,
-			Buckets: make(map[string]int64),
-		}
-	}
+func (m *InMemoryMetrics) RecordValue(ctx context.Context, name string, value float64, labels map[string]string) {
+	m.observe(ctx, name, value, labels, DefaultBuckets)
 }
 
 // RecordDuration records a duration for a histogram metric
-func (m *InMemoryMetrics) RecordDuration(name string, duration time.Duration, labels map[string]string) {
-	m.RecordValue(name, duration.Seconds(), labels)
+func (m *InMemoryMetrics) RecordDuration(ctx context.Context, name string, duration time.Duration, labels map[string]string) {
+	m.observe(ctx, name, duration.Seconds(), labels, LatencyBuckets)
 }
 
 // SetGauge sets a gauge metric value
-func (m *InMemoryMetrics) SetGauge(name string, value float64, labels map[string]string) {
+func (m *InMemoryMetrics) SetGauge(ctx context.Context, name string, value float64, labels map[string]string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	
+
 	key := m.metricKey(name, labels)
-	
+
 	m.gauges[key] = &Gauge{
 		Name:   name,
 		Labels: m.copyLabels(labels),
@@ -123,7 +150,7 @@ func (m *InMemoryMetrics) SetGauge(name string, value float64, labels map[string
 func (m *InMemoryMetrics) GetMetrics() map[string]interface{} {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	
+
 	return map[string]interface{}{
 		"service":    m.serviceName,
 		"counters":   m.copyCounters(),
@@ -134,12 +161,66 @@ func (m *InMemoryMetrics) GetMetrics() map[string]interface{} {
 
 // Helper methods
 
+// observe records a histogram value, keeping the latest sampled trace as exemplar
+func (m *InMemoryMetrics) observe(ctx context.Context, name string, value float64, labels map[string]string, bounds []float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := m.metricKey(name, labels)
+
+	histogram, exists := m.histograms[key]
+	if !exists {
+		histogram = &Histogram{
+			Name:    name,
+			Labels:  m.copyLabels(labels),
+			Buckets: make(map[string]int64, len(bounds)+1),
+			bounds:  bounds,
+		}
+		m.histograms[key] = histogram
+	}
+
+	histogram.Count++
+	histogram.Sum += value
+	for _, bound := range histogram.bounds {
+		if value <= bound {
+			histogram.Buckets[strconv.FormatFloat(bound, 'g', -1, 64)]++
+		}
+	}
+	histogram.Buckets["+Inf"]++
+
+	if exemplar := exemplarFromContext(ctx, value); exemplar != nil {
+		histogram.Exemplar = exemplar
+	}
+}
+
+// exemplarFromContext returns an exemplar for the sampled trace of ctx, if any
+func exemplarFromContext(ctx context.Context, value float64) *Exemplar {
+	if ctx == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() || !spanContext.IsSampled() {
+		return nil
+	}
+	return &Exemplar{
+		TraceID:   spanContext.TraceID().String(),
+		SpanID:    spanContext.SpanID().String(),
+		Value:     value,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
 func (m *InMemoryMetrics) metricKey(name string, labels map[string]string) string {
+	// Labels are sorted so the same set always maps to the same key
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
 	key := name
-	if labels != nil {
-		for k, v := range labels {
-			key += fmt.Sprintf("_%s_%s", k, v)
-		}
+	for _, k := range keys {
+		key += fmt.Sprintf("_%s_%s", k, labels[k])
 	}
 	return key
 }
@@ -148,7 +229,7 @@ func (m *InMemoryMetrics) copyLabels(labels map[string]string) map[string]string
 	if labels == nil {
 		return nil
 	}
-	
+
 	copy := make(map[string]string)
 	for k, v := range labels {
 		copy[k] = v
@@ -187,14 +268,19 @@ func (m *InMemoryMetrics) copyHistograms() map[string]*Histogram {
 		for bk, bv := range v.Buckets {
 			buckets[bk] = bv
 		}
-		
-		copy[k] = &Histogram{
+
+		histogram := &Histogram{
 			Name:    v.Name,
 			Labels:  m.copyLabels(v.Labels),
 			Count:   v.Count,
 			Sum:     v.Sum,
 			Buckets: buckets,
 		}
+		if v.Exemplar != nil {
+			exemplar := *v.Exemplar
+			histogram.Exemplar = &exemplar
+		}
+		copy[k] = histogram
 	}
 	return copy
 }
@@ -207,22 +293,29 @@ func NewNoOpMetrics() Metrics {
 	return &NoOpMetrics{}
 }
 
-func (n *NoOpMetrics) IncrementCounter(name string, labels map[string]string)                    {}
-func (n *NoOpMetrics) RecordValue(name string, value float64, labels map[string]string)         {}
-func (n *NoOpMetrics) RecordDuration(name string, duration time.Duration, labels map[string]string) {}
-func (n *NoOpMetrics) SetGauge(name string, value float64, labels map[string]string)            {}
+func (n *NoOpMetrics) IncrementCounter(ctx context.Context, name string, labels map[string]string) {}
+func (n *NoOpMetrics) AddCounter(ctx context.Context, name string, delta int64, labels map[string]string) {
+}
+func (n *NoOpMetrics) RecordValue(ctx context.Context, name string, value float64, labels map[string]string) {
+}
+func (n *NoOpMetrics) RecordDuration(ctx context.Context, name string, duration time.Duration, labels map[string]string) {
+}
+func (n *NoOpMetrics) SetGauge(ctx context.Context, name string, value float64, labels map[string]string) {
+}
 
 // Timer is a helper for timing operations
 type Timer struct {
+	ctx     context.Context
 	metrics Metrics
 	name    string
 	labels  map[string]string
 	start   time.Time
 }
 
-// StartTimer starts a new timer
-func StartTimer(metrics Metrics, name string, labels map[string]string) *Timer {
+// StartTimer starts a new timer; the duration is linked to the trace of ctx
+func StartTimer(ctx context.Context, metrics Metrics, name string, labels map[string]string) *Timer {
 	return &Timer{
+		ctx:     ctx,
 		metrics: metrics,
 		name:    name,
 		labels:  labels,
@@ -233,5 +326,5 @@ func StartTimer(metrics Metrics, name string, labels map[string]string) *Timer {
 // Stop stops the timer and records the duration
 func (t *Timer) Stop() {
 	duration := time.Since(t.start)
-	t.metrics.RecordDuration(t.name, duration, t.labels)
-}
\ No newline at end of file
+	t.metrics.RecordDuration(t.ctx, t.name, duration, t.labels)
+}
@@ -0,0 +1,275 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+
+	"github.com/amiosamu/rocket-science/shared/platform/observability/labels"
+)
+
+// Config holds configuration for exporting metrics
+type Config struct {
+	ServiceName    string
+	Labels         labels.Standard // Environment, region, tenant and version of every series
+	OTLPEndpoint   string          // Collector gRPC endpoint; empty disables OTLP export
+	ExportInterval time.Duration   // How often metrics are pushed over OTLP
+	Prometheus     bool            // Serve metrics for scraping, see Handler
+}
+
+// ConfigFromEnv reads the export configuration of a service:
+//
+//	OTEL_METRICS_ENDPOINT       collector gRPC endpoint, default OTEL_ENDPOINT
+//	METRICS_EXPORT_INTERVAL     OTLP push interval, default 15s
+//	METRICS_PROMETHEUS_ENABLED  serve the Prometheus scrape endpoint, default true
+//
+// and the standard labels, see labels.FromEnv.
+func ConfigFromEnv(serviceName string) (Config, error) {
+	config := Config{
+		ServiceName:    serviceName,
+		Labels:         labels.FromEnv(),
+		OTLPEndpoint:   os.Getenv("OTEL_METRICS_ENDPOINT"),
+		ExportInterval: 15 * time.Second,
+		Prometheus:     true,
+	}
+	if config.OTLPEndpoint == "" {
+		config.OTLPEndpoint = os.Getenv("OTEL_ENDPOINT")
+	}
+
+	if value := os.Getenv("METRICS_EXPORT_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return Config{}, fmt.Errorf("invalid METRICS_EXPORT_INTERVAL %q", value)
+		}
+		config.ExportInterval = interval
+	}
+	if value := os.Getenv("METRICS_PROMETHEUS_ENABLED"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid METRICS_PROMETHEUS_ENABLED %q", value)
+		}
+		config.Prometheus = enabled
+	}
+	return config, nil
+}
+
+// NewMetrics creates the metrics of a service, exported as configured in the
+// environment (see ConfigFromEnv)
+func NewMetrics(serviceName string) (Metrics, error) {
+	config, err := ConfigFromEnv(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return NewOTelMetrics(config)
+}
+
+// OTelMetrics implements Metrics with OpenTelemetry instruments. Metrics are
+// also kept in memory, so GetMetrics keeps serving the JSON endpoints.
+type OTelMetrics struct {
+	*InMemoryMetrics
+
+	provider *sdkmetric.MeterProvider
+	meter    metric.Meter
+	handler  http.Handler // Prometheus scrape endpoint, nil when disabled
+
+	mu         sync.Mutex
+	counters   map[string]metric.Int64Counter
+	histograms map[string]metric.Float64Histogram
+	gauges     map[string]metric.Float64Gauge
+}
+
+// NewOTelMetrics creates metrics exported over OTLP and to Prometheus
+func NewOTelMetrics(config Config) (*OTelMetrics, error) {
+	res, err := resource.Merge(
+		resource.Default(),
+		// Schemaless, so the schema of resource.Default never conflicts
+		resource.NewSchemaless(append(config.Labels.Attributes(),
+			semconv.ServiceNameKey.String(config.ServiceName),
+			attribute.String("service.namespace", "rocket-science"),
+		)...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	// Histogram observations made in a sampled trace keep it as an exemplar
+	opts := []sdkmetric.Option{
+		sdkmetric.WithResource(res),
+		sdkmetric.WithExemplarFilter(exemplar.TraceBasedFilter),
+	}
+
+	m := &OTelMetrics{
+		InMemoryMetrics: NewInMemoryMetrics(config.ServiceName),
+		counters:        make(map[string]metric.Int64Counter),
+		histograms:      make(map[string]metric.Float64Histogram),
+		gauges:          make(map[string]metric.Float64Gauge),
+	}
+
+	if config.OTLPEndpoint != "" {
+		exporter, err := otlpmetricgrpc.New(context.Background(),
+			otlpmetricgrpc.WithEndpoint(trimScheme(config.OTLPEndpoint)),
+			otlpmetricgrpc.WithInsecure(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+		}
+		opts = append(opts, sdkmetric.WithReader(
+			sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(config.ExportInterval)),
+		))
+	}
+
+	if config.Prometheus {
+		// A registry per instance keeps several instances in one process apart
+		registry := prometheus.NewRegistry()
+		// Scraped series carry the standard labels themselves, not only target_info
+		exporter, err := otelprometheus.New(
+			otelprometheus.WithRegisterer(registry),
+			otelprometheus.WithResourceAsConstantLabels(labels.Filter),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Prometheus exporter: %w", err)
+		}
+		opts = append(opts, sdkmetric.WithReader(exporter))
+		// Exemplars are only exposed in the OpenMetrics format
+		m.handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
+	}
+
+	m.provider = sdkmetric.NewMeterProvider(opts...)
+	m.meter = m.provider.Meter("github.com/amiosamu/rocket-science/" + config.ServiceName)
+	return m, nil
+}
+
+// IncrementCounter increments a counter metric
+func (m *OTelMetrics) IncrementCounter(ctx context.Context, name string, labels map[string]string) {
+	m.AddCounter(ctx, name, 1, labels)
+}
+
+// AddCounter adds delta to a counter metric
+func (m *OTelMetrics) AddCounter(ctx context.Context, name string, delta int64, labels map[string]string) {
+	m.InMemoryMetrics.AddCounter(ctx, name, delta, labels)
+
+	m.mu.Lock()
+	counter, ok := m.counters[name]
+	if !ok {
+		counter, _ = m.meter.Int64Counter(name)
+		m.counters[name] = counter
+	}
+	m.mu.Unlock()
+
+	counter.Add(contextOrBackground(ctx), delta, metric.WithAttributes(attributes(labels)...))
+}
+
+// RecordValue records a value for a histogram metric
+func (m *OTelMetrics) RecordValue(ctx context.Context, name string, value float64, labels map[string]string) {
+	m.InMemoryMetrics.RecordValue(ctx, name, value, labels)
+	m.histogram(name, "", DefaultBuckets).Record(contextOrBackground(ctx), value, metric.WithAttributes(attributes(labels)...))
+}
+
+// RecordDuration records a duration in seconds for a histogram metric
+func (m *OTelMetrics) RecordDuration(ctx context.Context, name string, duration time.Duration, labels map[string]string) {
+	m.InMemoryMetrics.RecordDuration(ctx, name, duration, labels)
+	m.histogram(name, "s", LatencyBuckets).Record(contextOrBackground(ctx), duration.Seconds(), metric.WithAttributes(attributes(labels)...))
+}
+
+// SetGauge sets a gauge metric value
+func (m *OTelMetrics) SetGauge(ctx context.Context, name string, value float64, labels map[string]string) {
+	m.InMemoryMetrics.SetGauge(ctx, name, value, labels)
+
+	m.mu.Lock()
+	gauge, ok := m.gauges[name]
+	if !ok {
+		gauge, _ = m.meter.Float64Gauge(name)
+		m.gauges[name] = gauge
+	}
+	m.mu.Unlock()
+
+	gauge.Record(contextOrBackground(ctx), value, metric.WithAttributes(attributes(labels)...))
+}
+
+// Handler serves the metrics for Prometheus to scrape, or nil when disabled
+func (m *OTelMetrics) Handler() http.Handler {
+	return m.handler
+}
+
+// Close flushes pending exports and shuts the exporters down
+func (m *OTelMetrics) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return m.provider.Shutdown(ctx)
+}
+
+// histogram returns the histogram instrument of name, creating it with the
+// bucket bounds on first use
+func (m *OTelMetrics) histogram(name, unit string, bounds []float64) metric.Float64Histogram {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	histogram, ok := m.histograms[name]
+	if !ok {
+		histogram, _ = m.meter.Float64Histogram(name,
+			metric.WithUnit(unit),
+			metric.WithExplicitBucketBoundaries(bounds...),
+		)
+		m.histograms[name] = histogram
+	}
+	return histogram
+}
+
+// Handler returns the Prometheus scrape endpoint of m, answering 404 Not Found
+// when m does not export to Prometheus
+func Handler(m Metrics) http.Handler {
+	if exporter, ok := m.(interface{ Handler() http.Handler }); ok {
+		if handler := exporter.Handler(); handler != nil {
+			return handler
+		}
+	}
+	return http.NotFoundHandler()
+}
+
+// Close shuts down the exporters of m, if any
+func Close(m Metrics) error {
+	if closer, ok := m.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func attributes(labels map[string]string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(labels))
+	for k, v := range labels {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return attrs
+}
+
+func contextOrBackground(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
+// trimScheme turns an endpoint URL such as http://otel-collector:4317 into the
+// host:port the OTLP exporter expects
+func trimScheme(endpoint string) string {
+	if _, rest, ok := strings.Cut(endpoint, "://"); ok {
+		return rest
+	}
+	return endpoint
+}
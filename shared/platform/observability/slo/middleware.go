@@ -0,0 +1,72 @@
+package slo
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HTTPMiddleware records every request against the SLO set. endpoint names
+// the route a request was served by; it is called after the handler so
+// routers can report the matched pattern instead of the raw path, keeping
+// the endpoint label bounded.
+func HTTPMiddleware(set *Set, endpoint func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+
+			next.ServeHTTP(recorder, r)
+
+			set.Observe(r.Context(), endpoint(r), time.Since(start), recorder.statusCode >= http.StatusInternalServerError)
+		})
+	}
+}
+
+// statusRecorder captures the status code a handler wrote
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Flush lets streaming handlers flush through the recorder
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// UnaryServerInterceptor records every unary call against the SLO set, with
+// the full method name as the endpoint
+func UnaryServerInterceptor(set *Set) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		set.Observe(ctx, info.FullMethod, time.Since(start), ServerFailure(err))
+		return resp, err
+	}
+}
+
+// ServerFailure reports whether a gRPC error counts against availability.
+// Errors caused by the caller, such as invalid arguments or missing
+// permissions, do not.
+func ServerFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.DeadlineExceeded, codes.Unimplemented:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,164 @@
+package slo
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BurnRateAlert pairs a long and a short window: it fires when both burn the
+// error budget fast enough to consume BudgetConsumed of it within Long. The
+// short window makes the alert reset soon after the burn stops.
+type BurnRateAlert struct {
+	Long           time.Duration
+	Short          time.Duration
+	BudgetConsumed float64 // Share of the whole error budget, e.g. 0.02
+	Severity       string  // "page" or "ticket"
+}
+
+// Factor is the burn rate, relative to spending the budget evenly over the
+// objective window, at which the alert fires
+func (a BurnRateAlert) Factor(window time.Duration) float64 {
+	return a.BudgetConsumed * float64(window) / float64(a.Long)
+}
+
+// DefaultBurnRateAlerts are the multi-window, multi-burn-rate alerts from the
+// SRE workbook. Over 30 days they fire at burn rates of 14.4, 6, 3 and 1.
+var DefaultBurnRateAlerts = []BurnRateAlert{
+	{Long: time.Hour, Short: 5 * time.Minute, BudgetConsumed: 0.02, Severity: "page"},
+	{Long: 6 * time.Hour, Short: 30 * time.Minute, BudgetConsumed: 0.05, Severity: "page"},
+	{Long: 24 * time.Hour, Short: 2 * time.Hour, BudgetConsumed: 0.10, Severity: "ticket"},
+	{Long: 72 * time.Hour, Short: 6 * time.Hour, BudgetConsumed: 0.10, Severity: "ticket"},
+}
+
+// RuleFile is a Prometheus rule file
+type RuleFile struct {
+	Groups []RuleGroup `yaml:"groups"`
+}
+
+// RuleGroup is a group of Prometheus rules
+type RuleGroup struct {
+	Name  string `yaml:"name"`
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule is a Prometheus recording or alerting rule
+type Rule struct {
+	Record      string            `yaml:"record,omitempty"`
+	Alert       string            `yaml:"alert,omitempty"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// PrometheusRules generates, for every objective, recording rules of its
+// error ratio over each alert window and the burn-rate alerts on them
+func (s *Set) PrometheusRules(alerts []BurnRateAlert) RuleFile {
+	var file RuleFile
+	for _, objective := range s.objectives {
+		group := RuleGroup{Name: fmt.Sprintf("slo:%s:%s", s.service, objective.Name)}
+		selector := fmt.Sprintf(`service=%q, slo=%q`, s.service, objective.Name)
+
+		for _, window := range alertWindows(alerts) {
+			bad := fmt.Sprintf("sum(rate(%s{%s}[%s]))", BadRequestsMetric, selector, promDuration(window))
+			good := fmt.Sprintf("sum(rate(%s{%s}[%s]))", GoodRequestsMetric, selector, promDuration(window))
+			group.Rules = append(group.Rules, Rule{
+				Record: errorRatioRecord(window),
+				Expr:   fmt.Sprintf("%s / (%s + %s)", bad, good, bad),
+				Labels: map[string]string{"service": s.service, "slo": objective.Name},
+			})
+		}
+
+		for _, alert := range alerts {
+			threshold := strconv.FormatFloat(alert.Factor(objective.Window)*objective.ErrorBudget(), 'g', 6, 64)
+			group.Rules = append(group.Rules, Rule{
+				Alert: "SLOErrorBudgetBurn",
+				Expr: fmt.Sprintf("%s{%s} > %s and %s{%s} > %s",
+					errorRatioRecord(alert.Long), selector, threshold,
+					errorRatioRecord(alert.Short), selector, threshold),
+				Labels: map[string]string{
+					"service":     s.service,
+					"slo":         objective.Name,
+					"severity":    alert.Severity,
+					"long_window": promDuration(alert.Long),
+				},
+				Annotations: map[string]string{
+					"summary": fmt.Sprintf("%s is burning the error budget of SLO %s", s.service, objective.Name),
+					"description": fmt.Sprintf("%s SLO %s (%s%% %s on %s) is burning its %s error budget at over %.1fx over %s and %s.",
+						s.service, objective.Name, strconv.FormatFloat(objective.Target*100, 'f', -1, 64), objective.Kind,
+						endpointName(objective.Endpoint), promDuration(objective.Window),
+						alert.Factor(objective.Window), promDuration(alert.Long), promDuration(alert.Short)),
+				},
+			})
+		}
+
+		file.Groups = append(file.Groups, group)
+	}
+	return file
+}
+
+// PrometheusRulesYAML renders the rules of the default burn-rate alerts as a
+// Prometheus rule file
+func (s *Set) PrometheusRulesYAML() ([]byte, error) {
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(s.PrometheusRules(DefaultBurnRateAlerts)); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// alertWindows returns the distinct windows of the alerts, shortest first
+func alertWindows(alerts []BurnRateAlert) []time.Duration {
+	seen := make(map[time.Duration]bool)
+	var windows []time.Duration
+	for _, alert := range alerts {
+		for _, window := range []time.Duration{alert.Long, alert.Short} {
+			if !seen[window] {
+				seen[window] = true
+				windows = append(windows, window)
+			}
+		}
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+	return windows
+}
+
+func errorRatioRecord(window time.Duration) string {
+	return "slo:error_ratio:rate" + promDuration(window)
+}
+
+func endpointName(endpoint string) string {
+	if endpoint == "" {
+		return "all endpoints"
+	}
+	return endpoint
+}
+
+// promDuration formats a duration in the largest whole Prometheus unit, e.g. 3d or 30m
+func promDuration(d time.Duration) string {
+	units := []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+	for _, unit := range units {
+		if d >= unit.size && d%unit.size == 0 {
+			return strconv.FormatInt(int64(d/unit.size), 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+}
@@ -0,0 +1,166 @@
+// Package slo lets a service declare service level objectives for its
+// endpoints and records the good and bad request counters that burn-rate
+// alerts are computed from.
+//
+// An availability objective counts a request as bad when it fails on the
+// server side (HTTP 5xx, gRPC Internal, Unavailable and the like); a latency
+// objective counts it as bad when it takes longer than the threshold. Every
+// observed request increments, per objective of its endpoint:
+//
+//	slo_requests_good_total{service, slo, endpoint}
+//	slo_requests_bad_total{service, slo, endpoint}
+//
+// The matching Prometheus recording rules and multi-window burn-rate alerts
+// are generated from the same declarations by PrometheusRules.
+package slo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Metric names of the SLO counters
+const (
+	GoodRequestsMetric = "slo_requests_good_total"
+	BadRequestsMetric  = "slo_requests_bad_total"
+)
+
+// DefaultWindow is the compliance period of objectives that don't set one
+const DefaultWindow = 30 * 24 * time.Hour
+
+// Kind is what an objective measures
+type Kind string
+
+const (
+	KindAvailability Kind = "availability"
+	KindLatency      Kind = "latency"
+)
+
+// Objective is a target share of good requests for an endpoint
+type Objective struct {
+	Name      string // Unique within the service, e.g. "create-order-latency"
+	Endpoint  string // HTTP route pattern or full gRPC method; empty matches every endpoint
+	Kind      Kind
+	Target    float64       // Share of good requests, e.g. 0.999
+	Threshold time.Duration // Latency objectives: requests slower than this are bad
+	Window    time.Duration // Compliance period; DefaultWindow when zero
+}
+
+// Validate checks the objective is complete
+func (o Objective) Validate() error {
+	if o.Name == "" {
+		return fmt.Errorf("SLO name is required")
+	}
+	if o.Target <= 0 || o.Target >= 1 {
+		return fmt.Errorf("SLO %s: target must be between 0 and 1 exclusive, got %v", o.Name, o.Target)
+	}
+	if o.Window < 0 {
+		return fmt.Errorf("SLO %s: window cannot be negative", o.Name)
+	}
+	switch o.Kind {
+	case KindAvailability:
+	case KindLatency:
+		if o.Threshold <= 0 {
+			return fmt.Errorf("SLO %s: latency objectives need a positive threshold", o.Name)
+		}
+	default:
+		return fmt.Errorf("SLO %s: unknown kind %q", o.Name, o.Kind)
+	}
+	return nil
+}
+
+// ErrorBudget is the share of requests allowed to be bad
+func (o Objective) ErrorBudget() float64 {
+	return 1 - o.Target
+}
+
+// window returns the compliance period
+func (o Objective) window() time.Duration {
+	if o.Window == 0 {
+		return DefaultWindow
+	}
+	return o.Window
+}
+
+// good reports whether a request meets the objective
+func (o Objective) good(duration time.Duration, failed bool) bool {
+	if o.Kind == KindLatency {
+		return !failed && duration <= o.Threshold
+	}
+	return !failed
+}
+
+// Set holds the objectives of a service and records requests against them
+type Set struct {
+	service    string
+	metrics    metrics.Metrics
+	objectives []Objective
+	byEndpoint map[string][]Objective
+}
+
+// NewSet validates the objectives of a service. Names must be unique.
+func NewSet(service string, m metrics.Metrics, objectives ...Objective) (*Set, error) {
+	s := &Set{
+		service:    service,
+		metrics:    m,
+		byEndpoint: make(map[string][]Objective),
+	}
+
+	names := make(map[string]bool, len(objectives))
+	for _, objective := range objectives {
+		if err := objective.Validate(); err != nil {
+			return nil, err
+		}
+		if names[objective.Name] {
+			return nil, fmt.Errorf("SLO %s is declared twice", objective.Name)
+		}
+		names[objective.Name] = true
+
+		objective.Window = objective.window()
+		s.objectives = append(s.objectives, objective)
+		s.byEndpoint[objective.Endpoint] = append(s.byEndpoint[objective.Endpoint], objective)
+	}
+	sort.Slice(s.objectives, func(i, j int) bool { return s.objectives[i].Name < s.objectives[j].Name })
+
+	return s, nil
+}
+
+// Service is the name the counters are labelled with
+func (s *Set) Service() string {
+	return s.service
+}
+
+// Objectives returns the declared objectives, sorted by name
+func (s *Set) Objectives() []Objective {
+	return append([]Objective(nil), s.objectives...)
+}
+
+// Observe records a finished request against the objectives of its endpoint
+// and those covering every endpoint. failed marks server-side failures.
+func (s *Set) Observe(ctx context.Context, endpoint string, duration time.Duration, failed bool) {
+	if s == nil || s.metrics == nil {
+		return
+	}
+	s.observe(ctx, s.byEndpoint[endpoint], endpoint, duration, failed)
+	if endpoint != "" {
+		s.observe(ctx, s.byEndpoint[""], endpoint, duration, failed)
+	}
+}
+
+func (s *Set) observe(ctx context.Context, objectives []Objective, endpoint string, duration time.Duration, failed bool) {
+	for _, objective := range objectives {
+		metric := BadRequestsMetric
+		if objective.good(duration, failed) {
+			metric = GoodRequestsMetric
+		}
+		s.metrics.IncrementCounter(ctx, metric, map[string]string{
+			"service":  s.service,
+			"slo":      objective.Name,
+			"endpoint": endpoint,
+		})
+	}
+}
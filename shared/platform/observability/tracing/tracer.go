@@ -16,6 +16,8 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/amiosamu/rocket-science/shared/platform/observability/labels"
 )
 
 // Tracer wraps OpenTelemetry functionality
@@ -33,23 +35,25 @@ type OTelTracer struct {
 
 // TracerConfig holds configuration for tracing
 type TracerConfig struct {
-	ServiceName    string
-	ServiceVersion string
-	Environment    string
-	OTELEndpoint   string
-	SamplingRatio  float64
-	Enabled        bool
+	ServiceName   string
+	Labels        labels.Standard // Environment, region, tenant and version of every span
+	OTELEndpoint  string
+	SamplingRatio float64
+	Enabled       bool
 }
 
-// NewTracer creates a new OpenTelemetry tracer
+// NewTracer creates a new OpenTelemetry tracer carrying the standard labels of
+// the environment (see labels.FromEnv); a non-empty serviceVersion overrides theirs
 func NewTracer(serviceName, serviceVersion, otelEndpoint string) (Tracer, error) {
 	config := TracerConfig{
-		ServiceName:    serviceName,
-		ServiceVersion: serviceVersion,
-		Environment:    "development", // Could be configurable
-		OTELEndpoint:   otelEndpoint,
-		SamplingRatio:  1.0, // Sample all traces in development
-		Enabled:        otelEndpoint != "",
+		ServiceName:   serviceName,
+		Labels:        labels.FromEnv(),
+		OTELEndpoint:  otelEndpoint,
+		SamplingRatio: 1.0, // Sample all traces in development
+		Enabled:       otelEndpoint != "",
+	}
+	if serviceVersion != "" {
+		config.Labels.ServiceVersion = serviceVersion
 	}
 
 	return NewTracerWithConfig(config)
@@ -146,10 +150,10 @@ func createResource(config TracerConfig) (*resource.Resource, error) {
 		resource.Default(),
 		resource.NewWithAttributes(
 			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(config.ServiceName),
-			semconv.ServiceVersionKey.String(config.ServiceVersion),
-			semconv.DeploymentEnvironmentKey.String(config.Environment),
-			attribute.String("service.namespace", "rocket-science"),
+			append(config.Labels.Attributes(),
+				semconv.ServiceNameKey.String(config.ServiceName),
+				attribute.String("service.namespace", "rocket-science"),
+			)...,
 		),
 	)
 }
@@ -0,0 +1,94 @@
+package purge
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrDisabled is returned for purges outside the environments that allow them
+var ErrDisabled = errors.New("test data purge is disabled in this environment")
+
+// DefaultAllowedEnvironments are the environments a purge may run in unless
+// configured otherwise
+var DefaultAllowedEnvironments = []string{"development", "demo", "test"}
+
+// productionEnvironments can never allow purges, whatever the configuration
+var productionEnvironments = []string{"production", "prod"}
+
+// Gate decides whether purges may run in the environment of a service. Both
+// the flag and the environment must allow them, so a demo configuration copied
+// to production stays harmless.
+type Gate struct {
+	Enabled             bool
+	Environment         string
+	AllowedEnvironments []string
+}
+
+// GateFromEnv reads the gate from the environment:
+//
+//	PURGE_ENABLED               true allows purges, default false
+//	ENVIRONMENT                 environment of the service, default development
+//	PURGE_ALLOWED_ENVIRONMENTS  comma separated, default development,demo,test
+func GateFromEnv() (Gate, error) {
+	gate := Gate{
+		Environment:         strings.TrimSpace(os.Getenv("ENVIRONMENT")),
+		AllowedEnvironments: DefaultAllowedEnvironments,
+	}
+	if gate.Environment == "" {
+		gate.Environment = "development"
+	}
+
+	if value := os.Getenv("PURGE_ENABLED"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return Gate{}, fmt.Errorf("invalid PURGE_ENABLED %q: %w", value, err)
+		}
+		gate.Enabled = enabled
+	}
+
+	if value := os.Getenv("PURGE_ALLOWED_ENVIRONMENTS"); value != "" {
+		gate.AllowedEnvironments = nil
+		for _, environment := range strings.Split(value, ",") {
+			if environment = strings.TrimSpace(environment); environment != "" {
+				gate.AllowedEnvironments = append(gate.AllowedEnvironments, environment)
+			}
+		}
+	}
+
+	return gate, gate.Validate()
+}
+
+// Validate rejects configurations allowing purges in production
+func (g Gate) Validate() error {
+	for _, environment := range g.AllowedEnvironments {
+		if isProduction(environment) {
+			return fmt.Errorf("test data purges cannot be allowed in the %s environment", environment)
+		}
+	}
+	return nil
+}
+
+// Check returns ErrDisabled unless purges may run
+func (g Gate) Check() error {
+	if !g.Enabled || isProduction(g.Environment) {
+		return ErrDisabled
+	}
+	for _, environment := range g.AllowedEnvironments {
+		if strings.EqualFold(environment, g.Environment) {
+			return nil
+		}
+	}
+	return ErrDisabled
+}
+
+func isProduction(environment string) bool {
+	for _, production := range productionEnvironments {
+		if strings.EqualFold(strings.TrimSpace(environment), production) {
+			return true
+		}
+	}
+	return false
+}
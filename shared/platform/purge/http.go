@@ -0,0 +1,131 @@
+package purge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/platform/httpclient"
+)
+
+// maxRequestSize bounds the body of a purge request
+const maxRequestSize = 2 << 20
+
+// Handler serves the purge endpoint of a participating service. Requests are
+// refused with 403 Forbidden unless the gate allows purges; the route is
+// expected behind the admin authentication of the service.
+func Handler(gate Gate, participant Participant) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+		if err := gate.Check(); err != nil {
+			writeJSON(w, http.StatusForbidden, map[string]string{"error": err.Error()})
+			return
+		}
+
+		var scope Scope
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestSize)).Decode(&scope); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid purge request: " + err.Error()})
+			return
+		}
+		if err := scope.Validate(); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		result, err := participant.Purge(r.Context(), scope)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	})
+}
+
+// Client is the Participant of a remote service, calling its purge endpoint
+type Client struct {
+	service string
+	url     string
+	token   string
+	http    *httpclient.Client
+}
+
+// NewClient creates a participant calling the purge endpoint of the service at
+// baseURL, e.g. its health server, with the admin bearer token when set.
+// Purges are idempotent, so failed requests are retried.
+func NewClient(service, baseURL, token string, timeout time.Duration) (*Client, error) {
+	cfg := httpclient.DefaultConfig("purge-" + service)
+	cfg.Timeout = timeout
+	cfg.RetryNonIdempotent = true
+	client, err := httpclient.New(cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		service: service,
+		url:     strings.TrimSuffix(baseURL, "/") + Path,
+		token:   token,
+		http:    client,
+	}, nil
+}
+
+// Purge implements Participant
+func (c *Client) Purge(ctx context.Context, scope Scope) (*Result, error) {
+	body, err := json.Marshal(scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode purge request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create purge request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s purge request failed: %w", c.service, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&failure)
+		if resp.StatusCode == http.StatusForbidden && failure.Error == ErrDisabled.Error() {
+			return nil, fmt.Errorf("%s: %w", c.service, ErrDisabled)
+		}
+		return nil, fmt.Errorf("%s purge failed with status %d: %s", c.service, resp.StatusCode, failure.Error)
+	}
+
+	var result Result
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode %s purge result: %w", c.service, err)
+	}
+	return &result, nil
+}
+
+// IsDisabled reports whether err is a purge refused by the environment gate
+func IsDisabled(err error) bool {
+	return errors.Is(err, ErrDisabled)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,79 @@
+// Package purge resets demo and test environments by deleting the data of test
+// users across the services. iam-service selects the users and runs the purge
+// as a saga over the participating services, each of which deletes its share
+// of the data behind an admin endpoint:
+//
+//	POST /admin/test-data/purge  {"user_ids": [...], "order_ids": [...], "dry_run": false}
+//
+// Purges only run where the environment Gate allows them, which is never the
+// case in production.
+package purge
+
+import (
+	"context"
+	"fmt"
+)
+
+// Path is the admin endpoint path of a participating service
+const Path = "/admin/test-data/purge"
+
+// MaxScopeSize bounds the users and orders of a single purge request
+const MaxScopeSize = 10000
+
+// Scope selects the data a purge deletes
+type Scope struct {
+	UserIDs  []string `json:"user_ids"`
+	OrderIDs []string `json:"order_ids,omitempty"` // Orders of the users, found by the discovery step
+	DryRun   bool     `json:"dry_run,omitempty"`   // Count what would be deleted without deleting it
+}
+
+// Validate checks that the scope selects something and is not too large
+func (s Scope) Validate() error {
+	if len(s.UserIDs) == 0 && len(s.OrderIDs) == 0 {
+		return fmt.Errorf("purge scope must select at least one user or order")
+	}
+	if len(s.UserIDs) > MaxScopeSize || len(s.OrderIDs) > MaxScopeSize {
+		return fmt.Errorf("purge scope is limited to %d users and %d orders", MaxScopeSize, MaxScopeSize)
+	}
+	for _, id := range append(append([]string{}, s.UserIDs...), s.OrderIDs...) {
+		if id == "" {
+			return fmt.Errorf("purge scope contains an empty ID")
+		}
+	}
+	return nil
+}
+
+// Result reports what a participant deleted, or would delete on a dry run
+type Result struct {
+	Service string           `json:"service"`
+	DryRun  bool             `json:"dry_run,omitempty"`
+	Deleted map[string]int64 `json:"deleted"` // Records per kind, e.g. "orders" or "sessions"
+
+	// OrderIDs are the orders of the scope's users, reported by order-service
+	// so the steps keyed by order can find their data
+	OrderIDs []string `json:"order_ids,omitempty"`
+}
+
+// NewResult creates an empty result of service for scope
+func NewResult(service string, scope Scope) *Result {
+	return &Result{
+		Service: service,
+		DryRun:  scope.DryRun,
+		Deleted: make(map[string]int64),
+	}
+}
+
+// Participant deletes a service's share of the test data. Purges must be
+// idempotent: data already deleted is skipped, so a failed saga can be run
+// again from the start.
+type Participant interface {
+	Purge(ctx context.Context, scope Scope) (*Result, error)
+}
+
+// ParticipantFunc adapts a function to a Participant
+type ParticipantFunc func(ctx context.Context, scope Scope) (*Result, error)
+
+// Purge implements Participant
+func (f ParticipantFunc) Purge(ctx context.Context, scope Scope) (*Result, error) {
+	return f(ctx, scope)
+}
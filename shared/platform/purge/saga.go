@@ -0,0 +1,150 @@
+package purge
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Step states reported by a saga run
+const (
+	StepSucceeded = "succeeded"
+	StepFailed    = "failed"
+	StepSkipped   = "skipped" // Not run because an earlier step failed
+)
+
+// Step is one participant of the purge saga
+type Step struct {
+	Name        string
+	Participant Participant
+
+	// Discover runs the step as a dry run before anything is deleted, adding
+	// the order IDs it reports to the scope of every step
+	Discover bool
+}
+
+// StepReport is the outcome of a step
+type StepReport struct {
+	Name       string     `json:"name"`
+	State      string     `json:"state"`
+	Result     *Result    `json:"result,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Report is the outcome of a saga run
+type Report struct {
+	Scope     Scope        `json:"scope"`
+	Discovery []StepReport `json:"discovery,omitempty"`
+	Steps     []StepReport `json:"steps"`
+	Completed bool         `json:"completed"`
+}
+
+// Saga deletes the data of a scope across the participants, one step after
+// the other. Deleted data cannot be restored, so a failed step is not
+// compensated: the run stops and the saga is recovered forward by running it
+// again, which skips what is already gone. Steps are ordered so data is
+// deleted before the data it refers to, e.g. payments before their orders and
+// orders before their users; a new run then still finds everything left.
+type Saga struct {
+	steps []Step
+}
+
+// NewSaga creates a saga running the discovery steps and then the purge steps
+// in the given order
+func NewSaga(steps ...Step) *Saga {
+	return &Saga{steps: steps}
+}
+
+// Steps returns the number of steps a run reports progress over
+func (s *Saga) Steps() int {
+	return len(s.steps)
+}
+
+// Run runs the saga for scope, calling progress after every step with the
+// number of steps done. It returns the report with an error naming the step
+// that failed.
+func (s *Saga) Run(ctx context.Context, scope Scope, progress func(done, total int)) (*Report, error) {
+	report := &Report{Scope: scope}
+	total := len(s.steps)
+	done := 0
+
+	// Discovery completes the scope before any step deletes the data it comes from
+	for _, step := range s.steps {
+		if !step.Discover {
+			continue
+		}
+		discoveryScope := scope
+		discoveryScope.DryRun = true
+		stepReport, result, err := runStep(ctx, step, discoveryScope)
+		report.Discovery = append(report.Discovery, stepReport)
+		if err != nil {
+			report.Steps = skippedSteps(s.steps)
+			return report, fmt.Errorf("purge discovery step %s failed: %w", step.Name, err)
+		}
+		scope.OrderIDs = mergeIDs(scope.OrderIDs, result.OrderIDs)
+	}
+	report.Scope = scope
+
+	for i, step := range s.steps {
+		stepReport, _, err := runStep(ctx, step, scope)
+		report.Steps = append(report.Steps, stepReport)
+		if err != nil {
+			report.Steps = append(report.Steps, skippedSteps(s.steps[i+1:])...)
+			return report, fmt.Errorf("purge step %s failed: %w", step.Name, err)
+		}
+
+		done++
+		if progress != nil {
+			progress(done, total)
+		}
+	}
+
+	report.Completed = true
+	return report, nil
+}
+
+func runStep(ctx context.Context, step Step, scope Scope) (StepReport, *Result, error) {
+	startedAt := time.Now().UTC()
+	report := StepReport{Name: step.Name, StartedAt: &startedAt}
+
+	result, err := step.Participant.Purge(ctx, scope)
+	finishedAt := time.Now().UTC()
+	report.FinishedAt = &finishedAt
+	if err == nil && result == nil {
+		err = fmt.Errorf("participant returned no result")
+	}
+	if err != nil {
+		report.State = StepFailed
+		report.Error = err.Error()
+		return report, nil, err
+	}
+
+	report.State = StepSucceeded
+	report.Result = result
+	return report, result, nil
+}
+
+func skippedSteps(steps []Step) []StepReport {
+	reports := make([]StepReport, len(steps))
+	for i, step := range steps {
+		reports[i] = StepReport{Name: step.Name, State: StepSkipped}
+	}
+	return reports
+}
+
+// mergeIDs appends the IDs of extra not yet in ids
+func mergeIDs(ids, extra []string) []string {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	for _, id := range extra {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
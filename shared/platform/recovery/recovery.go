@@ -0,0 +1,249 @@
+// Package recovery keeps a panic in one request or message handler from taking
+// the service down. The gRPC interceptors, the HTTP middleware and the Kafka
+// handler wrapper recover the panic, report it to a Reporter and fail only the
+// call that panicked: gRPC callers get Internal, HTTP callers a 500 and Kafka
+// messages an error, so they are retried or dead-lettered like any other failure.
+//
+// Crashes are always logged; with a Sentry DSN configured they are sent to
+// Sentry as well.
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amiosamu/rocket-science/shared/platform/concurrency"
+	"github.com/amiosamu/rocket-science/shared/platform/ctxmeta"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+)
+
+// Sources of crashes, reported as the source tag
+const (
+	SourceGRPC  = "grpc"
+	SourceHTTP  = "http"
+	SourceKafka = "kafka"
+)
+
+// Crash is a panic recovered from a handler
+type Crash struct {
+	Source    string      // grpc, http or kafka
+	Operation string      // gRPC method, HTTP method and path, or Kafka topic
+	Value     interface{} // The value passed to panic
+	Stack     []byte
+	Time      time.Time
+}
+
+// Message describes the panic
+func (c *Crash) Message() string {
+	return fmt.Sprintf("panic: %v", c.Value)
+}
+
+// Fields returns the crash and the request metadata of ctx as log fields
+func (c *Crash) Fields(ctx context.Context) map[string]interface{} {
+	fields := map[string]interface{}{
+		"source":    c.Source,
+		"operation": c.Operation,
+		"panic":     fmt.Sprintf("%v", c.Value),
+		"stack":     string(c.Stack),
+	}
+	if requestID, ok := ctxmeta.RequestID(ctx); ok {
+		fields["request_id"] = requestID
+	}
+	if userID, ok := ctxmeta.UserID(ctx); ok {
+		fields["user_id"] = userID
+	}
+	return fields
+}
+
+// Reporter receives the crashes recovered by the interceptors. Report must not
+// block the failed call for long.
+type Reporter interface {
+	Report(ctx context.Context, crash *Crash)
+}
+
+// Logger writes crash entries; grpclog.FromLogger and grpclog.FromSlog adapt
+// the service loggers
+type Logger interface {
+	Log(ctx context.Context, message string, err error, fields map[string]interface{})
+}
+
+// Config selects where crashes are reported
+type Config struct {
+	// SentryDSN sends crashes to Sentry; empty only logs them
+	SentryDSN string
+
+	// Environment is reported with crashes, e.g. "production"
+	Environment string
+
+	// Timeout bounds sending one crash to Sentry
+	Timeout time.Duration
+}
+
+// FromEnv reads the configuration from the environment:
+//
+//	SENTRY_DSN            Sentry project DSN; crashes are only logged when empty
+//	SENTRY_ENVIRONMENT    environment reported with crashes, default $ENVIRONMENT
+//	CRASH_REPORT_TIMEOUT  bound on sending one crash, default 5s
+func FromEnv() (Config, error) {
+	cfg := Config{
+		SentryDSN:   os.Getenv("SENTRY_DSN"),
+		Environment: os.Getenv("SENTRY_ENVIRONMENT"),
+		Timeout:     5 * time.Second,
+	}
+	if cfg.Environment == "" {
+		cfg.Environment = os.Getenv("ENVIRONMENT")
+	}
+
+	if value := os.Getenv("CRASH_REPORT_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return Config{}, fmt.Errorf("invalid CRASH_REPORT_TIMEOUT %q: must be a positive duration", value)
+		}
+		cfg.Timeout = timeout
+	}
+
+	return cfg, nil
+}
+
+// New creates the reporter for a service: one logging crashes, which also sends
+// them to Sentry when a DSN is configured
+func New(cfg Config, service string, logger Logger) (Reporter, error) {
+	logReporter := NewLogReporter(logger)
+	if cfg.SentryDSN == "" {
+		return logReporter, nil
+	}
+
+	sentry, err := NewSentryReporter(cfg, service)
+	if err != nil {
+		return nil, err
+	}
+	return multiReporter{logReporter, sentry}, nil
+}
+
+// LogReporter logs crashes with their stack
+type LogReporter struct {
+	logger Logger
+}
+
+// NewLogReporter creates a reporter that only logs crashes
+func NewLogReporter(logger Logger) *LogReporter {
+	return &LogReporter{logger: logger}
+}
+
+// Report logs the crash
+func (r *LogReporter) Report(ctx context.Context, crash *Crash) {
+	r.logger.Log(ctx, "Handler panic recovered", fmt.Errorf("%s", crash.Message()), crash.Fields(ctx))
+}
+
+type multiReporter []Reporter
+
+func (m multiReporter) Report(ctx context.Context, crash *Crash) {
+	for _, reporter := range m {
+		reporter.Report(ctx, crash)
+	}
+}
+
+// recovered reports a recovered panic value and returns it as a crash
+func recovered(ctx context.Context, reporter Reporter, source, operation string, value interface{}) *Crash {
+	crash := &Crash{
+		Source:    source,
+		Operation: operation,
+		Value:     value,
+		Stack:     debug.Stack(),
+		Time:      time.Now().UTC(),
+	}
+	reporter.Report(ctx, crash)
+	return crash
+}
+
+// UnaryServerInterceptor fails a panicking unary call with Internal
+func UnaryServerInterceptor(reporter Reporter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if value := recover(); value != nil {
+				recovered(ctx, reporter, SourceGRPC, info.FullMethod, value)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor fails a panicking stream with Internal
+func StreamServerInterceptor(reporter Reporter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if value := recover(); value != nil {
+				recovered(stream.Context(), reporter, SourceGRPC, info.FullMethod, value)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(srv, stream)
+	}
+}
+
+// Middleware answers a panicking HTTP request with a 500. Panics with
+// http.ErrAbortHandler are passed on, as they abort the response on purpose.
+func Middleware(reporter Reporter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				value := recover()
+				if value == nil {
+					return
+				}
+				if value == http.ErrAbortHandler {
+					panic(value)
+				}
+				recovered(r.Context(), reporter, SourceHTTP, r.Method+" "+r.URL.Path, value)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"error": "Internal server error", "code": 500}`))
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Call calls fn, reporting a panic and returning it as a *concurrency.PanicError.
+// It covers handlers outside the interceptors, such as Sarama consumer group
+// handlers, whose panics would otherwise crash the service.
+func Call(ctx context.Context, reporter Reporter, source, operation string, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			crash := recovered(ctx, reporter, source, operation, value)
+			err = &concurrency.PanicError{Value: value, Stack: crash.Stack}
+		}
+	}()
+	return fn(ctx)
+}
+
+// Handler wraps a Kafka message handler so that a panic fails the message with
+// a *concurrency.PanicError instead of stopping the consumer
+func Handler(handler kafka.MessageHandler, reporter Reporter) kafka.MessageHandler {
+	return &recoveringHandler{handler: handler, reporter: reporter}
+}
+
+type recoveringHandler struct {
+	handler  kafka.MessageHandler
+	reporter Reporter
+}
+
+func (h *recoveringHandler) HandleMessage(ctx context.Context, message *kafka.Message) error {
+	return Call(ctx, h.reporter, SourceKafka, message.Topic, func(ctx context.Context) error {
+		return h.handler.HandleMessage(ctx, message)
+	})
+}
+
+func (h *recoveringHandler) GetSupportedTopics() []string {
+	return h.handler.GetSupportedTopics()
+}
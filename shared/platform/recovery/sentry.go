@@ -0,0 +1,167 @@
+package recovery
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/amiosamu/rocket-science/shared/platform/version"
+)
+
+// sentryClient identifies the reporter to Sentry
+const sentryClient = "rocket-science-recovery/1.0"
+
+// SentryReporter sends crashes to the store endpoint of a Sentry project. Crashes
+// are sent in the background, so reporting never delays the failed call.
+type SentryReporter struct {
+	endpoint    string
+	auth        string
+	service     string
+	environment string
+	serverName  string
+	timeout     time.Duration
+	client      *http.Client
+}
+
+// NewSentryReporter creates a reporter for the project of cfg.SentryDSN, which
+// has the form https://<key>@<host>/<project>
+func NewSentryReporter(cfg Config, service string) (*SentryReporter, error) {
+	dsn, err := url.Parse(cfg.SentryDSN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SENTRY_DSN: %w", err)
+	}
+	path := strings.TrimSuffix(dsn.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	if dsn.User == nil || dsn.User.Username() == "" || dsn.Host == "" || slash < 0 || path[slash+1:] == "" {
+		return nil, fmt.Errorf("invalid SENTRY_DSN: expected https://<key>@<host>/<project>")
+	}
+
+	auth := fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", sentryClient, dsn.User.Username())
+	if secret, ok := dsn.User.Password(); ok {
+		auth += ", sentry_secret=" + secret
+	}
+	serverName, _ := os.Hostname()
+
+	return &SentryReporter{
+		endpoint:    fmt.Sprintf("%s://%s%s/api/%s/store/", dsn.Scheme, dsn.Host, path[:slash], path[slash+1:]),
+		auth:        auth,
+		service:     service,
+		environment: cfg.Environment,
+		serverName:  serverName,
+		timeout:     cfg.Timeout,
+		client:      &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Report sends the crash to Sentry in the background
+func (r *SentryReporter) Report(ctx context.Context, crash *Crash) {
+	event := r.event(ctx, crash)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+		defer cancel()
+		if err := r.send(ctx, event); err != nil {
+			log.Printf("failed to report crash to Sentry: %v", err)
+		}
+	}()
+}
+
+type sentryEvent struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   string                 `json:"timestamp"`
+	Level       string                 `json:"level"`
+	Platform    string                 `json:"platform"`
+	Logger      string                 `json:"logger"`
+	Transaction string                 `json:"transaction,omitempty"`
+	ServerName  string                 `json:"server_name,omitempty"`
+	Release     string                 `json:"release,omitempty"`
+	Environment string                 `json:"environment,omitempty"`
+	Message     string                 `json:"message"`
+	Exception   sentryExceptions       `json:"exception"`
+	Tags        map[string]string      `json:"tags"`
+	Extra       map[string]interface{} `json:"extra"`
+}
+
+type sentryExceptions struct {
+	Values []sentryException `json:"values"`
+}
+
+type sentryException struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func (r *SentryReporter) event(ctx context.Context, crash *Crash) *sentryEvent {
+	info := version.Get(r.service)
+	release := info.Version
+	if info.GitCommit != "" {
+		release += "+" + info.GitCommit
+	}
+
+	extra := make(map[string]interface{})
+	for key, value := range crash.Fields(ctx) {
+		extra[key] = value
+	}
+
+	return &sentryEvent{
+		EventID:     newEventID(),
+		Timestamp:   crash.Time.Format(time.RFC3339),
+		Level:       "fatal",
+		Platform:    "go",
+		Logger:      crash.Source,
+		Transaction: crash.Operation,
+		ServerName:  r.serverName,
+		Release:     r.service + "@" + release,
+		Environment: r.environment,
+		Message:     crash.Message(),
+		Exception: sentryExceptions{Values: []sentryException{{
+			Type:  "panic",
+			Value: fmt.Sprintf("%v", crash.Value),
+		}}},
+		Tags: map[string]string{
+			"service": r.service,
+			"source":  crash.Source,
+		},
+		Extra: extra,
+	}
+}
+
+func (r *SentryReporter) send(ctx context.Context, event *sentryEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", r.auth)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry answered %s", resp.Status)
+	}
+	return nil
+}
+
+// newEventID returns a random 32 character hex ID, the format Sentry expects
+func newEventID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,154 @@
+// Package retention deletes the rows of internal bookkeeping tables, such as
+// processed-event records, delivery logs, audit trails and finished jobs, once
+// they are older than the table's time to live, so the tables don't grow
+// unbounded.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/amiosamu/rocket-science/shared/platform/observability/logging"
+	"github.com/amiosamu/rocket-science/shared/platform/observability/metrics"
+)
+
+// Config controls the periodic purge of expired rows
+type Config struct {
+	Enabled   bool          `json:"enabled"`
+	Interval  time.Duration `json:"interval"`   // How often expired rows are purged
+	BatchSize int           `json:"batch_size"` // Rows deleted per statement, keeping locks short
+}
+
+// Validate checks the interval and batch size of an enabled purge
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("retention interval must be positive")
+	}
+	if c.BatchSize < 1 {
+		return fmt.Errorf("retention batch size must be at least 1")
+	}
+	return nil
+}
+
+// Policy is the retention of one table. Rows whose TimeColumn is older than
+// the TTL are deleted, unless Condition excludes them. A zero TTL keeps the
+// rows forever.
+type Policy struct {
+	Table      string
+	TimeColumn string
+	TTL        time.Duration
+	Condition  string // SQL condition a row must also meet to be deleted, e.g. rows still in use are kept; empty for none
+}
+
+// Purger deletes the expired rows of the tables with a retention policy
+type Purger struct {
+	db       *sqlx.DB
+	policies []Policy
+	config   Config
+	logger   logging.Logger
+	metrics  metrics.Metrics
+}
+
+// NewPurger creates a purger of the policies' tables. Policies with a zero TTL
+// are skipped.
+func NewPurger(db *sqlx.DB, cfg Config, policies []Policy, logger logging.Logger, metrics metrics.Metrics) (*Purger, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	purger := &Purger{db: db, config: cfg, logger: logger, metrics: metrics}
+	for _, policy := range policies {
+		if policy.TTL < 0 {
+			return nil, fmt.Errorf("retention TTL of %s cannot be negative", policy.Table)
+		}
+		if policy.TTL > 0 {
+			purger.policies = append(purger.policies, policy)
+		}
+	}
+	return purger, nil
+}
+
+// Run purges expired rows every interval until the context is cancelled
+func (p *Purger) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.Purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge deletes the expired rows of every table and returns how many were
+// deleted per table. A table failing to purge is logged and does not hold up
+// the others.
+func (p *Purger) Purge(ctx context.Context) map[string]int64 {
+	purged := make(map[string]int64, len(p.policies))
+	for _, policy := range p.policies {
+		if ctx.Err() != nil {
+			break
+		}
+
+		deleted, err := p.purgeTable(ctx, policy)
+		purged[policy.Table] = deleted
+		if deleted > 0 {
+			p.metrics.AddCounter(ctx, "retention_rows_purged_total", deleted, map[string]string{
+				"table": policy.Table,
+			})
+			p.logger.Info(ctx, "Expired rows purged", map[string]interface{}{
+				"table":   policy.Table,
+				"deleted": deleted,
+				"ttl":     policy.TTL.String(),
+			})
+		}
+		if err != nil && ctx.Err() == nil {
+			p.metrics.IncrementCounter(ctx, "retention_purge_failures_total", map[string]string{
+				"table": policy.Table,
+			})
+			p.logger.Error(ctx, "Failed to purge expired rows", err, map[string]interface{}{
+				"table": policy.Table,
+			})
+		}
+	}
+	return purged
+}
+
+// purgeTable deletes the table's expired rows in batches until none are left
+func (p *Purger) purgeTable(ctx context.Context, policy Policy) (int64, error) {
+	condition := "TRUE"
+	if policy.Condition != "" {
+		condition = policy.Condition
+	}
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s WHERE ctid = ANY(ARRAY(
+			SELECT ctid FROM %[1]s WHERE %[2]s < $1 AND (%[3]s) LIMIT $2
+		))`, policy.Table, policy.TimeColumn, condition)
+	cutoff := time.Now().Add(-policy.TTL)
+
+	var total int64
+	for {
+		result, err := p.db.ExecContext(ctx, query, cutoff, p.config.BatchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to delete expired rows of %s: %w", policy.Table, err)
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("failed to count deleted rows of %s: %w", policy.Table, err)
+		}
+		total += deleted
+		if deleted < int64(p.config.BatchSize) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package version
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build information injected at link time, e.g.
+//
+//	go build -ldflags "-X github.com/amiosamu/rocket-science/shared/platform/version.Version=1.2.0 \
+//	  -X github.com/amiosamu/rocket-science/shared/platform/version.GitCommit=$(git rev-parse --short HEAD) \
+//	  -X github.com/amiosamu/rocket-science/shared/platform/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "dev"
+	GitCommit = ""
+	BuildTime = ""
+)
+
+// Info describes the build of a running service
+type Info struct {
+	Service   string `json:"service"`
+	Version   string `json:"version"`
+	GitCommit string `json:"git_commit"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// Get returns the build information for the given service. When the binary
+// was built without ldflags the commit falls back to the VCS stamp embedded
+// by the Go toolchain, if any.
+func Get(service string) Info {
+	info := Info{
+		Service:   service,
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if info.GitCommit == "" || info.BuildTime == "" {
+		if buildInfo, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range buildInfo.Settings {
+				switch setting.Key {
+				case "vcs.revision":
+					if info.GitCommit == "" {
+						info.GitCommit = setting.Value
+					}
+				case "vcs.time":
+					if info.BuildTime == "" {
+						info.BuildTime = setting.Value
+					}
+				}
+			}
+		}
+	}
+
+	if info.GitCommit == "" {
+		info.GitCommit = "unknown"
+	}
+
+	return info
+}
+
+// Handler returns an HTTP handler serving the build information as JSON
+func Handler(service string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Get(service))
+	})
+}
@@ -898,17 +898,21 @@ func (x *OrderCancelledEvent) GetRefundRequired() bool {
 
 // Payment-related events
 type PaymentProcessedEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
-	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Amount        *common.Money          `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	PaymentMethod string                 `protobuf:"bytes,5,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
-	TransactionId string                 `protobuf:"bytes,6,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Status        PaymentStatus          `protobuf:"varint,7,opt,name=status,proto3,enum=events.PaymentStatus" json:"status,omitempty"`
-	ProcessedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId       string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId         string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount          *common.Money          `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	PaymentMethod   string                 `protobuf:"bytes,5,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	TransactionId   string                 `protobuf:"bytes,6,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Status          PaymentStatus          `protobuf:"varint,7,opt,name=status,proto3,enum=events.PaymentStatus" json:"status,omitempty"`
+	ProcessedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
+	SerialNumbers   []*AllocatedSerial     `protobuf:"bytes,9,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`        // Serialized units allocated to the order
+	Components      []*RocketComponent     `protobuf:"bytes,10,rep,name=components,proto3" json:"components,omitempty"`                                  // Ordered bill of materials; mass in specifications["mass_kg"]
+	ShippingAddress *DeliveryAddress       `protobuf:"bytes,11,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"` // Snapshot taken when the order was placed; unset if it does not ship
+	ShipmentId      string                 `protobuf:"bytes,12,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`                // Set when one shipment of a partially fulfilled order was paid
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *PaymentProcessedEvent) Reset() {
@@ -997,6 +1001,34 @@ func (x *PaymentProcessedEvent) GetProcessedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *PaymentProcessedEvent) GetSerialNumbers() []*AllocatedSerial {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *PaymentProcessedEvent) GetComponents() []*RocketComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *PaymentProcessedEvent) GetShippingAddress() *DeliveryAddress {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *PaymentProcessedEvent) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
 type PaymentFailedEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
@@ -1098,6 +1130,7 @@ type AssemblyStartedEvent struct {
 	Components               []*RocketComponent     `protobuf:"bytes,4,rep,name=components,proto3" json:"components,omitempty"`
 	StartedAt                *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
 	EstimatedDurationSeconds int32                  `protobuf:"varint,6,opt,name=estimated_duration_seconds,json=estimatedDurationSeconds,proto3" json:"estimated_duration_seconds,omitempty"`
+	ShipmentId               string                 `protobuf:"bytes,7,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
 	unknownFields            protoimpl.UnknownFields
 	sizeCache                protoimpl.SizeCache
 }
@@ -1174,6 +1207,13 @@ func (x *AssemblyStartedEvent) GetEstimatedDurationSeconds() int32 {
 	return 0
 }
 
+func (x *AssemblyStartedEvent) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
 type AssemblyCompletedEvent struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	AssemblyId            string                 `protobuf:"bytes,1,opt,name=assembly_id,json=assemblyId,proto3" json:"assembly_id,omitempty"`
@@ -1182,6 +1222,11 @@ type AssemblyCompletedEvent struct {
 	ActualDurationSeconds int32                  `protobuf:"varint,4,opt,name=actual_duration_seconds,json=actualDurationSeconds,proto3" json:"actual_duration_seconds,omitempty"`
 	Quality               AssemblyQuality        `protobuf:"varint,5,opt,name=quality,proto3,enum=events.AssemblyQuality" json:"quality,omitempty"`
 	CompletedAt           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	SerialNumbers         []*AllocatedSerial     `protobuf:"bytes,7,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"` // Serialized units built into the rocket
+	StageTimings          []*AssemblyStageTiming `protobuf:"bytes,8,rep,name=stage_timings,json=stageTimings,proto3" json:"stage_timings,omitempty"`
+	ShippingAddress       *DeliveryAddress       `protobuf:"bytes,9,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"` // Where the rocket ships
+	ShipmentId            string                 `protobuf:"bytes,10,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`               // Set when one shipment of a partially fulfilled order was assembled
+	ArtifactUrl           string                 `protobuf:"bytes,11,opt,name=artifact_url,json=artifactUrl,proto3" json:"artifact_url,omitempty"`            // Signed URL of the completion report in object storage; unset unless uploaded
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -1258,6 +1303,41 @@ func (x *AssemblyCompletedEvent) GetCompletedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *AssemblyCompletedEvent) GetSerialNumbers() []*AllocatedSerial {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *AssemblyCompletedEvent) GetStageTimings() []*AssemblyStageTiming {
+	if x != nil {
+		return x.StageTimings
+	}
+	return nil
+}
+
+func (x *AssemblyCompletedEvent) GetShippingAddress() *DeliveryAddress {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *AssemblyCompletedEvent) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+func (x *AssemblyCompletedEvent) GetArtifactUrl() string {
+	if x != nil {
+		return x.ArtifactUrl
+	}
+	return ""
+}
+
 type AssemblyFailedEvent struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	AssemblyId       string                 `protobuf:"bytes,1,opt,name=assembly_id,json=assemblyId,proto3" json:"assembly_id,omitempty"`
@@ -1267,6 +1347,9 @@ type AssemblyFailedEvent struct {
 	ErrorCode        string                 `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
 	FailedAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
 	FailedComponents []string               `protobuf:"bytes,7,rep,name=failed_components,json=failedComponents,proto3" json:"failed_components,omitempty"`
+	FailedStage      string                 `protobuf:"bytes,8,opt,name=failed_stage,json=failedStage,proto3" json:"failed_stage,omitempty"`
+	StageTimings     []*AssemblyStageTiming `protobuf:"bytes,9,rep,name=stage_timings,json=stageTimings,proto3" json:"stage_timings,omitempty"` // Stages run up to and including the failed one
+	ShipmentId       string                 `protobuf:"bytes,10,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -1350,6 +1433,113 @@ func (x *AssemblyFailedEvent) GetFailedComponents() []string {
 	return nil
 }
 
+func (x *AssemblyFailedEvent) GetFailedStage() string {
+	if x != nil {
+		return x.FailedStage
+	}
+	return ""
+}
+
+func (x *AssemblyFailedEvent) GetStageTimings() []*AssemblyStageTiming {
+	if x != nil {
+		return x.StageTimings
+	}
+	return nil
+}
+
+func (x *AssemblyFailedEvent) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+// AssemblyPartsConsumedEvent reports the inventory parts built into a completed
+// rocket, so inventory can book them out of the order's confirmed stock
+type AssemblyPartsConsumedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssemblyId    string                 `protobuf:"bytes,1,opt,name=assembly_id,json=assemblyId,proto3" json:"assembly_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Parts         []*ConsumedPart        `protobuf:"bytes,4,rep,name=parts,proto3" json:"parts,omitempty"`
+	ConsumedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=consumed_at,json=consumedAt,proto3" json:"consumed_at,omitempty"`
+	ShipmentId    string                 `protobuf:"bytes,6,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"` // Parts were reserved under the shipment ID when set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssemblyPartsConsumedEvent) Reset() {
+	*x = AssemblyPartsConsumedEvent{}
+	mi := &file_events_events_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssemblyPartsConsumedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssemblyPartsConsumedEvent) ProtoMessage() {}
+
+func (x *AssemblyPartsConsumedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssemblyPartsConsumedEvent.ProtoReflect.Descriptor instead.
+func (*AssemblyPartsConsumedEvent) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AssemblyPartsConsumedEvent) GetAssemblyId() string {
+	if x != nil {
+		return x.AssemblyId
+	}
+	return ""
+}
+
+func (x *AssemblyPartsConsumedEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AssemblyPartsConsumedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AssemblyPartsConsumedEvent) GetParts() []*ConsumedPart {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+func (x *AssemblyPartsConsumedEvent) GetConsumedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConsumedAt
+	}
+	return nil
+}
+
+func (x *AssemblyPartsConsumedEvent) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
 // Inventory-related events
 type InventoryReservedEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1364,7 +1554,7 @@ type InventoryReservedEvent struct {
 
 func (x *InventoryReservedEvent) Reset() {
 	*x = InventoryReservedEvent{}
-	mi := &file_events_events_proto_msgTypes[11]
+	mi := &file_events_events_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1376,7 +1566,7 @@ func (x *InventoryReservedEvent) String() string {
 func (*InventoryReservedEvent) ProtoMessage() {}
 
 func (x *InventoryReservedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[11]
+	mi := &file_events_events_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1389,7 +1579,7 @@ func (x *InventoryReservedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InventoryReservedEvent.ProtoReflect.Descriptor instead.
 func (*InventoryReservedEvent) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{11}
+	return file_events_events_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *InventoryReservedEvent) GetReservationId() string {
@@ -1440,7 +1630,7 @@ type InventoryReleasedEvent struct {
 
 func (x *InventoryReleasedEvent) Reset() {
 	*x = InventoryReleasedEvent{}
-	mi := &file_events_events_proto_msgTypes[12]
+	mi := &file_events_events_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1452,7 +1642,7 @@ func (x *InventoryReleasedEvent) String() string {
 func (*InventoryReleasedEvent) ProtoMessage() {}
 
 func (x *InventoryReleasedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[12]
+	mi := &file_events_events_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1465,7 +1655,7 @@ func (x *InventoryReleasedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InventoryReleasedEvent.ProtoReflect.Descriptor instead.
 func (*InventoryReleasedEvent) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{12}
+	return file_events_events_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *InventoryReleasedEvent) GetReservationId() string {
@@ -1517,7 +1707,7 @@ type InventoryUpdatedEvent struct {
 
 func (x *InventoryUpdatedEvent) Reset() {
 	*x = InventoryUpdatedEvent{}
-	mi := &file_events_events_proto_msgTypes[13]
+	mi := &file_events_events_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1529,7 +1719,7 @@ func (x *InventoryUpdatedEvent) String() string {
 func (*InventoryUpdatedEvent) ProtoMessage() {}
 
 func (x *InventoryUpdatedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[13]
+	mi := &file_events_events_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1542,7 +1732,7 @@ func (x *InventoryUpdatedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InventoryUpdatedEvent.ProtoReflect.Descriptor instead.
 func (*InventoryUpdatedEvent) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{13}
+	return file_events_events_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *InventoryUpdatedEvent) GetItemId() string {
@@ -1604,7 +1794,7 @@ type NotificationSentEvent struct {
 
 func (x *NotificationSentEvent) Reset() {
 	*x = NotificationSentEvent{}
-	mi := &file_events_events_proto_msgTypes[14]
+	mi := &file_events_events_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1616,7 +1806,7 @@ func (x *NotificationSentEvent) String() string {
 func (*NotificationSentEvent) ProtoMessage() {}
 
 func (x *NotificationSentEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[14]
+	mi := &file_events_events_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1629,7 +1819,7 @@ func (x *NotificationSentEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotificationSentEvent.ProtoReflect.Descriptor instead.
 func (*NotificationSentEvent) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{14}
+	return file_events_events_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *NotificationSentEvent) GetNotificationId() string {
@@ -1704,7 +1894,7 @@ type NotificationFailedEvent struct {
 
 func (x *NotificationFailedEvent) Reset() {
 	*x = NotificationFailedEvent{}
-	mi := &file_events_events_proto_msgTypes[15]
+	mi := &file_events_events_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1716,7 +1906,7 @@ func (x *NotificationFailedEvent) String() string {
 func (*NotificationFailedEvent) ProtoMessage() {}
 
 func (x *NotificationFailedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[15]
+	mi := &file_events_events_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1729,7 +1919,7 @@ func (x *NotificationFailedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotificationFailedEvent.ProtoReflect.Descriptor instead.
 func (*NotificationFailedEvent) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{15}
+	return file_events_events_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *NotificationFailedEvent) GetNotificationId() string {
@@ -1802,7 +1992,7 @@ type UserCreatedEvent struct {
 
 func (x *UserCreatedEvent) Reset() {
 	*x = UserCreatedEvent{}
-	mi := &file_events_events_proto_msgTypes[16]
+	mi := &file_events_events_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1814,7 +2004,7 @@ func (x *UserCreatedEvent) String() string {
 func (*UserCreatedEvent) ProtoMessage() {}
 
 func (x *UserCreatedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[16]
+	mi := &file_events_events_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1827,7 +2017,7 @@ func (x *UserCreatedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserCreatedEvent.ProtoReflect.Descriptor instead.
 func (*UserCreatedEvent) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{16}
+	return file_events_events_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *UserCreatedEvent) GetUserId() string {
@@ -1879,7 +2069,7 @@ type UserSessionStartedEvent struct {
 
 func (x *UserSessionStartedEvent) Reset() {
 	*x = UserSessionStartedEvent{}
-	mi := &file_events_events_proto_msgTypes[17]
+	mi := &file_events_events_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1891,7 +2081,7 @@ func (x *UserSessionStartedEvent) String() string {
 func (*UserSessionStartedEvent) ProtoMessage() {}
 
 func (x *UserSessionStartedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[17]
+	mi := &file_events_events_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1904,7 +2094,7 @@ func (x *UserSessionStartedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserSessionStartedEvent.ProtoReflect.Descriptor instead.
 func (*UserSessionStartedEvent) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{17}
+	return file_events_events_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *UserSessionStartedEvent) GetSessionId() string {
@@ -1961,7 +2151,7 @@ type UserSessionEndedEvent struct {
 
 func (x *UserSessionEndedEvent) Reset() {
 	*x = UserSessionEndedEvent{}
-	mi := &file_events_events_proto_msgTypes[18]
+	mi := &file_events_events_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1973,7 +2163,7 @@ func (x *UserSessionEndedEvent) String() string {
 func (*UserSessionEndedEvent) ProtoMessage() {}
 
 func (x *UserSessionEndedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[18]
+	mi := &file_events_events_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1986,7 +2176,7 @@ func (x *UserSessionEndedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserSessionEndedEvent.ProtoReflect.Descriptor instead.
 func (*UserSessionEndedEvent) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{18}
+	return file_events_events_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *UserSessionEndedEvent) GetSessionId() string {
@@ -2031,7 +2221,7 @@ type OrderItem struct {
 
 func (x *OrderItem) Reset() {
 	*x = OrderItem{}
-	mi := &file_events_events_proto_msgTypes[19]
+	mi := &file_events_events_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2043,7 +2233,7 @@ func (x *OrderItem) String() string {
 func (*OrderItem) ProtoMessage() {}
 
 func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[19]
+	mi := &file_events_events_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2056,7 +2246,7 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
 func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{19}
+	return file_events_events_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *OrderItem) GetItemId() string {
@@ -2107,7 +2297,7 @@ type RocketComponent struct {
 
 func (x *RocketComponent) Reset() {
 	*x = RocketComponent{}
-	mi := &file_events_events_proto_msgTypes[20]
+	mi := &file_events_events_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2119,7 +2309,7 @@ func (x *RocketComponent) String() string {
 func (*RocketComponent) ProtoMessage() {}
 
 func (x *RocketComponent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[20]
+	mi := &file_events_events_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2132,7 +2322,7 @@ func (x *RocketComponent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RocketComponent.ProtoReflect.Descriptor instead.
 func (*RocketComponent) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{20}
+	return file_events_events_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *RocketComponent) GetComponentId() string {
@@ -2170,6 +2360,290 @@ func (x *RocketComponent) GetQuantity() int32 {
 	return 0
 }
 
+// AssemblyStageTiming reports how long a simulated assembly stage took
+type AssemblyStageTiming struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Stage             string                 `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	PlannedDurationMs int64                  `protobuf:"varint,2,opt,name=planned_duration_ms,json=plannedDurationMs,proto3" json:"planned_duration_ms,omitempty"`
+	ActualDurationMs  int64                  `protobuf:"varint,3,opt,name=actual_duration_ms,json=actualDurationMs,proto3" json:"actual_duration_ms,omitempty"`
+	Failed            bool                   `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AssemblyStageTiming) Reset() {
+	*x = AssemblyStageTiming{}
+	mi := &file_events_events_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssemblyStageTiming) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssemblyStageTiming) ProtoMessage() {}
+
+func (x *AssemblyStageTiming) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssemblyStageTiming.ProtoReflect.Descriptor instead.
+func (*AssemblyStageTiming) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AssemblyStageTiming) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *AssemblyStageTiming) GetPlannedDurationMs() int64 {
+	if x != nil {
+		return x.PlannedDurationMs
+	}
+	return 0
+}
+
+func (x *AssemblyStageTiming) GetActualDurationMs() int64 {
+	if x != nil {
+		return x.ActualDurationMs
+	}
+	return 0
+}
+
+func (x *AssemblyStageTiming) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
+
+// ConsumedPart is a quantity of an inventory item built into a rocket
+type ConsumedPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	SerialNumbers []string               `protobuf:"bytes,3,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"` // Serialized units of the item built in
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumedPart) Reset() {
+	*x = ConsumedPart{}
+	mi := &file_events_events_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumedPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumedPart) ProtoMessage() {}
+
+func (x *ConsumedPart) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumedPart.ProtoReflect.Descriptor instead.
+func (*ConsumedPart) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ConsumedPart) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ConsumedPart) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ConsumedPart) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+// AllocatedSerial identifies a serialized unit of an inventory item
+type AllocatedSerial struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocatedSerial) Reset() {
+	*x = AllocatedSerial{}
+	mi := &file_events_events_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocatedSerial) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocatedSerial) ProtoMessage() {}
+
+func (x *AllocatedSerial) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocatedSerial.ProtoReflect.Descriptor instead.
+func (*AllocatedSerial) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AllocatedSerial) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *AllocatedSerial) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+// DeliveryAddress is the address an order ships to
+type DeliveryAddress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipientName string                 `protobuf:"bytes,1,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	Line1         string                 `protobuf:"bytes,2,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2         string                 `protobuf:"bytes,3,opt,name=line2,proto3" json:"line2,omitempty"`
+	City          string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Region        string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,6,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country       string                 `protobuf:"bytes,7,opt,name=country,proto3" json:"country,omitempty"` // ISO 3166-1 alpha-2
+	Phone         string                 `protobuf:"bytes,8,opt,name=phone,proto3" json:"phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryAddress) Reset() {
+	*x = DeliveryAddress{}
+	mi := &file_events_events_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryAddress) ProtoMessage() {}
+
+func (x *DeliveryAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryAddress.ProtoReflect.Descriptor instead.
+func (*DeliveryAddress) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeliveryAddress) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *DeliveryAddress) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *DeliveryAddress) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *DeliveryAddress) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *DeliveryAddress) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *DeliveryAddress) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *DeliveryAddress) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *DeliveryAddress) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
 type InventoryItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
@@ -2183,7 +2657,7 @@ type InventoryItem struct {
 
 func (x *InventoryItem) Reset() {
 	*x = InventoryItem{}
-	mi := &file_events_events_proto_msgTypes[21]
+	mi := &file_events_events_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2195,7 +2669,7 @@ func (x *InventoryItem) String() string {
 func (*InventoryItem) ProtoMessage() {}
 
 func (x *InventoryItem) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[21]
+	mi := &file_events_events_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2208,7 +2682,7 @@ func (x *InventoryItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InventoryItem.ProtoReflect.Descriptor instead.
 func (*InventoryItem) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{21}
+	return file_events_events_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *InventoryItem) GetItemId() string {
@@ -2259,7 +2733,7 @@ type BatchOrderEvents struct {
 
 func (x *BatchOrderEvents) Reset() {
 	*x = BatchOrderEvents{}
-	mi := &file_events_events_proto_msgTypes[22]
+	mi := &file_events_events_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2271,7 +2745,7 @@ func (x *BatchOrderEvents) String() string {
 func (*BatchOrderEvents) ProtoMessage() {}
 
 func (x *BatchOrderEvents) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[22]
+	mi := &file_events_events_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2284,7 +2758,7 @@ func (x *BatchOrderEvents) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchOrderEvents.ProtoReflect.Descriptor instead.
 func (*BatchOrderEvents) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{22}
+	return file_events_events_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *BatchOrderEvents) GetEvents() []*BaseEvent {
@@ -2330,7 +2804,7 @@ type DeadLetterEvent struct {
 
 func (x *DeadLetterEvent) Reset() {
 	*x = DeadLetterEvent{}
-	mi := &file_events_events_proto_msgTypes[23]
+	mi := &file_events_events_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2342,7 +2816,7 @@ func (x *DeadLetterEvent) String() string {
 func (*DeadLetterEvent) ProtoMessage() {}
 
 func (x *DeadLetterEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[23]
+	mi := &file_events_events_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2355,7 +2829,7 @@ func (x *DeadLetterEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeadLetterEvent.ProtoReflect.Descriptor instead.
 func (*DeadLetterEvent) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{23}
+	return file_events_events_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *DeadLetterEvent) GetOriginalEvent() *BaseEvent {
@@ -2455,7 +2929,7 @@ const file_events_events_proto_rawDesc = "" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
 	"\x06reason\x18\x03 \x01(\tR\x06reason\x12=\n" +
 	"\fcancelled_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAt\x12'\n" +
-	"\x0frefund_required\x18\x05 \x01(\bR\x0erefundRequired\"\xcd\x02\n" +
+	"\x0frefund_required\x18\x05 \x01(\bR\x0erefundRequired\"\xab\x04\n" +
 	"\x15PaymentProcessedEvent\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
@@ -2465,7 +2939,15 @@ const file_events_events_proto_rawDesc = "" +
 	"\x0epayment_method\x18\x05 \x01(\tR\rpaymentMethod\x12%\n" +
 	"\x0etransaction_id\x18\x06 \x01(\tR\rtransactionId\x12-\n" +
 	"\x06status\x18\a \x01(\x0e2\x15.events.PaymentStatusR\x06status\x12=\n" +
-	"\fprocessed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\"\xfe\x01\n" +
+	"\fprocessed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\x12>\n" +
+	"\x0eserial_numbers\x18\t \x03(\v2\x17.events.AllocatedSerialR\rserialNumbers\x127\n" +
+	"\n" +
+	"components\x18\n" +
+	" \x03(\v2\x17.events.RocketComponentR\n" +
+	"components\x12B\n" +
+	"\x10shipping_address\x18\v \x01(\v2\x17.events.DeliveryAddressR\x0fshippingAddress\x12\x1f\n" +
+	"\vshipment_id\x18\f \x01(\tR\n" +
+	"shipmentId\"\xfe\x01\n" +
 	"\x12PaymentFailedEvent\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
@@ -2475,7 +2957,7 @@ const file_events_events_proto_rawDesc = "" +
 	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1d\n" +
 	"\n" +
 	"error_code\x18\x06 \x01(\tR\terrorCode\x127\n" +
-	"\tfailed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\"\x9d\x02\n" +
+	"\tfailed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\"\xbe\x02\n" +
 	"\x14AssemblyStartedEvent\x12\x1f\n" +
 	"\vassembly_id\x18\x01 \x01(\tR\n" +
 	"assemblyId\x12\x19\n" +
@@ -2486,7 +2968,9 @@ const file_events_events_proto_rawDesc = "" +
 	"components\x129\n" +
 	"\n" +
 	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12<\n" +
-	"\x1aestimated_duration_seconds\x18\x06 \x01(\x05R\x18estimatedDurationSeconds\"\x97\x02\n" +
+	"\x1aestimated_duration_seconds\x18\x06 \x01(\x05R\x18estimatedDurationSeconds\x12\x1f\n" +
+	"\vshipment_id\x18\a \x01(\tR\n" +
+	"shipmentId\"\xa1\x04\n" +
 	"\x16AssemblyCompletedEvent\x12\x1f\n" +
 	"\vassembly_id\x18\x01 \x01(\tR\n" +
 	"assemblyId\x12\x19\n" +
@@ -2494,7 +2978,14 @@ const file_events_events_proto_rawDesc = "" +
 	"\auser_id\x18\x03 \x01(\tR\x06userId\x126\n" +
 	"\x17actual_duration_seconds\x18\x04 \x01(\x05R\x15actualDurationSeconds\x121\n" +
 	"\aquality\x18\x05 \x01(\x0e2\x17.events.AssemblyQualityR\aquality\x12=\n" +
-	"\fcompleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\x87\x02\n" +
+	"\fcompleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12>\n" +
+	"\x0eserial_numbers\x18\a \x03(\v2\x17.events.AllocatedSerialR\rserialNumbers\x12@\n" +
+	"\rstage_timings\x18\b \x03(\v2\x1b.events.AssemblyStageTimingR\fstageTimings\x12B\n" +
+	"\x10shipping_address\x18\t \x01(\v2\x17.events.DeliveryAddressR\x0fshippingAddress\x12\x1f\n" +
+	"\vshipment_id\x18\n" +
+	" \x01(\tR\n" +
+	"shipmentId\x12!\n" +
+	"\fartifact_url\x18\v \x01(\tR\vartifactUrl\"\x8d\x03\n" +
 	"\x13AssemblyFailedEvent\x12\x1f\n" +
 	"\vassembly_id\x18\x01 \x01(\tR\n" +
 	"assemblyId\x12\x19\n" +
@@ -2504,7 +2995,22 @@ const file_events_events_proto_rawDesc = "" +
 	"\n" +
 	"error_code\x18\x05 \x01(\tR\terrorCode\x127\n" +
 	"\tfailed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\x12+\n" +
-	"\x11failed_components\x18\a \x03(\tR\x10failedComponents\"\xff\x01\n" +
+	"\x11failed_components\x18\a \x03(\tR\x10failedComponents\x12!\n" +
+	"\ffailed_stage\x18\b \x01(\tR\vfailedStage\x12@\n" +
+	"\rstage_timings\x18\t \x03(\v2\x1b.events.AssemblyStageTimingR\fstageTimings\x12\x1f\n" +
+	"\vshipment_id\x18\n" +
+	" \x01(\tR\n" +
+	"shipmentId\"\xfb\x01\n" +
+	"\x1aAssemblyPartsConsumedEvent\x12\x1f\n" +
+	"\vassembly_id\x18\x01 \x01(\tR\n" +
+	"assemblyId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12*\n" +
+	"\x05parts\x18\x04 \x03(\v2\x14.events.ConsumedPartR\x05parts\x12;\n" +
+	"\vconsumed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"consumedAt\x12\x1f\n" +
+	"\vshipment_id\x18\x06 \x01(\tR\n" +
+	"shipmentId\"\xff\x01\n" +
 	"\x16InventoryReservedEvent\x12%\n" +
 	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x19\n" +
 	"\border_id\x18\x02 \x01(\tR\aorderId\x12+\n" +
@@ -2589,7 +3095,29 @@ const file_events_events_proto_rawDesc = "" +
 	"\bquantity\x18\x05 \x01(\x05R\bquantity\x1aA\n" +
 	"\x13SpecificationsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa2\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa1\x01\n" +
+	"\x13AssemblyStageTiming\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12.\n" +
+	"\x13planned_duration_ms\x18\x02 \x01(\x03R\x11plannedDurationMs\x12,\n" +
+	"\x12actual_duration_ms\x18\x03 \x01(\x03R\x10actualDurationMs\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\bR\x06failed\"c\n" +
+	"\fConsumedPart\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12%\n" +
+	"\x0eserial_numbers\x18\x03 \x03(\tR\rserialNumbers\"H\n" +
+	"\x0fAllocatedSerial\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12#\n" +
+	"\rserial_number\x18\x02 \x01(\tR\fserialNumber\"\xe1\x01\n" +
+	"\x0fDeliveryAddress\x12%\n" +
+	"\x0erecipient_name\x18\x01 \x01(\tR\rrecipientName\x12\x14\n" +
+	"\x05line1\x18\x02 \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\x03 \x01(\tR\x05line2\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x1f\n" +
+	"\vpostal_code\x18\x06 \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\a \x01(\tR\acountry\x12\x14\n" +
+	"\x05phone\x18\b \x01(\tR\x05phone\"\xa2\x01\n" +
 	"\rInventoryItem\x12\x17\n" +
 	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1b\n" +
 	"\titem_name\x18\x02 \x01(\tR\bitemName\x12\x1a\n" +
@@ -2675,101 +3203,115 @@ func file_events_events_proto_rawDescGZIP() []byte {
 }
 
 var file_events_events_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_events_events_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_events_events_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_events_events_proto_goTypes = []any{
-	(OrderStatus)(0),                // 0: events.OrderStatus
-	(PaymentStatus)(0),              // 1: events.PaymentStatus
-	(AssemblyQuality)(0),            // 2: events.AssemblyQuality
-	(ComponentType)(0),              // 3: events.ComponentType
-	(NotificationType)(0),           // 4: events.NotificationType
-	(NotificationStatus)(0),         // 5: events.NotificationStatus
-	(*BaseEvent)(nil),               // 6: events.BaseEvent
-	(*EventEnvelope)(nil),           // 7: events.EventEnvelope
-	(*OrderCreatedEvent)(nil),       // 8: events.OrderCreatedEvent
-	(*OrderPaidEvent)(nil),          // 9: events.OrderPaidEvent
-	(*OrderStatusChangedEvent)(nil), // 10: events.OrderStatusChangedEvent
-	(*OrderCancelledEvent)(nil),     // 11: events.OrderCancelledEvent
-	(*PaymentProcessedEvent)(nil),   // 12: events.PaymentProcessedEvent
-	(*PaymentFailedEvent)(nil),      // 13: events.PaymentFailedEvent
-	(*AssemblyStartedEvent)(nil),    // 14: events.AssemblyStartedEvent
-	(*AssemblyCompletedEvent)(nil),  // 15: events.AssemblyCompletedEvent
-	(*AssemblyFailedEvent)(nil),     // 16: events.AssemblyFailedEvent
-	(*InventoryReservedEvent)(nil),  // 17: events.InventoryReservedEvent
-	(*InventoryReleasedEvent)(nil),  // 18: events.InventoryReleasedEvent
-	(*InventoryUpdatedEvent)(nil),   // 19: events.InventoryUpdatedEvent
-	(*NotificationSentEvent)(nil),   // 20: events.NotificationSentEvent
-	(*NotificationFailedEvent)(nil), // 21: events.NotificationFailedEvent
-	(*UserCreatedEvent)(nil),        // 22: events.UserCreatedEvent
-	(*UserSessionStartedEvent)(nil), // 23: events.UserSessionStartedEvent
-	(*UserSessionEndedEvent)(nil),   // 24: events.UserSessionEndedEvent
-	(*OrderItem)(nil),               // 25: events.OrderItem
-	(*RocketComponent)(nil),         // 26: events.RocketComponent
-	(*InventoryItem)(nil),           // 27: events.InventoryItem
-	(*BatchOrderEvents)(nil),        // 28: events.BatchOrderEvents
-	(*DeadLetterEvent)(nil),         // 29: events.DeadLetterEvent
-	nil,                             // 30: events.BaseEvent.ExtensionsEntry
-	nil,                             // 31: events.RocketComponent.SpecificationsEntry
-	(*timestamppb.Timestamp)(nil),   // 32: google.protobuf.Timestamp
-	(*anypb.Any)(nil),               // 33: google.protobuf.Any
-	(*common.RequestMetadata)(nil),  // 34: common.RequestMetadata
-	(*common.Money)(nil),            // 35: common.Money
+	(OrderStatus)(0),                   // 0: events.OrderStatus
+	(PaymentStatus)(0),                 // 1: events.PaymentStatus
+	(AssemblyQuality)(0),               // 2: events.AssemblyQuality
+	(ComponentType)(0),                 // 3: events.ComponentType
+	(NotificationType)(0),              // 4: events.NotificationType
+	(NotificationStatus)(0),            // 5: events.NotificationStatus
+	(*BaseEvent)(nil),                  // 6: events.BaseEvent
+	(*EventEnvelope)(nil),              // 7: events.EventEnvelope
+	(*OrderCreatedEvent)(nil),          // 8: events.OrderCreatedEvent
+	(*OrderPaidEvent)(nil),             // 9: events.OrderPaidEvent
+	(*OrderStatusChangedEvent)(nil),    // 10: events.OrderStatusChangedEvent
+	(*OrderCancelledEvent)(nil),        // 11: events.OrderCancelledEvent
+	(*PaymentProcessedEvent)(nil),      // 12: events.PaymentProcessedEvent
+	(*PaymentFailedEvent)(nil),         // 13: events.PaymentFailedEvent
+	(*AssemblyStartedEvent)(nil),       // 14: events.AssemblyStartedEvent
+	(*AssemblyCompletedEvent)(nil),     // 15: events.AssemblyCompletedEvent
+	(*AssemblyFailedEvent)(nil),        // 16: events.AssemblyFailedEvent
+	(*AssemblyPartsConsumedEvent)(nil), // 17: events.AssemblyPartsConsumedEvent
+	(*InventoryReservedEvent)(nil),     // 18: events.InventoryReservedEvent
+	(*InventoryReleasedEvent)(nil),     // 19: events.InventoryReleasedEvent
+	(*InventoryUpdatedEvent)(nil),      // 20: events.InventoryUpdatedEvent
+	(*NotificationSentEvent)(nil),      // 21: events.NotificationSentEvent
+	(*NotificationFailedEvent)(nil),    // 22: events.NotificationFailedEvent
+	(*UserCreatedEvent)(nil),           // 23: events.UserCreatedEvent
+	(*UserSessionStartedEvent)(nil),    // 24: events.UserSessionStartedEvent
+	(*UserSessionEndedEvent)(nil),      // 25: events.UserSessionEndedEvent
+	(*OrderItem)(nil),                  // 26: events.OrderItem
+	(*RocketComponent)(nil),            // 27: events.RocketComponent
+	(*AssemblyStageTiming)(nil),        // 28: events.AssemblyStageTiming
+	(*ConsumedPart)(nil),               // 29: events.ConsumedPart
+	(*AllocatedSerial)(nil),            // 30: events.AllocatedSerial
+	(*DeliveryAddress)(nil),            // 31: events.DeliveryAddress
+	(*InventoryItem)(nil),              // 32: events.InventoryItem
+	(*BatchOrderEvents)(nil),           // 33: events.BatchOrderEvents
+	(*DeadLetterEvent)(nil),            // 34: events.DeadLetterEvent
+	nil,                                // 35: events.BaseEvent.ExtensionsEntry
+	nil,                                // 36: events.RocketComponent.SpecificationsEntry
+	(*timestamppb.Timestamp)(nil),      // 37: google.protobuf.Timestamp
+	(*anypb.Any)(nil),                  // 38: google.protobuf.Any
+	(*common.RequestMetadata)(nil),     // 39: common.RequestMetadata
+	(*common.Money)(nil),               // 40: common.Money
 }
 var file_events_events_proto_depIdxs = []int32{
-	32, // 0: events.BaseEvent.time:type_name -> google.protobuf.Timestamp
-	33, // 1: events.BaseEvent.data:type_name -> google.protobuf.Any
-	30, // 2: events.BaseEvent.extensions:type_name -> events.BaseEvent.ExtensionsEntry
+	37, // 0: events.BaseEvent.time:type_name -> google.protobuf.Timestamp
+	38, // 1: events.BaseEvent.data:type_name -> google.protobuf.Any
+	35, // 2: events.BaseEvent.extensions:type_name -> events.BaseEvent.ExtensionsEntry
 	6,  // 3: events.EventEnvelope.event:type_name -> events.BaseEvent
-	34, // 4: events.EventEnvelope.metadata:type_name -> common.RequestMetadata
-	32, // 5: events.EventEnvelope.original_timestamp:type_name -> google.protobuf.Timestamp
-	25, // 6: events.OrderCreatedEvent.items:type_name -> events.OrderItem
-	35, // 7: events.OrderCreatedEvent.total_amount:type_name -> common.Money
-	32, // 8: events.OrderCreatedEvent.created_at:type_name -> google.protobuf.Timestamp
-	35, // 9: events.OrderPaidEvent.amount:type_name -> common.Money
-	32, // 10: events.OrderPaidEvent.paid_at:type_name -> google.protobuf.Timestamp
+	39, // 4: events.EventEnvelope.metadata:type_name -> common.RequestMetadata
+	37, // 5: events.EventEnvelope.original_timestamp:type_name -> google.protobuf.Timestamp
+	26, // 6: events.OrderCreatedEvent.items:type_name -> events.OrderItem
+	40, // 7: events.OrderCreatedEvent.total_amount:type_name -> common.Money
+	37, // 8: events.OrderCreatedEvent.created_at:type_name -> google.protobuf.Timestamp
+	40, // 9: events.OrderPaidEvent.amount:type_name -> common.Money
+	37, // 10: events.OrderPaidEvent.paid_at:type_name -> google.protobuf.Timestamp
 	0,  // 11: events.OrderStatusChangedEvent.old_status:type_name -> events.OrderStatus
 	0,  // 12: events.OrderStatusChangedEvent.new_status:type_name -> events.OrderStatus
-	32, // 13: events.OrderStatusChangedEvent.changed_at:type_name -> google.protobuf.Timestamp
-	32, // 14: events.OrderCancelledEvent.cancelled_at:type_name -> google.protobuf.Timestamp
-	35, // 15: events.PaymentProcessedEvent.amount:type_name -> common.Money
+	37, // 13: events.OrderStatusChangedEvent.changed_at:type_name -> google.protobuf.Timestamp
+	37, // 14: events.OrderCancelledEvent.cancelled_at:type_name -> google.protobuf.Timestamp
+	40, // 15: events.PaymentProcessedEvent.amount:type_name -> common.Money
 	1,  // 16: events.PaymentProcessedEvent.status:type_name -> events.PaymentStatus
-	32, // 17: events.PaymentProcessedEvent.processed_at:type_name -> google.protobuf.Timestamp
-	35, // 18: events.PaymentFailedEvent.amount:type_name -> common.Money
-	32, // 19: events.PaymentFailedEvent.failed_at:type_name -> google.protobuf.Timestamp
-	26, // 20: events.AssemblyStartedEvent.components:type_name -> events.RocketComponent
-	32, // 21: events.AssemblyStartedEvent.started_at:type_name -> google.protobuf.Timestamp
-	2,  // 22: events.AssemblyCompletedEvent.quality:type_name -> events.AssemblyQuality
-	32, // 23: events.AssemblyCompletedEvent.completed_at:type_name -> google.protobuf.Timestamp
-	32, // 24: events.AssemblyFailedEvent.failed_at:type_name -> google.protobuf.Timestamp
-	27, // 25: events.InventoryReservedEvent.items:type_name -> events.InventoryItem
-	32, // 26: events.InventoryReservedEvent.reserved_at:type_name -> google.protobuf.Timestamp
-	32, // 27: events.InventoryReservedEvent.expires_at:type_name -> google.protobuf.Timestamp
-	27, // 28: events.InventoryReleasedEvent.items:type_name -> events.InventoryItem
-	32, // 29: events.InventoryReleasedEvent.released_at:type_name -> google.protobuf.Timestamp
-	32, // 30: events.InventoryUpdatedEvent.updated_at:type_name -> google.protobuf.Timestamp
-	4,  // 31: events.NotificationSentEvent.type:type_name -> events.NotificationType
-	5,  // 32: events.NotificationSentEvent.status:type_name -> events.NotificationStatus
-	32, // 33: events.NotificationSentEvent.sent_at:type_name -> google.protobuf.Timestamp
-	4,  // 34: events.NotificationFailedEvent.type:type_name -> events.NotificationType
-	32, // 35: events.NotificationFailedEvent.failed_at:type_name -> google.protobuf.Timestamp
-	32, // 36: events.UserCreatedEvent.created_at:type_name -> google.protobuf.Timestamp
-	32, // 37: events.UserSessionStartedEvent.started_at:type_name -> google.protobuf.Timestamp
-	32, // 38: events.UserSessionStartedEvent.expires_at:type_name -> google.protobuf.Timestamp
-	32, // 39: events.UserSessionEndedEvent.ended_at:type_name -> google.protobuf.Timestamp
-	35, // 40: events.OrderItem.unit_price:type_name -> common.Money
-	35, // 41: events.OrderItem.total_price:type_name -> common.Money
-	3,  // 42: events.RocketComponent.type:type_name -> events.ComponentType
-	31, // 43: events.RocketComponent.specifications:type_name -> events.RocketComponent.SpecificationsEntry
-	35, // 44: events.InventoryItem.price:type_name -> common.Money
-	6,  // 45: events.BatchOrderEvents.events:type_name -> events.BaseEvent
-	32, // 46: events.BatchOrderEvents.created_at:type_name -> google.protobuf.Timestamp
-	6,  // 47: events.DeadLetterEvent.original_event:type_name -> events.BaseEvent
-	32, // 48: events.DeadLetterEvent.first_failed_at:type_name -> google.protobuf.Timestamp
-	32, // 49: events.DeadLetterEvent.last_failed_at:type_name -> google.protobuf.Timestamp
-	50, // [50:50] is the sub-list for method output_type
-	50, // [50:50] is the sub-list for method input_type
-	50, // [50:50] is the sub-list for extension type_name
-	50, // [50:50] is the sub-list for extension extendee
-	0,  // [0:50] is the sub-list for field type_name
+	37, // 17: events.PaymentProcessedEvent.processed_at:type_name -> google.protobuf.Timestamp
+	30, // 18: events.PaymentProcessedEvent.serial_numbers:type_name -> events.AllocatedSerial
+	27, // 19: events.PaymentProcessedEvent.components:type_name -> events.RocketComponent
+	31, // 20: events.PaymentProcessedEvent.shipping_address:type_name -> events.DeliveryAddress
+	40, // 21: events.PaymentFailedEvent.amount:type_name -> common.Money
+	37, // 22: events.PaymentFailedEvent.failed_at:type_name -> google.protobuf.Timestamp
+	27, // 23: events.AssemblyStartedEvent.components:type_name -> events.RocketComponent
+	37, // 24: events.AssemblyStartedEvent.started_at:type_name -> google.protobuf.Timestamp
+	2,  // 25: events.AssemblyCompletedEvent.quality:type_name -> events.AssemblyQuality
+	37, // 26: events.AssemblyCompletedEvent.completed_at:type_name -> google.protobuf.Timestamp
+	30, // 27: events.AssemblyCompletedEvent.serial_numbers:type_name -> events.AllocatedSerial
+	28, // 28: events.AssemblyCompletedEvent.stage_timings:type_name -> events.AssemblyStageTiming
+	31, // 29: events.AssemblyCompletedEvent.shipping_address:type_name -> events.DeliveryAddress
+	37, // 30: events.AssemblyFailedEvent.failed_at:type_name -> google.protobuf.Timestamp
+	28, // 31: events.AssemblyFailedEvent.stage_timings:type_name -> events.AssemblyStageTiming
+	29, // 32: events.AssemblyPartsConsumedEvent.parts:type_name -> events.ConsumedPart
+	37, // 33: events.AssemblyPartsConsumedEvent.consumed_at:type_name -> google.protobuf.Timestamp
+	32, // 34: events.InventoryReservedEvent.items:type_name -> events.InventoryItem
+	37, // 35: events.InventoryReservedEvent.reserved_at:type_name -> google.protobuf.Timestamp
+	37, // 36: events.InventoryReservedEvent.expires_at:type_name -> google.protobuf.Timestamp
+	32, // 37: events.InventoryReleasedEvent.items:type_name -> events.InventoryItem
+	37, // 38: events.InventoryReleasedEvent.released_at:type_name -> google.protobuf.Timestamp
+	37, // 39: events.InventoryUpdatedEvent.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 40: events.NotificationSentEvent.type:type_name -> events.NotificationType
+	5,  // 41: events.NotificationSentEvent.status:type_name -> events.NotificationStatus
+	37, // 42: events.NotificationSentEvent.sent_at:type_name -> google.protobuf.Timestamp
+	4,  // 43: events.NotificationFailedEvent.type:type_name -> events.NotificationType
+	37, // 44: events.NotificationFailedEvent.failed_at:type_name -> google.protobuf.Timestamp
+	37, // 45: events.UserCreatedEvent.created_at:type_name -> google.protobuf.Timestamp
+	37, // 46: events.UserSessionStartedEvent.started_at:type_name -> google.protobuf.Timestamp
+	37, // 47: events.UserSessionStartedEvent.expires_at:type_name -> google.protobuf.Timestamp
+	37, // 48: events.UserSessionEndedEvent.ended_at:type_name -> google.protobuf.Timestamp
+	40, // 49: events.OrderItem.unit_price:type_name -> common.Money
+	40, // 50: events.OrderItem.total_price:type_name -> common.Money
+	3,  // 51: events.RocketComponent.type:type_name -> events.ComponentType
+	36, // 52: events.RocketComponent.specifications:type_name -> events.RocketComponent.SpecificationsEntry
+	40, // 53: events.InventoryItem.price:type_name -> common.Money
+	6,  // 54: events.BatchOrderEvents.events:type_name -> events.BaseEvent
+	37, // 55: events.BatchOrderEvents.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 56: events.DeadLetterEvent.original_event:type_name -> events.BaseEvent
+	37, // 57: events.DeadLetterEvent.first_failed_at:type_name -> google.protobuf.Timestamp
+	37, // 58: events.DeadLetterEvent.last_failed_at:type_name -> google.protobuf.Timestamp
+	59, // [59:59] is the sub-list for method output_type
+	59, // [59:59] is the sub-list for method input_type
+	59, // [59:59] is the sub-list for extension type_name
+	59, // [59:59] is the sub-list for extension extendee
+	0,  // [0:59] is the sub-list for field type_name
 }
 
 func init() { file_events_events_proto_init() }
@@ -2783,7 +3325,7 @@ func file_events_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_events_proto_rawDesc), len(file_events_events_proto_rawDesc)),
 			NumEnums:      6,
-			NumMessages:   26,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
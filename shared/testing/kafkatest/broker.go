@@ -0,0 +1,236 @@
+// Package kafkatest provides an in-memory Kafka broker for tests. Messages are
+// recorded per topic and handed synchronously to the handlers subscribed to the
+// topic, so a saga step in one service can drive the consumer of another without
+// a real cluster.
+package kafkatest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+
+	"github.com/amiosamu/rocket-science/shared/platform/deadline"
+	"github.com/amiosamu/rocket-science/shared/platform/messaging/kafka"
+)
+
+// Publisher is the publishing side of the shared Kafka producer. Code that
+// publishes through it can be handed a Broker in tests.
+type Publisher interface {
+	SendMessage(ctx context.Context, topic, key string, value interface{}, headers map[string]string) error
+}
+
+var (
+	_ Publisher = (*kafka.Producer)(nil)
+	_ Publisher = (*Broker)(nil)
+)
+
+// Broker is an in-memory Kafka broker with a single partition per topic
+type Broker struct {
+	mu       sync.RWMutex
+	messages map[string][]*kafka.Message
+	handlers map[string][]kafka.MessageHandler
+	faults   map[string]error
+}
+
+// NewBroker creates an empty broker
+func NewBroker() *Broker {
+	return &Broker{
+		messages: make(map[string][]*kafka.Message),
+		handlers: make(map[string][]kafka.MessageHandler),
+		faults:   make(map[string]error),
+	}
+}
+
+// Subscribe delivers the messages of the handler's topics to it from now on
+func (b *Broker) Subscribe(handler kafka.MessageHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, topic := range handler.GetSupportedTopics() {
+		b.handlers[topic] = append(b.handlers[topic], handler)
+	}
+}
+
+// FailOn makes every later publish to the topic fail with err, until it is
+// cleared with a nil err
+func (b *Broker) FailOn(topic string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		delete(b.faults, topic)
+		return
+	}
+	b.faults[topic] = err
+}
+
+// SendMessage publishes a message the way kafka.Producer does: byte slices and
+// strings are sent as is, anything else as JSON. The message is delivered to the
+// subscribed handlers before SendMessage returns, and the first handler error is
+// returned.
+func (b *Broker) SendMessage(ctx context.Context, topic, key string, value interface{}, headers map[string]string) error {
+	data, err := encode(value)
+	if err != nil {
+		return fmt.Errorf("failed to serialize message value: %w", err)
+	}
+
+	all := map[string]string{
+		"message-id": uuid.New().String(),
+		"timestamp":  time.Now().UTC().Format(time.RFC3339),
+	}
+	if budget, ok := deadline.Header(ctx); ok {
+		all[deadline.KafkaHeader] = budget
+	}
+	for k, v := range headers {
+		all[k] = v
+	}
+
+	_, err = b.publish(ctx, topic, key, data, all)
+	return err
+}
+
+// SyncProducer returns the broker as a sarama.SyncProducer, for services that
+// publish with sarama directly
+func (b *Broker) SyncProducer() sarama.SyncProducer {
+	return &syncProducer{broker: b}
+}
+
+// Messages returns the messages published to the topic, oldest first
+func (b *Broker) Messages(topic string) []*kafka.Message {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return append([]*kafka.Message(nil), b.messages[topic]...)
+}
+
+// Last returns the latest message published to the topic, or nil
+func (b *Broker) Last(topic string) *kafka.Message {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	messages := b.messages[topic]
+	if len(messages) == 0 {
+		return nil
+	}
+	return messages[len(messages)-1]
+}
+
+// Reset drops every recorded message and fault; subscriptions are kept
+func (b *Broker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.messages = make(map[string][]*kafka.Message)
+	b.faults = make(map[string]error)
+}
+
+func (b *Broker) publish(ctx context.Context, topic, key string, value []byte, headers map[string]string) (int64, error) {
+	b.mu.Lock()
+	if err := b.faults[topic]; err != nil {
+		b.mu.Unlock()
+		return 0, err
+	}
+	msg := &kafka.Message{
+		Topic:       topic,
+		Offset:      int64(len(b.messages[topic])),
+		Key:         key,
+		Value:       value,
+		Headers:     headers,
+		Timestamp:   time.Now(),
+		EventType:   headers["event-type"],
+		EventID:     headers["event-id"],
+		EventSource: headers["event-source"],
+	}
+	b.messages[topic] = append(b.messages[topic], msg)
+	handlers := append([]kafka.MessageHandler(nil), b.handlers[topic]...)
+	b.mu.Unlock()
+
+	// Consumers relay the budget of the message, as the shared consumer does
+	if msgDeadline, ok := deadline.FromHeaders(headers); ok {
+		ctx = deadline.Attach(ctx, msgDeadline)
+	}
+	for _, handler := range handlers {
+		if err := handler.HandleMessage(ctx, msg); err != nil {
+			return msg.Offset, err
+		}
+	}
+	return msg.Offset, nil
+}
+
+func encode(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return json.Marshal(v)
+	}
+}
+
+// syncProducer adapts the broker to sarama.SyncProducer. Transactions are not
+// supported.
+type syncProducer struct {
+	broker *Broker
+}
+
+func (p *syncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	var key, value []byte
+	var err error
+	if msg.Key != nil {
+		if key, err = msg.Key.Encode(); err != nil {
+			return 0, 0, err
+		}
+	}
+	if msg.Value != nil {
+		if value, err = msg.Value.Encode(); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	headers := make(map[string]string, len(msg.Headers))
+	for _, header := range msg.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+
+	offset, err := p.broker.publish(context.Background(), msg.Topic, string(key), value, headers)
+	if err != nil {
+		return 0, 0, err
+	}
+	msg.Offset = offset
+	return 0, offset, nil
+}
+
+func (p *syncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	for _, msg := range msgs {
+		if _, _, err := p.SendMessage(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *syncProducer) Close() error { return nil }
+
+func (p *syncProducer) TxnStatus() sarama.ProducerTxnStatusFlag { return sarama.ProducerTxnFlagReady }
+
+func (p *syncProducer) IsTransactional() bool { return false }
+
+func (p *syncProducer) BeginTxn() error { return sarama.ErrTransactionNotReady }
+
+func (p *syncProducer) CommitTxn() error { return sarama.ErrTransactionNotReady }
+
+func (p *syncProducer) AbortTxn() error { return sarama.ErrTransactionNotReady }
+
+func (p *syncProducer) AddOffsetsToTxn(map[string][]*sarama.PartitionOffsetMetadata, string) error {
+	return sarama.ErrTransactionNotReady
+}
+
+func (p *syncProducer) AddMessageToTxn(*sarama.ConsumerMessage, string, *string) error {
+	return sarama.ErrTransactionNotReady
+}
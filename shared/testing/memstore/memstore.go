@@ -0,0 +1,192 @@
+// Package memstore is the in-memory table behind the repository fakes. Each
+// service keeps its fakes next to its domain (internal/fakes), since Go does not
+// let a shared module import service internals; the fakes share this store for
+// storage, call counting and fault injection.
+package memstore
+
+import (
+	"sync"
+)
+
+// Store is a concurrency-safe table of values keyed by K. Values are cloned on
+// the way in and out, so callers cannot mutate stored state without going through
+// the store, the same as with a real database. Listings follow insertion order.
+type Store[K comparable, V any] struct {
+	mu     sync.RWMutex
+	items  map[K]V
+	keys   []K
+	clone  func(V) V
+	calls  map[string]int
+	faults map[string]error
+}
+
+// New creates an empty store. clone copies a value; pass nil for value types
+// that need no deep copy.
+func New[K comparable, V any](clone func(V) V) *Store[K, V] {
+	if clone == nil {
+		clone = func(v V) V { return v }
+	}
+	return &Store[K, V]{
+		items:  make(map[K]V),
+		clone:  clone,
+		calls:  make(map[string]int),
+		faults: make(map[string]error),
+	}
+}
+
+// Get returns a copy of the value stored under key
+func (s *Store[K, V]) Get(key K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, ok := s.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return s.clone(v), true
+}
+
+// Has reports whether a value is stored under key
+func (s *Store[K, V]) Has(key K) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.items[key]
+	return ok
+}
+
+// Put stores a copy of the value under key, replacing any previous value
+func (s *Store[K, V]) Put(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[key]; !ok {
+		s.keys = append(s.keys, key)
+	}
+	s.items[key] = s.clone(value)
+}
+
+// Insert stores a copy of the value under key unless the key is taken. It
+// reports whether the value was stored.
+func (s *Store[K, V]) Insert(key K, value V) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[key]; ok {
+		return false
+	}
+	s.keys = append(s.keys, key)
+	s.items[key] = s.clone(value)
+	return true
+}
+
+// Modify applies fn to a copy of the value under key and stores the result
+// unless fn fails. It reports whether the key exists.
+func (s *Store[K, V]) Modify(key K, fn func(V) (V, error)) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.items[key]
+	if !ok {
+		return false, nil
+	}
+	updated, err := fn(s.clone(v))
+	if err != nil {
+		return true, err
+	}
+	s.items[key] = s.clone(updated)
+	return true, nil
+}
+
+// Delete removes the value under key and reports whether there was one
+func (s *Store[K, V]) Delete(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[key]; !ok {
+		return false
+	}
+	delete(s.items, key)
+	for i, k := range s.keys {
+		if k == key {
+			s.keys = append(s.keys[:i], s.keys[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// List returns copies of the values match accepts, in insertion order. A nil
+// match returns every value.
+func (s *Store[K, V]) List(match func(V) bool) []V {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var values []V
+	for _, key := range s.keys {
+		v := s.items[key]
+		if match == nil || match(v) {
+			values = append(values, s.clone(v))
+		}
+	}
+	return values
+}
+
+// Keys returns the stored keys in insertion order
+func (s *Store[K, V]) Keys() []K {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]K(nil), s.keys...)
+}
+
+// Len returns the number of stored values
+func (s *Store[K, V]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.items)
+}
+
+// Reset removes every value, call count and fault
+func (s *Store[K, V]) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items = make(map[K]V)
+	s.keys = nil
+	s.calls = make(map[string]int)
+	s.faults = make(map[string]error)
+}
+
+// FailOn makes every later call of the operation fail with err, until it is
+// cleared with a nil err
+func (s *Store[K, V]) FailOn(op string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		delete(s.faults, op)
+		return
+	}
+	s.faults[op] = err
+}
+
+// Call records a call of the operation and returns the fault injected for it,
+// if any. Fakes call it first thing in every method.
+func (s *Store[K, V]) Call(op string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls[op]++
+	return s.faults[op]
+}
+
+// Calls returns how many times the operation was called
+func (s *Store[K, V]) Calls(op string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.calls[op]
+}